@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/johnrirwin/flyingforge/internal/models"
 	"github.com/johnrirwin/flyingforge/internal/moderation"
 )
 
@@ -45,7 +46,7 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	decision, err := moderator.ModerateImageBytes(ctx, imageBytes)
+	decision, err := moderator.ModerateImageBytes(ctx, models.ImageEntityOther, imageBytes)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "rekognition call failed: %v\n", err)
 		os.Exit(1)
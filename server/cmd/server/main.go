@@ -2,10 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/johnrirwin/flyingforge/internal/admincli"
 	"github.com/johnrirwin/flyingforge/internal/app"
 	"github.com/johnrirwin/flyingforge/internal/config"
 )
@@ -14,6 +19,11 @@ func main() {
 	// Load configuration
 	cfg := config.Load()
 
+	// "admin <command>" runs a one-off recovery action instead of the server
+	if args := flag.Args(); len(args) > 0 && args[0] == "admin" {
+		os.Exit(runAdmin(cfg, args[1:]))
+	}
+
 	// Create application
 	application, err := app.New(cfg)
 	if err != nil {
@@ -40,3 +50,32 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// runAdmin runs an admin subcommand and returns the process exit code
+func runAdmin(cfg *config.Config, args []string) int {
+	if len(args) == 0 || !admincli.Known(args[0]) {
+		admincli.WriteUsage(os.Stderr)
+		return 2
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	connectCtx, connectCancel := context.WithTimeout(ctx, 30*time.Second)
+	db, err := admincli.Connect(connectCtx, cfg)
+	connectCancel()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "admin: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	if err := admincli.NewRunner(db, os.Stdout).Run(ctx, args); err != nil {
+		if errors.Is(err, admincli.ErrUsage) {
+			return 2
+		}
+		fmt.Fprintf(os.Stderr, "admin: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,313 @@
+// Package admincli implements the "admin" subcommands of the server binary:
+// recovery actions that run straight against the database through the
+// existing stores, so operators don't have to write SQL by hand.
+package admincli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/config"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/secrets"
+)
+
+// ErrUsage is returned when the command line is wrong; the usage text has
+// already been written
+var ErrUsage = errors.New("invalid admin command")
+
+const usage = `Usage: flyingforge [flags] admin <command> [options]
+
+Commands:
+  promote-user [-role admin|content-admin] <email or user ID>
+      Grant a user full admin (the default) or content admin access
+  republish-catalog-item <catalog item ID>
+      Set a gear catalog item back to published
+  run-migration
+      Apply database migrations
+  cleanup-temp-builds
+      Delete temporary builds that have expired
+  recompute-canonical-keys [-dry-run]
+      Recompute gear catalog canonical keys and report collisions
+  normalize-spec-units [-dry-run]
+      Convert gear catalog spec weights, lengths and capacities to g, mm and mAh
+`
+
+// Known reports whether command is an admin command, so a typo is caught
+// before connecting to the database
+func Known(command string) bool {
+	switch command {
+	case "promote-user", "republish-catalog-item", "run-migration", "cleanup-temp-builds", "recompute-canonical-keys", "normalize-spec-units":
+		return true
+	}
+	return false
+}
+
+// WriteUsage writes the admin command help
+func WriteUsage(w io.Writer) {
+	fmt.Fprint(w, usage)
+}
+
+// UserStore defines the user operations the admin commands use
+type UserStore interface {
+	GetByID(ctx context.Context, id string) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	AdminUpdate(ctx context.Context, id string, params models.AdminUpdateUserParams) (*models.User, error)
+}
+
+// CatalogStore defines the gear catalog operations the admin commands use
+type CatalogStore interface {
+	Get(ctx context.Context, id string) (*models.GearCatalogItem, error)
+	AdminUpdate(ctx context.Context, id string, adminUserID string, params models.AdminUpdateGearCatalogParams) (*models.GearCatalogItem, error)
+	RecomputeCanonicalKeys(ctx context.Context, dryRun bool) (*models.CanonicalKeyMigrationReport, error)
+	NormalizeSpecUnits(ctx context.Context, dryRun bool) (*models.SpecUnitNormalizationReport, error)
+}
+
+// BuildStore defines the build operations the admin commands use
+type BuildStore interface {
+	DeleteExpiredTemp(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// Migrator applies the database migrations
+type Migrator interface {
+	Migrate(ctx context.Context) error
+}
+
+// Runner runs admin commands and writes their results to out
+type Runner struct {
+	users    UserStore
+	catalog  CatalogStore
+	builds   BuildStore
+	migrator Migrator
+	out      io.Writer
+	now      func() time.Time
+}
+
+// NewRunner creates a runner backed by the database stores
+func NewRunner(db *database.DB, out io.Writer) *Runner {
+	return &Runner{
+		users:    database.NewUserStore(db),
+		catalog:  database.NewGearCatalogStore(db),
+		builds:   database.NewBuildStore(db),
+		migrator: db,
+		out:      out,
+		now:      time.Now,
+	}
+}
+
+// Connect resolves secret references in cfg and opens the database the same
+// way the server does. Migrations are not run; use run-migration for that.
+func Connect(ctx context.Context, cfg *config.Config) (*database.DB, error) {
+	if err := secrets.NewResolver(cfg.Secrets).ResolveConfig(ctx, cfg); err != nil {
+		return nil, fmt.Errorf("failed to resolve secrets: %w", err)
+	}
+
+	return database.New(database.Config{
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		User:     cfg.Database.User,
+		Password: cfg.Database.Password,
+		Database: cfg.Database.Database,
+		SSLMode:  cfg.Database.SSLMode,
+	})
+}
+
+// Run runs the command named by args[0]
+func (r *Runner) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return r.usageError("")
+	}
+
+	// Recovery actions reach users and builds in any tenant
+	ctx = database.AllTenants(ctx)
+	command, args := args[0], args[1:]
+	switch command {
+	case "promote-user":
+		return r.promoteUser(ctx, args)
+	case "republish-catalog-item":
+		return r.republishCatalogItem(ctx, args)
+	case "run-migration":
+		return r.runMigration(ctx, args)
+	case "cleanup-temp-builds":
+		return r.cleanupTempBuilds(ctx, args)
+	case "recompute-canonical-keys":
+		return r.recomputeCanonicalKeys(ctx, args)
+	case "normalize-spec-units":
+		return r.normalizeSpecUnits(ctx, args)
+	default:
+		return r.usageError(fmt.Sprintf("unknown command %q", command))
+	}
+}
+
+func (r *Runner) promoteUser(ctx context.Context, args []string) error {
+	fs := r.flagSet("promote-user")
+	role := fs.String("role", "admin", "admin or content-admin")
+	if err := fs.Parse(args); err != nil {
+		return ErrUsage
+	}
+	if fs.NArg() != 1 {
+		return r.usageError("promote-user takes one email or user ID")
+	}
+
+	var params models.AdminUpdateUserParams
+	granted := true
+	switch *role {
+	case "admin":
+		params.IsAdmin = &granted
+	case "content-admin":
+		params.IsContentAdmin = &granted
+	default:
+		return r.usageError(fmt.Sprintf("unknown role %q", *role))
+	}
+
+	ref := fs.Arg(0)
+	var user *models.User
+	var err error
+	if strings.Contains(ref, "@") {
+		user, err = r.users.GetByEmail(ctx, ref)
+	} else {
+		user, err = r.users.GetByID(ctx, ref)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return fmt.Errorf("user not found: %s", ref)
+	}
+
+	user, err = r.users.AdminUpdate(ctx, user.ID, params)
+	if err != nil {
+		return fmt.Errorf("failed to promote user: %w", err)
+	}
+
+	fmt.Fprintf(r.out, "Promoted %s (%s): admin=%t contentAdmin=%t\n", user.Email, user.ID, user.IsAdmin, user.IsContentAdmin)
+	return nil
+}
+
+func (r *Runner) republishCatalogItem(ctx context.Context, args []string) error {
+	fs := r.flagSet("republish-catalog-item")
+	if err := fs.Parse(args); err != nil {
+		return ErrUsage
+	}
+	if fs.NArg() != 1 {
+		return r.usageError("republish-catalog-item takes one catalog item ID")
+	}
+
+	id := fs.Arg(0)
+	item, err := r.catalog.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get catalog item: %w", err)
+	}
+	if item == nil {
+		return fmt.Errorf("catalog item not found: %s", id)
+	}
+	if item.Status == models.CatalogStatusPublished {
+		fmt.Fprintf(r.out, "%s %s (%s) is already published\n", item.Brand, item.Model, item.ID)
+		return nil
+	}
+
+	// Going through AdminUpdate queues catalog.published like an admin
+	// publish, so the search index picks the item up
+	status := models.CatalogStatusPublished
+	item, err = r.catalog.AdminUpdate(ctx, id, "", models.AdminUpdateGearCatalogParams{Status: &status})
+	if err != nil {
+		return fmt.Errorf("failed to republish catalog item: %w", err)
+	}
+
+	fmt.Fprintf(r.out, "Republished %s %s (%s)\n", item.Brand, item.Model, item.ID)
+	return nil
+}
+
+func (r *Runner) runMigration(ctx context.Context, args []string) error {
+	fs := r.flagSet("run-migration")
+	if err := fs.Parse(args); err != nil {
+		return ErrUsage
+	}
+	if fs.NArg() != 0 {
+		return r.usageError("run-migration takes no arguments")
+	}
+
+	if err := r.migrator.Migrate(ctx); err != nil {
+		return err
+	}
+	fmt.Fprintln(r.out, "Migrations applied")
+	return nil
+}
+
+func (r *Runner) cleanupTempBuilds(ctx context.Context, args []string) error {
+	fs := r.flagSet("cleanup-temp-builds")
+	if err := fs.Parse(args); err != nil {
+		return ErrUsage
+	}
+	if fs.NArg() != 0 {
+		return r.usageError("cleanup-temp-builds takes no arguments")
+	}
+
+	deleted, err := r.builds.DeleteExpiredTemp(ctx, r.now().UTC())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(r.out, "Deleted %d expired temp builds\n", deleted)
+	return nil
+}
+
+func (r *Runner) recomputeCanonicalKeys(ctx context.Context, args []string) error {
+	fs := r.flagSet("recompute-canonical-keys")
+	dryRun := fs.Bool("dry-run", false, "report planned key changes without writing them")
+	if err := fs.Parse(args); err != nil {
+		return ErrUsage
+	}
+	if fs.NArg() != 0 {
+		return r.usageError("recompute-canonical-keys takes no arguments")
+	}
+
+	report, err := r.catalog.RecomputeCanonicalKeys(ctx, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, collision := range report.Collisions {
+		fmt.Fprintf(r.out, "Collision: %s wants %q, held by %s\n", collision.CatalogID, collision.ProposedKey, collision.ConflictsWithID)
+	}
+	fmt.Fprintf(r.out, "Canonical keys: total=%d updated=%d unchanged=%d collisions=%d dryRun=%t\n",
+		report.Total, report.Updated, report.Unchanged, len(report.Collisions), report.DryRun)
+	return nil
+}
+
+func (r *Runner) normalizeSpecUnits(ctx context.Context, args []string) error {
+	fs := r.flagSet("normalize-spec-units")
+	dryRun := fs.Bool("dry-run", false, "count the items that would change without writing them")
+	if err := fs.Parse(args); err != nil {
+		return ErrUsage
+	}
+	if fs.NArg() != 0 {
+		return r.usageError("normalize-spec-units takes no arguments")
+	}
+
+	report, err := r.catalog.NormalizeSpecUnits(ctx, *dryRun)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(r.out, "Spec units: total=%d updated=%d dryRun=%t\n", report.Total, report.Updated, report.DryRun)
+	return nil
+}
+
+func (r *Runner) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(r.out)
+	return fs
+}
+
+func (r *Runner) usageError(message string) error {
+	if message != "" {
+		fmt.Fprintf(r.out, "%s\n\n", message)
+	}
+	fmt.Fprint(r.out, usage)
+	return ErrUsage
+}
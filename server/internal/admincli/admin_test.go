@@ -0,0 +1,250 @@
+package admincli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+type fakeUserStore struct {
+	users   map[string]*models.User
+	updates []models.AdminUpdateUserParams
+}
+
+func (f *fakeUserStore) GetByID(ctx context.Context, id string) (*models.User, error) {
+	return f.users[id], nil
+}
+
+func (f *fakeUserStore) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	for _, user := range f.users {
+		if strings.EqualFold(user.Email, email) {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeUserStore) AdminUpdate(ctx context.Context, id string, params models.AdminUpdateUserParams) (*models.User, error) {
+	f.updates = append(f.updates, params)
+	user := f.users[id]
+	if params.IsAdmin != nil {
+		user.IsAdmin = *params.IsAdmin
+	}
+	if params.IsContentAdmin != nil {
+		user.IsContentAdmin = *params.IsContentAdmin
+	}
+	return user, nil
+}
+
+type fakeCatalogStore struct {
+	items   map[string]*models.GearCatalogItem
+	updates int
+	dryRun  bool
+}
+
+func (f *fakeCatalogStore) Get(ctx context.Context, id string) (*models.GearCatalogItem, error) {
+	return f.items[id], nil
+}
+
+func (f *fakeCatalogStore) AdminUpdate(ctx context.Context, id string, adminUserID string, params models.AdminUpdateGearCatalogParams) (*models.GearCatalogItem, error) {
+	f.updates++
+	item := f.items[id]
+	item.Status = *params.Status
+	return item, nil
+}
+
+func (f *fakeCatalogStore) RecomputeCanonicalKeys(ctx context.Context, dryRun bool) (*models.CanonicalKeyMigrationReport, error) {
+	f.dryRun = dryRun
+	return &models.CanonicalKeyMigrationReport{
+		DryRun:     dryRun,
+		Total:      3,
+		Updated:    1,
+		Unchanged:  1,
+		Collisions: []models.CanonicalKeyCollision{{CatalogID: "c1", ConflictsWithID: "c2", ProposedKey: "motor:tmotor:f60"}},
+	}, nil
+}
+
+func (f *fakeCatalogStore) NormalizeSpecUnits(ctx context.Context, dryRun bool) (*models.SpecUnitNormalizationReport, error) {
+	f.dryRun = dryRun
+	return &models.SpecUnitNormalizationReport{DryRun: dryRun, Total: 5, Updated: 2}, nil
+}
+
+type fakeBuildStore struct {
+	cutoff time.Time
+}
+
+func (f *fakeBuildStore) DeleteExpiredTemp(ctx context.Context, cutoff time.Time) (int64, error) {
+	f.cutoff = cutoff
+	return 4, nil
+}
+
+type fakeMigrator struct {
+	err error
+	ran bool
+}
+
+func (f *fakeMigrator) Migrate(ctx context.Context) error {
+	f.ran = true
+	return f.err
+}
+
+type testRunner struct {
+	*Runner
+	users    *fakeUserStore
+	catalog  *fakeCatalogStore
+	builds   *fakeBuildStore
+	migrator *fakeMigrator
+	out      *bytes.Buffer
+}
+
+func newTestRunner() *testRunner {
+	tr := &testRunner{
+		users: &fakeUserStore{users: map[string]*models.User{
+			"u1": {ID: "u1", Email: "pilot@example.com"},
+		}},
+		catalog: &fakeCatalogStore{items: map[string]*models.GearCatalogItem{
+			"c1": {ID: "c1", Brand: "T-Motor", Model: "F60", Status: models.CatalogStatusRemoved},
+			"c2": {ID: "c2", Brand: "iFlight", Model: "XING2", Status: models.CatalogStatusPublished},
+		}},
+		builds:   &fakeBuildStore{},
+		migrator: &fakeMigrator{},
+		out:      &bytes.Buffer{},
+	}
+	tr.Runner = &Runner{
+		users:    tr.users,
+		catalog:  tr.catalog,
+		builds:   tr.builds,
+		migrator: tr.migrator,
+		out:      tr.out,
+		now:      func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) },
+	}
+	return tr
+}
+
+func TestPromoteUser(t *testing.T) {
+	tr := newTestRunner()
+
+	if err := tr.Run(context.Background(), []string{"promote-user", "PILOT@example.com"}); err != nil {
+		t.Fatalf("promote-user failed: %v", err)
+	}
+	if !tr.users.users["u1"].IsAdmin {
+		t.Error("Expected the user to be an admin")
+	}
+
+	if err := tr.Run(context.Background(), []string{"promote-user", "-role", "content-admin", "u1"}); err != nil {
+		t.Fatalf("promote-user by ID failed: %v", err)
+	}
+	if last := tr.users.updates[len(tr.users.updates)-1]; last.IsContentAdmin == nil || last.IsAdmin != nil {
+		t.Errorf("Expected only content admin to be granted, got %+v", last)
+	}
+}
+
+func TestPromoteUser_Errors(t *testing.T) {
+	tr := newTestRunner()
+
+	if err := tr.Run(context.Background(), []string{"promote-user", "nobody@example.com"}); err == nil || errors.Is(err, ErrUsage) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+	if err := tr.Run(context.Background(), []string{"promote-user", "-role", "owner", "u1"}); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected a usage error for an unknown role, got %v", err)
+	}
+	if err := tr.Run(context.Background(), []string{"promote-user"}); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected a usage error without a user, got %v", err)
+	}
+	if len(tr.users.updates) != 0 {
+		t.Errorf("Expected no updates, got %d", len(tr.users.updates))
+	}
+}
+
+func TestRepublishCatalogItem(t *testing.T) {
+	tr := newTestRunner()
+
+	if err := tr.Run(context.Background(), []string{"republish-catalog-item", "c1"}); err != nil {
+		t.Fatalf("republish-catalog-item failed: %v", err)
+	}
+	if tr.catalog.items["c1"].Status != models.CatalogStatusPublished {
+		t.Error("Expected the item to be published")
+	}
+
+	// Already published items are left alone
+	if err := tr.Run(context.Background(), []string{"republish-catalog-item", "c2"}); err != nil {
+		t.Fatalf("republish-catalog-item failed: %v", err)
+	}
+	if tr.catalog.updates != 1 {
+		t.Errorf("Expected 1 update, got %d", tr.catalog.updates)
+	}
+
+	if err := tr.Run(context.Background(), []string{"republish-catalog-item", "missing"}); err == nil {
+		t.Error("Expected an error for a missing item")
+	}
+}
+
+func TestRunMigration(t *testing.T) {
+	tr := newTestRunner()
+	if err := tr.Run(context.Background(), []string{"run-migration"}); err != nil {
+		t.Fatalf("run-migration failed: %v", err)
+	}
+	if !tr.migrator.ran {
+		t.Error("Expected migrations to run")
+	}
+
+	tr.migrator.err = errors.New("migration 3 failed")
+	if err := tr.Run(context.Background(), []string{"run-migration"}); err == nil {
+		t.Error("Expected the migration error")
+	}
+}
+
+func TestCleanupTempBuilds(t *testing.T) {
+	tr := newTestRunner()
+	if err := tr.Run(context.Background(), []string{"cleanup-temp-builds"}); err != nil {
+		t.Fatalf("cleanup-temp-builds failed: %v", err)
+	}
+	if !tr.builds.cutoff.Equal(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the cutoff to be now, got %v", tr.builds.cutoff)
+	}
+	if !strings.Contains(tr.out.String(), "Deleted 4 expired temp builds") {
+		t.Errorf("Unexpected output: %q", tr.out.String())
+	}
+}
+
+func TestRecomputeCanonicalKeys(t *testing.T) {
+	tr := newTestRunner()
+	if err := tr.Run(context.Background(), []string{"recompute-canonical-keys", "-dry-run"}); err != nil {
+		t.Fatalf("recompute-canonical-keys failed: %v", err)
+	}
+	if !tr.catalog.dryRun {
+		t.Error("Expected a dry run")
+	}
+	out := tr.out.String()
+	if !strings.Contains(out, "c1 wants \"motor:tmotor:f60\", held by c2") || !strings.Contains(out, "collisions=1 dryRun=true") {
+		t.Errorf("Unexpected output: %q", out)
+	}
+}
+
+func TestNormalizeSpecUnits(t *testing.T) {
+	tr := newTestRunner()
+	if err := tr.Run(context.Background(), []string{"normalize-spec-units"}); err != nil {
+		t.Fatalf("normalize-spec-units failed: %v", err)
+	}
+	if tr.catalog.dryRun {
+		t.Error("Expected a real run")
+	}
+	if out := tr.out.String(); !strings.Contains(out, "total=5 updated=2 dryRun=false") {
+		t.Errorf("Unexpected output: %q", out)
+	}
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	tr := newTestRunner()
+	if err := tr.Run(context.Background(), []string{"drop-everything"}); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected a usage error, got %v", err)
+	}
+	if Known("drop-everything") || !Known("run-migration") {
+		t.Error("Known disagrees with the command list")
+	}
+}
@@ -3,6 +3,8 @@ package aggregator
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"html"
 	"sort"
 	"strings"
 	"sync"
@@ -18,13 +20,28 @@ import (
 const (
 	allItemsCacheKey = "all_items"
 	allItemsCacheTTL = 36 * time.Hour
+
+	// Search snippets come back from the database with matches between these
+	// control characters, so the rest can be escaped before adding <mark>
+	snippetStart = "\x02"
+	snippetStop  = "\x03"
 )
 
+// ErrArchiveUnavailable is returned by Search when feed items aren't kept in a database
+var ErrArchiveUnavailable = errors.New("feed archive is not available")
+
 // FeedItemStore persists aggregated feed items for long-term history.
 type FeedItemStore interface {
 	UpsertItems(ctx context.Context, items []models.FeedItem) error
 	DeleteItemsOlderThan(ctx context.Context, cutoff time.Time) (int64, error)
+	DeleteItems(ctx context.Context, ids []string) (int64, error)
 	QueryItems(ctx context.Context, params models.FilterParams, resolvedSources []string) ([]models.FeedItem, int, error)
+	SearchItems(ctx context.Context, params models.FilterParams, resolvedSources []string, snippetStart, snippetStop string) ([]models.FeedSearchResult, int, error)
+}
+
+// ItemFilter splits fetched items into those to keep and those to remove
+type ItemFilter interface {
+	Filter(ctx context.Context, items []models.FeedItem) (kept []models.FeedItem, removed []models.FeedItem)
 }
 
 type Aggregator struct {
@@ -36,6 +53,12 @@ type Aggregator struct {
 	logger        *logging.Logger
 	mu            sync.RWMutex
 	items         []models.FeedItem
+	schedMu       sync.Mutex
+	scheduleCfg   ScheduleConfig
+	schedules     map[string]*sourceSchedule
+	previewer     LinkPreviewer
+	filter        ItemFilter
+	now           func() time.Time
 }
 
 func New(fetchers []sources.Fetcher, c cache.Cache, tagger *tagging.Tagger, logger *logging.Logger) *Aggregator {
@@ -46,6 +69,9 @@ func New(fetchers []sources.Fetcher, c cache.Cache, tagger *tagging.Tagger, logg
 		logger:        logger,
 		retentionDays: 90,
 		items:         make([]models.FeedItem, 0),
+		scheduleCfg:   DefaultScheduleConfig(),
+		schedules:     make(map[string]*sourceSchedule),
+		now:           time.Now,
 	}
 }
 
@@ -53,20 +79,42 @@ func (a *Aggregator) SetStore(store FeedItemStore) {
 	a.store = store
 }
 
+// SetFilter applies admin-managed filters to every refresh
+func (a *Aggregator) SetFilter(f ItemFilter) {
+	a.filter = f
+}
+
 func (a *Aggregator) SetRetentionDays(days int) {
 	a.retentionDays = days
 }
 
+// Refresh fetches every source and replaces the items held in memory
 func (a *Aggregator) Refresh(ctx context.Context) error {
+	return a.refresh(ctx, a.fetchers, false)
+}
+
+// RefreshDue fetches only the sources whose schedule is due and merges
+// their items with the rest. It returns how many sources were fetched.
+func (a *Aggregator) RefreshDue(ctx context.Context) (int, error) {
+	due := a.dueFetchers(a.now())
+	if len(due) == 0 {
+		return 0, nil
+	}
+	return len(due), a.refresh(ctx, due, true)
+}
+
+func (a *Aggregator) refresh(ctx context.Context, fetchers []sources.Fetcher, merge bool) error {
 	var wg sync.WaitGroup
-	results := make(chan sources.FetchResult, len(a.fetchers))
+	results := make(chan sources.FetchResult, len(fetchers))
 
-	for _, fetcher := range a.fetchers {
+	for _, fetcher := range fetchers {
 		wg.Add(1)
 		go func(f sources.Fetcher) {
 			defer wg.Done()
 
+			started := a.now()
 			items, err := f.Fetch(ctx)
+			a.recordFetch(f.SourceInfo(), f.Name(), items, err, started, a.now().Sub(started))
 			results <- sources.FetchResult{
 				Items:  items,
 				Source: f.SourceInfo(),
@@ -81,7 +129,15 @@ func (a *Aggregator) Refresh(ctx context.Context) error {
 	}()
 
 	allItems := make([]models.FeedItem, 0)
+	fetched := make(map[string]bool)
+	unchanged := make(map[string]bool)
 	for result := range results {
+		if errors.Is(result.Error, sources.ErrNotModified) {
+			// Nothing to parse or tag; the source keeps its items
+			unchanged[result.Source.Name] = true
+			a.logger.Debug("Source not modified", logging.WithField("source", result.Source.Name))
+			continue
+		}
 		if result.Error != nil {
 			a.logger.Warn("Failed to fetch from source", logging.WithFields(map[string]interface{}{
 				"source": result.Source.Name,
@@ -101,6 +157,30 @@ func (a *Aggregator) Refresh(ctx context.Context) error {
 		}
 
 		allItems = append(allItems, result.Items...)
+		fetched[result.Source.Name] = true
+	}
+	a.enrich(ctx, allItems)
+	allItems, removed := a.applyFilter(ctx, allItems)
+
+	fetchedItems := a.deduplicate(allItems)
+	if merge || len(unchanged) > 0 {
+		// Keep what unchanged sources, and when merging every source not
+		// fetched, had last time
+		a.mu.RLock()
+		existing := a.items
+		a.mu.RUnlock()
+		if len(existing) == 0 {
+			existing, _ = a.loadItemsFromCache()
+		}
+		kept := make([]models.FeedItem, 0, len(existing))
+		for _, item := range existing {
+			if unchanged[item.Source] || (merge && !fetched[item.Source]) {
+				kept = append(kept, item)
+			}
+		}
+		// Filters added since these were fetched apply to them too
+		kept, _ = a.applyFilter(ctx, kept)
+		allItems = append(allItems, kept...)
 	}
 
 	dedupedItems := a.deduplicate(allItems)
@@ -115,10 +195,22 @@ func (a *Aggregator) Refresh(ctx context.Context) error {
 	}
 
 	if a.store != nil {
-		if err := a.store.UpsertItems(ctx, dedupedItems); err != nil {
+		if err := a.store.UpsertItems(ctx, fetchedItems); err != nil {
 			return err
 		}
 
+		// Sources keep listing posts for days, so this also clears ones
+		// stored before a filter was added
+		if len(removed) > 0 {
+			ids := make([]string, 0, len(removed))
+			for _, item := range removed {
+				ids = append(ids, item.ID)
+			}
+			if _, err := a.store.DeleteItems(ctx, ids); err != nil {
+				return err
+			}
+		}
+
 		// Enforce retention policy to cap DB growth.
 		// NOTE: configurable via config/env (default: 90 days). A value <= 0 disables retention cleanup.
 		if a.retentionDays > 0 {
@@ -136,12 +228,24 @@ func (a *Aggregator) Refresh(ctx context.Context) error {
 
 	a.logger.Info("Aggregation complete", logging.WithFields(map[string]interface{}{
 		"total_items":  len(dedupedItems),
-		"sources_used": len(a.fetchers),
+		"sources_used": len(fetchers),
 	}))
 
 	return nil
 }
 
+// applyFilter removes the items the filter blocks, returning them separately
+func (a *Aggregator) applyFilter(ctx context.Context, items []models.FeedItem) ([]models.FeedItem, []models.FeedItem) {
+	if a.filter == nil || len(items) == 0 {
+		return items, nil
+	}
+	kept, removed := a.filter.Filter(ctx, items)
+	if len(removed) > 0 {
+		a.logger.Info("Filtered feed items", logging.WithField("count", len(removed)))
+	}
+	return kept, removed
+}
+
 func (a *Aggregator) GetItems(ctx context.Context, params models.FilterParams) models.AggregatedResponse {
 	// When a persistent store is configured, prefer it so we can serve history
 	// across runs (not just the last cached refresh).
@@ -215,6 +319,34 @@ func (a *Aggregator) GetItems(ctx context.Context, params models.FilterParams) m
 	}
 }
 
+// Search runs a full-text search over every stored item, including ones that
+// have dropped out of the live feed, back to the retention cutoff.
+func (a *Aggregator) Search(ctx context.Context, params models.FilterParams) (*models.FeedSearchResponse, error) {
+	if a.store == nil {
+		return nil, ErrArchiveUnavailable
+	}
+
+	results, total, err := a.store.SearchItems(ctx, params, a.resolveSourceNames(params.Sources), snippetStart, snippetStop)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		results[i].Snippet = highlightSnippet(results[i].Snippet)
+	}
+
+	return &models.FeedSearchResponse{
+		Query:      params.Query,
+		Items:      results,
+		TotalCount: total,
+	}, nil
+}
+
+// highlightSnippet escapes a snippet and turns its match markers into <mark> tags
+func highlightSnippet(snippet string) string {
+	escaped := html.EscapeString(snippet)
+	return strings.NewReplacer(snippetStart, "<mark>", snippetStop, "</mark>").Replace(escaped)
+}
+
 // resolveSourceNames maps source IDs (as returned by /api/sources) into the source
 // name values stored on FeedItem.Source.
 func (a *Aggregator) resolveSourceNames(sourceIDs []string) []string {
@@ -422,3 +422,42 @@ func TestAggregator_GetItems_LoadsFromGenericCachePayload(t *testing.T) {
 		t.Fatalf("GetItems() first generic cached item = %q, want %q", resp.Items[0].ID, "generic-cache-item")
 	}
 }
+
+type searchStore struct {
+	FeedItemStore
+	results []models.FeedSearchResult
+	sources []string
+}
+
+func (s *searchStore) SearchItems(ctx context.Context, params models.FilterParams, resolvedSources []string, start, stop string) ([]models.FeedSearchResult, int, error) {
+	s.sources = resolvedSources
+	return s.results, len(s.results), nil
+}
+
+func TestAggregator_Search_NoStore(t *testing.T) {
+	a := &Aggregator{}
+
+	if _, err := a.Search(context.Background(), models.FilterParams{Query: "rates"}); err != ErrArchiveUnavailable {
+		t.Errorf("Search() error = %v, want ErrArchiveUnavailable", err)
+	}
+}
+
+func TestAggregator_Search_HighlightsSnippets(t *testing.T) {
+	store := &searchStore{results: []models.FeedSearchResult{
+		{FeedItem: models.FeedItem{ID: "1"}, Snippet: "New " + snippetStart + "rates" + snippetStop + " for <b>5\" quads</b> & whoops"},
+	}}
+	a := &Aggregator{store: store}
+
+	resp, err := a.Search(context.Background(), models.FilterParams{Query: "rates"})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	want := "New <mark>rates</mark> for &lt;b&gt;5&#34; quads&lt;/b&gt; &amp; whoops"
+	if resp.TotalCount != 1 || resp.Items[0].Snippet != want {
+		t.Errorf("Search() snippet = %q, want %q", resp.Items[0].Snippet, want)
+	}
+	if resp.Query != "rates" {
+		t.Errorf("Search().Query = %q, want rates", resp.Query)
+	}
+}
@@ -0,0 +1,100 @@
+package aggregator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	// enrichTimeout caps how long a refresh waits on link previews. Items
+	// not reached are tried again on the next refresh.
+	enrichTimeout     = 45 * time.Second
+	enrichConcurrency = 4
+)
+
+// LinkPreviewer reads the OpenGraph metadata of a page
+type LinkPreviewer interface {
+	Preview(ctx context.Context, pageURL string) (*models.LinkPreview, error)
+}
+
+// SetLinkPreviewer enables filling in missing thumbnails and summaries from
+// each item's page
+func (a *Aggregator) SetLinkPreviewer(p LinkPreviewer) {
+	a.previewer = p
+}
+
+// needsPreview reports whether an item is missing a thumbnail or summary its
+// page could supply. Reddit links go to the thread, whose preview is
+// Reddit's own.
+func needsPreview(item models.FeedItem) bool {
+	if item.URL == "" || item.SourceType == "reddit" {
+		return false
+	}
+	return item.Thumbnail == "" || item.Summary == ""
+}
+
+// enrich fills in missing thumbnails and summaries from the items' link
+// previews
+func (a *Aggregator) enrich(ctx context.Context, items []models.FeedItem) {
+	if a.previewer == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, enrichTimeout)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		enriched int
+		sem      = make(chan struct{}, enrichConcurrency)
+	)
+	for i := range items {
+		if !needsPreview(items[i]) {
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(item *models.FeedItem) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			preview, err := a.previewer.Preview(ctx, item.URL)
+			if err != nil {
+				a.logger.Debug("Failed to load link preview", logging.WithFields(map[string]interface{}{
+					"url":   item.URL,
+					"error": err.Error(),
+				}))
+				return
+			}
+
+			changed := false
+			if item.Thumbnail == "" && preview.ImageURL != "" {
+				item.Thumbnail = preview.ImageURL
+				changed = true
+			}
+			if item.Summary == "" && preview.Description != "" {
+				item.Summary = preview.Description
+				changed = true
+			}
+			if changed {
+				mu.Lock()
+				enriched++
+				mu.Unlock()
+			}
+		}(&items[i])
+	}
+	wg.Wait()
+
+	if enriched > 0 {
+		a.logger.Info("Added link previews to feed items", logging.WithField("count", enriched))
+	}
+}
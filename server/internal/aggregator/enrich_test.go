@@ -0,0 +1,59 @@
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+type fakePreviewer struct {
+	previews map[string]*models.LinkPreview
+	mu       sync.Mutex
+	calls    []string
+}
+
+func (f *fakePreviewer) Preview(ctx context.Context, pageURL string) (*models.LinkPreview, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, pageURL)
+	f.mu.Unlock()
+	preview, ok := f.previews[pageURL]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return preview, nil
+}
+
+func TestAggregator_Enrich(t *testing.T) {
+	previewer := &fakePreviewer{previews: map[string]*models.LinkPreview{
+		"https://example.com/bare":    {ImageURL: "https://example.com/bare.jpg", Description: "From the page"},
+		"https://example.com/summary": {ImageURL: "https://example.com/summary.jpg", Description: "Ignored"},
+	}}
+	a := &Aggregator{logger: logging.New(logging.LevelError)}
+	a.SetLinkPreviewer(previewer)
+
+	items := []models.FeedItem{
+		{URL: "https://example.com/bare", SourceType: "rss"},
+		{URL: "https://example.com/summary", SourceType: "rss", Summary: "From the feed"},
+		{URL: "https://example.com/complete", SourceType: "rss", Summary: "Has both", Thumbnail: "https://example.com/t.jpg"},
+		{URL: "https://www.reddit.com/r/fpv/comments/abc", SourceType: "reddit"},
+		{URL: "https://example.com/broken", SourceType: "rss"},
+	}
+	a.enrich(context.Background(), items)
+
+	if items[0].Thumbnail != "https://example.com/bare.jpg" || items[0].Summary != "From the page" {
+		t.Errorf("bare item = %+v, want thumbnail and summary from the preview", items[0])
+	}
+	if items[1].Thumbnail != "https://example.com/summary.jpg" || items[1].Summary != "From the feed" {
+		t.Errorf("item with summary = %+v, want thumbnail added and summary kept", items[1])
+	}
+	if items[4].Thumbnail != "" || items[4].Summary != "" {
+		t.Errorf("broken item = %+v, want unchanged", items[4])
+	}
+	if len(previewer.calls) != 3 {
+		t.Errorf("previews requested for %v, want only items missing fields, excluding Reddit", previewer.calls)
+	}
+}
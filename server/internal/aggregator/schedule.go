@@ -0,0 +1,181 @@
+package aggregator
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/sources"
+)
+
+// postsForInterval is how many of a source's newest posts are used to
+// estimate how often it posts
+const postsForInterval = 10
+
+// ScheduleConfig bounds how often each source is fetched
+type ScheduleConfig struct {
+	MinInterval time.Duration
+	MaxInterval time.Duration
+}
+
+// DefaultScheduleConfig polls the busiest sources every 15 minutes and
+// dormant ones once a day
+func DefaultScheduleConfig() ScheduleConfig {
+	return ScheduleConfig{
+		MinInterval: 15 * time.Minute,
+		MaxInterval: 24 * time.Hour,
+	}
+}
+
+func (c ScheduleConfig) clamp(d time.Duration) time.Duration {
+	if d < c.MinInterval {
+		return c.MinInterval
+	}
+	if d > c.MaxInterval {
+		return c.MaxInterval
+	}
+	return d
+}
+
+// sourceSchedule is the schedule and fetch history of one source
+type sourceSchedule struct {
+	stats    models.SourceSchedule
+	interval time.Duration
+	next     time.Time
+	latest   time.Time // newest post seen
+}
+
+// SetSchedule sets the bounds for per-source fetch intervals
+func (a *Aggregator) SetSchedule(cfg ScheduleConfig) {
+	a.schedMu.Lock()
+	defer a.schedMu.Unlock()
+	a.scheduleCfg = cfg
+}
+
+// dueFetchers returns the sources whose next fetch is due. Sources that
+// have never been fetched are always due.
+func (a *Aggregator) dueFetchers(now time.Time) []sources.Fetcher {
+	a.schedMu.Lock()
+	defer a.schedMu.Unlock()
+
+	due := make([]sources.Fetcher, 0)
+	for _, f := range a.fetchers {
+		sched, ok := a.schedules[f.Name()]
+		if !ok || !now.Before(sched.next) {
+			due = append(due, f)
+		}
+	}
+	return due
+}
+
+// recordFetch updates a source's metrics and picks when to fetch it next
+func (a *Aggregator) recordFetch(info models.SourceInfo, name string, items []models.FeedItem, fetchErr error, started time.Time, took time.Duration) {
+	a.schedMu.Lock()
+	defer a.schedMu.Unlock()
+
+	sched, ok := a.schedules[name]
+	if !ok {
+		sched = &sourceSchedule{}
+		a.schedules[name] = sched
+	}
+	sched.stats.ID = info.ID
+	sched.stats.Name = name
+	sched.stats.Fetches++
+	sched.stats.LastDurationMs = took.Milliseconds()
+	fetchedAt := started
+	sched.stats.LastFetchAt = &fetchedAt
+
+	switch {
+	case errors.Is(fetchErr, sources.ErrNotModified):
+		sched.stats.NotModified++
+		sched.stats.ConsecutiveFailures = 0
+		sched.stats.LastError = ""
+		sched.stats.LastSuccessAt = &fetchedAt
+		// Nothing new since the last fetch, so wait at least as long again,
+		// and longer the longer it's been since the source last posted
+		wait := sched.interval
+		if !sched.latest.IsZero() {
+			wait = max(wait, started.Sub(sched.latest)/2)
+		}
+		sched.interval = a.scheduleCfg.clamp(wait)
+	case fetchErr != nil:
+		sched.stats.Failures++
+		sched.stats.ConsecutiveFailures++
+		sched.stats.LastError = fetchErr.Error()
+		// Back off a failing source the same way as a quiet one
+		sched.interval = a.scheduleCfg.clamp(2 * max(sched.interval, a.scheduleCfg.MinInterval))
+	default:
+		sched.stats.ConsecutiveFailures = 0
+		sched.stats.LastError = ""
+		sched.stats.LastSuccessAt = &fetchedAt
+		sched.stats.ItemsFetched += len(items)
+
+		newest := sched.latest
+		for _, item := range items {
+			if item.PublishedAt.After(sched.latest) {
+				sched.stats.NewItems++
+			}
+			if item.PublishedAt.After(newest) {
+				newest = item.PublishedAt
+			}
+		}
+		sched.latest = newest
+		if !newest.IsZero() {
+			latest := newest
+			sched.stats.LatestPostAt = &latest
+		}
+		sched.interval = nextInterval(a.scheduleCfg, sched.interval, items, started)
+	}
+
+	sched.next = started.Add(sched.interval)
+	next := sched.next
+	sched.stats.NextFetchAt = &next
+	sched.stats.IntervalSeconds = int64(sched.interval / time.Second)
+	sched.stats.NotModifiedRate = float64(sched.stats.NotModified) / float64(sched.stats.Fetches)
+}
+
+// nextInterval estimates how long until a source posts again, from the gaps
+// between its recent posts and how long it's been since the last one, and
+// polls twice in that time. Sources without enough dated posts to tell are
+// backed off.
+func nextInterval(cfg ScheduleConfig, prev time.Duration, items []models.FeedItem, now time.Time) time.Duration {
+	dated := make([]time.Time, 0, len(items))
+	for _, item := range items {
+		if !item.PublishedAt.IsZero() {
+			dated = append(dated, item.PublishedAt)
+		}
+	}
+	if len(dated) < 2 {
+		return cfg.clamp(2 * max(prev, cfg.MinInterval))
+	}
+
+	sort.Slice(dated, func(i, j int) bool { return dated[i].After(dated[j]) })
+	if len(dated) > postsForInterval {
+		dated = dated[:postsForInterval]
+	}
+	gaps := make([]time.Duration, 0, len(dated)-1)
+	for i := 1; i < len(dated); i++ {
+		gaps = append(gaps, dated[i-1].Sub(dated[i]))
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+
+	expected := max(gaps[len(gaps)/2], now.Sub(dated[0]))
+	return cfg.clamp(expected / 2)
+}
+
+// Schedules returns each source's schedule and fetch metrics
+func (a *Aggregator) Schedules() []models.SourceSchedule {
+	a.schedMu.Lock()
+	defer a.schedMu.Unlock()
+
+	result := make([]models.SourceSchedule, 0, len(a.fetchers))
+	for _, f := range a.fetchers {
+		if sched, ok := a.schedules[f.Name()]; ok {
+			result = append(result, sched.stats)
+			continue
+		}
+		result = append(result, models.SourceSchedule{ID: f.SourceInfo().ID, Name: f.Name()})
+	}
+	return result
+}
@@ -0,0 +1,187 @@
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/sources"
+	"github.com/johnrirwin/flyingforge/internal/tagging"
+)
+
+type fakeFetcher struct {
+	name  string
+	items []models.FeedItem
+	err   error
+	calls int
+}
+
+func (f *fakeFetcher) Name() string { return f.name }
+
+func (f *fakeFetcher) Fetch(ctx context.Context) ([]models.FeedItem, error) {
+	f.calls++
+	return f.items, f.err
+}
+
+func (f *fakeFetcher) SourceInfo() models.SourceInfo {
+	return models.SourceInfo{ID: f.name, Name: f.name}
+}
+
+// postsEvery returns n items from source published gap apart, the newest
+// at latest
+func postsEvery(source string, n int, gap time.Duration, latest time.Time) []models.FeedItem {
+	items := make([]models.FeedItem, 0, n)
+	for i := 0; i < n; i++ {
+		items = append(items, models.FeedItem{
+			ID:          fmt.Sprintf("%s-%d", source, i),
+			Title:       fmt.Sprintf("%s post %d", source, i),
+			URL:         fmt.Sprintf("https://example.com/%s/%d", source, i),
+			Source:      source,
+			PublishedAt: latest.Add(-time.Duration(i) * gap),
+		})
+	}
+	return items
+}
+
+func TestNextInterval(t *testing.T) {
+	cfg := ScheduleConfig{MinInterval: 15 * time.Minute, MaxInterval: 24 * time.Hour}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		prev  time.Duration
+		items []models.FeedItem
+		want  time.Duration
+	}{
+		{
+			name:  "busy source polled at the minimum",
+			items: postsEvery("busy", 10, 10*time.Minute, now),
+			want:  15 * time.Minute,
+		},
+		{
+			name:  "posts every 4 hours",
+			items: postsEvery("blog", 10, 4*time.Hour, now.Add(-time.Hour)),
+			want:  2 * time.Hour,
+		},
+		{
+			name:  "quiet since its last post",
+			items: postsEvery("blog", 10, time.Hour, now.Add(-10*time.Hour)),
+			want:  5 * time.Hour,
+		},
+		{
+			name:  "dormant source polled at the maximum",
+			items: postsEvery("dormant", 10, 24*time.Hour, now.Add(-90*24*time.Hour)),
+			want:  24 * time.Hour,
+		},
+		{
+			name: "too few posts backs off",
+			prev: 2 * time.Hour,
+			items: []models.FeedItem{
+				{ID: "1", PublishedAt: now},
+				{ID: "2"},
+			},
+			want: 4 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextInterval(cfg, tt.prev, tt.items, now); got != tt.want {
+				t.Errorf("nextInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregator_RefreshDue(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	busy := &fakeFetcher{name: "busy", items: postsEvery("busy", 5, 5*time.Minute, now)}
+	dormant := &fakeFetcher{name: "dormant", items: postsEvery("dormant", 5, 24*time.Hour, now.Add(-60*24*time.Hour))}
+
+	a := New([]sources.Fetcher{busy, dormant}, nil, tagging.New(), logging.New(logging.LevelError))
+	a.SetSchedule(ScheduleConfig{MinInterval: 15 * time.Minute, MaxInterval: 24 * time.Hour})
+	a.now = func() time.Time { return now }
+
+	fetched, err := a.RefreshDue(context.Background())
+	if err != nil || fetched != 2 {
+		t.Fatalf("first RefreshDue() = %d, %v, want both sources", fetched, err)
+	}
+
+	// 20 minutes later only the busy source is due, and the dormant
+	// source's items are kept
+	now = now.Add(20 * time.Minute)
+	busy.items = postsEvery("busy", 5, 5*time.Minute, now)
+	fetched, err = a.RefreshDue(context.Background())
+	if err != nil || fetched != 1 {
+		t.Fatalf("second RefreshDue() = %d, %v, want 1 source", fetched, err)
+	}
+	if busy.calls != 2 || dormant.calls != 1 {
+		t.Errorf("fetch calls busy=%d dormant=%d, want 2 and 1", busy.calls, dormant.calls)
+	}
+	if got := len(a.items); got != 10 {
+		t.Errorf("items after merge = %d, want 10", got)
+	}
+
+	schedules := a.Schedules()
+	if schedules[0].Fetches != 2 || schedules[0].IntervalSeconds != int64((15*time.Minute)/time.Second) {
+		t.Errorf("busy schedule = %+v, want 2 fetches every 15m", schedules[0])
+	}
+	if schedules[0].NewItems != 9 {
+		t.Errorf("busy NewItems = %d, want 9", schedules[0].NewItems)
+	}
+	if schedules[1].IntervalSeconds != int64((24*time.Hour)/time.Second) {
+		t.Errorf("dormant interval = %ds, want 24h", schedules[1].IntervalSeconds)
+	}
+}
+
+func TestAggregator_RefreshDue_FailureBacksOff(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	failing := &fakeFetcher{name: "down", err: errors.New("connection refused")}
+
+	a := New([]sources.Fetcher{failing}, nil, tagging.New(), logging.New(logging.LevelError))
+	a.SetSchedule(ScheduleConfig{MinInterval: 15 * time.Minute, MaxInterval: 24 * time.Hour})
+	a.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if _, err := a.RefreshDue(context.Background()); err != nil {
+			t.Fatalf("RefreshDue() error = %v", err)
+		}
+		now = now.Add(24 * time.Hour)
+	}
+
+	got := a.Schedules()[0]
+	if got.Failures != 2 || got.ConsecutiveFailures != 2 || got.LastError != "connection refused" {
+		t.Errorf("schedule = %+v, want 2 consecutive failures", got)
+	}
+	if got.IntervalSeconds != int64((time.Hour)/time.Second) {
+		t.Errorf("interval = %ds, want 1h after two failures", got.IntervalSeconds)
+	}
+}
+
+func TestAggregator_Refresh_KeepsItemsOfUnchangedSources(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	blog := &fakeFetcher{name: "blog", items: postsEvery("blog", 3, time.Hour, now)}
+
+	a := New([]sources.Fetcher{blog}, nil, tagging.New(), logging.New(logging.LevelError))
+	a.now = func() time.Time { return now }
+
+	if err := a.Refresh(context.Background()); err != nil {
+		t.Fatalf("first Refresh() error = %v", err)
+	}
+	blog.items, blog.err = nil, sources.ErrNotModified
+	if err := a.Refresh(context.Background()); err != nil {
+		t.Fatalf("second Refresh() error = %v", err)
+	}
+
+	if got := len(a.items); got != 3 {
+		t.Errorf("items after not modified = %d, want 3", got)
+	}
+	got := a.Schedules()[0]
+	if got.NotModified != 1 || got.Failures != 0 || got.NotModifiedRate != 0.5 {
+		t.Errorf("schedule = %+v, want 1 of 2 fetches not modified and no failures", got)
+	}
+}
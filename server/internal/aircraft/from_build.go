@@ -0,0 +1,203 @@
+package aircraft
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const defaultAircraftName = "New Aircraft"
+
+// BuildSource loads the builds a user can copy into their hangar
+type BuildSource interface {
+	GetOwnedOrPublic(ctx context.Context, id string, userID string) (*models.Build, error)
+}
+
+// SetBuildSource enables creating aircraft from builds
+func (s *Service) SetBuildSource(builds BuildSource) {
+	s.builds = builds
+}
+
+// plannedComponent is a build part that will become an aircraft component
+type plannedComponent struct {
+	category    models.ComponentCategory
+	catalogItem *models.BuildCatalogItem
+	quantity    int
+	notes       string
+}
+
+// CreateFromBuild creates an aircraft with components taken from one of the
+// user's builds or a published build. Each part is linked to the user's
+// inventory item for the same catalog item, or a new inventory item is added.
+// Returns nil if the build is not found.
+func (s *Service) CreateFromBuild(ctx context.Context, userID string, buildID string) (*models.AircraftFromBuildResponse, error) {
+	if s.builds == nil {
+		return nil, &ServiceError{Message: "builds are not available"}
+	}
+	buildID = strings.TrimSpace(buildID)
+	if buildID == "" {
+		return nil, &ServiceError{Message: "build id is required"}
+	}
+
+	build, err := s.builds.GetOwnedOrPublic(ctx, buildID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if build == nil {
+		return nil, nil
+	}
+
+	planned, skipped := planComponents(build.Parts)
+
+	name := strings.TrimSpace(build.Title)
+	if name == "" {
+		name = defaultAircraftName
+	}
+	aircraft, err := s.Create(ctx, userID, models.CreateAircraftParams{
+		Name:        name,
+		Description: build.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.AircraftFromBuildResponse{
+		Aircraft:   *aircraft,
+		Components: make([]models.AircraftComponent, 0, len(planned)),
+		Skipped:    skipped,
+	}
+
+	for _, plan := range planned {
+		item, created, err := s.inventoryItemForPart(ctx, userID, plan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s to inventory: %w", plan.category, err)
+		}
+		if created {
+			response.CreatedInventoryItems++
+		} else {
+			response.LinkedInventoryItems++
+		}
+
+		component, err := s.store.SetComponent(ctx, aircraft.ID, plan.category, item.ID, plan.notes)
+		if err != nil {
+			return nil, err
+		}
+		component.InventoryItem = item
+		response.Components = append(response.Components, *component)
+	}
+
+	s.logger.Info("Created aircraft from build", logging.WithFields(map[string]interface{}{
+		"aircraft_id": aircraft.ID,
+		"build_id":    build.ID,
+		"components":  len(response.Components),
+		"skipped":     len(skipped),
+	}))
+	return response, nil
+}
+
+// inventoryItemForPart returns the user's inventory item for a part's catalog
+// item, adding one with the part's quantity if there is none
+func (s *Service) inventoryItemForPart(ctx context.Context, userID string, plan plannedComponent) (*models.InventoryItem, bool, error) {
+	existing, err := s.inventorySvc.GetItemByCatalogID(ctx, userID, plan.catalogItem.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing != nil && !existing.Archived {
+		return existing, false, nil
+	}
+
+	// Adding an archived catalog item restores it with the part's quantity
+	category := plan.catalogItem.GearType.ToEquipmentCategory()
+	if plan.catalogItem.GearType == "" {
+		category = mapComponentToEquipmentCategory(plan.category)
+	}
+	item, err := s.inventorySvc.AddItem(ctx, userID, models.AddInventoryParams{
+		Name:         plan.catalogItem.DisplayName(),
+		Category:     category,
+		Manufacturer: plan.catalogItem.Brand,
+		Quantity:     plan.quantity,
+		CatalogID:    plan.catalogItem.ID,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return item, true, nil
+}
+
+// planComponents maps build parts to aircraft components. An aircraft has one
+// component per category, so parts repeating a category with the same catalog
+// item add to its quantity and parts with a different one are skipped.
+func planComponents(parts []models.BuildPart) ([]plannedComponent, []models.SkippedBuildPart) {
+	var planned []plannedComponent
+	var skipped []models.SkippedBuildPart
+	byCategory := make(map[models.ComponentCategory]int)
+
+	for _, part := range parts {
+		skip := models.SkippedBuildPart{
+			GearType:      part.GearType,
+			CatalogItemID: part.CatalogItemID,
+			Name:          part.CatalogItem.DisplayName(),
+		}
+
+		category := gearTypeToComponentCategory(part.GearType)
+		if category == "" {
+			skip.Reason = fmt.Sprintf("%s parts are not aircraft components", part.GearType)
+			skipped = append(skipped, skip)
+			continue
+		}
+		if part.CatalogItemID == "" || part.CatalogItem == nil {
+			skip.Reason = "part has no catalog item"
+			skipped = append(skipped, skip)
+			continue
+		}
+
+		if i, ok := byCategory[category]; ok {
+			if planned[i].catalogItem.ID == part.CatalogItemID {
+				planned[i].quantity++
+				continue
+			}
+			skip.Reason = fmt.Sprintf("aircraft already has a %s component", category)
+			skipped = append(skipped, skip)
+			continue
+		}
+
+		byCategory[category] = len(planned)
+		planned = append(planned, plannedComponent{
+			category:    category,
+			catalogItem: part.CatalogItem,
+			quantity:    1,
+			notes:       part.Notes,
+		})
+	}
+	return planned, skipped
+}
+
+func gearTypeToComponentCategory(gearType models.GearType) models.ComponentCategory {
+	switch gearType {
+	case models.GearTypeFrame:
+		return models.ComponentCategoryFrame
+	case models.GearTypeMotor:
+		return models.ComponentCategoryMotors
+	case models.GearTypeAIO:
+		return models.ComponentCategoryAIO
+	case models.GearTypeFC:
+		return models.ComponentCategoryFC
+	case models.GearTypeESC:
+		return models.ComponentCategoryESC
+	case models.GearTypeReceiver:
+		return models.ComponentCategoryReceiver
+	case models.GearTypeVTX, models.GearTypeHDUnit:
+		return models.ComponentCategoryVTX
+	case models.GearTypeCamera:
+		return models.ComponentCategoryCamera
+	case models.GearTypeProp:
+		return models.ComponentCategoryProps
+	case models.GearTypeAntenna:
+		return models.ComponentCategoryAntenna
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,75 @@
+package aircraft
+
+import (
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+func TestPlanComponents(t *testing.T) {
+	motor := &models.BuildCatalogItem{ID: "motor-1", GearType: models.GearTypeMotor, Brand: "T-Motor", Model: "F60"}
+	otherMotor := &models.BuildCatalogItem{ID: "motor-2", GearType: models.GearTypeMotor, Brand: "Emax", Model: "Eco II"}
+	frame := &models.BuildCatalogItem{ID: "frame-1", GearType: models.GearTypeFrame, Brand: "ImpulseRC", Model: "Apex"}
+	battery := &models.BuildCatalogItem{ID: "battery-1", GearType: models.GearTypeBattery, Brand: "CNHL", Model: "1300"}
+
+	parts := []models.BuildPart{
+		{GearType: models.GearTypeFrame, CatalogItemID: frame.ID, CatalogItem: frame, Notes: "5 inch"},
+		{GearType: models.GearTypeMotor, CatalogItemID: motor.ID, CatalogItem: motor},
+		{GearType: models.GearTypeMotor, CatalogItemID: motor.ID, CatalogItem: motor},
+		{GearType: models.GearTypeMotor, CatalogItemID: otherMotor.ID, CatalogItem: otherMotor},
+		{GearType: models.GearTypeBattery, CatalogItemID: battery.ID, CatalogItem: battery},
+		{GearType: models.GearTypeVTX},
+	}
+
+	planned, skipped := planComponents(parts)
+
+	if len(planned) != 2 {
+		t.Fatalf("expected 2 planned components, got %+v", planned)
+	}
+	if planned[0].category != models.ComponentCategoryFrame || planned[0].notes != "5 inch" {
+		t.Errorf("frame component = %+v", planned[0])
+	}
+	if planned[1].category != models.ComponentCategoryMotors || planned[1].quantity != 2 {
+		t.Errorf("motor component = %+v, want quantity 2", planned[1])
+	}
+
+	wantReasons := []string{
+		"aircraft already has a motors component",
+		"battery parts are not aircraft components",
+		"part has no catalog item",
+	}
+	if len(skipped) != len(wantReasons) {
+		t.Fatalf("skipped = %+v", skipped)
+	}
+	for i, want := range wantReasons {
+		if skipped[i].Reason != want {
+			t.Errorf("skipped[%d].Reason = %q, want %q", i, skipped[i].Reason, want)
+		}
+	}
+	if skipped[0].Name != "Emax Eco II" {
+		t.Errorf("skipped[0].Name = %q", skipped[0].Name)
+	}
+}
+
+func TestGearTypeToComponentCategory(t *testing.T) {
+	gearTypes := map[models.ComponentCategory]models.GearType{
+		models.ComponentCategoryFC:       models.GearTypeFC,
+		models.ComponentCategoryESC:      models.GearTypeESC,
+		models.ComponentCategoryAIO:      models.GearTypeAIO,
+		models.ComponentCategoryReceiver: models.GearTypeReceiver,
+		models.ComponentCategoryVTX:      models.GearTypeVTX,
+		models.ComponentCategoryMotors:   models.GearTypeMotor,
+		models.ComponentCategoryCamera:   models.GearTypeCamera,
+		models.ComponentCategoryFrame:    models.GearTypeFrame,
+		models.ComponentCategoryProps:    models.GearTypeProp,
+		models.ComponentCategoryAntenna:  models.GearTypeAntenna,
+	}
+	for category, gearType := range gearTypes {
+		if got := gearTypeToComponentCategory(gearType); got != category {
+			t.Errorf("gearTypeToComponentCategory(%s) = %s, want %s", gearType, got, category)
+		}
+	}
+	if got := gearTypeToComponentCategory(models.GearTypeRadio); got != "" {
+		t.Errorf("radio should not map to a component, got %s", got)
+	}
+}
@@ -0,0 +1,195 @@
+package aircraft
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/push"
+)
+
+const (
+	// defaultExpirationWindowDays is how far ahead expirations are listed by default.
+	defaultExpirationWindowDays = 60
+	// maxExpirationWindowDays bounds the expirations lookahead.
+	maxExpirationWindowDays = 365
+	// ReminderLeadDays is how long before an expiry date the reminder is sent.
+	ReminderLeadDays = 30
+)
+
+// SetNotifier enables registration and insurance expiry reminders.
+func (s *Service) SetNotifier(notifier push.Notifier) {
+	s.notifier = notifier
+}
+
+// GetRegistration retrieves an aircraft's registration details
+func (s *Service) GetRegistration(ctx context.Context, aircraftID string, userID string) (*models.AircraftRegistration, error) {
+	aircraft, err := s.store.Get(ctx, aircraftID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if aircraft == nil {
+		return nil, &ServiceError{Message: "aircraft not found"}
+	}
+
+	return s.store.GetRegistration(ctx, aircraftID)
+}
+
+// SetRegistration replaces an aircraft's registration, insurance, and remote
+// ID details. The remote ID module must be an item in the user's inventory.
+func (s *Service) SetRegistration(ctx context.Context, userID string, params models.SetAircraftRegistrationParams) (*models.AircraftRegistration, error) {
+	params.Normalize()
+	if err := validateRegistration(params); err != nil {
+		return nil, err
+	}
+
+	aircraft, err := s.store.Get(ctx, params.AircraftID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if aircraft == nil {
+		return nil, &ServiceError{Message: "aircraft not found"}
+	}
+
+	if params.RemoteIDItemID != "" {
+		item, err := s.inventorySvc.GetItem(ctx, params.RemoteIDItemID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil {
+			return nil, &ServiceError{Message: "remote ID module not found in inventory"}
+		}
+	}
+
+	registration, err := s.store.SetRegistration(ctx, params)
+	if err != nil {
+		s.logger.Error("Failed to set aircraft registration", logging.WithField("error", err.Error()))
+		return nil, err
+	}
+
+	s.logger.Info("Set aircraft registration", logging.WithField("aircraft_id", params.AircraftID))
+	return registration, nil
+}
+
+// ListExpirations lists registration and insurance expiry dates within the
+// next days (default 60), including any that have already passed.
+func (s *Service) ListExpirations(ctx context.Context, userID string, days int, now time.Time) (*models.AircraftExpirationListResponse, error) {
+	if days <= 0 {
+		days = defaultExpirationWindowDays
+	}
+	if days > maxExpirationWindowDays {
+		days = maxExpirationWindowDays
+	}
+
+	today := startOfDay(now)
+	expirations, err := s.store.ListExpirations(ctx, userID, today.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+	for i := range expirations {
+		expirations[i].DaysLeft = daysUntil(expirations[i].ExpiresOn, today)
+	}
+	return &models.AircraftExpirationListResponse{Expirations: expirations}, nil
+}
+
+// SendExpirationReminders notifies owners once about each registration or
+// insurance expiring within ReminderLeadDays, for aircraft with reminders
+// enabled. Returns the number of reminders sent.
+func (s *Service) SendExpirationReminders(ctx context.Context, now time.Time) (int, error) {
+	if s.notifier == nil {
+		return 0, nil
+	}
+
+	today := startOfDay(now)
+	due, err := s.store.ListReminderDue(ctx, today, today.AddDate(0, 0, ReminderLeadDays))
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, expiration := range due {
+		expiration.DaysLeft = daysUntil(expiration.ExpiresOn, today)
+		if err := s.notifier.Notify(ctx, expiration.UserID, expirationNotification(expiration)); err != nil {
+			s.logger.Warn("Failed to send aircraft expiry reminder", logging.WithFields(map[string]interface{}{
+				"aircraft_id": expiration.AircraftID,
+				"error":       err.Error(),
+			}))
+			continue
+		}
+		if err := s.store.MarkReminded(ctx, expiration.AircraftID, expiration.Kind, expiration.ExpiresOn); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+func validateRegistration(params models.SetAircraftRegistrationParams) error {
+	if params.AircraftID == "" {
+		return &ServiceError{Message: "aircraftId is required"}
+	}
+	if len(params.RegistrationNumber) > 64 {
+		return &ServiceError{Message: "registrationNumber must be at most 64 characters"}
+	}
+	if params.Authority != "" && !models.IsValidRegistrationAuthority(params.Authority) {
+		return &ServiceError{Message: "authority must be one of faa, caa, easa, tc, casa, other"}
+	}
+	if len(params.InsuranceProvider) > 255 {
+		return &ServiceError{Message: "insuranceProvider must be at most 255 characters"}
+	}
+	if len(params.InsurancePolicyNumber) > 100 {
+		return &ServiceError{Message: "insurancePolicyNumber must be at most 100 characters"}
+	}
+	if !isComplianceDate(params.RegistrationExpiresOn) {
+		return &ServiceError{Message: "registrationExpiresOn must be a date in YYYY-MM-DD format"}
+	}
+	if !isComplianceDate(params.InsuranceExpiresOn) {
+		return &ServiceError{Message: "insuranceExpiresOn must be a date in YYYY-MM-DD format"}
+	}
+	return nil
+}
+
+// isComplianceDate reports whether value is empty or a YYYY-MM-DD date.
+func isComplianceDate(value string) bool {
+	if value == "" {
+		return true
+	}
+	_, err := time.Parse(models.ComplianceDateLayout, value)
+	return err == nil
+}
+
+// daysUntil returns the whole days from today to a YYYY-MM-DD date.
+func daysUntil(date string, today time.Time) int {
+	expiresOn, err := time.Parse(models.ComplianceDateLayout, date)
+	if err != nil {
+		return 0
+	}
+	return int(expiresOn.Sub(today).Hours() / 24)
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func expirationNotification(expiration models.AircraftExpiration) models.Notification {
+	when := fmt.Sprintf("in %d days", expiration.DaysLeft)
+	switch expiration.DaysLeft {
+	case 0:
+		when = "today"
+	case 1:
+		when = "tomorrow"
+	}
+	return models.Notification{
+		Kind:  models.NotificationAircraftExpiring,
+		Title: fmt.Sprintf("%s %s expires %s", expiration.AircraftName, expiration.Kind, when),
+		Body:  fmt.Sprintf("The %s for %s expires on %s.", expiration.Kind, expiration.AircraftName, expiration.ExpiresOn),
+		Data: map[string]string{
+			"aircraftId": expiration.AircraftID,
+			"kind":       string(expiration.Kind),
+			"expiresOn":  expiration.ExpiresOn,
+		},
+	}
+}
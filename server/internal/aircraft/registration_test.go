@@ -0,0 +1,81 @@
+package aircraft
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+func TestValidateRegistration(t *testing.T) {
+	valid := models.SetAircraftRegistrationParams{
+		AircraftID:            "aircraft-1",
+		RegistrationNumber:    " fa3xxxxxxx ",
+		Authority:             "FAA",
+		RegistrationExpiresOn: "2027-03-01",
+		InsuranceExpiresOn:    "",
+	}
+	valid.Normalize()
+	if err := validateRegistration(valid); err != nil {
+		t.Fatalf("validateRegistration(valid) error = %v", err)
+	}
+	if valid.RegistrationNumber != "FA3XXXXXXX" || valid.Authority != models.RegistrationAuthorityFAA {
+		t.Errorf("normalized = %+v, want uppercased number and lowercased authority", valid)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *models.SetAircraftRegistrationParams)
+	}{
+		{name: "unknown authority", modify: func(p *models.SetAircraftRegistrationParams) { p.Authority = "nasa" }},
+		{name: "bad registration date", modify: func(p *models.SetAircraftRegistrationParams) { p.RegistrationExpiresOn = "03/01/2027" }},
+		{name: "bad insurance date", modify: func(p *models.SetAircraftRegistrationParams) { p.InsuranceExpiresOn = "soon" }},
+		{name: "missing aircraft", modify: func(p *models.SetAircraftRegistrationParams) { p.AircraftID = "" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := valid
+			tt.modify(&params)
+			var svcErr *ServiceError
+			if err := validateRegistration(params); !errors.As(err, &svcErr) {
+				t.Errorf("validateRegistration() error = %v, want ServiceError", err)
+			}
+		})
+	}
+}
+
+func TestExpirationNotification(t *testing.T) {
+	now := time.Date(2026, 10, 17, 22, 30, 0, 0, time.UTC)
+	today := startOfDay(now)
+
+	tests := []struct {
+		expiresOn string
+		daysLeft  int
+		title     string
+	}{
+		{expiresOn: "2026-10-17", daysLeft: 0, title: "Freestyle 5 registration expires today"},
+		{expiresOn: "2026-10-18", daysLeft: 1, title: "Freestyle 5 registration expires tomorrow"},
+		{expiresOn: "2026-11-16", daysLeft: 30, title: "Freestyle 5 registration expires in 30 days"},
+	}
+	for _, tt := range tests {
+		expiration := models.AircraftExpiration{
+			AircraftID:   "aircraft-1",
+			AircraftName: "Freestyle 5",
+			Kind:         models.ComplianceRegistration,
+			ExpiresOn:    tt.expiresOn,
+		}
+		expiration.DaysLeft = daysUntil(expiration.ExpiresOn, today)
+		if expiration.DaysLeft != tt.daysLeft {
+			t.Errorf("daysUntil(%s) = %d, want %d", tt.expiresOn, expiration.DaysLeft, tt.daysLeft)
+		}
+		n := expirationNotification(expiration)
+		if n.Title != tt.title || n.Kind != models.NotificationAircraftExpiring || n.Data["expiresOn"] != tt.expiresOn {
+			t.Errorf("notification = %+v, want title %q", n, tt.title)
+		}
+	}
+
+	if days := daysUntil("2026-10-10", today); days != -7 {
+		t.Errorf("daysUntil(past) = %d, want -7", days)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/johnrirwin/flyingforge/internal/inventory"
 	"github.com/johnrirwin/flyingforge/internal/logging"
 	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/push"
 )
 
 // Service handles aircraft operations
@@ -20,6 +21,8 @@ type Service struct {
 	inventorySvc     inventory.InventoryManager
 	gearCatalogStore *database.GearCatalogStore
 	imageSvc         *images.Service
+	notifier         push.Notifier
+	builds           BuildSource
 	logger           *logging.Logger
 }
 
@@ -310,9 +313,6 @@ func (s *Service) SetImage(ctx context.Context, userID string, params models.Set
 			Status: models.ImageModerationApproved,
 			Reason: "Approved",
 		}
-		if params.ImageType == "" {
-			params.ImageType = http.DetectContentType(asset.ImageBytes)
-		}
 	} else {
 		if len(params.ImageData) == 0 {
 			return nil, &ServiceError{Message: "image data is required"}
@@ -342,9 +342,9 @@ func (s *Service) SetImage(ctx context.Context, userID string, params models.Set
 		}
 	}
 
-	if params.ImageType == "" {
-		params.ImageType = http.DetectContentType(asset.ImageBytes)
-	}
+	// The image pipeline may re-encode uploads, so type the stored bytes rather
+	// than trusting the uploaded type
+	params.ImageType = http.DetectContentType(asset.ImageBytes)
 	if params.ImageType == "" {
 		params.ImageType = "application/octet-stream"
 	}
@@ -0,0 +1,229 @@
+package announcements
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/push"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+	maxTitleLength   = 200
+	maxBodyLength    = 10000
+	// maxPushBodyLength trims the body shown in a push notification
+	maxPushBodyLength = 180
+)
+
+// Store defines the announcement persistence operations
+type Store interface {
+	Create(ctx context.Context, createdBy string, params models.CreateAnnouncementParams) (*models.Announcement, error)
+	Get(ctx context.Context, id string) (*models.Announcement, error)
+	Update(ctx context.Context, id string, params models.UpdateAnnouncementParams) (*models.Announcement, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, params models.AnnouncementListParams) (*models.AnnouncementListResponse, error)
+	ListActive(ctx context.Context, userID string, limit, offset int) (*models.AnnouncementListResponse, error)
+	MarkRead(ctx context.Context, id, userID string) error
+	MarkAllRead(ctx context.Context, userID string) (int, error)
+	ClaimDueNotifications(ctx context.Context) ([]models.Announcement, error)
+	ListNotificationRecipients(ctx context.Context, announcementID string) ([]string, error)
+}
+
+// Service posts release notes and outage notices and tracks who has read them
+type Service struct {
+	store    Store
+	notifier push.Notifier
+	logger   *logging.Logger
+}
+
+// NewService creates a new announcement service
+func NewService(store *database.AnnouncementStore, logger *logging.Logger) *Service {
+	return &Service{store: store, logger: logger}
+}
+
+// SetNotifier enables pushing announcements that ask to notify users.
+func (s *Service) SetNotifier(notifier push.Notifier) {
+	s.notifier = notifier
+}
+
+// Create posts an announcement. One that asks to notify users is pushed by
+// SendDue once it's published.
+func (s *Service) Create(ctx context.Context, adminUserID string, params models.CreateAnnouncementParams) (*models.Announcement, error) {
+	params.Kind = normalizeKind(params.Kind)
+	params.Title = strings.TrimSpace(params.Title)
+	params.Body = strings.TrimSpace(params.Body)
+
+	publishedAt := time.Now()
+	if params.PublishedAt != nil {
+		publishedAt = *params.PublishedAt
+	}
+	if err := validate(params.Kind, params.Title, params.Body, publishedAt, params.ExpiresAt); err != nil {
+		return nil, err
+	}
+	return s.store.Create(ctx, adminUserID, params)
+}
+
+// Get returns an announcement by ID, or nil if it doesn't exist
+func (s *Service) Get(ctx context.Context, id string) (*models.Announcement, error) {
+	return s.store.Get(ctx, id)
+}
+
+// Update edits or reschedules an announcement. Users who already read it
+// keep it marked read.
+func (s *Service) Update(ctx context.Context, id string, params models.UpdateAnnouncementParams) (*models.Announcement, error) {
+	params.Kind = normalizeKind(params.Kind)
+	params.Title = strings.TrimSpace(params.Title)
+	params.Body = strings.TrimSpace(params.Body)
+
+	if params.PublishedAt.IsZero() {
+		return nil, &ServiceError{Message: "publishedAt is required"}
+	}
+	if err := validate(params.Kind, params.Title, params.Body, params.PublishedAt, params.ExpiresAt); err != nil {
+		return nil, err
+	}
+	return s.store.Update(ctx, id, params)
+}
+
+// Delete removes an announcement
+func (s *Service) Delete(ctx context.Context, id string) error {
+	return s.store.Delete(ctx, id)
+}
+
+// List returns announcements for admins, including scheduled and expired ones
+func (s *Service) List(ctx context.Context, params models.AnnouncementListParams) (*models.AnnouncementListResponse, error) {
+	switch params.Status {
+	case "", models.AnnouncementStatusScheduled, models.AnnouncementStatusActive, models.AnnouncementStatusExpired:
+	default:
+		return nil, &ServiceError{Message: "status must be scheduled, active, or expired"}
+	}
+	params.Limit, params.Offset = clampPage(params.Limit, params.Offset)
+	return s.store.List(ctx, params)
+}
+
+// Active returns the announcements users can see. With a userID the
+// response says which ones they've read.
+func (s *Service) Active(ctx context.Context, userID string, limit, offset int) (*models.AnnouncementListResponse, error) {
+	limit, offset = clampPage(limit, offset)
+	return s.store.ListActive(ctx, userID, limit, offset)
+}
+
+// MarkRead marks an announcement read for a user
+func (s *Service) MarkRead(ctx context.Context, id, userID string) error {
+	return s.store.MarkRead(ctx, id, userID)
+}
+
+// MarkAllRead marks every visible announcement read for a user
+func (s *Service) MarkAllRead(ctx context.Context, userID string) (int, error) {
+	return s.store.MarkAllRead(ctx, userID)
+}
+
+// SendDue pushes published announcements that asked to notify users and
+// haven't been sent yet. Each is claimed before sending so it goes out once,
+// even if delivery to some devices fails, and only reaches users of the
+// tenant that posted it. Returns the number sent.
+func (s *Service) SendDue(ctx context.Context) (int, error) {
+	if s.notifier == nil {
+		return 0, nil
+	}
+
+	due, err := s.store.ClaimDueNotifications(ctx)
+	if err != nil || len(due) == 0 {
+		return 0, err
+	}
+
+	for _, announcement := range due {
+		recipients, err := s.store.ListNotificationRecipients(ctx, announcement.ID)
+		if err != nil {
+			s.logger.Error("Failed to list announcement recipients", logging.WithFields(map[string]interface{}{
+				"announcementId": announcement.ID,
+				"error":          err.Error(),
+			}))
+			continue
+		}
+		notification := announcementNotification(announcement)
+		failed := 0
+		for _, userID := range recipients {
+			if err := s.notifier.Notify(ctx, userID, notification); err != nil {
+				failed++
+			}
+		}
+		if failed > 0 {
+			s.logger.Warn("Some announcement notifications failed", logging.WithFields(map[string]interface{}{
+				"announcementId": announcement.ID,
+				"failed":         failed,
+				"recipients":     len(recipients),
+			}))
+		}
+	}
+	return len(due), nil
+}
+
+func announcementNotification(a models.Announcement) models.Notification {
+	body := a.Body
+	if runes := []rune(body); len(runes) > maxPushBodyLength {
+		body = strings.TrimSpace(string(runes[:maxPushBodyLength])) + "…"
+	}
+	return models.Notification{
+		Kind:  models.NotificationAnnouncement,
+		Title: a.Title,
+		Body:  body,
+		Data: map[string]string{
+			"announcementId": a.ID,
+			"kind":           string(a.Kind),
+		},
+	}
+}
+
+func normalizeKind(kind models.AnnouncementKind) models.AnnouncementKind {
+	return models.AnnouncementKind(strings.ToLower(strings.TrimSpace(string(kind))))
+}
+
+func validate(kind models.AnnouncementKind, title, body string, publishedAt time.Time, expiresAt *time.Time) error {
+	if !models.IsValidAnnouncementKind(kind) {
+		return &ServiceError{Message: "kind must be release, outage, or notice"}
+	}
+	if title == "" {
+		return &ServiceError{Message: "title is required"}
+	}
+	if len(title) > maxTitleLength {
+		return &ServiceError{Message: fmt.Sprintf("title must be %d characters or fewer", maxTitleLength)}
+	}
+	if body == "" {
+		return &ServiceError{Message: "body is required"}
+	}
+	if len(body) > maxBodyLength {
+		return &ServiceError{Message: fmt.Sprintf("body must be %d characters or fewer", maxBodyLength)}
+	}
+	if expiresAt != nil && !expiresAt.After(publishedAt) {
+		return &ServiceError{Message: "expiresAt must be after publishedAt"}
+	}
+	return nil
+}
+
+func clampPage(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+// ServiceError represents an announcement request error that should be shown to the client
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,231 @@
+package announcements
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+// mockStore implements the Store interface for testing
+type mockStore struct {
+	created    *models.CreateAnnouncementParams
+	due        []models.Announcement
+	recipients map[string][]string // keyed by announcement ID
+}
+
+func (m *mockStore) Create(ctx context.Context, createdBy string, params models.CreateAnnouncementParams) (*models.Announcement, error) {
+	m.created = &params
+	return &models.Announcement{ID: "announcement-new", Kind: params.Kind, Title: params.Title}, nil
+}
+
+func (m *mockStore) Get(ctx context.Context, id string) (*models.Announcement, error) {
+	return nil, nil
+}
+
+func (m *mockStore) Update(ctx context.Context, id string, params models.UpdateAnnouncementParams) (*models.Announcement, error) {
+	return &models.Announcement{ID: id}, nil
+}
+
+func (m *mockStore) Delete(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m *mockStore) List(ctx context.Context, params models.AnnouncementListParams) (*models.AnnouncementListResponse, error) {
+	return &models.AnnouncementListResponse{}, nil
+}
+
+func (m *mockStore) ListActive(ctx context.Context, userID string, limit, offset int) (*models.AnnouncementListResponse, error) {
+	return &models.AnnouncementListResponse{}, nil
+}
+
+func (m *mockStore) MarkRead(ctx context.Context, id, userID string) error {
+	return nil
+}
+
+func (m *mockStore) MarkAllRead(ctx context.Context, userID string) (int, error) {
+	return 0, nil
+}
+
+func (m *mockStore) ClaimDueNotifications(ctx context.Context) ([]models.Announcement, error) {
+	due := m.due
+	m.due = nil
+	return due, nil
+}
+
+func (m *mockStore) ListNotificationRecipients(ctx context.Context, announcementID string) ([]string, error) {
+	return m.recipients[announcementID], nil
+}
+
+// mockNotifier records notifications and fails for one user
+type mockNotifier struct {
+	sent   map[string][]models.Notification
+	failID string
+}
+
+func (m *mockNotifier) Notify(ctx context.Context, userID string, n models.Notification) error {
+	if userID == m.failID {
+		return errors.New("push failed")
+	}
+	if m.sent == nil {
+		m.sent = make(map[string][]models.Notification)
+	}
+	m.sent[userID] = append(m.sent[userID], n)
+	return nil
+}
+
+func newTestService(store *mockStore) *Service {
+	return &Service{store: store, logger: testutil.NullLogger()}
+}
+
+func TestService_Create_Validation(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		params  models.CreateAnnouncementParams
+		wantErr string
+	}{
+		{
+			name:   "release notes",
+			params: models.CreateAnnouncementParams{Kind: "release", Title: "v2.3", Body: "New build wizard", ExpiresAt: &future},
+		},
+		{
+			name:   "kind normalized",
+			params: models.CreateAnnouncementParams{Kind: " Outage ", Title: "Maintenance", Body: "Down at 02:00 UTC"},
+		},
+		{
+			name:    "unknown kind",
+			params:  models.CreateAnnouncementParams{Kind: "promo", Title: "Sale", Body: "Everything half off"},
+			wantErr: "kind must be release, outage, or notice",
+		},
+		{
+			name:    "missing title",
+			params:  models.CreateAnnouncementParams{Kind: "notice", Title: "  ", Body: "Hello"},
+			wantErr: "title is required",
+		},
+		{
+			name:    "missing body",
+			params:  models.CreateAnnouncementParams{Kind: "notice", Title: "Hello"},
+			wantErr: "body is required",
+		},
+		{
+			name:    "expires before it's published",
+			params:  models.CreateAnnouncementParams{Kind: "notice", Title: "Hello", Body: "Hi", PublishedAt: &future, ExpiresAt: &past},
+			wantErr: "expiresAt must be after publishedAt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockStore{}
+			svc := newTestService(store)
+
+			_, err := svc.Create(context.Background(), "admin-1", tt.params)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				if store.created == nil || !models.IsValidAnnouncementKind(store.created.Kind) {
+					t.Errorf("store received %+v, want normalized params", store.created)
+				}
+				return
+			}
+
+			var svcErr *ServiceError
+			if !errors.As(err, &svcErr) || svcErr.Message != tt.wantErr {
+				t.Errorf("Create() error = %v, want ServiceError %q", err, tt.wantErr)
+			}
+			if store.created != nil {
+				t.Error("store should not be called for invalid params")
+			}
+		})
+	}
+}
+
+func TestService_SendDue(t *testing.T) {
+	outage := models.Announcement{ID: "a1", Kind: models.AnnouncementOutage, Title: "Maintenance tonight", Body: strings.Repeat("x", 300)}
+
+	tests := []struct {
+		name       string
+		due        []models.Announcement
+		recipients map[string][]string
+		notifier   *mockNotifier
+		wantSent   int
+		want       map[string][]string // announcement IDs each user was sent
+	}{
+		{
+			name:       "without a notifier nothing is claimed",
+			due:        []models.Announcement{outage},
+			recipients: map[string][]string{"a1": {"user-1"}},
+		},
+		{
+			name:       "a failing device doesn't stop the others",
+			due:        []models.Announcement{outage},
+			recipients: map[string][]string{"a1": {"user-1", "user-2", "user-3"}},
+			notifier:   &mockNotifier{failID: "user-2"},
+			wantSent:   1,
+			want:       map[string][]string{"user-1": {"a1"}, "user-3": {"a1"}},
+		},
+		{
+			// Each announcement's recipients come from the tenant that posted it
+			name: "only to the posting tenant",
+			due: []models.Announcement{
+				{ID: "default-a", Kind: models.AnnouncementNotice, Title: "Site news"},
+				{ID: "club-a", Kind: models.AnnouncementNotice, Title: "Club field day"},
+			},
+			recipients: map[string][]string{"default-a": {"default-user"}, "club-a": {"club-user"}},
+			notifier:   &mockNotifier{},
+			wantSent:   2,
+			want:       map[string][]string{"default-user": {"default-a"}, "club-user": {"club-a"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockStore{due: tt.due, recipients: tt.recipients}
+			svc := newTestService(store)
+			if tt.notifier != nil {
+				svc.SetNotifier(tt.notifier)
+			}
+
+			sent, err := svc.SendDue(context.Background())
+			if err != nil || sent != tt.wantSent {
+				t.Fatalf("SendDue() = %d, %v; want %d, nil", sent, err, tt.wantSent)
+			}
+			if tt.notifier == nil {
+				if len(store.due) != len(tt.due) {
+					t.Error("SendDue() without a notifier claimed announcements")
+				}
+				return
+			}
+
+			got := make(map[string][]string)
+			for userID, notifications := range tt.notifier.sent {
+				for _, n := range notifications {
+					if n.Kind != models.NotificationAnnouncement {
+						t.Errorf("notification kind = %q, want announcement", n.Kind)
+					}
+					if n.Data["announcementId"] == outage.ID && len([]rune(n.Body)) != maxPushBodyLength+1 {
+						t.Errorf("body length = %d, want trimmed to %d plus an ellipsis", len([]rune(n.Body)), maxPushBodyLength)
+					}
+					got[userID] = append(got[userID], n.Data["announcementId"])
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sent = %v, want %v", got, tt.want)
+			}
+
+			if sent, _ := svc.SendDue(context.Background()); sent != 0 {
+				t.Errorf("second SendDue() = %d, want claimed announcements not sent again", sent)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+// Package apierror defines the machine-readable codes attached to every API
+// error response. Handlers branch on a code instead of matching error text,
+// and clients get a stable value to switch on.
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Code identifies a kind of API error
+type Code string
+
+// Generic codes, one per HTTP status. Errors without a more specific code get
+// the one for their status.
+const (
+	InvalidRequest       Code = "INVALID_REQUEST"
+	InvalidBody          Code = "INVALID_BODY"
+	Unauthorized         Code = "UNAUTHORIZED"
+	Forbidden            Code = "FORBIDDEN"
+	NotFound             Code = "NOT_FOUND"
+	MethodNotAllowed     Code = "METHOD_NOT_ALLOWED"
+	Conflict             Code = "CONFLICT"
+	Gone                 Code = "GONE"
+	PayloadTooLarge      Code = "PAYLOAD_TOO_LARGE"
+	UnsupportedMediaType Code = "UNSUPPORTED_MEDIA_TYPE"
+	Unprocessable        Code = "UNPROCESSABLE"
+	RateLimited          Code = "RATE_LIMITED"
+	Internal             Code = "INTERNAL"
+	Unavailable          Code = "UNAVAILABLE"
+	Timeout              Code = "TIMEOUT"
+)
+
+// Domain codes
+const (
+	InvalidToken             Code = "INVALID_TOKEN"
+	AccountDisabled          Code = "ACCOUNT_DISABLED"
+	AccountSuspended         Code = "ACCOUNT_SUSPENDED"
+	AppealAlreadyOpen        Code = "APPEAL_ALREADY_OPEN"
+	UserNotFound             Code = "USER_NOT_FOUND"
+	NotConfigured            Code = "NOT_CONFIGURED"
+	PolicyAcceptanceRequired Code = "POLICY_ACCEPTANCE_REQUIRED"
+
+	CallsignRequired Code = "CALLSIGN_REQUIRED"
+	CallsignTaken    Code = "CALLSIGN_TAKEN"
+	ProfilePrivate   Code = "PROFILE_PRIVATE"
+	CannotFollowSelf Code = "CANNOT_FOLLOW_SELF"
+
+	CatalogDuplicate Code = "CATALOG_DUPLICATE"
+
+	BuildNotFound      Code = "BUILD_NOT_FOUND"
+	BuildNotPending    Code = "BUILD_NOT_PENDING"
+	BuildPresetInvalid Code = "BUILD_PRESET_INVALID"
+	DraftLimitReached  Code = "DRAFT_LIMIT_REACHED"
+	CaptchaRequired    Code = "CAPTCHA_REQUIRED"
+	TempBuildCapacity  Code = "TEMP_BUILD_CAPACITY"
+
+	BatteryNotFound     Code = "BATTERY_NOT_FOUND"
+	RadioNotFound       Code = "RADIO_NOT_FOUND"
+	RadioBackupNotFound Code = "RADIO_BACKUP_NOT_FOUND"
+	FCConfigNotFound    Code = "FC_CONFIG_NOT_FOUND"
+	BlackboxLogNotFound Code = "BLACKBOX_LOG_NOT_FOUND"
+	AircraftNotFound    Code = "AIRCRAFT_NOT_FOUND"
+
+	ImageRejected      Code = "IMAGE_REJECTED"
+	ImagePendingReview Code = "IMAGE_PENDING_REVIEW"
+	ImageNotApproved   Code = "IMAGE_NOT_APPROVED"
+	ImageInvalid       Code = "IMAGE_INVALID"
+	QuotaExceeded      Code = "QUOTA_EXCEEDED"
+	UploadInvalid      Code = "UPLOAD_INVALID"
+	QueryTooShort      Code = "QUERY_TOO_SHORT"
+)
+
+// Entry describes a code in the catalog
+type Entry struct {
+	Code        Code   `json:"code"`
+	Status      int    `json:"status"`
+	Description string `json:"description"`
+}
+
+// catalog lists every code with the status it's sent with
+var catalog = []Entry{
+	{InvalidRequest, http.StatusBadRequest, "The request is missing a field or has an invalid value"},
+	{InvalidBody, http.StatusBadRequest, "The request body isn't valid JSON or doesn't match the expected shape"},
+	{Unauthorized, http.StatusUnauthorized, "Sign in is required"},
+	{Forbidden, http.StatusForbidden, "The caller isn't allowed to do this"},
+	{NotFound, http.StatusNotFound, "The resource doesn't exist or isn't visible to the caller"},
+	{MethodNotAllowed, http.StatusMethodNotAllowed, "The route doesn't support this method"},
+	{Conflict, http.StatusConflict, "The request conflicts with the resource's current state"},
+	{Gone, http.StatusGone, "The resource no longer exists"},
+	{PayloadTooLarge, http.StatusRequestEntityTooLarge, "The request body is too large"},
+	{UnsupportedMediaType, http.StatusUnsupportedMediaType, "The content type isn't accepted"},
+	{Unprocessable, http.StatusUnprocessableEntity, "The request is well formed but can't be applied"},
+	{RateLimited, http.StatusTooManyRequests, "Too many requests; retry after the Retry-After header"},
+	{Internal, http.StatusInternalServerError, "An unexpected server error"},
+	{Unavailable, http.StatusServiceUnavailable, "A dependency is unavailable; retry later"},
+	{Timeout, http.StatusGatewayTimeout, "An upstream request timed out"},
+
+	{InvalidToken, http.StatusUnauthorized, "The access or refresh token is invalid or expired"},
+	{AccountDisabled, http.StatusForbidden, "The account is disabled"},
+	{AccountSuspended, http.StatusForbidden, "The account is suspended until the time in the account notice"},
+	{AppealAlreadyOpen, http.StatusConflict, "The account already has an appeal waiting for review"},
+	{UserNotFound, http.StatusUnauthorized, "The signed-in account no longer exists"},
+	{NotConfigured, http.StatusConflict, "The feature needs server configuration that isn't set"},
+	{PolicyAcceptanceRequired, http.StatusForbidden, "The current terms and privacy policy must be accepted first"},
+	{CallsignRequired, http.StatusBadRequest, "A call sign must be set first"},
+	{CallsignTaken, http.StatusConflict, "The call sign is already in use"},
+	{ProfilePrivate, http.StatusForbidden, "The pilot's profile is private"},
+	{CannotFollowSelf, http.StatusBadRequest, "Pilots can't follow themselves"},
+	{CatalogDuplicate, http.StatusConflict, "Another catalog item already has this gear type, brand, model and variant"},
+	{BuildNotFound, http.StatusNotFound, "The build doesn't exist or isn't the caller's"},
+	{BuildNotPending, http.StatusBadRequest, "The build isn't pending moderation"},
+	{BuildPresetInvalid, http.StatusBadRequest, "The Betaflight preset couldn't be parsed"},
+	{DraftLimitReached, http.StatusConflict, "The caller has as many active drafts as allowed; archive one first"},
+	{CaptchaRequired, http.StatusForbidden, "A valid captcha response is required in the X-Captcha-Token header"},
+	{TempBuildCapacity, http.StatusServiceUnavailable, "Too many temporary builds are open; sign in or retry later"},
+	{BatteryNotFound, http.StatusNotFound, "The battery doesn't exist or isn't the caller's"},
+	{RadioNotFound, http.StatusNotFound, "The radio doesn't exist or isn't the caller's"},
+	{RadioBackupNotFound, http.StatusNotFound, "The radio backup or its file doesn't exist"},
+	{FCConfigNotFound, http.StatusNotFound, "The flight controller config doesn't exist or isn't the caller's"},
+	{BlackboxLogNotFound, http.StatusNotFound, "The blackbox log or its file doesn't exist"},
+	{AircraftNotFound, http.StatusNotFound, "The aircraft doesn't exist or isn't the caller's"},
+	{ImageRejected, http.StatusUnprocessableEntity, "Image moderation rejected the image"},
+	{ImagePendingReview, http.StatusServiceUnavailable, "The image couldn't be checked right now; retry later"},
+	{ImageNotApproved, http.StatusUnprocessableEntity, "The upload hasn't been approved, or its approval expired"},
+	{ImageInvalid, http.StatusBadRequest, "The image couldn't be read"},
+	{QuotaExceeded, http.StatusForbidden, "The caller's storage quota is used up"},
+	{UploadInvalid, http.StatusBadRequest, "The upload is missing, incomplete or not the caller's"},
+	{QueryTooShort, http.StatusBadRequest, "The search query is too short"},
+}
+
+var statuses = func() map[Code]int {
+	m := make(map[Code]int, len(catalog))
+	for _, entry := range catalog {
+		m[entry.Code] = entry.Status
+	}
+	return m
+}()
+
+// Catalog returns every code with its status and description
+func Catalog() []Entry {
+	entries := make([]Entry, len(catalog))
+	copy(entries, catalog)
+	return entries
+}
+
+// Status returns the HTTP status code is sent with, or 500 for unknown codes
+func Status(code Code) int {
+	if status, ok := statuses[code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// FromStatus returns the generic code for an HTTP status
+func FromStatus(status int) Code {
+	switch status {
+	case http.StatusBadRequest:
+		return InvalidRequest
+	case http.StatusUnauthorized:
+		return Unauthorized
+	case http.StatusForbidden:
+		return Forbidden
+	case http.StatusNotFound:
+		return NotFound
+	case http.StatusMethodNotAllowed:
+		return MethodNotAllowed
+	case http.StatusConflict:
+		return Conflict
+	case http.StatusGone:
+		return Gone
+	case http.StatusRequestEntityTooLarge:
+		return PayloadTooLarge
+	case http.StatusUnsupportedMediaType:
+		return UnsupportedMediaType
+	case http.StatusUnprocessableEntity:
+		return Unprocessable
+	case http.StatusTooManyRequests:
+		return RateLimited
+	case http.StatusServiceUnavailable:
+		return Unavailable
+	case http.StatusGatewayTimeout:
+		return Timeout
+	}
+	if status >= 400 && status < 500 {
+		return InvalidRequest
+	}
+	return Internal
+}
+
+// Coder is implemented by errors that carry a code. Service error types
+// implement it so handlers can pick a response without reading the message.
+type Coder interface {
+	ErrorCode() Code
+}
+
+// Error is an error with a code
+type Error struct {
+	Code    Code
+	Message string
+}
+
+// New returns an error with a code
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// ErrorCode returns the error's code
+func (e *Error) ErrorCode() Code {
+	return e.Code
+}
+
+// CodeOf returns the code carried by err or any error it wraps, or "" when
+// there is none
+func CodeOf(err error) Code {
+	var coder Coder
+	if errors.As(err, &coder) {
+		return coder.ErrorCode()
+	}
+	return ""
+}
+
+// StatusOf returns the status for err's code, or fallback when err carries
+// no code
+func StatusOf(err error, fallback int) int {
+	if code := CodeOf(err); code != "" {
+		return Status(code)
+	}
+	return fallback
+}
+
+// Body is the JSON shape of an error response. Message repeats Error for
+// clients that read either field.
+type Body struct {
+	Code    Code   `json:"code"`
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// Write writes an error response
+func Write(w http.ResponseWriter, status int, code Code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(Body{Code: code, Error: message, Message: message})
+}
+
+// WriteStatus writes an error response with the generic code for status
+func WriteStatus(w http.ResponseWriter, status int, message string) {
+	Write(w, status, FromStatus(status), message)
+}
@@ -0,0 +1,82 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCatalog(t *testing.T) {
+	seen := make(map[Code]bool)
+	for _, entry := range Catalog() {
+		if seen[entry.Code] {
+			t.Errorf("%s is in the catalog twice", entry.Code)
+		}
+		seen[entry.Code] = true
+		if entry.Status < 400 || entry.Status > 599 {
+			t.Errorf("%s status = %d, want an error status", entry.Code, entry.Status)
+		}
+		if entry.Description == "" {
+			t.Errorf("%s has no description", entry.Code)
+		}
+	}
+
+	// Every generic code is in the catalog with the status it stands for
+	for _, status := range []int{400, 401, 403, 404, 405, 409, 410, 413, 415, 422, 429, 500, 503, 504} {
+		code := FromStatus(status)
+		if got := Status(code); got != status {
+			t.Errorf("Status(FromStatus(%d)) = %d", status, got)
+		}
+	}
+}
+
+func TestFromStatus_Fallbacks(t *testing.T) {
+	if got := FromStatus(http.StatusTeapot); got != InvalidRequest {
+		t.Errorf("FromStatus(418) = %s, want %s", got, InvalidRequest)
+	}
+	if got := FromStatus(http.StatusBadGateway); got != Internal {
+		t.Errorf("FromStatus(502) = %s, want %s", got, Internal)
+	}
+	if got := Status("NOT_A_CODE"); got != http.StatusInternalServerError {
+		t.Errorf("Status(unknown) = %d, want 500", got)
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	err := fmt.Errorf("loading build: %w", New(BuildNotFound, "build not found"))
+
+	if got := CodeOf(err); got != BuildNotFound {
+		t.Errorf("CodeOf(wrapped) = %q, want %q", got, BuildNotFound)
+	}
+	if got := StatusOf(err, http.StatusBadRequest); got != http.StatusNotFound {
+		t.Errorf("StatusOf(wrapped) = %d, want 404", got)
+	}
+	if got := CodeOf(errors.New("plain")); got != "" {
+		t.Errorf("CodeOf(plain) = %q, want none", got)
+	}
+	if got := StatusOf(errors.New("plain"), http.StatusBadRequest); got != http.StatusBadRequest {
+		t.Errorf("StatusOf(plain) = %d, want the fallback", got)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Write(rec, http.StatusConflict, CallsignTaken, "this callsign is already in use")
+
+	if rec.Code != http.StatusConflict {
+		t.Errorf("status = %d, want 409", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	var body Body
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body %q: %v", rec.Body.String(), err)
+	}
+	if body.Code != CallsignTaken || body.Error != "this callsign is already in use" || body.Message != body.Error {
+		t.Errorf("body = %+v", body)
+	}
+}
@@ -3,58 +3,138 @@ package app
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/http"
 	"time"
 
 	"github.com/johnrirwin/flyingforge/internal/aggregator"
 	"github.com/johnrirwin/flyingforge/internal/aircraft"
+	"github.com/johnrirwin/flyingforge/internal/announcements"
+	"github.com/johnrirwin/flyingforge/internal/appeals"
 	"github.com/johnrirwin/flyingforge/internal/auth"
 	"github.com/johnrirwin/flyingforge/internal/battery"
+	"github.com/johnrirwin/flyingforge/internal/blackbox"
+	"github.com/johnrirwin/flyingforge/internal/blobstore"
 	"github.com/johnrirwin/flyingforge/internal/builds"
 	"github.com/johnrirwin/flyingforge/internal/cache"
+	"github.com/johnrirwin/flyingforge/internal/captcha"
+	"github.com/johnrirwin/flyingforge/internal/catalogrules"
 	"github.com/johnrirwin/flyingforge/internal/config"
 	"github.com/johnrirwin/flyingforge/internal/crypto"
 	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/domainevents"
+	"github.com/johnrirwin/flyingforge/internal/ecosystems"
 	"github.com/johnrirwin/flyingforge/internal/equipment"
+	"github.com/johnrirwin/flyingforge/internal/events"
+	"github.com/johnrirwin/flyingforge/internal/featured"
+	"github.com/johnrirwin/flyingforge/internal/feedfilter"
+	"github.com/johnrirwin/flyingforge/internal/fwtargets"
+	"github.com/johnrirwin/flyingforge/internal/groups"
+	"github.com/johnrirwin/flyingforge/internal/grpcapi"
+	"github.com/johnrirwin/flyingforge/internal/home"
 	"github.com/johnrirwin/flyingforge/internal/httpapi"
 	"github.com/johnrirwin/flyingforge/internal/images"
+	"github.com/johnrirwin/flyingforge/internal/imagesourcing"
+	"github.com/johnrirwin/flyingforge/internal/importers"
+	"github.com/johnrirwin/flyingforge/internal/inactivity"
 	"github.com/johnrirwin/flyingforge/internal/inventory"
+	"github.com/johnrirwin/flyingforge/internal/linkcheck"
+	"github.com/johnrirwin/flyingforge/internal/linkpreview"
 	"github.com/johnrirwin/flyingforge/internal/logging"
 	"github.com/johnrirwin/flyingforge/internal/mcp"
 	"github.com/johnrirwin/flyingforge/internal/models"
 	"github.com/johnrirwin/flyingforge/internal/moderation"
+	"github.com/johnrirwin/flyingforge/internal/offlinesync"
+	"github.com/johnrirwin/flyingforge/internal/policies"
+	"github.com/johnrirwin/flyingforge/internal/publicurl"
+	"github.com/johnrirwin/flyingforge/internal/push"
 	"github.com/johnrirwin/flyingforge/internal/radio"
 	"github.com/johnrirwin/flyingforge/internal/ratelimit"
+	"github.com/johnrirwin/flyingforge/internal/recommend"
+	"github.com/johnrirwin/flyingforge/internal/reputation"
+	"github.com/johnrirwin/flyingforge/internal/retention"
+	"github.com/johnrirwin/flyingforge/internal/search"
+	"github.com/johnrirwin/flyingforge/internal/secrets"
 	"github.com/johnrirwin/flyingforge/internal/sellers"
+	"github.com/johnrirwin/flyingforge/internal/seo"
+	"github.com/johnrirwin/flyingforge/internal/shortlinks"
 	"github.com/johnrirwin/flyingforge/internal/sources"
 	"github.com/johnrirwin/flyingforge/internal/tagging"
+	"github.com/johnrirwin/flyingforge/internal/tenancy"
+	"github.com/johnrirwin/flyingforge/internal/uploads"
+	"github.com/johnrirwin/flyingforge/internal/videoembed"
+	"github.com/johnrirwin/flyingforge/internal/views"
+	"github.com/johnrirwin/flyingforge/internal/webui"
 )
 
 // App holds all application dependencies
 type App struct {
-	Config           *config.Config
-	Logger           *logging.Logger
-	Cache            cache.Cache
-	Aggregator       *aggregator.Aggregator
-	EquipmentSvc     *equipment.Service
-	InventorySvc     inventory.InventoryManager
-	AircraftSvc      *aircraft.Service
-	BuildSvc         *builds.Service
-	RadioSvc         *radio.Service
-	BatterySvc       *battery.Service
-	AuthService      *auth.Service
-	AuthMiddleware   *auth.Middleware
-	HTTPServer       *httpapi.Server
-	MCPServer        *mcp.Server
-	db               *database.DB
-	userStore        *database.UserStore
-	aircraftStore    *database.AircraftStore
-	fcConfigStore    *database.FCConfigStore
-	inventoryStore   *database.InventoryStore
-	buildStore       *database.BuildStore
-	gearCatalogStore *database.GearCatalogStore
-	imageAssetStore  *database.ImageAssetStore
-	imageSvc         *images.Service
-	refreshLimiter   ratelimit.RateLimiter
+	Config             *config.Config
+	Logger             *logging.Logger
+	Cache              cache.Cache
+	Aggregator         *aggregator.Aggregator
+	EquipmentSvc       *equipment.Service
+	InventorySvc       inventory.InventoryManager
+	AircraftSvc        *aircraft.Service
+	BuildSvc           *builds.Service
+	RadioSvc           *radio.Service
+	UploadSvc          *uploads.Service
+	BlackboxSvc        *blackbox.Service
+	ViewSvc            *views.Service
+	LinkCheckSvc       *linkcheck.Service
+	FeedFilterSvc      *feedfilter.Service
+	BatterySvc         *battery.Service
+	SyncSvc            *offlinesync.Service
+	FeaturedSvc        *featured.Service
+	HomeSvc            *home.Service
+	AnnouncementSvc    *announcements.Service
+	PolicySvc          *policies.Service
+	RetentionSvc       *retention.Service
+	InactivitySvc      *inactivity.Service
+	AppealSvc          *appeals.Service
+	RecommendSvc       *recommend.Service
+	EcosystemSvc       *ecosystems.Service
+	FirmwareTargetSvc  *fwtargets.Service
+	TenancySvc         *tenancy.Service
+	SEOSvc             *seo.Service
+	ShortLinkSvc       *shortlinks.Service
+	GroupSvc           *groups.Service
+	EventSvc           *events.Service
+	ImportSvc          *importers.Service
+	PushSvc            *push.Service
+	AuthService        *auth.Service
+	AuthMiddleware     *auth.Middleware
+	HTTPServer         *httpapi.Server
+	GRPCServer         *grpcapi.Server
+	MCPServer          *mcp.Server
+	db                 *database.DB
+	userStore          *database.UserStore
+	aircraftStore      *database.AircraftStore
+	fcConfigStore      *database.FCConfigStore
+	inventoryStore     *database.InventoryStore
+	attachmentSvc      *inventory.AttachmentService
+	buildStore         *database.BuildStore
+	gearCatalogStore   *database.GearCatalogStore
+	brandStore         *database.BrandStore
+	imageAssetStore    *database.ImageAssetStore
+	imageSvc           *images.Service
+	imageRescanner     *images.Rescanner
+	imageArchiver      *images.Archiver
+	moderationPolicies *moderation.Policies
+	publishRules       *catalogrules.Engine
+	reputationSvc      *reputation.Service
+	moderationClaims   *database.ModerationClaimStore
+	moderationSLA      *moderation.SLAMonitor
+	dbPool             *database.PoolMonitor
+	imageSourcing      *imagesourcing.Service
+	outboxStore        *database.OutboxStore
+	eventRelay         *domainevents.Relay
+	searchSvc          *search.Service
+	blobStore          blobstore.Store
+	fetchLimiter       *ratelimit.Limiter
+	refreshLimiter     ratelimit.RateLimiter
+	secrets            *secrets.Resolver
+	jwtSecretRef       string // reference the JWT secret is re-read from
 }
 
 // New creates and initializes a new App instance
@@ -64,11 +144,18 @@ func New(cfg *config.Config) (*App, error) {
 	// Initialize logger
 	app.Logger = app.initLogger()
 
+	// Resolve secret references (files, Vault, AWS Secrets Manager)
+	if err := app.initSecrets(); err != nil {
+		app.Logger.Error("Failed to resolve secrets", logging.WithField("error", err.Error()))
+		return nil, err
+	}
+
 	// Initialize cache
 	app.Cache = app.initCache()
 
 	// Initialize rate limiter and tagger
 	limiter := ratelimit.New(cfg.Server.RateLimitDur)
+	app.fetchLimiter = limiter
 	tagger := tagging.New()
 
 	// Initialize feed fetchers
@@ -77,6 +164,11 @@ func New(cfg *config.Config) (*App, error) {
 	// Initialize aggregator
 	app.Aggregator = aggregator.New(fetchers, app.Cache, tagger, app.Logger)
 	app.Aggregator.SetRetentionDays(cfg.Server.FeedRetentionDays)
+	app.Aggregator.SetLinkPreviewer(linkpreview.NewService(app.Cache, limiter))
+	app.Aggregator.SetSchedule(aggregator.ScheduleConfig{
+		MinInterval: cfg.Feeds.MinInterval,
+		MaxInterval: cfg.Feeds.MaxInterval,
+	})
 
 	// Initialize seller registry
 	sellerRegistry := app.initSellers(limiter)
@@ -98,6 +190,12 @@ func (a *App) Run(ctx context.Context) error {
 	if a.Config.Server.RefreshOnceMode {
 		return a.runRefreshOnceMode(ctx)
 	}
+	if a.Config.Server.RekeyCatalogMode {
+		return a.runRekeyCatalogMode(ctx)
+	}
+	if a.Config.Server.RotateJWTKeyMode {
+		return a.runRotateJWTKeyMode(ctx)
+	}
 	if a.Config.Server.MCPMode {
 		return a.runMCPMode(ctx)
 	}
@@ -112,6 +210,16 @@ func (a *App) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	if a.GRPCServer != nil {
+		a.GRPCServer.Stop(ctx)
+	}
+
+	if a.eventRelay != nil {
+		if err := a.eventRelay.Close(); err != nil {
+			a.Logger.Error("Event broker close error", logging.WithField("error", err.Error()))
+		}
+	}
+
 	if a.db != nil {
 		if err := a.db.Close(); err != nil {
 			a.Logger.Error("Database close error", logging.WithField("error", err.Error()))
@@ -138,9 +246,15 @@ func (a *App) initCache() cache.Cache {
 	switch a.Config.Cache.Backend {
 	case "redis":
 		a.Logger.Info("Using Redis cache backend", logging.WithField("addr", a.Config.Cache.RedisAddr))
+		encodings, err := cache.ParseEncodings(a.Config.Cache.RedisEncoding)
+		if err != nil {
+			a.Logger.Warn("Invalid CACHE_REDIS_ENCODING, storing plain JSON", logging.WithField("error", err.Error()))
+			encodings = cache.DefaultEncodings()
+		}
 		redisCache, err := cache.NewRedis(cache.RedisConfig{
-			Addr:   a.Config.Cache.RedisAddr,
-			Prefix: "mcp-news:",
+			Addr:      a.Config.Cache.RedisAddr,
+			Prefix:    "mcp-news:",
+			Encodings: encodings,
 		}, a.Config.Cache.TTL)
 		if err != nil {
 			a.Logger.Error("Failed to connect to Redis, falling back to memory cache", logging.WithField("error", err.Error()))
@@ -160,6 +274,7 @@ func (a *App) initCache() cache.Cache {
 
 func (a *App) initFetchers(limiter *ratelimit.Limiter) []sources.Fetcher {
 	fetcherConfig := sources.DefaultConfig()
+	fetcherConfig.Validators = sources.NewValidatorStore(a.Cache)
 
 	// Try to load feeds from config file
 	configPath := sources.FindFeedsConfig()
@@ -194,6 +309,18 @@ func (a *App) initSellers(limiter *ratelimit.Limiter) *sellers.Registry {
 	return registry
 }
 
+// initSecrets replaces secret references in the config with their values.
+// The JWT secret reference is kept so the secret can be re-read while
+// running.
+func (a *App) initSecrets() error {
+	a.jwtSecretRef = a.Config.Auth.JWTSecret
+	a.secrets = secrets.NewResolver(a.Config.Secrets)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return a.secrets.ResolveConfig(ctx, a.Config)
+}
+
 func (a *App) initDatabaseServices() {
 	dbConfig := database.Config{
 		Host:     a.Config.Database.Host,
@@ -222,10 +349,18 @@ func (a *App) initDatabaseServices() {
 	}
 
 	a.db = db
+	// Cache catalog searches in the shared cache so writes on any instance
+	// invalidate them
+	db.SetCache(a.Cache)
+	a.dbPool = database.NewPoolMonitor(db, a.Config.Metrics.PoolWaitAlert)
 	// Persist aggregated feed items in Postgres when available so we can keep history
 	// across refresh runs.
 	if a.Aggregator != nil {
 		a.Aggregator.SetStore(database.NewFeedItemStore(db))
+
+		// Remove spam and off-topic items with admin-managed filters
+		a.FeedFilterSvc = feedfilter.NewService(database.NewFeedFilterStore(db), a.Aggregator, a.Logger)
+		a.Aggregator.SetFilter(a.FeedFilterSvc)
 	}
 
 	// Initialize encryptor for sensitive data
@@ -243,6 +378,7 @@ func (a *App) initDatabaseServices() {
 
 	// Initialize centralized image storage + moderation pipeline
 	a.imageAssetStore = database.NewImageAssetStore(db)
+	a.moderationPolicies = moderation.NewPolicies(database.NewModerationPolicyStore(db), a.Config.Moderation.RejectConfidence, time.Minute)
 	moderatorSvc, err := a.newModerationService()
 	if err != nil {
 		a.Logger.Warn("Image moderation setup failed, uploads will return PENDING_REVIEW",
@@ -257,9 +393,32 @@ func (a *App) initDatabaseServices() {
 		a.Logger.Info("Using in-memory pending upload store")
 	}
 	a.imageSvc = images.NewService(moderatorSvc, a.imageAssetStore, pendingStore, a.Config.Moderation.Timeout)
+	a.imageSvc.SetNormalization(images.NormalizeOptions{
+		MaxDimension: a.Config.Images.MaxDimension,
+		JPEGQuality:  a.Config.Images.JPEGQuality,
+	})
+	a.imageSvc.SetQuota(models.ImageQuota{
+		MaxCount: a.Config.Images.QuotaMaxCount,
+		MaxBytes: a.Config.Images.QuotaMaxBytes,
+	}, a.imageAssetStore)
+	a.imageSvc.SetGallery(database.NewEntityImageStore(db))
+	a.initImageTranscoders()
+	a.initBackgroundRemover()
+	a.outboxStore = database.NewOutboxStore(db)
+	a.imageSvc.SetEvents(a.outboxStore)
+	a.imageRescanner = images.NewRescanner(moderatorSvc, a.imageAssetStore, database.NewImageRescanStore(db),
+		a.Config.Moderation.RescanRate, a.Config.Moderation.Timeout, a.Logger)
+
+	a.attachmentSvc = inventory.NewAttachmentService(database.NewInventoryAttachmentStore(db), a.imageSvc, a.Logger)
 
 	// Initialize gear catalog store (before aircraft, since aircraft contributes to catalog)
 	a.gearCatalogStore = database.NewGearCatalogStore(db)
+	a.brandStore = database.NewBrandStore(db)
+	a.reputationSvc = reputation.NewService(database.NewReputationStore(db), time.Minute)
+	a.publishRules = catalogrules.NewEngine(database.NewCatalogPublishRuleStore(db), time.Minute, a.Logger)
+	a.publishRules.SetReputation(a.reputationSvc)
+	a.moderationClaims = database.NewModerationClaimStore(db)
+	a.imageSourcing = imagesourcing.NewService(a.EquipmentSvc, a.gearCatalogStore, database.NewGearImageCandidateStore(db), a.imageSvc, a.fetchLimiter, a.Logger)
 
 	// Initialize aircraft (with encryption support and gear catalog contribution)
 	a.aircraftStore = database.NewAircraftStore(db, encryptor)
@@ -268,29 +427,189 @@ func (a *App) initDatabaseServices() {
 	// Initialize builds service (public builds + draft/temp builder)
 	a.buildStore = database.NewBuildStore(db)
 	a.BuildSvc = builds.NewService(a.buildStore, a.aircraftStore, a.gearCatalogStore, a.imageSvc, a.Logger)
+	a.BuildSvc.SetTransactor(db)
+	a.BuildSvc.SetGallery(a.imageSvc)
+	a.BuildSvc.SetVideoEmbedder(videoembed.NewService(cache.NewMemory(24*time.Hour), 24*time.Hour, a.Logger))
+	a.BuildSvc.SetPriceLookup(a.EquipmentSvc)
+	a.BuildSvc.SetInventory(a.inventoryStore)
+	a.BuildSvc.SetPresetStore(database.NewBuildPresetStore(db))
+	a.BuildSvc.SetTemplates(database.NewBuildTemplateStore(db), a.gearCatalogStore)
+	a.BuildSvc.SetCardCache(cache.NewMemory(time.Hour))
+	a.BuildSvc.SetDraftLimit(a.Config.Builds.MaxActiveDrafts)
+	a.BuildSvc.SetTempAdmission(a.newTempBuildLimiter(), a.Config.Builds.MaxOutstandingTemp, a.newCaptchaVerifier())
+	a.AircraftSvc.SetBuildSource(a.BuildSvc)
+	a.initSearchIndex()
+	a.initEventRelay()
 
 	// Initialize radio
+	a.blobStore = a.newBlobStore()
+
+	// Archived images are restored on read even with archiving turned off
+	a.imageArchiver = images.NewArchiver(a.imageAssetStore, a.blobStore, a.Config.Images.ArchiveAfter, a.Logger)
+	db.SetImageRestorer(a.imageArchiver)
+	a.imageSvc.SetArchiver(a.imageArchiver)
+
 	radioStore := database.NewRadioStore(db)
-	a.RadioSvc = radio.NewService(radioStore, "", a.Logger) // Empty string uses default storage dir
+	a.RadioSvc = radio.NewService(radioStore, a.blobStore, a.Logger)
+
+	// Initialize resumable uploads
+	a.UploadSvc = uploads.NewService(database.NewUploadStore(db), a.blobStore, a.Config.Storage.UploadExpiry, a.Logger)
+	a.UploadSvc.RegisterHandler(models.UploadPurposeRadioBackup, a.RadioSvc)
+
+	// Initialize blackbox logs
+	a.BlackboxSvc = blackbox.NewService(database.NewBlackboxStore(db), a.aircraftStore, a.blobStore, a.Logger)
+	a.UploadSvc.RegisterHandler(models.UploadPurposeBlackboxLog, a.BlackboxSvc)
+
+	// Initialize view counting; Redis shares the buffer across instances
+	var viewBuffer views.Buffer = views.NewMemoryBuffer()
+	if redisCache, ok := a.Cache.(*cache.RedisCache); ok {
+		viewBuffer = views.NewRedisBuffer(redisCache.Client())
+	}
+	a.ViewSvc = views.NewService(database.NewViewStore(db), viewBuffer, a.Logger)
+
+	// Initialize dead link checking for stored product links
+	a.LinkCheckSvc = linkcheck.NewService(database.NewLinkCheckStore(db), a.EquipmentSvc, a.fetchLimiter, a.Logger)
 
 	// Initialize battery
 	batteryStore := database.NewBatteryStore(db)
 	a.BatterySvc = battery.NewService(batteryStore, a.Logger)
+	a.BatterySvc.SetStorageReminderDays(a.Config.Battery.StorageReminderDays)
+
+	// Initialize offline sync (change feeds over inventory, aircraft, and batteries)
+	a.SyncSvc = offlinesync.NewService(database.NewSyncStore(db), a.InventorySvc, a.AircraftSvc, a.BatterySvc, a.Logger)
 
 	// Initialize auth
 	a.userStore = database.NewUserStore(db)
 	a.AuthService = auth.NewService(a.userStore, a.Config.Auth, a.Logger)
+	if encryptor != nil {
+		// Rotated signing keys live in the database, encrypted like bind phrases
+		a.AuthService.SetKeyStore(database.NewJWTKeyStore(db, encryptor))
+		keyCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := a.AuthService.ReloadKeys(keyCtx); err != nil {
+			a.Logger.Warn("Failed to load JWT signing keys, signing with AUTH_JWT_SECRET", logging.WithField("error", err.Error()))
+		}
+		cancel()
+	}
 	a.AuthMiddleware = auth.NewMiddleware(a.AuthService)
+	a.BuildSvc.SetHandoff(database.NewBuildHandoffStore(db), a.AuthService)
+	a.BuildSvc.SetModerationHistory(database.NewBuildModerationStore(db))
+	a.AuthService.SetHandoffClaimer(a.BuildSvc)
 
 	// Initialize FC config store
 	a.fcConfigStore = database.NewFCConfigStore(db)
 
+	// Initialize push notifications (build approvals, etc.)
+	a.PushSvc = a.newPushService(db)
+	a.BuildSvc.SetNotifier(a.PushSvc)
+	a.AircraftSvc.SetNotifier(a.PushSvc)
+	a.BatterySvc.SetNotifier(a.PushSvc)
+
+	a.moderationSLA = moderation.NewSLAMonitor(database.NewModerationAgingStore(db), map[models.ModerationQueue]time.Duration{
+		models.ModerationQueueGear:  a.Config.Moderation.GearSLA,
+		models.ModerationQueueBuild: a.Config.Moderation.BuildSLA,
+	}, a.Logger)
+	if a.Config.Moderation.SLAEscalationInterval > 0 {
+		a.moderationSLA.SetNotifier(a.PushSvc)
+	}
+	a.FeaturedSvc = featured.NewService(database.NewFeaturedStore(db), a.BuildSvc, a.gearCatalogStore, a.Logger)
+	a.AnnouncementSvc = announcements.NewService(database.NewAnnouncementStore(db), a.Logger)
+	a.AnnouncementSvc.SetNotifier(a.PushSvc)
+	a.HomeSvc = home.NewService(database.NewHomeModuleStore(db), a.FeaturedSvc, a.BuildSvc, a.gearCatalogStore, a.Aggregator, a.AnnouncementSvc, a.Logger)
+	a.PolicySvc = policies.NewService(database.NewPolicyStore(db), a.Logger)
+	a.RetentionSvc = retention.NewService(database.NewRetentionStore(db), a.Logger)
+	a.InactivitySvc = inactivity.NewService(database.NewInactivityStore(db), a.Logger)
+	a.InactivitySvc.SetNotifier(a.PushSvc)
+	a.AppealSvc = appeals.NewService(database.NewAppealStore(db), a.userStore, a.AuthService, a.Logger)
+	a.RecommendSvc = recommend.NewService(database.NewRecommendationStore(db), a.Logger)
+	a.EcosystemSvc = ecosystems.NewService(database.NewEcosystemStore(db), a.Logger)
+	a.FirmwareTargetSvc = fwtargets.NewService(database.NewFirmwareTargetStore(db), a.Config.Firmware.TargetsURL, a.Logger)
+	a.TenancySvc = tenancy.NewService(database.NewTenantStore(db), a.Config.Tenancy.Enabled, a.Logger)
+	if err := a.TenancySvc.Refresh(context.Background()); err != nil {
+		a.Logger.Warn("Failed to load tenants, serving the default tenant", logging.WithField("error", err.Error()))
+	}
+	a.ShortLinkSvc = shortlinks.NewService(database.NewShortLinkStore(db), a.BuildSvc, a.gearCatalogStore, a.Logger)
+	a.BuildSvc.SetShortLinker(a.ShortLinkSvc)
+	groupStore := database.NewGroupStore(db)
+	a.GroupSvc = groups.NewService(groupStore, a.userStore, a.BuildSvc, a.Logger)
+	a.EventSvc = events.NewService(database.NewEventStore(db), groupStore, a.AircraftSvc, a.Logger)
+	a.ImportSvc = importers.NewService(a.gearCatalogStore, a.BuildSvc, a.Logger)
+	a.SEOSvc = seo.NewService(database.NewSitemapStore(db), a.Config.SEO.SiteURL, a.Logger)
+
 	a.Logger.Info("Authentication service initialized")
 }
 
 func (a *App) initServers() {
+	// Serve the embedded web frontend when asked to
+	var webUI http.Handler
+	if a.Config.Server.ServeWeb {
+		handler, err := webui.New()
+		if err != nil {
+			a.Logger.Warn("Web frontend not served", logging.WithField("error", err.Error()))
+		} else {
+			webUI = handler
+			a.Logger.Info("Serving embedded web frontend")
+		}
+	}
+
 	// Initialize HTTP server with auth, aircraft, radio, battery, fc-config, gear-catalog, and profile/pilot support
-	a.HTTPServer = httpapi.New(a.Aggregator, a.EquipmentSvc, a.InventorySvc, a.AircraftSvc, a.BuildSvc, a.RadioSvc, a.BatterySvc, a.AuthService, a.AuthMiddleware, a.userStore, a.aircraftStore, a.fcConfigStore, a.inventoryStore, a.gearCatalogStore, a.imageSvc, a.refreshLimiter, a.Config.Server.EnableManualRefresh, a.Logger)
+	a.HTTPServer = httpapi.New(httpapi.Deps{
+		Aggregator:          a.Aggregator,
+		EquipmentSvc:        a.EquipmentSvc,
+		InventorySvc:        a.InventorySvc,
+		AttachmentSvc:       a.attachmentSvc,
+		AircraftSvc:         a.AircraftSvc,
+		BuildSvc:            a.BuildSvc,
+		RadioSvc:            a.RadioSvc,
+		UploadSvc:           a.UploadSvc,
+		BlackboxSvc:         a.BlackboxSvc,
+		ViewSvc:             a.ViewSvc,
+		BatterySvc:          a.BatterySvc,
+		SyncSvc:             a.SyncSvc,
+		PushSvc:             a.PushSvc,
+		FeaturedSvc:         a.FeaturedSvc,
+		HomeSvc:             a.HomeSvc,
+		AnnouncementSvc:     a.AnnouncementSvc,
+		PolicySvc:           a.PolicySvc,
+		RetentionSvc:        a.RetentionSvc,
+		TenancySvc:          a.TenancySvc,
+		SEOSvc:              a.SEOSvc,
+		ShortLinkSvc:        a.ShortLinkSvc,
+		GroupSvc:            a.GroupSvc,
+		EventSvc:            a.EventSvc,
+		ImportSvc:           a.ImportSvc,
+		AuthSvc:             a.AuthService,
+		AuthMiddleware:      a.AuthMiddleware,
+		UserStore:           a.userStore,
+		AircraftStore:       a.aircraftStore,
+		FCConfigStore:       a.fcConfigStore,
+		InventoryStore:      a.inventoryStore,
+		GearCatalogStore:    a.gearCatalogStore,
+		BrandStore:          a.brandStore,
+		ImageSvc:            a.imageSvc,
+		ImageRescanner:      a.imageRescanner,
+		ModerationPolicies:  a.moderationPolicies,
+		PublishRules:        a.publishRules,
+		ReputationSvc:       a.reputationSvc,
+		ModerationClaims:    a.moderationClaims,
+		ModerationSLA:       a.moderationSLA,
+		ImageSourcing:       a.imageSourcing,
+		LinkCheckSvc:        a.LinkCheckSvc,
+		FeedFilterSvc:       a.FeedFilterSvc,
+		QueryStats:          a.db.QueryStats(),
+		AdminSearch:         database.NewAdminSearchStore(a.db),
+		DBPool:              a.dbPool,
+		InactivitySvc:       a.InactivitySvc,
+		AppealSvc:           a.AppealSvc,
+		RecommendSvc:        a.RecommendSvc,
+		EcosystemSvc:        a.EcosystemSvc,
+		FirmwareTargetSvc:   a.FirmwareTargetSvc,
+		WebUI:               webUI,
+		PublicURL:           publicurl.New(a.Config.Server.PublicBaseURL, a.Config.Server.TrustProxyHeaders),
+		RefreshLimiter:      a.refreshLimiter,
+		EnableManualRefresh: a.Config.Server.EnableManualRefresh,
+		MetricsToken:        a.Config.Metrics.Token,
+		Logger:              a.Logger,
+	})
 
 	// Initialize MCP server
 	mcpHandler := mcp.NewHandler(a.Aggregator, a.EquipmentSvc, a.InventorySvc, a.Logger)
@@ -312,7 +631,186 @@ func (a *App) newModerationService() (images.Moderator, error) {
 	if err != nil {
 		return nil, err
 	}
-	return moderation.NewService(detector, a.Config.Moderation.RejectConfidence), nil
+	svc := moderation.NewService(detector, a.Config.Moderation.RejectConfidence)
+	if a.moderationPolicies != nil {
+		svc.SetPolicies(a.moderationPolicies)
+	}
+	return svc, nil
+}
+
+// initImageTranscoders enables WebP/AVIF image serving for each format whose
+// encoder command is configured. AVIF is preferred since it compresses better.
+func (a *App) initImageTranscoders() {
+	cfg := a.Config.Images
+	formats := []struct {
+		contentType string
+		command     string
+	}{
+		{contentType: "image/avif", command: cfg.AVIFCommand},
+		{contentType: "image/webp", command: cfg.WebPCommand},
+	}
+
+	var transcoders []images.Transcoder
+	for _, f := range formats {
+		if f.command == "" {
+			continue
+		}
+		transcoder, err := images.NewCommandTranscoder(f.contentType, f.command, 10*time.Second, 2)
+		if err != nil {
+			a.Logger.Warn("Image transcoding disabled", logging.WithFields(map[string]interface{}{
+				"format": f.contentType,
+				"error":  err.Error(),
+			}))
+			continue
+		}
+		transcoders = append(transcoders, transcoder)
+		a.Logger.Info("Image transcoding enabled", logging.WithField("format", f.contentType))
+	}
+
+	if len(transcoders) > 0 {
+		// Variants are raw bytes, so they live in process memory rather than Redis
+		a.imageSvc.SetTranscoders(cache.NewMemory(cfg.VariantCacheTTL), transcoders...)
+	}
+}
+
+// initBackgroundRemover enables product shot cleanup for admin image uploads.
+// A local command is preferred over the external API when both are set.
+func (a *App) initBackgroundRemover() {
+	cfg := a.Config.Images
+	switch {
+	case cfg.BackgroundCommand != "":
+		remover, err := images.NewCommandBackgroundRemover(cfg.BackgroundCommand, 20*time.Second)
+		if err != nil {
+			a.Logger.Warn("Image background removal disabled", logging.WithField("error", err.Error()))
+			return
+		}
+		a.imageSvc.SetBackgroundRemover(remover)
+		a.Logger.Info("Image background removal enabled", logging.WithField("remover", "command"))
+	case cfg.BackgroundAPIURL != "":
+		a.imageSvc.SetBackgroundRemover(images.NewHTTPBackgroundRemover(cfg.BackgroundAPIURL, cfg.BackgroundAPIKey, 20*time.Second))
+		a.Logger.Info("Image background removal enabled", logging.WithField("remover", "api"))
+	}
+}
+
+// initSearchIndex routes catalog and build text searches to the configured
+// external search engine
+func (a *App) initSearchIndex() {
+	cfg := a.Config.Search
+	if cfg.Backend == "" {
+		return
+	}
+	backend, err := search.NewBackend(cfg.Backend, cfg.URL, cfg.APIKey, cfg.IndexPrefix)
+	if err != nil {
+		a.Logger.Warn("Search index disabled", logging.WithField("error", err.Error()))
+		return
+	}
+	a.searchSvc = search.NewService(backend, a.gearCatalogStore, a.buildStore, a.Logger)
+	a.gearCatalogStore.SetSearchIndex(a.searchSvc)
+	a.buildStore.SetSearchIndex(a.searchSvc)
+	a.Logger.Info("Search index enabled", logging.WithField("backend", cfg.Backend))
+}
+
+// initEventRelay connects the domain event outbox to the configured broker
+// and the search index. With neither, events stay in the outbox until
+// they're purged.
+func (a *App) initEventRelay() {
+	cfg := a.Config.Events
+	var broker domainevents.Broker
+	if cfg.Broker != "" {
+		var err error
+		broker, err = domainevents.NewBroker(cfg.Broker, cfg.URL)
+		if err != nil {
+			a.Logger.Warn("Domain event publishing disabled", logging.WithField("error", err.Error()))
+			broker = nil
+		} else {
+			a.Logger.Info("Domain event publishing enabled", logging.WithField("broker", cfg.Broker))
+		}
+	}
+	if broker == nil && a.searchSvc == nil {
+		return
+	}
+
+	a.eventRelay = domainevents.NewRelay(a.outboxStore, broker, cfg.Prefix, a.Logger)
+	if a.searchSvc != nil {
+		a.eventRelay.AddHandler(a.searchSvc.HandleEvent)
+	}
+}
+
+// newBlobStore creates the store for large uploads such as radio backups.
+// S3 without a bucket falls back to local disk.
+func (a *App) newBlobStore() blobstore.Store {
+	cfg := a.Config.Storage
+	if cfg.Backend == "s3" {
+		if cfg.S3Bucket != "" && cfg.S3Region != "" {
+			a.Logger.Info("Upload storage: S3", logging.WithField("bucket", cfg.S3Bucket))
+			return blobstore.NewS3(blobstore.S3Config{
+				Bucket:    cfg.S3Bucket,
+				Region:    cfg.S3Region,
+				Endpoint:  cfg.S3Endpoint,
+				Prefix:    cfg.S3Prefix,
+				PathStyle: cfg.S3PathStyle,
+			})
+		}
+		a.Logger.Warn("STORAGE_BACKEND=s3 needs STORAGE_S3_BUCKET and a region, using local storage")
+	}
+	a.Logger.Info("Upload storage: local", logging.WithField("dir", cfg.LocalDir))
+	return blobstore.NewLocal(cfg.LocalDir)
+}
+
+// newTempBuildLimiter limits anonymous temp builds per client IP, shared
+// across instances when Redis is the cache
+func (a *App) newTempBuildLimiter() ratelimit.RateLimiter {
+	cfg := a.Config.Builds
+	if redisCache, ok := a.Cache.(*cache.RedisCache); ok {
+		return ratelimit.NewRedisWindow(redisCache.Client(), "ratelimit:tempbuild:", cfg.TempIPLimit, cfg.TempIPWindow)
+	}
+	return ratelimit.NewWindow(cfg.TempIPLimit, cfg.TempIPWindow)
+}
+
+// newCaptchaVerifier returns the captcha check for anonymous temp builds, or
+// nil when no captcha secret is configured
+func (a *App) newCaptchaVerifier() builds.CaptchaVerifier {
+	cfg := a.Config.Builds
+	if cfg.CaptchaSecret == "" {
+		return nil
+	}
+	a.Logger.Info("Temp build captcha enabled")
+	return captcha.New(cfg.CaptchaVerifyURL, cfg.CaptchaSecret)
+}
+
+// newPushService creates the push service and enables each platform whose
+// credentials are configured
+func (a *App) newPushService(db *database.DB) *push.Service {
+	svc := push.NewService(database.NewPushDeviceStore(db), a.Logger)
+	cfg := a.Config.Push
+
+	if cfg.FCMCredentialsFile != "" {
+		sender, err := push.NewFCMSender(cfg.FCMCredentialsFile)
+		if err != nil {
+			a.Logger.Warn("FCM push disabled", logging.WithField("error", err.Error()))
+		} else {
+			svc.SetSender(models.PushPlatformAndroid, sender)
+			a.Logger.Info("FCM push enabled")
+		}
+	}
+
+	if cfg.APNsKeyFile != "" {
+		sender, err := push.NewAPNsSender(push.APNsConfig{
+			KeyFile: cfg.APNsKeyFile,
+			KeyID:   cfg.APNsKeyID,
+			TeamID:  cfg.APNsTeamID,
+			Topic:   cfg.APNsTopic,
+			Sandbox: cfg.APNsSandbox,
+		})
+		if err != nil {
+			a.Logger.Warn("APNs push disabled", logging.WithField("error", err.Error()))
+		} else {
+			svc.SetSender(models.PushPlatformIOS, sender)
+			a.Logger.Info("APNs push enabled", logging.WithField("sandbox", cfg.APNsSandbox))
+		}
+	}
+
+	return svc
 }
 
 func (a *App) runMCPMode(ctx context.Context) error {
@@ -329,13 +827,114 @@ func (a *App) runMCPMode(ctx context.Context) error {
 func (a *App) runHTTPMode(ctx context.Context) error {
 	a.Logger.Info("Starting HTTP server", logging.WithField("addr", a.Config.Server.HTTPAddr))
 
+	// Background jobs work through every tenant's rows; requests are scoped
+	// to their own tenant by the router
+	ctx = database.AllTenants(ctx)
+
+	if a.Config.GRPC.Enabled {
+		if err := a.startGRPC(); err != nil {
+			return err
+		}
+	}
+
 	if a.BuildSvc != nil {
 		go a.runTempBuildCleanup(ctx)
 	}
+	if a.SEOSvc != nil {
+		go a.runSitemapRegeneration(ctx)
+	}
+	if a.AircraftSvc != nil {
+		go a.runAircraftExpiryReminders(ctx)
+	}
+	if a.BatterySvc != nil {
+		go a.runBatteryStorageReminders(ctx)
+	}
+	if a.moderationSLA != nil && a.Config.Moderation.SLAEscalationInterval > 0 {
+		go a.runModerationSLAEscalation(ctx)
+	}
+	if a.dbPool != nil {
+		go a.runDBPoolCheck(ctx)
+	}
+	if a.AnnouncementSvc != nil {
+		go a.runAnnouncementNotifications(ctx)
+	}
+	if a.RetentionSvc != nil {
+		go a.runRetentionPurge(ctx)
+	}
+	if a.InactivitySvc != nil {
+		go a.runInactiveAccountCleanup(ctx)
+	}
+	if a.RecommendSvc != nil {
+		go a.runRecommendationRefresh(ctx)
+	}
+	if a.EcosystemSvc != nil {
+		go a.runEcosystemLinkRefresh(ctx)
+	}
+	if a.FirmwareTargetSvc != nil && a.FirmwareTargetSvc.SyncEnabled() {
+		go a.runFirmwareTargetSync(ctx)
+	}
+	if a.outboxStore != nil {
+		go a.runDomainEventRelay(ctx)
+	}
+	if a.searchSvc != nil {
+		go a.runSearchReindex(ctx)
+	}
+	if a.UploadSvc != nil {
+		go a.runUploadCleanup(ctx)
+	}
+	if a.imageArchiver != nil {
+		go a.runImageArchive(ctx)
+	}
+	if a.gearCatalogStore != nil {
+		go a.runInventoryDisplaySync(ctx)
+	}
+	if a.LinkCheckSvc != nil {
+		go a.runLinkCheck(ctx)
+	}
+	if a.Config.Feeds.Enabled {
+		go a.runFeedSchedule(ctx)
+	}
+	if a.ViewSvc != nil {
+		go a.runViewFlush(ctx)
+	}
+	if a.TenancySvc != nil && a.TenancySvc.Enabled() {
+		go a.runTenantRefresh(ctx)
+	}
+	if a.AuthService != nil && a.secrets != nil {
+		go a.secrets.Watch(ctx, a.jwtSecretRef, a.Config.Auth.JWTSecret, a.Config.Secrets.RefreshInterval, a.AuthService.SetJWTSecret, a.Logger)
+	}
+	if a.AuthService != nil {
+		go a.runSigningKeyReload(ctx)
+	}
 
 	return a.HTTPServer.Start(a.Config.Server.HTTPAddr)
 }
 
+// startGRPC serves the internal gRPC API in the background. It needs the
+// database-backed catalog and builds, and a token to authenticate callers.
+// The port is bound before returning so a bad GRPC_ADDR stops startup.
+func (a *App) startGRPC() error {
+	if a.gearCatalogStore == nil || a.BuildSvc == nil || a.TenancySvc == nil {
+		return fmt.Errorf("gRPC API needs a database")
+	}
+	server, err := grpcapi.New(a.gearCatalogStore, a.InventorySvc, a.BuildSvc, a.TenancySvc, a.Config.GRPC.Token, a.Logger)
+	if err != nil {
+		return err
+	}
+	lis, err := net.Listen("tcp", a.Config.GRPC.Addr)
+	if err != nil {
+		return fmt.Errorf("listen on GRPC_ADDR %s: %w", a.Config.GRPC.Addr, err)
+	}
+	a.GRPCServer = server
+
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			a.Logger.Error("gRPC API server error", logging.WithField("error", err.Error()))
+		}
+	}()
+	return nil
+}
+
 func (a *App) runRefreshOnceMode(ctx context.Context) error {
 	a.Logger.Info("Running one-shot feed refresh")
 	if err := a.Aggregator.Refresh(ctx); err != nil {
@@ -346,6 +945,74 @@ func (a *App) runRefreshOnceMode(ctx context.Context) error {
 	return nil
 }
 
+func (a *App) runRekeyCatalogMode(ctx context.Context) error {
+	if a.gearCatalogStore == nil {
+		return fmt.Errorf("catalog rekey requires a database connection")
+	}
+
+	a.Logger.Info("Recomputing gear catalog canonical keys", logging.WithField("dryRun", a.Config.Server.RekeyDryRun))
+	report, err := a.gearCatalogStore.RecomputeCanonicalKeys(ctx, a.Config.Server.RekeyDryRun)
+	if err != nil {
+		a.Logger.Error("Catalog rekey failed", logging.WithField("error", err.Error()))
+		return err
+	}
+
+	for _, collision := range report.Collisions {
+		a.Logger.Warn("Canonical key collision",
+			logging.WithFields(map[string]interface{}{
+				"catalogId":       collision.CatalogID,
+				"conflictsWithId": collision.ConflictsWithID,
+				"proposedKey":     collision.ProposedKey,
+			}),
+		)
+	}
+	a.Logger.Info("Catalog rekey complete",
+		logging.WithFields(map[string]interface{}{
+			"dryRun":     report.DryRun,
+			"total":      report.Total,
+			"updated":    report.Updated,
+			"unchanged":  report.Unchanged,
+			"collisions": len(report.Collisions),
+		}),
+	)
+	return nil
+}
+
+// runRotateJWTKeyMode rotates the JWT signing key and exits. Running servers
+// pick the new key up within a minute; tokens signed with the old key stay
+// valid until they expire.
+func (a *App) runRotateJWTKeyMode(ctx context.Context) error {
+	if a.AuthService == nil {
+		return fmt.Errorf("JWT key rotation requires a database connection")
+	}
+
+	key, err := a.AuthService.RotateSigningKey(ctx)
+	if err != nil {
+		a.Logger.Error("JWT key rotation failed", logging.WithField("error", err.Error()))
+		return err
+	}
+	a.Logger.Info("JWT key rotation complete", logging.WithField("kid", key.KID))
+	return nil
+}
+
+// runSigningKeyReload re-reads the JWT signing keys so a rotation on another
+// instance or from the CLI is picked up for signing, not just verification
+func (a *App) runSigningKeyReload(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.AuthService.ReloadKeys(ctx); err != nil {
+				a.Logger.Warn("JWT signing key reload failed", logging.WithField("error", err.Error()))
+			}
+		}
+	}
+}
+
 func (a *App) runTempBuildCleanup(ctx context.Context) {
 	ticker := time.NewTicker(30 * time.Minute)
 	defer ticker.Stop()
@@ -373,3 +1040,597 @@ func (a *App) runTempBuildCleanup(ctx context.Context) {
 		}
 	}
 }
+
+func (a *App) runAircraftExpiryReminders(ctx context.Context) {
+	ticker := time.NewTicker(6 * time.Hour)
+	defer ticker.Stop()
+
+	remind := func() {
+		sent, err := a.AircraftSvc.SendExpirationReminders(ctx, time.Now())
+		if err != nil {
+			a.Logger.Warn("Aircraft expiry reminders failed", logging.WithField("error", err.Error()))
+			return
+		}
+		if sent > 0 {
+			a.Logger.Info("Sent aircraft expiry reminders", logging.WithField("count", sent))
+		}
+	}
+
+	// Run once at startup, then periodically.
+	remind()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			remind()
+		}
+	}
+}
+
+func (a *App) runBatteryStorageReminders(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	remind := func() {
+		sent, err := a.BatterySvc.SendStorageReminders(ctx, time.Now())
+		if err != nil {
+			a.Logger.Warn("Battery storage reminders failed", logging.WithField("error", err.Error()))
+			return
+		}
+		if sent > 0 {
+			a.Logger.Info("Sent battery storage reminders", logging.WithField("count", sent))
+		}
+	}
+
+	// Run once at startup, then periodically.
+	remind()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			remind()
+		}
+	}
+}
+
+func (a *App) runModerationSLAEscalation(ctx context.Context) {
+	ticker := time.NewTicker(a.Config.Moderation.SLAEscalationInterval)
+	defer ticker.Stop()
+
+	escalate := func() {
+		escalated, err := a.moderationSLA.Escalate(ctx, time.Now())
+		if err != nil {
+			a.Logger.Warn("Moderation SLA escalation failed", logging.WithField("error", err.Error()))
+			return
+		}
+		if escalated > 0 {
+			a.Logger.Info("Alerted moderators about overdue items", logging.WithField("count", escalated))
+		}
+	}
+
+	// Run once at startup, then periodically.
+	escalate()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			escalate()
+		}
+	}
+}
+
+// runDBPoolCheck warns when requests start waiting on the connection pools,
+// before pool exhaustion turns into timeouts
+func (a *App) runDBPoolCheck(ctx context.Context) {
+	ticker := time.NewTicker(a.Config.Metrics.PoolCheckInterval)
+	defer ticker.Stop()
+
+	check := func() {
+		for _, alert := range a.dbPool.Check(time.Now()) {
+			a.Logger.Warn("Database connection waits spiked", logging.WithFields(map[string]interface{}{
+				"pool":               alert.Pool,
+				"waits":              alert.Waits,
+				"meanWaitMs":         alert.MeanWaitMs,
+				"thresholdMs":        alert.ThresholdMs,
+				"openConnections":    alert.OpenConnections,
+				"maxOpenConnections": alert.MaxOpenConnections,
+			}))
+		}
+	}
+
+	// Record the starting totals, then compare on every tick
+	check()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
+
+// runTenantRefresh reloads the hostname-to-tenant mapping so tenants added on
+// another instance are served here too
+func (a *App) runTenantRefresh(ctx context.Context) {
+	ticker := time.NewTicker(a.Config.Tenancy.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.TenancySvc.Refresh(ctx); err != nil {
+				a.Logger.Warn("Failed to reload tenants", logging.WithField("error", err.Error()))
+			}
+		}
+	}
+}
+
+// runRecommendationRefresh recomputes catalog item co-occurrence nightly
+func (a *App) runRecommendationRefresh(ctx context.Context) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	refresh := func() {
+		if err := a.RecommendSvc.Recompute(ctx); err != nil {
+			a.Logger.Warn("Recommendation refresh failed", logging.WithField("error", err.Error()))
+		}
+	}
+
+	// Run once at startup, then periodically.
+	refresh()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refresh()
+		}
+	}
+}
+
+// runFirmwareTargetSync downloads the Betaflight target list and links FC
+// configs and catalog boards to it
+func (a *App) runFirmwareTargetSync(ctx context.Context) {
+	ticker := time.NewTicker(a.Config.Firmware.SyncInterval)
+	defer ticker.Stop()
+
+	refresh := func() {
+		if _, err := a.FirmwareTargetSvc.Sync(ctx); err != nil {
+			a.Logger.Warn("Firmware target sync failed", logging.WithField("error", err.Error()))
+		}
+	}
+
+	// Run once at startup, then periodically.
+	refresh()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refresh()
+		}
+	}
+}
+
+// runEcosystemLinkRefresh re-links catalog items to the RC protocol and
+// video system registry, picking up newly added and edited items
+func (a *App) runEcosystemLinkRefresh(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	refresh := func() {
+		if err := a.EcosystemSvc.RefreshLinks(ctx); err != nil {
+			a.Logger.Warn("Ecosystem link refresh failed", logging.WithField("error", err.Error()))
+		}
+	}
+
+	// Run once at startup, then periodically.
+	refresh()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refresh()
+		}
+	}
+}
+
+// runRetentionPurge deletes log data older than each user's retention
+func (a *App) runRetentionPurge(ctx context.Context) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	purge := func() {
+		results, err := a.RetentionSvc.Purge(ctx)
+		for _, result := range results {
+			if result.Deleted > 0 {
+				a.Logger.Info("Purged data past retention", logging.WithFields(map[string]interface{}{
+					"category": result.Category,
+					"users":    result.Users,
+					"deleted":  result.Deleted,
+				}))
+			}
+		}
+		if err != nil {
+			a.Logger.Warn("Retention purge had errors", logging.WithField("error", err.Error()))
+		}
+	}
+
+	// Run once at startup, then periodically.
+	purge()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purge()
+		}
+	}
+}
+
+// runDomainEventRelay publishes outbox events to the broker and purges old
+// ones. Without a broker it only purges, so the outbox doesn't grow without
+// bound.
+func (a *App) runDomainEventRelay(ctx context.Context) {
+	cfg := a.Config.Events
+	ticker := time.NewTicker(cfg.PollInterval)
+	defer ticker.Stop()
+	lastPurge := time.Time{}
+
+	run := func() {
+		if a.eventRelay != nil {
+			if _, err := a.eventRelay.RelayOnce(ctx); err != nil {
+				a.Logger.Warn("Domain event relay failed", logging.WithField("error", err.Error()))
+			}
+		}
+
+		if time.Since(lastPurge) < time.Hour {
+			return
+		}
+		lastPurge = time.Now()
+		cutoff := time.Now().Add(-cfg.RetainPublished)
+		var purged int64
+		var err error
+		if a.eventRelay != nil {
+			purged, err = a.eventRelay.Purge(ctx, cutoff)
+		} else {
+			purged, err = a.outboxStore.PurgeStale(ctx, cutoff)
+		}
+		if err != nil {
+			a.Logger.Warn("Domain event purge failed", logging.WithField("error", err.Error()))
+		} else if purged > 0 {
+			a.Logger.Info("Purged domain events", logging.WithField("count", purged))
+		}
+	}
+
+	// Run once at startup, then periodically.
+	run()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run()
+		}
+	}
+}
+
+// runSearchReindex sets up the search index and rewrites it from Postgres,
+// picking up edits and unpublished content the event stream doesn't carry
+func (a *App) runSearchReindex(ctx context.Context) {
+	ticker := time.NewTicker(a.Config.Search.ReindexInterval)
+	defer ticker.Stop()
+
+	run := func() {
+		if err := a.searchSvc.Setup(ctx); err != nil {
+			a.Logger.Warn("Search index setup failed", logging.WithField("error", err.Error()))
+			return
+		}
+		result, err := a.searchSvc.Reindex(ctx)
+		if err != nil {
+			a.Logger.Warn("Search reindex failed", logging.WithField("error", err.Error()))
+			return
+		}
+		a.Logger.Info("Reindexed search", logging.WithFields(map[string]interface{}{
+			"catalog_items": result.CatalogItems,
+			"builds":        result.Builds,
+		}))
+	}
+
+	// Run once at startup, then periodically.
+	run()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run()
+		}
+	}
+}
+
+// runInactiveAccountCleanup applies the inactive account policy
+func (a *App) runInactiveAccountCleanup(ctx context.Context) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	run := func() {
+		result, err := a.InactivitySvc.Run(ctx, time.Now(), false)
+		if result != nil && (len(result.Flagged) > 0 || len(result.Actioned) > 0) {
+			a.Logger.Info("Applied inactive account policy", logging.WithFields(map[string]interface{}{
+				"flagged":  len(result.Flagged),
+				"notified": result.Notified,
+				"actioned": len(result.Actioned),
+				"action":   result.Action,
+			}))
+		}
+		if err != nil {
+			a.Logger.Warn("Inactive account cleanup failed", logging.WithField("error", err.Error()))
+		}
+	}
+
+	// Run once at startup, then periodically.
+	run()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run()
+		}
+	}
+}
+
+// runUploadCleanup removes resumable uploads that expired before they were
+// finished, along with their chunks
+func (a *App) runUploadCleanup(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	cleanup := func() {
+		removed, err := a.UploadSvc.CleanupExpired(ctx)
+		if err != nil {
+			a.Logger.Warn("Failed to clean up expired uploads", logging.WithField("error", err.Error()))
+		}
+		if removed > 0 {
+			a.Logger.Info("Removed expired uploads", logging.WithField("count", removed))
+		}
+	}
+
+	// Run once at startup, then periodically.
+	cleanup()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cleanup()
+		}
+	}
+}
+
+// runImageArchive moves idle images to cold storage and removes archived
+// copies of images that were restored or deleted
+func (a *App) runImageArchive(ctx context.Context) {
+	ticker := time.NewTicker(a.Config.Images.ArchiveInterval)
+	defer ticker.Stop()
+
+	archive := func() {
+		run, err := a.imageArchiver.Run(ctx)
+		if err != nil {
+			a.Logger.Warn("Image archive run failed", logging.WithField("error", err.Error()))
+		}
+		if run.Archived > 0 || run.OrphansRemoved > 0 {
+			a.Logger.Info("Archived idle images", logging.WithFields(map[string]interface{}{
+				"archived":       run.Archived,
+				"archivedBytes":  run.ArchivedBytes,
+				"orphansRemoved": run.OrphansRemoved,
+			}))
+		}
+	}
+
+	// Run once at startup, then periodically.
+	archive()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			archive()
+		}
+	}
+}
+
+// runInventoryDisplaySync refreshes inventory names and manufacturers copied
+// from catalog items that have since been edited, such as by a brand merge.
+// Admin edits sync their own items straight away.
+func (a *App) runInventoryDisplaySync(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	sync := func() {
+		report, err := a.gearCatalogStore.SyncInventoryDisplayFields(ctx, "", false)
+		if err != nil {
+			a.Logger.Warn("Failed to sync inventory display fields", logging.WithField("error", err.Error()))
+			return
+		}
+		if report.Affected > 0 {
+			a.Logger.Info("Synced inventory display fields", logging.WithField("count", report.Affected))
+		}
+	}
+
+	// Run once at startup, then periodically.
+	sync()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sync()
+		}
+	}
+}
+
+// runViewFlush writes buffered page views to Postgres every minute, and
+// once more on shutdown so the last minute's views aren't lost.
+func (a *App) runViewFlush(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	flush := func(ctx context.Context) {
+		written, err := a.ViewSvc.Flush(ctx)
+		if err != nil {
+			a.Logger.Warn("Failed to flush view counts", logging.WithField("error", err.Error()))
+		}
+		if written > 0 {
+			a.Logger.Debug("Flushed view counts", logging.WithField("count", written))
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(database.AllTenants(context.Background()), 10*time.Second)
+			flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			flush(ctx)
+		}
+	}
+}
+
+// runFeedSchedule fetches feed sources as their schedules come due. Each
+// source sets its own interval, so this only needs to look every minute.
+func (a *App) runFeedSchedule(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	refresh := func() {
+		fetched, err := a.Aggregator.RefreshDue(ctx)
+		if err != nil {
+			a.Logger.Warn("Scheduled feed refresh failed", logging.WithField("error", err.Error()))
+			return
+		}
+		if fetched > 0 {
+			a.Logger.Debug("Refreshed due feed sources", logging.WithField("count", fetched))
+		}
+	}
+
+	// Run once at startup, then periodically.
+	refresh()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refresh()
+		}
+	}
+}
+
+// runLinkCheck checks a batch of stored product links every hour. Each link
+// is rechecked weekly, so batches stay small.
+func (a *App) runLinkCheck(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	check := func() {
+		checked, err := a.LinkCheckSvc.RunOnce(ctx)
+		if err != nil {
+			a.Logger.Warn("Stored link check failed", logging.WithField("error", err.Error()))
+			return
+		}
+		if checked > 0 {
+			a.Logger.Info("Checked stored links", logging.WithField("count", checked))
+		}
+	}
+
+	// Run once at startup, then periodically.
+	check()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
+
+// runAnnouncementNotifications pushes announcements that asked to notify
+// users. Checking every minute lets scheduled ones go out close to their
+// publish time.
+func (a *App) runAnnouncementNotifications(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	send := func() {
+		sent, err := a.AnnouncementSvc.SendDue(ctx)
+		if err != nil {
+			a.Logger.Warn("Announcement notifications failed", logging.WithField("error", err.Error()))
+			return
+		}
+		if sent > 0 {
+			a.Logger.Info("Sent announcement notifications", logging.WithField("count", sent))
+		}
+	}
+
+	// Run once at startup, then periodically.
+	send()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			send()
+		}
+	}
+}
+
+func (a *App) runSitemapRegeneration(ctx context.Context) {
+	ticker := time.NewTicker(a.Config.SEO.SitemapInterval)
+	defer ticker.Stop()
+
+	regenerate := func() {
+		if err := a.SEOSvc.Regenerate(ctx); err != nil {
+			a.Logger.Warn("Sitemap regeneration failed", logging.WithField("error", err.Error()))
+		}
+	}
+
+	// Run once at startup, then periodically.
+	regenerate()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			regenerate()
+		}
+	}
+}
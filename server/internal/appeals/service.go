@@ -0,0 +1,129 @@
+// Package appeals lets disabled and suspended users appeal, and admins work
+// the queue of appeals: reinstating the account or upholding its status.
+package appeals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// Store defines the appeal persistence operations
+type Store interface {
+	Create(ctx context.Context, user *models.User, message string) (*models.AccountAppeal, error)
+	List(ctx context.Context, params models.AppealListParams) (*models.AppealsResponse, error)
+	Resolve(ctx context.Context, id, adminUserID string, params models.ResolveAppealParams) (*models.AccountAppeal, error)
+}
+
+// UserLookup loads the account an appeal is for
+type UserLookup interface {
+	GetByID(ctx context.Context, id string) (*models.User, error)
+}
+
+// TokenVerifier checks the appeal token handed out with a refused sign-in
+type TokenVerifier interface {
+	ParseAppealToken(token string) (string, error)
+}
+
+// Service handles account appeals
+type Service struct {
+	store  Store
+	users  UserLookup
+	tokens TokenVerifier
+	logger *logging.Logger
+}
+
+// NewService creates a new appeals service
+func NewService(store *database.AppealStore, users *database.UserStore, tokens TokenVerifier, logger *logging.Logger) *Service {
+	return &Service{store: store, users: users, tokens: tokens, logger: logger}
+}
+
+// Submit records an appeal from a user who was refused sign-in. The token
+// stands in for a session, since the user can't get one.
+func (s *Service) Submit(ctx context.Context, params models.SubmitAppealParams) (*models.AccountAppeal, error) {
+	if strings.TrimSpace(params.AppealToken) == "" {
+		return nil, &ServiceError{Code: apierror.InvalidRequest, Message: "appealToken is required"}
+	}
+	userID, err := s.tokens.ParseAppealToken(params.AppealToken)
+	if err != nil {
+		return nil, err
+	}
+
+	message := strings.TrimSpace(params.Message)
+	if n := utf8.RuneCountInString(message); n < models.MinAppealMessageLength || n > models.MaxAppealMessageLength {
+		return nil, &ServiceError{Code: apierror.InvalidRequest, Message: fmt.Sprintf("message must be between %d and %d characters", models.MinAppealMessageLength, models.MaxAppealMessageLength)}
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, &ServiceError{Code: apierror.InvalidToken, Message: "invalid or expired appeal token"}
+	}
+	if user.Status != models.UserStatusDisabled && user.Status != models.UserStatusSuspended {
+		return nil, &ServiceError{Code: apierror.InvalidRequest, Message: "account is not disabled or suspended"}
+	}
+
+	appeal, err := s.store.Create(ctx, user, message)
+	if errors.Is(err, database.ErrAppealAlreadyOpen) {
+		return nil, &ServiceError{Code: apierror.AppealAlreadyOpen, Message: "an appeal for this account is already waiting for review"}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Account appeal submitted", logging.WithFields(map[string]interface{}{
+		"appealId": appeal.ID,
+		"userId":   user.ID,
+		"status":   user.Status,
+	}))
+	return appeal, nil
+}
+
+// List returns a page of the appeal queue
+func (s *Service) List(ctx context.Context, params models.AppealListParams) (*models.AppealsResponse, error) {
+	if params.Status != "" && !models.IsValidAppealStatus(params.Status) {
+		return nil, &ServiceError{Code: apierror.InvalidRequest, Message: "status must be open, reinstated, or upheld"}
+	}
+	return s.store.List(ctx, params)
+}
+
+// Resolve closes an open appeal. Reinstating makes the account active again.
+func (s *Service) Resolve(ctx context.Context, id, adminUserID string, params models.ResolveAppealParams) (*models.AccountAppeal, error) {
+	if params.Decision != models.AppealStatusReinstated && params.Decision != models.AppealStatusUpheld {
+		return nil, &ServiceError{Code: apierror.InvalidRequest, Message: "decision must be reinstated or upheld"}
+	}
+	params.Note = strings.TrimSpace(params.Note)
+
+	appeal, err := s.store.Resolve(ctx, id, adminUserID, params)
+	if errors.Is(err, database.ErrAppealNotFound) {
+		return nil, &ServiceError{Code: apierror.NotFound, Message: "appeal not found or already resolved"}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return appeal, nil
+}
+
+// ServiceError represents an appeal request error that should be shown to the client
+type ServiceError struct {
+	Code    apierror.Code
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
+
+// ErrorCode returns the error's API error code
+func (e *ServiceError) ErrorCode() apierror.Code {
+	return e.Code
+}
@@ -0,0 +1,164 @@
+package appeals
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+// mockStore keeps appeals in memory, one open appeal per user
+type mockStore struct {
+	appeals  []models.AccountAppeal
+	resolved models.ResolveAppealParams
+}
+
+func (m *mockStore) Create(ctx context.Context, user *models.User, message string) (*models.AccountAppeal, error) {
+	for _, a := range m.appeals {
+		if a.UserID == user.ID && a.Status == models.AppealStatusOpen {
+			return nil, database.ErrAppealAlreadyOpen
+		}
+	}
+	appeal := models.AccountAppeal{ID: "appeal-1", UserID: user.ID, UserStatus: user.Status, StatusReason: user.StatusReason, Message: message, Status: models.AppealStatusOpen}
+	m.appeals = append(m.appeals, appeal)
+	return &appeal, nil
+}
+
+func (m *mockStore) List(ctx context.Context, params models.AppealListParams) (*models.AppealsResponse, error) {
+	return &models.AppealsResponse{Appeals: m.appeals, TotalCount: len(m.appeals)}, nil
+}
+
+func (m *mockStore) Resolve(ctx context.Context, id, adminUserID string, params models.ResolveAppealParams) (*models.AccountAppeal, error) {
+	for i := range m.appeals {
+		if m.appeals[i].ID == id && m.appeals[i].Status == models.AppealStatusOpen {
+			m.resolved = params
+			m.appeals[i].Status = params.Decision
+			m.appeals[i].ResolvedBy = adminUserID
+			return &m.appeals[i], nil
+		}
+	}
+	return nil, database.ErrAppealNotFound
+}
+
+type mockUsers map[string]*models.User
+
+func (m mockUsers) GetByID(ctx context.Context, id string) (*models.User, error) {
+	return m[id], nil
+}
+
+// mockTokens treats "token-<userID>" as a valid appeal token
+type mockTokens struct{}
+
+func (mockTokens) ParseAppealToken(token string) (string, error) {
+	if !strings.HasPrefix(token, "token-") {
+		return "", errors.New("invalid appeal token")
+	}
+	return strings.TrimPrefix(token, "token-"), nil
+}
+
+func newTestService(store *mockStore) *Service {
+	users := mockUsers{
+		"suspended": {ID: "suspended", Status: models.UserStatusSuspended, StatusReason: "spam"},
+		"active":    {ID: "active", Status: models.UserStatusActive},
+	}
+	return &Service{store: store, users: users, tokens: mockTokens{}, logger: testutil.NullLogger()}
+}
+
+func TestSubmit(t *testing.T) {
+	store := &mockStore{}
+	svc := newTestService(store)
+	ctx := context.Background()
+	message := "I posted the same build twice by mistake"
+
+	appeal, err := svc.Submit(ctx, models.SubmitAppealParams{AppealToken: "token-suspended", Message: "  " + message + "  "})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if appeal.Message != message || appeal.UserStatus != models.UserStatusSuspended || appeal.StatusReason != "spam" {
+		t.Errorf("appeal = %+v", appeal)
+	}
+
+	_, err = svc.Submit(ctx, models.SubmitAppealParams{AppealToken: "token-suspended", Message: message})
+	if apierror.CodeOf(err) != apierror.AppealAlreadyOpen {
+		t.Errorf("second appeal error = %v, want %s", err, apierror.AppealAlreadyOpen)
+	}
+
+	tests := []struct {
+		name   string
+		params models.SubmitAppealParams
+	}{
+		{"no token", models.SubmitAppealParams{Message: message}},
+		{"bad token", models.SubmitAppealParams{AppealToken: "forged", Message: message}},
+		{"short message", models.SubmitAppealParams{AppealToken: "token-suspended", Message: "sorry"}},
+		{"active account", models.SubmitAppealParams{AppealToken: "token-active", Message: message}},
+		{"unknown account", models.SubmitAppealParams{AppealToken: "token-gone", Message: message}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.Submit(ctx, tt.params); err == nil {
+				t.Error("Submit() succeeded, want an error")
+			}
+		})
+	}
+	if len(store.appeals) != 1 {
+		t.Errorf("%d appeals stored, want 1", len(store.appeals))
+	}
+}
+
+func TestResolve(t *testing.T) {
+	store := &mockStore{appeals: []models.AccountAppeal{{ID: "appeal-1", UserID: "suspended", Status: models.AppealStatusOpen}}}
+	svc := newTestService(store)
+	ctx := context.Background()
+
+	// Steps run in order against the one open appeal
+	steps := []struct {
+		name    string
+		params  models.ResolveAppealParams
+		wantErr func(error) bool // nil for success
+	}{
+		{
+			name:   "reopening",
+			params: models.ResolveAppealParams{Decision: models.AppealStatusOpen},
+			wantErr: func(err error) bool {
+				var svcErr *ServiceError
+				return errors.As(err, &svcErr)
+			},
+		},
+		{
+			name:   "reinstating",
+			params: models.ResolveAppealParams{Decision: models.AppealStatusReinstated, Note: " welcome back "},
+		},
+		{
+			name:    "resolving twice",
+			params:  models.ResolveAppealParams{Decision: models.AppealStatusUpheld},
+			wantErr: func(err error) bool { return apierror.CodeOf(err) == apierror.NotFound },
+		},
+	}
+	for _, step := range steps {
+		appeal, err := svc.Resolve(ctx, "appeal-1", "admin-1", step.params)
+		if step.wantErr != nil {
+			if !step.wantErr(err) {
+				t.Errorf("%s: unexpected error %v", step.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: Resolve() error = %v", step.name, err)
+		}
+		if appeal.Status != step.params.Decision || appeal.ResolvedBy != "admin-1" || store.resolved.Note != strings.TrimSpace(step.params.Note) {
+			t.Errorf("%s: appeal = %+v, params = %+v", step.name, appeal, store.resolved)
+		}
+	}
+}
+
+func TestList_RejectsUnknownStatus(t *testing.T) {
+	svc := newTestService(&mockStore{})
+	if _, err := svc.List(context.Background(), models.AppealListParams{Status: "pending"}); err == nil {
+		t.Error("List() accepted an unknown status")
+	}
+}
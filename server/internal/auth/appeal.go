@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// appealAudience keeps appeal tokens from being accepted as access tokens
+// and the other way round
+const appealAudience = "flyingforge-account-appeal"
+
+// appealTokenTTL is how long a user has to appeal after a refused sign-in.
+// Retired signing keys are kept this long, so rotating the key doesn't
+// break appeal links that are still valid.
+const appealTokenTTL = 7 * 24 * time.Hour
+
+// statusError refuses sign-in for an account that isn't active. Disabled
+// and suspended users get the admin's reason, when a suspension ends, and a
+// token to submit an appeal with, since they can't get a session.
+func (s *Service) statusError(user *models.User) *AuthError {
+	if user.Status != models.UserStatusDisabled && user.Status != models.UserStatusSuspended {
+		return &AuthError{Code: apierror.AccountDisabled, Message: "account is disabled"}
+	}
+
+	code, message := apierror.AccountDisabled, "account is disabled"
+	if user.Status == models.UserStatusSuspended {
+		code, message = apierror.AccountSuspended, "account is suspended"
+	}
+	notice := &models.AccountStatusNotice{
+		Status:    user.Status,
+		Reason:    user.StatusReason,
+		ExpiresAt: user.StatusExpiresAt,
+	}
+
+	token, err := s.IssueAppealToken(user.ID, time.Now().Add(appealTokenTTL))
+	if err != nil {
+		s.logger.Warn("Failed to issue appeal token", logging.WithField("error", err.Error()))
+	}
+	notice.AppealToken = token
+
+	return &AuthError{Code: code, Message: message, Account: notice}
+}
+
+// IssueAppealToken signs a token that lets a disabled or suspended user
+// appeal, with the current signing key. Expiry is capped at appealTokenTTL
+// from now.
+func (s *Service) IssueAppealToken(userID string, expiresAt time.Time) (string, error) {
+	if latest := time.Now().Add(appealTokenTTL); expiresAt.After(latest) {
+		expiresAt = latest
+	}
+	claims := jwt.MapClaims{
+		"sub": userID,
+		"iss": s.config.JWTIssuer,
+		"aud": appealAudience,
+		"iat": time.Now().Unix(),
+		"exp": expiresAt.Unix(),
+	}
+
+	key := s.signingKey()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = key.id
+	signed, err := token.SignedString(key.secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign appeal token: %w", err)
+	}
+	return signed, nil
+}
+
+// ParseAppealToken verifies an appeal token and returns the user it names
+func (s *Service) ParseAppealToken(tokenString string) (string, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		return s.verificationKey(kid, appealTokenTTL)
+	}, jwt.WithIssuer(s.config.JWTIssuer), jwt.WithAudience(appealAudience), jwt.WithExpirationRequired())
+	if err != nil {
+		return "", &AuthError{Code: apierror.InvalidToken, Message: "invalid or expired appeal token"}
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return "", &AuthError{Code: apierror.InvalidToken, Message: "invalid appeal token claims"}
+	}
+	userID, _ := claims["sub"].(string)
+	if userID == "" {
+		return "", &AuthError{Code: apierror.InvalidToken, Message: "appeal token has no user"}
+	}
+	return userID, nil
+}
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// handoffAudience keeps handoff tokens from being accepted as access tokens
+// and the other way round
+const handoffAudience = "flyingforge-build-handoff"
+
+// handoffTokenTTL is the longest a handoff token lives. Retired signing keys
+// are kept at least this long, so rotating the key doesn't break handoffs
+// that are still pending.
+const handoffTokenTTL = time.Hour
+
+// HandoffClaimer gives the builds of an anonymous session to an account
+type HandoffClaimer interface {
+	ClaimHandoff(ctx context.Context, sessionID, userID string) (*models.BuildHandoffResult, error)
+}
+
+// SetHandoffClaimer enables claiming anonymous temp builds at sign-in
+func (s *Service) SetHandoffClaimer(claimer HandoffClaimer) {
+	s.handoffs = claimer
+}
+
+// IssueHandoffToken signs a token naming an anonymous session with the
+// current signing key. Expiry is capped at handoffTokenTTL from now.
+func (s *Service) IssueHandoffToken(sessionID string, expiresAt time.Time) (string, error) {
+	if latest := time.Now().Add(handoffTokenTTL); expiresAt.After(latest) {
+		expiresAt = latest
+	}
+	claims := jwt.MapClaims{
+		"sid": sessionID,
+		"iss": s.config.JWTIssuer,
+		"aud": handoffAudience,
+		"iat": time.Now().Unix(),
+		"exp": expiresAt.Unix(),
+	}
+
+	key := s.signingKey()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = key.id
+	signed, err := token.SignedString(key.secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign handoff token: %w", err)
+	}
+	return signed, nil
+}
+
+// parseHandoffToken verifies a handoff token and returns its session ID
+func (s *Service) parseHandoffToken(tokenString string) (string, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		return s.verificationKey(kid, handoffTokenTTL)
+	}, jwt.WithIssuer(s.config.JWTIssuer), jwt.WithAudience(handoffAudience), jwt.WithExpirationRequired())
+	if err != nil {
+		return "", fmt.Errorf("invalid handoff token: %w", err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return "", fmt.Errorf("invalid handoff token claims")
+	}
+	sessionID, _ := claims["sid"].(string)
+	if sessionID == "" {
+		return "", fmt.Errorf("handoff token has no session")
+	}
+	return sessionID, nil
+}
+
+// claimHandoff claims the builds named by a handoff token for user. Sign-in
+// goes ahead whatever happens here; the builds just stay anonymous.
+func (s *Service) claimHandoff(ctx context.Context, handoffToken string, user *models.User) *models.BuildHandoffResult {
+	if handoffToken == "" || s.handoffs == nil {
+		return nil
+	}
+	sessionID, err := s.parseHandoffToken(handoffToken)
+	if err != nil {
+		s.logger.Warn("Ignoring build handoff token", logging.WithField("error", err.Error()))
+		return nil
+	}
+	result, err := s.handoffs.ClaimHandoff(ctx, sessionID, user.ID)
+	if err != nil {
+		s.logger.Warn("Build handoff failed", logging.WithFields(map[string]interface{}{
+			"userId": user.ID,
+			"error":  err.Error(),
+		}))
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,274 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// keyReloadInterval limits how often a token with an unknown kid makes the
+// service re-read the key store
+const keyReloadInterval = 10 * time.Second
+
+// ErrNoKeyStore is returned when rotating keys without a key store
+var ErrNoKeyStore = errors.New("JWT signing keys are not stored in the database")
+
+// KeyStore persists signing keys so every instance signs with the same
+// current key and verifies tokens signed with recently retired ones
+type KeyStore interface {
+	ListUsable(ctx context.Context) ([]models.JWTSigningKey, error)
+	Rotate(ctx context.Context, next models.JWTSigningKey, outgoing []models.JWTSigningKey, retireUntil time.Time) error
+}
+
+// signingKey is a JWT secret and the ID tokens signed with it carry in their
+// kid header. The ID is derived from the secret, so every instance reading
+// the same secret agrees on it.
+type signingKey struct {
+	id         string
+	secret     []byte
+	retiredAt  time.Time // when the key stopped signing; zero for the current key
+	validUntil time.Time // when a retired key stops verifying tokens
+}
+
+// retiredKeyTTL is how long a retired key is kept: long enough to verify
+// every token it could have signed. Each kind of token is only accepted
+// for its own lifetime after the key retired, so keeping keys for appeal
+// and handoff tokens doesn't let old access tokens through.
+func (s *Service) retiredKeyTTL() time.Duration {
+	ttl := s.config.AccessTokenTTL
+	for _, tokenTTL := range []time.Duration{handoffTokenTTL, appealTokenTTL} {
+		if tokenTTL > ttl {
+			ttl = tokenTTL
+		}
+	}
+	return ttl
+}
+
+func newSigningKey(secret string) signingKey {
+	sum := sha256.Sum256([]byte("flyingforge-kid:" + secret))
+	return signingKey{id: hex.EncodeToString(sum[:8]), secret: []byte(secret)}
+}
+
+// SetKeyStore enables rotating signing keys through the database. Call
+// ReloadKeys afterwards to pick up keys rotated before this process started.
+func (s *Service) SetKeyStore(store KeyStore) {
+	s.keysMu.Lock()
+	defer s.keysMu.Unlock()
+	s.keyStore = store
+}
+
+// ReloadKeys reads the signing keys from the key store. Until the first
+// rotation the store is empty and the configured secret keeps signing.
+func (s *Service) ReloadKeys(ctx context.Context) error {
+	s.keysMu.RLock()
+	store := s.keyStore
+	s.keysMu.RUnlock()
+	if store == nil {
+		return nil
+	}
+
+	keys, err := store.ListUsable(ctx)
+
+	s.keysMu.Lock()
+	defer s.keysMu.Unlock()
+	s.lastReload = time.Now()
+	if err != nil {
+		return fmt.Errorf("failed to load JWT signing keys: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	var signing signingKey
+	var retired []signingKey
+	for _, k := range keys {
+		key := signingKey{id: k.KID, secret: []byte(k.Secret)}
+		if k.Current && signing.id == "" {
+			signing = key
+			continue
+		}
+		if k.RetiredAt != nil {
+			key.retiredAt = *k.RetiredAt
+		}
+		if k.ExpiresAt != nil {
+			key.validUntil = *k.ExpiresAt
+		}
+		retired = append(retired, key)
+	}
+	if signing.id == "" {
+		// Every key is retired, which only happens if rows were edited by
+		// hand; keep signing with what we have rather than stop logging in
+		return fmt.Errorf("no current JWT signing key in the database")
+	}
+	s.signing = signing
+	s.retired = retired
+	s.storedKeys = true
+	return nil
+}
+
+// RotateSigningKey creates a new random signing key and makes it current
+// on every instance. Tokens signed with the old key stay valid until they
+// expire, so nobody is signed out.
+func (s *Service) RotateSigningKey(ctx context.Context) (*models.JWTSigningKey, error) {
+	s.keysMu.RLock()
+	store := s.keyStore
+	var outgoing []models.JWTSigningKey
+	if !s.storedKeys {
+		// The configured secret signed every token issued so far
+		outgoing = append(outgoing, models.JWTSigningKey{KID: s.signing.id, Secret: string(s.signing.secret)})
+		for _, k := range s.retired {
+			outgoing = append(outgoing, models.JWTSigningKey{KID: k.id, Secret: string(k.secret)})
+		}
+	}
+	s.keysMu.RUnlock()
+	if store == nil {
+		return nil, ErrNoKeyStore
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	kid := make([]byte, 8)
+	if _, err := rand.Read(kid); err != nil {
+		return nil, fmt.Errorf("failed to generate key id: %w", err)
+	}
+	next := models.JWTSigningKey{
+		KID:       hex.EncodeToString(kid),
+		Secret:    base64.RawURLEncoding.EncodeToString(secret),
+		Current:   true,
+		CreatedAt: time.Now(),
+	}
+
+	if err := store.Rotate(ctx, next, outgoing, next.CreatedAt.Add(s.retiredKeyTTL())); err != nil {
+		return nil, err
+	}
+	if err := s.ReloadKeys(ctx); err != nil {
+		return nil, err
+	}
+	if s.logger != nil {
+		s.logger.Info("JWT signing key rotated", logging.WithField("kid", next.KID))
+	}
+	return &next, nil
+}
+
+// SigningKeys lists the keys tokens are currently verified with. Secrets
+// are never serialized.
+func (s *Service) SigningKeys(ctx context.Context) ([]models.JWTSigningKey, error) {
+	s.keysMu.RLock()
+	store := s.keyStore
+	stored := s.storedKeys
+	keys := []models.JWTSigningKey{{KID: s.signing.id, Current: true}}
+	for _, k := range s.retired {
+		expiresAt := k.validUntil
+		keys = append(keys, models.JWTSigningKey{KID: k.id, ExpiresAt: &expiresAt})
+	}
+	s.keysMu.RUnlock()
+
+	if store != nil && stored {
+		return store.ListUsable(ctx)
+	}
+	return keys, nil
+}
+
+// SetJWTSecret switches token signing to a new secret without signing anyone
+// out. The old secret keeps verifying the tokens it signed until they expire.
+// Once keys have been rotated through the key store the configured secret is
+// no longer used, so changes to it are ignored.
+func (s *Service) SetJWTSecret(secret string) {
+	key := newSigningKey(secret)
+	now := time.Now()
+
+	s.keysMu.Lock()
+	defer s.keysMu.Unlock()
+	if key.id == s.signing.id {
+		return
+	}
+	if s.storedKeys {
+		if s.logger != nil {
+			s.logger.Warn("Ignoring new JWT secret; signing keys are rotated in the database")
+		}
+		return
+	}
+
+	retired := s.signing
+	retired.retiredAt = now
+	retired.validUntil = now.Add(s.retiredKeyTTL())
+	kept := s.retired[:0]
+	for _, k := range s.retired {
+		if k.validUntil.After(now) && k.id != key.id {
+			kept = append(kept, k)
+		}
+	}
+	s.retired = append(kept, retired)
+	s.signing = key
+
+	if s.logger != nil {
+		s.logger.Info("JWT signing secret rotated")
+	}
+}
+
+func (s *Service) signingKey() signingKey {
+	s.keysMu.RLock()
+	defer s.keysMu.RUnlock()
+	return s.signing
+}
+
+// verificationKey finds the secret for a token's kid. Tokens without one
+// were signed before key IDs and are checked against the current secret. A
+// retired key only verifies tokens for ttl after it retired, the longest
+// the kind of token being checked can live. An unknown kid may come from a
+// key another instance just rotated in, so the key store is re-read, at
+// most once per keyReloadInterval.
+func (s *Service) verificationKey(kid string, ttl time.Duration) ([]byte, error) {
+	if secret, ok := s.lookupKey(kid, ttl); ok {
+		return secret, nil
+	}
+
+	s.keysMu.Lock()
+	reload := s.keyStore != nil && time.Since(s.lastReload) >= keyReloadInterval
+	if reload {
+		// Claim the reload so a burst of such tokens reads the store once
+		s.lastReload = time.Now()
+	}
+	s.keysMu.Unlock()
+	if reload {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err := s.ReloadKeys(ctx)
+		cancel()
+		if err != nil && s.logger != nil {
+			s.logger.Warn("Failed to reload JWT signing keys", logging.WithField("error", err.Error()))
+		}
+		if secret, ok := s.lookupKey(kid, ttl); ok {
+			return secret, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (s *Service) lookupKey(kid string, ttl time.Duration) ([]byte, bool) {
+	s.keysMu.RLock()
+	defer s.keysMu.RUnlock()
+
+	if kid == "" || kid == s.signing.id {
+		return s.signing.secret, true
+	}
+	now := time.Now()
+	for _, key := range s.retired {
+		if key.id != kid || !key.validUntil.After(now) {
+			continue
+		}
+		if key.retiredAt.IsZero() || key.retiredAt.Add(ttl).After(now) {
+			return key.secret, true
+		}
+	}
+	return nil, false
+}
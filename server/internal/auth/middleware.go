@@ -4,6 +4,9 @@ import (
 	"context"
 	"net/http"
 	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/database"
 )
 
 // contextKey is a type for context keys
@@ -29,13 +32,13 @@ func (m *Middleware) RequireAuth(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		token := extractToken(r)
 		if token == "" {
-			http.Error(w, `{"error":"authorization required"}`, http.StatusUnauthorized)
+			apierror.WriteStatus(w, http.StatusUnauthorized, "authorization required")
 			return
 		}
 
-		userID, err := m.authService.ValidateAccessToken(token)
+		userID, err := m.authService.ValidateAccessTokenForTenant(token, database.TenantFromContext(r.Context()))
 		if err != nil {
-			http.Error(w, `{"error":"invalid or expired token"}`, http.StatusUnauthorized)
+			apierror.Write(w, http.StatusUnauthorized, apierror.InvalidToken, "invalid or expired token")
 			return
 		}
 
@@ -50,7 +53,7 @@ func (m *Middleware) OptionalAuth(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		token := extractToken(r)
 		if token != "" {
-			userID, err := m.authService.ValidateAccessToken(token)
+			userID, err := m.authService.ValidateAccessTokenForTenant(token, database.TenantFromContext(r.Context()))
 			if err == nil {
 				ctx := context.WithValue(r.Context(), UserIDKey, userID)
 				r = r.WithContext(ctx)
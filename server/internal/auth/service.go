@@ -12,10 +12,12 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/config"
 	"github.com/johnrirwin/flyingforge/internal/database"
 	"github.com/johnrirwin/flyingforge/internal/logging"
@@ -27,6 +29,15 @@ type Service struct {
 	config    config.AuthConfig
 	userStore *database.UserStore
 	logger    *logging.Logger
+
+	keysMu     sync.RWMutex
+	signing    signingKey
+	retired    []signingKey
+	keyStore   KeyStore
+	storedKeys bool      // keys come from keyStore rather than config
+	lastReload time.Time // last time keyStore was read
+
+	handoffs HandoffClaimer
 }
 
 // NewService creates a new auth service
@@ -35,6 +46,7 @@ func NewService(userStore *database.UserStore, cfg config.AuthConfig, logger *lo
 		config:    cfg,
 		userStore: userStore,
 		logger:    logger,
+		signing:   newSigningKey(cfg.JWTSecret),
 	}
 }
 
@@ -48,7 +60,7 @@ func (s *Service) LoginWithGoogle(ctx context.Context, params models.GoogleLogin
 	} else if params.Code != "" {
 		claims, err = s.exchangeGoogleCode(ctx, params.Code, params.RedirectURI)
 	} else {
-		return nil, &AuthError{Code: "invalid_input", Message: "id_token or code is required"}
+		return nil, &AuthError{Code: apierror.InvalidRequest, Message: "id_token or code is required"}
 	}
 
 	if err != nil {
@@ -74,7 +86,7 @@ func (s *Service) LoginWithGoogle(ctx context.Context, params models.GoogleLogin
 			return nil, fmt.Errorf("failed to get user: %w", err)
 		}
 		if user == nil {
-			return nil, &AuthError{Code: "user_not_found", Message: "user not found"}
+			return nil, &AuthError{Code: apierror.UserNotFound, Message: "user not found"}
 		}
 	} else {
 		// Identity doesn't exist - check if user exists by email
@@ -122,9 +134,18 @@ func (s *Service) LoginWithGoogle(ctx context.Context, params models.GoogleLogin
 		}
 	}
 
-	// Check status
+	// Check status. A suspension that has run out ends at the next sign-in.
+	if user.Status == models.UserStatusSuspended && user.StatusExpiresAt != nil && !user.StatusExpiresAt.After(time.Now()) {
+		reactivated, err := s.userStore.ReactivateExpired(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to end suspension: %w", err)
+		}
+		if reactivated != nil {
+			user = reactivated
+		}
+	}
 	if user.Status != models.UserStatusActive {
-		return nil, &AuthError{Code: "account_disabled", Message: "account is disabled"}
+		return nil, s.statusError(user)
 	}
 
 	// Update last login
@@ -143,6 +164,7 @@ func (s *Service) LoginWithGoogle(ctx context.Context, params models.GoogleLogin
 		Tokens:    tokens,
 		IsNewUser: isNewUser,
 		IsLinked:  isLinked,
+		Handoff:   s.claimHandoff(ctx, params.HandoffToken, user),
 	}, nil
 }
 
@@ -155,7 +177,7 @@ func (s *Service) RefreshTokens(ctx context.Context, refreshToken string) (*mode
 		return nil, fmt.Errorf("failed to get refresh token: %w", err)
 	}
 	if storedToken == nil {
-		return nil, &AuthError{Code: "invalid_token", Message: "invalid or expired refresh token"}
+		return nil, &AuthError{Code: apierror.InvalidToken, Message: "invalid or expired refresh token"}
 	}
 
 	user, err := s.userStore.GetByID(ctx, storedToken.UserID)
@@ -163,7 +185,7 @@ func (s *Service) RefreshTokens(ctx context.Context, refreshToken string) (*mode
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil || user.Status != models.UserStatusActive {
-		return nil, &AuthError{Code: "invalid_token", Message: "user not found or disabled"}
+		return nil, &AuthError{Code: apierror.InvalidToken, Message: "user not found or disabled"}
 	}
 
 	// Revoke old token
@@ -187,33 +209,51 @@ func (s *Service) Logout(ctx context.Context, userID string) error {
 
 // ValidateAccessToken validates a JWT access token and returns the user ID
 func (s *Service) ValidateAccessToken(tokenString string) (string, error) {
+	return s.ValidateAccessTokenForTenant(tokenString, "")
+}
+
+// ValidateAccessTokenForTenant validates an access token and checks it was
+// issued for the tenant serving the request. Tokens without a tenant belong
+// to the default tenant. An empty tenantID skips the check.
+func (s *Service) ValidateAccessTokenForTenant(tokenString, tenantID string) (string, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return []byte(s.config.JWTSecret), nil
+		kid, _ := token.Header["kid"].(string)
+		return s.verificationKey(kid, s.config.AccessTokenTTL)
 	})
 
 	if err != nil {
-		return "", &AuthError{Code: "invalid_token", Message: "invalid or expired token"}
+		return "", &AuthError{Code: apierror.InvalidToken, Message: "invalid or expired token"}
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok || !token.Valid {
-		return "", &AuthError{Code: "invalid_token", Message: "invalid token claims"}
+		return "", &AuthError{Code: apierror.InvalidToken, Message: "invalid token claims"}
 	}
 
 	// Validate issuer and audience
 	if iss, _ := claims["iss"].(string); iss != s.config.JWTIssuer {
-		return "", &AuthError{Code: "invalid_token", Message: "invalid token issuer"}
+		return "", &AuthError{Code: apierror.InvalidToken, Message: "invalid token issuer"}
 	}
 	if aud, _ := claims["aud"].(string); aud != s.config.JWTAudience {
-		return "", &AuthError{Code: "invalid_token", Message: "invalid token audience"}
+		return "", &AuthError{Code: apierror.InvalidToken, Message: "invalid token audience"}
 	}
 
 	userID, ok := claims["sub"].(string)
 	if !ok || userID == "" {
-		return "", &AuthError{Code: "invalid_token", Message: "invalid token subject"}
+		return "", &AuthError{Code: apierror.InvalidToken, Message: "invalid token subject"}
+	}
+
+	if tenantID != "" {
+		tokenTenant, _ := claims["tid"].(string)
+		if tokenTenant == "" {
+			tokenTenant = database.DefaultTenantID
+		}
+		if tokenTenant != tenantID {
+			return "", &AuthError{Code: apierror.InvalidToken, Message: "token was issued for another site"}
+		}
 	}
 
 	return userID, nil
@@ -224,11 +264,14 @@ func (s *Service) GetUser(ctx context.Context, userID string) (*models.User, err
 	return s.userStore.GetByID(ctx, userID)
 }
 
-// generateTokens generates access and refresh tokens
-func (s *Service) generateTokens(ctx context.Context, user *models.User) (*models.AuthTokens, error) {
-	now := time.Now()
+// signAccessToken signs a short-lived access token with the current key
+func (s *Service) signAccessToken(user *models.User, now time.Time) (string, error) {
+	return s.signAccessTokenForTenant(user, "", now)
+}
 
-	// Generate access token
+// signAccessTokenForTenant signs an access token that is only accepted on the
+// given tenant's sites
+func (s *Service) signAccessTokenForTenant(user *models.User, tenantID string, now time.Time) (string, error) {
 	accessClaims := jwt.MapClaims{
 		"sub":   user.ID,
 		"email": user.Email,
@@ -238,11 +281,28 @@ func (s *Service) generateTokens(ctx context.Context, user *models.User) (*model
 		"iat":   now.Unix(),
 		"exp":   now.Add(s.config.AccessTokenTTL).Unix(),
 	}
+	if tenantID != "" {
+		accessClaims["tid"] = tenantID
+	}
 
+	key := s.signingKey()
 	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	accessTokenString, err := accessToken.SignedString([]byte(s.config.JWTSecret))
+	accessToken.Header["kid"] = key.id
+	signed, err := accessToken.SignedString(key.secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign access token: %w", err)
+	}
+	return signed, nil
+}
+
+// generateTokens generates access and refresh tokens
+func (s *Service) generateTokens(ctx context.Context, user *models.User) (*models.AuthTokens, error) {
+	now := time.Now()
+
+	// Generate access token
+	accessTokenString, err := s.signAccessTokenForTenant(user, database.TenantFromContext(ctx), now)
 	if err != nil {
-		return nil, fmt.Errorf("failed to sign access token: %w", err)
+		return nil, err
 	}
 
 	// Generate refresh token
@@ -360,10 +420,17 @@ func hashToken(token string) string {
 
 // AuthError represents an authentication error
 type AuthError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code    apierror.Code `json:"code"`
+	Message string        `json:"message"`
+	// Account explains a refused sign-in to a disabled or suspended user
+	Account *models.AccountStatusNotice `json:"account,omitempty"`
 }
 
 func (e *AuthError) Error() string {
 	return e.Message
 }
+
+// ErrorCode returns the error's API error code
+func (e *AuthError) ErrorCode() apierror.Code {
+	return e.Code
+}
@@ -1,11 +1,15 @@
 package auth
 
 import (
+	"context"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/config"
 	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/models"
 	"github.com/johnrirwin/flyingforge/internal/testutil"
 )
 
@@ -34,7 +38,7 @@ func setupTestAuthService(t *testing.T) *Service {
 func TestAuthError(t *testing.T) {
 	tests := []struct {
 		name     string
-		code     string
+		code     apierror.Code
 		message  string
 		expected string
 	}{
@@ -94,3 +98,367 @@ func TestValidateAccessToken_Empty(t *testing.T) {
 		t.Error("Expected error for empty token, got nil")
 	}
 }
+
+func TestValidateAccessTokenForTenant(t *testing.T) {
+	cfg := config.AuthConfig{
+		JWTSecret:      "test-secret",
+		JWTIssuer:      "flyingforge-test",
+		JWTAudience:    "flyingforge-users",
+		AccessTokenTTL: 15 * time.Minute,
+	}
+	service := NewService(nil, cfg, testutil.NullLogger())
+	user := &models.User{ID: "user-1"}
+	clubTenant := "5d9b6d1e-3c61-4c39-9c0e-4a3f1d1b2a10"
+
+	legacyToken, _ := service.signAccessToken(user, time.Now())
+	clubToken, _ := service.signAccessTokenForTenant(user, clubTenant, time.Now())
+
+	tests := []struct {
+		name     string
+		token    string
+		tenantID string
+		wantErr  bool
+	}{
+		{"unscoped request accepts any tenant", clubToken, "", false},
+		{"token without tenant belongs to default", legacyToken, database.DefaultTenantID, false},
+		{"token without tenant rejected by other tenant", legacyToken, clubTenant, true},
+		{"token accepted by its tenant", clubToken, clubTenant, false},
+		{"token rejected by default tenant", clubToken, database.DefaultTenantID, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID, err := service.ValidateAccessTokenForTenant(tt.token, tt.tenantID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateAccessTokenForTenant() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && userID != "user-1" {
+				t.Errorf("userID = %q", userID)
+			}
+		})
+	}
+}
+
+func TestSetJWTSecret_KeepsOldTokensValid(t *testing.T) {
+	cfg := config.AuthConfig{
+		JWTSecret:      "first-secret",
+		JWTIssuer:      "flyingforge-test",
+		JWTAudience:    "flyingforge-users",
+		AccessTokenTTL: 15 * time.Minute,
+	}
+	service := NewService(nil, cfg, testutil.NullLogger())
+	user := &models.User{ID: "user-1"}
+
+	oldToken, err := service.signAccessToken(user, time.Now())
+	if err != nil {
+		t.Fatalf("signAccessToken() error = %v", err)
+	}
+
+	service.SetJWTSecret("second-secret")
+	newToken, err := service.signAccessToken(user, time.Now())
+	if err != nil {
+		t.Fatalf("signAccessToken() error = %v", err)
+	}
+	for name, token := range map[string]string{"old": oldToken, "new": newToken} {
+		if userID, err := service.ValidateAccessToken(token); err != nil || userID != "user-1" {
+			t.Errorf("%s token: userID = %q, err = %v", name, userID, err)
+		}
+	}
+
+	// Once the old key's tokens have expired it stops verifying
+	service.retired[0].validUntil = time.Now().Add(-time.Second)
+	if _, err := service.ValidateAccessToken(oldToken); err == nil {
+		t.Error("token signed with an expired key should fail")
+	}
+
+	// Setting the same secret again is not a rotation
+	service.SetJWTSecret("second-secret")
+	if len(service.retired) != 1 {
+		t.Errorf("retired keys = %d, want 1", len(service.retired))
+	}
+}
+
+// memoryKeyStore is a KeyStore shared by several services, as the database
+// is shared by several instances
+type memoryKeyStore struct {
+	mu   sync.Mutex
+	keys []models.JWTSigningKey
+}
+
+func (m *memoryKeyStore) ListUsable(ctx context.Context) ([]models.JWTSigningKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var keys []models.JWTSigningKey
+	for i := len(m.keys) - 1; i >= 0; i-- {
+		if k := m.keys[i]; k.ExpiresAt == nil || k.ExpiresAt.After(time.Now()) {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+func (m *memoryKeyStore) Rotate(ctx context.Context, next models.JWTSigningKey, outgoing []models.JWTSigningKey, retireUntil time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	for i := range m.keys {
+		if m.keys[i].Current {
+			m.keys[i].Current = false
+			m.keys[i].RetiredAt = &now
+			m.keys[i].ExpiresAt = &retireUntil
+		}
+	}
+outer:
+	for _, k := range outgoing {
+		for _, existing := range m.keys {
+			if existing.KID == k.KID {
+				continue outer
+			}
+		}
+		k.RetiredAt = &now
+		k.ExpiresAt = &retireUntil
+		m.keys = append(m.keys, k)
+	}
+	next.Current = true
+	m.keys = append(m.keys, next)
+	return nil
+}
+
+func TestRotateSigningKey_AcrossInstances(t *testing.T) {
+	cfg := config.AuthConfig{
+		JWTSecret:      "configured-secret",
+		JWTIssuer:      "flyingforge-test",
+		JWTAudience:    "flyingforge-users",
+		AccessTokenTTL: 15 * time.Minute,
+	}
+	store := &memoryKeyStore{}
+	ctx := context.Background()
+	first := NewService(nil, cfg, testutil.NullLogger())
+	second := NewService(nil, cfg, testutil.NullLogger())
+	for _, service := range []*Service{first, second} {
+		service.SetKeyStore(store)
+		if err := service.ReloadKeys(ctx); err != nil {
+			t.Fatalf("ReloadKeys() error = %v", err)
+		}
+	}
+	user := &models.User{ID: "user-1"}
+
+	configToken, _ := first.signAccessToken(user, time.Now())
+	key, err := first.RotateSigningKey(ctx)
+	if err != nil {
+		t.Fatalf("RotateSigningKey() error = %v", err)
+	}
+	rotatedToken, _ := first.signAccessToken(user, time.Now())
+	if got := first.signingKey().id; got != key.KID {
+		t.Errorf("signing kid = %q, want %q", got, key.KID)
+	}
+
+	// The second instance hasn't seen the rotation; once its last reload is
+	// old enough, the unknown kid makes it read the store again
+	second.lastReload = time.Time{}
+	for name, token := range map[string]string{"config": configToken, "rotated": rotatedToken} {
+		for i, service := range []*Service{first, second} {
+			if userID, err := service.ValidateAccessToken(token); err != nil || userID != "user-1" {
+				t.Errorf("%s token on instance %d: userID = %q, err = %v", name, i, userID, err)
+			}
+		}
+	}
+	if got := second.signingKey().id; got != key.KID {
+		t.Errorf("second instance signing kid = %q, want %q", got, key.KID)
+	}
+
+	// A changed config secret no longer takes over from stored keys
+	second.SetJWTSecret("another-secret")
+	if got := second.signingKey().id; got != key.KID {
+		t.Errorf("SetJWTSecret replaced the stored key: kid = %q", got)
+	}
+
+	// Rotating again keeps the previous key verifying until it expires
+	if _, err := second.RotateSigningKey(ctx); err != nil {
+		t.Fatalf("RotateSigningKey() error = %v", err)
+	}
+	if _, err := second.ValidateAccessToken(rotatedToken); err != nil {
+		t.Errorf("token signed with the previous key: %v", err)
+	}
+	keys, err := second.SigningKeys(ctx)
+	if err != nil || len(keys) != 3 || !keys[0].Current {
+		t.Errorf("SigningKeys() = %+v, %v", keys, err)
+	}
+}
+
+func TestRotateSigningKey_NeedsKeyStore(t *testing.T) {
+	service := NewService(nil, config.AuthConfig{JWTSecret: "secret"}, testutil.NullLogger())
+	if _, err := service.RotateSigningKey(context.Background()); err != ErrNoKeyStore {
+		t.Errorf("RotateSigningKey() error = %v, want ErrNoKeyStore", err)
+	}
+}
+
+type fakeHandoffClaimer struct {
+	sessionID, userID string
+}
+
+func (f *fakeHandoffClaimer) ClaimHandoff(ctx context.Context, sessionID, userID string) (*models.BuildHandoffResult, error) {
+	f.sessionID, f.userID = sessionID, userID
+	return &models.BuildHandoffResult{DraftBuildID: "build-1", ClaimedBuildIDs: []string{"build-1"}}, nil
+}
+
+func TestClaimHandoff(t *testing.T) {
+	cfg := config.AuthConfig{
+		JWTSecret:      "handoff-secret",
+		JWTIssuer:      "flyingforge-test",
+		JWTAudience:    "flyingforge-users",
+		AccessTokenTTL: 15 * time.Minute,
+	}
+	service := NewService(nil, cfg, testutil.NullLogger())
+	claimer := &fakeHandoffClaimer{}
+	service.SetHandoffClaimer(claimer)
+	user := &models.User{ID: "user-1"}
+
+	token, err := service.IssueHandoffToken("session-1", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("IssueHandoffToken() error = %v", err)
+	}
+	if _, err := service.ValidateAccessToken(token); err == nil {
+		t.Error("handoff token should not work as an access token")
+	}
+	accessToken, _ := service.signAccessToken(user, time.Now())
+	if result := service.claimHandoff(context.Background(), accessToken, user); result != nil {
+		t.Errorf("access token claimed builds: %+v", result)
+	}
+
+	expired, _ := service.IssueHandoffToken("session-1", time.Now().Add(-time.Minute))
+	if result := service.claimHandoff(context.Background(), expired, user); result != nil {
+		t.Errorf("expired token claimed builds: %+v", result)
+	}
+
+	result := service.claimHandoff(context.Background(), token, user)
+	if result == nil || result.DraftBuildID != "build-1" {
+		t.Fatalf("claimHandoff() = %+v", result)
+	}
+	if claimer.sessionID != "session-1" || claimer.userID != "user-1" {
+		t.Errorf("claimed session %q for %q", claimer.sessionID, claimer.userID)
+	}
+}
+
+func TestHandoffToken_SurvivesKeyRotation(t *testing.T) {
+	cfg := config.AuthConfig{
+		JWTSecret:      "configured-secret",
+		JWTIssuer:      "flyingforge-test",
+		JWTAudience:    "flyingforge-users",
+		AccessTokenTTL: 15 * time.Minute,
+	}
+	ctx := context.Background()
+	service := NewService(nil, cfg, testutil.NullLogger())
+	service.SetKeyStore(&memoryKeyStore{})
+	if err := service.ReloadKeys(ctx); err != nil {
+		t.Fatalf("ReloadKeys() error = %v", err)
+	}
+
+	token, err := service.IssueHandoffToken("session-1", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("IssueHandoffToken() error = %v", err)
+	}
+	accessToken, _ := service.signAccessToken(&models.User{ID: "user-1"}, time.Now())
+	if _, err := service.RotateSigningKey(ctx); err != nil {
+		t.Fatalf("RotateSigningKey() error = %v", err)
+	}
+
+	// Half an hour after the rotation the old key no longer verifies access
+	// tokens, but pending handoffs still go through
+	service.retired[0].retiredAt = time.Now().Add(-30 * time.Minute)
+	if sessionID, err := service.parseHandoffToken(token); err != nil || sessionID != "session-1" {
+		t.Errorf("parseHandoffToken() after rotation = %q, %v", sessionID, err)
+	}
+	if _, err := service.ValidateAccessToken(accessToken); err == nil {
+		t.Error("retired key verified an access token past ACCESS_TOKEN_TTL")
+	}
+
+	service.retired[0].retiredAt = time.Now().Add(-2 * time.Hour)
+	if _, err := service.parseHandoffToken(token); err == nil {
+		t.Error("retired key verified a handoff token past its lifetime")
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	cfg := config.AuthConfig{
+		JWTSecret:      "appeal-secret",
+		JWTIssuer:      "flyingforge-test",
+		JWTAudience:    "flyingforge-users",
+		AccessTokenTTL: 15 * time.Minute,
+	}
+	service := NewService(nil, cfg, testutil.NullLogger())
+
+	pending := service.statusError(&models.User{ID: "user-1", Status: models.UserStatusPending})
+	if pending.Code != apierror.AccountDisabled || pending.Account != nil {
+		t.Errorf("pending account error = %+v, want no notice", pending)
+	}
+
+	until := time.Now().Add(48 * time.Hour)
+	suspended := service.statusError(&models.User{
+		ID:              "user-1",
+		Status:          models.UserStatusSuspended,
+		StatusReason:    "spam in build descriptions",
+		StatusExpiresAt: &until,
+	})
+	if suspended.Code != apierror.AccountSuspended || suspended.Account == nil {
+		t.Fatalf("suspended account error = %+v", suspended)
+	}
+	notice := suspended.Account
+	if notice.Reason != "spam in build descriptions" || notice.ExpiresAt == nil || !notice.ExpiresAt.Equal(until) {
+		t.Errorf("notice = %+v", notice)
+	}
+
+	if _, err := service.ValidateAccessToken(notice.AppealToken); err == nil {
+		t.Error("appeal token should not work as an access token")
+	}
+	userID, err := service.ParseAppealToken(notice.AppealToken)
+	if err != nil || userID != "user-1" {
+		t.Errorf("ParseAppealToken() = %q, %v", userID, err)
+	}
+
+	accessToken, _ := service.signAccessToken(&models.User{ID: "user-1"}, time.Now())
+	if _, err := service.ParseAppealToken(accessToken); err == nil {
+		t.Error("access token should not work as an appeal token")
+	}
+	expired, _ := service.IssueAppealToken("user-1", time.Now().Add(-time.Minute))
+	if _, err := service.ParseAppealToken(expired); err == nil {
+		t.Error("expired appeal token was accepted")
+	}
+}
+
+func TestAppealToken_SurvivesKeyRotation(t *testing.T) {
+	cfg := config.AuthConfig{
+		JWTSecret:      "configured-secret",
+		JWTIssuer:      "flyingforge-test",
+		JWTAudience:    "flyingforge-users",
+		AccessTokenTTL: 15 * time.Minute,
+	}
+	ctx := context.Background()
+	store := &memoryKeyStore{}
+	service := NewService(nil, cfg, testutil.NullLogger())
+	service.SetKeyStore(store)
+	if err := service.ReloadKeys(ctx); err != nil {
+		t.Fatalf("ReloadKeys() error = %v", err)
+	}
+
+	token, err := service.IssueAppealToken("user-1", time.Now().Add(appealTokenTTL))
+	if err != nil {
+		t.Fatalf("IssueAppealToken() error = %v", err)
+	}
+	if _, err := service.RotateSigningKey(ctx); err != nil {
+		t.Fatalf("RotateSigningKey() error = %v", err)
+	}
+	if expiresAt := store.keys[0].ExpiresAt; expiresAt == nil || expiresAt.Before(time.Now().Add(appealTokenTTL-time.Minute)) {
+		t.Errorf("retired key expires at %v, want at least the appeal token lifetime", expiresAt)
+	}
+
+	// Days after the rotation the appeal link still works
+	service.retired[0].retiredAt = time.Now().Add(-3 * 24 * time.Hour)
+	if userID, err := service.ParseAppealToken(token); err != nil || userID != "user-1" {
+		t.Errorf("ParseAppealToken() after rotation = %q, %v", userID, err)
+	}
+
+	service.retired[0].retiredAt = time.Now().Add(-8 * 24 * time.Hour)
+	if _, err := service.ParseAppealToken(token); err == nil {
+		t.Error("retired key verified an appeal token past its lifetime")
+	}
+}
@@ -7,14 +7,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/database"
 	"github.com/johnrirwin/flyingforge/internal/logging"
 	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/push"
 )
 
-// ServiceError represents a service-level error
+// ServiceError represents a service-level error. Code is set for errors
+// handlers map to something other than a 400.
 type ServiceError struct {
+	Code    apierror.Code
 	Message string
 }
 
@@ -22,6 +27,11 @@ func (e *ServiceError) Error() string {
 	return e.Message
 }
 
+// ErrorCode returns the error's API error code
+func (e *ServiceError) ErrorCode() apierror.Code {
+	return e.Code
+}
+
 // Store defines the interface for battery storage operations
 type Store interface {
 	BatteryCodeExists(ctx context.Context, userID, code string) (bool, error)
@@ -34,19 +44,26 @@ type Store interface {
 	CreateLog(ctx context.Context, userID string, params models.CreateBatteryLogParams) (*models.BatteryLog, error)
 	ListLogs(ctx context.Context, batteryID, userID string, limit int) (*models.BatteryLogListResponse, error)
 	DeleteLog(ctx context.Context, logID, userID string) error
+	MarkCharged(ctx context.Context, id, userID string, chargedAt, reminderDueAt time.Time) (bool, error)
+	ClearCharged(ctx context.Context, id, userID string, before time.Time) error
+	ListStorageReminderDue(ctx context.Context, now time.Time) ([]models.ChargedBattery, error)
+	MarkStorageReminded(ctx context.Context, id string) error
 }
 
 // Service handles battery operations
 type Service struct {
-	store  Store
-	logger *logging.Logger
+	store               Store
+	notifier            push.Notifier
+	storageReminderDays int
+	logger              *logging.Logger
 }
 
 // NewService creates a new battery service
 func NewService(store *database.BatteryStore, logger *logging.Logger) *Service {
 	return &Service{
-		store:  store,
-		logger: logger,
+		store:               store,
+		storageReminderDays: DefaultStorageReminderDays,
+		logger:              logger,
 	}
 }
 
@@ -154,7 +171,7 @@ func (s *Service) Update(ctx context.Context, userID string, params models.Updat
 	}
 
 	if battery == nil {
-		return nil, &ServiceError{Message: "battery not found"}
+		return nil, &ServiceError{Code: apierror.BatteryNotFound, Message: "battery not found"}
 	}
 
 	s.logger.Info("Updated battery", logging.WithField("id", battery.ID))
@@ -216,7 +233,7 @@ func (s *Service) CreateLog(ctx context.Context, userID string, params models.Cr
 			return nil, err
 		}
 		if battery == nil {
-			return nil, &ServiceError{Message: "battery not found"}
+			return nil, &ServiceError{Code: apierror.BatteryNotFound, Message: "battery not found"}
 		}
 
 		// Parse IR array and check length
@@ -234,6 +251,12 @@ func (s *Service) CreateLog(ctx context.Context, userID string, params models.Cr
 		return nil, err
 	}
 
+	if resolvesCharge(params) {
+		if err := s.store.ClearCharged(ctx, log.BatteryID, userID, log.LoggedAt); err != nil {
+			s.logger.Warn("Failed to clear battery charged state", logging.WithField("error", err.Error()))
+		}
+	}
+
 	s.logger.Info("Created battery log", logging.WithFields(map[string]interface{}{
 		"log_id":     log.ID,
 		"battery_id": log.BatteryID,
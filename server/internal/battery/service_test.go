@@ -3,6 +3,7 @@ package battery
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/johnrirwin/flyingforge/internal/models"
 	"github.com/johnrirwin/flyingforge/internal/testutil"
@@ -11,6 +12,10 @@ import (
 // mockStore implements the Store interface for testing
 type mockStore struct {
 	codeExists bool
+	charged    map[string]time.Time
+	dueAt      map[string]time.Time
+	due        []models.ChargedBattery
+	reminded   []string
 }
 
 func (m *mockStore) BatteryCodeExists(ctx context.Context, userID, code string) (bool, error) {
@@ -55,7 +60,18 @@ func (m *mockStore) List(ctx context.Context, userID string, params models.Batte
 }
 
 func (m *mockStore) CreateLog(ctx context.Context, userID string, params models.CreateBatteryLogParams) (*models.BatteryLog, error) {
-	return nil, nil
+	loggedAt := time.Now()
+	if params.LoggedAt != nil {
+		loggedAt = *params.LoggedAt
+	}
+	return &models.BatteryLog{
+		ID:         "log-test-1",
+		BatteryID:  params.BatteryID,
+		UserID:     userID,
+		LoggedAt:   loggedAt,
+		CycleDelta: params.CycleDelta,
+		StorageOk:  params.StorageOk,
+	}, nil
 }
 
 func (m *mockStore) ListLogs(ctx context.Context, batteryID, userID string, limit int) (*models.BatteryLogListResponse, error) {
@@ -66,6 +82,36 @@ func (m *mockStore) DeleteLog(ctx context.Context, logID, userID string) error {
 	return nil
 }
 
+func (m *mockStore) MarkCharged(ctx context.Context, id, userID string, chargedAt, reminderDueAt time.Time) (bool, error) {
+	if id != "bat-test-1" {
+		return false, nil
+	}
+	if m.charged == nil {
+		m.charged = map[string]time.Time{}
+		m.dueAt = map[string]time.Time{}
+	}
+	m.charged[id] = chargedAt
+	m.dueAt[id] = reminderDueAt
+	return true, nil
+}
+
+func (m *mockStore) ClearCharged(ctx context.Context, id, userID string, before time.Time) error {
+	if chargedAt, ok := m.charged[id]; ok && !chargedAt.After(before) {
+		delete(m.charged, id)
+		delete(m.dueAt, id)
+	}
+	return nil
+}
+
+func (m *mockStore) ListStorageReminderDue(ctx context.Context, now time.Time) ([]models.ChargedBattery, error) {
+	return m.due, nil
+}
+
+func (m *mockStore) MarkStorageReminded(ctx context.Context, id string) error {
+	m.reminded = append(m.reminded, id)
+	return nil
+}
+
 func newTestService() *Service {
 	return &Service{
 		store:  &mockStore{},
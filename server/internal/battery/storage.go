@@ -0,0 +1,142 @@
+package battery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/push"
+)
+
+const (
+	// DefaultStorageReminderDays is how long a battery can stay charged before
+	// its owner is reminded to discharge it to storage voltage.
+	DefaultStorageReminderDays = 2
+	// maxStorageReminderDays bounds the per-charge reminder delay.
+	maxStorageReminderDays = 30
+)
+
+// SetNotifier enables storage reminders for batteries left charged.
+func (s *Service) SetNotifier(notifier push.Notifier) {
+	s.notifier = notifier
+}
+
+// SetStorageReminderDays sets the default number of days a battery can stay
+// charged before a storage reminder is sent.
+func (s *Service) SetStorageReminderDays(days int) {
+	if days > 0 {
+		s.storageReminderDays = days
+	}
+}
+
+// MarkCharged marks a battery as charged for a session. Unless a discharge or
+// storage log is recorded first, its owner is reminded after RemindAfterDays,
+// or the server default when unset.
+func (s *Service) MarkCharged(ctx context.Context, userID string, params models.MarkBatteryChargedParams, now time.Time) (*models.Battery, error) {
+	if params.BatteryID == "" {
+		return nil, &ServiceError{Message: "id is required"}
+	}
+
+	if params.RemindAfterDays < 0 || params.RemindAfterDays > maxStorageReminderDays {
+		return nil, &ServiceError{Message: fmt.Sprintf("remind_after_days must be between 1 and %d", maxStorageReminderDays)}
+	}
+	days := params.RemindAfterDays
+	if days == 0 {
+		days = s.storageReminderDays
+	}
+	if days <= 0 {
+		days = DefaultStorageReminderDays
+	}
+
+	found, err := s.store.MarkCharged(ctx, params.BatteryID, userID, now, now.AddDate(0, 0, days))
+	if err != nil {
+		s.logger.Error("Failed to mark battery charged", logging.WithField("error", err.Error()))
+		return nil, err
+	}
+	if !found {
+		return nil, &ServiceError{Code: apierror.BatteryNotFound, Message: "battery not found"}
+	}
+
+	return s.store.Get(ctx, params.BatteryID, userID)
+}
+
+// ClearCharged clears a battery's charged state without recording a log
+func (s *Service) ClearCharged(ctx context.Context, id string, userID string, now time.Time) (*models.Battery, error) {
+	if id == "" {
+		return nil, &ServiceError{Message: "id is required"}
+	}
+
+	if err := s.store.ClearCharged(ctx, id, userID, now); err != nil {
+		return nil, err
+	}
+
+	battery, err := s.store.Get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if battery == nil {
+		return nil, &ServiceError{Code: apierror.BatteryNotFound, Message: "battery not found"}
+	}
+	return battery, nil
+}
+
+// SendStorageReminders notifies owners once about each battery left charged
+// past its reminder time. Returns the number of reminders sent.
+func (s *Service) SendStorageReminders(ctx context.Context, now time.Time) (int, error) {
+	if s.notifier == nil {
+		return 0, nil
+	}
+
+	due, err := s.store.ListStorageReminderDue(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, battery := range due {
+		if err := s.notifier.Notify(ctx, battery.UserID, storageNotification(battery, now)); err != nil {
+			s.logger.Warn("Failed to send battery storage reminder", logging.WithFields(map[string]interface{}{
+				"battery_id": battery.ID,
+				"error":      err.Error(),
+			}))
+			continue
+		}
+		if err := s.store.MarkStorageReminded(ctx, battery.ID); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// resolvesCharge reports whether a log shows the battery was discharged by
+// flying or put into storage, ending its charged state.
+func resolvesCharge(params models.CreateBatteryLogParams) bool {
+	return params.CycleDelta > 0 || (params.StorageOk != nil && *params.StorageOk)
+}
+
+func storageNotification(battery models.ChargedBattery, now time.Time) models.Notification {
+	label := battery.BatteryCode
+	if battery.Name != "" {
+		label = fmt.Sprintf("%s (%s)", battery.Name, battery.BatteryCode)
+	}
+
+	days := int(now.Sub(battery.ChargedAt).Hours() / 24)
+	when := fmt.Sprintf("%d days ago", days)
+	if days == 1 {
+		when = "1 day ago"
+	}
+
+	return models.Notification{
+		Kind:  models.NotificationBatteryStorage,
+		Title: fmt.Sprintf("%s is still charged", label),
+		Body:  fmt.Sprintf("%s was charged %s. Discharge it to storage voltage and log it.", label, when),
+		Data: map[string]string{
+			"batteryId": battery.ID,
+			"chargedAt": battery.ChargedAt.UTC().Format(time.RFC3339),
+		},
+	}
+}
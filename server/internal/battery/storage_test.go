@@ -0,0 +1,133 @@
+package battery
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+type recordingNotifier struct {
+	sent []models.Notification
+	fail bool
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, userID string, notification models.Notification) error {
+	if n.fail {
+		return errors.New("push unavailable")
+	}
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func TestService_MarkCharged(t *testing.T) {
+	now := time.Date(2026, 5, 1, 18, 0, 0, 0, time.UTC)
+	store := &mockStore{}
+	svc := &Service{store: store, logger: testutil.NullLogger()}
+	svc.SetStorageReminderDays(3)
+
+	if _, err := svc.MarkCharged(context.Background(), "user-123", models.MarkBatteryChargedParams{BatteryID: "bat-test-1"}, now); err != nil {
+		t.Fatalf("MarkCharged() error = %v", err)
+	}
+	if got, want := store.dueAt["bat-test-1"], now.AddDate(0, 0, 3); !got.Equal(want) {
+		t.Errorf("reminder due at %v, want server default %v", got, want)
+	}
+
+	params := models.MarkBatteryChargedParams{BatteryID: "bat-test-1", RemindAfterDays: 1}
+	if _, err := svc.MarkCharged(context.Background(), "user-123", params, now); err != nil {
+		t.Fatalf("MarkCharged() error = %v", err)
+	}
+	if got, want := store.dueAt["bat-test-1"], now.AddDate(0, 0, 1); !got.Equal(want) {
+		t.Errorf("reminder due at %v, want %v", got, want)
+	}
+
+	for _, params := range []models.MarkBatteryChargedParams{
+		{BatteryID: "bat-test-1", RemindAfterDays: -1},
+		{BatteryID: "bat-test-1", RemindAfterDays: maxStorageReminderDays + 1},
+		{BatteryID: "bat-missing"},
+	} {
+		_, err := svc.MarkCharged(context.Background(), "user-123", params, now)
+		var svcErr *ServiceError
+		if !errors.As(err, &svcErr) {
+			t.Errorf("MarkCharged(%+v) error = %v, want ServiceError", params, err)
+		}
+	}
+}
+
+func TestService_CreateLogClearsCharge(t *testing.T) {
+	chargedAt := time.Date(2026, 5, 1, 18, 0, 0, 0, time.UTC)
+	before := chargedAt.Add(-time.Hour)
+	after := chargedAt.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		params      models.CreateBatteryLogParams
+		wantCharged bool
+	}{
+		{"flown", models.CreateBatteryLogParams{CycleDelta: 1, LoggedAt: &after}, false},
+		{"stored", models.CreateBatteryLogParams{StorageOk: ptr(true), LoggedAt: &after}, false},
+		{"not at storage voltage", models.CreateBatteryLogParams{StorageOk: ptr(false), LoggedAt: &after}, true},
+		{"health check only", models.CreateBatteryLogParams{Notes: "puffed slightly", LoggedAt: &after}, true},
+		{"back-dated discharge", models.CreateBatteryLogParams{CycleDelta: 1, LoggedAt: &before}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockStore{
+				charged: map[string]time.Time{"bat-test-1": chargedAt},
+				dueAt:   map[string]time.Time{"bat-test-1": chargedAt.AddDate(0, 0, 2)},
+			}
+			svc := &Service{store: store, logger: testutil.NullLogger()}
+
+			tt.params.BatteryID = "bat-test-1"
+			if _, err := svc.CreateLog(context.Background(), "user-123", tt.params); err != nil {
+				t.Fatalf("CreateLog() error = %v", err)
+			}
+			if _, charged := store.charged["bat-test-1"]; charged != tt.wantCharged {
+				t.Errorf("charged after log = %v, want %v", charged, tt.wantCharged)
+			}
+		})
+	}
+}
+
+func TestService_SendStorageReminders(t *testing.T) {
+	now := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	store := &mockStore{due: []models.ChargedBattery{
+		{ID: "bat-1", UserID: "user-123", BatteryCode: "BAT-A1B2", Name: "Race pack 1", ChargedAt: now.AddDate(0, 0, -3)},
+		{ID: "bat-2", UserID: "user-123", BatteryCode: "BAT-C3D4", ChargedAt: now.AddDate(0, 0, -1)},
+	}}
+	svc := &Service{store: store, logger: testutil.NullLogger()}
+
+	sent, err := svc.SendStorageReminders(context.Background(), now)
+	if err != nil || sent != 0 {
+		t.Fatalf("SendStorageReminders() without notifier = %d, %v, want 0, nil", sent, err)
+	}
+
+	notifier := &recordingNotifier{}
+	svc.SetNotifier(notifier)
+	sent, err = svc.SendStorageReminders(context.Background(), now)
+	if err != nil {
+		t.Fatalf("SendStorageReminders() error = %v", err)
+	}
+	if sent != 2 || len(store.reminded) != 2 {
+		t.Fatalf("sent %d reminders, marked %v, want 2", sent, store.reminded)
+	}
+	if got := notifier.sent[0]; got.Kind != models.NotificationBatteryStorage ||
+		got.Title != "Race pack 1 (BAT-A1B2) is still charged" ||
+		got.Body != "Race pack 1 (BAT-A1B2) was charged 3 days ago. Discharge it to storage voltage and log it." {
+		t.Errorf("first notification = %+v", got)
+	}
+	if got := notifier.sent[1].Body; got != "BAT-C3D4 was charged 1 day ago. Discharge it to storage voltage and log it." {
+		t.Errorf("second notification body = %q", got)
+	}
+
+	store.reminded = nil
+	svc.SetNotifier(&recordingNotifier{fail: true})
+	sent, err = svc.SendStorageReminders(context.Background(), now)
+	if err != nil || sent != 0 || len(store.reminded) != 0 {
+		t.Errorf("SendStorageReminders() with failing push = %d, %v, marked %v; want nothing marked", sent, err, store.reminded)
+	}
+}
@@ -0,0 +1,242 @@
+package betaflight
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// Betaflight packs an OSD element's position and visibility into one value:
+// bits 0-4 are X, bits 5-9 are Y, bit 10 extends X for HD displays, and
+// bits 11-13 flag the OSD profiles the element is shown in.
+const (
+	osdPositionBits    = 5
+	osdPositionMask    = (1 << osdPositionBits) - 1
+	osdXHDBit          = 10
+	osdProfileBitsPos  = 11
+	osdProfileCount    = 3
+	osdMaxElementValue = 0xFFFF
+	osdMaxElements     = 100
+)
+
+var (
+	osdElementPattern     = regexp.MustCompile(`^set\s+osd_([a-z0-9_]+)_pos\s*=\s*(\d+)$`)
+	osdVideoSystemPattern = regexp.MustCompile(`^set\s+vcd_video_system\s*=\s*(\S+)$`)
+	osdElementNamePattern = regexp.MustCompile(`^[a-z0-9_]{1,40}$`)
+)
+
+// osdGrids is the character grid for each video system. AUTO previews as PAL,
+// the larger of the two analog grids.
+var osdGrids = map[string][2]int{
+	models.OSDVideoAuto: {30, 16},
+	models.OSDVideoPAL:  {30, 16},
+	models.OSDVideoNTSC: {30, 13},
+	models.OSDVideoHD:   {53, 20},
+}
+
+// osdSampleText is what the preview draws for well-known elements, roughly
+// what they show in flight. Other elements are drawn by name.
+var osdSampleText = map[string]string{
+	"rssi":              "RSSI 99",
+	"link_quality":      "LQ 9:100",
+	"vbat":              "16.8V",
+	"avg_cell_voltage":  "4.20V",
+	"current":           "12.3A",
+	"mah_drawn":         "690MAH",
+	"crosshairs":        "-+-",
+	"ah_sbar":           "|  |",
+	"ah":                "------",
+	"flymode":           "ACRO",
+	"craft_name":        "CRAFT",
+	"pilot_name":        "PILOT",
+	"display_name":      "NAME",
+	"throttle":          "THR 25",
+	"vtx_channel":       "R:2:25",
+	"warnings":          "LOW BATTERY",
+	"disarmed":          "DISARMED",
+	"altitude":          "12.5M",
+	"tim_1":             "00:42",
+	"tim_2":             "03:15",
+	"gps_speed":         "42K",
+	"gps_sats":          "14 SAT",
+	"home_dir":          "^",
+	"home_dist":         "115M",
+	"pit_ang":           "P 5.0",
+	"rol_ang":           "R 2.0",
+	"battery_usage":     "[=====   ]",
+	"esc_tmp":           "45C",
+	"esc_rpm":           "22000",
+	"rtc_date_time":     "2024-01-01 12:00",
+	"power":             "250W",
+	"efficiency":        "12MAH/K",
+	"numerical_heading": "270",
+}
+
+// parseOSDLayout extracts OSD element positions. It returns nil when the dump
+// has none, such as INAV dumps or a bare "diff" with default positions.
+func parseOSDLayout(lines []string) *models.OSDLayout {
+	layout := &models.OSDLayout{Elements: make([]models.OSDElement, 0)}
+	seen := make(map[string]int)
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+
+		if matches := osdVideoSystemPattern.FindStringSubmatch(line); len(matches) == 2 {
+			layout.VideoSystem = strings.ToUpper(matches[1])
+			continue
+		}
+
+		matches := osdElementPattern.FindStringSubmatch(line)
+		if len(matches) != 3 {
+			continue
+		}
+		value, err := strconv.Atoi(matches[2])
+		if err != nil || value > osdMaxElementValue {
+			continue
+		}
+
+		element := DecodeOSDElement(matches[1], value)
+		// A later setting for the same element wins, as it would in the CLI
+		if idx, ok := seen[element.Name]; ok {
+			layout.Elements[idx] = element
+			continue
+		}
+		seen[element.Name] = len(layout.Elements)
+		layout.Elements = append(layout.Elements, element)
+	}
+
+	if len(layout.Elements) == 0 {
+		return nil
+	}
+	return layout
+}
+
+// DecodeOSDElement unpacks an osd_<name>_pos value
+func DecodeOSDElement(name string, value int) models.OSDElement {
+	element := models.OSDElement{
+		Name:  name,
+		Value: value,
+		X:     (value & osdPositionMask) | ((value >> (osdXHDBit - osdPositionBits)) & (1 << osdPositionBits)),
+		Y:     (value >> osdPositionBits) & osdPositionMask,
+	}
+	for profile := 1; profile <= osdProfileCount; profile++ {
+		if value&(1<<(osdProfileBitsPos+profile-1)) != 0 {
+			element.Profiles = append(element.Profiles, profile)
+		}
+	}
+	return element
+}
+
+// NormalizeOSDLayout validates an imported layout and decodes each element
+// from its value, so a client can't send positions that disagree with it
+func NormalizeOSDLayout(layout *models.OSDLayout) (*models.OSDLayout, error) {
+	if layout == nil || len(layout.Elements) == 0 {
+		return nil, fmt.Errorf("layout has no elements")
+	}
+	if len(layout.Elements) > osdMaxElements {
+		return nil, fmt.Errorf("layout has more than %d elements", osdMaxElements)
+	}
+
+	videoSystem := strings.ToUpper(strings.TrimSpace(layout.VideoSystem))
+	if _, ok := osdGrids[videoSystem]; videoSystem != "" && !ok {
+		return nil, fmt.Errorf("unknown video system %q", layout.VideoSystem)
+	}
+
+	normalized := &models.OSDLayout{
+		VideoSystem: videoSystem,
+		Elements:    make([]models.OSDElement, 0, len(layout.Elements)),
+	}
+	seen := make(map[string]bool)
+	for _, element := range layout.Elements {
+		name := strings.ToLower(strings.TrimSpace(element.Name))
+		if !osdElementNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid element name %q", element.Name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("element %q is listed twice", name)
+		}
+		if element.Value < 0 || element.Value > osdMaxElementValue {
+			return nil, fmt.Errorf("element %q has an out of range value", name)
+		}
+		seen[name] = true
+		normalized.Elements = append(normalized.Elements, DecodeOSDElement(name, element.Value))
+	}
+
+	return normalized, nil
+}
+
+// OSDLayoutCLI returns the CLI commands that restore a layout
+func OSDLayoutCLI(layout *models.OSDLayout) string {
+	var b strings.Builder
+	b.WriteString("# OSD layout\n")
+	if layout.VideoSystem != "" {
+		fmt.Fprintf(&b, "set vcd_video_system = %s\n", layout.VideoSystem)
+	}
+	for _, element := range layout.Elements {
+		fmt.Fprintf(&b, "set osd_%s_pos = %d\n", element.Name, element.Value)
+	}
+	b.WriteString("save\n")
+	return b.String()
+}
+
+// ValidOSDProfile reports whether profile is an OSD profile number
+func ValidOSDProfile(profile int) bool {
+	return profile >= 1 && profile <= osdProfileCount
+}
+
+// RenderOSDPreview draws the elements shown in an OSD profile onto the video
+// system's character grid. Elements are drawn in layout order, so a later
+// element overwrites an earlier one that overlaps it, as on the goggles.
+func RenderOSDPreview(layout *models.OSDLayout, profile int) *models.OSDPreview {
+	videoSystem := layout.VideoSystem
+	if _, ok := osdGrids[videoSystem]; !ok {
+		videoSystem = models.OSDVideoAuto
+	}
+	grid := osdGrids[videoSystem]
+	columns, rows := grid[0], grid[1]
+
+	cells := make([][]byte, rows)
+	for i := range cells {
+		cells[i] = []byte(strings.Repeat(" ", columns))
+	}
+
+	preview := &models.OSDPreview{
+		VideoSystem: videoSystem,
+		Profile:     profile,
+		Columns:     columns,
+		Rows:        rows,
+	}
+
+	for _, element := range layout.Elements {
+		if !shownInProfile(element, profile) {
+			continue
+		}
+		if element.X >= columns || element.Y >= rows {
+			preview.Offscreen = append(preview.Offscreen, element.Name)
+			continue
+		}
+		text := osdSampleText[element.Name]
+		if text == "" {
+			text = strings.ToUpper(element.Name)
+		}
+		copy(cells[element.Y][element.X:], text)
+	}
+
+	preview.Lines = make([]string, rows)
+	for i, row := range cells {
+		preview.Lines[i] = string(row)
+	}
+	return preview
+}
+
+func shownInProfile(element models.OSDElement, profile int) bool {
+	for _, p := range element.Profiles {
+		if p == profile {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package betaflight
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const osdDump = `# Betaflight / STM32F405 (S405) 4.4.2 Jun 1 2023 / 12:34:56 (1234567) MSP API: 1.45
+# board_name MATEKF405
+set vcd_video_system = NTSC
+set osd_vbat_pos = 2456
+set osd_crosshairs_pos = 6413
+set osd_rssi_pos = 34
+set osd_tim_2_pos = 8599
+set osd_vbat_pos = 2458
+`
+
+func TestParse_OSDLayout(t *testing.T) {
+	result := NewParser().Parse(osdDump)
+
+	layout := result.OSDLayout
+	if layout == nil {
+		t.Fatal("Expected an OSD layout")
+	}
+	if layout.VideoSystem != models.OSDVideoNTSC {
+		t.Errorf("Expected NTSC, got %q", layout.VideoSystem)
+	}
+	if len(layout.Elements) != 4 {
+		t.Fatalf("Expected 4 elements, got %d", len(layout.Elements))
+	}
+
+	// The repeated vbat setting replaces the first one in place
+	vbat := layout.Elements[0]
+	if vbat.Name != "vbat" || vbat.Value != 2458 || vbat.X != 26 || vbat.Y != 12 {
+		t.Errorf("Unexpected vbat element: %+v", vbat)
+	}
+	if len(vbat.Profiles) != 1 || vbat.Profiles[0] != 1 {
+		t.Errorf("Expected vbat in profile 1, got %v", vbat.Profiles)
+	}
+
+	// 34 has no profile bits, so RSSI is hidden
+	if rssi := layout.Elements[2]; len(rssi.Profiles) != 0 {
+		t.Errorf("Expected RSSI to be hidden, got %v", rssi.Profiles)
+	}
+}
+
+func TestParse_NoOSDLayout(t *testing.T) {
+	result := NewParser().Parse("set p_roll = 45\n")
+	if result.OSDLayout != nil {
+		t.Errorf("Expected no OSD layout, got %+v", result.OSDLayout)
+	}
+}
+
+func TestDecodeOSDElement_HDExtendedX(t *testing.T) {
+	// X of 40 needs bit 10: 40 = 8 | 32
+	value := (1 << 11) | (1 << 10) | (5 << 5) | 8
+	element := DecodeOSDElement("vbat", value)
+	if element.X != 40 || element.Y != 5 {
+		t.Errorf("Expected (40, 5), got (%d, %d)", element.X, element.Y)
+	}
+}
+
+func TestRenderOSDPreview(t *testing.T) {
+	layout := NewParser().Parse(osdDump).OSDLayout
+	layout.Elements = append(layout.Elements, DecodeOSDElement("far_away", (1<<11)|(20<<5)))
+
+	preview := RenderOSDPreview(layout, 1)
+	if preview.Columns != 30 || preview.Rows != 13 {
+		t.Fatalf("Expected a 30x13 NTSC grid, got %dx%d", preview.Columns, preview.Rows)
+	}
+	if len(preview.Lines) != 13 {
+		t.Fatalf("Expected 13 lines, got %d", len(preview.Lines))
+	}
+	for _, line := range preview.Lines {
+		if len(line) != 30 {
+			t.Fatalf("Expected 30 columns, got %q", line)
+		}
+	}
+
+	// vbat at (26, 12) is clipped at the right edge
+	if got := preview.Lines[12][26:]; got != "16.8" {
+		t.Errorf("Expected vbat on the last row, got %q", got)
+	}
+	if !strings.Contains(preview.Lines[8], "-+-") {
+		t.Errorf("Expected crosshairs on row 8, got %q", preview.Lines[8])
+	}
+	if strings.Contains(strings.Join(preview.Lines, "\n"), "RSSI") {
+		t.Error("Expected hidden RSSI to be left out")
+	}
+	if len(preview.Offscreen) != 1 || preview.Offscreen[0] != "far_away" {
+		t.Errorf("Expected far_away to be offscreen, got %v", preview.Offscreen)
+	}
+
+	// tim_2 is only in profile 3
+	preview = RenderOSDPreview(layout, 3)
+	if strings.Contains(strings.Join(preview.Lines, "\n"), "16.8") {
+		t.Error("Expected vbat to be left out of profile 3")
+	}
+	if !strings.Contains(strings.Join(preview.Lines, "\n"), "03:15") {
+		t.Error("Expected tim_2 in profile 3")
+	}
+}
+
+func TestNormalizeOSDLayout(t *testing.T) {
+	layout, err := NormalizeOSDLayout(&models.OSDLayout{
+		VideoSystem: "pal",
+		Elements:    []models.OSDElement{{Name: "VBAT", Value: 2456, X: 1, Y: 1}},
+	})
+	if err != nil {
+		t.Fatalf("NormalizeOSDLayout failed: %v", err)
+	}
+	if layout.VideoSystem != models.OSDVideoPAL {
+		t.Errorf("Expected PAL, got %q", layout.VideoSystem)
+	}
+	if element := layout.Elements[0]; element.Name != "vbat" || element.X != 24 || element.Y != 12 {
+		t.Errorf("Expected the position decoded from the value, got %+v", element)
+	}
+
+	invalid := []*models.OSDLayout{
+		nil,
+		{Elements: []models.OSDElement{}},
+		{VideoSystem: "SECAM", Elements: []models.OSDElement{{Name: "vbat"}}},
+		{Elements: []models.OSDElement{{Name: "vbat; save"}}},
+		{Elements: []models.OSDElement{{Name: "vbat", Value: 70000}}},
+		{Elements: []models.OSDElement{{Name: "vbat"}, {Name: "vbat"}}},
+	}
+	for i, layout := range invalid {
+		if _, err := NormalizeOSDLayout(layout); err == nil {
+			t.Errorf("Expected layout %d to be rejected", i)
+		}
+	}
+}
+
+func TestOSDLayoutCLI(t *testing.T) {
+	cli := OSDLayoutCLI(&models.OSDLayout{
+		VideoSystem: models.OSDVideoHD,
+		Elements:    []models.OSDElement{{Name: "vbat", Value: 2456}},
+	})
+	expected := "# OSD layout\nset vcd_video_system = HD\nset osd_vbat_pos = 2456\nsave\n"
+	if cli != expected {
+		t.Errorf("Expected %q, got %q", expected, cli)
+	}
+}
@@ -33,6 +33,7 @@ type ParseResult struct {
 	ParseStatus     models.ParseStatus
 	ParseWarnings   []string
 	ParsedTuning    *models.ParsedTuning
+	OSDLayout       *models.OSDLayout
 }
 
 // NewParser creates a new Betaflight CLI parser
@@ -81,6 +82,7 @@ func (p *Parser) Parse(cliDump string) *ParseResult {
 	p.parseMotorMixer(lines, result)
 	p.parseFeatures(lines, result)
 	p.parseMiscSettings(lines, result)
+	result.OSDLayout = parseOSDLayout(lines)
 
 	// Set active profile data as the main PIDs/Rates
 	if len(result.ParsedTuning.PIDProfiles) > 0 {
@@ -120,9 +122,9 @@ func (p *Parser) parseMetadata(lines []string, result *ParseResult) {
 		// Check for Betaflight version
 		if strings.Contains(line, "Betaflight") {
 			result.FirmwareName = models.FirmwareBetaflight
-			matches := regexp.MustCompile(`Betaflight\s*/\s*(\S+)\s+(\d+\.\d+\.\d+)`).FindStringSubmatch(line)
+			matches := regexp.MustCompile(`Betaflight\s*/\s*(\S+)\s+(?:\(\S+\)\s+)?(\d+\.\d+\.\d+)`).FindStringSubmatch(line)
 			if len(matches) >= 3 {
-				result.BoardTarget = matches[1]
+				result.BoardTarget = models.NormalizeFirmwareTarget(matches[1])
 				result.FirmwareVersion = matches[2]
 			}
 		}
@@ -130,9 +132,9 @@ func (p *Parser) parseMetadata(lines []string, result *ParseResult) {
 		// Check for INAV
 		if strings.Contains(line, "INAV") {
 			result.FirmwareName = models.FirmwareINAV
-			matches := regexp.MustCompile(`INAV\s*/\s*(\S+)\s+(\d+\.\d+\.\d+)`).FindStringSubmatch(line)
+			matches := regexp.MustCompile(`INAV\s*/\s*(\S+)\s+(?:\(\S+\)\s+)?(\d+\.\d+\.\d+)`).FindStringSubmatch(line)
 			if len(matches) >= 3 {
-				result.BoardTarget = matches[1]
+				result.BoardTarget = models.NormalizeFirmwareTarget(matches[1])
 				result.FirmwareVersion = matches[2]
 			}
 		}
@@ -141,7 +143,7 @@ func (p *Parser) parseMetadata(lines []string, result *ParseResult) {
 		if strings.HasPrefix(line, "board_name") || strings.HasPrefix(line, "# board_name") {
 			parts := strings.Fields(line)
 			if len(parts) >= 2 {
-				result.BoardName = parts[len(parts)-1]
+				result.BoardName = models.NormalizeFirmwareTarget(parts[len(parts)-1])
 			}
 		}
 
@@ -185,3 +185,13 @@ feature OSD
 		t.Error("Expected Filters to be nil")
 	}
 }
+
+func TestParseMetadata_NormalizesBoardTarget(t *testing.T) {
+	dump := "# Betaflight / stm32f405 (S405) 4.4.2 Jun  9 2023 / 00:00:00 (abc123) MSP API: 1.45\n" +
+		"board_name speedybeef405v4\n"
+
+	result := NewParser().Parse(dump)
+	if result.BoardTarget != "STM32F405" || result.BoardName != "SPEEDYBEEF405V4" {
+		t.Errorf("board target %q, name %q; want STM32F405, SPEEDYBEEF405V4", result.BoardTarget, result.BoardName)
+	}
+}
@@ -0,0 +1,69 @@
+package betaflight
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// privateSettings are settings that identify a pilot or their radio link.
+// They are dropped from configs shared publicly.
+var privateSettings = map[string]string{
+	"name":                    "craft name",
+	"craft_name":              "craft name",
+	"pilot_name":              "pilot name",
+	"display_name":            "display name",
+	"expresslrs_uid":          "ExpressLRS binding UID",
+	"expresslrs_passphrase":   "ExpressLRS binding phrase",
+	"frsky_spi_tx_id":         "receiver binding ID",
+	"frsky_spi_bind_hop_data": "receiver binding ID",
+	"rx_spi_bind_tx_id":       "receiver binding ID",
+	"spektrum_sat_bind":       "receiver binding ID",
+}
+
+var (
+	setLine = regexp.MustCompile(`^set\s+(\S+)\s*=`)
+	// secretSetting catches credentials under any name, such as wifi_password
+	secretSetting = regexp.MustCompile(`password|passphrase|ssid|secret|serial_?num`)
+	// identifyingComment catches comment lines carrying hardware serials
+	identifyingComment = regexp.MustCompile(`(?i)^#.*\b(serial\s*(number|no\.?|#)|mcu[ _]?id|uid|signature)\b`)
+)
+
+// Sanitize removes settings that identify the pilot or their hardware from a
+// CLI dump, such as the OSD craft name, receiver binding IDs, wifi credentials
+// and serial numbers. It returns the cleaned dump and a description of each
+// kind of setting removed, sorted.
+func Sanitize(cliDump string) (string, []string) {
+	lines := strings.Split(strings.ReplaceAll(cliDump, "\r\n", "\n"), "\n")
+	kept := make([]string, 0, len(lines))
+	removed := make(map[string]bool)
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		trimmed := strings.TrimSpace(line)
+
+		if identifyingComment.MatchString(trimmed) {
+			removed["hardware serial number"] = true
+			continue
+		}
+		if match := setLine.FindStringSubmatch(trimmed); match != nil {
+			key := strings.ToLower(match[1])
+			if label, ok := privateSettings[key]; ok {
+				removed[label] = true
+				continue
+			}
+			if secretSetting.MatchString(key) {
+				removed[key] = true
+				continue
+			}
+		}
+		kept = append(kept, line)
+	}
+
+	labels := make([]string, 0, len(removed))
+	for label := range removed {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return strings.TrimSpace(strings.Join(kept, "\n")) + "\n", labels
+}
@@ -0,0 +1,46 @@
+package betaflight
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	dump := "# Betaflight / STM32F405 (S405) 4.4.2 Jun 1 2023 / 12:34:56 (1234567) MSP API: 1.45\r\n" +
+		"# board_name MATEKF405\r\n" +
+		"# MCU ID: 3a0021000f51333036383932\r\n" +
+		"set name = SAMS RIPPER\r\n" +
+		"set osd_craft_name_pos = 2048\r\n" +
+		"set expresslrs_uid = 12,34,56,78,90,12\r\n" +
+		"set wifi_password = hunter2\r\n" +
+		"serial 0 64 115200 57600 0 115200\r\n" +
+		"set p_roll = 45   \r\n"
+
+	sanitized, removed := Sanitize(dump)
+
+	for _, secret := range []string{"SAMS RIPPER", "12,34,56", "hunter2", "3a0021000f"} {
+		if strings.Contains(sanitized, secret) {
+			t.Errorf("sanitized dump still contains %q:\n%s", secret, sanitized)
+		}
+	}
+	// Look-alike settings and serial port config stay
+	for _, kept := range []string{"osd_craft_name_pos = 2048", "serial 0 64", "set p_roll = 45\n", "# board_name MATEKF405"} {
+		if !strings.Contains(sanitized, kept) {
+			t.Errorf("sanitized dump lost %q:\n%s", kept, sanitized)
+		}
+	}
+	if strings.Contains(sanitized, "\r") {
+		t.Error("sanitized dump should use unix line endings")
+	}
+
+	want := []string{"ExpressLRS binding UID", "craft name", "hardware serial number", "wifi_password"}
+	if strings.Join(removed, "|") != strings.Join(want, "|") {
+		t.Errorf("removed = %v, want %v", removed, want)
+	}
+
+	// The result still parses
+	result := NewParser().Parse(sanitized)
+	if result.FirmwareVersion != "4.4.2" || result.BoardName != "MATEKF405" {
+		t.Errorf("parse after sanitize = %+v", result)
+	}
+}
@@ -0,0 +1,862 @@
+// Package blackbox reads Betaflight-format blackbox logs (.bbl and .bfl
+// files, also written by INAV and Cleanflight) and summarizes each flight in
+// them. A file can hold several logs, one per arm; each starts with a text
+// header describing how its binary frames are encoded.
+package blackbox
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Field predictors: what a decoded value is added to
+const (
+	predictZero              = 0
+	predictPrevious          = 1
+	predictStraightLine      = 2
+	predictAverage2          = 3
+	predictMinThrottle       = 4
+	predictMotor0            = 5
+	predictIncrement         = 6
+	predictHomeCoord         = 7
+	predict1500              = 8
+	predictVBatRef           = 9
+	predictLastMainFrameTime = 10
+	predictMinMotor          = 11
+)
+
+// Field encodings: how a value is packed into bytes
+const (
+	encodeSignedVB        = 0
+	encodeUnsignedVB      = 1
+	encodeNeg14Bit        = 3
+	encodeTag8_8SVB       = 6
+	encodeTag2_3S32       = 7
+	encodeTag8_4S16       = 8
+	encodeNull            = 9
+	encodeTag2_3SVariable = 10
+)
+
+const (
+	// logStart begins the header of every log
+	logStart = "H Product:Blackbox flight data recorder by Nicholas Sherlock"
+	// logEndMessage follows the log end event
+	logEndMessage = "End of log\x00"
+	// maxFrameLength is the longest a valid frame can be; longer ones are
+	// corrupt
+	maxFrameLength = 256
+	// maxHeaderLine bounds a header line so garbage can't make one huge
+	maxHeaderLine = 2048
+)
+
+var errCorruptFrame = errors.New("corrupt frame")
+
+// frameDef describes the fields of one frame type
+type frameDef struct {
+	names      []string
+	signed     []bool
+	predictors []int
+	encodings  []int
+}
+
+func (f *frameDef) index(name string) int {
+	for i, n := range f.names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// header is the parsed text header of one log
+type header struct {
+	values map[string]string
+
+	firmware     string // Firmware revision, such as "Betaflight 4.4.2 (8b9d8d3) STM32F405"
+	firmwareType string
+	craftName    string
+	board        string
+	startedAt    *time.Time
+
+	intra, inter, slow, gps, home frameDef
+
+	frameIntervalI      int
+	frameIntervalPNum   int
+	frameIntervalPDenom int
+	minThrottle         int64
+	minMotor            int64
+	vbatRef             int64
+	gyroScale           float64
+}
+
+// readHeader reads header lines, which start with "H ", up to the first
+// frame. Only read errors are returned; a header cut short by the end of
+// the file is returned as far as it goes.
+func readHeader(r *bufio.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	for {
+		prefix, err := r.Peek(2)
+		if err == io.EOF || (err == nil && string(prefix) != "H ") {
+			return values, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, err := readLine(r)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if name, value, ok := strings.Cut(strings.TrimPrefix(line, "H "), ":"); ok {
+			values[name] = value
+		}
+	}
+}
+
+// newHeader interprets a log's header values
+func newHeader(values map[string]string) (*header, error) {
+	h := &header{
+		values:              values,
+		frameIntervalI:      32,
+		frameIntervalPNum:   1,
+		frameIntervalPDenom: 1,
+		gyroScale:           1,
+	}
+
+	h.firmware = h.values["Firmware revision"]
+	h.firmwareType = h.values["Firmware type"]
+	h.craftName = strings.TrimSpace(h.values["Craft name"])
+	h.board = strings.TrimSpace(h.values["Board information"])
+	if started, err := time.Parse("2006-01-02T15:04:05.000-07:00", h.values["Log start datetime"]); err == nil && started.Year() >= 2000 {
+		started = started.UTC()
+		h.startedAt = &started
+	}
+
+	if v := h.values["Data version"]; v != "" && v != "2" {
+		return nil, fmt.Errorf("unsupported blackbox data version %s", v)
+	}
+	var err error
+	if h.intra, err = h.frameDef("I", "I"); err != nil {
+		return nil, err
+	}
+	if len(h.intra.names) == 0 {
+		return nil, errors.New("log header has no field definitions")
+	}
+	if h.inter, err = h.frameDef("I", "P"); err != nil {
+		return nil, err
+	}
+	if h.slow, err = h.frameDef("S", "S"); err != nil {
+		return nil, err
+	}
+	if h.gps, err = h.frameDef("G", "G"); err != nil {
+		return nil, err
+	}
+	if h.home, err = h.frameDef("H", "H"); err != nil {
+		return nil, err
+	}
+
+	if v, err := strconv.Atoi(h.values["I interval"]); err == nil && v > 0 {
+		h.frameIntervalI = v
+	}
+	if v := h.values["P interval"]; v != "" {
+		num, denom, ok := strings.Cut(v, "/")
+		if !ok {
+			num, denom = "1", v
+		}
+		n, errN := strconv.Atoi(num)
+		d, errD := strconv.Atoi(denom)
+		if errN == nil && errD == nil && n > 0 && d > 0 {
+			h.frameIntervalPNum, h.frameIntervalPDenom = n, d
+		}
+	}
+	h.minThrottle = headerInt(h.values["minthrottle"])
+	h.vbatRef = headerInt(h.values["vbatref"])
+	if motorOutput, _, _ := strings.Cut(h.values["motorOutput"], ","); motorOutput != "" {
+		h.minMotor = headerInt(motorOutput)
+	} else {
+		h.minMotor = h.minThrottle
+	}
+	if v := h.values["gyro_scale"]; v != "" {
+		if bits, err := strconv.ParseUint(strings.TrimPrefix(v, "0x"), 16, 32); err == nil {
+			if scale := math.Float32frombits(uint32(bits)); scale > 0 {
+				h.gyroScale = float64(scale)
+			}
+		}
+	}
+	return h, nil
+}
+
+// frameDef reads the field definitions for a frame type. P frames take
+// their names and signedness from the I frame.
+func (h *header) frameDef(namesFrom, frameType string) (frameDef, error) {
+	var def frameDef
+	if names := h.values["Field "+namesFrom+" name"]; names != "" {
+		def.names = strings.Split(names, ",")
+	}
+	if len(def.names) == 0 {
+		return def, nil
+	}
+	signed := headerInts(h.values["Field "+namesFrom+" signed"])
+	def.signed = make([]bool, len(def.names))
+	for i := range def.signed {
+		def.signed[i] = i < len(signed) && signed[i] != 0
+	}
+	def.predictors = headerInts(h.values["Field "+frameType+" predictor"])
+	def.encodings = headerInts(h.values["Field "+frameType+" encoding"])
+	if len(def.predictors) != len(def.names) || len(def.encodings) != len(def.names) {
+		if frameType != namesFrom && len(def.predictors) == 0 && len(def.encodings) == 0 {
+			// Logs with only I frames
+			return frameDef{}, nil
+		}
+		return def, fmt.Errorf("field %s definitions don't match its %d names", frameType, len(def.names))
+	}
+	return def, nil
+}
+
+// shouldHaveFrame reports whether the main frame for a loop iteration was
+// logged, given the log's I and P intervals
+func (h *header) shouldHaveFrame(iteration int64) bool {
+	return (iteration%int64(h.frameIntervalI)+int64(h.frameIntervalPNum)-1)%int64(h.frameIntervalPDenom) < int64(h.frameIntervalPNum)
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	var line []byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return string(line), err
+		}
+		if b == '\n' {
+			return strings.TrimSuffix(string(line), "\r"), nil
+		}
+		if len(line) < maxHeaderLine {
+			line = append(line, b)
+		}
+	}
+}
+
+func headerInt(s string) int64 {
+	v, _ := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	return v
+}
+
+func headerInts(s string) []int {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	values := make([]int, len(parts))
+	for i, part := range parts {
+		values[i], _ = strconv.Atoi(strings.TrimSpace(part))
+	}
+	return values
+}
+
+// frameSink receives the main frames of one log that decoded cleanly
+type frameSink interface {
+	mainFrame(values []int64)
+}
+
+// decoder reads the logs in a file one frame at a time. A frame that
+// doesn't decode, or isn't followed by the start of another frame, is
+// dropped, and inter frames are ignored until the next intra frame since
+// they are predicted from the frames before them.
+type decoder struct {
+	r         *bufio.Reader
+	frameLen  int
+	corrupt   int // frames and stray bytes dropped
+	warnings  []string
+	newLog    func(h *header) frameSink
+	log       *header
+	sink      frameSink
+	ended     bool
+	valid     bool // whether the main frame history can predict an inter frame
+	current   []int64
+	previous  []int64
+	previous2 []int64
+	scratch   []int64
+
+	lastIteration int64
+	lastTime      int64
+	haveMain      bool
+	motor0        int
+	iterationIdx  int
+	timeIdx       int
+}
+
+func newDecoder(r io.Reader, newLog func(h *header) frameSink) *decoder {
+	return &decoder{r: bufio.NewReaderSize(r, 64*1024), newLog: newLog}
+}
+
+// run decodes the whole file. It only fails if reading does.
+func (d *decoder) run() error {
+	for {
+		b, err := d.r.Peek(1)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if b[0] == 'H' {
+			start, _ := d.r.Peek(len(logStart))
+			if string(start) == logStart {
+				if err := d.startLog(); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if d.log == nil || d.ended || b[0] == 0xFF || b[0] == 0x00 {
+			// Between logs, unused flash after the last one, or padding.
+			// Neither byte starts a frame, so they aren't counted as
+			// corrupt, but the frames around them aren't contiguous.
+			d.valid = false
+			if _, err := d.r.ReadByte(); err != nil {
+				return readErr(err)
+			}
+			continue
+		}
+
+		if err := d.frame(b[0]); err != nil {
+			if errors.Is(err, errCorruptFrame) || err == io.ErrUnexpectedEOF {
+				d.corrupt++
+				d.valid = false
+				continue
+			}
+			return err
+		}
+	}
+}
+
+func (d *decoder) startLog() error {
+	values, err := readHeader(d.r)
+	if err != nil {
+		return err
+	}
+	h, err := newHeader(values)
+	if err != nil {
+		d.warnings = append(d.warnings, fmt.Sprintf("skipped a log: %v", err))
+		d.log = nil
+		return nil
+	}
+	d.log = h
+	d.sink = d.newLog(h)
+	d.ended = false
+	d.valid = false
+	d.haveMain = false
+	n := len(h.intra.names)
+	d.current, d.previous, d.previous2, d.scratch = make([]int64, n), make([]int64, n), make([]int64, n), make([]int64, 8)
+	d.motor0 = h.intra.index("motor[0]")
+	d.iterationIdx = h.intra.index("loopIteration")
+	d.timeIdx = h.intra.index("time")
+	return nil
+}
+
+// frame decodes the frame starting at the next byte, which is its type
+func (d *decoder) frame(frameType byte) error {
+	d.frameLen = 0
+	if _, err := d.readByte(); err != nil {
+		return err
+	}
+
+	var err error
+	switch {
+	case frameType == 'I':
+		err = d.mainFrame(&d.log.intra, true)
+	case frameType == 'P' && len(d.log.inter.names) > 0:
+		err = d.mainFrame(&d.log.inter, false)
+	case frameType == 'E':
+		err = d.event()
+	case frameType == 'S' && len(d.log.slow.names) > 0:
+		err = d.decodeFields(&d.log.slow, make([]int64, len(d.log.slow.names)), nil, nil, 0)
+	case frameType == 'G' && len(d.log.gps.names) > 0:
+		err = d.decodeFields(&d.log.gps, make([]int64, len(d.log.gps.names)), nil, nil, 0)
+	case frameType == 'H' && len(d.log.home.names) > 0:
+		err = d.decodeFields(&d.log.home, make([]int64, len(d.log.home.names)), nil, nil, 0)
+	default:
+		return errCorruptFrame
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// mainFrame decodes an intra (I) or inter (P) frame, which carry the
+// loop-rate data, and hands it on if it decoded cleanly
+func (d *decoder) mainFrame(def *frameDef, intra bool) error {
+	skipped := 0
+	previous, previous2 := d.previous, d.previous2
+	if intra {
+		if !d.haveMain {
+			previous = nil
+		}
+		previous2 = nil
+	} else {
+		if !d.valid {
+			previous, previous2 = nil, nil
+		}
+		skipped = d.skippedFrames()
+	}
+
+	if err := d.decodeFields(def, d.current, previous, previous2, skipped); err != nil {
+		return err
+	}
+	if err := d.frameEnd(); err != nil {
+		return err
+	}
+	if !intra && !d.valid {
+		// Predicted from frames that were lost
+		return nil
+	}
+
+	if d.iterationIdx >= 0 && d.timeIdx >= 0 && d.haveMain {
+		iteration, t := d.current[d.iterationIdx], d.current[d.timeIdx]
+		if iteration < d.lastIteration || t < d.lastTime {
+			return errCorruptFrame
+		}
+	}
+
+	if intra {
+		copy(d.previous2, d.current)
+	} else {
+		copy(d.previous2, d.previous)
+	}
+	copy(d.previous, d.current)
+	d.valid = true
+	d.haveMain = true
+	if d.iterationIdx >= 0 {
+		d.lastIteration = d.current[d.iterationIdx]
+	}
+	if d.timeIdx >= 0 {
+		d.lastTime = d.current[d.timeIdx]
+	}
+	d.sink.mainFrame(d.current)
+	return nil
+}
+
+// skippedFrames counts the loop iterations since the last main frame that
+// the log's P interval left out on purpose
+func (d *decoder) skippedFrames() int {
+	if !d.haveMain || d.iterationIdx < 0 {
+		return 0
+	}
+	count := 0
+	for i := d.lastIteration + 1; !d.log.shouldHaveFrame(i) && count < 1<<16; i++ {
+		count++
+	}
+	return count
+}
+
+// event decodes an event frame. Only the end of the log changes decoding.
+func (d *decoder) event() error {
+	eventType, err := d.readByte()
+	if err != nil {
+		return err
+	}
+	switch eventType {
+	case 0: // sync beep
+		_, err = d.readUnsignedVB()
+	case 13: // in-flight adjustment
+		var function byte
+		if function, err = d.readByte(); err == nil {
+			if function < 128 {
+				_, err = d.readSignedVB()
+			} else {
+				_, err = d.readBytes(4)
+			}
+		}
+	case 14: // logging resumed after a pause
+		var iteration, t uint32
+		if iteration, err = d.readUnsignedVB(); err == nil {
+			if t, err = d.readUnsignedVB(); err == nil {
+				d.lastIteration, d.lastTime = int64(iteration), int64(t)
+			}
+		}
+	case 15: // disarm
+		_, err = d.readUnsignedVB()
+	case 30: // flight mode change
+		if _, err = d.readUnsignedVB(); err == nil {
+			_, err = d.readUnsignedVB()
+		}
+	case 255: // log end
+		var message []byte
+		if message, err = d.readBytes(len(logEndMessage)); err == nil {
+			if string(message) != logEndMessage {
+				return errCorruptFrame
+			}
+			d.ended = true
+			return nil
+		}
+	default:
+		return errCorruptFrame
+	}
+	if err != nil {
+		return err
+	}
+	return d.frameEnd()
+}
+
+// frameEnd checks that a frame is a plausible length and is followed by
+// another frame or the end of the file
+func (d *decoder) frameEnd() error {
+	if d.frameLen > maxFrameLength {
+		return errCorruptFrame
+	}
+	next, err := d.r.Peek(1)
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if bytes.IndexByte([]byte("IPESGH"), next[0]) < 0 {
+		return errCorruptFrame
+	}
+	return nil
+}
+
+// decodeFields reads and predicts every field of a frame into values
+func (d *decoder) decodeFields(def *frameDef, values, previous, previous2 []int64, skipped int) error {
+	for i := 0; i < len(def.names); {
+		if def.predictors[i] == predictIncrement {
+			values[i] = int64(skipped) + 1
+			if previous != nil {
+				values[i] += previous[i]
+			}
+			i++
+			continue
+		}
+
+		raw := d.scratch[:1]
+		var err error
+		switch def.encodings[i] {
+		case encodeSignedVB:
+			var v int32
+			v, err = d.readSignedVB()
+			raw[0] = int64(v)
+		case encodeUnsignedVB:
+			var v uint32
+			v, err = d.readUnsignedVB()
+			raw[0] = int64(v)
+		case encodeNeg14Bit:
+			var v uint32
+			v, err = d.readUnsignedVB()
+			raw[0] = -signExtend(v, 14)
+		case encodeTag8_4S16:
+			raw = d.scratch[:4]
+			err = d.readTag8_4S16(raw)
+		case encodeTag2_3S32:
+			raw = d.scratch[:3]
+			err = d.readTag2_3S32(raw)
+		case encodeTag2_3SVariable:
+			raw = d.scratch[:3]
+			err = d.readTag2_3SVariable(raw)
+		case encodeTag8_8SVB:
+			group := 1
+			for group < 8 && i+group < len(def.names) && def.encodings[i+group] == encodeTag8_8SVB {
+				group++
+			}
+			raw = d.scratch[:group]
+			err = d.readTag8_8SVB(raw)
+		case encodeNull:
+			raw[0] = 0
+		default:
+			return errCorruptFrame
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, v := range raw {
+			if i >= len(def.names) {
+				break
+			}
+			predicted, err := d.predict(def, i, v, values, previous, previous2)
+			if err != nil {
+				return err
+			}
+			values[i] = predicted
+			i++
+		}
+	}
+	return nil
+}
+
+func (d *decoder) predict(def *frameDef, i int, value int64, current, previous, previous2 []int64) (int64, error) {
+	switch def.predictors[i] {
+	case predictZero:
+	case predictPrevious:
+		if previous != nil {
+			value += previous[i]
+		}
+	case predictStraightLine:
+		if previous != nil && previous2 != nil {
+			value += 2*previous[i] - previous2[i]
+		}
+	case predictAverage2:
+		if previous != nil && previous2 != nil {
+			value += (previous[i] + previous2[i]) / 2
+		}
+	case predictMinThrottle:
+		value += d.log.minThrottle
+	case predictMotor0:
+		if d.motor0 >= 0 && d.motor0 < i {
+			value += current[d.motor0]
+		}
+	case predict1500:
+		value += 1500
+	case predictVBatRef:
+		value += d.log.vbatRef
+	case predictMinMotor:
+		value += d.log.minMotor
+	case predictHomeCoord, predictLastMainFrameTime:
+		// Only GPS frames use these, and their values aren't summarized
+	default:
+		return 0, errCorruptFrame
+	}
+	return value, nil
+}
+
+func (d *decoder) readByte() (byte, error) {
+	b, err := d.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	d.frameLen++
+	return b, nil
+}
+
+func (d *decoder) readBytes(n int) ([]byte, error) {
+	buf := make([]byte, n)
+	for i := range buf {
+		b, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		buf[i] = b
+	}
+	return buf, nil
+}
+
+// readUnsignedVB reads a variable-length integer: 7 bits per byte, low
+// bits first, with the top bit set on every byte but the last
+func (d *decoder) readUnsignedVB() (uint32, error) {
+	var result uint32
+	for shift := 0; shift < 35; shift += 7 {
+		b, err := d.readByte()
+		if err != nil {
+			return 0, err
+		}
+		result |= uint32(b&0x7F) << shift
+		if b < 0x80 {
+			return result, nil
+		}
+	}
+	return 0, errCorruptFrame
+}
+
+// readSignedVB reads a zig-zag encoded variable-length integer
+func (d *decoder) readSignedVB() (int32, error) {
+	u, err := d.readUnsignedVB()
+	if err != nil {
+		return 0, err
+	}
+	return int32(u>>1) ^ -int32(u&1), nil
+}
+
+// readTag8_4S16 reads four values whose widths (0, 4, 8 or 16 bits) are
+// given by a leading byte. Values are packed as nibbles, so an 8 or 16 bit
+// value can start halfway through a byte.
+func (d *decoder) readTag8_4S16(values []int64) error {
+	selector, err := d.readByte()
+	if err != nil {
+		return err
+	}
+	var buffer byte
+	halfByte := false
+	for i := 0; i < 4; i++ {
+		switch selector & 0x03 {
+		case 0:
+			values[i] = 0
+		case 1: // 4 bits
+			if !halfByte {
+				if buffer, err = d.readByte(); err != nil {
+					return err
+				}
+				values[i] = signExtend(uint32(buffer>>4), 4)
+			} else {
+				values[i] = signExtend(uint32(buffer&0x0F), 4)
+			}
+			halfByte = !halfByte
+		case 2: // 8 bits
+			b, err := d.readByte()
+			if err != nil {
+				return err
+			}
+			if !halfByte {
+				values[i] = signExtend(uint32(b), 8)
+			} else {
+				values[i] = signExtend(uint32(buffer&0x0F)<<4|uint32(b>>4), 8)
+				buffer = b
+			}
+		case 3: // 16 bits
+			b1, err := d.readByte()
+			if err != nil {
+				return err
+			}
+			b2, err := d.readByte()
+			if err != nil {
+				return err
+			}
+			if !halfByte {
+				values[i] = signExtend(uint32(b1)<<8|uint32(b2), 16)
+			} else {
+				values[i] = signExtend(uint32(buffer&0x0F)<<12|uint32(b1)<<4|uint32(b2>>4), 16)
+				buffer = b2
+			}
+		}
+		selector >>= 2
+	}
+	return nil
+}
+
+// readTag2_3S32 reads three values sharing a width given by the top two
+// bits of the first byte: 2, 4 or 6 bits each, or a width per value
+func (d *decoder) readTag2_3S32(values []int64) error {
+	lead, err := d.readByte()
+	if err != nil {
+		return err
+	}
+	switch lead >> 6 {
+	case 0:
+		values[0] = signExtend(uint32(lead>>4)&0x03, 2)
+		values[1] = signExtend(uint32(lead>>2)&0x03, 2)
+		values[2] = signExtend(uint32(lead)&0x03, 2)
+	case 1:
+		values[0] = signExtend(uint32(lead)&0x0F, 4)
+		b, err := d.readByte()
+		if err != nil {
+			return err
+		}
+		values[1] = signExtend(uint32(b>>4), 4)
+		values[2] = signExtend(uint32(b)&0x0F, 4)
+	case 2:
+		values[0] = signExtend(uint32(lead)&0x3F, 6)
+		for i := 1; i < 3; i++ {
+			b, err := d.readByte()
+			if err != nil {
+				return err
+			}
+			values[i] = signExtend(uint32(b)&0x3F, 6)
+		}
+	case 3:
+		return d.readVariableWidths(lead, values)
+	}
+	return nil
+}
+
+// readTag2_3SVariable is like readTag2_3S32 but packs its middle widths
+// as 5/5/4 and 8/7/7 bits
+func (d *decoder) readTag2_3SVariable(values []int64) error {
+	lead, err := d.readByte()
+	if err != nil {
+		return err
+	}
+	switch lead >> 6 {
+	case 0:
+		values[0] = signExtend(uint32(lead>>4)&0x03, 2)
+		values[1] = signExtend(uint32(lead>>2)&0x03, 2)
+		values[2] = signExtend(uint32(lead)&0x03, 2)
+	case 1:
+		b, err := d.readByte()
+		if err != nil {
+			return err
+		}
+		values[0] = signExtend(uint32(lead&0x3E)>>1, 5)
+		values[1] = signExtend(uint32(lead&0x01)<<4|uint32(b&0xF0)>>4, 5)
+		values[2] = signExtend(uint32(b&0x0F), 4)
+	case 2:
+		b1, err := d.readByte()
+		if err != nil {
+			return err
+		}
+		b2, err := d.readByte()
+		if err != nil {
+			return err
+		}
+		values[0] = signExtend(uint32(lead&0x3F)<<2|uint32(b1&0xC0)>>6, 8)
+		values[1] = signExtend(uint32(b1&0x3F)<<1|uint32(b2&0x80)>>7, 7)
+		values[2] = signExtend(uint32(b2&0x7F), 7)
+	case 3:
+		return d.readVariableWidths(lead, values)
+	}
+	return nil
+}
+
+// readVariableWidths reads three little-endian values of 8 to 32 bits, with
+// each width given by two bits of lead
+func (d *decoder) readVariableWidths(lead byte, values []int64) error {
+	for i := 0; i < 3; i++ {
+		width := int(lead&0x03) + 1
+		var v uint32
+		for j := 0; j < width; j++ {
+			b, err := d.readByte()
+			if err != nil {
+				return err
+			}
+			v |= uint32(b) << (8 * j)
+		}
+		values[i] = signExtend(v, 8*width)
+		lead >>= 2
+	}
+	return nil
+}
+
+// readTag8_8SVB reads up to eight signed values. A leading byte flags
+// which are non-zero, unless there's only one value.
+func (d *decoder) readTag8_8SVB(values []int64) error {
+	if len(values) == 1 {
+		v, err := d.readSignedVB()
+		values[0] = int64(v)
+		return err
+	}
+	flags, err := d.readByte()
+	if err != nil {
+		return err
+	}
+	for i := range values {
+		values[i] = 0
+		if flags&0x01 != 0 {
+			v, err := d.readSignedVB()
+			if err != nil {
+				return err
+			}
+			values[i] = int64(v)
+		}
+		flags >>= 1
+	}
+	return nil
+}
+
+// signExtend treats the low bits of v as a two's complement number
+func signExtend(v uint32, bits int) int64 {
+	shift := 32 - bits
+	return int64(int32(v<<shift) >> shift)
+}
+
+func readErr(err error) error {
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,243 @@
+package blackbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/blobstore"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// MaxFileSize is the largest blackbox file accepted (256MB), enough for a
+// long SD card session
+const MaxFileSize = 256 * 1024 * 1024
+
+// ServiceError represents a service-level error. Code is set for errors
+// handlers map to something other than a 400.
+type ServiceError struct {
+	Code    apierror.Code
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
+
+// ErrorCode returns the error's API error code
+func (e *ServiceError) ErrorCode() apierror.Code {
+	return e.Code
+}
+
+// Service stores blackbox files and the summaries parsed from them
+type Service struct {
+	store         *database.BlackboxStore
+	aircraftStore *database.AircraftStore
+	storage       blobstore.Store
+	logger        *logging.Logger
+}
+
+// NewService creates a new blackbox service
+func NewService(store *database.BlackboxStore, aircraftStore *database.AircraftStore, storage blobstore.Store, logger *logging.Logger) *Service {
+	return &Service{
+		store:         store,
+		aircraftStore: aircraftStore,
+		storage:       storage,
+		logger:        logger,
+	}
+}
+
+// Create stores an uploaded blackbox file and summarizes it. The file is
+// parsed as it streams to storage, so it's only read once. Files without a
+// readable log are rejected.
+func (s *Service) Create(ctx context.Context, userID string, params models.CreateBlackboxLogParams, file io.Reader) (*models.BlackboxLog, error) {
+	if params.FileName == "" {
+		return nil, &ServiceError{Message: "file name is required"}
+	}
+	if err := s.checkAircraft(ctx, userID, params.AircraftID); err != nil {
+		return nil, err
+	}
+
+	type parsed struct {
+		summary *Summary
+		err     error
+	}
+	pr, pw := io.Pipe()
+	done := make(chan parsed, 1)
+	go func() {
+		summary, err := Summarize(pr)
+		// Keep draining so storage isn't blocked if parsing stopped early
+		_, _ = io.Copy(io.Discard, pr)
+		done <- parsed{summary, err}
+	}()
+
+	// Reading one byte past the limit detects oversized uploads
+	key := fmt.Sprintf("blackbox_logs/%s/%s-%s", userID, uuid.NewString(), sanitizeFileName(params.FileName))
+	object, err := s.storage.Put(ctx, key, io.TeeReader(io.LimitReader(file, MaxFileSize+1), pw))
+	pw.CloseWithError(err)
+	result := <-done
+	if err != nil {
+		s.logger.Error("Failed to store blackbox file", logging.WithField("error", err.Error()))
+		return nil, &ServiceError{Message: "failed to store blackbox file"}
+	}
+
+	if object.Size > MaxFileSize {
+		s.discardObject(key)
+		return nil, &ServiceError{Message: fmt.Sprintf("file size exceeds maximum allowed (%d bytes)", MaxFileSize)}
+	}
+	if params.Checksum != "" && !strings.EqualFold(params.Checksum, object.Checksum) {
+		s.discardObject(key)
+		return nil, &ServiceError{Message: fmt.Sprintf("checksum mismatch: received file has SHA-256 %s", object.Checksum)}
+	}
+	if result.err != nil || len(result.summary.Flights) == 0 {
+		s.discardObject(key)
+		return nil, &ServiceError{Message: "file doesn't contain a readable blackbox log"}
+	}
+
+	log := &models.BlackboxLog{
+		UserID:         userID,
+		AircraftID:     params.AircraftID,
+		FileName:       params.FileName,
+		FileSize:       object.Size,
+		Checksum:       object.Checksum,
+		Notes:          params.Notes,
+		Firmware:       result.summary.Flights[0].Firmware,
+		CraftName:      result.summary.Flights[0].CraftName,
+		ParseStatus:    models.ParseStatusSuccess,
+		ParseWarnings:  result.summary.Warnings,
+		Flights:        result.summary.Flights,
+		StorageBackend: s.storage.Name(),
+		StoragePath:    key,
+	}
+	if len(log.ParseWarnings) > 0 {
+		log.ParseStatus = models.ParseStatusPartial
+	}
+	for _, flight := range log.Flights {
+		log.DurationSeconds += flight.DurationSeconds
+	}
+
+	if err := s.store.Create(ctx, log); err != nil {
+		s.discardObject(key)
+		s.logger.Error("Failed to create blackbox log record", logging.WithField("error", err.Error()))
+		return nil, err
+	}
+
+	s.logger.Info("Created blackbox log", logging.WithFields(map[string]interface{}{
+		"id":      log.ID,
+		"flights": len(log.Flights),
+		"status":  log.ParseStatus,
+	}))
+	return log, nil
+}
+
+// List lists a user's blackbox logs
+func (s *Service) List(ctx context.Context, userID string, params models.BlackboxLogListParams) (*models.BlackboxLogListResponse, error) {
+	return s.store.List(ctx, userID, params)
+}
+
+// Get retrieves one of a user's blackbox logs
+func (s *Service) Get(ctx context.Context, id, userID string) (*models.BlackboxLog, error) {
+	return s.store.Get(ctx, id, userID)
+}
+
+// GetFile returns a seekable reader over a blackbox file, for serving range
+// requests
+func (s *Service) GetFile(ctx context.Context, id, userID string) (*blobstore.ReadSeeker, *models.BlackboxLog, error) {
+	log, err := s.store.Get(ctx, id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if log == nil {
+		return nil, nil, &ServiceError{Code: apierror.BlackboxLogNotFound, Message: "blackbox log not found"}
+	}
+	if log.StorageBackend != s.storage.Name() {
+		s.logger.Error("Blackbox log is in an unavailable store", logging.WithFields(map[string]interface{}{
+			"id":      log.ID,
+			"backend": log.StorageBackend,
+		}))
+		return nil, nil, &ServiceError{Code: apierror.BlackboxLogNotFound, Message: "blackbox file not found"}
+	}
+
+	file := blobstore.NewReadSeeker(ctx, s.storage, log.StoragePath, log.FileSize)
+	if err := file.Open(); err != nil {
+		s.logger.Error("Failed to open blackbox file", logging.WithFields(map[string]interface{}{
+			"path":  log.StoragePath,
+			"error": err.Error(),
+		}))
+		return nil, nil, &ServiceError{Code: apierror.BlackboxLogNotFound, Message: "blackbox file not found"}
+	}
+	return file, log, nil
+}
+
+// Delete deletes a blackbox log and its file
+func (s *Service) Delete(ctx context.Context, id, userID string) error {
+	log, err := s.store.Delete(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if log == nil {
+		return &ServiceError{Code: apierror.BlackboxLogNotFound, Message: "blackbox log not found"}
+	}
+
+	if log.StorageBackend == s.storage.Name() {
+		if err := s.storage.Delete(ctx, log.StoragePath); err != nil {
+			s.logger.Warn("Failed to delete blackbox file", logging.WithFields(map[string]interface{}{
+				"path":  log.StoragePath,
+				"error": err.Error(),
+			}))
+		}
+	}
+
+	s.logger.Info("Deleted blackbox log", logging.WithField("id", id))
+	return nil
+}
+
+// checkAircraft verifies that an optional aircraft belongs to the user
+func (s *Service) checkAircraft(ctx context.Context, userID, aircraftID string) error {
+	if aircraftID == "" {
+		return nil
+	}
+	aircraft, err := s.aircraftStore.Get(ctx, aircraftID, userID)
+	if err != nil {
+		return err
+	}
+	if aircraft == nil {
+		return &ServiceError{Code: apierror.AircraftNotFound, Message: "aircraft not found"}
+	}
+	return nil
+}
+
+// discardObject removes an upload that was rejected after it was stored.
+// It runs on its own context since the request's may have been cancelled.
+func (s *Service) discardObject(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := s.storage.Delete(ctx, key); err != nil {
+		s.logger.Warn("Failed to discard rejected blackbox file", logging.WithFields(map[string]interface{}{
+			"path":  key,
+			"error": err.Error(),
+		}))
+	}
+}
+
+// sanitizeFileName replaces characters that aren't safe in a storage key
+// and limits the name's length
+func sanitizeFileName(name string) string {
+	safe := strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`<>:"/\|?*`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if len(safe) > 200 {
+		safe = safe[len(safe)-200:]
+	}
+	return safe
+}
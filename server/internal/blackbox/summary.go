@@ -0,0 +1,424 @@
+package blackbox
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	// fftSize is the number of gyro samples in each spectrum segment
+	fftSize = 1024
+	// maxFrameGap is the longest gap between frames, in microseconds, that
+	// still counts as flying; longer ones are pauses in logging
+	maxFrameGap = 1_000_000
+	// sagWindow is how far back, in microseconds, voltage sag is measured
+	// from
+	sagWindow = 1_000_000
+	// noisePeakMinHz is where the noise peak search starts. Below it is
+	// mostly the pilot's own stick movement.
+	noisePeakMinHz = 100
+)
+
+// noiseBandEdges split the gyro spectrum into bands, up to half the
+// sample rate
+var noiseBandEdges = []float64{0, 100, 300, 600, 1000, 2000, 4000}
+
+var gyroAxes = []string{"roll", "pitch", "yaw"}
+
+// Summary is what a blackbox file holds
+type Summary struct {
+	Flights  []models.BlackboxFlight
+	Warnings []string
+}
+
+// Summarize reads every log in a blackbox file and summarizes each as a
+// flight. Damaged parts of a file are skipped with a warning; an error is
+// only returned if reading fails.
+func Summarize(r io.Reader) (*Summary, error) {
+	var flights []*flightSummary
+	d := newDecoder(r, func(h *header) frameSink {
+		f := newFlightSummary(h)
+		flights = append(flights, f)
+		return f
+	})
+	if err := d.run(); err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{Flights: []models.BlackboxFlight{}, Warnings: d.warnings}
+	for i, f := range flights {
+		if f.frames == 0 {
+			summary.Warnings = append(summary.Warnings, fmt.Sprintf("log %d has no readable frames", i+1))
+			continue
+		}
+		flight := f.flight()
+		flight.Index = i + 1
+		summary.Flights = append(summary.Flights, flight)
+	}
+	if d.corrupt > 0 {
+		summary.Warnings = append(summary.Warnings, fmt.Sprintf("skipped %d corrupt frames", d.corrupt))
+	}
+	if len(flights) == 0 && len(d.warnings) == 0 {
+		summary.Warnings = append(summary.Warnings, "no blackbox logs found")
+	}
+	for _, f := range flights {
+		if f.frames > 0 && f.vbatIdx >= 0 && f.voltScale == 0 {
+			summary.Warnings = append(summary.Warnings, fmt.Sprintf("battery units aren't known for %q; voltage and current are left out", f.h.firmware))
+			break
+		}
+	}
+	return summary, nil
+}
+
+// flightSummary accumulates the statistics of one log
+type flightSummary struct {
+	h *header
+
+	timeIdx    int
+	currentIdx int
+	vbatIdx    int
+	gyroIdx    [3]int
+
+	voltScale    float64 // vbat units per volt, or 0 if not known
+	currentScale float64 // amperage units per amp, or 0 if not known
+
+	frames   int
+	haveTime bool
+	lastTime int64
+	duration int64         // microseconds
+	gaps     map[int64]int // how often each gap between frames occurs
+
+	maxCurrent   float64
+	currentSum   float64
+	currentCount int
+
+	startVoltage float64
+	minVoltage   float64
+	voltageCount int
+	maxSag       float64
+	recent       []voltageSample // decreasing voltage over the last sagWindow
+
+	noise [3]*spectrum
+}
+
+type voltageSample struct {
+	time  int64
+	volts float64
+}
+
+func newFlightSummary(h *header) *flightSummary {
+	f := &flightSummary{
+		h:          h,
+		timeIdx:    h.intra.index("time"),
+		currentIdx: firstIndex(&h.intra, "amperageLatest", "amperage"),
+		vbatIdx:    firstIndex(&h.intra, "vbatLatest", "vbat"),
+		gaps:       make(map[int64]int),
+	}
+	f.voltScale, f.currentScale = batteryScales(h.firmware)
+	for axis := range f.gyroIdx {
+		f.gyroIdx[axis] = h.intra.index(fmt.Sprintf("gyroADC[%d]", axis))
+		if f.gyroIdx[axis] >= 0 {
+			f.noise[axis] = &spectrum{}
+		}
+	}
+	return f
+}
+
+func (f *flightSummary) mainFrame(values []int64) {
+	f.frames++
+
+	if f.timeIdx >= 0 {
+		t := values[f.timeIdx]
+		if f.haveTime && t > f.lastTime && t-f.lastTime <= maxFrameGap {
+			f.duration += t - f.lastTime
+			if len(f.gaps) < 10000 {
+				f.gaps[t-f.lastTime]++
+			}
+		} else if f.haveTime {
+			// Logging paused: the frames either side aren't contiguous
+			for _, s := range f.noise {
+				if s != nil {
+					s.reset()
+				}
+			}
+			f.recent = f.recent[:0]
+		}
+		f.lastTime, f.haveTime = t, true
+	}
+
+	if f.currentIdx >= 0 && f.currentScale > 0 {
+		amps := float64(values[f.currentIdx]) / f.currentScale
+		f.maxCurrent = math.Max(f.maxCurrent, amps)
+		f.currentSum += amps
+		f.currentCount++
+	}
+
+	if f.vbatIdx >= 0 && f.voltScale > 0 && values[f.vbatIdx] > 0 {
+		f.addVoltage(float64(values[f.vbatIdx]) / f.voltScale)
+	}
+
+	for axis, s := range f.noise {
+		if s != nil {
+			s.add(float64(values[f.gyroIdx[axis]]) * f.h.gyroScale)
+		}
+	}
+}
+
+// addVoltage tracks the minimum and the sag: how far the voltage is below
+// its highest point over the previous second
+func (f *flightSummary) addVoltage(volts float64) {
+	if f.voltageCount == 0 {
+		f.startVoltage, f.minVoltage = volts, volts
+	}
+	f.voltageCount++
+	f.minVoltage = math.Min(f.minVoltage, volts)
+
+	t := f.lastTime
+	for len(f.recent) > 0 && f.recent[0].time < t-sagWindow {
+		f.recent = f.recent[1:]
+	}
+	if len(f.recent) > 0 {
+		f.maxSag = math.Max(f.maxSag, f.recent[0].volts-volts)
+	}
+	for len(f.recent) > 0 && f.recent[len(f.recent)-1].volts <= volts {
+		f.recent = f.recent[:len(f.recent)-1]
+	}
+	f.recent = append(f.recent, voltageSample{time: t, volts: volts})
+}
+
+func (f *flightSummary) flight() models.BlackboxFlight {
+	flight := models.BlackboxFlight{
+		Firmware:        f.h.firmware,
+		CraftName:       f.h.craftName,
+		Board:           f.h.board,
+		StartedAt:       f.h.startedAt,
+		DurationSeconds: round(float64(f.duration)/1e6, 1),
+		FrameCount:      f.frames,
+	}
+	sampleRate := 0.0
+	if gap := f.medianGap(); gap > 0 {
+		sampleRate = 1e6 / float64(gap)
+		flight.SampleRateHz = round(sampleRate, 0)
+	}
+
+	if f.currentCount > 0 {
+		flight.MaxCurrentAmps = ptr(round(f.maxCurrent, 2))
+		flight.AvgCurrentAmps = ptr(round(f.currentSum/float64(f.currentCount), 2))
+	}
+	if f.voltageCount > 0 {
+		flight.StartVoltage = ptr(round(f.startVoltage, 2))
+		flight.MinVoltage = ptr(round(f.minVoltage, 2))
+		flight.MaxSagVolts = ptr(round(f.maxSag, 2))
+	}
+
+	for axis, s := range f.noise {
+		if s == nil {
+			continue
+		}
+		if noise, ok := s.summarize(gyroAxes[axis], sampleRate); ok {
+			flight.GyroNoise = append(flight.GyroNoise, noise)
+		}
+	}
+	return flight
+}
+
+// medianGap is the typical time between frames in microseconds. Unlike
+// frames over duration, it isn't thrown off by frames lost to corruption.
+func (f *flightSummary) medianGap() int64 {
+	total := 0
+	gaps := make([]int64, 0, len(f.gaps))
+	for gap, count := range f.gaps {
+		gaps = append(gaps, gap)
+		total += count
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	seen := 0
+	for _, gap := range gaps {
+		seen += f.gaps[gap]
+		if seen*2 >= total {
+			return gap
+		}
+	}
+	return 0
+}
+
+// batteryScales returns how many vbat and amperage units make a volt and an
+// amp for a firmware, or 0 where they aren't known. Betaflight before 4.0
+// logged voltage as raw ADC readings, and before 3.2 current too.
+func batteryScales(firmware string) (volts, amps float64) {
+	fields := strings.Fields(firmware)
+	if len(fields) < 2 {
+		return 0, 0
+	}
+	major, minor := parseVersion(fields[1])
+	switch strings.ToLower(fields[0]) {
+	case "betaflight":
+		if major >= 4 {
+			volts = 100
+		}
+		if major > 3 || (major == 3 && minor >= 2) {
+			amps = 100
+		}
+	case "inav":
+		volts, amps = 100, 100
+	}
+	return volts, amps
+}
+
+func parseVersion(v string) (major, minor int) {
+	parts := strings.SplitN(v, ".", 3)
+	major, _ = strconv.Atoi(parts[0])
+	if len(parts) > 1 {
+		minor, _ = strconv.Atoi(parts[1])
+	}
+	return major, minor
+}
+
+func firstIndex(def *frameDef, names ...string) int {
+	for _, name := range names {
+		if i := def.index(name); i >= 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+func round(v float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(v*scale) / scale
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
+
+// spectrum averages the power spectrum of a signal over fftSize-sample
+// segments (Welch's method), so noise is measured across the whole flight
+type spectrum struct {
+	segment  []float64
+	power    [fftSize/2 + 1]float64
+	segments int
+}
+
+var hannWindow, hannPower = func() ([]float64, float64) {
+	window := make([]float64, fftSize)
+	power := 0.0
+	for i := range window {
+		window[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(fftSize-1))
+		power += window[i] * window[i]
+	}
+	return window, power
+}()
+
+func (s *spectrum) add(v float64) {
+	s.segment = append(s.segment, v)
+	if len(s.segment) == fftSize {
+		s.accumulate()
+		s.segment = s.segment[:0]
+	}
+}
+
+func (s *spectrum) reset() {
+	s.segment = s.segment[:0]
+}
+
+// accumulate adds the segment's one-sided power spectrum, scaled so the
+// bins sum to the segment's mean square
+func (s *spectrum) accumulate() {
+	mean := 0.0
+	for _, v := range s.segment {
+		mean += v
+	}
+	mean /= fftSize
+
+	re := make([]float64, fftSize)
+	im := make([]float64, fftSize)
+	for i, v := range s.segment {
+		re[i] = (v - mean) * hannWindow[i]
+	}
+	fft(re, im)
+
+	norm := float64(fftSize) * hannPower
+	for k := range s.power {
+		p := (re[k]*re[k] + im[k]*im[k]) / norm
+		if k > 0 && k < fftSize/2 {
+			p *= 2
+		}
+		s.power[k] += p
+	}
+	s.segments++
+}
+
+// summarize reports the RMS in each noise band and the strongest frequency
+// above noisePeakMinHz
+func (s *spectrum) summarize(axis string, sampleRate float64) (models.BlackboxGyroNoise, bool) {
+	if s.segments == 0 || sampleRate <= 0 {
+		return models.BlackboxGyroNoise{}, false
+	}
+	binHz := sampleRate / fftSize
+	nyquist := sampleRate / 2
+	noise := models.BlackboxGyroNoise{Axis: axis}
+
+	peak := 0.0
+	for k := 1; k < len(s.power); k++ {
+		if hz := float64(k) * binHz; hz >= noisePeakMinHz && s.power[k] > peak {
+			peak = s.power[k]
+			noise.PeakHz = round(hz, 0)
+		}
+	}
+
+	for i := 0; i+1 < len(noiseBandEdges) && noiseBandEdges[i] < nyquist; i++ {
+		from, to := noiseBandEdges[i], math.Min(noiseBandEdges[i+1], nyquist)
+		meanSquare := 0.0
+		for k := 1; k < len(s.power); k++ {
+			hz := float64(k) * binHz
+			if hz >= from && (hz < to || (to == nyquist && hz <= to)) {
+				meanSquare += s.power[k] / float64(s.segments)
+			}
+		}
+		noise.Bands = append(noise.Bands, models.BlackboxNoiseBand{
+			FromHz:          from,
+			ToHz:            round(to, 0),
+			RMSDegPerSecond: round(math.Sqrt(meanSquare), 2),
+		})
+	}
+	return noise, true
+}
+
+// fft is an in-place radix-2 fast Fourier transform; len(re) must be a
+// power of two
+func fft(re, im []float64) {
+	n := len(re)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			re[i], re[j] = re[j], re[i]
+			im[i], im[j] = im[j], im[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		angle := -2 * math.Pi / float64(size)
+		stepRe, stepIm := math.Cos(angle), math.Sin(angle)
+		for start := 0; start < n; start += size {
+			wRe, wIm := 1.0, 0.0
+			for k := 0; k < size/2; k++ {
+				a, b := start+k, start+k+size/2
+				tRe := re[b]*wRe - im[b]*wIm
+				tIm := re[b]*wIm + im[b]*wRe
+				re[b], im[b] = re[a]-tRe, im[a]-tIm
+				re[a], im[a] = re[a]+tRe, im[a]+tIm
+				wRe, wIm = wRe*stepRe-wIm*stepIm, wRe*stepIm+wIm*stepRe
+			}
+		}
+	}
+}
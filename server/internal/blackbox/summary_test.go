@@ -0,0 +1,256 @@
+package blackbox
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+// logWriter encodes a log the way Betaflight does, for the fields the
+// tests use
+type logWriter struct {
+	buf         bytes.Buffer
+	prev, prev2 []int64
+}
+
+const testHeader = `H Product:Blackbox flight data recorder by Nicholas Sherlock
+H Data version:2
+H I interval:32
+H P interval:1/2
+H Firmware type:Cleanflight
+H Firmware revision:%s
+H Craft name:Test Quad
+H Log start datetime:2024-05-01T10:00:00.000+00:00
+H vbatref:1680
+H motorOutput:48,2047
+H Field I name:loopIteration,time,vbatLatest,amperageLatest,gyroADC[0],gyroADC[1],gyroADC[2],motor[0],motor[1]
+H Field I signed:0,0,0,1,1,1,1,0,0
+H Field I predictor:0,0,9,0,0,0,0,11,5
+H Field I encoding:1,1,3,0,0,0,0,1,1
+H Field P predictor:6,2,1,1,1,1,1,3,3
+H Field P encoding:9,0,6,6,7,7,7,0,0
+`
+
+func (w *logWriter) header(firmware string) {
+	fmt.Fprintf(&w.buf, testHeader, firmware)
+	w.prev, w.prev2 = nil, nil
+}
+
+func (w *logWriter) unsignedVB(v uint32) {
+	for v >= 0x80 {
+		w.buf.WriteByte(byte(v) | 0x80)
+		v >>= 7
+	}
+	w.buf.WriteByte(byte(v))
+}
+
+func (w *logWriter) signedVB(v int64) {
+	w.unsignedVB(uint32((int32(v) << 1) ^ (int32(v) >> 31)))
+}
+
+func (w *logWriter) tag8_8SVB(values ...int64) {
+	flags := byte(0)
+	for i, v := range values {
+		if v != 0 {
+			flags |= 1 << i
+		}
+	}
+	w.buf.WriteByte(flags)
+	for _, v := range values {
+		if v != 0 {
+			w.signedVB(v)
+		}
+	}
+}
+
+func (w *logWriter) tag2_3S32(values ...int64) {
+	fits := func(bits int) bool {
+		for _, v := range values {
+			if v < -(1<<(bits-1)) || v >= 1<<(bits-1) {
+				return false
+			}
+		}
+		return true
+	}
+	switch {
+	case fits(2):
+		w.buf.WriteByte(byte(values[0]&3)<<4 | byte(values[1]&3)<<2 | byte(values[2]&3))
+	case fits(4):
+		w.buf.WriteByte(1<<6 | byte(values[0]&0x0F))
+		w.buf.WriteByte(byte(values[1]&0x0F)<<4 | byte(values[2]&0x0F))
+	case fits(6):
+		w.buf.WriteByte(2<<6 | byte(values[0]&0x3F))
+		w.buf.WriteByte(byte(values[1] & 0x3F))
+		w.buf.WriteByte(byte(values[2] & 0x3F))
+	default:
+		lead := byte(3 << 6)
+		for i := range values {
+			lead |= 1 << (2 * i) // 16 bits each
+		}
+		w.buf.WriteByte(lead)
+		for _, v := range values {
+			w.buf.WriteByte(byte(v))
+			w.buf.WriteByte(byte(v >> 8))
+		}
+	}
+}
+
+// frame writes an I frame, or a P frame predicted from the frames before
+func (w *logWriter) frame(intra bool, v []int64) {
+	if intra {
+		w.buf.WriteByte('I')
+		w.unsignedVB(uint32(v[0]))
+		w.unsignedVB(uint32(v[1]))
+		w.unsignedVB(uint32(-(v[2] - 1680)) & 0x3FFF)
+		for _, x := range v[3:7] {
+			w.signedVB(x)
+		}
+		w.unsignedVB(uint32(v[7] - 48))
+		w.unsignedVB(uint32(v[8] - v[7]))
+		w.prev = append([]int64(nil), v...)
+		w.prev2 = append([]int64(nil), v...)
+		return
+	}
+	p, p2 := w.prev, w.prev2
+	w.buf.WriteByte('P')
+	w.signedVB(v[1] - (2*p[1] - p2[1]))
+	w.tag8_8SVB(v[2]-p[2], v[3]-p[3])
+	w.tag2_3S32(v[4]-p[4], v[5]-p[5], v[6]-p[6])
+	w.signedVB(v[7] - (p[7]+p2[7])/2)
+	w.signedVB(v[8] - (p[8]+p2[8])/2)
+	w.prev2 = w.prev
+	w.prev = append([]int64(nil), v...)
+}
+
+func (w *logWriter) end() {
+	w.buf.WriteString("E\xff" + logEndMessage)
+}
+
+// writeFlight logs a flight at 2 kHz: frames on even loop iterations of a
+// 4 kHz loop, with an I frame every 32 iterations
+func (w *logWriter) writeFlight(seconds float64, values func(t float64) []int64) {
+	for iteration := int64(0); float64(iteration)/4000 < seconds; iteration += 2 {
+		t := float64(iteration) / 4000
+		v := append([]int64{iteration, 1_000_000 + iteration*250}, values(t)...)
+		w.frame(iteration%32 == 0, v)
+		if iteration == 4000 {
+			w.buf.WriteString("E\x1e\x01\x00") // flight mode change
+		}
+		if iteration == 8002 {
+			w.buf.WriteString("X") // a corrupt byte mid-log
+		}
+	}
+	w.end()
+}
+
+func TestSummarize(t *testing.T) {
+	var w logWriter
+	w.buf.WriteString("\xff\xff")
+	w.header("Betaflight 4.4.2 (8b9d8d3) STM32F405")
+	w.writeFlight(10, func(t float64) []int64 {
+		volts, amps := int64(1680), int64(500)
+		if t >= 4 && t < 5 {
+			volts, amps = 1500, 8000
+		}
+		return []int64{
+			volts, amps,
+			int64(math.Round(200 * math.Sin(2*math.Pi*200*t))),
+			int64(math.Round(50 * math.Sin(2*math.Pi*350*t))),
+			int64(math.Round(10 * math.Sin(2*math.Pi*50*t))),
+			1200, 1210,
+		}
+	})
+	w.buf.WriteString("\xff\xff\xff")
+
+	w.header("Betaflight 3.1.0 (1234567) STM32F303")
+	i := int64(0)
+	w.writeFlight(1, func(t float64) []int64 {
+		i++
+		return []int64{1600, 300, i%7 - 3, i%5 - 2, 0, 1100, 1100}
+	})
+
+	summary, err := Summarize(bytes.NewReader(w.buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	if len(summary.Flights) != 2 {
+		t.Fatalf("flights = %d, want 2 (warnings %v)", len(summary.Flights), summary.Warnings)
+	}
+
+	first := summary.Flights[0]
+	if first.CraftName != "Test Quad" || !strings.HasPrefix(first.Firmware, "Betaflight 4.4.2") || first.StartedAt == nil || first.StartedAt.Year() != 2024 {
+		t.Errorf("header = %q %q %v", first.CraftName, first.Firmware, first.StartedAt)
+	}
+	if math.Abs(first.DurationSeconds-10) > 0.1 || math.Abs(first.SampleRateHz-2000) > 5 {
+		t.Errorf("duration %.1fs at %.0f Hz, want 10s at 2000 Hz", first.DurationSeconds, first.SampleRateHz)
+	}
+	if first.MaxCurrentAmps == nil || *first.MaxCurrentAmps != 80 {
+		t.Errorf("max current = %v, want 80", first.MaxCurrentAmps)
+	}
+	if first.StartVoltage == nil || *first.StartVoltage != 16.8 || *first.MinVoltage != 15 || *first.MaxSagVolts != 1.8 {
+		t.Errorf("voltage = start %v min %v sag %v, want 16.8, 15 and 1.8", first.StartVoltage, first.MinVoltage, first.MaxSagVolts)
+	}
+
+	if len(first.GyroNoise) != 3 {
+		t.Fatalf("gyro noise = %+v, want all three axes", first.GyroNoise)
+	}
+	roll, pitch := first.GyroNoise[0], first.GyroNoise[1]
+	if math.Abs(roll.PeakHz-200) > 3 || math.Abs(pitch.PeakHz-350) > 3 {
+		t.Errorf("peaks = %.0f Hz and %.0f Hz, want 200 and 350", roll.PeakHz, pitch.PeakHz)
+	}
+	if len(roll.Bands) != 4 || roll.Bands[3].ToHz != 1000 {
+		t.Fatalf("bands = %+v, want four up to 1000 Hz", roll.Bands)
+	}
+	// A 200 deg/s sine has an RMS of 141 deg/s
+	if rms := roll.Bands[1].RMSDegPerSecond; math.Abs(rms-141.4) > 10 {
+		t.Errorf("roll 100-300 Hz RMS = %.1f, want about 141", rms)
+	}
+	if rms := roll.Bands[2].RMSDegPerSecond; rms > 5 {
+		t.Errorf("roll 300-600 Hz RMS = %.1f, want next to nothing", rms)
+	}
+
+	second := summary.Flights[1]
+	if second.Index != 2 || second.MinVoltage != nil || second.MaxCurrentAmps != nil {
+		t.Errorf("second flight = %+v, want no battery figures for Betaflight 3.1", second)
+	}
+	if math.Abs(second.DurationSeconds-1) > 0.05 {
+		t.Errorf("second flight duration = %.2fs, want 1s", second.DurationSeconds)
+	}
+
+	warnings := strings.Join(summary.Warnings, "; ")
+	if !strings.Contains(warnings, "corrupt") || !strings.Contains(warnings, "Betaflight 3.1.0") {
+		t.Errorf("warnings = %q, want the corrupt frames and unknown units", warnings)
+	}
+}
+
+func TestSummarize_NotABlackboxLog(t *testing.T) {
+	summary, err := Summarize(strings.NewReader("# Betaflight / STM32F405 4.4.2\nset gyro_lpf1_static_hz = 250\n"))
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	if len(summary.Flights) != 0 || len(summary.Warnings) == 0 {
+		t.Errorf("summary = %+v, want no flights and a warning", summary)
+	}
+}
+
+func TestSignExtend(t *testing.T) {
+	tests := []struct {
+		v    uint32
+		bits int
+		want int64
+	}{
+		{0x3, 2, -1},
+		{0x1, 2, 1},
+		{0x8, 4, -8},
+		{0x7F, 8, 127},
+		{0x2000, 14, -8192},
+		{0xFFFF, 16, -1},
+	}
+	for _, tt := range tests {
+		if got := signExtend(tt.v, tt.bits); got != tt.want {
+			t.Errorf("signExtend(%#x, %d) = %d, want %d", tt.v, tt.bits, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package blackbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// PrepareUpload checks a resumable blackbox upload before any data is
+// sent. Metadata may name an aircraftId and notes.
+func (s *Service) PrepareUpload(ctx context.Context, userID string, params models.CreateUploadParams) error {
+	if params.Size > MaxFileSize {
+		return &ServiceError{Message: fmt.Sprintf("file size exceeds maximum allowed (%d bytes)", MaxFileSize)}
+	}
+	return s.checkAircraft(ctx, userID, params.Metadata["aircraftId"])
+}
+
+// CompleteUpload saves a finished resumable upload as a blackbox log and
+// returns the log's ID
+func (s *Service) CompleteUpload(ctx context.Context, upload *models.Upload, file io.Reader) (string, error) {
+	log, err := s.Create(ctx, upload.UserID, models.CreateBlackboxLogParams{
+		AircraftID: upload.Metadata["aircraftId"],
+		FileName:   upload.FileName,
+		Notes:      upload.Metadata["notes"],
+		Checksum:   upload.Checksum,
+	}, file)
+	if err != nil {
+		return "", err
+	}
+	return log.ID, nil
+}
@@ -0,0 +1,136 @@
+// Package blobstore stores large uploaded files, such as radio backups, on
+// local disk or in S3-compatible object storage.
+package blobstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+)
+
+// ErrNotFound is returned when an object doesn't exist
+var ErrNotFound = errors.New("object not found")
+
+// Object describes a stored object
+type Object struct {
+	Key      string
+	Size     int64
+	Checksum string // hex SHA-256 of the content
+}
+
+// Store is a place objects are kept. Keys are slash-separated paths chosen
+// by the caller.
+type Store interface {
+	// Name identifies the backend, and is recorded with each object so it
+	// can be found again after the configured backend changes
+	Name() string
+	// Put streams r into the object at key, replacing any object there
+	Put(ctx context.Context, key string, r io.Reader) (*Object, error)
+	// Open reads an object from offset to its end
+	Open(ctx context.Context, key string, offset int64) (io.ReadCloser, error)
+	// Delete removes an object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// checksumReader counts and hashes what is read through it
+type checksumReader struct {
+	r    io.Reader
+	hash hash.Hash
+	size int64
+}
+
+func newChecksumReader(r io.Reader) *checksumReader {
+	return &checksumReader{r: r, hash: sha256.New()}
+}
+
+func (c *checksumReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.hash.Write(p[:n])
+	c.size += int64(n)
+	return n, err
+}
+
+func (c *checksumReader) object(key string) *Object {
+	return &Object{Key: key, Size: c.size, Checksum: hex.EncodeToString(c.hash.Sum(nil))}
+}
+
+// ReadSeeker reads an object of known size, reopening it at the new offset
+// when a read follows a seek. It lets http.ServeContent answer range
+// requests without downloading the whole object.
+type ReadSeeker struct {
+	ctx        context.Context
+	store      Store
+	key        string
+	size       int64
+	offset     int64
+	body       io.ReadCloser
+	bodyOffset int64 // where body will read next
+}
+
+// NewReadSeeker returns a ReadSeeker over the object at key
+func NewReadSeeker(ctx context.Context, store Store, key string, size int64) *ReadSeeker {
+	return &ReadSeeker{ctx: ctx, store: store, key: key, size: size}
+}
+
+// Open opens the object at the current offset, so a missing object is
+// reported before a response is started
+func (rs *ReadSeeker) Open() error {
+	if rs.body != nil && rs.bodyOffset == rs.offset {
+		return nil
+	}
+	rs.closeBody()
+	body, err := rs.store.Open(rs.ctx, rs.key, rs.offset)
+	if err != nil {
+		return err
+	}
+	rs.body = body
+	rs.bodyOffset = rs.offset
+	return nil
+}
+
+// Read reads from the current offset
+func (rs *ReadSeeker) Read(p []byte) (int, error) {
+	if rs.offset >= rs.size {
+		return 0, io.EOF
+	}
+	if err := rs.Open(); err != nil {
+		return 0, err
+	}
+	n, err := rs.body.Read(p)
+	rs.offset += int64(n)
+	rs.bodyOffset = rs.offset
+	return n, err
+}
+
+// Seek moves the offset. Seeking doesn't touch the object; the next read
+// reopens it if the offset changed.
+func (rs *ReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += rs.offset
+	case io.SeekEnd:
+		offset += rs.size
+	}
+	if offset < 0 {
+		return 0, errors.New("blobstore: negative seek offset")
+	}
+	rs.offset = offset
+	return offset, nil
+}
+
+// Close releases the open object, if any
+func (rs *ReadSeeker) Close() error {
+	return rs.closeBody()
+}
+
+func (rs *ReadSeeker) closeBody() error {
+	if rs.body == nil {
+		return nil
+	}
+	err := rs.body.Close()
+	rs.body = nil
+	return err
+}
@@ -0,0 +1,265 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func readAt(t *testing.T, store Store, key string, offset int64) string {
+	t.Helper()
+	body, err := store.Open(context.Background(), key, offset)
+	if err != nil {
+		t.Fatalf("Open(%q, %d) error = %v", key, offset, err)
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("read %q: %v", key, err)
+	}
+	return string(data)
+}
+
+func TestLocal_PutOpenDelete(t *testing.T) {
+	root := t.TempDir()
+	store := NewLocal(root)
+	ctx := context.Background()
+	content := []byte("radio backup contents")
+
+	obj, err := store.Put(ctx, "radio_backups/r1/backup.bin", bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if obj.Size != int64(len(content)) || obj.Checksum != checksum(content) {
+		t.Errorf("Put() = %+v", obj)
+	}
+	if got := readAt(t, store, "radio_backups/r1/backup.bin", 6); got != "backup contents" {
+		t.Errorf("Open at offset = %q", got)
+	}
+
+	// Keys can't climb out of the root
+	if _, err := store.Put(ctx, "../../escape.bin", bytes.NewReader(content)); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "escape.bin")); err != nil {
+		t.Errorf("escaping key should be stored under the root: %v", err)
+	}
+
+	if err := store.Delete(ctx, "radio_backups/r1/backup.bin"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Open(ctx, "radio_backups/r1/backup.bin", 0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open() after delete error = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, "radio_backups/r1/backup.bin"); err != nil {
+		t.Errorf("deleting a missing object: %v", err)
+	}
+}
+
+func TestLocal_FailedPutLeavesNothing(t *testing.T) {
+	root := t.TempDir()
+	store := NewLocal(root)
+
+	_, err := store.Put(context.Background(), "a/b.bin", io.MultiReader(strings.NewReader("partial"), failingReader{}))
+	if err == nil {
+		t.Fatal("Put() should fail")
+	}
+	entries, _ := os.ReadDir(filepath.Join(root, "a"))
+	if len(entries) != 0 {
+		t.Errorf("files left behind: %v", entries)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("connection reset") }
+
+// fakeS3 serves enough of the S3 API for uploads, ranged downloads and
+// deletes, with path-style addressing
+type fakeS3 struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	uploads  map[string]map[int][]byte
+	aborted  int
+	failPart int
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{objects: map[string][]byte{}, uploads: map[string]map[int][]byte{}}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("Authorization") == "" {
+		http.Error(w, "unsigned", http.StatusForbidden)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+	body, _ := io.ReadAll(r.Body)
+	if r.Header.Get("X-Amz-Content-Sha256") != checksum(body) {
+		http.Error(w, "payload hash mismatch", http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		id := fmt.Sprintf("upload-%d", len(f.uploads)+1)
+		f.uploads[id] = map[int][]byte{}
+		fmt.Fprintf(w, "<InitiateMultipartUploadResult><UploadId>%s</UploadId></InitiateMultipartUploadResult>", id)
+	case r.Method == http.MethodPut && query.Has("partNumber"):
+		number, _ := strconv.Atoi(query.Get("partNumber"))
+		if number == f.failPart {
+			http.Error(w, "slow down", http.StatusServiceUnavailable)
+			return
+		}
+		f.uploads[query.Get("uploadId")][number] = body
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, number))
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		var complete struct {
+			Parts []completedPart `xml:"Part"`
+		}
+		_ = xml.Unmarshal(body, &complete)
+		var data []byte
+		for _, part := range complete.Parts {
+			data = append(data, f.uploads[query.Get("uploadId")][part.PartNumber]...)
+		}
+		f.objects[key] = data
+		fmt.Fprint(w, "<CompleteMultipartUploadResult></CompleteMultipartUploadResult>")
+	case r.Method == http.MethodDelete && query.Has("uploadId"):
+		f.aborted++
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		f.objects[key] = body
+	case r.Method == http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		var offset int
+		fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-", &offset)
+		w.Write(data[offset:])
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newTestS3(t *testing.T, fake *fakeS3) *S3 {
+	t.Helper()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store := NewS3(S3Config{Bucket: "bucket", Region: "us-east-1", Endpoint: server.URL, PathStyle: true})
+	store.client = server.Client()
+	store.partSize = 8
+	store.creds = aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+		return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}, nil
+	})
+	return store
+}
+
+func TestS3_PutOpenDelete(t *testing.T) {
+	fake := newFakeS3()
+	store := newTestS3(t, fake)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		key     string
+		content string
+	}{
+		{"single request", "small.bin", "tiny"},
+		{"multipart", "radio backups/large file.bin", "twenty-six letters abcdefg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := store.Put(ctx, tt.key, strings.NewReader(tt.content))
+			if err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+			if obj.Size != int64(len(tt.content)) || obj.Checksum != checksum([]byte(tt.content)) {
+				t.Errorf("Put() = %+v", obj)
+			}
+			if got := readAt(t, store, tt.key, 0); got != tt.content {
+				t.Errorf("Open() = %q, want %q", got, tt.content)
+			}
+			if got := readAt(t, store, tt.key, 2); got != tt.content[2:] {
+				t.Errorf("Open at offset = %q", got)
+			}
+		})
+	}
+
+	if err := store.Delete(ctx, "small.bin"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Open(ctx, "small.bin", 0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open() after delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestS3_FailedMultipartUploadIsAborted(t *testing.T) {
+	fake := newFakeS3()
+	fake.failPart = 2
+	store := newTestS3(t, fake)
+
+	if _, err := store.Put(context.Background(), "big.bin", strings.NewReader("more than two parts of data")); err == nil {
+		t.Fatal("Put() should fail")
+	}
+	if fake.aborted != 1 {
+		t.Errorf("aborted = %d, want 1", fake.aborted)
+	}
+	if _, ok := fake.objects["big.bin"]; ok {
+		t.Error("failed upload should not create the object")
+	}
+}
+
+func TestReadSeeker_ServesRanges(t *testing.T) {
+	store := NewLocal(t.TempDir())
+	content := "0123456789abcdef"
+	if _, err := store.Put(context.Background(), "file.bin", strings.NewReader(content)); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	rs := NewReadSeeker(context.Background(), store, "file.bin", int64(len(content)))
+	defer rs.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/download", nil)
+	req.Header.Set("Range", "bytes=10-13")
+	rec := httptest.NewRecorder()
+	http.ServeContent(rec, req, "file.bin", time.Time{}, rs)
+
+	if rec.Code != http.StatusPartialContent {
+		t.Fatalf("status = %d, want 206", rec.Code)
+	}
+	if got := rec.Body.String(); got != "abcd" {
+		t.Errorf("body = %q, want %q", got, "abcd")
+	}
+	if got := rec.Header().Get("Content-Range"); got != "bytes 10-13/16" {
+		t.Errorf("Content-Range = %q", got)
+	}
+}
@@ -0,0 +1,108 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Local keeps objects as files under a root directory
+type Local struct {
+	root string
+}
+
+// NewLocal creates a store rooted at dir. An empty dir treats keys as file
+// paths, which is how records from before pluggable storage are read.
+func NewLocal(dir string) *Local {
+	return &Local{root: dir}
+}
+
+// Name identifies the backend
+func (l *Local) Name() string {
+	return "local"
+}
+
+// Put writes the object to a temporary file and renames it into place, so
+// a failed upload never leaves a partial file behind
+func (l *Local) Put(ctx context.Context, key string, r io.Reader) (*Object, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	reader := newChecksumReader(r)
+	if _, err := io.Copy(tmp, reader); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+	return reader.object(key), nil
+}
+
+// Open opens the file at offset
+func (l *Local) Open(ctx context.Context, key string, offset int64) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	if offset > 0 {
+		if _, err := file.Seek(offset, io.SeekStart); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to seek file: %w", err)
+		}
+	}
+	return file, nil
+}
+
+// Delete removes the file
+func (l *Local) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// path maps a key to a file under the root
+func (l *Local) path(key string) (string, error) {
+	if l.root == "" {
+		return key, nil
+	}
+	// Cleaning the key as an absolute path drops any ".." that would climb
+	// above the root
+	clean := filepath.Clean("/" + filepath.FromSlash(key))
+	if clean == string(filepath.Separator) {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return filepath.Join(l.root, clean), nil
+}
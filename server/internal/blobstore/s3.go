@@ -0,0 +1,307 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+// defaultPartSize is the multipart upload part size. Each upload buffers one
+// part in memory; S3 needs at least 5MB for every part but the last.
+const defaultPartSize = 8 * 1024 * 1024
+
+// S3Config holds where objects are kept in S3 or an S3-compatible service
+type S3Config struct {
+	Bucket   string
+	Region   string
+	Endpoint string // for S3-compatible services; empty uses AWS
+	Prefix   string // prepended to every key
+	// PathStyle addresses the bucket in the path rather than the hostname,
+	// which most S3-compatible services need
+	PathStyle bool
+}
+
+// S3 keeps objects in an S3 bucket. The REST API is called directly with
+// signed requests, which saves pulling in the S3 SDK for a handful of
+// calls. Objects larger than one part are sent as a multipart upload, so
+// only one part is ever held in memory.
+type S3 struct {
+	cfg      S3Config
+	client   *http.Client
+	signer   *v4.Signer
+	partSize int
+
+	credsOnce sync.Once
+	creds     aws.CredentialsProvider
+	credsErr  error
+}
+
+// NewS3 creates an S3 store using the ambient AWS credentials
+func NewS3(cfg S3Config) *S3 {
+	return &S3{
+		cfg:      cfg,
+		client:   &http.Client{Timeout: 10 * time.Minute},
+		signer:   v4.NewSigner(func(o *v4.SignerOptions) { o.DisableURIPathEscaping = true }),
+		partSize: defaultPartSize,
+	}
+}
+
+// Name identifies the backend
+func (s *S3) Name() string {
+	return "s3"
+}
+
+// Put uploads the object, as a multipart upload when it's larger than one
+// part. A failed multipart upload is aborted so its parts aren't billed.
+func (s *S3) Put(ctx context.Context, key string, r io.Reader) (*Object, error) {
+	reader := newChecksumReader(r)
+	buf := make([]byte, s.partSize)
+
+	n, err := io.ReadFull(reader, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if n < s.partSize {
+		resp, err := s.do(ctx, http.MethodPut, key, nil, buf[:n], nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload object: %w", err)
+		}
+		resp.Body.Close()
+		return reader.object(key), nil
+	}
+
+	uploadID, err := s.createMultipartUpload(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := s.uploadParts(ctx, key, uploadID, reader, buf, n)
+	if err == nil {
+		err = s.completeMultipartUpload(ctx, key, uploadID, parts)
+	}
+	if err != nil {
+		// Use a fresh context, the request's may be what failed
+		abortCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if resp, abortErr := s.do(abortCtx, http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil, nil); abortErr == nil {
+			resp.Body.Close()
+		}
+		return nil, err
+	}
+	return reader.object(key), nil
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+func (s *S3) uploadParts(ctx context.Context, key, uploadID string, r io.Reader, buf []byte, n int) ([]completedPart, error) {
+	var parts []completedPart
+	for number := 1; n > 0; number++ {
+		query := url.Values{"partNumber": {strconv.Itoa(number)}, "uploadId": {uploadID}}
+		resp, err := s.do(ctx, http.MethodPut, key, query, buf[:n], nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload part %d: %w", number, err)
+		}
+		resp.Body.Close()
+		parts = append(parts, completedPart{PartNumber: number, ETag: resp.Header.Get("ETag")})
+
+		if n, err = io.ReadFull(r, buf); err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return nil, fmt.Errorf("failed to read upload: %w", err)
+		}
+	}
+	return parts, nil
+}
+
+func (s *S3) createMultipartUpload(ctx context.Context, key string) (string, error) {
+	resp, err := s.do(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to start multipart upload: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil || result.UploadID == "" {
+		return "", fmt.Errorf("failed to start multipart upload: no upload id")
+	}
+	return result.UploadID, nil
+}
+
+func (s *S3) completeMultipartUpload(ctx context.Context, key, uploadID string, parts []completedPart) error {
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return fmt.Errorf("failed to encode multipart completion: %w", err)
+	}
+
+	resp, err := s.do(ctx, http.MethodPost, key, url.Values{"uploadId": {uploadID}}, body, nil)
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// S3 can report a failed completion in the body of a 200 response
+	var result struct {
+		XMLName xml.Name
+		Message string `xml:"Message"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err == nil && result.XMLName.Local == "Error" {
+		return fmt.Errorf("failed to complete multipart upload: %s", result.Message)
+	}
+	return nil
+}
+
+// Open downloads the object from offset
+func (s *S3) Open(ctx context.Context, key string, offset int64) (io.ReadCloser, error) {
+	var header http.Header
+	if offset > 0 {
+		header = http.Header{"Range": {fmt.Sprintf("bytes=%d-", offset)}}
+	}
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil, header)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete removes the object
+func (s *S3) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil, nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// do sends a signed request for an object. Responses other than 2xx are
+// returned as errors with their body closed.
+func (s *S3) do(ctx context.Context, method, key string, query url.Values, body []byte, header http.Header) (*http.Response, error) {
+	creds, err := s.credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key, query), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.ContentLength = int64(len(body))
+
+	hash := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(hash[:])
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if err := s.signer.SignHTTP(ctx, creds, req, payloadHash, "s3", s.cfg.Region, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	return nil, fmt.Errorf("s3 returned %d for %s %s", resp.StatusCode, method, key)
+}
+
+func (s *S3) credentials(ctx context.Context) (aws.Credentials, error) {
+	s.credsOnce.Do(func() {
+		if s.creds != nil {
+			return
+		}
+		cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(s.cfg.Region))
+		if err != nil {
+			s.credsErr = fmt.Errorf("load aws config: %w", err)
+			return
+		}
+		s.creds = cfg.Credentials
+	})
+	if s.credsErr != nil {
+		return aws.Credentials{}, s.credsErr
+	}
+	creds, err := s.creds.Retrieve(ctx)
+	if err != nil {
+		return aws.Credentials{}, fmt.Errorf("failed to get aws credentials: %w", err)
+	}
+	return creds, nil
+}
+
+func (s *S3) objectURL(key string, query url.Values) string {
+	path := escapeKey(strings.TrimLeft(s.cfg.Prefix+key, "/"))
+
+	var base string
+	switch {
+	case s.cfg.Endpoint != "" && s.cfg.PathStyle:
+		base = strings.TrimRight(s.cfg.Endpoint, "/") + "/" + s.cfg.Bucket
+	case s.cfg.Endpoint != "":
+		u, _ := url.Parse(s.cfg.Endpoint)
+		base = u.Scheme + "://" + s.cfg.Bucket + "." + u.Host
+	case s.cfg.PathStyle:
+		base = fmt.Sprintf("https://s3.%s.amazonaws.com/%s", s.cfg.Region, s.cfg.Bucket)
+	default:
+		base = fmt.Sprintf("https://%s.s3.%s.amazonaws.com", s.cfg.Bucket, s.cfg.Region)
+	}
+
+	u := base + "/" + path
+	if len(query) > 0 {
+		u += "?" + encodeQuery(query)
+	}
+	return u
+}
+
+// encodeQuery encodes a query string, writing subresource flags such as
+// uploads without a trailing "="
+func encodeQuery(query url.Values) string {
+	pairs := strings.Split(query.Encode(), "&")
+	for i, pair := range pairs {
+		pairs[i] = strings.TrimSuffix(pair, "=")
+	}
+	return strings.Join(pairs, "&")
+}
+
+// escapeKey percent-encodes every byte of a key except unreserved
+// characters and slashes, as SigV4 expects for S3 paths
+func escapeKey(key string) string {
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if c == '/' || c == '-' || c == '_' || c == '.' || c == '~' ||
+			(c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
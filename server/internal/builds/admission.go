@@ -0,0 +1,102 @@
+package builds
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/ratelimit"
+)
+
+// CaptchaVerifier checks a captcha response. An error means the provider
+// couldn't be asked, not that the response is bad.
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
+
+// tempAdmission guards anonymous temp build creation
+type tempAdmission struct {
+	ipLimiter      ratelimit.RateLimiter
+	maxOutstanding int
+	captcha        CaptchaVerifier
+
+	admitted      atomic.Int64
+	rateLimited   atomic.Int64
+	captchaFailed atomic.Int64
+	captchaErrors atomic.Int64
+	atCapacity    atomic.Int64
+}
+
+// SetTempAdmission limits anonymous temp build creation. ipLimiter caps
+// creations per client IP, maxOutstanding caps unexpired temp builds across
+// all clients (zero for no cap), and captcha, if set, must accept a captcha
+// response first. Any of them can be left unset.
+func (s *Service) SetTempAdmission(ipLimiter ratelimit.RateLimiter, maxOutstanding int, captcha CaptchaVerifier) {
+	s.admission.ipLimiter = ipLimiter
+	s.admission.maxOutstanding = maxOutstanding
+	s.admission.captcha = captcha
+}
+
+// AdmitTemp checks whether an anonymous client may create a temp build,
+// returning a ServiceError if not. Signed-in callers are always admitted.
+// The cheapest checks run first so refused clients cost as little as
+// possible.
+func (s *Service) AdmitTemp(ctx context.Context, ownerUserID, clientIP, captchaToken string) error {
+	if ownerUserID != "" {
+		return nil
+	}
+	a := &s.admission
+
+	if a.ipLimiter != nil && !a.ipLimiter.Allow(clientIP) {
+		a.rateLimited.Add(1)
+		return &ServiceError{Code: apierror.RateLimited, Message: "too many temporary builds created from this IP; sign in to keep building"}
+	}
+
+	if a.captcha != nil {
+		ok, err := a.captcha.Verify(ctx, captchaToken, clientIP)
+		switch {
+		case err != nil:
+			// A provider outage shouldn't stop everyone building
+			a.captchaErrors.Add(1)
+			s.logger.Warn("Captcha verification failed, admitting temp build", logging.WithField("error", err.Error()))
+		case !ok:
+			a.captchaFailed.Add(1)
+			return &ServiceError{Code: apierror.CaptchaRequired, Message: "captcha verification failed"}
+		}
+	}
+
+	if a.maxOutstanding > 0 {
+		outstanding, err := s.store.CountOutstandingTemp(ctx)
+		if err != nil {
+			return err
+		}
+		if outstanding >= a.maxOutstanding {
+			a.atCapacity.Add(1)
+			return &ServiceError{Code: apierror.TempBuildCapacity, Message: "too many temporary builds are open right now; sign in or try again later"}
+		}
+	}
+
+	a.admitted.Add(1)
+	return nil
+}
+
+// TempAdmissionStats returns temp build admission counts since start
+func (s *Service) TempAdmissionStats(ctx context.Context) (*models.TempBuildAdmissionStats, error) {
+	outstanding, err := s.store.CountOutstandingTemp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a := &s.admission
+	return &models.TempBuildAdmissionStats{
+		Admitted:       a.admitted.Load(),
+		RateLimited:    a.rateLimited.Load(),
+		CaptchaFailed:  a.captchaFailed.Load(),
+		CaptchaErrors:  a.captchaErrors.Load(),
+		AtCapacity:     a.atCapacity.Load(),
+		Outstanding:    outstanding,
+		MaxOutstanding: a.maxOutstanding,
+		CaptchaEnabled: a.captcha != nil,
+	}, nil
+}
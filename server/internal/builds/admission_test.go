@@ -0,0 +1,92 @@
+package builds
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/ratelimit"
+)
+
+type fakeCaptcha struct {
+	err   error
+	calls int
+}
+
+func (f *fakeCaptcha) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	f.calls++
+	return token == "human", f.err
+}
+
+func admissionCode(err error) apierror.Code {
+	var svcErr *ServiceError
+	if errors.As(err, &svcErr) {
+		return svcErr.Code
+	}
+	return ""
+}
+
+func TestAdmitTemp(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeBuildStore()
+	svc := NewServiceWithDeps(store, nil, nil, logging.New(logging.LevelError))
+	captcha := &fakeCaptcha{}
+	svc.SetTempAdmission(ratelimit.NewWindow(2, time.Hour), 2, captcha)
+
+	if err := svc.AdmitTemp(ctx, "", "1.2.3.4", "robot"); admissionCode(err) != apierror.CaptchaRequired {
+		t.Fatalf("AdmitTemp() with a bad captcha error = %v, want %s", err, apierror.CaptchaRequired)
+	}
+	if err := svc.AdmitTemp(ctx, "", "1.2.3.4", "human"); err != nil {
+		t.Fatalf("AdmitTemp() error = %v", err)
+	}
+
+	// The IP's quota is spent, so the captcha isn't even checked
+	captcha.calls = 0
+	if err := svc.AdmitTemp(ctx, "", "1.2.3.4", "human"); admissionCode(err) != apierror.RateLimited {
+		t.Fatalf("AdmitTemp() over the IP limit error = %v, want %s", err, apierror.RateLimited)
+	}
+	if captcha.calls != 0 {
+		t.Errorf("captcha checked %d times for a rate limited client", captcha.calls)
+	}
+
+	// Signed-in callers skip every check
+	if err := svc.AdmitTemp(ctx, "user-1", "1.2.3.4", ""); err != nil {
+		t.Fatalf("AdmitTemp() for a signed-in user error = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.CreateTemp(ctx, "", models.CreateBuildParams{}); err != nil {
+			t.Fatalf("CreateTemp() error = %v", err)
+		}
+	}
+	if err := svc.AdmitTemp(ctx, "", "5.6.7.8", "human"); admissionCode(err) != apierror.TempBuildCapacity {
+		t.Fatalf("AdmitTemp() at capacity error = %v, want %s", err, apierror.TempBuildCapacity)
+	}
+
+	// A captcha outage lets clients through rather than stopping everyone
+	svc.SetTempAdmission(nil, 0, &fakeCaptcha{err: errors.New("provider down")})
+	if err := svc.AdmitTemp(ctx, "", "5.6.7.8", ""); err != nil {
+		t.Fatalf("AdmitTemp() during a captcha outage error = %v", err)
+	}
+
+	stats, err := svc.TempAdmissionStats(ctx)
+	if err != nil {
+		t.Fatalf("TempAdmissionStats() error = %v", err)
+	}
+	want := models.TempBuildAdmissionStats{
+		Admitted:       2,
+		RateLimited:    1,
+		CaptchaFailed:  1,
+		CaptchaErrors:  1,
+		AtCapacity:     1,
+		Outstanding:    2,
+		CaptchaEnabled: true,
+	}
+	if *stats != want {
+		t.Errorf("TempAdmissionStats() = %+v, want %+v", *stats, want)
+	}
+}
@@ -0,0 +1,282 @@
+package builds
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/textpdf"
+)
+
+// bomPriceTimeout bounds the live price lookups for one bill of materials
+const bomPriceTimeout = 10 * time.Second
+
+// BOM formats
+const (
+	BOMFormatJSON     = "json"
+	BOMFormatCSV      = "csv"
+	BOMFormatMarkdown = "md"
+	BOMFormatPDF      = "pdf"
+)
+
+// PriceLookup finds a current seller listing for a part by name.
+type PriceLookup interface {
+	FindOffer(ctx context.Context, name string) (*models.EquipmentItem, error)
+}
+
+// SetPriceLookup enables live prices and purchase links in bills of materials.
+func (s *Service) SetPriceLookup(prices PriceLookup) {
+	s.prices = prices
+}
+
+// BOMForOwner returns the bill of materials for one of the owner's builds, or
+// nil if the build is not found.
+func (s *Service) BOMForOwner(ctx context.Context, id string, ownerUserID string) (*models.BuildBOM, error) {
+	build, err := s.store.GetForOwner(ctx, strings.TrimSpace(id), ownerUserID)
+	if err != nil || build == nil {
+		return nil, err
+	}
+	return s.buildBOM(ctx, build, time.Now()), nil
+}
+
+// PublicBOM returns the bill of materials for a published build, or nil if
+// the build is not found.
+func (s *Service) PublicBOM(ctx context.Context, id string) (*models.BuildBOM, error) {
+	build, err := s.store.GetPublic(ctx, strings.TrimSpace(id))
+	if err != nil || build == nil {
+		return nil, err
+	}
+	return s.buildBOM(ctx, build, time.Now()), nil
+}
+
+func (s *Service) buildBOM(ctx context.Context, build *models.Build, now time.Time) *models.BuildBOM {
+	bom := &models.BuildBOM{
+		BuildID:     build.ID,
+		Title:       build.Title,
+		Lines:       bomLines(build.Parts),
+		GeneratedAt: now,
+	}
+	s.addLivePrices(ctx, bom.Lines)
+
+	for _, line := range bom.Lines {
+		if line.MSRP != nil {
+			bom.MSRPTotal += *line.MSRP * float64(line.Quantity)
+		}
+		if line.LivePrice != nil {
+			bom.LivePriceTotal += *line.LivePrice * float64(line.Quantity)
+			if bom.Currency == "" {
+				bom.Currency = line.Currency
+			}
+		}
+	}
+	return bom
+}
+
+// bomLines combines parts that reference the same catalog item, keeping the
+// order parts were listed in. Parts without a catalog item get a line each.
+func bomLines(parts []models.BuildPart) []models.BOMLine {
+	lines := make([]models.BOMLine, 0, len(parts))
+	byCatalogItem := make(map[string]int)
+
+	for _, part := range parts {
+		if part.CatalogItemID != "" {
+			if i, ok := byCatalogItem[part.CatalogItemID]; ok {
+				lines[i].Quantity++
+				continue
+			}
+			byCatalogItem[part.CatalogItemID] = len(lines)
+		}
+
+		line := models.BOMLine{
+			GearType:      part.GearType,
+			CatalogItemID: part.CatalogItemID,
+			Name:          part.CatalogItem.DisplayName(),
+			Quantity:      1,
+			Notes:         part.Notes,
+		}
+		if part.CatalogItem != nil && part.CatalogItem.MSRP != nil {
+			msrp := *part.CatalogItem.MSRP
+			line.MSRP = &msrp
+		}
+		if line.Name == "" {
+			line.Name = string(part.GearType)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// addLivePrices looks up a seller listing for each catalog line in parallel.
+// Lines whose lookup fails or finds nothing are left without a live price.
+func (s *Service) addLivePrices(ctx context.Context, lines []models.BOMLine) {
+	if s.prices == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, bomPriceTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := range lines {
+		if lines[i].CatalogItemID == "" {
+			continue
+		}
+		wg.Add(1)
+		go func(line *models.BOMLine) {
+			defer wg.Done()
+			offer, err := s.prices.FindOffer(ctx, line.Name)
+			if err != nil {
+				s.logger.Warn("BOM price lookup failed", logging.WithFields(map[string]interface{}{
+					"part":  line.Name,
+					"error": err.Error(),
+				}))
+				return
+			}
+			if offer == nil {
+				return
+			}
+			price := offer.Price
+			inStock := offer.InStock
+			line.LivePrice = &price
+			line.Currency = offer.Currency
+			line.Seller = offer.Seller
+			line.InStock = &inStock
+			line.PurchaseURL = offer.ProductURL
+		}(&lines[i])
+	}
+	wg.Wait()
+}
+
+// RenderBOM renders a bill of materials as CSV, Markdown, or PDF, returning
+// the body and its content type.
+func RenderBOM(bom *models.BuildBOM, format string) ([]byte, string, error) {
+	switch format {
+	case BOMFormatCSV:
+		body, err := renderBOMCSV(bom)
+		return body, "text/csv; charset=utf-8", err
+	case BOMFormatMarkdown:
+		return renderBOMMarkdown(bom), "text/markdown; charset=utf-8", nil
+	case BOMFormatPDF:
+		return renderBOMPDF(bom), "application/pdf", nil
+	default:
+		return nil, "", &ServiceError{Message: "format must be one of json, csv, md, pdf"}
+	}
+}
+
+func renderBOMCSV(bom *models.BuildBOM) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	rows := [][]string{{"Gear Type", "Part", "Quantity", "MSRP", "Live Price", "Currency", "Seller", "In Stock", "Purchase URL", "Notes"}}
+	for _, line := range bom.Lines {
+		inStock := ""
+		if line.InStock != nil {
+			inStock = strconv.FormatBool(*line.InStock)
+		}
+		rows = append(rows, []string{
+			string(line.GearType),
+			line.Name,
+			strconv.Itoa(line.Quantity),
+			formatOptionalPrice(line.MSRP),
+			formatOptionalPrice(line.LivePrice),
+			line.Currency,
+			line.Seller,
+			inStock,
+			line.PurchaseURL,
+			line.Notes,
+		})
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("failed to write BOM CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func renderBOMMarkdown(bom *models.BuildBOM) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", markdownCell(bom.Title))
+	b.WriteString("| Part | Type | Qty | MSRP | Live Price | Buy |\n")
+	b.WriteString("| --- | --- | ---: | ---: | ---: | --- |\n")
+	for _, line := range bom.Lines {
+		buy := ""
+		if line.PurchaseURL != "" {
+			buy = fmt.Sprintf("[%s](%s)", markdownCell(sellerLabel(line)), line.PurchaseURL)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %d | %s | %s | %s |\n",
+			markdownCell(line.Name), line.GearType, line.Quantity,
+			formatOptionalPrice(line.MSRP), formatOptionalPrice(line.LivePrice), buy)
+	}
+	fmt.Fprintf(&b, "\n**MSRP total:** %s  \n**Live price total:** %s\n",
+		formatPrice(bom.MSRPTotal), formatPrice(bom.LivePriceTotal))
+	return []byte(b.String())
+}
+
+// bomPDFColumns are the widths of the PDF table columns, in points
+var bomPDFColumns = []float64{230, 60, 35, 60, 60, 59}
+
+func renderBOMPDF(bom *models.BuildBOM) []byte {
+	doc := textpdf.New()
+	doc.Heading(bom.Title)
+	doc.Text("Bill of materials generated " + bom.GeneratedAt.UTC().Format("2006-01-02 15:04 UTC"))
+	doc.Gap()
+
+	doc.Row([]string{"Part", "Type", "Qty", "MSRP", "Live", "Seller"}, bomPDFColumns, true)
+	for _, line := range bom.Lines {
+		doc.Row([]string{
+			line.Name,
+			string(line.GearType),
+			strconv.Itoa(line.Quantity),
+			formatOptionalPrice(line.MSRP),
+			formatOptionalPrice(line.LivePrice),
+			line.Seller,
+		}, bomPDFColumns, false)
+	}
+	doc.Gap()
+	doc.Text("MSRP total: " + formatPrice(bom.MSRPTotal))
+	doc.Text("Live price total: " + formatPrice(bom.LivePriceTotal))
+
+	// Links can't be clicked in a text-only PDF, so list them after the table
+	var links []models.BOMLine
+	for _, line := range bom.Lines {
+		if line.PurchaseURL != "" {
+			links = append(links, line)
+		}
+	}
+	if len(links) > 0 {
+		doc.Gap()
+		doc.Row([]string{"Purchase links"}, []float64{textpdf.ContentWidth}, true)
+		for _, line := range links {
+			doc.Text(line.Name + ": " + line.PurchaseURL)
+		}
+	}
+	return doc.Bytes()
+}
+
+func sellerLabel(line models.BOMLine) string {
+	if line.Seller != "" {
+		return line.Seller
+	}
+	return "Buy"
+}
+
+func markdownCell(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	return strings.ReplaceAll(text, "|", `\|`)
+}
+
+func formatOptionalPrice(price *float64) string {
+	if price == nil {
+		return ""
+	}
+	return formatPrice(*price)
+}
+
+func formatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', 2, 64)
+}
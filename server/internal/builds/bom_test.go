@@ -0,0 +1,132 @@
+package builds
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+type fakePriceLookup struct {
+	offers map[string]*models.EquipmentItem
+}
+
+func (f fakePriceLookup) FindOffer(ctx context.Context, name string) (*models.EquipmentItem, error) {
+	return f.offers[name], nil
+}
+
+func bomTestBuild() *models.Build {
+	msrp := 24.99
+	motor := &models.BuildCatalogItem{ID: "motor-1", GearType: models.GearTypeMotor, Brand: "T-Motor", Model: "F60", MSRP: &msrp}
+	return &models.Build{
+		ID:          "build-1",
+		OwnerUserID: "user-1",
+		Status:      models.BuildStatusPublished,
+		Title:       "5\" Freestyle",
+		Parts: []models.BuildPart{
+			{GearType: models.GearTypeFrame, CatalogItemID: "frame-1", CatalogItem: &models.BuildCatalogItem{ID: "frame-1", Brand: "ImpulseRC", Model: "Apex"}},
+			{GearType: models.GearTypeMotor, CatalogItemID: "motor-1", CatalogItem: motor, Position: 0},
+			{GearType: models.GearTypeMotor, CatalogItemID: "motor-1", CatalogItem: motor, Position: 1},
+			{GearType: models.GearTypeMotor, CatalogItemID: "motor-1", CatalogItem: motor, Position: 2},
+			{GearType: models.GearTypeMotor, CatalogItemID: "motor-1", CatalogItem: motor, Position: 3},
+			{GearType: models.GearTypeOther, Notes: "zip ties"},
+		},
+	}
+}
+
+func TestBOMLines_GroupsByCatalogItem(t *testing.T) {
+	lines := bomLines(bomTestBuild().Parts)
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d: %+v", len(lines), lines)
+	}
+	if lines[0].Name != "ImpulseRC Apex" || lines[0].Quantity != 1 {
+		t.Errorf("frame line = %+v", lines[0])
+	}
+	if lines[1].Name != "T-Motor F60" || lines[1].Quantity != 4 {
+		t.Errorf("motor line = %+v", lines[1])
+	}
+	if lines[2].Name != "other" || lines[2].Notes != "zip ties" || lines[2].CatalogItemID != "" {
+		t.Errorf("uncataloged line = %+v", lines[2])
+	}
+}
+
+func TestBuildBOM_AddsLivePricesAndTotals(t *testing.T) {
+	svc := NewServiceWithDeps(newFakeBuildStore(), nil, nil, logging.New(logging.LevelError))
+	svc.SetPriceLookup(fakePriceLookup{offers: map[string]*models.EquipmentItem{
+		"T-Motor F60": {Name: "T-Motor F60 Pro V", Price: 21.5, Currency: "USD", Seller: "RDQ", ProductURL: "https://example.com/f60", InStock: true},
+	}})
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	bom := svc.buildBOM(context.Background(), bomTestBuild(), now)
+
+	motor := bom.Lines[1]
+	if motor.LivePrice == nil || *motor.LivePrice != 21.5 || motor.PurchaseURL != "https://example.com/f60" {
+		t.Fatalf("motor live price not set: %+v", motor)
+	}
+	if bom.Lines[0].LivePrice != nil {
+		t.Errorf("frame should have no live price: %+v", bom.Lines[0])
+	}
+	if bom.MSRPTotal != 24.99*4 {
+		t.Errorf("MSRPTotal = %v, want %v", bom.MSRPTotal, 24.99*4)
+	}
+	if bom.LivePriceTotal != 86 {
+		t.Errorf("LivePriceTotal = %v, want 86", bom.LivePriceTotal)
+	}
+	if bom.Currency != "USD" {
+		t.Errorf("Currency = %q, want USD", bom.Currency)
+	}
+}
+
+func TestPublicBOM_RequiresPublishedBuild(t *testing.T) {
+	store := newFakeBuildStore()
+	build := bomTestBuild()
+	build.Status = models.BuildStatusDraft
+	store.byID[build.ID] = build
+	svc := NewServiceWithDeps(store, nil, nil, logging.New(logging.LevelError))
+
+	bom, err := svc.PublicBOM(context.Background(), build.ID)
+	if err != nil || bom != nil {
+		t.Fatalf("expected nil BOM for draft build, got %+v, %v", bom, err)
+	}
+
+	bom, err = svc.BOMForOwner(context.Background(), build.ID, "user-1")
+	if err != nil || bom == nil || len(bom.Lines) != 3 {
+		t.Fatalf("expected owner BOM, got %+v, %v", bom, err)
+	}
+}
+
+func TestRenderBOM(t *testing.T) {
+	svc := NewServiceWithDeps(newFakeBuildStore(), nil, nil, logging.New(logging.LevelError))
+	bom := svc.buildBOM(context.Background(), bomTestBuild(), time.Now())
+
+	body, contentType, err := RenderBOM(bom, BOMFormatCSV)
+	if err != nil || !strings.HasPrefix(contentType, "text/csv") {
+		t.Fatalf("csv: %v, %q", err, contentType)
+	}
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	if err != nil {
+		t.Fatalf("csv did not parse: %v", err)
+	}
+	if len(records) != 4 || records[2][1] != "T-Motor F60" || records[2][2] != "4" || records[2][3] != "24.99" {
+		t.Errorf("unexpected csv rows: %v", records)
+	}
+
+	body, _, err = RenderBOM(bom, BOMFormatMarkdown)
+	if err != nil || !strings.Contains(string(body), "| T-Motor F60 | motor | 4 | 24.99 |") {
+		t.Errorf("unexpected markdown: %s", body)
+	}
+
+	body, contentType, err = RenderBOM(bom, BOMFormatPDF)
+	if err != nil || contentType != "application/pdf" || !bytes.HasPrefix(body, []byte("%PDF-")) {
+		t.Errorf("pdf: %v, %q", err, contentType)
+	}
+
+	if _, _, err := RenderBOM(bom, "xlsx"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -0,0 +1,135 @@
+package builds
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	_ "image/jpeg" // build photos are stored as JPEG or PNG
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/speccard"
+)
+
+// cardBrand is printed above the title on spec cards
+const cardBrand = "FlyingForge"
+
+// cardParts lists the gear types shown on spec cards, in order, with their
+// labels. Parts of other types are left to the full build page.
+var cardParts = []struct {
+	gearType models.GearType
+	label    string
+}{
+	{models.GearTypeFrame, "Frame"},
+	{models.GearTypeMotor, "Motors"},
+	{models.GearTypeAIO, "AIO"},
+	{models.GearTypeFC, "FC"},
+	{models.GearTypeESC, "ESC"},
+	{models.GearTypeHDUnit, "HD"},
+	{models.GearTypeVTX, "VTX"},
+	{models.GearTypeCamera, "Camera"},
+	{models.GearTypeReceiver, "RX"},
+	{models.GearTypeProp, "Props"},
+}
+
+// CardCache stores rendered spec cards keyed by build revision
+type CardCache interface {
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{})
+}
+
+// BuildCard is a rendered spec card for a published build
+type BuildCard struct {
+	PNG       []byte
+	ETag      string // changes whenever the card's content would
+	UpdatedAt time.Time
+}
+
+// SetCardCache keeps rendered spec cards so repeat requests skip rendering.
+func (s *Service) SetCardCache(cache CardCache) {
+	s.cardCache = cache
+}
+
+// PublicCard returns the spec card for a published build with a QR code for
+// link, or nil if the build is not found. Cards are cached until the build
+// changes.
+func (s *Service) PublicCard(ctx context.Context, id string, link string) (*BuildCard, error) {
+	build, err := s.store.GetPublic(ctx, strings.TrimSpace(id))
+	if err != nil || build == nil {
+		return nil, err
+	}
+
+	key := strings.Join([]string{"build-card", build.ID, strconv.FormatInt(build.UpdatedAt.UnixNano(), 10), build.ImageAssetID, link}, "|")
+	if s.cardCache != nil {
+		if cached, ok := s.cardCache.Get(key); ok {
+			if card, ok := cached.(*BuildCard); ok {
+				return card, nil
+			}
+		}
+	}
+
+	content := speccard.Card{
+		Brand: cardBrand,
+		Title: build.Title,
+		Specs: cardSpecs(build.Parts),
+		Link:  link,
+	}
+	if build.Pilot != nil {
+		content.Byline = build.Pilot.DisplayNameOrDefault()
+	}
+	imageData, _, err := s.GetPublicImage(ctx, build.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(imageData) > 0 {
+		// A photo that won't decode leaves the card without one
+		if photo, _, err := image.Decode(bytes.NewReader(imageData)); err == nil {
+			content.Photo = photo
+		}
+	}
+
+	png, err := speccard.Render(content)
+	if errors.Is(err, speccard.ErrLinkTooLong) {
+		// A very long public URL costs the card its QR code, not the card
+		content.Link = ""
+		png, err = speccard.Render(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256([]byte(key))
+	card := &BuildCard{
+		PNG:       png,
+		ETag:      `"` + hex.EncodeToString(sum[:16]) + `"`,
+		UpdatedAt: build.UpdatedAt,
+	}
+	if s.cardCache != nil {
+		s.cardCache.Set(key, card)
+	}
+	return card, nil
+}
+
+// cardSpecs picks the key parts for a spec card. Several parts of one type,
+// such as motors, are listed once.
+func cardSpecs(parts []models.BuildPart) []speccard.Spec {
+	var specs []speccard.Spec
+	for _, cp := range cardParts {
+		for _, part := range parts {
+			if part.GearType != cp.gearType {
+				continue
+			}
+			name := part.CatalogItem.DisplayName()
+			if name == "" {
+				continue
+			}
+			specs = append(specs, speccard.Spec{Label: cp.label, Value: name})
+			break
+		}
+	}
+	return specs
+}
@@ -0,0 +1,61 @@
+package builds
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/cache"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+func TestPublicCard(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeBuildStore()
+	svc := NewServiceWithDeps(store, nil, nil, logging.New(logging.LevelError))
+	svc.SetCardCache(cache.NewMemory(time.Hour))
+
+	build := bomTestBuild()
+	build.UpdatedAt = time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	store.byID[build.ID] = build
+
+	card, err := svc.PublicCard(ctx, build.ID, "https://flyingforge.example/builds/build-1")
+	if err != nil || card == nil {
+		t.Fatalf("PublicCard() = %v, %v", card, err)
+	}
+	if _, err := png.Decode(bytes.NewReader(card.PNG)); err != nil {
+		t.Fatalf("card isn't a PNG: %v", err)
+	}
+
+	again, err := svc.PublicCard(ctx, build.ID, "https://flyingforge.example/builds/build-1")
+	if err != nil || again != card {
+		t.Errorf("PublicCard() for an unchanged build rendered again")
+	}
+
+	build.UpdatedAt = build.UpdatedAt.Add(time.Minute)
+	edited, err := svc.PublicCard(ctx, build.ID, "https://flyingforge.example/builds/build-1")
+	if err != nil || edited == nil || edited.ETag == card.ETag {
+		t.Errorf("PublicCard() after an edit kept ETag %s", card.ETag)
+	}
+
+	build.Status = models.BuildStatusDraft
+	if card, err := svc.PublicCard(ctx, build.ID, ""); err != nil || card != nil {
+		t.Errorf("PublicCard() for a draft = %v, %v, want nil", card, err)
+	}
+}
+
+func TestCardSpecs(t *testing.T) {
+	specs := cardSpecs(bomTestBuild().Parts)
+	if len(specs) != 2 {
+		t.Fatalf("cardSpecs() = %+v, want frame and motors", specs)
+	}
+	if specs[0].Label != "Frame" || specs[0].Value != "ImpulseRC Apex" {
+		t.Errorf("first spec = %+v", specs[0])
+	}
+	if specs[1].Label != "Motors" || specs[1].Value != "T-Motor F60" {
+		t.Errorf("second spec = %+v", specs[1])
+	}
+}
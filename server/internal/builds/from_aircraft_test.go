@@ -0,0 +1,154 @@
+package builds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+type fakeAircraftReader struct {
+	details *models.AircraftDetailsResponse
+}
+
+func (f fakeAircraftReader) GetDetails(ctx context.Context, id string, userID string) (*models.AircraftDetailsResponse, error) {
+	if f.details == nil || f.details.Aircraft.ID != id {
+		return nil, nil
+	}
+	return f.details, nil
+}
+
+func (f fakeAircraftReader) GetImage(ctx context.Context, id string, userID string) ([]byte, string, error) {
+	return nil, "", nil
+}
+
+type fakeGearCatalog struct {
+	migrated    map[string]string // inventory item name -> catalog ID, missing means failure
+	nearMatches []models.NearMatch
+	inTx        []bool
+}
+
+func (f *fakeGearCatalog) MigrateInventoryItem(ctx context.Context, inventoryItemID, userID, name, manufacturer string, category models.EquipmentCategory, specs json.RawMessage) (*models.GearCatalogItem, error) {
+	f.inTx = append(f.inTx, ctx.Value(fakeTxKey{}) != nil)
+	id, ok := f.migrated[name]
+	if !ok {
+		return nil, errors.New("migration failed")
+	}
+	return &models.GearCatalogItem{ID: id, Status: models.CatalogStatusPending}, nil
+}
+
+func (f *fakeGearCatalog) FindNearMatches(ctx context.Context, gearType models.GearType, brand, model string, threshold float64) ([]models.NearMatch, error) {
+	return f.nearMatches, nil
+}
+
+func TestCreateDraftFromAircraft_MigratesAndReportsSkipped(t *testing.T) {
+	aircraft := fakeAircraftReader{details: &models.AircraftDetailsResponse{
+		Aircraft: models.Aircraft{ID: "aircraft-1", Name: "Freestyle"},
+		Components: []models.AircraftComponent{
+			{Category: models.ComponentCategoryFrame, InventoryItem: &models.InventoryItem{ID: "inv-1", Name: "Apex", CatalogID: "frame-1"}},
+			{Category: models.ComponentCategoryMotors, InventoryItem: &models.InventoryItem{ID: "inv-2", Name: "F60", Category: models.CategoryMotors}},
+			{Category: models.ComponentCategoryVTX, InventoryItem: &models.InventoryItem{ID: "inv-3", Name: "Mystery VTX", Category: models.CategoryVTX}},
+		},
+	}}
+	catalog := &fakeGearCatalog{migrated: map[string]string{"F60": "motor-1"}}
+	svc := NewServiceWithDeps(newFakeBuildStore(), aircraft, catalog, logging.New(logging.LevelError))
+
+	response, err := svc.CreateDraftFromAircraft(context.Background(), "user-1", "aircraft-1")
+	if err != nil {
+		t.Fatalf("CreateDraftFromAircraft() error = %v", err)
+	}
+	if response == nil || response.Build == nil {
+		t.Fatal("expected a build")
+	}
+	if response.Title != "Freestyle Build" || len(response.Parts) != 2 {
+		t.Fatalf("build = %+v", response.Build)
+	}
+	if response.Parts[1].CatalogItemID != "motor-1" {
+		t.Errorf("motor part should use the migrated catalog item, got %+v", response.Parts[1])
+	}
+	if len(response.SkippedComponents) != 1 || response.SkippedComponents[0].Category != models.ComponentCategoryVTX {
+		t.Fatalf("skipped = %+v, want the VTX", response.SkippedComponents)
+	}
+	if response.SkippedComponents[0].Reason == "" {
+		t.Error("skipped component should have a reason")
+	}
+
+	// Build fields stay at the top level of the JSON
+	body, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if decoded["id"] != response.ID || decoded["skippedComponents"] == nil {
+		t.Errorf("unexpected JSON shape: %s", body)
+	}
+}
+
+type fakeTxKey struct{}
+
+type fakeTransactor struct {
+	calls int
+	err   error
+}
+
+func (f *fakeTransactor) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.calls++
+	f.err = fn(context.WithValue(ctx, fakeTxKey{}, true))
+	return f.err
+}
+
+func TestCreateDraftFromAircraft_RunsInOneTransaction(t *testing.T) {
+	aircraft := fakeAircraftReader{details: &models.AircraftDetailsResponse{
+		Aircraft: models.Aircraft{ID: "aircraft-1", Name: "Freestyle"},
+		Components: []models.AircraftComponent{
+			{Category: models.ComponentCategoryMotors, InventoryItem: &models.InventoryItem{ID: "inv-2", Name: "F60", Category: models.CategoryMotors}},
+		},
+	}}
+	catalog := &fakeGearCatalog{migrated: map[string]string{"F60": "motor-1"}}
+	svc := NewServiceWithDeps(newFakeBuildStore(), aircraft, catalog, logging.New(logging.LevelError))
+	tx := &fakeTransactor{}
+	svc.SetTransactor(tx)
+
+	response, err := svc.CreateDraftFromAircraft(context.Background(), "user-1", "aircraft-1")
+	if err != nil {
+		t.Fatalf("CreateDraftFromAircraft() error = %v", err)
+	}
+	if response == nil || response.Build == nil {
+		t.Fatal("expected a build")
+	}
+	if tx.calls != 1 || tx.err != nil {
+		t.Errorf("transactor calls = %d, err = %v; want one committed unit", tx.calls, tx.err)
+	}
+	if len(catalog.inTx) != 1 || !catalog.inTx[0] {
+		t.Errorf("catalog migration should run inside the unit of work, got %v", catalog.inTx)
+	}
+}
+
+func TestUnpublishedPartMatches(t *testing.T) {
+	catalog := &fakeGearCatalog{nearMatches: []models.NearMatch{
+		{Item: models.GearCatalogItem{ID: "motor-1", Status: models.CatalogStatusPending}},
+		{Item: models.GearCatalogItem{ID: "motor-9", Brand: "T-Motor", Model: "F60 Pro V", Status: models.CatalogStatusPublished}, Similarity: 0.8},
+	}}
+	svc := NewServiceWithDeps(newFakeBuildStore(), nil, catalog, logging.New(logging.LevelError))
+
+	matches := svc.unpublishedPartMatches(context.Background(), []models.BuildPart{
+		{GearType: models.GearTypeFrame, CatalogItemID: "frame-1", CatalogItem: publishedCatalog("frame-1", models.GearTypeFrame)},
+		{GearType: models.GearTypeMotor, CatalogItemID: "motor-1", CatalogItem: pendingCatalog("motor-1", models.GearTypeMotor)},
+	})
+
+	if len(matches) != 1 {
+		t.Fatalf("expected one prompt for the pending motor, got %+v", matches)
+	}
+	if matches[0].CatalogItemID != "motor-1" || matches[0].Name != "Brand Model" {
+		t.Errorf("prompt = %+v", matches[0])
+	}
+	if len(matches[0].Matches) != 1 || matches[0].Matches[0].Item.ID != "motor-9" {
+		t.Errorf("matches = %+v, want motor-9 only", matches[0].Matches)
+	}
+}
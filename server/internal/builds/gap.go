@@ -0,0 +1,107 @@
+package builds
+
+import (
+	"context"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// InventoryCounter counts a user's inventory by catalog item.
+type InventoryCounter interface {
+	QuantitiesByCatalogIDs(ctx context.Context, userID string, catalogIDs []string) (map[string]int, error)
+}
+
+// SetInventory enables gap analysis against users' inventories.
+func (s *Service) SetInventory(inventory InventoryCounter) {
+	s.inventory = inventory
+}
+
+// GapAnalysis compares a build with the caller's inventory. The build can be
+// the caller's own or any published build. Returns nil if neither is found.
+func (s *Service) GapAnalysis(ctx context.Context, id string, userID string) (*models.BuildGapAnalysis, error) {
+	if s.inventory == nil {
+		return nil, &ServiceError{Message: "gap analysis is not available"}
+	}
+
+	build, err := s.GetOwnedOrPublic(ctx, id, userID)
+	if err != nil || build == nil {
+		return nil, err
+	}
+
+	lines := bomLines(build.Parts)
+	catalogIDs := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line.CatalogItemID != "" {
+			catalogIDs = append(catalogIDs, line.CatalogItemID)
+		}
+	}
+	owned, err := s.inventory.QuantitiesByCatalogIDs(ctx, userID, catalogIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := gapAnalysis(build, lines, owned)
+
+	// Only what still has to be bought needs a live price
+	missing := make([]models.BOMLine, len(analysis.Missing))
+	for i := range analysis.Missing {
+		missing[i] = analysis.Missing[i].BOMLine
+	}
+	s.addLivePrices(ctx, missing)
+	for i := range analysis.Missing {
+		analysis.Missing[i].BOMLine = missing[i]
+	}
+
+	estimateGapCost(analysis)
+	return analysis, nil
+}
+
+// gapAnalysis splits lines into those fully covered by owned quantities and
+// those still needed. Parts without a catalog item can't be matched to
+// inventory, so they are always listed as missing.
+func gapAnalysis(build *models.Build, lines []models.BOMLine, owned map[string]int) *models.BuildGapAnalysis {
+	analysis := &models.BuildGapAnalysis{
+		BuildID: build.ID,
+		Title:   build.Title,
+		Owned:   []models.BuildGapLine{},
+		Missing: []models.BuildGapLine{},
+	}
+
+	for _, line := range lines {
+		have := 0
+		if line.CatalogItemID != "" {
+			have = owned[line.CatalogItemID]
+		}
+		if have > line.Quantity {
+			have = line.Quantity
+		}
+
+		gap := models.BuildGapLine{
+			BOMLine:        line,
+			OwnedQuantity:  have,
+			NeededQuantity: line.Quantity - have,
+		}
+		if gap.NeededQuantity == 0 {
+			analysis.Owned = append(analysis.Owned, gap)
+		} else {
+			analysis.Missing = append(analysis.Missing, gap)
+		}
+	}
+	return analysis
+}
+
+func estimateGapCost(analysis *models.BuildGapAnalysis) {
+	for _, line := range analysis.Missing {
+		switch {
+		case line.LivePrice != nil:
+			analysis.EstimatedCost += *line.LivePrice * float64(line.NeededQuantity)
+			if analysis.Currency == "" {
+				analysis.Currency = line.Currency
+			}
+		case line.MSRP != nil:
+			analysis.EstimatedCost += *line.MSRP * float64(line.NeededQuantity)
+		default:
+			analysis.UnpricedLines++
+		}
+	}
+}
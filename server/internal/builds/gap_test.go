@@ -0,0 +1,94 @@
+package builds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+type fakeInventoryCounter map[string]int
+
+func (f fakeInventoryCounter) QuantitiesByCatalogIDs(ctx context.Context, userID string, catalogIDs []string) (map[string]int, error) {
+	result := make(map[string]int)
+	for _, id := range catalogIDs {
+		if qty, ok := f[id]; ok {
+			result[id] = qty
+		}
+	}
+	return result, nil
+}
+
+func TestGapAnalysis_SplitsOwnedAndMissing(t *testing.T) {
+	store := newFakeBuildStore()
+	build := bomTestBuild()
+	build.OwnerUserID = "someone-else"
+	store.byID[build.ID] = build
+
+	svc := NewServiceWithDeps(store, nil, nil, logging.New(logging.LevelError))
+	svc.SetInventory(fakeInventoryCounter{"frame-1": 1, "motor-1": 2})
+	svc.SetPriceLookup(fakePriceLookup{offers: map[string]*models.EquipmentItem{
+		"T-Motor F60": {Price: 20, Currency: "USD", ProductURL: "https://example.com/f60"},
+	}})
+
+	analysis, err := svc.GapAnalysis(context.Background(), build.ID, "user-2")
+	if err != nil {
+		t.Fatalf("GapAnalysis() error = %v", err)
+	}
+	if analysis == nil {
+		t.Fatal("expected analysis for published build")
+	}
+
+	if len(analysis.Owned) != 1 || analysis.Owned[0].CatalogItemID != "frame-1" {
+		t.Fatalf("owned = %+v, want frame only", analysis.Owned)
+	}
+	if len(analysis.Missing) != 2 {
+		t.Fatalf("missing = %+v, want motors and uncataloged part", analysis.Missing)
+	}
+	motor := analysis.Missing[0]
+	if motor.OwnedQuantity != 2 || motor.NeededQuantity != 2 || motor.PurchaseURL == "" {
+		t.Errorf("motor line = %+v", motor)
+	}
+	if analysis.EstimatedCost != 40 {
+		t.Errorf("EstimatedCost = %v, want 40", analysis.EstimatedCost)
+	}
+	if analysis.UnpricedLines != 1 {
+		t.Errorf("UnpricedLines = %d, want 1", analysis.UnpricedLines)
+	}
+}
+
+func TestGapAnalysis_FallsBackToMSRP(t *testing.T) {
+	store := newFakeBuildStore()
+	build := bomTestBuild()
+	store.byID[build.ID] = build
+
+	svc := NewServiceWithDeps(store, nil, nil, logging.New(logging.LevelError))
+	svc.SetInventory(fakeInventoryCounter{"motor-1": 10})
+
+	analysis, err := svc.GapAnalysis(context.Background(), build.ID, "user-1")
+	if err != nil || analysis == nil {
+		t.Fatalf("GapAnalysis() = %+v, %v", analysis, err)
+	}
+	if len(analysis.Owned) != 1 || analysis.Owned[0].OwnedQuantity != 4 {
+		t.Errorf("owned = %+v, want motors capped at 4", analysis.Owned)
+	}
+	if analysis.EstimatedCost != 0 || analysis.UnpricedLines != 2 {
+		t.Errorf("estimate = %v with %d unpriced, want 0 with 2", analysis.EstimatedCost, analysis.UnpricedLines)
+	}
+}
+
+func TestGapAnalysis_HiddenDraftNotFound(t *testing.T) {
+	store := newFakeBuildStore()
+	build := bomTestBuild()
+	build.Status = models.BuildStatusDraft
+	store.byID[build.ID] = build
+
+	svc := NewServiceWithDeps(store, nil, nil, logging.New(logging.LevelError))
+	svc.SetInventory(fakeInventoryCounter{})
+
+	analysis, err := svc.GapAnalysis(context.Background(), build.ID, "user-2")
+	if err != nil || analysis != nil {
+		t.Errorf("GapAnalysis() = %+v, %v, want nil for another user's draft", analysis, err)
+	}
+}
@@ -0,0 +1,117 @@
+package builds
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// handoffTTL is how long a handoff token can be used. Every edit of the
+// temp build issues a fresh one. The auth service caps handoff tokens, and
+// keeps retired signing keys, at the same hour.
+const handoffTTL = time.Hour
+
+// HandoffStore tracks the anonymous session temp builds were created in.
+type HandoffStore interface {
+	TagTempBuild(ctx context.Context, buildID, sessionID string) error
+	SessionForToken(ctx context.Context, token string) (string, error)
+	ClaimSession(ctx context.Context, sessionID, userID string, maxDrafts int) (*models.BuildHandoffResult, error)
+}
+
+// HandoffSigner signs handoff tokens, so a client can only claim the
+// session it was given.
+type HandoffSigner interface {
+	IssueHandoffToken(sessionID string, expiresAt time.Time) (string, error)
+}
+
+// SetHandoff enables handing anonymous temp builds over to the account
+// their creator signs in with.
+func (s *Service) SetHandoff(store HandoffStore, signer HandoffSigner) {
+	s.handoffs = store
+	s.handoffSigner = signer
+}
+
+// ClaimHandoff gives the builds of an anonymous session to userID. The
+// session ID comes from a verified handoff token. Claiming never fails on the
+// draft limit; older drafts are archived to make room instead.
+func (s *Service) ClaimHandoff(ctx context.Context, sessionID, userID string) (*models.BuildHandoffResult, error) {
+	if s.handoffs == nil {
+		return nil, &ServiceError{Message: "build handoff is not available"}
+	}
+	if strings.TrimSpace(sessionID) == "" || strings.TrimSpace(userID) == "" {
+		return nil, &ServiceError{Message: "session and user are required"}
+	}
+
+	result, err := s.handoffs.ClaimSession(ctx, sessionID, userID, s.maxDrafts)
+	if errors.Is(err, database.ErrHandoffClaimed) {
+		return nil, &ServiceError{Message: "these temporary builds were already claimed"}
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("Temporary builds claimed", logging.WithFields(map[string]interface{}{
+		"userId":   userID,
+		"draftId":  result.DraftBuildID,
+		"claimed":  len(result.ClaimedBuildIDs),
+		"archived": len(result.ArchivedBuildIDs),
+	}))
+	return result, nil
+}
+
+// startHandoff opens a new anonymous session for a temp build created by
+// someone who isn't signed in.
+func (s *Service) startHandoff(ctx context.Context, resp *models.TempBuildCreateResponse) {
+	if s.handoffs == nil || s.handoffSigner == nil {
+		return
+	}
+	raw := make([]byte, 16)
+	if _, err := crand.Read(raw); err != nil {
+		s.logger.Warn("Failed to start build handoff session", logging.WithField("error", err.Error()))
+		return
+	}
+	s.attachHandoff(ctx, hex.EncodeToString(raw), resp)
+}
+
+// continueHandoff carries the session of the build at sourceToken over to
+// the build in resp, a new revision or shared snapshot of it.
+func (s *Service) continueHandoff(ctx context.Context, sourceToken string, resp *models.TempBuildCreateResponse) {
+	if s.handoffs == nil || s.handoffSigner == nil {
+		return
+	}
+	sessionID, err := s.handoffs.SessionForToken(ctx, strings.TrimSpace(sourceToken))
+	if err != nil {
+		s.logger.Warn("Failed to look up build handoff session", logging.WithField("error", err.Error()))
+		return
+	}
+	if sessionID == "" {
+		return
+	}
+	s.attachHandoff(ctx, sessionID, resp)
+}
+
+// attachHandoff tags the build with the session and adds a signed handoff
+// token to the response. Failures only cost the handoff, never the build.
+func (s *Service) attachHandoff(ctx context.Context, sessionID string, resp *models.TempBuildCreateResponse) {
+	if resp == nil || resp.Build == nil || resp.Build.OwnerUserID != "" {
+		return
+	}
+	if err := s.handoffs.TagTempBuild(ctx, resp.Build.ID, sessionID); err != nil {
+		s.logger.Warn("Failed to tag temp build for handoff", logging.WithField("error", err.Error()))
+		return
+	}
+	expiresAt := time.Now().UTC().Add(handoffTTL)
+	token, err := s.handoffSigner.IssueHandoffToken(sessionID, expiresAt)
+	if err != nil {
+		s.logger.Warn("Failed to sign build handoff token", logging.WithField("error", err.Error()))
+		return
+	}
+	resp.HandoffToken = token
+	resp.HandoffExpiresAt = &expiresAt
+}
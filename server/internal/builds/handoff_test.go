@@ -0,0 +1,108 @@
+package builds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// fakeHandoffStore tags builds of a fakeBuildStore with sessions
+type fakeHandoffStore struct {
+	builds   *fakeBuildStore
+	sessions map[string]string // build ID -> session
+	claimed  map[string]bool
+}
+
+func (f *fakeHandoffStore) TagTempBuild(ctx context.Context, buildID, sessionID string) error {
+	f.sessions[buildID] = sessionID
+	return nil
+}
+
+func (f *fakeHandoffStore) SessionForToken(ctx context.Context, token string) (string, error) {
+	return f.sessions[f.builds.byToken[token]], nil
+}
+
+func (f *fakeHandoffStore) ClaimSession(ctx context.Context, sessionID, userID string, maxDrafts int) (*models.BuildHandoffResult, error) {
+	if f.claimed[sessionID] {
+		return nil, database.ErrHandoffClaimed
+	}
+	f.claimed[sessionID] = true
+	result := &models.BuildHandoffResult{ClaimedBuildIDs: []string{}}
+	for id, session := range f.sessions {
+		if session == sessionID {
+			f.builds.byID[id].OwnerUserID = userID
+			result.ClaimedBuildIDs = append(result.ClaimedBuildIDs, id)
+		}
+	}
+	return result, nil
+}
+
+type fakeHandoffSigner struct{}
+
+func (fakeHandoffSigner) IssueHandoffToken(sessionID string, expiresAt time.Time) (string, error) {
+	return "signed:" + sessionID, nil
+}
+
+func TestTempBuildHandoff(t *testing.T) {
+	store := newFakeBuildStore()
+	handoffs := &fakeHandoffStore{builds: store, sessions: map[string]string{}, claimed: map[string]bool{}}
+	svc := NewServiceWithDeps(store, nil, nil, logging.New(logging.LevelError))
+	svc.SetHandoff(handoffs, fakeHandoffSigner{})
+	ctx := context.Background()
+
+	created, err := svc.CreateTemp(ctx, "", models.CreateBuildParams{Title: "Anon quad"})
+	if err != nil {
+		t.Fatalf("CreateTemp() error = %v", err)
+	}
+	if created.HandoffToken == "" || created.HandoffExpiresAt == nil {
+		t.Fatalf("CreateTemp() returned no handoff token: %+v", created)
+	}
+	sessionID := handoffs.sessions[created.Build.ID]
+
+	// Edits and shares stay in the same session
+	title := "Renamed"
+	updated, err := svc.UpdateTempByToken(ctx, created.Token, models.UpdateBuildParams{Title: &title})
+	if err != nil {
+		t.Fatalf("UpdateTempByToken() error = %v", err)
+	}
+	if handoffs.sessions[updated.Build.ID] != sessionID || updated.HandoffToken != created.HandoffToken {
+		t.Errorf("updated build session = %q, token %q", handoffs.sessions[updated.Build.ID], updated.HandoffToken)
+	}
+	shared, err := svc.ShareTempByToken(ctx, updated.Token)
+	if err != nil {
+		t.Fatalf("ShareTempByToken() error = %v", err)
+	}
+	if handoffs.sessions[shared.Build.ID] != sessionID {
+		t.Errorf("shared build session = %q, want %q", handoffs.sessions[shared.Build.ID], sessionID)
+	}
+
+	result, err := svc.ClaimHandoff(ctx, sessionID, "user-9")
+	if err != nil {
+		t.Fatalf("ClaimHandoff() error = %v", err)
+	}
+	if len(result.ClaimedBuildIDs) != 3 || store.byID[shared.Build.ID].OwnerUserID != "user-9" {
+		t.Errorf("ClaimHandoff() = %+v", result)
+	}
+	if _, err := svc.ClaimHandoff(ctx, sessionID, "user-10"); err == nil {
+		t.Error("claiming a session twice should fail")
+	}
+}
+
+func TestTempBuildHandoff_SignedInCreatorGetsNone(t *testing.T) {
+	store := newFakeBuildStore()
+	handoffs := &fakeHandoffStore{builds: store, sessions: map[string]string{}, claimed: map[string]bool{}}
+	svc := NewServiceWithDeps(store, nil, nil, logging.New(logging.LevelError))
+	svc.SetHandoff(handoffs, fakeHandoffSigner{})
+
+	created, err := svc.CreateTemp(context.Background(), "user-1", models.CreateBuildParams{})
+	if err != nil {
+		t.Fatalf("CreateTemp() error = %v", err)
+	}
+	if created.HandoffToken != "" || len(handoffs.sessions) != 0 {
+		t.Errorf("signed-in temp build got a handoff: %+v", created)
+	}
+}
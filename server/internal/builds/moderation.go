@@ -0,0 +1,120 @@
+package builds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ModerationHistory records moderator decisions on builds.
+type ModerationHistory interface {
+	Reject(ctx context.Context, buildID, moderatorUserID string, params models.RejectBuildParams) (*models.BuildModerationEvent, error)
+	Record(ctx context.Context, event models.BuildModerationEvent) (*models.BuildModerationEvent, error)
+	ListForBuild(ctx context.Context, buildID string) ([]models.BuildModerationEvent, error)
+	LatestRejection(ctx context.Context, buildID string) (*models.BuildModerationEvent, error)
+}
+
+// SetModerationHistory enables rejecting builds with feedback and keeps a
+// history of moderation decisions.
+func (s *Service) SetModerationHistory(history ModerationHistory) {
+	s.history = history
+}
+
+// RejectForModeration sends a pending build back to its owner as a draft
+// with the moderator's reason, and notifies the owner.
+func (s *Service) RejectForModeration(ctx context.Context, id, moderatorUserID string, params models.RejectBuildParams) (*models.BuildRejectResponse, error) {
+	if s.history == nil {
+		return nil, &ServiceError{Message: "build rejection is not available"}
+	}
+	params.Normalize()
+	if msg := params.Validate(); msg != "" {
+		return nil, &ServiceError{Message: msg}
+	}
+
+	build, err := s.store.GetForModeration(ctx, strings.TrimSpace(id))
+	if err != nil {
+		return nil, err
+	}
+	if build == nil {
+		return nil, nil
+	}
+
+	event, err := s.history.Reject(ctx, build.ID, moderatorUserID, params)
+	if errors.Is(err, database.ErrBuildNotPending) {
+		return nil, &ServiceError{Code: apierror.BuildNotPending, Message: "build is not pending moderation"}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.store.GetForModeration(ctx, build.ID)
+	if err != nil {
+		return nil, err
+	}
+	if updated != nil {
+		updated.Verified = isBuildVerified(updated)
+		updated.Rejection = event
+	}
+
+	body := fmt.Sprintf("%s was returned to drafts: %s.", build.Title, params.Category.Label())
+	if params.Note != "" {
+		body += " " + params.Note
+	}
+	s.notifyOwner(ctx, build.OwnerUserID, models.Notification{
+		Kind:  models.NotificationBuildRejected,
+		Title: "Your build needs changes",
+		Body:  body,
+		Data:  map[string]string{"buildId": build.ID, "category": string(params.Category)},
+	})
+	return &models.BuildRejectResponse{Build: updated, Rejection: event}, nil
+}
+
+// ModerationHistoryFor returns a build's moderation decisions, newest first.
+func (s *Service) ModerationHistoryFor(ctx context.Context, id string) ([]models.BuildModerationEvent, error) {
+	if s.history == nil {
+		return []models.BuildModerationEvent{}, nil
+	}
+	return s.history.ListForBuild(ctx, strings.TrimSpace(id))
+}
+
+// recordApproval adds an approval to the build's history. The build is
+// already published, so a failure is only logged.
+func (s *Service) recordApproval(ctx context.Context, buildID, moderatorUserID string) {
+	if s.history == nil {
+		return
+	}
+	_, err := s.history.Record(ctx, models.BuildModerationEvent{
+		BuildID:         buildID,
+		Action:          models.BuildModerationApproved,
+		ModeratorUserID: moderatorUserID,
+	})
+	if err != nil {
+		s.logger.Warn("Build approval not recorded", logging.WithFields(map[string]interface{}{
+			"buildId": buildID,
+			"error":   err.Error(),
+		}))
+	}
+}
+
+// setRejection attaches the latest rejection to a draft so its owner sees
+// what to fix.
+func (s *Service) setRejection(ctx context.Context, build *models.Build) {
+	if s.history == nil || build.Status != models.BuildStatusDraft {
+		return
+	}
+	rejection, err := s.history.LatestRejection(ctx, build.ID)
+	if err != nil {
+		s.logger.Warn("Build rejection lookup failed", logging.WithFields(map[string]interface{}{
+			"buildId": build.ID,
+			"error":   err.Error(),
+		}))
+		return
+	}
+	build.Rejection = rejection
+}
@@ -0,0 +1,100 @@
+package builds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// fakeModerationHistory applies rejections to a fakeBuildStore
+type fakeModerationHistory struct {
+	store  *fakeBuildStore
+	events []models.BuildModerationEvent
+}
+
+func (f *fakeModerationHistory) Reject(ctx context.Context, buildID, moderatorUserID string, params models.RejectBuildParams) (*models.BuildModerationEvent, error) {
+	build := f.store.byID[buildID]
+	if build == nil || build.Status != models.BuildStatusPendingReview {
+		return nil, database.ErrBuildNotPending
+	}
+	build.Status = models.BuildStatusDraft
+	return f.Record(ctx, models.BuildModerationEvent{
+		BuildID: buildID, Action: models.BuildModerationRejected,
+		Category: params.Category, Note: params.Note, ModeratorUserID: moderatorUserID,
+	})
+}
+
+func (f *fakeModerationHistory) Record(ctx context.Context, event models.BuildModerationEvent) (*models.BuildModerationEvent, error) {
+	event.CreatedAt = time.Now()
+	f.events = append([]models.BuildModerationEvent{event}, f.events...)
+	return &event, nil
+}
+
+func (f *fakeModerationHistory) ListForBuild(ctx context.Context, buildID string) ([]models.BuildModerationEvent, error) {
+	return f.events, nil
+}
+
+func (f *fakeModerationHistory) LatestRejection(ctx context.Context, buildID string) (*models.BuildModerationEvent, error) {
+	if len(f.events) == 0 || f.events[0].Action != models.BuildModerationRejected {
+		return nil, nil
+	}
+	return &f.events[0], nil
+}
+
+type recordingNotifier struct {
+	sent chan models.Notification
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, userID string, notification models.Notification) error {
+	n.sent <- notification
+	return nil
+}
+
+func TestRejectForModeration(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeBuildStore()
+	svc := NewServiceWithDeps(store, nil, nil, logging.New(logging.LevelError))
+	history := &fakeModerationHistory{store: store}
+	svc.SetModerationHistory(history)
+	notifier := &recordingNotifier{sent: make(chan models.Notification, 1)}
+	svc.SetNotifier(notifier)
+
+	store.byID["build-1"] = &models.Build{ID: "build-1", OwnerUserID: "user-1", Status: models.BuildStatusPendingReview, Title: "Cinewhoop"}
+
+	if _, err := svc.RejectForModeration(ctx, "build-1", "mod-1", models.RejectBuildParams{Category: "other"}); err == nil {
+		t.Error("category other without a note should fail")
+	}
+	if _, err := svc.RejectForModeration(ctx, "build-1", "mod-1", models.RejectBuildParams{Category: "spam"}); err == nil {
+		t.Error("unknown category should fail")
+	}
+
+	response, err := svc.RejectForModeration(ctx, "build-1", "mod-1", models.RejectBuildParams{Category: " Poor_Images ", Note: "The photos are blurry."})
+	if err != nil {
+		t.Fatalf("RejectForModeration() error = %v", err)
+	}
+	if response.Build.Status != models.BuildStatusDraft || response.Rejection.Category != models.BuildRejectionPoorImages {
+		t.Errorf("response = %+v / %+v", response.Build, response.Rejection)
+	}
+
+	select {
+	case sent := <-notifier.sent:
+		if sent.Kind != models.NotificationBuildRejected || sent.Data["category"] != "poor_images" {
+			t.Errorf("notification = %+v", sent)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("owner was not notified")
+	}
+
+	owned, err := svc.GetByOwner(ctx, "build-1", "user-1")
+	if err != nil || owned == nil || owned.Rejection == nil || owned.Rejection.Note != "The photos are blurry." {
+		t.Errorf("owner view rejection = %+v, %v", owned, err)
+	}
+
+	if _, err := svc.RejectForModeration(ctx, "build-1", "mod-1", models.RejectBuildParams{Category: "duplicate"}); err == nil {
+		t.Error("rejecting a draft should fail")
+	}
+}
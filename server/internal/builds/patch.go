@@ -0,0 +1,214 @@
+package builds
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// maxBuildTitleLength matches the builds.title column.
+const maxBuildTitleLength = 255
+
+// PartKey addresses one build part in a patch.
+type PartKey struct {
+	GearType models.GearType
+	Position int
+}
+
+// PartPatch changes one build part. Nil fields are left as they are.
+type PartPatch struct {
+	CatalogItemID *string
+	Notes         *string
+}
+
+// Patch is a parsed JSON merge patch (RFC 7396) for an owned build. Absent
+// members are left unchanged; parts map to nil are removed.
+type Patch struct {
+	Title       *string
+	Description *string
+	VideoURL    *string
+	Parts       map[PartKey]*PartPatch
+}
+
+// ParsePatch parses a merge patch body. Parts are an object keyed by
+// "gearType" or "gearType:position", so the editor can send only the part
+// that changed:
+//
+//	{"title": "Race quad", "parts": {"motor": {"catalogItemId": "..."}, "vtx": null}}
+func ParsePatch(body []byte) (*Patch, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil || members == nil {
+		return nil, &ServiceError{Message: "patch must be a JSON object"}
+	}
+
+	patch := &Patch{}
+	for name, raw := range members {
+		var err error
+		switch name {
+		case "title":
+			if isJSONNull(raw) {
+				return nil, &ServiceError{Message: "title cannot be removed"}
+			}
+			patch.Title, err = patchString(name, raw)
+		case "description":
+			patch.Description, err = patchString(name, raw)
+		case "videoUrl":
+			patch.VideoURL, err = patchString(name, raw)
+		case "parts":
+			patch.Parts, err = parsePartPatches(raw)
+		default:
+			return nil, &ServiceError{Message: fmt.Sprintf("%s cannot be patched", name)}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return patch, nil
+}
+
+func parsePartPatches(raw json.RawMessage) (map[PartKey]*PartPatch, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &members); err != nil || members == nil {
+		return nil, &ServiceError{Message: "parts must be an object keyed by gear type"}
+	}
+
+	parts := make(map[PartKey]*PartPatch, len(members))
+	for name, value := range members {
+		key, err := parsePartKey(name)
+		if err != nil {
+			return nil, err
+		}
+		if isJSONNull(value) {
+			parts[key] = nil
+			continue
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(value, &fields); err != nil || fields == nil {
+			return nil, &ServiceError{Message: fmt.Sprintf("parts.%s must be an object or null", name)}
+		}
+		part := &PartPatch{}
+		for field, fieldValue := range fields {
+			switch field {
+			case "catalogItemId":
+				part.CatalogItemID, err = patchString("catalogItemId", fieldValue)
+			case "notes":
+				part.Notes, err = patchString("notes", fieldValue)
+			default:
+				err = &ServiceError{Message: fmt.Sprintf("parts.%s.%s cannot be patched", name, field)}
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		parts[key] = part
+	}
+	return parts, nil
+}
+
+func parsePartKey(name string) (PartKey, error) {
+	gearType, positionText, hasPosition := strings.Cut(name, ":")
+	key := PartKey{GearType: models.GearType(strings.TrimSpace(gearType))}
+
+	if !key.GearType.IsValid() {
+		return PartKey{}, &ServiceError{Message: fmt.Sprintf("unknown gear type %q", gearType)}
+	}
+	if hasPosition {
+		position, err := strconv.Atoi(positionText)
+		if err != nil || position < 0 {
+			return PartKey{}, &ServiceError{Message: fmt.Sprintf("invalid part position in %q", name)}
+		}
+		key.Position = position
+	}
+	return key, nil
+}
+
+// patchString decodes a string member; null clears it to "".
+func patchString(name string, raw json.RawMessage) (*string, error) {
+	value := ""
+	if isJSONNull(raw) {
+		return &value, nil
+	}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, &ServiceError{Message: fmt.Sprintf("%s must be a string or null", name)}
+	}
+	return &value, nil
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
+
+// PatchByOwner applies a merge patch to an owned build and bumps updated_at.
+// Returns nil if the build is not found.
+func (s *Service) PatchByOwner(ctx context.Context, id string, ownerUserID string, patch *Patch) (*models.Build, error) {
+	params := models.UpdateBuildParams{
+		Title:       patch.Title,
+		Description: patch.Description,
+		VideoURL:    patch.VideoURL,
+	}
+	if params.Title != nil {
+		title := strings.TrimSpace(*params.Title)
+		if title == "" {
+			return nil, &ServiceError{Message: "title cannot be empty"}
+		}
+		if len(title) > maxBuildTitleLength {
+			return nil, &ServiceError{Message: fmt.Sprintf("title must be at most %d characters", maxBuildTitleLength)}
+		}
+	}
+
+	if patch.Parts != nil {
+		build, err := s.store.GetForOwner(ctx, strings.TrimSpace(id), ownerUserID)
+		if err != nil || build == nil {
+			return nil, err
+		}
+		parts, err := applyPartPatches(models.BuildPartInputsFromParts(build.Parts), patch.Parts)
+		if err != nil {
+			return nil, err
+		}
+		params.Parts = parts
+	}
+
+	return s.UpdateByOwner(ctx, id, ownerUserID, params)
+}
+
+// applyPartPatches merges part changes into a build's current parts. The
+// result is never nil, so removing the last part clears the build's parts.
+func applyPartPatches(current []models.BuildPartInput, patches map[PartKey]*PartPatch) ([]models.BuildPartInput, error) {
+	byKey := make(map[PartKey]models.BuildPartInput, len(current))
+	for _, part := range current {
+		byKey[PartKey{GearType: part.GearType, Position: part.Position}] = part
+	}
+
+	for key, patch := range patches {
+		if patch == nil {
+			delete(byKey, key)
+			continue
+		}
+		part, exists := byKey[key]
+		if !exists {
+			part = models.BuildPartInput{GearType: key.GearType, Position: key.Position}
+		}
+		if patch.CatalogItemID != nil {
+			part.CatalogItemID = strings.TrimSpace(*patch.CatalogItemID)
+		}
+		if patch.Notes != nil {
+			part.Notes = strings.TrimSpace(*patch.Notes)
+		}
+		if part.CatalogItemID == "" {
+			return nil, &ServiceError{Message: fmt.Sprintf("%s part needs a catalogItemId", key.GearType)}
+		}
+		byKey[key] = part
+	}
+
+	parts := make([]models.BuildPartInput, 0, len(byKey))
+	for _, part := range byKey {
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
@@ -0,0 +1,109 @@
+package builds
+
+import (
+	"context"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/betaflight"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// maxPresetBytes bounds an attached diff. A `diff all` from a full-featured
+// board is well under this.
+const maxPresetBytes = 256 * 1024
+
+// PresetStore persists the Betaflight presets attached to builds.
+type PresetStore interface {
+	Save(ctx context.Context, preset *models.BuildPreset) (*models.BuildPreset, error)
+	Get(ctx context.Context, buildID string) (*models.BuildPreset, error)
+	Delete(ctx context.Context, buildID string) (bool, error)
+}
+
+// SetPresetStore enables attaching Betaflight presets to builds.
+func (s *Service) SetPresetStore(presets PresetStore) {
+	s.presets = presets
+}
+
+// SetPresetByOwner attaches a Betaflight `diff all` to one of the owner's
+// builds, replacing any it had. The diff is parsed with the FC config parser
+// to check it is a Betaflight config, and settings that identify the pilot
+// or their hardware are removed before it is saved. Returns nil if the build
+// is not found.
+func (s *Service) SetPresetByOwner(ctx context.Context, id string, ownerUserID string, params models.SetBuildPresetParams) (*models.BuildPreset, error) {
+	if s.presets == nil {
+		return nil, &ServiceError{Message: "presets are not available"}
+	}
+	diff := strings.TrimSpace(params.Diff)
+	if diff == "" {
+		return nil, &ServiceError{Message: "diff is required"}
+	}
+	if len(diff) > maxPresetBytes {
+		return nil, &ServiceError{Message: "diff must be 256 KB or less"}
+	}
+
+	build, err := s.store.GetForOwner(ctx, strings.TrimSpace(id), ownerUserID)
+	if err != nil || build == nil {
+		return nil, err
+	}
+	if build.Status == models.BuildStatusTemp || build.Status == models.BuildStatusShared {
+		return nil, &ServiceError{Message: "presets can only be attached to saved builds"}
+	}
+
+	sanitized, removed := betaflight.Sanitize(diff)
+	parsed := betaflight.NewParser().Parse(sanitized)
+	if parsed.FirmwareName != models.FirmwareBetaflight {
+		return nil, &ServiceError{Message: "diff must come from Betaflight; paste the output of `diff all`"}
+	}
+	if parsed.ParseStatus == models.ParseStatusFailed {
+		return nil, &ServiceError{Message: "diff has no tuning settings"}
+	}
+
+	return s.presets.Save(ctx, &models.BuildPreset{
+		BuildID:         build.ID,
+		Diff:            sanitized,
+		FirmwareVersion: parsed.FirmwareVersion,
+		BoardTarget:     parsed.BoardTarget,
+		BoardName:       parsed.BoardName,
+		RemovedSettings: removed,
+		ParsedTuning:    parsed.ParsedTuning,
+	})
+}
+
+// PresetForOwner returns the preset attached to one of the owner's builds, or
+// nil if the build is not found or has no preset.
+func (s *Service) PresetForOwner(ctx context.Context, id string, ownerUserID string) (*models.BuildPreset, error) {
+	if s.presets == nil {
+		return nil, nil
+	}
+	build, err := s.store.GetForOwner(ctx, strings.TrimSpace(id), ownerUserID)
+	if err != nil || build == nil {
+		return nil, err
+	}
+	return s.presets.Get(ctx, build.ID)
+}
+
+// PublicPreset returns the preset attached to a published build, or nil if
+// the build is not found or has no preset.
+func (s *Service) PublicPreset(ctx context.Context, id string) (*models.BuildPreset, error) {
+	if s.presets == nil {
+		return nil, nil
+	}
+	build, err := s.store.GetPublic(ctx, strings.TrimSpace(id))
+	if err != nil || build == nil {
+		return nil, err
+	}
+	return s.presets.Get(ctx, build.ID)
+}
+
+// DeletePresetByOwner removes the preset from one of the owner's builds,
+// reporting whether there was one.
+func (s *Service) DeletePresetByOwner(ctx context.Context, id string, ownerUserID string) (bool, error) {
+	if s.presets == nil {
+		return false, nil
+	}
+	build, err := s.store.GetForOwner(ctx, strings.TrimSpace(id), ownerUserID)
+	if err != nil || build == nil {
+		return false, err
+	}
+	return s.presets.Delete(ctx, build.ID)
+}
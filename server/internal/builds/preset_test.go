@@ -0,0 +1,117 @@
+package builds
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+type fakePresetStore map[string]*models.BuildPreset
+
+func (f fakePresetStore) Save(ctx context.Context, preset *models.BuildPreset) (*models.BuildPreset, error) {
+	saved := *preset
+	f[preset.BuildID] = &saved
+	return &saved, nil
+}
+
+func (f fakePresetStore) Get(ctx context.Context, buildID string) (*models.BuildPreset, error) {
+	return f[buildID], nil
+}
+
+func (f fakePresetStore) Delete(ctx context.Context, buildID string) (bool, error) {
+	_, ok := f[buildID]
+	delete(f, buildID)
+	return ok, nil
+}
+
+const presetTestDiff = `# Betaflight / STM32F405 (S405) 4.4.2 Jun 1 2023 / 12:34:56 (1234567) MSP API: 1.45
+# board_name SPEEDYBEEF405V4
+set name = MYQUAD
+profile 0
+set p_roll = 45
+set i_roll = 80
+set d_roll = 30
+set p_pitch = 47
+set i_pitch = 84
+set d_pitch = 34
+set p_yaw = 45
+set i_yaw = 80
+`
+
+func TestSetPresetByOwner(t *testing.T) {
+	store := newFakeBuildStore()
+	build := bomTestBuild()
+	store.byID[build.ID] = build
+	presets := fakePresetStore{}
+
+	svc := NewServiceWithDeps(store, nil, nil, logging.New(logging.LevelError))
+	svc.SetPresetStore(presets)
+
+	preset, err := svc.SetPresetByOwner(context.Background(), build.ID, "user-1", models.SetBuildPresetParams{Diff: presetTestDiff})
+	if err != nil {
+		t.Fatalf("SetPresetByOwner() error = %v", err)
+	}
+	if preset == nil || preset.FirmwareVersion != "4.4.2" || preset.BoardName != "SPEEDYBEEF405V4" {
+		t.Fatalf("preset = %+v", preset)
+	}
+	if strings.Contains(preset.Diff, "MYQUAD") || len(preset.RemovedSettings) != 1 || preset.RemovedSettings[0] != "craft name" {
+		t.Errorf("craft name not stripped: removed = %v", preset.RemovedSettings)
+	}
+	if preset.ParsedTuning == nil || preset.ParsedTuning.PIDs == nil {
+		t.Error("expected parsed tuning")
+	}
+
+	public, err := svc.PublicPreset(context.Background(), build.ID)
+	if err != nil || public == nil || public.Diff != preset.Diff {
+		t.Errorf("PublicPreset() = %+v, %v", public, err)
+	}
+
+	// Someone else's build is not found
+	if other, err := svc.SetPresetByOwner(context.Background(), build.ID, "user-2", models.SetBuildPresetParams{Diff: presetTestDiff}); other != nil || err != nil {
+		t.Errorf("other owner = %+v, %v", other, err)
+	}
+
+	deleted, err := svc.DeletePresetByOwner(context.Background(), build.ID, "user-1")
+	if err != nil || !deleted {
+		t.Errorf("DeletePresetByOwner() = %v, %v", deleted, err)
+	}
+}
+
+func TestSetPresetByOwner_Rejects(t *testing.T) {
+	store := newFakeBuildStore()
+	build := bomTestBuild()
+	store.byID[build.ID] = build
+	temp := bomTestBuild()
+	temp.ID = "build-temp"
+	temp.Status = models.BuildStatusTemp
+	store.byID[temp.ID] = temp
+
+	svc := NewServiceWithDeps(store, nil, nil, logging.New(logging.LevelError))
+	svc.SetPresetStore(fakePresetStore{})
+
+	tests := []struct {
+		name    string
+		buildID string
+		diff    string
+		want    string
+	}{
+		{"empty", build.ID, "  ", "required"},
+		{"too large", build.ID, strings.Repeat("x", maxPresetBytes+1), "256 KB"},
+		{"inav", build.ID, "# INAV / MATEKF405 7.0.0\nset p_roll = 40\n", "Betaflight"},
+		{"no tuning", build.ID, "# Betaflight / STM32F405 (S405) 4.4.2\nset some_setting = 1\n", "tuning"},
+		{"temp build", temp.ID, presetTestDiff, "saved builds"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.SetPresetByOwner(context.Background(), tt.buildID, "user-1", models.SetBuildPresetParams{Diff: tt.diff})
+			var svcErr *ServiceError
+			if !errors.As(err, &svcErr) || !strings.Contains(svcErr.Message, tt.want) {
+				t.Errorf("error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
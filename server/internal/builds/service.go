@@ -5,16 +5,20 @@ import (
 	crand "crypto/rand"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/database"
 	"github.com/johnrirwin/flyingforge/internal/images"
 	"github.com/johnrirwin/flyingforge/internal/logging"
 	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/push"
+	"github.com/johnrirwin/flyingforge/internal/videoembed"
 )
 
 const (
@@ -23,8 +27,10 @@ const (
 	defaultTempBuildName = "Temporary Build"
 )
 
-// ServiceError represents a build service validation/runtime error.
+// ServiceError represents a build service validation/runtime error. Code is
+// set for errors handlers map to something other than a 400.
 type ServiceError struct {
+	Code    apierror.Code
 	Message string
 }
 
@@ -32,6 +38,11 @@ func (e *ServiceError) Error() string {
 	return e.Message
 }
 
+// ErrorCode returns the error's API error code
+func (e *ServiceError) ErrorCode() apierror.Code {
+	return e.Code
+}
+
 // ValidationError is returned when publish validation fails.
 type ValidationError struct {
 	Validation models.BuildValidationResult
@@ -50,6 +61,8 @@ type buildStore interface {
 	GetForOwner(ctx context.Context, id string, ownerUserID string) (*models.Build, error)
 	GetPublic(ctx context.Context, id string) (*models.Build, error)
 	GetTempByToken(ctx context.Context, token string) (*models.Build, error)
+	GetTempByViewToken(ctx context.Context, viewToken string) (*models.Build, error)
+	IssueTempViewToken(ctx context.Context, token string, viewToken string) (string, error)
 	GetForModeration(ctx context.Context, id string) (*models.Build, error)
 	Update(ctx context.Context, id string, ownerUserID string, params models.UpdateBuildParams) (*models.Build, error)
 	UpdateTempByToken(ctx context.Context, token string, params models.UpdateBuildParams, nextToken string) (*models.Build, error)
@@ -58,6 +71,8 @@ type buildStore interface {
 	SetImage(ctx context.Context, id string, ownerUserID string, imageAssetID string) (string, error)
 	SetImageForModeration(ctx context.Context, id string, imageAssetID string) (string, error)
 	GetImageForOwner(ctx context.Context, id string, ownerUserID string) ([]byte, error)
+	CountActiveDrafts(ctx context.Context, ownerUserID string) (int, error)
+	CountOutstandingTemp(ctx context.Context) (int, error)
 	GetPublicImage(ctx context.Context, id string) ([]byte, error)
 	GetImageForModeration(ctx context.Context, id string) ([]byte, error)
 	DeleteImage(ctx context.Context, id string, ownerUserID string) (string, error)
@@ -79,6 +94,7 @@ type gearCatalogMigrator interface {
 		category models.EquipmentCategory,
 		specs json.RawMessage,
 	) (*models.GearCatalogItem, error)
+	FindNearMatches(ctx context.Context, gearType models.GearType, brand, model string, threshold float64) ([]models.NearMatch, error)
 }
 
 type imagePipeline interface {
@@ -87,13 +103,58 @@ type imagePipeline interface {
 	Delete(ctx context.Context, imageID string) error
 }
 
+// ImageGallery manages the ordered images of a build beyond its primary image.
+type ImageGallery interface {
+	Images(ctx context.Context, entityType models.ImageEntityType, entityID, primaryAssetID, baseURL string) ([]models.EntityImage, error)
+	LoadAt(ctx context.Context, entityType models.ImageEntityType, entityID string, index int) ([]byte, error)
+	AttachAt(ctx context.Context, entityType models.ImageEntityType, entityID string, slot images.PrimarySlot, index int, assetID string) error
+	RemoveAt(ctx context.Context, entityType models.ImageEntityType, entityID string, slot images.PrimarySlot, index int) error
+	SetPrimaryAt(ctx context.Context, entityType models.ImageEntityType, entityID string, slot images.PrimarySlot, index int) error
+	PromoteFirst(ctx context.Context, entityType models.ImageEntityType, entityID string, slot images.PrimarySlot) error
+}
+
+// VideoEmbedder looks up embed details for a build's video link.
+type VideoEmbedder interface {
+	Lookup(ctx context.Context, videoURL string) *models.BuildVideo
+}
+
+// ShortLinker issues short share links for published builds.
+type ShortLinker interface {
+	Issue(ctx context.Context, targetType models.ShortLinkTargetType, targetID string) (*models.ShortLink, error)
+}
+
+// Transactor runs a function as a single database unit of work, so writes
+// made through different stores commit or roll back together.
+type Transactor interface {
+	RunInTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// notifyTimeout bounds background notification delivery.
+const notifyTimeout = 15 * time.Second
+
 // Service coordinates build business logic.
 type Service struct {
-	store         buildStore
-	aircraftStore aircraftDetailsReader
-	gearCatalog   gearCatalogMigrator
-	imageSvc      imagePipeline
-	logger        *logging.Logger
+	store           buildStore
+	aircraftStore   aircraftDetailsReader
+	gearCatalog     gearCatalogMigrator
+	imageSvc        imagePipeline
+	gallery         ImageGallery
+	videos          VideoEmbedder
+	notifier        push.Notifier
+	shortLinks      ShortLinker
+	prices          PriceLookup
+	inventory       InventoryCounter
+	presets         PresetStore
+	templates       TemplateStore
+	templateCatalog TemplateCatalog
+	handoffs        HandoffStore
+	handoffSigner   HandoffSigner
+	history         ModerationHistory
+	tx              Transactor
+	maxDrafts       int
+	admission       tempAdmission
+	cardCache       CardCache
+	logger          *logging.Logger
 }
 
 // NewService creates a build service.
@@ -118,6 +179,66 @@ func NewServiceWithDeps(store buildStore, aircraftStore aircraftDetailsReader, g
 	}
 }
 
+// SetNotifier configures delivery of build notifications to owners.
+func (s *Service) SetNotifier(notifier push.Notifier) {
+	s.notifier = notifier
+}
+
+// SetTransactor makes multi-store operations, like creating a build from an
+// aircraft, run in one transaction. Without it each store call commits on
+// its own.
+func (s *Service) SetTransactor(tx Transactor) {
+	s.tx = tx
+}
+
+// runInTx runs fn in a unit of work when a transactor is configured
+func (s *Service) runInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.tx == nil {
+		return fn(ctx)
+	}
+	return s.tx.RunInTx(ctx, fn)
+}
+
+// SetGallery enables multiple images per build. Without it only the primary
+// image can be set.
+func (s *Service) SetGallery(gallery ImageGallery) {
+	s.gallery = gallery
+}
+
+// SetVideoEmbedder configures oEmbed lookups for build video links.
+func (s *Service) SetVideoEmbedder(videos VideoEmbedder) {
+	s.videos = videos
+}
+
+// SetShortLinker configures short link issuing when builds are published.
+func (s *Service) SetShortLinker(shortLinks ShortLinker) {
+	s.shortLinks = shortLinks
+}
+
+// SetDraftLimit caps how many active drafts a user can have. Zero, the
+// default, means no limit.
+func (s *Service) SetDraftLimit(maxDrafts int) {
+	s.maxDrafts = maxDrafts
+}
+
+// checkDraftLimit returns a ServiceError if the owner can't start another draft
+func (s *Service) checkDraftLimit(ctx context.Context, ownerUserID string) error {
+	if s.maxDrafts <= 0 {
+		return nil
+	}
+	count, err := s.store.CountActiveDrafts(ctx, ownerUserID)
+	if err != nil {
+		return err
+	}
+	if count >= s.maxDrafts {
+		return &ServiceError{
+			Code:    apierror.DraftLimitReached,
+			Message: fmt.Sprintf("you have %d active drafts, the most allowed; archive or delete one first", count),
+		}
+	}
+	return nil
+}
+
 // ListPublic returns published builds.
 func (s *Service) ListPublic(ctx context.Context, params models.BuildListParams) (*models.BuildListResponse, error) {
 	resp, err := s.store.ListPublic(ctx, params)
@@ -152,6 +273,10 @@ func (s *Service) GetPublic(ctx context.Context, id string) (*models.Build, erro
 		return nil, nil
 	}
 	build.Verified = isBuildVerified(build)
+	if err := s.setImages(ctx, build, "/api/public/builds/"+build.ID+"/image"); err != nil {
+		return nil, err
+	}
+	s.setVideo(ctx, build)
 	return build, nil
 }
 
@@ -187,11 +312,15 @@ func (s *Service) CreateTemp(ctx context.Context, ownerUserID string, params mod
 	build.Verified = isBuildVerified(build)
 	build.Token = ""
 
-	return &models.TempBuildCreateResponse{
+	resp := &models.TempBuildCreateResponse{
 		Build: build,
 		Token: token,
 		URL:   "/builds/temp/" + token,
-	}, nil
+	}
+	if ownerUserID == "" {
+		s.startHandoff(ctx, resp)
+	}
+	return resp, nil
 }
 
 // GetTempByToken returns a temp build if token is valid.
@@ -208,6 +337,42 @@ func (s *Service) GetTempByToken(ctx context.Context, token string) (*models.Bui
 	return build, nil
 }
 
+// GetTempByViewToken returns a temp build by its read-only token.
+func (s *Service) GetTempByViewToken(ctx context.Context, viewToken string) (*models.Build, error) {
+	build, err := s.store.GetTempByViewToken(ctx, strings.TrimSpace(viewToken))
+	if err != nil {
+		return nil, err
+	}
+	if build == nil {
+		return nil, nil
+	}
+	build.Verified = isBuildVerified(build)
+	build.Token = ""
+	return build, nil
+}
+
+// TempViewLink returns a read-only link to the temp build with edit token
+// token, issuing its view token the first time. The same link is returned
+// every time after that, across edits. An edit token for a revision that a
+// later edit replaced gets no link.
+func (s *Service) TempViewLink(ctx context.Context, token string) (*models.TempBuildViewLink, error) {
+	viewToken, err := generateTempToken()
+	if err != nil {
+		return nil, err
+	}
+	issued, err := s.store.IssueTempViewToken(ctx, strings.TrimSpace(token), viewToken)
+	if err != nil {
+		return nil, err
+	}
+	if issued == "" {
+		return nil, nil
+	}
+	return &models.TempBuildViewLink{
+		ViewToken: issued,
+		URL:       "/builds/view/" + issued,
+	}, nil
+}
+
 // UpdateTempByToken updates editable temp fields and rotates the temporary URL token.
 func (s *Service) UpdateTempByToken(ctx context.Context, token string, params models.UpdateBuildParams) (*models.TempBuildCreateResponse, error) {
 	if params.Title != nil {
@@ -236,11 +401,13 @@ func (s *Service) UpdateTempByToken(ctx context.Context, token string, params mo
 	}
 	build.Verified = isBuildVerified(build)
 	build.Token = ""
-	return &models.TempBuildCreateResponse{
+	resp := &models.TempBuildCreateResponse{
 		Build: build,
 		Token: nextToken,
 		URL:   "/builds/temp/" + nextToken,
-	}, nil
+	}
+	s.continueHandoff(ctx, token, resp)
+	return resp, nil
 }
 
 // ShareTempByToken snapshots a temporary/shared build into a new permanent shared link.
@@ -276,11 +443,13 @@ func (s *Service) ShareTempByToken(ctx context.Context, token string) (*models.T
 	sharedBuild.Verified = isBuildVerified(sharedBuild)
 	sharedBuild.Token = ""
 
-	return &models.TempBuildCreateResponse{
+	resp := &models.TempBuildCreateResponse{
 		Build: sharedBuild,
 		Token: sharedToken,
 		URL:   "/builds/temp/" + sharedToken,
-	}, nil
+	}
+	s.continueHandoff(ctx, token, resp)
+	return resp, nil
 }
 
 // ListByOwner returns authenticated user's builds.
@@ -297,6 +466,10 @@ func (s *Service) ListByOwner(ctx context.Context, ownerUserID string, params mo
 
 // CreateDraft creates a new draft build for a user.
 func (s *Service) CreateDraft(ctx context.Context, ownerUserID string, params models.CreateBuildParams) (*models.Build, error) {
+	if err := s.checkDraftLimit(ctx, ownerUserID); err != nil {
+		return nil, err
+	}
+
 	title := strings.TrimSpace(params.Title)
 	if title == "" {
 		title = defaultBuildTitle
@@ -320,8 +493,12 @@ func (s *Service) CreateDraft(ctx context.Context, ownerUserID string, params mo
 	return build, nil
 }
 
-// CreateDraftFromAircraft creates a draft build pre-filled from aircraft components.
-func (s *Service) CreateDraftFromAircraft(ctx context.Context, ownerUserID string, aircraftID string) (*models.Build, error) {
+// CreateDraftFromAircraft creates a draft build pre-filled from aircraft
+// components. Inventory items without a catalog entry are added to the catalog
+// first. Parts whose catalog item is still awaiting review come back with
+// near matches from the published catalog, and components that could not be
+// added are listed with a reason.
+func (s *Service) CreateDraftFromAircraft(ctx context.Context, ownerUserID string, aircraftID string) (*models.BuildFromAircraftResponse, error) {
 	aircraftID = strings.TrimSpace(aircraftID)
 	if aircraftID == "" {
 		return nil, &ServiceError{Message: "aircraft id is required"}
@@ -337,51 +514,8 @@ func (s *Service) CreateDraftFromAircraft(ctx context.Context, ownerUserID strin
 	if details == nil || details.Aircraft.ID == "" {
 		return nil, nil
 	}
-
-	parts := make([]models.BuildPartInput, 0)
-	for _, component := range details.Components {
-		if component.InventoryItem == nil {
-			continue
-		}
-		gearType := aircraftComponentToGearType(component.Category)
-		if gearType == "" {
-			continue
-		}
-		catalogID := strings.TrimSpace(component.InventoryItem.CatalogID)
-		if catalogID == "" && s.gearCatalog != nil {
-			category := component.InventoryItem.Category
-			if category == "" {
-				category = componentCategoryToEquipmentCategory(component.Category)
-			}
-			if category != "" {
-				catalogItem, err := s.gearCatalog.MigrateInventoryItem(
-					ctx,
-					component.InventoryItem.ID,
-					ownerUserID,
-					component.InventoryItem.Name,
-					component.InventoryItem.Manufacturer,
-					category,
-					component.InventoryItem.Specs,
-				)
-				if err != nil {
-					s.logger.Warn("Failed to backfill catalog entry while creating build from aircraft",
-						logging.WithFields(map[string]interface{}{
-							"aircraft_id": details.Aircraft.ID,
-							"component":   component.Category,
-							"error":       err.Error(),
-						}))
-				} else if catalogItem != nil {
-					catalogID = strings.TrimSpace(catalogItem.ID)
-				}
-			}
-		}
-		if catalogID == "" {
-			continue
-		}
-		parts = append(parts, models.BuildPartInput{
-			GearType:      gearType,
-			CatalogItemID: catalogID,
-		})
+	if err := s.checkDraftLimit(ctx, ownerUserID); err != nil {
+		return nil, err
 	}
 
 	title := strings.TrimSpace(details.Aircraft.Name)
@@ -392,17 +526,50 @@ func (s *Service) CreateDraftFromAircraft(ctx context.Context, ownerUserID strin
 		title += " Build"
 	}
 
-	build, err := s.store.Create(
-		ctx,
-		ownerUserID,
-		models.BuildStatusDraft,
-		title,
-		"",
-		details.Aircraft.ID,
-		"",
-		nil,
-		normalizeParts(parts),
-	)
+	// Catalog entries added for the aircraft's components are only kept if
+	// the build that uses them is created
+	var build *models.Build
+	var skipped []models.SkippedAircraftComponent
+	err = s.runInTx(ctx, func(ctx context.Context) error {
+		parts := make([]models.BuildPartInput, 0)
+		skipped = make([]models.SkippedAircraftComponent, 0)
+		for _, component := range details.Components {
+			if component.InventoryItem == nil {
+				continue
+			}
+			gearType := aircraftComponentToGearType(component.Category)
+			if gearType == "" {
+				continue
+			}
+			catalogID, reason := s.catalogIDForComponent(ctx, ownerUserID, details.Aircraft.ID, component)
+			if catalogID == "" {
+				skipped = append(skipped, models.SkippedAircraftComponent{
+					Category: component.Category,
+					Name:     component.InventoryItem.Name,
+					Reason:   reason,
+				})
+				continue
+			}
+			parts = append(parts, models.BuildPartInput{
+				GearType:      gearType,
+				CatalogItemID: catalogID,
+			})
+		}
+
+		created, err := s.store.Create(
+			ctx,
+			ownerUserID,
+			models.BuildStatusDraft,
+			title,
+			"",
+			details.Aircraft.ID,
+			"",
+			nil,
+			normalizeParts(parts),
+		)
+		build = created
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -420,7 +587,85 @@ func (s *Service) CreateDraftFromAircraft(ctx context.Context, ownerUserID strin
 		}
 	}
 	build.Verified = isBuildVerified(build)
-	return build, nil
+
+	response := &models.BuildFromAircraftResponse{Build: build}
+	if len(skipped) > 0 {
+		response.SkippedComponents = skipped
+	}
+	response.CatalogMatches = s.unpublishedPartMatches(ctx, build.Parts)
+	return response, nil
+}
+
+// catalogIDForComponent returns the catalog item for an aircraft component's
+// inventory item, adding one to the catalog when the item has none. When no
+// catalog item can be found it returns a reason instead.
+func (s *Service) catalogIDForComponent(ctx context.Context, ownerUserID string, aircraftID string, component models.AircraftComponent) (string, string) {
+	item := component.InventoryItem
+	if catalogID := strings.TrimSpace(item.CatalogID); catalogID != "" {
+		return catalogID, ""
+	}
+	if s.gearCatalog == nil {
+		return "", "inventory item is not in the gear catalog"
+	}
+
+	category := item.Category
+	if category == "" {
+		category = componentCategoryToEquipmentCategory(component.Category)
+	}
+	if category == "" {
+		return "", "inventory item has no category"
+	}
+
+	catalogItem, err := s.gearCatalog.MigrateInventoryItem(ctx, item.ID, ownerUserID, item.Name, item.Manufacturer, category, item.Specs)
+	if err != nil {
+		s.logger.Warn("Failed to backfill catalog entry while creating build from aircraft",
+			logging.WithFields(map[string]interface{}{
+				"aircraft_id": aircraftID,
+				"component":   component.Category,
+				"error":       err.Error(),
+			}))
+		return "", "could not add inventory item to the gear catalog"
+	}
+	if catalogItem == nil || strings.TrimSpace(catalogItem.ID) == "" {
+		return "", "could not add inventory item to the gear catalog"
+	}
+	return strings.TrimSpace(catalogItem.ID), ""
+}
+
+// unpublishedPartMatches looks up published near matches for every part whose
+// catalog item can't be published yet, so the owner can swap them in.
+func (s *Service) unpublishedPartMatches(ctx context.Context, parts []models.BuildPart) []models.BuildPartCatalogMatch {
+	var result []models.BuildPartCatalogMatch
+	for _, part := range parts {
+		if part.CatalogItem == nil || models.NormalizeCatalogStatus(part.CatalogItem.Status) == models.CatalogStatusPublished {
+			continue
+		}
+
+		prompt := models.BuildPartCatalogMatch{
+			GearType:      part.GearType,
+			Position:      part.Position,
+			CatalogItemID: part.CatalogItemID,
+			Name:          part.CatalogItem.DisplayName(),
+			Matches:       []models.NearMatch{},
+		}
+		if s.gearCatalog != nil {
+			matches, err := s.gearCatalog.FindNearMatches(ctx, part.GearType, part.CatalogItem.Brand, part.CatalogItem.Model, 0)
+			if err != nil {
+				s.logger.Warn("Failed to find catalog near matches for build part",
+					logging.WithFields(map[string]interface{}{
+						"catalog_item_id": part.CatalogItemID,
+						"error":           err.Error(),
+					}))
+			}
+			for _, match := range matches {
+				if match.Item.ID != part.CatalogItemID {
+					prompt.Matches = append(prompt.Matches, match)
+				}
+			}
+		}
+		result = append(result, prompt)
+	}
+	return result
 }
 
 // GetByOwner fetches one build for owner.
@@ -433,6 +678,29 @@ func (s *Service) GetByOwner(ctx context.Context, id string, ownerUserID string)
 		return nil, nil
 	}
 	build.Verified = isBuildVerified(build)
+	if err := s.setImages(ctx, build, "/api/builds/"+build.ID+"/image"); err != nil {
+		return nil, err
+	}
+	s.setVideo(ctx, build)
+	s.setRejection(ctx, build)
+	return build, nil
+}
+
+// GetOwnedOrPublic fetches one of the user's own builds or, failing that, a
+// published build. Images and video details are not loaded.
+func (s *Service) GetOwnedOrPublic(ctx context.Context, id string, userID string) (*models.Build, error) {
+	id = strings.TrimSpace(id)
+	build, err := s.store.GetForOwner(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if build == nil {
+		build, err = s.store.GetPublic(ctx, id)
+		if err != nil || build == nil {
+			return nil, err
+		}
+	}
+	build.Verified = isBuildVerified(build)
 	return build, nil
 }
 
@@ -459,6 +727,13 @@ func (s *Service) UpdateByOwner(ctx context.Context, id string, ownerUserID stri
 		desc := strings.TrimSpace(*params.Description)
 		params.Description = &desc
 	}
+	if params.VideoURL != nil {
+		videoURL, err := videoembed.Normalize(*params.VideoURL)
+		if err != nil {
+			return nil, &ServiceError{Message: err.Error()}
+		}
+		params.VideoURL = &videoURL
+	}
 	if params.Parts != nil {
 		params.Parts = normalizeParts(params.Parts)
 	}
@@ -484,6 +759,13 @@ func (s *Service) UpdateForModeration(ctx context.Context, id string, params mod
 		desc := strings.TrimSpace(*params.Description)
 		params.Description = &desc
 	}
+	if params.VideoURL != nil {
+		videoURL, err := videoembed.Normalize(*params.VideoURL)
+		if err != nil {
+			return nil, &ServiceError{Message: err.Error()}
+		}
+		params.VideoURL = &videoURL
+	}
 	if params.Parts != nil {
 		params.Parts = normalizeParts(params.Parts)
 	}
@@ -538,8 +820,33 @@ func (s *Service) Unpublish(ctx context.Context, id string, ownerUserID string)
 	return updated, nil
 }
 
+// ArchiveDraft sets a draft aside so it no longer counts toward the draft
+// limit. Returns nil if the owner has no draft with that id.
+func (s *Service) ArchiveDraft(ctx context.Context, id string, ownerUserID string) (*models.Build, error) {
+	updated, err := s.store.SetStatus(ctx, strings.TrimSpace(id), ownerUserID, models.BuildStatusArchived)
+	if err != nil || updated == nil {
+		return nil, err
+	}
+	updated.Verified = isBuildVerified(updated)
+	return updated, nil
+}
+
+// RestoreDraft makes an archived draft active again, if the owner is under
+// the draft limit. Returns nil if the owner has no archived draft with that id.
+func (s *Service) RestoreDraft(ctx context.Context, id string, ownerUserID string) (*models.Build, error) {
+	if err := s.checkDraftLimit(ctx, ownerUserID); err != nil {
+		return nil, err
+	}
+	updated, err := s.store.SetStatus(ctx, strings.TrimSpace(id), ownerUserID, models.BuildStatusDraft)
+	if err != nil || updated == nil {
+		return nil, err
+	}
+	updated.Verified = isBuildVerified(updated)
+	return updated, nil
+}
+
 // ApproveForModeration publishes a pending build from the moderation queue.
-func (s *Service) ApproveForModeration(ctx context.Context, id string) (*models.Build, models.BuildValidationResult, error) {
+func (s *Service) ApproveForModeration(ctx context.Context, id string, moderatorUserID string) (*models.Build, models.BuildValidationResult, error) {
 	build, err := s.store.GetForModeration(ctx, strings.TrimSpace(id))
 	if err != nil {
 		return nil, models.BuildValidationResult{}, err
@@ -553,7 +860,7 @@ func (s *Service) ApproveForModeration(ctx context.Context, id string) (*models.
 		return nil, validation, &ValidationError{Validation: validation}
 	}
 	if build.Status != models.BuildStatusPendingReview {
-		return nil, validation, &ServiceError{Message: "build is not pending moderation"}
+		return nil, validation, &ServiceError{Code: apierror.BuildNotPending, Message: "build is not pending moderation"}
 	}
 
 	updated, err := s.store.ApproveForModeration(ctx, build.ID)
@@ -564,9 +871,51 @@ func (s *Service) ApproveForModeration(ctx context.Context, id string) (*models.
 		return nil, validation, nil
 	}
 	updated.Verified = isBuildVerified(updated)
+	s.recordApproval(ctx, updated.ID, moderatorUserID)
+	s.issueShortLink(ctx, updated.ID)
+	s.notifyOwner(ctx, updated.OwnerUserID, models.Notification{
+		Kind:  models.NotificationBuildApproved,
+		Title: "Your build was approved",
+		Body:  fmt.Sprintf("%s is now published.", updated.Title),
+		Data:  map[string]string{"buildId": updated.ID},
+	})
 	return updated, validation, nil
 }
 
+// issueShortLink gives a newly published build its short link. Failures are
+// logged only; the link is issued on demand later.
+func (s *Service) issueShortLink(ctx context.Context, buildID string) {
+	if s.shortLinks == nil {
+		return
+	}
+	if _, err := s.shortLinks.Issue(ctx, models.ShortLinkBuild, buildID); err != nil {
+		s.logger.Warn("Short link issue failed", logging.WithFields(map[string]interface{}{
+			"buildId": buildID,
+			"error":   err.Error(),
+		}))
+	}
+}
+
+// notifyOwner delivers a notification in the background so moderation
+// requests never wait on push services. It runs in the request's tenant.
+func (s *Service) notifyOwner(ctx context.Context, userID string, n models.Notification) {
+	if s.notifier == nil || userID == "" {
+		return
+	}
+	detached := database.Detached(ctx)
+	go func() {
+		ctx, cancel := context.WithTimeout(detached, notifyTimeout)
+		defer cancel()
+		if err := s.notifier.Notify(ctx, userID, n); err != nil {
+			s.logger.Warn("Build notification failed", logging.WithFields(map[string]interface{}{
+				"userId": userID,
+				"kind":   n.Kind,
+				"error":  err.Error(),
+			}))
+		}
+	}()
+}
+
 // DeleteByOwner deletes an owned non-temp build regardless of draft/publication status.
 func (s *Service) DeleteByOwner(ctx context.Context, id string, ownerUserID string) (bool, error) {
 	return s.store.Delete(ctx, strings.TrimSpace(id), ownerUserID)
@@ -586,7 +935,7 @@ func (s *Service) SetImage(ctx context.Context, userID string, params models.Set
 		return nil, err
 	}
 	if build == nil {
-		return nil, &ServiceError{Message: "build not found"}
+		return nil, &ServiceError{Code: apierror.BuildNotFound, Message: "build not found"}
 	}
 
 	var (
@@ -638,6 +987,17 @@ func (s *Service) SetImage(ctx context.Context, userID string, params models.Set
 		return nil, &ServiceError{Message: "failed to persist build image"}
 	}
 
+	if params.Index != nil {
+		if s.gallery == nil {
+			_ = s.imageSvc.Delete(ctx, asset.ID)
+			return nil, &ServiceError{Message: images.ErrGalleryUnavailable.Error()}
+		}
+		if err := s.gallery.AttachAt(ctx, models.ImageEntityBuild, build.ID, s.ownerSlot(build, userID), *params.Index, asset.ID); err != nil {
+			return nil, galleryError(err)
+		}
+		return decision, nil
+	}
+
 	previousAssetID, err := s.store.SetImage(ctx, build.ID, userID, asset.ID)
 	if err != nil {
 		_ = s.imageSvc.Delete(ctx, asset.ID)
@@ -664,7 +1024,7 @@ func (s *Service) SetImageForModeration(ctx context.Context, moderatorUserID str
 		return nil, err
 	}
 	if build == nil {
-		return nil, &ServiceError{Message: "build not found"}
+		return nil, &ServiceError{Code: apierror.BuildNotFound, Message: "build not found"}
 	}
 
 	if len(params.ImageData) == 0 {
@@ -747,7 +1107,7 @@ func (s *Service) DeleteImage(ctx context.Context, buildID string, userID string
 		return err
 	}
 	if build == nil {
-		return &ServiceError{Message: "build not found"}
+		return &ServiceError{Code: apierror.BuildNotFound, Message: "build not found"}
 	}
 
 	previousAssetID, err := s.store.DeleteImage(ctx, build.ID, userID)
@@ -757,9 +1117,124 @@ func (s *Service) DeleteImage(ctx context.Context, buildID string, userID string
 	if previousAssetID != "" && s.imageSvc != nil {
 		_ = s.imageSvc.Delete(ctx, previousAssetID)
 	}
+	if s.gallery != nil {
+		build.ImageAssetID = ""
+		return s.gallery.PromoteFirst(ctx, models.ImageEntityBuild, build.ID, s.ownerSlot(build, userID))
+	}
+	return nil
+}
+
+// GetImageAt retrieves one of a build's images for its owner.
+func (s *Service) GetImageAt(ctx context.Context, buildID string, userID string, index int) ([]byte, string, error) {
+	if s.gallery == nil && index == 0 {
+		return s.GetImage(ctx, buildID, userID)
+	}
+	build, err := s.store.GetForOwner(ctx, strings.TrimSpace(buildID), userID)
+	if err != nil || build == nil {
+		return nil, "", err
+	}
+	return s.loadImageAt(ctx, build.ID, index)
+}
+
+// GetPublicImageAt retrieves one of a published build's images.
+func (s *Service) GetPublicImageAt(ctx context.Context, buildID string, index int) ([]byte, string, error) {
+	if s.gallery == nil && index == 0 {
+		return s.GetPublicImage(ctx, buildID)
+	}
+	build, err := s.store.GetPublic(ctx, strings.TrimSpace(buildID))
+	if err != nil || build == nil {
+		return nil, "", err
+	}
+	return s.loadImageAt(ctx, build.ID, index)
+}
+
+// DeleteImageAt removes one of a build's images. Removing the primary image
+// promotes the next one.
+func (s *Service) DeleteImageAt(ctx context.Context, buildID string, userID string, index int) error {
+	build, err := s.store.GetForOwner(ctx, strings.TrimSpace(buildID), userID)
+	if err != nil {
+		return err
+	}
+	if build == nil {
+		return &ServiceError{Code: apierror.BuildNotFound, Message: "build not found"}
+	}
+	if s.gallery == nil {
+		return &ServiceError{Message: images.ErrGalleryUnavailable.Error()}
+	}
+	return galleryError(s.gallery.RemoveAt(ctx, models.ImageEntityBuild, build.ID, s.ownerSlot(build, userID), index))
+}
+
+// SetPrimaryImage makes one of a build's images its primary image.
+func (s *Service) SetPrimaryImage(ctx context.Context, buildID string, userID string, index int) error {
+	build, err := s.store.GetForOwner(ctx, strings.TrimSpace(buildID), userID)
+	if err != nil {
+		return err
+	}
+	if build == nil {
+		return &ServiceError{Code: apierror.BuildNotFound, Message: "build not found"}
+	}
+	if s.gallery == nil {
+		return &ServiceError{Message: images.ErrGalleryUnavailable.Error()}
+	}
+	return galleryError(s.gallery.SetPrimaryAt(ctx, models.ImageEntityBuild, build.ID, s.ownerSlot(build, userID), index))
+}
+
+func (s *Service) loadImageAt(ctx context.Context, buildID string, index int) ([]byte, string, error) {
+	if s.gallery == nil {
+		return nil, "", nil
+	}
+	imageData, err := s.gallery.LoadAt(ctx, models.ImageEntityBuild, buildID, index)
+	if errors.Is(err, images.ErrImageIndexOutOfRange) {
+		return nil, "", nil
+	}
+	if err != nil || len(imageData) == 0 {
+		return nil, "", err
+	}
+	return imageData, http.DetectContentType(imageData), nil
+}
+
+// ownerSlot exposes an owned build's primary image column to the gallery.
+func (s *Service) ownerSlot(build *models.Build, userID string) images.PrimarySlot {
+	return images.PrimarySlot{
+		AssetID: build.ImageAssetID,
+		Set: func(ctx context.Context, assetID string) (string, error) {
+			return s.store.SetImage(ctx, build.ID, userID, assetID)
+		},
+		Clear: func(ctx context.Context) (string, error) {
+			return s.store.DeleteImage(ctx, build.ID, userID)
+		},
+	}
+}
+
+// setImages lists a single build's images for API responses.
+func (s *Service) setImages(ctx context.Context, build *models.Build, baseURL string) error {
+	if s.gallery == nil {
+		return nil
+	}
+	buildImages, err := s.gallery.Images(ctx, models.ImageEntityBuild, build.ID, build.ImageAssetID, baseURL)
+	if err != nil {
+		return err
+	}
+	build.Images = buildImages
 	return nil
 }
 
+// setVideo adds embed details for a single build's video link.
+func (s *Service) setVideo(ctx context.Context, build *models.Build) {
+	if s.videos == nil || build.VideoURL == "" {
+		return
+	}
+	build.Video = s.videos.Lookup(ctx, build.VideoURL)
+}
+
+// galleryError turns gallery validation errors into service errors.
+func galleryError(err error) error {
+	if errors.Is(err, images.ErrImageIndexOutOfRange) || errors.Is(err, images.ErrTooManyImages) || errors.Is(err, images.ErrDuplicateImage) {
+		return &ServiceError{Message: err.Error()}
+	}
+	return err
+}
+
 // DeleteImageForModeration removes an image from a build via content moderation.
 func (s *Service) DeleteImageForModeration(ctx context.Context, buildID string) error {
 	build, err := s.store.GetForModeration(ctx, strings.TrimSpace(buildID))
@@ -767,7 +1242,7 @@ func (s *Service) DeleteImageForModeration(ctx context.Context, buildID string)
 		return err
 	}
 	if build == nil {
-		return &ServiceError{Message: "build not found"}
+		return &ServiceError{Code: apierror.BuildNotFound, Message: "build not found"}
 	}
 
 	previousAssetID, err := s.store.DeleteImageForModeration(ctx, build.ID)
@@ -854,23 +1329,19 @@ func ValidateForPublish(build *models.Build) models.BuildValidationResult {
 		})
 	}
 
-	required := []struct {
-		gearType models.GearType
-		label    string
-		category string
-	}{
-		{models.GearTypeFrame, "Frame", "frame"},
-		{models.GearTypeMotor, "Motors", "motor"},
-		{models.GearTypeReceiver, "Receiver", "receiver"},
-		{models.GearTypeVTX, "VTX", "vtx"},
+	var required []models.GearTypeInfo
+	for _, info := range models.GearTypeInfos() {
+		if info.RequiredForPublish {
+			required = append(required, info)
+		}
 	}
 
 	for _, req := range required {
-		if !hasPart(build.Parts, req.gearType) {
+		if !hasRequiredPart(build.Parts, req.GearType) {
 			errors = append(errors, models.BuildValidationError{
-				Category: req.category,
+				Category: string(req.GearType),
 				Code:     "missing_required",
-				Message:  req.label + " is required",
+				Message:  req.Label + " is required",
 			})
 		}
 	}
@@ -887,51 +1358,30 @@ func ValidateForPublish(build *models.Build) models.BuildValidationResult {
 	}
 
 	if build.SourceAircraftID != "" {
-		checkPublished := []struct {
-			gearType models.GearType
-			label    string
-			category string
-		}{
-			{models.GearTypeFrame, "Frame", "frame"},
-			{models.GearTypeMotor, "Motors", "motor"},
-			{models.GearTypeReceiver, "Receiver", "receiver"},
-			{models.GearTypeVTX, "VTX", "vtx"},
-		}
+		checkPublished := append([]models.GearTypeInfo(nil), required...)
+		powerStack := []models.GearType{models.GearTypeFC, models.GearTypeESC}
 		if hasAIO {
-			checkPublished = append(checkPublished, struct {
-				gearType models.GearType
-				label    string
-				category string
-			}{models.GearTypeAIO, "AIO", "aio"})
-		} else {
-			checkPublished = append(checkPublished,
-				struct {
-					gearType models.GearType
-					label    string
-					category string
-				}{models.GearTypeFC, "Flight Controller", "fc"},
-				struct {
-					gearType models.GearType
-					label    string
-					category string
-				}{models.GearTypeESC, "ESC", "esc"},
-			)
+			powerStack = []models.GearType{models.GearTypeAIO}
+		}
+		for _, gearType := range powerStack {
+			info, _ := models.LookupGearType(gearType)
+			checkPublished = append(checkPublished, info)
 		}
 
 		for _, part := range checkPublished {
-			p := findFirstPart(build.Parts, part.gearType)
+			p := requiredPart(build.Parts, part.GearType)
 			if p == nil {
 				continue
 			}
 			if p.CatalogItem == nil || models.NormalizeCatalogStatus(p.CatalogItem.Status) != models.CatalogStatusPublished {
-				name := part.label
+				name := part.Label
 				if p.CatalogItem != nil {
 					if displayName := p.CatalogItem.DisplayName(); displayName != "" {
 						name = displayName
 					}
 				}
 				errors = append(errors, models.BuildValidationError{
-					Category: part.category,
+					Category: string(part.GearType),
 					Code:     "not_published",
 					Message:  fmt.Sprintf("%s is not a published catalog item", name),
 				})
@@ -1000,6 +1450,36 @@ func findFirstPart(parts []models.BuildPart, gearType models.GearType) *models.B
 	return nil
 }
 
+// hasRequiredPart reports whether a build has a part of a required gear type
+// or of a type that can stand in for it, such as an HD unit for a VTX
+func hasRequiredPart(parts []models.BuildPart, gearType models.GearType) bool {
+	if hasPart(parts, gearType) {
+		return true
+	}
+	for _, info := range models.GearTypeInfos() {
+		if info.Satisfies == gearType && hasPart(parts, info.GearType) {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredPart returns the first part filling a required gear type
+func requiredPart(parts []models.BuildPart, gearType models.GearType) *models.BuildPart {
+	if part := findFirstPart(parts, gearType); part != nil {
+		return part
+	}
+	for _, info := range models.GearTypeInfos() {
+		if info.Satisfies != gearType {
+			continue
+		}
+		if part := findFirstPart(parts, info.GearType); part != nil {
+			return part
+		}
+	}
+	return nil
+}
+
 func isBuildVerified(build *models.Build) bool {
 	if build == nil {
 		return false
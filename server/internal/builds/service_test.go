@@ -2,11 +2,13 @@ package builds
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/images"
 	"github.com/johnrirwin/flyingforge/internal/logging"
 	"github.com/johnrirwin/flyingforge/internal/models"
@@ -77,6 +79,31 @@ func TestValidateForPublish_PowerStackLogic(t *testing.T) {
 	}
 }
 
+func TestValidateForPublish_HDUnitStandsInForVTX(t *testing.T) {
+	build := &models.Build{
+		ImageAssetID:     "asset-1",
+		Description:      "Test build",
+		SourceAircraftID: "aircraft-1",
+		Parts: []models.BuildPart{
+			{GearType: models.GearTypeFrame, CatalogItemID: "frame-1", CatalogItem: publishedCatalog("frame-1", models.GearTypeFrame)},
+			{GearType: models.GearTypeMotor, CatalogItemID: "motor-1", CatalogItem: publishedCatalog("motor-1", models.GearTypeMotor)},
+			{GearType: models.GearTypeAIO, CatalogItemID: "aio-1", CatalogItem: publishedCatalog("aio-1", models.GearTypeAIO)},
+			{GearType: models.GearTypeReceiver, CatalogItemID: "rx-1", CatalogItem: publishedCatalog("rx-1", models.GearTypeReceiver)},
+			{GearType: models.GearTypeHDUnit, CatalogItemID: "hd-1", CatalogItem: publishedCatalog("hd-1", models.GearTypeHDUnit)},
+			{GearType: models.GearTypeGPS, CatalogItemID: "gps-1", CatalogItem: publishedCatalog("gps-1", models.GearTypeGPS)},
+		},
+	}
+
+	result := ValidateForPublish(build)
+	if !result.Valid {
+		t.Fatalf("expected HD unit to satisfy the VTX requirement, errors=%v", result.Errors)
+	}
+
+	build.Parts[4].CatalogItem = pendingCatalog("hd-1", models.GearTypeHDUnit)
+	result = ValidateForPublish(build)
+	assertHasValidationCode(t, result.Errors, "vtx", "not_published")
+}
+
 func TestValidateForPublish_FromAircraftRequiresPublishedCatalogParts(t *testing.T) {
 	build := &models.Build{
 		ImageAssetID:     "asset-1",
@@ -370,6 +397,53 @@ func TestUpdateTempByToken_SharedSnapshotIsReadOnly(t *testing.T) {
 	}
 }
 
+func TestTempViewLink_IsReadOnly(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeBuildStore()
+	svc := NewServiceWithDeps(store, nil, nil, logging.New(logging.LevelError))
+
+	created, err := svc.CreateTemp(ctx, "", models.CreateBuildParams{Title: "Look only"})
+	if err != nil {
+		t.Fatalf("CreateTemp error: %v", err)
+	}
+	link, err := svc.TempViewLink(ctx, created.Token)
+	if err != nil || link == nil {
+		t.Fatalf("TempViewLink = %+v, %v", link, err)
+	}
+	if link.ViewToken == created.Token || link.URL != "/builds/view/"+link.ViewToken {
+		t.Fatalf("unexpected view link %+v", link)
+	}
+	again, err := svc.TempViewLink(ctx, created.Token)
+	if err != nil || again == nil || again.ViewToken != link.ViewToken {
+		t.Fatalf("second TempViewLink = %+v, %v; want the same token", again, err)
+	}
+
+	// The view token can't edit, share or issue links
+	title := "Vandalized"
+	if updated, err := svc.UpdateTempByToken(ctx, link.ViewToken, models.UpdateBuildParams{Title: &title}); err != nil || updated != nil {
+		t.Fatalf("UpdateTempByToken with view token = %+v, %v; want nil", updated, err)
+	}
+	if shared, err := svc.ShareTempByToken(ctx, link.ViewToken); err != nil || shared != nil {
+		t.Fatalf("ShareTempByToken with view token = %+v, %v; want nil", shared, err)
+	}
+	if viewLink, err := svc.TempViewLink(ctx, link.ViewToken); err != nil || viewLink != nil {
+		t.Fatalf("TempViewLink with view token = %+v, %v; want nil", viewLink, err)
+	}
+
+	// Edits with the edit token show up through the same view link
+	title = "Look only v2"
+	if _, err := svc.UpdateTempByToken(ctx, created.Token, models.UpdateBuildParams{Title: &title}); err != nil {
+		t.Fatalf("UpdateTempByToken error: %v", err)
+	}
+	viewed, err := svc.GetTempByViewToken(ctx, link.ViewToken)
+	if err != nil || viewed == nil {
+		t.Fatalf("GetTempByViewToken = %+v, %v", viewed, err)
+	}
+	if viewed.Title != "Look only v2" || viewed.Token != "" {
+		t.Errorf("viewed build title = %q, token = %q", viewed.Title, viewed.Token)
+	}
+}
+
 func TestDeleteByOwner(t *testing.T) {
 	ctx := context.Background()
 	store := newFakeBuildStore()
@@ -397,6 +471,190 @@ func TestDeleteByOwner(t *testing.T) {
 	}
 }
 
+func TestDraftLimit_ArchiveAndRestore(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeBuildStore()
+	svc := NewServiceWithDeps(store, nil, nil, logging.New(logging.LevelError))
+	svc.SetDraftLimit(2)
+
+	first, err := svc.CreateDraft(ctx, "user-1", models.CreateBuildParams{Title: "First"})
+	if err != nil {
+		t.Fatalf("CreateDraft error: %v", err)
+	}
+	if _, err := svc.CreateDraft(ctx, "user-1", models.CreateBuildParams{Title: "Second"}); err != nil {
+		t.Fatalf("CreateDraft error: %v", err)
+	}
+
+	_, err = svc.CreateDraft(ctx, "user-1", models.CreateBuildParams{Title: "Third"})
+	var svcErr *ServiceError
+	if !errors.As(err, &svcErr) || svcErr.Code != apierror.DraftLimitReached {
+		t.Fatalf("CreateDraft over the limit error = %v, want %s", err, apierror.DraftLimitReached)
+	}
+	if _, err := svc.CreateDraft(ctx, "user-2", models.CreateBuildParams{Title: "Other user"}); err != nil {
+		t.Fatalf("CreateDraft for another user error: %v", err)
+	}
+
+	archived, err := svc.ArchiveDraft(ctx, first.ID, "user-1")
+	if err != nil || archived == nil || archived.Status != models.BuildStatusArchived {
+		t.Fatalf("ArchiveDraft = %+v, %v", archived, err)
+	}
+	listed, err := svc.ListByOwner(ctx, "user-1", models.BuildListParams{Archived: true})
+	if err != nil || listed.TotalCount != 1 || listed.Builds[0].ID != first.ID {
+		t.Fatalf("archived list = %+v, %v", listed, err)
+	}
+	if _, err := svc.CreateDraft(ctx, "user-1", models.CreateBuildParams{Title: "Third"}); err != nil {
+		t.Fatalf("CreateDraft after archiving error: %v", err)
+	}
+
+	// Restoring needs room under the limit too
+	if _, err := svc.RestoreDraft(ctx, first.ID, "user-1"); !errors.As(err, &svcErr) || svcErr.Code != apierror.DraftLimitReached {
+		t.Fatalf("RestoreDraft at the limit error = %v, want %s", err, apierror.DraftLimitReached)
+	}
+	svc.SetDraftLimit(0)
+	restored, err := svc.RestoreDraft(ctx, first.ID, "user-1")
+	if err != nil || restored == nil || restored.Status != models.BuildStatusDraft {
+		t.Fatalf("RestoreDraft = %+v, %v", restored, err)
+	}
+}
+
+type fakeVideoEmbedder struct{}
+
+func (fakeVideoEmbedder) Lookup(ctx context.Context, videoURL string) *models.BuildVideo {
+	return &models.BuildVideo{Provider: "youtube", EmbedURL: "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ", Title: "Maiden flight"}
+}
+
+func TestUpdateByOwner_VideoURL(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeBuildStore()
+	svc := NewServiceWithDeps(store, nil, nil, logging.New(logging.LevelError))
+	svc.SetVideoEmbedder(fakeVideoEmbedder{})
+
+	created, err := svc.CreateDraft(ctx, "user-1", models.CreateBuildParams{Title: "Freestyle 5"})
+	if err != nil {
+		t.Fatalf("CreateDraft error: %v", err)
+	}
+
+	invalid := "https://example.com/maiden.mp4"
+	_, err = svc.UpdateByOwner(ctx, created.ID, "user-1", models.UpdateBuildParams{VideoURL: &invalid})
+	var svcErr *ServiceError
+	if !errors.As(err, &svcErr) {
+		t.Fatalf("UpdateByOwner(unsupported video) error = %v, want ServiceError", err)
+	}
+
+	videoURL := "https://youtu.be/dQw4w9WgXcQ"
+	updated, err := svc.UpdateByOwner(ctx, created.ID, "user-1", models.UpdateBuildParams{VideoURL: &videoURL})
+	if err != nil {
+		t.Fatalf("UpdateByOwner error: %v", err)
+	}
+	if updated.VideoURL != "https://www.youtube.com/watch?v=dQw4w9WgXcQ" {
+		t.Errorf("videoUrl = %q, want canonical YouTube link", updated.VideoURL)
+	}
+
+	fetched, err := svc.GetByOwner(ctx, created.ID, "user-1")
+	if err != nil {
+		t.Fatalf("GetByOwner error: %v", err)
+	}
+	if fetched.Video == nil || fetched.Video.Title != "Maiden flight" {
+		t.Errorf("video = %+v, want embed details", fetched.Video)
+	}
+}
+
+func TestParsePatch(t *testing.T) {
+	patch, err := ParsePatch([]byte(`{"title":"Race quad","description":null,"parts":{"motor":{"catalogItemId":"motor-2"},"prop:1":null}}`))
+	if err != nil {
+		t.Fatalf("ParsePatch error: %v", err)
+	}
+	if patch.Title == nil || *patch.Title != "Race quad" {
+		t.Errorf("title = %v, want Race quad", patch.Title)
+	}
+	if patch.Description == nil || *patch.Description != "" {
+		t.Errorf("description = %v, want cleared", patch.Description)
+	}
+	if patch.VideoURL != nil {
+		t.Errorf("videoUrl = %v, want untouched", patch.VideoURL)
+	}
+	if motor := patch.Parts[PartKey{GearType: models.GearTypeMotor}]; motor == nil || *motor.CatalogItemID != "motor-2" || motor.Notes != nil {
+		t.Errorf("motor patch = %+v, want catalog item only", motor)
+	}
+	if prop, ok := patch.Parts[PartKey{GearType: models.GearTypeProp, Position: 1}]; !ok || prop != nil {
+		t.Errorf("prop:1 patch = %+v, want removal", prop)
+	}
+
+	for _, body := range []string{
+		`[]`,
+		`{"title":null}`,
+		`{"status":"published"}`,
+		`{"parts":{"warp_drive":{"catalogItemId":"x"}}}`,
+		`{"parts":{"motor:-1":null}}`,
+		`{"parts":{"motor":{"quantity":4}}}`,
+	} {
+		var svcErr *ServiceError
+		if _, err := ParsePatch([]byte(body)); !errors.As(err, &svcErr) {
+			t.Errorf("ParsePatch(%s) error = %v, want ServiceError", body, err)
+		}
+	}
+}
+
+func TestPatchByOwner(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeBuildStore()
+	svc := NewServiceWithDeps(store, nil, nil, logging.New(logging.LevelError))
+
+	created, err := svc.CreateDraft(ctx, "user-1", models.CreateBuildParams{
+		Title: "Freestyle 5",
+		Parts: []models.BuildPartInput{
+			{GearType: models.GearTypeFrame, CatalogItemID: "frame-1"},
+			{GearType: models.GearTypeMotor, CatalogItemID: "motor-1", Notes: "2207 1750kv"},
+			{GearType: models.GearTypeVTX, CatalogItemID: "vtx-1"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateDraft error: %v", err)
+	}
+	before := store.byID[created.ID].UpdatedAt
+
+	patch, err := ParsePatch([]byte(`{"title":" Race quad ","parts":{"motor":{"catalogItemId":"motor-2"},"vtx":null,"camera":{"catalogItemId":"cam-1"}}}`))
+	if err != nil {
+		t.Fatalf("ParsePatch error: %v", err)
+	}
+	updated, err := svc.PatchByOwner(ctx, created.ID, "user-1", patch)
+	if err != nil {
+		t.Fatalf("PatchByOwner error: %v", err)
+	}
+	if updated.Title != "Race quad" {
+		t.Errorf("title = %q, want Race quad", updated.Title)
+	}
+	if updated.UpdatedAt.Before(before) {
+		t.Errorf("updatedAt = %v, want bumped from %v", updated.UpdatedAt, before)
+	}
+
+	parts := map[models.GearType]models.BuildPart{}
+	for _, part := range updated.Parts {
+		parts[part.GearType] = part
+	}
+	if len(parts) != 3 || parts[models.GearTypeFrame].CatalogItemID != "frame-1" || parts[models.GearTypeCamera].CatalogItemID != "cam-1" {
+		t.Errorf("parts = %+v, want frame, motor, and camera", updated.Parts)
+	}
+	if motor := parts[models.GearTypeMotor]; motor.CatalogItemID != "motor-2" || motor.Notes != "2207 1750kv" {
+		t.Errorf("motor = %+v, want new catalog item with notes kept", motor)
+	}
+
+	emptyTitle := ""
+	var svcErr *ServiceError
+	if _, err := svc.PatchByOwner(ctx, created.ID, "user-1", &Patch{Title: &emptyTitle}); !errors.As(err, &svcErr) {
+		t.Errorf("PatchByOwner(empty title) error = %v, want ServiceError", err)
+	}
+	newPart := &Patch{Parts: map[PartKey]*PartPatch{{GearType: models.GearTypeESC}: {Notes: &emptyTitle}}}
+	if _, err := svc.PatchByOwner(ctx, created.ID, "user-1", newPart); !errors.As(err, &svcErr) {
+		t.Errorf("PatchByOwner(part without catalog item) error = %v, want ServiceError", err)
+	}
+
+	other, err := svc.PatchByOwner(ctx, created.ID, "user-2", patch)
+	if err != nil || other != nil {
+		t.Errorf("PatchByOwner(other owner) = %+v, %v, want nil", other, err)
+	}
+}
+
 func TestPublish_SubmitsPendingReview(t *testing.T) {
 	ctx := context.Background()
 	store := newFakeBuildStore()
@@ -459,7 +717,7 @@ func TestApproveForModeration_PublishesPendingBuild(t *testing.T) {
 	}
 	store.byID[build.ID] = cloneBuild(build)
 
-	updated, validation, err := svc.ApproveForModeration(ctx, build.ID)
+	updated, validation, err := svc.ApproveForModeration(ctx, build.ID, "moderator-1")
 	if err != nil {
 		t.Fatalf("ApproveForModeration error: %v", err)
 	}
@@ -674,15 +932,17 @@ func pendingCatalog(id string, gearType models.GearType) *models.BuildCatalogIte
 
 // fakeBuildStore is a lightweight in-memory store used for service tests.
 type fakeBuildStore struct {
-	byID    map[string]*models.Build
-	byToken map[string]string
-	nextID  int
+	byID        map[string]*models.Build
+	byToken     map[string]string
+	byViewToken map[string]string
+	nextID      int
 }
 
 func newFakeBuildStore() *fakeBuildStore {
 	return &fakeBuildStore{
-		byID:    map[string]*models.Build{},
-		byToken: map[string]string{},
+		byID:        map[string]*models.Build{},
+		byToken:     map[string]string{},
+		byViewToken: map[string]string{},
 	}
 }
 
@@ -713,8 +973,16 @@ func (s *fakeBuildStore) Create(ctx context.Context, ownerUserID string, status
 func (s *fakeBuildStore) ListByOwner(ctx context.Context, ownerUserID string, params models.BuildListParams) (*models.BuildListResponse, error) {
 	items := make([]models.Build, 0)
 	for _, build := range s.byID {
-		if build.OwnerUserID == ownerUserID &&
-			(build.Status == models.BuildStatusDraft || build.Status == models.BuildStatusPendingReview || build.Status == models.BuildStatusPublished || build.Status == models.BuildStatusUnpublished) {
+		if build.OwnerUserID != ownerUserID {
+			continue
+		}
+		if params.Archived {
+			if build.Status == models.BuildStatusArchived {
+				items = append(items, *cloneBuild(build))
+			}
+			continue
+		}
+		if build.Status == models.BuildStatusDraft || build.Status == models.BuildStatusPendingReview || build.Status == models.BuildStatusPublished || build.Status == models.BuildStatusUnpublished {
 			items = append(items, *cloneBuild(build))
 		}
 	}
@@ -814,6 +1082,9 @@ func (s *fakeBuildStore) Update(ctx context.Context, id string, ownerUserID stri
 	if params.Description != nil {
 		build.Description = *params.Description
 	}
+	if params.VideoURL != nil {
+		build.VideoURL = *params.VideoURL
+	}
 	if params.Parts != nil {
 		build.Parts = convertParts(params.Parts)
 	}
@@ -871,9 +1142,36 @@ func (s *fakeBuildStore) UpdateTempByToken(ctx context.Context, token string, pa
 
 	s.byID[newID] = cloneBuild(next)
 	s.byToken[nextToken] = newID
+	for viewToken, buildID := range s.byViewToken {
+		if buildID == id {
+			s.byViewToken[viewToken] = newID
+		}
+	}
 	return cloneBuild(next), nil
 }
 
+func (s *fakeBuildStore) GetTempByViewToken(ctx context.Context, viewToken string) (*models.Build, error) {
+	build := s.byID[s.byViewToken[viewToken]]
+	if build == nil || build.Status != models.BuildStatusTemp {
+		return nil, nil
+	}
+	return cloneBuild(build), nil
+}
+
+func (s *fakeBuildStore) IssueTempViewToken(ctx context.Context, token string, viewToken string) (string, error) {
+	id, ok := s.byToken[token]
+	if !ok || s.byID[id].Status != models.BuildStatusTemp {
+		return "", nil
+	}
+	for existing, buildID := range s.byViewToken {
+		if buildID == id {
+			return existing, nil
+		}
+	}
+	s.byViewToken[viewToken] = id
+	return viewToken, nil
+}
+
 func (s *fakeBuildStore) UpdateForModeration(ctx context.Context, id string, params models.UpdateBuildParams) (*models.Build, error) {
 	build := s.byID[id]
 	if build == nil {
@@ -916,6 +1214,27 @@ func (s *fakeBuildStore) ShareTempByToken(ctx context.Context, token string) (*m
 	return cloneBuild(build), nil
 }
 
+func (s *fakeBuildStore) CountActiveDrafts(ctx context.Context, ownerUserID string) (int, error) {
+	count := 0
+	for _, build := range s.byID {
+		if build.OwnerUserID == ownerUserID && build.Status == models.BuildStatusDraft {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *fakeBuildStore) CountOutstandingTemp(ctx context.Context) (int, error) {
+	count := 0
+	now := time.Now().UTC()
+	for _, build := range s.byID {
+		if build.Status == models.BuildStatusTemp && (build.ExpiresAt == nil || build.ExpiresAt.After(now)) {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func (s *fakeBuildStore) SetStatus(ctx context.Context, id string, ownerUserID string, status models.BuildStatus) (*models.Build, error) {
 	build := s.byID[id]
 	if build == nil || build.OwnerUserID != ownerUserID {
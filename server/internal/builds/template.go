@@ -0,0 +1,117 @@
+package builds
+
+import (
+	"context"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// templateCandidates is how many of the most used published items of a gear
+// type are checked against a slot's spec filters
+const templateCandidates = 200
+
+// TemplateStore reads curated build templates.
+type TemplateStore interface {
+	List(ctx context.Context) ([]models.BuildTemplate, error)
+	Get(ctx context.Context, id string) (*models.BuildTemplate, error)
+}
+
+// TemplateCatalog finds published parts to pre-fill template slots with.
+type TemplateCatalog interface {
+	GetPopular(ctx context.Context, gearType models.GearType, limit int) ([]models.GearCatalogItem, error)
+}
+
+// SetTemplates enables starting drafts from curated build templates.
+func (s *Service) SetTemplates(templates TemplateStore, catalog TemplateCatalog) {
+	s.templates = templates
+	s.templateCatalog = catalog
+}
+
+// ListTemplates returns the curated build templates.
+func (s *Service) ListTemplates(ctx context.Context) (*models.BuildTemplateListResponse, error) {
+	if s.templates == nil {
+		return &models.BuildTemplateListResponse{Templates: []models.BuildTemplate{}}, nil
+	}
+	templates, err := s.templates.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &models.BuildTemplateListResponse{Templates: templates}, nil
+}
+
+// CreateDraftFromTemplate starts a draft build from a template. Each slot is
+// filled with the most used published part that meets its spec filters.
+// Slots nothing matches are left out of the draft and listed in the
+// response. Returns nil if there is no template with that id.
+func (s *Service) CreateDraftFromTemplate(ctx context.Context, ownerUserID string, templateID string) (*models.BuildFromTemplateResponse, error) {
+	templateID = strings.TrimSpace(templateID)
+	if templateID == "" {
+		return nil, &ServiceError{Message: "template id is required"}
+	}
+	if s.templates == nil {
+		return nil, nil
+	}
+
+	template, err := s.templates.Get(ctx, templateID)
+	if err != nil || template == nil {
+		return nil, err
+	}
+	if err := s.checkDraftLimit(ctx, ownerUserID); err != nil {
+		return nil, err
+	}
+
+	candidates := make(map[models.GearType][]models.GearCatalogItem)
+	parts := make([]models.BuildPartInput, 0, len(template.Slots))
+	unfilled := make([]models.BuildTemplateSlot, 0)
+	for _, slot := range template.Slots {
+		items, ok := candidates[slot.GearType]
+		if !ok && s.templateCatalog != nil {
+			items, err = s.templateCatalog.GetPopular(ctx, slot.GearType, templateCandidates)
+			if err != nil {
+				return nil, err
+			}
+			candidates[slot.GearType] = items
+		}
+
+		catalogID := ""
+		for _, item := range items {
+			if models.MatchesSpecs(slot.SpecFilters, item.Specs) {
+				catalogID = item.ID
+				break
+			}
+		}
+		if catalogID == "" {
+			unfilled = append(unfilled, slot)
+			continue
+		}
+		parts = append(parts, models.BuildPartInput{
+			GearType:      slot.GearType,
+			CatalogItemID: catalogID,
+			Position:      slot.Position,
+			Notes:         slot.Label,
+		})
+	}
+
+	build, err := s.store.Create(
+		ctx,
+		ownerUserID,
+		models.BuildStatusDraft,
+		template.Title,
+		template.Description,
+		"",
+		"",
+		nil,
+		normalizeParts(parts),
+	)
+	if err != nil {
+		return nil, err
+	}
+	build.Verified = isBuildVerified(build)
+
+	return &models.BuildFromTemplateResponse{
+		Build:         build,
+		TemplateID:    template.ID,
+		UnfilledSlots: unfilled,
+	}, nil
+}
@@ -0,0 +1,131 @@
+package builds
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+type fakeTemplateStore map[string]*models.BuildTemplate
+
+func (f fakeTemplateStore) List(ctx context.Context) ([]models.BuildTemplate, error) {
+	templates := make([]models.BuildTemplate, 0, len(f))
+	for _, template := range f {
+		templates = append(templates, *template)
+	}
+	return templates, nil
+}
+
+func (f fakeTemplateStore) Get(ctx context.Context, id string) (*models.BuildTemplate, error) {
+	return f[id], nil
+}
+
+type fakeTemplateCatalog map[models.GearType][]models.GearCatalogItem
+
+func (f fakeTemplateCatalog) GetPopular(ctx context.Context, gearType models.GearType, limit int) ([]models.GearCatalogItem, error) {
+	return f[gearType], nil
+}
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func TestCreateDraftFromTemplate(t *testing.T) {
+	motorFilters := []models.BuildSpecFilter{
+		{Key: "statorSize", Values: []string{"2207", "2306"}},
+		{Key: "kv", Min: float(1600), Max: float(1950)},
+	}
+	templates := fakeTemplateStore{
+		"tpl-5in": {
+			ID:          "tpl-5in",
+			Title:       `5" Freestyle`,
+			Description: "A durable 5 inch quad",
+			Slots: []models.BuildTemplateSlot{
+				{GearType: models.GearTypeMotor, Position: 0, Label: "2207 motor", SpecFilters: motorFilters},
+				{GearType: models.GearTypeMotor, Position: 1, Label: "2207 motor", SpecFilters: motorFilters},
+				{GearType: models.GearTypeFrame, Position: 0, SpecFilters: []models.BuildSpecFilter{{Key: "size", Min: float(5), Max: float(5)}}},
+				{GearType: models.GearTypeVTX, Position: 0, SpecFilters: []models.BuildSpecFilter{}},
+			},
+		},
+	}
+	catalog := fakeTemplateCatalog{
+		models.GearTypeMotor: {
+			{ID: "motor-2807", Specs: json.RawMessage(`{"statorSize": "2807", "kv": 1300}`)},
+			{ID: "motor-hot", Specs: json.RawMessage(`{"statorSize": "2207", "kv": "2550kv"}`)},
+			{ID: "motor-nospecs"},
+			{ID: "motor-2207", Specs: json.RawMessage(`{"statorSize": "2207", "kv": "1750KV"}`)},
+		},
+		models.GearTypeFrame: {
+			{ID: "frame-7", Specs: json.RawMessage(`{"size": "7\""}`)},
+		},
+		models.GearTypeVTX: {
+			{ID: "vtx-1"},
+		},
+	}
+
+	svc := NewServiceWithDeps(newFakeBuildStore(), nil, nil, logging.New(logging.LevelError))
+	svc.SetTemplates(templates, catalog)
+
+	resp, err := svc.CreateDraftFromTemplate(context.Background(), "user-1", "tpl-5in")
+	if err != nil {
+		t.Fatalf("CreateDraftFromTemplate() error = %v", err)
+	}
+	if resp == nil || resp.Build == nil {
+		t.Fatalf("expected a draft, got %+v", resp)
+	}
+	if resp.Build.Status != models.BuildStatusDraft || resp.Build.OwnerUserID != "user-1" || resp.Build.Title != `5" Freestyle` {
+		t.Fatalf("unexpected build %+v", resp.Build)
+	}
+
+	got := map[string]int{}
+	for _, part := range resp.Build.Parts {
+		got[part.CatalogItemID]++
+	}
+	if got["motor-2207"] != 2 || got["vtx-1"] != 1 || len(resp.Build.Parts) != 3 {
+		t.Fatalf("unexpected parts %+v", resp.Build.Parts)
+	}
+	if len(resp.UnfilledSlots) != 1 || resp.UnfilledSlots[0].GearType != models.GearTypeFrame {
+		t.Fatalf("expected the frame slot unfilled, got %+v", resp.UnfilledSlots)
+	}
+}
+
+func TestCreateDraftFromTemplate_NotFound(t *testing.T) {
+	svc := NewServiceWithDeps(newFakeBuildStore(), nil, nil, logging.New(logging.LevelError))
+	svc.SetTemplates(fakeTemplateStore{}, fakeTemplateCatalog{})
+
+	resp, err := svc.CreateDraftFromTemplate(context.Background(), "user-1", "missing")
+	if err != nil || resp != nil {
+		t.Fatalf("expected nil, nil; got %+v, %v", resp, err)
+	}
+}
+
+func TestBuildSpecFilterMatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter models.BuildSpecFilter
+		specs  string
+		want   bool
+	}{
+		{"number in range", models.BuildSpecFilter{Key: "kv", Min: float(1600), Max: float(1950)}, `{"kv": 1750}`, true},
+		{"string with unit", models.BuildSpecFilter{Key: "kv", Min: float(1600), Max: float(1950)}, `{"kv": "1750KV"}`, true},
+		{"above max", models.BuildSpecFilter{Key: "kv", Max: float(1950)}, `{"kv": "2550kv"}`, false},
+		{"inch size", models.BuildSpecFilter{Key: "size", Min: float(5), Max: float(5)}, `{"size": "5\""}`, true},
+		{"cells", models.BuildSpecFilter{Key: "cells", Min: float(6)}, `{"cells": "6S"}`, true},
+		{"cell range overlaps", models.BuildSpecFilter{Key: "cells", Min: float(6)}, `{"cells": "3-6S"}`, true},
+		{"cell range below", models.BuildSpecFilter{Key: "cells", Min: float(6)}, `{"cells": "2-4S"}`, false},
+		{"value ignores case", models.BuildSpecFilter{Key: "protocol", Values: []string{"ELRS"}}, `{"protocol": "elrs"}`, true},
+		{"numeric value", models.BuildSpecFilter{Key: "statorSize", Values: []string{"2207"}}, `{"statorSize": 2207}`, true},
+		{"missing key", models.BuildSpecFilter{Key: "kv", Min: float(1)}, `{}`, false},
+		{"not a number", models.BuildSpecFilter{Key: "kv", Min: float(1)}, `{"kv": "fast"}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := models.MatchesSpecs([]models.BuildSpecFilter{tt.filter}, json.RawMessage(tt.specs)); got != tt.want {
+				t.Errorf("MatchesSpecs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,349 @@
+package builds
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/compat"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// wizardChoicesPerSlot bounds how many matching parts each slot keeps to
+// compose candidates from
+const wizardChoicesPerSlot = 5
+
+// Wizard strategies, in the order candidates are composed. A balanced
+// build that ends up the same as another is dropped.
+const (
+	wizardStrategyPopular  = "popular"
+	wizardStrategyValue    = "value"
+	wizardStrategyBalanced = "balanced"
+)
+
+// wizardSlot is one part of a wizard build. Quantity parts of the same item
+// fill it, like four motors.
+type wizardSlot struct {
+	gearType models.GearType
+	quantity int
+	filters  []models.BuildSpecFilter
+}
+
+func wizardFilter(key string, min, max float64) models.BuildSpecFilter {
+	return models.BuildSpecFilter{Key: key, Min: &min, Max: &max}
+}
+
+// wizardStyles lists each style's slots besides video and the receiver,
+// which depend on the other answers
+var wizardStyles = map[models.BuildWizardStyle]struct {
+	title string
+	slots []wizardSlot
+}{
+	models.BuildWizardFreestyle: {
+		title: `5" Freestyle`,
+		slots: []wizardSlot{
+			{gearType: models.GearTypeFrame, quantity: 1, filters: []models.BuildSpecFilter{wizardFilter("size", 5, 5)}},
+			{gearType: models.GearTypeMotor, quantity: 4, filters: []models.BuildSpecFilter{wizardFilter("kv", 1600, 1950)}},
+			{gearType: models.GearTypeFC, quantity: 1},
+			{gearType: models.GearTypeESC, quantity: 1, filters: []models.BuildSpecFilter{wizardFilter("cells", 6, 6)}},
+			{gearType: models.GearTypeProp, quantity: 1, filters: []models.BuildSpecFilter{wizardFilter("size", 5, 5.3)}},
+			{gearType: models.GearTypeBattery, quantity: 1, filters: []models.BuildSpecFilter{wizardFilter("cells", 6, 6)}},
+		},
+	},
+	models.BuildWizardSub250: {
+		title: `3.5" Sub-250`,
+		slots: []wizardSlot{
+			{gearType: models.GearTypeFrame, quantity: 1, filters: []models.BuildSpecFilter{wizardFilter("size", 3.5, 3.5)}},
+			{gearType: models.GearTypeMotor, quantity: 4, filters: []models.BuildSpecFilter{{Key: "statorSize", Values: []string{"1404", "1504", "1505", "1506", "1604"}}}},
+			{gearType: models.GearTypeAIO, quantity: 1},
+			{gearType: models.GearTypeProp, quantity: 1, filters: []models.BuildSpecFilter{wizardFilter("size", 3.5, 3.5)}},
+			{gearType: models.GearTypeBattery, quantity: 1, filters: []models.BuildSpecFilter{wizardFilter("cells", 4, 4), wizardFilter("capacity", 650, 850)}},
+		},
+	},
+	models.BuildWizardLongRange: {
+		title: `7" Long Range`,
+		slots: []wizardSlot{
+			{gearType: models.GearTypeFrame, quantity: 1, filters: []models.BuildSpecFilter{wizardFilter("size", 7, 7)}},
+			{gearType: models.GearTypeMotor, quantity: 4, filters: []models.BuildSpecFilter{wizardFilter("kv", 1100, 1500)}},
+			{gearType: models.GearTypeFC, quantity: 1},
+			{gearType: models.GearTypeESC, quantity: 1, filters: []models.BuildSpecFilter{wizardFilter("cells", 6, 6)}},
+			{gearType: models.GearTypeGPS, quantity: 1},
+			{gearType: models.GearTypeProp, quantity: 1, filters: []models.BuildSpecFilter{wizardFilter("size", 7, 7.5)}},
+			{gearType: models.GearTypeBattery, quantity: 1, filters: []models.BuildSpecFilter{wizardFilter("cells", 6, 6)}},
+		},
+	},
+}
+
+// wizardChoices are the parts that can fill a slot, most used first
+type wizardChoices struct {
+	slot  wizardSlot
+	items []models.GearCatalogItem
+}
+
+// BuildWizard composes candidate builds from published parts for a
+// beginner's answers. Each slot takes parts matching the style's specs,
+// the video answer and the radio ecosystem. Candidates are checked with the
+// compatibility engine and priced like a bill of materials, then ranked:
+// compatible builds first, then builds within budget by popularity, then
+// the rest by cost.
+func (s *Service) BuildWizard(ctx context.Context, params models.BuildWizardParams) (*models.BuildWizardResponse, error) {
+	params.Style = models.BuildWizardStyle(strings.ToLower(strings.TrimSpace(string(params.Style))))
+	params.Video = models.BuildWizardVideo(strings.ToLower(strings.TrimSpace(string(params.Video))))
+	params.RadioEcosystem = models.RCProtocol(strings.ToLower(strings.TrimSpace(string(params.RadioEcosystem))))
+
+	style, ok := wizardStyles[params.Style]
+	if !ok {
+		return nil, &ServiceError{Message: "style must be one of freestyle, sub250, long_range"}
+	}
+	if params.Video != models.BuildWizardAnalog && params.Video != models.BuildWizardDigital {
+		return nil, &ServiceError{Message: "video must be analog or digital"}
+	}
+	switch params.RadioEcosystem {
+	case models.RCProtocolELRS, models.RCProtocolCrossfire, models.RCProtocolGhost, models.RCProtocolFrSky:
+	default:
+		return nil, &ServiceError{Message: "radioEcosystem must be one of elrs, crossfire, ghost, frsky"}
+	}
+	if params.Budget < 0 {
+		return nil, &ServiceError{Message: "budget can't be negative"}
+	}
+	if s.templateCatalog == nil {
+		return nil, &ServiceError{Message: "the build wizard is not available"}
+	}
+
+	slots := append([]wizardSlot{}, style.slots...)
+	if params.Video == models.BuildWizardDigital {
+		slots = append(slots, wizardSlot{gearType: models.GearTypeHDUnit, quantity: 1})
+	} else {
+		slots = append(slots,
+			wizardSlot{gearType: models.GearTypeVTX, quantity: 1},
+			wizardSlot{gearType: models.GearTypeCamera, quantity: 1})
+	}
+	slots = append(slots, wizardSlot{gearType: models.GearTypeReceiver, quantity: 1})
+
+	choices := make([]wizardChoices, 0, len(slots))
+	var missing []models.GearType
+	for _, slot := range slots {
+		items, err := s.templateCatalog.GetPopular(ctx, slot.gearType, templateCandidates)
+		if err != nil {
+			return nil, err
+		}
+		matched := make([]models.GearCatalogItem, 0, wizardChoicesPerSlot)
+		for _, item := range items {
+			if len(matched) == wizardChoicesPerSlot {
+				break
+			}
+			if models.MatchesSpecs(slot.filters, item.Specs) && wizardAnswersMatch(params, item) {
+				matched = append(matched, item)
+			}
+		}
+		if len(matched) == 0 {
+			missing = append(missing, slot.gearType)
+			continue
+		}
+		choices = append(choices, wizardChoices{slot: slot, items: matched})
+	}
+	if missing == nil {
+		missing = []models.GearType{}
+	}
+
+	candidates := make([]models.BuildWizardCandidate, 0, 3)
+	seen := make(map[string]bool)
+	for _, strategy := range []string{wizardStrategyPopular, wizardStrategyValue, wizardStrategyBalanced} {
+		picks := wizardPicks(strategy, choices, params.Budget)
+		key := wizardPicksKey(picks)
+		if len(picks) == 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		candidates = append(candidates, s.wizardCandidate(ctx, strategy, style.title, choices, picks, params.Budget, missing))
+	}
+
+	rankWizardCandidates(candidates)
+	return &models.BuildWizardResponse{Params: params, Candidates: candidates}, nil
+}
+
+// wizardAnswersMatch checks the answers that depend on the part's
+// ecosystem rather than a single spec
+func wizardAnswersMatch(params models.BuildWizardParams, item models.GearCatalogItem) bool {
+	switch item.GearType {
+	case models.GearTypeReceiver:
+		return compat.ProfileOf(compat.PartFromCatalog(item)).Speaks(params.RadioEcosystem)
+	case models.GearTypeVTX, models.GearTypeCamera, models.GearTypeHDUnit:
+		system := compat.ProfileOf(compat.PartFromCatalog(item)).VideoSystem
+		if params.Video == models.BuildWizardAnalog {
+			return system == models.VideoSystemAnalog
+		}
+		return system != "" && system != models.VideoSystemAnalog
+	}
+	return true
+}
+
+// wizardPicks returns the chosen item index for each slot
+func wizardPicks(strategy string, choices []wizardChoices, budget float64) []int {
+	picks := make([]int, len(choices))
+	if strategy == wizardStrategyPopular {
+		return picks
+	}
+
+	for i, choice := range choices {
+		picks[i] = cheapestChoice(choice.items)
+	}
+	if strategy == wizardStrategyValue || budget <= 0 {
+		if strategy == wizardStrategyBalanced {
+			// Without a budget there is nothing to balance against
+			return nil
+		}
+		return picks
+	}
+
+	// Upgrade slots to their most used part, in slot order, while the
+	// build's MSRP total stays within budget
+	total := wizardMSRPTotal(choices, picks)
+	for i, choice := range choices {
+		if picks[i] == 0 {
+			continue
+		}
+		delta := float64(choice.slot.quantity) * (msrpOf(choice.items[0]) - msrpOf(choice.items[picks[i]]))
+		if total+delta <= budget {
+			total += delta
+			picks[i] = 0
+		}
+	}
+	return picks
+}
+
+// cheapestChoice returns the index of the cheapest item with an MSRP, or 0
+// when none has one
+func cheapestChoice(items []models.GearCatalogItem) int {
+	best := -1
+	for i, item := range items {
+		if item.MSRP == nil {
+			continue
+		}
+		if best < 0 || *item.MSRP < *items[best].MSRP {
+			best = i
+		}
+	}
+	if best < 0 {
+		return 0
+	}
+	return best
+}
+
+func msrpOf(item models.GearCatalogItem) float64 {
+	if item.MSRP == nil {
+		return 0
+	}
+	return *item.MSRP
+}
+
+func wizardMSRPTotal(choices []wizardChoices, picks []int) float64 {
+	total := 0.0
+	for i, choice := range choices {
+		total += float64(choice.slot.quantity) * msrpOf(choice.items[picks[i]])
+	}
+	return total
+}
+
+func wizardPicksKey(picks []int) string {
+	var b strings.Builder
+	for _, pick := range picks {
+		b.WriteByte(byte('0' + pick))
+	}
+	return b.String()
+}
+
+// wizardCandidate turns picks into a priced, checked build
+func (s *Service) wizardCandidate(ctx context.Context, strategy, title string, choices []wizardChoices, picks []int, budget float64, missing []models.GearType) models.BuildWizardCandidate {
+	parts := make([]models.BuildPart, 0)
+	compatParts := make([]compat.Part, 0, len(choices))
+	for i, choice := range choices {
+		item := choice.items[picks[i]]
+		for position := 0; position < choice.slot.quantity; position++ {
+			parts = append(parts, models.BuildPart{
+				GearType:      choice.slot.gearType,
+				CatalogItemID: item.ID,
+				Position:      position,
+				CatalogItem: &models.BuildCatalogItem{
+					ID:       item.ID,
+					GearType: item.GearType,
+					Brand:    item.Brand,
+					Model:    item.Model,
+					Variant:  item.Variant,
+					Status:   item.Status,
+					ImageURL: item.ImageURL,
+					MSRP:     item.MSRP,
+					Specs:    item.Specs,
+				},
+			})
+		}
+		compatParts = append(compatParts, compat.PartFromCatalog(item))
+	}
+
+	lines := bomLines(parts)
+	s.addLivePrices(ctx, lines)
+
+	candidate := models.BuildWizardCandidate{
+		Strategy:         strategy,
+		Title:            title,
+		Parts:            parts,
+		Lines:            lines,
+		Compatibility:    compat.Check(compatParts),
+		MissingGearTypes: missing,
+	}
+	for _, line := range lines {
+		switch {
+		case line.LivePrice != nil:
+			candidate.EstimatedCost += *line.LivePrice * float64(line.Quantity)
+			if candidate.Currency == "" {
+				candidate.Currency = line.Currency
+			}
+		case line.MSRP != nil:
+			candidate.EstimatedCost += *line.MSRP * float64(line.Quantity)
+		default:
+			candidate.UnpricedLines++
+		}
+	}
+	candidate.WithinBudget = budget <= 0 || candidate.EstimatedCost <= budget
+	return candidate
+}
+
+// rankWizardCandidates orders candidates best first and numbers them
+func rankWizardCandidates(candidates []models.BuildWizardCandidate) {
+	hasError := func(c models.BuildWizardCandidate) bool {
+		for _, issue := range c.Compatibility {
+			if issue.Severity == models.CompatibilityError {
+				return true
+			}
+		}
+		return false
+	}
+	popularity := func(c models.BuildWizardCandidate) int {
+		// Strategies are already ordered by how much they favor popular parts
+		switch c.Strategy {
+		case wizardStrategyPopular:
+			return 2
+		case wizardStrategyBalanced:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if hasError(a) != hasError(b) {
+			return !hasError(a)
+		}
+		if a.WithinBudget != b.WithinBudget {
+			return a.WithinBudget
+		}
+		if a.WithinBudget {
+			return popularity(a) > popularity(b)
+		}
+		return a.EstimatedCost < b.EstimatedCost
+	})
+	for i := range candidates {
+		candidates[i].Rank = i + 1
+	}
+}
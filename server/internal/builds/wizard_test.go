@@ -0,0 +1,204 @@
+package builds
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+func wizardItem(id string, gearType models.GearType, model string, msrp float64, specs string) models.GearCatalogItem {
+	item := models.GearCatalogItem{ID: id, GearType: gearType, Brand: "Test", Model: model, Status: models.CatalogStatusPublished, MSRP: &msrp}
+	if specs != "" {
+		item.Specs = json.RawMessage(specs)
+	}
+	return item
+}
+
+func wizardCatalog() fakeTemplateCatalog {
+	return fakeTemplateCatalog{
+		models.GearTypeFrame: {
+			wizardItem("frame-7", models.GearTypeFrame, "Chimera7", 90, `{"size": "7\""}`),
+			wizardItem("frame-pop", models.GearTypeFrame, "Source One", 40, `{"size": "5\""}`),
+			wizardItem("frame-cheap", models.GearTypeFrame, "Budget Five", 25, `{"size": 5}`),
+		},
+		models.GearTypeMotor: {
+			wizardItem("motor-pop", models.GearTypeMotor, "2207 1750KV", 25, `{"kv": "1750kv"}`),
+			wizardItem("motor-cheap", models.GearTypeMotor, "2207 1800KV", 15, `{"kv": 1800}`),
+		},
+		models.GearTypeFC: {
+			wizardItem("fc", models.GearTypeFC, "F7", 50, ""),
+		},
+		models.GearTypeESC: {
+			wizardItem("esc", models.GearTypeESC, "45A 4in1", 60, `{"cells": "3-6S"}`),
+		},
+		models.GearTypeProp: {
+			wizardItem("prop", models.GearTypeProp, "5.1x3.1x3", 4, `{"size": "5.1x3.1x3"}`),
+		},
+		models.GearTypeBattery: {
+			wizardItem("battery", models.GearTypeBattery, "6S 1100", 30, `{"cells": "6S"}`),
+		},
+		models.GearTypeVTX: {
+			wizardItem("vtx-analog", models.GearTypeVTX, "Unify Pro32", 35, ""),
+		},
+		models.GearTypeCamera: {
+			wizardItem("cam-analog", models.GearTypeCamera, "Ratel 2", 25, ""),
+		},
+		models.GearTypeHDUnit: {
+			wizardItem("o3", models.GearTypeHDUnit, "DJI O3 Air Unit", 229, ""),
+		},
+		models.GearTypeReceiver: {
+			wizardItem("rx-tbs", models.GearTypeReceiver, "Crossfire Nano RX", 30, ""),
+			wizardItem("rx-elrs", models.GearTypeReceiver, "ELRS EP2", 15, ""),
+		},
+	}
+}
+
+func newWizardService() *Service {
+	svc := NewServiceWithDeps(newFakeBuildStore(), nil, nil, logging.New(logging.LevelError))
+	svc.SetTemplates(fakeTemplateStore{}, wizardCatalog())
+	return svc
+}
+
+func partIDs(candidate models.BuildWizardCandidate) map[string]int {
+	ids := map[string]int{}
+	for _, part := range candidate.Parts {
+		ids[part.CatalogItemID]++
+	}
+	return ids
+}
+
+func strategies(candidates []models.BuildWizardCandidate) []string {
+	out := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		out[i] = candidate.Strategy
+	}
+	return out
+}
+
+func TestBuildWizard_ComposesFromAnswers(t *testing.T) {
+	resp, err := newWizardService().BuildWizard(context.Background(), models.BuildWizardParams{
+		Budget:         340,
+		Style:          "Freestyle",
+		Video:          models.BuildWizardAnalog,
+		RadioEcosystem: models.RCProtocolELRS,
+	})
+	if err != nil {
+		t.Fatalf("BuildWizard() error = %v", err)
+	}
+	if len(resp.Candidates) != 3 {
+		t.Fatalf("expected popular, value and balanced candidates, got %v", strategies(resp.Candidates))
+	}
+
+	for _, candidate := range resp.Candidates {
+		ids := partIDs(candidate)
+		if ids["rx-tbs"] > 0 || ids["rx-elrs"] != 1 {
+			t.Fatalf("%s: expected the ELRS receiver, got %v", candidate.Strategy, ids)
+		}
+		if ids["o3"] > 0 || ids["vtx-analog"] != 1 || ids["cam-analog"] != 1 {
+			t.Fatalf("%s: expected analog video, got %v", candidate.Strategy, ids)
+		}
+		if ids["frame-7"] > 0 {
+			t.Fatalf("%s: 7 inch frame on a 5 inch build: %v", candidate.Strategy, ids)
+		}
+		if len(candidate.Compatibility) != 0 {
+			t.Fatalf("%s: unexpected compatibility issues %+v", candidate.Strategy, candidate.Compatibility)
+		}
+	}
+
+	// Value:    25 + 4*15 + 50 + 60 + 4 + 30 + 35 + 25 + 15 = 304
+	// Balanced: the popular frame fits (319), the popular motors don't
+	// Popular:  40 + 4*25 + ... = 359, over budget
+	want := []struct {
+		strategy string
+		cost     float64
+		within   bool
+	}{
+		{"balanced", 319, true},
+		{"value", 304, true},
+		{"popular", 359, false},
+	}
+	for i, w := range want {
+		got := resp.Candidates[i]
+		if got.Rank != i+1 || got.Strategy != w.strategy || got.EstimatedCost != w.cost || got.WithinBudget != w.within {
+			t.Fatalf("candidate %d = %s %.0f within=%v, want %s %.0f within=%v", i, got.Strategy, got.EstimatedCost, got.WithinBudget, w.strategy, w.cost, w.within)
+		}
+	}
+	balanced := partIDs(resp.Candidates[0])
+	if balanced["frame-pop"] != 1 || balanced["motor-cheap"] != 4 {
+		t.Fatalf("balanced parts = %v", balanced)
+	}
+}
+
+func TestBuildWizard_OverBudgetRanksByCost(t *testing.T) {
+	resp, err := newWizardService().BuildWizard(context.Background(), models.BuildWizardParams{
+		Budget:         300,
+		Style:          models.BuildWizardFreestyle,
+		Video:          models.BuildWizardAnalog,
+		RadioEcosystem: models.RCProtocolELRS,
+	})
+	if err != nil {
+		t.Fatalf("BuildWizard() error = %v", err)
+	}
+
+	// Value: 25 + 4*15 + 50 + 60 + 4 + 30 + 35 + 25 + 15 = 304, over 300,
+	// so the balanced build matches it and is dropped
+	if len(resp.Candidates) != 2 {
+		t.Fatalf("expected two candidates, got %v", strategies(resp.Candidates))
+	}
+	if resp.Candidates[0].Strategy != "value" || resp.Candidates[0].EstimatedCost != 304 || resp.Candidates[0].WithinBudget {
+		t.Fatalf("expected the cheaper value build first, got %v", strategies(resp.Candidates))
+	}
+}
+
+func TestBuildWizard_DigitalAndMissing(t *testing.T) {
+	resp, err := newWizardService().BuildWizard(context.Background(), models.BuildWizardParams{
+		Style:          models.BuildWizardLongRange,
+		Video:          models.BuildWizardDigital,
+		RadioEcosystem: models.RCProtocolCrossfire,
+	})
+	if err != nil {
+		t.Fatalf("BuildWizard() error = %v", err)
+	}
+	if len(resp.Candidates) == 0 {
+		t.Fatal("expected candidates")
+	}
+	ids := partIDs(resp.Candidates[0])
+	if ids["o3"] != 1 || ids["rx-tbs"] != 1 || ids["frame-7"] != 1 {
+		t.Fatalf("unexpected parts %v", ids)
+	}
+
+	missing := map[models.GearType]bool{}
+	for _, gearType := range resp.Candidates[0].MissingGearTypes {
+		missing[gearType] = true
+	}
+	// No 1100-1500KV motors, 7" props or GPS modules in the catalog
+	if !missing[models.GearTypeMotor] || !missing[models.GearTypeProp] || !missing[models.GearTypeGPS] {
+		t.Fatalf("MissingGearTypes = %v", resp.Candidates[0].MissingGearTypes)
+	}
+}
+
+func TestBuildWizard_ValidatesAnswers(t *testing.T) {
+	valid := models.BuildWizardParams{Style: models.BuildWizardFreestyle, Video: models.BuildWizardAnalog, RadioEcosystem: models.RCProtocolELRS}
+	tests := []struct {
+		name   string
+		mutate func(p *models.BuildWizardParams)
+	}{
+		{"style", func(p *models.BuildWizardParams) { p.Style = "racing" }},
+		{"video", func(p *models.BuildWizardParams) { p.Video = "" }},
+		{"radio", func(p *models.BuildWizardParams) { p.RadioEcosystem = "spektrum" }},
+		{"budget", func(p *models.BuildWizardParams) { p.Budget = -1 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := valid
+			tt.mutate(&params)
+			_, err := newWizardService().BuildWizard(context.Background(), params)
+			if _, ok := err.(*ServiceError); !ok {
+				t.Fatalf("expected a ServiceError, got %v", err)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 type MemoryCache struct {
 	mu     sync.RWMutex
 	items  map[string]entry
+	tags   map[string]map[string]struct{} // tag -> keys stored under it
 	ttl    time.Duration
 	stopCh chan struct{}
 }
@@ -16,12 +17,14 @@ type MemoryCache struct {
 type entry struct {
 	value     interface{}
 	expiresAt time.Time
+	tags      []string
 }
 
 // NewMemory creates a new in-memory cache with the specified TTL
 func NewMemory(ttl time.Duration) *MemoryCache {
 	c := &MemoryCache{
 		items:  make(map[string]entry),
+		tags:   make(map[string]map[string]struct{}),
 		ttl:    ttl,
 		stopCh: make(chan struct{}),
 	}
@@ -49,29 +52,62 @@ func (c *MemoryCache) Get(key string) (interface{}, bool) {
 }
 
 func (c *MemoryCache) Set(key string, value interface{}) {
+	c.SetWithTags(key, value, c.ttl)
+}
+
+func (c *MemoryCache) SetWithTTL(key string, value interface{}, ttl time.Duration) {
+	c.SetWithTags(key, value, ttl)
+}
+
+func (c *MemoryCache) SetWithTags(key string, value interface{}, ttl time.Duration, tags ...string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.remove(key)
 	c.items[key] = entry{
 		value:     value,
-		expiresAt: time.Now().Add(c.ttl),
+		expiresAt: time.Now().Add(ttl),
+		tags:      tags,
+	}
+	for _, tag := range tags {
+		keys, ok := c.tags[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			c.tags[tag] = keys
+		}
+		keys[key] = struct{}{}
 	}
 }
 
-func (c *MemoryCache) SetWithTTL(key string, value interface{}, ttl time.Duration) {
+func (c *MemoryCache) InvalidateTag(tag string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.items[key] = entry{
-		value:     value,
-		expiresAt: time.Now().Add(ttl),
+	for key := range c.tags[tag] {
+		c.remove(key)
 	}
+	delete(c.tags, tag)
 }
 
 func (c *MemoryCache) Delete(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.remove(key)
+}
+
+// remove deletes key and drops it from its tags. The caller holds mu.
+func (c *MemoryCache) remove(key string) {
+	e, ok := c.items[key]
+	if !ok {
+		return
+	}
 	delete(c.items, key)
+	for _, tag := range e.tags {
+		delete(c.tags[tag], key)
+		if len(c.tags[tag]) == 0 {
+			delete(c.tags, tag)
+		}
+	}
 }
 
 func (c *MemoryCache) Invalidate(key string) {
@@ -82,6 +118,7 @@ func (c *MemoryCache) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.items = make(map[string]entry)
+	c.tags = make(map[string]map[string]struct{})
 }
 
 func (c *MemoryCache) Stop() {
@@ -109,7 +146,7 @@ func (c *MemoryCache) removeExpired() {
 	now := time.Now()
 	for key, e := range c.items {
 		if now.After(e.expiresAt) {
-			delete(c.items, key)
+			c.remove(key)
 		}
 	}
 }
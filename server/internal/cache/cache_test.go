@@ -271,3 +271,55 @@ func TestMemoryCache_NilValue(t *testing.T) {
 		t.Errorf("Get() should return nil for nil value, got %v", got)
 	}
 }
+
+func TestMemoryCache_InvalidateTag(t *testing.T) {
+	c := NewMemory(time.Minute)
+	defer c.Stop()
+
+	c.SetWithTags("search:frames", "frames", time.Minute, "catalog-search")
+	c.SetWithTags("search:motors", "motors", time.Minute, "catalog-search", "motors")
+	c.SetWithTags("item:1", "item", time.Minute, "catalog-item")
+	c.Set("untagged", "value")
+
+	c.InvalidateTag("catalog-search")
+
+	for _, key := range []string{"search:frames", "search:motors"} {
+		if _, ok := c.Get(key); ok {
+			t.Errorf("Get(%q) should miss after its tag was invalidated", key)
+		}
+	}
+	for _, key := range []string{"item:1", "untagged"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("Get(%q) should still hit", key)
+		}
+	}
+	if _, ok := c.tags["motors"]; ok {
+		t.Error("deleted entries should be dropped from their other tags")
+	}
+}
+
+func TestMemoryCache_OverwriteDropsOldTags(t *testing.T) {
+	c := NewMemory(time.Minute)
+	defer c.Stop()
+
+	c.SetWithTags("key", "tagged", time.Minute, "old")
+	c.Set("key", "untagged")
+	c.InvalidateTag("old")
+
+	got, ok := c.Get("key")
+	if !ok || got != "untagged" {
+		t.Errorf("Get() = %v, %v; overwriting should untag the entry", got, ok)
+	}
+}
+
+func TestMemoryCache_RemoveExpiredCleansTags(t *testing.T) {
+	c := NewMemory(time.Minute)
+	defer c.Stop()
+
+	c.SetWithTags("key", "value", -time.Second, "tag")
+	c.removeExpired()
+
+	if len(c.tags) != 0 {
+		t.Errorf("tags = %v, want none after the entry expired", c.tags)
+	}
+}
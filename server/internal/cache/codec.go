@@ -0,0 +1,252 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codec is how Redis cache values are serialized
+type Codec string
+
+const (
+	CodecJSON    Codec = "json"
+	CodecMsgpack Codec = "msgpack" // smaller and faster to parse than JSON
+)
+
+// Compression is how serialized Redis cache values are compressed
+type Compression string
+
+const (
+	CompressionNone   Compression = "none"
+	CompressionGzip   Compression = "gzip"
+	CompressionSnappy Compression = "snappy" // fastest, compresses least
+	CompressionZstd   Compression = "zstd"   // compresses best for its speed
+)
+
+// minCompressSize is the smallest value worth compressing; below it the
+// compression framing can outweigh the savings
+const minCompressSize = 1024
+
+// Encoding is the codec and compression for a namespace's values
+type Encoding struct {
+	Codec       Codec
+	Compression Compression
+}
+
+// Encodings picks a value's encoding by its key's namespace: the part before
+// the first ':' ("link_preview" in "link_preview:https://..."), or the whole
+// key when it has none. Namespaces not listed use Default.
+type Encodings struct {
+	Default    Encoding
+	Namespaces map[string]Encoding
+}
+
+// DefaultEncodings stores everything as plain JSON
+func DefaultEncodings() Encodings {
+	return Encodings{Default: Encoding{Codec: CodecJSON, Compression: CompressionNone}}
+}
+
+// ParseEncodings parses a comma separated list of namespace=codec[+compression]
+// entries, such as "all_items=msgpack+zstd,catalog-search=json+snappy". "*"
+// sets the default for unlisted namespaces.
+func ParseEncodings(spec string) (Encodings, error) {
+	encodings := DefaultEncodings()
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		namespace, value, ok := strings.Cut(entry, "=")
+		namespace = strings.TrimSpace(namespace)
+		if !ok || namespace == "" {
+			return Encodings{}, fmt.Errorf("invalid cache encoding %q: want namespace=codec[+compression]", entry)
+		}
+
+		codec, compression, _ := strings.Cut(strings.ToLower(strings.TrimSpace(value)), "+")
+		encoding := Encoding{Codec: Codec(codec), Compression: CompressionNone}
+		if compression != "" {
+			encoding.Compression = Compression(compression)
+		}
+		if encoding.Codec != CodecJSON && encoding.Codec != CodecMsgpack {
+			return Encodings{}, fmt.Errorf("invalid cache codec %q for %s: want json or msgpack", codec, namespace)
+		}
+		if _, ok := compressionIDs[encoding.Compression]; !ok {
+			return Encodings{}, fmt.Errorf("invalid cache compression %q for %s: want none, gzip, snappy or zstd", compression, namespace)
+		}
+
+		if namespace == "*" {
+			encodings.Default = encoding
+			continue
+		}
+		if encodings.Namespaces == nil {
+			encodings.Namespaces = make(map[string]Encoding)
+		}
+		encodings.Namespaces[namespace] = encoding
+	}
+	return encodings, nil
+}
+
+// For returns the encoding for key
+func (e Encodings) For(key string) Encoding {
+	namespace, _, _ := strings.Cut(key, ":")
+	if encoding, ok := e.Namespaces[namespace]; ok {
+		return encoding
+	}
+	return e.Default
+}
+
+// Encoded values start with encodedMarker, then a codec byte and a
+// compression byte. JSON never starts with a control character, so values
+// stored as plain JSON (by this or earlier versions) are still read, and a
+// value always decodes by how it was written, whatever the config says now.
+const encodedMarker = 0x01
+
+var (
+	codecIDs       = map[Codec]byte{CodecJSON: 0, CodecMsgpack: 1}
+	compressionIDs = map[Compression]byte{CompressionNone: 0, CompressionGzip: 1, CompressionSnappy: 2, CompressionZstd: 3}
+)
+
+// The zstd encoder and decoder are safe for concurrent EncodeAll and
+// DecodeAll calls, so one of each is shared and only built when first used
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+	zstdErr     error
+)
+
+func zstdCoders() (*zstd.Encoder, *zstd.Decoder, error) {
+	zstdOnce.Do(func() {
+		zstdEncoder, zstdErr = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithEncoderConcurrency(1))
+		if zstdErr != nil {
+			zstdErr = fmt.Errorf("failed to create zstd encoder: %w", zstdErr)
+			return
+		}
+		zstdDecoder, zstdErr = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+		if zstdErr != nil {
+			zstdErr = fmt.Errorf("failed to create zstd decoder: %w", zstdErr)
+		}
+	})
+	return zstdEncoder, zstdDecoder, zstdErr
+}
+
+// compress compresses data with compression, which must not be none, and
+// returns the compression it used. zstd falls back to gzip when its encoder
+// can't be built; the value's header records which one was used.
+func compress(data []byte, compression Compression) ([]byte, Compression, error) {
+	switch compression {
+	case CompressionGzip:
+		var buf bytes.Buffer
+		w, _ := gzip.NewWriterLevel(&buf, gzip.BestSpeed)
+		if _, err := w.Write(data); err != nil {
+			return nil, "", err
+		}
+		if err := w.Close(); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), CompressionGzip, nil
+	case CompressionSnappy:
+		return s2.EncodeSnappy(nil, data), CompressionSnappy, nil
+	case CompressionZstd:
+		encoder, _, err := zstdCoders()
+		if err != nil {
+			return compress(data, CompressionGzip)
+		}
+		return encoder.EncodeAll(data, nil), CompressionZstd, nil
+	}
+	return nil, "", fmt.Errorf("unknown cache compression %q", compression)
+}
+
+// decompress reverses compress for the compression with the given ID
+func decompress(body []byte, id byte) ([]byte, error) {
+	switch id {
+	case compressionIDs[CompressionNone]:
+		return body, nil
+	case compressionIDs[CompressionGzip]:
+		r, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		return io.ReadAll(r)
+	case compressionIDs[CompressionSnappy]:
+		return s2.Decode(nil, body)
+	case compressionIDs[CompressionZstd]:
+		_, decoder, err := zstdCoders()
+		if err != nil {
+			return nil, err
+		}
+		return decoder.DecodeAll(body, nil)
+	}
+	return nil, fmt.Errorf("unknown cache compression %d", id)
+}
+
+// encodeValue serializes value with encoding. Plain JSON that isn't
+// compressed is stored without a header, as before encodings existed.
+func encodeValue(value interface{}, encoding Encoding) ([]byte, error) {
+	var data []byte
+	var err error
+	if encoding.Codec == CodecMsgpack {
+		data, err = marshalMsgpack(value)
+	} else {
+		data, err = json.Marshal(value)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	compression := CompressionNone
+	if encoding.Compression != "" && encoding.Compression != CompressionNone && len(data) >= minCompressSize {
+		compressed, used, err := compress(data, encoding.Compression)
+		if err != nil {
+			return nil, err
+		}
+		// Keep the original when compression doesn't help
+		if len(compressed) < len(data) {
+			data, compression = compressed, used
+		}
+	}
+
+	codec := encoding.Codec
+	if codec == "" {
+		codec = CodecJSON
+	}
+	if codec == CodecJSON && compression == CompressionNone {
+		return data, nil
+	}
+	return append([]byte{encodedMarker, codecIDs[codec], compressionIDs[compression]}, data...), nil
+}
+
+// decodeValue reads a value written by encodeValue
+func decodeValue(data []byte) (interface{}, error) {
+	if len(data) == 0 || data[0] != encodedMarker {
+		var value interface{}
+		err := json.Unmarshal(data, &value)
+		return value, err
+	}
+	if len(data) < 3 {
+		return nil, fmt.Errorf("cache value header is truncated")
+	}
+	codec := data[1]
+	body, err := decompress(data[3:], data[2])
+	if err != nil {
+		return nil, err
+	}
+
+	switch codec {
+	case codecIDs[CodecJSON]:
+		var value interface{}
+		err := json.Unmarshal(body, &value)
+		return value, err
+	case codecIDs[CodecMsgpack]:
+		return unmarshalMsgpack(body)
+	}
+	return nil, fmt.Errorf("unknown cache codec %d", codec)
+}
@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type codecItem struct {
+	ID     string   `json:"id"`
+	Title  string   `json:"title"`
+	Score  float64  `json:"score"`
+	Count  int64    `json:"count"`
+	Tags   []string `json:"tags"`
+	Hidden bool     `json:"hidden,omitempty"`
+	Parent *string  `json:"parent"`
+}
+
+func TestEncodeValue_RoundTrips(t *testing.T) {
+	items := make([]codecItem, 200)
+	for i := range items {
+		items[i] = codecItem{
+			ID:    strings.Repeat("x", i%40),
+			Title: "Five inch freestyle build with a long description " + strings.Repeat("é", i%300),
+			Score: float64(i) / 3,
+			Count: int64(i*i*i*i) - 1<<31,
+			Tags:  []string{"fpv", "freestyle"},
+		}
+	}
+
+	encodings := []Encoding{
+		{Codec: CodecJSON, Compression: CompressionNone},
+		{Codec: CodecJSON, Compression: CompressionGzip},
+		{Codec: CodecMsgpack, Compression: CompressionNone},
+		{Codec: CodecMsgpack, Compression: CompressionGzip},
+		{Codec: CodecJSON, Compression: CompressionSnappy},
+		{Codec: CodecMsgpack, Compression: CompressionSnappy},
+		{Codec: CodecJSON, Compression: CompressionZstd},
+		{Codec: CodecMsgpack, Compression: CompressionZstd},
+	}
+	plain, _ := json.Marshal(items)
+	for _, encoding := range encodings {
+		data, err := encodeValue(items, encoding)
+		if err != nil {
+			t.Fatalf("%+v: encodeValue() error = %v", encoding, err)
+		}
+		if encoding.Compression != CompressionNone && len(data) >= len(plain)/2 {
+			t.Errorf("%+v: %d bytes, want well under the %d byte JSON", encoding, len(data), len(plain))
+		}
+
+		decoded, err := decodeValue(data)
+		if err != nil {
+			t.Fatalf("%+v: decodeValue() error = %v", encoding, err)
+		}
+		// Callers turn generic values back into their types through JSON
+		raw, _ := json.Marshal(decoded)
+		var got []codecItem
+		if err := json.Unmarshal(raw, &got); err != nil {
+			t.Fatalf("%+v: %v", encoding, err)
+		}
+		if !reflect.DeepEqual(got, items) {
+			t.Errorf("%+v: round trip changed the items", encoding)
+		}
+	}
+}
+
+func TestEncodeValue_PlainJSONHasNoHeader(t *testing.T) {
+	data, err := encodeValue(map[string]string{"etag": "abc"}, Encoding{Codec: CodecJSON, Compression: CompressionGzip})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Too small to compress, so it's stored as plain JSON
+	if string(data) != `{"etag":"abc"}` {
+		t.Errorf("encodeValue() = %q", data)
+	}
+}
+
+func TestDecodeValue_ReadsLegacyJSON(t *testing.T) {
+	got, err := decodeValue([]byte(`{"id":"1","n":2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"id": "1", "n": float64(2)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("decodeValue() = %#v, want %#v", got, want)
+	}
+}
+
+func TestMsgpack_Values(t *testing.T) {
+	long := strings.Repeat("a", 70000)
+	values := []interface{}{
+		nil, true, false, "", "short", strings.Repeat("b", 40), strings.Repeat("c", 300), long,
+		int64(0), int64(127), int64(128), int64(-1), int64(-32), int64(-33), int64(-129), int64(40000), int64(-40000),
+		int64(1 << 40), int64(math.MinInt64), int64(math.MaxInt64), 1.5, -0.25,
+		[]interface{}{}, make([]interface{}, 20), map[string]interface{}{}, map[string]interface{}{"nested": []interface{}{"x", int64(1)}},
+	}
+	for _, value := range values {
+		data, err := marshalMsgpack(value)
+		if err != nil {
+			t.Fatalf("marshalMsgpack(%T) error = %v", value, err)
+		}
+		got, err := unmarshalMsgpack(data)
+		if err != nil {
+			t.Fatalf("unmarshalMsgpack(%T) error = %v", value, err)
+		}
+		if !reflect.DeepEqual(got, value) {
+			t.Errorf("round trip of %T: got %#v", value, got)
+		}
+	}
+}
+
+func TestMsgpack_RejectsTruncated(t *testing.T) {
+	data, _ := marshalMsgpack(map[string]interface{}{"title": "a build", "parts": []interface{}{"frame", "motors"}})
+	for i := 0; i < len(data); i++ {
+		if _, err := unmarshalMsgpack(data[:i]); err == nil {
+			t.Errorf("unmarshalMsgpack of %d/%d bytes succeeded", i, len(data))
+		}
+	}
+	// An array claiming four billion elements fails before allocating them
+	if _, err := unmarshalMsgpack([]byte{0xdd, 0xff, 0xff, 0xff, 0xff}); err == nil {
+		t.Error("unmarshalMsgpack accepted an oversized array length")
+	}
+}
+
+func TestEncodeValue_ZstdFallsBackToGzip(t *testing.T) {
+	zstdCoders()
+	encoder, decoder := zstdEncoder, zstdDecoder
+	zstdEncoder, zstdDecoder, zstdErr = nil, nil, errors.New("no zstd")
+	defer func() { zstdEncoder, zstdDecoder, zstdErr = encoder, decoder, nil }()
+
+	value := strings.Repeat("freestyle ", 200)
+	data, err := encodeValue(value, Encoding{Codec: CodecJSON, Compression: CompressionZstd})
+	if err != nil {
+		t.Fatalf("encodeValue() error = %v", err)
+	}
+	if len(data) < 3 || data[2] != compressionIDs[CompressionGzip] {
+		t.Fatalf("header = % x, want gzip", data[:3])
+	}
+	if got, err := decodeValue(data); err != nil || got != value {
+		t.Errorf("decodeValue() = %.20q, %v", got, err)
+	}
+}
+
+func TestParseEncodings(t *testing.T) {
+	encodings, err := ParseEncodings(" all_items=msgpack+zstd, catalog-search=json+gzip ,feed_validators=json+snappy,*=msgpack")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]Encoding{
+		"all_items":                   {Codec: CodecMsgpack, Compression: CompressionZstd},
+		"catalog-search:tenant:hash":  {Codec: CodecJSON, Compression: CompressionGzip},
+		"feed_validators:https://a":   {Codec: CodecJSON, Compression: CompressionSnappy},
+		"link_preview:https://a.test": {Codec: CodecMsgpack, Compression: CompressionNone},
+	}
+	for key, want := range tests {
+		if got := encodings.For(key); got != want {
+			t.Errorf("For(%q) = %+v, want %+v", key, got, want)
+		}
+	}
+
+	if got := (Encodings{}).For("anything"); got != (Encoding{}) {
+		t.Errorf("zero Encodings.For() = %+v", got)
+	}
+	if defaults, _ := ParseEncodings(""); defaults.For("x") != DefaultEncodings().Default {
+		t.Errorf("empty spec = %+v, want plain JSON", defaults)
+	}
+
+	for _, spec := range []string{"all_items", "=json", "all_items=cbor", "all_items=json+lz4"} {
+		if _, err := ParseEncodings(spec); err == nil {
+			t.Errorf("ParseEncodings(%q) should fail", spec)
+		}
+	}
+}
@@ -7,6 +7,11 @@ type Cache interface {
 	Get(key string) (interface{}, bool)
 	Set(key string, value interface{})
 	SetWithTTL(key string, value interface{}, ttl time.Duration)
+	// SetWithTags stores value like SetWithTTL and files it under each tag,
+	// so a write can drop every related entry at once with InvalidateTag
+	SetWithTags(key string, value interface{}, ttl time.Duration, tags ...string)
+	// InvalidateTag deletes every entry stored under tag
+	InvalidateTag(tag string)
 	Delete(key string)
 	Clear()
 }
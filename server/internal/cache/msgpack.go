@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MessagePack cache values. Values go through JSON first, so struct tags
+// apply and they decode to the same maps, slices, strings, numbers, bools
+// and nils the JSON codec gives back. Integers decode as int64 rather than
+// float64; callers that re-marshal the value to JSON see the same numbers
+// either way.
+
+// marshalMsgpack encodes value as MessagePack
+func marshalMsgpack(value interface{}) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	generic, err = fromJSONNumbers(generic)
+	if err != nil {
+		return nil, err
+	}
+	return msgp.AppendIntf(nil, generic)
+}
+
+// unmarshalMsgpack decodes a single MessagePack value
+func unmarshalMsgpack(data []byte) (interface{}, error) {
+	value, rest, err := msgp.ReadIntfBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("msgpack: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("msgpack: %d trailing bytes", len(rest))
+	}
+	return value, nil
+}
+
+// fromJSONNumbers replaces the json.Numbers in a value decoded with
+// UseNumber by int64, or float64 when they aren't integers. msgp would
+// store a float that fits as float32, which decodes as float32.
+func fromJSONNumbers(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("msgpack: invalid number %q", v)
+		}
+		return f, nil
+	case []interface{}:
+		for i, item := range v {
+			converted, err := fromJSONNumbers(item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = converted
+		}
+	case map[string]interface{}:
+		for key, item := range v {
+			converted, err := fromJSONNumbers(item)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = converted
+		}
+	}
+	return value, nil
+}
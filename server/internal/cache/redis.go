@@ -2,7 +2,6 @@ package cache
 
 import (
 	"context"
-	"encoding/json"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -10,17 +9,20 @@ import (
 
 // RedisCache is a Redis-backed cache implementation
 type RedisCache struct {
-	client *redis.Client
-	ttl    time.Duration
-	prefix string
+	client    *redis.Client
+	ttl       time.Duration
+	prefix    string
+	encodings Encodings
 }
 
-// RedisConfig holds configuration for the Redis cache
+// RedisConfig holds configuration for the Redis cache. Encodings sets the
+// codec and compression per key namespace; the zero value stores plain JSON.
 type RedisConfig struct {
-	Addr     string
-	Password string
-	DB       int
-	Prefix   string
+	Addr      string
+	Password  string
+	DB        int
+	Prefix    string
+	Encodings Encodings
 }
 
 // NewRedis creates a new Redis cache with the specified configuration
@@ -44,10 +46,16 @@ func NewRedis(cfg RedisConfig, ttl time.Duration) (*RedisCache, error) {
 		prefix = "mcp-news:"
 	}
 
+	encodings := cfg.Encodings
+	if encodings.Default.Codec == "" {
+		encodings.Default = DefaultEncodings().Default
+	}
+
 	return &RedisCache{
-		client: client,
-		ttl:    ttl,
-		prefix: prefix,
+		client:    client,
+		ttl:       ttl,
+		prefix:    prefix,
+		encodings: encodings,
 	}, nil
 }
 
@@ -63,8 +71,8 @@ func (c *RedisCache) Get(key string) (interface{}, bool) {
 		return nil, false
 	}
 
-	var value interface{}
-	if err := json.Unmarshal(data, &value); err != nil {
+	value, err := decodeValue(data)
+	if err != nil {
 		return nil, false
 	}
 
@@ -78,7 +86,7 @@ func (c *RedisCache) Set(key string, value interface{}) {
 func (c *RedisCache) SetWithTTL(key string, value interface{}, ttl time.Duration) {
 	ctx := context.Background()
 
-	data, err := json.Marshal(value)
+	data, err := encodeValue(value, c.encodings.For(key))
 	if err != nil {
 		return
 	}
@@ -86,6 +94,57 @@ func (c *RedisCache) SetWithTTL(key string, value interface{}, ttl time.Duration
 	c.client.Set(ctx, c.key(key), data, ttl)
 }
 
+// tagKey is the set of keys stored under tag
+func (c *RedisCache) tagKey(tag string) string {
+	return c.prefix + "tag:" + tag
+}
+
+// SetWithTags stores the value and adds its key to each tag's set in one
+// transaction. A tag's set expires with the longest-lived entry added to
+// it; keys in it that already expired are harmless to delete.
+func (c *RedisCache) SetWithTags(key string, value interface{}, ttl time.Duration, tags ...string) {
+	if len(tags) == 0 {
+		c.SetWithTTL(key, value, ttl)
+		return
+	}
+	ctx := context.Background()
+
+	data, err := encodeValue(value, c.encodings.For(key))
+	if err != nil {
+		return
+	}
+
+	pipe := c.client.TxPipeline()
+	pipe.Set(ctx, c.key(key), data, ttl)
+	for _, tag := range tags {
+		tagKey := c.tagKey(tag)
+		pipe.SAdd(ctx, tagKey, c.key(key))
+		if ttl > 0 {
+			pipe.ExpireNX(ctx, tagKey, ttl)
+			pipe.ExpireGT(ctx, tagKey, ttl)
+		} else {
+			pipe.Persist(ctx, tagKey)
+		}
+	}
+	pipe.Exec(ctx)
+}
+
+// invalidateTagScript deletes a tag's keys and the tag's set atomically, so
+// an entry tagged mid-invalidation isn't left behind untracked
+var invalidateTagScript = redis.NewScript(`
+local keys = redis.call('SMEMBERS', KEYS[1])
+for i = 1, #keys, 500 do
+	redis.call('DEL', unpack(keys, i, math.min(i + 499, #keys)))
+end
+redis.call('DEL', KEYS[1])
+return #keys
+`)
+
+func (c *RedisCache) InvalidateTag(tag string) {
+	ctx := context.Background()
+	invalidateTagScript.Run(ctx, c.client, []string{c.tagKey(tag)})
+}
+
 func (c *RedisCache) Delete(key string) {
 	ctx := context.Background()
 	c.client.Del(ctx, c.key(key))
@@ -0,0 +1,160 @@
+// Package calc holds the FPV calculators behind the /api/tools endpoints.
+// Calculations are pure functions so they can be tested without a database.
+package calc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// FlyingStyle describes how hard the aircraft is flown
+type FlyingStyle string
+
+const (
+	StyleCinematic FlyingStyle = "cinematic"
+	StyleCruise    FlyingStyle = "cruise"
+	StyleFreestyle FlyingStyle = "freestyle"
+	StyleRacing    FlyingStyle = "racing"
+)
+
+const (
+	// Hover efficiency of typical FPV motor and prop combinations, in grams of
+	// thrust per watt. The lower bound gives the short end of the estimate.
+	minGramsPerWatt     = 2.0
+	typicalGramsPerWatt = 2.5
+	maxGramsPerWatt     = 3.0
+
+	// usableCapacity is the share of the pack flown before landing, leaving
+	// the rest to protect the cells
+	usableCapacity = 0.8
+
+	defaultCellVoltage = 3.7
+)
+
+// styleMultipliers scale hover power to the average power for a style
+var styleMultipliers = map[FlyingStyle]float64{
+	StyleCinematic: 1.1,
+	StyleCruise:    1.3,
+	StyleFreestyle: 1.7,
+	StyleRacing:    2.3,
+}
+
+// cellVoltages are nominal cell voltages by battery chemistry
+var cellVoltages = map[string]float64{
+	"LIPO":    3.7,
+	"LIPO_HV": 3.8,
+	"LIION":   3.6,
+}
+
+// FlightTimeInput holds what the flight time estimate is based on
+type FlightTimeInput struct {
+	WeightGrams        float64     // aircraft without the battery
+	BatteryWeightGrams float64     // optional
+	Cells              int         // series cell count
+	CapacityMah        int         // pack capacity
+	Chemistry          string      // LIPO, LIPO_HV or LIION; defaults to LIPO
+	Style              FlyingStyle // defaults to freestyle
+}
+
+// FlightTimeEstimate is an estimated flight time range
+type FlightTimeEstimate struct {
+	Style            FlyingStyle `json:"style"`
+	AllUpWeightGrams float64     `json:"allUpWeightGrams"`
+	UsableEnergyWh   float64     `json:"usableEnergyWh"`
+	AveragePowerW    float64     `json:"averagePowerW"`
+	AverageCurrentA  float64     `json:"averageCurrentA"`
+	MinMinutes       float64     `json:"minMinutes"`
+	TypicalMinutes   float64     `json:"typicalMinutes"`
+	MaxMinutes       float64     `json:"maxMinutes"`
+}
+
+// EstimateFlightTime estimates flight time from hover power, scaled up for the
+// flying style. Hover power comes from the all-up weight and a range of
+// typical thrust efficiencies, which gives the min and max of the estimate.
+// Returns an error describing the problem when the input is invalid.
+func EstimateFlightTime(in FlightTimeInput) (*FlightTimeEstimate, error) {
+	if in.WeightGrams <= 0 {
+		return nil, fmt.Errorf("weight must be greater than zero")
+	}
+	if in.BatteryWeightGrams < 0 {
+		return nil, fmt.Errorf("battery weight cannot be negative")
+	}
+	if in.Cells < 1 || in.Cells > 12 {
+		return nil, fmt.Errorf("cells must be between 1 and 12")
+	}
+	if in.CapacityMah <= 0 {
+		return nil, fmt.Errorf("capacity must be greater than zero")
+	}
+
+	style := in.Style
+	if style == "" {
+		style = StyleFreestyle
+	}
+	multiplier, ok := styleMultipliers[style]
+	if !ok {
+		return nil, fmt.Errorf("unknown flying style %q", style)
+	}
+
+	cellVoltage := defaultCellVoltage
+	if in.Chemistry != "" {
+		cellVoltage, ok = cellVoltages[strings.ToUpper(in.Chemistry)]
+		if !ok {
+			return nil, fmt.Errorf("unknown battery chemistry %q", in.Chemistry)
+		}
+	}
+
+	packVoltage := float64(in.Cells) * cellVoltage
+	energyWh := float64(in.CapacityMah) / 1000 * packVoltage * usableCapacity
+	allUpWeight := in.WeightGrams + in.BatteryWeightGrams
+
+	minutes := func(gramsPerWatt float64) float64 {
+		return round1(energyWh / (allUpWeight / gramsPerWatt * multiplier) * 60)
+	}
+	averagePower := allUpWeight / typicalGramsPerWatt * multiplier
+
+	return &FlightTimeEstimate{
+		Style:            style,
+		AllUpWeightGrams: round1(allUpWeight),
+		UsableEnergyWh:   round1(energyWh),
+		AveragePowerW:    round1(averagePower),
+		AverageCurrentA:  round1(averagePower / packVoltage),
+		MinMinutes:       minutes(minGramsPerWatt),
+		TypicalMinutes:   minutes(typicalGramsPerWatt),
+		MaxMinutes:       minutes(maxGramsPerWatt),
+	}, nil
+}
+
+// SpecWeightGrams reads a part's weight from catalog specs. It accepts a
+// "weight", "weightGrams" or "weight_g" key holding a number or a string such
+// as "32g".
+func SpecWeightGrams(specs json.RawMessage) (float64, bool) {
+	if len(specs) == 0 {
+		return 0, false
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(specs, &fields); err != nil {
+		return 0, false
+	}
+
+	for _, key := range []string{"weightGrams", "weight_g", "weight"} {
+		switch value := fields[key].(type) {
+		case float64:
+			if value > 0 {
+				return value, true
+			}
+		case string:
+			text := strings.TrimSpace(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(value)), "g"))
+			if parsed, err := strconv.ParseFloat(text, 64); err == nil && parsed > 0 {
+				return parsed, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func round1(value float64) float64 {
+	return math.Round(value*10) / 10
+}
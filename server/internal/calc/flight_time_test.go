@@ -0,0 +1,122 @@
+package calc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestEstimateFlightTime(t *testing.T) {
+	// A typical 5" freestyle quad: 480g dry, 6S 1300mAh weighing 170g
+	estimate, err := EstimateFlightTime(FlightTimeInput{
+		WeightGrams:        480,
+		BatteryWeightGrams: 170,
+		Cells:              6,
+		CapacityMah:        1300,
+		Style:              StyleFreestyle,
+	})
+	if err != nil {
+		t.Fatalf("EstimateFlightTime() error = %v", err)
+	}
+
+	if estimate.AllUpWeightGrams != 650 {
+		t.Errorf("AllUpWeightGrams = %v, want 650", estimate.AllUpWeightGrams)
+	}
+	// 1.3Ah * 22.2V * 0.8 usable
+	if estimate.UsableEnergyWh != 23.1 {
+		t.Errorf("UsableEnergyWh = %v, want 23.1", estimate.UsableEnergyWh)
+	}
+	// 650g / 2.5 g/W * 1.7
+	if estimate.AveragePowerW != 442 {
+		t.Errorf("AveragePowerW = %v, want 442", estimate.AveragePowerW)
+	}
+	if estimate.AverageCurrentA != 19.9 {
+		t.Errorf("AverageCurrentA = %v, want 19.9", estimate.AverageCurrentA)
+	}
+	if estimate.MinMinutes != 2.5 || estimate.TypicalMinutes != 3.1 || estimate.MaxMinutes != 3.8 {
+		t.Errorf("minutes = %v / %v / %v, want 2.5 / 3.1 / 3.8", estimate.MinMinutes, estimate.TypicalMinutes, estimate.MaxMinutes)
+	}
+}
+
+func TestEstimateFlightTime_StylesAndChemistry(t *testing.T) {
+	base := FlightTimeInput{WeightGrams: 650, Cells: 6, CapacityMah: 1300}
+
+	var previous float64
+	for i, style := range []FlyingStyle{StyleRacing, StyleFreestyle, StyleCruise, StyleCinematic} {
+		input := base
+		input.Style = style
+		estimate, err := EstimateFlightTime(input)
+		if err != nil {
+			t.Fatalf("%s: error = %v", style, err)
+		}
+		if i > 0 && estimate.TypicalMinutes <= previous {
+			t.Errorf("%s should fly longer than the harder style before it, got %v <= %v", style, estimate.TypicalMinutes, previous)
+		}
+		previous = estimate.TypicalMinutes
+	}
+
+	defaulted, _ := EstimateFlightTime(base)
+	if defaulted.Style != StyleFreestyle {
+		t.Errorf("default style = %s, want freestyle", defaulted.Style)
+	}
+
+	hv := base
+	hv.Chemistry = "lipo_hv"
+	hvEstimate, err := EstimateFlightTime(hv)
+	if err != nil {
+		t.Fatalf("LIPO_HV error = %v", err)
+	}
+	if hvEstimate.UsableEnergyWh <= defaulted.UsableEnergyWh {
+		t.Errorf("HV pack should hold more energy: %v <= %v", hvEstimate.UsableEnergyWh, defaulted.UsableEnergyWh)
+	}
+}
+
+func TestEstimateFlightTime_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   FlightTimeInput
+		wantErr string
+	}{
+		{"no weight", FlightTimeInput{Cells: 4, CapacityMah: 850}, "weight"},
+		{"no cells", FlightTimeInput{WeightGrams: 300, CapacityMah: 850}, "cells"},
+		{"too many cells", FlightTimeInput{WeightGrams: 300, Cells: 13, CapacityMah: 850}, "cells"},
+		{"no capacity", FlightTimeInput{WeightGrams: 300, Cells: 4}, "capacity"},
+		{"negative battery weight", FlightTimeInput{WeightGrams: 300, BatteryWeightGrams: -1, Cells: 4, CapacityMah: 850}, "battery weight"},
+		{"unknown style", FlightTimeInput{WeightGrams: 300, Cells: 4, CapacityMah: 850, Style: "aerobatic"}, "flying style"},
+		{"unknown chemistry", FlightTimeInput{WeightGrams: 300, Cells: 4, CapacityMah: 850, Chemistry: "NIMH"}, "chemistry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := EstimateFlightTime(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSpecWeightGrams(t *testing.T) {
+	tests := []struct {
+		specs  string
+		want   float64
+		wantOK bool
+	}{
+		{`{"weightGrams": 32.5}`, 32.5, true},
+		{`{"weight_g": 120}`, 120, true},
+		{`{"weight": "28g"}`, 28, true},
+		{`{"weight": " 28 G "}`, 28, true},
+		{`{"weight": "light"}`, 0, false},
+		{`{"weight": 0}`, 0, false},
+		{`{"kv": 1950}`, 0, false},
+		{`not json`, 0, false},
+		{``, 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := SpecWeightGrams(json.RawMessage(tt.specs))
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("SpecWeightGrams(%s) = %v, %v; want %v, %v", tt.specs, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Package vtx plans 5.8GHz video channels for pilots flying together.
+package vtx
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Video systems a VTX can use
+const (
+	SystemAnalog    = "analog"
+	SystemHDZero    = "hdzero"
+	SystemDJI       = "dji"
+	SystemWalksnail = "walksnail"
+)
+
+const (
+	minFrequencyMHz = 5600
+	maxFrequencyMHz = 6000
+)
+
+// bandFrequencies are the analog 5.8GHz band tables, channels 1 to 8
+var bandFrequencies = map[string][8]int{
+	"A": {5865, 5845, 5825, 5805, 5785, 5765, 5745, 5725},
+	"B": {5733, 5752, 5771, 5790, 5809, 5828, 5847, 5866},
+	"E": {5705, 5685, 5665, 5645, 5885, 5905, 5925, 5945},
+	"F": {5740, 5760, 5780, 5800, 5820, 5840, 5860, 5880},
+	"R": {5658, 5695, 5732, 5769, 5806, 5843, 5880, 5917},
+}
+
+// bandOrder decides which name a shared frequency gets, so 5880 is R7
+// rather than F8
+var bandOrder = []string{"R", "F", "E", "A", "B"}
+
+// Channel is a named VTX frequency
+type Channel struct {
+	Name         string `json:"name"`
+	FrequencyMHz int    `json:"frequencyMhz"`
+}
+
+// Capability is what a pilot's VTX can tune to
+type Capability struct {
+	System         string   `json:"system,omitempty"`
+	Bands          []string `json:"bands,omitempty"`
+	FrequenciesMHz []int    `json:"frequenciesMhz,omitempty"`
+	PowerLevelsMw  []int    `json:"powerLevelsMw,omitempty"`
+}
+
+// Channels lists the frequencies a VTX can use, lowest first. Explicit
+// frequencies win over bands. With neither, analog VTXs get all five bands
+// and HDZero gets Raceband; DJI and Walksnail need frequencies listed.
+func (c Capability) Channels() ([]Channel, error) {
+	if len(c.FrequenciesMHz) > 0 {
+		seen := make(map[int]bool)
+		var channels []Channel
+		for _, freq := range c.FrequenciesMHz {
+			if freq < minFrequencyMHz || freq > maxFrequencyMHz {
+				return nil, fmt.Errorf("frequency %d MHz is outside the 5.8GHz band", freq)
+			}
+			if seen[freq] {
+				continue
+			}
+			seen[freq] = true
+			channels = append(channels, Channel{Name: channelName(freq), FrequencyMHz: freq})
+		}
+		sortChannels(channels)
+		return channels, nil
+	}
+
+	bands := c.Bands
+	if len(bands) == 0 {
+		switch strings.ToLower(c.System) {
+		case "", SystemAnalog:
+			bands = bandOrder
+		case SystemHDZero:
+			bands = []string{"R"}
+		default:
+			return nil, fmt.Errorf("%s VTX needs its frequencies listed", c.System)
+		}
+	}
+
+	seen := make(map[int]bool)
+	var channels []Channel
+	for _, band := range bands {
+		band = strings.ToUpper(strings.TrimSpace(band))
+		frequencies, ok := bandFrequencies[band]
+		if !ok {
+			return nil, fmt.Errorf("unknown band %q", band)
+		}
+		for _, freq := range frequencies {
+			if !seen[freq] {
+				seen[freq] = true
+				channels = append(channels, Channel{Name: channelName(freq), FrequencyMHz: freq})
+			}
+		}
+	}
+	sortChannels(channels)
+	return channels, nil
+}
+
+// CapabilityFromSpecs reads a VTX capability from inventory or catalog specs.
+// It understands "system" (or "videoSystem"), "bands" as a list or a
+// comma-separated string, "frequencies" (or "frequenciesMhz") and
+// "powerLevels" (or "powerLevelsMw"). Missing keys are left empty.
+func CapabilityFromSpecs(specs json.RawMessage) Capability {
+	var capability Capability
+	if len(specs) == 0 {
+		return capability
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(specs, &fields); err != nil {
+		return capability
+	}
+
+	for _, key := range []string{"system", "videoSystem"} {
+		if value, ok := fields[key].(string); ok && value != "" {
+			capability.System = strings.ToLower(strings.TrimSpace(value))
+			break
+		}
+	}
+
+	switch bands := fields["bands"].(type) {
+	case string:
+		for _, band := range strings.Split(bands, ",") {
+			if band = strings.TrimSpace(band); band != "" {
+				capability.Bands = append(capability.Bands, band)
+			}
+		}
+	case []interface{}:
+		for _, band := range bands {
+			if text, ok := band.(string); ok && strings.TrimSpace(text) != "" {
+				capability.Bands = append(capability.Bands, strings.TrimSpace(text))
+			}
+		}
+	}
+
+	for _, key := range []string{"frequenciesMhz", "frequencies"} {
+		if values := numbers(fields[key]); len(values) > 0 {
+			capability.FrequenciesMHz = values
+			break
+		}
+	}
+	for _, key := range []string{"powerLevelsMw", "powerLevels"} {
+		if values := numbers(fields[key]); len(values) > 0 {
+			capability.PowerLevelsMw = values
+			break
+		}
+	}
+	return capability
+}
+
+func numbers(value interface{}) []int {
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	var result []int
+	for _, item := range list {
+		if number, ok := item.(float64); ok && number > 0 {
+			result = append(result, int(number))
+		}
+	}
+	return result
+}
+
+// channelName returns the band channel name for a frequency, or the
+// frequency itself for one outside the band tables
+func channelName(freq int) string {
+	for _, band := range bandOrder {
+		for i, bandFreq := range bandFrequencies[band] {
+			if bandFreq == freq {
+				return fmt.Sprintf("%s%d", band, i+1)
+			}
+		}
+	}
+	return fmt.Sprintf("%d MHz", freq)
+}
+
+func sortChannels(channels []Channel) {
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].FrequencyMHz < channels[j].FrequencyMHz
+	})
+}
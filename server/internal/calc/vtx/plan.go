@@ -0,0 +1,307 @@
+package vtx
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxPilots is the most pilots that fit in the 5.8GHz band with usable
+	// separation
+	MaxPilots = 8
+	// DefaultPowerMw is the transmit power used when none is requested
+	DefaultPowerMw = 25
+
+	// minSpacingMHz is how far apart two analog channels need to be to stay
+	// out of each other's video
+	minSpacingMHz = 37
+	// imdWindowMHz is how close an intermodulation product must land to a
+	// channel in use to show up in its video
+	imdWindowMHz = 20
+	// lowIMDRating is the rating below which a plan warns about interference
+	lowIMDRating = 50
+	// spacingWeight makes crowded channels cost more than intermodulation
+	spacingWeight = 4
+	// searchBudget bounds the number of partial assignments tried
+	searchBudget = 200000
+)
+
+// Pilot is one pilot in the session and what their VTX can do
+type Pilot struct {
+	Name string `json:"name"`
+	Capability
+}
+
+// Options tune a channel plan
+type Options struct {
+	PowerMw int // transmit power for everyone; defaults to DefaultPowerMw
+}
+
+// Assignment is the channel and power given to one pilot
+type Assignment struct {
+	Pilot        string `json:"pilot"`
+	Channel      string `json:"channel"`
+	FrequencyMHz int    `json:"frequencyMhz"`
+	PowerMw      int    `json:"powerMw"`
+}
+
+// Plan is a channel assignment for a group of pilots
+type Plan struct {
+	Assignments   []Assignment `json:"assignments"`
+	MinSpacingMHz int          `json:"minSpacingMhz"`
+	// IMDRating runs from 0 to 100. 100 means no third-order
+	// intermodulation product lands near a channel in use.
+	IMDRating   int       `json:"imdRating"`
+	PowerMw     int       `json:"powerMw"`
+	Warnings    []string  `json:"warnings,omitempty"`
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+// NewPlan gives every pilot a different channel their VTX supports, choosing
+// the set with the widest spacing and least intermodulation (IMD). Products
+// 2*f1-f2 of any two channels landing near a third are what show up as
+// interference in a pilot's goggles.
+func NewPlan(pilots []Pilot, opts Options, now time.Time) (*Plan, error) {
+	if len(pilots) == 0 {
+		return nil, fmt.Errorf("at least one pilot is required")
+	}
+	if len(pilots) > MaxPilots {
+		return nil, fmt.Errorf("at most %d pilots can share the 5.8GHz band", MaxPilots)
+	}
+
+	options := make([][]Channel, len(pilots))
+	for i, pilot := range pilots {
+		channels, err := pilot.Channels()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pilotLabel(pilot, i), err)
+		}
+		if len(channels) == 0 {
+			return nil, fmt.Errorf("%s: VTX has no channels", pilotLabel(pilot, i))
+		}
+		options[i] = channels
+	}
+
+	// Try keeping every pair properly spaced first, which prunes most of the
+	// search; crowd channels only when the VTXs leave no other choice
+	chosen, complete := search(options, true)
+	if chosen == nil {
+		chosen, complete = search(options, false)
+	}
+	if chosen == nil {
+		return nil, fmt.Errorf("could not give every pilot a separate channel")
+	}
+
+	powerMw := opts.PowerMw
+	if powerMw <= 0 {
+		powerMw = DefaultPowerMw
+	}
+
+	plan := &Plan{
+		Assignments: make([]Assignment, len(pilots)),
+		PowerMw:     powerMw,
+		GeneratedAt: now,
+	}
+	frequencies := make([]int, len(pilots))
+	for i, pilot := range pilots {
+		channel := chosen[i]
+		frequencies[i] = channel.FrequencyMHz
+		power, warning := pickPower(pilot.PowerLevelsMw, powerMw)
+		if warning != "" {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("%s %s", pilotLabel(pilot, i), warning))
+		}
+		plan.Assignments[i] = Assignment{
+			Pilot:        pilotLabel(pilot, i),
+			Channel:      channel.Name,
+			FrequencyMHz: channel.FrequencyMHz,
+			PowerMw:      power,
+		}
+	}
+
+	plan.MinSpacingMHz = minSpacing(frequencies)
+	plan.IMDRating = imdRating(imdPenalty(frequencies))
+	if len(pilots) > 1 && plan.MinSpacingMHz < minSpacingMHz {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("channels are only %d MHz apart; expect some crosstalk", plan.MinSpacingMHz))
+	}
+	if plan.IMDRating < lowIMDRating {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("IMD rating is %d/100; expect intermodulation noise with this many pilots", plan.IMDRating))
+	}
+	if !complete {
+		plan.Warnings = append(plan.Warnings, "search stopped early; a better plan may exist")
+	}
+	return plan, nil
+}
+
+// search assigns channels by branch and bound. Pilots with the fewest
+// channels are placed first, and since adding a channel never lowers the
+// score, any partial assignment already worse than the best is dropped. With
+// spaced set, channels closer than minSpacingMHz are never combined.
+// Reports false if the budget ran out before the search finished.
+func search(options [][]Channel, spaced bool) ([]Channel, bool) {
+	order := make([]int, len(options))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return len(options[order[a]]) < len(options[order[b]])
+	})
+
+	// Pilots with the same channels are interchangeable, so give them
+	// ascending frequencies to avoid trying every permutation of a set
+	sameAsPrevious := make([]bool, len(order))
+	for depth := 1; depth < len(order); depth++ {
+		sameAsPrevious[depth] = sameChannels(options[order[depth]], options[order[depth-1]])
+	}
+
+	var best []Channel
+	bestScore := math.MaxInt
+	current := make([]Channel, len(options))
+	frequencies := make([]int, 0, len(options))
+	used := make(map[int]bool)
+	nodes := 0
+
+	var place func(depth int)
+	place = func(depth int) {
+		if depth == len(order) {
+			score := planScore(frequencies)
+			if score < bestScore {
+				bestScore = score
+				best = append([]Channel(nil), current...)
+			}
+			return
+		}
+		pilot := order[depth]
+		for _, channel := range options[pilot] {
+			if nodes >= searchBudget {
+				return
+			}
+			if used[channel.FrequencyMHz] || (spaced && tooClose(frequencies, channel.FrequencyMHz)) {
+				continue
+			}
+			if sameAsPrevious[depth] && channel.FrequencyMHz < current[order[depth-1]].FrequencyMHz {
+				continue
+			}
+			nodes++
+			frequencies = append(frequencies, channel.FrequencyMHz)
+			if planScore(frequencies) < bestScore {
+				used[channel.FrequencyMHz] = true
+				current[pilot] = channel
+				place(depth + 1)
+				used[channel.FrequencyMHz] = false
+			}
+			frequencies = frequencies[:len(frequencies)-1]
+		}
+	}
+	place(0)
+	return best, nodes < searchBudget
+}
+
+func sameChannels(a, b []Channel) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].FrequencyMHz != b[i].FrequencyMHz {
+			return false
+		}
+	}
+	return true
+}
+
+func tooClose(frequencies []int, freq int) bool {
+	for _, other := range frequencies {
+		if abs(other-freq) < minSpacingMHz {
+			return true
+		}
+	}
+	return false
+}
+
+// planScore is lower for better plans: crowded pairs plus IMD
+func planScore(frequencies []int) int {
+	score := imdPenalty(frequencies)
+	for i := range frequencies {
+		for j := i + 1; j < len(frequencies); j++ {
+			if gap := abs(frequencies[i] - frequencies[j]); gap < minSpacingMHz {
+				score += spacingWeight * (minSpacingMHz - gap) * (minSpacingMHz - gap)
+			}
+		}
+	}
+	return score
+}
+
+// imdPenalty adds up how close each product 2*f1-f2 lands to another
+// channel in use, counting only products within imdWindowMHz
+func imdPenalty(frequencies []int) int {
+	penalty := 0
+	for i, f1 := range frequencies {
+		for j, f2 := range frequencies {
+			if i == j {
+				continue
+			}
+			product := 2*f1 - f2
+			for k, f3 := range frequencies {
+				if k == i || k == j {
+					continue
+				}
+				if gap := abs(product - f3); gap < imdWindowMHz {
+					penalty += (imdWindowMHz - gap) * (imdWindowMHz - gap)
+				}
+			}
+		}
+	}
+	return penalty
+}
+
+func imdRating(penalty int) int {
+	return max(0, 100-penalty/5)
+}
+
+func minSpacing(frequencies []int) int {
+	if len(frequencies) < 2 {
+		return 0
+	}
+	spacing := math.MaxInt
+	for i := range frequencies {
+		for j := i + 1; j < len(frequencies); j++ {
+			spacing = min(spacing, abs(frequencies[i]-frequencies[j]))
+		}
+	}
+	return spacing
+}
+
+// pickPower returns the highest power level at or below the target, or the
+// lowest level with a warning when the VTX can't go that low
+func pickPower(levels []int, target int) (int, string) {
+	if len(levels) == 0 {
+		return target, ""
+	}
+	sorted := append([]int(nil), levels...)
+	sort.Ints(sorted)
+	power := 0
+	for _, level := range sorted {
+		if level <= target {
+			power = level
+		}
+	}
+	if power == 0 {
+		return sorted[0], fmt.Sprintf("can't go below %d mW", sorted[0])
+	}
+	return power, ""
+}
+
+func pilotLabel(pilot Pilot, index int) string {
+	if name := strings.TrimSpace(pilot.Name); name != "" {
+		return name
+	}
+	return fmt.Sprintf("Pilot %d", index+1)
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,78 @@
+package vtx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/textpdf"
+)
+
+// Plan output formats
+const (
+	FormatJSON     = "json"
+	FormatMarkdown = "md"
+	FormatPDF      = "pdf"
+)
+
+// planPDFColumns are the widths of the PDF table columns, in points
+var planPDFColumns = []float64{224, 100, 100, 80}
+
+// Render renders a plan as a printable document for the field, returning the
+// body and content type
+func Render(plan *Plan, format string) ([]byte, string, error) {
+	switch format {
+	case FormatMarkdown:
+		return renderMarkdown(plan), "text/markdown; charset=utf-8", nil
+	case FormatPDF:
+		return renderPDF(plan), "application/pdf", nil
+	default:
+		return nil, "", fmt.Errorf("format must be one of json, md, pdf")
+	}
+}
+
+func renderMarkdown(plan *Plan) []byte {
+	var b strings.Builder
+	b.WriteString("# VTX Channel Plan\n\n")
+	b.WriteString("| Pilot | Channel | Frequency | Power |\n")
+	b.WriteString("| --- | --- | ---: | ---: |\n")
+	for _, a := range plan.Assignments {
+		fmt.Fprintf(&b, "| %s | %s | %d MHz | %d mW |\n",
+			strings.ReplaceAll(a.Pilot, "|", "\\|"), a.Channel, a.FrequencyMHz, a.PowerMw)
+	}
+	b.WriteString("\n" + summary(plan) + "\n")
+	for _, warning := range plan.Warnings {
+		fmt.Fprintf(&b, "\n- %s", warning)
+	}
+	if len(plan.Warnings) > 0 {
+		b.WriteString("\n")
+	}
+	return []byte(b.String())
+}
+
+func renderPDF(plan *Plan) []byte {
+	doc := textpdf.New()
+	doc.Heading("VTX Channel Plan")
+	doc.Text("Generated " + plan.GeneratedAt.UTC().Format("2006-01-02 15:04 UTC"))
+	doc.Gap()
+
+	doc.Row([]string{"Pilot", "Channel", "Frequency", "Power"}, planPDFColumns, true)
+	for _, a := range plan.Assignments {
+		doc.Row([]string{
+			a.Pilot,
+			a.Channel,
+			strconv.Itoa(a.FrequencyMHz) + " MHz",
+			strconv.Itoa(a.PowerMw) + " mW",
+		}, planPDFColumns, false)
+	}
+	doc.Gap()
+	doc.Text(summary(plan))
+	for _, warning := range plan.Warnings {
+		doc.Text("- " + warning)
+	}
+	return doc.Bytes()
+}
+
+func summary(plan *Plan) string {
+	return fmt.Sprintf("Minimum spacing: %d MHz. IMD rating: %d/100.", plan.MinSpacingMHz, plan.IMDRating)
+}
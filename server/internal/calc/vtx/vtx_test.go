@@ -0,0 +1,193 @@
+package vtx
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCapabilityChannels(t *testing.T) {
+	analog, err := Capability{}.Channels()
+	if err != nil {
+		t.Fatalf("analog error = %v", err)
+	}
+	// Some bands share frequencies, so count unique ones
+	seen := make(map[int]bool)
+	for _, band := range bandFrequencies {
+		for _, freq := range band {
+			seen[freq] = true
+		}
+	}
+	if len(analog) != len(seen) {
+		t.Errorf("analog channels = %d, want %d unique frequencies", len(analog), len(seen))
+	}
+	for i := 1; i < len(analog); i++ {
+		if analog[i].FrequencyMHz <= analog[i-1].FrequencyMHz {
+			t.Fatalf("channels not sorted: %+v", analog)
+		}
+	}
+
+	hdzero, err := Capability{System: SystemHDZero}.Channels()
+	if err != nil || len(hdzero) != 8 || hdzero[0].Name != "R1" {
+		t.Errorf("hdzero channels = %+v, err = %v", hdzero, err)
+	}
+
+	if _, err := (Capability{System: SystemDJI}).Channels(); err == nil {
+		t.Error("DJI without frequencies should fail")
+	}
+	if _, err := (Capability{Bands: []string{"X"}}).Channels(); err == nil {
+		t.Error("unknown band should fail")
+	}
+
+	custom, err := Capability{System: SystemDJI, FrequenciesMHz: []int{5880, 5660, 5880}}.Channels()
+	if err != nil || len(custom) != 2 || custom[0].Name != "5660 MHz" || custom[1].Name != "R7" {
+		t.Errorf("custom channels = %+v, err = %v", custom, err)
+	}
+	if _, err := (Capability{FrequenciesMHz: []int{2400}}).Channels(); err == nil {
+		t.Error("frequency outside 5.8GHz should fail")
+	}
+}
+
+func TestCapabilityFromSpecs(t *testing.T) {
+	capability := CapabilityFromSpecs(json.RawMessage(`{"videoSystem": "HDZero", "bands": "R, F", "powerLevels": [25, 200, 0]}`))
+	if capability.System != SystemHDZero {
+		t.Errorf("system = %q", capability.System)
+	}
+	if len(capability.Bands) != 2 || capability.Bands[1] != "F" {
+		t.Errorf("bands = %v", capability.Bands)
+	}
+	if len(capability.PowerLevelsMw) != 2 || capability.PowerLevelsMw[1] != 200 {
+		t.Errorf("power levels = %v", capability.PowerLevelsMw)
+	}
+
+	capability = CapabilityFromSpecs(json.RawMessage(`{"system": "dji", "frequenciesMhz": [5660, 5700]}`))
+	if len(capability.FrequenciesMHz) != 2 {
+		t.Errorf("frequencies = %v", capability.FrequenciesMHz)
+	}
+
+	if got := CapabilityFromSpecs(json.RawMessage(`not json`)); got.System != "" || got.Bands != nil {
+		t.Errorf("invalid specs = %+v", got)
+	}
+}
+
+func TestNewPlan_SpreadsPilotsWithoutIMD(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	pilots := make([]Pilot, 6)
+	for i := range pilots {
+		pilots[i] = Pilot{Name: string(rune('A' + i))}
+	}
+
+	plan, err := NewPlan(pilots, Options{}, now)
+	if err != nil {
+		t.Fatalf("NewPlan() error = %v", err)
+	}
+	if len(plan.Assignments) != 6 {
+		t.Fatalf("assignments = %+v", plan.Assignments)
+	}
+	used := make(map[int]bool)
+	for _, a := range plan.Assignments {
+		if used[a.FrequencyMHz] {
+			t.Fatalf("frequency %d assigned twice", a.FrequencyMHz)
+		}
+		used[a.FrequencyMHz] = true
+		if a.PowerMw != DefaultPowerMw {
+			t.Errorf("%s power = %d", a.Pilot, a.PowerMw)
+		}
+	}
+	if plan.MinSpacingMHz < minSpacingMHz {
+		t.Errorf("min spacing = %d, want at least %d", plan.MinSpacingMHz, minSpacingMHz)
+	}
+	// Six pilots fit with no IMD product near a channel in use
+	if plan.IMDRating != 100 {
+		t.Errorf("IMD rating = %d, want 100", plan.IMDRating)
+	}
+	for _, warning := range plan.Warnings {
+		t.Errorf("unexpected warning: %s", warning)
+	}
+}
+
+func TestNewPlan_RespectsCapabilitiesAndPower(t *testing.T) {
+	pilots := []Pilot{
+		{Name: "Analog", Capability: Capability{PowerLevelsMw: []int{200, 400}}},
+		{Name: "Digital", Capability: Capability{System: SystemHDZero, PowerLevelsMw: []int{25, 200}}},
+		{Name: "Fixed", Capability: Capability{FrequenciesMHz: []int{5880}}},
+	}
+
+	plan, err := NewPlan(pilots, Options{PowerMw: 25}, time.Now())
+	if err != nil {
+		t.Fatalf("NewPlan() error = %v", err)
+	}
+	if plan.Assignments[1].Channel[0] != 'R' {
+		t.Errorf("HDZero pilot got %s, want a Raceband channel", plan.Assignments[1].Channel)
+	}
+	if plan.Assignments[2].FrequencyMHz != 5880 {
+		t.Errorf("fixed pilot got %d", plan.Assignments[2].FrequencyMHz)
+	}
+	if plan.Assignments[0].PowerMw != 200 || plan.Assignments[1].PowerMw != 25 {
+		t.Errorf("power = %d / %d, want 200 / 25", plan.Assignments[0].PowerMw, plan.Assignments[1].PowerMw)
+	}
+	if len(plan.Warnings) != 1 || !strings.Contains(plan.Warnings[0], "Analog can't go below 200 mW") {
+		t.Errorf("warnings = %v", plan.Warnings)
+	}
+}
+
+func TestNewPlan_WarnsWhenCrowded(t *testing.T) {
+	plan, err := NewPlan(make([]Pilot, MaxPilots), Options{}, time.Now())
+	if err != nil {
+		t.Fatalf("NewPlan() error = %v", err)
+	}
+	if plan.IMDRating >= lowIMDRating {
+		t.Fatalf("IMD rating = %d, expected eight pilots to crowd the band", plan.IMDRating)
+	}
+	if len(plan.Warnings) == 0 || !strings.Contains(plan.Warnings[len(plan.Warnings)-1], "intermodulation") {
+		t.Errorf("warnings = %v", plan.Warnings)
+	}
+}
+
+func TestNewPlan_Errors(t *testing.T) {
+	if _, err := NewPlan(nil, Options{}, time.Now()); err == nil {
+		t.Error("no pilots should fail")
+	}
+	if _, err := NewPlan(make([]Pilot, MaxPilots+1), Options{}, time.Now()); err == nil {
+		t.Error("too many pilots should fail")
+	}
+
+	sameChannel := []Pilot{
+		{Capability: Capability{FrequenciesMHz: []int{5800}}},
+		{Capability: Capability{FrequenciesMHz: []int{5800}}},
+	}
+	if _, err := NewPlan(sameChannel, Options{}, time.Now()); err == nil {
+		t.Error("pilots stuck on one channel should fail")
+	}
+
+	_, err := NewPlan([]Pilot{{Capability: Capability{System: SystemWalksnail}}}, Options{}, time.Now())
+	if err == nil || !strings.Contains(err.Error(), "Pilot 1") {
+		t.Errorf("error = %v, want it to name the pilot", err)
+	}
+}
+
+func TestRender(t *testing.T) {
+	plan, err := NewPlan([]Pilot{{Name: "Sam | Lee"}, {Name: "Alex"}}, Options{}, time.Now())
+	if err != nil {
+		t.Fatalf("NewPlan() error = %v", err)
+	}
+
+	body, contentType, err := Render(plan, FormatMarkdown)
+	if err != nil || !strings.HasPrefix(contentType, "text/markdown") {
+		t.Fatalf("markdown: %q, %v", contentType, err)
+	}
+	if !strings.Contains(string(body), `Sam \| Lee`) || !strings.Contains(string(body), "IMD rating") {
+		t.Errorf("markdown body = %s", body)
+	}
+
+	body, contentType, err = Render(plan, FormatPDF)
+	if err != nil || contentType != "application/pdf" || !bytes.HasPrefix(body, []byte("%PDF-")) {
+		t.Errorf("pdf: %q, %v", contentType, err)
+	}
+
+	if _, _, err := Render(plan, "csv"); err == nil {
+		t.Error("unknown format should fail")
+	}
+}
@@ -0,0 +1,72 @@
+// Package captcha verifies captcha responses with a siteverify endpoint.
+// Cloudflare Turnstile, hCaptcha and reCAPTCHA all accept the same form
+// post, so one verifier works with any of them.
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultVerifyURL is Cloudflare Turnstile's siteverify endpoint
+const DefaultVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+
+// Verifier checks captcha responses against a provider's siteverify endpoint
+type Verifier struct {
+	verifyURL string
+	secret    string
+	client    *http.Client
+}
+
+// New creates a verifier that posts to verifyURL with the site's secret key
+func New(verifyURL, secret string) *Verifier {
+	if verifyURL == "" {
+		verifyURL = DefaultVerifyURL
+	}
+	return &Verifier{
+		verifyURL: verifyURL,
+		secret:    secret,
+		client:    &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Verify reports whether token is a valid captcha response. An error means
+// the provider couldn't be asked, not that the token is bad.
+func (v *Verifier) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return false, nil
+	}
+
+	form := url.Values{"secret": {v.secret}, "response": {token}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, fmt.Errorf("failed to create captcha request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify captcha: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("captcha verify returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("failed to decode captcha response: %w", err)
+	}
+	return result.Success, nil
+}
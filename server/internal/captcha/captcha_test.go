@@ -0,0 +1,53 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("ParseForm() error = %v", err)
+		}
+		if r.Form.Get("secret") != "site-secret" || r.Form.Get("remoteip") != "1.2.3.4" {
+			t.Errorf("unexpected form %v", r.Form)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]bool{"success": r.Form.Get("response") == "good"})
+	}))
+	defer srv.Close()
+
+	v := New(srv.URL, "site-secret")
+	ctx := context.Background()
+	tests := []struct {
+		token string
+		want  bool
+	}{
+		{"good", true},
+		{"bad", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		ok, err := v.Verify(ctx, tt.token, "1.2.3.4")
+		if err != nil {
+			t.Fatalf("Verify(%q) error = %v", tt.token, err)
+		}
+		if ok != tt.want {
+			t.Errorf("Verify(%q) = %v, want %v", tt.token, ok, tt.want)
+		}
+	}
+}
+
+func TestVerify_ProviderError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	if _, err := New(srv.URL, "site-secret").Verify(context.Background(), "good", ""); err == nil {
+		t.Error("Verify() should return an error when the provider fails")
+	}
+}
@@ -0,0 +1,324 @@
+// Package catalogrules publishes user catalog submissions that meet an
+// admin-configured rule, so trusted submitters with complete entries skip
+// the pending queue.
+package catalogrules
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	maxRuleNameLength    = 100
+	maxRequiredSpecs     = 50
+	maxPublishedRequired = 10000
+)
+
+// Store persists publish rules and the items they publish
+type Store interface {
+	ListRules(ctx context.Context) ([]models.CatalogPublishRule, error)
+	CreateRule(ctx context.Context, adminUserID string, params models.SetCatalogPublishRuleParams) (*models.CatalogPublishRule, error)
+	UpdateRule(ctx context.Context, id, adminUserID string, params models.SetCatalogPublishRuleParams) (*models.CatalogPublishRule, error)
+	DeleteRule(ctx context.Context, id string) error
+	CountPublishedSubmissions(ctx context.Context, userID, excludeItemID string) (int, error)
+	Publish(ctx context.Context, decision *models.CatalogPublishDecision) (bool, error)
+	ListDecisions(ctx context.Context, limit, offset int) ([]models.CatalogPublishDecision, error)
+}
+
+// Reputation scores submitters
+type Reputation interface {
+	Score(ctx context.Context, userID string) (int, error)
+}
+
+// Engine checks pending submissions against the publish rules. Rules are
+// cached for ttl so every instance picks up admin changes without a
+// database read per submission.
+type Engine struct {
+	store      Store
+	reputation Reputation
+	ttl        time.Duration
+	logger     *logging.Logger
+
+	mu       sync.RWMutex
+	cached   []models.CatalogPublishRule
+	loadedAt time.Time
+}
+
+// NewEngine creates a publish rule engine
+func NewEngine(store Store, ttl time.Duration, logger *logging.Logger) *Engine {
+	return &Engine{store: store, ttl: ttl, logger: logger}
+}
+
+// SetReputation sets where submitter reputation comes from. Rules with a
+// minimum reputation never match without it.
+func (e *Engine) SetReputation(reputation Reputation) {
+	e.reputation = reputation
+}
+
+// Evaluate publishes item if it is a pending user submission and an enabled
+// rule matches it. Rules are tried in priority order and the first match
+// wins. It returns the recorded decision, or nil when no rule fired.
+func (e *Engine) Evaluate(ctx context.Context, item *models.GearCatalogItem, trigger models.CatalogPublishTrigger) (*models.CatalogPublishDecision, error) {
+	if item == nil || item.CreatedByUserID == "" || models.NormalizeCatalogStatus(item.Status) != models.CatalogStatusPending {
+		return nil, nil
+	}
+
+	rules, err := e.rules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Loaded on the first rule that needs them
+	published, score := -1, -1
+	for _, rule := range rules {
+		if !rule.Enabled || !matchesItem(rule, item) {
+			continue
+		}
+		if rule.MinPublishedSubmissions > 0 {
+			if published < 0 {
+				if published, err = e.store.CountPublishedSubmissions(ctx, item.CreatedByUserID, item.ID); err != nil {
+					return nil, err
+				}
+			}
+			if published < rule.MinPublishedSubmissions {
+				continue
+			}
+		}
+		if rule.MinReputation > 0 {
+			if e.reputation == nil {
+				continue
+			}
+			if score < 0 {
+				if score, err = e.reputation.Score(ctx, item.CreatedByUserID); err != nil {
+					return nil, err
+				}
+			}
+			if score < rule.MinReputation {
+				continue
+			}
+		}
+
+		snapshot, err := json.Marshal(rule)
+		if err != nil {
+			return nil, err
+		}
+		decision := &models.CatalogPublishDecision{
+			CatalogItemID:   item.ID,
+			RuleID:          rule.ID,
+			RuleName:        rule.Name,
+			Rule:            snapshot,
+			SubmitterUserID: item.CreatedByUserID,
+			Trigger:         trigger,
+		}
+		ok, err := e.store.Publish(ctx, decision)
+		if err != nil || !ok {
+			return nil, err
+		}
+		item.Status = models.CatalogStatusPublished
+
+		if e.logger != nil {
+			e.logger.Info("Catalog item auto-published",
+				logging.WithFields(map[string]interface{}{
+					"item_id": item.ID,
+					"rule":    rule.Name,
+					"trigger": string(trigger),
+				}))
+		}
+		return decision, nil
+	}
+	return nil, nil
+}
+
+// List returns every rule in evaluation order
+func (e *Engine) List(ctx context.Context) ([]models.CatalogPublishRule, error) {
+	return e.load(ctx)
+}
+
+// Create validates and stores a new rule
+func (e *Engine) Create(ctx context.Context, adminUserID string, params models.SetCatalogPublishRuleParams) (*models.CatalogPublishRule, error) {
+	if err := normalize(&params); err != nil {
+		return nil, err
+	}
+	rule, err := e.store.CreateRule(ctx, adminUserID, params)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = e.load(ctx)
+	return rule, nil
+}
+
+// Update validates and replaces a rule
+func (e *Engine) Update(ctx context.Context, id, adminUserID string, params models.SetCatalogPublishRuleParams) (*models.CatalogPublishRule, error) {
+	if err := normalize(&params); err != nil {
+		return nil, err
+	}
+	rule, err := e.store.UpdateRule(ctx, id, adminUserID, params)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = e.load(ctx)
+	return rule, nil
+}
+
+// Delete removes a rule
+func (e *Engine) Delete(ctx context.Context, id string) error {
+	if err := e.store.DeleteRule(ctx, id); err != nil {
+		return err
+	}
+	_, _ = e.load(ctx)
+	return nil
+}
+
+// Decisions returns the audit log of auto-published items, newest first
+func (e *Engine) Decisions(ctx context.Context, limit, offset int) ([]models.CatalogPublishDecision, error) {
+	return e.store.ListDecisions(ctx, limit, offset)
+}
+
+// rules returns the cached rules, reloading them once they are older than
+// the ttl. If they can't be reloaded the last loaded rules are used.
+func (e *Engine) rules(ctx context.Context) ([]models.CatalogPublishRule, error) {
+	e.mu.RLock()
+	cached, loaded := e.cached, !e.loadedAt.IsZero()
+	fresh := loaded && time.Since(e.loadedAt) < e.ttl
+	e.mu.RUnlock()
+	if fresh {
+		return cached, nil
+	}
+
+	rules, err := e.load(ctx)
+	if err != nil {
+		if loaded {
+			return cached, nil
+		}
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (e *Engine) load(ctx context.Context) ([]models.CatalogPublishRule, error) {
+	rules, err := e.store.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	e.cached = rules
+	e.loadedAt = time.Now()
+	e.mu.Unlock()
+	return rules, nil
+}
+
+// matchesItem checks the conditions a rule places on the item itself
+func matchesItem(rule models.CatalogPublishRule, item *models.GearCatalogItem) bool {
+	if len(rule.GearTypes) > 0 {
+		found := false
+		for _, gearType := range rule.GearTypes {
+			if gearType == item.GearType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if rule.RequireDescription && strings.TrimSpace(item.Description) == "" {
+		return false
+	}
+	if rule.RequireImage && item.ImageStatus != models.ImageStatusScanned && item.ImageStatus != models.ImageStatusApproved {
+		return false
+	}
+	if len(rule.RequiredSpecs) > 0 {
+		var specs map[string]interface{}
+		if len(item.Specs) == 0 || json.Unmarshal(item.Specs, &specs) != nil {
+			return false
+		}
+		for _, key := range rule.RequiredSpecs {
+			if !hasValue(specs[key]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func hasValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case string:
+		return strings.TrimSpace(v) != ""
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	default:
+		return true
+	}
+}
+
+// normalize validates rule params and tidies their lists. A rule needs at
+// least one condition; one without any would publish every submission.
+func normalize(params *models.SetCatalogPublishRuleParams) error {
+	params.Name = strings.TrimSpace(params.Name)
+	if params.Name == "" {
+		return &RuleError{Message: "name is required"}
+	}
+	if len(params.Name) > maxRuleNameLength {
+		return &RuleError{Message: "name is too long"}
+	}
+	if params.MinPublishedSubmissions < 0 || params.MinPublishedSubmissions > maxPublishedRequired {
+		return &RuleError{Message: "minPublishedSubmissions is out of range"}
+	}
+	if params.MinReputation < 0 {
+		return &RuleError{Message: "minReputation can't be negative"}
+	}
+
+	gearTypes := make([]models.GearType, 0, len(params.GearTypes))
+	seenTypes := make(map[models.GearType]bool)
+	for _, gearType := range params.GearTypes {
+		if !gearType.IsValid() {
+			return &RuleError{Message: "invalid gearType: " + string(gearType)}
+		}
+		if !seenTypes[gearType] {
+			seenTypes[gearType] = true
+			gearTypes = append(gearTypes, gearType)
+		}
+	}
+	params.GearTypes = gearTypes
+
+	specs := make([]string, 0, len(params.RequiredSpecs))
+	seenSpecs := make(map[string]bool)
+	for _, key := range params.RequiredSpecs {
+		key = strings.TrimSpace(key)
+		if key == "" || seenSpecs[key] {
+			continue
+		}
+		seenSpecs[key] = true
+		specs = append(specs, key)
+	}
+	if len(specs) > maxRequiredSpecs {
+		return &RuleError{Message: "too many required specs"}
+	}
+	params.RequiredSpecs = specs
+
+	if params.MinPublishedSubmissions == 0 && params.MinReputation == 0 && len(specs) == 0 && !params.RequireImage && !params.RequireDescription {
+		return &RuleError{Message: "a rule needs at least one condition besides gear type"}
+	}
+	return nil
+}
+
+// RuleError represents an invalid rule change that should be shown to the client
+type RuleError struct {
+	Message string
+}
+
+func (e *RuleError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,227 @@
+package catalogrules
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+// fakeStore keeps rules in memory and records published items
+type fakeStore struct {
+	rules     []models.CatalogPublishRule
+	published map[string]int // user id -> earlier published submissions
+	decisions []models.CatalogPublishDecision
+	pending   map[string]bool
+	lists     int
+}
+
+func (f *fakeStore) ListRules(ctx context.Context) ([]models.CatalogPublishRule, error) {
+	f.lists++
+	return append([]models.CatalogPublishRule(nil), f.rules...), nil
+}
+
+func (f *fakeStore) CreateRule(ctx context.Context, adminUserID string, params models.SetCatalogPublishRuleParams) (*models.CatalogPublishRule, error) {
+	rule := models.CatalogPublishRule{
+		ID: params.Name, Name: params.Name, Enabled: params.Enabled, Priority: params.Priority,
+		GearTypes: params.GearTypes, MinPublishedSubmissions: params.MinPublishedSubmissions,
+		RequiredSpecs: params.RequiredSpecs, RequireImage: params.RequireImage, RequireDescription: params.RequireDescription,
+		UpdatedByUserID: adminUserID,
+	}
+	f.rules = append(f.rules, rule)
+	return &rule, nil
+}
+
+func (f *fakeStore) UpdateRule(ctx context.Context, id, adminUserID string, params models.SetCatalogPublishRuleParams) (*models.CatalogPublishRule, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeStore) DeleteRule(ctx context.Context, id string) error {
+	for i := range f.rules {
+		if f.rules[i].ID == id {
+			f.rules = append(f.rules[:i], f.rules[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("publish rule not found")
+}
+
+func (f *fakeStore) CountPublishedSubmissions(ctx context.Context, userID, excludeItemID string) (int, error) {
+	return f.published[userID], nil
+}
+
+func (f *fakeStore) Publish(ctx context.Context, decision *models.CatalogPublishDecision) (bool, error) {
+	if !f.pending[decision.CatalogItemID] {
+		return false, nil
+	}
+	f.pending[decision.CatalogItemID] = false
+	decision.ID = "decision-" + decision.CatalogItemID
+	f.decisions = append(f.decisions, *decision)
+	return true, nil
+}
+
+func (f *fakeStore) ListDecisions(ctx context.Context, limit, offset int) ([]models.CatalogPublishDecision, error) {
+	return f.decisions, nil
+}
+
+func pendingItem(id string) *models.GearCatalogItem {
+	return &models.GearCatalogItem{
+		ID:              id,
+		GearType:        models.GearTypeMotor,
+		CreatedByUserID: "user-1",
+		Status:          models.CatalogStatusPending,
+		Specs:           json.RawMessage(`{"kv": 1950, "statorSize": "2207", "weight": ""}`),
+		ImageStatus:     models.ImageStatusMissing,
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	store := &fakeStore{
+		rules: []models.CatalogPublishRule{
+			{ID: "disabled", Name: "disabled", Priority: 0, RequireDescription: true},
+			{ID: "trusted-motors", Name: "trusted motors", Enabled: true, Priority: 1, GearTypes: []models.GearType{models.GearTypeMotor}, MinPublishedSubmissions: 5, RequiredSpecs: []string{"kv", "statorSize"}},
+			{ID: "with-image", Name: "with image", Enabled: true, Priority: 2, RequireImage: true},
+		},
+		published: map[string]int{"user-1": 5, "user-2": 1},
+		pending:   map[string]bool{"a": true, "b": true, "c": true, "d": true},
+	}
+	engine := NewEngine(store, time.Minute, testutil.NullLogger())
+	ctx := context.Background()
+
+	item := pendingItem("a")
+	decision, err := engine.Evaluate(ctx, item, models.CatalogPublishOnSubmit)
+	if err != nil || decision == nil {
+		t.Fatalf("Evaluate() = %v, %v; want trusted motors to fire", decision, err)
+	}
+	if decision.RuleID != "trusted-motors" || item.Status != models.CatalogStatusPublished {
+		t.Errorf("decision = %+v, item status = %s", decision, item.Status)
+	}
+	var snapshot models.CatalogPublishRule
+	if err := json.Unmarshal(decision.Rule, &snapshot); err != nil || snapshot.MinPublishedSubmissions != 5 {
+		t.Errorf("rule snapshot = %s, %v", decision.Rule, err)
+	}
+
+	// Empty spec values don't count
+	item = pendingItem("b")
+	store.rules[1].RequiredSpecs = []string{"weight"}
+	engine.loadedAt = time.Time{}
+	if decision, _ := engine.Evaluate(ctx, item, models.CatalogPublishOnSubmit); decision != nil {
+		t.Errorf("empty spec published the item by %s", decision.RuleName)
+	}
+
+	// A new submitter waits for the image rule
+	item = pendingItem("c")
+	item.CreatedByUserID = "user-2"
+	if decision, _ := engine.Evaluate(ctx, item, models.CatalogPublishOnSubmit); decision != nil {
+		t.Errorf("untrusted submission published by %s", decision.RuleName)
+	}
+	item.ImageStatus = models.ImageStatusScanned
+	if decision, _ := engine.Evaluate(ctx, item, models.CatalogPublishOnImage); decision == nil || decision.RuleID != "with-image" || decision.Trigger != models.CatalogPublishOnImage {
+		t.Errorf("image decision = %+v", decision)
+	}
+
+	// Items that aren't pending, or were published meanwhile, are left alone
+	item = pendingItem("d")
+	item.Status = models.CatalogStatusRemoved
+	item.ImageStatus = models.ImageStatusApproved
+	if decision, _ := engine.Evaluate(ctx, item, models.CatalogPublishOnImage); decision != nil {
+		t.Error("removed item was published")
+	}
+	item = pendingItem("a")
+	item.ImageStatus = models.ImageStatusApproved
+	if decision, _ := engine.Evaluate(ctx, item, models.CatalogPublishOnImage); decision != nil || item.Status != models.CatalogStatusPending {
+		t.Error("item already published recorded a second decision")
+	}
+	if len(store.decisions) != 2 {
+		t.Errorf("decisions = %d, want 2", len(store.decisions))
+	}
+}
+
+func TestEvaluate_CachesRules(t *testing.T) {
+	store := &fakeStore{pending: map[string]bool{}}
+	engine := NewEngine(store, time.Minute, testutil.NullLogger())
+	ctx := context.Background()
+
+	for _, id := range []string{"a", "b", "c"} {
+		if _, err := engine.Evaluate(ctx, pendingItem(id), models.CatalogPublishOnSubmit); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if store.lists != 1 {
+		t.Errorf("rules loaded %d times, want 1", store.lists)
+	}
+
+	if _, err := engine.Create(ctx, "admin", models.SetCatalogPublishRuleParams{Name: "described", Enabled: true, RequireDescription: true}); err != nil {
+		t.Fatal(err)
+	}
+	item := pendingItem("e")
+	item.Description = "A 2207 motor"
+	store.pending["e"] = true
+	if decision, _ := engine.Evaluate(ctx, item, models.CatalogPublishOnSubmit); decision == nil {
+		t.Error("new rule was not picked up after Create")
+	}
+}
+
+func TestCreate_Validation(t *testing.T) {
+	engine := NewEngine(&fakeStore{}, time.Minute, testutil.NullLogger())
+	ctx := context.Background()
+
+	tests := []models.SetCatalogPublishRuleParams{
+		{Name: "  ", RequireImage: true},
+		{Name: "any motor", GearTypes: []models.GearType{models.GearTypeMotor}},
+		{Name: "bad type", GearTypes: []models.GearType{"warp-drive"}, RequireImage: true},
+		{Name: "negative", MinPublishedSubmissions: -1, RequireImage: true},
+		{Name: "blank specs", RequiredSpecs: []string{" ", ""}},
+	}
+	for _, params := range tests {
+		var ruleErr *RuleError
+		if _, err := engine.Create(ctx, "admin", params); !errors.As(err, &ruleErr) {
+			t.Errorf("Create(%+v) error = %v, want RuleError", params, err)
+		}
+	}
+
+	rule, err := engine.Create(ctx, "admin", models.SetCatalogPublishRuleParams{
+		Name:          " trusted ",
+		GearTypes:     []models.GearType{models.GearTypeMotor, models.GearTypeMotor},
+		RequiredSpecs: []string{"kv", " kv ", ""},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.Name != "trusted" || len(rule.GearTypes) != 1 || len(rule.RequiredSpecs) != 1 {
+		t.Errorf("normalized rule = %+v", rule)
+	}
+}
+
+type fakeReputation map[string]int
+
+func (f fakeReputation) Score(ctx context.Context, userID string) (int, error) {
+	return f[userID], nil
+}
+
+func TestEvaluate_MinReputation(t *testing.T) {
+	store := &fakeStore{
+		rules:   []models.CatalogPublishRule{{ID: "reputable", Name: "reputable", Enabled: true, MinReputation: 200}},
+		pending: map[string]bool{"a": true, "b": true},
+	}
+	engine := NewEngine(store, time.Minute, testutil.NullLogger())
+	ctx := context.Background()
+
+	if decision, _ := engine.Evaluate(ctx, pendingItem("a"), models.CatalogPublishOnSubmit); decision != nil {
+		t.Error("reputation rule matched without a reputation source")
+	}
+
+	engine.SetReputation(fakeReputation{"user-1": 250, "user-2": 120})
+	if decision, _ := engine.Evaluate(ctx, pendingItem("a"), models.CatalogPublishOnSubmit); decision == nil {
+		t.Error("reputable submitter was not published")
+	}
+	item := pendingItem("b")
+	item.CreatedByUserID = "user-2"
+	if decision, _ := engine.Evaluate(ctx, item, models.CatalogPublishOnSubmit); decision != nil {
+		t.Error("submitter below the minimum reputation was published")
+	}
+}
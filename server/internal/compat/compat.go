@@ -0,0 +1,370 @@
+// Package compat checks whether FPV parts work together: RC link and video
+// ecosystems, battery cell count against ESC ratings, and prop size against
+// the frame. It reads catalog specs where they are set and falls back to the
+// part's name, since most catalog items only name their protocol.
+package compat
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// propSizeTolerance is how much larger than the frame's rated size a prop
+// can be; 5" frames take 5.1" props
+const propSizeTolerance = 0.5
+
+// Part is one catalog item to check
+type Part struct {
+	ID       string
+	GearType models.GearType
+	Name     string
+	Specs    json.RawMessage
+}
+
+// PartFromCatalog converts a catalog item
+func PartFromCatalog(item models.GearCatalogItem) Part {
+	return Part{
+		ID:       item.ID,
+		GearType: item.GearType,
+		Name:     strings.TrimSpace(strings.Join([]string{item.Brand, item.Model, item.Variant}, " ")),
+		Specs:    item.Specs,
+	}
+}
+
+// Profile is what the checks know about a part
+type Profile struct {
+	RCProtocols []models.RCProtocol
+	VideoSystem models.VideoSystem
+	MinCells    int
+	MaxCells    int
+	SizeInches  float64
+}
+
+// rcKeywords and videoKeywords map name and spec words to ecosystems
+var rcKeywords = map[string]models.RCProtocol{
+	"elrs":       models.RCProtocolELRS,
+	"expresslrs": models.RCProtocolELRS,
+	"crossfire":  models.RCProtocolCrossfire,
+	"crsf":       models.RCProtocolCrossfire,
+	"ghost":      models.RCProtocolGhost,
+	"frsky":      models.RCProtocolFrSky,
+	"accst":      models.RCProtocolFrSky,
+	"r-xsr":      models.RCProtocolFrSky,
+	"xm+":        models.RCProtocolFrSky,
+}
+
+var videoKeywords = map[string]models.VideoSystem{
+	"analog":    models.VideoSystemAnalog,
+	"analogue":  models.VideoSystemAnalog,
+	"dji":       models.VideoSystemDJI,
+	"vista":     models.VideoSystemDJI,
+	"o3":        models.VideoSystemDJI,
+	"o4":        models.VideoSystemDJI,
+	"hdzero":    models.VideoSystemHDZero,
+	"walksnail": models.VideoSystemWalksnail,
+	"avatar":    models.VideoSystemWalksnail,
+}
+
+// ProfileOf reads a part's ecosystems and ratings. Analog VTXs and cameras
+// rarely say so in their name, so a VTX or camera that names no digital
+// system is taken to be analog.
+func ProfileOf(part Part) Profile {
+	var profile Profile
+	var specs map[string]interface{}
+	if len(part.Specs) > 0 {
+		_ = json.Unmarshal(part.Specs, &specs)
+	}
+
+	text := strings.ToLower(part.Name)
+	for _, key := range []string{"protocol", "rxProtocol", "rcProtocol", "videoSystem", "video", "system"} {
+		if value, ok := specs[key].(string); ok {
+			text += " " + strings.ToLower(value)
+		}
+	}
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '-'
+	})
+	// "HD Zero" and "Air Unit" are written apart as often as not
+	joined := " " + strings.Join(words, " ") + " "
+	if strings.Contains(joined, " hd zero ") {
+		words = append(words, "hdzero")
+	}
+	if strings.Contains(joined, " air unit ") {
+		words = append(words, "dji")
+	}
+
+	seen := make(map[models.RCProtocol]bool)
+	for _, word := range words {
+		if protocol, ok := rcKeywords[word]; ok && !seen[protocol] {
+			seen[protocol] = true
+			profile.RCProtocols = append(profile.RCProtocols, protocol)
+		}
+		if system, ok := videoKeywords[word]; ok && profile.VideoSystem == "" {
+			profile.VideoSystem = system
+		}
+	}
+	sort.Slice(profile.RCProtocols, func(i, j int) bool { return profile.RCProtocols[i] < profile.RCProtocols[j] })
+	if profile.VideoSystem == "" && (part.GearType == models.GearTypeVTX || part.GearType == models.GearTypeCamera) {
+		profile.VideoSystem = models.VideoSystemAnalog
+	}
+
+	if raw, ok := specs["cells"]; ok {
+		profile.MinCells, profile.MaxCells = parseCells(raw)
+	}
+	if raw, ok := specs["size"]; ok {
+		profile.SizeInches, _ = models.SpecNumber(raw)
+	}
+	return profile
+}
+
+// Speaks reports whether the part uses the RC protocol
+func (p Profile) Speaks(protocol models.RCProtocol) bool {
+	for _, candidate := range p.RCProtocols {
+		if candidate == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns every problem between the parts, errors first
+func Check(parts []Part) []models.CompatibilityIssue {
+	profiles := make([]Profile, len(parts))
+	for i, part := range parts {
+		profiles[i] = ProfileOf(part)
+	}
+
+	issues := make([]models.CompatibilityIssue, 0)
+	issues = append(issues, checkRCLink(parts, profiles)...)
+	issues = append(issues, checkVideo(parts, profiles)...)
+	issues = append(issues, checkCells(parts, profiles)...)
+	issues = append(issues, checkPropSize(parts, profiles)...)
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Severity == models.CompatibilityError && issues[j].Severity != models.CompatibilityError
+	})
+	return issues
+}
+
+// Involving returns the issues that name the catalog item
+func Involving(issues []models.CompatibilityIssue, itemID string) []models.CompatibilityIssue {
+	filtered := make([]models.CompatibilityIssue, 0)
+	for _, issue := range issues {
+		for _, id := range issue.ItemIDs {
+			if id == itemID {
+				filtered = append(filtered, issue)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// checkRCLink flags receivers that speak none of the radios' protocols.
+// Parts whose protocol can't be read are left out.
+func checkRCLink(parts []Part, profiles []Profile) []models.CompatibilityIssue {
+	radioProtocols := make(map[models.RCProtocol]bool)
+	var radioIDs, radioNames []string
+	for i, part := range parts {
+		if part.GearType != models.GearTypeRadio || len(profiles[i].RCProtocols) == 0 {
+			continue
+		}
+		for _, protocol := range profiles[i].RCProtocols {
+			radioProtocols[protocol] = true
+		}
+		radioIDs = append(radioIDs, part.ID)
+		radioNames = append(radioNames, part.Name)
+	}
+	if len(radioProtocols) == 0 {
+		return nil
+	}
+
+	var issues []models.CompatibilityIssue
+	for i, part := range parts {
+		if part.GearType != models.GearTypeReceiver || len(profiles[i].RCProtocols) == 0 {
+			continue
+		}
+		shared := false
+		for _, protocol := range profiles[i].RCProtocols {
+			if radioProtocols[protocol] {
+				shared = true
+				break
+			}
+		}
+		if shared {
+			continue
+		}
+		issues = append(issues, models.CompatibilityIssue{
+			Code:     models.CompatRCProtocolMismatch,
+			Severity: models.CompatibilityError,
+			Message: fmt.Sprintf("%s is %s, but %s %s; it won't bind without another module",
+				part.Name, protocolList(profiles[i].RCProtocols), strings.Join(radioNames, ", "), radioVerb(len(radioNames), radioProtocols)),
+			ItemIDs: append([]string{part.ID}, radioIDs...),
+		})
+	}
+	return issues
+}
+
+func radioVerb(count int, protocols map[models.RCProtocol]bool) string {
+	list := make([]models.RCProtocol, 0, len(protocols))
+	for protocol := range protocols {
+		list = append(list, protocol)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	if count == 1 {
+		return "uses " + protocolList(list)
+	}
+	return "use " + protocolList(list)
+}
+
+func protocolList(protocols []models.RCProtocol) string {
+	names := make([]string, len(protocols))
+	for i, protocol := range protocols {
+		names[i] = ProtocolLabel(protocol)
+	}
+	return strings.Join(names, "/")
+}
+
+// ProtocolLabel is the display name of an RC protocol
+func ProtocolLabel(protocol models.RCProtocol) string {
+	switch protocol {
+	case models.RCProtocolELRS:
+		return "ELRS"
+	case models.RCProtocolCrossfire:
+		return "Crossfire"
+	case models.RCProtocolGhost:
+		return "Ghost"
+	case models.RCProtocolFrSky:
+		return "FrSky"
+	default:
+		return string(protocol)
+	}
+}
+
+// VideoLabel is the display name of a video system
+func VideoLabel(system models.VideoSystem) string {
+	switch system {
+	case models.VideoSystemAnalog:
+		return "analog"
+	case models.VideoSystemDJI:
+		return "DJI"
+	case models.VideoSystemHDZero:
+		return "HDZero"
+	case models.VideoSystemWalksnail:
+		return "Walksnail"
+	default:
+		return string(system)
+	}
+}
+
+// checkVideo flags video parts on different systems
+func checkVideo(parts []Part, profiles []Profile) []models.CompatibilityIssue {
+	var first = -1
+	for i, part := range parts {
+		if !isVideoPart(part.GearType) || profiles[i].VideoSystem == "" {
+			continue
+		}
+		if first < 0 {
+			first = i
+			continue
+		}
+		if profiles[i].VideoSystem != profiles[first].VideoSystem {
+			return []models.CompatibilityIssue{{
+				Code:     models.CompatVideoSystemMismatch,
+				Severity: models.CompatibilityError,
+				Message: fmt.Sprintf("%s is %s video, but %s is %s",
+					parts[first].Name, VideoLabel(profiles[first].VideoSystem), part.Name, VideoLabel(profiles[i].VideoSystem)),
+				ItemIDs: []string{parts[first].ID, part.ID},
+			}}
+		}
+	}
+	return nil
+}
+
+func isVideoPart(gearType models.GearType) bool {
+	return gearType == models.GearTypeVTX || gearType == models.GearTypeCamera || gearType == models.GearTypeHDUnit
+}
+
+// checkCells compares batteries with the cell range of ESCs and AIOs
+func checkCells(parts []Part, profiles []Profile) []models.CompatibilityIssue {
+	var issues []models.CompatibilityIssue
+	for b, battery := range parts {
+		cells := profiles[b].MaxCells
+		if battery.GearType != models.GearTypeBattery || cells == 0 {
+			continue
+		}
+		for e, esc := range parts {
+			if esc.GearType != models.GearTypeESC && esc.GearType != models.GearTypeAIO {
+				continue
+			}
+			rating := profiles[e]
+			switch {
+			case rating.MaxCells > 0 && cells > rating.MaxCells:
+				issues = append(issues, models.CompatibilityIssue{
+					Code:     models.CompatCellsOverRating,
+					Severity: models.CompatibilityError,
+					Message:  fmt.Sprintf("%s is %dS, but %s is rated for %dS at most", battery.Name, cells, esc.Name, rating.MaxCells),
+					ItemIDs:  []string{battery.ID, esc.ID},
+				})
+			case rating.MinCells > 0 && cells < rating.MinCells:
+				issues = append(issues, models.CompatibilityIssue{
+					Code:     models.CompatCellsUnderRating,
+					Severity: models.CompatibilityWarning,
+					Message:  fmt.Sprintf("%s is %dS, below the %dS minimum of %s", battery.Name, cells, rating.MinCells, esc.Name),
+					ItemIDs:  []string{battery.ID, esc.ID},
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// checkPropSize flags props larger than the frame takes
+func checkPropSize(parts []Part, profiles []Profile) []models.CompatibilityIssue {
+	var issues []models.CompatibilityIssue
+	for f, frame := range parts {
+		size := profiles[f].SizeInches
+		if frame.GearType != models.GearTypeFrame || size == 0 {
+			continue
+		}
+		for p, prop := range parts {
+			if prop.GearType != models.GearTypeProp || profiles[p].SizeInches == 0 {
+				continue
+			}
+			if profiles[p].SizeInches > size+propSizeTolerance {
+				issues = append(issues, models.CompatibilityIssue{
+					Code:     models.CompatPropTooLarge,
+					Severity: models.CompatibilityError,
+					Message:  fmt.Sprintf("%s is %g\", too large for %s, a %g\" frame", prop.Name, profiles[p].SizeInches, frame.Name, size),
+					ItemIDs:  []string{prop.ID, frame.ID},
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// parseCells reads a cell rating such as 6, "6S" or "3-6S"
+func parseCells(value interface{}) (int, int) {
+	switch v := value.(type) {
+	case float64:
+		return int(v), int(v)
+	case string:
+		text := strings.ToLower(strings.TrimSpace(v))
+		if low, high, ok := strings.Cut(text, "-"); ok {
+			min, minOK := models.SpecNumber(low)
+			max, maxOK := models.SpecNumber(high)
+			if minOK && maxOK {
+				return int(min), int(max)
+			}
+		}
+		if cells, ok := models.SpecNumber(text); ok {
+			return int(cells), int(cells)
+		}
+	}
+	return 0, 0
+}
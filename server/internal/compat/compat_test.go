@@ -0,0 +1,120 @@
+package compat
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+func part(id string, gearType models.GearType, name string, specs string) Part {
+	p := Part{ID: id, GearType: gearType, Name: name}
+	if specs != "" {
+		p.Specs = json.RawMessage(specs)
+	}
+	return p
+}
+
+func codes(issues []models.CompatibilityIssue) []string {
+	out := make([]string, len(issues))
+	for i, issue := range issues {
+		out[i] = issue.Code
+	}
+	return out
+}
+
+func TestProfileOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		part      Part
+		protocols []models.RCProtocol
+		video     models.VideoSystem
+		minCells  int
+		maxCells  int
+	}{
+		{"elrs receiver by name", part("1", models.GearTypeReceiver, "RadioMaster RP1 ExpressLRS", ""), []models.RCProtocol{models.RCProtocolELRS}, "", 0, 0},
+		{"crossfire by spec", part("2", models.GearTypeReceiver, "TBS Nano RX", `{"protocol": "CRSF"}`), []models.RCProtocol{models.RCProtocolCrossfire}, "", 0, 0},
+		{"multi-protocol radio", part("3", models.GearTypeRadio, "TX16S MKII ELRS + FrSky", ""), []models.RCProtocol{models.RCProtocolELRS, models.RCProtocolFrSky}, "", 0, 0},
+		{"analog vtx by default", part("4", models.GearTypeVTX, "TBS Unify Pro32", ""), nil, models.VideoSystemAnalog, 0, 0},
+		{"hdzero written apart", part("5", models.GearTypeVTX, "HD Zero Race V3", ""), nil, models.VideoSystemHDZero, 0, 0},
+		{"dji air unit", part("6", models.GearTypeHDUnit, "DJI O3 Air Unit", ""), nil, models.VideoSystemDJI, 0, 0},
+		{"esc cell range", part("7", models.GearTypeESC, "Tekko32 F4 45A", `{"cells": "3-6S"}`), nil, "", 3, 6},
+		{"battery cells", part("8", models.GearTypeBattery, "CNHL 1300mAh", `{"cells": 6}`), nil, "", 6, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := ProfileOf(tt.part)
+			if len(profile.RCProtocols) != len(tt.protocols) {
+				t.Fatalf("RCProtocols = %v, want %v", profile.RCProtocols, tt.protocols)
+			}
+			for i := range tt.protocols {
+				if profile.RCProtocols[i] != tt.protocols[i] {
+					t.Fatalf("RCProtocols = %v, want %v", profile.RCProtocols, tt.protocols)
+				}
+			}
+			if profile.VideoSystem != tt.video {
+				t.Errorf("VideoSystem = %q, want %q", profile.VideoSystem, tt.video)
+			}
+			if profile.MinCells != tt.minCells || profile.MaxCells != tt.maxCells {
+				t.Errorf("cells = %d-%d, want %d-%d", profile.MinCells, profile.MaxCells, tt.minCells, tt.maxCells)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	radio := part("radio", models.GearTypeRadio, "RadioMaster Boxer ELRS", "")
+	elrsRX := part("elrs-rx", models.GearTypeReceiver, "BetaFPV ELRS Nano", "")
+	tbsRX := part("tbs-rx", models.GearTypeReceiver, "TBS Crossfire Nano RX", "")
+	analogVTX := part("vtx", models.GearTypeVTX, "Rush Tank Ultimate", "")
+	analogCam := part("cam", models.GearTypeCamera, "Caddx Ratel 2", "")
+	djiUnit := part("o3", models.GearTypeHDUnit, "DJI O3 Air Unit", "")
+	esc := part("esc", models.GearTypeESC, "SpeedyBee 4in1", `{"cells": "3-4S"}`)
+	battery6S := part("6s", models.GearTypeBattery, "Tattu 6S 1050", `{"cells": "6S"}`)
+	battery2S := part("2s", models.GearTypeBattery, "2S 450", `{"cells": 2}`)
+	frame := part("frame", models.GearTypeFrame, "Source One 5", `{"size": "5\""}`)
+	prop51 := part("p51", models.GearTypeProp, "HQ 5.1x3.1x3", `{"size": "5.1x3.1x3"}`)
+	prop7 := part("p7", models.GearTypeProp, "HQ 7x4x3", `{"size": 7}`)
+
+	tests := []struct {
+		name  string
+		parts []Part
+		want  []string
+	}{
+		{"compatible", []Part{radio, elrsRX, analogVTX, analogCam, frame, prop51}, []string{}},
+		{"receiver on the wrong link", []Part{radio, tbsRX}, []string{models.CompatRCProtocolMismatch}},
+		{"receiver without a radio", []Part{tbsRX}, []string{}},
+		{"analog camera on a digital unit", []Part{djiUnit, analogCam}, []string{models.CompatVideoSystemMismatch}},
+		{"battery over rating", []Part{esc, battery6S}, []string{models.CompatCellsOverRating}},
+		{"battery under rating", []Part{esc, battery2S}, []string{models.CompatCellsUnderRating}},
+		{"prop too large", []Part{frame, prop7}, []string{models.CompatPropTooLarge}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := codes(Check(tt.parts))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Check() = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Check() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheck_ErrorsFirst(t *testing.T) {
+	issues := Check([]Part{
+		part("esc", models.GearTypeESC, "ESC", `{"cells": "3-6S"}`),
+		part("2s", models.GearTypeBattery, "2S", `{"cells": 2}`),
+		part("radio", models.GearTypeRadio, "Zorro ELRS", ""),
+		part("rx", models.GearTypeReceiver, "Ghost Atto", ""),
+	})
+	if len(issues) != 2 || issues[0].Severity != models.CompatibilityError || issues[1].Severity != models.CompatibilityWarning {
+		t.Fatalf("expected the error before the warning, got %+v", issues)
+	}
+	if involving := Involving(issues, "rx"); len(involving) != 1 || involving[0].Code != models.CompatRCProtocolMismatch {
+		t.Fatalf("Involving(rx) = %+v", involving)
+	}
+}
@@ -0,0 +1,115 @@
+package compat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// CheckEcosystem warns when a part being added doesn't fit the RC link or
+// video system the owner already uses. Unlike Check, the existing parts
+// aren't one build, so only ecosystem fit is compared and the results are
+// always warnings: the owner may be switching systems on purpose.
+func CheckEcosystem(added Part, existing []Part) []models.CompatibilityIssue {
+	profile := ProfileOf(added)
+	profiles := make([]Profile, len(existing))
+	for i, part := range existing {
+		profiles[i] = ProfileOf(part)
+	}
+
+	issues := make([]models.CompatibilityIssue, 0)
+	switch added.GearType {
+	case models.GearTypeReceiver:
+		if issue, ok := ecosystemRCIssue(added, profile, existing, profiles, models.GearTypeRadio, "radios"); ok {
+			issues = append(issues, issue)
+		}
+	case models.GearTypeRadio:
+		if issue, ok := ecosystemRCIssue(added, profile, existing, profiles, models.GearTypeReceiver, "receivers"); ok {
+			issues = append(issues, issue)
+		}
+	}
+	if isVideoPart(added.GearType) {
+		if issue, ok := ecosystemVideoIssue(added, profile, existing, profiles); ok {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// ecosystemRCIssue compares the added part's protocols with those of the
+// owner's parts of the other side of the link
+func ecosystemRCIssue(added Part, profile Profile, existing []Part, profiles []Profile, other models.GearType, plural string) (models.CompatibilityIssue, bool) {
+	if len(profile.RCProtocols) == 0 {
+		return models.CompatibilityIssue{}, false
+	}
+	owned := make(map[models.RCProtocol]bool)
+	var ids []string
+	for i, part := range existing {
+		if part.GearType != other || part.ID == added.ID || len(profiles[i].RCProtocols) == 0 {
+			continue
+		}
+		for _, protocol := range profiles[i].RCProtocols {
+			owned[protocol] = true
+		}
+		ids = append(ids, part.ID)
+	}
+	if len(owned) == 0 {
+		return models.CompatibilityIssue{}, false
+	}
+	for _, protocol := range profile.RCProtocols {
+		if owned[protocol] {
+			return models.CompatibilityIssue{}, false
+		}
+	}
+
+	ownedList := make([]models.RCProtocol, 0, len(owned))
+	for protocol := range owned {
+		ownedList = append(ownedList, protocol)
+	}
+	sort.Slice(ownedList, func(i, j int) bool { return ownedList[i] < ownedList[j] })
+	return models.CompatibilityIssue{
+		Code:     models.CompatRCProtocolMismatch,
+		Severity: models.CompatibilityWarning,
+		Message: fmt.Sprintf("%s is %s, but your %s are %s",
+			added.Name, protocolList(profile.RCProtocols), plural, protocolList(ownedList)),
+		ItemIDs: append([]string{added.ID}, ids...),
+	}, true
+}
+
+// ecosystemVideoIssue warns when none of the owner's video parts are on the
+// added part's video system
+func ecosystemVideoIssue(added Part, profile Profile, existing []Part, profiles []Profile) (models.CompatibilityIssue, bool) {
+	if profile.VideoSystem == "" {
+		return models.CompatibilityIssue{}, false
+	}
+	owned := make(map[models.VideoSystem]bool)
+	var ids []string
+	for i, part := range existing {
+		if !isVideoPart(part.GearType) || part.ID == added.ID || profiles[i].VideoSystem == "" {
+			continue
+		}
+		if profiles[i].VideoSystem == profile.VideoSystem {
+			return models.CompatibilityIssue{}, false
+		}
+		owned[profiles[i].VideoSystem] = true
+		ids = append(ids, part.ID)
+	}
+	if len(owned) == 0 {
+		return models.CompatibilityIssue{}, false
+	}
+
+	labels := make([]string, 0, len(owned))
+	for system := range owned {
+		labels = append(labels, VideoLabel(system))
+	}
+	sort.Strings(labels)
+	return models.CompatibilityIssue{
+		Code:     models.CompatVideoSystemMismatch,
+		Severity: models.CompatibilityWarning,
+		Message: fmt.Sprintf("%s is %s video, but your other video gear is %s",
+			added.Name, VideoLabel(profile.VideoSystem), strings.Join(labels, "/")),
+		ItemIDs: append([]string{added.ID}, ids...),
+	}, true
+}
@@ -0,0 +1,48 @@
+package compat
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+func TestCheckEcosystem(t *testing.T) {
+	elrsRadio := part("radio", models.GearTypeRadio, "RadioMaster Boxer ELRS", "")
+	elrsRX := part("rx", models.GearTypeReceiver, "RadioMaster RP2 ExpressLRS", "")
+	analogVTX := part("vtx", models.GearTypeVTX, "TBS Unify Pro32", "")
+
+	tests := []struct {
+		name     string
+		added    Part
+		existing []Part
+		want     []string
+		ids      []string
+	}{
+		{"tbs receiver with elrs radios", part("new", models.GearTypeReceiver, "TBS Crossfire Nano RX", ""), []Part{elrsRadio}, []string{models.CompatRCProtocolMismatch}, []string{"new", "radio"}},
+		{"matching receiver", part("new", models.GearTypeReceiver, "BetaFPV ELRS Lite", ""), []Part{elrsRadio}, []string{}, nil},
+		{"radio without matching receivers", part("new", models.GearTypeRadio, "TBS Tango 2 Crossfire", ""), []Part{elrsRX}, []string{models.CompatRCProtocolMismatch}, []string{"new", "rx"}},
+		{"no radios yet", part("new", models.GearTypeReceiver, "TBS Crossfire Nano RX", ""), []Part{analogVTX}, []string{}, nil},
+		{"digital vtx with analog gear", part("new", models.GearTypeHDUnit, "DJI O3 Air Unit", ""), []Part{analogVTX, elrsRadio}, []string{models.CompatVideoSystemMismatch}, []string{"new", "vtx"}},
+		{"one matching video part is enough", part("new", models.GearTypeCamera, "Foxeer Razer Mini", ""), []Part{part("o3", models.GearTypeHDUnit, "DJI O3", ""), analogVTX}, []string{}, nil},
+		{"unknown protocol", part("new", models.GearTypeReceiver, "Generic Receiver", ""), []Part{elrsRadio}, []string{}, nil},
+		{"re-adding an owned part", elrsRadio, []Part{elrsRadio, part("tbs", models.GearTypeReceiver, "TBS Nano RX", `{"protocol": "crsf"}`)}, []string{models.CompatRCProtocolMismatch}, []string{"radio", "tbs"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := CheckEcosystem(tt.added, tt.existing)
+			if got := codes(issues); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("CheckEcosystem() = %v, want %v", got, tt.want)
+			}
+			for _, issue := range issues {
+				if issue.Severity != models.CompatibilityWarning {
+					t.Errorf("severity = %q, want warning", issue.Severity)
+				}
+				if !reflect.DeepEqual(issue.ItemIDs, tt.ids) {
+					t.Errorf("ItemIDs = %v, want %v", issue.ItemIDs, tt.ids)
+				}
+			}
+		})
+	}
+}
@@ -17,6 +17,20 @@ type Config struct {
 	Auth       AuthConfig
 	Crypto     CryptoConfig
 	Moderation ModerationConfig
+	Push       PushConfig
+	Images     ImageConfig
+	SEO        SEOConfig
+	Battery    BatteryConfig
+	Builds     BuildConfig
+	Secrets    SecretsConfig
+	Tenancy    TenancyConfig
+	Storage    StorageConfig
+	Feeds      FeedScheduleConfig
+	Events     EventsConfig
+	Search     SearchConfig
+	Firmware   FirmwareConfig
+	Metrics    MetricsConfig
+	GRPC       GRPCConfig
 }
 
 // ServerConfig holds HTTP/MCP server configuration
@@ -24,7 +38,13 @@ type ServerConfig struct {
 	HTTPAddr            string
 	MCPMode             bool
 	RefreshOnceMode     bool
+	RekeyCatalogMode    bool
+	RekeyDryRun         bool
+	RotateJWTKeyMode    bool
 	EnableManualRefresh bool
+	ServeWeb            bool   // serve the web frontend embedded in the binary
+	PublicBaseURL       string // public origin for generated absolute URLs; empty derives it from requests
+	TrustProxyHeaders   bool   // apply X-Forwarded-Proto and X-Forwarded-Host from a reverse proxy
 	RateLimitDur        time.Duration
 	FeedRetentionDays   int
 }
@@ -34,6 +54,9 @@ type CacheConfig struct {
 	Backend   string // "memory" or "redis"
 	TTL       time.Duration
 	RedisAddr string
+	// RedisEncoding sets the codec and compression of Redis values per key
+	// namespace, e.g. "all_items=msgpack+zstd,*=json"; empty stores JSON
+	RedisEncoding string
 }
 
 // DatabaseConfig holds PostgreSQL configuration
@@ -73,13 +96,177 @@ type CryptoConfig struct {
 	EncryptionKey []byte
 }
 
-// ModerationConfig holds image moderation settings.
+// ModerationConfig holds image moderation settings and moderation queue
+// SLAs. A zero SLAEscalationInterval disables overdue alerts to moderators.
 type ModerationConfig struct {
-	Enabled          bool
-	AWSRegion        string
-	RejectConfidence float64
-	Timeout          time.Duration
-	PendingUploadTTL time.Duration
+	Enabled               bool
+	AWSRegion             string
+	RejectConfidence      float64
+	Timeout               time.Duration
+	PendingUploadTTL      time.Duration
+	RescanRate            float64 // moderation calls per second during admin re-scans
+	GearSLA               time.Duration
+	BuildSLA              time.Duration
+	SLAEscalationInterval time.Duration
+}
+
+// ImageConfig holds image upload processing settings and default per-user
+// storage quotas. Zero quotas mean unlimited; admins can override both limits
+// for individual users. WebP/AVIF serving is enabled per format by setting its
+// encoder command. Background removal for admin uploads uses
+// BackgroundCommand, or the API at BackgroundAPIURL when no command is set.
+type ImageConfig struct {
+	MaxDimension      int
+	JPEGQuality       int
+	QuotaMaxCount     int
+	QuotaMaxBytes     int64
+	WebPCommand       string
+	AVIFCommand       string
+	VariantCacheTTL   time.Duration
+	BackgroundCommand string
+	BackgroundAPIURL  string
+	BackgroundAPIKey  string
+	// ArchiveAfter moves images idle this long to cold storage; zero disables
+	ArchiveAfter    time.Duration
+	ArchiveInterval time.Duration
+}
+
+// SEOConfig holds sitemap and structured data settings. SiteURL is the public
+// web origin used for absolute links.
+type SEOConfig struct {
+	SiteURL         string
+	SitemapInterval time.Duration
+}
+
+// BatteryConfig holds battery care settings
+type BatteryConfig struct {
+	StorageReminderDays int
+}
+
+// BuildConfig holds build settings. MaxActiveDrafts caps how many drafts a
+// user can have before archiving one; zero means no limit.
+//
+// The Temp settings guard anonymous temp build creation: at most TempIPLimit
+// per client IP every TempIPWindow, and at most MaxOutstandingTemp unexpired
+// temp builds in total (zero for no cap). A captcha response is required
+// when CaptchaSecret is set.
+type BuildConfig struct {
+	MaxActiveDrafts    int
+	TempIPLimit        int
+	TempIPWindow       time.Duration
+	MaxOutstandingTemp int
+	CaptchaSecret      string
+	CaptchaVerifyURL   string
+}
+
+// SecretsConfig holds where secret references are resolved from. Database
+// credentials, the JWT secret, the Google client secret and AWS keys can be
+// set to a reference (file:, vault: or awssm:) instead of the plain value.
+// RefreshInterval is how often the JWT secret reference is re-read; zero
+// disables hot reload.
+type SecretsConfig struct {
+	VaultAddr       string
+	VaultToken      string
+	VaultNamespace  string
+	AWSRegion       string
+	RefreshInterval time.Duration
+}
+
+// TenancyConfig holds white-label multi-tenancy settings. When disabled,
+// every request belongs to the default tenant. RefreshInterval is how often
+// the hostname-to-tenant mapping is reloaded from the database.
+type TenancyConfig struct {
+	Enabled         bool
+	RefreshInterval time.Duration
+}
+
+// FeedScheduleConfig holds per-source feed scheduling. When Enabled, the
+// HTTP server fetches each source on its own interval between MinInterval
+// and MaxInterval, adapted to how often it posts. Off by default, since
+// deployments that refresh with -refresh-once don't need it.
+type FeedScheduleConfig struct {
+	Enabled     bool
+	MinInterval time.Duration
+	MaxInterval time.Duration
+}
+
+// StorageConfig holds where large uploads such as radio backups are kept.
+// Backend is "local" (files under LocalDir) or "s3". S3Endpoint and
+// S3PathStyle are for S3-compatible services. UploadExpiry is how long a
+// resumable upload can sit idle before it's removed.
+type StorageConfig struct {
+	Backend      string
+	LocalDir     string
+	S3Bucket     string
+	S3Region     string
+	S3Endpoint   string
+	S3Prefix     string
+	S3PathStyle  bool
+	UploadExpiry time.Duration
+}
+
+// EventsConfig holds where domain events (catalog.published,
+// build.published, user.registered, image.rejected) are published.
+// Broker is "nats", "kafka" (through a Kafka REST proxy at URL), or empty
+// to keep events in the outbox unpublished. Subjects and topics are Prefix
+// followed by the event type. RetainPublished is how long published events
+// stay in the outbox before they're purged.
+type EventsConfig struct {
+	Broker          string
+	URL             string
+	Prefix          string
+	PollInterval    time.Duration
+	RetainPublished time.Duration
+}
+
+// SearchConfig holds the optional external search engine. Backend is
+// "meilisearch", "typesense", or empty to search with Postgres only.
+// Index names are IndexPrefix followed by "catalog" or "builds".
+// ReindexInterval is how often the index is rewritten from Postgres.
+type SearchConfig struct {
+	Backend         string
+	URL             string
+	APIKey          string
+	IndexPrefix     string
+	ReindexInterval time.Duration
+}
+
+// FirmwareConfig holds the Betaflight target registry sync. TargetsURL is
+// the build API's target list; "off" turns the sync off. SyncInterval is
+// how often it's downloaded.
+type FirmwareConfig struct {
+	TargetsURL   string
+	SyncInterval time.Duration
+}
+
+// MetricsConfig holds the Prometheus endpoint and connection pool alerts.
+// GET /metrics is only served when Token is set, and scrapers send it as a
+// bearer token. Pools are checked every PoolCheckInterval, and a warning is
+// logged when new waits for a connection averaged PoolWaitAlert or longer;
+// zero turns the alert off.
+type MetricsConfig struct {
+	Token             string
+	PoolCheckInterval time.Duration
+	PoolWaitAlert     time.Duration
+}
+
+// GRPCConfig holds the internal gRPC API. It is served on Addr only when
+// Enabled, and callers send Token as a bearer token.
+type GRPCConfig struct {
+	Enabled bool
+	Addr    string
+	Token   string
+}
+
+// PushConfig holds mobile push notification credentials. A platform is only
+// enabled when its credentials are set.
+type PushConfig struct {
+	FCMCredentialsFile string
+	APNsKeyFile        string
+	APNsKeyID          string
+	APNsTeamID         string
+	APNsTopic          string
+	APNsSandbox        bool
 }
 
 // Load parses flags and environment variables to build configuration
@@ -90,6 +277,11 @@ func Load() *Config {
 	httpAddr := flag.String("http", ":8080", "HTTP server address")
 	mcpMode := flag.Bool("mcp", false, "Run in MCP stdio mode")
 	refreshOnceMode := flag.Bool("refresh-once", false, "Run a single feed refresh and exit")
+	rekeyCatalogMode := flag.Bool("rekey-catalog", false, "Recompute gear catalog canonical keys, report collisions, and exit")
+	rekeyDryRun := flag.Bool("rekey-dry-run", false, "With -rekey-catalog, report planned key changes without writing them")
+	rotateJWTKeyMode := flag.Bool("rotate-jwt-key", false, "Rotate the JWT signing key stored in the database and exit")
+	serveWeb := flag.Bool("serve-web", false, "Serve the web frontend embedded in the binary")
+	serveGRPC := flag.Bool("grpc", false, "Serve the internal gRPC API alongside HTTP")
 	cacheTTL := flag.Duration("cache-ttl", 5*time.Minute, "Cache TTL for feed items")
 	cacheBackend := flag.String("cache-backend", "memory", "Cache backend: memory or redis")
 	redisAddr := flag.String("redis-addr", "localhost:6379", "Redis server address")
@@ -110,23 +302,40 @@ func Load() *Config {
 	if v := strings.ToLower(strings.TrimSpace(os.Getenv("ENABLE_MANUAL_REFRESH"))); v == "true" || v == "1" {
 		enableManualRefresh = true
 	}
+	if v := strings.ToLower(strings.TrimSpace(os.Getenv("SERVE_WEB"))); v == "true" || v == "1" {
+		*serveWeb = true
+	}
+	if v := strings.ToLower(strings.TrimSpace(os.Getenv("GRPC_ENABLED"))); v == "true" || v == "1" {
+		*serveGRPC = true
+	}
+	trustProxyHeaders := false
+	if v := strings.ToLower(strings.TrimSpace(os.Getenv("TRUST_PROXY_HEADERS"))); v == "true" || v == "1" {
+		trustProxyHeaders = true
+	}
 
-	applyEnvOverrides(httpAddr, mcpMode, refreshOnceMode, cacheTTL, cacheBackend, redisAddr, rateLimitDur, feedRetentionDays, logLevel, dbHost, dbPort, dbUser, dbPassword, dbName, dbSSLMode)
+	applyEnvOverrides(httpAddr, mcpMode, refreshOnceMode, rekeyCatalogMode, cacheTTL, cacheBackend, redisAddr, rateLimitDur, feedRetentionDays, logLevel, dbHost, dbPort, dbUser, dbPassword, dbName, dbSSLMode)
 
 	// Build config struct
 	cfg.Server = ServerConfig{
 		HTTPAddr:            *httpAddr,
 		MCPMode:             *mcpMode,
 		RefreshOnceMode:     *refreshOnceMode,
+		RekeyCatalogMode:    *rekeyCatalogMode,
+		RekeyDryRun:         *rekeyDryRun,
+		RotateJWTKeyMode:    *rotateJWTKeyMode,
 		EnableManualRefresh: enableManualRefresh,
+		ServeWeb:            *serveWeb,
+		PublicBaseURL:       strings.TrimRight(strings.TrimSpace(os.Getenv("PUBLIC_BASE_URL")), "/"),
+		TrustProxyHeaders:   trustProxyHeaders,
 		RateLimitDur:        *rateLimitDur,
 		FeedRetentionDays:   *feedRetentionDays,
 	}
 
 	cfg.Cache = CacheConfig{
-		Backend:   *cacheBackend,
-		TTL:       *cacheTTL,
-		RedisAddr: *redisAddr,
+		Backend:       *cacheBackend,
+		TTL:           *cacheTTL,
+		RedisAddr:     *redisAddr,
+		RedisEncoding: strings.TrimSpace(os.Getenv("CACHE_REDIS_ENCODING")),
 	}
 
 	cfg.Database = DatabaseConfig{
@@ -151,6 +360,51 @@ func Load() *Config {
 	// Load moderation config from environment
 	cfg.Moderation = loadModerationConfig()
 
+	// Load push notification config from environment
+	cfg.Push = loadPushConfig()
+
+	// Load image quota config from environment
+	cfg.Images = loadImageConfig()
+
+	// Load sitemap/structured data config from environment
+	cfg.SEO = loadSEOConfig()
+
+	// Load battery storage reminder config from environment
+	cfg.Battery = loadBatteryConfig()
+
+	// Load build draft limit and temp build protection from environment
+	cfg.Builds = loadBuildConfig()
+
+	// Load secret store config from environment
+	cfg.Secrets = loadSecretsConfig()
+
+	// Load multi-tenancy config from environment
+	cfg.Tenancy = loadTenancyConfig()
+
+	// Load upload storage config from environment
+	cfg.Storage = loadStorageConfig()
+
+	// Load feed scheduling config from environment
+	cfg.Feeds = loadFeedScheduleConfig()
+
+	// Load domain event broker config from environment
+	cfg.Events = loadEventsConfig()
+
+	// Load search index config from environment
+	cfg.Search = loadSearchConfig()
+
+	// Load firmware target sync config from environment
+	cfg.Firmware = loadFirmwareConfig()
+
+	// Load metrics endpoint and pool alert config from environment
+	cfg.Metrics = loadMetricsConfig()
+
+	cfg.GRPC = GRPCConfig{
+		Enabled: *serveGRPC,
+		Addr:    getEnvOrDefault("GRPC_ADDR", ":9090"),
+		Token:   strings.TrimSpace(os.Getenv("GRPC_TOKEN")),
+	}
+
 	return cfg
 }
 
@@ -218,17 +472,385 @@ func loadModerationConfig() ModerationConfig {
 		}
 	}
 
+	rescanRate := 5.0
+	if v := os.Getenv("MODERATION_RESCAN_RATE"); v != "" {
+		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed > 0 {
+			rescanRate = parsed
+		}
+	}
+
+	gearSLA := 48 * time.Hour
+	if v := os.Getenv("MODERATION_SLA_GEAR"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			gearSLA = parsed
+		}
+	}
+
+	buildSLA := 72 * time.Hour
+	if v := os.Getenv("MODERATION_SLA_BUILD"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			buildSLA = parsed
+		}
+	}
+
+	var escalationInterval time.Duration
+	if v := os.Getenv("MODERATION_SLA_ESCALATION_INTERVAL"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			escalationInterval = parsed
+		}
+	}
+
 	enabled := true
 	if v := strings.ToLower(strings.TrimSpace(os.Getenv("IMAGE_MODERATION_ENABLED"))); v == "false" || v == "0" {
 		enabled = false
 	}
 
 	return ModerationConfig{
-		Enabled:          enabled,
-		AWSRegion:        os.Getenv("AWS_REGION"),
-		RejectConfidence: rejectConfidence,
-		Timeout:          timeout,
-		PendingUploadTTL: pendingTTL,
+		Enabled:               enabled,
+		AWSRegion:             os.Getenv("AWS_REGION"),
+		RejectConfidence:      rejectConfidence,
+		Timeout:               timeout,
+		PendingUploadTTL:      pendingTTL,
+		RescanRate:            rescanRate,
+		GearSLA:               gearSLA,
+		BuildSLA:              buildSLA,
+		SLAEscalationInterval: escalationInterval,
+	}
+}
+
+func loadImageConfig() ImageConfig {
+	maxDimension := 2048
+	if v := os.Getenv("IMAGE_MAX_DIMENSION"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			maxDimension = parsed
+		}
+	}
+
+	jpegQuality := 85
+	if v := os.Getenv("IMAGE_JPEG_QUALITY"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 && parsed <= 100 {
+			jpegQuality = parsed
+		}
+	}
+
+	maxCount := 200
+	if v := os.Getenv("IMAGE_QUOTA_MAX_COUNT"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
+			maxCount = parsed
+		}
+	}
+
+	maxBytes := int64(200 * 1024 * 1024)
+	if v := os.Getenv("IMAGE_QUOTA_MAX_BYTES"); v != "" {
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil && parsed >= 0 {
+			maxBytes = parsed
+		}
+	}
+
+	variantCacheTTL := time.Hour
+	if v := os.Getenv("IMAGE_VARIANT_CACHE_TTL"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			variantCacheTTL = parsed
+		}
+	}
+
+	archiveAfter := time.Duration(0)
+	if v := os.Getenv("IMAGE_ARCHIVE_AFTER_DAYS"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
+			archiveAfter = time.Duration(parsed) * 24 * time.Hour
+		}
+	}
+
+	archiveInterval := 6 * time.Hour
+	if v := os.Getenv("IMAGE_ARCHIVE_INTERVAL"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			archiveInterval = parsed
+		}
+	}
+
+	return ImageConfig{
+		MaxDimension:      maxDimension,
+		JPEGQuality:       jpegQuality,
+		QuotaMaxCount:     maxCount,
+		QuotaMaxBytes:     maxBytes,
+		WebPCommand:       strings.TrimSpace(os.Getenv("IMAGE_WEBP_COMMAND")),
+		AVIFCommand:       strings.TrimSpace(os.Getenv("IMAGE_AVIF_COMMAND")),
+		VariantCacheTTL:   variantCacheTTL,
+		BackgroundCommand: strings.TrimSpace(os.Getenv("IMAGE_BACKGROUND_COMMAND")),
+		BackgroundAPIURL:  strings.TrimSpace(os.Getenv("IMAGE_BACKGROUND_API_URL")),
+		BackgroundAPIKey:  strings.TrimSpace(os.Getenv("IMAGE_BACKGROUND_API_KEY")),
+		ArchiveAfter:      archiveAfter,
+		ArchiveInterval:   archiveInterval,
+	}
+}
+
+func loadSEOConfig() SEOConfig {
+	siteURL := os.Getenv("SITE_URL")
+	if siteURL == "" {
+		siteURL = os.Getenv("PUBLIC_BASE_URL")
+	}
+	if siteURL == "" {
+		siteURL = getEnvOrDefault("AUTH_FRONTEND_URL", "http://localhost:3000")
+	}
+
+	interval := 6 * time.Hour
+	if v := os.Getenv("SITEMAP_REFRESH_INTERVAL"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			interval = parsed
+		}
+	}
+
+	return SEOConfig{
+		SiteURL:         strings.TrimRight(strings.TrimSpace(siteURL), "/"),
+		SitemapInterval: interval,
+	}
+}
+
+func loadBatteryConfig() BatteryConfig {
+	storageReminderDays := 2
+	if v := os.Getenv("BATTERY_STORAGE_REMINDER_DAYS"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			storageReminderDays = parsed
+		}
+	}
+
+	return BatteryConfig{
+		StorageReminderDays: storageReminderDays,
+	}
+}
+
+func loadBuildConfig() BuildConfig {
+	maxActiveDrafts := 50
+	if v := os.Getenv("BUILD_MAX_ACTIVE_DRAFTS"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
+			maxActiveDrafts = parsed
+		}
+	}
+
+	tempIPLimit := 20
+	if v := os.Getenv("TEMP_BUILD_IP_LIMIT"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			tempIPLimit = parsed
+		}
+	}
+	tempIPWindow := time.Hour
+	if v := os.Getenv("TEMP_BUILD_IP_WINDOW"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			tempIPWindow = parsed
+		}
+	}
+	maxOutstandingTemp := 50000
+	if v := os.Getenv("TEMP_BUILD_MAX_OUTSTANDING"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
+			maxOutstandingTemp = parsed
+		}
+	}
+
+	return BuildConfig{
+		MaxActiveDrafts:    maxActiveDrafts,
+		TempIPLimit:        tempIPLimit,
+		TempIPWindow:       tempIPWindow,
+		MaxOutstandingTemp: maxOutstandingTemp,
+		CaptchaSecret:      strings.TrimSpace(os.Getenv("TEMP_BUILD_CAPTCHA_SECRET")),
+		CaptchaVerifyURL:   strings.TrimSpace(os.Getenv("TEMP_BUILD_CAPTCHA_VERIFY_URL")),
+	}
+}
+
+func loadSecretsConfig() SecretsConfig {
+	refreshInterval := 5 * time.Minute
+	if v := os.Getenv("SECRETS_REFRESH_INTERVAL"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed >= 0 {
+			refreshInterval = parsed
+		}
+	}
+
+	region := os.Getenv("SECRETS_AWS_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+
+	return SecretsConfig{
+		VaultAddr:       strings.TrimRight(strings.TrimSpace(os.Getenv("VAULT_ADDR")), "/"),
+		VaultToken:      os.Getenv("VAULT_TOKEN"),
+		VaultNamespace:  os.Getenv("VAULT_NAMESPACE"),
+		AWSRegion:       strings.TrimSpace(region),
+		RefreshInterval: refreshInterval,
+	}
+}
+
+func loadTenancyConfig() TenancyConfig {
+	enabled := false
+	if v := strings.ToLower(strings.TrimSpace(os.Getenv("MULTI_TENANT"))); v == "true" || v == "1" {
+		enabled = true
+	}
+
+	refreshInterval := time.Minute
+	if v := os.Getenv("TENANT_REFRESH_INTERVAL"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			refreshInterval = parsed
+		}
+	}
+
+	return TenancyConfig{
+		Enabled:         enabled,
+		RefreshInterval: refreshInterval,
+	}
+}
+
+func loadFeedScheduleConfig() FeedScheduleConfig {
+	enabled := false
+	if v := strings.ToLower(strings.TrimSpace(os.Getenv("FEED_SCHEDULE_ENABLED"))); v == "true" || v == "1" {
+		enabled = true
+	}
+
+	minInterval := 15 * time.Minute
+	if v := os.Getenv("FEED_MIN_INTERVAL"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			minInterval = parsed
+		}
+	}
+
+	maxInterval := 24 * time.Hour
+	if v := os.Getenv("FEED_MAX_INTERVAL"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			maxInterval = parsed
+		}
+	}
+	if maxInterval < minInterval {
+		maxInterval = minInterval
+	}
+
+	return FeedScheduleConfig{
+		Enabled:     enabled,
+		MinInterval: minInterval,
+		MaxInterval: maxInterval,
+	}
+}
+
+func loadStorageConfig() StorageConfig {
+	region := os.Getenv("STORAGE_S3_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	pathStyle := false
+	if v := strings.ToLower(strings.TrimSpace(os.Getenv("STORAGE_S3_PATH_STYLE"))); v == "true" || v == "1" {
+		pathStyle = true
+	}
+
+	uploadExpiry := 24 * time.Hour
+	if v := os.Getenv("UPLOAD_EXPIRY"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			uploadExpiry = parsed
+		}
+	}
+
+	return StorageConfig{
+		Backend:      strings.ToLower(getEnvOrDefault("STORAGE_BACKEND", "local")),
+		LocalDir:     getEnvOrDefault("STORAGE_LOCAL_DIR", "./data"),
+		S3Bucket:     strings.TrimSpace(os.Getenv("STORAGE_S3_BUCKET")),
+		S3Region:     strings.TrimSpace(region),
+		S3Endpoint:   strings.TrimRight(strings.TrimSpace(os.Getenv("STORAGE_S3_ENDPOINT")), "/"),
+		S3Prefix:     os.Getenv("STORAGE_S3_PREFIX"),
+		S3PathStyle:  pathStyle,
+		UploadExpiry: uploadExpiry,
+	}
+}
+
+func loadPushConfig() PushConfig {
+	sandbox := false
+	if v := strings.ToLower(strings.TrimSpace(os.Getenv("APNS_SANDBOX"))); v == "true" || v == "1" {
+		sandbox = true
+	}
+
+	return PushConfig{
+		FCMCredentialsFile: os.Getenv("FCM_CREDENTIALS_FILE"),
+		APNsKeyFile:        os.Getenv("APNS_KEY_FILE"),
+		APNsKeyID:          os.Getenv("APNS_KEY_ID"),
+		APNsTeamID:         os.Getenv("APNS_TEAM_ID"),
+		APNsTopic:          os.Getenv("APNS_TOPIC"),
+		APNsSandbox:        sandbox,
+	}
+}
+
+func loadEventsConfig() EventsConfig {
+	pollInterval := 5 * time.Second
+	if v := os.Getenv("EVENTS_POLL_INTERVAL"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			pollInterval = parsed
+		}
+	}
+
+	retainPublished := 7 * 24 * time.Hour
+	if v := os.Getenv("EVENTS_RETAIN_PUBLISHED"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			retainPublished = parsed
+		}
+	}
+
+	return EventsConfig{
+		Broker:          strings.ToLower(strings.TrimSpace(os.Getenv("EVENTS_BROKER"))),
+		URL:             strings.TrimSpace(os.Getenv("EVENTS_BROKER_URL")),
+		Prefix:          getEnvOrDefault("EVENTS_TOPIC_PREFIX", "flyingforge."),
+		PollInterval:    pollInterval,
+		RetainPublished: retainPublished,
+	}
+}
+
+func loadSearchConfig() SearchConfig {
+	reindexInterval := 24 * time.Hour
+	if v := os.Getenv("SEARCH_REINDEX_INTERVAL"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			reindexInterval = parsed
+		}
+	}
+
+	return SearchConfig{
+		Backend:         strings.ToLower(strings.TrimSpace(os.Getenv("SEARCH_BACKEND"))),
+		URL:             strings.TrimSpace(os.Getenv("SEARCH_URL")),
+		APIKey:          os.Getenv("SEARCH_API_KEY"),
+		IndexPrefix:     getEnvOrDefault("SEARCH_INDEX_PREFIX", "flyingforge_"),
+		ReindexInterval: reindexInterval,
+	}
+}
+
+func loadFirmwareConfig() FirmwareConfig {
+	syncInterval := 24 * time.Hour
+	if v := os.Getenv("FIRMWARE_TARGETS_SYNC_INTERVAL"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			syncInterval = parsed
+		}
+	}
+
+	targetsURL := strings.TrimSpace(getEnvOrDefault("FIRMWARE_TARGETS_URL", "https://build.betaflight.com/api/targets"))
+	if strings.EqualFold(targetsURL, "off") {
+		targetsURL = ""
+	}
+
+	return FirmwareConfig{
+		TargetsURL:   targetsURL,
+		SyncInterval: syncInterval,
+	}
+}
+
+func loadMetricsConfig() MetricsConfig {
+	checkInterval := time.Minute
+	if v := os.Getenv("DB_POOL_CHECK_INTERVAL"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			checkInterval = parsed
+		}
+	}
+
+	waitAlert := 250 * time.Millisecond
+	if v := os.Getenv("DB_POOL_WAIT_ALERT"); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed >= 0 {
+			waitAlert = parsed
+		}
+	}
+
+	return MetricsConfig{
+		Token:             strings.TrimSpace(os.Getenv("METRICS_TOKEN")),
+		PoolCheckInterval: checkInterval,
+		PoolWaitAlert:     waitAlert,
 	}
 }
 
@@ -243,6 +865,7 @@ func applyEnvOverrides(
 	httpAddr *string,
 	mcpMode *bool,
 	refreshOnceMode *bool,
+	rekeyCatalogMode *bool,
 	cacheTTL *time.Duration,
 	cacheBackend *string,
 	redisAddr *string,
@@ -265,6 +888,9 @@ func applyEnvOverrides(
 	if v := os.Getenv("REFRESH_ONCE_MODE"); v == "true" || v == "1" {
 		*refreshOnceMode = true
 	}
+	if v := os.Getenv("REKEY_CATALOG_MODE"); v == "true" || v == "1" {
+		*rekeyCatalogMode = true
+	}
 	if v := os.Getenv("CACHE_TTL"); v != "" {
 		if d, err := time.ParseDuration(v); err == nil {
 			*cacheTTL = d
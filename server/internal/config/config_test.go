@@ -70,3 +70,14 @@ func TestLoad_RefreshOnceMode_FromFlag(t *testing.T) {
 		t.Fatalf("expected RefreshOnceMode=true when -refresh-once is provided")
 	}
 }
+
+func TestLoad_RekeyCatalogMode_FromFlag(t *testing.T) {
+	t.Setenv("REKEY_CATALOG_MODE", "")
+	cfg := loadWithArgs(t, "test", "-rekey-catalog", "-rekey-dry-run")
+	if !cfg.Server.RekeyCatalogMode {
+		t.Fatalf("expected RekeyCatalogMode=true when -rekey-catalog is provided")
+	}
+	if !cfg.Server.RekeyDryRun {
+		t.Fatalf("expected RekeyDryRun=true when -rekey-dry-run is provided")
+	}
+}
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// adminSearchQueries find one type's matches for the admin search. Each
+// selects id, title, subtitle, status, owner and created_at. $1 is a
+// lower-cased LIKE pattern, $2 the query when it's a UUID (NULL otherwise)
+// and $3 the limit. A pasted UUID matches the row itself and, for builds,
+// orders and catalog submissions, the user they belong to.
+var adminSearchQueries = map[models.AdminSearchType]string{
+	models.AdminSearchUser: `
+		SELECT id::text, COALESCE(NULLIF(call_sign, ''), display_name), email, status, '', created_at
+		FROM users
+		WHERE id = $2
+		   OR LOWER(email) LIKE $1
+		   OR LOWER(COALESCE(call_sign, '')) LIKE $1
+		   OR LOWER(display_name) LIKE $1
+		ORDER BY id = $2 DESC NULLS LAST, created_at DESC
+		LIMIT $3`,
+	models.AdminSearchCatalogItem: `
+		SELECT id::text, TRIM(brand || ' ' || model || ' ' || COALESCE(variant, '')), gear_type, status,
+		       COALESCE(created_by_user_id::text, ''), created_at
+		FROM gear_catalog
+		WHERE id = $2
+		   OR created_by_user_id = $2
+		   OR LOWER(brand || ' ' || model || ' ' || COALESCE(variant, '')) LIKE $1
+		ORDER BY id = $2 DESC NULLS LAST, created_at DESC
+		LIMIT $3`,
+	// Temp builds are anonymous and gone within a day, so they're left out
+	models.AdminSearchBuild: `
+		SELECT b.id::text, COALESCE(b.title, ''), COALESCE(NULLIF(u.call_sign, ''), u.display_name, ''), b.status,
+		       COALESCE(b.owner_user_id::text, ''), b.created_at
+		FROM builds b
+		LEFT JOIN users u ON u.id = b.owner_user_id
+		WHERE b.status <> 'TEMP'
+		  AND (b.id = $2
+		       OR b.owner_user_id = $2
+		       OR LOWER(COALESCE(b.title, '')) LIKE $1
+		       OR LOWER(COALESCE(u.call_sign, '')) LIKE $1)
+		ORDER BY b.id = $2 DESC NULLS LAST, b.created_at DESC
+		LIMIT $3`,
+	models.AdminSearchOrder: `
+		SELECT id::text, COALESCE(NULLIF(label, ''), tracking_number), carrier || ' ' || tracking_number, status,
+		       COALESCE(user_id::text, ''), created_at
+		FROM orders
+		WHERE id = $2
+		   OR user_id = $2
+		   OR LOWER(tracking_number) LIKE $1
+		   OR LOWER(COALESCE(label, '')) LIKE $1
+		ORDER BY id = $2 DESC NULLS LAST, created_at DESC
+		LIMIT $3`,
+}
+
+// AdminSearchStore searches users, catalog items, builds and orders at once
+// for admins triaging a report
+type AdminSearchStore struct {
+	db *DB
+}
+
+// NewAdminSearchStore creates a new admin search store
+func NewAdminSearchStore(db *DB) *AdminSearchStore {
+	return &AdminSearchStore{db: db}
+}
+
+// Search returns up to limit matches of each of types for query, in the
+// order of models.AdminSearchTypes
+func (s *AdminSearchStore) Search(ctx context.Context, query string, types []models.AdminSearchType, limit int) (*models.AdminSearchResponse, error) {
+	query = strings.TrimSpace(query)
+	pattern := "%" + strings.ToLower(query) + "%"
+	var id interface{}
+	if parsed, err := uuid.Parse(query); err == nil {
+		id = parsed.String()
+	}
+
+	wanted := make(map[models.AdminSearchType]bool, len(types))
+	for _, t := range types {
+		wanted[t] = true
+	}
+
+	response := &models.AdminSearchResponse{Query: query, Limit: limit, Results: []models.AdminSearchResult{}}
+	for _, t := range models.AdminSearchTypes {
+		if len(wanted) > 0 && !wanted[t] {
+			continue
+		}
+		results, err := s.searchType(ctx, t, pattern, id, limit)
+		if err != nil {
+			return nil, err
+		}
+		response.Results = append(response.Results, results...)
+	}
+	return response, nil
+}
+
+func (s *AdminSearchStore) searchType(ctx context.Context, t models.AdminSearchType, pattern string, id interface{}, limit int) ([]models.AdminSearchResult, error) {
+	rows, err := s.db.QueryContext(ctx, adminSearchQueries[t], pattern, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %ss: %w", t, err)
+	}
+	defer rows.Close()
+
+	var results []models.AdminSearchResult
+	for rows.Next() {
+		result := models.AdminSearchResult{Type: t}
+		var createdAt sql.NullTime
+		if err := rows.Scan(&result.ID, &result.Title, &result.Subtitle, &result.Status, &result.OwnerID, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan %s search result: %w", t, err)
+		}
+		if createdAt.Valid {
+			result.CreatedAt = createdAt.Time
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search %ss: %w", t, err)
+	}
+	return results, nil
+}
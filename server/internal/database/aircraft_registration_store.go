@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// GetRegistration retrieves an aircraft's registration details. Returns nil
+// if none have been set.
+func (s *AircraftStore) GetRegistration(ctx context.Context, aircraftID string) (*models.AircraftRegistration, error) {
+	query := `
+		SELECT aircraft_id, registration_number, authority, registration_expires_on,
+		       insurance_provider, insurance_policy_number, insurance_expires_on,
+		       remote_id_item_id, reminders_enabled, updated_at
+		FROM aircraft_registrations
+		WHERE aircraft_id = $1
+	`
+
+	registration, err := scanAircraftRegistration(s.db.QueryRowContext(ctx, query, aircraftID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aircraft registration: %w", err)
+	}
+	return registration, nil
+}
+
+// SetRegistration creates or replaces an aircraft's registration details
+func (s *AircraftStore) SetRegistration(ctx context.Context, params models.SetAircraftRegistrationParams) (*models.AircraftRegistration, error) {
+	query := `
+		INSERT INTO aircraft_registrations (
+			aircraft_id, registration_number, authority, registration_expires_on,
+			insurance_provider, insurance_policy_number, insurance_expires_on,
+			remote_id_item_id, reminders_enabled
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (aircraft_id) DO UPDATE SET
+			registration_number = EXCLUDED.registration_number,
+			authority = EXCLUDED.authority,
+			registration_expires_on = EXCLUDED.registration_expires_on,
+			insurance_provider = EXCLUDED.insurance_provider,
+			insurance_policy_number = EXCLUDED.insurance_policy_number,
+			insurance_expires_on = EXCLUDED.insurance_expires_on,
+			remote_id_item_id = EXCLUDED.remote_id_item_id,
+			reminders_enabled = EXCLUDED.reminders_enabled,
+			updated_at = NOW()
+		RETURNING aircraft_id, registration_number, authority, registration_expires_on,
+		          insurance_provider, insurance_policy_number, insurance_expires_on,
+		          remote_id_item_id, reminders_enabled, updated_at
+	`
+
+	registration, err := scanAircraftRegistration(s.db.QueryRowContext(ctx, query,
+		params.AircraftID, params.RegistrationNumber, string(params.Authority), nullString(params.RegistrationExpiresOn),
+		params.InsuranceProvider, params.InsurancePolicyNumber, nullString(params.InsuranceExpiresOn),
+		nullString(params.RemoteIDItemID), params.RemindersEnabled,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set aircraft registration: %w", err)
+	}
+	return registration, nil
+}
+
+// ListExpirations returns a user's registration and insurance expiry dates on
+// or before the given date, including ones that have already passed, soonest first
+func (s *AircraftStore) ListExpirations(ctx context.Context, userID string, before time.Time) ([]models.AircraftExpiration, error) {
+	query := `
+		SELECT r.aircraft_id, a.name, a.user_id, 'registration', r.registration_number, r.registration_expires_on
+		FROM aircraft_registrations r
+		JOIN aircraft a ON a.id = r.aircraft_id
+		WHERE a.user_id = $1 AND r.registration_expires_on <= $2
+		UNION ALL
+		SELECT r.aircraft_id, a.name, a.user_id, 'insurance', r.insurance_provider, r.insurance_expires_on
+		FROM aircraft_registrations r
+		JOIN aircraft a ON a.id = r.aircraft_id
+		WHERE a.user_id = $1 AND r.insurance_expires_on <= $2
+		ORDER BY 6, 2
+	`
+
+	return s.queryExpirations(ctx, query, userID, before)
+}
+
+// ListReminderDue returns expirations between from and before on aircraft
+// with reminders enabled that haven't been reminded about yet
+func (s *AircraftStore) ListReminderDue(ctx context.Context, from, before time.Time) ([]models.AircraftExpiration, error) {
+	query := `
+		SELECT r.aircraft_id, a.name, a.user_id, 'registration', r.registration_number, r.registration_expires_on
+		FROM aircraft_registrations r
+		JOIN aircraft a ON a.id = r.aircraft_id
+		WHERE r.reminders_enabled AND a.user_id IS NOT NULL
+		  AND r.registration_expires_on BETWEEN $1 AND $2
+		  AND r.registration_reminded_on IS DISTINCT FROM r.registration_expires_on
+		UNION ALL
+		SELECT r.aircraft_id, a.name, a.user_id, 'insurance', r.insurance_provider, r.insurance_expires_on
+		FROM aircraft_registrations r
+		JOIN aircraft a ON a.id = r.aircraft_id
+		WHERE r.reminders_enabled AND a.user_id IS NOT NULL
+		  AND r.insurance_expires_on BETWEEN $1 AND $2
+		  AND r.insurance_reminded_on IS DISTINCT FROM r.insurance_expires_on
+		ORDER BY 6
+	`
+
+	return s.queryExpirations(ctx, query, from, before)
+}
+
+// MarkReminded records that a reminder was sent for an expiry date, so it
+// isn't sent again unless the date changes
+func (s *AircraftStore) MarkReminded(ctx context.Context, aircraftID string, kind models.ComplianceKind, expiresOn string) error {
+	var column string
+	switch kind {
+	case models.ComplianceRegistration:
+		column = "registration_reminded_on"
+	case models.ComplianceInsurance:
+		column = "insurance_reminded_on"
+	default:
+		return fmt.Errorf("unknown compliance kind %q", kind)
+	}
+
+	query := fmt.Sprintf(`UPDATE aircraft_registrations SET %s = $2 WHERE aircraft_id = $1`, column)
+	if _, err := s.db.ExecContext(ctx, query, aircraftID, expiresOn); err != nil {
+		return fmt.Errorf("failed to mark aircraft reminder: %w", err)
+	}
+	return nil
+}
+
+func (s *AircraftStore) queryExpirations(ctx context.Context, query string, args ...interface{}) ([]models.AircraftExpiration, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list aircraft expirations: %w", err)
+	}
+	defer rows.Close()
+
+	expirations := make([]models.AircraftExpiration, 0)
+	for rows.Next() {
+		var expiration models.AircraftExpiration
+		var kind string
+		var expiresOn time.Time
+		if err := rows.Scan(
+			&expiration.AircraftID, &expiration.AircraftName, &expiration.UserID,
+			&kind, &expiration.Reference, &expiresOn,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan aircraft expiration: %w", err)
+		}
+		expiration.Kind = models.ComplianceKind(kind)
+		expiration.ExpiresOn = expiresOn.Format(models.ComplianceDateLayout)
+		expirations = append(expirations, expiration)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list aircraft expirations: %w", err)
+	}
+	return expirations, nil
+}
+
+func scanAircraftRegistration(row *sql.Row) (*models.AircraftRegistration, error) {
+	registration := &models.AircraftRegistration{}
+	var authority string
+	var registrationExpiresOn, insuranceExpiresOn sql.NullTime
+	var remoteIDItemID sql.NullString
+
+	if err := row.Scan(
+		&registration.AircraftID, &registration.RegistrationNumber, &authority, &registrationExpiresOn,
+		&registration.InsuranceProvider, &registration.InsurancePolicyNumber, &insuranceExpiresOn,
+		&remoteIDItemID, &registration.RemindersEnabled, &registration.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	registration.Authority = models.RegistrationAuthority(authority)
+	if registrationExpiresOn.Valid {
+		registration.RegistrationExpiresOn = registrationExpiresOn.Time.Format(models.ComplianceDateLayout)
+	}
+	if insuranceExpiresOn.Valid {
+		registration.InsuranceExpiresOn = insuranceExpiresOn.Time.Format(models.ComplianceDateLayout)
+	}
+	registration.RemoteIDItemID = remoteIDItemID.String
+	return registration, nil
+}
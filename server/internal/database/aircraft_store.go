@@ -564,10 +564,16 @@ func (s *AircraftStore) GetDetails(ctx context.Context, id string, userID string
 		return nil, err
 	}
 
+	registration, err := s.GetRegistration(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
 	return &models.AircraftDetailsResponse{
 		Aircraft:         *aircraft,
 		Components:       components,
 		ReceiverSettings: receiverSettings,
+		Registration:     registration,
 	}, nil
 }
 
@@ -608,7 +614,7 @@ func (s *AircraftStore) SetImage(ctx context.Context, id string, userID string,
 // GetImage retrieves the image data for an aircraft
 func (s *AircraftStore) GetImage(ctx context.Context, id string, userID string) ([]byte, string, error) {
 	query := `
-		SELECT COALESCE(ia.image_bytes, a.image_data), a.image_type
+		SELECT COALESCE(ia.image_bytes, a.image_data), a.image_type, ia.id, ia.storage_tier
 		FROM aircraft a
 		LEFT JOIN image_assets ia ON ia.id = a.image_asset_id AND ia.status = 'APPROVED'
 		WHERE a.id = $1
@@ -616,15 +622,19 @@ func (s *AircraftStore) GetImage(ctx context.Context, id string, userID string)
 		  AND ((a.image_asset_id IS NOT NULL AND ia.id IS NOT NULL) OR a.image_data IS NOT NULL)
 	`
 	var imageData []byte
-	var imageType sql.NullString
+	var imageType, imageID, tier sql.NullString
 
-	err := s.db.QueryRowContext(ctx, query, id, userID).Scan(&imageData, &imageType)
+	err := s.db.QueryRowContext(ctx, query, id, userID).Scan(&imageData, &imageType, &imageID, &tier)
 	if err == sql.ErrNoRows {
 		return nil, "", nil
 	}
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to get aircraft image: %w", err)
 	}
+	imageData, err = s.db.imageBytes(ctx, imageID, tier, imageData)
+	if err != nil {
+		return nil, "", err
+	}
 
 	return imageData, imageType.String, nil
 }
@@ -633,7 +643,7 @@ func (s *AircraftStore) GetImage(ctx context.Context, id string, userID string)
 // This is used for public pilot profiles - checks owner's social settings
 func (s *AircraftStore) GetPublicImage(ctx context.Context, aircraftID string) ([]byte, string, error) {
 	query := `
-		SELECT COALESCE(ia.image_bytes, a.image_data), a.image_type 
+		SELECT COALESCE(ia.image_bytes, a.image_data), a.image_type, ia.id, ia.storage_tier
 		FROM aircraft a
 		LEFT JOIN image_assets ia ON ia.id = a.image_asset_id AND ia.status = 'APPROVED'
 		JOIN users u ON a.user_id = u.id
@@ -643,15 +653,19 @@ func (s *AircraftStore) GetPublicImage(ctx context.Context, aircraftID string) (
 		  AND u.profile_visibility = 'public'
 	`
 	var imageData []byte
-	var imageType sql.NullString
+	var imageType, imageID, tier sql.NullString
 
-	err := s.db.QueryRowContext(ctx, query, aircraftID).Scan(&imageData, &imageType)
+	err := s.db.QueryRowContext(ctx, query, aircraftID).Scan(&imageData, &imageType, &imageID, &tier)
 	if err == sql.ErrNoRows {
 		return nil, "", nil
 	}
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to get public aircraft image: %w", err)
 	}
+	imageData, err = s.db.imageBytes(ctx, imageID, tier, imageData)
+	if err != nil {
+		return nil, "", err
+	}
 
 	return imageData, imageType.String, nil
 }
@@ -0,0 +1,295 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ErrAnnouncementNotFound is returned when an announcement doesn't exist, or
+// isn't visible to users yet
+var ErrAnnouncementNotFound = errors.New("announcement not found")
+
+// announcementStatusSQL derives an announcement's schedule status from the
+// current time
+const announcementStatusSQL = `
+	CASE
+		WHEN a.published_at > NOW() THEN 'scheduled'
+		WHEN a.expires_at IS NOT NULL AND a.expires_at <= NOW() THEN 'expired'
+		ELSE 'active'
+	END`
+
+// announcementActiveSQL matches announcements users can see
+const announcementActiveSQL = `a.published_at <= NOW() AND (a.expires_at IS NULL OR a.expires_at > NOW())`
+
+const announcementColumns = `a.id, a.kind, a.title, a.body, a.published_at, a.expires_at,
+	` + announcementStatusSQL + `, a.notified_at, COALESCE(a.created_by::text, ''), a.created_at, a.updated_at`
+
+// AnnouncementStore handles announcements and which users have read them
+type AnnouncementStore struct {
+	db *DB
+}
+
+// NewAnnouncementStore creates a new announcement store
+func NewAnnouncementStore(db *DB) *AnnouncementStore {
+	return &AnnouncementStore{db: db}
+}
+
+// Create posts a new announcement
+func (s *AnnouncementStore) Create(ctx context.Context, createdBy string, params models.CreateAnnouncementParams) (*models.Announcement, error) {
+	query := `
+		INSERT INTO announcements AS a (kind, title, body, published_at, expires_at, notify, created_by)
+		VALUES ($1, $2, $3, COALESCE($4, NOW()), $5, $6, $7)
+		RETURNING ` + announcementColumns
+
+	announcement, err := scanAnnouncement(s.db.QueryRowContext(ctx, query,
+		params.Kind, params.Title, params.Body, params.PublishedAt, params.ExpiresAt, params.Notify, nullString(createdBy),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create announcement: %w", err)
+	}
+	return announcement, nil
+}
+
+// Get retrieves an announcement by ID, or nil if it doesn't exist
+func (s *AnnouncementStore) Get(ctx context.Context, id string) (*models.Announcement, error) {
+	announcement, err := scanAnnouncement(s.db.QueryRowContext(ctx, `SELECT `+announcementColumns+` FROM announcements a WHERE a.id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get announcement: %w", err)
+	}
+	return announcement, nil
+}
+
+// Update replaces an announcement's editable fields, returning nil if it
+// doesn't exist
+func (s *AnnouncementStore) Update(ctx context.Context, id string, params models.UpdateAnnouncementParams) (*models.Announcement, error) {
+	query := `
+		UPDATE announcements AS a
+		SET kind = $2, title = $3, body = $4, published_at = $5, expires_at = $6, updated_at = NOW()
+		WHERE a.id = $1
+		RETURNING ` + announcementColumns
+
+	announcement, err := scanAnnouncement(s.db.QueryRowContext(ctx, query,
+		id, params.Kind, params.Title, params.Body, params.PublishedAt, params.ExpiresAt,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update announcement: %w", err)
+	}
+	return announcement, nil
+}
+
+// Delete removes an announcement and its read receipts
+func (s *AnnouncementStore) Delete(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM announcements WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete announcement: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrAnnouncementNotFound
+	}
+	return nil
+}
+
+// List returns announcements for admins, latest publication first
+func (s *AnnouncementStore) List(ctx context.Context, params models.AnnouncementListParams) (*models.AnnouncementListResponse, error) {
+	var args []interface{}
+	where := ""
+	if params.Status != "" {
+		args = append(args, params.Status)
+		where = fmt.Sprintf("WHERE (%s) = $1", announcementStatusSQL)
+	}
+
+	var totalCount int
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM announcements a "+where, args...).Scan(&totalCount); err != nil {
+		return nil, fmt.Errorf("failed to count announcements: %w", err)
+	}
+
+	args = append(args, params.Limit, params.Offset)
+	query := fmt.Sprintf(`SELECT %s FROM announcements a %s ORDER BY a.published_at DESC LIMIT $%d OFFSET $%d`,
+		announcementColumns, where, len(args)-1, len(args))
+	announcements, err := s.queryAnnouncements(ctx, query, false, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &models.AnnouncementListResponse{Announcements: announcements, TotalCount: totalCount}, nil
+}
+
+// ListActive returns the announcements users can see, newest first. With a
+// userID each announcement says whether they've read it. Announcements from
+// before the user signed up count as read.
+func (s *AnnouncementStore) ListActive(ctx context.Context, userID string, limit, offset int) (*models.AnnouncementListResponse, error) {
+	var totalCount int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM announcements a WHERE `+announcementActiveSQL).Scan(&totalCount); err != nil {
+		return nil, fmt.Errorf("failed to count announcements: %w", err)
+	}
+
+	if userID == "" {
+		announcements, err := s.queryAnnouncements(ctx, `
+			SELECT `+announcementColumns+` FROM announcements a
+			WHERE `+announcementActiveSQL+`
+			ORDER BY a.published_at DESC LIMIT $1 OFFSET $2
+		`, false, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+		return &models.AnnouncementListResponse{Announcements: announcements, TotalCount: totalCount}, nil
+	}
+
+	announcements, err := s.queryAnnouncements(ctx, `
+		SELECT `+announcementColumns+`, `+announcementReadSQL+`
+		FROM announcements a
+		JOIN users u ON u.id = $1
+		LEFT JOIN announcement_reads r ON r.announcement_id = a.id AND r.user_id = u.id
+		WHERE `+announcementActiveSQL+`
+		ORDER BY a.published_at DESC LIMIT $2 OFFSET $3
+	`, true, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	var unread int
+	err = s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM announcements a
+		JOIN users u ON u.id = $1
+		LEFT JOIN announcement_reads r ON r.announcement_id = a.id AND r.user_id = u.id
+		WHERE `+announcementActiveSQL+` AND NOT `+announcementReadSQL,
+		userID).Scan(&unread)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread announcements: %w", err)
+	}
+	return &models.AnnouncementListResponse{Announcements: announcements, TotalCount: totalCount, UnreadCount: &unread}, nil
+}
+
+// announcementReadSQL reports whether user u has read announcement a
+const announcementReadSQL = `(r.user_id IS NOT NULL OR a.published_at < u.created_at)`
+
+// MarkRead records that a user read an announcement they can see
+func (s *AnnouncementStore) MarkRead(ctx context.Context, id, userID string) error {
+	var found bool
+	err := s.db.QueryRowContext(ctx, `
+		WITH target AS (
+			SELECT a.id FROM announcements a WHERE a.id = $1 AND `+announcementActiveSQL+`
+		), inserted AS (
+			INSERT INTO announcement_reads (announcement_id, user_id)
+			SELECT id, $2 FROM target
+			ON CONFLICT DO NOTHING
+		)
+		SELECT EXISTS (SELECT 1 FROM target)
+	`, id, userID).Scan(&found)
+	if err != nil {
+		return fmt.Errorf("failed to mark announcement read: %w", err)
+	}
+	if !found {
+		return ErrAnnouncementNotFound
+	}
+	return nil
+}
+
+// MarkAllRead records that a user read every announcement they can see.
+// Returns the number newly marked.
+func (s *AnnouncementStore) MarkAllRead(ctx context.Context, userID string) (int, error) {
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO announcement_reads (announcement_id, user_id)
+		SELECT a.id, $1 FROM announcements a WHERE `+announcementActiveSQL+`
+		ON CONFLICT DO NOTHING
+	`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark announcements read: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return int(rows), nil
+}
+
+// ClaimDueNotifications marks published announcements that asked to notify
+// users as notified and returns them. Each is claimed once across instances.
+func (s *AnnouncementStore) ClaimDueNotifications(ctx context.Context) ([]models.Announcement, error) {
+	return s.queryAnnouncements(ctx, `
+		UPDATE announcements AS a SET notified_at = NOW()
+		WHERE a.notify AND a.notified_at IS NULL AND `+announcementActiveSQL+`
+		RETURNING `+announcementColumns, false)
+}
+
+// ListNotificationRecipients returns active users with a registered device
+// in the tenant that posted an announcement. Announcements are sent by a
+// job that sees every tenant, so the tenant comes from the announcement.
+func (s *AnnouncementStore) ListNotificationRecipients(ctx context.Context, announcementID string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT DISTINCT d.user_id::text FROM push_devices d
+		JOIN users u ON u.id = d.user_id
+		JOIN announcements a ON a.tenant_id = u.tenant_id
+		WHERE a.id = $1 AND u.status = $2
+	`, announcementID, models.UserStatusActive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list announcement recipients: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan announcement recipient: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (s *AnnouncementStore) queryAnnouncements(ctx context.Context, query string, withRead bool, args ...interface{}) ([]models.Announcement, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list announcements: %w", err)
+	}
+	defer rows.Close()
+
+	announcements := make([]models.Announcement, 0)
+	for rows.Next() {
+		var announcement *models.Announcement
+		if withRead {
+			var read bool
+			announcement, err = scanAnnouncement(rows, &read)
+			if announcement != nil {
+				announcement.Read = &read
+			}
+		} else {
+			announcement, err = scanAnnouncement(rows)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan announcement: %w", err)
+		}
+		announcements = append(announcements, *announcement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list announcements: %w", err)
+	}
+	return announcements, nil
+}
+
+func scanAnnouncement(row interface{ Scan(...interface{}) error }, extra ...interface{}) (*models.Announcement, error) {
+	var announcement models.Announcement
+	var expiresAt, notifiedAt sql.NullTime
+	dest := append([]interface{}{
+		&announcement.ID, &announcement.Kind, &announcement.Title, &announcement.Body,
+		&announcement.PublishedAt, &expiresAt, &announcement.Status, &notifiedAt,
+		&announcement.CreatedByUserID, &announcement.CreatedAt, &announcement.UpdatedAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	if expiresAt.Valid {
+		announcement.ExpiresAt = &expiresAt.Time
+	}
+	if notifiedAt.Valid {
+		announcement.NotifiedAt = &notifiedAt.Time
+	}
+	return &announcement, nil
+}
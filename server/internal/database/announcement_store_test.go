@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+func TestAnnouncementStore_ListNotificationRecipients_StaysInTenant(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Close()
+	store := NewAnnouncementStore(&DB{DB: testDB.DB})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var clubID string
+	if err := testDB.QueryRowContext(ctx, `
+		INSERT INTO tenants (slug, name) VALUES ($1, 'Club') RETURNING id
+	`, "club-"+uuid.NewString()[:8]).Scan(&clubID); err != nil {
+		t.Fatalf("seed tenant: %v", err)
+	}
+	t.Cleanup(func() { testDB.ExecContext(context.Background(), `DELETE FROM tenants WHERE id = $1`, clubID) })
+
+	// One user with a device in each tenant, and an announcement from each
+	users := map[string]string{}
+	announcements := map[string]string{}
+	for _, tenantID := range []string{DefaultTenantID, clubID} {
+		var userID string
+		if err := testDB.QueryRowContext(ctx, `
+			INSERT INTO users (email, display_name, tenant_id) VALUES ($1, 'Announcement Test', $2) RETURNING id
+		`, "announce-"+uuid.NewString()[:8]+"@example.com", tenantID).Scan(&userID); err != nil {
+			t.Fatalf("seed user: %v", err)
+		}
+		t.Cleanup(func() { testDB.ExecContext(context.Background(), `DELETE FROM users WHERE id = $1`, userID) })
+		if _, err := testDB.ExecContext(ctx, `
+			INSERT INTO push_devices (user_id, platform, token) VALUES ($1, 'ios', $2)
+		`, userID, uuid.NewString()); err != nil {
+			t.Fatalf("seed device: %v", err)
+		}
+
+		var announcementID string
+		if err := testDB.QueryRowContext(ctx, `
+			INSERT INTO announcements (kind, title, body, notify, tenant_id)
+			VALUES ('notice', 'Field day', 'See you there', TRUE, $1)
+			RETURNING id
+		`, tenantID).Scan(&announcementID); err != nil {
+			t.Fatalf("seed announcement: %v", err)
+		}
+		t.Cleanup(func() {
+			testDB.ExecContext(context.Background(), `DELETE FROM announcements WHERE id = $1`, announcementID)
+		})
+		users[tenantID], announcements[tenantID] = userID, announcementID
+	}
+
+	// The send job sees every tenant, as in production
+	ctx = AllTenants(ctx)
+	for _, tenantID := range []string{DefaultTenantID, clubID} {
+		recipients, err := store.ListNotificationRecipients(ctx, announcements[tenantID])
+		if err != nil {
+			t.Fatalf("ListNotificationRecipients() error = %v", err)
+		}
+		for _, userID := range recipients {
+			for otherTenant, otherUser := range users {
+				if otherTenant != tenantID && userID == otherUser {
+					t.Errorf("announcement from tenant %s reached a user of tenant %s", tenantID, otherTenant)
+				}
+			}
+		}
+		found := false
+		for _, userID := range recipients {
+			found = found || userID == users[tenantID]
+		}
+		if !found {
+			t.Errorf("announcement from tenant %s missed its own user; got %v", tenantID, recipients)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// uniqueViolation is the Postgres error code for a unique constraint or
+// unique index violation
+const uniqueViolation = "23505"
+
+var ErrAppealNotFound = errors.New("appeal not found")
+var ErrAppealAlreadyOpen = errors.New("an appeal for this account is already open")
+
+// AppealStore persists account appeals, the queue admins review to decide
+// whether a disabled or suspended account is reinstated
+type AppealStore struct {
+	db *DB
+}
+
+// NewAppealStore creates a new appeal store
+func NewAppealStore(db *DB) *AppealStore {
+	return &AppealStore{db: db}
+}
+
+const appealColumns = `a.id, a.user_id, u.email, COALESCE(u.call_sign, ''), a.user_status, COALESCE(a.status_reason, ''),
+	a.message, a.status, COALESCE(a.resolved_by::text, ''), COALESCE(a.resolution_note, ''), a.resolved_at, a.created_at`
+
+// Create records an appeal against the user's current status. Only one
+// appeal per account can be open at a time.
+func (s *AppealStore) Create(ctx context.Context, user *models.User, message string) (*models.AccountAppeal, error) {
+	var id string
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO account_appeals (user_id, user_status, status_reason, message)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`, user.ID, user.Status, nullString(user.StatusReason), message).Scan(&id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == "idx_account_appeals_open" {
+			return nil, ErrAppealAlreadyOpen
+		}
+		return nil, fmt.Errorf("failed to create appeal: %w", err)
+	}
+	return s.Get(ctx, id)
+}
+
+// Get returns an appeal by ID, or nil when it doesn't exist
+func (s *AppealStore) Get(ctx context.Context, id string) (*models.AccountAppeal, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT `+appealColumns+`
+		FROM account_appeals a
+		JOIN users u ON u.id = a.user_id
+		WHERE a.id = $1
+	`, id)
+	appeal, err := scanAppeal(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get appeal: %w", err)
+	}
+	return appeal, nil
+}
+
+// List returns a page of appeals, oldest first so the queue is worked in
+// the order appeals arrived
+func (s *AppealStore) List(ctx context.Context, params models.AppealListParams) (*models.AppealsResponse, error) {
+	where := ""
+	var args []interface{}
+	if params.Status != "" {
+		args = append(args, params.Status)
+		where = "WHERE a.status = $1"
+	}
+
+	var total int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM account_appeals a `+where, args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count appeals: %w", err)
+	}
+
+	limit := params.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	offset := params.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	args = append(args, limit, offset)
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT `+appealColumns+`
+		FROM account_appeals a
+		JOIN users u ON u.id = a.user_id
+		%s
+		ORDER BY a.created_at ASC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list appeals: %w", err)
+	}
+	defer rows.Close()
+
+	appeals := []models.AccountAppeal{}
+	for rows.Next() {
+		appeal, err := scanAppeal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan appeal: %w", err)
+		}
+		appeals = append(appeals, *appeal)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list appeals: %w", err)
+	}
+	return &models.AppealsResponse{Appeals: appeals, TotalCount: total}, nil
+}
+
+// Resolve closes an open appeal with the admin's decision. Reinstating makes
+// the account active and clears its status reason in the same transaction.
+func (s *AppealStore) Resolve(ctx context.Context, id, adminUserID string, params models.ResolveAppealParams) (*models.AccountAppeal, error) {
+	err := s.db.RunInTx(ctx, func(ctx context.Context) error {
+		var userID string
+		err := s.db.QueryRowContext(ctx, `
+			UPDATE account_appeals
+			SET status = $2, resolved_by = $3, resolution_note = $4, resolved_at = NOW()
+			WHERE id = $1 AND status = 'open'
+			RETURNING user_id
+		`, id, params.Decision, nullString(adminUserID), nullString(params.Note)).Scan(&userID)
+		if err == sql.ErrNoRows {
+			return ErrAppealNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to resolve appeal: %w", err)
+		}
+
+		if params.Decision != models.AppealStatusReinstated {
+			return nil
+		}
+		_, err = s.db.ExecContext(ctx, `
+			UPDATE users
+			SET status = 'active', status_reason = NULL, status_expires_at = NULL, updated_at = NOW()
+			WHERE id = $1
+		`, userID)
+		if err != nil {
+			return fmt.Errorf("failed to reinstate user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, id)
+}
+
+type appealScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAppeal(row appealScanner) (*models.AccountAppeal, error) {
+	var appeal models.AccountAppeal
+	var resolvedAt sql.NullTime
+	err := row.Scan(
+		&appeal.ID, &appeal.UserID, &appeal.UserEmail, &appeal.UserCallSign, &appeal.UserStatus, &appeal.StatusReason,
+		&appeal.Message, &appeal.Status, &appeal.ResolvedBy, &appeal.ResolutionNote, &resolvedAt, &appeal.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if resolvedAt.Valid {
+		appeal.ResolvedAt = &resolvedAt.Time
+	}
+	return &appeal, nil
+}
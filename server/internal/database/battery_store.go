@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/johnrirwin/flyingforge/internal/models"
 )
@@ -25,14 +26,15 @@ func (s *BatteryStore) Create(ctx context.Context, userID string, batteryCode st
 	query := `
 		INSERT INTO batteries (user_id, battery_code, name, chemistry, cells, capacity_mah, c_rating, connector, weight_grams, brand, model, purchase_date, notes)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
-		RETURNING id, user_id, battery_code, name, chemistry, cells, capacity_mah, c_rating, connector, weight_grams, brand, model, purchase_date, notes, created_at, updated_at
+		RETURNING id, user_id, battery_code, name, chemistry, cells, capacity_mah, c_rating, connector, weight_grams, brand, model, purchase_date, notes, created_at, updated_at,
+		          charged_at, storage_reminder_due_at
 	`
 
 	battery := &models.Battery{}
 	var (
 		scanName, scanConnector, scanNotes, scanBrand, scanModel sql.NullString
 		scanCRating, scanWeightGrams                             sql.NullInt32
-		scanPurchaseDate                                         sql.NullTime
+		scanPurchaseDate, scanChargedAt, scanReminderDueAt       sql.NullTime
 	)
 
 	// Prepare nullable fields
@@ -73,6 +75,7 @@ func (s *BatteryStore) Create(ctx context.Context, userID string, batteryCode st
 		&battery.Chemistry, &battery.Cells, &battery.CapacityMah,
 		&scanCRating, &scanConnector, &scanWeightGrams, &scanBrand, &scanModel, &scanPurchaseDate, &scanNotes,
 		&battery.CreatedAt, &battery.UpdatedAt,
+		&scanChargedAt, &scanReminderDueAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create battery: %w", err)
@@ -94,6 +97,12 @@ func (s *BatteryStore) Create(ctx context.Context, userID string, batteryCode st
 	if scanPurchaseDate.Valid {
 		battery.PurchaseDate = &scanPurchaseDate.Time
 	}
+	if scanChargedAt.Valid {
+		battery.ChargedAt = &scanChargedAt.Time
+	}
+	if scanReminderDueAt.Valid {
+		battery.StorageReminderDueAt = &scanReminderDueAt.Time
+	}
 
 	return battery, nil
 }
@@ -103,8 +112,9 @@ func (s *BatteryStore) Get(ctx context.Context, id string, userID string) (*mode
 	query := `
 		SELECT b.id, b.user_id, b.battery_code, b.name, b.chemistry, b.cells, b.capacity_mah,
 		       b.c_rating, b.connector, b.weight_grams, b.brand, b.model, b.purchase_date, b.notes, b.created_at, b.updated_at,
-		       COALESCE(SUM(l.cycle_delta), 0) as total_cycles,
-		       MAX(l.logged_at) as last_logged
+		       b.charged_at, b.storage_reminder_due_at,
+		       b.purged_cycles + COALESCE(SUM(l.cycle_delta), 0) as total_cycles,
+		       GREATEST(MAX(l.logged_at), b.purged_last_logged_at) as last_logged
 		FROM batteries b
 		LEFT JOIN battery_logs l ON l.battery_id = b.id
 		WHERE b.id = $1 AND b.user_id = $2
@@ -116,6 +126,7 @@ func (s *BatteryStore) Get(ctx context.Context, id string, userID string) (*mode
 		scanName, scanConnector, scanNotes, scanBrand, scanModel sql.NullString
 		scanCRating, scanWeightGrams                             sql.NullInt32
 		scanPurchaseDate, scanLastLogged                         sql.NullTime
+		scanChargedAt, scanReminderDueAt                         sql.NullTime
 	)
 
 	err := s.db.QueryRowContext(ctx, query, id, userID).Scan(
@@ -123,6 +134,7 @@ func (s *BatteryStore) Get(ctx context.Context, id string, userID string) (*mode
 		&battery.Chemistry, &battery.Cells, &battery.CapacityMah,
 		&scanCRating, &scanConnector, &scanWeightGrams, &scanBrand, &scanModel, &scanPurchaseDate, &scanNotes,
 		&battery.CreatedAt, &battery.UpdatedAt,
+		&scanChargedAt, &scanReminderDueAt,
 		&battery.TotalCycles, &scanLastLogged,
 	)
 	if err == sql.ErrNoRows {
@@ -148,6 +160,12 @@ func (s *BatteryStore) Get(ctx context.Context, id string, userID string) (*mode
 	if scanPurchaseDate.Valid {
 		battery.PurchaseDate = &scanPurchaseDate.Time
 	}
+	if scanChargedAt.Valid {
+		battery.ChargedAt = &scanChargedAt.Time
+	}
+	if scanReminderDueAt.Valid {
+		battery.StorageReminderDueAt = &scanReminderDueAt.Time
+	}
 	if scanLastLogged.Valid {
 		battery.LastLoggedDate = &scanLastLogged.Time
 	}
@@ -160,8 +178,9 @@ func (s *BatteryStore) GetByCode(ctx context.Context, batteryCode string, userID
 	query := `
 		SELECT b.id, b.user_id, b.battery_code, b.name, b.chemistry, b.cells, b.capacity_mah,
 		       b.c_rating, b.connector, b.weight_grams, b.brand, b.model, b.purchase_date, b.notes, b.created_at, b.updated_at,
-		       COALESCE(SUM(l.cycle_delta), 0) as total_cycles,
-		       MAX(l.logged_at) as last_logged
+		       b.charged_at, b.storage_reminder_due_at,
+		       b.purged_cycles + COALESCE(SUM(l.cycle_delta), 0) as total_cycles,
+		       GREATEST(MAX(l.logged_at), b.purged_last_logged_at) as last_logged
 		FROM batteries b
 		LEFT JOIN battery_logs l ON l.battery_id = b.id
 		WHERE b.battery_code = $1 AND b.user_id = $2
@@ -173,6 +192,7 @@ func (s *BatteryStore) GetByCode(ctx context.Context, batteryCode string, userID
 		scanName, scanConnector, scanNotes, scanBrand, scanModel sql.NullString
 		scanCRating, scanWeightGrams                             sql.NullInt32
 		scanPurchaseDate, scanLastLogged                         sql.NullTime
+		scanChargedAt, scanReminderDueAt                         sql.NullTime
 	)
 
 	err := s.db.QueryRowContext(ctx, query, batteryCode, userID).Scan(
@@ -180,6 +200,7 @@ func (s *BatteryStore) GetByCode(ctx context.Context, batteryCode string, userID
 		&battery.Chemistry, &battery.Cells, &battery.CapacityMah,
 		&scanCRating, &scanConnector, &scanWeightGrams, &scanBrand, &scanModel, &scanPurchaseDate, &scanNotes,
 		&battery.CreatedAt, &battery.UpdatedAt,
+		&scanChargedAt, &scanReminderDueAt,
 		&battery.TotalCycles, &scanLastLogged,
 	)
 	if err == sql.ErrNoRows {
@@ -205,6 +226,12 @@ func (s *BatteryStore) GetByCode(ctx context.Context, batteryCode string, userID
 	if scanPurchaseDate.Valid {
 		battery.PurchaseDate = &scanPurchaseDate.Time
 	}
+	if scanChargedAt.Valid {
+		battery.ChargedAt = &scanChargedAt.Time
+	}
+	if scanReminderDueAt.Valid {
+		battery.StorageReminderDueAt = &scanReminderDueAt.Time
+	}
 	if scanLastLogged.Valid {
 		battery.LastLoggedDate = &scanLastLogged.Time
 	}
@@ -277,7 +304,8 @@ func (s *BatteryStore) Update(ctx context.Context, userID string, params models.
 	query := fmt.Sprintf(`
 		UPDATE batteries SET %s
 		WHERE id = $%d AND user_id = $%d
-		RETURNING id, user_id, battery_code, name, chemistry, cells, capacity_mah, c_rating, connector, weight_grams, brand, model, purchase_date, notes, created_at, updated_at
+		RETURNING id, user_id, battery_code, name, chemistry, cells, capacity_mah, c_rating, connector, weight_grams, brand, model, purchase_date, notes, created_at, updated_at,
+		          charged_at, storage_reminder_due_at
 	`, strings.Join(setClauses, ", "), argIndex, argIndex+1)
 
 	args = append(args, params.ID, userID)
@@ -286,7 +314,7 @@ func (s *BatteryStore) Update(ctx context.Context, userID string, params models.
 	var (
 		scanName, scanConnector, scanNotes, scanBrand, scanModel sql.NullString
 		scanCRating, scanWeightGrams                             sql.NullInt32
-		scanPurchaseDate                                         sql.NullTime
+		scanPurchaseDate, scanChargedAt, scanReminderDueAt       sql.NullTime
 	)
 
 	err := s.db.QueryRowContext(ctx, query, args...).Scan(
@@ -294,6 +322,7 @@ func (s *BatteryStore) Update(ctx context.Context, userID string, params models.
 		&battery.Chemistry, &battery.Cells, &battery.CapacityMah,
 		&scanCRating, &scanConnector, &scanWeightGrams, &scanBrand, &scanModel, &scanPurchaseDate, &scanNotes,
 		&battery.CreatedAt, &battery.UpdatedAt,
+		&scanChargedAt, &scanReminderDueAt,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -318,6 +347,12 @@ func (s *BatteryStore) Update(ctx context.Context, userID string, params models.
 	if scanPurchaseDate.Valid {
 		battery.PurchaseDate = &scanPurchaseDate.Time
 	}
+	if scanChargedAt.Valid {
+		battery.ChargedAt = &scanChargedAt.Time
+	}
+	if scanReminderDueAt.Valid {
+		battery.StorageReminderDueAt = &scanReminderDueAt.Time
+	}
 
 	return battery, nil
 }
@@ -456,8 +491,9 @@ func (s *BatteryStore) List(ctx context.Context, userID string, params models.Ba
 	query := fmt.Sprintf(`
 		SELECT b.id, b.user_id, b.battery_code, b.name, b.chemistry, b.cells, b.capacity_mah,
 		       b.c_rating, b.connector, b.weight_grams, b.brand, b.model, b.purchase_date, b.notes, b.created_at, b.updated_at,
-		       COALESCE(SUM(l.cycle_delta), 0) as total_cycles,
-		       MAX(l.logged_at) as last_logged
+		       b.charged_at, b.storage_reminder_due_at,
+		       b.purged_cycles + COALESCE(SUM(l.cycle_delta), 0) as total_cycles,
+		       GREATEST(MAX(l.logged_at), b.purged_last_logged_at) as last_logged
 		FROM batteries b
 		LEFT JOIN battery_logs l ON l.battery_id = b.id
 		WHERE %s
@@ -492,6 +528,7 @@ func (s *BatteryStore) List(ctx context.Context, userID string, params models.Ba
 			scanName, scanConnector, scanNotes, scanBrand, scanModel sql.NullString
 			scanCRating, scanWeightGrams                             sql.NullInt32
 			scanPurchaseDate, scanLastLogged                         sql.NullTime
+			scanChargedAt, scanReminderDueAt                         sql.NullTime
 		)
 
 		if err := rows.Scan(
@@ -499,6 +536,7 @@ func (s *BatteryStore) List(ctx context.Context, userID string, params models.Ba
 			&battery.Chemistry, &battery.Cells, &battery.CapacityMah,
 			&scanCRating, &scanConnector, &scanWeightGrams, &scanBrand, &scanModel, &scanPurchaseDate, &scanNotes,
 			&battery.CreatedAt, &battery.UpdatedAt,
+			&scanChargedAt, &scanReminderDueAt,
 			&battery.TotalCycles, &scanLastLogged,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan battery: %w", err)
@@ -520,6 +558,12 @@ func (s *BatteryStore) List(ctx context.Context, userID string, params models.Ba
 		if scanPurchaseDate.Valid {
 			battery.PurchaseDate = &scanPurchaseDate.Time
 		}
+		if scanChargedAt.Valid {
+			battery.ChargedAt = &scanChargedAt.Time
+		}
+		if scanReminderDueAt.Valid {
+			battery.StorageReminderDueAt = &scanReminderDueAt.Time
+		}
 		if scanLastLogged.Valid {
 			battery.LastLoggedDate = &scanLastLogged.Time
 		}
@@ -700,3 +744,69 @@ func (s *BatteryStore) DeleteLog(ctx context.Context, logID string, userID strin
 	}
 	return nil
 }
+
+// MarkCharged records that a battery was charged for a session and when its
+// storage reminder is due. Returns false if the battery was not found.
+func (s *BatteryStore) MarkCharged(ctx context.Context, id string, userID string, chargedAt, reminderDueAt time.Time) (bool, error) {
+	query := `UPDATE batteries SET charged_at = $3, storage_reminder_due_at = $4 WHERE id = $1 AND user_id = $2`
+	result, err := s.db.ExecContext(ctx, query, id, userID, chargedAt, reminderDueAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark battery charged: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to mark battery charged: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// ClearCharged clears a battery's charged state if it was charged at or
+// before the given time, so a back-dated log doesn't clear a newer charge
+func (s *BatteryStore) ClearCharged(ctx context.Context, id string, userID string, before time.Time) error {
+	query := `
+		UPDATE batteries SET charged_at = NULL, storage_reminder_due_at = NULL
+		WHERE id = $1 AND user_id = $2 AND charged_at <= $3`
+	if _, err := s.db.ExecContext(ctx, query, id, userID, before); err != nil {
+		return fmt.Errorf("failed to clear battery charged state: %w", err)
+	}
+	return nil
+}
+
+// ListStorageReminderDue returns charged batteries whose storage reminder is
+// due at or before the given time
+func (s *BatteryStore) ListStorageReminderDue(ctx context.Context, now time.Time) ([]models.ChargedBattery, error) {
+	query := `
+		SELECT id, user_id, battery_code, COALESCE(name, ''), charged_at
+		FROM batteries
+		WHERE storage_reminder_due_at <= $1 AND charged_at IS NOT NULL
+		ORDER BY storage_reminder_due_at`
+
+	rows, err := s.db.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list charged batteries: %w", err)
+	}
+	defer rows.Close()
+
+	batteries := make([]models.ChargedBattery, 0)
+	for rows.Next() {
+		var battery models.ChargedBattery
+		if err := rows.Scan(&battery.ID, &battery.UserID, &battery.BatteryCode, &battery.Name, &battery.ChargedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan charged battery: %w", err)
+		}
+		batteries = append(batteries, battery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list charged batteries: %w", err)
+	}
+	return batteries, nil
+}
+
+// MarkStorageReminded records that a storage reminder was sent. The battery
+// stays charged until a discharge or storage log is recorded.
+func (s *BatteryStore) MarkStorageReminded(ctx context.Context, id string) error {
+	query := `UPDATE batteries SET storage_reminder_due_at = NULL WHERE id = $1`
+	if _, err := s.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark battery storage reminder: %w", err)
+	}
+	return nil
+}
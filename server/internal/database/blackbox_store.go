@@ -0,0 +1,151 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// BlackboxStore handles blackbox log records. The files themselves are kept
+// in blob storage.
+type BlackboxStore struct {
+	db *DB
+}
+
+// NewBlackboxStore creates a new blackbox store
+func NewBlackboxStore(db *DB) *BlackboxStore {
+	return &BlackboxStore{db: db}
+}
+
+const blackboxLogColumns = `id, user_id, COALESCE(aircraft_id::text, ''), file_name, file_size, checksum, COALESCE(notes, ''),
+	COALESCE(firmware, ''), COALESCE(craft_name, ''), duration_seconds, parse_status, parse_warnings, flights,
+	storage_backend, storage_path, created_at`
+
+// Create saves a parsed blackbox log, filling in its ID and creation time
+func (s *BlackboxStore) Create(ctx context.Context, log *models.BlackboxLog) error {
+	flights, err := json.Marshal(log.Flights)
+	if err != nil {
+		return fmt.Errorf("failed to encode blackbox flights: %w", err)
+	}
+	warnings := log.ParseWarnings
+	if warnings == nil {
+		warnings = []string{}
+	}
+	err = s.db.QueryRowContext(ctx, `
+		INSERT INTO blackbox_logs (user_id, aircraft_id, file_name, file_size, checksum, notes, firmware, craft_name,
+			duration_seconds, parse_status, parse_warnings, flights, storage_backend, storage_path)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id, created_at
+	`,
+		log.UserID, nullString(log.AircraftID), log.FileName, log.FileSize, log.Checksum, nullString(log.Notes),
+		nullString(log.Firmware), nullString(log.CraftName), log.DurationSeconds, string(log.ParseStatus),
+		pq.Array(warnings), flights, log.StorageBackend, log.StoragePath,
+	).Scan(&log.ID, &log.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create blackbox log: %w", err)
+	}
+	return nil
+}
+
+// Get returns one of a user's blackbox logs, or nil if it doesn't exist
+func (s *BlackboxStore) Get(ctx context.Context, id, userID string) (*models.BlackboxLog, error) {
+	log, err := scanBlackboxLog(s.db.QueryRowContext(ctx, `
+		SELECT `+blackboxLogColumns+` FROM blackbox_logs WHERE id = $1 AND user_id = $2
+	`, id, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blackbox log: %w", err)
+	}
+	return log, nil
+}
+
+// List returns a user's blackbox logs, newest first
+func (s *BlackboxStore) List(ctx context.Context, userID string, params models.BlackboxLogListParams) (*models.BlackboxLogListResponse, error) {
+	where := `WHERE user_id = $1`
+	args := []interface{}{userID}
+	if params.AircraftID != "" {
+		where += ` AND aircraft_id = $2`
+		args = append(args, params.AircraftID)
+	}
+
+	var totalCount int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM blackbox_logs `+where, args...).Scan(&totalCount); err != nil {
+		return nil, fmt.Errorf("failed to count blackbox logs: %w", err)
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	offset := params.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM blackbox_logs %s ORDER BY created_at DESC LIMIT $%d OFFSET $%d`,
+		blackboxLogColumns, where, len(args)+1, len(args)+2)
+	rows, err := s.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blackbox logs: %w", err)
+	}
+	defer rows.Close()
+
+	logs := []models.BlackboxLog{}
+	for rows.Next() {
+		log, err := scanBlackboxLog(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan blackbox log: %w", err)
+		}
+		logs = append(logs, *log)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list blackbox logs: %w", err)
+	}
+
+	return &models.BlackboxLogListResponse{Logs: logs, TotalCount: totalCount}, nil
+}
+
+// Delete removes one of a user's blackbox logs and returns it, or nil if it
+// doesn't exist
+func (s *BlackboxStore) Delete(ctx context.Context, id, userID string) (*models.BlackboxLog, error) {
+	log, err := scanBlackboxLog(s.db.QueryRowContext(ctx, `
+		DELETE FROM blackbox_logs WHERE id = $1 AND user_id = $2
+		RETURNING `+blackboxLogColumns,
+		id, userID,
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete blackbox log: %w", err)
+	}
+	return log, nil
+}
+
+func scanBlackboxLog(row interface{ Scan(...interface{}) error }) (*models.BlackboxLog, error) {
+	var log models.BlackboxLog
+	var status string
+	var flights []byte
+	if err := row.Scan(
+		&log.ID, &log.UserID, &log.AircraftID, &log.FileName, &log.FileSize, &log.Checksum, &log.Notes,
+		&log.Firmware, &log.CraftName, &log.DurationSeconds, &status, pq.Array(&log.ParseWarnings), &flights,
+		&log.StorageBackend, &log.StoragePath, &log.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	log.ParseStatus = models.ParseStatus(status)
+	log.Flights = []models.BlackboxFlight{}
+	if len(flights) > 0 {
+		if err := json.Unmarshal(flights, &log.Flights); err != nil {
+			return nil, fmt.Errorf("failed to decode blackbox flights: %w", err)
+		}
+	}
+	return &log, nil
+}
@@ -0,0 +1,497 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+var ErrBrandNotFound = errors.New("brand not found")
+var ErrBrandConflict = errors.New("brand name or alias already belongs to another brand")
+
+// BrandStore handles canonical brand names and their aliases
+type BrandStore struct {
+	db *DB
+}
+
+// NewBrandStore creates a new brand store
+func NewBrandStore(db *DB) *BrandStore {
+	return &BrandStore{db: db}
+}
+
+// Resolve returns the canonical brand name for the given brand or alias.
+// Unknown brands are returned trimmed but otherwise unchanged.
+func (s *BrandStore) Resolve(ctx context.Context, brand string) (string, error) {
+	brand = strings.TrimSpace(brand)
+	key := models.NormalizeBrandKey(brand)
+	if key == "" {
+		return brand, nil
+	}
+
+	query := `
+		SELECT name FROM brands WHERE name_key = $1
+		UNION ALL
+		SELECT b.name FROM brand_aliases a JOIN brands b ON b.id = a.brand_id WHERE a.alias_key = $1
+		LIMIT 1
+	`
+	var name string
+	err := s.db.QueryRowContext(ctx, query, key).Scan(&name)
+	if err == sql.ErrNoRows {
+		return brand, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve brand: %w", err)
+	}
+	return name, nil
+}
+
+// List returns brands with their aliases, optionally filtered by name or alias
+func (s *BrandStore) List(ctx context.Context, query string, limit, offset int) (*models.BrandListResponse, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+
+	where := "1=1"
+	args := []interface{}{}
+	if key := models.NormalizeBrandKey(query); key != "" {
+		where = `(b.name_key LIKE $1 OR EXISTS (
+			SELECT 1 FROM brand_aliases a WHERE a.brand_id = b.id AND a.alias_key LIKE $1
+		))`
+		args = append(args, "%"+key+"%")
+	}
+
+	var totalCount int
+	if err := s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT COUNT(*) FROM brands b WHERE %s", where), args...).Scan(&totalCount); err != nil {
+		return nil, fmt.Errorf("failed to count brands: %w", err)
+	}
+
+	listQuery := fmt.Sprintf(`
+		SELECT b.id, b.name, b.created_at, b.updated_at,
+			   COALESCE(ARRAY(SELECT a.alias FROM brand_aliases a WHERE a.brand_id = b.id ORDER BY a.alias), '{}')
+		FROM brands b
+		WHERE %s
+		ORDER BY LOWER(b.name)
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := s.db.QueryContext(ctx, listQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list brands: %w", err)
+	}
+	defer rows.Close()
+
+	brands := make([]models.Brand, 0)
+	for rows.Next() {
+		var brand models.Brand
+		if err := rows.Scan(&brand.ID, &brand.Name, &brand.CreatedAt, &brand.UpdatedAt, pq.Array(&brand.Aliases)); err != nil {
+			return nil, fmt.Errorf("failed to scan brand: %w", err)
+		}
+		brands = append(brands, brand)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list brands: %w", err)
+	}
+
+	return &models.BrandListResponse{Brands: brands, TotalCount: totalCount}, nil
+}
+
+// Get retrieves a brand by ID. Returns nil if not found.
+func (s *BrandStore) Get(ctx context.Context, id string) (*models.Brand, error) {
+	return s.get(ctx, s.db, id)
+}
+
+func (s *BrandStore) get(ctx context.Context, q queryRower, id string) (*models.Brand, error) {
+	query := `
+		SELECT b.id, b.name, b.created_at, b.updated_at,
+			   COALESCE(ARRAY(SELECT a.alias FROM brand_aliases a WHERE a.brand_id = b.id ORDER BY a.alias), '{}')
+		FROM brands b
+		WHERE b.id = $1
+	`
+	var brand models.Brand
+	err := q.QueryRowContext(ctx, query, id).Scan(&brand.ID, &brand.Name, &brand.CreatedAt, &brand.UpdatedAt, pq.Array(&brand.Aliases))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get brand: %w", err)
+	}
+	return &brand, nil
+}
+
+// Create adds a canonical brand with optional aliases
+func (s *BrandStore) Create(ctx context.Context, params models.CreateBrandParams) (*models.Brand, error) {
+	name := strings.TrimSpace(params.Name)
+	nameKey := models.NormalizeBrandKey(name)
+	if nameKey == "" {
+		return nil, fmt.Errorf("brand name is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := checkBrandKeyAvailable(ctx, tx, nameKey, ""); err != nil {
+		return nil, err
+	}
+
+	var id string
+	if err := tx.QueryRowContext(ctx,
+		`INSERT INTO brands (name, name_key) VALUES ($1, $2) RETURNING id`,
+		name, nameKey,
+	).Scan(&id); err != nil {
+		return nil, fmt.Errorf("failed to create brand: %w", err)
+	}
+
+	if err := replaceBrandAliases(ctx, tx, id, name, params.Aliases); err != nil {
+		return nil, err
+	}
+
+	brand, err := s.get(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return brand, nil
+}
+
+// Update renames a brand and/or replaces its aliases
+func (s *BrandStore) Update(ctx context.Context, id string, params models.UpdateBrandParams) (*models.Brand, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	current, err := s.get(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, ErrBrandNotFound
+	}
+
+	name := current.Name
+	if params.Name != nil {
+		name = strings.TrimSpace(*params.Name)
+		nameKey := models.NormalizeBrandKey(name)
+		if nameKey == "" {
+			return nil, fmt.Errorf("brand name is required")
+		}
+		if err := checkBrandKeyAvailable(ctx, tx, nameKey, id); err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE brands SET name = $1, name_key = $2, updated_at = NOW() WHERE id = $3`,
+			name, nameKey, id,
+		); err != nil {
+			return nil, fmt.Errorf("failed to update brand: %w", err)
+		}
+	}
+
+	aliases := current.Aliases
+	if params.Aliases != nil {
+		aliases = *params.Aliases
+	}
+	if params.Name != nil || params.Aliases != nil {
+		if err := replaceBrandAliases(ctx, tx, id, name, aliases); err != nil {
+			return nil, err
+		}
+	}
+
+	brand, err := s.get(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return brand, nil
+}
+
+// Delete removes a brand and its aliases. Catalog rows are left untouched.
+func (s *BrandStore) Delete(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM brands WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete brand: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check delete result: %w", err)
+	}
+	if rows == 0 {
+		return ErrBrandNotFound
+	}
+	return nil
+}
+
+// Merge folds the source brands into the target: source names and aliases become
+// target aliases, the source brands are removed, and catalog rows using any of
+// the merged names are rewritten to the target's canonical name.
+func (s *BrandStore) Merge(ctx context.Context, params models.MergeBrandsParams) (*models.BrandRewriteResult, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	target, err := s.get(ctx, tx, params.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, ErrBrandNotFound
+	}
+
+	for _, sourceID := range params.SourceIDs {
+		if sourceID == params.TargetID {
+			continue
+		}
+		source, err := s.get(ctx, tx, sourceID)
+		if err != nil {
+			return nil, err
+		}
+		if source == nil {
+			return nil, ErrBrandNotFound
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE brand_aliases SET brand_id = $1 WHERE brand_id = $2`, target.ID, source.ID); err != nil {
+			return nil, fmt.Errorf("failed to move brand aliases: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM brands WHERE id = $1`, source.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete merged brand: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO brand_aliases (alias_key, alias, brand_id) VALUES ($1, $2, $3)
+			ON CONFLICT (alias_key) DO UPDATE SET brand_id = EXCLUDED.brand_id
+		`, models.NormalizeBrandKey(source.Name), source.Name, target.ID); err != nil {
+			return nil, fmt.Errorf("failed to add merged brand alias: %w", err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE brands SET updated_at = NOW() WHERE id = $1`, target.ID); err != nil {
+		return nil, fmt.Errorf("failed to update brand: %w", err)
+	}
+
+	result, err := s.rewriteCatalog(ctx, tx, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	s.db.invalidateTag(ctx, catalogSearchTag)
+	return result, nil
+}
+
+// RewriteCatalog renames catalog rows whose brand matches the brand or any of its
+// aliases to the canonical name, recomputing canonical keys. Rows whose new key
+// collides with an existing item are left alone and reported as conflicts.
+func (s *BrandStore) RewriteCatalog(ctx context.Context, id string) (*models.BrandRewriteResult, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := s.rewriteCatalog(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	s.db.invalidateTag(ctx, catalogSearchTag)
+	return result, nil
+}
+
+func (s *BrandStore) rewriteCatalog(ctx context.Context, tx *Tx, id string) (*models.BrandRewriteResult, error) {
+	brand, err := s.get(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if brand == nil {
+		return nil, ErrBrandNotFound
+	}
+
+	keys := []string{models.NormalizeBrandKey(brand.Name)}
+	for _, alias := range brand.Aliases {
+		keys = append(keys, models.NormalizeBrandKey(alias))
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, gear_type, brand, model, COALESCE(variant, ''), canonical_key
+		FROM gear_catalog
+		WHERE regexp_replace(LOWER(brand), '[^[:alnum:]]+', '', 'g') = ANY($1)
+		  AND brand <> $2
+		ORDER BY created_at
+		FOR UPDATE
+	`, pq.Array(keys), brand.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find catalog rows for brand: %w", err)
+	}
+
+	type catalogRow struct {
+		id, brand, model, variant, canonicalKey string
+		gearType                                models.GearType
+	}
+	var candidates []catalogRow
+	for rows.Next() {
+		var row catalogRow
+		if err := rows.Scan(&row.id, &row.gearType, &row.brand, &row.model, &row.variant, &row.canonicalKey); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan catalog row: %w", err)
+		}
+		candidates = append(candidates, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find catalog rows for brand: %w", err)
+	}
+
+	result := &models.BrandRewriteResult{
+		Brand:     brand,
+		Conflicts: make([]models.BrandRewriteConflict, 0),
+	}
+	for _, row := range candidates {
+		newKey := models.BuildCanonicalKeyV2(row.gearType, brand.Name, row.model, row.variant)
+		if newKey != row.canonicalKey {
+			var conflictID string
+			err := tx.QueryRowContext(ctx,
+				`SELECT id FROM gear_catalog WHERE canonical_key = $1 AND id <> $2`,
+				newKey, row.id,
+			).Scan(&conflictID)
+			if err == nil {
+				result.Conflicts = append(result.Conflicts, models.BrandRewriteConflict{
+					ItemID:          row.id,
+					Brand:           row.brand,
+					Model:           row.model,
+					CanonicalKey:    newKey,
+					ConflictsWithID: conflictID,
+				})
+				continue
+			}
+			if err != sql.ErrNoRows {
+				return nil, fmt.Errorf("failed to check canonical key conflict: %w", err)
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE gear_catalog SET brand = $1, canonical_key = $2, canonical_key_version = $3, updated_at = NOW() WHERE id = $4`,
+			brand.Name, newKey, models.CanonicalKeyVersion, row.id,
+		); err != nil {
+			return nil, fmt.Errorf("failed to rewrite catalog brand: %w", err)
+		}
+		if err := recordLegacyCanonicalKey(ctx, tx, row.canonicalKey, newKey, row.id); err != nil {
+			return nil, err
+		}
+		result.RewrittenCount++
+	}
+
+	return result, nil
+}
+
+// queryRower is satisfied by both *DB and *Tx
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// checkBrandKeyAvailable returns ErrBrandConflict if the key is already used as a
+// brand name or alias by a brand other than exceptID
+func checkBrandKeyAvailable(ctx context.Context, q queryRower, key, exceptID string) error {
+	var exists bool
+	err := q.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM brands WHERE name_key = $1 AND id::text <> $2
+			UNION ALL
+			SELECT 1 FROM brand_aliases WHERE alias_key = $1 AND brand_id::text <> $2
+		)
+	`, key, exceptID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check brand availability: %w", err)
+	}
+	if exists {
+		return ErrBrandConflict
+	}
+	return nil
+}
+
+// replaceBrandAliases swaps the alias set for a brand inside a transaction
+func replaceBrandAliases(ctx context.Context, tx *Tx, brandID, name string, aliases []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM brand_aliases WHERE brand_id = $1`, brandID); err != nil {
+		return fmt.Errorf("failed to clear brand aliases: %w", err)
+	}
+	for _, alias := range models.NormalizeBrandAliases(name, aliases) {
+		key := models.NormalizeBrandKey(alias)
+		if err := checkBrandKeyAvailable(ctx, tx, key, brandID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO brand_aliases (alias_key, alias, brand_id) VALUES ($1, $2, $3)`,
+			key, alias, brandID,
+		); err != nil {
+			return fmt.Errorf("failed to add brand alias: %w", err)
+		}
+	}
+	return nil
+}
+
+// resolverSnapshot loads every brand key into memory so bulk jobs can resolve
+// brands without a query per row
+func (s *BrandStore) resolverSnapshot(ctx context.Context) (func(string) string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT name_key, name FROM brands
+		UNION ALL
+		SELECT a.alias_key, b.name FROM brand_aliases a JOIN brands b ON b.id = a.brand_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load brands: %w", err)
+	}
+	defer rows.Close()
+
+	names := make(map[string]string)
+	for rows.Next() {
+		var key, name string
+		if err := rows.Scan(&key, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan brand key: %w", err)
+		}
+		names[key] = name
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load brands: %w", err)
+	}
+
+	return func(brand string) string {
+		brand = strings.TrimSpace(brand)
+		if name, ok := names[models.NormalizeBrandKey(brand)]; ok {
+			return name
+		}
+		return brand
+	}, nil
+}
+
+// recordLegacyCanonicalKey keeps a replaced canonical key resolvable to its item
+func recordLegacyCanonicalKey(ctx context.Context, tx *Tx, oldKey, newKey, catalogID string) error {
+	if oldKey == "" || oldKey == newKey {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO gear_catalog_legacy_keys (legacy_key, catalog_id) VALUES ($1, $2)
+		ON CONFLICT (legacy_key) DO NOTHING
+	`, oldKey, catalogID); err != nil {
+		return fmt.Errorf("failed to record legacy canonical key: %w", err)
+	}
+	return nil
+}
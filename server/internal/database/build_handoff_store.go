@@ -0,0 +1,172 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ErrHandoffClaimed is returned when an anonymous session's builds were
+// already claimed by an account
+var ErrHandoffClaimed = errors.New("temporary builds were already claimed")
+
+// BuildHandoffStore tracks which anonymous session created temp builds so
+// the account that signs in from it can claim them
+type BuildHandoffStore struct {
+	db *DB
+}
+
+// NewBuildHandoffStore creates a new build handoff store
+func NewBuildHandoffStore(db *DB) *BuildHandoffStore {
+	return &BuildHandoffStore{db: db}
+}
+
+// TagTempBuild records the anonymous session a build was created in
+func (s *BuildHandoffStore) TagTempBuild(ctx context.Context, buildID, sessionID string) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE builds SET handoff_session = $2
+		WHERE id = $1 AND owner_user_id IS NULL AND status IN ('TEMP', 'SHARED')
+	`, buildID, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to tag temp build: %w", err)
+	}
+	return nil
+}
+
+// SessionForToken returns the anonymous session of the temp or shared build
+// with token, or "" if it has none
+func (s *BuildHandoffStore) SessionForToken(ctx context.Context, token string) (string, error) {
+	var session sql.NullString
+	err := s.db.QueryRowContext(ctx, `
+		SELECT handoff_session FROM builds
+		WHERE token = $1 AND status IN ('TEMP', 'SHARED')
+	`, token).Scan(&session)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get temp build session: %w", err)
+	}
+	return session.String, nil
+}
+
+// ClaimSession gives the anonymous builds of a session to userID in one
+// transaction. The newest unexpired temp build becomes a draft the user can
+// keep editing; other builds, such as shared snapshots, keep their status
+// under the new owner. A session can only be claimed once.
+//
+// With maxDrafts above zero, the user's least recently updated drafts are
+// archived so the claimed draft doesn't take them past the limit.
+func (s *BuildHandoffStore) ClaimSession(ctx context.Context, sessionID, userID string, maxDrafts int) (*models.BuildHandoffResult, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO build_handoff_claims (session_id, user_id) VALUES ($1, $2)
+		ON CONFLICT (session_id) DO NOTHING
+	`, sessionID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record build handoff: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return nil, ErrHandoffClaimed
+	}
+
+	claimed := &models.BuildHandoffResult{ClaimedBuildIDs: []string{}}
+	err = tx.QueryRowContext(ctx, `
+		UPDATE builds
+		SET owner_user_id = $2, status = 'DRAFT', token = NULL, view_token = NULL, expires_at = NULL, updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM builds
+			WHERE handoff_session = $1 AND owner_user_id IS NULL AND status = 'TEMP'
+			  AND (expires_at IS NULL OR expires_at > NOW())
+			ORDER BY created_at DESC
+			LIMIT 1
+		)
+		RETURNING id
+	`, sessionID, userID).Scan(&claimed.DraftBuildID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to claim temp build: %w", err)
+	}
+	if claimed.DraftBuildID != "" {
+		claimed.ClaimedBuildIDs = append(claimed.ClaimedBuildIDs, claimed.DraftBuildID)
+	}
+	if claimed.DraftBuildID != "" && maxDrafts > 0 {
+		archived, err := archiveExcessDrafts(ctx, tx, userID, claimed.DraftBuildID, maxDrafts)
+		if err != nil {
+			return nil, err
+		}
+		claimed.ArchivedBuildIDs = archived
+	}
+
+	// Older revisions of the temp build were replaced by the draft
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM builds WHERE handoff_session = $1 AND owner_user_id IS NULL AND status = 'TEMP'
+	`, sessionID); err != nil {
+		return nil, fmt.Errorf("failed to remove temp build revisions: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		UPDATE builds SET owner_user_id = $2, updated_at = NOW()
+		WHERE handoff_session = $1 AND owner_user_id IS NULL AND status = 'SHARED'
+		RETURNING id
+	`, sessionID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim shared builds: %w", err)
+	}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan claimed build: %w", err)
+		}
+		claimed.ClaimedBuildIDs = append(claimed.ClaimedBuildIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to claim shared builds: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit build handoff: %w", err)
+	}
+	return claimed, nil
+}
+
+// archiveExcessDrafts archives the owner's least recently updated drafts
+// beyond maxDrafts, never keepID
+func archiveExcessDrafts(ctx context.Context, tx *Tx, ownerUserID, keepID string, maxDrafts int) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `
+		UPDATE builds SET status = 'ARCHIVED', updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM builds
+			WHERE owner_user_id = $1 AND status = 'DRAFT' AND id <> $2
+			ORDER BY updated_at DESC
+			OFFSET $3
+		)
+		RETURNING id
+	`, ownerUserID, keepID, maxDrafts-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive excess drafts: %w", err)
+	}
+	defer rows.Close()
+
+	var archived []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan archived draft: %w", err)
+		}
+		archived = append(archived, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to archive excess drafts: %w", err)
+	}
+	return archived, nil
+}
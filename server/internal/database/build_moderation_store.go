@@ -0,0 +1,145 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ErrBuildNotPending is returned when a moderation decision targets a build
+// that is no longer waiting for review
+var ErrBuildNotPending = errors.New("build is not pending moderation")
+
+// BuildModerationStore records moderator decisions on builds
+type BuildModerationStore struct {
+	db *DB
+}
+
+// NewBuildModerationStore creates a new build moderation store
+func NewBuildModerationStore(db *DB) *BuildModerationStore {
+	return &BuildModerationStore{db: db}
+}
+
+// Reject moves a pending build back to DRAFT and records why, in one
+// transaction
+func (s *BuildModerationStore) Reject(ctx context.Context, buildID, moderatorUserID string, params models.RejectBuildParams) (*models.BuildModerationEvent, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE builds SET status = 'DRAFT', updated_at = NOW()
+		WHERE id = $1 AND status = 'PENDING_REVIEW'
+	`, buildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reject build: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return nil, ErrBuildNotPending
+	}
+
+	event, err := insertBuildModerationEvent(ctx, tx, models.BuildModerationEvent{
+		BuildID:         buildID,
+		Action:          models.BuildModerationRejected,
+		Category:        params.Category,
+		Note:            params.Note,
+		ModeratorUserID: moderatorUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return event, nil
+}
+
+// Record adds a decision that was applied elsewhere, such as an approval
+func (s *BuildModerationStore) Record(ctx context.Context, event models.BuildModerationEvent) (*models.BuildModerationEvent, error) {
+	return insertBuildModerationEvent(ctx, s.db, event)
+}
+
+// ListForBuild returns a build's moderation history, newest first
+func (s *BuildModerationStore) ListForBuild(ctx context.Context, buildID string) ([]models.BuildModerationEvent, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+buildModerationEventColumns+`
+		FROM build_moderation_events
+		WHERE build_id = $1
+		ORDER BY created_at DESC
+	`, buildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list build moderation history: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]models.BuildModerationEvent, 0)
+	for rows.Next() {
+		event, err := scanBuildModerationEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan build moderation event: %w", err)
+		}
+		events = append(events, *event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list build moderation history: %w", err)
+	}
+	return events, nil
+}
+
+// LatestRejection returns the build's most recent decision if it was a
+// rejection, or nil
+func (s *BuildModerationStore) LatestRejection(ctx context.Context, buildID string) (*models.BuildModerationEvent, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT `+buildModerationEventColumns+`
+		FROM build_moderation_events
+		WHERE build_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, buildID)
+	event, err := scanBuildModerationEvent(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest build rejection: %w", err)
+	}
+	if event.Action != models.BuildModerationRejected {
+		return nil, nil
+	}
+	return event, nil
+}
+
+const buildModerationEventColumns = `
+	id, build_id, action, COALESCE(category, ''), COALESCE(note, ''),
+	COALESCE(moderator_user_id::text, ''), created_at
+`
+
+func insertBuildModerationEvent(ctx context.Context, q interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}, event models.BuildModerationEvent) (*models.BuildModerationEvent, error) {
+	row := q.QueryRowContext(ctx, `
+		INSERT INTO build_moderation_events (build_id, action, category, note, moderator_user_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+buildModerationEventColumns,
+		event.BuildID, string(event.Action), nullString(string(event.Category)), nullString(event.Note), nullString(event.ModeratorUserID))
+	saved, err := scanBuildModerationEvent(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record build moderation event: %w", err)
+	}
+	return saved, nil
+}
+
+func scanBuildModerationEvent(row interface{ Scan(...interface{}) error }) (*models.BuildModerationEvent, error) {
+	var event models.BuildModerationEvent
+	err := row.Scan(&event.ID, &event.BuildID, &event.Action, &event.Category, &event.Note, &event.ModeratorUserID, &event.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// BuildPresetStore handles Betaflight presets attached to builds
+type BuildPresetStore struct {
+	db *DB
+}
+
+// NewBuildPresetStore creates a new build preset store
+func NewBuildPresetStore(db *DB) *BuildPresetStore {
+	return &BuildPresetStore{db: db}
+}
+
+// Save attaches a preset to a build, replacing any it already has
+func (s *BuildPresetStore) Save(ctx context.Context, preset *models.BuildPreset) (*models.BuildPreset, error) {
+	var parsedTuning []byte
+	if preset.ParsedTuning != nil {
+		var err error
+		if parsedTuning, err = json.Marshal(preset.ParsedTuning); err != nil {
+			return nil, fmt.Errorf("failed to encode parsed tuning: %w", err)
+		}
+	}
+	removed := preset.RemovedSettings
+	if removed == nil {
+		removed = []string{}
+	}
+
+	saved := *preset
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO build_presets (build_id, diff, firmware_version, board_target, board_name, removed_settings, parsed_tuning)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (build_id) DO UPDATE SET
+			diff = EXCLUDED.diff,
+			firmware_version = EXCLUDED.firmware_version,
+			board_target = EXCLUDED.board_target,
+			board_name = EXCLUDED.board_name,
+			removed_settings = EXCLUDED.removed_settings,
+			parsed_tuning = EXCLUDED.parsed_tuning,
+			updated_at = NOW()
+		RETURNING updated_at
+	`, preset.BuildID, preset.Diff, preset.FirmwareVersion, preset.BoardTarget, preset.BoardName,
+		pq.Array(removed), parsedTuning).Scan(&saved.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save build preset: %w", err)
+	}
+	return &saved, nil
+}
+
+// Get returns the preset attached to a build, or nil if it has none
+func (s *BuildPresetStore) Get(ctx context.Context, buildID string) (*models.BuildPreset, error) {
+	preset := &models.BuildPreset{BuildID: buildID}
+	var parsedTuning []byte
+	err := s.db.QueryRowContext(ctx, `
+		SELECT diff, firmware_version, board_target, board_name, removed_settings, parsed_tuning, updated_at
+		FROM build_presets
+		WHERE build_id = $1
+	`, buildID).Scan(
+		&preset.Diff, &preset.FirmwareVersion, &preset.BoardTarget, &preset.BoardName,
+		pq.Array(&preset.RemovedSettings), &parsedTuning, &preset.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get build preset: %w", err)
+	}
+
+	if len(parsedTuning) > 0 {
+		preset.ParsedTuning = &models.ParsedTuning{}
+		if err := json.Unmarshal(parsedTuning, preset.ParsedTuning); err != nil {
+			preset.ParsedTuning = nil
+		}
+	}
+	return preset, nil
+}
+
+// Delete removes the preset from a build, reporting whether it had one
+func (s *BuildPresetStore) Delete(ctx context.Context, buildID string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM build_presets WHERE build_id = $1`, buildID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete build preset: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
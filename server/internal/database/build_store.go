@@ -14,7 +14,8 @@ import (
 
 // BuildStore handles build persistence.
 type BuildStore struct {
-	db *DB
+	db    *DB
+	index SearchIndex
 }
 
 // NewBuildStore creates a new build store.
@@ -22,6 +23,11 @@ func NewBuildStore(db *DB) *BuildStore {
 	return &BuildStore{db: db}
 }
 
+// SetSearchIndex routes public build text searches to an external index
+func (s *BuildStore) SetSearchIndex(index SearchIndex) {
+	s.index = index
+}
+
 // Create inserts a build and optional parts.
 func (s *BuildStore) Create(
 	ctx context.Context,
@@ -90,11 +96,16 @@ func (s *BuildStore) ListByOwner(ctx context.Context, ownerUserID string, params
 		params.Offset = 0
 	}
 
+	// Archived drafts are listed on their own
+	statusFilter := "b.status IN ('DRAFT', 'PENDING_REVIEW', 'PUBLISHED', 'UNPUBLISHED')"
+	if params.Archived {
+		statusFilter = "b.status = 'ARCHIVED'"
+	}
+
 	countQuery := `
 		SELECT COUNT(*)
 		FROM builds b
-		WHERE b.owner_user_id = $1 AND b.status IN ('DRAFT', 'PENDING_REVIEW', 'PUBLISHED', 'UNPUBLISHED')
-	`
+		WHERE b.owner_user_id = $1 AND ` + statusFilter
 	var totalCount int
 	if err := s.db.QueryRowContext(ctx, countQuery, ownerUserID).Scan(&totalCount); err != nil {
 		return nil, fmt.Errorf("failed to count owner builds: %w", err)
@@ -103,6 +114,7 @@ func (s *BuildStore) ListByOwner(ctx context.Context, ownerUserID string, params
 	query := `
 		SELECT
 			b.id,
+			b.tenant_id,
 			b.owner_user_id,
 			b.image_asset_id,
 			b.status,
@@ -110,17 +122,19 @@ func (s *BuildStore) ListByOwner(ctx context.Context, ownerUserID string, params
 			b.expires_at,
 			b.title,
 			b.description,
+			b.video_url,
 			b.source_aircraft_id,
 			b.created_at,
 			b.updated_at,
 			b.published_at,
+			b.view_count,
 			u.id,
 			u.call_sign,
 			COALESCE(NULLIF(u.display_name, ''), NULLIF(u.google_name, ''), NULLIF(u.call_sign, ''), 'Pilot'),
 			COALESCE(u.profile_visibility, 'public') = 'public'
 		FROM builds b
 		LEFT JOIN users u ON b.owner_user_id = u.id
-		WHERE b.owner_user_id = $1 AND b.status IN ('DRAFT', 'PENDING_REVIEW', 'PUBLISHED', 'UNPUBLISHED')
+		WHERE b.owner_user_id = $1 AND ` + statusFilter + `
 		ORDER BY b.updated_at DESC
 		LIMIT $2 OFFSET $3
 	`
@@ -165,10 +179,36 @@ func (s *BuildStore) ListPublic(ctx context.Context, params models.BuildListPara
 	if params.Offset < 0 {
 		params.Offset = 0
 	}
+	params.Query = strings.TrimSpace(params.Query)
+
+	// The index knows nothing of frame or ecosystem filters or group
+	// membership
+	if s.index != nil && params.Query != "" && params.IDs == nil && strings.TrimSpace(params.FrameFilter) == "" &&
+		params.RCProtocol == "" && params.VideoSystem == "" && params.OwnerUserIDs == nil {
+		if response, err := s.listPublicFromIndex(ctx, params); err == nil {
+			return response, nil
+		}
+	}
 
 	conditions := []string{"b.status = 'PUBLISHED'"}
 	args := []interface{}{}
 	argIndex := 1
+	orderBy := "b.published_at DESC NULLS LAST, b.created_at DESC"
+	if params.Sort == models.BuildSortPopular {
+		orderBy = "b.view_count DESC, " + orderBy
+	}
+
+	if params.IDs != nil {
+		conditions = append(conditions, fmt.Sprintf("b.id::text = ANY($%d)", argIndex))
+		orderBy = fmt.Sprintf("array_position($%d::text[], b.id::text)", argIndex)
+		args = append(args, pq.Array(params.IDs))
+		argIndex++
+	} else if params.Query != "" {
+		conditions = append(conditions, fmt.Sprintf("b.search_vector @@ websearch_to_tsquery('english', $%d)", argIndex))
+		orderBy = fmt.Sprintf("ts_rank(b.search_vector, websearch_to_tsquery('english', $%d)) DESC, ", argIndex) + orderBy
+		args = append(args, params.Query)
+		argIndex++
+	}
 
 	if strings.TrimSpace(params.FrameFilter) != "" {
 		conditions = append(conditions, fmt.Sprintf(`
@@ -190,6 +230,37 @@ func (s *BuildStore) ListPublic(ctx context.Context, params models.BuildListPara
 		argIndex++
 	}
 
+	if params.RCProtocol != "" {
+		conditions = append(conditions, fmt.Sprintf(`
+			EXISTS (
+				SELECT 1
+				FROM build_parts bp
+				JOIN gear_catalog_ecosystems ge ON ge.catalog_id = bp.catalog_item_id
+				WHERE bp.build_id = b.id AND ge.rc_protocol = $%d
+			)
+		`, argIndex))
+		args = append(args, params.RCProtocol)
+		argIndex++
+	}
+	if params.VideoSystem != "" {
+		conditions = append(conditions, fmt.Sprintf(`
+			EXISTS (
+				SELECT 1
+				FROM build_parts bp
+				JOIN gear_catalog_ecosystems ge ON ge.catalog_id = bp.catalog_item_id
+				WHERE bp.build_id = b.id AND ge.video_system = $%d
+			)
+		`, argIndex))
+		args = append(args, params.VideoSystem)
+		argIndex++
+	}
+
+	if params.OwnerUserIDs != nil {
+		conditions = append(conditions, fmt.Sprintf("b.owner_user_id = ANY($%d::uuid[])", argIndex))
+		args = append(args, pq.Array(params.OwnerUserIDs))
+		argIndex++
+	}
+
 	whereClause := strings.Join(conditions, " AND ")
 
 	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM builds b WHERE %s`, whereClause)
@@ -201,6 +272,7 @@ func (s *BuildStore) ListPublic(ctx context.Context, params models.BuildListPara
 	query := fmt.Sprintf(`
 		SELECT
 			b.id,
+			b.tenant_id,
 			b.owner_user_id,
 			b.image_asset_id,
 			b.status,
@@ -208,10 +280,12 @@ func (s *BuildStore) ListPublic(ctx context.Context, params models.BuildListPara
 			b.expires_at,
 			b.title,
 			b.description,
+			b.video_url,
 			b.source_aircraft_id,
 			b.created_at,
 			b.updated_at,
 			b.published_at,
+			b.view_count,
 			u.id,
 			u.call_sign,
 			COALESCE(NULLIF(u.display_name, ''), NULLIF(u.google_name, ''), NULLIF(u.call_sign, ''), 'Pilot'),
@@ -219,9 +293,9 @@ func (s *BuildStore) ListPublic(ctx context.Context, params models.BuildListPara
 		FROM builds b
 		LEFT JOIN users u ON b.owner_user_id = u.id
 		WHERE %s
-		ORDER BY b.published_at DESC NULLS LAST, b.created_at DESC
+		ORDER BY %s
 		LIMIT $%d OFFSET $%d
-	`, whereClause, argIndex, argIndex+1)
+	`, whereClause, orderBy, argIndex, argIndex+1)
 
 	args = append(args, params.Limit, params.Offset)
 
@@ -249,9 +323,45 @@ func (s *BuildStore) ListPublic(ctx context.Context, params models.BuildListPara
 		TotalCount:  totalCount,
 		Sort:        params.Sort,
 		FrameFilter: strings.TrimSpace(params.FrameFilter),
+		Query:       params.Query,
+		RCProtocol:  params.RCProtocol,
+		VideoSystem: params.VideoSystem,
 	}, nil
 }
 
+// listPublicFromIndex matches a text query in the search index and loads
+// the matching page of builds in the index's relevance order. The index
+// holds every tenant's builds, so the query is filtered to ctx's tenant
+// for the page and total to match what ListPublic can load.
+func (s *BuildStore) listPublicFromIndex(ctx context.Context, params models.BuildListParams) (*models.BuildListResponse, error) {
+	query := models.SearchIndexQuery{
+		Collection: models.SearchCollectionBuilds,
+		Query:      params.Query,
+		Limit:      params.Limit,
+		Offset:     params.Offset,
+	}
+	if scope := tenantScope(ctx); scope != allTenantsScope {
+		query.Filters = map[string]string{"tenantId": scope}
+	}
+	result, err := s.index.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := params
+	page.IDs = result.IDs
+	if page.IDs == nil {
+		page.IDs = []string{}
+	}
+	page.Offset = 0
+	response, err := s.ListPublic(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	response.TotalCount = result.Total
+	return response, nil
+}
+
 // GetByID returns a build without owner/public filtering.
 func (s *BuildStore) GetByID(ctx context.Context, id string) (*models.Build, error) {
 	query := baseBuildSelect + ` WHERE b.id = $1`
@@ -313,6 +423,43 @@ func (s *BuildStore) GetTempByToken(ctx context.Context, token string) (*models.
 	return build, nil
 }
 
+// GetTempByViewToken fetches an unexpired temp build by its read-only token.
+func (s *BuildStore) GetTempByViewToken(ctx context.Context, viewToken string) (*models.Build, error) {
+	query := baseBuildSelect + `
+		WHERE b.view_token = $1
+		  AND b.status = 'TEMP' AND (b.expires_at IS NULL OR b.expires_at > NOW())`
+	build, err := s.scanBuild(ctx, query, viewToken)
+	if err != nil || build == nil {
+		return build, err
+	}
+	if err := s.attachParts(ctx, []*models.Build{build}); err != nil {
+		return nil, err
+	}
+	s.setMainImageURLs([]*models.Build{build}, false)
+	return build, nil
+}
+
+// IssueTempViewToken gives the unexpired temp build with token the read-only
+// token viewToken, unless it already has one. Returns the build's view token,
+// or "" if there is no such build or an edit has replaced it with a newer
+// revision: the link would be stuck on the stale one.
+func (s *BuildStore) IssueTempViewToken(ctx context.Context, token string, viewToken string) (string, error) {
+	var issued string
+	err := s.db.QueryRowContext(ctx, `
+		UPDATE builds SET view_token = COALESCE(view_token, $2)
+		WHERE token = $1 AND status = 'TEMP' AND (expires_at IS NULL OR expires_at > NOW())
+		  AND replaced_at IS NULL
+		RETURNING view_token
+	`, token, viewToken).Scan(&issued)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to issue temp build view token: %w", err)
+	}
+	return issued, nil
+}
+
 // Update updates mutable build fields and optionally replaces parts.
 func (s *BuildStore) Update(ctx context.Context, id string, ownerUserID string, params models.UpdateBuildParams) (*models.Build, error) {
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -335,6 +482,11 @@ func (s *BuildStore) Update(ctx context.Context, id string, ownerUserID string,
 		args = append(args, strings.TrimSpace(*params.Description))
 		argIndex++
 	}
+	if params.VideoURL != nil {
+		setClauses = append(setClauses, fmt.Sprintf("video_url = $%d", argIndex))
+		args = append(args, nullString(*params.VideoURL))
+		argIndex++
+	}
 
 	query := fmt.Sprintf(`
 		UPDATE builds
@@ -390,17 +542,54 @@ func (s *BuildStore) UpdateTempByToken(ctx context.Context, token string, params
 		parts = params.Parts
 	}
 
-	return s.Create(
-		ctx,
-		build.OwnerUserID,
-		models.BuildStatusTemp,
-		title,
-		description,
-		build.SourceAircraftID,
-		nextToken,
-		build.ExpiresAt,
-		parts,
-	)
+	// The view-only link moves to the new revision, so it keeps showing the
+	// latest version of the build, and the old revision is marked replaced
+	// so it can't be given another
+	var next *models.Build
+	err = s.db.RunInTx(ctx, func(ctx context.Context) error {
+		created, err := s.Create(
+			ctx,
+			build.OwnerUserID,
+			models.BuildStatusTemp,
+			title,
+			description,
+			build.SourceAircraftID,
+			nextToken,
+			build.ExpiresAt,
+			parts,
+		)
+		if err != nil {
+			return err
+		}
+		next = created
+
+		// RETURNING sees the row after the update, so the old token comes
+		// from a locked read of the row before it
+		var viewToken sql.NullString
+		err = s.db.QueryRowContext(ctx, `
+			UPDATE builds b SET view_token = NULL, replaced_at = NOW()
+			FROM (SELECT id, view_token FROM builds WHERE id = $1 FOR UPDATE) old
+			WHERE b.id = old.id
+			RETURNING old.view_token
+		`, build.ID).Scan(&viewToken)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to move temp build view token: %w", err)
+		}
+		if !viewToken.Valid || viewToken.String == "" {
+			return nil
+		}
+		if _, err := s.db.ExecContext(ctx, `UPDATE builds SET view_token = $2 WHERE id = $1`, created.ID, viewToken.String); err != nil {
+			return fmt.Errorf("failed to move temp build view token: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return next, nil
 }
 
 // ShareTempByToken promotes a temp build token to a permanent shared link.
@@ -454,15 +643,38 @@ func (s *BuildStore) SetStatus(ctx context.Context, id string, ownerUserID strin
 			SET status = 'UNPUBLISHED', published_at = NULL, updated_at = NOW()
 			WHERE id = $1 AND owner_user_id = $2 AND status = 'PUBLISHED'
 		`
+	case models.BuildStatusArchived:
+		query = `
+			UPDATE builds
+			SET status = 'ARCHIVED', updated_at = NOW()
+			WHERE id = $1 AND owner_user_id = $2 AND status = 'DRAFT'
+		`
+	case models.BuildStatusDraft:
+		// Only archived drafts go back to being drafts
+		query = `
+			UPDATE builds
+			SET status = 'DRAFT', updated_at = NOW()
+			WHERE id = $1 AND owner_user_id = $2 AND status = 'ARCHIVED'
+		`
 	default:
 		return nil, fmt.Errorf("unsupported status transition to %q", status)
 	}
 
-	result, err := s.db.ExecContext(ctx, query, id, ownerUserID)
+	var rows int64
+	err := s.db.RunInTx(ctx, func(ctx context.Context) error {
+		result, err := s.db.ExecContext(ctx, query, id, ownerUserID)
+		if err != nil {
+			return fmt.Errorf("failed to update build status: %w", err)
+		}
+		rows, _ = result.RowsAffected()
+		if rows == 0 || status != models.BuildStatusPublished {
+			return nil
+		}
+		return enqueueDomainEvent(ctx, s.db, models.DomainEventBuildPublished, id, models.BuildPublishedPayload{BuildID: id, OwnerUserID: ownerUserID})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update build status: %w", err)
+		return nil, err
 	}
-	rows, _ := result.RowsAffected()
 	if rows == 0 {
 		return nil, nil
 	}
@@ -510,30 +722,31 @@ func (s *BuildStore) SetImage(ctx context.Context, id string, ownerUserID string
 // GetImageForOwner loads approved build image bytes for an owner-visible build.
 func (s *BuildStore) GetImageForOwner(ctx context.Context, id string, ownerUserID string) ([]byte, error) {
 	query := `
-		SELECT ia.image_bytes
+		SELECT ia.image_bytes, ia.id, ia.storage_tier
 		FROM builds b
 		JOIN image_assets ia ON ia.id = b.image_asset_id AND ia.status = 'APPROVED'
 		WHERE b.id = $1
 		  AND b.owner_user_id = $2
-		  AND b.status IN ('DRAFT', 'PENDING_REVIEW', 'PUBLISHED', 'UNPUBLISHED')
+		  AND b.status IN ('DRAFT', 'PENDING_REVIEW', 'PUBLISHED', 'UNPUBLISHED', 'ARCHIVED')
 		  AND b.image_asset_id IS NOT NULL
 	`
 
 	var imageData []byte
-	err := s.db.QueryRowContext(ctx, query, id, ownerUserID).Scan(&imageData)
+	var imageID, tier sql.NullString
+	err := s.db.QueryRowContext(ctx, query, id, ownerUserID).Scan(&imageData, &imageID, &tier)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get build image: %w", err)
 	}
-	return imageData, nil
+	return s.db.imageBytes(ctx, imageID, tier, imageData)
 }
 
 // GetPublicImage loads approved build image bytes for a published build.
 func (s *BuildStore) GetPublicImage(ctx context.Context, id string) ([]byte, error) {
 	query := `
-		SELECT ia.image_bytes
+		SELECT ia.image_bytes, ia.id, ia.storage_tier
 		FROM builds b
 		JOIN image_assets ia ON ia.id = b.image_asset_id AND ia.status = 'APPROVED'
 		WHERE b.id = $1
@@ -542,14 +755,15 @@ func (s *BuildStore) GetPublicImage(ctx context.Context, id string) ([]byte, err
 	`
 
 	var imageData []byte
-	err := s.db.QueryRowContext(ctx, query, id).Scan(&imageData)
+	var imageID, tier sql.NullString
+	err := s.db.QueryRowContext(ctx, query, id).Scan(&imageData, &imageID, &tier)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get public build image: %w", err)
 	}
-	return imageData, nil
+	return s.db.imageBytes(ctx, imageID, tier, imageData)
 }
 
 // DeleteImage removes a build image and returns any previous image asset ID.
@@ -591,7 +805,7 @@ func (s *BuildStore) DeleteImage(ctx context.Context, id string, ownerUserID str
 func (s *BuildStore) Delete(ctx context.Context, id string, ownerUserID string) (bool, error) {
 	result, err := s.db.ExecContext(
 		ctx,
-		`DELETE FROM builds WHERE id = $1 AND owner_user_id = $2 AND status IN ('DRAFT', 'PENDING_REVIEW', 'PUBLISHED', 'UNPUBLISHED')`,
+		`DELETE FROM builds WHERE id = $1 AND owner_user_id = $2 AND status IN ('DRAFT', 'PENDING_REVIEW', 'PUBLISHED', 'UNPUBLISHED', 'ARCHIVED')`,
 		id,
 		ownerUserID,
 	)
@@ -602,6 +816,33 @@ func (s *BuildStore) Delete(ctx context.Context, id string, ownerUserID string)
 	return rowsAffected > 0, nil
 }
 
+// CountActiveDrafts returns how many drafts the owner has that aren't archived.
+func (s *BuildStore) CountActiveDrafts(ctx context.Context, ownerUserID string) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM builds WHERE owner_user_id = $1 AND status = 'DRAFT'`,
+		ownerUserID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count active drafts: %w", err)
+	}
+	return count, nil
+}
+
+// CountOutstandingTemp returns how many temp builds haven't expired yet.
+func (s *BuildStore) CountOutstandingTemp(ctx context.Context) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM builds WHERE status = 'TEMP' AND (expires_at IS NULL OR expires_at > NOW())`,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count outstanding temp builds: %w", err)
+	}
+	return count, nil
+}
+
 // DeleteExpiredTemp deletes temp builds expired at or before cutoff.
 func (s *BuildStore) DeleteExpiredTemp(ctx context.Context, cutoff time.Time) (int64, error) {
 	result, err := s.db.ExecContext(
@@ -668,6 +909,7 @@ func (s *BuildStore) ListForModeration(ctx context.Context, params models.BuildM
 	query := fmt.Sprintf(`
 		SELECT
 			b.id,
+			b.tenant_id,
 			b.owner_user_id,
 			b.image_asset_id,
 			b.status,
@@ -675,10 +917,12 @@ func (s *BuildStore) ListForModeration(ctx context.Context, params models.BuildM
 			b.expires_at,
 			b.title,
 			b.description,
+			b.video_url,
 			b.source_aircraft_id,
 			b.created_at,
 			b.updated_at,
 			b.published_at,
+			b.view_count,
 			u.id,
 			u.call_sign,
 			COALESCE(NULLIF(u.display_name, ''), NULLIF(u.google_name, ''), NULLIF(u.call_sign, ''), 'Pilot'),
@@ -710,6 +954,9 @@ func (s *BuildStore) ListForModeration(ctx context.Context, params models.BuildM
 	if err := s.attachParts(ctx, buildPtrs); err != nil {
 		return nil, err
 	}
+	if err := s.attachModerationState(ctx, buildPtrs); err != nil {
+		return nil, err
+	}
 	s.setAdminMainImageURLs(buildPtrs)
 
 	return &models.BuildListResponse{
@@ -755,6 +1002,11 @@ func (s *BuildStore) UpdateForModeration(ctx context.Context, id string, params
 		args = append(args, strings.TrimSpace(*params.Description))
 		argIndex++
 	}
+	if params.VideoURL != nil {
+		setClauses = append(setClauses, fmt.Sprintf("video_url = $%d", argIndex))
+		args = append(args, nullString(*params.VideoURL))
+		argIndex++
+	}
 
 	query := fmt.Sprintf(`
 		UPDATE builds
@@ -822,7 +1074,7 @@ func (s *BuildStore) SetImageForModeration(ctx context.Context, id string, image
 // GetImageForModeration loads approved image bytes for admin moderation views.
 func (s *BuildStore) GetImageForModeration(ctx context.Context, id string) ([]byte, error) {
 	query := `
-		SELECT ia.image_bytes
+		SELECT ia.image_bytes, ia.id, ia.storage_tier
 		FROM builds b
 		JOIN image_assets ia ON ia.id = b.image_asset_id AND ia.status = 'APPROVED'
 		WHERE b.id = $1
@@ -831,14 +1083,15 @@ func (s *BuildStore) GetImageForModeration(ctx context.Context, id string) ([]by
 	`
 
 	var imageData []byte
-	err := s.db.QueryRowContext(ctx, query, id).Scan(&imageData)
+	var imageID, tier sql.NullString
+	err := s.db.QueryRowContext(ctx, query, id).Scan(&imageData, &imageID, &tier)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get moderation build image: %w", err)
 	}
-	return imageData, nil
+	return s.db.imageBytes(ctx, imageID, tier, imageData)
 }
 
 // DeleteImageForModeration removes a build image and returns any previous asset ID.
@@ -875,23 +1128,73 @@ func (s *BuildStore) DeleteImageForModeration(ctx context.Context, id string) (s
 
 // ApproveForModeration publishes a build from the pending moderation queue.
 func (s *BuildStore) ApproveForModeration(ctx context.Context, id string) (*models.Build, error) {
-	result, err := s.db.ExecContext(
-		ctx,
-		`UPDATE builds SET status = 'PUBLISHED', published_at = NOW(), updated_at = NOW() WHERE id = $1 AND status = 'PENDING_REVIEW'`,
-		id,
-	)
+	var ownerUserID sql.NullString
+	err := s.db.RunInTx(ctx, func(ctx context.Context) error {
+		err := s.db.QueryRowContext(
+			ctx,
+			`UPDATE builds SET status = 'PUBLISHED', published_at = NOW(), updated_at = NOW() WHERE id = $1 AND status = 'PENDING_REVIEW'
+			RETURNING owner_user_id`,
+			id,
+		).Scan(&ownerUserID)
+		if err != nil {
+			return err
+		}
+		return enqueueDomainEvent(ctx, s.db, models.DomainEventBuildPublished, id, models.BuildPublishedPayload{BuildID: id, OwnerUserID: ownerUserID.String})
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to approve moderation build: %w", err)
 	}
-	rows, _ := result.RowsAffected()
-	if rows == 0 {
-		return nil, nil
-	}
 
 	return s.GetForModeration(ctx, id)
 }
 
-func (s *BuildStore) replacePartsTx(ctx context.Context, tx *sql.Tx, buildID string, parts []models.BuildPartInput) error {
+// attachModerationState loads the unexpired moderator assignments of builds
+// and how long they've been queued
+func (s *BuildStore) attachModerationState(ctx context.Context, builds []*models.Build) error {
+	if len(builds) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(builds))
+	byID := make(map[string]*models.Build, len(builds))
+	for _, build := range builds {
+		ids = append(ids, build.ID)
+		byID[build.ID] = build
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, assigned_to::text, assigned_at, queued_at FROM builds
+		WHERE id::text = ANY($1) AND (assigned_to IS NOT NULL OR queued_at IS NOT NULL)
+	`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to load build moderation state: %w", err)
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	for rows.Next() {
+		var id string
+		var assignedTo sql.NullString
+		var assignedAt, queuedAt sql.NullTime
+		if err := rows.Scan(&id, &assignedTo, &assignedAt, &queuedAt); err != nil {
+			return fmt.Errorf("failed to scan build moderation state: %w", err)
+		}
+		build := byID[id]
+		if build == nil {
+			continue
+		}
+		build.Assignment = assignmentFromColumns(assignedTo, assignedAt)
+		if queuedAt.Valid {
+			build.QueuedAt = &queuedAt.Time
+			build.QueueAgeSeconds = models.QueueAge(build.QueuedAt, now)
+		}
+	}
+	return rows.Err()
+}
+
+func (s *BuildStore) replacePartsTx(ctx context.Context, tx *Tx, buildID string, parts []models.BuildPartInput) error {
 	if _, err := tx.ExecContext(ctx, `DELETE FROM build_parts WHERE build_id = $1`, buildID); err != nil {
 		return fmt.Errorf("failed to clear build parts: %w", err)
 	}
@@ -944,51 +1247,64 @@ func (s *BuildStore) scanBuild(ctx context.Context, query string, args ...interf
 	return build, nil
 }
 
+// attachParts loads parts for every build in a single round trip (build_id = ANY(...))
+// joined to gear_catalog, so list pages cost one query regardless of page size.
 func (s *BuildStore) attachParts(ctx context.Context, builds []*models.Build) error {
 	if len(builds) == 0 {
 		return nil
 	}
 
 	ids := make([]string, 0, len(builds))
-	idToIndex := make(map[string]int, len(builds))
 	for i := range builds {
 		ids = append(ids, builds[i].ID)
-		idToIndex[builds[i].ID] = i
 	}
 
-	query := `
-		SELECT
-			bp.id,
-			bp.build_id,
-			bp.gear_type,
-			bp.catalog_item_id,
-			bp.position,
-			bp.notes,
-			bp.created_at,
-			bp.updated_at,
-			gc.id,
-			gc.gear_type,
-			gc.brand,
-			gc.model,
-			gc.variant,
-			gc.status,
-			CASE
-				WHEN (gc.image_asset_id IS NOT NULL OR gc.image_data IS NOT NULL) AND COALESCE(gc.image_status, 'missing') IN ('approved', 'scanned')
-					THEN '/api/gear-catalog/' || gc.id || '/image?v=' || (EXTRACT(EPOCH FROM COALESCE(gc.image_curated_at, gc.updated_at))*1000)::bigint
-				ELSE NULL
-			END AS image_url
-		FROM build_parts bp
-		LEFT JOIN gear_catalog gc ON gc.id = bp.catalog_item_id
-		WHERE bp.build_id = ANY($1::uuid[])
-		ORDER BY bp.build_id, bp.gear_type, bp.position
-	`
-
-	rows, err := s.db.QueryContext(ctx, query, pq.Array(ids))
+	rows, err := s.db.QueryContext(ctx, buildPartsByBuildIDsQuery, pq.Array(ids))
 	if err != nil {
 		return fmt.Errorf("failed to load build parts: %w", err)
 	}
 	defer rows.Close()
 
+	parts, err := scanBuildPartRows(rows)
+	if err != nil {
+		return err
+	}
+
+	assignBuildParts(builds, parts)
+	return nil
+}
+
+const buildPartsByBuildIDsQuery = `
+	SELECT
+		bp.id,
+		bp.build_id,
+		bp.gear_type,
+		bp.catalog_item_id,
+		bp.position,
+		bp.notes,
+		bp.created_at,
+		bp.updated_at,
+		gc.id,
+		gc.gear_type,
+		gc.brand,
+		gc.model,
+		gc.variant,
+		gc.status,
+		gc.msrp,
+		gc.specs,
+		CASE
+			WHEN (gc.image_asset_id IS NOT NULL OR gc.image_data IS NOT NULL) AND COALESCE(gc.image_status, 'missing') IN ('approved', 'scanned')
+				THEN '/api/gear-catalog/' || gc.id || '/image?v=' || (EXTRACT(EPOCH FROM COALESCE(gc.image_curated_at, gc.updated_at))*1000)::bigint
+			ELSE NULL
+		END AS image_url
+	FROM build_parts bp
+	LEFT JOIN gear_catalog gc ON gc.id = bp.catalog_item_id
+	WHERE bp.build_id = ANY($1::uuid[])
+	ORDER BY bp.build_id, bp.gear_type, bp.position
+`
+
+func scanBuildPartRows(rows *sql.Rows) ([]models.BuildPart, error) {
+	parts := make([]models.BuildPart, 0)
 	for rows.Next() {
 		var part models.BuildPart
 		var catalogItemID sql.NullString
@@ -999,6 +1315,8 @@ func (s *BuildStore) attachParts(ctx context.Context, builds []*models.Build) er
 		var catalogModel sql.NullString
 		var catalogVariant sql.NullString
 		var catalogStatus sql.NullString
+		var catalogMSRP sql.NullFloat64
+		var catalogSpecs []byte
 		var catalogImageURL sql.NullString
 
 		if err := rows.Scan(
@@ -1016,9 +1334,11 @@ func (s *BuildStore) attachParts(ctx context.Context, builds []*models.Build) er
 			&catalogModel,
 			&catalogVariant,
 			&catalogStatus,
+			&catalogMSRP,
+			&catalogSpecs,
 			&catalogImageURL,
 		); err != nil {
-			return fmt.Errorf("failed to scan build part: %w", err)
+			return nil, fmt.Errorf("failed to scan build part: %w", err)
 		}
 
 		part.CatalogItemID = catalogItemID.String
@@ -1033,9 +1353,31 @@ func (s *BuildStore) attachParts(ctx context.Context, builds []*models.Build) er
 				Variant:  catalogVariant.String,
 				Status:   models.NormalizeCatalogStatus(models.CatalogItemStatus(catalogStatus.String)),
 				ImageURL: catalogImageURL.String,
+				Specs:    catalogSpecs,
+			}
+			if catalogMSRP.Valid {
+				msrp := catalogMSRP.Float64
+				part.CatalogItem.MSRP = &msrp
 			}
 		}
 
+		parts = append(parts, part)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load build parts: %w", err)
+	}
+	return parts, nil
+}
+
+// assignBuildParts distributes batch-loaded parts onto their builds and derives the
+// frame-based main image for builds without an uploaded image.
+func assignBuildParts(builds []*models.Build, parts []models.BuildPart) {
+	idToIndex := make(map[string]int, len(builds))
+	for i := range builds {
+		idToIndex[builds[i].ID] = i
+	}
+
+	for _, part := range parts {
 		idx, ok := idToIndex[part.BuildID]
 		if !ok {
 			continue
@@ -1051,8 +1393,6 @@ func (s *BuildStore) attachParts(ctx context.Context, builds []*models.Build) er
 			}
 		}
 	}
-
-	return nil
 }
 
 func (s *BuildStore) setMainImageURLs(builds []*models.Build, isPublic bool) {
@@ -1086,6 +1426,7 @@ func (s *BuildStore) setAdminMainImageURLs(builds []*models.Build) {
 var baseBuildSelect = `
 	SELECT
 		b.id,
+		b.tenant_id,
 		b.owner_user_id,
 		b.image_asset_id,
 		b.status,
@@ -1093,10 +1434,12 @@ var baseBuildSelect = `
 		b.expires_at,
 		b.title,
 		b.description,
+		b.video_url,
 		b.source_aircraft_id,
 		b.created_at,
 		b.updated_at,
 		b.published_at,
+		b.view_count,
 		u.id,
 		u.call_sign,
 		COALESCE(NULLIF(u.display_name, ''), NULLIF(u.google_name, ''), NULLIF(u.call_sign, ''), 'Pilot'),
@@ -1126,6 +1469,7 @@ func scanBuildRow(scanner interface {
 	var token sql.NullString
 	var expiresAt sql.NullTime
 	var description sql.NullString
+	var videoURL sql.NullString
 	var sourceAircraftID sql.NullString
 	var publishedAt sql.NullTime
 
@@ -1136,6 +1480,7 @@ func scanBuildRow(scanner interface {
 
 	err := scanner.Scan(
 		&item.ID,
+		&item.TenantID,
 		&ownerUserID,
 		&imageAssetID,
 		&item.Status,
@@ -1143,10 +1488,12 @@ func scanBuildRow(scanner interface {
 		&expiresAt,
 		&item.Title,
 		&description,
+		&videoURL,
 		&sourceAircraftID,
 		&item.CreatedAt,
 		&item.UpdatedAt,
 		&publishedAt,
+		&item.ViewCount,
 		&pilotUserID,
 		&pilotCallSign,
 		&pilotDisplayName,
@@ -1160,6 +1507,7 @@ func scanBuildRow(scanner interface {
 	item.ImageAssetID = imageAssetID.String
 	item.Token = token.String
 	item.Description = description.String
+	item.VideoURL = videoURL.String
 	item.SourceAircraftID = sourceAircraftID.String
 	if expiresAt.Valid {
 		item.ExpiresAt = &expiresAt.Time
@@ -0,0 +1,287 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+// benchBuildPageSize mirrors the max page size accepted by the build list endpoints.
+const benchBuildPageSize = 100
+
+func TestAssignBuildParts(t *testing.T) {
+	builds := []*models.Build{{ID: "b1"}, {ID: "b2"}, {ID: "b3"}}
+	parts := []models.BuildPart{
+		{BuildID: "b1", GearType: models.GearTypeMotor},
+		{BuildID: "b1", GearType: models.GearTypeFrame, CatalogItem: &models.BuildCatalogItem{ImageURL: "/frame.png"}},
+		{BuildID: "b2", GearType: models.GearTypeFrame, CatalogItem: &models.BuildCatalogItem{}},
+		{BuildID: "unknown", GearType: models.GearTypeFC},
+	}
+
+	assignBuildParts(builds, parts)
+
+	if got := len(builds[0].Parts); got != 2 {
+		t.Fatalf("build b1 parts = %d, want 2", got)
+	}
+	if builds[0].MainImageURL != "/frame.png" {
+		t.Fatalf("build b1 main image = %q, want /frame.png", builds[0].MainImageURL)
+	}
+	if got := len(builds[1].Parts); got != 1 {
+		t.Fatalf("build b2 parts = %d, want 1", got)
+	}
+	if builds[1].MainImageURL != "" {
+		t.Fatalf("build b2 main image = %q, want empty", builds[1].MainImageURL)
+	}
+	if len(builds[2].Parts) != 0 {
+		t.Fatalf("build b3 should have no parts, got %d", len(builds[2].Parts))
+	}
+}
+
+func BenchmarkAssignBuildParts100Builds(b *testing.B) {
+	partTypes := []models.GearType{
+		models.GearTypeFrame, models.GearTypeMotor, models.GearTypeESC, models.GearTypeFC,
+		models.GearTypeVTX, models.GearTypeReceiver, models.GearTypeCamera, models.GearTypeProp,
+	}
+	parts := make([]models.BuildPart, 0, benchBuildPageSize*len(partTypes))
+	ids := make([]string, benchBuildPageSize)
+	for i := range ids {
+		ids[i] = uuid.NewString()
+		for _, gt := range partTypes {
+			parts = append(parts, models.BuildPart{
+				BuildID:     ids[i],
+				GearType:    gt,
+				CatalogItem: &models.BuildCatalogItem{ImageURL: "/api/gear-catalog/x/image"},
+			})
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		builds := make([]*models.Build, len(ids))
+		for j, id := range ids {
+			builds[j] = &models.Build{ID: id}
+		}
+		assignBuildParts(builds, parts)
+	}
+}
+
+// BenchmarkAttachParts100Builds measures the batched part loader against a live
+// database. Compare with BenchmarkAttachPartsPerBuild100Builds, which issues one
+// query per build the way list pages used to scale.
+func BenchmarkAttachParts100Builds(b *testing.B) {
+	store, builds := setupBuildPartsBenchmark(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resetBuildParts(builds)
+		if err := store.attachParts(ctx, builds); err != nil {
+			b.Fatalf("attachParts: %v", err)
+		}
+	}
+}
+
+func BenchmarkAttachPartsPerBuild100Builds(b *testing.B) {
+	store, builds := setupBuildPartsBenchmark(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resetBuildParts(builds)
+		for _, build := range builds {
+			if err := store.attachParts(ctx, []*models.Build{build}); err != nil {
+				b.Fatalf("attachParts: %v", err)
+			}
+		}
+	}
+}
+
+func resetBuildParts(builds []*models.Build) {
+	for _, build := range builds {
+		build.Parts = nil
+		build.MainImageURL = ""
+	}
+}
+
+// setupBuildPartsBenchmark seeds a page of builds with a full parts list each.
+// Skips when no test database is available.
+func setupBuildPartsBenchmark(b *testing.B) (*BuildStore, []*models.Build) {
+	b.Helper()
+
+	testDB := testutil.NewTestDB(b)
+	b.Cleanup(func() { testDB.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	prefix := "bench-" + uuid.NewString()[:8]
+	partTypes := []models.GearType{
+		models.GearTypeFrame, models.GearTypeMotor, models.GearTypeESC, models.GearTypeFC,
+		models.GearTypeVTX, models.GearTypeReceiver, models.GearTypeCamera, models.GearTypeProp,
+	}
+
+	catalogIDs := make(map[models.GearType]string, len(partTypes))
+	for _, gt := range partTypes {
+		var id string
+		err := testDB.QueryRowContext(ctx, `
+			INSERT INTO gear_catalog (gear_type, brand, model, canonical_key, status)
+			VALUES ($1, $2, $3, $4, 'published')
+			RETURNING id
+		`, gt, prefix, string(gt), fmt.Sprintf("%s|%s", prefix, gt)).Scan(&id)
+		if err != nil {
+			b.Fatalf("seed catalog item: %v", err)
+		}
+		catalogIDs[gt] = id
+	}
+
+	builds := make([]*models.Build, 0, benchBuildPageSize)
+	for i := 0; i < benchBuildPageSize; i++ {
+		var buildID string
+		if err := testDB.QueryRowContext(ctx,
+			`INSERT INTO builds (status, title) VALUES ('PUBLISHED', $1) RETURNING id`,
+			fmt.Sprintf("%s-%d", prefix, i),
+		).Scan(&buildID); err != nil {
+			b.Fatalf("seed build: %v", err)
+		}
+		for _, gt := range partTypes {
+			testDB.MustExec(ctx,
+				`INSERT INTO build_parts (build_id, gear_type, catalog_item_id) VALUES ($1, $2, $3)`,
+				buildID, gt, catalogIDs[gt],
+			)
+		}
+		builds = append(builds, &models.Build{ID: buildID})
+	}
+
+	b.Cleanup(func() {
+		cleanupCtx, cleanupCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cleanupCancel()
+		_, _ = testDB.ExecContext(cleanupCtx, `DELETE FROM builds WHERE title LIKE $1`, prefix+"-%")
+		_, _ = testDB.ExecContext(cleanupCtx, `DELETE FROM gear_catalog WHERE brand = $1`, prefix)
+	})
+
+	return NewBuildStore(&DB{DB: testDB.DB}), builds
+}
+
+func TestUpdateTempByToken_MovesViewToken(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Close()
+	store := NewBuildStore(&DB{DB: testDB.DB})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	prefix := "viewtoken-" + uuid.NewString()[:8]
+	defer testDB.ExecContext(context.Background(), `DELETE FROM builds WHERE title LIKE $1`, prefix+"%")
+
+	expires := time.Now().Add(time.Hour)
+	newTemp := func(name string) string {
+		t.Helper()
+		token := prefix + "-" + name + "-" + uuid.NewString()
+		if _, err := store.Create(ctx, "", models.BuildStatusTemp, prefix+" "+name, "", "", token, &expires, nil); err != nil {
+			t.Fatalf("create temp build: %v", err)
+		}
+		return token
+	}
+	edit := func(token string) (string, *models.Build) {
+		t.Helper()
+		title := prefix + " edited"
+		next := token + "-next"
+		build, err := store.UpdateTempByToken(ctx, token, models.UpdateBuildParams{Title: &title}, next)
+		if err != nil {
+			t.Fatalf("UpdateTempByToken() error = %v", err)
+		}
+		return next, build
+	}
+
+	shared := newTemp("shared")
+	viewToken := "view-" + uuid.NewString()
+	if issued, err := store.IssueTempViewToken(ctx, shared, viewToken); err != nil || issued != viewToken {
+		t.Fatalf("IssueTempViewToken() = %q, %v", issued, err)
+	}
+
+	_, edited := edit(shared)
+	viewed, err := store.GetTempByViewToken(ctx, viewToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if viewed == nil || viewed.ID != edited.ID {
+		t.Fatalf("view link shows %+v, want the edited revision %s", viewed, edited.ID)
+	}
+
+	// The replaced revision's edit token can't issue a link of its own
+	if issued, err := store.IssueTempViewToken(ctx, shared, "view-"+uuid.NewString()); err != nil || issued != "" {
+		t.Fatalf("IssueTempViewToken() on the replaced revision = %q, %v", issued, err)
+	}
+	stale := newTemp("stale")
+	edit(stale)
+	if issued, err := store.IssueTempViewToken(ctx, stale, "view-"+uuid.NewString()); err != nil || issued != "" {
+		t.Fatalf("IssueTempViewToken() on a revision replaced before any link = %q, %v", issued, err)
+	}
+
+	// Builds without a view link keep a NULL token, so editing two of them
+	// doesn't collide on the unique index
+	for _, name := range []string{"a", "b"} {
+		next, build := edit(newTemp(name))
+		var stored sql.NullString
+		if err := testDB.QueryRowContext(ctx, `SELECT view_token FROM builds WHERE id = $1`, build.ID).Scan(&stored); err != nil {
+			t.Fatal(err)
+		}
+		if stored.Valid {
+			t.Errorf("build %s view_token = %q, want NULL", name, stored.String)
+		}
+		edit(next)
+	}
+}
+
+type recordingSearchIndex struct {
+	queries []models.SearchIndexQuery
+}
+
+func (r *recordingSearchIndex) Search(ctx context.Context, query models.SearchIndexQuery) (*models.SearchIndexResult, error) {
+	r.queries = append(r.queries, query)
+	return &models.SearchIndexResult{}, nil
+}
+
+func TestListPublic_FiltersIndexByTenant(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Close()
+	store := NewBuildStore(&DB{DB: testDB.DB})
+	index := &recordingSearchIndex{}
+	store.SetSearchIndex(index)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	other := uuid.NewString()
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want map[string]string
+	}{
+		{name: "unscoped", ctx: ctx, want: map[string]string{"tenantId": DefaultTenantID}},
+		{name: "tenant", ctx: WithTenant(ctx, other), want: map[string]string{"tenantId": other}},
+		{name: "all tenants", ctx: AllTenants(ctx), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index.queries = nil
+			if _, err := store.ListPublic(tt.ctx, models.BuildListParams{Query: "freestyle", Limit: 10}); err != nil {
+				t.Fatalf("ListPublic() error = %v", err)
+			}
+			if len(index.queries) != 1 {
+				t.Fatalf("index searched %d times, want 1", len(index.queries))
+			}
+			if got := index.queries[0].Filters; fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("filters = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// BuildTemplateStore reads curated build templates: builds with status
+// TEMPLATE whose parts are placeholder slots with spec filters
+type BuildTemplateStore struct {
+	db *DB
+}
+
+// NewBuildTemplateStore creates a new build template store
+func NewBuildTemplateStore(db *DB) *BuildTemplateStore {
+	return &BuildTemplateStore{db: db}
+}
+
+// List returns every template with its slots, ordered by title
+func (s *BuildTemplateStore) List(ctx context.Context) ([]models.BuildTemplate, error) {
+	return s.list(ctx, "")
+}
+
+// Get returns one template, or nil if there is no template with that id
+func (s *BuildTemplateStore) Get(ctx context.Context, id string) (*models.BuildTemplate, error) {
+	templates, err := s.list(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(templates) == 0 {
+		return nil, nil
+	}
+	return &templates[0], nil
+}
+
+func (s *BuildTemplateStore) list(ctx context.Context, id string) ([]models.BuildTemplate, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT b.id, b.title, COALESCE(b.description, ''),
+		       bp.gear_type, bp.position, COALESCE(bp.notes, ''), COALESCE(bp.spec_filters, '[]'::jsonb)
+		FROM builds b
+		LEFT JOIN build_parts bp ON bp.build_id = b.id
+		WHERE b.status = 'TEMPLATE' AND ($1 = '' OR b.id::text = $1)
+		ORDER BY b.title, b.id, bp.gear_type, bp.position
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list build templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := make([]models.BuildTemplate, 0)
+	for rows.Next() {
+		var template models.BuildTemplate
+		var gearType sql.NullString
+		var position sql.NullInt64
+		var label string
+		var filters []byte
+		if err := rows.Scan(&template.ID, &template.Title, &template.Description, &gearType, &position, &label, &filters); err != nil {
+			return nil, fmt.Errorf("failed to scan build template: %w", err)
+		}
+		if n := len(templates); n == 0 || templates[n-1].ID != template.ID {
+			template.Slots = make([]models.BuildTemplateSlot, 0)
+			templates = append(templates, template)
+		}
+		if !gearType.Valid {
+			continue
+		}
+
+		slot := models.BuildTemplateSlot{
+			GearType: models.GearType(gearType.String),
+			Position: int(position.Int64),
+			Label:    label,
+		}
+		if err := json.Unmarshal(filters, &slot.SpecFilters); err != nil {
+			return nil, fmt.Errorf("failed to parse spec filters: %w", err)
+		}
+		if slot.SpecFilters == nil {
+			slot.SpecFilters = make([]models.BuildSpecFilter, 0)
+		}
+		current := &templates[len(templates)-1]
+		current.Slots = append(current.Slots, slot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list build templates: %w", err)
+	}
+	return templates, nil
+}
@@ -0,0 +1,217 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ErrPublishRuleNotFound is returned when a catalog publish rule doesn't exist
+var ErrPublishRuleNotFound = errors.New("publish rule not found")
+
+// CatalogPublishRuleStore persists catalog auto-publish rules and the audit
+// log of the items they published
+type CatalogPublishRuleStore struct {
+	db *DB
+}
+
+// NewCatalogPublishRuleStore creates a new catalog publish rule store
+func NewCatalogPublishRuleStore(db *DB) *CatalogPublishRuleStore {
+	return &CatalogPublishRuleStore{db: db}
+}
+
+const catalogPublishRuleColumns = `
+	id, name, enabled, priority, gear_types, min_published_submissions, min_reputation, required_specs,
+	require_image, require_description, COALESCE(updated_by_user_id::text, ''), created_at, updated_at
+`
+
+// ListRules returns every rule in evaluation order
+func (s *CatalogPublishRuleStore) ListRules(ctx context.Context) ([]models.CatalogPublishRule, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+catalogPublishRuleColumns+` FROM catalog_publish_rules ORDER BY priority, created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list publish rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := make([]models.CatalogPublishRule, 0)
+	for rows.Next() {
+		rule, err := scanCatalogPublishRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan publish rule: %w", err)
+		}
+		rules = append(rules, *rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list publish rules: %w", err)
+	}
+	return rules, nil
+}
+
+// CreateRule stores a new rule
+func (s *CatalogPublishRuleStore) CreateRule(ctx context.Context, adminUserID string, params models.SetCatalogPublishRuleParams) (*models.CatalogPublishRule, error) {
+	row := s.db.QueryRowContext(ctx, `
+		INSERT INTO catalog_publish_rules (name, enabled, priority, gear_types, min_published_submissions, min_reputation,
+			required_specs, require_image, require_description, updated_by_user_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING `+catalogPublishRuleColumns,
+		params.Name, params.Enabled, params.Priority, pq.Array(gearTypeStrings(params.GearTypes)), params.MinPublishedSubmissions,
+		params.MinReputation, pq.Array(params.RequiredSpecs), params.RequireImage, params.RequireDescription, nullString(adminUserID))
+	rule, err := scanCatalogPublishRule(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create publish rule: %w", err)
+	}
+	return rule, nil
+}
+
+// UpdateRule replaces a rule
+func (s *CatalogPublishRuleStore) UpdateRule(ctx context.Context, id, adminUserID string, params models.SetCatalogPublishRuleParams) (*models.CatalogPublishRule, error) {
+	row := s.db.QueryRowContext(ctx, `
+		UPDATE catalog_publish_rules SET
+			name = $2, enabled = $3, priority = $4, gear_types = $5, min_published_submissions = $6,
+			min_reputation = $7, required_specs = $8, require_image = $9, require_description = $10,
+			updated_by_user_id = $11, updated_at = NOW()
+		WHERE id::text = $1
+		RETURNING `+catalogPublishRuleColumns,
+		id, params.Name, params.Enabled, params.Priority, pq.Array(gearTypeStrings(params.GearTypes)), params.MinPublishedSubmissions,
+		params.MinReputation, pq.Array(params.RequiredSpecs), params.RequireImage, params.RequireDescription, nullString(adminUserID))
+	rule, err := scanCatalogPublishRule(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPublishRuleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update publish rule: %w", err)
+	}
+	return rule, nil
+}
+
+// DeleteRule removes a rule. Decisions it made keep the rule's name and
+// snapshot.
+func (s *CatalogPublishRuleStore) DeleteRule(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM catalog_publish_rules WHERE id::text = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete publish rule: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrPublishRuleNotFound
+	}
+	return nil
+}
+
+// CountPublishedSubmissions counts a user's catalog submissions that are
+// published, leaving out excludeItemID
+func (s *CatalogPublishRuleStore) CountPublishedSubmissions(ctx context.Context, userID, excludeItemID string) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM gear_catalog
+		WHERE created_by_user_id = $1 AND status = 'published' AND id::text <> $2
+	`, userID, excludeItemID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count published submissions: %w", err)
+	}
+	return count, nil
+}
+
+// Publish publishes a pending catalog item and records the decision in one
+// transaction. It returns false, and records nothing, when the item is no
+// longer pending.
+func (s *CatalogPublishRuleStore) Publish(ctx context.Context, decision *models.CatalogPublishDecision) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE gear_catalog SET status = 'published', updated_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+	`, decision.CatalogItemID)
+	if err != nil {
+		return false, fmt.Errorf("failed to publish catalog item: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return false, nil
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO catalog_publish_decisions (catalog_item_id, rule_id, rule_name, rule_snapshot, submitter_user_id, trigger)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, decided_at
+	`, decision.CatalogItemID, nullString(decision.RuleID), decision.RuleName, []byte(decision.Rule),
+		nullString(decision.SubmitterUserID), string(decision.Trigger)).Scan(&decision.ID, &decision.DecidedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to record publish decision: %w", err)
+	}
+
+	payload := models.CatalogPublishedPayload{CatalogItemID: decision.CatalogItemID, Via: "rule"}
+	if err := enqueueDomainEvent(ctx, tx, models.DomainEventCatalogPublished, decision.CatalogItemID, payload); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	s.db.invalidateTag(ctx, catalogSearchTag)
+	return true, nil
+}
+
+// ListDecisions returns the most recent auto-publish decisions, newest first
+func (s *CatalogPublishRuleStore) ListDecisions(ctx context.Context, limit, offset int) ([]models.CatalogPublishDecision, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, catalog_item_id, COALESCE(rule_id::text, ''), rule_name, rule_snapshot,
+		       COALESCE(submitter_user_id::text, ''), trigger, decided_at
+		FROM catalog_publish_decisions
+		ORDER BY decided_at DESC
+		LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list publish decisions: %w", err)
+	}
+	defer rows.Close()
+
+	decisions := make([]models.CatalogPublishDecision, 0)
+	for rows.Next() {
+		var decision models.CatalogPublishDecision
+		var snapshot []byte
+		if err := rows.Scan(&decision.ID, &decision.CatalogItemID, &decision.RuleID, &decision.RuleName, &snapshot,
+			&decision.SubmitterUserID, &decision.Trigger, &decision.DecidedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan publish decision: %w", err)
+		}
+		decision.Rule = snapshot
+		decisions = append(decisions, decision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list publish decisions: %w", err)
+	}
+	return decisions, nil
+}
+
+func scanCatalogPublishRule(row interface{ Scan(...interface{}) error }) (*models.CatalogPublishRule, error) {
+	var rule models.CatalogPublishRule
+	var gearTypes []string
+	err := row.Scan(&rule.ID, &rule.Name, &rule.Enabled, &rule.Priority, pq.Array(&gearTypes), &rule.MinPublishedSubmissions, &rule.MinReputation,
+		pq.Array(&rule.RequiredSpecs), &rule.RequireImage, &rule.RequireDescription, &rule.UpdatedByUserID, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	rule.GearTypes = make([]models.GearType, 0, len(gearTypes))
+	for _, gearType := range gearTypes {
+		rule.GearTypes = append(rule.GearTypes, models.GearType(gearType))
+	}
+	if rule.RequiredSpecs == nil {
+		rule.RequiredSpecs = []string{}
+	}
+	return &rule, nil
+}
+
+func gearTypeStrings(gearTypes []models.GearType) []string {
+	out := make([]string, len(gearTypes))
+	for i, gearType := range gearTypes {
+		out[i] = string(gearType)
+	}
+	return out
+}
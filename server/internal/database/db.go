@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
+
+	"github.com/johnrirwin/flyingforge/internal/cache"
 )
 
 // Config holds database configuration
@@ -37,10 +39,15 @@ func DefaultConfig() Config {
 	}
 }
 
-// DB wraps the sql.DB connection
+// DB wraps the sql.DB connection. Each query is scoped to the tenant in its
+// context (see tenant.go).
 type DB struct {
 	*sql.DB
 	config Config
+	stats  *QueryStats
+	cache  cache.Cache // caches tagged queries, see query_cache.go
+
+	imageRestorer ImageRestorer // restores archived image bytes, see image_archive_store.go
 }
 
 // New creates a new database connection
@@ -50,10 +57,11 @@ func New(config Config) (*DB, error) {
 		config.Host, config.Port, config.User, config.Password, config.Database, config.SSLMode,
 	)
 
-	db, err := sql.Open("postgres", dsn)
+	connector, err := pq.NewConnector(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
+	db := sql.OpenDB(tenantConnector{Connector: connector})
 
 	db.SetMaxOpenConns(config.MaxOpenConns)
 	db.SetMaxIdleConns(config.MaxIdleConns)
@@ -67,7 +75,16 @@ func New(config Config) (*DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &DB{DB: db, config: config}, nil
+	return &DB{DB: db, config: config, stats: NewQueryStats()}, nil
+}
+
+// QueryStats returns the timings of statements run through db, or nil when
+// there is no database
+func (db *DB) QueryStats() *QueryStats {
+	if db == nil {
+		return nil
+	}
+	return db.stats
 }
 
 // Close closes the database connection
@@ -124,8 +141,66 @@ func (db *DB) Migrate(ctx context.Context) error {
 		migrationBuilds,                                    // Adds user/public/temp builds with part mappings
 		migrationFeedItems,                                 // Adds persistent storage for aggregated feed/news items
 		migrationDropLegacyImageURLs,                       // Drops legacy image_url columns in favor of image_assets
+		migrationGearCatalogUsageCount,                     // Materializes usage_count maintained by inventory_items triggers
+		migrationGearCatalogSearchVector,                   // Adds weighted tsvector + compact trigram column for search ranking
+		migrationBrands,                                    // Canonical brands with aliases used to normalize catalog brand names
+		migrationCanonicalKeyV2,                            // Canonical key versioning, legacy key lookup, and collision tracking
+		migrationSyncChangeFeed,                            // Change sequence, tombstones, and client refs for offline sync
+		migrationPushDevices,                               // Device tokens for mobile push notifications
+		migrationImageQuotas,                               // Image byte sizes and per-user image quota overrides
+		migrationFeaturedContent,                           // Editorially scheduled featured builds and catalog items
+		migrationShortLinks,                                // Short share slugs for published builds and catalog items
+		migrationImageRescan,                               // Image review queue status and moderation re-scan jobs
+		migrationModerationPolicies,                        // Per-entity-type moderation thresholds and label allowlists
+		migrationGearImageCandidates,                       // Sourced catalog images waiting for admin approval
+		migrationEntityImages,                              // Ordered images per build and catalog item
+		migrationBuildVideo,                                // YouTube/Vimeo link on builds
+		migrationInventoryAttachments,                      // Receipts, manuals, and other files attached to inventory items
+		migrationAircraftRegistrations,                     // Aircraft registration, insurance, and remote ID tracking
+		migrationBatteryStorageState,                       // Charged-for-session state and storage reminders on batteries
+		migrationGroups,                                    // Clubs with memberships, invitations, and shared fleets
+		migrationEvents,                                    // Race days and meetups with pilot registration and check-in
+		migrationBuildPresets,                              // Sanitized Betaflight diffs shared with builds
+		migrationUserMerges,                                // Audit log of duplicate accounts merged by admins
+		migrationJWTSigningKeys,                            // Rotatable JWT signing keys, looked up by kid
+		migrationBuildHandoff,                              // Anonymous temp build sessions claimed by accounts at sign-in
+		migrationCatalogPublishRules,                       // Auto-publish rules for catalog submissions and their audit log
+		migrationPublishRuleReputation,                     // Minimum submitter reputation on auto-publish rules
+		migrationBuildModerationEvents,                     // Build approvals and rejections with moderator feedback
+		migrationModerationAssignments,                     // Moderator claims on pending gear items and builds
+		migrationModerationQueueAging,                      // When gear items and builds entered the moderation queue
+		migrationAnnouncements,                             // Admin announcements and per-user read tracking
+		migrationPolicyVersions,                            // Terms/privacy policy versions and user acceptances
+		migrationDataRetention,                             // Per-user retention settings for log data
+		migrationTenancy,                                   // White-label tenants and row-level tenant isolation
+		migrationRadioBackupStorage,                        // Which blob store holds each radio backup
+		migrationResumableUploads,                          // Chunked uploads that can resume after a dropped connection
+		migrationBlackboxLogs,                              // Uploaded blackbox files with per-flight summaries
+		migrationInventoryDisplayOverride,                  // Keep user-renamed inventory items out of catalog syncs
+		migrationInventoryArchive,                          // Archived (retired) inventory items
+		migrationViewCounts,                                // Page views of builds and catalog items
+		migrationHomeModules,                               // Admin-configured home page layout
+		migrationFeedSearch,                                // Full-text search over archived feed items
+		migrationLinkChecks,                                // Health of stored product and manufacturer links
+		migrationFeedFilters,                               // Admin-managed blocklists for the aggregated feed
+		migrationInactivityPolicy,                          // Inactive account cleanup policy and flags
+		migrationAccountAppeals,                            // Account status reasons and appeals
+		migrationDomainEventOutbox,                         // Domain event outbox
+		migrationBuildSearch,                               // Full-text search on public builds
+		migrationCatalogRecommendations,                    // Catalog item co-occurrence for recommendations
+		migrationBuildTemplates,                            // Curated build templates with placeholder slots
+		migrationEcosystemRegistry,                         // RC link and video system registry linked to catalog items
+		migrationFirmwareTargets,                           // Betaflight board target registry linked to FC configs and catalog items
+		migrationFCConfigOSDLayouts,                        // OSD element layouts parsed from FC config CLI dumps
+		migrationImageAssetDedup,                           // Content hashes and reference counts for deduplicated image assets
+		migrationImageArchive,                              // Cold storage tier for idle image assets
+		migrationSpecUnits,                                 // Original spec values and user unit preference
+		migrationBuildArchive,                              // Archived build drafts
+		migrationBuildViewTokens,                           // View-only links for temp builds
 	}
 
+	// Migrations backfill and rewrite rows of every tenant
+	ctx = AllTenants(ctx)
 	for i, migration := range migrations {
 		if _, err := db.ExecContext(ctx, migration); err != nil {
 			return fmt.Errorf("migration %d failed: %w", i+1, err)
@@ -895,9 +970,12 @@ BEGIN
     END IF;
 END $$;
 
+-- Every migration runs on each boot, so this is the only place the status
+-- list is defined: add new statuses here rather than re-adding the
+-- constraint in a later migration, which this one would then undo.
 ALTER TABLE builds
 ADD CONSTRAINT chk_builds_status
-CHECK (status IN ('TEMP', 'SHARED', 'DRAFT', 'PENDING_REVIEW', 'PUBLISHED', 'UNPUBLISHED'));
+CHECK (status IN ('TEMP', 'SHARED', 'DRAFT', 'PENDING_REVIEW', 'PUBLISHED', 'UNPUBLISHED', 'TEMPLATE', 'ARCHIVED'));
 
 CREATE TABLE IF NOT EXISTS build_parts (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -984,3 +1062,1328 @@ ALTER TABLE gear_catalog DROP COLUMN IF EXISTS image_url;
 ALTER TABLE inventory_items DROP COLUMN IF EXISTS image_url;
 ALTER TABLE equipment_items DROP COLUMN IF EXISTS image_url;
 `
+
+// Migration to materialize gear_catalog.usage_count instead of counting inventory rows per read.
+// The count is maintained by a trigger on inventory_items and backfilled on every run so
+// drift (e.g. from manual SQL with triggers disabled) self-heals on the next deploy.
+const migrationGearCatalogUsageCount = `
+ALTER TABLE gear_catalog ADD COLUMN IF NOT EXISTS usage_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE OR REPLACE FUNCTION gear_catalog_usage_count_sync() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.catalog_id IS NOT NULL THEN
+        IF TG_OP = 'DELETE' OR NEW.catalog_id IS DISTINCT FROM OLD.catalog_id THEN
+            UPDATE gear_catalog SET usage_count = GREATEST(usage_count - 1, 0) WHERE id = OLD.catalog_id;
+        END IF;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.catalog_id IS NOT NULL THEN
+        IF TG_OP = 'INSERT' OR NEW.catalog_id IS DISTINCT FROM OLD.catalog_id THEN
+            UPDATE gear_catalog SET usage_count = usage_count + 1 WHERE id = NEW.catalog_id;
+        END IF;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_inventory_items_usage_count ON inventory_items;
+CREATE TRIGGER trg_inventory_items_usage_count
+AFTER INSERT OR DELETE OR UPDATE OF catalog_id ON inventory_items
+FOR EACH ROW EXECUTE FUNCTION gear_catalog_usage_count_sync();
+
+-- Backfill (and repair any drift) from the source of truth.
+UPDATE gear_catalog gc
+SET usage_count = COALESCE(counts.total, 0)
+FROM gear_catalog target
+LEFT JOIN (
+    SELECT catalog_id, COUNT(*) AS total
+    FROM inventory_items
+    WHERE catalog_id IS NOT NULL
+    GROUP BY catalog_id
+) counts ON counts.catalog_id = target.id
+WHERE gc.id = target.id
+  AND gc.usage_count IS DISTINCT FROM COALESCE(counts.total, 0);
+
+CREATE INDEX IF NOT EXISTS idx_gear_catalog_usage_count ON gear_catalog(usage_count DESC);
+`
+
+// Migration to store a weighted search vector (brand > model > variant) and a compacted
+// lowercase name for pg_trgm typo tolerance ("tmotor" vs "T-Motor").
+const migrationGearCatalogSearchVector = `
+ALTER TABLE gear_catalog ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', COALESCE(brand, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(model, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(variant, '')), 'C')
+    ) STORED;
+
+ALTER TABLE gear_catalog ADD COLUMN IF NOT EXISTS search_compact TEXT
+    GENERATED ALWAYS AS (
+        regexp_replace(LOWER(brand || model || COALESCE(variant, '')), '[^[:alnum:]]+', '', 'g')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_gear_catalog_search_vector ON gear_catalog USING gin(search_vector);
+
+DO $$
+BEGIN
+    CREATE INDEX IF NOT EXISTS idx_gear_catalog_search_compact_trgm ON gear_catalog USING gin(search_compact gin_trgm_ops);
+EXCEPTION WHEN OTHERS THEN
+    RAISE NOTICE 'Could not create compact trigram index, typo tolerance disabled';
+END $$;
+`
+
+// Migration for canonical brands and their aliases. Keys are lowercase with
+// punctuation and whitespace stripped (see models.NormalizeBrandKey).
+const migrationBrands = `
+CREATE TABLE IF NOT EXISTS brands (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    name_key VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS brand_aliases (
+    alias_key VARCHAR(255) PRIMARY KEY,
+    alias VARCHAR(255) NOT NULL,
+    brand_id UUID NOT NULL REFERENCES brands(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_brand_aliases_brand_id ON brand_aliases(brand_id);
+
+-- Seed the most common offender; admins manage the rest
+INSERT INTO brands (name, name_key) VALUES ('T-Motor', 'tmotor') ON CONFLICT (name_key) DO NOTHING;
+INSERT INTO brand_aliases (alias_key, alias, brand_id)
+SELECT 'tigermotor', 'Tiger Motor', id FROM brands WHERE name_key = 'tmotor'
+ON CONFLICT (alias_key) DO NOTHING;
+`
+
+// Migration for canonical key v2. Rows keep canonical_key_version = 1 until the
+// -rekey-catalog command recomputes them; replaced keys are kept in
+// gear_catalog_legacy_keys so lookups by an old key still resolve.
+const migrationCanonicalKeyV2 = `
+ALTER TABLE gear_catalog ADD COLUMN IF NOT EXISTS canonical_key_version SMALLINT NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS gear_catalog_legacy_keys (
+    legacy_key VARCHAR(1024) PRIMARY KEY,
+    catalog_id UUID NOT NULL REFERENCES gear_catalog(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_gear_catalog_legacy_keys_catalog_id ON gear_catalog_legacy_keys(catalog_id);
+
+CREATE TABLE IF NOT EXISTS gear_catalog_key_collisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    catalog_id UUID NOT NULL REFERENCES gear_catalog(id) ON DELETE CASCADE,
+    conflicts_with_id UUID NOT NULL REFERENCES gear_catalog(id) ON DELETE CASCADE,
+    proposed_key VARCHAR(1024) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ,
+    resolved_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_gear_catalog_key_collisions_open ON gear_catalog_key_collisions(created_at) WHERE resolved_at IS NULL;
+`
+
+// Migration for the offline sync change feed. Every insert/update on a synced table
+// stamps the row with the next value of a shared sequence; deletes leave a
+// tombstone carrying its own sequence value. Clients page through changes in
+// sequence order. Tombstones intentionally have no users FK so cascaded user
+// deletes are not blocked.
+//
+// A value taken when the row is written could commit after a higher one, and a
+// client whose cursor had already passed it would never see that change. So the
+// value taken at write time is only a placeholder: deferred triggers restamp
+// each row and tombstone as its transaction commits, holding a transaction-level
+// advisory lock until the commit is visible. Stamps are therefore handed out in
+// commit order. A client ref is reserved (entity_id NULL) before its entity is
+// created in the same transaction, so a replayed create can't create twice.
+const migrationSyncChangeFeed = `
+CREATE SEQUENCE IF NOT EXISTS sync_change_seq;
+
+CREATE TABLE IF NOT EXISTS sync_tombstones (
+    seq BIGINT PRIMARY KEY DEFAULT nextval('sync_change_seq'),
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT clock_timestamp()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_tombstones_user_seq ON sync_tombstones(user_id, seq);
+
+CREATE TABLE IF NOT EXISTS sync_client_refs (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_ref VARCHAR(128) NOT NULL,
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, client_ref)
+);
+ALTER TABLE sync_client_refs ALTER COLUMN entity_id DROP NOT NULL;
+
+CREATE OR REPLACE FUNCTION sync_touch() RETURNS trigger AS $$
+BEGIN
+    -- The commit stamp only changes sync_seq; leave it alone
+    IF TG_OP = 'UPDATE' AND NEW.sync_seq IS DISTINCT FROM OLD.sync_seq THEN
+        RETURN NEW;
+    END IF;
+    NEW.sync_seq := nextval('sync_change_seq');
+    NEW.sync_changed_at := clock_timestamp();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION sync_commit_stamp() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('sync_change_seq'));
+    IF TG_TABLE_NAME = 'sync_tombstones' THEN
+        UPDATE sync_tombstones SET seq = nextval('sync_change_seq') WHERE seq = NEW.seq;
+    ELSE
+        EXECUTE format('UPDATE %I.%I SET sync_seq = nextval(''sync_change_seq'') WHERE id = $1', TG_TABLE_SCHEMA, TG_TABLE_NAME)
+            USING NEW.id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_sync_tombstones_commit_stamp ON sync_tombstones;
+CREATE CONSTRAINT TRIGGER trg_sync_tombstones_commit_stamp AFTER INSERT ON sync_tombstones
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION sync_commit_stamp();
+
+CREATE OR REPLACE FUNCTION sync_record_tombstone() RETURNS trigger AS $$
+BEGIN
+    IF OLD.user_id IS NOT NULL THEN
+        INSERT INTO sync_tombstones (entity_type, entity_id, user_id)
+        VALUES (TG_ARGV[0], OLD.id, OLD.user_id);
+    END IF;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE inventory_items ADD COLUMN IF NOT EXISTS sync_seq BIGINT;
+ALTER TABLE inventory_items ADD COLUMN IF NOT EXISTS sync_changed_at TIMESTAMPTZ;
+UPDATE inventory_items SET sync_seq = nextval('sync_change_seq'), sync_changed_at = COALESCE(updated_at, NOW()) WHERE sync_seq IS NULL;
+CREATE INDEX IF NOT EXISTS idx_inventory_items_user_sync_seq ON inventory_items(user_id, sync_seq);
+DROP TRIGGER IF EXISTS trg_inventory_items_sync_touch ON inventory_items;
+CREATE TRIGGER trg_inventory_items_sync_touch BEFORE INSERT OR UPDATE ON inventory_items
+    FOR EACH ROW EXECUTE FUNCTION sync_touch();
+DROP TRIGGER IF EXISTS trg_inventory_items_sync_commit_stamp ON inventory_items;
+CREATE CONSTRAINT TRIGGER trg_inventory_items_sync_commit_stamp AFTER INSERT ON inventory_items
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION sync_commit_stamp();
+DROP TRIGGER IF EXISTS trg_inventory_items_sync_commit_restamp ON inventory_items;
+CREATE CONSTRAINT TRIGGER trg_inventory_items_sync_commit_restamp AFTER UPDATE ON inventory_items
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW WHEN (NEW.sync_changed_at IS DISTINCT FROM OLD.sync_changed_at)
+    EXECUTE FUNCTION sync_commit_stamp();
+DROP TRIGGER IF EXISTS trg_inventory_items_sync_tombstone ON inventory_items;
+CREATE TRIGGER trg_inventory_items_sync_tombstone AFTER DELETE ON inventory_items
+    FOR EACH ROW EXECUTE FUNCTION sync_record_tombstone('inventory');
+
+ALTER TABLE aircraft ADD COLUMN IF NOT EXISTS sync_seq BIGINT;
+ALTER TABLE aircraft ADD COLUMN IF NOT EXISTS sync_changed_at TIMESTAMPTZ;
+UPDATE aircraft SET sync_seq = nextval('sync_change_seq'), sync_changed_at = COALESCE(updated_at, NOW()) WHERE sync_seq IS NULL;
+CREATE INDEX IF NOT EXISTS idx_aircraft_user_sync_seq ON aircraft(user_id, sync_seq);
+DROP TRIGGER IF EXISTS trg_aircraft_sync_touch ON aircraft;
+CREATE TRIGGER trg_aircraft_sync_touch BEFORE INSERT OR UPDATE ON aircraft
+    FOR EACH ROW EXECUTE FUNCTION sync_touch();
+DROP TRIGGER IF EXISTS trg_aircraft_sync_commit_stamp ON aircraft;
+CREATE CONSTRAINT TRIGGER trg_aircraft_sync_commit_stamp AFTER INSERT ON aircraft
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION sync_commit_stamp();
+DROP TRIGGER IF EXISTS trg_aircraft_sync_commit_restamp ON aircraft;
+CREATE CONSTRAINT TRIGGER trg_aircraft_sync_commit_restamp AFTER UPDATE ON aircraft
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW WHEN (NEW.sync_changed_at IS DISTINCT FROM OLD.sync_changed_at)
+    EXECUTE FUNCTION sync_commit_stamp();
+DROP TRIGGER IF EXISTS trg_aircraft_sync_tombstone ON aircraft;
+CREATE TRIGGER trg_aircraft_sync_tombstone AFTER DELETE ON aircraft
+    FOR EACH ROW EXECUTE FUNCTION sync_record_tombstone('aircraft');
+
+ALTER TABLE batteries ADD COLUMN IF NOT EXISTS sync_seq BIGINT;
+ALTER TABLE batteries ADD COLUMN IF NOT EXISTS sync_changed_at TIMESTAMPTZ;
+UPDATE batteries SET sync_seq = nextval('sync_change_seq'), sync_changed_at = COALESCE(updated_at, NOW()) WHERE sync_seq IS NULL;
+CREATE INDEX IF NOT EXISTS idx_batteries_user_sync_seq ON batteries(user_id, sync_seq);
+DROP TRIGGER IF EXISTS trg_batteries_sync_touch ON batteries;
+CREATE TRIGGER trg_batteries_sync_touch BEFORE INSERT OR UPDATE ON batteries
+    FOR EACH ROW EXECUTE FUNCTION sync_touch();
+DROP TRIGGER IF EXISTS trg_batteries_sync_commit_stamp ON batteries;
+CREATE CONSTRAINT TRIGGER trg_batteries_sync_commit_stamp AFTER INSERT ON batteries
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION sync_commit_stamp();
+DROP TRIGGER IF EXISTS trg_batteries_sync_commit_restamp ON batteries;
+CREATE CONSTRAINT TRIGGER trg_batteries_sync_commit_restamp AFTER UPDATE ON batteries
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW WHEN (NEW.sync_changed_at IS DISTINCT FROM OLD.sync_changed_at)
+    EXECUTE FUNCTION sync_commit_stamp();
+DROP TRIGGER IF EXISTS trg_batteries_sync_tombstone ON batteries;
+CREATE TRIGGER trg_batteries_sync_tombstone AFTER DELETE ON batteries
+    FOR EACH ROW EXECUTE FUNCTION sync_record_tombstone('battery');
+`
+
+const migrationPushDevices = `
+CREATE TABLE IF NOT EXISTS push_devices (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    platform VARCHAR(20) NOT NULL,
+    token TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_push_devices_user_id ON push_devices(user_id);
+`
+
+const migrationImageQuotas = `
+ALTER TABLE image_assets ADD COLUMN IF NOT EXISTS byte_size BIGINT GENERATED ALWAYS AS (octet_length(image_bytes)) STORED;
+
+CREATE TABLE IF NOT EXISTS image_quota_overrides (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    max_count INTEGER CHECK (max_count >= 0),
+    max_bytes BIGINT CHECK (max_bytes >= 0),
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+`
+
+const migrationFeaturedContent = `
+CREATE TABLE IF NOT EXISTS featured_content (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    content_type VARCHAR(20) NOT NULL CHECK (content_type IN ('build', 'gear')),
+    content_id UUID NOT NULL,
+    headline VARCHAR(200) NOT NULL DEFAULT '',
+    blurb TEXT NOT NULL DEFAULT '',
+    position INTEGER NOT NULL DEFAULT 0,
+    starts_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ends_at TIMESTAMPTZ,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_featured_content_schedule ON featured_content(starts_at, ends_at);
+CREATE INDEX IF NOT EXISTS idx_featured_content_content ON featured_content(content_type, content_id);
+`
+
+const migrationShortLinks = `
+CREATE TABLE IF NOT EXISTS short_links (
+    slug VARCHAR(16) PRIMARY KEY,
+    target_type VARCHAR(20) NOT NULL CHECK (target_type IN ('build', 'gear')),
+    target_id UUID NOT NULL,
+    click_count BIGINT NOT NULL DEFAULT 0,
+    last_clicked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (target_type, target_id)
+);
+`
+
+// Allows flagged images to wait in a review queue (hidden from serving, which
+// only joins APPROVED assets) and tracks admin-triggered moderation re-scans.
+const migrationImageRescan = `
+ALTER TABLE image_assets DROP CONSTRAINT IF EXISTS image_assets_status_check;
+ALTER TABLE image_assets ADD CONSTRAINT image_assets_status_check
+    CHECK (status IN ('APPROVED', 'REJECTED', 'PENDING_REVIEW'));
+ALTER TABLE image_assets ADD COLUMN IF NOT EXISTS flagged_at TIMESTAMPTZ;
+ALTER TABLE image_assets ADD COLUMN IF NOT EXISTS rescanned_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS image_rescan_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    status VARCHAR(20) NOT NULL,
+    entity_type VARCHAR(20) NOT NULL DEFAULT '',
+    started_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    cursor_id UUID,
+    scanned INTEGER NOT NULL DEFAULT 0,
+    flagged INTEGER NOT NULL DEFAULT 0,
+    errors INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ
+);
+
+-- At most one re-scan runs at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_image_rescan_jobs_running ON image_rescan_jobs(status) WHERE status = 'running';
+CREATE INDEX IF NOT EXISTS idx_image_assets_review ON image_assets(flagged_at) WHERE status = 'PENDING_REVIEW';
+`
+
+const migrationModerationPolicies = `
+CREATE TABLE IF NOT EXISTS moderation_policies (
+    entity_type VARCHAR(20) PRIMARY KEY,
+    reject_confidence DOUBLE PRECISION NOT NULL CHECK (reject_confidence > 0 AND reject_confidence <= 100),
+    allowed_labels TEXT[] NOT NULL DEFAULT '{}',
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+`
+
+const migrationGearImageCandidates = `
+CREATE TABLE IF NOT EXISTS gear_image_candidates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    gear_id UUID NOT NULL REFERENCES gear_catalog(id) ON DELETE CASCADE,
+    image_asset_id UUID NOT NULL REFERENCES image_assets(id) ON DELETE CASCADE,
+    image_type VARCHAR(50) NOT NULL,
+    source_name VARCHAR(100) NOT NULL,
+    source_url TEXT NOT NULL,
+    page_url TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (gear_id, source_url)
+);
+
+CREATE INDEX IF NOT EXISTS idx_gear_image_candidates_gear ON gear_image_candidates(gear_id, created_at);
+`
+
+// Builds and catalog items can have several ordered images. image_asset_id on
+// builds and gear_catalog stays as the primary image so existing joins and
+// primary image URLs keep working; the trigger mirrors it into entity_images.
+const migrationEntityImages = `
+CREATE TABLE IF NOT EXISTS entity_images (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id UUID NOT NULL,
+    image_asset_id UUID NOT NULL REFERENCES image_assets(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (entity_type, entity_id, image_asset_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_entity_images_entity ON entity_images(entity_type, entity_id, position);
+
+INSERT INTO entity_images (entity_type, entity_id, image_asset_id, position)
+SELECT 'build', id, image_asset_id, 0 FROM builds WHERE image_asset_id IS NOT NULL
+ON CONFLICT (entity_type, entity_id, image_asset_id) DO NOTHING;
+
+INSERT INTO entity_images (entity_type, entity_id, image_asset_id, position)
+SELECT 'gear', id, image_asset_id, 0 FROM gear_catalog WHERE image_asset_id IS NOT NULL
+ON CONFLICT (entity_type, entity_id, image_asset_id) DO NOTHING;
+
+CREATE OR REPLACE FUNCTION entity_images_sync_primary() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        DELETE FROM entity_images WHERE entity_type = TG_ARGV[0] AND entity_id = OLD.id;
+        RETURN OLD;
+    END IF;
+    IF TG_OP = 'UPDATE' AND NEW.image_asset_id IS NOT DISTINCT FROM OLD.image_asset_id THEN
+        RETURN NEW;
+    END IF;
+
+    IF TG_OP = 'UPDATE' AND OLD.image_asset_id IS NOT NULL THEN
+        IF NEW.image_asset_id IS NULL THEN
+            DELETE FROM entity_images
+            WHERE entity_type = TG_ARGV[0] AND entity_id = NEW.id AND image_asset_id = OLD.image_asset_id;
+        ELSIF NOT EXISTS (
+            SELECT 1 FROM entity_images
+            WHERE entity_type = TG_ARGV[0] AND entity_id = NEW.id AND image_asset_id = NEW.image_asset_id
+        ) THEN
+            -- A replaced primary image keeps the old one's place in the order
+            UPDATE entity_images SET image_asset_id = NEW.image_asset_id
+            WHERE entity_type = TG_ARGV[0] AND entity_id = NEW.id AND image_asset_id = OLD.image_asset_id;
+        END IF;
+    END IF;
+
+    IF NEW.image_asset_id IS NOT NULL THEN
+        INSERT INTO entity_images (entity_type, entity_id, image_asset_id, position)
+        SELECT TG_ARGV[0], NEW.id, NEW.image_asset_id, COALESCE(MIN(position) - 1, 0)
+        FROM entity_images
+        WHERE entity_type = TG_ARGV[0] AND entity_id = NEW.id
+        ON CONFLICT (entity_type, entity_id, image_asset_id) DO NOTHING;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_builds_entity_images ON builds;
+CREATE TRIGGER trg_builds_entity_images AFTER INSERT OR UPDATE OF image_asset_id OR DELETE ON builds
+    FOR EACH ROW EXECUTE FUNCTION entity_images_sync_primary('build');
+DROP TRIGGER IF EXISTS trg_gear_catalog_entity_images ON gear_catalog;
+CREATE TRIGGER trg_gear_catalog_entity_images AFTER INSERT OR UPDATE OF image_asset_id OR DELETE ON gear_catalog
+    FOR EACH ROW EXECUTE FUNCTION entity_images_sync_primary('gear');
+`
+
+const migrationBuildVideo = `
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS video_url TEXT;
+`
+
+const migrationInventoryAttachments = `
+CREATE TABLE IF NOT EXISTS inventory_attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    inventory_item_id UUID NOT NULL REFERENCES inventory_items(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL DEFAULT 'other',
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    file_bytes BYTEA NOT NULL,
+    byte_size BIGINT GENERATED ALWAYS AS (octet_length(file_bytes)) STORED,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_attachments_item ON inventory_attachments(inventory_item_id, created_at);
+`
+
+const migrationAircraftRegistrations = `
+CREATE TABLE IF NOT EXISTS aircraft_registrations (
+    aircraft_id UUID PRIMARY KEY REFERENCES aircraft(id) ON DELETE CASCADE,
+    registration_number VARCHAR(64) NOT NULL DEFAULT '',
+    authority VARCHAR(20) NOT NULL DEFAULT '',
+    registration_expires_on DATE,
+    insurance_provider VARCHAR(255) NOT NULL DEFAULT '',
+    insurance_policy_number VARCHAR(100) NOT NULL DEFAULT '',
+    insurance_expires_on DATE,
+    remote_id_item_id UUID REFERENCES inventory_items(id) ON DELETE SET NULL,
+    reminders_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    -- Expiry dates a reminder was already sent for
+    registration_reminded_on DATE,
+    insurance_reminded_on DATE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_aircraft_registrations_registration_expiry
+    ON aircraft_registrations(registration_expires_on) WHERE registration_expires_on IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_aircraft_registrations_insurance_expiry
+    ON aircraft_registrations(insurance_expires_on) WHERE insurance_expires_on IS NOT NULL;
+`
+
+const migrationBatteryStorageState = `
+ALTER TABLE batteries ADD COLUMN IF NOT EXISTS charged_at TIMESTAMPTZ;
+ALTER TABLE batteries ADD COLUMN IF NOT EXISTS storage_reminder_due_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_batteries_storage_reminder_due
+    ON batteries(storage_reminder_due_at) WHERE storage_reminder_due_at IS NOT NULL;
+`
+
+const migrationGroups = `
+CREATE TABLE IF NOT EXISTS pilot_groups (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_by_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS pilot_group_members (
+    group_id UUID NOT NULL REFERENCES pilot_groups(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member',
+    joined_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pilot_group_members_user ON pilot_group_members(user_id);
+
+CREATE TABLE IF NOT EXISTS pilot_group_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    group_id UUID NOT NULL REFERENCES pilot_groups(id) ON DELETE CASCADE,
+    invited_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    invited_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'member',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    responded_at TIMESTAMPTZ
+);
+
+-- One open invitation per user and group
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pilot_group_invitations_pending
+    ON pilot_group_invitations(group_id, invited_user_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_pilot_group_invitations_user
+    ON pilot_group_invitations(invited_user_id, status);
+
+CREATE TABLE IF NOT EXISTS pilot_group_aircraft (
+    group_id UUID NOT NULL REFERENCES pilot_groups(id) ON DELETE CASCADE,
+    aircraft_id UUID NOT NULL REFERENCES aircraft(id) ON DELETE CASCADE,
+    shared_by_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    shared_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (group_id, aircraft_id)
+);
+
+CREATE TABLE IF NOT EXISTS pilot_group_batteries (
+    group_id UUID NOT NULL REFERENCES pilot_groups(id) ON DELETE CASCADE,
+    battery_id UUID NOT NULL REFERENCES batteries(id) ON DELETE CASCADE,
+    shared_by_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    shared_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (group_id, battery_id)
+);
+`
+
+const migrationEvents = `
+CREATE TABLE IF NOT EXISTS events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organizer_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    group_id UUID REFERENCES pilot_groups(id) ON DELETE CASCADE,
+    name VARCHAR(150) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ,
+    -- public, followers (of the organizer), or group (members of group_id)
+    visibility VARCHAR(20) NOT NULL DEFAULT 'public',
+    max_pilots INTEGER CHECK (max_pilots IS NULL OR max_pilots > 0),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_starts_at ON events(starts_at);
+CREATE INDEX IF NOT EXISTS idx_events_group ON events(group_id) WHERE group_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS event_registrations (
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    aircraft_id UUID REFERENCES aircraft(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'registered',
+    registered_at TIMESTAMPTZ DEFAULT NOW(),
+    checked_in_at TIMESTAMPTZ,
+    PRIMARY KEY (event_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_registrations_user ON event_registrations(user_id);
+`
+
+const migrationBuildPresets = `
+CREATE TABLE IF NOT EXISTS build_presets (
+    build_id UUID PRIMARY KEY REFERENCES builds(id) ON DELETE CASCADE,
+    diff TEXT NOT NULL,
+    firmware_version VARCHAR(50) NOT NULL DEFAULT '',
+    board_target VARCHAR(100) NOT NULL DEFAULT '',
+    board_name VARCHAR(100) NOT NULL DEFAULT '',
+    removed_settings TEXT[] NOT NULL DEFAULT '{}',
+    parsed_tuning JSONB,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+`
+
+const migrationUserMerges = `
+CREATE TABLE IF NOT EXISTS user_merges (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    -- No foreign keys, so the record outlives either account
+    source_user_id UUID NOT NULL,
+    target_user_id UUID NOT NULL,
+    merged_by_user_id UUID,
+    source_email VARCHAR(255) NOT NULL,
+    merged_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_merges_target ON user_merges(target_user_id);
+`
+
+const migrationJWTSigningKeys = `
+CREATE TABLE IF NOT EXISTS jwt_signing_keys (
+    kid VARCHAR(32) PRIMARY KEY,
+    -- Encrypted with BIND_PHRASE_ENCRYPTION_KEY
+    secret TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    retired_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ
+);
+`
+
+const migrationBuildHandoff = `
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS handoff_session VARCHAR(64);
+CREATE INDEX IF NOT EXISTS idx_builds_handoff_session ON builds(handoff_session) WHERE handoff_session IS NOT NULL;
+
+-- A session can only be claimed once, so a leaked handoff token can't move
+-- builds again after their creator signed in
+CREATE TABLE IF NOT EXISTS build_handoff_claims (
+    session_id VARCHAR(64) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    claimed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+`
+
+const migrationCatalogPublishRules = `
+CREATE TABLE IF NOT EXISTS catalog_publish_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    priority INTEGER NOT NULL DEFAULT 0,
+    gear_types TEXT[] NOT NULL DEFAULT '{}',
+    min_published_submissions INTEGER NOT NULL DEFAULT 0,
+    required_specs TEXT[] NOT NULL DEFAULT '{}',
+    require_image BOOLEAN NOT NULL DEFAULT FALSE,
+    require_description BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Which rule published which item. The rule is copied as it was when it
+-- fired, since it may be edited or deleted later.
+CREATE TABLE IF NOT EXISTS catalog_publish_decisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    catalog_item_id UUID NOT NULL REFERENCES gear_catalog(id) ON DELETE CASCADE,
+    rule_id UUID REFERENCES catalog_publish_rules(id) ON DELETE SET NULL,
+    rule_name VARCHAR(100) NOT NULL,
+    rule_snapshot JSONB NOT NULL,
+    submitter_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    trigger VARCHAR(20) NOT NULL,
+    decided_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_catalog_publish_decisions_decided_at ON catalog_publish_decisions(decided_at DESC);
+CREATE INDEX IF NOT EXISTS idx_catalog_publish_decisions_item ON catalog_publish_decisions(catalog_item_id);
+`
+
+const migrationPublishRuleReputation = `
+ALTER TABLE catalog_publish_rules ADD COLUMN IF NOT EXISTS min_reputation INTEGER NOT NULL DEFAULT 0;
+`
+
+const migrationBuildModerationEvents = `
+CREATE TABLE IF NOT EXISTS build_moderation_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    build_id UUID NOT NULL REFERENCES builds(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('approved', 'rejected')),
+    category VARCHAR(40),
+    note TEXT,
+    moderator_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_build_moderation_events_build ON build_moderation_events(build_id, created_at DESC);
+`
+
+const migrationModerationAssignments = `
+ALTER TABLE gear_catalog ADD COLUMN IF NOT EXISTS assigned_to UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE gear_catalog ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMPTZ;
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS assigned_to UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMPTZ;
+`
+
+// queued_at records when an item entered its moderation queue and is cleared
+// when it leaves, so resubmitted builds start a fresh clock.
+// sla_escalated_at marks items moderators were already alerted about.
+const migrationModerationQueueAging = `
+ALTER TABLE gear_catalog ADD COLUMN IF NOT EXISTS queued_at TIMESTAMPTZ;
+ALTER TABLE gear_catalog ADD COLUMN IF NOT EXISTS sla_escalated_at TIMESTAMPTZ;
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS queued_at TIMESTAMPTZ;
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS sla_escalated_at TIMESTAMPTZ;
+
+CREATE OR REPLACE FUNCTION moderation_queue_touch() RETURNS trigger AS $$
+BEGIN
+    IF NEW.status = TG_ARGV[0] THEN
+        IF TG_OP = 'INSERT' OR OLD.status IS DISTINCT FROM NEW.status THEN
+            NEW.queued_at := NOW();
+            NEW.sla_escalated_at := NULL;
+        END IF;
+    ELSE
+        NEW.queued_at := NULL;
+        NEW.sla_escalated_at := NULL;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_gear_catalog_queue_touch ON gear_catalog;
+CREATE TRIGGER trg_gear_catalog_queue_touch BEFORE INSERT OR UPDATE OF status ON gear_catalog
+    FOR EACH ROW EXECUTE FUNCTION moderation_queue_touch('pending');
+DROP TRIGGER IF EXISTS trg_builds_queue_touch ON builds;
+CREATE TRIGGER trg_builds_queue_touch BEFORE INSERT OR UPDATE OF status ON builds
+    FOR EACH ROW EXECUTE FUNCTION moderation_queue_touch('PENDING_REVIEW');
+
+UPDATE gear_catalog SET queued_at = created_at WHERE status = 'pending' AND queued_at IS NULL;
+UPDATE builds SET queued_at = updated_at WHERE status = 'PENDING_REVIEW' AND queued_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_gear_catalog_queued_at ON gear_catalog(queued_at) WHERE queued_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_builds_queued_at ON builds(queued_at) WHERE queued_at IS NOT NULL;
+`
+
+const migrationAnnouncements = `
+CREATE TABLE IF NOT EXISTS announcements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('release', 'outage', 'notice')),
+    title VARCHAR(200) NOT NULL,
+    body TEXT NOT NULL,
+    published_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ,
+    notify BOOLEAN NOT NULL DEFAULT FALSE,
+    notified_at TIMESTAMPTZ,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_announcements_published_at ON announcements(published_at DESC);
+
+CREATE TABLE IF NOT EXISTS announcement_reads (
+    announcement_id UUID NOT NULL REFERENCES announcements(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    read_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (announcement_id, user_id)
+);
+`
+
+const migrationPolicyVersions = `
+CREATE TABLE IF NOT EXISTS policy_versions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('terms', 'privacy')),
+    version VARCHAR(50) NOT NULL,
+    url TEXT NOT NULL,
+    summary TEXT NOT NULL DEFAULT '',
+    published_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    UNIQUE (kind, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_policy_versions_kind_published ON policy_versions(kind, published_at DESC);
+
+CREATE TABLE IF NOT EXISTS policy_acceptances (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    policy_version_id UUID NOT NULL REFERENCES policy_versions(id) ON DELETE CASCADE,
+    accepted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, policy_version_id)
+);
+`
+
+const migrationDataRetention = `
+CREATE TABLE IF NOT EXISTS user_retention_settings (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category VARCHAR(50) NOT NULL,
+    retain_days INTEGER NOT NULL CHECK (retain_days > 0),
+    last_purged_at TIMESTAMPTZ,
+    last_purged_count INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, category)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_retention_settings_category ON user_retention_settings(category);
+
+-- Purged battery logs still count toward a battery's cycles and last use
+ALTER TABLE batteries ADD COLUMN IF NOT EXISTS purged_cycles INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE batteries ADD COLUMN IF NOT EXISTS purged_last_logged_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_battery_logs_user_logged_at ON battery_logs(user_id, logged_at);
+`
+
+const migrationTenancy = `
+CREATE TABLE IF NOT EXISTS tenants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    slug VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    hostnames TEXT[] NOT NULL DEFAULT '{}',
+    branding JSONB NOT NULL DEFAULT '{}',
+    features JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tenants_hostnames ON tenants USING GIN (hostnames);
+
+-- Everything that existed before tenancy belongs to the default tenant
+INSERT INTO tenants (id, slug, name)
+VALUES ('00000000-0000-0000-0000-000000000001', 'default', 'FlyingForge')
+ON CONFLICT (id) DO NOTHING;
+
+-- The tenant a connection is scoped to, set before each statement by the
+-- store layer. '*' scopes it to every tenant, which migrations, background
+-- jobs and admin commands ask for explicitly; current_tenant_id() is NULL
+-- then. A connection that never set it sees no tenant's rows.
+CREATE OR REPLACE FUNCTION current_tenant_id() RETURNS UUID AS $$
+    SELECT NULLIF(NULLIF(current_setting('app.tenant_id', true), ''), '*')::uuid
+$$ LANGUAGE SQL STABLE;
+
+CREATE OR REPLACE FUNCTION tenant_visible(row_tenant UUID) RETURNS BOOLEAN AS $$
+    SELECT COALESCE(current_setting('app.tenant_id', true) = '*', FALSE) OR row_tenant = current_tenant_id()
+$$ LANGUAGE SQL STABLE;
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'users', 'user_identities', 'builds', 'aircraft', 'inventory_items', 'batteries',
+        'radios', 'fc_configs', 'follows', 'pilot_groups', 'events', 'featured_content',
+        'announcements', 'short_links'
+    ] LOOP
+        EXECUTE format('ALTER TABLE %I ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL
+            DEFAULT COALESCE(current_tenant_id(), ''00000000-0000-0000-0000-000000000001'') REFERENCES tenants(id)', t);
+        EXECUTE format('CREATE INDEX IF NOT EXISTS %I ON %I(tenant_id)', 'idx_' || t || '_tenant', t);
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+        EXECUTE format('CREATE POLICY tenant_isolation ON %I
+            USING (tenant_visible(tenant_id))
+            WITH CHECK (tenant_visible(tenant_id))', t);
+    END LOOP;
+END $$;
+
+-- Emails, call signs and sign-in identities are unique within a tenant
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_call_sign_key;
+ALTER TABLE user_identities DROP CONSTRAINT IF EXISTS user_identities_provider_provider_subject_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_tenant_email ON users(tenant_id, email);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_tenant_call_sign ON users(tenant_id, call_sign);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_identities_tenant_subject ON user_identities(tenant_id, provider, provider_subject);
+`
+
+const migrationRadioBackupStorage = `
+-- NULL for backups saved before pluggable storage, whose storage_path is a
+-- file path rather than an object key
+ALTER TABLE radio_backups ADD COLUMN IF NOT EXISTS storage_backend VARCHAR(20);
+`
+
+const migrationResumableUploads = `
+-- chunk_keys lists the blob keys of the stored chunks in order; together
+-- they cover bytes [0, offset_bytes)
+CREATE TABLE IF NOT EXISTS uploads (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose VARCHAR(40) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    offset_bytes BIGINT NOT NULL DEFAULT 0,
+    checksum VARCHAR(64),
+    metadata JSONB NOT NULL DEFAULT '{}',
+    chunk_keys TEXT[] NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'completed', 'failed')),
+    result_id VARCHAR(64),
+    error TEXT,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_uploads_user_status ON uploads(user_id, status);
+CREATE INDEX IF NOT EXISTS idx_uploads_expires_at ON uploads(expires_at);
+`
+
+const migrationBlackboxLogs = `
+-- flights holds one summary per log in the file, as parsed at upload
+CREATE TABLE IF NOT EXISTS blackbox_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    aircraft_id UUID REFERENCES aircraft(id) ON DELETE SET NULL,
+    file_name VARCHAR(255) NOT NULL,
+    file_size BIGINT NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    notes TEXT,
+    firmware VARCHAR(200),
+    craft_name VARCHAR(100),
+    duration_seconds DOUBLE PRECISION NOT NULL DEFAULT 0,
+    parse_status VARCHAR(20) NOT NULL,
+    parse_warnings TEXT[] NOT NULL DEFAULT '{}',
+    flights JSONB NOT NULL DEFAULT '[]',
+    storage_backend VARCHAR(20) NOT NULL,
+    storage_path TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_blackbox_logs_user_created ON blackbox_logs(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_blackbox_logs_aircraft ON blackbox_logs(aircraft_id);
+`
+
+const migrationInventoryDisplayOverride = `
+-- Set once a user changes an item's name or manufacturer; until then both
+-- follow the linked catalog item. Items that already differed from their
+-- catalog item when the column was added were edited by their owner, so the
+-- backfill marks them overridden. It runs only with the column's creation:
+-- later drift comes from catalog renames, which the sync should apply.
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1
+        FROM information_schema.columns
+        WHERE table_schema = 'public'
+          AND table_name = 'inventory_items'
+          AND column_name = 'display_overridden'
+    ) THEN
+        ALTER TABLE inventory_items ADD COLUMN display_overridden BOOLEAN NOT NULL DEFAULT FALSE;
+
+        UPDATE inventory_items i
+        SET display_overridden = TRUE
+        FROM gear_catalog gc
+        WHERE gc.id = i.catalog_id
+          AND (i.name <> TRIM(gc.brand || ' ' || gc.model || COALESCE(' ' || NULLIF(gc.variant, ''), ''))
+               OR COALESCE(i.manufacturer, '') <> gc.brand);
+    END IF;
+END $$;
+`
+
+const migrationInventoryArchive = `
+ALTER TABLE inventory_items ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_inventory_user_active ON inventory_items(user_id, created_at DESC) WHERE archived_at IS NULL;
+`
+
+const migrationViewCounts = `
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS view_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE gear_catalog ADD COLUMN IF NOT EXISTS view_count BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_builds_published_views ON builds(view_count DESC, published_at DESC) WHERE status = 'PUBLISHED';
+`
+
+const migrationHomeModules = `
+CREATE TABLE IF NOT EXISTS home_modules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL DEFAULT COALESCE(current_tenant_id(), '00000000-0000-0000-0000-000000000001') REFERENCES tenants(id),
+    kind VARCHAR(30) NOT NULL,
+    title VARCHAR(100) NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    item_limit INT NOT NULL DEFAULT 6,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    settings JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_home_modules_tenant ON home_modules(tenant_id, position);
+
+-- Each tenant lays out its own home page
+ALTER TABLE home_modules ENABLE ROW LEVEL SECURITY;
+ALTER TABLE home_modules FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON home_modules;
+CREATE POLICY tenant_isolation ON home_modules
+    USING (tenant_visible(tenant_id))
+    WITH CHECK (tenant_visible(tenant_id));
+`
+
+const migrationFeedSearch = `
+ALTER TABLE feed_items ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(summary, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(content, '')), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_feed_items_search ON feed_items USING GIN(search_vector);
+`
+
+const migrationLinkChecks = `
+-- One row per distinct stored link, across inventory, equipment, and
+-- catalog image candidate pages
+CREATE TABLE IF NOT EXISTS link_checks (
+    url TEXT PRIMARY KEY,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'ok', 'failing', 'dead')),
+    http_status INT,
+    last_error TEXT,
+    failures INT NOT NULL DEFAULT 0,
+    checked_at TIMESTAMPTZ,
+    dead_since TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_link_checks_due ON link_checks(checked_at NULLS FIRST);
+CREATE INDEX IF NOT EXISTS idx_link_checks_dead ON link_checks(dead_since) WHERE status = 'dead';
+CREATE INDEX IF NOT EXISTS idx_inventory_product_url ON inventory_items(product_url) WHERE product_url IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_gear_image_candidates_page_url ON gear_image_candidates(page_url) WHERE page_url IS NOT NULL;
+`
+
+const migrationFeedFilters = `
+-- The feed is shared by every tenant, so filters are too
+CREATE TABLE IF NOT EXISTS feed_filters (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('keyword', 'domain', 'min_length')),
+    pattern TEXT,
+    min_length INT NOT NULL DEFAULT 0,
+    sources TEXT[] NOT NULL DEFAULT '{}',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    note TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+`
+
+const migrationInactivityPolicy = `
+-- One row; the policy applies to every tenant
+CREATE TABLE IF NOT EXISTS inactivity_policy (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    inactive_months INT NOT NULL,
+    grace_days INT NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('none', 'disable', 'delete')),
+    exempt_published BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Set when the cleanup job notifies an inactive account, cleared on login
+ALTER TABLE users ADD COLUMN IF NOT EXISTS inactive_flagged_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_users_inactive_flagged ON users(inactive_flagged_at) WHERE inactive_flagged_at IS NOT NULL;
+`
+
+const migrationAccountAppeals = `
+-- Why an admin disabled or suspended an account, and when a suspension ends
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status_reason TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status_expires_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS account_appeals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_status VARCHAR(20) NOT NULL,
+    status_reason TEXT,
+    message TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'reinstated', 'upheld')),
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolution_note TEXT,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One open appeal per account
+CREATE UNIQUE INDEX IF NOT EXISTS idx_account_appeals_open ON account_appeals(user_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_account_appeals_status ON account_appeals(status, created_at);
+`
+
+const migrationDomainEventOutbox = `
+-- Domain events written in the same transaction as the change they describe,
+-- relayed to the message broker by a background job
+CREATE TABLE IF NOT EXISTS domain_events_outbox (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_type VARCHAR(50) NOT NULL,
+    aggregate_id VARCHAR(100) NOT NULL,
+    tenant_id UUID DEFAULT current_tenant_id(),
+    payload JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMPTZ,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_domain_events_outbox_pending ON domain_events_outbox(next_attempt_at, created_at) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_domain_events_outbox_published ON domain_events_outbox(published_at) WHERE published_at IS NOT NULL;
+`
+
+const migrationBuildSearch = `
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_builds_search ON builds USING GIN(search_vector) WHERE status = 'PUBLISHED';
+`
+
+const migrationCatalogRecommendations = `
+-- How often two catalog items appear in the same inventory or build,
+-- recomputed nightly
+CREATE TABLE IF NOT EXISTS catalog_item_cooccurrence (
+    item_id UUID NOT NULL REFERENCES gear_catalog(id) ON DELETE CASCADE,
+    related_item_id UUID NOT NULL REFERENCES gear_catalog(id) ON DELETE CASCADE,
+    shared_count INT NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (item_id, related_item_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_catalog_item_cooccurrence_score ON catalog_item_cooccurrence(item_id, score DESC);
+`
+
+const migrationBuildTemplates = `
+-- Templates are builds with status TEMPLATE and no owner. Their parts are
+-- placeholder slots: no catalog item, just spec filters a part must match.
+-- The TEMPLATE status is allowed by chk_builds_status in migrationBuilds.
+
+ALTER TABLE build_parts ADD COLUMN IF NOT EXISTS spec_filters JSONB;
+
+CREATE INDEX IF NOT EXISTS idx_builds_templates ON builds(title) WHERE status = 'TEMPLATE';
+
+INSERT INTO builds (id, status, title, description) VALUES
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000501', 'TEMPLATE', '5" Freestyle',
+     'A durable 5 inch quad on 6S for freestyle and bando. 2207 motors around 1750KV, 5.1 inch tri-blades, and a 1100-1300mAh pack.'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000502', 'TEMPLATE', '3.5" Sub-250',
+     'A 3.5 inch quad under 250 grams with the battery, so it flies in more places without registration. 1404-1604 motors and a light 4S pack.'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000503', 'TEMPLATE', '7" Long Range',
+     'An efficient 7 inch cruiser on 6S Li-ion or LiPo with GPS for rescue. 2806.5 class motors around 1300KV and a long-range receiver.')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO build_parts (build_id, gear_type, position, notes, spec_filters)
+SELECT v.build_id::uuid, v.gear_type, v.position, v.notes, v.spec_filters::jsonb
+FROM (VALUES
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000501', 'frame', 0, '5 inch freestyle frame', '[{"key": "size", "min": 5, "max": 5}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000501', 'motor', 0, '2207 motor, 1700-1950KV', '[{"key": "statorSize", "values": ["2207", "2306", "2307"]}, {"key": "kv", "min": 1600, "max": 1950}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000501', 'motor', 1, '2207 motor, 1700-1950KV', '[{"key": "statorSize", "values": ["2207", "2306", "2307"]}, {"key": "kv", "min": 1600, "max": 1950}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000501', 'motor', 2, '2207 motor, 1700-1950KV', '[{"key": "statorSize", "values": ["2207", "2306", "2307"]}, {"key": "kv", "min": 1600, "max": 1950}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000501', 'motor', 3, '2207 motor, 1700-1950KV', '[{"key": "statorSize", "values": ["2207", "2306", "2307"]}, {"key": "kv", "min": 1600, "max": 1950}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000501', 'fc', 0, '30x30 or 20x20 flight controller', '[]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000501', 'esc', 0, '4-in-1 ESC rated for 6S', '[{"key": "cells", "min": 6}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000501', 'vtx', 0, 'Video transmitter', '[]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000501', 'receiver', 0, 'Receiver', '[]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000501', 'prop', 0, '5.1 inch props', '[{"key": "size", "min": 5, "max": 5.3}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000501', 'battery', 0, '6S 1100-1300mAh', '[{"key": "cells", "min": 6, "max": 6}, {"key": "capacity", "min": 1000, "max": 1400}]'),
+
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000502', 'frame', 0, '3.5 inch frame', '[{"key": "size", "min": 3.5, "max": 3.5}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000502', 'motor', 0, '1404-1604 motor', '[{"key": "statorSize", "values": ["1404", "1504", "1505", "1506", "1604"]}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000502', 'motor', 1, '1404-1604 motor', '[{"key": "statorSize", "values": ["1404", "1504", "1505", "1506", "1604"]}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000502', 'motor', 2, '1404-1604 motor', '[{"key": "statorSize", "values": ["1404", "1504", "1505", "1506", "1604"]}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000502', 'motor', 3, '1404-1604 motor', '[{"key": "statorSize", "values": ["1404", "1504", "1505", "1506", "1604"]}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000502', 'aio', 0, '20x20 AIO flight controller and ESC', '[]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000502', 'vtx', 0, 'Lightweight video transmitter', '[]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000502', 'receiver', 0, 'Receiver', '[]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000502', 'prop', 0, '3.5 inch props', '[{"key": "size", "min": 3.5, "max": 3.5}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000502', 'battery', 0, '4S 650-850mAh', '[{"key": "cells", "min": 4, "max": 4}, {"key": "capacity", "min": 650, "max": 850}]'),
+
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000503', 'frame', 0, '7 inch long-range frame', '[{"key": "size", "min": 7, "max": 7}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000503', 'motor', 0, '2806.5-2809 motor, 1100-1500KV', '[{"key": "kv", "min": 1100, "max": 1500}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000503', 'motor', 1, '2806.5-2809 motor, 1100-1500KV', '[{"key": "kv", "min": 1100, "max": 1500}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000503', 'motor', 2, '2806.5-2809 motor, 1100-1500KV', '[{"key": "kv", "min": 1100, "max": 1500}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000503', 'motor', 3, '2806.5-2809 motor, 1100-1500KV', '[{"key": "kv", "min": 1100, "max": 1500}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000503', 'fc', 0, 'Flight controller', '[]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000503', 'esc', 0, '4-in-1 ESC rated for 6S', '[{"key": "cells", "min": 6}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000503', 'vtx', 0, 'Video transmitter', '[]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000503', 'receiver', 0, 'Long-range receiver', '[]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000503', 'gps', 0, 'GPS for rescue mode', '[]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000503', 'prop', 0, '7 inch props', '[{"key": "size", "min": 7, "max": 7.5}]'),
+    ('6d1f3c1e-5a0b-4c1e-9a51-000000000503', 'battery', 0, '6S pack', '[{"key": "cells", "min": 6, "max": 6}]')
+) AS v(build_id, gear_type, position, notes, spec_filters)
+WHERE EXISTS (SELECT 1 FROM builds b WHERE b.id = v.build_id::uuid AND b.status = 'TEMPLATE')
+ON CONFLICT (build_id, gear_type, position) DO NOTHING;
+`
+
+const migrationEcosystemRegistry = `
+-- Reference data for the RC link protocols and video systems parts have to
+-- agree on. Catalog items are linked by a background job that reads their
+-- specs and names, so builds and the catalog can be filtered by ecosystem.
+CREATE TABLE IF NOT EXISTS rc_protocols (
+    id VARCHAR(20) PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    sort_order INT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS video_systems (
+    id VARCHAR(20) PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    sort_order INT NOT NULL DEFAULT 0
+);
+
+INSERT INTO rc_protocols (id, name, description, sort_order) VALUES
+    ('elrs', 'ExpressLRS', 'Open-source 2.4GHz and 900MHz link. ELRS receivers bind only to an ELRS radio or module on the same band.', 1),
+    ('crossfire', 'TBS Crossfire', 'TBS 868/915MHz long-range link. Crossfire receivers need a Crossfire module or a radio with one built in.', 2),
+    ('ghost', 'ImmersionRC Ghost', '2.4GHz link from ImmersionRC. Ghost receivers need a Ghost module.', 3),
+    ('frsky', 'FrSky', 'FrSky ACCST and ACCESS 2.4GHz receivers. Needs a FrSky or multi-protocol radio.', 4)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO video_systems (id, name, description, sort_order) VALUES
+    ('analog', 'Analog', '5.8GHz analog video. Any analog camera, VTX and goggles work together.', 1),
+    ('dji', 'DJI', 'DJI digital video: Vista, O3 and O4 air units with DJI goggles.', 2),
+    ('hdzero', 'HDZero', 'Low-latency digital video. HDZero VTXs need an HDZero camera and goggles or VRX.', 3),
+    ('walksnail', 'Walksnail Avatar', 'Walksnail Avatar digital video. Its VTXs need Avatar goggles or a DJI goggle with a Walksnail VRX.', 4)
+ON CONFLICT (id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS gear_catalog_ecosystems (
+    catalog_id UUID NOT NULL REFERENCES gear_catalog(id) ON DELETE CASCADE,
+    rc_protocol VARCHAR(20) REFERENCES rc_protocols(id) ON DELETE CASCADE,
+    video_system VARCHAR(20) REFERENCES video_systems(id) ON DELETE CASCADE,
+    CONSTRAINT chk_gear_catalog_ecosystems_one CHECK ((rc_protocol IS NULL) <> (video_system IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gear_catalog_ecosystems_rc ON gear_catalog_ecosystems(catalog_id, rc_protocol) WHERE rc_protocol IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gear_catalog_ecosystems_video ON gear_catalog_ecosystems(catalog_id, video_system) WHERE video_system IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_gear_catalog_ecosystems_rc_lookup ON gear_catalog_ecosystems(rc_protocol, catalog_id) WHERE rc_protocol IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_gear_catalog_ecosystems_video_lookup ON gear_catalog_ecosystems(video_system, catalog_id) WHERE video_system IS NOT NULL;
+`
+
+const migrationFirmwareTargets = `
+-- Board targets from the Betaflight build API. Targets that drop out of the
+-- list are kept and marked removed, since older configs still name them.
+CREATE TABLE IF NOT EXISTS firmware_targets (
+    target VARCHAR(100) PRIMARY KEY,
+    manufacturer VARCHAR(100) NOT NULL DEFAULT '',
+    mcu VARCHAR(50) NOT NULL DEFAULT '',
+    first_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    removed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_firmware_targets_prefix ON firmware_targets(target varchar_pattern_ops);
+
+-- The registry target an FC config or catalog flight controller runs
+ALTER TABLE fc_configs ADD COLUMN IF NOT EXISTS firmware_target VARCHAR(100);
+ALTER TABLE gear_catalog ADD COLUMN IF NOT EXISTS firmware_target VARCHAR(100);
+
+CREATE INDEX IF NOT EXISTS idx_gear_catalog_firmware_target ON gear_catalog(firmware_target) WHERE firmware_target IS NOT NULL;
+
+-- Board targets and names are now stored in Betaflight's upper case
+UPDATE fc_configs SET board_target = UPPER(board_target) WHERE board_target <> UPPER(board_target);
+UPDATE fc_configs SET board_name = UPPER(board_name) WHERE board_name <> UPPER(board_name);
+`
+
+const migrationFCConfigOSDLayouts = `
+-- OSD element positions parsed from the CLI dump, or imported from another config
+ALTER TABLE fc_configs ADD COLUMN IF NOT EXISTS osd_layout JSONB;
+`
+
+const migrationImageAssetDedup = `
+-- Identical uploads share one asset. ref_count is how many saves reference it;
+-- the asset is deleted when the last reference is released. Existing
+-- duplicates keep their own rows.
+ALTER TABLE image_assets ADD COLUMN IF NOT EXISTS content_hash CHAR(64);
+ALTER TABLE image_assets ADD COLUMN IF NOT EXISTS ref_count INTEGER NOT NULL DEFAULT 1;
+
+UPDATE image_assets SET content_hash = encode(sha256(image_bytes), 'hex') WHERE content_hash IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_image_assets_content_hash ON image_assets(content_hash, entity_type) WHERE status = 'APPROVED';
+`
+
+const migrationImageArchive = `
+-- Idle images move to object storage. Cold rows keep their metadata but not
+-- their bytes; archive_size stands in for byte_size in quotas and stats.
+ALTER TABLE image_assets ADD COLUMN IF NOT EXISTS storage_tier VARCHAR(10) NOT NULL DEFAULT 'hot';
+ALTER TABLE image_assets ADD COLUMN IF NOT EXISTS archive_key TEXT;
+ALTER TABLE image_assets ADD COLUMN IF NOT EXISTS archive_backend VARCHAR(20);
+ALTER TABLE image_assets ADD COLUMN IF NOT EXISTS archive_size BIGINT;
+ALTER TABLE image_assets ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+ALTER TABLE image_assets ADD COLUMN IF NOT EXISTS restored_at TIMESTAMPTZ;
+
+ALTER TABLE image_assets DROP CONSTRAINT IF EXISTS image_assets_storage_tier_check;
+ALTER TABLE image_assets ADD CONSTRAINT image_assets_storage_tier_check CHECK (storage_tier IN ('hot', 'cold'));
+
+CREATE INDEX IF NOT EXISTS idx_image_assets_archive_candidates ON image_assets(created_at) WHERE status = 'APPROVED' AND storage_tier = 'hot';
+
+-- When builds and catalog items were last viewed, so their images stay hot
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS last_viewed_at TIMESTAMPTZ;
+ALTER TABLE gear_catalog ADD COLUMN IF NOT EXISTS last_viewed_at TIMESTAMPTZ;
+
+-- Objects no image references any more, after a restore or a delete
+-- (including cascades), removed by the next archiver run
+CREATE TABLE IF NOT EXISTS image_archive_orphans (
+    object_key TEXT PRIMARY KEY,
+    backend VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE OR REPLACE FUNCTION image_archive_record_orphan() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO image_archive_orphans (object_key, backend)
+    VALUES (OLD.archive_key, OLD.archive_backend)
+    ON CONFLICT (object_key) DO NOTHING;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_image_assets_archive_orphan ON image_assets;
+CREATE TRIGGER trg_image_assets_archive_orphan AFTER DELETE ON image_assets
+    FOR EACH ROW WHEN (OLD.archive_key IS NOT NULL) EXECUTE FUNCTION image_archive_record_orphan();
+`
+
+const migrationSpecUnits = `
+-- Weights, lengths and capacities in catalog specs are stored in g, mm and
+-- mAh; spec_originals keeps the text they were entered as.
+ALTER TABLE gear_catalog ADD COLUMN IF NOT EXISTS spec_originals JSONB NOT NULL DEFAULT '{}';
+
+-- The unit system each user reads specs in
+ALTER TABLE users ADD COLUMN IF NOT EXISTS unit_system VARCHAR(10) NOT NULL DEFAULT 'metric';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_unit_system_check;
+ALTER TABLE users ADD CONSTRAINT users_unit_system_check CHECK (unit_system IN ('metric', 'imperial'));
+`
+
+const migrationBuildArchive = `
+-- Drafts can be archived to stay within the per-user active draft limit.
+-- The ARCHIVED status is allowed by chk_builds_status in migrationBuilds.
+
+CREATE INDEX IF NOT EXISTS idx_builds_owner_drafts ON builds(owner_user_id, updated_at) WHERE status = 'DRAFT';
+`
+
+const migrationBuildViewTokens = `
+-- A temp build's token lets anyone holding it edit the build; view_token is
+-- a second, read-only token issued on demand for sharing
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS view_token VARCHAR(128);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_builds_view_token_unique ON builds(view_token) WHERE view_token IS NOT NULL;
+
+-- Editing a temp build makes a new revision; replaced_at marks the old one,
+-- which can no longer be given a view token
+ALTER TABLE builds ADD COLUMN IF NOT EXISTS replaced_at TIMESTAMPTZ;
+`
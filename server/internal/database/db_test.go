@@ -0,0 +1,42 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+// Every migration runs on each boot, so running them again over data they
+// allowed must succeed
+func TestMigrate_RerunsCleanly(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Close()
+	db := &DB{DB: testDB.DB}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	if err := db.Migrate(ctx); err != nil {
+		t.Fatalf("first Migrate() error = %v", err)
+	}
+
+	var templates int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM builds WHERE status = 'TEMPLATE'`).Scan(&templates); err != nil {
+		t.Fatal(err)
+	}
+	if templates == 0 {
+		t.Fatal("expected seeded TEMPLATE builds")
+	}
+
+	var archivedID string
+	if err := db.QueryRowContext(ctx, `INSERT INTO builds (status, title) VALUES ('ARCHIVED', 'migrate rerun test') RETURNING id`).Scan(&archivedID); err != nil {
+		t.Fatalf("insert archived build: %v", err)
+	}
+	defer db.ExecContext(context.Background(), `DELETE FROM builds WHERE id = $1`, archivedID)
+
+	if err := db.Migrate(ctx); err != nil {
+		t.Fatalf("second Migrate() error = %v", err)
+	}
+}
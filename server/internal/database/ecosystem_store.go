@@ -0,0 +1,145 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// EcosystemStore reads the RC protocol and video system registry and keeps
+// catalog items linked to it
+type EcosystemStore struct {
+	db *DB
+}
+
+// NewEcosystemStore creates a new ecosystem store
+func NewEcosystemStore(db *DB) *EcosystemStore {
+	return &EcosystemStore{db: db}
+}
+
+// List returns every registered ecosystem with its count of published
+// catalog items
+func (s *EcosystemStore) List(ctx context.Context) (*models.EcosystemRegistryResponse, error) {
+	rcProtocols, err := s.list(ctx, "rc_protocols", "rc_protocol")
+	if err != nil {
+		return nil, err
+	}
+	videoSystems, err := s.list(ctx, "video_systems", "video_system")
+	if err != nil {
+		return nil, err
+	}
+	return &models.EcosystemRegistryResponse{RCProtocols: rcProtocols, VideoSystems: videoSystems}, nil
+}
+
+// list reads one registry table; table and column are constants from List
+func (s *EcosystemStore) list(ctx context.Context, table, column string) ([]models.Ecosystem, error) {
+	query := fmt.Sprintf(`
+		SELECT r.id, r.name, r.description,
+		       (SELECT COUNT(*)
+		        FROM gear_catalog_ecosystems ge
+		        JOIN gear_catalog gc ON gc.id = ge.catalog_id
+		        WHERE ge.%s = r.id AND gc.status = 'published')
+		FROM %s r
+		ORDER BY r.sort_order, r.id
+	`, column, table)
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	ecosystems := make([]models.Ecosystem, 0)
+	for rows.Next() {
+		var ecosystem models.Ecosystem
+		if err := rows.Scan(&ecosystem.ID, &ecosystem.Name, &ecosystem.Description, &ecosystem.ItemCount); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", table, err)
+		}
+		ecosystems = append(ecosystems, ecosystem)
+	}
+	return ecosystems, rows.Err()
+}
+
+// CatalogItems returns the fields the ecosystem links are read from for
+// every catalog item
+func (s *EcosystemStore) CatalogItems(ctx context.Context) ([]models.GearCatalogItem, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, gear_type, brand, model, COALESCE(variant, ''), specs
+		FROM gear_catalog
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalog items for ecosystems: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]models.GearCatalogItem, 0)
+	for rows.Next() {
+		var item models.GearCatalogItem
+		var specs []byte
+		if err := rows.Scan(&item.ID, &item.GearType, &item.Brand, &item.Model, &item.Variant, &specs); err != nil {
+			return nil, fmt.Errorf("failed to scan catalog item for ecosystems: %w", err)
+		}
+		if len(specs) > 0 {
+			item.Specs = specs
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// ReplaceLinks replaces every catalog ecosystem link in one transaction, so
+// filters never see a half-written set. Returns the number of links written.
+func (s *EcosystemStore) ReplaceLinks(ctx context.Context, links []models.CatalogEcosystemLinks) (int64, error) {
+	var rcCatalogIDs, rcProtocols, videoCatalogIDs, videoSystems []string
+	for _, link := range links {
+		for _, protocol := range link.RCProtocols {
+			rcCatalogIDs = append(rcCatalogIDs, link.CatalogID)
+			rcProtocols = append(rcProtocols, string(protocol))
+		}
+		if link.VideoSystem != "" {
+			videoCatalogIDs = append(videoCatalogIDs, link.CatalogID)
+			videoSystems = append(videoSystems, string(link.VideoSystem))
+		}
+	}
+
+	var written int64
+	err := s.db.RunInTx(ctx, func(ctx context.Context) error {
+		if _, err := s.db.ExecContext(ctx, `DELETE FROM gear_catalog_ecosystems`); err != nil {
+			return fmt.Errorf("failed to clear catalog ecosystems: %w", err)
+		}
+		// Items deleted since they were read are skipped by the join
+		result, err := s.db.ExecContext(ctx, `
+			INSERT INTO gear_catalog_ecosystems (catalog_id, rc_protocol)
+			SELECT gc.id, l.rc_protocol
+			FROM unnest($1::uuid[], $2::text[]) AS l(catalog_id, rc_protocol)
+			JOIN gear_catalog gc ON gc.id = l.catalog_id
+			JOIN rc_protocols r ON r.id = l.rc_protocol
+		`, pq.Array(rcCatalogIDs), pq.Array(rcProtocols))
+		if err != nil {
+			return fmt.Errorf("failed to link catalog RC protocols: %w", err)
+		}
+		rcLinks, _ := result.RowsAffected()
+
+		result, err = s.db.ExecContext(ctx, `
+			INSERT INTO gear_catalog_ecosystems (catalog_id, video_system)
+			SELECT gc.id, l.video_system
+			FROM unnest($1::uuid[], $2::text[]) AS l(catalog_id, video_system)
+			JOIN gear_catalog gc ON gc.id = l.catalog_id
+			JOIN video_systems v ON v.id = l.video_system
+		`, pq.Array(videoCatalogIDs), pq.Array(videoSystems))
+		if err != nil {
+			return fmt.Errorf("failed to link catalog video systems: %w", err)
+		}
+		videoLinks, _ := result.RowsAffected()
+		written = rcLinks + videoLinks
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	s.db.invalidateTag(ctx, catalogSearchTag)
+	return written, nil
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// EntityImageStore handles the ordered images attached to builds and catalog
+// items. The primary image is still tracked by the entity's image_asset_id
+// column; a trigger keeps it mirrored into entity_images.
+type EntityImageStore struct {
+	db *DB
+}
+
+// NewEntityImageStore creates a new entity image store
+func NewEntityImageStore(db *DB) *EntityImageStore {
+	return &EntityImageStore{db: db}
+}
+
+// List returns an entity's image asset IDs in display order
+func (s *EntityImageStore) List(ctx context.Context, entityType models.ImageEntityType, entityID string) ([]string, error) {
+	query := `
+		SELECT image_asset_id FROM entity_images
+		WHERE entity_type = $1 AND entity_id = $2
+		ORDER BY position, created_at, id`
+
+	rows, err := s.db.QueryContext(ctx, query, string(entityType), entityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list entity images: %w", err)
+	}
+	defer rows.Close()
+
+	assetIDs := make([]string, 0)
+	for rows.Next() {
+		var assetID string
+		if err := rows.Scan(&assetID); err != nil {
+			return nil, fmt.Errorf("failed to scan entity image: %w", err)
+		}
+		assetIDs = append(assetIDs, assetID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list entity images: %w", err)
+	}
+	return assetIDs, nil
+}
+
+// Append adds an image after the entity's existing images
+func (s *EntityImageStore) Append(ctx context.Context, entityType models.ImageEntityType, entityID, assetID string) error {
+	query := `
+		INSERT INTO entity_images (entity_type, entity_id, image_asset_id, position)
+		SELECT $1, $2, $3, COALESCE(MAX(position) + 1, 0)
+		FROM entity_images
+		WHERE entity_type = $1 AND entity_id = $2
+		ON CONFLICT (entity_type, entity_id, image_asset_id) DO NOTHING`
+
+	if _, err := s.db.ExecContext(ctx, query, string(entityType), entityID, assetID); err != nil {
+		return fmt.Errorf("failed to append entity image: %w", err)
+	}
+	return nil
+}
+
+// Replace swaps one of an entity's images for another, keeping its position
+func (s *EntityImageStore) Replace(ctx context.Context, entityType models.ImageEntityType, entityID, oldAssetID, newAssetID string) error {
+	query := `
+		UPDATE entity_images SET image_asset_id = $4
+		WHERE entity_type = $1 AND entity_id = $2 AND image_asset_id = $3`
+
+	result, err := s.db.ExecContext(ctx, query, string(entityType), entityID, oldAssetID, newAssetID)
+	if err != nil {
+		return fmt.Errorf("failed to replace entity image: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("entity image not found")
+	}
+	return nil
+}
+
+// Remove detaches an image from an entity, leaving the image asset in place
+func (s *EntityImageStore) Remove(ctx context.Context, entityType models.ImageEntityType, entityID, assetID string) error {
+	query := `DELETE FROM entity_images WHERE entity_type = $1 AND entity_id = $2 AND image_asset_id = $3`
+
+	if _, err := s.db.ExecContext(ctx, query, string(entityType), entityID, assetID); err != nil {
+		return fmt.Errorf("failed to remove entity image: %w", err)
+	}
+	return nil
+}
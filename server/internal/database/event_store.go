@@ -0,0 +1,249 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// EventStore handles event and registration persistence
+type EventStore struct {
+	db *DB
+}
+
+// NewEventStore creates a new event store
+func NewEventStore(db *DB) *EventStore {
+	return &EventStore{db: db}
+}
+
+// eventSelect loads events with the organizer's name, the registration count,
+// and the caller's ($1) registration status and visibility
+var eventSelect = `
+	SELECT e.id, e.organizer_user_id, ` + fmt.Sprintf(groupUserName, "o") + `, COALESCE(e.group_id::text, ''),
+		e.name, e.description, e.location, e.starts_at, e.ends_at, e.visibility, e.max_pilots,
+		(SELECT COUNT(*) FROM event_registrations c WHERE c.event_id = e.id AND c.status = 'registered'),
+		e.created_at, e.updated_at,
+		COALESCE(r.status, ''),
+		(` + eventVisibleCondition + `)
+	FROM events e
+	JOIN users o ON o.id = e.organizer_user_id
+	LEFT JOIN event_registrations r ON r.event_id = e.id AND r.user_id::text = $1
+`
+
+// eventVisibleCondition is true when the user in $1 can see event e
+const eventVisibleCondition = `
+	e.organizer_user_id::text = $1
+	OR r.user_id IS NOT NULL
+	OR e.visibility = 'public'
+	OR (e.visibility = 'followers' AND EXISTS (
+		SELECT 1 FROM follows f WHERE f.followed_user_id = e.organizer_user_id AND f.follower_user_id::text = $1
+	))
+	OR (e.group_id IS NOT NULL AND EXISTS (
+		SELECT 1 FROM pilot_group_members gm WHERE gm.group_id = e.group_id AND gm.user_id::text = $1
+	))
+`
+
+// Create inserts an event
+func (s *EventStore) Create(ctx context.Context, organizerID string, params models.CreateEventParams) (string, error) {
+	var id string
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO events (organizer_user_id, group_id, name, description, location, starts_at, ends_at, visibility, max_pilots)
+		VALUES ($1, NULLIF($2, '')::uuid, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`, organizerID, params.GroupID, params.Name, params.Description, params.Location,
+		params.StartsAt, params.EndsAt, params.Visibility, params.MaxPilots).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("failed to create event: %w", err)
+	}
+	return id, nil
+}
+
+// Get retrieves an event as seen by the user. Returns nil if it does not exist.
+func (s *EventStore) Get(ctx context.Context, id, userID string) (*models.Event, error) {
+	event, err := scanEvent(s.db.QueryRowContext(ctx, eventSelect+` WHERE e.id = $2`, userID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	return event, nil
+}
+
+// ListVisible returns upcoming and ongoing events the user can see, soonest first
+func (s *EventStore) ListVisible(ctx context.Context, userID string, params models.EventListParams) ([]models.Event, error) {
+	if params.Limit <= 0 || params.Limit > 100 {
+		params.Limit = 50
+	}
+	if params.Offset < 0 {
+		params.Offset = 0
+	}
+
+	rows, err := s.db.QueryContext(ctx, eventSelect+`
+		WHERE COALESCE(e.ends_at, e.starts_at) >= $2 AND (`+eventVisibleCondition+`)
+		ORDER BY e.starts_at, e.name
+		LIMIT $3 OFFSET $4
+	`, userID, params.From, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.Event{}
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, *event)
+	}
+	return events, rows.Err()
+}
+
+// Update writes an event's editable fields
+func (s *EventStore) Update(ctx context.Context, event *models.Event) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE events
+		SET name = $2, description = $3, location = $4, starts_at = $5, ends_at = $6,
+			visibility = $7, max_pilots = $8, updated_at = NOW()
+		WHERE id = $1
+	`, event.ID, event.Name, event.Description, event.Location, event.StartsAt, event.EndsAt,
+		event.Visibility, event.MaxPilots)
+	if err != nil {
+		return fmt.Errorf("failed to update event: %w", err)
+	}
+	return nil
+}
+
+// Delete removes an event and its registrations
+func (s *EventStore) Delete(ctx context.Context, id string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM events WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete event: %w", err)
+	}
+	return nil
+}
+
+// Register signs a user up for an event, or updates their aircraft and
+// reactivates a cancelled registration. Returns false if the event is full.
+func (s *EventStore) Register(ctx context.Context, eventID, userID, aircraftID string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO event_registrations (event_id, user_id, aircraft_id)
+		SELECT e.id, $2, NULLIF($3, '')::uuid
+		FROM events e
+		WHERE e.id = $1
+		  AND (
+			e.max_pilots IS NULL
+			OR EXISTS (SELECT 1 FROM event_registrations x WHERE x.event_id = e.id AND x.user_id = $2 AND x.status = 'registered')
+			OR (SELECT COUNT(*) FROM event_registrations c WHERE c.event_id = e.id AND c.status = 'registered') < e.max_pilots
+		  )
+		ON CONFLICT (event_id, user_id) DO UPDATE
+		SET aircraft_id = EXCLUDED.aircraft_id,
+			status = 'registered',
+			registered_at = CASE WHEN event_registrations.status = 'registered'
+				THEN event_registrations.registered_at ELSE NOW() END
+	`, eventID, userID, aircraftID)
+	if err != nil {
+		return false, fmt.Errorf("failed to register for event: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// Cancel cancels a user's registration. Returns false if they weren't registered.
+func (s *EventStore) Cancel(ctx context.Context, eventID, userID string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE event_registrations
+		SET status = 'cancelled', checked_in_at = NULL
+		WHERE event_id = $1 AND user_id = $2 AND status = 'registered'
+	`, eventID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel event registration: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// SetCheckedIn checks a registered pilot in, or undoes it. Returns false if
+// they aren't registered.
+func (s *EventStore) SetCheckedIn(ctx context.Context, eventID, userID string, checkedIn bool) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE event_registrations
+		SET checked_in_at = CASE WHEN $3 THEN COALESCE(checked_in_at, NOW()) ELSE NULL END
+		WHERE event_id = $1 AND user_id = $2 AND status = 'registered'
+	`, eventID, userID, checkedIn)
+	if err != nil {
+		return false, fmt.Errorf("failed to update event check-in: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// ListRegistrations returns an event's active registrations in sign-up order
+func (s *EventStore) ListRegistrations(ctx context.Context, eventID string) ([]models.EventRegistration, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT r.event_id, r.user_id, COALESCE(u.call_sign, ''), `+fmt.Sprintf(groupUserName, "u")+`,
+			COALESCE(r.aircraft_id::text, ''), COALESCE(a.name, ''), r.status, r.registered_at, r.checked_in_at
+		FROM event_registrations r
+		JOIN users u ON u.id = r.user_id
+		LEFT JOIN aircraft a ON a.id = r.aircraft_id
+		WHERE r.event_id = $1 AND r.status = 'registered'
+		ORDER BY r.registered_at, u.call_sign
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list event registrations: %w", err)
+	}
+	defer rows.Close()
+
+	registrations := []models.EventRegistration{}
+	for rows.Next() {
+		var reg models.EventRegistration
+		var checkedInAt sql.NullTime
+		if err := rows.Scan(&reg.EventID, &reg.UserID, &reg.CallSign, &reg.DisplayName,
+			&reg.AircraftID, &reg.AircraftName, &reg.Status, &reg.RegisteredAt, &checkedInAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event registration: %w", err)
+		}
+		if checkedInAt.Valid {
+			reg.CheckedInAt = &checkedInAt.Time
+		}
+		registrations = append(registrations, reg)
+	}
+	return registrations, rows.Err()
+}
+
+func scanEvent(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.Event, error) {
+	var event models.Event
+	var endsAt sql.NullTime
+	var maxPilots sql.NullInt64
+	if err := scanner.Scan(
+		&event.ID,
+		&event.OrganizerUserID,
+		&event.OrganizerName,
+		&event.GroupID,
+		&event.Name,
+		&event.Description,
+		&event.Location,
+		&event.StartsAt,
+		&endsAt,
+		&event.Visibility,
+		&maxPilots,
+		&event.RegisteredCount,
+		&event.CreatedAt,
+		&event.UpdatedAt,
+		&event.MyStatus,
+		&event.Visible,
+	); err != nil {
+		return nil, err
+	}
+	if endsAt.Valid {
+		event.EndsAt = &endsAt.Time
+	}
+	if maxPilots.Valid {
+		limit := int(maxPilots.Int64)
+		event.MaxPilots = &limit
+	}
+	return &event, nil
+}
@@ -6,12 +6,16 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/johnrirwin/flyingforge/internal/models"
 )
 
+// ErrFCConfigNotFound is returned when a config doesn't exist or isn't the user's
+var ErrFCConfigNotFound = errors.New("config not found")
+
 // FCConfigStore handles flight controller config database operations
 type FCConfigStore struct {
 	db *DB
@@ -37,13 +41,25 @@ func (s *FCConfigStore) SaveConfig(ctx context.Context, userID string, config *m
 		}
 	}
 
+	osdLayout, err := marshalOSDLayout(config.OSDLayout)
+	if err != nil {
+		return err
+	}
+
+	// The board name is the real target on Betaflight 4.1+, where the
+	// board target is only the MCU, so it's matched first
 	query := `
 		INSERT INTO fc_configs (
 			user_id, inventory_item_id, name, notes, raw_cli_dump,
 			firmware_name, firmware_version, board_target, board_name, mcu_type,
-			parse_status, parse_warnings, parsed_tuning
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
-		RETURNING id, created_at, updated_at
+			parse_status, parse_warnings, parsed_tuning, osd_layout, firmware_target
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, (
+			SELECT target FROM firmware_targets
+			WHERE target IN (UPPER($9), UPPER($8))
+			ORDER BY target = UPPER($9) DESC
+			LIMIT 1
+		))
+		RETURNING id, created_at, updated_at, COALESCE(firmware_target, '')
 	`
 
 	err = s.db.QueryRowContext(ctx, query,
@@ -60,7 +76,8 @@ func (s *FCConfigStore) SaveConfig(ctx context.Context, userID string, config *m
 		config.ParseStatus,
 		parseWarnings,
 		parsedTuning,
-	).Scan(&config.ID, &config.CreatedAt, &config.UpdatedAt)
+		osdLayout,
+	).Scan(&config.ID, &config.CreatedAt, &config.UpdatedAt, &config.FirmwareTarget)
 
 	if err != nil {
 		return fmt.Errorf("failed to save FC config: %w", err)
@@ -75,14 +92,14 @@ func (s *FCConfigStore) GetConfig(ctx context.Context, id string, userID string)
 	query := `
 		SELECT id, user_id, inventory_item_id, name, notes, raw_cli_dump,
 			   firmware_name, firmware_version, board_target, board_name, mcu_type,
-			   parse_status, parse_warnings, parsed_tuning, created_at, updated_at
+			   COALESCE(firmware_target, ''), parse_status, parse_warnings, parsed_tuning, osd_layout, created_at, updated_at
 		FROM fc_configs
 		WHERE id = $1 AND user_id = $2
 	`
 
 	config := &models.FlightControllerConfig{}
 	var notes, firmwareVersion, boardTarget, boardName, mcuType sql.NullString
-	var parseWarnings, parsedTuning []byte
+	var parseWarnings, parsedTuning, osdLayout []byte
 
 	err := s.db.QueryRowContext(ctx, query, id, userID).Scan(
 		&config.ID,
@@ -96,9 +113,11 @@ func (s *FCConfigStore) GetConfig(ctx context.Context, id string, userID string)
 		&boardTarget,
 		&boardName,
 		&mcuType,
+		&config.FirmwareTarget,
 		&config.ParseStatus,
 		&parseWarnings,
 		&parsedTuning,
+		&osdLayout,
 		&config.CreatedAt,
 		&config.UpdatedAt,
 	)
@@ -123,6 +142,10 @@ func (s *FCConfigStore) GetConfig(ctx context.Context, id string, userID string)
 		config.ParsedTuning = &models.ParsedTuning{}
 		_ = json.Unmarshal(parsedTuning, config.ParsedTuning)
 	}
+	if len(osdLayout) > 0 {
+		config.OSDLayout = &models.OSDLayout{}
+		_ = json.Unmarshal(osdLayout, config.OSDLayout)
+	}
 
 	return config, nil
 }
@@ -133,7 +156,7 @@ func (s *FCConfigStore) ListConfigs(ctx context.Context, userID string, params m
 	listQuery := `
 		SELECT id, user_id, inventory_item_id, name, notes,
 			   firmware_name, firmware_version, board_target, board_name, mcu_type,
-			   parse_status, parse_warnings, created_at, updated_at
+			   COALESCE(firmware_target, ''), parse_status, parse_warnings, created_at, updated_at
 		FROM fc_configs
 		WHERE user_id = $1
 	`
@@ -190,6 +213,7 @@ func (s *FCConfigStore) ListConfigs(ctx context.Context, userID string, params m
 			&boardTarget,
 			&boardName,
 			&mcuType,
+			&config.FirmwareTarget,
 			&config.ParseStatus,
 			&parseWarnings,
 			&config.CreatedAt,
@@ -265,6 +289,44 @@ func (s *FCConfigStore) UpdateConfig(ctx context.Context, id string, userID stri
 	return s.GetConfig(ctx, id, userID)
 }
 
+// UpdateOSDLayout replaces a config's OSD layout. It returns nil when the
+// config doesn't exist or isn't the user's.
+func (s *FCConfigStore) UpdateOSDLayout(ctx context.Context, id string, userID string, layout *models.OSDLayout) (*models.FlightControllerConfig, error) {
+	osdLayout, err := marshalOSDLayout(layout)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE fc_configs
+		SET osd_layout = $1, updated_at = NOW()
+		WHERE id = $2 AND user_id = $3
+	`
+
+	result, err := s.db.ExecContext(ctx, query, osdLayout, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update OSD layout: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return nil, nil
+	}
+
+	return s.GetConfig(ctx, id, userID)
+}
+
+func marshalOSDLayout(layout *models.OSDLayout) ([]byte, error) {
+	if layout == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(layout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal OSD layout: %w", err)
+	}
+	return data, nil
+}
+
 // DeleteConfig deletes a config
 func (s *FCConfigStore) DeleteConfig(ctx context.Context, id string, userID string) error {
 	query := `DELETE FROM fc_configs WHERE id = $1 AND user_id = $2`
@@ -275,7 +337,7 @@ func (s *FCConfigStore) DeleteConfig(ctx context.Context, id string, userID stri
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		return fmt.Errorf("config not found")
+		return ErrFCConfigNotFound
 	}
 
 	return nil
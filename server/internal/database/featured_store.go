@@ -0,0 +1,197 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ErrFeaturedContentNotFound is returned when a featured slot doesn't exist
+var ErrFeaturedContentNotFound = errors.New("featured content not found")
+
+// featuredStatusSQL derives a slot's schedule status from the current time
+const featuredStatusSQL = `
+	CASE
+		WHEN starts_at > NOW() THEN 'scheduled'
+		WHEN ends_at IS NOT NULL AND ends_at <= NOW() THEN 'expired'
+		ELSE 'active'
+	END`
+
+const featuredColumns = `id, content_type, content_id, headline, blurb, position, starts_at, ends_at,
+	` + featuredStatusSQL + `, COALESCE(created_by::text, ''), created_at, updated_at`
+
+// FeaturedStore handles featured content scheduling
+type FeaturedStore struct {
+	db *DB
+}
+
+// NewFeaturedStore creates a new featured content store
+func NewFeaturedStore(db *DB) *FeaturedStore {
+	return &FeaturedStore{db: db}
+}
+
+// Create schedules a new featured slot
+func (s *FeaturedStore) Create(ctx context.Context, createdBy string, params models.CreateFeaturedContentParams) (*models.FeaturedContent, error) {
+	query := `
+		INSERT INTO featured_content (content_type, content_id, headline, blurb, position, starts_at, ends_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6, NOW()), $7, $8)
+		RETURNING ` + featuredColumns
+
+	item, err := scanFeatured(s.db.QueryRowContext(ctx, query,
+		params.ContentType, params.ContentID, params.Headline, params.Blurb, params.Position,
+		params.StartsAt, params.EndsAt, nullString(createdBy),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create featured content: %w", err)
+	}
+	return item, nil
+}
+
+// Get retrieves a featured slot by ID
+func (s *FeaturedStore) Get(ctx context.Context, id string) (*models.FeaturedContent, error) {
+	item, err := scanFeatured(s.db.QueryRowContext(ctx, `SELECT `+featuredColumns+` FROM featured_content WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get featured content: %w", err)
+	}
+	return item, nil
+}
+
+// Update replaces a featured slot's editable fields
+func (s *FeaturedStore) Update(ctx context.Context, id string, params models.UpdateFeaturedContentParams) (*models.FeaturedContent, error) {
+	query := `
+		UPDATE featured_content
+		SET headline = $2, blurb = $3, position = $4, starts_at = $5, ends_at = $6, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + featuredColumns
+
+	item, err := scanFeatured(s.db.QueryRowContext(ctx, query,
+		id, params.Headline, params.Blurb, params.Position, params.StartsAt, params.EndsAt,
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update featured content: %w", err)
+	}
+	return item, nil
+}
+
+// Delete removes a featured slot
+func (s *FeaturedStore) Delete(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM featured_content WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete featured content: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrFeaturedContentNotFound
+	}
+	return nil
+}
+
+// List returns featured slots for admins, latest start first
+func (s *FeaturedStore) List(ctx context.Context, params models.FeaturedContentListParams) (*models.FeaturedContentListResponse, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+	offset := params.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	var conditions []string
+	var args []interface{}
+	if params.Status != "" {
+		args = append(args, params.Status)
+		conditions = append(conditions, fmt.Sprintf("(%s) = $%d", featuredStatusSQL, len(args)))
+	}
+	if params.ContentType != "" {
+		args = append(args, params.ContentType)
+		conditions = append(conditions, fmt.Sprintf("content_type = $%d", len(args)))
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var totalCount int
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM featured_content "+where, args...).Scan(&totalCount); err != nil {
+		return nil, fmt.Errorf("failed to count featured content: %w", err)
+	}
+
+	args = append(args, limit, offset)
+	query := fmt.Sprintf(`SELECT %s FROM featured_content %s ORDER BY starts_at DESC, position ASC LIMIT $%d OFFSET $%d`,
+		featuredColumns, where, len(args)-1, len(args))
+
+	items, err := s.queryFeatured(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &models.FeaturedContentListResponse{Items: items, TotalCount: totalCount}, nil
+}
+
+// ListActive returns slots whose schedule includes now, in display order
+func (s *FeaturedStore) ListActive(ctx context.Context, contentType models.FeaturedContentType, limit int) ([]models.FeaturedContent, error) {
+	query := `
+		SELECT ` + featuredColumns + `
+		FROM featured_content
+		WHERE starts_at <= NOW() AND (ends_at IS NULL OR ends_at > NOW())
+		  AND ($1 = '' OR content_type = $1)
+		ORDER BY position ASC, starts_at DESC
+		LIMIT $2
+	`
+	return s.queryFeatured(ctx, query, string(contentType), limit)
+}
+
+func (s *FeaturedStore) queryFeatured(ctx context.Context, query string, args ...interface{}) ([]models.FeaturedContent, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list featured content: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]models.FeaturedContent, 0)
+	for rows.Next() {
+		item, err := scanFeatured(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan featured content: %w", err)
+		}
+		items = append(items, *item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list featured content: %w", err)
+	}
+	return items, nil
+}
+
+func scanFeatured(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.FeaturedContent, error) {
+	var item models.FeaturedContent
+	var endsAt sql.NullTime
+	if err := scanner.Scan(
+		&item.ID, &item.ContentType, &item.ContentID, &item.Headline, &item.Blurb, &item.Position,
+		&item.StartsAt, &endsAt, &item.Status, &item.CreatedByUserID, &item.CreatedAt, &item.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if endsAt.Valid {
+		item.EndsAt = &endsAt.Time
+	}
+	return &item, nil
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ErrFeedFilterNotFound is returned when a feed filter doesn't exist
+var ErrFeedFilterNotFound = errors.New("feed filter not found")
+
+const feedFilterColumns = `id, kind, COALESCE(pattern, ''), min_length, sources, enabled, COALESCE(note, ''), created_at, updated_at`
+
+// FeedFilterStore handles the admin-managed feed filters
+type FeedFilterStore struct {
+	db *DB
+}
+
+// NewFeedFilterStore creates a new feed filter store
+func NewFeedFilterStore(db *DB) *FeedFilterStore {
+	return &FeedFilterStore{db: db}
+}
+
+// List returns every filter, oldest first
+func (s *FeedFilterStore) List(ctx context.Context) ([]models.FeedFilter, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+feedFilterColumns+` FROM feed_filters ORDER BY created_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feed filters: %w", err)
+	}
+	defer rows.Close()
+
+	filters := make([]models.FeedFilter, 0)
+	for rows.Next() {
+		filter, err := scanFeedFilter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feed filter: %w", err)
+		}
+		filters = append(filters, *filter)
+	}
+	return filters, rows.Err()
+}
+
+// Create adds a filter. Params must already be validated.
+func (s *FeedFilterStore) Create(ctx context.Context, createdBy string, params models.SaveFeedFilterParams) (*models.FeedFilter, error) {
+	query := `
+		INSERT INTO feed_filters (kind, pattern, min_length, sources, enabled, note, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + feedFilterColumns
+	filter, err := scanFeedFilter(s.db.QueryRowContext(ctx, query,
+		params.Kind, nullString(params.Pattern), params.MinLength, pq.Array(params.Sources), *params.Enabled, nullString(params.Note), nullString(createdBy),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create feed filter: %w", err)
+	}
+	return filter, nil
+}
+
+// Update replaces a filter, returning nil if it doesn't exist
+func (s *FeedFilterStore) Update(ctx context.Context, id string, params models.SaveFeedFilterParams) (*models.FeedFilter, error) {
+	query := `
+		UPDATE feed_filters
+		SET kind = $2, pattern = $3, min_length = $4, sources = $5, enabled = $6, note = $7, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + feedFilterColumns
+	filter, err := scanFeedFilter(s.db.QueryRowContext(ctx, query,
+		id, params.Kind, nullString(params.Pattern), params.MinLength, pq.Array(params.Sources), *params.Enabled, nullString(params.Note),
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update feed filter: %w", err)
+	}
+	return filter, nil
+}
+
+// Delete removes a filter
+func (s *FeedFilterStore) Delete(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM feed_filters WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete feed filter: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrFeedFilterNotFound
+	}
+	return nil
+}
+
+func scanFeedFilter(row interface{ Scan(...interface{}) error }) (*models.FeedFilter, error) {
+	var filter models.FeedFilter
+	if err := row.Scan(
+		&filter.ID, &filter.Kind, &filter.Pattern, &filter.MinLength, pq.Array(&filter.Sources), &filter.Enabled, &filter.Note,
+		&filter.CreatedAt, &filter.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if filter.Sources == nil {
+		filter.Sources = []string{}
+	}
+	return &filter, nil
+}
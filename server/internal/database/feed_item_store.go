@@ -12,6 +12,15 @@ import (
 	"github.com/johnrirwin/flyingforge/internal/models"
 )
 
+// feedItemColumns are the columns scanFeedItem reads
+const feedItemColumns = `
+			id, title, url, source, source_type,
+			author, summary, content,
+			published_at, fetched_at,
+			thumbnail, tags,
+			upvotes, comments,
+			media_type, media_image_url, media_video_url, media_duration`
+
 // FeedItemStore persists aggregated feed items in Postgres.
 type FeedItemStore struct {
 	db *DB
@@ -137,10 +146,147 @@ func (s *FeedItemStore) DeleteItemsOlderThan(ctx context.Context, cutoff time.Ti
 	return rows, nil
 }
 
+// DeleteItems removes items by ID, e.g. ones a feed filter now blocks.
+func (s *FeedItemStore) DeleteItems(ctx context.Context, ids []string) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	res, err := s.db.ExecContext(ctx, `DELETE FROM feed_items WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return 0, fmt.Errorf("delete feed items: %w", err)
+	}
+	rows, _ := res.RowsAffected()
+	return rows, nil
+}
+
 // QueryItems returns items + total matching count (before limit/offset).
 // resolvedSources should contain normalized source names (lowercased) that map
 // to FeedItem.Source values, not SourceInfo IDs.
 func (s *FeedItemStore) QueryItems(ctx context.Context, params models.FilterParams, resolvedSources []string) ([]models.FeedItem, int, error) {
+	whereParts, args := feedItemFilters(params, resolvedSources)
+	argPos := len(args) + 1
+
+	// Filter by query (case-insensitive search across multiple fields).
+	if strings.TrimSpace(params.Query) != "" {
+		placeholder := fmt.Sprintf("$%d", argPos)
+		whereParts = append(whereParts, fmt.Sprintf("(title ILIKE %s OR summary ILIKE %s OR content ILIKE %s OR source ILIKE %s)", placeholder, placeholder, placeholder, placeholder))
+		args = append(args, "%"+strings.TrimSpace(params.Query)+"%")
+		argPos++
+	}
+
+	whereSQL := strings.Join(whereParts, " AND ")
+
+	// Count query (no limit/offset).
+	countQuery := "SELECT COUNT(*) FROM feed_items WHERE " + whereSQL
+	var total int
+	if err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count feed items: %w", err)
+	}
+
+	// Sort.
+	orderSQL := "ORDER BY published_at DESC"
+	switch strings.ToLower(strings.TrimSpace(params.Sort)) {
+	case "score", "top":
+		orderSQL = "ORDER BY (COALESCE(upvotes, 0) + COALESCE(comments, 0)) DESC, published_at DESC"
+	}
+
+	// Select query + pagination.
+	selectQuery := `
+		SELECT ` + feedItemColumns + `
+		FROM feed_items
+		WHERE ` + whereSQL + "\n\t\t" + orderSQL
+
+	selectArgs := append([]interface{}{}, args...)
+	if params.Limit > 0 {
+		selectQuery += fmt.Sprintf("\n\t\tLIMIT $%d OFFSET $%d", argPos, argPos+1)
+		selectArgs = append(selectArgs, params.Limit, params.Offset)
+	}
+
+	rows, err := s.db.QueryContext(ctx, selectQuery, selectArgs...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query feed items: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]models.FeedItem, 0)
+	for rows.Next() {
+		item, err := scanFeedItem(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate feed items: %w", err)
+	}
+
+	return items, total, nil
+}
+
+// SearchItems runs a full-text search over the archive. query uses web
+// search syntax: quoted phrases, OR, and -word to exclude. Results come back
+// by relevance unless params.Sort is "newest", each with a short snippet
+// where matches are wrapped in snippetStart and snippetStop.
+func (s *FeedItemStore) SearchItems(ctx context.Context, params models.FilterParams, resolvedSources []string, snippetStart, snippetStop string) ([]models.FeedSearchResult, int, error) {
+	whereParts, args := feedItemFilters(params, resolvedSources)
+	args = append(args, strings.TrimSpace(params.Query))
+	queryPos := len(args)
+	whereParts = append(whereParts, fmt.Sprintf("search_vector @@ websearch_to_tsquery('english', $%d)", queryPos))
+	whereSQL := strings.Join(whereParts, " AND ")
+
+	var total int
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM feed_items WHERE "+whereSQL, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count feed search results: %w", err)
+	}
+
+	orderSQL := "rank DESC, published_at DESC"
+	if strings.ToLower(strings.TrimSpace(params.Sort)) == "newest" {
+		orderSQL = "published_at DESC"
+	}
+
+	// Headlines are only built for the page being returned, since
+	// ts_headline re-parses each document
+	args = append(args, params.Limit, params.Offset, fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxWords=35, MinWords=15`, snippetStart, snippetStop))
+	query := fmt.Sprintf(`
+		SELECT %s,
+			rank,
+			ts_headline('english', COALESCE(NULLIF(summary, ''), NULLIF(content, ''), title), websearch_to_tsquery('english', $%d), $%d)
+		FROM (
+			SELECT *, ts_rank_cd(search_vector, websearch_to_tsquery('english', $%d)) AS rank
+			FROM feed_items
+			WHERE %s
+			ORDER BY %s
+			LIMIT $%d OFFSET $%d
+		) page
+		ORDER BY %s
+	`, feedItemColumns, queryPos, queryPos+3, queryPos, whereSQL, orderSQL, queryPos+1, queryPos+2, orderSQL)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("search feed items: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]models.FeedSearchResult, 0)
+	for rows.Next() {
+		var result models.FeedSearchResult
+		item, err := scanFeedItem(rows, &result.Rank, &result.Snippet)
+		if err != nil {
+			return nil, 0, err
+		}
+		result.FeedItem = item
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate feed search results: %w", err)
+	}
+
+	return results, total, nil
+}
+
+// feedItemFilters builds the WHERE conditions shared by listing and search
+// for sources, source type, tag, and date range. Placeholders start at $1.
+func feedItemFilters(params models.FilterParams, resolvedSources []string) ([]string, []interface{}) {
 	whereParts := []string{"TRUE"}
 	args := make([]interface{}, 0)
 	argPos := 1
@@ -185,14 +331,6 @@ func (s *FeedItemStore) QueryItems(ctx context.Context, params models.FilterPara
 		argPos++
 	}
 
-	// Filter by query (case-insensitive search across multiple fields).
-	if strings.TrimSpace(params.Query) != "" {
-		placeholder := fmt.Sprintf("$%d", argPos)
-		whereParts = append(whereParts, fmt.Sprintf("(title ILIKE %s OR summary ILIKE %s OR content ILIKE %s OR source ILIKE %s)", placeholder, placeholder, placeholder, placeholder))
-		args = append(args, "%"+strings.TrimSpace(params.Query)+"%")
-		argPos++
-	}
-
 	// Filter by date range.
 	if fromTime, ok := models.ParseDateFilter(params.FromDate); ok {
 		whereParts = append(whereParts, fmt.Sprintf("published_at >= $%d", argPos))
@@ -207,116 +345,74 @@ func (s *FeedItemStore) QueryItems(ctx context.Context, params models.FilterPara
 		argPos++
 	}
 
-	whereSQL := strings.Join(whereParts, " AND ")
+	return whereParts, args
+}
 
-	// Count query (no limit/offset).
-	countQuery := "SELECT COUNT(*) FROM feed_items WHERE " + whereSQL
-	var total int
-	if err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
-		return nil, 0, fmt.Errorf("count feed items: %w", err)
+// scanFeedItem scans the feedItemColumns, followed by any extra columns
+func scanFeedItem(row interface{ Scan(...interface{}) error }, extra ...interface{}) (models.FeedItem, error) {
+	var item models.FeedItem
+	var author, summary, content, thumbnail sql.NullString
+	var tags pq.StringArray
+	var upvotes, comments sql.NullInt64
+	var mediaType, mediaImageURL, mediaVideoURL, mediaDuration sql.NullString
+
+	dest := []interface{}{
+		&item.ID,
+		&item.Title,
+		&item.URL,
+		&item.Source,
+		&item.SourceType,
+		&author,
+		&summary,
+		&content,
+		&item.PublishedAt,
+		&item.FetchedAt,
+		&thumbnail,
+		&tags,
+		&upvotes,
+		&comments,
+		&mediaType,
+		&mediaImageURL,
+		&mediaVideoURL,
+		&mediaDuration,
 	}
-
-	// Sort.
-	orderSQL := "ORDER BY published_at DESC"
-	switch strings.ToLower(strings.TrimSpace(params.Sort)) {
-	case "score", "top":
-		orderSQL = "ORDER BY (COALESCE(upvotes, 0) + COALESCE(comments, 0)) DESC, published_at DESC"
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return item, fmt.Errorf("scan feed item: %w", err)
 	}
 
-	// Select query + pagination.
-	selectQuery := `
-		SELECT
-			id, title, url, source, source_type,
-			author, summary, content,
-			published_at, fetched_at,
-			thumbnail, tags,
-			upvotes, comments,
-			media_type, media_image_url, media_video_url, media_duration
-		FROM feed_items
-		WHERE ` + whereSQL + "\n\t\t" + orderSQL
-
-	selectArgs := append([]interface{}{}, args...)
-	if params.Limit > 0 {
-		selectQuery += fmt.Sprintf("\n\t\tLIMIT $%d OFFSET $%d", argPos, argPos+1)
-		selectArgs = append(selectArgs, params.Limit, params.Offset)
+	if author.Valid {
+		item.Author = author.String
 	}
-
-	rows, err := s.db.QueryContext(ctx, selectQuery, selectArgs...)
-	if err != nil {
-		return nil, 0, fmt.Errorf("query feed items: %w", err)
+	if summary.Valid {
+		item.Summary = summary.String
+	}
+	if content.Valid {
+		item.Content = content.String
+	}
+	if thumbnail.Valid {
+		item.Thumbnail = thumbnail.String
 	}
-	defer rows.Close()
-
-	items := make([]models.FeedItem, 0)
-	for rows.Next() {
-		var item models.FeedItem
-		var author, summary, content, thumbnail sql.NullString
-		var tags pq.StringArray
-		var upvotes, comments sql.NullInt64
-		var mediaType, mediaImageURL, mediaVideoURL, mediaDuration sql.NullString
-
-		if err := rows.Scan(
-			&item.ID,
-			&item.Title,
-			&item.URL,
-			&item.Source,
-			&item.SourceType,
-			&author,
-			&summary,
-			&content,
-			&item.PublishedAt,
-			&item.FetchedAt,
-			&thumbnail,
-			&tags,
-			&upvotes,
-			&comments,
-			&mediaType,
-			&mediaImageURL,
-			&mediaVideoURL,
-			&mediaDuration,
-		); err != nil {
-			return nil, 0, fmt.Errorf("scan feed item: %w", err)
-		}
 
-		if author.Valid {
-			item.Author = author.String
-		}
-		if summary.Valid {
-			item.Summary = summary.String
-		}
-		if content.Valid {
-			item.Content = content.String
-		}
-		if thumbnail.Valid {
-			item.Thumbnail = thumbnail.String
-		}
+	item.Tags = []string(tags)
+	if item.Tags == nil {
+		item.Tags = []string{}
+	}
 
-		item.Tags = []string(tags)
-		if item.Tags == nil {
-			item.Tags = []string{}
-		}
-
-		if upvotes.Valid || comments.Valid {
-			item.Engagement = &models.Engagement{
-				Upvotes:  int(upvotes.Int64),
-				Comments: int(comments.Int64),
-			}
+	if upvotes.Valid || comments.Valid {
+		item.Engagement = &models.Engagement{
+			Upvotes:  int(upvotes.Int64),
+			Comments: int(comments.Int64),
 		}
+	}
 
-		if mediaType.Valid || mediaImageURL.Valid || mediaVideoURL.Valid || mediaDuration.Valid {
-			item.Media = &models.MediaInfo{
-				Type:     mediaType.String,
-				ImageUrl: mediaImageURL.String,
-				VideoUrl: mediaVideoURL.String,
-				Duration: mediaDuration.String,
-			}
+	if mediaType.Valid || mediaImageURL.Valid || mediaVideoURL.Valid || mediaDuration.Valid {
+		item.Media = &models.MediaInfo{
+			Type:     mediaType.String,
+			ImageUrl: mediaImageURL.String,
+			VideoUrl: mediaVideoURL.String,
+			Duration: mediaDuration.String,
 		}
-
-		items = append(items, item)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("iterate feed items: %w", err)
 	}
 
-	return items, total, nil
+	return item, nil
 }
@@ -0,0 +1,216 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// FirmwareTargetStore persists the Betaflight board target registry and
+// links FC configs and catalog flight controllers to it
+type FirmwareTargetStore struct {
+	db *DB
+}
+
+// NewFirmwareTargetStore creates a new firmware target store
+func NewFirmwareTargetStore(db *DB) *FirmwareTargetStore {
+	return &FirmwareTargetStore{db: db}
+}
+
+// Sync upserts the current target list and marks targets missing from it
+// as removed. Returns how many targets were new and how many were removed.
+func (s *FirmwareTargetStore) Sync(ctx context.Context, targets []models.FirmwareTarget) (int, int64, error) {
+	names := make([]string, len(targets))
+	manufacturers := make([]string, len(targets))
+	mcus := make([]string, len(targets))
+	for i, target := range targets {
+		names[i] = target.Target
+		manufacturers[i] = target.Manufacturer
+		mcus[i] = target.MCU
+	}
+
+	var added int
+	var removed int64
+	err := s.db.RunInTx(ctx, func(ctx context.Context) error {
+		rows, err := s.db.QueryContext(ctx, `
+			INSERT INTO firmware_targets (target, manufacturer, mcu)
+			SELECT * FROM unnest($1::text[], $2::text[], $3::text[])
+			ON CONFLICT (target) DO UPDATE SET
+				manufacturer = EXCLUDED.manufacturer,
+				mcu = EXCLUDED.mcu,
+				last_seen_at = NOW(),
+				removed_at = NULL
+			RETURNING xmax = 0
+		`, pq.Array(names), pq.Array(manufacturers), pq.Array(mcus))
+		if err != nil {
+			return fmt.Errorf("failed to upsert firmware targets: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var inserted bool
+			if err := rows.Scan(&inserted); err != nil {
+				return fmt.Errorf("failed to scan firmware target upsert: %w", err)
+			}
+			if inserted {
+				added++
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to upsert firmware targets: %w", err)
+		}
+
+		result, err := s.db.ExecContext(ctx, `
+			UPDATE firmware_targets SET removed_at = NOW()
+			WHERE removed_at IS NULL AND NOT (target = ANY($1::text[]))
+		`, pq.Array(names))
+		if err != nil {
+			return fmt.Errorf("failed to mark removed firmware targets: %w", err)
+		}
+		removed, _ = result.RowsAffected()
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return added, removed, nil
+}
+
+// LinkConfigs links FC configs saved before their target was known. The
+// board name is matched before the board target, which on Betaflight 4.1+
+// is only the MCU.
+func (s *FirmwareTargetStore) LinkConfigs(ctx context.Context) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE fc_configs c SET firmware_target = (
+			SELECT t.target FROM firmware_targets t
+			WHERE t.target IN (UPPER(c.board_name), UPPER(c.board_target))
+			ORDER BY t.target = UPPER(c.board_name) DESC
+			LIMIT 1
+		)
+		WHERE c.firmware_target IS NULL
+		  AND EXISTS (
+			SELECT 1 FROM firmware_targets t
+			WHERE t.target IN (UPPER(c.board_name), UPPER(c.board_target))
+		  )
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to link FC configs to firmware targets: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// LinkCatalog links catalog flight controllers and AIOs to their target.
+// A `target` spec wins; otherwise the brand and model, with and without
+// the variant and run together, are tried as a target name, which is how
+// Betaflight names most boards (SpeedyBee F405 V4 is SPEEDYBEEF405V4).
+func (s *FirmwareTargetStore) LinkCatalog(ctx context.Context) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `
+		WITH candidates AS (
+			SELECT gc.id,
+			       NULLIF(UPPER(regexp_replace(COALESCE(gc.specs->>'target', ''), '[^[:alnum:]_]+', '', 'g')), '') AS spec_target,
+			       UPPER(gc.search_compact) AS full_name,
+			       UPPER(regexp_replace(gc.brand || gc.model, '[^[:alnum:]]+', '', 'g')) AS short_name
+			FROM gear_catalog gc
+			WHERE gc.gear_type IN ('fc', 'aio') AND gc.firmware_target IS NULL
+		),
+		matched AS (
+			SELECT DISTINCT ON (c.id) c.id, t.target
+			FROM candidates c
+			JOIN firmware_targets t ON t.target IN (c.spec_target, c.full_name, c.short_name)
+			ORDER BY c.id, t.target = c.spec_target DESC, t.target = c.full_name DESC
+		)
+		UPDATE gear_catalog gc SET firmware_target = m.target
+		FROM matched m
+		WHERE gc.id = m.id
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to link catalog items to firmware targets: %w", err)
+	}
+	linked, err := result.RowsAffected()
+	if linked > 0 {
+		s.db.invalidateTag(ctx, catalogSearchTag)
+	}
+	return linked, err
+}
+
+// Search returns targets starting with prefix, current targets first. The
+// prefix must already be normalized.
+func (s *FirmwareTargetStore) Search(ctx context.Context, prefix string, limit int) ([]models.FirmwareTarget, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT target, manufacturer, mcu, removed_at, first_seen_at, last_seen_at
+		FROM firmware_targets
+		WHERE target LIKE $1 || '%'
+		ORDER BY removed_at IS NOT NULL, target
+		LIMIT $2
+	`, strings.ReplaceAll(prefix, "_", `\_`), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search firmware targets: %w", err)
+	}
+	defer rows.Close()
+
+	targets := make([]models.FirmwareTarget, 0)
+	for rows.Next() {
+		target, err := scanFirmwareTarget(rows)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, *target)
+	}
+	return targets, rows.Err()
+}
+
+// Get returns a target with the published catalog items linked to it, or
+// nil when the target isn't in the registry
+func (s *FirmwareTargetStore) Get(ctx context.Context, name string) (*models.FirmwareTargetDetail, error) {
+	target, err := scanFirmwareTarget(s.db.QueryRowContext(ctx, `
+		SELECT target, manufacturer, mcu, removed_at, first_seen_at, last_seen_at
+		FROM firmware_targets
+		WHERE target = $1
+	`, name))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, gear_type, brand, model, COALESCE(variant, '')
+		FROM gear_catalog
+		WHERE firmware_target = $1 AND status = 'published'
+		ORDER BY usage_count DESC, brand, model
+	`, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalog items for firmware target: %w", err)
+	}
+	defer rows.Close()
+
+	detail := &models.FirmwareTargetDetail{FirmwareTarget: *target, CatalogItems: make([]models.GearCatalogItem, 0)}
+	for rows.Next() {
+		var item models.GearCatalogItem
+		if err := rows.Scan(&item.ID, &item.GearType, &item.Brand, &item.Model, &item.Variant); err != nil {
+			return nil, fmt.Errorf("failed to scan catalog item for firmware target: %w", err)
+		}
+		detail.CatalogItems = append(detail.CatalogItems, item)
+	}
+	return detail, rows.Err()
+}
+
+func scanFirmwareTarget(row interface{ Scan(...interface{}) error }) (*models.FirmwareTarget, error) {
+	var target models.FirmwareTarget
+	var removedAt sql.NullTime
+	if err := row.Scan(&target.Target, &target.Manufacturer, &target.MCU, &removedAt, &target.FirstSeenAt, &target.LastSeenAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan firmware target: %w", err)
+	}
+	if removedAt.Valid {
+		target.RemovedAt = &removedAt.Time
+	}
+	return &target, nil
+}
@@ -7,37 +7,62 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 
 	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/units"
 )
 
 // GearCatalogStore handles gear catalog database operations
 type GearCatalogStore struct {
-	db *DB
+	db     *DB
+	brands *BrandStore
+	index  SearchIndex
 }
 
 var ErrCatalogItemNotFound = errors.New("catalog item not found")
 var ErrCatalogImageAlreadyCurated = errors.New("catalog image already curated")
 var ErrCatalogImageMissing = errors.New("catalog image missing")
+var ErrKeyCollisionNotFound = errors.New("key collision not found")
+
+// ErrCatalogDuplicate is returned when an edit would give an item the same
+// gear type, brand, model and variant as another item
+var ErrCatalogDuplicate = errors.New("another catalog item already exists")
 
 // NewGearCatalogStore creates a new gear catalog store
 func NewGearCatalogStore(db *DB) *GearCatalogStore {
-	return &GearCatalogStore{db: db}
+	return &GearCatalogStore{db: db, brands: NewBrandStore(db)}
 }
 
 // Create inserts a new catalog item or returns existing if canonical_key matches
 func (s *GearCatalogStore) Create(ctx context.Context, userID string, params models.CreateGearCatalogParams) (*models.GearCatalogCreateResponse, error) {
+	defer s.invalidateSearches(ctx)
+
+	// Normalize brand through the alias table so "TMotor" and "T-Motor" dedupe
+	brand, err := s.brands.Resolve(ctx, params.Brand)
+	if err != nil {
+		return nil, err
+	}
+	params.Brand = brand
+
 	// Build canonical key
-	canonicalKey := models.BuildCanonicalKey(params.GearType, params.Brand, params.Model, params.Variant)
+	canonicalKey := models.BuildCanonicalKeyV2(params.GearType, params.Brand, params.Model, params.Variant)
 
-	// First, check if an item with this canonical key already exists
+	// First, check if an item with this canonical key already exists.
+	// Rows not yet rekeyed still carry the legacy key, so check that too.
 	existing, err := s.GetByCanonicalKey(ctx, canonicalKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check for existing item: %w", err)
 	}
+	if existing == nil {
+		existing, err = s.GetByCanonicalKey(ctx, models.BuildCanonicalKey(params.GearType, params.Brand, params.Model, params.Variant))
+		if err != nil {
+			return nil, fmt.Errorf("failed to check for existing item: %w", err)
+		}
+	}
 
 	if existing != nil {
 		return &models.GearCatalogCreateResponse{
@@ -51,13 +76,14 @@ func (s *GearCatalogStore) Create(ctx context.Context, userID string, params mod
 	if specs == nil {
 		specs = json.RawMessage(`{}`)
 	}
+	specs, specOriginals := normalizeSpecUnits(specs)
 
 	query := `
 		INSERT INTO gear_catalog (
 			gear_type, brand, model, variant, specs, best_for, msrp, source,
 			created_by_user_id, status, canonical_key, description,
-			image_status, description_status
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			image_status, description_status, canonical_key_version, spec_originals
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 		RETURNING id, created_at, updated_at
 	`
 
@@ -94,7 +120,7 @@ func (s *GearCatalogStore) Create(ctx context.Context, userID string, params mod
 		item.GearType, item.Brand, item.Model, nullString(item.Variant),
 		item.Specs, pq.Array(item.BestFor), item.MSRP, item.Source, createdByUserIDPtr, item.Status,
 		item.CanonicalKey, nullString(item.Description),
-		item.ImageStatus, descriptionStatus,
+		item.ImageStatus, descriptionStatus, models.CanonicalKeyVersion, specOriginals,
 	).Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt)
 
 	if err != nil {
@@ -123,16 +149,19 @@ func (s *GearCatalogStore) Get(ctx context.Context, id string) (*models.GearCata
 		SELECT id, gear_type, brand, model, variant, specs, best_for, msrp, source,
 			   created_by_user_id, status, canonical_key,
 			   CASE WHEN image_asset_id IS NOT NULL OR image_data IS NOT NULL THEN '/api/gear-catalog/' || id || '/image?v=' || (EXTRACT(EPOCH FROM COALESCE(image_curated_at, updated_at))*1000)::bigint ELSE NULL END as image_url,
+			   COALESCE(image_asset_id::text, ''),
 			   description,
 			   created_at, updated_at,
-			   (SELECT COUNT(*) FROM inventory_items WHERE catalog_id = gear_catalog.id) as usage_count,
+			   usage_count, view_count,
 			   COALESCE(image_status, 'missing'), image_curated_by_user_id, image_curated_at,
-			   COALESCE(description_status, 'missing'), description_curated_by_user_id, description_curated_at
+			   COALESCE(description_status, 'missing'), description_curated_by_user_id, description_curated_at,
+			   spec_originals
 		FROM gear_catalog
 		WHERE id = $1
 	`
 
 	item := &models.GearCatalogItem{}
+	var specOriginals []byte
 	var variant, imageURL, description, createdByUserID sql.NullString
 	var imageCuratedByUserID, descriptionCuratedByUserID sql.NullString
 	var imageCuratedAt, descriptionCuratedAt sql.NullTime
@@ -141,10 +170,11 @@ func (s *GearCatalogStore) Get(ctx context.Context, id string) (*models.GearCata
 	err := s.db.QueryRowContext(ctx, query, id).Scan(
 		&item.ID, &item.GearType, &item.Brand, &item.Model, &variant,
 		&item.Specs, pq.Array(&item.BestFor), &msrp, &item.Source, &createdByUserID, &item.Status,
-		&item.CanonicalKey, &imageURL, &description,
-		&item.CreatedAt, &item.UpdatedAt, &item.UsageCount,
+		&item.CanonicalKey, &imageURL, &item.ImageAssetID, &description,
+		&item.CreatedAt, &item.UpdatedAt, &item.UsageCount, &item.ViewCount,
 		&item.ImageStatus, &imageCuratedByUserID, &imageCuratedAt,
 		&item.DescriptionStatus, &descriptionCuratedByUserID, &descriptionCuratedAt,
+		&specOriginals,
 	)
 
 	if err == sql.ErrNoRows {
@@ -153,6 +183,9 @@ func (s *GearCatalogStore) Get(ctx context.Context, id string) (*models.GearCata
 	if err != nil {
 		return nil, fmt.Errorf("failed to get catalog item: %w", err)
 	}
+	if err := json.Unmarshal(specOriginals, &item.SpecOriginals); err != nil {
+		return nil, fmt.Errorf("failed to decode spec originals: %w", err)
+	}
 
 	item.Variant = variant.String
 	item.ImageURL = imageURL.String
@@ -185,11 +218,14 @@ func (s *GearCatalogStore) GetByCanonicalKey(ctx context.Context, canonicalKey s
 			   CASE WHEN image_asset_id IS NOT NULL OR image_data IS NOT NULL THEN '/api/gear-catalog/' || id || '/image?v=' || (EXTRACT(EPOCH FROM COALESCE(image_curated_at, updated_at))*1000)::bigint ELSE NULL END as image_url,
 			   description,
 			   created_at, updated_at,
-			   (SELECT COUNT(*) FROM inventory_items WHERE catalog_id = gear_catalog.id) as usage_count,
+			   usage_count,
 			   COALESCE(image_status, 'missing'), image_curated_by_user_id, image_curated_at,
 			   COALESCE(description_status, 'missing'), description_curated_by_user_id, description_curated_at
 		FROM gear_catalog
 		WHERE canonical_key = $1
+		   OR id = (SELECT catalog_id FROM gear_catalog_legacy_keys WHERE legacy_key = $1)
+		ORDER BY (canonical_key = $1) DESC
+		LIMIT 1
 	`
 
 	item := &models.GearCatalogItem{}
@@ -237,8 +273,105 @@ func (s *GearCatalogStore) GetByCanonicalKey(ctx context.Context, canonicalKey s
 	return item, nil
 }
 
-// Search searches the catalog with various filters
+// invalidateSearches drops cached catalog searches. Writers defer it so it
+// runs after their transaction commits.
+func (s *GearCatalogStore) invalidateSearches(ctx context.Context) {
+	s.db.invalidateTag(ctx, catalogSearchTag)
+}
+
+// SetSearchIndex routes published catalog text searches to an external index
+func (s *GearCatalogStore) SetSearchIndex(index SearchIndex) {
+	s.index = index
+}
+
+// Search searches the catalog with various filters.
+// Text queries blend weighted full-text rank (brand > model > variant, prefix-matched)
+// with pg_trgm word similarity over a compacted name so "tmotor" finds "T-Motor".
+// When pg_trgm is unavailable the trigram signal is dropped rather than failing.
+// Results are cached under catalogSearchTag, which every catalog write drops.
 func (s *GearCatalogStore) Search(ctx context.Context, params models.GearCatalogSearchParams) (*models.GearCatalogSearchResponse, error) {
+	if params.Brand != "" {
+		brand, err := s.brands.Resolve(ctx, params.Brand)
+		if err != nil {
+			return nil, err
+		}
+		params.Brand = brand
+	}
+
+	var key string
+	if !params.Debug && params.IDs == nil {
+		key = s.db.queryCacheKey(ctx, catalogSearchTag, params)
+	}
+	var cached models.GearCatalogSearchResponse
+	if s.db.getCached(key, &cached) {
+		return &cached, nil
+	}
+
+	response, err := s.searchUncached(ctx, params)
+	if err == nil {
+		s.db.setCached(key, response, catalogSearchTag)
+	}
+	return response, err
+}
+
+func (s *GearCatalogStore) searchUncached(ctx context.Context, params models.GearCatalogSearchParams) (*models.GearCatalogSearchResponse, error) {
+	if s.index != nil && params.Query != "" && params.IDs == nil && !params.Debug &&
+		params.RCProtocol == "" && params.VideoSystem == "" && (params.Status == "" || models.NormalizeCatalogStatus(params.Status) == models.CatalogStatusPublished) {
+		if response, err := s.searchFromIndex(ctx, params); err == nil {
+			return response, nil
+		}
+	}
+
+	response, err := s.search(ctx, params, true)
+	if err != nil && params.Query != "" && isMissingTrigramError(err) {
+		return s.search(ctx, params, false)
+	}
+	return response, err
+}
+
+// searchFromIndex matches a text query in the search index and loads the
+// matching page of items in the index's relevance order
+func (s *GearCatalogStore) searchFromIndex(ctx context.Context, params models.GearCatalogSearchParams) (*models.GearCatalogSearchResponse, error) {
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.Limit > 100 {
+		params.Limit = 100
+	}
+	filters := map[string]string{}
+	if params.GearType != "" {
+		filters["gearType"] = string(params.GearType)
+	}
+	if params.Brand != "" {
+		filters["brandKey"] = strings.ToLower(params.Brand)
+	}
+
+	result, err := s.index.Search(ctx, models.SearchIndexQuery{
+		Collection: models.SearchCollectionCatalog,
+		Query:      params.Query,
+		Filters:    filters,
+		Limit:      params.Limit,
+		Offset:     params.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := params
+	page.IDs = result.IDs
+	if page.IDs == nil {
+		page.IDs = []string{}
+	}
+	page.Offset = 0
+	response, err := s.search(ctx, page, false)
+	if err != nil {
+		return nil, err
+	}
+	response.TotalCount = result.Total
+	return response, nil
+}
+
+func (s *GearCatalogStore) search(ctx context.Context, params models.GearCatalogSearchParams, useTrigram bool) (*models.GearCatalogSearchResponse, error) {
 	// Default limit
 	if params.Limit <= 0 {
 		params.Limit = 20
@@ -264,6 +397,20 @@ func (s *GearCatalogStore) Search(ctx context.Context, params models.GearCatalog
 		argIdx++
 	}
 
+	if params.RCProtocol != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM gear_catalog_ecosystems ge WHERE ge.catalog_id = gear_catalog.id AND ge.rc_protocol = $%d)", argIdx))
+		args = append(args, params.RCProtocol)
+		argIdx++
+	}
+
+	if params.VideoSystem != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM gear_catalog_ecosystems ge WHERE ge.catalog_id = gear_catalog.id AND ge.video_system = $%d)", argIdx))
+		args = append(args, params.VideoSystem)
+		argIdx++
+	}
+
 	if params.Status != "" {
 		normalizedStatus := models.NormalizeCatalogStatus(params.Status)
 		if !models.IsValidCatalogStatus(normalizedStatus) {
@@ -276,28 +423,41 @@ func (s *GearCatalogStore) Search(ctx context.Context, params models.GearCatalog
 	}
 
 	// Text search
-	var orderBy string
-	if params.Query != "" {
-		// Use both full-text search and ILIKE for flexibility
-		searchClause := fmt.Sprintf(`(
-			to_tsvector('english', brand || ' ' || model || ' ' || COALESCE(variant, '')) @@ plainto_tsquery('english', $%d)
-			OR LOWER(brand || ' ' || model || ' ' || COALESCE(variant, '')) LIKE LOWER($%d)
-		)`, argIdx, argIdx+1)
-		whereClauses = append(whereClauses, searchClause)
-		args = append(args, params.Query, "%"+params.Query+"%")
-		argIdx += 2
-
-		// Order by relevance when searching
-		orderBy = fmt.Sprintf(`
-			ts_rank(to_tsvector('english', brand || ' ' || model || ' ' || COALESCE(variant, '')), plainto_tsquery('english', $%d)) DESC,
-			(SELECT COUNT(*) FROM inventory_items WHERE catalog_id = gear_catalog.id) DESC,
-			brand, model
-		`, argIdx)
-		args = append(args, params.Query)
+	tsQuery := models.BuildCatalogSearchTSQuery(params.Query)
+	compactQuery := models.CompactSearchTerm(params.Query)
+	textRankExpr := "0::float8"
+	trigramExpr := "0::float8"
+	orderBy := "usage_count DESC, view_count DESC, brand, model"
+	if params.IDs != nil {
+		// Already matched by the search index
+		whereClauses = append(whereClauses, fmt.Sprintf("id::text = ANY($%d)", argIdx))
+		orderBy = fmt.Sprintf("array_position($%d::text[], id::text)", argIdx)
+		args = append(args, pq.Array(params.IDs))
 		argIdx++
-	} else {
-		// Default ordering: most used first, then alphabetical
-		orderBy = "(SELECT COUNT(*) FROM inventory_items WHERE catalog_id = gear_catalog.id) DESC, brand, model"
+		tsQuery, compactQuery = "", ""
+	} else if params.Query != "" && (tsQuery != "" || compactQuery != "") {
+		matchClauses := make([]string, 0, 3)
+		if tsQuery != "" {
+			matchClauses = append(matchClauses, fmt.Sprintf("search_vector @@ to_tsquery('english', $%d)", argIdx))
+			textRankExpr = fmt.Sprintf("ts_rank(search_vector, to_tsquery('english', $%d))::float8", argIdx)
+			args = append(args, tsQuery)
+			argIdx++
+		}
+		if compactQuery != "" {
+			matchClauses = append(matchClauses, fmt.Sprintf("search_compact LIKE $%d", argIdx))
+			args = append(args, "%"+compactQuery+"%")
+			argIdx++
+			if useTrigram {
+				matchClauses = append(matchClauses, fmt.Sprintf("word_similarity($%d, search_compact) >= %g", argIdx, catalogTrigramThreshold))
+				trigramExpr = fmt.Sprintf("word_similarity($%d, search_compact)::float8", argIdx)
+				args = append(args, compactQuery)
+				argIdx++
+			}
+		}
+		whereClauses = append(whereClauses, "("+strings.Join(matchClauses, " OR ")+")")
+
+		// Blend text rank with trigram similarity; popularity only breaks ties.
+		orderBy = "relevance DESC, usage_count DESC, view_count DESC, brand, model"
 	}
 
 	whereClause := strings.Join(whereClauses, " AND ")
@@ -305,30 +465,30 @@ func (s *GearCatalogStore) Search(ctx context.Context, params models.GearCatalog
 	// Count query
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM gear_catalog WHERE %s", whereClause)
 	var totalCount int
-	// Use only the non-orderBy args for count
-	countArgs := args[:]
-	if params.Query != "" {
-		countArgs = args[:len(args)-1] // Exclude the last orderBy arg
-	}
-	if err := s.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&totalCount); err != nil {
+	if err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&totalCount); err != nil {
 		return nil, fmt.Errorf("failed to count catalog items: %w", err)
 	}
 
 	// Main query
 	query := fmt.Sprintf(`
-		SELECT id, gear_type, brand, model, variant, specs, best_for, msrp, source,
-			   created_by_user_id, status, canonical_key,
-			   CASE WHEN image_asset_id IS NOT NULL OR image_data IS NOT NULL THEN '/api/gear-catalog/' || id || '/image?v=' || (EXTRACT(EPOCH FROM COALESCE(image_curated_at, updated_at))*1000)::bigint ELSE NULL END as image_url,
-			   description,
-			   created_at, updated_at,
-			   (SELECT COUNT(*) FROM inventory_items WHERE catalog_id = gear_catalog.id) as usage_count,
-			   COALESCE(image_status, 'missing'), image_curated_by_user_id, image_curated_at,
-			   COALESCE(description_status, 'missing'), description_curated_by_user_id, description_curated_at
-		FROM gear_catalog
-		WHERE %s
+		SELECT * FROM (
+			SELECT id, gear_type, brand, model, variant, specs, best_for, msrp, source,
+				   created_by_user_id, status, canonical_key,
+				   CASE WHEN image_asset_id IS NOT NULL OR image_data IS NOT NULL THEN '/api/gear-catalog/' || id || '/image?v=' || (EXTRACT(EPOCH FROM COALESCE(image_curated_at, updated_at))*1000)::bigint ELSE NULL END as image_url,
+				   description,
+				   created_at, updated_at,
+				   usage_count, view_count,
+				   COALESCE(image_status, 'missing'), image_curated_by_user_id, image_curated_at,
+				   COALESCE(description_status, 'missing'), description_curated_by_user_id, description_curated_at,
+				   %s AS text_rank,
+				   %s AS trigram_score,
+				   %s * %g + %s * %g AS relevance
+			FROM gear_catalog
+			WHERE %s
+		) ranked
 		ORDER BY %s
 		LIMIT $%d OFFSET $%d
-	`, whereClause, orderBy, argIdx, argIdx+1)
+	`, textRankExpr, trigramExpr, textRankExpr, catalogTextRankWeight, trigramExpr, catalogTrigramWeight, whereClause, orderBy, argIdx, argIdx+1)
 
 	args = append(args, params.Limit, params.Offset)
 
@@ -339,20 +499,23 @@ func (s *GearCatalogStore) Search(ctx context.Context, params models.GearCatalog
 	defer rows.Close()
 
 	items := make([]models.GearCatalogItem, 0)
+	scores := make([]models.GearCatalogRelevanceInfo, 0)
 	for rows.Next() {
 		var item models.GearCatalogItem
 		var variant, imageURL, description, createdByUserID sql.NullString
 		var imageCuratedByUserID, descriptionCuratedByUserID sql.NullString
 		var imageCuratedAt, descriptionCuratedAt sql.NullTime
 		var msrp sql.NullFloat64
+		var score models.GearCatalogRelevanceInfo
 
 		if err := rows.Scan(
 			&item.ID, &item.GearType, &item.Brand, &item.Model, &variant,
 			&item.Specs, pq.Array(&item.BestFor), &msrp, &item.Source, &createdByUserID, &item.Status,
 			&item.CanonicalKey, &imageURL, &description,
-			&item.CreatedAt, &item.UpdatedAt, &item.UsageCount,
+			&item.CreatedAt, &item.UpdatedAt, &item.UsageCount, &item.ViewCount,
 			&item.ImageStatus, &imageCuratedByUserID, &imageCuratedAt,
 			&item.DescriptionStatus, &descriptionCuratedByUserID, &descriptionCuratedAt,
+			&score.TextRank, &score.TrigramScore, &score.Score,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan catalog item: %w", err)
 		}
@@ -377,14 +540,43 @@ func (s *GearCatalogStore) Search(ctx context.Context, params models.GearCatalog
 			item.DescriptionCuratedAt = &descriptionCuratedAt.Time
 		}
 
+		score.ID = item.ID
+		scores = append(scores, score)
 		items = append(items, item)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search catalog: %w", err)
+	}
 
-	return &models.GearCatalogSearchResponse{
+	response := &models.GearCatalogSearchResponse{
 		Items:      items,
 		TotalCount: totalCount,
 		Query:      params.Query,
-	}, nil
+	}
+	if params.Debug {
+		response.Debug = &models.GearCatalogSearchDebug{
+			TSQuery:      tsQuery,
+			CompactQuery: compactQuery,
+			Trigram:      useTrigram,
+			Scores:       scores,
+		}
+	}
+	return response, nil
+}
+
+// Relevance blending weights for catalog search. Full-text rank is the primary signal;
+// trigram similarity rescues typos and punctuation variants that tsquery misses.
+const (
+	catalogTextRankWeight   = 1.0
+	catalogTrigramWeight    = 0.5
+	catalogTrigramThreshold = 0.4
+)
+
+// isMissingTrigramError reports whether err came from pg_trgm functions being unavailable.
+func isMissingTrigramError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "function similarity") || strings.Contains(msg, "function word_similarity") ||
+		(strings.Contains(msg, "does not exist") && strings.Contains(msg, "similarity"))
 }
 
 // FindNearMatches finds potential duplicate items using similarity search
@@ -400,7 +592,7 @@ func (s *GearCatalogStore) FindNearMatches(ctx context.Context, gearType models.
 			   CASE WHEN image_asset_id IS NOT NULL OR image_data IS NOT NULL THEN '/api/gear-catalog/' || id || '/image?v=' || (EXTRACT(EPOCH FROM updated_at)*1000)::bigint ELSE NULL END as image_url,
 			   description,
 			   created_at, updated_at,
-			   (SELECT COUNT(*) FROM inventory_items WHERE catalog_id = gear_catalog.id) as usage_count,
+			   usage_count,
 			   COALESCE(similarity(LOWER(brand || ' ' || model), LOWER($2 || ' ' || $3)), 0) as sim_score
 		FROM gear_catalog
 		WHERE gear_type = $1
@@ -461,7 +653,7 @@ func (s *GearCatalogStore) findNearMatchesFallback(ctx context.Context, gearType
 			   CASE WHEN image_asset_id IS NOT NULL OR image_data IS NOT NULL THEN '/api/gear-catalog/' || id || '/image?v=' || (EXTRACT(EPOCH FROM COALESCE(image_curated_at, updated_at))*1000)::bigint ELSE NULL END as image_url,
 			   description,
 			   created_at, updated_at,
-			   (SELECT COUNT(*) FROM inventory_items WHERE catalog_id = gear_catalog.id) as usage_count
+			   usage_count
 		FROM gear_catalog
 		WHERE gear_type = $1
 		  AND status = 'published'
@@ -534,7 +726,7 @@ func (s *GearCatalogStore) FindNearMatchesAdmin(ctx context.Context, gearType mo
 			   CASE WHEN image_asset_id IS NOT NULL OR image_data IS NOT NULL THEN '/api/gear-catalog/' || id || '/image?v=' || (EXTRACT(EPOCH FROM COALESCE(image_curated_at, updated_at))*1000)::bigint ELSE NULL END as image_url,
 			   description,
 			   created_at, updated_at,
-			   (SELECT COUNT(*) FROM inventory_items WHERE catalog_id = gear_catalog.id) as usage_count,
+			   usage_count,
 			   COALESCE(image_status, 'missing'), image_curated_by_user_id, image_curated_at,
 			   COALESCE(description_status, 'missing'), description_curated_by_user_id, description_curated_at,
 			   COALESCE(similarity(LOWER(brand || ' ' || model), LOWER($2 || ' ' || $3)), 0) as sim_score
@@ -616,7 +808,7 @@ func (s *GearCatalogStore) findNearMatchesAdminFallback(ctx context.Context, gea
 			   CASE WHEN image_asset_id IS NOT NULL OR image_data IS NOT NULL THEN '/api/gear-catalog/' || id || '/image?v=' || (EXTRACT(EPOCH FROM COALESCE(image_curated_at, updated_at))*1000)::bigint ELSE NULL END as image_url,
 			   description,
 			   created_at, updated_at,
-			   (SELECT COUNT(*) FROM inventory_items WHERE catalog_id = gear_catalog.id) as usage_count,
+			   usage_count,
 			   COALESCE(image_status, 'missing'), image_curated_by_user_id, image_curated_at,
 			   COALESCE(description_status, 'missing'), description_curated_by_user_id, description_curated_at
 		FROM gear_catalog
@@ -697,6 +889,8 @@ func (s *GearCatalogStore) findNearMatchesAdminFallback(ctx context.Context, gea
 
 // UpdateStatus updates the status of a catalog item (for moderation)
 func (s *GearCatalogStore) UpdateStatus(ctx context.Context, id string, status models.CatalogItemStatus) error {
+	defer s.invalidateSearches(ctx)
+
 	query := `UPDATE gear_catalog SET status = $1, updated_at = NOW() WHERE id = $2`
 
 	result, err := s.db.ExecContext(ctx, query, status, id)
@@ -724,7 +918,7 @@ func (s *GearCatalogStore) GetPopular(ctx context.Context, gearType models.GearT
 			   CASE WHEN image_asset_id IS NOT NULL OR image_data IS NOT NULL THEN '/api/gear-catalog/' || id || '/image?v=' || (EXTRACT(EPOCH FROM COALESCE(image_curated_at, updated_at))*1000)::bigint ELSE NULL END as image_url,
 			   description,
 			   created_at, updated_at,
-			   (SELECT COUNT(*) FROM inventory_items WHERE catalog_id = gear_catalog.id) as usage_count,
+			   usage_count,
 			   COALESCE(image_status, 'missing'), image_curated_by_user_id, image_curated_at,
 			   COALESCE(description_status, 'missing'), description_curated_by_user_id, description_curated_at
 		FROM gear_catalog
@@ -794,6 +988,8 @@ func (s *GearCatalogStore) GetPopular(ctx context.Context, gearType models.GearT
 // and links the inventory item to it. Uses a transaction to ensure consistency.
 // Note: Does NOT copy image data from inventory - catalog images require admin curation.
 func (s *GearCatalogStore) MigrateInventoryItem(ctx context.Context, inventoryItemID, userID, name, manufacturer string, category models.EquipmentCategory, specs json.RawMessage) (*models.GearCatalogItem, error) {
+	defer s.invalidateSearches(ctx)
+
 	// Start a transaction to ensure atomic create+link
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -801,8 +997,9 @@ func (s *GearCatalogStore) MigrateInventoryItem(ctx context.Context, inventoryIt
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	// Convert category to gear type
-	gearType := models.GearTypeFromEquipmentCategory(category)
+	// Convert category to gear type; the name tells apart the gear types
+	// that share a category
+	gearType := models.GearTypeForEquipment(category, name)
 
 	// Extract brand, model, variant from the item name
 	brand, model, variant := models.ExtractBrandModelFromName(name, manufacturer)
@@ -812,23 +1009,33 @@ func (s *GearCatalogStore) MigrateInventoryItem(ctx context.Context, inventoryIt
 	if model == "" {
 		model = name
 	}
+	if brand, err = s.brands.Resolve(ctx, brand); err != nil {
+		return nil, err
+	}
 
 	// Generate canonical key
-	canonicalKey := models.BuildCanonicalKey(gearType, brand, model, variant)
-
-	// First check if this catalog item already exists
-	checkQuery := `SELECT id FROM gear_catalog WHERE canonical_key = $1`
+	canonicalKey := models.BuildCanonicalKeyV2(gearType, brand, model, variant)
+	legacyKey := models.BuildCanonicalKey(gearType, brand, model, variant)
+
+	// First check if this catalog item already exists under either key format
+	checkQuery := `
+		SELECT id FROM gear_catalog WHERE canonical_key = ANY($1)
+		UNION ALL
+		SELECT catalog_id FROM gear_catalog_legacy_keys WHERE legacy_key = ANY($1)
+		LIMIT 1
+	`
 	var catalogID string
-	err = tx.QueryRowContext(ctx, checkQuery, canonicalKey).Scan(&catalogID)
+	err = tx.QueryRowContext(ctx, checkQuery, pq.Array([]string{canonicalKey, legacyKey})).Scan(&catalogID)
 
 	if err == sql.ErrNoRows {
 		// Create new catalog entry - image_status='missing' enforces admin curation
 		catalogID = uuid.New().String()
+		specs, specOriginals := normalizeSpecUnits(specs)
 		insertQuery := `
-			INSERT INTO gear_catalog (id, gear_type, brand, model, variant, specs, source, created_by_user_id, status, canonical_key, image_status, description_status, created_at, updated_at)
-			VALUES ($1, $2, $3, $4, $5, $6, 'user', $7, 'pending', $8, 'missing', 'missing', NOW(), NOW())
+			INSERT INTO gear_catalog (id, gear_type, brand, model, variant, specs, spec_originals, source, created_by_user_id, status, canonical_key, canonical_key_version, image_status, description_status, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, 'user', $8, 'pending', $9, $10, 'missing', 'missing', NOW(), NOW())
 		`
-		_, err = tx.ExecContext(ctx, insertQuery, catalogID, gearType, brand, model, variant, specs, userID, canonicalKey)
+		_, err = tx.ExecContext(ctx, insertQuery, catalogID, gearType, brand, model, variant, specs, specOriginals, userID, canonicalKey, models.CanonicalKeyVersion)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create catalog entry: %w", err)
 		}
@@ -941,9 +1148,10 @@ func (s *GearCatalogStore) AdminSearch(ctx context.Context, params models.AdminG
 			   CASE WHEN image_asset_id IS NOT NULL OR image_data IS NOT NULL THEN '/api/gear-catalog/' || id || '/image?v=' || (EXTRACT(EPOCH FROM COALESCE(image_curated_at, updated_at))*1000)::bigint ELSE NULL END as image_url,
 			   description,
 			   created_at, updated_at,
-			   (SELECT COUNT(*) FROM inventory_items WHERE catalog_id = gear_catalog.id) as usage_count,
+			   usage_count,
 			   COALESCE(image_status, 'missing'), image_curated_by_user_id, image_curated_at,
-			   COALESCE(description_status, 'missing'), description_curated_by_user_id, description_curated_at
+			   COALESCE(description_status, 'missing'), description_curated_by_user_id, description_curated_at,
+			   assigned_to::text, assigned_at, queued_at
 		FROM gear_catalog
 		WHERE %s
 		ORDER BY %s
@@ -964,6 +1172,8 @@ func (s *GearCatalogStore) AdminSearch(ctx context.Context, params models.AdminG
 		var variant, imageURL, description, createdByUserID sql.NullString
 		var imageCuratedByUserID, descriptionCuratedByUserID sql.NullString
 		var imageCuratedAt, descriptionCuratedAt sql.NullTime
+		var assignedTo sql.NullString
+		var assignedAt, queuedAt sql.NullTime
 		var msrp sql.NullFloat64
 
 		if err := rows.Scan(
@@ -973,6 +1183,7 @@ func (s *GearCatalogStore) AdminSearch(ctx context.Context, params models.AdminG
 			&item.CreatedAt, &item.UpdatedAt, &item.UsageCount,
 			&item.ImageStatus, &imageCuratedByUserID, &imageCuratedAt,
 			&item.DescriptionStatus, &descriptionCuratedByUserID, &descriptionCuratedAt,
+			&assignedTo, &assignedAt, &queuedAt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan admin catalog item: %w", err)
 		}
@@ -996,6 +1207,11 @@ func (s *GearCatalogStore) AdminSearch(ctx context.Context, params models.AdminG
 		if descriptionCuratedAt.Valid {
 			item.DescriptionCuratedAt = &descriptionCuratedAt.Time
 		}
+		item.Assignment = assignmentFromColumns(assignedTo, assignedAt)
+		if queuedAt.Valid {
+			item.QueuedAt = &queuedAt.Time
+			item.QueueAgeSeconds = models.QueueAge(item.QueuedAt, time.Now())
+		}
 
 		items = append(items, item)
 	}
@@ -1009,6 +1225,8 @@ func (s *GearCatalogStore) AdminSearch(ctx context.Context, params models.AdminG
 
 // AdminUpdate updates a gear catalog item with admin-provided values
 func (s *GearCatalogStore) AdminUpdate(ctx context.Context, id string, adminUserID string, params models.AdminUpdateGearCatalogParams) (*models.GearCatalogItem, error) {
+	defer s.invalidateSearches(ctx)
+
 	// If gearType/brand/model/variant is changing, we need to recompute canonical_key
 	needsCanonicalKeyUpdate := params.GearType != nil || params.Brand != nil || params.Model != nil || params.Variant != nil
 
@@ -1047,10 +1265,14 @@ func (s *GearCatalogStore) AdminUpdate(ctx context.Context, id string, adminUser
 	}
 
 	if params.Brand != nil {
+		brand, err := s.brands.Resolve(ctx, *params.Brand)
+		if err != nil {
+			return nil, err
+		}
 		sets = append(sets, fmt.Sprintf("brand = $%d", argIdx))
-		args = append(args, *params.Brand)
+		args = append(args, brand)
 		argIdx++
-		effectiveBrand = *params.Brand
+		effectiveBrand = brand
 	}
 	if params.Model != nil {
 		sets = append(sets, fmt.Sprintf("model = $%d", argIdx))
@@ -1066,26 +1288,34 @@ func (s *GearCatalogStore) AdminUpdate(ctx context.Context, id string, adminUser
 	}
 
 	if params.Specs != nil {
-		sets = append(sets, fmt.Sprintf("specs = $%d", argIdx))
-		args = append(args, params.Specs)
-		argIdx++
+		// Originals of values that didn't change are kept
+		specs, specOriginals := normalizeSpecUnits(params.Specs)
+		sets = append(sets, fmt.Sprintf(`specs = $%[1]d, spec_originals = COALESCE((
+			SELECT jsonb_object_agg(key, value) FROM jsonb_each(spec_originals)
+			WHERE specs -> key = $%[1]d::jsonb -> key
+		), '{}') || $%[2]d::jsonb`, argIdx, argIdx+1))
+		args = append(args, specs, specOriginals)
+		argIdx += 2
 	}
 
 	// Recompute canonical_key if brand/model/variant changed
 	if needsCanonicalKeyUpdate {
-		newCanonicalKey := models.BuildCanonicalKey(effectiveGearType, effectiveBrand, effectiveModel, effectiveVariant)
+		newCanonicalKey := models.BuildCanonicalKeyV2(effectiveGearType, effectiveBrand, effectiveModel, effectiveVariant)
 		// Check if new canonical_key would conflict with another item
 		if newCanonicalKey != currentItem.CanonicalKey {
 			existing, err := s.GetByCanonicalKey(ctx, newCanonicalKey)
 			if err != nil {
 				return nil, fmt.Errorf("failed to check for canonical key conflict: %w", err)
 			}
-			if existing != nil {
-				return nil, fmt.Errorf("cannot update: another item already exists with gearType=%q brand=%q model=%q variant=%q", effectiveGearType, effectiveBrand, effectiveModel, effectiveVariant)
+			if existing != nil && existing.ID != id {
+				return nil, fmt.Errorf("%w with gearType=%q brand=%q model=%q variant=%q", ErrCatalogDuplicate, effectiveGearType, effectiveBrand, effectiveModel, effectiveVariant)
 			}
 			sets = append(sets, fmt.Sprintf("canonical_key = $%d", argIdx))
 			args = append(args, newCanonicalKey)
 			argIdx++
+			sets = append(sets, fmt.Sprintf("canonical_key_version = $%d", argIdx))
+			args = append(args, models.CanonicalKeyVersion)
+			argIdx++
 		}
 	}
 
@@ -1203,9 +1433,29 @@ func (s *GearCatalogStore) AdminUpdate(ctx context.Context, id string, adminUser
 		WHERE id = $%d
 	`, strings.Join(sets, ", "), argIdx)
 
-	_, err = s.db.ExecContext(ctx, query, args...)
+	publishing := params.Status != nil && *params.Status == models.CatalogStatusPublished
+	err = s.db.RunInTx(ctx, func(ctx context.Context) error {
+		// Lock the row first so catalog.published fires only on the transition
+		var previousStatus sql.NullString
+		if publishing {
+			err := s.db.QueryRowContext(ctx, `SELECT status FROM gear_catalog WHERE id = $1 FOR UPDATE`, id).Scan(&previousStatus)
+			if err != nil && err != sql.ErrNoRows {
+				return fmt.Errorf("failed to admin update catalog item: %w", err)
+			}
+		}
+
+		if _, err := s.db.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to admin update catalog item: %w", err)
+		}
+
+		if !publishing || !previousStatus.Valid || previousStatus.String == string(models.CatalogStatusPublished) {
+			return nil
+		}
+		payload := models.CatalogPublishedPayload{CatalogItemID: id, Via: "admin"}
+		return enqueueDomainEvent(ctx, s.db, models.DomainEventCatalogPublished, id, payload)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to admin update catalog item: %w", err)
+		return nil, err
 	}
 
 	return s.Get(ctx, id)
@@ -1214,6 +1464,8 @@ func (s *GearCatalogStore) AdminUpdate(ctx context.Context, id string, adminUser
 // SetImage stores an approved image asset reference for a gear catalog item (admin only).
 // Returns any previous image asset ID for cleanup.
 func (s *GearCatalogStore) SetImage(ctx context.Context, id string, adminUserID string, imageType string, imageAssetID string) (string, error) {
+	defer s.invalidateSearches(ctx)
+
 	var previousAssetID sql.NullString
 	if err := s.db.QueryRowContext(ctx, `SELECT image_asset_id FROM gear_catalog WHERE id = $1`, id).Scan(&previousAssetID); err != nil {
 		if err == sql.ErrNoRows {
@@ -1253,6 +1505,8 @@ func (s *GearCatalogStore) SetImage(ctx context.Context, id string, adminUserID
 // This marks image_status as "scanned" so it remains in the admin moderation queue.
 // Returns previous image asset ID for cleanup.
 func (s *GearCatalogStore) SetUserSubmittedImage(ctx context.Context, id string, imageType string, imageAssetID string) (string, error) {
+	defer s.invalidateSearches(ctx)
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to begin transaction for user-submitted gear image: %w", err)
@@ -1314,6 +1568,8 @@ func (s *GearCatalogStore) SetUserSubmittedImage(ctx context.Context, id string,
 
 // ApproveImage marks an existing catalog image as approved by an admin.
 func (s *GearCatalogStore) ApproveImage(ctx context.Context, id string, adminUserID string) error {
+	defer s.invalidateSearches(ctx)
+
 	var (
 		imageStatus models.ImageStatus
 		hasImage    bool
@@ -1365,14 +1621,14 @@ func (s *GearCatalogStore) ApproveImage(ctx context.Context, id string, adminUse
 // GetImage retrieves the binary image data for a gear catalog item
 func (s *GearCatalogStore) GetImage(ctx context.Context, id string) ([]byte, string, error) {
 	query := `
-		SELECT COALESCE(ia.image_bytes, gc.image_data), gc.image_type
+		SELECT COALESCE(ia.image_bytes, gc.image_data), gc.image_type, ia.id, ia.storage_tier
 		FROM gear_catalog gc
 		LEFT JOIN image_assets ia ON ia.id = gc.image_asset_id AND ia.status = 'APPROVED'
 		WHERE gc.id = $1 AND ((gc.image_asset_id IS NOT NULL AND ia.id IS NOT NULL) OR gc.image_data IS NOT NULL)
 	`
 	var imageData []byte
-	var imageType sql.NullString
-	err := s.db.QueryRowContext(ctx, query, id).Scan(&imageData, &imageType)
+	var imageType, imageID, tier sql.NullString
+	err := s.db.QueryRowContext(ctx, query, id).Scan(&imageData, &imageType, &imageID, &tier)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, "", nil
@@ -1380,6 +1636,10 @@ func (s *GearCatalogStore) GetImage(ctx context.Context, id string) ([]byte, str
 		return nil, "", fmt.Errorf("failed to get gear image: %w", err)
 	}
 
+	imageData, err = s.db.imageBytes(ctx, imageID, tier, imageData)
+	if err != nil {
+		return nil, "", err
+	}
 	return imageData, imageType.String, nil
 }
 
@@ -1412,6 +1672,8 @@ func (s *GearCatalogStore) HasImage(ctx context.Context, id string) (bool, error
 // DeleteImage removes the image from a gear catalog item (admin only).
 // Returns previous image asset ID for cleanup.
 func (s *GearCatalogStore) DeleteImage(ctx context.Context, id string) (string, error) {
+	defer s.invalidateSearches(ctx)
+
 	var previousAssetID sql.NullString
 	if err := s.db.QueryRowContext(ctx, `SELECT image_asset_id FROM gear_catalog WHERE id = $1`, id).Scan(&previousAssetID); err != nil {
 		if err == sql.ErrNoRows {
@@ -1450,6 +1712,8 @@ func (s *GearCatalogStore) DeleteImage(ctx context.Context, id string) (string,
 // AdminDelete permanently deletes a gear catalog item (admin only).
 // Related inventory_items.catalog_id references are nulled via FK ON DELETE SET NULL.
 func (s *GearCatalogStore) AdminDelete(ctx context.Context, id string) error {
+	defer s.invalidateSearches(ctx)
+
 	query := `DELETE FROM gear_catalog WHERE id = $1`
 
 	result, err := s.db.ExecContext(ctx, query, id)
@@ -1466,6 +1730,8 @@ func (s *GearCatalogStore) AdminDelete(ctx context.Context, id string) error {
 }
 
 func (s *GearCatalogStore) AdminBulkDelete(ctx context.Context, ids []string) ([]string, error) {
+	defer s.invalidateSearches(ctx)
+
 	if len(ids) == 0 {
 		return nil, nil
 	}
@@ -1492,3 +1758,448 @@ func (s *GearCatalogStore) AdminBulkDelete(ctx context.Context, ids []string) ([
 
 	return deletedIDs, nil
 }
+
+// canonicalKeyRow is the slice of a catalog row needed to recompute its key
+type canonicalKeyRow struct {
+	ID           string
+	GearType     models.GearType
+	Brand        string
+	Model        string
+	Variant      string
+	CanonicalKey string
+	NewKey       string
+}
+
+// planCanonicalKeys computes v2 keys for rows given in priority order (the first
+// row to claim a key keeps it). Rows that lose a key keep their current key and
+// are reported as collisions; winners whose key is still held by a losing row are
+// demoted too, so applying the plan never violates the unique index.
+func planCanonicalKeys(rows []canonicalKeyRow, resolveBrand func(string) string) ([]canonicalKeyRow, []models.CanonicalKeyCollision) {
+	planned := make([]canonicalKeyRow, len(rows))
+	owner := make(map[string]int, len(rows))
+	for i, row := range rows {
+		row.NewKey = models.BuildCanonicalKeyV2(row.GearType, resolveBrand(row.Brand), row.Model, row.Variant)
+		planned[i] = row
+		if _, taken := owner[row.NewKey]; !taken {
+			owner[row.NewKey] = i
+		}
+	}
+
+	lost := make(map[int]int) // loser index -> index of the row it conflicts with
+	for i, row := range planned {
+		if owner[row.NewKey] != i {
+			lost[i] = owner[row.NewKey]
+		}
+	}
+
+	// A loser keeps its current key; if a winner planned to take that key, the
+	// winner has to stay put as well. Repeat until no retained key is contested.
+	for changed := true; changed; {
+		changed = false
+		retained := make(map[string]int, len(lost))
+		for i := range lost {
+			retained[planned[i].CanonicalKey] = i
+		}
+		for i, row := range planned {
+			if _, isLoser := lost[i]; isLoser {
+				continue
+			}
+			if holder, ok := retained[row.NewKey]; ok && holder != i {
+				lost[i] = holder
+				changed = true
+			}
+		}
+	}
+
+	updates := make([]canonicalKeyRow, 0, len(planned))
+	collisions := make([]models.CanonicalKeyCollision, 0, len(lost))
+	for i, row := range planned {
+		if conflictsWith, isLoser := lost[i]; isLoser {
+			collisions = append(collisions, models.CanonicalKeyCollision{
+				CatalogID:       row.ID,
+				ConflictsWithID: planned[conflictsWith].ID,
+				ProposedKey:     row.NewKey,
+				Brand:           row.Brand,
+				Model:           row.Model,
+				Variant:         row.Variant,
+			})
+			continue
+		}
+		if row.NewKey != row.CanonicalKey {
+			updates = append(updates, row)
+		}
+	}
+	return updates, collisions
+}
+
+// RecomputeCanonicalKeys rewrites every catalog row to the current canonical key
+// format. Replaced keys are kept in gear_catalog_legacy_keys; rows whose new key is
+// already taken are left alone and recorded in gear_catalog_key_collisions for an
+// admin to resolve. With dryRun the plan is reported but nothing is written.
+func (s *GearCatalogStore) RecomputeCanonicalKeys(ctx context.Context, dryRun bool) (*models.CanonicalKeyMigrationReport, error) {
+	defer s.invalidateSearches(ctx)
+
+	resolveBrand, err := s.brands.resolverSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Rows already on the current format claim their key first, then the most used.
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, gear_type, brand, model, COALESCE(variant, ''), canonical_key
+		FROM gear_catalog
+		ORDER BY (canonical_key_version = $1) DESC, usage_count DESC, created_at, id
+		FOR UPDATE
+	`, models.CanonicalKeyVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load catalog keys: %w", err)
+	}
+	var current []canonicalKeyRow
+	for rows.Next() {
+		var row canonicalKeyRow
+		if err := rows.Scan(&row.ID, &row.GearType, &row.Brand, &row.Model, &row.Variant, &row.CanonicalKey); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan catalog key: %w", err)
+		}
+		current = append(current, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load catalog keys: %w", err)
+	}
+
+	updates, collisions := planCanonicalKeys(current, resolveBrand)
+	report := &models.CanonicalKeyMigrationReport{
+		DryRun:     dryRun,
+		Total:      len(current),
+		Updated:    len(updates),
+		Unchanged:  len(current) - len(updates) - len(collisions),
+		Collisions: collisions,
+	}
+	if dryRun {
+		return report, nil
+	}
+
+	ids := make([]string, len(updates))
+	keys := make([]string, len(updates))
+	for i, row := range updates {
+		ids[i] = row.ID
+		keys[i] = row.NewKey
+		if err := recordLegacyCanonicalKey(ctx, tx, row.CanonicalKey, row.NewKey, row.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Park changing rows on a unique placeholder first so swapping keys between
+	// rows cannot trip the unique index mid-statement.
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE gear_catalog SET canonical_key = 'rekey|' || id::text WHERE id = ANY($1::uuid[])`,
+		pq.Array(ids),
+	); err != nil {
+		return nil, fmt.Errorf("failed to park canonical keys: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE gear_catalog g
+		SET canonical_key = k.key, canonical_key_version = $3, updated_at = NOW()
+		FROM unnest($1::uuid[], $2::text[]) AS k(id, key)
+		WHERE g.id = k.id
+	`, pq.Array(ids), pq.Array(keys), models.CanonicalKeyVersion); err != nil {
+		return nil, fmt.Errorf("failed to update canonical keys: %w", err)
+	}
+	// Rows whose key did not change are still on the current format now
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE gear_catalog SET canonical_key_version = $1
+		WHERE canonical_key_version <> $1 AND canonical_key LIKE 'v2|%'
+	`, models.CanonicalKeyVersion); err != nil {
+		return nil, fmt.Errorf("failed to update canonical key versions: %w", err)
+	}
+
+	// Replace the open collision list with this run's findings
+	if _, err := tx.ExecContext(ctx, `DELETE FROM gear_catalog_key_collisions WHERE resolved_at IS NULL`); err != nil {
+		return nil, fmt.Errorf("failed to clear key collisions: %w", err)
+	}
+	for i := range report.Collisions {
+		collision := &report.Collisions[i]
+		if err := tx.QueryRowContext(ctx, `
+			INSERT INTO gear_catalog_key_collisions (catalog_id, conflicts_with_id, proposed_key)
+			VALUES ($1, $2, $3)
+			RETURNING id, created_at
+		`, collision.CatalogID, collision.ConflictsWithID, collision.ProposedKey).Scan(&collision.ID, &collision.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to record key collision: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return report, nil
+}
+
+// normalizeSpecUnits converts spec values to canonical units, returning the
+// specs to store and the JSON of the values' original text
+func normalizeSpecUnits(specs json.RawMessage) (json.RawMessage, []byte) {
+	normalized, originals := units.NormalizeSpecs(specs)
+	if originals == nil {
+		return normalized, []byte(`{}`)
+	}
+	encoded, err := json.Marshal(originals)
+	if err != nil {
+		return specs, []byte(`{}`)
+	}
+	return normalized, encoded
+}
+
+// NormalizeSpecUnits converts the spec values of catalog items saved before
+// units were normalized on write. With dryRun the items that would change are
+// counted but nothing is written.
+func (s *GearCatalogStore) NormalizeSpecUnits(ctx context.Context, dryRun bool) (*models.SpecUnitNormalizationReport, error) {
+	defer s.invalidateSearches(ctx)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, `SELECT id, specs FROM gear_catalog ORDER BY id FOR UPDATE`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load catalog specs: %w", err)
+	}
+	type specUpdate struct {
+		id        string
+		specs     json.RawMessage
+		originals []byte
+	}
+	var updates []specUpdate
+	report := &models.SpecUnitNormalizationReport{DryRun: dryRun}
+	for rows.Next() {
+		var id string
+		var specs json.RawMessage
+		if err := rows.Scan(&id, &specs); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan catalog specs: %w", err)
+		}
+		report.Total++
+		normalized, originals := normalizeSpecUnits(specs)
+		if string(originals) != "{}" {
+			updates = append(updates, specUpdate{id: id, specs: normalized, originals: originals})
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load catalog specs: %w", err)
+	}
+
+	report.Updated = len(updates)
+	if dryRun {
+		return report, nil
+	}
+
+	for _, update := range updates {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE gear_catalog
+			SET specs = $2, spec_originals = spec_originals || $3::jsonb, updated_at = NOW()
+			WHERE id = $1
+		`, update.id, update.specs, update.originals); err != nil {
+			return nil, fmt.Errorf("failed to update catalog specs: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return report, nil
+}
+
+// ListKeyCollisions returns canonical key collisions awaiting admin resolution
+func (s *GearCatalogStore) ListKeyCollisions(ctx context.Context, includeResolved bool) ([]models.CanonicalKeyCollision, error) {
+	query := `
+		SELECT c.id, c.catalog_id, c.conflicts_with_id, c.proposed_key,
+			   g.brand, g.model, COALESCE(g.variant, ''), c.created_at, c.resolved_at
+		FROM gear_catalog_key_collisions c
+		JOIN gear_catalog g ON g.id = c.catalog_id
+		WHERE $1 OR c.resolved_at IS NULL
+		ORDER BY c.created_at, c.id
+	`
+	rows, err := s.db.QueryContext(ctx, query, includeResolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list key collisions: %w", err)
+	}
+	defer rows.Close()
+
+	collisions := make([]models.CanonicalKeyCollision, 0)
+	for rows.Next() {
+		var collision models.CanonicalKeyCollision
+		var resolvedAt sql.NullTime
+		if err := rows.Scan(
+			&collision.ID, &collision.CatalogID, &collision.ConflictsWithID, &collision.ProposedKey,
+			&collision.Brand, &collision.Model, &collision.Variant, &collision.CreatedAt, &resolvedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan key collision: %w", err)
+		}
+		if resolvedAt.Valid {
+			collision.ResolvedAt = &resolvedAt.Time
+		}
+		collisions = append(collisions, collision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list key collisions: %w", err)
+	}
+	return collisions, nil
+}
+
+// ResolveKeyCollision marks a collision as handled by an admin
+func (s *GearCatalogStore) ResolveKeyCollision(ctx context.Context, id string, adminUserID string) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE gear_catalog_key_collisions
+		SET resolved_at = NOW(), resolved_by_user_id = $2
+		WHERE id = $1 AND resolved_at IS NULL
+	`, id, nullString(adminUserID))
+	if err != nil {
+		return fmt.Errorf("failed to resolve key collision: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check resolve result: %w", err)
+	}
+	if rows == 0 {
+		return ErrKeyCollisionNotFound
+	}
+	return nil
+}
+
+// BrandStats counts catalog items per brand by status, image status and
+// description status in one pass over the catalog, busiest brands first
+func (s *GearCatalogStore) BrandStats(ctx context.Context) ([]models.CatalogBrandStats, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT brand,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE status = 'published'),
+		       COUNT(*) FILTER (WHERE status = 'pending'),
+		       COUNT(*) FILTER (WHERE status = 'removed'),
+		       COUNT(*) FILTER (WHERE COALESCE(image_status, 'missing') = 'missing'),
+		       COUNT(*) FILTER (WHERE image_status = 'scanned'),
+		       COUNT(*) FILTER (WHERE image_status = 'approved'),
+		       COUNT(*) FILTER (WHERE COALESCE(description_status, 'missing') = 'missing'),
+		       COUNT(*) FILTER (WHERE description_status = 'scanned'),
+		       COUNT(*) FILTER (WHERE description_status = 'approved'),
+		       MAX(updated_at),
+		       GREATEST(MAX(image_curated_at), MAX(description_curated_at))
+		FROM gear_catalog
+		GROUP BY brand
+		ORDER BY COUNT(*) DESC, brand ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count catalog items by brand: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []models.CatalogBrandStats{}
+	for rows.Next() {
+		var b models.CatalogBrandStats
+		var published, pending, removed int
+		var imageMissing, imageScanned, imageApproved int
+		var descMissing, descScanned, descApproved int
+		var lastUpdated, lastCurated sql.NullTime
+		if err := rows.Scan(
+			&b.Brand, &b.Total,
+			&published, &pending, &removed,
+			&imageMissing, &imageScanned, &imageApproved,
+			&descMissing, &descScanned, &descApproved,
+			&lastUpdated, &lastCurated,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan brand stats: %w", err)
+		}
+		b.ByStatus = map[models.CatalogItemStatus]int{
+			models.CatalogStatusPublished: published,
+			models.CatalogStatusPending:   pending,
+			models.CatalogStatusRemoved:   removed,
+		}
+		b.ByImageStatus = map[models.ImageStatus]int{
+			models.ImageStatusMissing:  imageMissing,
+			models.ImageStatusScanned:  imageScanned,
+			models.ImageStatusApproved: imageApproved,
+		}
+		b.ByDescriptionStatus = map[models.ImageStatus]int{
+			models.ImageStatusMissing:  descMissing,
+			models.ImageStatusScanned:  descScanned,
+			models.ImageStatusApproved: descApproved,
+		}
+		if lastUpdated.Valid {
+			b.LastUpdatedAt = &lastUpdated.Time
+		}
+		if lastCurated.Valid {
+			b.LastCuratedAt = &lastCurated.Time
+		}
+		stats = append(stats, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count catalog items by brand: %w", err)
+	}
+	return stats, nil
+}
+
+// inventoryDisplayDrift selects catalog-linked inventory items whose name or
+// manufacturer differ from their catalog item, skipping ones the user changed.
+// The expected name matches GearCatalogItem.DisplayName. $1 limits it to one
+// catalog item unless empty.
+const inventoryDisplayDrift = `
+	SELECT id, catalog_id, name, manufacturer, new_name, new_manufacturer
+	FROM (
+		SELECT i.id, i.catalog_id::text AS catalog_id, i.name, COALESCE(i.manufacturer, '') AS manufacturer,
+			TRIM(gc.brand || ' ' || gc.model || COALESCE(' ' || NULLIF(gc.variant, ''), '')) AS new_name,
+			gc.brand AS new_manufacturer
+		FROM inventory_items i
+		JOIN gear_catalog gc ON gc.id = i.catalog_id
+		WHERE NOT i.display_overridden AND ($1 = '' OR i.catalog_id::text = $1)
+	) linked
+	WHERE name <> new_name OR manufacturer <> new_manufacturer
+`
+
+// SyncInventoryDisplayFields copies catalog names and brands onto the linked
+// inventory items that still show stale copies. An empty catalogID syncs
+// every item. With dryRun the affected items are reported but nothing is
+// written.
+func (s *GearCatalogStore) SyncInventoryDisplayFields(ctx context.Context, catalogID string, dryRun bool) (*models.InventoryDisplaySyncReport, error) {
+	query := inventoryDisplayDrift
+	if !dryRun {
+		// Rechecking display_overridden on the locked row keeps a rename made
+		// during the sync
+		query = `
+			UPDATE inventory_items i
+			SET name = d.new_name, manufacturer = d.new_manufacturer, updated_at = NOW()
+			FROM (` + inventoryDisplayDrift + `) d
+			WHERE i.id = d.id AND NOT i.display_overridden
+			RETURNING d.id, d.catalog_id, d.name, d.manufacturer, d.new_name, d.new_manufacturer
+		`
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, catalogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync inventory display fields: %w", err)
+	}
+	defer rows.Close()
+
+	report := &models.InventoryDisplaySyncReport{DryRun: dryRun, Changes: []models.InventoryDisplaySyncChange{}}
+	for rows.Next() {
+		var change models.InventoryDisplaySyncChange
+		if err := rows.Scan(&change.InventoryItemID, &change.CatalogID, &change.OldName, &change.OldManufacturer,
+			&change.NewName, &change.NewManufacturer); err != nil {
+			return nil, fmt.Errorf("failed to scan inventory display change: %w", err)
+		}
+		report.Affected++
+		if len(report.Changes) < models.MaxInventorySyncChanges {
+			report.Changes = append(report.Changes, change)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to sync inventory display fields: %w", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+func TestPlanCanonicalKeys(t *testing.T) {
+	identity := func(brand string) string { return brand }
+
+	t.Run("legacy rows are rekeyed", func(t *testing.T) {
+		rows := []canonicalKeyRow{
+			{ID: "a", GearType: models.GearTypeMotor, Brand: "T-Motor", Model: "F60 Pro", CanonicalKey: "motor|t motor|f60 pro"},
+		}
+		updates, collisions := planCanonicalKeys(rows, identity)
+		if len(collisions) != 0 {
+			t.Fatalf("collisions = %d, want 0", len(collisions))
+		}
+		if len(updates) != 1 || updates[0].NewKey != "v2|motor|tmotor|f60 pro" {
+			t.Fatalf("updates = %+v, want single v2 key", updates)
+		}
+	})
+
+	t.Run("unchanged rows are skipped", func(t *testing.T) {
+		rows := []canonicalKeyRow{
+			{ID: "a", GearType: models.GearTypeMotor, Brand: "T-Motor", Model: "F60 Pro", CanonicalKey: "v2|motor|tmotor|f60 pro"},
+		}
+		updates, collisions := planCanonicalKeys(rows, identity)
+		if len(updates) != 0 || len(collisions) != 0 {
+			t.Fatalf("updates = %d, collisions = %d, want 0 and 0", len(updates), len(collisions))
+		}
+	})
+
+	t.Run("first row wins a shared key", func(t *testing.T) {
+		rows := []canonicalKeyRow{
+			{ID: "a", GearType: models.GearTypeMotor, Brand: "T-Motor", Model: "F60 Pro", CanonicalKey: "motor|t motor|f60 pro"},
+			{ID: "b", GearType: models.GearTypeMotor, Brand: "TMotor", Model: "Pro F60", CanonicalKey: "motor|tmotor|pro f60"},
+		}
+		updates, collisions := planCanonicalKeys(rows, identity)
+		if len(updates) != 1 || updates[0].ID != "a" {
+			t.Fatalf("updates = %+v, want only a", updates)
+		}
+		if len(collisions) != 1 || collisions[0].CatalogID != "b" || collisions[0].ConflictsWithID != "a" {
+			t.Fatalf("collisions = %+v, want b conflicting with a", collisions)
+		}
+	})
+
+	t.Run("brand aliases are applied", func(t *testing.T) {
+		resolve := func(brand string) string {
+			if brand == "Tiger Motor" {
+				return "T-Motor"
+			}
+			return brand
+		}
+		rows := []canonicalKeyRow{
+			{ID: "a", GearType: models.GearTypeMotor, Brand: "Tiger Motor", Model: "F60", CanonicalKey: "motor|tiger motor|f60"},
+		}
+		updates, _ := planCanonicalKeys(rows, resolve)
+		if len(updates) != 1 || updates[0].NewKey != "v2|motor|tmotor|f60" {
+			t.Fatalf("updates = %+v, want alias-resolved key", updates)
+		}
+	})
+
+	t.Run("winner demoted when loser keeps the key", func(t *testing.T) {
+		// b already holds the key a wants, but b loses its own new key to c,
+		// so b keeps its current key and a has to stay put as well.
+		rows := []canonicalKeyRow{
+			{ID: "c", GearType: models.GearTypeMotor, Brand: "X", Model: "Two", CanonicalKey: "motor|x|two"},
+			{ID: "b", GearType: models.GearTypeMotor, Brand: "X", Model: "Two", CanonicalKey: "v2|motor|x|one"},
+			{ID: "a", GearType: models.GearTypeMotor, Brand: "X", Model: "One", CanonicalKey: "motor|x|one"},
+		}
+		updates, collisions := planCanonicalKeys(rows, identity)
+		if len(updates) != 1 || updates[0].ID != "c" {
+			t.Fatalf("updates = %+v, want only c", updates)
+		}
+		if len(collisions) != 2 {
+			t.Fatalf("collisions = %+v, want 2", collisions)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const gearImageCandidateColumns = `id, gear_id, image_asset_id, image_type, source_name, source_url, COALESCE(page_url, ''), created_at`
+
+// GearImageCandidateStore handles sourced catalog images waiting for admin approval
+type GearImageCandidateStore struct {
+	db *DB
+}
+
+// NewGearImageCandidateStore creates a new gear image candidate store
+func NewGearImageCandidateStore(db *DB) *GearImageCandidateStore {
+	return &GearImageCandidateStore{db: db}
+}
+
+// Create stores a candidate. Returns nil without an error if the item already
+// has a candidate from the same source URL.
+func (s *GearImageCandidateStore) Create(ctx context.Context, createdBy string, candidate models.GearImageCandidate) (*models.GearImageCandidate, error) {
+	query := `
+		INSERT INTO gear_image_candidates (gear_id, image_asset_id, image_type, source_name, source_url, page_url, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (gear_id, source_url) DO NOTHING
+		RETURNING ` + gearImageCandidateColumns
+
+	created, err := scanGearImageCandidate(s.db.QueryRowContext(ctx, query,
+		candidate.GearID, candidate.ImageAssetID, candidate.ImageType, candidate.SourceName,
+		candidate.SourceURL, nullString(candidate.PageURL), nullString(createdBy)))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gear image candidate: %w", err)
+	}
+	return created, nil
+}
+
+// Get retrieves a candidate by ID
+func (s *GearImageCandidateStore) Get(ctx context.Context, id string) (*models.GearImageCandidate, error) {
+	query := `SELECT ` + gearImageCandidateColumns + ` FROM gear_image_candidates WHERE id = $1`
+
+	candidate, err := scanGearImageCandidate(s.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gear image candidate: %w", err)
+	}
+	return candidate, nil
+}
+
+// ListByGear returns a catalog item's candidates, oldest first
+func (s *GearImageCandidateStore) ListByGear(ctx context.Context, gearID string) ([]models.GearImageCandidate, error) {
+	query := `SELECT ` + gearImageCandidateColumns + ` FROM gear_image_candidates WHERE gear_id = $1 ORDER BY created_at, id`
+
+	rows, err := s.db.QueryContext(ctx, query, gearID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gear image candidates: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := make([]models.GearImageCandidate, 0)
+	for rows.Next() {
+		candidate, err := scanGearImageCandidate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan gear image candidate: %w", err)
+		}
+		candidates = append(candidates, *candidate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list gear image candidates: %w", err)
+	}
+	return candidates, nil
+}
+
+// Delete removes a candidate row, leaving its image asset in place
+func (s *GearImageCandidateStore) Delete(ctx context.Context, id string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM gear_image_candidates WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete gear image candidate: %w", err)
+	}
+	return nil
+}
+
+func scanGearImageCandidate(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.GearImageCandidate, error) {
+	var candidate models.GearImageCandidate
+	if err := scanner.Scan(
+		&candidate.ID,
+		&candidate.GearID,
+		&candidate.ImageAssetID,
+		&candidate.ImageType,
+		&candidate.SourceName,
+		&candidate.SourceURL,
+		&candidate.PageURL,
+		&candidate.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &candidate, nil
+}
@@ -0,0 +1,482 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// groupUserName is the display name expression for a joined users row
+const groupUserName = `COALESCE(NULLIF(%[1]s.display_name, ''), NULLIF(%[1]s.google_name, ''), NULLIF(%[1]s.call_sign, ''), 'Pilot')`
+
+// GroupStore handles group, membership, invitation, and shared fleet persistence
+type GroupStore struct {
+	db *DB
+}
+
+// NewGroupStore creates a new group store
+func NewGroupStore(db *DB) *GroupStore {
+	return &GroupStore{db: db}
+}
+
+// Create inserts a group and makes the creator its owner
+func (s *GroupStore) Create(ctx context.Context, userID string, params models.CreateGroupParams) (*models.Group, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	group := &models.Group{
+		Name:            params.Name,
+		Description:     params.Description,
+		CreatedByUserID: userID,
+		MemberCount:     1,
+		Role:            models.GroupRoleOwner,
+	}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO pilot_groups (name, description, created_by_user_id)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`, params.Name, params.Description, userID).Scan(&group.ID, &group.CreatedAt, &group.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create group: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO pilot_group_members (group_id, user_id, role) VALUES ($1, $2, $3)
+	`, group.ID, userID, models.GroupRoleOwner); err != nil {
+		return nil, fmt.Errorf("failed to add group owner: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return group, nil
+}
+
+const groupSelect = `
+	SELECT g.id, g.name, g.description, g.created_by_user_id, g.created_at, g.updated_at,
+		(SELECT COUNT(*) FROM pilot_group_members c WHERE c.group_id = g.id),
+		COALESCE(m.role, '')
+	FROM pilot_groups g
+	LEFT JOIN pilot_group_members m ON m.group_id = g.id AND m.user_id = $1
+`
+
+// Get retrieves a group with the given user's role in it. Returns nil if the
+// group does not exist.
+func (s *GroupStore) Get(ctx context.Context, id, userID string) (*models.Group, error) {
+	group, err := scanGroup(s.db.QueryRowContext(ctx, groupSelect+` WHERE g.id = $2`, userID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+	return group, nil
+}
+
+// ListForUser returns the groups a user belongs to
+func (s *GroupStore) ListForUser(ctx context.Context, userID string) ([]models.Group, error) {
+	rows, err := s.db.QueryContext(ctx, groupSelect+` WHERE m.user_id IS NOT NULL ORDER BY g.name`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+	defer rows.Close()
+
+	groups := []models.Group{}
+	for rows.Next() {
+		group, err := scanGroup(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan group: %w", err)
+		}
+		groups = append(groups, *group)
+	}
+	return groups, rows.Err()
+}
+
+// Update applies the non-nil fields of params to a group
+func (s *GroupStore) Update(ctx context.Context, id string, params models.UpdateGroupParams) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE pilot_groups
+		SET name = COALESCE($2, name),
+			description = COALESCE($3, description),
+			updated_at = NOW()
+		WHERE id = $1
+	`, id, params.Name, params.Description)
+	if err != nil {
+		return fmt.Errorf("failed to update group: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a group along with its memberships, invitations, and shares
+func (s *GroupStore) Delete(ctx context.Context, id string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM pilot_groups WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+	return nil
+}
+
+// GetMemberRole returns a user's role in a group, or an empty role if they
+// are not a member
+func (s *GroupStore) GetMemberRole(ctx context.Context, groupID, userID string) (models.GroupRole, error) {
+	var role models.GroupRole
+	err := s.db.QueryRowContext(ctx, `
+		SELECT role FROM pilot_group_members WHERE group_id = $1 AND user_id = $2
+	`, groupID, userID).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get group role: %w", err)
+	}
+	return role, nil
+}
+
+// ListMembers returns a group's members, owner and admins first
+func (s *GroupStore) ListMembers(ctx context.Context, groupID string) ([]models.GroupMember, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT m.user_id, COALESCE(u.call_sign, ''), `+fmt.Sprintf(groupUserName, "u")+`, m.role, m.joined_at
+		FROM pilot_group_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.group_id = $1
+		ORDER BY CASE m.role WHEN 'owner' THEN 0 WHEN 'admin' THEN 1 ELSE 2 END, m.joined_at
+	`, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []models.GroupMember{}
+	for rows.Next() {
+		var member models.GroupMember
+		if err := rows.Scan(&member.UserID, &member.CallSign, &member.DisplayName, &member.Role, &member.JoinedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan group member: %w", err)
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// SetMemberRole changes a member's role. Returns false if they are not a member.
+func (s *GroupStore) SetMemberRole(ctx context.Context, groupID, userID string, role models.GroupRole) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE pilot_group_members SET role = $3 WHERE group_id = $1 AND user_id = $2
+	`, groupID, userID, role)
+	if err != nil {
+		return false, fmt.Errorf("failed to set group role: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// RemoveMember removes a member and unshares everything they shared with the
+// group. Returns false if they were not a member.
+func (s *GroupStore) RemoveMember(ctx context.Context, groupID, userID string) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM pilot_group_members WHERE group_id = $1 AND user_id = $2
+	`, groupID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove group member: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return false, nil
+	}
+
+	for _, query := range []string{
+		`DELETE FROM pilot_group_aircraft WHERE group_id = $1 AND shared_by_user_id = $2`,
+		`DELETE FROM pilot_group_batteries WHERE group_id = $1 AND shared_by_user_id = $2`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, groupID, userID); err != nil {
+			return false, fmt.Errorf("failed to unshare member fleet: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return true, nil
+}
+
+const invitationSelect = `
+	SELECT i.id, i.group_id, g.name, i.invited_user_id, %s, COALESCE(i.invited_by_user_id::text, ''), %s,
+		i.role, i.status, i.created_at, i.expires_at, i.responded_at
+	FROM pilot_group_invitations i
+	JOIN pilot_groups g ON g.id = i.group_id
+	JOIN users iu ON iu.id = i.invited_user_id
+	LEFT JOIN users ib ON ib.id = i.invited_by_user_id
+`
+
+var invitationQuery = fmt.Sprintf(invitationSelect, fmt.Sprintf(groupUserName, "iu"), fmt.Sprintf(groupUserName, "ib"))
+
+// CreateInvitation records a pending invitation. Returns nil without an error
+// if the user already has one pending for the group.
+func (s *GroupStore) CreateInvitation(ctx context.Context, groupID, invitedUserID, invitedByUserID string, role models.GroupRole, expiresAt time.Time) (*models.GroupInvitation, error) {
+	var id string
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO pilot_group_invitations (group_id, invited_user_id, invited_by_user_id, role, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT DO NOTHING
+		RETURNING id
+	`, groupID, invitedUserID, invitedByUserID, role, expiresAt).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create group invitation: %w", err)
+	}
+	return s.GetInvitation(ctx, id)
+}
+
+// GetInvitation retrieves an invitation by ID. Returns nil if it does not exist.
+func (s *GroupStore) GetInvitation(ctx context.Context, id string) (*models.GroupInvitation, error) {
+	invitation, err := scanGroupInvitation(s.db.QueryRowContext(ctx, invitationQuery+` WHERE i.id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group invitation: %w", err)
+	}
+	return invitation, nil
+}
+
+// ListGroupInvitations returns a group's unexpired pending invitations
+func (s *GroupStore) ListGroupInvitations(ctx context.Context, groupID string) ([]models.GroupInvitation, error) {
+	return s.listInvitations(ctx, `i.group_id = $1`, groupID)
+}
+
+// ListUserInvitations returns the unexpired pending invitations sent to a user
+func (s *GroupStore) ListUserInvitations(ctx context.Context, userID string) ([]models.GroupInvitation, error) {
+	return s.listInvitations(ctx, `i.invited_user_id = $1`, userID)
+}
+
+func (s *GroupStore) listInvitations(ctx context.Context, condition string, arg string) ([]models.GroupInvitation, error) {
+	rows, err := s.db.QueryContext(ctx, invitationQuery+`
+		WHERE `+condition+` AND i.status = 'pending' AND i.expires_at > NOW()
+		ORDER BY i.created_at DESC
+	`, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group invitations: %w", err)
+	}
+	defer rows.Close()
+
+	invitations := []models.GroupInvitation{}
+	for rows.Next() {
+		invitation, err := scanGroupInvitation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan group invitation: %w", err)
+		}
+		invitations = append(invitations, *invitation)
+	}
+	return invitations, rows.Err()
+}
+
+// RespondToInvitation closes a pending invitation with the given status, adding
+// the invitee as a member when accepted. Returns false if the invitation was
+// no longer pending.
+func (s *GroupStore) RespondToInvitation(ctx context.Context, id string, status models.GroupInvitationStatus) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var groupID, userID string
+	var role models.GroupRole
+	err = tx.QueryRowContext(ctx, `
+		UPDATE pilot_group_invitations
+		SET status = $2, responded_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+		RETURNING group_id, invited_user_id, role
+	`, id, status).Scan(&groupID, &userID, &role)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to update group invitation: %w", err)
+	}
+
+	if status == models.GroupInvitationAccepted {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO pilot_group_members (group_id, user_id, role)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (group_id, user_id) DO NOTHING
+		`, groupID, userID, role); err != nil {
+			return false, fmt.Errorf("failed to add group member: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return true, nil
+}
+
+// ShareAircraft shares one of the user's aircraft with a group. Returns false
+// if the user does not own the aircraft.
+func (s *GroupStore) ShareAircraft(ctx context.Context, groupID, aircraftID, userID string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO pilot_group_aircraft (group_id, aircraft_id, shared_by_user_id)
+		SELECT $1, a.id, a.user_id FROM aircraft a WHERE a.id = $2 AND a.user_id = $3
+		ON CONFLICT (group_id, aircraft_id) DO UPDATE SET shared_by_user_id = EXCLUDED.shared_by_user_id
+	`, groupID, aircraftID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to share aircraft: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// UnshareAircraft removes an aircraft from a group. When sharedByUserID is set,
+// only a share made by that user is removed. Returns false if nothing matched.
+func (s *GroupStore) UnshareAircraft(ctx context.Context, groupID, aircraftID, sharedByUserID string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM pilot_group_aircraft
+		WHERE group_id = $1 AND aircraft_id = $2 AND ($3 = '' OR shared_by_user_id::text = $3)
+	`, groupID, aircraftID, sharedByUserID)
+	if err != nil {
+		return false, fmt.Errorf("failed to unshare aircraft: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// ListAircraft returns the aircraft shared with a group
+func (s *GroupStore) ListAircraft(ctx context.Context, groupID string) ([]models.GroupSharedAircraft, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT a.id, a.name, COALESCE(a.nickname, ''), COALESCE(a.type, ''), a.user_id, `+fmt.Sprintf(groupUserName, "u")+`,
+			ga.shared_by_user_id, ga.shared_at
+		FROM pilot_group_aircraft ga
+		JOIN aircraft a ON a.id = ga.aircraft_id
+		JOIN users u ON u.id = a.user_id
+		WHERE ga.group_id = $1
+		ORDER BY a.name
+	`, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group aircraft: %w", err)
+	}
+	defer rows.Close()
+
+	aircraft := []models.GroupSharedAircraft{}
+	for rows.Next() {
+		var a models.GroupSharedAircraft
+		if err := rows.Scan(&a.AircraftID, &a.Name, &a.Nickname, &a.Type, &a.OwnerUserID, &a.OwnerName, &a.SharedByUserID, &a.SharedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan group aircraft: %w", err)
+		}
+		aircraft = append(aircraft, a)
+	}
+	return aircraft, rows.Err()
+}
+
+// ShareBattery shares one of the user's batteries with a group. Returns false
+// if the user does not own the battery.
+func (s *GroupStore) ShareBattery(ctx context.Context, groupID, batteryID, userID string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO pilot_group_batteries (group_id, battery_id, shared_by_user_id)
+		SELECT $1, b.id, b.user_id FROM batteries b WHERE b.id = $2 AND b.user_id = $3
+		ON CONFLICT (group_id, battery_id) DO UPDATE SET shared_by_user_id = EXCLUDED.shared_by_user_id
+	`, groupID, batteryID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to share battery: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// UnshareBattery removes a battery from a group. When sharedByUserID is set,
+// only a share made by that user is removed. Returns false if nothing matched.
+func (s *GroupStore) UnshareBattery(ctx context.Context, groupID, batteryID, sharedByUserID string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM pilot_group_batteries
+		WHERE group_id = $1 AND battery_id = $2 AND ($3 = '' OR shared_by_user_id::text = $3)
+	`, groupID, batteryID, sharedByUserID)
+	if err != nil {
+		return false, fmt.Errorf("failed to unshare battery: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// ListBatteries returns the batteries shared with a group
+func (s *GroupStore) ListBatteries(ctx context.Context, groupID string) ([]models.GroupSharedBattery, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT b.id, b.battery_code, COALESCE(b.name, ''), b.chemistry, b.cells, b.capacity_mah,
+			b.user_id, `+fmt.Sprintf(groupUserName, "u")+`, gb.shared_by_user_id, gb.shared_at
+		FROM pilot_group_batteries gb
+		JOIN batteries b ON b.id = gb.battery_id
+		JOIN users u ON u.id = b.user_id
+		WHERE gb.group_id = $1
+		ORDER BY b.battery_code
+	`, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group batteries: %w", err)
+	}
+	defer rows.Close()
+
+	batteries := []models.GroupSharedBattery{}
+	for rows.Next() {
+		var b models.GroupSharedBattery
+		if err := rows.Scan(&b.BatteryID, &b.BatteryCode, &b.Name, &b.Chemistry, &b.Cells, &b.CapacityMah,
+			&b.OwnerUserID, &b.OwnerName, &b.SharedByUserID, &b.SharedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan group battery: %w", err)
+		}
+		batteries = append(batteries, b)
+	}
+	return batteries, rows.Err()
+}
+
+func scanGroup(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.Group, error) {
+	var group models.Group
+	if err := scanner.Scan(
+		&group.ID,
+		&group.Name,
+		&group.Description,
+		&group.CreatedByUserID,
+		&group.CreatedAt,
+		&group.UpdatedAt,
+		&group.MemberCount,
+		&group.Role,
+	); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func scanGroupInvitation(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.GroupInvitation, error) {
+	var invitation models.GroupInvitation
+	var respondedAt sql.NullTime
+	if err := scanner.Scan(
+		&invitation.ID,
+		&invitation.GroupID,
+		&invitation.GroupName,
+		&invitation.InvitedUserID,
+		&invitation.InvitedUserName,
+		&invitation.InvitedByUserID,
+		&invitation.InvitedByName,
+		&invitation.Role,
+		&invitation.Status,
+		&invitation.CreatedAt,
+		&invitation.ExpiresAt,
+		&respondedAt,
+	); err != nil {
+		return nil, err
+	}
+	if respondedAt.Valid {
+		invitation.RespondedAt = &respondedAt.Time
+	}
+	return &invitation, nil
+}
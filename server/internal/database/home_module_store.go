@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ErrHomeModuleNotFound is returned when a home module doesn't exist
+var ErrHomeModuleNotFound = errors.New("home module not found")
+
+const homeModuleColumns = `id, kind, title, position, item_limit, enabled, settings, created_at, updated_at`
+
+// HomeModuleStore handles the admin-configured home page layout
+type HomeModuleStore struct {
+	db *DB
+}
+
+// NewHomeModuleStore creates a new home module store
+func NewHomeModuleStore(db *DB) *HomeModuleStore {
+	return &HomeModuleStore{db: db}
+}
+
+// List returns every module in display order
+func (s *HomeModuleStore) List(ctx context.Context) ([]models.HomeModule, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+homeModuleColumns+` FROM home_modules ORDER BY position, created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list home modules: %w", err)
+	}
+	defer rows.Close()
+
+	modules := make([]models.HomeModule, 0)
+	for rows.Next() {
+		module, err := scanHomeModule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan home module: %w", err)
+		}
+		modules = append(modules, *module)
+	}
+	return modules, rows.Err()
+}
+
+// Get retrieves a module by ID, or nil if it doesn't exist
+func (s *HomeModuleStore) Get(ctx context.Context, id string) (*models.HomeModule, error) {
+	module, err := scanHomeModule(s.db.QueryRowContext(ctx, `SELECT `+homeModuleColumns+` FROM home_modules WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home module: %w", err)
+	}
+	return module, nil
+}
+
+// Create adds a module. Params must already be validated.
+func (s *HomeModuleStore) Create(ctx context.Context, params models.SaveHomeModuleParams) (*models.HomeModule, error) {
+	settings, err := json.Marshal(params.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode home module settings: %w", err)
+	}
+
+	query := `
+		INSERT INTO home_modules (kind, title, position, item_limit, enabled, settings)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + homeModuleColumns
+	module, err := scanHomeModule(s.db.QueryRowContext(ctx, query,
+		params.Kind, params.Title, params.Position, params.Limit, *params.Enabled, settings,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create home module: %w", err)
+	}
+	return module, nil
+}
+
+// Update replaces a module, returning nil if it doesn't exist
+func (s *HomeModuleStore) Update(ctx context.Context, id string, params models.SaveHomeModuleParams) (*models.HomeModule, error) {
+	settings, err := json.Marshal(params.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode home module settings: %w", err)
+	}
+
+	query := `
+		UPDATE home_modules
+		SET kind = $2, title = $3, position = $4, item_limit = $5, enabled = $6, settings = $7, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + homeModuleColumns
+	module, err := scanHomeModule(s.db.QueryRowContext(ctx, query,
+		id, params.Kind, params.Title, params.Position, params.Limit, *params.Enabled, settings,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update home module: %w", err)
+	}
+	return module, nil
+}
+
+// Delete removes a module
+func (s *HomeModuleStore) Delete(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM home_modules WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete home module: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrHomeModuleNotFound
+	}
+	return nil
+}
+
+func scanHomeModule(row interface{ Scan(...interface{}) error }) (*models.HomeModule, error) {
+	var module models.HomeModule
+	var settings []byte
+	if err := row.Scan(
+		&module.ID, &module.Kind, &module.Title, &module.Position, &module.Limit, &module.Enabled, &settings,
+		&module.CreatedAt, &module.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(settings, &module.Settings); err != nil {
+		return nil, fmt.Errorf("failed to decode home module settings: %w", err)
+	}
+	return &module, nil
+}
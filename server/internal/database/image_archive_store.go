@@ -0,0 +1,229 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/images"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ImageRestorer brings an archived image's bytes back from cold storage
+type ImageRestorer interface {
+	RestoreImage(ctx context.Context, imageID string) ([]byte, error)
+}
+
+// SetImageRestorer lets queries that read image bytes restore archived
+// images transparently.
+func (db *DB) SetImageRestorer(restorer ImageRestorer) {
+	db.imageRestorer = restorer
+}
+
+// imageBytes returns image bytes read together with the asset's ID and
+// storage tier, restoring them from cold storage when the asset is archived.
+// Legacy images stored on the entity row have no asset and are returned as is.
+func (db *DB) imageBytes(ctx context.Context, imageID, tier sql.NullString, data []byte) ([]byte, error) {
+	if !tier.Valid || tier.String != string(models.ImageTierCold) {
+		return data, nil
+	}
+	return db.restoreImage(ctx, imageID.String)
+}
+
+// restoreImage returns the bytes of an archived image
+func (db *DB) restoreImage(ctx context.Context, imageID string) ([]byte, error) {
+	if db.imageRestorer == nil {
+		return nil, fmt.Errorf("image %s is archived and no image archive is configured", imageID)
+	}
+	return db.imageRestorer.RestoreImage(ctx, imageID)
+}
+
+// ListArchiveCandidates returns approved hot images, oldest first, that
+// haven't been created or restored since idleSince and aren't shown on a
+// build or catalog item viewed since then. Avatars are never archived, as
+// they are shown across the site without being counted as views.
+func (s *ImageAssetStore) ListArchiveCandidates(ctx context.Context, idleSince time.Time, limit int) ([]models.ImageAsset, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT ia.id, ia.owner_user_id, ia.entity_type, COALESCE(ia.entity_id::text, ''), ia.image_bytes
+		FROM image_assets ia
+		WHERE ia.status = 'APPROVED'
+		  AND ia.storage_tier = 'hot'
+		  AND ia.entity_type <> $3
+		  AND COALESCE(ia.restored_at, ia.created_at) < $1
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM entity_images ei
+			LEFT JOIN builds b ON ei.entity_type = 'build' AND b.id = ei.entity_id
+			LEFT JOIN gear_catalog gc ON ei.entity_type = 'gear' AND gc.id = ei.entity_id
+			WHERE ei.image_asset_id = ia.id
+			  AND GREATEST(b.last_viewed_at, gc.last_viewed_at) >= $1
+		  )
+		ORDER BY ia.created_at, ia.id
+		LIMIT $2
+	`, idleSince, limit, string(models.ImageEntityAvatar))
+	if err != nil {
+		return nil, fmt.Errorf("list images to archive: %w", err)
+	}
+	defer rows.Close()
+
+	assets := make([]models.ImageAsset, 0, limit)
+	for rows.Next() {
+		var asset models.ImageAsset
+		if err := rows.Scan(&asset.ID, &asset.OwnerUserID, &asset.EntityType, &asset.EntityID, &asset.ImageBytes); err != nil {
+			return nil, fmt.Errorf("scan image to archive: %w", err)
+		}
+		asset.Status = models.ImageModerationApproved
+		assets = append(assets, asset)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list images to archive: %w", err)
+	}
+	return assets, nil
+}
+
+// MarkArchived replaces an image's bytes with a reference to the object now
+// holding them. The size is kept so quotas still count the image.
+func (s *ImageAssetStore) MarkArchived(ctx context.Context, imageID, key, backend string, size int64) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE image_assets
+		SET storage_tier = 'cold',
+			image_bytes = ''::bytea,
+			archive_key = $2,
+			archive_backend = $3,
+			archive_size = $4,
+			archived_at = NOW()
+		WHERE id = $1 AND storage_tier = 'hot' AND status = 'APPROVED'
+	`, imageID, key, backend, size)
+	if err != nil {
+		return false, fmt.Errorf("mark image archived: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// GetArchivedImage returns an image's storage tier, with its bytes when hot
+// or its object when cold. Returns nil if the image doesn't exist.
+func (s *ImageAssetStore) GetArchivedImage(ctx context.Context, imageID string) (*images.ArchivedImage, error) {
+	var image images.ArchivedImage
+	var tier string
+	var key, backend, hash sql.NullString
+	err := s.db.QueryRowContext(ctx, `
+		SELECT storage_tier, image_bytes, archive_key, archive_backend, content_hash
+		FROM image_assets
+		WHERE id = $1
+	`, imageID).Scan(&tier, &image.ImageBytes, &key, &backend, &hash)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get archived image: %w", err)
+	}
+	image.Tier = models.ImageStorageTier(tier)
+	image.Key = key.String
+	image.Backend = backend.String
+	image.ContentHash = hash.String
+	return &image, nil
+}
+
+// MarkRestored puts an archived image's bytes back and queues its object for
+// removal by the next archiver run.
+func (s *ImageAssetStore) MarkRestored(ctx context.Context, imageID string, imageBytes []byte) (bool, error) {
+	var key string
+	err := s.db.QueryRowContext(ctx, `
+		WITH restored AS (
+			UPDATE image_assets ia
+			SET storage_tier = 'hot',
+				image_bytes = $2,
+				archive_key = NULL,
+				archive_backend = NULL,
+				archive_size = NULL,
+				archived_at = NULL,
+				restored_at = NOW()
+			FROM image_assets old
+			WHERE ia.id = $1 AND old.id = ia.id AND ia.storage_tier = 'cold'
+			RETURNING old.archive_key, old.archive_backend
+		), queued AS (
+			INSERT INTO image_archive_orphans (object_key, backend)
+			SELECT archive_key, archive_backend FROM restored
+			ON CONFLICT (object_key) DO NOTHING
+		)
+		SELECT archive_key FROM restored
+	`, imageID, imageBytes).Scan(&key)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("mark image restored: %w", err)
+	}
+	return true, nil
+}
+
+// ListArchiveOrphans returns objects in backend no image references any
+// more, oldest first
+func (s *ImageAssetStore) ListArchiveOrphans(ctx context.Context, backend string, limit int) ([]images.ArchiveObject, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT object_key, backend FROM image_archive_orphans
+		WHERE backend = $1
+		ORDER BY created_at
+		LIMIT $2
+	`, backend, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list archive orphans: %w", err)
+	}
+	defer rows.Close()
+
+	orphans := make([]images.ArchiveObject, 0)
+	for rows.Next() {
+		var orphan images.ArchiveObject
+		if err := rows.Scan(&orphan.Key, &orphan.Backend); err != nil {
+			return nil, fmt.Errorf("scan archive orphan: %w", err)
+		}
+		orphans = append(orphans, orphan)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list archive orphans: %w", err)
+	}
+	return orphans, nil
+}
+
+// DeleteArchiveOrphan forgets an object once it has been removed
+func (s *ImageAssetStore) DeleteArchiveOrphan(ctx context.Context, key string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM image_archive_orphans WHERE object_key = $1`, key); err != nil {
+		return fmt.Errorf("delete archive orphan: %w", err)
+	}
+	return nil
+}
+
+// TierStats returns how many images each storage tier holds and their size
+func (s *ImageAssetStore) TierStats(ctx context.Context) ([]models.ImageTierStats, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT storage_tier, COUNT(*), COALESCE(SUM(COALESCE(archive_size, byte_size)), 0)
+		FROM image_assets
+		GROUP BY storage_tier
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("get image tier stats: %w", err)
+	}
+	defer rows.Close()
+
+	byTier := make(map[models.ImageStorageTier]models.ImageTierStats)
+	for rows.Next() {
+		var stats models.ImageTierStats
+		if err := rows.Scan(&stats.Tier, &stats.Count, &stats.Bytes); err != nil {
+			return nil, fmt.Errorf("scan image tier stats: %w", err)
+		}
+		byTier[stats.Tier] = stats
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get image tier stats: %w", err)
+	}
+
+	tiers := []models.ImageTierStats{}
+	for _, tier := range []models.ImageStorageTier{models.ImageTierHot, models.ImageTierCold} {
+		stats := byTier[tier]
+		stats.Tier = tier
+		tiers = append(tiers, stats)
+	}
+	return tiers, nil
+}
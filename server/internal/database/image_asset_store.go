@@ -20,7 +20,13 @@ func NewImageAssetStore(db *DB) *ImageAssetStore {
 	return &ImageAssetStore{db: db}
 }
 
-// Save stores approved image bytes and moderation metadata.
+// imageAssetColumns are the columns scanned by scanImageAsset
+const imageAssetColumns = `id, owner_user_id, entity_type, entity_id, image_bytes, status, moderation_labels, moderation_max_confidence, storage_tier, created_at, updated_at`
+
+// Save stores approved image bytes and moderation metadata. An approved asset
+// with identical bytes is reused instead, taking another reference, so the
+// same product photo uploaded to many catalog items is stored once. Catalog
+// images are reused across owners; other images only within one owner's.
 func (s *ImageAssetStore) Save(ctx context.Context, req images.SaveRequest) (*models.ImageAsset, error) {
 	if len(req.ImageBytes) == 0 {
 		return nil, fmt.Errorf("image bytes are required")
@@ -32,6 +38,17 @@ func (s *ImageAssetStore) Save(ctx context.Context, req images.SaveRequest) (*mo
 		req.EntityType = models.ImageEntityOther
 	}
 
+	hash := images.ContentHash(req.ImageBytes)
+	asset, err := s.reuse(ctx, req, hash)
+	if err != nil {
+		return nil, err
+	}
+	if asset != nil {
+		// An archived copy stays archived; the bytes are the same either way
+		asset.ImageBytes = req.ImageBytes
+		return asset, nil
+	}
+
 	labelsJSON, err := json.Marshal(req.ModerationLabels)
 	if err != nil {
 		return nil, fmt.Errorf("marshal moderation labels: %w", err)
@@ -50,16 +67,13 @@ func (s *ImageAssetStore) Save(ctx context.Context, req images.SaveRequest) (*mo
 			image_bytes,
 			status,
 			moderation_labels,
-			moderation_max_confidence
+			moderation_max_confidence,
+			content_hash
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, owner_user_id, entity_type, entity_id, image_bytes, status, moderation_labels, moderation_max_confidence, created_at, updated_at
-	`
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + imageAssetColumns
 
-	var asset models.ImageAsset
-	var status string
-	var scanEntityID sql.NullString
-	err = s.db.QueryRowContext(
+	asset, err = scanImageAsset(s.db.QueryRowContext(
 		ctx,
 		query,
 		req.OwnerUserID,
@@ -69,72 +83,330 @@ func (s *ImageAssetStore) Save(ctx context.Context, req images.SaveRequest) (*mo
 		string(models.ImageModerationApproved),
 		labelsJSON,
 		req.ModerationMaxConfidence,
-	).Scan(
-		&asset.ID,
-		&asset.OwnerUserID,
-		&asset.EntityType,
-		&scanEntityID,
-		&asset.ImageBytes,
-		&status,
-		&asset.ModerationLabels,
-		&asset.ModerationMaxConfidence,
-		&asset.CreatedAt,
-		&asset.UpdatedAt,
-	)
+		hash,
+	))
 	if err != nil {
 		return nil, fmt.Errorf("save image asset: %w", err)
 	}
-	asset.Status = models.ImageModerationStatus(status)
-	if scanEntityID.Valid {
-		asset.EntityID = scanEntityID.String
-	}
+	return asset, nil
+}
 
-	return &asset, nil
+// reuse takes a reference to an approved asset with the same bytes, returning
+// nil if there is none. Two identical uploads saved at the same moment may
+// both be stored; later uploads reuse the older copy.
+func (s *ImageAssetStore) reuse(ctx context.Context, req images.SaveRequest, hash string) (*models.ImageAsset, error) {
+	query := `
+		UPDATE image_assets
+		SET ref_count = ref_count + 1, updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM image_assets
+			WHERE content_hash = $1
+			  AND entity_type = $2
+			  AND status = 'APPROVED'
+			  AND ($3 OR owner_user_id = $4)
+			ORDER BY created_at, id
+			LIMIT 1
+		)
+		RETURNING ` + imageAssetColumns
+
+	asset, err := scanImageAsset(s.db.QueryRowContext(ctx, query,
+		hash, string(req.EntityType), images.SharedAcrossOwners(req.EntityType), req.OwnerUserID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reuse image asset: %w", err)
+	}
+	return asset, nil
 }
 
-// Load retrieves an image asset by ID.
+// Load retrieves an image asset by ID, restoring its bytes if archived.
 func (s *ImageAssetStore) Load(ctx context.Context, imageID string) (*models.ImageAsset, error) {
-	query := `
-		SELECT id, owner_user_id, entity_type, entity_id, image_bytes, status, moderation_labels, moderation_max_confidence, created_at, updated_at
-		FROM image_assets
-		WHERE id = $1
-	`
+	query := `SELECT ` + imageAssetColumns + ` FROM image_assets WHERE id = $1`
 
+	asset, err := scanImageAsset(s.db.QueryRowContext(ctx, query, imageID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load image asset: %w", err)
+	}
+	if asset.StorageTier == models.ImageTierCold {
+		if asset.ImageBytes, err = s.db.restoreImage(ctx, asset.ID); err != nil {
+			return nil, err
+		}
+		asset.StorageTier = models.ImageTierHot
+	}
+	return asset, nil
+}
+
+func scanImageAsset(row *sql.Row) (*models.ImageAsset, error) {
 	var asset models.ImageAsset
 	var status string
-	var scanEntityID sql.NullString
-	err := s.db.QueryRowContext(ctx, query, imageID).Scan(
+	var entityID sql.NullString
+	err := row.Scan(
 		&asset.ID,
 		&asset.OwnerUserID,
 		&asset.EntityType,
-		&scanEntityID,
+		&entityID,
 		&asset.ImageBytes,
 		&status,
 		&asset.ModerationLabels,
 		&asset.ModerationMaxConfidence,
+		&asset.StorageTier,
 		&asset.CreatedAt,
 		&asset.UpdatedAt,
 	)
+	if err != nil {
+		return nil, err
+	}
+	asset.Status = models.ImageModerationStatus(status)
+	if entityID.Valid {
+		asset.EntityID = entityID.String
+	}
+	return &asset, nil
+}
+
+// Delete releases one reference to an image asset, removing the asset once
+// nothing references it.
+func (s *ImageAssetStore) Delete(ctx context.Context, imageID string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var refCount int
+	err = tx.QueryRowContext(ctx, `SELECT ref_count FROM image_assets WHERE id = $1 FOR UPDATE`, imageID).Scan(&refCount)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("delete image asset: %w", err)
+	}
+
+	if refCount > 1 {
+		_, err = tx.ExecContext(ctx, `UPDATE image_assets SET ref_count = ref_count - 1, updated_at = NOW() WHERE id = $1`, imageID)
+	} else {
+		_, err = tx.ExecContext(ctx, `DELETE FROM image_assets WHERE id = $1`, imageID)
+	}
+	if err != nil {
+		return fmt.Errorf("delete image asset: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Usage returns how many images a user owns and their total size, including
+// archived images. Gear catalog images are shared catalog content and are not
+// counted. Images that belong to the excluded entity are left out so replacing
+// an entity's image is measured against usage without the image being
+// replaced.
+func (s *ImageAssetStore) Usage(ctx context.Context, ownerUserID string, excludeType models.ImageEntityType, excludeEntityID string) (int, int64, error) {
+	var count int
+	var bytes int64
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(COALESCE(archive_size, byte_size)), 0)
+		FROM image_assets
+		WHERE owner_user_id = $1
+		  AND entity_type <> $4
+		  AND NOT ($3 <> '' AND entity_type = $2 AND entity_id::text = $3)
+	`, ownerUserID, string(excludeType), excludeEntityID, string(models.ImageEntityGear)).Scan(&count, &bytes)
+	if err != nil {
+		return 0, 0, fmt.Errorf("get image usage: %w", err)
+	}
+	return count, bytes, nil
+}
+
+// GetQuotaOverride returns the user's quota override, or nil if none is set.
+func (s *ImageAssetStore) GetQuotaOverride(ctx context.Context, userID string) (*models.ImageQuotaOverride, error) {
+	var override models.ImageQuotaOverride
+	var maxCount sql.NullInt64
+	var maxBytes sql.NullInt64
+	var updatedBy sql.NullString
+	err := s.db.QueryRowContext(ctx, `
+		SELECT user_id, max_count, max_bytes, updated_by, updated_at
+		FROM image_quota_overrides
+		WHERE user_id = $1
+	`, userID).Scan(&override.UserID, &maxCount, &maxBytes, &updatedBy, &override.UpdatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
-		return nil, fmt.Errorf("load image asset: %w", err)
+		return nil, fmt.Errorf("get image quota override: %w", err)
 	}
 
-	asset.Status = models.ImageModerationStatus(status)
-	if scanEntityID.Valid {
-		asset.EntityID = scanEntityID.String
+	if maxCount.Valid {
+		v := int(maxCount.Int64)
+		override.MaxCount = &v
+	}
+	if maxBytes.Valid {
+		v := maxBytes.Int64
+		override.MaxBytes = &v
 	}
+	override.UpdatedBy = updatedBy.String
+	return &override, nil
+}
 
-	return &asset, nil
+// SetQuotaOverride creates or replaces the user's quota override.
+func (s *ImageAssetStore) SetQuotaOverride(ctx context.Context, userID, adminUserID string, params models.SetImageQuotaOverrideParams) (*models.ImageQuotaOverride, error) {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO image_quota_overrides (user_id, max_count, max_bytes, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			max_count = EXCLUDED.max_count,
+			max_bytes = EXCLUDED.max_bytes,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+	`, userID, params.MaxCount, params.MaxBytes, nullString(adminUserID))
+	if err != nil {
+		return nil, fmt.Errorf("set image quota override: %w", err)
+	}
+	return s.GetQuotaOverride(ctx, userID)
 }
 
-// Delete removes an image asset by ID.
-func (s *ImageAssetStore) Delete(ctx context.Context, imageID string) error {
-	_, err := s.db.ExecContext(ctx, `DELETE FROM image_assets WHERE id = $1`, imageID)
+// DeleteQuotaOverride removes the user's quota override, restoring the default.
+func (s *ImageAssetStore) DeleteQuotaOverride(ctx context.Context, userID string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM image_quota_overrides WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("delete image quota override: %w", err)
+	}
+	return nil
+}
+
+// ListApprovedForRescan returns approved image assets ordered by ID, starting
+// after afterID. An empty entityType includes every entity type. Archived
+// images were scanned before they were archived and are skipped.
+func (s *ImageAssetStore) ListApprovedForRescan(ctx context.Context, afterID string, entityType models.ImageEntityType, limit int) ([]models.ImageAsset, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, owner_user_id, entity_type, COALESCE(entity_id::text, ''), image_bytes
+		FROM image_assets
+		WHERE status = $1
+		  AND storage_tier = 'hot'
+		  AND ($2 = '' OR id > $2::uuid)
+		  AND ($3 = '' OR entity_type = $3)
+		ORDER BY id
+		LIMIT $4
+	`, string(models.ImageModerationApproved), afterID, string(entityType), limit)
 	if err != nil {
-		return fmt.Errorf("delete image asset: %w", err)
+		return nil, fmt.Errorf("list images for rescan: %w", err)
+	}
+	defer rows.Close()
+
+	assets := make([]models.ImageAsset, 0, limit)
+	for rows.Next() {
+		var asset models.ImageAsset
+		if err := rows.Scan(&asset.ID, &asset.OwnerUserID, &asset.EntityType, &asset.EntityID, &asset.ImageBytes); err != nil {
+			return nil, fmt.Errorf("scan image for rescan: %w", err)
+		}
+		asset.Status = models.ImageModerationApproved
+		assets = append(assets, asset)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list images for rescan: %w", err)
+	}
+	return assets, nil
+}
+
+// RecordRescan stores a re-scan decision for an approved image. Images that no
+// longer pass are moved to the review queue rather than deleted. Images whose
+// status changed since they were listed are left alone.
+func (s *ImageAssetStore) RecordRescan(ctx context.Context, imageID string, decision models.ModerationDecision) error {
+	labelsJSON, err := json.Marshal(decision.Labels)
+	if err != nil {
+		return fmt.Errorf("marshal moderation labels: %w", err)
+	}
+
+	status := models.ImageModerationApproved
+	if decision.Status != models.ImageModerationApproved {
+		status = models.ImageModerationPendingReview
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		UPDATE image_assets
+		SET status = $2,
+			moderation_labels = $3,
+			moderation_max_confidence = $4,
+			flagged_at = CASE WHEN $2 = 'PENDING_REVIEW' THEN NOW() ELSE flagged_at END,
+			rescanned_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1 AND status = 'APPROVED'
+	`, imageID, string(status), labelsJSON, decision.MaxConfidence)
+	if err != nil {
+		return fmt.Errorf("record image rescan: %w", err)
 	}
 	return nil
 }
+
+// ListPendingReview returns flagged images awaiting review, oldest first.
+func (s *ImageAssetStore) ListPendingReview(ctx context.Context, limit, offset int) (*models.ImageReviewListResponse, error) {
+	var total int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM image_assets WHERE status = 'PENDING_REVIEW'`).Scan(&total); err != nil {
+		return nil, fmt.Errorf("count images pending review: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, owner_user_id, entity_type, COALESCE(entity_id::text, ''), moderation_labels,
+			moderation_max_confidence, byte_size, flagged_at, created_at
+		FROM image_assets
+		WHERE status = 'PENDING_REVIEW'
+		ORDER BY flagged_at NULLS FIRST, id
+		LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("list images pending review: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]models.ImageReviewItem, 0)
+	for rows.Next() {
+		var item models.ImageReviewItem
+		var labelsJSON []byte
+		var flaggedAt sql.NullTime
+		if err := rows.Scan(
+			&item.ID, &item.OwnerUserID, &item.EntityType, &item.EntityID, &labelsJSON,
+			&item.ModerationMaxConfidence, &item.ByteSize, &flaggedAt, &item.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan image pending review: %w", err)
+		}
+		if err := json.Unmarshal(labelsJSON, &item.ModerationLabels); err != nil || item.ModerationLabels == nil {
+			item.ModerationLabels = []models.ModerationLabel{}
+		}
+		if flaggedAt.Valid {
+			item.FlaggedAt = &flaggedAt.Time
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list images pending review: %w", err)
+	}
+
+	return &models.ImageReviewListResponse{Items: items, TotalCount: total}, nil
+}
+
+// ApproveReview restores a flagged image. Returns false if the image isn't in
+// the review queue.
+func (s *ImageAssetStore) ApproveReview(ctx context.Context, imageID string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE image_assets
+		SET status = 'APPROVED', flagged_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND status = 'PENDING_REVIEW'
+	`, imageID)
+	if err != nil {
+		return false, fmt.Errorf("approve image review: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// RejectReview deletes a flagged image; entities referencing it lose their
+// image. Returns false if the image isn't in the review queue.
+func (s *ImageAssetStore) RejectReview(ctx context.Context, imageID string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM image_assets WHERE id = $1 AND status = 'PENDING_REVIEW'`, imageID)
+	if err != nil {
+		return false, fmt.Errorf("reject image review: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
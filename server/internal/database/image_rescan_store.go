@@ -0,0 +1,134 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// rescanStaleAfter is how long a running job may go without progress before
+// it is considered abandoned (e.g. its server instance stopped).
+const rescanStaleAfter = 10 * time.Minute
+
+const imageRescanColumns = `id, status, entity_type, COALESCE(started_by::text, ''), COALESCE(cursor_id::text, ''),
+	scanned, flagged, errors, last_error, started_at, updated_at, finished_at`
+
+// ImageRescanStore persists moderation re-scan jobs
+type ImageRescanStore struct {
+	db *DB
+}
+
+// NewImageRescanStore creates a new image re-scan job store
+func NewImageRescanStore(db *DB) *ImageRescanStore {
+	return &ImageRescanStore{db: db}
+}
+
+// CreateJob starts a new running job. Returns nil without an error if another
+// job is already running. Running jobs that stopped reporting progress are
+// marked interrupted first.
+func (s *ImageRescanStore) CreateJob(ctx context.Context, startedBy string, entityType models.ImageEntityType) (*models.ImageRescanJob, error) {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE image_rescan_jobs
+		SET status = $1, finished_at = NOW()
+		WHERE status = $2 AND updated_at < $3
+	`, models.ImageRescanInterrupted, models.ImageRescanRunning, time.Now().Add(-rescanStaleAfter))
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire stale rescan jobs: %w", err)
+	}
+
+	query := `
+		INSERT INTO image_rescan_jobs (status, entity_type, started_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+		RETURNING ` + imageRescanColumns
+
+	job, err := scanImageRescanJob(s.db.QueryRowContext(ctx, query, models.ImageRescanRunning, string(entityType), nullString(startedBy)))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rescan job: %w", err)
+	}
+	return job, nil
+}
+
+// SaveProgress records a running job's counters and cursor. Returns false if
+// the job is no longer running (e.g. it was cancelled).
+func (s *ImageRescanStore) SaveProgress(ctx context.Context, job *models.ImageRescanJob) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE image_rescan_jobs
+		SET cursor_id = NULLIF($2, '')::uuid, scanned = $3, flagged = $4, errors = $5, last_error = $6, updated_at = NOW()
+		WHERE id = $1 AND status = $7
+	`, job.ID, job.CursorID, job.Scanned, job.Flagged, job.Errors, job.LastError, models.ImageRescanRunning)
+	if err != nil {
+		return false, fmt.Errorf("failed to save rescan progress: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+// FinishJob moves a running job to a final status
+func (s *ImageRescanStore) FinishJob(ctx context.Context, id string, status models.ImageRescanStatus, lastError string) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE image_rescan_jobs
+		SET status = $2, last_error = CASE WHEN $3 = '' THEN last_error ELSE $3 END, updated_at = NOW(), finished_at = NOW()
+		WHERE id = $1 AND status = $4
+	`, id, status, lastError, models.ImageRescanRunning)
+	if err != nil {
+		return fmt.Errorf("failed to finish rescan job: %w", err)
+	}
+	return nil
+}
+
+// CancelRunning cancels the running job, if any, and returns it
+func (s *ImageRescanStore) CancelRunning(ctx context.Context) (*models.ImageRescanJob, error) {
+	query := `
+		UPDATE image_rescan_jobs
+		SET status = $1, updated_at = NOW(), finished_at = NOW()
+		WHERE status = $2
+		RETURNING ` + imageRescanColumns
+
+	job, err := scanImageRescanJob(s.db.QueryRowContext(ctx, query, models.ImageRescanCancelled, models.ImageRescanRunning))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel rescan job: %w", err)
+	}
+	return job, nil
+}
+
+// LatestJob returns the most recently started job
+func (s *ImageRescanStore) LatestJob(ctx context.Context) (*models.ImageRescanJob, error) {
+	query := `SELECT ` + imageRescanColumns + ` FROM image_rescan_jobs ORDER BY started_at DESC LIMIT 1`
+
+	job, err := scanImageRescanJob(s.db.QueryRowContext(ctx, query))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest rescan job: %w", err)
+	}
+	return job, nil
+}
+
+func scanImageRescanJob(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.ImageRescanJob, error) {
+	var job models.ImageRescanJob
+	var finishedAt sql.NullTime
+
+	if err := scanner.Scan(
+		&job.ID, &job.Status, &job.EntityType, &job.StartedByUserID, &job.CursorID,
+		&job.Scanned, &job.Flagged, &job.Errors, &job.LastError, &job.StartedAt, &job.UpdatedAt, &finishedAt,
+	); err != nil {
+		return nil, err
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+	return &job, nil
+}
@@ -0,0 +1,183 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// InactivityStore persists the inactive account cleanup policy and finds
+// the accounts it applies to
+type InactivityStore struct {
+	db *DB
+}
+
+// NewInactivityStore creates a new inactivity store
+func NewInactivityStore(db *DB) *InactivityStore {
+	return &InactivityStore{db: db}
+}
+
+// lastActivitySQL is when a user last signed in or refreshed a session.
+// Users who stay signed in through refresh tokens never log in again.
+const lastActivitySQL = `GREATEST(
+	COALESCE(u.last_login_at, u.created_at),
+	COALESCE((SELECT MAX(rt.created_at) FROM refresh_tokens rt WHERE rt.user_id = u.id), u.created_at)
+)`
+
+// inactiveCandidateSQL limits a query to active, non-staff users idle since $1
+const inactiveCandidateSQL = `u.status = 'active'
+	AND NOT COALESCE(u.is_admin, FALSE)
+	AND NOT COALESCE(u.is_content_admin, u.is_gear_admin, FALSE)
+	AND ` + lastActivitySQL + ` < $1`
+
+// publishedExemptionSQL excludes users with published content
+const publishedExemptionSQL = `
+	AND NOT EXISTS (SELECT 1 FROM builds b WHERE b.owner_user_id = u.id AND b.status = 'PUBLISHED')
+	AND NOT EXISTS (SELECT 1 FROM gear_catalog gc WHERE gc.created_by_user_id = u.id AND gc.status = 'published')`
+
+// inactiveDisableReason is shown to users the cleanup job disabled when they
+// next try to sign in
+const inactiveDisableReason = "Disabled after a long period of inactivity. Submit an appeal to reactivate the account."
+
+// GetPolicy returns the saved policy, or nil when none has been saved
+func (s *InactivityStore) GetPolicy(ctx context.Context) (*models.InactivityPolicy, error) {
+	var policy models.InactivityPolicy
+	var updatedAt time.Time
+	err := s.db.QueryRowContext(ctx, `
+		SELECT enabled, inactive_months, grace_days, action, exempt_published, COALESCE(updated_by::text, ''), updated_at
+		FROM inactivity_policy
+	`).Scan(&policy.Enabled, &policy.InactiveMonths, &policy.GraceDays, &policy.Action, &policy.ExemptPublished, &policy.UpdatedBy, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inactivity policy: %w", err)
+	}
+	policy.UpdatedAt = &updatedAt
+	return &policy, nil
+}
+
+// SavePolicy creates or replaces the policy
+func (s *InactivityStore) SavePolicy(ctx context.Context, adminUserID string, policy models.InactivityPolicy) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO inactivity_policy (id, enabled, inactive_months, grace_days, action, exempt_published, updated_by, updated_at)
+		VALUES (TRUE, $1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			inactive_months = EXCLUDED.inactive_months,
+			grace_days = EXCLUDED.grace_days,
+			action = EXCLUDED.action,
+			exempt_published = EXCLUDED.exempt_published,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+	`, policy.Enabled, policy.InactiveMonths, policy.GraceDays, policy.Action, policy.ExemptPublished, nullString(adminUserID))
+	if err != nil {
+		return fmt.Errorf("failed to save inactivity policy: %w", err)
+	}
+	return nil
+}
+
+// ListUnflagged returns up to limit accounts idle since cutoff that haven't
+// been flagged yet, longest idle first
+func (s *InactivityStore) ListUnflagged(ctx context.Context, cutoff time.Time, exemptPublished bool, limit int) ([]models.InactiveAccount, error) {
+	var query strings.Builder
+	query.WriteString(`SELECT u.id, u.email, u.display_name, u.last_login_at, u.created_at, u.inactive_flagged_at
+		FROM users u
+		WHERE u.inactive_flagged_at IS NULL AND ` + inactiveCandidateSQL)
+	if exemptPublished {
+		query.WriteString(publishedExemptionSQL)
+	}
+	query.WriteString(`
+		ORDER BY ` + lastActivitySQL + `
+		LIMIT $2`)
+	return s.listAccounts(ctx, query.String(), cutoff, limit)
+}
+
+// ListGraceExpired returns up to limit accounts flagged at or before
+// flaggedBefore that are still idle since cutoff
+func (s *InactivityStore) ListGraceExpired(ctx context.Context, cutoff, flaggedBefore time.Time, exemptPublished bool, limit int) ([]models.InactiveAccount, error) {
+	var query strings.Builder
+	query.WriteString(`SELECT u.id, u.email, u.display_name, u.last_login_at, u.created_at, u.inactive_flagged_at
+		FROM users u
+		WHERE u.inactive_flagged_at <= $3 AND ` + inactiveCandidateSQL)
+	if exemptPublished {
+		query.WriteString(publishedExemptionSQL)
+	}
+	query.WriteString(`
+		ORDER BY u.inactive_flagged_at
+		LIMIT $2`)
+	return s.listAccounts(ctx, query.String(), cutoff, limit, flaggedBefore)
+}
+
+func (s *InactivityStore) listAccounts(ctx context.Context, query string, args ...interface{}) ([]models.InactiveAccount, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inactive accounts: %w", err)
+	}
+	defer rows.Close()
+
+	accounts := make([]models.InactiveAccount, 0)
+	for rows.Next() {
+		var account models.InactiveAccount
+		var lastLoginAt, flaggedAt sql.NullTime
+		if err := rows.Scan(&account.UserID, &account.Email, &account.DisplayName, &lastLoginAt, &account.CreatedAt, &flaggedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan inactive account: %w", err)
+		}
+		if lastLoginAt.Valid {
+			account.LastLoginAt = &lastLoginAt.Time
+		}
+		if flaggedAt.Valid {
+			account.FlaggedAt = &flaggedAt.Time
+		}
+		accounts = append(accounts, account)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list inactive accounts: %w", err)
+	}
+	return accounts, nil
+}
+
+// Flag marks an account as notified of its inactivity
+func (s *InactivityStore) Flag(ctx context.Context, userID string, at time.Time) error {
+	if _, err := s.db.ExecContext(ctx, `UPDATE users SET inactive_flagged_at = $2 WHERE id = $1`, userID, at); err != nil {
+		return fmt.Errorf("failed to flag inactive account: %w", err)
+	}
+	return nil
+}
+
+// ClearReturned unflags accounts that have been active since they were
+// flagged, returning how many were cleared. Logging in clears the flag
+// directly; this catches sessions kept alive by refresh tokens.
+func (s *InactivityStore) ClearReturned(ctx context.Context) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE users u SET inactive_flagged_at = NULL
+		WHERE u.inactive_flagged_at IS NOT NULL AND `+lastActivitySQL+` > u.inactive_flagged_at
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear returned accounts: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// Disable disables an account and signs it out everywhere
+func (s *InactivityStore) Disable(ctx context.Context, userID string) error {
+	return s.db.RunInTx(ctx, func(ctx context.Context) error {
+		if _, err := s.db.ExecContext(ctx, `
+			UPDATE users
+			SET status = 'disabled', status_reason = $2, status_expires_at = NULL, updated_at = NOW()
+			WHERE id = $1
+		`, userID, inactiveDisableReason); err != nil {
+			return fmt.Errorf("failed to disable inactive account: %w", err)
+		}
+		return NewUserStore(s.db).RevokeAllUserRefreshTokens(ctx, userID)
+	})
+}
+
+// Delete permanently removes an account and its data
+func (s *InactivityStore) Delete(ctx context.Context, userID string) error {
+	return NewUserStore(s.db).HardDelete(ctx, userID)
+}
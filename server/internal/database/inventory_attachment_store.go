@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// InventoryAttachmentStore handles files attached to inventory items
+type InventoryAttachmentStore struct {
+	db *DB
+}
+
+// NewInventoryAttachmentStore creates a new inventory attachment store
+func NewInventoryAttachmentStore(db *DB) *InventoryAttachmentStore {
+	return &InventoryAttachmentStore{db: db}
+}
+
+// List returns an item's attachments without file bytes, oldest first
+func (s *InventoryAttachmentStore) List(ctx context.Context, itemID, userID string) ([]models.InventoryAttachment, error) {
+	query := `
+		SELECT id, inventory_item_id, kind, file_name, content_type, byte_size, created_at
+		FROM inventory_attachments
+		WHERE inventory_item_id = $1 AND user_id = $2
+		ORDER BY created_at, id`
+
+	rows, err := s.db.QueryContext(ctx, query, itemID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inventory attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := make([]models.InventoryAttachment, 0)
+	for rows.Next() {
+		var attachment models.InventoryAttachment
+		if err := rows.Scan(
+			&attachment.ID, &attachment.InventoryItemID, &attachment.Kind, &attachment.FileName,
+			&attachment.ContentType, &attachment.ByteSize, &attachment.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan inventory attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list inventory attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+// Add stores an attachment on an item the user owns. Returns nil if the item
+// is not found.
+func (s *InventoryAttachmentStore) Add(ctx context.Context, userID, contentType string, params models.AddInventoryAttachmentParams) (*models.InventoryAttachment, error) {
+	query := `
+		INSERT INTO inventory_attachments (inventory_item_id, user_id, kind, file_name, content_type, file_bytes)
+		SELECT id, user_id, $3, $4, $5, $6
+		FROM inventory_items
+		WHERE id = $1 AND user_id = $2
+		RETURNING id, inventory_item_id, kind, file_name, content_type, byte_size, created_at`
+
+	attachment := &models.InventoryAttachment{}
+	err := s.db.QueryRowContext(ctx, query,
+		params.InventoryItemID, userID, string(params.Kind), params.FileName, contentType, params.FileBytes,
+	).Scan(
+		&attachment.ID, &attachment.InventoryItemID, &attachment.Kind, &attachment.FileName,
+		&attachment.ContentType, &attachment.ByteSize, &attachment.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to add inventory attachment: %w", err)
+	}
+	return attachment, nil
+}
+
+// Get returns an attachment with its file bytes. Returns nil if not found.
+func (s *InventoryAttachmentStore) Get(ctx context.Context, id, itemID, userID string) (*models.InventoryAttachment, error) {
+	query := `
+		SELECT id, inventory_item_id, kind, file_name, content_type, byte_size, created_at, file_bytes
+		FROM inventory_attachments
+		WHERE id = $1 AND inventory_item_id = $2 AND user_id = $3`
+
+	attachment := &models.InventoryAttachment{}
+	err := s.db.QueryRowContext(ctx, query, id, itemID, userID).Scan(
+		&attachment.ID, &attachment.InventoryItemID, &attachment.Kind, &attachment.FileName,
+		&attachment.ContentType, &attachment.ByteSize, &attachment.CreatedAt, &attachment.FileBytes,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory attachment: %w", err)
+	}
+	return attachment, nil
+}
+
+// Delete removes an attachment. Returns false if it was not found.
+func (s *InventoryAttachmentStore) Delete(ctx context.Context, id, itemID, userID string) (bool, error) {
+	query := `DELETE FROM inventory_attachments WHERE id = $1 AND inventory_item_id = $2 AND user_id = $3`
+
+	result, err := s.db.ExecContext(ctx, query, id, itemID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete inventory attachment: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete inventory attachment: %w", err)
+	}
+	return rows > 0, nil
+}
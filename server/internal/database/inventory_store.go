@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/lib/pq"
 )
 
 // InventoryStore handles inventory database operations
@@ -20,6 +21,17 @@ func NewInventoryStore(db *DB) *InventoryStore {
 	return &InventoryStore{db: db}
 }
 
+// catalogDisplayMismatch is true when the name ($2) or manufacturer ($4) of
+// an item being added differ from its catalog item ($13), so the user's
+// choice survives catalog syncs. The expected values match
+// inventoryDisplayDrift.
+const catalogDisplayMismatch = `EXISTS (
+	SELECT 1 FROM gear_catalog gc
+	WHERE gc.id = $13
+	  AND ($2 <> TRIM(gc.brand || ' ' || gc.model || COALESCE(' ' || NULLIF(gc.variant, ''), ''))
+	       OR $4 <> gc.brand)
+)`
+
 // Add creates a new inventory item
 func (s *InventoryStore) Add(ctx context.Context, userID string, params models.AddInventoryParams) (*models.InventoryItem, error) {
 	specs := params.Specs
@@ -36,8 +48,8 @@ func (s *InventoryStore) Add(ctx context.Context, userID string, params models.A
 		INSERT INTO inventory_items (
 			user_id, name, category, manufacturer, quantity, notes,
 			build_id, purchase_price, purchase_seller,
-			product_url, specs, source_equipment_id, catalog_id
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			product_url, specs, source_equipment_id, catalog_id, display_overridden
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, ` + catalogDisplayMismatch + `)
 		RETURNING id, created_at, updated_at
 	`
 
@@ -88,16 +100,19 @@ func (s *InventoryStore) AddOrIncrement(ctx context.Context, userID string, para
 		quantity = 1
 	}
 
-	// UPSERT: insert if not exists, otherwise increment quantity
+	// UPSERT: insert if not exists, otherwise increment quantity. An archived
+	// item is restored with the new quantity instead, keeping its history.
 	// The ON CONFLICT predicate must match the partial unique index exactly
 	query := `
 		INSERT INTO inventory_items (
 			user_id, name, category, manufacturer, quantity, notes,
 			build_id, purchase_price, purchase_seller,
-			product_url, specs, source_equipment_id, catalog_id
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			product_url, specs, source_equipment_id, catalog_id, display_overridden
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, ` + catalogDisplayMismatch + `)
 		ON CONFLICT (user_id, catalog_id) WHERE user_id IS NOT NULL AND catalog_id IS NOT NULL
-		DO UPDATE SET quantity = inventory_items.quantity + EXCLUDED.quantity, updated_at = NOW()
+		DO UPDATE SET
+			quantity = CASE WHEN inventory_items.archived_at IS NULL THEN inventory_items.quantity + EXCLUDED.quantity ELSE EXCLUDED.quantity END,
+			archived_at = NULL, updated_at = NOW()
 		RETURNING id, user_id, name, category, manufacturer, quantity, notes,
 			build_id, purchase_price, purchase_seller,
 			product_url, specs, source_equipment_id, catalog_id, created_at, updated_at
@@ -153,7 +168,7 @@ func (s *InventoryStore) Get(ctx context.Context, id string, userID string) (*mo
 			             THEN '/api/gear-catalog/' || gc.id || '/image?v=' || (EXTRACT(EPOCH FROM COALESCE(gc.image_curated_at, gc.updated_at))*1000)::bigint
 			        ELSE NULL
 			   END as image_url,
-			   i.specs, i.source_equipment_id, i.catalog_id, i.created_at, i.updated_at
+			   i.specs, i.source_equipment_id, i.catalog_id, i.created_at, i.updated_at, i.archived_at
 		FROM inventory_items i
 		LEFT JOIN gear_catalog gc ON i.catalog_id = gc.id
 		WHERE i.id = $1
@@ -172,7 +187,7 @@ func (s *InventoryStore) Get(ctx context.Context, id string, userID string) (*mo
 				             THEN '/api/gear-catalog/' || gc.id || '/image?v=' || (EXTRACT(EPOCH FROM COALESCE(gc.image_curated_at, gc.updated_at))*1000)::bigint
 				        ELSE NULL
 				   END as image_url,
-				   i.specs, i.source_equipment_id, i.catalog_id, i.created_at, i.updated_at
+				   i.specs, i.source_equipment_id, i.catalog_id, i.created_at, i.updated_at, i.archived_at
 			FROM inventory_items i
 			LEFT JOIN gear_catalog gc ON i.catalog_id = gc.id
 			WHERE i.id = $1 AND i.user_id = $2
@@ -184,13 +199,14 @@ func (s *InventoryStore) Get(ctx context.Context, id string, userID string) (*mo
 	var itemUserID sql.NullString
 	var buildID, purchaseSeller, productURL, imageURL, sourceEquipmentID, catalogID sql.NullString
 	var purchasePrice sql.NullFloat64
+	var archivedAt sql.NullTime
 
 	err := s.db.QueryRowContext(ctx, query, args...).Scan(
 		&item.ID, &itemUserID, &item.Name, &item.Category, &item.Manufacturer,
 		&item.Quantity, &item.Notes,
 		&buildID, &purchasePrice, &purchaseSeller,
 		&productURL, &imageURL, &item.Specs, &sourceEquipmentID, &catalogID,
-		&item.CreatedAt, &item.UpdatedAt,
+		&item.CreatedAt, &item.UpdatedAt, &archivedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -209,6 +225,7 @@ func (s *InventoryStore) Get(ctx context.Context, id string, userID string) (*mo
 	item.ImageURL = imageURL.String
 	item.SourceEquipmentID = sourceEquipmentID.String
 	item.CatalogID = catalogID.String
+	setArchived(item, archivedAt)
 
 	if purchasePrice.Valid {
 		item.PurchasePrice = &purchasePrice.Float64
@@ -231,6 +248,14 @@ func (s *InventoryStore) List(ctx context.Context, userID string, params models.
 		argIndex++
 	}
 
+	switch params.Status {
+	case models.InventoryStatusArchived:
+		conditions = append(conditions, "i.archived_at IS NOT NULL")
+	case models.InventoryStatusAll:
+	default:
+		conditions = append(conditions, "i.archived_at IS NULL")
+	}
+
 	if params.Category != "" {
 		conditions = append(conditions, fmt.Sprintf("i.category = $%d", argIndex))
 		args = append(args, params.Category)
@@ -281,7 +306,7 @@ func (s *InventoryStore) List(ctx context.Context, userID string, params models.
 			             THEN '/api/gear-catalog/' || gc.id || '/image?v=' || (EXTRACT(EPOCH FROM COALESCE(gc.image_curated_at, gc.updated_at))*1000)::bigint
 			        ELSE NULL
 			   END as image_url,
-			   i.specs, i.source_equipment_id, i.catalog_id, i.created_at, i.updated_at
+			   i.specs, i.source_equipment_id, i.catalog_id, i.created_at, i.updated_at, i.archived_at
 		FROM inventory_items i
 		LEFT JOIN gear_catalog gc ON i.catalog_id = gc.id
 		%s
@@ -304,13 +329,14 @@ func (s *InventoryStore) List(ctx context.Context, userID string, params models.
 		var item models.InventoryItem
 		var buildID, purchaseSeller, productURL, imageURL, sourceEquipmentID, catalogID sql.NullString
 		var purchasePrice sql.NullFloat64
+		var archivedAt sql.NullTime
 
 		if err := rows.Scan(
 			&item.ID, &item.UserID, &item.Name, &item.Category, &item.Manufacturer,
 			&item.Quantity, &item.Notes,
 			&buildID, &purchasePrice, &purchaseSeller,
 			&productURL, &imageURL, &item.Specs, &sourceEquipmentID, &catalogID,
-			&item.CreatedAt, &item.UpdatedAt,
+			&item.CreatedAt, &item.UpdatedAt, &archivedAt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan inventory item: %w", err)
 		}
@@ -321,6 +347,7 @@ func (s *InventoryStore) List(ctx context.Context, userID string, params models.
 		item.ImageURL = imageURL.String
 		item.SourceEquipmentID = sourceEquipmentID.String
 		item.CatalogID = catalogID.String
+		setArchived(&item, archivedAt)
 
 		if purchasePrice.Valid {
 			item.PurchasePrice = &purchasePrice.Float64
@@ -344,8 +371,13 @@ func (s *InventoryStore) Update(ctx context.Context, userID string, params model
 	var args []interface{}
 	argIndex := 1
 
+	// A changed name or manufacturer stops catalog syncs overwriting it.
+	// SET expressions see the old row, so resending the same value doesn't
+	// count as a change.
+	var overrides []string
 	if params.Name != nil {
 		sets = append(sets, fmt.Sprintf("name = $%d", argIndex))
+		overrides = append(overrides, fmt.Sprintf("name <> $%d", argIndex))
 		args = append(args, *params.Name)
 		argIndex++
 	}
@@ -358,9 +390,13 @@ func (s *InventoryStore) Update(ctx context.Context, userID string, params model
 
 	if params.Manufacturer != nil {
 		sets = append(sets, fmt.Sprintf("manufacturer = $%d", argIndex))
+		overrides = append(overrides, fmt.Sprintf("COALESCE(manufacturer, '') <> $%d", argIndex))
 		args = append(args, *params.Manufacturer)
 		argIndex++
 	}
+	if len(overrides) > 0 {
+		sets = append(sets, "display_overridden = display_overridden OR "+strings.Join(overrides, " OR "))
+	}
 
 	if params.Quantity != nil {
 		sets = append(sets, fmt.Sprintf("quantity = $%d", argIndex))
@@ -462,17 +498,17 @@ func (s *InventoryStore) Delete(ctx context.Context, id string, userID string) e
 	return nil
 }
 
-// GetSummary returns a summary of the inventory (scoped to user if userID provided)
+// GetSummary returns a summary of the active inventory (scoped to user if userID provided)
 func (s *InventoryStore) GetSummary(ctx context.Context, userID string) (*models.InventorySummary, error) {
 	// Get total items and value
 	var totalItems int
 	var totalValue sql.NullFloat64
 
-	query := `SELECT COUNT(*), COALESCE(SUM(purchase_price * quantity), 0) FROM inventory_items`
+	query := `SELECT COUNT(*), COALESCE(SUM(purchase_price * quantity), 0) FROM inventory_items WHERE archived_at IS NULL`
 	args := []interface{}{}
 
 	if userID != "" {
-		query += " WHERE user_id = $1"
+		query += " AND user_id = $1"
 		args = append(args, userID)
 	}
 
@@ -484,9 +520,9 @@ func (s *InventoryStore) GetSummary(ctx context.Context, userID string) (*models
 	// Get counts by category
 	byCategory := make(map[models.EquipmentCategory]int)
 
-	categoryQuery := `SELECT category, COUNT(*) FROM inventory_items`
+	categoryQuery := `SELECT category, COUNT(*) FROM inventory_items WHERE archived_at IS NULL`
 	if userID != "" {
-		categoryQuery += " WHERE user_id = $1"
+		categoryQuery += " AND user_id = $1"
 	}
 	categoryQuery += " GROUP BY category"
 
@@ -528,7 +564,7 @@ func (s *InventoryStore) GetByCatalogID(ctx context.Context, userID, catalogID s
 			             THEN '/api/gear-catalog/' || gc.id || '/image?v=' || (EXTRACT(EPOCH FROM COALESCE(gc.image_curated_at, gc.updated_at))*1000)::bigint
 			        ELSE NULL
 			   END as image_url,
-			   i.specs, i.source_equipment_id, i.catalog_id, i.created_at, i.updated_at
+			   i.specs, i.source_equipment_id, i.catalog_id, i.created_at, i.updated_at, i.archived_at
 		FROM inventory_items i
 		LEFT JOIN gear_catalog gc ON i.catalog_id = gc.id
 		WHERE i.user_id = $1 AND i.catalog_id = $2
@@ -540,13 +576,14 @@ func (s *InventoryStore) GetByCatalogID(ctx context.Context, userID, catalogID s
 	var itemUserID sql.NullString
 	var buildID, purchaseSeller, productURL, imageURL, sourceEquipmentID, itemCatalogID sql.NullString
 	var purchasePrice sql.NullFloat64
+	var archivedAt sql.NullTime
 
 	err := s.db.QueryRowContext(ctx, query, userID, catalogID).Scan(
 		&item.ID, &itemUserID, &item.Name, &item.Category, &item.Manufacturer,
 		&item.Quantity, &item.Notes,
 		&buildID, &purchasePrice, &purchaseSeller,
 		&productURL, &imageURL, &item.Specs, &sourceEquipmentID, &itemCatalogID,
-		&item.CreatedAt, &item.UpdatedAt,
+		&item.CreatedAt, &item.UpdatedAt, &archivedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -565,6 +602,7 @@ func (s *InventoryStore) GetByCatalogID(ctx context.Context, userID, catalogID s
 	item.ImageURL = imageURL.String
 	item.SourceEquipmentID = sourceEquipmentID.String
 	item.CatalogID = itemCatalogID.String
+	setArchived(item, archivedAt)
 
 	if purchasePrice.Valid {
 		item.PurchasePrice = &purchasePrice.Float64
@@ -573,6 +611,71 @@ func (s *InventoryStore) GetByCatalogID(ctx context.Context, userID, catalogID s
 	return item, nil
 }
 
+// QuantitiesByCatalogIDs returns how many of each catalog item a user has in
+// inventory, keyed by catalog ID. Items the user doesn't own or has archived
+// are left out.
+func (s *InventoryStore) QuantitiesByCatalogIDs(ctx context.Context, userID string, catalogIDs []string) (map[string]int, error) {
+	quantities := make(map[string]int)
+	if len(catalogIDs) == 0 {
+		return quantities, nil
+	}
+
+	query := `
+		SELECT catalog_id, SUM(quantity)
+		FROM inventory_items
+		WHERE user_id = $1 AND catalog_id = ANY($2::uuid[]) AND archived_at IS NULL
+		GROUP BY catalog_id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, userID, pq.Array(catalogIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count inventory by catalog ID: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var catalogID string
+		var quantity int
+		if err := rows.Scan(&catalogID, &quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan inventory quantity: %w", err)
+		}
+		quantities[catalogID] = quantity
+	}
+	return quantities, rows.Err()
+}
+
+// CatalogItemsForUser returns the distinct catalog items behind a user's
+// active inventory, including parts installed on their aircraft. Items
+// without a catalog link are left out.
+func (s *InventoryStore) CatalogItemsForUser(ctx context.Context, userID string) ([]models.GearCatalogItem, error) {
+	query := `
+		SELECT DISTINCT gc.id, gc.gear_type, gc.brand, gc.model, COALESCE(gc.variant, ''), gc.specs
+		FROM inventory_items i
+		JOIN gear_catalog gc ON i.catalog_id = gc.id
+		WHERE i.user_id = $1 AND i.archived_at IS NULL
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inventory catalog items: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]models.GearCatalogItem, 0)
+	for rows.Next() {
+		var item models.GearCatalogItem
+		var specs []byte
+		if err := rows.Scan(&item.ID, &item.GearType, &item.Brand, &item.Model, &item.Variant, &specs); err != nil {
+			return nil, fmt.Errorf("failed to scan inventory catalog item: %w", err)
+		}
+		if len(specs) > 0 {
+			item.Specs = specs
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
 // IncrementQuantity increases the quantity of an existing inventory item
 func (s *InventoryStore) IncrementQuantity(ctx context.Context, id string, userID string, amount int) (*models.InventoryItem, error) {
 	if amount <= 0 {
@@ -598,6 +701,37 @@ func (s *InventoryStore) IncrementQuantity(ctx context.Context, id string, userI
 	return s.Get(ctx, id, userID)
 }
 
+// SetArchived archives or restores an inventory item. Archived items keep
+// their history and anything referencing them, but are hidden from the
+// inventory list and summary by default.
+func (s *InventoryStore) SetArchived(ctx context.Context, id string, userID string, archived bool) (*models.InventoryItem, error) {
+	query := `
+		UPDATE inventory_items
+		SET archived_at = CASE WHEN $1 THEN COALESCE(archived_at, NOW()) END, updated_at = NOW()
+		WHERE id = $2 AND user_id = $3
+		RETURNING id
+	`
+
+	var returnedID string
+	err := s.db.QueryRowContext(ctx, query, archived, id, userID).Scan(&returnedID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive inventory item: %w", err)
+	}
+
+	return s.Get(ctx, id, userID)
+}
+
+// setArchived fills an item's archived state from its archived_at column
+func setArchived(item *models.InventoryItem, archivedAt sql.NullTime) {
+	if archivedAt.Valid {
+		item.Archived = true
+		item.ArchivedAt = &archivedAt.Time
+	}
+}
+
 // Helper function for nullable strings
 func nullString(s string) sql.NullString {
 	if s == "" {
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+func TestSyncInventoryDisplayFields_KeepsCustomizedItems(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Close()
+	db := &DB{DB: testDB.DB}
+	inventory := NewInventoryStore(db)
+	catalog := NewGearCatalogStore(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	brand := "sync-" + uuid.NewString()[:8]
+	var catalogID string
+	if err := testDB.QueryRowContext(ctx, `
+		INSERT INTO gear_catalog (gear_type, brand, model, canonical_key, status)
+		VALUES ('motor', $1, 'F60', $1 || '|f60', 'published')
+		RETURNING id
+	`, brand).Scan(&catalogID); err != nil {
+		t.Fatalf("seed catalog item: %v", err)
+	}
+	defer testDB.ExecContext(context.Background(), `DELETE FROM inventory_items WHERE catalog_id = $1`, catalogID)
+	defer testDB.ExecContext(context.Background(), `DELETE FROM gear_catalog WHERE id = $1`, catalogID)
+
+	add := func(name, manufacturer string, upsert bool) string {
+		t.Helper()
+		params := models.AddInventoryParams{
+			Name: name, Manufacturer: manufacturer, Category: models.CategoryMotors, CatalogID: catalogID,
+		}
+		add := inventory.Add
+		if upsert {
+			add = inventory.AddOrIncrement
+		}
+		item, err := add(ctx, "", params)
+		if err != nil {
+			t.Fatalf("add %q: %v", name, err)
+		}
+		return item.ID
+	}
+
+	linked := add(brand+" F60", brand, false)
+	renamed := add("Spare motor", brand, false)
+	rebranded := add(brand+" F60", "My Shop", true)
+	edited := add(brand+" F60", brand, true)
+	newName := "Race motor"
+	if _, err := inventory.Update(ctx, "", models.UpdateInventoryParams{ID: edited, Name: &newName}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	testDB.MustExec(ctx, `UPDATE gear_catalog SET model = 'F60 Pro' WHERE id = $1`, catalogID)
+
+	report, err := catalog.SyncInventoryDisplayFields(ctx, catalogID, false)
+	if err != nil {
+		t.Fatalf("SyncInventoryDisplayFields() error = %v", err)
+	}
+	if report.Affected != 1 {
+		t.Errorf("Affected = %d, want only the linked item", report.Affected)
+	}
+
+	want := map[string][2]string{
+		linked:    {brand + " F60 Pro", brand},
+		renamed:   {"Spare motor", brand},
+		rebranded: {brand + " F60", "My Shop"},
+		edited:    {"Race motor", brand},
+	}
+	for id, fields := range want {
+		item, err := inventory.Get(ctx, id, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if item.Name != fields[0] || item.Manufacturer != fields[1] {
+			t.Errorf("item %s = %q by %q, want %q by %q", id, item.Name, item.Manufacturer, fields[0], fields[1])
+		}
+	}
+}
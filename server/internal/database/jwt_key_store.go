@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/crypto"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// JWTKeyStore handles JWT signing key database operations. Secrets are
+// stored encrypted, so the store needs an encryptor.
+type JWTKeyStore struct {
+	db        *DB
+	encryptor *crypto.Encryptor
+}
+
+// NewJWTKeyStore creates a new JWT signing key store
+func NewJWTKeyStore(db *DB, encryptor *crypto.Encryptor) *JWTKeyStore {
+	return &JWTKeyStore{db: db, encryptor: encryptor}
+}
+
+// ListUsable returns the keys that can still verify tokens, newest first.
+// The first key without a retirement time is the current signing key.
+func (s *JWTKeyStore) ListUsable(ctx context.Context) ([]models.JWTSigningKey, error) {
+	if s.encryptor == nil {
+		return nil, fmt.Errorf("JWT signing keys need an encryption key")
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT kid, secret, created_at, retired_at, expires_at
+		FROM jwt_signing_keys
+		WHERE expires_at IS NULL OR expires_at > NOW()
+		ORDER BY created_at DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list JWT signing keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []models.JWTSigningKey
+	current := false
+	for rows.Next() {
+		var key models.JWTSigningKey
+		var encrypted string
+		var retiredAt, expiresAt sql.NullTime
+		if err := rows.Scan(&key.KID, &encrypted, &key.CreatedAt, &retiredAt, &expiresAt); err != nil {
+			return nil, fmt.Errorf("failed to scan JWT signing key: %w", err)
+		}
+		if key.Secret, err = s.encryptor.Decrypt(encrypted); err != nil {
+			return nil, fmt.Errorf("failed to decrypt JWT signing key %s: %w", key.KID, err)
+		}
+		if retiredAt.Valid {
+			key.RetiredAt = &retiredAt.Time
+		} else if !current {
+			key.Current = true
+			current = true
+		}
+		if expiresAt.Valid {
+			key.ExpiresAt = &expiresAt.Time
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list JWT signing keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Rotate makes next the signing key in one transaction. The current key is
+// retired and keeps verifying tokens until retireUntil. Keys in outgoing,
+// such as the configured secret before the first rotation, are stored as
+// retired with the same expiry so every instance can still verify them.
+func (s *JWTKeyStore) Rotate(ctx context.Context, next models.JWTSigningKey, outgoing []models.JWTSigningKey, retireUntil time.Time) error {
+	if s.encryptor == nil {
+		return fmt.Errorf("JWT signing keys need an encryption key")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE jwt_signing_keys SET retired_at = NOW(), expires_at = $1
+		WHERE retired_at IS NULL
+	`, retireUntil); err != nil {
+		return fmt.Errorf("failed to retire JWT signing key: %w", err)
+	}
+
+	for _, key := range outgoing {
+		encrypted, err := s.encryptor.Encrypt(key.Secret)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt JWT signing key: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO jwt_signing_keys (kid, secret, retired_at, expires_at)
+			VALUES ($1, $2, NOW(), $3)
+			ON CONFLICT (kid) DO NOTHING
+		`, key.KID, encrypted, retireUntil); err != nil {
+			return fmt.Errorf("failed to store retired JWT signing key: %w", err)
+		}
+	}
+
+	encrypted, err := s.encryptor.Encrypt(next.Secret)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt JWT signing key: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO jwt_signing_keys (kid, secret) VALUES ($1, $2)
+	`, next.KID, encrypted); err != nil {
+		return fmt.Errorf("failed to store JWT signing key: %w", err)
+	}
+
+	// Keys that expired a while ago can't verify anything
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM jwt_signing_keys WHERE expires_at < NOW() - INTERVAL '7 days'
+	`); err != nil {
+		return fmt.Errorf("failed to prune JWT signing keys: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit JWT key rotation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// linkUsagesSQL lists where a link_checks row's URL is used, one row per
+// source. Used as a LATERAL join on lc.
+const linkUsagesSQL = `
+	SELECT 'inventory' AS source,
+		MIN(i.catalog_id::text) AS ref_id,
+		MIN(COALESCE(NULLIF(TRIM(CONCAT_WS(' ', gc.brand, gc.model)), ''), i.name)) AS name,
+		COUNT(*) AS uses
+	FROM inventory_items i
+	LEFT JOIN gear_catalog gc ON gc.id = i.catalog_id
+	WHERE i.product_url = lc.url
+	UNION ALL
+	SELECT 'catalog', MIN(c.gear_id::text), MIN(gc.brand || ' ' || gc.model), COUNT(*)
+	FROM gear_image_candidates c
+	JOIN gear_catalog gc ON gc.id = c.gear_id
+	WHERE c.page_url = lc.url
+	UNION ALL
+	SELECT 'equipment', MIN(e.id), MIN(e.name), COUNT(*)
+	FROM equipment_items e
+	WHERE e.product_url = lc.url`
+
+// LinkCheckStore tracks the health of product links stored on inventory,
+// equipment, and catalog records
+type LinkCheckStore struct {
+	db *DB
+}
+
+// NewLinkCheckStore creates a new link check store
+func NewLinkCheckStore(db *DB) *LinkCheckStore {
+	return &LinkCheckStore{db: db}
+}
+
+// SyncURLs starts tracking links that have been stored since the last sync
+// and stops tracking ones no longer stored anywhere
+func (s *LinkCheckStore) SyncURLs(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO link_checks (url)
+		SELECT product_url FROM inventory_items WHERE product_url ~* '^https?://'
+		UNION
+		SELECT product_url FROM equipment_items WHERE product_url ~* '^https?://'
+		UNION
+		SELECT page_url FROM gear_image_candidates WHERE page_url ~* '^https?://'
+		ON CONFLICT (url) DO NOTHING
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to track stored links: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		DELETE FROM link_checks lc
+		WHERE NOT EXISTS (SELECT 1 FROM inventory_items WHERE product_url = lc.url)
+		  AND NOT EXISTS (SELECT 1 FROM equipment_items WHERE product_url = lc.url)
+		  AND NOT EXISTS (SELECT 1 FROM gear_image_candidates WHERE page_url = lc.url)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to untrack removed links: %w", err)
+	}
+	return nil
+}
+
+// DueURLs returns links never checked, failing links last checked before
+// retryBefore, and other links last checked before recheckBefore
+func (s *LinkCheckStore) DueURLs(ctx context.Context, recheckBefore, retryBefore time.Time, limit int) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT url FROM link_checks
+		WHERE checked_at IS NULL
+		   OR (status = 'failing' AND checked_at < $2)
+		   OR checked_at < $1
+		ORDER BY checked_at NULLS FIRST
+		LIMIT $3
+	`, recheckBefore, retryBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list links due for checking: %w", err)
+	}
+	defer rows.Close()
+
+	urls := make([]string, 0)
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			return nil, fmt.Errorf("failed to scan link: %w", err)
+		}
+		urls = append(urls, url)
+	}
+	return urls, rows.Err()
+}
+
+// RecordResult saves a check. A link becomes dead after deadAfter failed
+// checks in a row, and any successful check clears it. Inconclusive checks
+// leave the status alone.
+func (s *LinkCheckStore) RecordResult(ctx context.Context, result models.LinkCheckResult, deadAfter int) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO link_checks (url) VALUES ($1) ON CONFLICT (url) DO NOTHING;
+	`, result.URL)
+	if err != nil {
+		return fmt.Errorf("failed to track link: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		UPDATE link_checks SET
+			checked_at = NOW(),
+			http_status = NULLIF($2, 0),
+			last_error = NULLIF($3, ''),
+			failures = CASE WHEN $4 THEN 0 WHEN $5 THEN failures ELSE failures + 1 END,
+			status = CASE
+				WHEN $4 THEN 'ok'
+				WHEN $5 THEN status
+				WHEN failures + 1 >= $6 THEN 'dead'
+				ELSE 'failing'
+			END,
+			dead_since = CASE
+				WHEN $4 THEN NULL
+				WHEN NOT $5 AND failures + 1 >= $6 THEN COALESCE(dead_since, NOW())
+				ELSE dead_since
+			END
+		WHERE url = $1
+	`, result.URL, result.HTTPStatus, result.Error, result.OK, result.Inconclusive, deadAfter)
+	if err != nil {
+		return fmt.Errorf("failed to record link check: %w", err)
+	}
+	return nil
+}
+
+// ListDead returns dead links still in use, the longest dead first, with
+// where each is used. Suggestions are left for the caller.
+func (s *LinkCheckStore) ListDead(ctx context.Context, limit, offset int) (*models.DeadLinkReport, error) {
+	report := &models.DeadLinkReport{
+		Links:  make([]models.DeadLink, 0),
+		Counts: make(map[models.LinkStatus]int),
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT status, COUNT(*) FROM link_checks GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count links: %w", err)
+	}
+	for rows.Next() {
+		var status models.LinkStatus
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan link count: %w", err)
+		}
+		report.Counts[status] = count
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count links: %w", err)
+	}
+	report.TotalCount = report.Counts[models.LinkStatusDead]
+
+	rows, err = s.db.QueryContext(ctx, `
+		SELECT lc.url, COALESCE(lc.http_status, 0), COALESCE(lc.last_error, ''), lc.failures, lc.checked_at, lc.dead_since,
+			u.source, COALESCE(u.ref_id, ''), COALESCE(u.name, ''), u.uses
+		FROM (
+			SELECT * FROM link_checks
+			WHERE status = 'dead'
+			ORDER BY dead_since, url
+			LIMIT $1 OFFSET $2
+		) lc
+		JOIN LATERAL (`+linkUsagesSQL+`) u ON u.uses > 0
+		ORDER BY lc.dead_since, lc.url, u.source
+	`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead links: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var link models.DeadLink
+		var checkedAt, deadSince sql.NullTime
+		var usage models.LinkUsage
+		if err := rows.Scan(
+			&link.URL, &link.HTTPStatus, &link.Error, &link.Failures, &checkedAt, &deadSince,
+			&usage.Source, &usage.RefID, &usage.Name, &usage.Count,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan dead link: %w", err)
+		}
+
+		// Rows for the same link are adjacent
+		if n := len(report.Links); n > 0 && report.Links[n-1].URL == link.URL {
+			report.Links[n-1].Usages = append(report.Links[n-1].Usages, usage)
+			continue
+		}
+		if checkedAt.Valid {
+			link.CheckedAt = &checkedAt.Time
+		}
+		if deadSince.Valid {
+			link.DeadSince = &deadSince.Time
+		}
+		link.Usages = []models.LinkUsage{usage}
+		report.Links = append(report.Links, link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list dead links: %w", err)
+	}
+	return report, nil
+}
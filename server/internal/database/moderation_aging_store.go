@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// moderationQueueTitles is the SQL for each queue item's display title
+var moderationQueueTitles = map[models.ModerationQueue]string{
+	models.ModerationQueueGear:  `TRIM(brand || ' ' || model || ' ' || COALESCE(variant, ''))`,
+	models.ModerationQueueBuild: `COALESCE(title, '')`,
+}
+
+// ModerationAgingStore reports how long items have waited in the moderation
+// queues. queued_at is maintained by a trigger when an item's status changes.
+type ModerationAgingStore struct {
+	db *DB
+}
+
+// NewModerationAgingStore creates a new moderation aging store
+func NewModerationAgingStore(db *DB) *ModerationAgingStore {
+	return &ModerationAgingStore{db: db}
+}
+
+// QueueAging summarizes a queue and lists up to limit items queued before
+// overdueBefore, oldest first
+func (s *ModerationAgingStore) QueueAging(ctx context.Context, queue models.ModerationQueue, overdueBefore time.Time, limit int) (*models.ModerationQueueAging, error) {
+	table, err := moderationQueueTable(queue)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	aging := &models.ModerationQueueAging{Queue: queue, Items: []models.ModerationAgingItem{}}
+	var oldest sql.NullTime
+	err = s.db.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE queued_at < $1), MIN(queued_at)
+		FROM %s WHERE queued_at IS NOT NULL
+	`, table), overdueBefore).Scan(&aging.Pending, &aging.Overdue, &oldest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize %s queue: %w", queue, err)
+	}
+	if oldest.Valid {
+		aging.OldestAgeSeconds = models.QueueAge(&oldest.Time, now)
+	}
+	if aging.Overdue == 0 || limit <= 0 {
+		return aging, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id::text, %s, queued_at, assigned_to::text, assigned_at
+		FROM %s
+		WHERE queued_at < $1
+		ORDER BY queued_at ASC
+		LIMIT $2
+	`, moderationQueueTitles[queue], table), overdueBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overdue %s items: %w", queue, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.ModerationAgingItem{Queue: queue}
+		var assignedTo sql.NullString
+		var assignedAt sql.NullTime
+		if err := rows.Scan(&item.ID, &item.Title, &item.QueuedAt, &assignedTo, &assignedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan overdue %s item: %w", queue, err)
+		}
+		item.AgeSeconds = models.QueueAge(&item.QueuedAt, now)
+		item.Assignment = assignmentFromColumns(assignedTo, assignedAt)
+		aging.Items = append(aging.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list overdue %s items: %w", queue, err)
+	}
+	return aging, nil
+}
+
+// ListUnescalated returns the IDs of items queued before overdueBefore that
+// moderators haven't been alerted about yet
+func (s *ModerationAgingStore) ListUnescalated(ctx context.Context, queue models.ModerationQueue, overdueBefore time.Time) ([]string, error) {
+	table, err := moderationQueueTable(queue)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id::text FROM %s
+		WHERE queued_at < $1 AND sla_escalated_at IS NULL
+		ORDER BY queued_at ASC
+	`, table), overdueBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unescalated %s items: %w", queue, err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan unescalated %s item: %w", queue, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// MarkEscalated records that moderators were alerted about items
+func (s *ModerationAgingStore) MarkEscalated(ctx context.Context, queue models.ModerationQueue, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	table, err := moderationQueueTable(queue)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, fmt.Sprintf(`
+		UPDATE %s SET sla_escalated_at = NOW() WHERE id::text = ANY($1)
+	`, table), pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to mark %s items escalated: %w", queue, err)
+	}
+	return nil
+}
+
+// ListModeratorIDs returns active users who can moderate content
+func (s *ModerationAgingStore) ListModeratorIDs(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id::text FROM users
+		WHERE status = $1 AND (COALESCE(is_admin, FALSE) OR COALESCE(is_content_admin, is_gear_admin, FALSE))
+	`, models.UserStatusActive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderators: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan moderator: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,147 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+var (
+	// ErrClaimedByOther is returned when another moderator holds an
+	// unexpired claim on an item
+	ErrClaimedByOther = errors.New("claimed by another moderator")
+	// ErrModerationItemNotFound is returned when the claimed item doesn't exist
+	ErrModerationItemNotFound = errors.New("moderation item not found")
+)
+
+// moderationQueueTables maps each claimable queue to its table
+var moderationQueueTables = map[models.ModerationQueue]string{
+	models.ModerationQueueGear:  "gear_catalog",
+	models.ModerationQueueBuild: "builds",
+}
+
+// ModerationClaimStore tracks which moderator is working on a gear item or
+// build. Claims expire after models.ModerationClaimTimeout.
+type ModerationClaimStore struct {
+	db *DB
+}
+
+// NewModerationClaimStore creates a new moderation claim store
+func NewModerationClaimStore(db *DB) *ModerationClaimStore {
+	return &ModerationClaimStore{db: db}
+}
+
+// Claim assigns an item to userID, or renews their claim. When another
+// moderator holds the item it returns ErrClaimedByOther with their
+// assignment.
+func (s *ModerationClaimStore) Claim(ctx context.Context, queue models.ModerationQueue, id, userID string) (*models.ModerationAssignment, error) {
+	table, err := moderationQueueTable(queue)
+	if err != nil {
+		return nil, err
+	}
+
+	var assignedAt time.Time
+	err = s.db.QueryRowContext(ctx, fmt.Sprintf(`
+		UPDATE %s SET assigned_to = $2, assigned_at = NOW()
+		WHERE id::text = $1 AND (assigned_to IS NULL OR assigned_to = $2 OR assigned_at IS NULL OR assigned_at < $3)
+		RETURNING assigned_at
+	`, table), id, userID, claimCutoff()).Scan(&assignedAt)
+	if err == nil {
+		return models.NewModerationAssignment(userID, &assignedAt), nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to claim %s: %w", queue, err)
+	}
+
+	current, err := s.Get(ctx, queue, id)
+	if err != nil {
+		return nil, err
+	}
+	return current, ErrClaimedByOther
+}
+
+// Release drops userID's claim on an item. With force, any moderator's
+// claim is dropped. Releasing an item nobody holds succeeds.
+func (s *ModerationClaimStore) Release(ctx context.Context, queue models.ModerationQueue, id, userID string, force bool) error {
+	table, err := moderationQueueTable(queue)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.db.ExecContext(ctx, fmt.Sprintf(`
+		UPDATE %s SET assigned_to = NULL, assigned_at = NULL
+		WHERE id::text = $1 AND (assigned_to IS NULL OR assigned_to = $2 OR $3 OR assigned_at < $4)
+	`, table), id, userID, force, claimCutoff())
+	if err != nil {
+		return fmt.Errorf("failed to release %s: %w", queue, err)
+	}
+	if rows, _ := result.RowsAffected(); rows > 0 {
+		return nil
+	}
+
+	if _, err := s.Get(ctx, queue, id); err != nil {
+		return err
+	}
+	return ErrClaimedByOther
+}
+
+// Get returns the unexpired assignment on an item, or nil
+func (s *ModerationClaimStore) Get(ctx context.Context, queue models.ModerationQueue, id string) (*models.ModerationAssignment, error) {
+	table, err := moderationQueueTable(queue)
+	if err != nil {
+		return nil, err
+	}
+
+	var assignedTo sql.NullString
+	var assignedAt sql.NullTime
+	err = s.db.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT assigned_to::text, assigned_at FROM %s WHERE id::text = $1
+	`, table), id).Scan(&assignedTo, &assignedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrModerationItemNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s assignment: %w", queue, err)
+	}
+	return assignmentFromColumns(assignedTo, assignedAt), nil
+}
+
+// CheckClaim returns ErrClaimedByOther when a moderator other than userID
+// holds an unexpired claim on the item
+func (s *ModerationClaimStore) CheckClaim(ctx context.Context, queue models.ModerationQueue, id, userID string) (*models.ModerationAssignment, error) {
+	current, err := s.Get(ctx, queue, id)
+	if err != nil {
+		if errors.Is(err, ErrModerationItemNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if current != nil && current.UserID != userID {
+		return current, ErrClaimedByOther
+	}
+	return current, nil
+}
+
+func assignmentFromColumns(assignedTo sql.NullString, assignedAt sql.NullTime) *models.ModerationAssignment {
+	if !assignedTo.Valid || !assignedAt.Valid {
+		return nil
+	}
+	return models.NewModerationAssignment(assignedTo.String, &assignedAt.Time)
+}
+
+func moderationQueueTable(queue models.ModerationQueue) (string, error) {
+	table, ok := moderationQueueTables[queue]
+	if !ok {
+		return "", fmt.Errorf("unknown moderation queue %q", queue)
+	}
+	return table, nil
+}
+
+// claimCutoff is the assignment time before which claims have expired
+func claimCutoff() time.Time {
+	return time.Now().Add(-models.ModerationClaimTimeout)
+}
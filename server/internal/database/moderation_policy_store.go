@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ErrModerationPolicyNotFound is returned when an entity type has no stored policy
+var ErrModerationPolicyNotFound = errors.New("moderation policy not found")
+
+// ModerationPolicyStore persists per-entity-type image moderation policies
+type ModerationPolicyStore struct {
+	db *DB
+}
+
+// NewModerationPolicyStore creates a new moderation policy store
+func NewModerationPolicyStore(db *DB) *ModerationPolicyStore {
+	return &ModerationPolicyStore{db: db}
+}
+
+// List returns every stored policy
+func (s *ModerationPolicyStore) List(ctx context.Context) ([]models.ModerationPolicy, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT entity_type, reject_confidence, allowed_labels, COALESCE(updated_by::text, ''), updated_at
+		FROM moderation_policies
+		ORDER BY entity_type
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation policies: %w", err)
+	}
+	defer rows.Close()
+
+	policies := make([]models.ModerationPolicy, 0)
+	for rows.Next() {
+		var policy models.ModerationPolicy
+		var updatedAt sql.NullTime
+		if err := rows.Scan(&policy.EntityType, &policy.RejectConfidence, pq.Array(&policy.AllowedLabels), &policy.UpdatedBy, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan moderation policy: %w", err)
+		}
+		if policy.AllowedLabels == nil {
+			policy.AllowedLabels = []string{}
+		}
+		if updatedAt.Valid {
+			policy.UpdatedAt = &updatedAt.Time
+		}
+		policy.Custom = true
+		policies = append(policies, policy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list moderation policies: %w", err)
+	}
+	return policies, nil
+}
+
+// Upsert creates or replaces the policy for an entity type
+func (s *ModerationPolicyStore) Upsert(ctx context.Context, entityType models.ImageEntityType, adminUserID string, params models.SetModerationPolicyParams) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO moderation_policies (entity_type, reject_confidence, allowed_labels, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (entity_type) DO UPDATE SET
+			reject_confidence = EXCLUDED.reject_confidence,
+			allowed_labels = EXCLUDED.allowed_labels,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+	`, string(entityType), params.RejectConfidence, pq.Array(params.AllowedLabels), nullString(adminUserID))
+	if err != nil {
+		return fmt.Errorf("failed to save moderation policy: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the policy for an entity type so the global default applies
+func (s *ModerationPolicyStore) Delete(ctx context.Context, entityType models.ImageEntityType) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM moderation_policies WHERE entity_type = $1`, string(entityType))
+	if err != nil {
+		return fmt.Errorf("failed to delete moderation policy: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return ErrModerationPolicyNotFound
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// OutboxStore persists domain events until they've been handed to the
+// message broker. Events are written in the same transaction as the change
+// they describe, so an event is never published for a change that rolled
+// back and never lost for one that committed.
+type OutboxStore struct {
+	db *DB
+}
+
+// NewOutboxStore creates a new outbox store
+func NewOutboxStore(db *DB) *OutboxStore {
+	return &OutboxStore{db: db}
+}
+
+// execer is satisfied by both *DB and *Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// enqueueDomainEvent writes an event to the outbox through q, so stores that
+// manage their own *Tx can enqueue inside it. The tenant comes from the
+// connection's scope.
+func enqueueDomainEvent(ctx context.Context, q execer, eventType models.DomainEventType, aggregateID string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode domain event: %w", err)
+	}
+	_, err = q.ExecContext(ctx, `
+		INSERT INTO domain_events_outbox (event_type, aggregate_id, payload)
+		VALUES ($1, $2, $3)
+	`, eventType, aggregateID, data)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue domain event: %w", err)
+	}
+	return nil
+}
+
+// Enqueue writes an event to the outbox, inside the context's unit of work
+// when there is one
+func (s *OutboxStore) Enqueue(ctx context.Context, eventType models.DomainEventType, aggregateID string, payload interface{}) error {
+	return enqueueDomainEvent(ctx, s.db, eventType, aggregateID, payload)
+}
+
+// Relay claims up to limit pending events, oldest first, and passes each to
+// publish. Published events are marked so they aren't sent again; failed
+// ones are retried with exponential backoff, capped at an hour. Claimed rows
+// stay locked until the batch finishes, so relays running on several
+// instances never send the same event concurrently. Delivery is at least
+// once: an event published just before the batch fails to commit is sent
+// again.
+func (s *OutboxStore) Relay(ctx context.Context, limit int, publish func(models.DomainEvent) error) (published int, err error) {
+	err = s.db.RunInTx(ctx, func(ctx context.Context) error {
+		rows, err := s.db.QueryContext(ctx, `
+			SELECT id, event_type, aggregate_id, COALESCE(tenant_id::text, ''), payload, created_at
+			FROM domain_events_outbox
+			WHERE published_at IS NULL AND next_attempt_at <= NOW()
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		`, limit)
+		if err != nil {
+			return fmt.Errorf("failed to claim domain events: %w", err)
+		}
+
+		var events []models.DomainEvent
+		for rows.Next() {
+			var e models.DomainEvent
+			var payload []byte
+			if err := rows.Scan(&e.ID, &e.Type, &e.AggregateID, &e.TenantID, &payload, &e.OccurredAt); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan domain event: %w", err)
+			}
+			e.Payload = json.RawMessage(payload)
+			events = append(events, e)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to claim domain events: %w", err)
+		}
+
+		for _, e := range events {
+			if pubErr := publish(e); pubErr != nil {
+				_, err := s.db.ExecContext(ctx, `
+					UPDATE domain_events_outbox
+					SET attempts = attempts + 1,
+						last_error = $2,
+						next_attempt_at = NOW() + LEAST(POWER(2, attempts), 3600) * INTERVAL '1 second'
+					WHERE id = $1
+				`, e.ID, pubErr.Error())
+				if err != nil {
+					return fmt.Errorf("failed to record domain event failure: %w", err)
+				}
+				continue
+			}
+			if _, err := s.db.ExecContext(ctx, `
+				UPDATE domain_events_outbox SET published_at = NOW(), attempts = attempts + 1, last_error = NULL
+				WHERE id = $1
+			`, e.ID); err != nil {
+				return fmt.Errorf("failed to mark domain event published: %w", err)
+			}
+			published++
+		}
+		return nil
+	})
+	return published, err
+}
+
+// PurgePublished deletes events published before the cutoff and returns
+// how many were removed
+func (s *OutboxStore) PurgePublished(ctx context.Context, before time.Time) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM domain_events_outbox WHERE published_at IS NOT NULL AND published_at < $1
+	`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge domain events: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// PurgeStale deletes every event created before the cutoff, published or
+// not. It's for deployments without a broker, where nothing would ever mark
+// events published.
+func (s *OutboxStore) PurgeStale(ctx context.Context, before time.Time) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM domain_events_outbox WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge domain events: %w", err)
+	}
+	return result.RowsAffected()
+}
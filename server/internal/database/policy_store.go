@@ -0,0 +1,159 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ErrPolicyVersionExists is returned when a policy kind already has the
+// version being published
+var ErrPolicyVersionExists = errors.New("policy version already exists")
+
+const policyVersionColumns = `v.id, v.kind, v.version, v.url, v.summary, v.published_at, COALESCE(v.created_by::text, '')`
+
+// latestPolicyVersionsSQL selects the newest version of each policy kind
+const latestPolicyVersionsSQL = `
+	SELECT DISTINCT ON (kind) * FROM policy_versions
+	ORDER BY kind, published_at DESC, id`
+
+// PolicyStore handles terms and privacy policy versions and who accepted them
+type PolicyStore struct {
+	db *DB
+}
+
+// NewPolicyStore creates a new policy store
+func NewPolicyStore(db *DB) *PolicyStore {
+	return &PolicyStore{db: db}
+}
+
+// Publish adds a policy version, which becomes the one users must accept
+func (s *PolicyStore) Publish(ctx context.Context, createdBy string, params models.PublishPolicyVersionParams) (*models.PolicyVersion, error) {
+	query := `
+		INSERT INTO policy_versions AS v (kind, version, url, summary, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (kind, version) DO NOTHING
+		RETURNING ` + policyVersionColumns
+
+	version, err := scanPolicyVersion(s.db.QueryRowContext(ctx, query,
+		params.Kind, params.Version, params.URL, params.Summary, nullString(createdBy),
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPolicyVersionExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish policy version: %w", err)
+	}
+	return version, nil
+}
+
+// List returns every published version, newest first
+func (s *PolicyStore) List(ctx context.Context) ([]models.PolicyVersion, error) {
+	return s.queryVersions(ctx, `SELECT `+policyVersionColumns+` FROM policy_versions v ORDER BY v.published_at DESC, v.kind`)
+}
+
+// Latest returns the current version of each policy kind
+func (s *PolicyStore) Latest(ctx context.Context) ([]models.PolicyVersion, error) {
+	return s.queryVersions(ctx, `SELECT `+policyVersionColumns+` FROM (`+latestPolicyVersionsSQL+`) v ORDER BY v.kind`)
+}
+
+// Pending returns the current versions a user hasn't accepted
+func (s *PolicyStore) Pending(ctx context.Context, userID string) ([]models.PolicyVersion, error) {
+	return s.queryVersions(ctx, `
+		SELECT `+policyVersionColumns+` FROM (`+latestPolicyVersionsSQL+`) v
+		WHERE NOT EXISTS (
+			SELECT 1 FROM policy_acceptances a
+			WHERE a.policy_version_id = v.id AND a.user_id = $1
+		)
+		ORDER BY v.kind
+	`, userID)
+}
+
+// Accept records that a user accepted the given versions. Versions that
+// don't exist are ignored, and accepting a version twice keeps the first
+// acceptance time. Returns the number of versions that exist.
+func (s *PolicyStore) Accept(ctx context.Context, userID string, versionIDs []string) (int, error) {
+	var found int
+	err := s.db.QueryRowContext(ctx, `
+		WITH target AS (
+			SELECT id FROM policy_versions WHERE id::text = ANY($2)
+		), inserted AS (
+			INSERT INTO policy_acceptances (user_id, policy_version_id)
+			SELECT $1, id FROM target
+			ON CONFLICT DO NOTHING
+		)
+		SELECT COUNT(*) FROM target
+	`, userID, pq.Array(versionIDs)).Scan(&found)
+	if err != nil {
+		return 0, fmt.Errorf("failed to accept policy versions: %w", err)
+	}
+	return found, nil
+}
+
+// ListAcceptances returns every policy version a user accepted, newest first
+func (s *PolicyStore) ListAcceptances(ctx context.Context, userID string) ([]models.PolicyAcceptance, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+policyVersionColumns+`, a.accepted_at
+		FROM policy_acceptances a
+		JOIN policy_versions v ON v.id = a.policy_version_id
+		WHERE a.user_id = $1
+		ORDER BY a.accepted_at DESC, v.kind
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list policy acceptances: %w", err)
+	}
+	defer rows.Close()
+
+	acceptances := make([]models.PolicyAcceptance, 0)
+	for rows.Next() {
+		var acceptance models.PolicyAcceptance
+		version, err := scanPolicyVersion(rows, &acceptance.AcceptedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan policy acceptance: %w", err)
+		}
+		acceptance.PolicyVersion = *version
+		acceptances = append(acceptances, acceptance)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list policy acceptances: %w", err)
+	}
+	return acceptances, nil
+}
+
+func (s *PolicyStore) queryVersions(ctx context.Context, query string, args ...interface{}) ([]models.PolicyVersion, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list policy versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := make([]models.PolicyVersion, 0)
+	for rows.Next() {
+		version, err := scanPolicyVersion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan policy version: %w", err)
+		}
+		versions = append(versions, *version)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list policy versions: %w", err)
+	}
+	return versions, nil
+}
+
+func scanPolicyVersion(row interface{ Scan(...interface{}) error }, extra ...interface{}) (*models.PolicyVersion, error) {
+	var version models.PolicyVersion
+	dest := append([]interface{}{
+		&version.ID, &version.Kind, &version.Version, &version.URL, &version.Summary,
+		&version.PublishedAt, &version.CreatedByUserID,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	// defaultPoolName labels the shared pool every tenant's queries run on
+	defaultPoolName = "default"
+	// maxPoolAlerts is how many recent alerts the diagnostic view keeps
+	maxPoolAlerts = 20
+)
+
+// PoolStats returns a snapshot of the connection pool. It is a list so the
+// diagnostic view can report more pools, such as a read replica's.
+func (db *DB) PoolStats() []models.DBPoolStats {
+	if db == nil || db.DB == nil {
+		return nil
+	}
+	return []models.DBPoolStats{poolStats(defaultPoolName, db.DB.Stats())}
+}
+
+func poolStats(name string, s sql.DBStats) models.DBPoolStats {
+	return models.DBPoolStats{
+		Pool:               name,
+		MaxOpenConnections: s.MaxOpenConnections,
+		OpenConnections:    s.OpenConnections,
+		InUse:              s.InUse,
+		Idle:               s.Idle,
+		WaitCount:          s.WaitCount,
+		WaitDurationMs:     millis(s.WaitDuration),
+		MaxIdleClosed:      s.MaxIdleClosed,
+		MaxIdleTimeClosed:  s.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  s.MaxLifetimeClosed,
+	}
+}
+
+// PoolMonitor watches the connection pools for waits. Pool exhaustion
+// otherwise only shows up once requests start timing out. Each Check
+// compares the wait totals with the previous check and raises an alert for
+// any pool whose new waits averaged at least the threshold.
+type PoolMonitor struct {
+	db        *DB
+	waitAlert time.Duration
+
+	mu        sync.Mutex
+	last      map[string]models.DBPoolStats
+	lastCheck time.Time
+	alerts    []models.DBPoolAlert // newest first
+}
+
+// NewPoolMonitor creates a monitor for db's pools. A zero waitAlert only
+// reports the pools and never alerts.
+func NewPoolMonitor(db *DB, waitAlert time.Duration) *PoolMonitor {
+	return &PoolMonitor{db: db, waitAlert: waitAlert, last: make(map[string]models.DBPoolStats)}
+}
+
+// Stats returns the current pool snapshot
+func (m *PoolMonitor) Stats() []models.DBPoolStats {
+	return m.db.PoolStats()
+}
+
+// Check samples the pools and returns any new alerts
+func (m *PoolMonitor) Check(now time.Time) []models.DBPoolAlert {
+	return m.check(now, m.db.PoolStats())
+}
+
+func (m *PoolMonitor) check(now time.Time, pools []models.DBPoolStats) []models.DBPoolAlert {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var alerts []models.DBPoolAlert
+	for _, pool := range pools {
+		prev, seen := m.last[pool.Pool]
+		m.last[pool.Pool] = pool
+		if !seen || m.waitAlert <= 0 {
+			continue
+		}
+
+		waits := pool.WaitCount - prev.WaitCount
+		waited := pool.WaitDurationMs - prev.WaitDurationMs
+		if waits <= 0 || waited/float64(waits) < millis(m.waitAlert) {
+			continue
+		}
+		alerts = append(alerts, models.DBPoolAlert{
+			Pool:               pool.Pool,
+			At:                 now,
+			Waits:              waits,
+			MeanWaitMs:         waited / float64(waits),
+			TotalWaitMs:        waited,
+			ThresholdMs:        millis(m.waitAlert),
+			OpenConnections:    pool.OpenConnections,
+			MaxOpenConnections: pool.MaxOpenConnections,
+		})
+	}
+	m.lastCheck = now
+
+	for _, alert := range alerts {
+		m.alerts = append([]models.DBPoolAlert{alert}, m.alerts...)
+	}
+	if len(m.alerts) > maxPoolAlerts {
+		m.alerts = m.alerts[:maxPoolAlerts]
+	}
+	return alerts
+}
+
+// Report returns the pools with the alert threshold and recent alerts
+func (m *PoolMonitor) Report() models.DBPoolResponse {
+	pools := m.db.PoolStats()
+	if pools == nil {
+		pools = []models.DBPoolStats{}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	report := models.DBPoolResponse{
+		Pools:        pools,
+		WaitAlertMs:  millis(m.waitAlert),
+		RecentAlerts: append([]models.DBPoolAlert{}, m.alerts...),
+	}
+	if !m.lastCheck.IsZero() {
+		lastCheck := m.lastCheck
+		report.LastCheck = &lastCheck
+	}
+	return report
+}
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+func TestPoolMonitor_AlertsOnMeanWait(t *testing.T) {
+	m := NewPoolMonitor(nil, 100*time.Millisecond)
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	pool := models.DBPoolStats{Pool: "default", MaxOpenConnections: 25, OpenConnections: 25, WaitCount: 10, WaitDurationMs: 5000}
+	if alerts := m.check(start, []models.DBPoolStats{pool}); len(alerts) != 0 {
+		t.Fatalf("first check alerted on totals from before monitoring: %+v", alerts)
+	}
+
+	// 20 short waits: 50ms on average
+	pool.WaitCount, pool.WaitDurationMs = 30, 6000
+	if alerts := m.check(start.Add(time.Minute), []models.DBPoolStats{pool}); len(alerts) != 0 {
+		t.Fatalf("alerted below threshold: %+v", alerts)
+	}
+
+	// 4 waits totalling 2s: 500ms on average
+	pool.WaitCount, pool.WaitDurationMs = 34, 8000
+	alerts := m.check(start.Add(2*time.Minute), []models.DBPoolStats{pool})
+	if len(alerts) != 1 {
+		t.Fatalf("alerts = %+v, want one", alerts)
+	}
+	if a := alerts[0]; a.Pool != "default" || a.Waits != 4 || a.MeanWaitMs != 500 || a.TotalWaitMs != 2000 || a.ThresholdMs != 100 {
+		t.Errorf("alert = %+v", a)
+	}
+
+	// No new waits means no alert, however long earlier waits were
+	if alerts := m.check(start.Add(3*time.Minute), []models.DBPoolStats{pool}); len(alerts) != 0 {
+		t.Errorf("alerted without new waits: %+v", alerts)
+	}
+
+	report := m.Report()
+	if len(report.RecentAlerts) != 1 || report.WaitAlertMs != 100 {
+		t.Errorf("report = %+v", report)
+	}
+	if report.LastCheck == nil || !report.LastCheck.Equal(start.Add(3*time.Minute)) {
+		t.Errorf("last check = %v", report.LastCheck)
+	}
+}
+
+func TestPoolMonitor_DisabledNeverAlerts(t *testing.T) {
+	m := NewPoolMonitor(nil, 0)
+	now := time.Now()
+	m.check(now, []models.DBPoolStats{{Pool: "default"}})
+	alerts := m.check(now.Add(time.Minute), []models.DBPoolStats{{Pool: "default", WaitCount: 5, WaitDurationMs: 60000}})
+	if len(alerts) != 0 {
+		t.Errorf("alerts = %+v, want none with alerts off", alerts)
+	}
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// PushDeviceStore handles push device token persistence
+type PushDeviceStore struct {
+	db *DB
+}
+
+// NewPushDeviceStore creates a new push device store
+func NewPushDeviceStore(db *DB) *PushDeviceStore {
+	return &PushDeviceStore{db: db}
+}
+
+// Register upserts a device token for the user. A token already registered to
+// another account moves to this user, since a device only has one signed-in user.
+func (s *PushDeviceStore) Register(ctx context.Context, userID string, params models.RegisterPushDeviceParams) (*models.PushDevice, error) {
+	device := &models.PushDevice{}
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO push_devices (user_id, platform, token)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (token) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			platform = EXCLUDED.platform,
+			last_seen_at = NOW()
+		RETURNING id, user_id, platform, token, created_at, last_seen_at
+	`, userID, params.Platform, params.Token).Scan(
+		&device.ID, &device.UserID, &device.Platform, &device.Token, &device.CreatedAt, &device.LastSeenAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register push device: %w", err)
+	}
+	return device, nil
+}
+
+// ListForUser returns all devices registered to the user, most recently seen first
+func (s *PushDeviceStore) ListForUser(ctx context.Context, userID string) ([]models.PushDevice, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, user_id, platform, token, created_at, last_seen_at
+		FROM push_devices
+		WHERE user_id = $1
+		ORDER BY last_seen_at DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list push devices: %w", err)
+	}
+	defer rows.Close()
+
+	devices := make([]models.PushDevice, 0)
+	for rows.Next() {
+		var device models.PushDevice
+		if err := rows.Scan(&device.ID, &device.UserID, &device.Platform, &device.Token, &device.CreatedAt, &device.LastSeenAt); err != nil {
+			return nil, fmt.Errorf("failed to scan push device: %w", err)
+		}
+		devices = append(devices, device)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list push devices: %w", err)
+	}
+	return devices, nil
+}
+
+// Delete removes a device owned by the user
+func (s *PushDeviceStore) Delete(ctx context.Context, id, userID string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM push_devices WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete push device: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("push device not found")
+	}
+	return nil
+}
+
+// DeleteToken removes a token regardless of owner. Used when a push service
+// reports the token as no longer valid.
+func (s *PushDeviceStore) DeleteToken(ctx context.Context, token string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM push_devices WHERE token = $1`, token); err != nil {
+		return fmt.Errorf("failed to delete push token: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/cache"
+)
+
+// Cached queries are tagged by what they read, and stores drop a tag after
+// writing the rows behind it, so results stay fresh without waiting out the
+// TTL. The TTL remains a backstop for writes made outside the stores.
+const (
+	// catalogSearchTag covers every cached catalog search
+	catalogSearchTag = "catalog-search"
+	// queryCacheTTL bounds how long a cached query can be stale
+	queryCacheTTL = 10 * time.Minute
+)
+
+// SetCache enables caching of read-heavy queries such as catalog search in c.
+// With a shared backend like Redis, a write on one instance invalidates the
+// cached results on all of them.
+func (db *DB) SetCache(c cache.Cache) {
+	db.cache = c
+}
+
+// queryCacheKey returns the cache key for a query's parameters in the
+// context's tenant, or "" when caching is off. Queries inside a unit of work
+// aren't cached, since they can see its uncommitted writes.
+func (db *DB) queryCacheKey(ctx context.Context, name string, params interface{}) string {
+	if db.cache == nil || InTx(ctx) {
+		return ""
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return name + ":" + tenantScope(ctx) + ":" + hex.EncodeToString(sum[:])
+}
+
+// getCached decodes the cached result for key into dst. Results are stored
+// as JSON text so callers never share a value, and so they read back the
+// same from every backend.
+func (db *DB) getCached(key string, dst interface{}) bool {
+	if key == "" {
+		return false
+	}
+	value, ok := db.cache.Get(key)
+	if !ok {
+		return false
+	}
+	text, ok := value.(string)
+	return ok && json.Unmarshal([]byte(text), dst) == nil
+}
+
+// setCached stores result under key and tags
+func (db *DB) setCached(key string, result interface{}, tags ...string) {
+	if key == "" {
+		return
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return
+	}
+	db.cache.SetWithTags(key, string(data), queryCacheTTL, tags...)
+}
+
+// invalidateTag drops every cached query under tag, after a write to the rows
+// it covers. Inside a unit of work it waits for the unit to commit.
+func (db *DB) invalidateTag(ctx context.Context, tag string) {
+	if db == nil || db.cache == nil {
+		return
+	}
+	if u := unitFromContext(ctx); u != nil {
+		u.invalidateAfterCommit(tag)
+		return
+	}
+	db.cache.InvalidateTag(tag)
+}
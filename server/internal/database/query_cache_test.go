@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/cache"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+func TestQueryCache_RoundTripAndInvalidate(t *testing.T) {
+	c := cache.NewMemory(time.Minute)
+	defer c.Stop()
+	db := &DB{}
+	db.SetCache(c)
+	ctx := context.Background()
+
+	params := models.GearCatalogSearchParams{Query: "nazgul", Limit: 20}
+	key := db.queryCacheKey(ctx, catalogSearchTag, params)
+	if key == "" {
+		t.Fatal("queryCacheKey() = \"\" with a cache set")
+	}
+	if other := db.queryCacheKey(WithTenant(ctx, "tenant-b"), catalogSearchTag, params); other == key {
+		t.Error("tenants should not share cached results")
+	}
+
+	db.setCached(key, &models.GearCatalogSearchResponse{TotalCount: 1, Items: []models.GearCatalogItem{{ID: "item-1"}}}, catalogSearchTag)
+	var got models.GearCatalogSearchResponse
+	if !db.getCached(key, &got) || got.TotalCount != 1 || len(got.Items) != 1 || got.Items[0].ID != "item-1" {
+		t.Fatalf("getCached() = %+v", got)
+	}
+
+	db.invalidateTag(ctx, catalogSearchTag)
+	if db.getCached(key, &got) {
+		t.Error("getCached() should miss after the tag was invalidated")
+	}
+}
+
+func TestQueryCache_WaitsForUnitOfWork(t *testing.T) {
+	c := cache.NewMemory(time.Minute)
+	defer c.Stop()
+	db := &DB{}
+	db.SetCache(c)
+
+	key := db.queryCacheKey(context.Background(), catalogSearchTag, "query")
+	db.setCached(key, "result", catalogSearchTag)
+
+	u := &unit{}
+	txCtx := context.WithValue(context.Background(), unitContextKey{}, u)
+	if db.queryCacheKey(txCtx, catalogSearchTag, "query") != "" {
+		t.Error("queries inside a unit of work should not be cached")
+	}
+
+	db.invalidateTag(txCtx, catalogSearchTag)
+	var got string
+	if !db.getCached(key, &got) {
+		t.Fatal("invalidation inside a unit of work should wait for the commit")
+	}
+	if _, ok := u.tags[catalogSearchTag]; !ok {
+		t.Errorf("unit tags = %v, want the tag queued", u.tags)
+	}
+}
+
+func TestQueryCache_Disabled(t *testing.T) {
+	db := &DB{}
+	if key := db.queryCacheKey(context.Background(), catalogSearchTag, "query"); key != "" {
+		t.Errorf("queryCacheKey() = %q, want \"\" without a cache", key)
+	}
+	db.setCached("", "result")
+	db.invalidateTag(context.Background(), catalogSearchTag)
+}
@@ -0,0 +1,170 @@
+package database
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	// maxTrackedQueries bounds memory when callers build SQL dynamically
+	maxTrackedQueries = 500
+	// queryStatSamples is how many recent durations each statement keeps
+	// for its p95
+	queryStatSamples = 200
+	// maxQueryTextLength truncates statements in the report
+	maxQueryTextLength = 1000
+)
+
+// QueryStats records how long every statement run through DB takes, for
+// operators who can't read pg_stat_statements. Statements are keyed by their
+// SQL text with whitespace collapsed, so arguments never reach the report.
+type QueryStats struct {
+	mu      sync.Mutex
+	queries map[string]*queryStat
+	dropped int64
+	since   time.Time
+	now     func() time.Time
+}
+
+type queryStat struct {
+	calls   int64
+	errors  int64
+	total   time.Duration
+	max     time.Duration
+	samples []time.Duration // ring of the most recent durations
+	next    int
+}
+
+// NewQueryStats creates an empty collector
+func NewQueryStats() *QueryStats {
+	return &QueryStats{queries: make(map[string]*queryStat), since: time.Now(), now: time.Now}
+}
+
+// Record adds one run of query. A nil collector records nothing.
+func (s *QueryStats) Record(query string, d time.Duration, err error) {
+	if s == nil {
+		return
+	}
+	key := normalizeQuery(query)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stat, ok := s.queries[key]
+	if !ok {
+		if len(s.queries) >= maxTrackedQueries {
+			s.dropped++
+			return
+		}
+		stat = &queryStat{}
+		s.queries[key] = stat
+	}
+
+	stat.calls++
+	if err != nil {
+		stat.errors++
+	}
+	stat.total += d
+	if d > stat.max {
+		stat.max = d
+	}
+	if len(stat.samples) < queryStatSamples {
+		stat.samples = append(stat.samples, d)
+	} else {
+		stat.samples[stat.next] = d
+		stat.next = (stat.next + 1) % queryStatSamples
+	}
+}
+
+// Top returns the limit statements that rank highest by sortBy
+func (s *QueryStats) Top(limit int, sortBy models.QueryStatsSort) models.QueryStatsResponse {
+	if !sortBy.IsValid() {
+		sortBy = models.QueryStatsSortTotal
+	}
+
+	s.mu.Lock()
+	stats := make([]models.QueryStat, 0, len(s.queries))
+	for query, stat := range s.queries {
+		stats = append(stats, models.QueryStat{
+			Query:   query,
+			Calls:   stat.calls,
+			Errors:  stat.errors,
+			TotalMs: millis(stat.total),
+			MeanMs:  millis(stat.total / time.Duration(stat.calls)),
+			P95Ms:   millis(percentile(stat.samples, 0.95)),
+			MaxMs:   millis(stat.max),
+		})
+	}
+	response := models.QueryStatsResponse{
+		Since:          s.since,
+		Sort:           sortBy,
+		TrackedQueries: len(s.queries),
+		DroppedCalls:   s.dropped,
+	}
+	s.mu.Unlock()
+
+	key := func(stat models.QueryStat) float64 {
+		switch sortBy {
+		case models.QueryStatsSortP95:
+			return stat.P95Ms
+		case models.QueryStatsSortMax:
+			return stat.MaxMs
+		case models.QueryStatsSortCalls:
+			return float64(stat.Calls)
+		}
+		return stat.TotalMs
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if ki, kj := key(stats[i]), key(stats[j]); ki != kj {
+			return ki > kj
+		}
+		return stats[i].Query < stats[j].Query
+	})
+	if limit > 0 && len(stats) > limit {
+		stats = stats[:limit]
+	}
+	response.Queries = stats
+	return response
+}
+
+// Reset clears every statement's stats
+func (s *QueryStats) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queries = make(map[string]*queryStat)
+	s.dropped = 0
+	s.since = s.now()
+}
+
+// normalizeQuery collapses whitespace so the same statement written with
+// different indentation is counted once
+func normalizeQuery(query string) string {
+	query = strings.Join(strings.Fields(query), " ")
+	if len(query) > maxQueryTextLength {
+		query = query[:maxQueryTextLength]
+	}
+	return query
+}
+
+// percentile returns the p-th percentile of samples by nearest rank
+func percentile(samples []time.Duration, p float64) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+func TestQueryStats_TopAndReset(t *testing.T) {
+	stats := NewQueryStats()
+	for i := 1; i <= 20; i++ {
+		stats.Record("SELECT *\n\t\tFROM builds WHERE id = $1", time.Duration(i)*time.Millisecond, nil)
+	}
+	stats.Record("SELECT * FROM builds WHERE id = $1", time.Millisecond, errors.New("boom"))
+	stats.Record("UPDATE users SET name = $1", 500*time.Millisecond, nil)
+
+	report := stats.Top(10, models.QueryStatsSortCalls)
+	if report.TrackedQueries != 2 || len(report.Queries) != 2 {
+		t.Fatalf("report = %+v, want two statements", report)
+	}
+	builds := report.Queries[0]
+	if builds.Query != "SELECT * FROM builds WHERE id = $1" {
+		t.Errorf("query text = %q, whitespace should be collapsed", builds.Query)
+	}
+	if builds.Calls != 21 || builds.Errors != 1 {
+		t.Errorf("calls = %d errors = %d, want 21 and 1", builds.Calls, builds.Errors)
+	}
+	if builds.P95Ms != 19 || builds.MaxMs != 20 {
+		t.Errorf("p95 = %v max = %v, want 19 and 20", builds.P95Ms, builds.MaxMs)
+	}
+
+	if top := stats.Top(1, models.QueryStatsSortMax); len(top.Queries) != 1 || top.Queries[0].Query != "UPDATE users SET name = $1" {
+		t.Errorf("slowest statement = %+v", top.Queries)
+	}
+
+	stats.Reset()
+	if report := stats.Top(10, models.QueryStatsSortTotal); report.TrackedQueries != 0 || len(report.Queries) != 0 {
+		t.Errorf("after reset = %+v", report)
+	}
+}
+
+func TestQueryStats_DropsPastLimit(t *testing.T) {
+	stats := NewQueryStats()
+	for i := 0; i < maxTrackedQueries; i++ {
+		stats.Record("SELECT "+string(rune('a'+i%26))+time.Duration(i).String(), time.Millisecond, nil)
+	}
+	stats.Record("SELECT one_too_many", time.Millisecond, nil)
+
+	report := stats.Top(0, models.QueryStatsSortTotal)
+	if report.TrackedQueries != maxTrackedQueries || report.DroppedCalls != 1 {
+		t.Errorf("tracked = %d dropped = %d", report.TrackedQueries, report.DroppedCalls)
+	}
+}
+
+func TestQueryStats_NilRecordsNothing(t *testing.T) {
+	var stats *QueryStats
+	stats.Record("SELECT 1", time.Millisecond, nil)
+}
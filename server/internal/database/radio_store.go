@@ -3,11 +3,18 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/johnrirwin/flyingforge/internal/models"
 )
 
+// ErrRadioNotFound is returned when a radio doesn't exist or isn't the user's
+var ErrRadioNotFound = errors.New("radio not found")
+
+// ErrRadioBackupNotFound is returned when a backup doesn't exist
+var ErrRadioBackupNotFound = errors.New("backup not found")
+
 // RadioStore handles radio database operations
 type RadioStore struct {
 	db *DB
@@ -179,7 +186,7 @@ func (s *RadioStore) UpdateRadio(ctx context.Context, id string, userID string,
 		return nil, err
 	}
 	if existing == nil {
-		return nil, fmt.Errorf("radio not found")
+		return nil, ErrRadioNotFound
 	}
 
 	// Build update query dynamically
@@ -253,28 +260,29 @@ func (s *RadioStore) DeleteRadio(ctx context.Context, id string, userID string)
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("radio not found")
+		return ErrRadioNotFound
 	}
 
 	return nil
 }
 
 // CreateBackup creates a new backup record
-func (s *RadioStore) CreateBackup(ctx context.Context, radioID string, params models.CreateRadioBackupParams, storagePath string) (*models.RadioBackup, error) {
+func (s *RadioStore) CreateBackup(ctx context.Context, radioID string, params models.CreateRadioBackupParams, storageBackend, storagePath string) (*models.RadioBackup, error) {
 	query := `
-		INSERT INTO radio_backups (radio_id, backup_name, backup_type, file_name, file_size, checksum, storage_path)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO radio_backups (radio_id, backup_name, backup_type, file_name, file_size, checksum, storage_path, storage_backend)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id, created_at
 	`
 
 	backup := &models.RadioBackup{
-		RadioID:     radioID,
-		BackupName:  params.BackupName,
-		BackupType:  params.BackupType,
-		FileName:    params.FileName,
-		FileSize:    params.FileSize,
-		Checksum:    params.Checksum,
-		StoragePath: storagePath,
+		RadioID:        radioID,
+		BackupName:     params.BackupName,
+		BackupType:     params.BackupType,
+		FileName:       params.FileName,
+		FileSize:       params.FileSize,
+		Checksum:       params.Checksum,
+		StoragePath:    storagePath,
+		StorageBackend: storageBackend,
 	}
 
 	err := s.db.QueryRowContext(ctx, query,
@@ -285,6 +293,7 @@ func (s *RadioStore) CreateBackup(ctx context.Context, radioID string, params mo
 		backup.FileSize,
 		nullString(backup.Checksum),
 		backup.StoragePath,
+		nullString(backup.StorageBackend),
 	).Scan(&backup.ID, &backup.CreatedAt)
 
 	if err != nil {
@@ -297,7 +306,7 @@ func (s *RadioStore) CreateBackup(ctx context.Context, radioID string, params mo
 // GetBackup retrieves a backup by ID
 func (s *RadioStore) GetBackup(ctx context.Context, id string, radioID string) (*models.RadioBackup, error) {
 	query := `
-		SELECT id, radio_id, backup_name, backup_type, file_name, file_size, checksum, storage_path, created_at
+		SELECT id, radio_id, backup_name, backup_type, file_name, file_size, checksum, storage_path, COALESCE(storage_backend, ''), created_at
 		FROM radio_backups
 		WHERE id = $1 AND radio_id = $2
 	`
@@ -314,6 +323,7 @@ func (s *RadioStore) GetBackup(ctx context.Context, id string, radioID string) (
 		&backup.FileSize,
 		&checksum,
 		&backup.StoragePath,
+		&backup.StorageBackend,
 		&backup.CreatedAt,
 	)
 
@@ -351,7 +361,7 @@ func (s *RadioStore) ListBackups(ctx context.Context, radioID string, params mod
 	}
 
 	query := `
-		SELECT id, radio_id, backup_name, backup_type, file_name, file_size, checksum, storage_path, created_at
+		SELECT id, radio_id, backup_name, backup_type, file_name, file_size, checksum, storage_path, COALESCE(storage_backend, ''), created_at
 		FROM radio_backups
 		WHERE radio_id = $1
 		ORDER BY created_at DESC
@@ -378,6 +388,7 @@ func (s *RadioStore) ListBackups(ctx context.Context, radioID string, params mod
 			&backup.FileSize,
 			&checksum,
 			&backup.StoragePath,
+			&backup.StorageBackend,
 			&backup.CreatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan backup: %w", err)
@@ -404,7 +415,7 @@ func (s *RadioStore) DeleteBackup(ctx context.Context, id string, radioID string
 		return nil, err
 	}
 	if backup == nil {
-		return nil, fmt.Errorf("backup not found")
+		return nil, ErrRadioBackupNotFound
 	}
 
 	query := `DELETE FROM radio_backups WHERE id = $1 AND radio_id = $2`
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// RecommendationStore persists catalog item co-occurrence: how often two
+// items turn up together in one pilot's inventory or in one build
+type RecommendationStore struct {
+	db *DB
+}
+
+// NewRecommendationStore creates a new recommendation store
+func NewRecommendationStore(db *DB) *RecommendationStore {
+	return &RecommendationStore{db: db}
+}
+
+// Recompute replaces every co-occurrence row. Each pilot's active
+// inventory and each saved build is one basket of catalog items. Pairs
+// seen in fewer than minShared baskets are dropped, so no recommendation
+// points back at a single pilot's setup. Scores are the cosine similarity
+// of the two items' baskets (shared / sqrt(countA * countB)), which keeps
+// items that are in everything from topping every list. Only the top
+// perItem related items are kept for each item.
+func (s *RecommendationStore) Recompute(ctx context.Context, minShared, perItem int) (int64, error) {
+	var rows int64
+	err := s.db.RunInTx(ctx, func(ctx context.Context) error {
+		if _, err := s.db.ExecContext(ctx, `DELETE FROM catalog_item_cooccurrence`); err != nil {
+			return fmt.Errorf("failed to clear co-occurrence: %w", err)
+		}
+
+		result, err := s.db.ExecContext(ctx, `
+			WITH baskets AS (
+				SELECT DISTINCT 'i:' || user_id::text AS basket, catalog_id AS item_id
+				FROM inventory_items
+				WHERE catalog_id IS NOT NULL AND user_id IS NOT NULL AND archived_at IS NULL
+				UNION
+				SELECT DISTINCT 'b:' || bp.build_id::text, bp.catalog_item_id
+				FROM build_parts bp
+				JOIN builds b ON b.id = bp.build_id
+				WHERE bp.catalog_item_id IS NOT NULL AND b.owner_user_id IS NOT NULL
+			),
+			item_counts AS (
+				SELECT item_id, COUNT(*) AS baskets FROM baskets GROUP BY item_id
+			),
+			pairs AS (
+				SELECT a.item_id, b.item_id AS related_item_id, COUNT(*) AS shared_count
+				FROM baskets a
+				JOIN baskets b ON b.basket = a.basket AND b.item_id <> a.item_id
+				GROUP BY a.item_id, b.item_id
+				HAVING COUNT(*) >= $1
+			),
+			scored AS (
+				SELECT p.item_id, p.related_item_id, p.shared_count,
+				       p.shared_count / SQRT(ca.baskets::float8 * cb.baskets::float8) AS score
+				FROM pairs p
+				JOIN item_counts ca ON ca.item_id = p.item_id
+				JOIN item_counts cb ON cb.item_id = p.related_item_id
+			),
+			ranked AS (
+				SELECT *, ROW_NUMBER() OVER (PARTITION BY item_id ORDER BY score DESC, shared_count DESC) AS rank
+				FROM scored
+			)
+			INSERT INTO catalog_item_cooccurrence (item_id, related_item_id, shared_count, score)
+			SELECT item_id, related_item_id, shared_count, score FROM ranked WHERE rank <= $2
+		`, minShared, perItem)
+		if err != nil {
+			return fmt.Errorf("failed to compute co-occurrence: %w", err)
+		}
+		rows, _ = result.RowsAffected()
+		return nil
+	})
+	return rows, err
+}
+
+// ListRelated returns the published items most often used with itemID,
+// best first, and when they were computed
+func (s *RecommendationStore) ListRelated(ctx context.Context, itemID string, limit int) ([]models.CatalogRecommendation, *time.Time, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT gc.id, gc.gear_type, gc.brand, gc.model, COALESCE(gc.variant, ''), gc.status,
+		       CASE WHEN gc.image_asset_id IS NOT NULL OR gc.image_data IS NOT NULL THEN '/api/gear-catalog/' || gc.id || '/image?v=' || (EXTRACT(EPOCH FROM COALESCE(gc.image_curated_at, gc.updated_at))*1000)::bigint ELSE '' END,
+		       c.shared_count, c.score, c.computed_at
+		FROM catalog_item_cooccurrence c
+		JOIN gear_catalog gc ON gc.id = c.related_item_id
+		WHERE c.item_id = $1 AND gc.status = 'published'
+		ORDER BY c.score DESC, c.shared_count DESC
+		LIMIT $2
+	`, itemID, limit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list recommendations: %w", err)
+	}
+	defer rows.Close()
+
+	recommendations := make([]models.CatalogRecommendation, 0)
+	var computedAt sql.NullTime
+	for rows.Next() {
+		var rec models.CatalogRecommendation
+		if err := rows.Scan(&rec.Item.ID, &rec.Item.GearType, &rec.Item.Brand, &rec.Item.Model, &rec.Item.Variant,
+			&rec.Item.Status, &rec.Item.ImageURL, &rec.SharedCount, &rec.Score, &computedAt); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan recommendation: %w", err)
+		}
+		recommendations = append(recommendations, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to list recommendations: %w", err)
+	}
+	if !computedAt.Valid {
+		return recommendations, nil, nil
+	}
+	return recommendations, &computedAt.Time, nil
+}
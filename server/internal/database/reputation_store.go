@@ -0,0 +1,54 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ReputationStore counts the catalog contributions reputation is built from
+type ReputationStore struct {
+	db *DB
+}
+
+// NewReputationStore creates a new reputation store
+func NewReputationStore(db *DB) *ReputationStore {
+	return &ReputationStore{db: db}
+}
+
+// ContributionStats counts a user's catalog submissions by status and their
+// gear images by outcome
+func (s *ReputationStore) ContributionStats(ctx context.Context, userID string) (*models.ContributionStats, error) {
+	var stats models.ContributionStats
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FILTER (WHERE status = 'published'),
+		       COUNT(*) FILTER (WHERE status = 'pending'),
+		       COUNT(*) FILTER (WHERE status = 'removed'),
+		       (SELECT COUNT(*) FROM gear_catalog gc
+		        JOIN image_assets ia ON ia.id = gc.image_asset_id
+		        WHERE ia.owner_user_id = $1 AND gc.image_status = 'approved'),
+		       (SELECT COUNT(*) FROM image_assets
+		        WHERE owner_user_id = $1 AND entity_type = 'gear' AND status = 'REJECTED')
+		FROM gear_catalog
+		WHERE created_by_user_id = $1
+	`, userID).Scan(&stats.SubmissionsPublished, &stats.SubmissionsPending, &stats.SubmissionsRemoved,
+		&stats.ImagesApproved, &stats.ImagesRejected)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count contributions: %w", err)
+	}
+	return &stats, nil
+}
+
+// CountSubmissionsSince counts the catalog items a user created after since
+func (s *ReputationStore) CountSubmissionsSince(ctx context.Context, userID string, since time.Time) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM gear_catalog WHERE created_by_user_id = $1 AND created_at > $2
+	`, userID, since).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recent submissions: %w", err)
+	}
+	return count, nil
+}
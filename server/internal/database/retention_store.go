@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// retentionPurges holds, per category, a CTE named deleted that removes rows
+// older than each user's retention and returns their user_id. Reads of the
+// category's data that aggregate over rows must account for what's purged.
+var retentionPurges = map[models.RetentionCategory]string{
+	// Purged logs' cycles and latest time are folded into the battery so
+	// its cycle count and last use don't change
+	models.RetentionBatteryLogs: `
+		deleted AS (
+			DELETE FROM battery_logs l
+			USING user_retention_settings s
+			WHERE s.category = 'battery_logs' AND s.user_id = l.user_id
+			  AND l.logged_at < NOW() - make_interval(days => s.retain_days)
+			RETURNING l.user_id, l.battery_id, l.cycle_delta, l.logged_at
+		), folded AS (
+			UPDATE batteries b
+			SET purged_cycles = b.purged_cycles + d.cycles,
+			    purged_last_logged_at = GREATEST(b.purged_last_logged_at, d.last_logged)
+			FROM (
+				SELECT battery_id, COALESCE(SUM(cycle_delta), 0) AS cycles, MAX(logged_at) AS last_logged
+				FROM deleted GROUP BY battery_id
+			) d
+			WHERE b.id = d.battery_id
+		)`,
+}
+
+// RetentionStore handles per-user data retention settings and purging
+type RetentionStore struct {
+	db *DB
+}
+
+// NewRetentionStore creates a new retention store
+func NewRetentionStore(db *DB) *RetentionStore {
+	return &RetentionStore{db: db}
+}
+
+// List returns a user's retention settings. Categories without a row keep
+// data forever and aren't returned.
+func (s *RetentionStore) List(ctx context.Context, userID string) ([]models.RetentionSetting, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT category, retain_days, last_purged_at, last_purged_count
+		FROM user_retention_settings
+		WHERE user_id = $1
+		ORDER BY category
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list retention settings: %w", err)
+	}
+	defer rows.Close()
+
+	settings := make([]models.RetentionSetting, 0)
+	for rows.Next() {
+		var setting models.RetentionSetting
+		var retainDays int
+		var lastPurgedAt sql.NullTime
+		if err := rows.Scan(&setting.Category, &retainDays, &lastPurgedAt, &setting.LastPurgedCount); err != nil {
+			return nil, fmt.Errorf("failed to scan retention setting: %w", err)
+		}
+		setting.RetainDays = &retainDays
+		if lastPurgedAt.Valid {
+			setting.LastPurgedAt = &lastPurgedAt.Time
+		}
+		settings = append(settings, setting)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list retention settings: %w", err)
+	}
+	return settings, nil
+}
+
+// Set changes a user's retention for one category. A nil retainDays keeps
+// the data forever.
+func (s *RetentionStore) Set(ctx context.Context, userID string, category models.RetentionCategory, retainDays *int) error {
+	var err error
+	if retainDays == nil {
+		_, err = s.db.ExecContext(ctx, `DELETE FROM user_retention_settings WHERE user_id = $1 AND category = $2`, userID, category)
+	} else {
+		_, err = s.db.ExecContext(ctx, `
+			INSERT INTO user_retention_settings (user_id, category, retain_days)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (user_id, category) DO UPDATE SET retain_days = EXCLUDED.retain_days, updated_at = NOW()
+		`, userID, category, *retainDays)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set retention: %w", err)
+	}
+	return nil
+}
+
+// Purge deletes one category's rows older than each user's retention and
+// records the count on each affected user's setting.
+func (s *RetentionStore) Purge(ctx context.Context, category models.RetentionCategory) (*models.RetentionPurgeResult, error) {
+	purge, ok := retentionPurges[category]
+	if !ok {
+		return nil, fmt.Errorf("no purge defined for retention category %q", category)
+	}
+
+	result := &models.RetentionPurgeResult{Category: category}
+	err := s.db.QueryRowContext(ctx, `
+		WITH `+purge+`, counts AS (
+			SELECT user_id, COUNT(*) AS n FROM deleted GROUP BY user_id
+		), marked AS (
+			UPDATE user_retention_settings s
+			SET last_purged_at = NOW(), last_purged_count = counts.n
+			FROM counts
+			WHERE s.user_id = counts.user_id AND s.category = $1
+		)
+		SELECT COUNT(*), COALESCE(SUM(n), 0) FROM counts
+	`, category).Scan(&result.Users, &result.Deleted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge %s: %w", category, err)
+	}
+	return result, nil
+}
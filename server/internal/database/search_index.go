@@ -0,0 +1,16 @@
+package database
+
+import (
+	"context"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// SearchIndex is an external full-text index (Meilisearch or Typesense)
+// mirroring published catalog items and builds. Stores with one configured
+// ask it for matching IDs, then load the rows themselves, so anything the
+// index still holds after it was unpublished drops out of results. Any
+// error from the index falls back to Postgres full-text search.
+type SearchIndex interface {
+	Search(ctx context.Context, query models.SearchIndexQuery) (*models.SearchIndexResult, error)
+}
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const shortLinkColumns = `slug, target_type, target_id, click_count, last_clicked_at, created_at`
+
+// ShortLinkStore handles short link persistence
+type ShortLinkStore struct {
+	db *DB
+}
+
+// NewShortLinkStore creates a new short link store
+func NewShortLinkStore(db *DB) *ShortLinkStore {
+	return &ShortLinkStore{db: db}
+}
+
+// Create inserts a short link. Returns nil without an error if the slug or the
+// target already has a link, so callers can retry or look up the existing one.
+func (s *ShortLinkStore) Create(ctx context.Context, slug string, targetType models.ShortLinkTargetType, targetID string) (*models.ShortLink, error) {
+	query := `
+		INSERT INTO short_links (slug, target_type, target_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+		RETURNING ` + shortLinkColumns
+
+	link, err := scanShortLink(s.db.QueryRowContext(ctx, query, slug, targetType, targetID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create short link: %w", err)
+	}
+	return link, nil
+}
+
+// GetByTarget retrieves the short link for a build or catalog item
+func (s *ShortLinkStore) GetByTarget(ctx context.Context, targetType models.ShortLinkTargetType, targetID string) (*models.ShortLink, error) {
+	query := `SELECT ` + shortLinkColumns + ` FROM short_links WHERE target_type = $1 AND target_id = $2`
+
+	link, err := scanShortLink(s.db.QueryRowContext(ctx, query, targetType, targetID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get short link: %w", err)
+	}
+	return link, nil
+}
+
+// RecordClick counts a visit to a slug of the given target type and returns its link
+func (s *ShortLinkStore) RecordClick(ctx context.Context, targetType models.ShortLinkTargetType, slug string) (*models.ShortLink, error) {
+	query := `
+		UPDATE short_links
+		SET click_count = click_count + 1, last_clicked_at = NOW()
+		WHERE slug = $1 AND target_type = $2
+		RETURNING ` + shortLinkColumns
+
+	link, err := scanShortLink(s.db.QueryRowContext(ctx, query, slug, targetType))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to record short link click: %w", err)
+	}
+	return link, nil
+}
+
+func scanShortLink(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.ShortLink, error) {
+	var link models.ShortLink
+	var lastClickedAt sql.NullTime
+
+	if err := scanner.Scan(
+		&link.Slug, &link.TargetType, &link.TargetID, &link.ClickCount, &lastClickedAt, &link.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if lastClickedAt.Valid {
+		link.LastClickedAt = &lastClickedAt.Time
+	}
+	return &link, nil
+}
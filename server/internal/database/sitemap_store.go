@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// SitemapStore lists the public pages that belong in the sitemap
+type SitemapStore struct {
+	db *DB
+}
+
+// NewSitemapStore creates a new sitemap store
+func NewSitemapStore(db *DB) *SitemapStore {
+	return &SitemapStore{db: db}
+}
+
+// PublishedBuilds returns every published build
+func (s *SitemapStore) PublishedBuilds(ctx context.Context) ([]models.SitemapEntry, error) {
+	return s.entries(ctx, "builds", `
+		SELECT id, COALESCE(updated_at, published_at, created_at)
+		FROM builds
+		WHERE status = 'PUBLISHED'
+		ORDER BY published_at DESC NULLS LAST
+	`)
+}
+
+// PublishedCatalogItems returns every published gear catalog item
+func (s *SitemapStore) PublishedCatalogItems(ctx context.Context) ([]models.SitemapEntry, error) {
+	return s.entries(ctx, "catalog items", `
+		SELECT id, COALESCE(updated_at, created_at)
+		FROM gear_catalog
+		WHERE status = 'published'
+		ORDER BY usage_count DESC, updated_at DESC
+	`)
+}
+
+// PublicPilots returns active pilots with a callsign and a public,
+// searchable profile. Pilots who opted out of search are left out.
+func (s *SitemapStore) PublicPilots(ctx context.Context) ([]models.SitemapEntry, error) {
+	return s.entries(ctx, "pilots", `
+		SELECT id, COALESCE(updated_at, created_at)
+		FROM users
+		WHERE status = 'active'
+		  AND call_sign IS NOT NULL
+		  AND call_sign != ''
+		  AND COALESCE(profile_visibility, 'public') = 'public'
+		  AND (allow_search IS NULL OR allow_search = true)
+		ORDER BY updated_at DESC NULLS LAST
+	`)
+}
+
+func (s *SitemapStore) entries(ctx context.Context, label, query string) ([]models.SitemapEntry, error) {
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sitemap %s: %w", label, err)
+	}
+	defer rows.Close()
+
+	entries := make([]models.SitemapEntry, 0)
+	for rows.Next() {
+		var entry models.SitemapEntry
+		if err := rows.Scan(&entry.ID, &entry.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan sitemap %s: %w", label, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list sitemap %s: %w", label, err)
+	}
+	return entries, nil
+}
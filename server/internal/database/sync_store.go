@@ -0,0 +1,164 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// syncTables maps sync entity types to the tables that back them
+var syncTables = map[models.SyncEntityType]string{
+	models.SyncEntityInventory: "inventory_items",
+	models.SyncEntityAircraft:  "aircraft",
+	models.SyncEntityBattery:   "batteries",
+}
+
+// SyncStore reads change sequences and tombstones for the offline sync API
+type SyncStore struct {
+	db *DB
+}
+
+// NewSyncStore creates a new sync store
+func NewSyncStore(db *DB) *SyncStore {
+	return &SyncStore{db: db}
+}
+
+// ChangesSince returns up to limit changes for the user with a sequence greater
+// than since, oldest first. Sequence values are stamped as each transaction
+// commits (see migrationSyncChangeFeed), so a change that becomes visible later
+// always has a higher sequence than one a reader has already passed. Entries
+// reference entities by ID only; callers load the current copy of non-deleted
+// entities themselves.
+func (s *SyncStore) ChangesSince(ctx context.Context, userID string, since int64, types []models.SyncEntityType, limit int) ([]models.SyncChange, error) {
+	if len(types) == 0 {
+		types = models.AllSyncEntityTypes()
+	}
+
+	selects := make([]string, 0, len(types)+1)
+	tombstoneTypes := make([]string, 0, len(types))
+	for _, entityType := range types {
+		table, ok := syncTables[entityType]
+		if !ok {
+			return nil, fmt.Errorf("unsupported sync entity type %q", entityType)
+		}
+		selects = append(selects, fmt.Sprintf(`
+			SELECT '%s' AS entity_type, id, sync_seq AS seq, FALSE AS deleted, sync_changed_at AS changed_at
+			FROM %s
+			WHERE user_id = $1 AND sync_seq > $2`, entityType, table))
+		tombstoneTypes = append(tombstoneTypes, "'"+string(entityType)+"'")
+	}
+	selects = append(selects, fmt.Sprintf(`
+		SELECT entity_type, entity_id, seq, TRUE, deleted_at
+		FROM sync_tombstones
+		WHERE user_id = $1 AND seq > $2 AND entity_type IN (%s)`, strings.Join(tombstoneTypes, ", ")))
+
+	query := strings.Join(selects, "\n\t\tUNION ALL") + "\n\t\tORDER BY seq\n\t\tLIMIT $3"
+
+	rows, err := s.db.QueryContext(ctx, query, userID, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sync changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := make([]models.SyncChange, 0)
+	for rows.Next() {
+		var change models.SyncChange
+		if err := rows.Scan(&change.EntityType, &change.ID, &change.Seq, &change.Deleted, &change.ChangedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan sync change: %w", err)
+		}
+		change.Cursor = models.FormatSyncCursor(change.Seq)
+		changes = append(changes, change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list sync changes: %w", err)
+	}
+	return changes, nil
+}
+
+// CurrentSeq returns the change sequence of an entity owned by the user.
+// Returns found=false if the entity does not exist for the user.
+func (s *SyncStore) CurrentSeq(ctx context.Context, entityType models.SyncEntityType, id, userID string) (int64, bool, error) {
+	table, ok := syncTables[entityType]
+	if !ok {
+		return 0, false, fmt.Errorf("unsupported sync entity type %q", entityType)
+	}
+
+	var seq sql.NullInt64
+	query := fmt.Sprintf(`SELECT sync_seq FROM %s WHERE id = $1 AND user_id = $2`, table)
+	err := s.db.QueryRowContext(ctx, query, id, userID).Scan(&seq)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get sync sequence: %w", err)
+	}
+	return seq.Int64, true, nil
+}
+
+// GetClientRef returns the entity previously created for a client reference, if any
+func (s *SyncStore) GetClientRef(ctx context.Context, userID, clientRef string) (models.SyncEntityType, string, error) {
+	var entityType models.SyncEntityType
+	var entityID sql.NullString
+	err := s.db.QueryRowContext(ctx,
+		`SELECT entity_type, entity_id FROM sync_client_refs WHERE user_id = $1 AND client_ref = $2`,
+		userID, clientRef,
+	).Scan(&entityType, &entityID)
+	if err == sql.ErrNoRows {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get sync client ref: %w", err)
+	}
+	return entityType, entityID.String, nil
+}
+
+// ReserveClientRef claims a client reference for an entity about to be created.
+// It returns false when the reference is already taken; a concurrent claim
+// blocks until the other transaction finishes, so callers creating the entity
+// in the same unit of work never create it twice.
+func (s *SyncStore) ReserveClientRef(ctx context.Context, userID, clientRef string, entityType models.SyncEntityType) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO sync_client_refs (user_id, client_ref, entity_type)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, client_ref) DO NOTHING
+	`, userID, clientRef, entityType)
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve sync client ref: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve sync client ref: %w", err)
+	}
+	return n == 1, nil
+}
+
+// SaveClientRef records the entity created for a reserved client reference
+func (s *SyncStore) SaveClientRef(ctx context.Context, userID, clientRef string, entityType models.SyncEntityType, entityID string) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE sync_client_refs SET entity_id = $4
+		WHERE user_id = $1 AND client_ref = $2 AND entity_type = $3
+	`, userID, clientRef, entityType, entityID)
+	if err != nil {
+		return fmt.Errorf("failed to save sync client ref: %w", err)
+	}
+	return nil
+}
+
+// RunInTx runs fn as a unit of work, so a client reference is reserved and
+// its entity created together
+func (s *SyncStore) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return s.db.RunInTx(ctx, fn)
+}
+
+// DeleteTombstones removes every tombstone recorded for a user. Deleting a
+// user cascades to their synced entities, whose delete triggers record
+// tombstones nobody can read any more.
+func (s *SyncStore) DeleteTombstones(ctx context.Context, userID string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM sync_tombstones WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete sync tombstones: %w", err)
+	}
+	return nil
+}
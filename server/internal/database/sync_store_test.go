@@ -0,0 +1,160 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+// seedSyncUser creates a user with two batteries and returns their IDs
+func seedSyncUser(ctx context.Context, t *testing.T, testDB *testutil.TestDB) (userID, first, second string) {
+	t.Helper()
+	if err := testDB.QueryRowContext(ctx, `
+		INSERT INTO users (email, display_name) VALUES ($1, 'Sync Test') RETURNING id
+	`, "sync-"+uuid.NewString()[:8]+"@example.com").Scan(&userID); err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	t.Cleanup(func() {
+		testDB.ExecContext(context.Background(), `DELETE FROM users WHERE id = $1`, userID)
+		testDB.ExecContext(context.Background(), `DELETE FROM sync_tombstones WHERE user_id = $1`, userID)
+	})
+
+	ids := make([]string, 2)
+	for i, code := range []string{"B1", "B2"} {
+		if err := testDB.QueryRowContext(ctx, `
+			INSERT INTO batteries (user_id, battery_code, chemistry, cells, capacity_mah)
+			VALUES ($1, $2, 'lipo', 6, 1300)
+			RETURNING id
+		`, userID, code).Scan(&ids[i]); err != nil {
+			t.Fatalf("seed battery: %v", err)
+		}
+	}
+	return userID, ids[0], ids[1]
+}
+
+func TestSyncStore_ChangesSince_CommitOrder(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Close()
+	store := NewSyncStore(&DB{DB: testDB.DB})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	userID, first, second := seedSyncUser(ctx, t, testDB)
+	types := []models.SyncEntityType{models.SyncEntityBattery}
+
+	changes, err := store.ChangesSince(ctx, userID, 0, types, 100)
+	if err != nil {
+		t.Fatalf("ChangesSince() error = %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("got %d changes, want the 2 seeded batteries", len(changes))
+	}
+	cursor := changes[len(changes)-1].Seq
+
+	// The first transaction writes before the second but commits after it
+	early, err := testDB.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer early.Rollback()
+	if _, err := early.ExecContext(ctx, `UPDATE batteries SET notes = 'early' WHERE id = $1`, first); err != nil {
+		t.Fatalf("early update: %v", err)
+	}
+	late, err := testDB.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer late.Rollback()
+	if _, err := late.ExecContext(ctx, `UPDATE batteries SET notes = 'late' WHERE id = $1`, second); err != nil {
+		t.Fatalf("late update: %v", err)
+	}
+	if err := late.Commit(); err != nil {
+		t.Fatalf("late commit: %v", err)
+	}
+
+	changes, err = store.ChangesSince(ctx, userID, cursor, types, 100)
+	if err != nil {
+		t.Fatalf("ChangesSince() error = %v", err)
+	}
+	if len(changes) != 1 || changes[0].ID != second {
+		t.Fatalf("changes after the late commit = %+v, want only %s", changes, second)
+	}
+	cursor = changes[0].Seq
+
+	if err := early.Commit(); err != nil {
+		t.Fatalf("early commit: %v", err)
+	}
+
+	changes, err = store.ChangesSince(ctx, userID, cursor, types, 100)
+	if err != nil {
+		t.Fatalf("ChangesSince() error = %v", err)
+	}
+	if len(changes) != 1 || changes[0].ID != first {
+		t.Fatalf("changes after the early commit = %+v, want %s past the cursor", changes, first)
+	}
+}
+
+func TestSyncStore_ReserveClientRef_Concurrent(t *testing.T) {
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Close()
+	db := &DB{DB: testDB.DB}
+	store := NewSyncStore(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	userID, batteryID, _ := seedSyncUser(ctx, t, testDB)
+
+	reserved := make(chan struct{})
+	release := make(chan struct{})
+	firstErr := make(chan error, 1)
+	go func() {
+		firstErr <- db.RunInTx(ctx, func(ctx context.Context) error {
+			ok, err := store.ReserveClientRef(ctx, userID, "local-1", models.SyncEntityBattery)
+			if err != nil || !ok {
+				t.Errorf("first ReserveClientRef() = %v, %v; want true", ok, err)
+			}
+			close(reserved)
+			<-release
+			return store.SaveClientRef(ctx, userID, "local-1", models.SyncEntityBattery, batteryID)
+		})
+	}()
+
+	<-reserved
+	secondDone := make(chan bool, 1)
+	go func() {
+		ok, err := store.ReserveClientRef(ctx, userID, "local-1", models.SyncEntityBattery)
+		if err != nil {
+			t.Errorf("second ReserveClientRef() error = %v", err)
+		}
+		secondDone <- ok
+	}()
+
+	// The second reservation waits for the first transaction
+	select {
+	case <-secondDone:
+		t.Fatal("second reservation returned before the first committed")
+	case <-time.After(200 * time.Millisecond):
+	}
+	close(release)
+	if err := <-firstErr; err != nil {
+		t.Fatalf("first transaction: %v", err)
+	}
+	if ok := <-secondDone; ok {
+		t.Fatal("second ReserveClientRef() = true, want the existing reservation")
+	}
+
+	entityType, entityID, err := store.GetClientRef(ctx, userID, "local-1")
+	if err != nil {
+		t.Fatalf("GetClientRef() error = %v", err)
+	}
+	if entityType != models.SyncEntityBattery || entityID != batteryID {
+		t.Errorf("GetClientRef() = %q, %q; want battery %s", entityType, entityID, batteryID)
+	}
+}
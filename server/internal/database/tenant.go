@@ -0,0 +1,240 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTenantID is the tenant that owns all data in a single-tenant
+// deployment and everything created before tenancy was enabled
+const DefaultTenantID = "00000000-0000-0000-0000-000000000001"
+
+// allTenantsScope is the app.tenant_id value that sees every tenant's rows
+const allTenantsScope = "*"
+
+type tenantContextKey struct{}
+
+// WithTenant returns a context whose queries are scoped to a tenant
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// AllTenants returns a context whose queries see every tenant's rows. It is
+// reserved for migrations, background jobs and admin commands; a request
+// that resolves a tenant replaces it with WithTenant.
+func AllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, allTenantsScope)
+}
+
+// Detached returns a background context with ctx's tenant scope, for work
+// that outlives ctx such as a goroutine a request starts. It drops ctx's
+// deadline, cancellation and unit of work.
+func Detached(ctx context.Context) context.Context {
+	scope, _ := ctx.Value(tenantContextKey{}).(string)
+	if scope == "" {
+		return context.Background()
+	}
+	return context.WithValue(context.Background(), tenantContextKey{}, scope)
+}
+
+// TenantFromContext returns the tenant queries in ctx are scoped to, or ""
+// when they aren't scoped to one
+func TenantFromContext(ctx context.Context) string {
+	tenantID, _ := ctx.Value(tenantContextKey{}).(string)
+	if tenantID == allTenantsScope {
+		return ""
+	}
+	return tenantID
+}
+
+// tenantScope returns the app.tenant_id value for queries made with ctx.
+// Queries without a tenant are scoped to the default tenant, so seeing
+// every tenant always takes an explicit AllTenants.
+func tenantScope(ctx context.Context) string {
+	if scope, _ := ctx.Value(tenantContextKey{}).(string); scope != "" {
+		return scope
+	}
+	return DefaultTenantID
+}
+
+// Tenant isolation is enforced by Postgres row level security on the
+// app.tenant_id setting. Every query runs on the one shared pool: each
+// connection is a tenantConn, which sets app.tenant_id from the statement's
+// context before running it whenever the connection was last scoped
+// differently. A pooled connection therefore never carries one tenant's
+// scope into another tenant's query, and the pool's size bounds the
+// connections however many tenants there are.
+
+// QueryContext runs a query scoped to the context's tenant, or in the
+// context's unit of work (see tx.go)
+func (db *DB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := db.conn(ctx).QueryContext(ctx, query, args...)
+	db.stats.Record(query, time.Since(start), err)
+	return rows, err
+}
+
+// QueryRowContext runs a single-row query scoped to the context's tenant,
+// or in the context's unit of work
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	row := db.conn(ctx).QueryRowContext(ctx, query, args...)
+	db.stats.Record(query, time.Since(start), row.Err())
+	return row
+}
+
+// ExecContext runs a statement scoped to the context's tenant, or in the
+// context's unit of work
+func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	result, err := db.conn(ctx).ExecContext(ctx, query, args...)
+	db.stats.Record(query, time.Since(start), err)
+	return result, err
+}
+
+// tenantConnector opens connections that scope each statement to the
+// tenant in its context
+type tenantConnector struct {
+	driver.Connector
+}
+
+func (c tenantConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tenantConn{Conn: conn}, nil
+}
+
+// tenantConn is a driver connection that knows which app.tenant_id it was
+// last scoped to. database/sql uses a connection from one goroutine at a
+// time, so the field needs no lock.
+type tenantConn struct {
+	driver.Conn
+	scope string // "" until set, and after a transaction that may have reverted it
+}
+
+// useScope scopes the connection to the tenant in ctx. It runs before a
+// transaction begins or outside one, where a session-level set_config
+// can't be undone by a rollback.
+func (c *tenantConn) useScope(ctx context.Context) error {
+	scope := tenantScope(ctx)
+	if scope == c.scope {
+		return nil
+	}
+	// Only UUIDs and the all-tenants marker reach the setting
+	if scope != allTenantsScope {
+		if _, err := uuid.Parse(scope); err != nil {
+			return fmt.Errorf("invalid tenant id %q", scope)
+		}
+	}
+
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return fmt.Errorf("database driver can't scope connections to a tenant")
+	}
+	c.scope = ""
+	if _, err := execer.ExecContext(ctx, `SELECT set_config('app.tenant_id', $1, false)`,
+		[]driver.NamedValue{{Ordinal: 1, Value: scope}}); err != nil {
+		return fmt.Errorf("failed to scope connection to tenant: %w", err)
+	}
+	c.scope = scope
+	return nil
+}
+
+func (c *tenantConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	if err := c.useScope(ctx); err != nil {
+		return nil, err
+	}
+	return queryer.QueryContext(ctx, query, args)
+}
+
+func (c *tenantConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	if err := c.useScope(ctx); err != nil {
+		return nil, err
+	}
+	return execer.ExecContext(ctx, query, args)
+}
+
+func (c *tenantConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if err := c.useScope(ctx); err != nil {
+		return nil, err
+	}
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *tenantConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	beginner, ok := c.Conn.(driver.ConnBeginTx)
+	if !ok {
+		return nil, fmt.Errorf("database driver doesn't support BeginTx")
+	}
+	if err := c.useScope(ctx); err != nil {
+		return nil, err
+	}
+	tx, err := beginner.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &tenantTx{Tx: tx, conn: c}, nil
+}
+
+func (c *tenantConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *tenantConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *tenantConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *tenantConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// tenantTx forgets its connection's scope when it ends: a statement inside
+// the transaction could have changed it, and a rollback would revert that
+type tenantTx struct {
+	driver.Tx
+	conn *tenantConn
+}
+
+func (tx *tenantTx) Commit() error {
+	tx.conn.scope = ""
+	return tx.Tx.Commit()
+}
+
+func (tx *tenantTx) Rollback() error {
+	tx.conn.scope = ""
+	return tx.Tx.Rollback()
+}
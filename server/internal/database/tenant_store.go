@@ -0,0 +1,151 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ErrTenantConflict is returned when a tenant's slug or one of its hostnames
+// is already used by another tenant
+var ErrTenantConflict = errors.New("tenant slug or hostname already in use")
+
+const tenantColumns = `id, slug, name, hostnames, branding, features, created_at, updated_at`
+
+// TenantStore handles white-label tenants. The tenants table isn't tenant
+// scoped, so every tenant is visible whichever pool runs the query.
+type TenantStore struct {
+	db *DB
+}
+
+// NewTenantStore creates a new tenant store
+func NewTenantStore(db *DB) *TenantStore {
+	return &TenantStore{db: db}
+}
+
+// List returns every tenant, default first
+func (s *TenantStore) List(ctx context.Context) ([]models.Tenant, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+tenantColumns+` FROM tenants ORDER BY id = $1 DESC, slug`, DefaultTenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	defer rows.Close()
+
+	tenants := make([]models.Tenant, 0)
+	for rows.Next() {
+		tenant, err := scanTenant(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tenant: %w", err)
+		}
+		tenants = append(tenants, *tenant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	return tenants, nil
+}
+
+// Create adds a tenant
+func (s *TenantStore) Create(ctx context.Context, params models.SaveTenantParams) (*models.Tenant, error) {
+	branding, features, err := marshalTenantConfig(params)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, err := scanTenant(s.db.QueryRowContext(ctx, `
+		INSERT INTO tenants (slug, name, hostnames, branding, features)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (slug) DO NOTHING
+		RETURNING `+tenantColumns,
+		params.Slug, params.Name, pq.Array(params.Hostnames), branding, features,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTenantConflict
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tenant: %w", err)
+	}
+	return tenant, nil
+}
+
+// Update replaces a tenant's settings, returning nil if it doesn't exist
+func (s *TenantStore) Update(ctx context.Context, id string, params models.SaveTenantParams) (*models.Tenant, error) {
+	branding, features, err := marshalTenantConfig(params)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, err := scanTenant(s.db.QueryRowContext(ctx, `
+		UPDATE tenants
+		SET slug = $2, name = $3, hostnames = $4, branding = $5, features = $6, updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+tenantColumns,
+		id, params.Slug, params.Name, pq.Array(params.Hostnames), branding, features,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update tenant: %w", err)
+	}
+	return tenant, nil
+}
+
+// Conflicts reports whether a tenant other than excludeID already uses the
+// slug or one of the hostnames
+func (s *TenantStore) Conflicts(ctx context.Context, excludeID, slug string, hostnames []string) (bool, error) {
+	var taken bool
+	err := s.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM tenants
+			WHERE (slug = $1 OR hostnames && $2) AND id::text <> $3
+		)
+	`, slug, pq.Array(hostnames), excludeID).Scan(&taken)
+	if err != nil {
+		return false, fmt.Errorf("failed to check tenant hostnames: %w", err)
+	}
+	return taken, nil
+}
+
+func marshalTenantConfig(params models.SaveTenantParams) ([]byte, []byte, error) {
+	branding, err := json.Marshal(params.Branding)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode tenant branding: %w", err)
+	}
+	features := params.Features
+	if features == nil {
+		features = map[models.TenantFeature]bool{}
+	}
+	featureJSON, err := json.Marshal(features)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode tenant features: %w", err)
+	}
+	return branding, featureJSON, nil
+}
+
+func scanTenant(row interface{ Scan(...interface{}) error }) (*models.Tenant, error) {
+	var tenant models.Tenant
+	var branding, features []byte
+	if err := row.Scan(
+		&tenant.ID, &tenant.Slug, &tenant.Name, pq.Array(&tenant.Hostnames), &branding, &features,
+		&tenant.CreatedAt, &tenant.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(branding, &tenant.Branding); err != nil {
+		return nil, fmt.Errorf("failed to decode tenant branding: %w", err)
+	}
+	if err := json.Unmarshal(features, &tenant.Features); err != nil {
+		return nil, fmt.Errorf("failed to decode tenant features: %w", err)
+	}
+	if tenant.Hostnames == nil {
+		tenant.Hostnames = []string{}
+	}
+	return &tenant, nil
+}
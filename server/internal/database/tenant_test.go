@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+)
+
+// scopeRecorder is a driver connection that records the tenant each
+// statement ran under
+type scopeRecorder struct {
+	scope string
+	ran   []string // "<scope> <query>" per statement
+}
+
+func (c *scopeRecorder) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if query == `SELECT set_config('app.tenant_id', $1, false)` {
+		c.scope = args[0].Value.(string)
+		c.ran = append(c.ran, "set "+c.scope)
+		return driver.RowsAffected(0), nil
+	}
+	c.ran = append(c.ran, c.scope+" "+query)
+	return driver.RowsAffected(1), nil
+}
+
+func (c *scopeRecorder) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	c.ran = append(c.ran, c.scope+" BEGIN")
+	return c, nil
+}
+
+func (c *scopeRecorder) Commit() error   { return nil }
+func (c *scopeRecorder) Rollback() error { return nil }
+
+func (c *scopeRecorder) Prepare(string) (driver.Stmt, error) { return nil, io.EOF }
+func (c *scopeRecorder) Close() error                        { return nil }
+func (c *scopeRecorder) Begin() (driver.Tx, error)           { return c, nil }
+
+type recorderConnector struct{ conn *scopeRecorder }
+
+func (c recorderConnector) Connect(context.Context) (driver.Conn, error) { return c.conn, nil }
+func (c recorderConnector) Driver() driver.Driver                        { return nil }
+
+func TestTenantConn_ScopesEachStatement(t *testing.T) {
+	rec := &scopeRecorder{}
+	pool := sql.OpenDB(tenantConnector{Connector: recorderConnector{conn: rec}})
+	pool.SetMaxOpenConns(1)
+	defer pool.Close()
+	db := &DB{DB: pool}
+
+	const tenantA = "0b8e8c5e-3f0a-4f5e-9a51-6a1f0f7d2a01"
+	ctx := context.Background()
+	exec := func(ctx context.Context, query string) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			t.Fatalf("ExecContext(%q) error = %v", query, err)
+		}
+	}
+
+	exec(WithTenant(ctx, tenantA), "q1")
+	exec(WithTenant(ctx, tenantA), "q2")
+	exec(ctx, "q3")
+	exec(AllTenants(ctx), "q4")
+	if err := db.RunInTx(WithTenant(ctx, tenantA), func(ctx context.Context) error {
+		exec(ctx, "q5")
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	exec(WithTenant(ctx, tenantA), "q6")
+
+	want := []string{
+		"set " + tenantA, tenantA + " q1", tenantA + " q2",
+		"set " + DefaultTenantID, DefaultTenantID + " q3",
+		"set *", "* q4",
+		"set " + tenantA, tenantA + " BEGIN", tenantA + " q5",
+		// A transaction may have reverted the setting, so it's set again
+		"set " + tenantA, tenantA + " q6",
+	}
+	if len(rec.ran) != len(want) {
+		t.Fatalf("ran %q, want %q", rec.ran, want)
+	}
+	for i := range want {
+		if rec.ran[i] != want[i] {
+			t.Fatalf("ran %q, want %q", rec.ran, want)
+		}
+	}
+
+	if _, err := db.ExecContext(WithTenant(ctx, "tenant-b'; --"), "q7"); err == nil {
+		t.Error("a tenant id that isn't a UUID reached the connection")
+	}
+}
+
+func TestDetached_KeepsTenantScope(t *testing.T) {
+	ctx, cancel := context.WithCancel(WithTenant(context.Background(), "tenant-a"))
+	cancel()
+	detached := Detached(ctx)
+	if detached.Err() != nil {
+		t.Errorf("Detached() carried over cancellation: %v", detached.Err())
+	}
+	if got := tenantScope(detached); got != "tenant-a" {
+		t.Errorf("Detached() scope = %q, want tenant-a", got)
+	}
+	if got := tenantScope(Detached(AllTenants(context.Background()))); got != allTenantsScope {
+		t.Errorf("Detached(AllTenants) scope = %q, want %q", got, allTenantsScope)
+	}
+}
+
+func TestTenantFromContext_AllTenantsIsUnscoped(t *testing.T) {
+	ctx := AllTenants(context.Background())
+	if got := TenantFromContext(ctx); got != "" {
+		t.Errorf("TenantFromContext(AllTenants) = %q, want \"\"", got)
+	}
+	if got := tenantScope(ctx); got != allTenantsScope {
+		t.Errorf("tenantScope(AllTenants) = %q, want %q", got, allTenantsScope)
+	}
+	if got := tenantScope(WithTenant(ctx, DefaultTenantID)); got != DefaultTenantID {
+		t.Errorf("WithTenant didn't replace AllTenants: scope %q", got)
+	}
+}
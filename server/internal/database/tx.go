@@ -0,0 +1,200 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// A unit of work runs every store call made with its context inside one
+// transaction, so a service operation that spans stores either lands
+// completely or not at all. Store methods don't take a transaction
+// parameter: DB's query methods pick up the unit's transaction from the
+// context, and BeginTx inside a unit opens a savepoint rather than a second
+// transaction. A failed statement inside a store's own BeginTx/Rollback only
+// rolls back to its savepoint, so callers that recover from a store error
+// can keep using the unit.
+//
+// A unit's transaction is a single connection. Its context must not be used
+// from more than one goroutine, and rows from one query must be closed
+// before the next query runs.
+
+type unitContextKey struct{}
+
+type unit struct {
+	tx *sql.Tx
+
+	mu        sync.Mutex
+	savepoint int
+	tags      map[string]struct{} // cache tags to invalidate once committed
+}
+
+func (u *unit) nextSavepoint() string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.savepoint++
+	return fmt.Sprintf("sp_%d", u.savepoint)
+}
+
+// invalidateAfterCommit queues a cache tag to drop once the unit commits, so
+// no one caches what it replaced in the meantime
+func (u *unit) invalidateAfterCommit(tag string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.tags == nil {
+		u.tags = make(map[string]struct{})
+	}
+	u.tags[tag] = struct{}{}
+}
+
+func unitFromContext(ctx context.Context) *unit {
+	u, _ := ctx.Value(unitContextKey{}).(*unit)
+	return u
+}
+
+// InTx reports whether queries made with ctx run inside a unit of work
+func InTx(ctx context.Context) bool {
+	return unitFromContext(ctx) != nil
+}
+
+// RunInTx runs fn as a unit of work. Every query made with the context
+// passed to fn joins the same transaction, which commits when fn returns nil
+// and rolls back when it returns an error or panics. Nested calls run in a
+// savepoint of the outer unit.
+func (db *DB) RunInTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	txCtx := ctx
+	var u *unit
+	if tx.savepoint == "" {
+		u = &unit{tx: tx.tx}
+		txCtx = context.WithValue(ctx, unitContextKey{}, u)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(txCtx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	if u != nil {
+		for tag := range u.tags {
+			db.invalidateTag(ctx, tag)
+		}
+	}
+	return nil
+}
+
+// Tx is a transaction begun with DB.BeginTx. Outside a unit of work it is a
+// plain database transaction; inside one it is a savepoint of the unit's
+// transaction, and Commit and Rollback release or roll back to that
+// savepoint.
+type Tx struct {
+	tx        *sql.Tx
+	savepoint string
+	done      bool
+	stats     *QueryStats
+}
+
+// BeginTx starts a transaction scoped to the context's tenant, or a
+// savepoint when the context carries a unit of work
+func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	if u := unitFromContext(ctx); u != nil {
+		name := u.nextSavepoint()
+		if _, err := u.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+			return nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+		return &Tx{tx: u.tx, savepoint: name, stats: db.stats}, nil
+	}
+
+	tx, err := db.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{tx: tx, stats: db.stats}, nil
+}
+
+// ExecContext runs a statement inside the transaction
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	result, err := tx.tx.ExecContext(ctx, query, args...)
+	tx.stats.Record(query, time.Since(start), err)
+	return result, err
+}
+
+// QueryContext runs a query inside the transaction
+func (tx *Tx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := tx.tx.QueryContext(ctx, query, args...)
+	tx.stats.Record(query, time.Since(start), err)
+	return rows, err
+}
+
+// QueryRowContext runs a single-row query inside the transaction
+func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	row := tx.tx.QueryRowContext(ctx, query, args...)
+	tx.stats.Record(query, time.Since(start), row.Err())
+	return row
+}
+
+// PrepareContext prepares a statement bound to the transaction
+func (tx *Tx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return tx.tx.PrepareContext(ctx, query)
+}
+
+// Commit commits the transaction or releases the savepoint
+func (tx *Tx) Commit() error {
+	if tx.done {
+		return sql.ErrTxDone
+	}
+	tx.done = true
+	if tx.savepoint == "" {
+		return tx.tx.Commit()
+	}
+	_, err := tx.tx.Exec("RELEASE SAVEPOINT " + tx.savepoint)
+	return err
+}
+
+// Rollback rolls back the transaction or to the savepoint. Calling it after
+// Commit is a no-op that returns sql.ErrTxDone, so it's safe to defer.
+func (tx *Tx) Rollback() error {
+	if tx.done {
+		return sql.ErrTxDone
+	}
+	tx.done = true
+	if tx.savepoint == "" {
+		return tx.tx.Rollback()
+	}
+	_, err := tx.tx.Exec("ROLLBACK TO SAVEPOINT " + tx.savepoint)
+	return err
+}
+
+// conn returns the unit's transaction for ctx, or the shared pool when ctx
+// has no unit of work
+func (db *DB) conn(ctx context.Context) queryer {
+	if u := unitFromContext(ctx); u != nil {
+		return u.tx
+	}
+	return db.DB
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestInTx(t *testing.T) {
+	ctx := context.Background()
+	if InTx(ctx) {
+		t.Error("background context should not be in a unit of work")
+	}
+	if !InTx(context.WithValue(ctx, unitContextKey{}, &unit{})) {
+		t.Error("context carrying a unit should be in a unit of work")
+	}
+}
+
+func TestUnitSavepointNames(t *testing.T) {
+	u := &unit{}
+	if got := u.nextSavepoint(); got != "sp_1" {
+		t.Errorf("first savepoint = %q, want sp_1", got)
+	}
+	if got := u.nextSavepoint(); got != "sp_2" {
+		t.Errorf("second savepoint = %q, want sp_2", got)
+	}
+}
+
+func TestTx_FinishedTxIsDone(t *testing.T) {
+	tx := &Tx{savepoint: "sp_1", done: true}
+	if err := tx.Commit(); !errors.Is(err, sql.ErrTxDone) {
+		t.Errorf("Commit() = %v, want sql.ErrTxDone", err)
+	}
+	if err := tx.Rollback(); !errors.Is(err, sql.ErrTxDone) {
+		t.Errorf("Rollback() = %v, want sql.ErrTxDone", err)
+	}
+}
@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// UploadStore handles resumable upload records. The chunks themselves are
+// kept in blob storage.
+type UploadStore struct {
+	db *DB
+}
+
+// NewUploadStore creates a new upload store
+func NewUploadStore(db *DB) *UploadStore {
+	return &UploadStore{db: db}
+}
+
+const uploadColumns = `id, user_id, purpose, file_name, size_bytes, offset_bytes, COALESCE(checksum, ''),
+	metadata, chunk_keys, status, COALESCE(result_id, ''), COALESCE(error, ''), expires_at, created_at, updated_at`
+
+// Create starts an upload
+func (s *UploadStore) Create(ctx context.Context, userID string, params models.CreateUploadParams, expiresAt time.Time) (*models.Upload, error) {
+	metadata, err := json.Marshal(params.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode upload metadata: %w", err)
+	}
+	upload, err := scanUpload(s.db.QueryRowContext(ctx, `
+		INSERT INTO uploads (user_id, purpose, file_name, size_bytes, checksum, metadata, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+uploadColumns,
+		userID, params.Purpose, params.FileName, params.Size, nullString(params.Checksum), metadata, expiresAt,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload: %w", err)
+	}
+	return upload, nil
+}
+
+// Get returns one of a user's uploads, or nil if it doesn't exist
+func (s *UploadStore) Get(ctx context.Context, id, userID string) (*models.Upload, error) {
+	upload, err := scanUpload(s.db.QueryRowContext(ctx, `
+		SELECT `+uploadColumns+` FROM uploads WHERE id = $1 AND user_id = $2
+	`, id, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upload: %w", err)
+	}
+	return upload, nil
+}
+
+// CountPending returns how many uploads a user has in progress
+func (s *UploadStore) CountPending(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM uploads WHERE user_id = $1 AND status = 'pending' AND expires_at > NOW()
+	`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pending uploads: %w", err)
+	}
+	return count, nil
+}
+
+// AppendChunk records a chunk stored under key that covers bytes [from, to).
+// It returns nil if the upload is no longer pending at from, such as when
+// another request stored a chunk there first.
+func (s *UploadStore) AppendChunk(ctx context.Context, id, userID string, from, to int64, key string, expiresAt time.Time) (*models.Upload, error) {
+	upload, err := scanUpload(s.db.QueryRowContext(ctx, `
+		UPDATE uploads
+		SET offset_bytes = $4, chunk_keys = array_append(chunk_keys, $5::text),
+		    expires_at = $6, updated_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND offset_bytes = $3 AND status = 'pending'
+		RETURNING `+uploadColumns,
+		id, userID, from, to, key, expiresAt,
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to record upload chunk: %w", err)
+	}
+	return upload, nil
+}
+
+// Finish records how an upload ended
+func (s *UploadStore) Finish(ctx context.Context, id string, status models.UploadStatus, resultID, errMessage string) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE uploads SET status = $2, result_id = $3, error = $4, updated_at = NOW()
+		WHERE id = $1
+	`, id, status, nullString(resultID), nullString(errMessage))
+	if err != nil {
+		return fmt.Errorf("failed to finish upload: %w", err)
+	}
+	return nil
+}
+
+// Delete removes one of a user's uploads and returns it, or nil if it
+// doesn't exist
+func (s *UploadStore) Delete(ctx context.Context, id, userID string) (*models.Upload, error) {
+	upload, err := scanUpload(s.db.QueryRowContext(ctx, `
+		DELETE FROM uploads WHERE id = $1 AND user_id = $2
+		RETURNING `+uploadColumns,
+		id, userID,
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete upload: %w", err)
+	}
+	return upload, nil
+}
+
+// DeleteExpired removes up to limit uploads that expired before now and
+// returns them so their chunks can be removed
+func (s *UploadStore) DeleteExpired(ctx context.Context, now time.Time, limit int) ([]models.Upload, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		DELETE FROM uploads
+		WHERE id IN (SELECT id FROM uploads WHERE expires_at < $1 ORDER BY expires_at LIMIT $2)
+		RETURNING `+uploadColumns,
+		now, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete expired uploads: %w", err)
+	}
+	defer rows.Close()
+
+	var uploads []models.Upload
+	for rows.Next() {
+		upload, err := scanUpload(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan upload: %w", err)
+		}
+		uploads = append(uploads, *upload)
+	}
+	return uploads, rows.Err()
+}
+
+func scanUpload(row interface{ Scan(...interface{}) error }) (*models.Upload, error) {
+	var upload models.Upload
+	var metadata []byte
+	var status string
+	if err := row.Scan(
+		&upload.ID, &upload.UserID, &upload.Purpose, &upload.FileName, &upload.Size, &upload.Offset, &upload.Checksum,
+		&metadata, pq.Array(&upload.ChunkKeys), &status, &upload.ResultID, &upload.Error,
+		&upload.ExpiresAt, &upload.CreatedAt, &upload.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	upload.Status = models.UploadStatus(status)
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &upload.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to decode upload metadata: %w", err)
+		}
+	}
+	return &upload, nil
+}
@@ -0,0 +1,308 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// Errors returned when a user merge is refused
+var (
+	ErrMergeUserNotFound   = errors.New("user not found")
+	ErrMergeSameUser       = errors.New("source and target must be different users")
+	ErrMergeNotConfirmed   = errors.New("confirmSourceEmail does not match the source account")
+	ErrMergeAdminSource    = errors.New("admin accounts can't be merged into another account")
+	ErrMergeDisabledTarget = errors.New("target account is disabled or suspended")
+)
+
+// maxDuplicateCandidates bounds one duplicate scan
+const maxDuplicateCandidates = 200
+
+// duplicateCandidateQuery pairs accounts whose emails differ only in case,
+// and accounts holding a Google sign-in whose email is another account's
+const duplicateCandidateQuery = `
+	SELECT a.id, b.id, 'email_case'
+	FROM users a
+	JOIN users b ON LOWER(b.email) = LOWER(a.email) AND b.id > a.id
+	UNION
+	SELECT i.user_id, u.id, 'google_account'
+	FROM user_identities i
+	JOIN users owner ON owner.id = i.user_id
+	JOIN users u ON LOWER(u.email) = LOWER(i.provider_email) AND u.id <> i.user_id
+	WHERE LOWER(owner.email) <> LOWER(u.email)
+	LIMIT $1
+`
+
+// FindDuplicateUsers lists pairs of accounts that likely belong to the same
+// pilot: emails that differ only in case, and Google sign-ins linked to one
+// account that carry another account's email.
+func (s *UserStore) FindDuplicateUsers(ctx context.Context) ([]models.DuplicateUserCandidate, error) {
+	rows, err := s.db.QueryContext(ctx, duplicateCandidateQuery, maxDuplicateCandidates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate users: %w", err)
+	}
+	defer rows.Close()
+
+	type pair struct {
+		a, b   string
+		reason models.DuplicateUserReason
+	}
+	var pairs []pair
+	seen := make(map[string]bool)
+	var ids []string
+	for rows.Next() {
+		var p pair
+		if err := rows.Scan(&p.a, &p.b, &p.reason); err != nil {
+			return nil, fmt.Errorf("failed to scan duplicate users: %w", err)
+		}
+		pairs = append(pairs, p)
+		for _, id := range []string{p.a, p.b} {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read duplicate users: %w", err)
+	}
+	if len(pairs) == 0 {
+		return []models.DuplicateUserCandidate{}, nil
+	}
+
+	summaries, err := s.duplicateSummaries(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]models.DuplicateUserCandidate, 0, len(pairs))
+	for _, p := range pairs {
+		a, okA := summaries[p.a]
+		b, okB := summaries[p.b]
+		if !okA || !okB {
+			continue
+		}
+		users := []models.DuplicateUserSummary{a, b}
+		sort.SliceStable(users, func(i, j int) bool { return users[i].CreatedAt.Before(users[j].CreatedAt) })
+		candidates = append(candidates, models.DuplicateUserCandidate{Reason: p.reason, Users: users})
+	}
+	return candidates, nil
+}
+
+func (s *UserStore) duplicateSummaries(ctx context.Context, ids []string) (map[string]models.DuplicateUserSummary, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT u.id, u.email, u.display_name, COALESCE(u.call_sign, ''), u.status, u.created_at, u.last_login_at,
+			(SELECT COUNT(*) FROM inventory_items i WHERE i.user_id = u.id),
+			(SELECT COUNT(*) FROM aircraft a WHERE a.user_id = u.id),
+			(SELECT COUNT(*) FROM builds b WHERE b.owner_user_id = u.id AND b.status <> 'TEMP')
+		FROM users u
+		WHERE u.id = ANY($1::uuid[])
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load duplicate users: %w", err)
+	}
+	defer rows.Close()
+
+	summaries := make(map[string]models.DuplicateUserSummary, len(ids))
+	for rows.Next() {
+		var summary models.DuplicateUserSummary
+		var lastLogin sql.NullTime
+		if err := rows.Scan(
+			&summary.ID, &summary.Email, &summary.DisplayName, &summary.CallSign, &summary.Status,
+			&summary.CreatedAt, &lastLogin,
+			&summary.InventoryCount, &summary.AircraftCount, &summary.BuildCount,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan duplicate user: %w", err)
+		}
+		if lastLogin.Valid {
+			summary.LastLoginAt = &lastLogin.Time
+		}
+		summaries[summary.ID] = summary
+	}
+	return summaries, rows.Err()
+}
+
+// checkMergeAllowed applies the guards on a merge. The admin has to type the
+// source account's email, and admin accounts can't be merged away.
+func checkMergeAllowed(source, target *models.User, confirmEmail string) error {
+	if source == nil || target == nil {
+		return ErrMergeUserNotFound
+	}
+	if source.ID == target.ID {
+		return ErrMergeSameUser
+	}
+	if !strings.EqualFold(strings.TrimSpace(confirmEmail), source.Email) {
+		return ErrMergeNotConfirmed
+	}
+	if source.IsAdmin {
+		return ErrMergeAdminSource
+	}
+	if target.Status == models.UserStatusDisabled || target.Status == models.UserStatusSuspended {
+		return ErrMergeDisabledTarget
+	}
+	return nil
+}
+
+// MergeUsers moves everything the source account owns to the target account
+// in one transaction: inventory, aircraft, radios, batteries, FC configs,
+// builds, follows, and Google sign-ins, so the pilot can sign in with either.
+// Inventory of a catalog item the target already has is added to the
+// target's quantity. Battery codes the target already uses get a suffix. The
+// source account is then disabled and signed out. Group memberships and
+// event registrations stay with the source account.
+func (s *UserStore) MergeUsers(ctx context.Context, params models.MergeUsersParams, adminUserID string) (*models.UserMergeResult, error) {
+	if params.SourceUserID == params.TargetUserID {
+		return nil, ErrMergeSameUser
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	source, err := lockMergeUser(ctx, tx, params.SourceUserID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := lockMergeUser(ctx, tx, params.TargetUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMergeAllowed(source, target, params.ConfirmSourceEmail); err != nil {
+		return nil, err
+	}
+
+	result := &models.UserMergeResult{SourceUserID: source.ID, TargetUserID: target.ID}
+	from, to := source.ID, target.ID
+
+	// Inventory the target already has: fold the quantity in, point anything
+	// that referenced the source's item at the target's, then drop it
+	if _, err := tx.ExecContext(ctx, `
+		CREATE TEMP TABLE merge_inventory ON COMMIT DROP AS
+		SELECT s.id AS source_id, t.id AS target_id, s.quantity
+		FROM inventory_items s
+		JOIN inventory_items t ON t.user_id = $2 AND t.catalog_id = s.catalog_id
+		WHERE s.user_id = $1 AND s.catalog_id IS NOT NULL
+	`, from, to); err != nil {
+		return nil, fmt.Errorf("failed to match inventory: %w", err)
+	}
+	for _, stmt := range []string{
+		`UPDATE inventory_items t SET quantity = t.quantity + m.quantity, updated_at = NOW() FROM merge_inventory m WHERE t.id = m.target_id`,
+		`UPDATE aircraft_components c SET inventory_item_id = m.target_id FROM merge_inventory m WHERE c.inventory_item_id = m.source_id`,
+		`UPDATE fc_configs c SET inventory_item_id = m.target_id FROM merge_inventory m WHERE c.inventory_item_id = m.source_id`,
+		`UPDATE aircraft_tuning_snapshots t SET flight_controller_id = m.target_id FROM merge_inventory m WHERE t.flight_controller_id = m.source_id`,
+		`UPDATE inventory_attachments a SET inventory_item_id = m.target_id FROM merge_inventory m WHERE a.inventory_item_id = m.source_id`,
+		`UPDATE aircraft_registrations r SET remote_id_item_id = m.target_id FROM merge_inventory m WHERE r.remote_id_item_id = m.source_id`,
+	} {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return nil, fmt.Errorf("failed to combine inventory: %w", err)
+		}
+	}
+	combined, err := execCount(ctx, tx, `DELETE FROM inventory_items WHERE id IN (SELECT source_id FROM merge_inventory)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove combined inventory: %w", err)
+	}
+	result.InventoryItemsCombined = combined
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE batteries s SET battery_code = LEFT(s.battery_code, 15) || '-' || UPPER(SUBSTR(MD5(s.id::text), 1, 4))
+		WHERE s.user_id = $1
+		  AND EXISTS (SELECT 1 FROM batteries t WHERE t.user_id = $2 AND t.battery_code = s.battery_code)
+	`, from, to); err != nil {
+		return nil, fmt.Errorf("failed to rename battery codes: %w", err)
+	}
+
+	// Drop follows the target already has, or that would be the pilot
+	// following themselves
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM follows f
+		WHERE (f.follower_user_id = $1 AND (f.followed_user_id = $2 OR EXISTS (
+			SELECT 1 FROM follows t WHERE t.follower_user_id = $2 AND t.followed_user_id = f.followed_user_id)))
+		   OR (f.followed_user_id = $1 AND (f.follower_user_id = $2 OR EXISTS (
+			SELECT 1 FROM follows t WHERE t.followed_user_id = $2 AND t.follower_user_id = f.follower_user_id)))
+	`, from, to); err != nil {
+		return nil, fmt.Errorf("failed to remove duplicate follows: %w", err)
+	}
+
+	var followersMoved int
+	moves := []struct {
+		query string
+		count *int
+	}{
+		{`UPDATE inventory_items SET user_id = $2, updated_at = NOW() WHERE user_id = $1`, &result.InventoryItems},
+		{`UPDATE aircraft SET user_id = $2, updated_at = NOW() WHERE user_id = $1`, &result.Aircraft},
+		{`UPDATE radios SET user_id = $2, updated_at = NOW() WHERE user_id = $1`, &result.Radios},
+		{`UPDATE batteries SET user_id = $2, updated_at = NOW() WHERE user_id = $1`, &result.Batteries},
+		{`UPDATE battery_logs SET user_id = $2 WHERE user_id = $1`, nil},
+		{`UPDATE fc_configs SET user_id = $2, updated_at = NOW() WHERE user_id = $1`, &result.FCConfigs},
+		{`UPDATE inventory_attachments SET user_id = $2 WHERE user_id = $1`, nil},
+		{`UPDATE orders SET user_id = $2 WHERE user_id = $1`, nil},
+		{`UPDATE builds SET owner_user_id = $2, updated_at = NOW() WHERE owner_user_id = $1`, &result.Builds},
+		{`UPDATE image_assets SET owner_user_id = $2 WHERE owner_user_id = $1`, nil},
+		{`UPDATE push_devices SET user_id = $2 WHERE user_id = $1`, nil},
+		{`UPDATE follows SET follower_user_id = $2 WHERE follower_user_id = $1`, &result.Follows},
+		{`UPDATE follows SET followed_user_id = $2 WHERE followed_user_id = $1`, &followersMoved},
+		{`UPDATE user_identities SET user_id = $2 WHERE user_id = $1`, &result.Identities},
+	}
+	for _, move := range moves {
+		n, err := execCount(ctx, tx, move.query, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to move account data: %w", err)
+		}
+		if move.count != nil {
+			*move.count = n
+		}
+	}
+	result.Follows += followersMoved
+
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET status = 'disabled', updated_at = NOW() WHERE id = $1`, from); err != nil {
+		return nil, fmt.Errorf("failed to disable merged account: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, from); err != nil {
+		return nil, fmt.Errorf("failed to sign out merged account: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO user_merges (source_user_id, target_user_id, merged_by_user_id, source_email, merged_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, from, to, nullString(adminUserID), source.Email, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to record user merge: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return result, nil
+}
+
+// lockMergeUser loads the fields a merge checks and locks the user row
+func lockMergeUser(ctx context.Context, tx *Tx, id string) (*models.User, error) {
+	user := &models.User{ID: id}
+	err := tx.QueryRowContext(ctx, `
+		SELECT email, status, COALESCE(is_admin, FALSE) FROM users WHERE id = $1 FOR UPDATE
+	`, id).Scan(&user.Email, &user.Status, &user.IsAdmin)
+	if err == sql.ErrNoRows {
+		return nil, ErrMergeUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	return user, nil
+}
+
+func execCount(ctx context.Context, tx *Tx, query string, args ...interface{}) (int, error) {
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
@@ -0,0 +1,35 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+func TestCheckMergeAllowed(t *testing.T) {
+	source := &models.User{ID: "u1", Email: "Pilot@Example.com", Status: models.UserStatusActive}
+	target := &models.User{ID: "u2", Email: "pilot@example.com", Status: models.UserStatusActive}
+
+	tests := []struct {
+		name    string
+		source  *models.User
+		target  *models.User
+		confirm string
+		want    error
+	}{
+		{"allowed, email case ignored", source, target, " pilot@example.COM ", nil},
+		{"missing user", source, nil, source.Email, ErrMergeUserNotFound},
+		{"same user", source, source, source.Email, ErrMergeSameUser},
+		{"wrong confirmation", source, target, "someone@example.com", ErrMergeNotConfirmed},
+		{"admin source", &models.User{ID: "u3", Email: "a@example.com", IsAdmin: true}, target, "a@example.com", ErrMergeAdminSource},
+		{"disabled target", source, &models.User{ID: "u4", Status: models.UserStatusDisabled}, source.Email, ErrMergeDisabledTarget},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkMergeAllowed(tt.source, tt.target, tt.confirm); !errors.Is(err, tt.want) {
+				t.Errorf("checkMergeAllowed() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -10,6 +11,12 @@ import (
 	"github.com/johnrirwin/flyingforge/internal/models"
 )
 
+// ErrCallSignTaken is returned when another user already has the call sign
+var ErrCallSignTaken = errors.New("call sign already in use")
+
+// ErrCannotFollowSelf is returned when a user tries to follow themselves
+var ErrCannotFollowSelf = errors.New("cannot follow yourself")
+
 // UserStore handles user database operations
 type UserStore struct {
 	db *DB
@@ -42,14 +49,21 @@ func (s *UserStore) Create(ctx context.Context, params models.CreateUserParams)
 	var avatarURL, callSign, googleName, googleAvatarURL, customAvatarURL, avatarTypeStr, avatarImageAssetID sql.NullString
 	var lastLoginAt sql.NullTime
 
-	err := s.db.QueryRowContext(ctx, query,
-		email, params.DisplayName, nullString(params.CallSign), nullString(params.AvatarURL), status,
-		nullString(params.GoogleName), nullString(params.GoogleAvatarURL), string(avatarType),
-	).Scan(
-		&user.ID, &user.Email, &user.DisplayName, &avatarURL,
-		&user.Status, &user.CreatedAt, &user.UpdatedAt, &lastLoginAt,
-		&callSign, &googleName, &googleAvatarURL, &avatarTypeStr, &customAvatarURL, &avatarImageAssetID,
-	)
+	// The user.registered event commits with the user row
+	err := s.db.RunInTx(ctx, func(ctx context.Context) error {
+		err := s.db.QueryRowContext(ctx, query,
+			email, params.DisplayName, nullString(params.CallSign), nullString(params.AvatarURL), status,
+			nullString(params.GoogleName), nullString(params.GoogleAvatarURL), string(avatarType),
+		).Scan(
+			&user.ID, &user.Email, &user.DisplayName, &avatarURL,
+			&user.Status, &user.CreatedAt, &user.UpdatedAt, &lastLoginAt,
+			&callSign, &googleName, &googleAvatarURL, &avatarTypeStr, &customAvatarURL, &avatarImageAssetID,
+		)
+		if err != nil {
+			return err
+		}
+		return enqueueDomainEvent(ctx, s.db, models.DomainEventUserRegistered, user.ID, models.UserRegisteredPayload{UserID: user.ID})
+	})
 
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key") {
@@ -91,7 +105,8 @@ func (s *UserStore) GetByID(ctx context.Context, id string) (*models.User, error
 	query := `
 		SELECT id, email, display_name, avatar_url, status, created_at, updated_at, last_login_at,
 		       call_sign, google_name, google_avatar_url, avatar_type, custom_avatar_url, avatar_image_asset_id,
-		       profile_visibility, show_aircraft, allow_search, COALESCE(is_admin, FALSE), COALESCE(is_content_admin, is_gear_admin, FALSE)
+		       profile_visibility, show_aircraft, allow_search, COALESCE(is_admin, FALSE), COALESCE(is_content_admin, is_gear_admin, FALSE),
+		       status_reason, status_expires_at
 		FROM users
 		WHERE id = $1
 	`
@@ -105,7 +120,8 @@ func (s *UserStore) GetByEmail(ctx context.Context, email string) (*models.User,
 	query := `
 		SELECT id, email, display_name, avatar_url, status, created_at, updated_at, last_login_at,
 		       call_sign, google_name, google_avatar_url, avatar_type, custom_avatar_url, avatar_image_asset_id,
-		       profile_visibility, show_aircraft, allow_search, COALESCE(is_admin, FALSE), COALESCE(is_content_admin, is_gear_admin, FALSE)
+		       profile_visibility, show_aircraft, allow_search, COALESCE(is_admin, FALSE), COALESCE(is_content_admin, is_gear_admin, FALSE),
+		       status_reason, status_expires_at
 		FROM users
 		WHERE LOWER(email) = $1
 	`
@@ -119,7 +135,8 @@ func (s *UserStore) GetByCallSign(ctx context.Context, callSign string) (*models
 	query := `
 		SELECT id, email, display_name, avatar_url, status, created_at, updated_at, last_login_at,
 		       call_sign, google_name, google_avatar_url, avatar_type, custom_avatar_url, avatar_image_asset_id,
-		       profile_visibility, show_aircraft, allow_search, COALESCE(is_admin, FALSE), COALESCE(is_content_admin, is_gear_admin, FALSE)
+		       profile_visibility, show_aircraft, allow_search, COALESCE(is_admin, FALSE), COALESCE(is_content_admin, is_gear_admin, FALSE),
+		       status_reason, status_expires_at
 		FROM users
 		WHERE LOWER(call_sign) = $1
 	`
@@ -195,10 +212,15 @@ func (s *UserStore) Update(ctx context.Context, id string, params models.UpdateU
 		WHERE id = $%d
 		RETURNING id, email, display_name, avatar_url, status, created_at, updated_at, last_login_at,
 		          call_sign, google_name, google_avatar_url, avatar_type, custom_avatar_url, avatar_image_asset_id,
-		          profile_visibility, show_aircraft, allow_search, COALESCE(is_admin, FALSE), COALESCE(is_content_admin, is_gear_admin, FALSE)
+		          profile_visibility, show_aircraft, allow_search, COALESCE(is_admin, FALSE), COALESCE(is_content_admin, is_gear_admin, FALSE),
+		          status_reason, status_expires_at
 	`, strings.Join(sets, ", "), argIdx)
 
-	return s.scanUser(s.db.QueryRowContext(ctx, query, args...))
+	user, err := s.scanUser(s.db.QueryRowContext(ctx, query, args...))
+	if err != nil && strings.Contains(err.Error(), "duplicate key") {
+		return nil, ErrCallSignTaken
+	}
+	return user, err
 }
 
 // AdminUpdate updates admin-managed user fields (status and role flags).
@@ -211,6 +233,22 @@ func (s *UserStore) AdminUpdate(ctx context.Context, id string, params models.Ad
 		sets = append(sets, fmt.Sprintf("status = $%d", argIdx))
 		args = append(args, *params.Status)
 		argIdx++
+
+		// A status change replaces the reason and expiry; active accounts
+		// have neither
+		var reason sql.NullString
+		var expiresAt sql.NullTime
+		if *params.Status != models.UserStatusActive {
+			if params.StatusReason != nil {
+				reason = nullString(strings.TrimSpace(*params.StatusReason))
+			}
+			if *params.Status == models.UserStatusSuspended && params.StatusExpiresAt != nil {
+				expiresAt = sql.NullTime{Time: *params.StatusExpiresAt, Valid: true}
+			}
+		}
+		sets = append(sets, fmt.Sprintf("status_reason = $%d", argIdx), fmt.Sprintf("status_expires_at = $%d", argIdx+1))
+		args = append(args, reason, expiresAt)
+		argIdx += 2
 	}
 	if params.IsAdmin != nil {
 		sets = append(sets, fmt.Sprintf("is_admin = $%d", argIdx))
@@ -242,7 +280,8 @@ func (s *UserStore) AdminUpdate(ctx context.Context, id string, params models.Ad
 		WHERE id = $%d
 		RETURNING id, email, display_name, avatar_url, status, created_at, updated_at, last_login_at,
 		          call_sign, google_name, google_avatar_url, avatar_type, custom_avatar_url, avatar_image_asset_id,
-		          profile_visibility, show_aircraft, allow_search, COALESCE(is_admin, FALSE), COALESCE(is_content_admin, is_gear_admin, FALSE)
+		          profile_visibility, show_aircraft, allow_search, COALESCE(is_admin, FALSE), COALESCE(is_content_admin, is_gear_admin, FALSE),
+		          status_reason, status_expires_at
 	`, strings.Join(sets, ", "), argIdx)
 
 	return s.scanUser(s.db.QueryRowContext(ctx, query, args...))
@@ -261,15 +300,33 @@ func (s *UserStore) AdminClearAvatar(ctx context.Context, id string) (*models.Us
 		WHERE id = $2
 		RETURNING id, email, display_name, avatar_url, status, created_at, updated_at, last_login_at,
 		          call_sign, google_name, google_avatar_url, avatar_type, custom_avatar_url, avatar_image_asset_id,
-		          profile_visibility, show_aircraft, allow_search, COALESCE(is_admin, FALSE), COALESCE(is_content_admin, is_gear_admin, FALSE)
+		          profile_visibility, show_aircraft, allow_search, COALESCE(is_admin, FALSE), COALESCE(is_content_admin, is_gear_admin, FALSE),
+		          status_reason, status_expires_at
 	`
 
 	return s.scanUser(s.db.QueryRowContext(ctx, query, string(models.AvatarTypeGoogle), id))
 }
 
+// ReactivateExpired makes a suspended account active again once its
+// suspension has ended. It returns nil when the account isn't suspended or
+// the suspension is still running.
+func (s *UserStore) ReactivateExpired(ctx context.Context, id string) (*models.User, error) {
+	query := `
+		UPDATE users
+		SET status = 'active', status_reason = NULL, status_expires_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND status = 'suspended' AND status_expires_at <= NOW()
+		RETURNING id, email, display_name, avatar_url, status, created_at, updated_at, last_login_at,
+		          call_sign, google_name, google_avatar_url, avatar_type, custom_avatar_url, avatar_image_asset_id,
+		          profile_visibility, show_aircraft, allow_search, COALESCE(is_admin, FALSE), COALESCE(is_content_admin, is_gear_admin, FALSE),
+		          status_reason, status_expires_at
+	`
+
+	return s.scanUser(s.db.QueryRowContext(ctx, query, id))
+}
+
 // UpdateLastLogin updates the last login timestamp
 func (s *UserStore) UpdateLastLogin(ctx context.Context, id string) error {
-	query := `UPDATE users SET last_login_at = NOW(), updated_at = NOW() WHERE id = $1`
+	query := `UPDATE users SET last_login_at = NOW(), inactive_flagged_at = NULL, updated_at = NOW() WHERE id = $1`
 	_, err := s.db.ExecContext(ctx, query, id)
 	return err
 }
@@ -367,26 +424,8 @@ func (s *UserStore) SearchPilots(ctx context.Context, params models.PilotSearchP
 
 // List retrieves users with optional filtering
 func (s *UserStore) List(ctx context.Context, params models.UserFilterParams) (*models.UsersResponse, error) {
-	var where []string
-	var args []interface{}
-	argIdx := 1
-
-	if params.Status != "" {
-		where = append(where, fmt.Sprintf("status = $%d", argIdx))
-		args = append(args, params.Status)
-		argIdx++
-	}
-
-	if params.Query != "" {
-		where = append(where, fmt.Sprintf("(LOWER(email) LIKE $%d OR LOWER(display_name) LIKE $%d OR LOWER(COALESCE(call_sign, '')) LIKE $%d)", argIdx, argIdx, argIdx))
-		args = append(args, "%"+strings.ToLower(params.Query)+"%")
-		argIdx++
-	}
-
-	whereClause := ""
-	if len(where) > 0 {
-		whereClause = "WHERE " + strings.Join(where, " AND ")
-	}
+	whereClause, args := userFilterWhere(params)
+	argIdx := len(args) + 1
 
 	// Get total count
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM users %s", whereClause)
@@ -409,7 +448,8 @@ func (s *UserStore) List(ctx context.Context, params models.UserFilterParams) (*
 	query := fmt.Sprintf(`
 		SELECT id, email, display_name, avatar_url, status, created_at, updated_at, last_login_at,
 		       call_sign, google_name, google_avatar_url, avatar_type, custom_avatar_url, avatar_image_asset_id,
-		       profile_visibility, show_aircraft, allow_search, COALESCE(is_admin, FALSE), COALESCE(is_content_admin, is_gear_admin, FALSE)
+		       profile_visibility, show_aircraft, allow_search, COALESCE(is_admin, FALSE), COALESCE(is_content_admin, is_gear_admin, FALSE),
+		       status_reason, status_expires_at
 		FROM users %s
 		ORDER BY created_at DESC
 		LIMIT $%d OFFSET $%d
@@ -440,12 +480,102 @@ func (s *UserStore) List(ctx context.Context, params models.UserFilterParams) (*
 	}, nil
 }
 
+// userFilterWhere builds the WHERE clause shared by List and Export
+func userFilterWhere(params models.UserFilterParams) (string, []interface{}) {
+	var where []string
+	var args []interface{}
+
+	if params.Status != "" {
+		args = append(args, params.Status)
+		where = append(where, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	if params.Query != "" {
+		args = append(args, "%"+strings.ToLower(params.Query)+"%")
+		where = append(where, fmt.Sprintf("(LOWER(email) LIKE $%d OR LOWER(display_name) LIKE $%d OR LOWER(COALESCE(call_sign, '')) LIKE $%d)", len(args), len(args), len(args)))
+	}
+
+	if len(where) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(where, " AND "), args
+}
+
+// Export calls fn with every user matching params' filters, oldest first,
+// along with their role and contribution counts. Limit and Offset are
+// ignored. Rows are read as fn consumes them so large exports aren't held
+// in memory; an error from fn stops the export and is returned.
+func (s *UserStore) Export(ctx context.Context, params models.UserFilterParams, fn func(models.UserExportRow) error) error {
+	whereClause, args := userFilterWhere(params)
+	query := fmt.Sprintf(`
+		SELECT u.id, u.email, u.display_name, COALESCE(u.call_sign, ''), u.status, u.created_at, u.last_login_at,
+		       COALESCE(u.is_admin, FALSE), COALESCE(u.is_content_admin, u.is_gear_admin, FALSE),
+		       COALESCE(gc.submissions, 0), COALESCE(gc.published, 0),
+		       COALESCE(b.published, 0), COALESCE(img.approved, 0)
+		FROM users u
+		LEFT JOIN (
+			SELECT created_by_user_id AS user_id,
+			       COUNT(*) AS submissions,
+			       COUNT(*) FILTER (WHERE status = 'published') AS published
+			FROM gear_catalog
+			WHERE created_by_user_id IS NOT NULL
+			GROUP BY created_by_user_id
+		) gc ON gc.user_id = u.id
+		LEFT JOIN (
+			SELECT owner_user_id AS user_id, COUNT(*) AS published
+			FROM builds
+			WHERE status = 'PUBLISHED' AND owner_user_id IS NOT NULL
+			GROUP BY owner_user_id
+		) b ON b.user_id = u.id
+		LEFT JOIN (
+			SELECT ia.owner_user_id AS user_id, COUNT(*) AS approved
+			FROM gear_catalog c
+			JOIN image_assets ia ON ia.id = c.image_asset_id
+			WHERE c.image_status = 'approved'
+			GROUP BY ia.owner_user_id
+		) img ON img.user_id = u.id
+		%s
+		ORDER BY u.created_at, u.id
+	`, whereClause)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to export users: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.UserExportRow
+		var lastLoginAt sql.NullTime
+		var isAdmin, isContentAdmin bool
+		if err := rows.Scan(
+			&row.ID, &row.Email, &row.DisplayName, &row.CallSign, &row.Status, &row.CreatedAt, &lastLoginAt,
+			&isAdmin, &isContentAdmin,
+			&row.CatalogSubmissions, &row.CatalogPublished, &row.PublishedBuilds, &row.ApprovedGearImages,
+		); err != nil {
+			return fmt.Errorf("failed to scan exported user: %w", err)
+		}
+		if lastLoginAt.Valid {
+			row.LastLoginAt = &lastLoginAt.Time
+		}
+		row.Role = models.RoleFor(isAdmin, isContentAdmin)
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to export users: %w", err)
+	}
+	return nil
+}
+
 func (s *UserStore) scanUser(row *sql.Row) (*models.User, error) {
 	user := &models.User{}
 	var avatarURL, callSign, googleName, googleAvatarURL, avatarType, customAvatarURL, avatarImageAssetID sql.NullString
 	var profileVisibility sql.NullString
 	var showAircraft, allowSearch sql.NullBool
-	var lastLoginAt sql.NullTime
+	var lastLoginAt, statusExpiresAt sql.NullTime
+	var statusReason sql.NullString
 	var isAdmin, isContentAdmin bool
 
 	err := row.Scan(
@@ -453,6 +583,7 @@ func (s *UserStore) scanUser(row *sql.Row) (*models.User, error) {
 		&user.Status, &user.CreatedAt, &user.UpdatedAt, &lastLoginAt,
 		&callSign, &googleName, &googleAvatarURL, &avatarType, &customAvatarURL, &avatarImageAssetID,
 		&profileVisibility, &showAircraft, &allowSearch, &isAdmin, &isContentAdmin,
+		&statusReason, &statusExpiresAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -471,6 +602,10 @@ func (s *UserStore) scanUser(row *sql.Row) (*models.User, error) {
 	if lastLoginAt.Valid {
 		user.LastLoginAt = &lastLoginAt.Time
 	}
+	user.StatusReason = statusReason.String
+	if statusExpiresAt.Valid {
+		user.StatusExpiresAt = &statusExpiresAt.Time
+	}
 	if callSign.Valid {
 		user.CallSign = callSign.String
 	}
@@ -510,7 +645,8 @@ func (s *UserStore) scanUserFromRows(rows *sql.Rows) (*models.User, error) {
 	var avatarURL, callSign, googleName, googleAvatarURL, avatarType, customAvatarURL, avatarImageAssetID sql.NullString
 	var profileVisibility sql.NullString
 	var showAircraft, allowSearch sql.NullBool
-	var lastLoginAt sql.NullTime
+	var lastLoginAt, statusExpiresAt sql.NullTime
+	var statusReason sql.NullString
 	var isAdmin, isContentAdmin bool
 
 	err := rows.Scan(
@@ -518,6 +654,7 @@ func (s *UserStore) scanUserFromRows(rows *sql.Rows) (*models.User, error) {
 		&user.Status, &user.CreatedAt, &user.UpdatedAt, &lastLoginAt,
 		&callSign, &googleName, &googleAvatarURL, &avatarType, &customAvatarURL, &avatarImageAssetID,
 		&profileVisibility, &showAircraft, &allowSearch, &isAdmin, &isContentAdmin,
+		&statusReason, &statusExpiresAt,
 	)
 
 	if err != nil {
@@ -533,6 +670,10 @@ func (s *UserStore) scanUserFromRows(rows *sql.Rows) (*models.User, error) {
 	if lastLoginAt.Valid {
 		user.LastLoginAt = &lastLoginAt.Time
 	}
+	user.StatusReason = statusReason.String
+	if statusExpiresAt.Valid {
+		user.StatusExpiresAt = &statusExpiresAt.Time
+	}
 	if callSign.Valid {
 		user.CallSign = callSign.String
 	}
@@ -777,12 +918,35 @@ func (s *UserStore) UpdateSocialSettings(ctx context.Context, userID string, par
 	return err
 }
 
+// GetUnitSystem returns the unit system a user reads catalog specs in.
+// Unknown users get metric.
+func (s *UserStore) GetUnitSystem(ctx context.Context, userID string) (models.UnitSystem, error) {
+	var system string
+	err := s.db.QueryRowContext(ctx, `SELECT unit_system FROM users WHERE id = $1`, userID).Scan(&system)
+	if err == sql.ErrNoRows {
+		return models.UnitSystemMetric, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get unit system: %w", err)
+	}
+	return models.UnitSystem(system), nil
+}
+
+// SetUnitSystem sets the unit system a user reads catalog specs in
+func (s *UserStore) SetUnitSystem(ctx context.Context, userID string, system models.UnitSystem) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE users SET unit_system = $2, updated_at = NOW() WHERE id = $1`, userID, string(system))
+	if err != nil {
+		return fmt.Errorf("failed to set unit system: %w", err)
+	}
+	return nil
+}
+
 // Follow operations
 
 // CreateFollow creates a follow relationship between two users
 func (s *UserStore) CreateFollow(ctx context.Context, followerUserID, followedUserID string) (*models.Follow, error) {
 	if followerUserID == followedUserID {
-		return nil, fmt.Errorf("cannot follow yourself")
+		return nil, ErrCannotFollowSelf
 	}
 
 	query := `
@@ -1109,29 +1273,36 @@ func (s *UserStore) GetFeaturedPilots(ctx context.Context, excludeUserID string,
 	}, nil
 }
 
-// HardDelete permanently removes a user and all associated data.
-// Related data in other tables is handled by database CASCADE constraints:
+// HardDelete permanently removes a user and all associated data in one
+// transaction. Related data in other tables is handled by database CASCADE
+// constraints:
 //   - user_identities, refresh_tokens, inventory_items, aircraft, radios,
-//     batteries, battery_logs, follows, orders, fc_configs: CASCADE delete
+//     batteries, battery_logs, follows, orders, fc_configs, image_assets:
+//     CASCADE delete
 //   - gear_catalog.created_by_user_id: SET NULL (preserves catalog items)
+//
+// The sync tombstones the cascade leaves behind are removed in the same
+// transaction.
 func (s *UserStore) HardDelete(ctx context.Context, userID string) error {
-	query := `DELETE FROM users WHERE id = $1`
+	return s.db.RunInTx(ctx, func(ctx context.Context) error {
+		query := `DELETE FROM users WHERE id = $1`
 
-	result, err := s.db.ExecContext(ctx, query, userID)
-	if err != nil {
-		return fmt.Errorf("failed to delete user: %w", err)
-	}
+		result, err := s.db.ExecContext(ctx, query, userID)
+		if err != nil {
+			return fmt.Errorf("failed to delete user: %w", err)
+		}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to check delete result: %w", err)
-	}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to check delete result: %w", err)
+		}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("user not found")
-	}
+		if rowsAffected == 0 {
+			return fmt.Errorf("user not found")
+		}
 
-	return nil
+		return NewSyncStore(s.db).DeleteTombstones(ctx, userID)
+	})
 }
 
 func effectiveAvatarURLFromFields(avatarType, customAvatarURL, avatarImageAssetID, googleAvatarURL, avatarURL sql.NullString) string {
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ViewStore adds page views to the items they were counted for
+type ViewStore struct {
+	db *DB
+}
+
+// NewViewStore creates a new view store
+func NewViewStore(db *DB) *ViewStore {
+	return &ViewStore{db: db}
+}
+
+// viewTables maps each view target to the table holding its view_count
+var viewTables = map[models.ViewTarget]string{
+	models.ViewTargetBuild: "builds",
+	models.ViewTargetGear:  "gear_catalog",
+}
+
+// AddViews adds view counts, keyed by item ID, in one statement, and marks
+// the items as just viewed. IDs that no longer exist are ignored.
+func (s *ViewStore) AddViews(ctx context.Context, target models.ViewTarget, counts map[string]int64) error {
+	table, ok := viewTables[target]
+	if !ok {
+		return fmt.Errorf("unknown view target %q", target)
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(counts))
+	views := make([]int64, 0, len(counts))
+	for id, n := range counts {
+		ids = append(ids, id)
+		views = append(views, n)
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %s t SET view_count = t.view_count + v.n, last_viewed_at = NOW()
+		FROM unnest($1::uuid[], $2::bigint[]) AS v(id, n)
+		WHERE t.id = v.id
+	`, table)
+	if _, err := s.db.ExecContext(ctx, query, pq.Array(ids), pq.Array(views)); err != nil {
+		return fmt.Errorf("failed to add %s views: %w", target, err)
+	}
+	return nil
+}
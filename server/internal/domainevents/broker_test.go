@@ -0,0 +1,144 @@
+package domainevents
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fakeNATSServer accepts one connection, records CONNECT options and
+// published messages, and answers PINGs
+func fakeNATSServer(t *testing.T, reject bool) (addr string, received chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	received = make(chan string, 10)
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = conn.Write([]byte(`INFO {"server_id":"test","max_payload":1048576}` + "\r\n"))
+		reader := bufio.NewReader(conn)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(line, "CONNECT "):
+				received <- line
+			case strings.HasPrefix(line, "PUB "):
+				parts := strings.Fields(line)
+				size, _ := strconv.Atoi(parts[len(parts)-1])
+				payload := make([]byte, size+2)
+				if _, err := io.ReadFull(reader, payload); err != nil {
+					return
+				}
+				if reject {
+					_, _ = conn.Write([]byte("-ERR 'Permissions Violation for Publish'\r\n"))
+					continue
+				}
+				received <- parts[1] + " " + string(payload[:size])
+			case line == "PING":
+				_, _ = conn.Write([]byte("PONG\r\n"))
+			}
+		}
+	}()
+	return ln.Addr().String(), received
+}
+
+func TestNATSBrokerPublish(t *testing.T) {
+	addr, received := fakeNATSServer(t, false)
+	broker, err := NewNATSBroker("nats://relay:secret@" + addr)
+	if err != nil {
+		t.Fatalf("NewNATSBroker: %v", err)
+	}
+	defer broker.Close()
+
+	if err := broker.Publish(context.Background(), "flyingforge.user.registered", "user-1", []byte(`{"id":"evt-1"}`)); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	connect := <-received
+	var options map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(connect, "CONNECT ")), &options); err != nil {
+		t.Fatalf("decode CONNECT: %v", err)
+	}
+	if options["user"] != "relay" || options["pass"] != "secret" {
+		t.Fatalf("CONNECT options=%v", options)
+	}
+	if got := <-received; got != `flyingforge.user.registered {"id":"evt-1"}` {
+		t.Fatalf("published %q", got)
+	}
+}
+
+func TestNATSBrokerPublishServerError(t *testing.T) {
+	addr, _ := fakeNATSServer(t, true)
+	broker, err := NewNATSBroker("nats://" + addr)
+	if err != nil {
+		t.Fatalf("NewNATSBroker: %v", err)
+	}
+	defer broker.Close()
+
+	err = broker.Publish(context.Background(), "flyingforge.user.registered", "", []byte(`{}`))
+	if err == nil || !strings.Contains(err.Error(), "Permissions Violation") {
+		t.Fatalf("expected NATS error, got %v", err)
+	}
+}
+
+func TestKafkaRESTBrokerPublish(t *testing.T) {
+	var gotPath, gotContentType string
+	var gotBody struct {
+		Records []struct {
+			Key   string          `json:"key"`
+			Value json.RawMessage `json:"value"`
+		} `json:"records"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotContentType = r.Header.Get("Content-Type")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":0,"offset":12}]}`))
+	}))
+	defer server.Close()
+
+	broker := NewKafkaRESTBroker(server.URL + "/")
+	if err := broker.Publish(context.Background(), "flyingforge.build.published", "build-1", []byte(`{"id":"evt-1"}`)); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if gotPath != "/topics/flyingforge.build.published" {
+		t.Fatalf("path=%q", gotPath)
+	}
+	if gotContentType != "application/vnd.kafka.json.v2+json" {
+		t.Fatalf("content type=%q", gotContentType)
+	}
+	if len(gotBody.Records) != 1 || gotBody.Records[0].Key != "build-1" || string(gotBody.Records[0].Value) != `{"id":"evt-1"}` {
+		t.Fatalf("body=%+v", gotBody)
+	}
+}
+
+func TestKafkaRESTBrokerPublishRecordError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":null,"offset":null,"error_code":40403,"error":"topic not found"}]}`))
+	}))
+	defer server.Close()
+
+	err := NewKafkaRESTBroker(server.URL).Publish(context.Background(), "missing", "", []byte(`{}`))
+	if err == nil || !strings.Contains(err.Error(), "topic not found") {
+		t.Fatalf("expected record error, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package domainevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// KafkaRESTBroker publishes to Kafka through a REST proxy speaking the
+// Confluent v2 API, which avoids a native Kafka client dependency. Records
+// are keyed by the event's aggregate ID, so events about the same build or
+// user land on the same partition in order.
+type KafkaRESTBroker struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewKafkaRESTBroker creates a broker for the REST proxy at baseURL
+func NewKafkaRESTBroker(baseURL string) *KafkaRESTBroker {
+	return &KafkaRESTBroker{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type kafkaRecord struct {
+	Key   string          `json:"key,omitempty"`
+	Value json.RawMessage `json:"value"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+// Publish produces data, which must be JSON, as one record on topic
+func (b *KafkaRESTBroker) Publish(ctx context.Context, topic, key string, data []byte) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"records": []kafkaRecord{{Key: key, Value: json.RawMessage(data)}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode Kafka record: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/topics/"+url.PathEscape(topic), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Kafka request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to publish to Kafka: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("kafka REST proxy returned %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var produced kafkaProduceResponse
+	if err := json.Unmarshal(respBody, &produced); err == nil {
+		for _, offset := range produced.Offsets {
+			if offset.ErrorCode != nil {
+				return fmt.Errorf("kafka rejected record: %s", offset.Error)
+			}
+		}
+	}
+	return nil
+}
+
+// Close is a no-op; the REST proxy holds no connection state
+func (b *KafkaRESTBroker) Close() error {
+	return nil
+}
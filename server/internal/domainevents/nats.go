@@ -0,0 +1,184 @@
+package domainevents
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const natsDialTimeout = 5 * time.Second
+
+// NATSBroker publishes to a NATS server over its text protocol. Each publish
+// is followed by a PING, and returns once the server's PONG arrives, so a
+// message that returns nil has reached the server. The connection is
+// opened on first use and reopened after any error.
+type NATSBroker struct {
+	addr  string
+	user  string
+	pass  string
+	token string
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewNATSBroker creates a broker for a nats:// URL. Credentials in the URL
+// are sent as user and password, or as a token when there's no password.
+func NewNATSBroker(rawURL string) (*NATSBroker, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NATS URL: %w", err)
+	}
+	if u.Scheme != "nats" {
+		return nil, fmt.Errorf("NATS URL must use the nats:// scheme")
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "4222")
+	}
+
+	b := &NATSBroker{addr: addr}
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			b.user = u.User.Username()
+			b.pass = pass
+		} else {
+			b.token = u.User.Username()
+		}
+	}
+	return b, nil
+}
+
+// Publish sends data to subject. NATS has no partition keys, so key is
+// unused.
+func (b *NATSBroker) Publish(ctx context.Context, subject, key string, data []byte) error {
+	_ = key
+	if strings.ContainsAny(subject, " \t\r\n") || subject == "" {
+		return fmt.Errorf("invalid NATS subject %q", subject)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.connect(ctx); err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = b.conn.SetDeadline(deadline)
+	} else {
+		_ = b.conn.SetDeadline(time.Now().Add(natsDialTimeout))
+	}
+
+	msg := fmt.Sprintf("PUB %s %d\r\n%s\r\nPING\r\n", subject, len(data), data)
+	if _, err := b.conn.Write([]byte(msg)); err != nil {
+		b.reset()
+		return fmt.Errorf("failed to publish to NATS: %w", err)
+	}
+	if err := b.awaitPong(); err != nil {
+		b.reset()
+		return err
+	}
+	return nil
+}
+
+// Close closes the connection, if one is open
+func (b *NATSBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reset()
+	return nil
+}
+
+// connect opens the connection and completes the INFO/CONNECT handshake
+func (b *NATSBroker) connect(ctx context.Context) error {
+	if b.conn != nil {
+		return nil
+	}
+
+	dialer := net.Dialer{Timeout: natsDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", b.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(natsDialTimeout))
+	b.conn = conn
+	b.reader = bufio.NewReader(conn)
+
+	line, err := b.reader.ReadString('\n')
+	if err != nil {
+		b.reset()
+		return fmt.Errorf("failed to read NATS server info: %w", err)
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		b.reset()
+		return fmt.Errorf("unexpected NATS greeting: %q", strings.TrimSpace(line))
+	}
+	var info struct {
+		TLSRequired bool `json:"tls_required"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(line[len("INFO "):])), &info); err == nil && info.TLSRequired {
+		b.reset()
+		return fmt.Errorf("NATS server requires TLS, which isn't supported")
+	}
+
+	options := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "flyingforge",
+		"lang":     "go",
+	}
+	if b.user != "" {
+		options["user"] = b.user
+		options["pass"] = b.pass
+	}
+	if b.token != "" {
+		options["auth_token"] = b.token
+	}
+	connectJSON, _ := json.Marshal(options)
+	if _, err := b.conn.Write([]byte("CONNECT " + string(connectJSON) + "\r\nPING\r\n")); err != nil {
+		b.reset()
+		return fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	if err := b.awaitPong(); err != nil {
+		b.reset()
+		return err
+	}
+	return nil
+}
+
+// awaitPong reads until the server answers our PING, replying to the
+// server's own PINGs and failing on -ERR
+func (b *NATSBroker) awaitPong() error {
+	for {
+		line, err := b.reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read from NATS: %w", err)
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := b.conn.Write([]byte("PONG\r\n")); err != nil {
+				return fmt.Errorf("failed to write to NATS: %w", err)
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("NATS error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+func (b *NATSBroker) reset() {
+	if b.conn != nil {
+		_ = b.conn.Close()
+	}
+	b.conn = nil
+	b.reader = nil
+}
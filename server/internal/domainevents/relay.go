@@ -0,0 +1,122 @@
+package domainevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// batchSize bounds how many events one outbox transaction claims
+const batchSize = 100
+
+// publishTimeout bounds one broker publish, so a hung broker can't hold the
+// claimed rows' locks indefinitely
+const publishTimeout = 10 * time.Second
+
+// Store defines the outbox operations the relay uses
+type Store interface {
+	Relay(ctx context.Context, limit int, publish func(models.DomainEvent) error) (int, error)
+	PurgePublished(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Handler consumes events in process, e.g. to update a search index
+type Handler func(ctx context.Context, event models.DomainEvent) error
+
+// Relay moves events from the outbox to the broker and any in-process
+// handlers
+type Relay struct {
+	store    Store
+	broker   Broker
+	prefix   string
+	handlers []Handler
+	logger   *logging.Logger
+}
+
+// NewRelay creates a relay publishing to broker with subjects named prefix
+// followed by the event type. broker may be nil when events are only
+// consumed by handlers.
+func NewRelay(store *database.OutboxStore, broker Broker, prefix string, logger *logging.Logger) *Relay {
+	return &Relay{store: store, broker: broker, prefix: prefix, logger: logger}
+}
+
+// AddHandler registers an in-process consumer. An event is only marked
+// published once the broker and every handler have accepted it, so a
+// failing handler means the broker may see the event more than once.
+func (r *Relay) AddHandler(handler Handler) {
+	r.handlers = append(r.handlers, handler)
+}
+
+// Subject returns the subject or topic an event type is published to
+func (r *Relay) Subject(eventType models.DomainEventType) string {
+	return r.prefix + string(eventType)
+}
+
+// RelayOnce publishes pending events until the outbox has no more due, and
+// returns how many were published. Events that fail stay in the outbox to
+// be retried with backoff.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		failed := 0
+		published, err := r.store.Relay(ctx, batchSize, func(event models.DomainEvent) error {
+			if err := r.publish(ctx, event); err != nil {
+				failed++
+				r.logger.Warn("Failed to publish domain event", logging.WithFields(map[string]interface{}{
+					"event_id":   event.ID,
+					"event_type": string(event.Type),
+					"error":      err.Error(),
+				}))
+				return err
+			}
+			return nil
+		})
+		total += published
+		if err != nil {
+			return total, err
+		}
+		// A short batch means the outbox is drained; a failing broker
+		// means the rest would fail too
+		if published+failed < batchSize || failed > 0 {
+			return total, nil
+		}
+	}
+}
+
+// Purge deletes events published before the cutoff
+func (r *Relay) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return r.store.PurgePublished(ctx, before)
+}
+
+// Close closes the broker connection
+func (r *Relay) Close() error {
+	if r.broker == nil {
+		return nil
+	}
+	return r.broker.Close()
+}
+
+func (r *Relay) publish(ctx context.Context, event models.DomainEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	if r.broker != nil {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode domain event: %w", err)
+		}
+		if err := r.broker.Publish(ctx, r.Subject(event.Type), event.AggregateID, data); err != nil {
+			return err
+		}
+	}
+	for _, handler := range r.handlers {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
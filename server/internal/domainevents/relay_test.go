@@ -0,0 +1,152 @@
+package domainevents
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+type fakeStore struct {
+	pending   []models.DomainEvent
+	published []string
+	failed    []string
+}
+
+func (f *fakeStore) Relay(ctx context.Context, limit int, publish func(models.DomainEvent) error) (int, error) {
+	_ = ctx
+	count := 0
+	var remaining []models.DomainEvent
+	for i, event := range f.pending {
+		if i >= limit {
+			remaining = append(remaining, event)
+			continue
+		}
+		if err := publish(event); err != nil {
+			f.failed = append(f.failed, event.ID)
+			remaining = append(remaining, event)
+			continue
+		}
+		f.published = append(f.published, event.ID)
+		count++
+	}
+	f.pending = remaining
+	return count, nil
+}
+
+func (f *fakeStore) PurgePublished(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+type publishedMessage struct {
+	subject string
+	key     string
+	data    []byte
+}
+
+type fakeBroker struct {
+	messages []publishedMessage
+	err      error
+}
+
+func (f *fakeBroker) Publish(ctx context.Context, subject, key string, data []byte) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.messages = append(f.messages, publishedMessage{subject: subject, key: key, data: data})
+	return nil
+}
+
+func (f *fakeBroker) Close() error { return nil }
+
+func newTestRelay(store Store, broker Broker) *Relay {
+	return &Relay{store: store, broker: broker, prefix: "flyingforge.", logger: testutil.NullLogger()}
+}
+
+func TestRelayOncePublishesToPrefixedSubject(t *testing.T) {
+	store := &fakeStore{pending: []models.DomainEvent{{
+		ID:          "evt-1",
+		Type:        models.DomainEventBuildPublished,
+		AggregateID: "build-1",
+		Payload:     json.RawMessage(`{"buildId":"build-1"}`),
+	}}}
+	broker := &fakeBroker{}
+
+	published, err := newTestRelay(store, broker).RelayOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RelayOnce: %v", err)
+	}
+	if published != 1 || len(broker.messages) != 1 {
+		t.Fatalf("published=%d messages=%d", published, len(broker.messages))
+	}
+	msg := broker.messages[0]
+	if msg.subject != "flyingforge.build.published" || msg.key != "build-1" {
+		t.Fatalf("message=%+v", msg)
+	}
+	var decoded models.DomainEvent
+	if err := json.Unmarshal(msg.data, &decoded); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if decoded.ID != "evt-1" || string(decoded.Payload) != `{"buildId":"build-1"}` {
+		t.Fatalf("decoded=%+v", decoded)
+	}
+}
+
+func TestRelayOnceLeavesFailedEventsPending(t *testing.T) {
+	store := &fakeStore{pending: []models.DomainEvent{
+		{ID: "evt-1", Type: models.DomainEventUserRegistered, AggregateID: "user-1"},
+		{ID: "evt-2", Type: models.DomainEventUserRegistered, AggregateID: "user-2"},
+	}}
+	broker := &fakeBroker{err: errors.New("broker down")}
+
+	published, err := newTestRelay(store, broker).RelayOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RelayOnce: %v", err)
+	}
+	if published != 0 {
+		t.Fatalf("published=%d", published)
+	}
+	if len(store.pending) != 2 || len(store.failed) != 2 {
+		t.Fatalf("pending=%d failed=%d", len(store.pending), len(store.failed))
+	}
+}
+
+func TestRelayOnceDrainsMultipleBatches(t *testing.T) {
+	store := &fakeStore{}
+	for i := 0; i < batchSize+5; i++ {
+		store.pending = append(store.pending, models.DomainEvent{ID: "evt", Type: models.DomainEventCatalogPublished})
+	}
+	broker := &fakeBroker{}
+
+	published, err := newTestRelay(store, broker).RelayOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RelayOnce: %v", err)
+	}
+	if published != batchSize+5 || len(store.pending) != 0 {
+		t.Fatalf("published=%d pending=%d", published, len(store.pending))
+	}
+}
+
+func TestRelayOnceRunsHandlersWithoutBroker(t *testing.T) {
+	store := &fakeStore{pending: []models.DomainEvent{
+		{ID: "evt-1", Type: models.DomainEventCatalogPublished, AggregateID: "item-1"},
+	}}
+	relay := newTestRelay(store, nil)
+	var handled []string
+	relay.AddHandler(func(ctx context.Context, event models.DomainEvent) error {
+		handled = append(handled, event.AggregateID)
+		return nil
+	})
+
+	published, err := relay.RelayOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RelayOnce: %v", err)
+	}
+	if published != 1 || len(handled) != 1 || handled[0] != "item-1" {
+		t.Fatalf("published=%d handled=%v", published, handled)
+	}
+}
@@ -0,0 +1,75 @@
+// Package ecosystems serves the RC protocol and video system registry and
+// keeps catalog items linked to it. Links are read from each item's specs
+// and name by the compatibility engine and rebuilt periodically, so builds
+// and the catalog can be filtered by ecosystem.
+package ecosystems
+
+import (
+	"context"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/compat"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// Store defines the registry persistence operations
+type Store interface {
+	List(ctx context.Context) (*models.EcosystemRegistryResponse, error)
+	CatalogItems(ctx context.Context) ([]models.GearCatalogItem, error)
+	ReplaceLinks(ctx context.Context, links []models.CatalogEcosystemLinks) (int64, error)
+}
+
+// Service serves the ecosystem registry
+type Service struct {
+	store  Store
+	logger *logging.Logger
+}
+
+// NewService creates a new ecosystem service
+func NewService(store *database.EcosystemStore, logger *logging.Logger) *Service {
+	return &Service{store: store, logger: logger}
+}
+
+// Registry returns every RC protocol and video system
+func (s *Service) Registry(ctx context.Context) (*models.EcosystemRegistryResponse, error) {
+	return s.store.List(ctx)
+}
+
+// RefreshLinks re-reads the ecosystems of every catalog item
+func (s *Service) RefreshLinks(ctx context.Context) error {
+	start := time.Now()
+	items, err := s.store.CatalogItems(ctx)
+	if err != nil {
+		return err
+	}
+	written, err := s.store.ReplaceLinks(ctx, Links(items))
+	if err != nil {
+		return err
+	}
+	s.logger.Info("Catalog ecosystem links refreshed", logging.WithFields(map[string]interface{}{
+		"items":    len(items),
+		"links":    written,
+		"duration": time.Since(start).String(),
+	}))
+	return nil
+}
+
+// Links reads the ecosystems of each catalog item. Items that name no
+// ecosystem are left out.
+func Links(items []models.GearCatalogItem) []models.CatalogEcosystemLinks {
+	links := make([]models.CatalogEcosystemLinks, 0)
+	for _, item := range items {
+		profile := compat.ProfileOf(compat.PartFromCatalog(item))
+		if len(profile.RCProtocols) == 0 && profile.VideoSystem == "" {
+			continue
+		}
+		links = append(links, models.CatalogEcosystemLinks{
+			CatalogID:   item.ID,
+			RCProtocols: profile.RCProtocols,
+			VideoSystem: profile.VideoSystem,
+		})
+	}
+	return links
+}
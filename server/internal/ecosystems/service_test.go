@@ -0,0 +1,66 @@
+package ecosystems
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+type fakeStore struct {
+	items    []models.GearCatalogItem
+	replaced []models.CatalogEcosystemLinks
+}
+
+func (f *fakeStore) List(ctx context.Context) (*models.EcosystemRegistryResponse, error) {
+	return &models.EcosystemRegistryResponse{}, nil
+}
+
+func (f *fakeStore) CatalogItems(ctx context.Context) ([]models.GearCatalogItem, error) {
+	return f.items, nil
+}
+
+func (f *fakeStore) ReplaceLinks(ctx context.Context, links []models.CatalogEcosystemLinks) (int64, error) {
+	f.replaced = links
+	return int64(len(links)), nil
+}
+
+func TestLinks(t *testing.T) {
+	items := []models.GearCatalogItem{
+		{ID: "rx", GearType: models.GearTypeReceiver, Brand: "RadioMaster", Model: "RP1", Variant: "ExpressLRS"},
+		{ID: "radio", GearType: models.GearTypeRadio, Brand: "RadioMaster", Model: "TX16S", Variant: "ELRS + FrSky"},
+		{ID: "crsf", GearType: models.GearTypeReceiver, Brand: "TBS", Model: "Nano RX", Specs: json.RawMessage(`{"protocol": "CRSF"}`)},
+		{ID: "o3", GearType: models.GearTypeHDUnit, Brand: "DJI", Model: "O3 Air Unit"},
+		{ID: "vtx", GearType: models.GearTypeVTX, Brand: "TBS", Model: "Unify Pro32"},
+		{ID: "motor", GearType: models.GearTypeMotor, Brand: "T-Motor", Model: "F60 Pro V"},
+	}
+
+	want := []models.CatalogEcosystemLinks{
+		{CatalogID: "rx", RCProtocols: []models.RCProtocol{models.RCProtocolELRS}},
+		{CatalogID: "radio", RCProtocols: []models.RCProtocol{models.RCProtocolELRS, models.RCProtocolFrSky}},
+		{CatalogID: "crsf", RCProtocols: []models.RCProtocol{models.RCProtocolCrossfire}},
+		{CatalogID: "o3", VideoSystem: models.VideoSystemDJI},
+		{CatalogID: "vtx", VideoSystem: models.VideoSystemAnalog},
+	}
+	if got := Links(items); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Links() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRefreshLinks(t *testing.T) {
+	store := &fakeStore{items: []models.GearCatalogItem{
+		{ID: "rx", GearType: models.GearTypeReceiver, Brand: "BetaFPV", Model: "ELRS Lite"},
+		{ID: "frame", GearType: models.GearTypeFrame, Brand: "ImpulseRC", Model: "Apex"},
+	}}
+	svc := &Service{store: store, logger: testutil.NullLogger()}
+
+	if err := svc.RefreshLinks(context.Background()); err != nil {
+		t.Fatalf("RefreshLinks() error = %v", err)
+	}
+	if len(store.replaced) != 1 || store.replaced[0].CatalogID != "rx" {
+		t.Fatalf("replaced = %+v, want only the receiver", store.replaced)
+	}
+}
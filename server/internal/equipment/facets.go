@@ -0,0 +1,175 @@
+package equipment
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// priceBucketBounds are the upper bounds of the price facet buckets. The last
+// bucket has no upper bound.
+var priceBucketBounds = []float64{25, 50, 100, 200}
+
+const (
+	// phraseMatchBoost is added when the whole query appears in the name.
+	phraseMatchBoost = 0.5
+	// prefixMatchBoost is added when the name starts with the query.
+	prefixMatchBoost = 0.25
+	// inStockBoost ranks in-stock items above out-of-stock ones with a
+	// similar text match.
+	inStockBoost = 0.5
+)
+
+// filterMatch records which of the search filters an item passes
+type filterMatch struct {
+	category bool
+	seller   bool
+	price    bool
+	stock    bool
+}
+
+func matchFilters(item models.EquipmentItem, params models.EquipmentSearchParams) filterMatch {
+	return filterMatch{
+		category: params.Category == "" || item.Category == params.Category,
+		seller:   params.Seller == "" || item.SellerID == params.Seller,
+		price: (params.MinPrice == nil || item.Price >= *params.MinPrice) &&
+			(params.MaxPrice == nil || item.Price <= *params.MaxPrice),
+		stock: !params.InStockOnly || item.InStock,
+	}
+}
+
+func (m filterMatch) all() bool {
+	return m.category && m.seller && m.price && m.stock
+}
+
+// filterAndFacet applies the search filters and counts facets in one pass
+// over the items. Each facet ignores its own filter.
+func filterAndFacet(items []models.EquipmentItem, params models.EquipmentSearchParams) ([]models.EquipmentItem, *models.EquipmentFacets) {
+	filtered := make([]models.EquipmentItem, 0, len(items))
+	categories := make(map[string]int)
+	sellerCounts := make(map[string]int)
+	bucketCounts := make([]int, len(priceBucketBounds)+1)
+	facets := &models.EquipmentFacets{}
+
+	for _, item := range items {
+		m := matchFilters(item, params)
+		if m.seller && m.price && m.stock {
+			categories[string(item.Category)]++
+		}
+		if m.category && m.price && m.stock {
+			sellerCounts[item.SellerID]++
+		}
+		if m.category && m.seller && m.price {
+			if item.InStock {
+				facets.InStock++
+			} else {
+				facets.OutOfStock++
+			}
+		}
+		if m.category && m.seller && m.stock {
+			bucketCounts[priceBucket(item.Price)]++
+		}
+		if m.all() {
+			filtered = append(filtered, item)
+		}
+	}
+
+	facets.Categories = sortedFacetCounts(categories)
+	facets.Sellers = sortedFacetCounts(sellerCounts)
+	facets.PriceBuckets = make([]models.PriceBucket, len(bucketCounts))
+	for i, count := range bucketCounts {
+		bucket := models.PriceBucket{Count: count}
+		if i > 0 {
+			bucket.Min = priceBucketBounds[i-1]
+		}
+		if i < len(priceBucketBounds) {
+			max := priceBucketBounds[i]
+			bucket.Max = &max
+		}
+		facets.PriceBuckets[i] = bucket
+	}
+
+	return filtered, facets
+}
+
+// priceBucket returns the index of the bucket a price falls into
+func priceBucket(price float64) int {
+	for i, bound := range priceBucketBounds {
+		if price < bound {
+			return i
+		}
+	}
+	return len(priceBucketBounds)
+}
+
+// sortedFacetCounts orders facet values by count, then alphabetically
+func sortedFacetCounts(counts map[string]int) []models.FacetCount {
+	result := make([]models.FacetCount, 0, len(counts))
+	for value, count := range counts {
+		if value == "" {
+			continue
+		}
+		result = append(result, models.FacetCount{Value: value, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Value < result[j].Value
+	})
+	return result
+}
+
+// sortByRelevance orders items by how well their name and manufacturer match
+// the query, preferring in-stock items. Ties are broken by name.
+func sortByRelevance(items []models.EquipmentItem, query string) {
+	phrase := strings.ToLower(strings.TrimSpace(query))
+	terms := strings.Fields(phrase)
+
+	type scored struct {
+		item  models.EquipmentItem
+		score float64
+	}
+	ranked := make([]scored, len(items))
+	for i, item := range items {
+		ranked[i] = scored{item: item, score: relevanceScore(item, terms, phrase)}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].item.Name < ranked[j].item.Name
+	})
+	for i := range ranked {
+		items[i] = ranked[i].item
+	}
+}
+
+func relevanceScore(item models.EquipmentItem, terms []string, phrase string) float64 {
+	score := 0.0
+	if len(terms) > 0 {
+		name := strings.ToLower(item.Name)
+		manufacturer := strings.ToLower(item.Manufacturer)
+
+		matched := 0
+		for _, term := range terms {
+			if strings.Contains(name, term) || strings.Contains(manufacturer, term) {
+				matched++
+			}
+		}
+		score = float64(matched) / float64(len(terms))
+
+		if strings.Contains(name, phrase) {
+			score += phraseMatchBoost
+		}
+		if strings.HasPrefix(name, phrase) {
+			score += prefixMatchBoost
+		}
+	}
+	if item.InStock {
+		score += inStockBoost
+	}
+	return score
+}
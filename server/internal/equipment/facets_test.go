@@ -0,0 +1,99 @@
+package equipment
+
+import (
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+func facetItems() []models.EquipmentItem {
+	return []models.EquipmentItem{
+		{ID: "1", Name: "Xing2 2207 Motor", Category: models.CategoryMotors, SellerID: "rdq", Price: 22, InStock: true},
+		{ID: "2", Name: "Tornado T5 2207", Category: models.CategoryMotors, SellerID: "rdq", Price: 27, InStock: false},
+		{ID: "3", Name: "Speedybee F405 V4", Category: models.CategoryFC, SellerID: "rdq", Price: 55, InStock: true},
+		{ID: "4", Name: "Apex 5 Frame", Category: models.CategoryFrames, SellerID: "pyrodrone", Price: 80, InStock: true},
+		{ID: "5", Name: "Xing2 2306 Motor", Category: models.CategoryMotors, SellerID: "pyrodrone", Price: 250, InStock: true},
+	}
+}
+
+func facetCount(counts []models.FacetCount, value string) int {
+	for _, c := range counts {
+		if c.Value == value {
+			return c.Count
+		}
+	}
+	return 0
+}
+
+func TestFilterAndFacet(t *testing.T) {
+	maxPrice := 100.0
+	params := models.EquipmentSearchParams{
+		Category:    models.CategoryMotors,
+		Seller:      "rdq",
+		InStockOnly: true,
+		MaxPrice:    &maxPrice,
+	}
+
+	filtered, facets := filterAndFacet(facetItems(), params)
+
+	if len(filtered) != 1 || filtered[0].ID != "1" {
+		t.Fatalf("filtered = %+v, want only item 1", filtered)
+	}
+
+	// Each facet ignores its own filter but applies the others
+	if got := facetCount(facets.Categories, string(models.CategoryMotors)); got != 1 {
+		t.Errorf("motors count = %d, want 1", got)
+	}
+	if got := facetCount(facets.Categories, string(models.CategoryFC)); got != 1 {
+		t.Errorf("flight controller count = %d, want 1", got)
+	}
+	if got := facetCount(facets.Sellers, "pyrodrone"); got != 0 {
+		t.Errorf("pyrodrone count = %d, want 0 (its only motor is over the max price)", got)
+	}
+	if facets.InStock != 1 || facets.OutOfStock != 1 {
+		t.Errorf("stock facet = %d in / %d out, want 1 / 1", facets.InStock, facets.OutOfStock)
+	}
+
+	if len(facets.PriceBuckets) != len(priceBucketBounds)+1 {
+		t.Fatalf("got %d price buckets, want %d", len(facets.PriceBuckets), len(priceBucketBounds)+1)
+	}
+	if got := facets.PriceBuckets[0].Count; got != 1 {
+		t.Errorf("under $25 bucket = %d, want 1", got)
+	}
+	last := facets.PriceBuckets[len(facets.PriceBuckets)-1]
+	if last.Max != nil || last.Count != 0 {
+		t.Errorf("open-ended bucket = %+v, want no max and 0 (out of stock or other sellers only)", last)
+	}
+}
+
+func TestFilterAndFacet_NoFilters(t *testing.T) {
+	filtered, facets := filterAndFacet(facetItems(), models.EquipmentSearchParams{})
+
+	if len(filtered) != 5 {
+		t.Errorf("filtered %d items, want 5", len(filtered))
+	}
+	if len(facets.Categories) != 3 || facets.Categories[0].Value != string(models.CategoryMotors) || facets.Categories[0].Count != 3 {
+		t.Errorf("categories = %+v, want motors first with 3", facets.Categories)
+	}
+	if facets.InStock != 4 || facets.OutOfStock != 1 {
+		t.Errorf("stock facet = %d in / %d out, want 4 / 1", facets.InStock, facets.OutOfStock)
+	}
+}
+
+func TestSortByRelevance(t *testing.T) {
+	items := facetItems()
+	sortByRelevance(items, "xing2 2207")
+
+	if items[0].ID != "1" {
+		t.Errorf("first result = %q, want the exact in-stock match", items[0].Name)
+	}
+
+	// An in-stock partial match outranks an out-of-stock partial match
+	rank := make(map[string]int)
+	for i, item := range items {
+		rank[item.ID] = i
+	}
+	if rank["5"] > rank["2"] {
+		t.Errorf("in-stock Xing2 2306 ranked %d, below out-of-stock Tornado at %d", rank["5"], rank["2"])
+	}
+}
@@ -0,0 +1,133 @@
+package equipment
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/sellers"
+)
+
+// DefaultSellerTimeout bounds how long a search waits for any one seller.
+const DefaultSellerTimeout = 8 * time.Second
+
+// sellerCall fetches items from one seller
+type sellerCall func(ctx context.Context, adapter sellers.Adapter) ([]models.EquipmentItem, error)
+
+type sellerResult struct {
+	index   int
+	items   []models.EquipmentItem
+	err     error
+	latency time.Duration
+}
+
+// fanOut calls every seller concurrently, each with its own timeout, and
+// returns whatever arrived in time along with a status per seller. A seller
+// that ignores its context is given up on once its timeout has passed.
+func (s *Service) fanOut(ctx context.Context, adapters []sellers.Adapter, call sellerCall) ([]models.EquipmentItem, []models.SellerSearchStatus) {
+	timeout := s.sellerTimeout
+	if timeout <= 0 {
+		timeout = DefaultSellerTimeout
+	}
+
+	statuses := make([]models.SellerSearchStatus, len(adapters))
+	// Buffered so sellers that finish after we stop waiting don't block
+	results := make(chan sellerResult, len(adapters))
+
+	for i, adapter := range adapters {
+		statuses[i] = models.SellerSearchStatus{ID: adapter.ID(), Status: models.SellerSearchTimeout}
+		go func(i int, a sellers.Adapter) {
+			sellerCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			started := time.Now()
+			items, err := call(sellerCtx, a)
+			results <- sellerResult{index: i, items: items, err: err, latency: time.Since(started)}
+		}(i, adapter)
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	var allItems []models.EquipmentItem
+	for pending := len(adapters); pending > 0; pending-- {
+		var result sellerResult
+		select {
+		case result = <-results:
+		case <-deadline.C:
+			return allItems, statuses
+		case <-ctx.Done():
+			return allItems, statuses
+		}
+
+		status := &statuses[result.index]
+		status.LatencyMs = result.latency.Milliseconds()
+		switch {
+		case result.err == nil:
+			status.Status = models.SellerSearchOK
+			status.ItemCount = len(result.items)
+			allItems = append(allItems, result.items...)
+		case errors.Is(result.err, sellers.ErrCircuitOpen):
+			status.Status = models.SellerSearchSkipped
+		case errors.Is(result.err, context.DeadlineExceeded):
+			status.Status = models.SellerSearchTimeout
+		default:
+			status.Status = models.SellerSearchError
+			s.logger.Warn("Search failed for seller", logging.WithFields(map[string]interface{}{
+				"seller": status.ID,
+				"error":  result.err.Error(),
+			}))
+		}
+	}
+
+	return allItems, statuses
+}
+
+// fanOutCategories fetches several categories from one seller in parallel.
+// It fails only if every category fails, so one bad category still leaves
+// the seller's other results.
+func fanOutCategories(ctx context.Context, adapter sellers.Adapter, categories []models.EquipmentCategory, limit int) ([]models.EquipmentItem, error) {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		allItems []models.EquipmentItem
+		lastErr  error
+		failures int
+	)
+
+	for _, category := range categories {
+		wg.Add(1)
+		go func(category models.EquipmentCategory) {
+			defer wg.Done()
+
+			items, err := adapter.GetByCategory(ctx, category, limit, 0)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				lastErr = err
+				failures++
+				return
+			}
+			allItems = append(allItems, items...)
+		}(category)
+	}
+	wg.Wait()
+
+	if failures == len(categories) && lastErr != nil {
+		return nil, lastErr
+	}
+	return allItems, nil
+}
+
+// partialResults reports whether any seller's results are missing
+func partialResults(statuses []models.SellerSearchStatus) bool {
+	for _, status := range statuses {
+		if status.Status != models.SellerSearchOK {
+			return true
+		}
+	}
+	return false
+}
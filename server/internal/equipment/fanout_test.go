@@ -0,0 +1,112 @@
+package equipment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/sellers"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+// fakeSeller returns its items after delay, or err. It ignores its context
+// when stubborn is set, like a client without a request timeout.
+type fakeSeller struct {
+	id       string
+	items    []models.EquipmentItem
+	err      error
+	delay    time.Duration
+	stubborn bool
+}
+
+func (f *fakeSeller) ID() string      { return f.id }
+func (f *fakeSeller) Name() string    { return f.id }
+func (f *fakeSeller) BaseURL() string { return "https://" + f.id + ".example" }
+
+func (f *fakeSeller) Search(ctx context.Context, query string, category models.EquipmentCategory, limit int) ([]models.EquipmentItem, error) {
+	if f.stubborn {
+		time.Sleep(f.delay)
+		return f.items, f.err
+	}
+	select {
+	case <-time.After(f.delay):
+		return f.items, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (f *fakeSeller) GetByCategory(ctx context.Context, category models.EquipmentCategory, limit, offset int) ([]models.EquipmentItem, error) {
+	if category == models.CategoryVTX {
+		return nil, errors.New("category unavailable")
+	}
+	return []models.EquipmentItem{{ID: f.id + "-" + string(category), Category: category}}, nil
+}
+
+func (f *fakeSeller) GetProduct(ctx context.Context, productID string) (*models.EquipmentItem, error) {
+	return nil, nil
+}
+
+func (f *fakeSeller) SyncProducts(ctx context.Context) error {
+	return nil
+}
+
+func TestService_FanOut(t *testing.T) {
+	svc := &Service{sellerTimeout: 50 * time.Millisecond, logger: testutil.NullLogger()}
+	adapters := []sellers.Adapter{
+		&fakeSeller{id: "fast", items: []models.EquipmentItem{{ID: "a"}, {ID: "b"}}},
+		&fakeSeller{id: "slow", items: []models.EquipmentItem{{ID: "c"}}, delay: time.Second},
+		&fakeSeller{id: "stubborn", items: []models.EquipmentItem{{ID: "d"}}, delay: 200 * time.Millisecond, stubborn: true},
+		&fakeSeller{id: "broken", err: errors.New("upstream 500")},
+		&fakeSeller{id: "tripped", err: sellers.ErrCircuitOpen},
+	}
+
+	started := time.Now()
+	items, statuses := svc.fanOut(context.Background(), adapters, func(ctx context.Context, a sellers.Adapter) ([]models.EquipmentItem, error) {
+		return a.Search(ctx, "motor", "", 10)
+	})
+	if elapsed := time.Since(started); elapsed > 150*time.Millisecond {
+		t.Errorf("fanOut() took %v, want about the seller timeout", elapsed)
+	}
+
+	if len(items) != 2 {
+		t.Errorf("got %d items, want the fast seller's 2", len(items))
+	}
+
+	want := map[string]models.SellerSearchOutcome{
+		"fast":     models.SellerSearchOK,
+		"slow":     models.SellerSearchTimeout,
+		"stubborn": models.SellerSearchTimeout,
+		"broken":   models.SellerSearchError,
+		"tripped":  models.SellerSearchSkipped,
+	}
+	for _, status := range statuses {
+		if status.Status != want[status.ID] {
+			t.Errorf("seller %q status = %q, want %q", status.ID, status.Status, want[status.ID])
+		}
+	}
+	if statuses[0].ItemCount != 2 {
+		t.Errorf("fast seller ItemCount = %d, want 2", statuses[0].ItemCount)
+	}
+	if !partialResults(statuses) {
+		t.Error("partialResults() = false, want true")
+	}
+}
+
+func TestFanOutCategories(t *testing.T) {
+	seller := &fakeSeller{id: "rdq"}
+
+	items, err := fanOutCategories(context.Background(), seller, []models.EquipmentCategory{models.CategoryFrames, models.CategoryVTX, models.CategoryMotors}, 3)
+	if err != nil {
+		t.Fatalf("fanOutCategories() error = %v, want the failed category skipped", err)
+	}
+	if len(items) != 2 {
+		t.Errorf("got %d items, want 2", len(items))
+	}
+
+	if _, err := fanOutCategories(context.Background(), seller, []models.EquipmentCategory{models.CategoryVTX}, 3); err == nil {
+		t.Error("fanOutCategories() with every category failing error = nil")
+	}
+}
@@ -0,0 +1,55 @@
+package equipment
+
+import (
+	"context"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// offerSearchLimit is how many results per seller FindOffer considers
+const offerSearchLimit = 5
+
+// FindOffer returns the best current seller listing for a product name, or
+// nil if no listing's name and manufacturer contain every word of it.
+// In-stock listings are preferred, then the lowest price.
+func (s *Service) FindOffer(ctx context.Context, name string) (*models.EquipmentItem, error) {
+	terms := strings.Fields(strings.ToLower(name))
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	resp, err := s.Search(ctx, models.EquipmentSearchParams{Query: name, Limit: offerSearchLimit})
+	if err != nil {
+		return nil, err
+	}
+
+	var best *models.EquipmentItem
+	for i := range resp.Items {
+		item := &resp.Items[i]
+		if !matchesAllTerms(*item, terms) {
+			continue
+		}
+		if best == nil || betterOffer(item, best) {
+			best = item
+		}
+	}
+	return best, nil
+}
+
+func matchesAllTerms(item models.EquipmentItem, terms []string) bool {
+	text := strings.ToLower(item.Manufacturer + " " + item.Name)
+	for _, term := range terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+	return true
+}
+
+func betterOffer(a, b *models.EquipmentItem) bool {
+	if a.InStock != b.InStock {
+		return a.InStock
+	}
+	return a.Price < b.Price
+}
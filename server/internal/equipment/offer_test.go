@@ -0,0 +1,37 @@
+package equipment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/cache"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/sellers"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+func TestService_FindOffer(t *testing.T) {
+	registry := sellers.NewRegistry()
+	registry.Register(&fakeSeller{id: "a", items: []models.EquipmentItem{
+		{ID: "a-1", Name: "F60 Pro V Motor", Manufacturer: "T-Motor", Price: 24, InStock: false, SellerID: "a"},
+		{ID: "a-2", Name: "F60 Pro V Motor", Manufacturer: "T-Motor", Price: 26, InStock: true, SellerID: "a"},
+	}})
+	registry.Register(&fakeSeller{id: "b", items: []models.EquipmentItem{
+		{ID: "b-1", Name: "F60 Pro V Motor", Manufacturer: "T-Motor", Price: 22, InStock: true, SellerID: "b"},
+		{ID: "b-2", Name: "F40 Motor", Manufacturer: "T-Motor", Price: 10, InStock: true, SellerID: "b"},
+	}})
+	svc := NewService(registry, cache.NewMemory(0), testutil.NullLogger())
+
+	offer, err := svc.FindOffer(context.Background(), "T-Motor F60")
+	if err != nil {
+		t.Fatalf("FindOffer() error = %v", err)
+	}
+	if offer == nil || offer.ID != "b-1" {
+		t.Fatalf("FindOffer() = %+v, want cheapest in-stock match b-1", offer)
+	}
+
+	offer, err = svc.FindOffer(context.Background(), "Emax Eco II")
+	if err != nil || offer != nil {
+		t.Errorf("FindOffer() = %+v, %v, want no match", offer, err)
+	}
+}
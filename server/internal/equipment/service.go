@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/johnrirwin/flyingforge/internal/cache"
 	"github.com/johnrirwin/flyingforge/internal/logging"
@@ -14,10 +15,11 @@ import (
 
 // Service handles equipment aggregation from multiple sellers
 type Service struct {
-	registry *sellers.Registry
-	cache    cache.Cache
-	logger   *logging.Logger
-	products map[string][]models.EquipmentItem // Cached products by category
+	registry      *sellers.Registry
+	cache         cache.Cache
+	logger        *logging.Logger
+	products      map[string][]models.EquipmentItem // Cached products by category
+	sellerTimeout time.Duration                     // Per-seller limit for searches
 }
 
 // ServiceError represents an equipment service error
@@ -32,10 +34,11 @@ func (e *ServiceError) Error() string {
 // NewService creates a new equipment service
 func NewService(registry *sellers.Registry, c cache.Cache, logger *logging.Logger) *Service {
 	return &Service{
-		registry: registry,
-		cache:    c,
-		logger:   logger,
-		products: make(map[string][]models.EquipmentItem),
+		registry:      registry,
+		cache:         c,
+		logger:        logger,
+		products:      make(map[string][]models.EquipmentItem),
+		sellerTimeout: DefaultSellerTimeout,
 	}
 }
 
@@ -51,13 +54,10 @@ func (s *Service) Search(ctx context.Context, params models.EquipmentSearchParam
 		}, nil
 	}
 
-	// If a specific seller is requested, only search that one
-	if params.Seller != "" {
-		adapter := s.registry.Get(params.Seller)
-		if adapter == nil {
-			return nil, &ServiceError{Message: "Unknown seller: " + params.Seller}
-		}
-		adapters = []sellers.Adapter{adapter}
+	// Every seller is searched even when one is selected, so the seller facet
+	// can count the others. The seller filter is applied with the rest.
+	if params.Seller != "" && s.registry.Get(params.Seller) == nil {
+		return nil, &ServiceError{Message: "Unknown seller: " + params.Seller}
 	}
 
 	limit := params.Limit
@@ -70,43 +70,16 @@ func (s *Service) Search(ctx context.Context, params models.EquipmentSearchParam
 		return s.getFeaturedProducts(ctx, adapters, limit, params)
 	}
 
-	// Search all adapters in parallel
-	var wg sync.WaitGroup
-	resultChan := make(chan []models.EquipmentItem, len(adapters))
-
-	for _, adapter := range adapters {
-		wg.Add(1)
-		go func(a sellers.Adapter) {
-			defer wg.Done()
-
-			items, err := a.Search(ctx, params.Query, params.Category, limit)
-			if err != nil {
-				s.logger.Warn("Search failed for seller", logging.WithFields(map[string]interface{}{
-					"seller": a.ID(),
-					"error":  err.Error(),
-				}))
-				return
-			}
-			resultChan <- items
-		}(adapter)
-	}
-
-	// Wait and collect results
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
-
-	var allItems []models.EquipmentItem
-	for items := range resultChan {
-		allItems = append(allItems, items...)
-	}
+	// Search all adapters in parallel, each with its own timeout
+	allItems, statuses := s.fanOut(ctx, adapters, func(ctx context.Context, a sellers.Adapter) ([]models.EquipmentItem, error) {
+		return a.Search(ctx, params.Query, params.Category, limit)
+	})
 
-	// Apply filters
-	allItems = s.applyFilters(allItems, params)
+	// Apply filters and count facets
+	allItems, facets := filterAndFacet(allItems, params)
 
 	// Sort results
-	allItems = s.sortItems(allItems, params.Sort)
+	allItems = s.sortItems(allItems, params)
 
 	// Paginate
 	totalCount := len(allItems)
@@ -127,6 +100,9 @@ func (s *Service) Search(ctx context.Context, params models.EquipmentSearchParam
 		Page:       (offset / limit) + 1,
 		PageSize:   limit,
 		Query:      params.Query,
+		Facets:     facets,
+		Sellers:    statuses,
+		Partial:    partialResults(statuses),
 	}, nil
 }
 
@@ -206,6 +182,11 @@ func (s *Service) GetSellers() []models.SellerInfo {
 	return s.registry.GetSellerInfo()
 }
 
+// SellerHealth returns request health and circuit breaker state per seller
+func (s *Service) SellerHealth() []models.SellerHealth {
+	return s.registry.Health()
+}
+
 // getFeaturedProducts returns a mix of products from all categories for browsing
 func (s *Service) getFeaturedProducts(ctx context.Context, adapters []sellers.Adapter, limit int, params models.EquipmentSearchParams) (*models.EquipmentSearchResponse, error) {
 	// Featured categories to show on initial browse
@@ -218,47 +199,18 @@ func (s *Service) getFeaturedProducts(ctx context.Context, adapters []sellers.Ad
 		models.CategoryBatteries,
 	}
 
-	var wg sync.WaitGroup
-	resultChan := make(chan []models.EquipmentItem, len(adapters)*len(featuredCategories))
-
 	// Get a few items from each featured category from each adapter
 	itemsPerCategory := 3
 	if limit > 0 {
 		itemsPerCategory = (limit / len(featuredCategories)) + 1
 	}
 
-	for _, adapter := range adapters {
-		for _, cat := range featuredCategories {
-			wg.Add(1)
-			go func(a sellers.Adapter, category models.EquipmentCategory) {
-				defer wg.Done()
-
-				items, err := a.GetByCategory(ctx, category, itemsPerCategory, 0)
-				if err != nil {
-					s.logger.Debug("GetByCategory failed for featured", logging.WithFields(map[string]interface{}{
-						"seller":   a.ID(),
-						"category": category,
-						"error":    err.Error(),
-					}))
-					return
-				}
-				resultChan <- items
-			}(adapter, cat)
-		}
-	}
-
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
-
-	var allItems []models.EquipmentItem
-	for items := range resultChan {
-		allItems = append(allItems, items...)
-	}
+	allItems, statuses := s.fanOut(ctx, adapters, func(ctx context.Context, a sellers.Adapter) ([]models.EquipmentItem, error) {
+		return fanOutCategories(ctx, a, featuredCategories, itemsPerCategory)
+	})
 
-	// Apply any filters
-	allItems = s.applyFilters(allItems, params)
+	// Apply any filters and count facets
+	allItems, facets := filterAndFacet(allItems, params)
 
 	// Sort by category then price for nice browsing
 	sort.Slice(allItems, func(i, j int) bool {
@@ -285,6 +237,9 @@ func (s *Service) getFeaturedProducts(ctx context.Context, adapters []sellers.Ad
 		TotalCount: totalCount,
 		Page:       (offset / limit) + 1,
 		PageSize:   limit,
+		Facets:     facets,
+		Sellers:    statuses,
+		Partial:    partialResults(statuses),
 	}, nil
 }
 
@@ -330,38 +285,9 @@ func (s *Service) SyncProducts(ctx context.Context) error {
 	return nil
 }
 
-// applyFilters applies search filters to items
-func (s *Service) applyFilters(items []models.EquipmentItem, params models.EquipmentSearchParams) []models.EquipmentItem {
-	filtered := make([]models.EquipmentItem, 0, len(items))
-
-	for _, item := range items {
-		// Filter by price range
-		if params.MinPrice != nil && item.Price < *params.MinPrice {
-			continue
-		}
-		if params.MaxPrice != nil && item.Price > *params.MaxPrice {
-			continue
-		}
-
-		// Filter by in-stock
-		if params.InStockOnly && !item.InStock {
-			continue
-		}
-
-		// Filter by category if specified
-		if params.Category != "" && item.Category != params.Category {
-			continue
-		}
-
-		filtered = append(filtered, item)
-	}
-
-	return filtered
-}
-
 // sortItems sorts items by the specified criteria
-func (s *Service) sortItems(items []models.EquipmentItem, sortBy string) []models.EquipmentItem {
-	switch sortBy {
+func (s *Service) sortItems(items []models.EquipmentItem, params models.EquipmentSearchParams) []models.EquipmentItem {
+	switch params.Sort {
 	case "price_asc":
 		sort.Slice(items, func(i, j int) bool {
 			return items[i].Price < items[j].Price
@@ -375,10 +301,8 @@ func (s *Service) sortItems(items []models.EquipmentItem, sortBy string) []model
 			return items[i].Name < items[j].Name
 		})
 	default:
-		// Default sort by name
-		sort.Slice(items, func(i, j int) bool {
-			return items[i].Name < items[j].Name
-		})
+		// Default sort by relevance, preferring in-stock items
+		sortByRelevance(items, params.Query)
 	}
 
 	return items
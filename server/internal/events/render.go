@@ -0,0 +1,98 @@
+package events
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/textpdf"
+)
+
+// Check-in sheet formats
+const (
+	SheetFormatCSV = "csv"
+	SheetFormatPDF = "pdf"
+)
+
+// sheetPDFColumns are the widths of the PDF table columns, in points. The
+// last column is left blank for signatures.
+var sheetPDFColumns = []float64{24, 120, 140, 70, 60, 90}
+
+// RenderCheckInSheet renders an event's attendees as a CSV or a printable PDF,
+// returning the body and content type
+func RenderCheckInSheet(event *models.Event, attendees []Attendee, format string) ([]byte, string, error) {
+	switch format {
+	case SheetFormatCSV:
+		body, err := renderSheetCSV(attendees)
+		return body, "text/csv; charset=utf-8", err
+	case SheetFormatPDF:
+		return renderSheetPDF(event, attendees), "application/pdf", nil
+	default:
+		return nil, "", &ServiceError{Message: "format must be csv or pdf"}
+	}
+}
+
+func renderSheetCSV(attendees []Attendee) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	rows := [][]string{{"Call Sign", "Name", "Aircraft", "Video System", "Registered At", "Checked In At"}}
+	for _, a := range attendees {
+		rows = append(rows, []string{
+			a.CallSign,
+			a.DisplayName,
+			a.AircraftName,
+			videoSystem(a),
+			a.RegisteredAt.UTC().Format(time.RFC3339),
+			formatCheckIn(a.CheckedInAt, time.RFC3339),
+		})
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("failed to write check-in CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func renderSheetPDF(event *models.Event, attendees []Attendee) []byte {
+	doc := textpdf.New()
+	doc.Heading(event.Name)
+	when := event.StartsAt.UTC().Format("Mon 2 Jan 2006 15:04 UTC")
+	if event.Location != "" {
+		when += " - " + event.Location
+	}
+	doc.Text(when)
+	doc.Text(fmt.Sprintf("%d pilots registered", len(attendees)))
+	doc.Gap()
+
+	doc.Row([]string{"#", "Pilot", "Aircraft", "Video", "Checked in", "Signature"}, sheetPDFColumns, true)
+	for i, a := range attendees {
+		doc.Row([]string{
+			fmt.Sprintf("%d", i+1),
+			attendeeName(a.EventRegistration),
+			a.AircraftName,
+			videoSystem(a),
+			formatCheckIn(a.CheckedInAt, "15:04"),
+			"",
+		}, sheetPDFColumns, false)
+	}
+	return doc.Bytes()
+}
+
+func videoSystem(a Attendee) string {
+	if a.VTX == nil {
+		return ""
+	}
+	if a.VTX.System == "" {
+		return "analog"
+	}
+	return strings.ToLower(a.VTX.System)
+}
+
+func formatCheckIn(checkedInAt *time.Time, layout string) string {
+	if checkedInAt == nil {
+		return ""
+	}
+	return checkedInAt.UTC().Format(layout)
+}
@@ -0,0 +1,418 @@
+// Package events manages race days and meetups: registration, check-in,
+// attendee VTX capabilities for channel planning, and check-in sheets.
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/aircraft"
+	"github.com/johnrirwin/flyingforge/internal/calc/vtx"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	// defaultEventLength is how long registration stays open after an event
+	// starts when it has no end time
+	defaultEventLength = 12 * time.Hour
+
+	maxNameLength = 150
+)
+
+var (
+	// ErrEventNotFound is returned when the event doesn't exist or the caller
+	// can't see it.
+	ErrEventNotFound = errors.New("event not found")
+	// ErrForbidden is returned when only the organizer (or the group's owner
+	// and admins) may make the change.
+	ErrForbidden = errors.New("only the event organizer can do that")
+)
+
+// Store defines the event persistence operations
+type Store interface {
+	Create(ctx context.Context, organizerID string, params models.CreateEventParams) (string, error)
+	Get(ctx context.Context, id, userID string) (*models.Event, error)
+	ListVisible(ctx context.Context, userID string, params models.EventListParams) ([]models.Event, error)
+	Update(ctx context.Context, event *models.Event) error
+	Delete(ctx context.Context, id string) error
+	Register(ctx context.Context, eventID, userID, aircraftID string) (bool, error)
+	Cancel(ctx context.Context, eventID, userID string) (bool, error)
+	SetCheckedIn(ctx context.Context, eventID, userID string, checkedIn bool) (bool, error)
+	ListRegistrations(ctx context.Context, eventID string) ([]models.EventRegistration, error)
+}
+
+// GroupRoles looks up a user's role in a group
+type GroupRoles interface {
+	GetMemberRole(ctx context.Context, groupID, userID string) (models.GroupRole, error)
+}
+
+// AircraftReader loads a user's aircraft with its components
+type AircraftReader interface {
+	GetDetails(ctx context.Context, id string, userID string) (*models.AircraftDetailsResponse, error)
+}
+
+// Attendee is a registered pilot with the VTX on the aircraft they're bringing
+type Attendee struct {
+	models.EventRegistration
+	VTX *vtx.Capability `json:"vtx,omitempty"`
+}
+
+// Service manages events and registrations
+type Service struct {
+	store    Store
+	groups   GroupRoles
+	aircraft AircraftReader
+	logger   *logging.Logger
+}
+
+// NewService creates a new event service
+func NewService(store *database.EventStore, groupStore *database.GroupStore, aircraftSvc *aircraft.Service, logger *logging.Logger) *Service {
+	return &Service{
+		store:    store,
+		groups:   groupStore,
+		aircraft: aircraftSvc,
+		logger:   logger,
+	}
+}
+
+// Create creates an event organized by the user. Group events can only be
+// created by the group's owner and admins.
+func (s *Service) Create(ctx context.Context, userID string, params models.CreateEventParams) (*models.Event, error) {
+	params.Name = strings.TrimSpace(params.Name)
+	params.Description = strings.TrimSpace(params.Description)
+	params.Location = strings.TrimSpace(params.Location)
+	params.GroupID = strings.TrimSpace(params.GroupID)
+
+	if params.Visibility == "" {
+		params.Visibility = models.EventVisibilityPublic
+		if params.GroupID != "" {
+			params.Visibility = models.EventVisibilityGroup
+		}
+	}
+	if params.GroupID != "" {
+		role, err := s.groups.GetMemberRole(ctx, params.GroupID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !role.CanManage() {
+			return nil, &ServiceError{Message: "only group owners and admins can create group events"}
+		}
+	}
+
+	event := &models.Event{
+		GroupID:     params.GroupID,
+		Name:        params.Name,
+		Description: params.Description,
+		Location:    params.Location,
+		StartsAt:    params.StartsAt,
+		EndsAt:      params.EndsAt,
+		Visibility:  params.Visibility,
+		MaxPilots:   params.MaxPilots,
+	}
+	if err := validateEvent(event); err != nil {
+		return nil, err
+	}
+
+	id, err := s.store.Create(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.Get(ctx, id, userID)
+}
+
+// List returns upcoming and ongoing events the user can see
+func (s *Service) List(ctx context.Context, userID string, limit, offset int, now time.Time) ([]models.Event, error) {
+	return s.store.ListVisible(ctx, userID, models.EventListParams{From: now, Limit: limit, Offset: offset})
+}
+
+// Get returns an event the user can see
+func (s *Service) Get(ctx context.Context, id, userID string) (*models.Event, error) {
+	event, err := s.store.Get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil || !event.Visible {
+		return nil, ErrEventNotFound
+	}
+	return event, nil
+}
+
+// Update applies the non-nil fields of params to an event
+func (s *Service) Update(ctx context.Context, id, userID string, params models.UpdateEventParams) (*models.Event, error) {
+	event, err := s.managedEvent(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Name != nil {
+		event.Name = strings.TrimSpace(*params.Name)
+	}
+	if params.Description != nil {
+		event.Description = strings.TrimSpace(*params.Description)
+	}
+	if params.Location != nil {
+		event.Location = strings.TrimSpace(*params.Location)
+	}
+	if params.StartsAt != nil {
+		event.StartsAt = *params.StartsAt
+	}
+	if params.EndsAt != nil {
+		event.EndsAt = params.EndsAt
+	}
+	if params.Visibility != nil {
+		event.Visibility = *params.Visibility
+	}
+	if params.MaxPilots != nil {
+		event.MaxPilots = params.MaxPilots
+	}
+	if err := validateEvent(event); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Update(ctx, event); err != nil {
+		return nil, err
+	}
+	return s.store.Get(ctx, id, userID)
+}
+
+// Delete deletes an event
+func (s *Service) Delete(ctx context.Context, id, userID string) error {
+	if _, err := s.managedEvent(ctx, id, userID); err != nil {
+		return err
+	}
+	return s.store.Delete(ctx, id)
+}
+
+// Register signs the user up, optionally with one of their aircraft.
+// Registering again changes the aircraft.
+func (s *Service) Register(ctx context.Context, id, userID string, params models.RegisterForEventParams, now time.Time) (*models.Event, error) {
+	event, err := s.Get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !now.Before(eventEnd(event)) {
+		return nil, &ServiceError{Message: "this event has ended"}
+	}
+
+	aircraftID := strings.TrimSpace(params.AircraftID)
+	if aircraftID != "" {
+		details, err := s.aircraft.GetDetails(ctx, aircraftID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if details == nil {
+			return nil, &ServiceError{Message: "aircraft not found"}
+		}
+	}
+
+	ok, err := s.store.Register(ctx, id, userID, aircraftID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &ServiceError{Message: "this event is full"}
+	}
+	return s.store.Get(ctx, id, userID)
+}
+
+// Cancel withdraws the user's registration
+func (s *Service) Cancel(ctx context.Context, id, userID string) error {
+	if _, err := s.Get(ctx, id, userID); err != nil {
+		return err
+	}
+	ok, err := s.store.Cancel(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &ServiceError{Message: "you aren't registered for this event"}
+	}
+	return nil
+}
+
+// CheckIn marks a registered pilot as arrived, or undoes it. Organizer only.
+func (s *Service) CheckIn(ctx context.Context, id, userID, pilotID string, checkedIn bool) error {
+	if _, err := s.managedEvent(ctx, id, userID); err != nil {
+		return err
+	}
+	ok, err := s.store.SetCheckedIn(ctx, id, pilotID, checkedIn)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &ServiceError{Message: "pilot isn't registered for this event"}
+	}
+	return nil
+}
+
+// Attendees lists registered pilots and their VTX capabilities. Visible to the
+// organizer and to registered pilots.
+func (s *Service) Attendees(ctx context.Context, id, userID string) ([]Attendee, error) {
+	event, err := s.Get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if event.MyStatus != models.EventRegistrationRegistered {
+		if managed, err := s.canManage(ctx, event, userID); err != nil {
+			return nil, err
+		} else if !managed {
+			return nil, ErrForbidden
+		}
+	}
+
+	registrations, err := s.store.ListRegistrations(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	attendees := make([]Attendee, len(registrations))
+	for i, reg := range registrations {
+		attendees[i] = Attendee{EventRegistration: reg}
+		if reg.AircraftID == "" {
+			continue
+		}
+		details, err := s.aircraft.GetDetails(ctx, reg.AircraftID, reg.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if details == nil {
+			continue
+		}
+		if item := details.ComponentItem(models.ComponentCategoryVTX); item != nil {
+			capability := vtx.CapabilityFromSpecs(item.Specs)
+			attendees[i].VTX = &capability
+		}
+	}
+	return attendees, nil
+}
+
+// VTXPlan runs the channel planner over the attendees. With checkedInOnly,
+// only pilots who have checked in are planned. Pilots with no VTX on file are
+// planned as analog with a warning.
+func (s *Service) VTXPlan(ctx context.Context, id, userID string, checkedInOnly bool, opts vtx.Options, now time.Time) (*vtx.Plan, error) {
+	attendees, err := s.Attendees(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pilots []vtx.Pilot
+	var warnings []string
+	for _, attendee := range attendees {
+		if checkedInOnly && attendee.CheckedInAt == nil {
+			continue
+		}
+		pilot := vtx.Pilot{Name: attendeeName(attendee.EventRegistration)}
+		if attendee.VTX != nil {
+			pilot.Capability = *attendee.VTX
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%s has no VTX on file; assumed analog", pilot.Name))
+		}
+		pilots = append(pilots, pilot)
+	}
+	if len(pilots) == 0 {
+		return nil, &ServiceError{Message: "no pilots to plan"}
+	}
+
+	plan, err := vtx.NewPlan(pilots, opts, now)
+	if err != nil {
+		return nil, &ServiceError{Message: err.Error()}
+	}
+	plan.Warnings = append(warnings, plan.Warnings...)
+	return plan, nil
+}
+
+// CheckInSheet renders the attendee list for printing. Organizer only.
+func (s *Service) CheckInSheet(ctx context.Context, id, userID, format string) ([]byte, string, error) {
+	event, err := s.managedEvent(ctx, id, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	attendees, err := s.Attendees(ctx, id, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	return RenderCheckInSheet(event, attendees, format)
+}
+
+// managedEvent loads an event the user can manage: they organized it, or
+// it belongs to a group they own or administer
+func (s *Service) managedEvent(ctx context.Context, id, userID string) (*models.Event, error) {
+	event, err := s.Get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	managed, err := s.canManage(ctx, event, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !managed {
+		return nil, ErrForbidden
+	}
+	return event, nil
+}
+
+func (s *Service) canManage(ctx context.Context, event *models.Event, userID string) (bool, error) {
+	if event.OrganizerUserID == userID {
+		return true, nil
+	}
+	if event.GroupID == "" {
+		return false, nil
+	}
+	role, err := s.groups.GetMemberRole(ctx, event.GroupID, userID)
+	if err != nil {
+		return false, err
+	}
+	return role.CanManage(), nil
+}
+
+func validateEvent(event *models.Event) error {
+	if event.Name == "" {
+		return &ServiceError{Message: "name is required"}
+	}
+	if len(event.Name) > maxNameLength {
+		return &ServiceError{Message: "name must be 150 characters or fewer"}
+	}
+	if event.StartsAt.IsZero() {
+		return &ServiceError{Message: "startsAt is required"}
+	}
+	if event.EndsAt != nil && !event.EndsAt.After(event.StartsAt) {
+		return &ServiceError{Message: "endsAt must be after startsAt"}
+	}
+	if !event.Visibility.IsValid() {
+		return &ServiceError{Message: "visibility must be public, followers, or group"}
+	}
+	if event.Visibility == models.EventVisibilityGroup && event.GroupID == "" {
+		return &ServiceError{Message: "group visibility needs a groupId"}
+	}
+	if event.MaxPilots != nil && *event.MaxPilots <= 0 {
+		return &ServiceError{Message: "maxPilots must be positive"}
+	}
+	return nil
+}
+
+func eventEnd(event *models.Event) time.Time {
+	if event.EndsAt != nil {
+		return *event.EndsAt
+	}
+	return event.StartsAt.Add(defaultEventLength)
+}
+
+func attendeeName(reg models.EventRegistration) string {
+	if reg.CallSign != "" {
+		return reg.CallSign
+	}
+	return reg.DisplayName
+}
+
+// ServiceError represents an event validation error
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
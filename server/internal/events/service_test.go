@@ -0,0 +1,321 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/calc/vtx"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+const (
+	eventID     = "event-1"
+	organizerID = "user-organizer"
+	pilotID     = "user-pilot"
+	otherID     = "user-other"
+)
+
+var startsAt = time.Date(2026, 7, 4, 9, 0, 0, 0, time.UTC)
+
+// mockStore implements the Store interface in memory for a single event
+type mockStore struct {
+	event         *models.Event
+	registrations []models.EventRegistration
+}
+
+func newMockStore() *mockStore {
+	return &mockStore{event: &models.Event{
+		ID:              eventID,
+		OrganizerUserID: organizerID,
+		Name:            "Summer Race Day",
+		StartsAt:        startsAt,
+		Visibility:      models.EventVisibilityPublic,
+	}}
+}
+
+func (m *mockStore) Create(ctx context.Context, organizerID string, params models.CreateEventParams) (string, error) {
+	m.event = &models.Event{ID: eventID, OrganizerUserID: organizerID, GroupID: params.GroupID, Name: params.Name,
+		StartsAt: params.StartsAt, Visibility: params.Visibility}
+	return eventID, nil
+}
+
+func (m *mockStore) Get(ctx context.Context, id, userID string) (*models.Event, error) {
+	if m.event == nil || id != eventID {
+		return nil, nil
+	}
+	event := *m.event
+	event.Visible = event.Visibility == models.EventVisibilityPublic || userID == organizerID
+	event.RegisteredCount = len(m.registrations)
+	for _, reg := range m.registrations {
+		if reg.UserID == userID {
+			event.MyStatus = models.EventRegistrationRegistered
+			event.Visible = true
+		}
+	}
+	return &event, nil
+}
+
+func (m *mockStore) ListVisible(ctx context.Context, userID string, params models.EventListParams) ([]models.Event, error) {
+	return nil, nil
+}
+
+func (m *mockStore) Update(ctx context.Context, event *models.Event) error {
+	m.event = event
+	return nil
+}
+
+func (m *mockStore) Delete(ctx context.Context, id string) error {
+	m.event = nil
+	return nil
+}
+
+func (m *mockStore) Register(ctx context.Context, eventID, userID, aircraftID string) (bool, error) {
+	for i, reg := range m.registrations {
+		if reg.UserID == userID {
+			m.registrations[i].AircraftID = aircraftID
+			return true, nil
+		}
+	}
+	if m.event.MaxPilots != nil && len(m.registrations) >= *m.event.MaxPilots {
+		return false, nil
+	}
+	m.registrations = append(m.registrations, models.EventRegistration{
+		EventID:      eventID,
+		UserID:       userID,
+		CallSign:     strings.TrimPrefix(userID, "user-"),
+		AircraftID:   aircraftID,
+		Status:       models.EventRegistrationRegistered,
+		RegisteredAt: startsAt.Add(-time.Hour),
+	})
+	return true, nil
+}
+
+func (m *mockStore) Cancel(ctx context.Context, eventID, userID string) (bool, error) {
+	return false, nil
+}
+
+func (m *mockStore) SetCheckedIn(ctx context.Context, eventID, userID string, checkedIn bool) (bool, error) {
+	for i, reg := range m.registrations {
+		if reg.UserID == userID {
+			m.registrations[i].CheckedInAt = nil
+			if checkedIn {
+				at := startsAt
+				m.registrations[i].CheckedInAt = &at
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockStore) ListRegistrations(ctx context.Context, eventID string) ([]models.EventRegistration, error) {
+	return m.registrations, nil
+}
+
+type mockGroups map[string]models.GroupRole
+
+func (m mockGroups) GetMemberRole(ctx context.Context, groupID, userID string) (models.GroupRole, error) {
+	return m[userID], nil
+}
+
+// mockAircraft maps aircraft ID to its owner and VTX specs
+type mockAircraft map[string]struct {
+	owner string
+	specs string
+}
+
+func (m mockAircraft) GetDetails(ctx context.Context, id string, userID string) (*models.AircraftDetailsResponse, error) {
+	a, ok := m[id]
+	if !ok || a.owner != userID {
+		return nil, nil
+	}
+	details := &models.AircraftDetailsResponse{Aircraft: models.Aircraft{ID: id, Name: id}}
+	if a.specs != "" {
+		details.Components = []models.AircraftComponent{{
+			Category:      models.ComponentCategoryVTX,
+			InventoryItem: &models.InventoryItem{Specs: json.RawMessage(a.specs)},
+		}}
+	}
+	return details, nil
+}
+
+func newTestService(store *mockStore) *Service {
+	return &Service{
+		store:  store,
+		groups: mockGroups{organizerID: models.GroupRoleOwner, pilotID: models.GroupRoleMember},
+		aircraft: mockAircraft{
+			"hdzero-quad": {owner: pilotID, specs: `{"videoSystem": "HDZero"}`},
+			"bare-quad":   {owner: otherID},
+		},
+		logger: testutil.NullLogger(),
+	}
+}
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         string
+		params         models.CreateEventParams
+		wantErr        bool
+		wantVisibility models.EventVisibility
+	}{
+		{
+			name:    "member can't create a group event",
+			userID:  pilotID,
+			params:  models.CreateEventParams{GroupID: "group-1", Name: "Club Night", StartsAt: startsAt},
+			wantErr: true,
+		},
+		{
+			name:           "organizer's group event defaults to group visibility",
+			userID:         organizerID,
+			params:         models.CreateEventParams{GroupID: "group-1", Name: "Club Night", StartsAt: startsAt},
+			wantVisibility: models.EventVisibilityGroup,
+		},
+		{
+			name:    "group visibility needs a group",
+			userID:  organizerID,
+			params:  models.CreateEventParams{Name: "Club Night", StartsAt: startsAt, Visibility: models.EventVisibilityGroup},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(newMockStore())
+			event, err := svc.Create(context.Background(), tt.userID, tt.params)
+			if tt.wantErr {
+				var svcErr *ServiceError
+				if !errors.As(err, &svcErr) {
+					t.Errorf("Create() error = %v, want ServiceError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if event.Visibility != tt.wantVisibility {
+				t.Errorf("visibility = %q, want %q", event.Visibility, tt.wantVisibility)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	full := 1
+	store.event.MaxPilots = &full
+	svc := newTestService(store)
+	before := startsAt.Add(-24 * time.Hour)
+
+	// Steps run in order against one event that holds a single pilot
+	steps := []struct {
+		name    string
+		userID  string
+		params  models.RegisterForEventParams
+		now     time.Time
+		wantErr string // "" for success
+	}{
+		{name: "someone else's aircraft", userID: pilotID, params: models.RegisterForEventParams{AircraftID: "bare-quad"}, now: before, wantErr: "aircraft not found"},
+		{name: "after the event", userID: pilotID, now: startsAt.Add(defaultEventLength), wantErr: "this event has ended"},
+		{name: "own aircraft", userID: pilotID, params: models.RegisterForEventParams{AircraftID: "hdzero-quad"}, now: before},
+		{name: "event is full", userID: otherID, now: before, wantErr: "this event is full"},
+	}
+
+	for _, step := range steps {
+		event, err := svc.Register(ctx, eventID, step.userID, step.params, step.now)
+		if step.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), step.wantErr) {
+				t.Errorf("%s: Register() error = %v, want %q", step.name, err, step.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: Register() error = %v", step.name, err)
+		}
+		if event.MyStatus != models.EventRegistrationRegistered || event.RegisteredCount != 1 {
+			t.Errorf("%s: event after registering = %+v", step.name, event)
+		}
+	}
+}
+
+func TestAttendeesAndVTXPlan(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	svc := newTestService(store)
+	before := startsAt.Add(-time.Hour)
+
+	for _, reg := range []struct{ user, aircraft string }{{pilotID, "hdzero-quad"}, {otherID, "bare-quad"}} {
+		if _, err := svc.Register(ctx, eventID, reg.user, models.RegisterForEventParams{AircraftID: reg.aircraft}, before); err != nil {
+			t.Fatalf("Register(%s) error = %v", reg.user, err)
+		}
+	}
+
+	if _, err := svc.Attendees(ctx, eventID, "user-stranger"); !errors.Is(err, ErrForbidden) {
+		t.Errorf("stranger attendees error = %v, want ErrForbidden", err)
+	}
+
+	attendees, err := svc.Attendees(ctx, eventID, pilotID)
+	if err != nil {
+		t.Fatalf("Attendees() error = %v", err)
+	}
+	if len(attendees) != 2 || attendees[0].VTX == nil || attendees[0].VTX.System != "hdzero" || attendees[1].VTX != nil {
+		t.Errorf("attendees = %+v", attendees)
+	}
+
+	plan, err := svc.VTXPlan(ctx, eventID, organizerID, false, vtx.Options{}, startsAt)
+	if err != nil {
+		t.Fatalf("VTXPlan() error = %v", err)
+	}
+	if len(plan.Assignments) != 2 || plan.Assignments[0].Channel[0] != 'R' {
+		t.Errorf("assignments = %+v", plan.Assignments)
+	}
+	if len(plan.Warnings) == 0 || !strings.Contains(plan.Warnings[0], "other has no VTX on file") {
+		t.Errorf("warnings = %v", plan.Warnings)
+	}
+
+	var svcErr *ServiceError
+	if _, err := svc.VTXPlan(ctx, eventID, organizerID, true, vtx.Options{}, startsAt); !errors.As(err, &svcErr) {
+		t.Errorf("plan with nobody checked in error = %v, want ServiceError", err)
+	}
+}
+
+func TestCheckInSheet(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	svc := newTestService(store)
+
+	if _, err := svc.Register(ctx, eventID, pilotID, models.RegisterForEventParams{AircraftID: "hdzero-quad"}, startsAt); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := svc.CheckIn(ctx, eventID, pilotID, pilotID, true); !errors.Is(err, ErrForbidden) {
+		t.Errorf("pilot checking in error = %v, want ErrForbidden", err)
+	}
+	if err := svc.CheckIn(ctx, eventID, organizerID, pilotID, true); err != nil {
+		t.Fatalf("CheckIn() error = %v", err)
+	}
+
+	body, contentType, err := svc.CheckInSheet(ctx, eventID, organizerID, SheetFormatCSV)
+	if err != nil || !strings.HasPrefix(contentType, "text/csv") {
+		t.Fatalf("CheckInSheet(csv) = %q, %v", contentType, err)
+	}
+	rows, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(rows) != 2 || rows[1][0] != "pilot" || rows[1][3] != "hdzero" || rows[1][5] != "2026-07-04T09:00:00Z" {
+		t.Errorf("rows = %v", rows)
+	}
+
+	body, contentType, err = svc.CheckInSheet(ctx, eventID, organizerID, SheetFormatPDF)
+	if err != nil || contentType != "application/pdf" || !bytes.HasPrefix(body, []byte("%PDF-")) {
+		t.Errorf("CheckInSheet(pdf) = %q, %v", contentType, err)
+	}
+}
@@ -0,0 +1,245 @@
+package featured
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/builds"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	defaultActiveLimit = 12
+	maxActiveLimit     = 50
+	maxHeadlineLength  = 200
+	maxBlurbLength     = 1000
+)
+
+// Store defines the featured content persistence operations
+type Store interface {
+	Create(ctx context.Context, createdBy string, params models.CreateFeaturedContentParams) (*models.FeaturedContent, error)
+	Get(ctx context.Context, id string) (*models.FeaturedContent, error)
+	Update(ctx context.Context, id string, params models.UpdateFeaturedContentParams) (*models.FeaturedContent, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, params models.FeaturedContentListParams) (*models.FeaturedContentListResponse, error)
+	ListActive(ctx context.Context, contentType models.FeaturedContentType, limit int) ([]models.FeaturedContent, error)
+}
+
+// BuildReader loads published builds
+type BuildReader interface {
+	GetPublic(ctx context.Context, id string) (*models.Build, error)
+}
+
+// CatalogReader loads gear catalog items
+type CatalogReader interface {
+	Get(ctx context.Context, id string) (*models.GearCatalogItem, error)
+}
+
+// Service schedules featured content and serves the currently featured items
+type Service struct {
+	store   Store
+	builds  BuildReader
+	catalog CatalogReader
+	logger  *logging.Logger
+}
+
+// NewService creates a new featured content service
+func NewService(store *database.FeaturedStore, buildSvc *builds.Service, catalogStore *database.GearCatalogStore, logger *logging.Logger) *Service {
+	return &Service{
+		store:   store,
+		builds:  buildSvc,
+		catalog: catalogStore,
+		logger:  logger,
+	}
+}
+
+// Create schedules a build or catalog item to be featured. Only published
+// content can be featured.
+func (s *Service) Create(ctx context.Context, adminUserID string, params models.CreateFeaturedContentParams) (*models.FeaturedContent, error) {
+	params.ContentType = models.FeaturedContentType(strings.ToLower(strings.TrimSpace(string(params.ContentType))))
+	params.ContentID = strings.TrimSpace(params.ContentID)
+	params.Headline = strings.TrimSpace(params.Headline)
+	params.Blurb = strings.TrimSpace(params.Blurb)
+
+	if !models.IsValidFeaturedContentType(params.ContentType) {
+		return nil, &ServiceError{Message: "contentType must be build or gear"}
+	}
+	if _, err := uuid.Parse(params.ContentID); err != nil {
+		return nil, &ServiceError{Message: "contentId must be a valid ID"}
+	}
+	if err := validateCopy(params.Headline, params.Blurb); err != nil {
+		return nil, err
+	}
+	if params.EndsAt != nil {
+		startsAt := time.Now()
+		if params.StartsAt != nil {
+			startsAt = *params.StartsAt
+		}
+		if !params.EndsAt.After(startsAt) {
+			return nil, &ServiceError{Message: "endsAt must be after startsAt"}
+		}
+	}
+
+	available, err := s.contentAvailable(ctx, params.ContentType, params.ContentID)
+	if err != nil {
+		return nil, err
+	}
+	if !available {
+		return nil, &ServiceError{Message: fmt.Sprintf("%s not found or not published", params.ContentType)}
+	}
+
+	return s.store.Create(ctx, adminUserID, params)
+}
+
+// Get returns a featured slot by ID, or nil if it doesn't exist
+func (s *Service) Get(ctx context.Context, id string) (*models.FeaturedContent, error) {
+	return s.store.Get(ctx, id)
+}
+
+// Update reschedules a featured slot or edits its copy
+func (s *Service) Update(ctx context.Context, id string, params models.UpdateFeaturedContentParams) (*models.FeaturedContent, error) {
+	params.Headline = strings.TrimSpace(params.Headline)
+	params.Blurb = strings.TrimSpace(params.Blurb)
+
+	if err := validateCopy(params.Headline, params.Blurb); err != nil {
+		return nil, err
+	}
+	if params.StartsAt.IsZero() {
+		return nil, &ServiceError{Message: "startsAt is required"}
+	}
+	if params.EndsAt != nil && !params.EndsAt.After(params.StartsAt) {
+		return nil, &ServiceError{Message: "endsAt must be after startsAt"}
+	}
+
+	return s.store.Update(ctx, id, params)
+}
+
+// Delete removes a featured slot
+func (s *Service) Delete(ctx context.Context, id string) error {
+	return s.store.Delete(ctx, id)
+}
+
+// List returns featured slots for admins
+func (s *Service) List(ctx context.Context, params models.FeaturedContentListParams) (*models.FeaturedContentListResponse, error) {
+	switch params.Status {
+	case "", models.FeaturedStatusScheduled, models.FeaturedStatusActive, models.FeaturedStatusExpired:
+	default:
+		return nil, &ServiceError{Message: "status must be scheduled, active, or expired"}
+	}
+	if params.ContentType != "" && !models.IsValidFeaturedContentType(params.ContentType) {
+		return nil, &ServiceError{Message: "contentType must be build or gear"}
+	}
+	return s.store.List(ctx, params)
+}
+
+// Active returns currently featured content with the build or catalog item
+// attached. Slots whose content has since been unpublished or removed are skipped.
+func (s *Service) Active(ctx context.Context, contentType models.FeaturedContentType, limit int) (*models.FeaturedResponse, error) {
+	if contentType != "" && !models.IsValidFeaturedContentType(contentType) {
+		return nil, &ServiceError{Message: "type must be build or gear"}
+	}
+	if limit <= 0 {
+		limit = defaultActiveLimit
+	}
+	if limit > maxActiveLimit {
+		limit = maxActiveLimit
+	}
+
+	slots, err := s.store.ListActive(ctx, contentType, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.FeaturedItem, 0, len(slots))
+	seen := make(map[string]struct{}, len(slots))
+	for _, slot := range slots {
+		// The same content may be scheduled in overlapping slots; show it once
+		key := string(slot.ContentType) + ":" + slot.ContentID
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		item := models.FeaturedItem{
+			ID:          slot.ID,
+			ContentType: slot.ContentType,
+			Headline:    slot.Headline,
+			Blurb:       slot.Blurb,
+			EndsAt:      slot.EndsAt,
+		}
+		switch slot.ContentType {
+		case models.FeaturedContentBuild:
+			build, err := s.builds.GetPublic(ctx, slot.ContentID)
+			if err != nil {
+				s.logSkipped(slot, err)
+				continue
+			}
+			item.Build = build
+		case models.FeaturedContentGear:
+			gear, err := s.catalog.Get(ctx, slot.ContentID)
+			if err != nil {
+				s.logSkipped(slot, err)
+				continue
+			}
+			if gear != nil && models.NormalizeCatalogStatus(gear.Status) == models.CatalogStatusPublished {
+				item.Gear = gear
+			}
+		}
+		if item.Build == nil && item.Gear == nil {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		items = append(items, item)
+	}
+
+	return &models.FeaturedResponse{Items: items}, nil
+}
+
+func (s *Service) contentAvailable(ctx context.Context, contentType models.FeaturedContentType, id string) (bool, error) {
+	switch contentType {
+	case models.FeaturedContentBuild:
+		build, err := s.builds.GetPublic(ctx, id)
+		return build != nil, err
+	case models.FeaturedContentGear:
+		gear, err := s.catalog.Get(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		return gear != nil && models.NormalizeCatalogStatus(gear.Status) == models.CatalogStatusPublished, nil
+	}
+	return false, nil
+}
+
+func (s *Service) logSkipped(slot models.FeaturedContent, err error) {
+	s.logger.Warn("Skipping featured content that failed to load", logging.WithFields(map[string]interface{}{
+		"featuredId":  slot.ID,
+		"contentType": slot.ContentType,
+		"contentId":   slot.ContentID,
+		"error":       err.Error(),
+	}))
+}
+
+func validateCopy(headline, blurb string) error {
+	if len(headline) > maxHeadlineLength {
+		return &ServiceError{Message: fmt.Sprintf("headline must be %d characters or fewer", maxHeadlineLength)}
+	}
+	if len(blurb) > maxBlurbLength {
+		return &ServiceError{Message: fmt.Sprintf("blurb must be %d characters or fewer", maxBlurbLength)}
+	}
+	return nil
+}
+
+// ServiceError represents a featured content request error that should be shown to the client
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,230 @@
+package featured
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+const (
+	buildID      = "11111111-1111-1111-1111-111111111111"
+	draftBuildID = "22222222-2222-2222-2222-222222222222"
+	gearID       = "33333333-3333-3333-3333-333333333333"
+	pendingGear  = "44444444-4444-4444-4444-444444444444"
+)
+
+// mockStore implements the Store interface for testing
+type mockStore struct {
+	active  []models.FeaturedContent
+	created *models.CreateFeaturedContentParams
+}
+
+func (m *mockStore) Create(ctx context.Context, createdBy string, params models.CreateFeaturedContentParams) (*models.FeaturedContent, error) {
+	m.created = &params
+	return &models.FeaturedContent{ID: "featured-new", ContentType: params.ContentType, ContentID: params.ContentID}, nil
+}
+
+func (m *mockStore) Get(ctx context.Context, id string) (*models.FeaturedContent, error) {
+	return nil, nil
+}
+
+func (m *mockStore) Update(ctx context.Context, id string, params models.UpdateFeaturedContentParams) (*models.FeaturedContent, error) {
+	return &models.FeaturedContent{ID: id}, nil
+}
+
+func (m *mockStore) Delete(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m *mockStore) List(ctx context.Context, params models.FeaturedContentListParams) (*models.FeaturedContentListResponse, error) {
+	return &models.FeaturedContentListResponse{}, nil
+}
+
+func (m *mockStore) ListActive(ctx context.Context, contentType models.FeaturedContentType, limit int) ([]models.FeaturedContent, error) {
+	return m.active, nil
+}
+
+// mockBuilds returns only published builds
+type mockBuilds struct {
+	err error
+}
+
+func (m *mockBuilds) GetPublic(ctx context.Context, id string) (*models.Build, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if id == buildID {
+		return &models.Build{ID: id, Title: "Featured Build"}, nil
+	}
+	return nil, nil
+}
+
+// mockCatalog returns one published and one pending item
+type mockCatalog struct{}
+
+func (m *mockCatalog) Get(ctx context.Context, id string) (*models.GearCatalogItem, error) {
+	switch id {
+	case gearID:
+		return &models.GearCatalogItem{ID: id, Status: models.CatalogStatusPublished}, nil
+	case pendingGear:
+		return &models.GearCatalogItem{ID: id, Status: models.CatalogStatusPending}, nil
+	}
+	return nil, nil
+}
+
+func newTestService(store *mockStore, builds *mockBuilds) *Service {
+	return &Service{
+		store:   store,
+		builds:  builds,
+		catalog: &mockCatalog{},
+		logger:  testutil.NullLogger(),
+	}
+}
+
+func TestService_Create_Validation(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		params  models.CreateFeaturedContentParams
+		wantErr string
+	}{
+		{
+			name:   "published build",
+			params: models.CreateFeaturedContentParams{ContentType: "build", ContentID: buildID, EndsAt: &future},
+		},
+		{
+			name:   "content type normalized",
+			params: models.CreateFeaturedContentParams{ContentType: " Gear ", ContentID: gearID},
+		},
+		{
+			name:    "unknown content type",
+			params:  models.CreateFeaturedContentParams{ContentType: "pilot", ContentID: buildID},
+			wantErr: "contentType must be build or gear",
+		},
+		{
+			name:    "invalid content id",
+			params:  models.CreateFeaturedContentParams{ContentType: "build", ContentID: "nope"},
+			wantErr: "contentId must be a valid ID",
+		},
+		{
+			name:    "ends before it starts",
+			params:  models.CreateFeaturedContentParams{ContentType: "build", ContentID: buildID, StartsAt: &future, EndsAt: &past},
+			wantErr: "endsAt must be after startsAt",
+		},
+		{
+			name:    "unpublished build",
+			params:  models.CreateFeaturedContentParams{ContentType: "build", ContentID: draftBuildID},
+			wantErr: "build not found or not published",
+		},
+		{
+			name:    "pending catalog item",
+			params:  models.CreateFeaturedContentParams{ContentType: "gear", ContentID: pendingGear},
+			wantErr: "gear not found or not published",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockStore{}
+			svc := newTestService(store, &mockBuilds{})
+
+			_, err := svc.Create(context.Background(), "admin-1", tt.params)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				if store.created == nil || !models.IsValidFeaturedContentType(store.created.ContentType) {
+					t.Errorf("store received %+v, want normalized params", store.created)
+				}
+				return
+			}
+
+			var svcErr *ServiceError
+			if !errors.As(err, &svcErr) || svcErr.Message != tt.wantErr {
+				t.Errorf("Create() error = %v, want ServiceError %q", err, tt.wantErr)
+			}
+			if store.created != nil {
+				t.Error("store should not be called for invalid params")
+			}
+		})
+	}
+}
+
+func TestService_Active(t *testing.T) {
+	tests := []struct {
+		name        string
+		active      []models.FeaturedContent
+		buildsErr   error
+		contentType models.FeaturedContentType
+		wantIDs     []string
+		wantErr     bool
+	}{
+		{
+			name: "drops unpublished content and duplicates",
+			active: []models.FeaturedContent{
+				{ID: "f1", ContentType: models.FeaturedContentBuild, ContentID: buildID, Headline: "Build of the week"},
+				{ID: "f2", ContentType: models.FeaturedContentBuild, ContentID: draftBuildID},
+				{ID: "f3", ContentType: models.FeaturedContentGear, ContentID: gearID},
+				{ID: "f4", ContentType: models.FeaturedContentGear, ContentID: pendingGear},
+				{ID: "f5", ContentType: models.FeaturedContentBuild, ContentID: buildID},
+			},
+			wantIDs: []string{"f1", "f3"},
+		},
+		{
+			name: "skips content that fails to load",
+			active: []models.FeaturedContent{
+				{ID: "f1", ContentType: models.FeaturedContentBuild, ContentID: buildID},
+				{ID: "f2", ContentType: models.FeaturedContentGear, ContentID: gearID},
+			},
+			buildsErr: errors.New("db down"),
+			wantIDs:   []string{"f2"},
+		},
+		{name: "unknown type", contentType: "pilot", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(&mockStore{active: tt.active}, &mockBuilds{err: tt.buildsErr})
+
+			resp, err := svc.Active(context.Background(), tt.contentType, 0)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Active() should fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Active() error = %v", err)
+			}
+
+			var ids []string
+			for _, item := range resp.Items {
+				ids = append(ids, item.ID)
+				if item.ContentType == models.FeaturedContentBuild && item.Build == nil {
+					t.Errorf("build item %s has no build attached", item.ID)
+				}
+				if item.ContentType == models.FeaturedContentGear && item.Gear == nil {
+					t.Errorf("gear item %s has no gear attached", item.ID)
+				}
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Fatalf("featured ids = %v, want %v", ids, tt.wantIDs)
+			}
+			for _, item := range resp.Items {
+				for _, want := range tt.active {
+					if want.ID == item.ID && item.Headline != want.Headline {
+						t.Errorf("item %s headline = %q, want %q", item.ID, item.Headline, want.Headline)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+// Package feedfilter removes spam and off-topic items from the aggregated
+// feed using admin-managed keyword, domain, and minimum-length filters.
+package feedfilter
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/johnrirwin/flyingforge/internal/aggregator"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	// reloadInterval is how often filters are re-read, so changes made on
+	// another instance take effect
+	reloadInterval = time.Minute
+
+	maxPatternLength = 100
+	maxMinLength     = 1000
+	maxSources       = 50
+	maxNoteLength    = 200
+	previewItems     = 500
+	previewSamples   = 50
+)
+
+// Store defines the feed filter persistence operations
+type Store interface {
+	List(ctx context.Context) ([]models.FeedFilter, error)
+	Create(ctx context.Context, createdBy string, params models.SaveFeedFilterParams) (*models.FeedFilter, error)
+	Update(ctx context.Context, id string, params models.SaveFeedFilterParams) (*models.FeedFilter, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// ItemLister lists the current feed, for previews
+type ItemLister interface {
+	GetItems(ctx context.Context, params models.FilterParams) models.AggregatedResponse
+}
+
+// Service manages feed filters and applies them to fetched items
+type Service struct {
+	store  Store
+	items  ItemLister
+	logger *logging.Logger
+	now    func() time.Time
+
+	mu       sync.Mutex
+	rules    []rule
+	loadedAt time.Time
+}
+
+// NewService creates a new feed filter service
+func NewService(store *database.FeedFilterStore, agg *aggregator.Aggregator, logger *logging.Logger) *Service {
+	return &Service{
+		store:  store,
+		items:  agg,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// List returns every filter
+func (s *Service) List(ctx context.Context) ([]models.FeedFilter, error) {
+	return s.store.List(ctx)
+}
+
+// Create adds a filter. It applies from the next refresh.
+func (s *Service) Create(ctx context.Context, createdBy string, params models.SaveFeedFilterParams) (*models.FeedFilter, error) {
+	if err := normalize(&params); err != nil {
+		return nil, err
+	}
+	filter, err := s.store.Create(ctx, createdBy, params)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return filter, nil
+}
+
+// Update replaces a filter, returning nil if it doesn't exist
+func (s *Service) Update(ctx context.Context, id string, params models.SaveFeedFilterParams) (*models.FeedFilter, error) {
+	if err := normalize(&params); err != nil {
+		return nil, err
+	}
+	filter, err := s.store.Update(ctx, id, params)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidate()
+	return filter, nil
+}
+
+// Delete removes a filter
+func (s *Service) Delete(ctx context.Context, id string) error {
+	if err := s.store.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+// Preview reports which items in the current feed a filter would remove,
+// without saving it
+func (s *Service) Preview(ctx context.Context, params models.SaveFeedFilterParams) (*models.FeedFilterPreview, error) {
+	if err := normalize(&params); err != nil {
+		return nil, err
+	}
+	r := compile(models.FeedFilter{
+		Kind:      params.Kind,
+		Pattern:   params.Pattern,
+		MinLength: params.MinLength,
+		Sources:   params.Sources,
+		Enabled:   true,
+	})
+
+	feed := s.items.GetItems(ctx, models.FilterParams{Limit: previewItems})
+	preview := &models.FeedFilterPreview{
+		Checked: len(feed.Items),
+		Items:   make([]models.FeedItem, 0),
+	}
+	for _, item := range feed.Items {
+		if !r.matches(item) {
+			continue
+		}
+		preview.Removed++
+		if len(preview.Items) < previewSamples {
+			preview.Items = append(preview.Items, item)
+		}
+	}
+	return preview, nil
+}
+
+// Filter splits items into those to keep and those an enabled filter
+// removes. If filters can't be loaded, the last ones loaded are used.
+func (s *Service) Filter(ctx context.Context, items []models.FeedItem) ([]models.FeedItem, []models.FeedItem) {
+	rules := s.currentRules(ctx)
+	if len(rules) == 0 {
+		return items, nil
+	}
+
+	kept := make([]models.FeedItem, 0, len(items))
+	removed := make([]models.FeedItem, 0)
+	for _, item := range items {
+		blocked := false
+		for _, r := range rules {
+			if r.matches(item) {
+				blocked = true
+				break
+			}
+		}
+		if blocked {
+			removed = append(removed, item)
+		} else {
+			kept = append(kept, item)
+		}
+	}
+	return kept, removed
+}
+
+func (s *Service) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadedAt = time.Time{}
+}
+
+func (s *Service) currentRules(ctx context.Context) []rule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.loadedAt.IsZero() && s.now().Sub(s.loadedAt) < reloadInterval {
+		return s.rules
+	}
+
+	filters, err := s.store.List(ctx)
+	if err != nil {
+		s.logger.Warn("Failed to load feed filters", logging.WithField("error", err.Error()))
+		return s.rules
+	}
+
+	rules := make([]rule, 0, len(filters))
+	for _, filter := range filters {
+		if filter.Enabled {
+			rules = append(rules, compile(filter))
+		}
+	}
+	s.rules = rules
+	s.loadedAt = s.now()
+	return s.rules
+}
+
+// rule is a filter ready to match items
+type rule struct {
+	filter  models.FeedFilter
+	keyword *regexp.Regexp
+	sources map[string]bool
+}
+
+func compile(filter models.FeedFilter) rule {
+	r := rule{filter: filter}
+	if filter.Kind == models.FeedFilterKeyword {
+		// Whole words only, so "ad" doesn't match "adapter"
+		r.keyword = regexp.MustCompile(`(?i)(^|\W)` + regexp.QuoteMeta(filter.Pattern) + `($|\W)`)
+	}
+	if len(filter.Sources) > 0 {
+		r.sources = make(map[string]bool, len(filter.Sources))
+		for _, source := range filter.Sources {
+			r.sources[strings.ToLower(source)] = true
+		}
+	}
+	return r
+}
+
+func (r rule) matches(item models.FeedItem) bool {
+	if r.sources != nil && !r.sources[strings.ToLower(item.Source)] {
+		return false
+	}
+
+	switch r.filter.Kind {
+	case models.FeedFilterKeyword:
+		return r.keyword.MatchString(item.Title) || r.keyword.MatchString(item.Summary)
+	case models.FeedFilterDomain:
+		u, err := url.Parse(item.URL)
+		if err != nil {
+			return false
+		}
+		host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+		return host == r.filter.Pattern || strings.HasSuffix(host, "."+r.filter.Pattern)
+	case models.FeedFilterMinLength:
+		text := strings.TrimSpace(strings.TrimSpace(item.Title) + " " + strings.TrimSpace(item.Summary))
+		return utf8.RuneCountInString(text) < r.filter.MinLength
+	}
+	return false
+}
+
+// normalize validates params and puts patterns in the form rules match on
+func normalize(params *models.SaveFeedFilterParams) error {
+	params.Pattern = strings.TrimSpace(params.Pattern)
+	params.Note = strings.TrimSpace(params.Note)
+
+	switch params.Kind {
+	case models.FeedFilterKeyword:
+		if utf8.RuneCountInString(params.Pattern) < 2 || len(params.Pattern) > maxPatternLength {
+			return &ServiceError{Message: fmt.Sprintf("pattern must be 2 to %d characters", maxPatternLength)}
+		}
+		params.MinLength = 0
+	case models.FeedFilterDomain:
+		domain, ok := normalizeDomain(params.Pattern)
+		if !ok {
+			return &ServiceError{Message: "pattern must be a domain like example.com"}
+		}
+		params.Pattern = domain
+		params.MinLength = 0
+	case models.FeedFilterMinLength:
+		if params.MinLength < 1 || params.MinLength > maxMinLength {
+			return &ServiceError{Message: fmt.Sprintf("minLength must be between 1 and %d", maxMinLength)}
+		}
+		params.Pattern = ""
+	default:
+		return &ServiceError{Message: "kind must be keyword, domain, or min_length"}
+	}
+
+	sources := make([]string, 0, len(params.Sources))
+	seen := make(map[string]bool, len(params.Sources))
+	for _, source := range params.Sources {
+		source = strings.TrimSpace(source)
+		if source == "" || seen[strings.ToLower(source)] {
+			continue
+		}
+		seen[strings.ToLower(source)] = true
+		sources = append(sources, source)
+	}
+	if len(sources) > maxSources {
+		return &ServiceError{Message: fmt.Sprintf("at most %d sources are allowed", maxSources)}
+	}
+	params.Sources = sources
+
+	if len(params.Note) > maxNoteLength {
+		return &ServiceError{Message: fmt.Sprintf("note must be at most %d characters", maxNoteLength)}
+	}
+	if params.Enabled == nil {
+		enabled := true
+		params.Enabled = &enabled
+	}
+	return nil
+}
+
+// normalizeDomain accepts a bare domain or a URL and returns the lowercased
+// host without "www."
+func normalizeDomain(pattern string) (string, bool) {
+	pattern = strings.ToLower(pattern)
+	if strings.Contains(pattern, "://") {
+		u, err := url.Parse(pattern)
+		if err != nil {
+			return "", false
+		}
+		pattern = u.Hostname()
+	}
+	pattern = strings.TrimPrefix(strings.TrimSuffix(pattern, "/"), "www.")
+	if len(pattern) > maxPatternLength || !strings.Contains(pattern, ".") || strings.ContainsAny(pattern, " /?#@:") {
+		return "", false
+	}
+	return pattern, true
+}
+
+// ServiceError represents a feed filter request error that should be shown to the client
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,156 @@
+package feedfilter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+type mockStore struct {
+	filters []models.FeedFilter
+	err     error
+	lists   int
+}
+
+func (m *mockStore) List(ctx context.Context) ([]models.FeedFilter, error) {
+	m.lists++
+	return m.filters, m.err
+}
+
+func (m *mockStore) Create(ctx context.Context, createdBy string, params models.SaveFeedFilterParams) (*models.FeedFilter, error) {
+	filter := models.FeedFilter{ID: "f-new", Kind: params.Kind, Pattern: params.Pattern, MinLength: params.MinLength, Sources: params.Sources, Enabled: *params.Enabled}
+	m.filters = append(m.filters, filter)
+	return &filter, nil
+}
+
+func (m *mockStore) Update(ctx context.Context, id string, params models.SaveFeedFilterParams) (*models.FeedFilter, error) {
+	return nil, nil
+}
+
+func (m *mockStore) Delete(ctx context.Context, id string) error { return nil }
+
+type mockLister struct {
+	items []models.FeedItem
+}
+
+func (m *mockLister) GetItems(ctx context.Context, params models.FilterParams) models.AggregatedResponse {
+	return models.AggregatedResponse{Items: m.items, TotalCount: len(m.items)}
+}
+
+var testItems = []models.FeedItem{
+	{ID: "1", Title: "Cheap followers, buy now", Source: "r/fpv", URL: "https://www.reddit.com/r/fpv/comments/1"},
+	{ID: "2", Title: "New ESC adapter board released", Summary: "A 4-in-1 adapter", Source: "DroneDJ", URL: "https://dronedj.com/esc"},
+	{ID: "3", Title: "Spam deal", Summary: "Limited offer", Source: "Deals", URL: "https://shop.spam.example/deal"},
+	{ID: "4", Title: "hi", Source: "r/fpv", URL: "https://www.reddit.com/r/fpv/comments/4"},
+	{ID: "5", Title: "hi", Source: "DroneDJ", URL: "https://dronedj.com/hi"},
+}
+
+func newTestService(store *mockStore) *Service {
+	return &Service{store: store, items: &mockLister{items: testItems}, logger: testutil.NullLogger(), now: time.Now}
+}
+
+func ids(items []models.FeedItem) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.ID)
+	}
+	return result
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter models.FeedFilter
+		want   []string
+	}{
+		{name: "keyword", filter: models.FeedFilter{Kind: models.FeedFilterKeyword, Pattern: "FOLLOWERS"}, want: []string{"1"}},
+		{name: "keyword whole words only", filter: models.FeedFilter{Kind: models.FeedFilterKeyword, Pattern: "ad"}, want: []string{}},
+		{name: "keyword in summary", filter: models.FeedFilter{Kind: models.FeedFilterKeyword, Pattern: "limited offer"}, want: []string{"3"}},
+		{name: "domain and subdomains", filter: models.FeedFilter{Kind: models.FeedFilterDomain, Pattern: "spam.example"}, want: []string{"3"}},
+		{name: "min length", filter: models.FeedFilter{Kind: models.FeedFilterMinLength, MinLength: 10}, want: []string{"4", "5"}},
+		{name: "scoped to sources", filter: models.FeedFilter{Kind: models.FeedFilterMinLength, MinLength: 10, Sources: []string{"R/FPV"}}, want: []string{"4"}},
+		{name: "disabled", filter: models.FeedFilter{Kind: models.FeedFilterKeyword, Pattern: "followers", Enabled: false}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.name != "disabled" {
+				tt.filter.Enabled = true
+			}
+			svc := newTestService(&mockStore{filters: []models.FeedFilter{tt.filter}})
+
+			kept, removed := svc.Filter(context.Background(), testItems)
+			if got := ids(removed); len(got) != len(tt.want) || (len(got) > 0 && got[0] != tt.want[0]) {
+				t.Errorf("Filter() removed %v, want %v", got, tt.want)
+			}
+			if len(kept)+len(removed) != len(testItems) {
+				t.Errorf("Filter() kept %d and removed %d of %d items", len(kept), len(removed), len(testItems))
+			}
+		})
+	}
+}
+
+func TestFilter_ReloadsAfterChanges(t *testing.T) {
+	store := &mockStore{}
+	svc := newTestService(store)
+
+	if _, removed := svc.Filter(context.Background(), testItems); len(removed) != 0 {
+		t.Fatalf("Filter() with no filters removed %v", ids(removed))
+	}
+	if _, err := svc.Create(context.Background(), "admin-1", models.SaveFeedFilterParams{Kind: models.FeedFilterDomain, Pattern: "https://www.Spam.Example/"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, removed := svc.Filter(context.Background(), testItems); len(removed) != 1 || removed[0].ID != "3" {
+		t.Errorf("Filter() after Create removed %v, want [3]", ids(removed))
+	}
+
+	// Cached between reloads, and kept when the store fails
+	store.err = errors.New("db down")
+	svc.loadedAt = time.Time{}
+	if _, removed := svc.Filter(context.Background(), testItems); len(removed) != 1 {
+		t.Errorf("Filter() with the store down removed %v, want the last loaded filters applied", ids(removed))
+	}
+}
+
+func TestCreate_Validation(t *testing.T) {
+	svc := newTestService(&mockStore{})
+
+	tests := []struct {
+		name   string
+		params models.SaveFeedFilterParams
+	}{
+		{name: "unknown kind", params: models.SaveFeedFilterParams{Kind: "regex", Pattern: "x+"}},
+		{name: "short keyword", params: models.SaveFeedFilterParams{Kind: models.FeedFilterKeyword, Pattern: " a "}},
+		{name: "not a domain", params: models.SaveFeedFilterParams{Kind: models.FeedFilterDomain, Pattern: "localhost"}},
+		{name: "zero min length", params: models.SaveFeedFilterParams{Kind: models.FeedFilterMinLength}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Create(context.Background(), "admin-1", tt.params)
+			var svcErr *ServiceError
+			if !errors.As(err, &svcErr) {
+				t.Errorf("Create() error = %v, want ServiceError", err)
+			}
+		})
+	}
+}
+
+func TestPreview(t *testing.T) {
+	store := &mockStore{}
+	svc := newTestService(store)
+
+	preview, err := svc.Preview(context.Background(), models.SaveFeedFilterParams{Kind: models.FeedFilterMinLength, MinLength: 10})
+	if err != nil {
+		t.Fatalf("Preview() error = %v", err)
+	}
+	if preview.Checked != len(testItems) || preview.Removed != 2 || len(preview.Items) != 2 {
+		t.Errorf("Preview() = checked %d, removed %d, %d items, want %d, 2, 2", preview.Checked, preview.Removed, len(preview.Items), len(testItems))
+	}
+	if store.lists != 0 || len(store.filters) != 0 {
+		t.Error("Preview() should not load or save filters")
+	}
+}
@@ -0,0 +1,192 @@
+// Package fwtargets keeps a local registry of Betaflight board targets,
+// synced from the Betaflight build API, so FC configs and catalog flight
+// controllers can be checked against real targets and target names can be
+// autocompleted.
+package fwtargets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	maxResponseBytes = 4 * 1024 * 1024
+	userAgent        = "FlyingForge-TargetSync/1.0"
+
+	DefaultLimit = 20
+	MaxLimit     = 50
+)
+
+// errEmptyList stops a sync from marking every target removed when the API
+// answers with nothing
+var errEmptyList = errors.New("target list is empty")
+
+// Store defines the registry persistence operations
+type Store interface {
+	Sync(ctx context.Context, targets []models.FirmwareTarget) (int, int64, error)
+	LinkConfigs(ctx context.Context) (int64, error)
+	LinkCatalog(ctx context.Context) (int64, error)
+	Search(ctx context.Context, prefix string, limit int) ([]models.FirmwareTarget, error)
+	Get(ctx context.Context, target string) (*models.FirmwareTargetDetail, error)
+}
+
+// Service syncs and serves the firmware target registry
+type Service struct {
+	store      Store
+	client     *http.Client
+	targetsURL string
+	logger     *logging.Logger
+}
+
+// NewService creates a firmware target service. An empty targetsURL turns
+// syncing off; the registry can still be read.
+func NewService(store *database.FirmwareTargetStore, targetsURL string, logger *logging.Logger) *Service {
+	return &Service{
+		store:      store,
+		client:     &http.Client{Timeout: 30 * time.Second},
+		targetsURL: strings.TrimSpace(targetsURL),
+		logger:     logger,
+	}
+}
+
+// SyncEnabled reports whether a target list URL is configured
+func (s *Service) SyncEnabled() bool {
+	return s.targetsURL != ""
+}
+
+// Sync downloads the target list, updates the registry and links FC configs
+// and catalog items that now match a target
+func (s *Service) Sync(ctx context.Context) (*models.FirmwareTargetSyncResult, error) {
+	start := time.Now()
+	targets, err := s.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.FirmwareTargetSyncResult{Targets: len(targets)}
+	if result.Added, result.Removed, err = s.store.Sync(ctx, targets); err != nil {
+		return nil, err
+	}
+	if result.LinkedConfigs, err = s.store.LinkConfigs(ctx); err != nil {
+		return nil, err
+	}
+	if result.LinkedCatalogItems, err = s.store.LinkCatalog(ctx); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Firmware targets synced", logging.WithFields(map[string]interface{}{
+		"targets":       result.Targets,
+		"added":         result.Added,
+		"removed":       result.Removed,
+		"linkedConfigs": result.LinkedConfigs,
+		"linkedCatalog": result.LinkedCatalogItems,
+		"duration":      time.Since(start).String(),
+	}))
+	return result, nil
+}
+
+// upstreamTarget is one entry of the build API's list. Only the name is
+// required; the rest is kept when the API includes it.
+type upstreamTarget struct {
+	Target       string `json:"target"`
+	Manufacturer string `json:"manufacturer"`
+	MCU          string `json:"mcu"`
+}
+
+// fetch downloads and parses the target list
+func (s *Service) fetch(ctx context.Context) ([]models.FirmwareTarget, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.targetsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch firmware targets: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(body) > maxResponseBytes {
+		return nil, fmt.Errorf("target list is larger than %d bytes", maxResponseBytes)
+	}
+	return parseTargets(body)
+}
+
+// parseTargets reads a list of target objects or of bare target names.
+// Names are normalized and duplicates dropped.
+func parseTargets(body []byte) ([]models.FirmwareTarget, error) {
+	var entries []upstreamTarget
+	if err := json.Unmarshal(body, &entries); err != nil {
+		var names []string
+		if nameErr := json.Unmarshal(body, &names); nameErr != nil {
+			return nil, fmt.Errorf("failed to parse target list: %w", err)
+		}
+		entries = make([]upstreamTarget, len(names))
+		for i, name := range names {
+			entries[i] = upstreamTarget{Target: name}
+		}
+	}
+
+	seen := make(map[string]bool, len(entries))
+	targets := make([]models.FirmwareTarget, 0, len(entries))
+	for _, entry := range entries {
+		name := models.NormalizeFirmwareTarget(entry.Target)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		targets = append(targets, models.FirmwareTarget{
+			Target:       name,
+			Manufacturer: strings.TrimSpace(entry.Manufacturer),
+			MCU:          strings.ToUpper(strings.TrimSpace(entry.MCU)),
+		})
+	}
+	if len(targets) == 0 {
+		return nil, errEmptyList
+	}
+	return targets, nil
+}
+
+// Search autocompletes a target name
+func (s *Service) Search(ctx context.Context, query string, limit int) (*models.FirmwareTargetListResponse, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	prefix := models.NormalizeFirmwareTarget(query)
+	targets, err := s.store.Search(ctx, prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.FirmwareTargetListResponse{Targets: targets, Query: prefix}, nil
+}
+
+// Get looks a target up by name; nil means it isn't a known target
+func (s *Service) Get(ctx context.Context, target string) (*models.FirmwareTargetDetail, error) {
+	name := models.NormalizeFirmwareTarget(target)
+	if name == "" {
+		return nil, nil
+	}
+	return s.store.Get(ctx, name)
+}
@@ -0,0 +1,151 @@
+package fwtargets
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+type fakeStore struct {
+	synced       []models.FirmwareTarget
+	linkedConfig bool
+	searched     string
+}
+
+func (f *fakeStore) Sync(ctx context.Context, targets []models.FirmwareTarget) (int, int64, error) {
+	f.synced = targets
+	return len(targets), 0, nil
+}
+
+func (f *fakeStore) LinkConfigs(ctx context.Context) (int64, error) {
+	f.linkedConfig = true
+	return 2, nil
+}
+
+func (f *fakeStore) LinkCatalog(ctx context.Context) (int64, error) {
+	return 1, nil
+}
+
+func (f *fakeStore) Search(ctx context.Context, prefix string, limit int) ([]models.FirmwareTarget, error) {
+	f.searched = prefix
+	return []models.FirmwareTarget{}, nil
+}
+
+func (f *fakeStore) Get(ctx context.Context, target string) (*models.FirmwareTargetDetail, error) {
+	return nil, nil
+}
+
+func newTestService(store Store, url string) *Service {
+	return &Service{store: store, client: http.DefaultClient, targetsURL: url, logger: testutil.NullLogger()}
+}
+
+func TestParseTargets(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []models.FirmwareTarget
+		wantErr bool
+		errIs   error
+	}{
+		{
+			name: "objects",
+			body: `[{"target": "SPEEDYBEEF405V4", "manufacturer": "SPBE", "mcu": "stm32f405"}, {"target": "matekh743"}]`,
+			want: []models.FirmwareTarget{
+				{Target: "SPEEDYBEEF405V4", Manufacturer: "SPBE", MCU: "STM32F405"},
+				{Target: "MATEKH743"},
+			},
+		},
+		{
+			name: "bare names with duplicates",
+			body: `["STM32F405", " stm32f405 ", "", "TMOTORF7"]`,
+			want: []models.FirmwareTarget{{Target: "STM32F405"}, {Target: "TMOTORF7"}},
+		},
+		{name: "empty list", body: `[]`, wantErr: true, errIs: errEmptyList},
+		{name: "not a list", body: `{"error": "maintenance"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTargets([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTargets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.errIs != nil && !errors.Is(err, tt.errIs) {
+				t.Fatalf("parseTargets() error = %v, want %v", err, tt.errIs)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("parseTargets() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSync(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    *models.FirmwareTargetSyncResult // nil when the sync fails
+		wantErr bool
+	}{
+		{
+			name:   "adds and links targets",
+			status: http.StatusOK,
+			body:   `[{"target": "SPEEDYBEEF405V4"}, {"target": "JHEF7DUAL"}]`,
+			want:   &models.FirmwareTargetSyncResult{Targets: 2, Added: 2, LinkedConfigs: 2, LinkedCatalogItems: 1},
+		},
+		{name: "upstream error", status: http.StatusBadGateway, wantErr: true},
+		{name: "empty list", status: http.StatusOK, body: `[]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("User-Agent") != userAgent {
+					t.Errorf("User-Agent = %q", r.Header.Get("User-Agent"))
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			store := &fakeStore{}
+			result, err := newTestService(store, server.URL).Sync(context.Background())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if store.synced != nil {
+					t.Fatal("registry must not be touched when the download fails")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+			if *result != *tt.want {
+				t.Fatalf("Sync() = %+v, want %+v", *result, *tt.want)
+			}
+			if len(store.synced) != tt.want.Targets || !store.linkedConfig {
+				t.Fatalf("store not updated: %+v", store)
+			}
+		})
+	}
+}
+
+func TestSearch_NormalizesPrefix(t *testing.T) {
+	store := &fakeStore{}
+	response, err := newTestService(store, "").Search(context.Background(), " speedy bee", 0)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if store.searched != "SPEEDYBEE" || response.Query != "SPEEDYBEE" {
+		t.Fatalf("searched %q, query %q; want SPEEDYBEE", store.searched, response.Query)
+	}
+}
@@ -0,0 +1,487 @@
+// Package groups manages clubs of pilots: memberships, invitations, the
+// aircraft and batteries members share, and a feed of members' public builds.
+package groups
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/builds"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	// invitationTTL is how long an invitation stays open
+	invitationTTL = 14 * 24 * time.Hour
+
+	maxNameLength        = 100
+	maxDescriptionLength = 2000
+)
+
+var (
+	// ErrGroupNotFound is returned when the group doesn't exist or the caller
+	// isn't a member of it.
+	ErrGroupNotFound = errors.New("group not found")
+	// ErrInvitationNotFound is returned when the invitation doesn't exist, isn't
+	// addressed to the caller, or is no longer open.
+	ErrInvitationNotFound = errors.New("invitation not found")
+	// ErrForbidden is returned when the caller's role doesn't allow the change.
+	ErrForbidden = errors.New("your role in this group doesn't allow that")
+)
+
+// Store defines the group persistence operations
+type Store interface {
+	Create(ctx context.Context, userID string, params models.CreateGroupParams) (*models.Group, error)
+	Get(ctx context.Context, id, userID string) (*models.Group, error)
+	ListForUser(ctx context.Context, userID string) ([]models.Group, error)
+	Update(ctx context.Context, id string, params models.UpdateGroupParams) error
+	Delete(ctx context.Context, id string) error
+
+	GetMemberRole(ctx context.Context, groupID, userID string) (models.GroupRole, error)
+	ListMembers(ctx context.Context, groupID string) ([]models.GroupMember, error)
+	SetMemberRole(ctx context.Context, groupID, userID string, role models.GroupRole) (bool, error)
+	RemoveMember(ctx context.Context, groupID, userID string) (bool, error)
+
+	CreateInvitation(ctx context.Context, groupID, invitedUserID, invitedByUserID string, role models.GroupRole, expiresAt time.Time) (*models.GroupInvitation, error)
+	GetInvitation(ctx context.Context, id string) (*models.GroupInvitation, error)
+	ListGroupInvitations(ctx context.Context, groupID string) ([]models.GroupInvitation, error)
+	ListUserInvitations(ctx context.Context, userID string) ([]models.GroupInvitation, error)
+	RespondToInvitation(ctx context.Context, id string, status models.GroupInvitationStatus) (bool, error)
+
+	ShareAircraft(ctx context.Context, groupID, aircraftID, userID string) (bool, error)
+	UnshareAircraft(ctx context.Context, groupID, aircraftID, sharedByUserID string) (bool, error)
+	ListAircraft(ctx context.Context, groupID string) ([]models.GroupSharedAircraft, error)
+	ShareBattery(ctx context.Context, groupID, batteryID, userID string) (bool, error)
+	UnshareBattery(ctx context.Context, groupID, batteryID, sharedByUserID string) (bool, error)
+	ListBatteries(ctx context.Context, groupID string) ([]models.GroupSharedBattery, error)
+}
+
+// UserReader looks up users to invite
+type UserReader interface {
+	GetByID(ctx context.Context, id string) (*models.User, error)
+	GetByCallSign(ctx context.Context, callSign string) (*models.User, error)
+}
+
+// BuildLister lists published builds
+type BuildLister interface {
+	ListPublic(ctx context.Context, params models.BuildListParams) (*models.BuildListResponse, error)
+}
+
+// Service enforces group roles on top of the group store
+type Service struct {
+	store  Store
+	users  UserReader
+	builds BuildLister
+	logger *logging.Logger
+}
+
+// NewService creates a new group service
+func NewService(store *database.GroupStore, userStore *database.UserStore, buildSvc *builds.Service, logger *logging.Logger) *Service {
+	return &Service{
+		store:  store,
+		users:  userStore,
+		builds: buildSvc,
+		logger: logger,
+	}
+}
+
+// Create creates a group owned by the user
+func (s *Service) Create(ctx context.Context, userID string, params models.CreateGroupParams) (*models.Group, error) {
+	params.Name = strings.TrimSpace(params.Name)
+	params.Description = strings.TrimSpace(params.Description)
+	if err := validateGroup(&params.Name, &params.Description); err != nil {
+		return nil, err
+	}
+	return s.store.Create(ctx, userID, params)
+}
+
+// List returns the groups the user belongs to
+func (s *Service) List(ctx context.Context, userID string) ([]models.Group, error) {
+	return s.store.ListForUser(ctx, userID)
+}
+
+// Get returns a group with its members. Owners and admins also see pending
+// invitations.
+func (s *Service) Get(ctx context.Context, groupID, userID string) (*models.GroupDetails, error) {
+	group, err := s.memberGroup(ctx, groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.store.ListMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	details := &models.GroupDetails{Group: *group, Members: members}
+	if group.Role.CanManage() {
+		if details.Invitations, err = s.store.ListGroupInvitations(ctx, groupID); err != nil {
+			return nil, err
+		}
+	}
+	return details, nil
+}
+
+// Update changes a group's name or description. Owners and admins only.
+func (s *Service) Update(ctx context.Context, groupID, userID string, params models.UpdateGroupParams) (*models.Group, error) {
+	if _, err := s.requireRole(ctx, groupID, userID, models.GroupRole.CanManage); err != nil {
+		return nil, err
+	}
+	if params.Name != nil {
+		name := strings.TrimSpace(*params.Name)
+		params.Name = &name
+	}
+	if params.Description != nil {
+		description := strings.TrimSpace(*params.Description)
+		params.Description = &description
+	}
+	if err := validateGroup(params.Name, params.Description); err != nil {
+		return nil, err
+	}
+	if err := s.store.Update(ctx, groupID, params); err != nil {
+		return nil, err
+	}
+	return s.store.Get(ctx, groupID, userID)
+}
+
+// Delete deletes a group. Owner only.
+func (s *Service) Delete(ctx context.Context, groupID, userID string) error {
+	if _, err := s.requireRole(ctx, groupID, userID, isOwner); err != nil {
+		return err
+	}
+	return s.store.Delete(ctx, groupID)
+}
+
+// Invite invites a user by ID or call sign. Owners and admins can invite
+// members; only the owner can invite admins.
+func (s *Service) Invite(ctx context.Context, groupID, userID string, params models.InviteGroupMemberParams, now time.Time) (*models.GroupInvitation, error) {
+	role, err := s.requireRole(ctx, groupID, userID, models.GroupRole.CanManage)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Role == "" {
+		params.Role = models.GroupRoleMember
+	}
+	if params.Role != models.GroupRoleMember && params.Role != models.GroupRoleAdmin {
+		return nil, &ServiceError{Message: "role must be member or admin"}
+	}
+	if params.Role == models.GroupRoleAdmin && role != models.GroupRoleOwner {
+		return nil, ErrForbidden
+	}
+
+	var invitee *models.User
+	switch {
+	case strings.TrimSpace(params.UserID) != "":
+		invitee, err = s.users.GetByID(ctx, strings.TrimSpace(params.UserID))
+	case strings.TrimSpace(params.CallSign) != "":
+		invitee, err = s.users.GetByCallSign(ctx, strings.TrimSpace(params.CallSign))
+	default:
+		return nil, &ServiceError{Message: "userId or callSign is required"}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if invitee == nil {
+		return nil, &ServiceError{Message: "user not found"}
+	}
+
+	existing, err := s.store.GetMemberRole(ctx, groupID, invitee.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != "" {
+		return nil, &ServiceError{Message: "user is already a member of this group"}
+	}
+
+	invitation, err := s.store.CreateInvitation(ctx, groupID, invitee.ID, userID, params.Role, now.Add(invitationTTL))
+	if err != nil {
+		return nil, err
+	}
+	if invitation == nil {
+		return nil, &ServiceError{Message: "user already has a pending invitation to this group"}
+	}
+	return invitation, nil
+}
+
+// RevokeInvitation withdraws a pending invitation. Owners and admins only.
+func (s *Service) RevokeInvitation(ctx context.Context, groupID, invitationID, userID string) error {
+	if _, err := s.requireRole(ctx, groupID, userID, models.GroupRole.CanManage); err != nil {
+		return err
+	}
+	invitation, err := s.store.GetInvitation(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if invitation == nil || invitation.GroupID != groupID {
+		return ErrInvitationNotFound
+	}
+	ok, err := s.store.RespondToInvitation(ctx, invitationID, models.GroupInvitationRevoked)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+// ListInvitations returns the open invitations sent to the user
+func (s *Service) ListInvitations(ctx context.Context, userID string) ([]models.GroupInvitation, error) {
+	return s.store.ListUserInvitations(ctx, userID)
+}
+
+// RespondToInvitation accepts or declines an invitation sent to the user
+func (s *Service) RespondToInvitation(ctx context.Context, invitationID, userID string, accept bool, now time.Time) error {
+	invitation, err := s.store.GetInvitation(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if invitation == nil || invitation.InvitedUserID != userID ||
+		invitation.Status != models.GroupInvitationPending || !now.Before(invitation.ExpiresAt) {
+		return ErrInvitationNotFound
+	}
+
+	status := models.GroupInvitationDeclined
+	if accept {
+		status = models.GroupInvitationAccepted
+	}
+	ok, err := s.store.RespondToInvitation(ctx, invitationID, status)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+// SetMemberRole promotes or demotes a member. Owner only; ownership can't be
+// handed over this way.
+func (s *Service) SetMemberRole(ctx context.Context, groupID, userID, memberID string, role models.GroupRole) error {
+	if _, err := s.requireRole(ctx, groupID, userID, isOwner); err != nil {
+		return err
+	}
+	if role != models.GroupRoleMember && role != models.GroupRoleAdmin {
+		return &ServiceError{Message: "role must be member or admin"}
+	}
+	if memberID == userID {
+		return &ServiceError{Message: "the owner's role can't be changed"}
+	}
+	ok, err := s.store.SetMemberRole(ctx, groupID, memberID, role)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &ServiceError{Message: "member not found"}
+	}
+	return nil
+}
+
+// RemoveMember removes a member, or lets a member leave when memberID is the
+// caller. The owner can't leave, and admins can only remove plain members.
+// Anything the member shared with the group is unshared.
+func (s *Service) RemoveMember(ctx context.Context, groupID, userID, memberID string) error {
+	role, err := s.requireRole(ctx, groupID, userID, func(models.GroupRole) bool { return true })
+	if err != nil {
+		return err
+	}
+
+	if memberID == userID {
+		if role == models.GroupRoleOwner {
+			return &ServiceError{Message: "the owner can't leave the group; delete it instead"}
+		}
+	} else {
+		if !role.CanManage() {
+			return ErrForbidden
+		}
+		memberRole, err := s.store.GetMemberRole(ctx, groupID, memberID)
+		if err != nil {
+			return err
+		}
+		if memberRole == "" {
+			return &ServiceError{Message: "member not found"}
+		}
+		if memberRole == models.GroupRoleOwner || (memberRole == models.GroupRoleAdmin && role != models.GroupRoleOwner) {
+			return ErrForbidden
+		}
+	}
+
+	ok, err := s.store.RemoveMember(ctx, groupID, memberID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &ServiceError{Message: "member not found"}
+	}
+	return nil
+}
+
+// ListAircraft returns the aircraft shared with the group
+func (s *Service) ListAircraft(ctx context.Context, groupID, userID string) ([]models.GroupSharedAircraft, error) {
+	if _, err := s.memberGroup(ctx, groupID, userID); err != nil {
+		return nil, err
+	}
+	return s.store.ListAircraft(ctx, groupID)
+}
+
+// ShareAircraft shares one of the member's own aircraft with the group
+func (s *Service) ShareAircraft(ctx context.Context, groupID, userID, aircraftID string) error {
+	if _, err := s.memberGroup(ctx, groupID, userID); err != nil {
+		return err
+	}
+	ok, err := s.store.ShareAircraft(ctx, groupID, aircraftID, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &ServiceError{Message: "aircraft not found"}
+	}
+	return nil
+}
+
+// UnshareAircraft removes an aircraft from the group. Members can unshare
+// their own aircraft; owners and admins can unshare any.
+func (s *Service) UnshareAircraft(ctx context.Context, groupID, userID, aircraftID string) error {
+	sharedBy, err := s.unshareScope(ctx, groupID, userID)
+	if err != nil {
+		return err
+	}
+	ok, err := s.store.UnshareAircraft(ctx, groupID, aircraftID, sharedBy)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &ServiceError{Message: "aircraft not found"}
+	}
+	return nil
+}
+
+// ListBatteries returns the batteries shared with the group
+func (s *Service) ListBatteries(ctx context.Context, groupID, userID string) ([]models.GroupSharedBattery, error) {
+	if _, err := s.memberGroup(ctx, groupID, userID); err != nil {
+		return nil, err
+	}
+	return s.store.ListBatteries(ctx, groupID)
+}
+
+// ShareBattery shares one of the member's own batteries with the group
+func (s *Service) ShareBattery(ctx context.Context, groupID, userID, batteryID string) error {
+	if _, err := s.memberGroup(ctx, groupID, userID); err != nil {
+		return err
+	}
+	ok, err := s.store.ShareBattery(ctx, groupID, batteryID, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &ServiceError{Message: "battery not found"}
+	}
+	return nil
+}
+
+// UnshareBattery removes a battery from the group. Members can unshare their
+// own batteries; owners and admins can unshare any.
+func (s *Service) UnshareBattery(ctx context.Context, groupID, userID, batteryID string) error {
+	sharedBy, err := s.unshareScope(ctx, groupID, userID)
+	if err != nil {
+		return err
+	}
+	ok, err := s.store.UnshareBattery(ctx, groupID, batteryID, sharedBy)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &ServiceError{Message: "battery not found"}
+	}
+	return nil
+}
+
+// Feed returns the published builds of the group's members, newest first
+func (s *Service) Feed(ctx context.Context, groupID, userID string, limit, offset int) (*models.BuildListResponse, error) {
+	if _, err := s.memberGroup(ctx, groupID, userID); err != nil {
+		return nil, err
+	}
+	members, err := s.store.ListMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	ownerIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		ownerIDs = append(ownerIDs, member.UserID)
+	}
+	return s.builds.ListPublic(ctx, models.BuildListParams{
+		Sort:         models.BuildSortNewest,
+		Limit:        limit,
+		Offset:       offset,
+		OwnerUserIDs: ownerIDs,
+	})
+}
+
+// memberGroup loads a group the user belongs to. Non-members get
+// ErrGroupNotFound so group IDs can't be probed.
+func (s *Service) memberGroup(ctx context.Context, groupID, userID string) (*models.Group, error) {
+	group, err := s.store.Get(ctx, groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil || group.Role == "" {
+		return nil, ErrGroupNotFound
+	}
+	return group, nil
+}
+
+// requireRole returns the user's role in the group if allowed accepts it
+func (s *Service) requireRole(ctx context.Context, groupID, userID string, allowed func(models.GroupRole) bool) (models.GroupRole, error) {
+	group, err := s.memberGroup(ctx, groupID, userID)
+	if err != nil {
+		return "", err
+	}
+	if !allowed(group.Role) {
+		return "", ErrForbidden
+	}
+	return group.Role, nil
+}
+
+// unshareScope returns whose shares the user may remove: anyone's for owners
+// and admins, otherwise only their own
+func (s *Service) unshareScope(ctx context.Context, groupID, userID string) (string, error) {
+	group, err := s.memberGroup(ctx, groupID, userID)
+	if err != nil {
+		return "", err
+	}
+	if group.Role.CanManage() {
+		return "", nil
+	}
+	return userID, nil
+}
+
+func isOwner(role models.GroupRole) bool {
+	return role == models.GroupRoleOwner
+}
+
+func validateGroup(name, description *string) error {
+	if name != nil {
+		if *name == "" {
+			return &ServiceError{Message: "name is required"}
+		}
+		if len(*name) > maxNameLength {
+			return &ServiceError{Message: "name must be 100 characters or fewer"}
+		}
+	}
+	if description != nil && len(*description) > maxDescriptionLength {
+		return &ServiceError{Message: "description must be 2000 characters or fewer"}
+	}
+	return nil
+}
+
+// ServiceError represents a group validation error
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
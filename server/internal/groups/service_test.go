@@ -0,0 +1,325 @@
+package groups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+const (
+	groupID  = "group-1"
+	ownerID  = "user-owner"
+	adminID  = "user-admin"
+	memberID = "user-member"
+	outsider = "user-outsider"
+)
+
+// mockStore implements the Store interface in memory
+type mockStore struct {
+	roles       map[string]models.GroupRole // user ID -> role in groupID
+	invitations map[string]*models.GroupInvitation
+	aircraft    map[string]string // aircraft ID -> shared by
+	removed     []string
+}
+
+func newMockStore() *mockStore {
+	return &mockStore{
+		roles: map[string]models.GroupRole{
+			ownerID:  models.GroupRoleOwner,
+			adminID:  models.GroupRoleAdmin,
+			memberID: models.GroupRoleMember,
+		},
+		invitations: make(map[string]*models.GroupInvitation),
+		aircraft:    map[string]string{"quad-member": memberID, "quad-owner": ownerID},
+	}
+}
+
+func (m *mockStore) Create(ctx context.Context, userID string, params models.CreateGroupParams) (*models.Group, error) {
+	return &models.Group{ID: groupID, Name: params.Name, Role: models.GroupRoleOwner}, nil
+}
+
+func (m *mockStore) Get(ctx context.Context, id, userID string) (*models.Group, error) {
+	if id != groupID {
+		return nil, nil
+	}
+	return &models.Group{ID: groupID, Name: "Field Crew", MemberCount: len(m.roles), Role: m.roles[userID]}, nil
+}
+
+func (m *mockStore) ListForUser(ctx context.Context, userID string) ([]models.Group, error) {
+	return nil, nil
+}
+
+func (m *mockStore) Update(ctx context.Context, id string, params models.UpdateGroupParams) error {
+	return nil
+}
+
+func (m *mockStore) Delete(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m *mockStore) GetMemberRole(ctx context.Context, groupID, userID string) (models.GroupRole, error) {
+	return m.roles[userID], nil
+}
+
+func (m *mockStore) ListMembers(ctx context.Context, groupID string) ([]models.GroupMember, error) {
+	var members []models.GroupMember
+	for _, id := range []string{ownerID, adminID, memberID} {
+		if role, ok := m.roles[id]; ok {
+			members = append(members, models.GroupMember{UserID: id, Role: role})
+		}
+	}
+	return members, nil
+}
+
+func (m *mockStore) SetMemberRole(ctx context.Context, groupID, userID string, role models.GroupRole) (bool, error) {
+	if _, ok := m.roles[userID]; !ok {
+		return false, nil
+	}
+	m.roles[userID] = role
+	return true, nil
+}
+
+func (m *mockStore) RemoveMember(ctx context.Context, groupID, userID string) (bool, error) {
+	if _, ok := m.roles[userID]; !ok {
+		return false, nil
+	}
+	delete(m.roles, userID)
+	m.removed = append(m.removed, userID)
+	return true, nil
+}
+
+func (m *mockStore) CreateInvitation(ctx context.Context, groupID, invitedUserID, invitedByUserID string, role models.GroupRole, expiresAt time.Time) (*models.GroupInvitation, error) {
+	for _, invitation := range m.invitations {
+		if invitation.InvitedUserID == invitedUserID && invitation.Status == models.GroupInvitationPending {
+			return nil, nil
+		}
+	}
+	invitation := &models.GroupInvitation{
+		ID:              fmt.Sprintf("invite-%d", len(m.invitations)+1),
+		GroupID:         groupID,
+		InvitedUserID:   invitedUserID,
+		InvitedByUserID: invitedByUserID,
+		Role:            role,
+		Status:          models.GroupInvitationPending,
+		ExpiresAt:       expiresAt,
+	}
+	m.invitations[invitation.ID] = invitation
+	return invitation, nil
+}
+
+func (m *mockStore) GetInvitation(ctx context.Context, id string) (*models.GroupInvitation, error) {
+	return m.invitations[id], nil
+}
+
+func (m *mockStore) ListGroupInvitations(ctx context.Context, groupID string) ([]models.GroupInvitation, error) {
+	return nil, nil
+}
+
+func (m *mockStore) ListUserInvitations(ctx context.Context, userID string) ([]models.GroupInvitation, error) {
+	return nil, nil
+}
+
+func (m *mockStore) RespondToInvitation(ctx context.Context, id string, status models.GroupInvitationStatus) (bool, error) {
+	invitation, ok := m.invitations[id]
+	if !ok || invitation.Status != models.GroupInvitationPending {
+		return false, nil
+	}
+	invitation.Status = status
+	if status == models.GroupInvitationAccepted {
+		m.roles[invitation.InvitedUserID] = invitation.Role
+	}
+	return true, nil
+}
+
+func (m *mockStore) ShareAircraft(ctx context.Context, groupID, aircraftID, userID string) (bool, error) {
+	return true, nil
+}
+
+func (m *mockStore) UnshareAircraft(ctx context.Context, groupID, aircraftID, sharedByUserID string) (bool, error) {
+	sharer, ok := m.aircraft[aircraftID]
+	if !ok || (sharedByUserID != "" && sharer != sharedByUserID) {
+		return false, nil
+	}
+	delete(m.aircraft, aircraftID)
+	return true, nil
+}
+
+func (m *mockStore) ListAircraft(ctx context.Context, groupID string) ([]models.GroupSharedAircraft, error) {
+	return nil, nil
+}
+
+func (m *mockStore) ShareBattery(ctx context.Context, groupID, batteryID, userID string) (bool, error) {
+	return true, nil
+}
+
+func (m *mockStore) UnshareBattery(ctx context.Context, groupID, batteryID, sharedByUserID string) (bool, error) {
+	return true, nil
+}
+
+func (m *mockStore) ListBatteries(ctx context.Context, groupID string) ([]models.GroupSharedBattery, error) {
+	return nil, nil
+}
+
+type mockUsers map[string]*models.User
+
+func (m mockUsers) GetByID(ctx context.Context, id string) (*models.User, error) {
+	return m[id], nil
+}
+
+func (m mockUsers) GetByCallSign(ctx context.Context, callSign string) (*models.User, error) {
+	for _, user := range m {
+		if user.CallSign == callSign {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+type mockBuilds struct {
+	params models.BuildListParams
+}
+
+func (m *mockBuilds) ListPublic(ctx context.Context, params models.BuildListParams) (*models.BuildListResponse, error) {
+	m.params = params
+	return &models.BuildListResponse{}, nil
+}
+
+func newTestService(store *mockStore, buildLister *mockBuilds) *Service {
+	return &Service{
+		store: store,
+		users: mockUsers{
+			outsider: {ID: outsider, CallSign: "Drifter"},
+			memberID: {ID: memberID, CallSign: "Member"},
+		},
+		builds: buildLister,
+		logger: testutil.NullLogger(),
+	}
+}
+
+func TestInvite_RolesAndAcceptance(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := newMockStore()
+	svc := newTestService(store, &mockBuilds{})
+
+	if _, err := svc.Invite(ctx, groupID, memberID, models.InviteGroupMemberParams{CallSign: "Drifter"}, now); !errors.Is(err, ErrForbidden) {
+		t.Errorf("member invite error = %v, want ErrForbidden", err)
+	}
+	if _, err := svc.Invite(ctx, groupID, adminID, models.InviteGroupMemberParams{CallSign: "Drifter", Role: models.GroupRoleAdmin}, now); !errors.Is(err, ErrForbidden) {
+		t.Errorf("admin inviting an admin error = %v, want ErrForbidden", err)
+	}
+	var svcErr *ServiceError
+	if _, err := svc.Invite(ctx, groupID, adminID, models.InviteGroupMemberParams{UserID: memberID}, now); !errors.As(err, &svcErr) {
+		t.Errorf("inviting a member error = %v, want ServiceError", err)
+	}
+
+	invitation, err := svc.Invite(ctx, groupID, adminID, models.InviteGroupMemberParams{CallSign: "Drifter"}, now)
+	if err != nil {
+		t.Fatalf("Invite() error = %v", err)
+	}
+	if invitation.Role != models.GroupRoleMember || !invitation.ExpiresAt.Equal(now.Add(invitationTTL)) {
+		t.Errorf("invitation = %+v", invitation)
+	}
+	if _, err := svc.Invite(ctx, groupID, ownerID, models.InviteGroupMemberParams{UserID: outsider}, now); !errors.As(err, &svcErr) {
+		t.Errorf("duplicate invite error = %v, want ServiceError", err)
+	}
+
+	if err := svc.RespondToInvitation(ctx, invitation.ID, memberID, true, now); !errors.Is(err, ErrInvitationNotFound) {
+		t.Errorf("accepting someone else's invitation error = %v, want ErrInvitationNotFound", err)
+	}
+	if err := svc.RespondToInvitation(ctx, invitation.ID, outsider, true, now.Add(invitationTTL)); !errors.Is(err, ErrInvitationNotFound) {
+		t.Errorf("accepting an expired invitation error = %v, want ErrInvitationNotFound", err)
+	}
+	if err := svc.RespondToInvitation(ctx, invitation.ID, outsider, true, now.Add(time.Hour)); err != nil {
+		t.Fatalf("RespondToInvitation() error = %v", err)
+	}
+	if store.roles[outsider] != models.GroupRoleMember {
+		t.Errorf("role after accepting = %q, want member", store.roles[outsider])
+	}
+}
+
+func TestRemoveMember_Roles(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		caller  string
+		target  string
+		wantErr bool
+	}{
+		{"member leaves", memberID, memberID, false},
+		{"owner can't leave", ownerID, ownerID, true},
+		{"member can't remove others", memberID, adminID, true},
+		{"admin removes member", adminID, memberID, false},
+		{"admin can't remove owner", adminID, ownerID, true},
+		{"owner removes admin", ownerID, adminID, false},
+		{"outsider", outsider, memberID, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMockStore()
+			svc := newTestService(store, &mockBuilds{})
+			err := svc.RemoveMember(ctx, groupID, tt.caller, tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RemoveMember() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (len(store.removed) != 1 || store.removed[0] != tt.target) {
+				t.Errorf("removed = %v, want %s", store.removed, tt.target)
+			}
+		})
+	}
+}
+
+func TestUnshareAircraft_Scope(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	svc := newTestService(store, &mockBuilds{})
+
+	var svcErr *ServiceError
+	if err := svc.UnshareAircraft(ctx, groupID, memberID, "quad-owner"); !errors.As(err, &svcErr) {
+		t.Errorf("member unsharing another's aircraft error = %v, want ServiceError", err)
+	}
+	if err := svc.UnshareAircraft(ctx, groupID, memberID, "quad-member"); err != nil {
+		t.Errorf("member unsharing own aircraft error = %v", err)
+	}
+	if err := svc.UnshareAircraft(ctx, groupID, adminID, "quad-owner"); err != nil {
+		t.Errorf("admin unsharing any aircraft error = %v", err)
+	}
+}
+
+func TestFeed_MembersOnly(t *testing.T) {
+	ctx := context.Background()
+	buildLister := &mockBuilds{}
+	svc := newTestService(newMockStore(), buildLister)
+
+	if _, err := svc.Feed(ctx, groupID, outsider, 10, 0); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("outsider feed error = %v, want ErrGroupNotFound", err)
+	}
+	if _, err := svc.Feed(ctx, "missing", memberID, 10, 0); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("missing group error = %v, want ErrGroupNotFound", err)
+	}
+
+	if _, err := svc.Feed(ctx, groupID, memberID, 10, 20); err != nil {
+		t.Fatalf("Feed() error = %v", err)
+	}
+	if len(buildLister.params.OwnerUserIDs) != 3 || buildLister.params.Limit != 10 || buildLister.params.Offset != 20 {
+		t.Errorf("list params = %+v", buildLister.params)
+	}
+}
+
+func TestCreate_Validation(t *testing.T) {
+	svc := newTestService(newMockStore(), &mockBuilds{})
+	if _, err := svc.Create(context.Background(), ownerID, models.CreateGroupParams{Name: "  "}); err == nil {
+		t.Error("blank name should fail")
+	}
+	group, err := svc.Create(context.Background(), ownerID, models.CreateGroupParams{Name: " Field Crew "})
+	if err != nil || group.Name != "Field Crew" {
+		t.Errorf("Create() = %+v, %v", group, err)
+	}
+}
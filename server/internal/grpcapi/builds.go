@@ -0,0 +1,90 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	flyingforgev1 "github.com/johnrirwin/flyingforge/proto/gen/go/flyingforge/v1"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// BuildReader reads builds; *builds.Service satisfies it
+type BuildReader interface {
+	GetPublic(ctx context.Context, id string) (*models.Build, error)
+	GetOwnedOrPublic(ctx context.Context, id string, userID string) (*models.Build, error)
+	ListPublic(ctx context.Context, params models.BuildListParams) (*models.BuildListResponse, error)
+	ListByOwner(ctx context.Context, ownerUserID string, params models.BuildListParams) (*models.BuildListResponse, error)
+}
+
+type buildServer struct {
+	flyingforgev1.UnimplementedBuildServiceServer
+	builds BuildReader
+	logger *logging.Logger
+}
+
+// GetBuild returns a published build, or any of the caller's own builds
+// when user_id is set
+func (s *buildServer) GetBuild(ctx context.Context, req *flyingforgev1.GetBuildRequest) (*flyingforgev1.GetBuildResponse, error) {
+	id := strings.TrimSpace(req.GetId())
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	var (
+		b   *models.Build
+		err error
+	)
+	if userID := strings.TrimSpace(req.GetUserId()); userID != "" {
+		b, err = s.builds.GetOwnedOrPublic(ctx, id, userID)
+	} else {
+		b, err = s.builds.GetPublic(ctx, id)
+	}
+	if err != nil {
+		return nil, serviceError(s.logger, "GetBuild", err)
+	}
+	if b == nil {
+		return nil, status.Error(codes.NotFound, "build not found")
+	}
+	return &flyingforgev1.GetBuildResponse{Build: build(b)}, nil
+}
+
+func (s *buildServer) ListPublishedBuilds(ctx context.Context, req *flyingforgev1.ListPublishedBuildsRequest) (*flyingforgev1.ListPublishedBuildsResponse, error) {
+	params := models.BuildListParams{
+		Sort:        models.BuildSort(req.GetSort()),
+		FrameFilter: req.GetFrameFilter(),
+	}
+	switch params.Sort {
+	case "":
+		params.Sort = models.BuildSortNewest
+	case models.BuildSortNewest, models.BuildSortPopular:
+	default:
+		return nil, status.Error(codes.InvalidArgument, `sort must be "newest" or "popular"`)
+	}
+	params.Limit, params.Offset = page(req.GetPage())
+
+	result, err := s.builds.ListPublic(ctx, params)
+	if err != nil {
+		return nil, serviceError(s.logger, "ListPublishedBuilds", err)
+	}
+	return &flyingforgev1.ListPublishedBuildsResponse{Builds: buildList(result.Builds), TotalCount: int32(result.TotalCount)}, nil
+}
+
+func (s *buildServer) ListUserBuilds(ctx context.Context, req *flyingforgev1.ListUserBuildsRequest) (*flyingforgev1.ListUserBuildsResponse, error) {
+	userID, err := requireUser(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	var params models.BuildListParams
+	params.Limit, params.Offset = page(req.GetPage())
+
+	result, err := s.builds.ListByOwner(ctx, userID, params)
+	if err != nil {
+		return nil, serviceError(s.logger, "ListUserBuilds", err)
+	}
+	return &flyingforgev1.ListUserBuildsResponse{Builds: buildList(result.Builds), TotalCount: int32(result.TotalCount)}, nil
+}
@@ -0,0 +1,65 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	flyingforgev1 "github.com/johnrirwin/flyingforge/proto/gen/go/flyingforge/v1"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// CatalogReader reads the gear catalog; *database.GearCatalogStore
+// satisfies it
+type CatalogReader interface {
+	Get(ctx context.Context, id string) (*models.GearCatalogItem, error)
+	Search(ctx context.Context, params models.GearCatalogSearchParams) (*models.GearCatalogSearchResponse, error)
+}
+
+type catalogServer struct {
+	flyingforgev1.UnimplementedCatalogServiceServer
+	catalog CatalogReader
+	logger  *logging.Logger
+}
+
+func (s *catalogServer) GetCatalogItem(ctx context.Context, req *flyingforgev1.GetCatalogItemRequest) (*flyingforgev1.GetCatalogItemResponse, error) {
+	id := strings.TrimSpace(req.GetId())
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	item, err := s.catalog.Get(ctx, id)
+	if err != nil {
+		return nil, serviceError(s.logger, "GetCatalogItem", err)
+	}
+	// Only published items, as on the public catalog API
+	if item == nil || models.NormalizeCatalogStatus(item.Status) != models.CatalogStatusPublished {
+		return nil, status.Error(codes.NotFound, "catalog item not found")
+	}
+	return &flyingforgev1.GetCatalogItemResponse{Item: catalogItem(item)}, nil
+}
+
+func (s *catalogServer) SearchCatalog(ctx context.Context, req *flyingforgev1.SearchCatalogRequest) (*flyingforgev1.SearchCatalogResponse, error) {
+	params := models.GearCatalogSearchParams{
+		Query:    req.GetQuery(),
+		GearType: models.GearType(req.GetGearType()),
+		Brand:    req.GetBrand(),
+		Status:   models.CatalogStatusPublished,
+	}
+	params.Limit, params.Offset = page(req.GetPage())
+
+	result, err := s.catalog.Search(ctx, params)
+	if err != nil {
+		return nil, serviceError(s.logger, "SearchCatalog", err)
+	}
+
+	items := make([]*flyingforgev1.CatalogItem, 0, len(result.Items))
+	for i := range result.Items {
+		items = append(items, catalogItem(&result.Items[i]))
+	}
+	return &flyingforgev1.SearchCatalogResponse{Items: items, TotalCount: int32(result.TotalCount)}, nil
+}
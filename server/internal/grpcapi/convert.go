@@ -0,0 +1,176 @@
+package grpcapi
+
+import (
+	"encoding/json"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	flyingforgev1 "github.com/johnrirwin/flyingforge/proto/gen/go/flyingforge/v1"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// page returns the limit and offset of a list call, with the HTTP API's
+// default and cap
+func page(p *flyingforgev1.Page) (limit, offset int) {
+	limit = int(p.GetLimit())
+	if limit <= 0 {
+		limit = defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+	offset = int(p.GetOffset())
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+// specsStruct converts stored specs to a Struct. Specs that aren't a JSON
+// object are left out.
+func specsStruct(specs json.RawMessage) *structpb.Struct {
+	if len(specs) == 0 {
+		return nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(specs, &fields); err != nil || fields == nil {
+		return nil
+	}
+	s, err := structpb.NewStruct(fields)
+	if err != nil {
+		return nil
+	}
+	return s
+}
+
+// specsJSON converts request specs to the JSON the services store, or nil
+// when none were sent
+func specsJSON(specs *structpb.Struct) (json.RawMessage, error) {
+	if specs == nil {
+		return nil, nil
+	}
+	return specs.MarshalJSON()
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamp(*t)
+}
+
+// images lists an entity's images, falling back to its primary image when
+// the full list wasn't loaded
+func images(list []models.EntityImage, primaryURL string) []*flyingforgev1.Image {
+	if len(list) == 0 {
+		if primaryURL == "" {
+			return nil
+		}
+		return []*flyingforgev1.Image{{Url: primaryURL}}
+	}
+	out := make([]*flyingforgev1.Image, 0, len(list))
+	for _, image := range list {
+		out = append(out, &flyingforgev1.Image{Url: image.URL})
+	}
+	return out
+}
+
+func catalogItem(item *models.GearCatalogItem) *flyingforgev1.CatalogItem {
+	return &flyingforgev1.CatalogItem{
+		Id:           item.ID,
+		GearType:     string(item.GearType),
+		Brand:        item.Brand,
+		Model:        item.Model,
+		Variant:      item.Variant,
+		Specs:        specsStruct(item.Specs),
+		BestFor:      item.BestFor,
+		Msrp:         item.MSRP,
+		CanonicalKey: item.CanonicalKey,
+		Description:  item.Description,
+		Images:       images(item.Images, item.ImageURL),
+		UsageCount:   int32(item.UsageCount),
+		CreatedAt:    timestamp(item.CreatedAt),
+		UpdatedAt:    timestamp(item.UpdatedAt),
+	}
+}
+
+func inventoryItem(item *models.InventoryItem) *flyingforgev1.InventoryItem {
+	return &flyingforgev1.InventoryItem{
+		Id:             item.ID,
+		UserId:         item.UserID,
+		Name:           item.Name,
+		Category:       string(item.Category),
+		Manufacturer:   item.Manufacturer,
+		Quantity:       int32(item.Quantity),
+		Notes:          item.Notes,
+		CatalogId:      item.CatalogID,
+		BuildId:        item.BuildID,
+		PurchasePrice:  item.PurchasePrice,
+		PurchaseSeller: item.PurchaseSeller,
+		ProductUrl:     item.ProductURL,
+		ImageUrl:       item.ImageURL,
+		Specs:          specsStruct(item.Specs),
+		Archived:       item.Archived,
+		ArchivedAt:     optionalTimestamp(item.ArchivedAt),
+		CreatedAt:      timestamp(item.CreatedAt),
+		UpdatedAt:      timestamp(item.UpdatedAt),
+	}
+}
+
+func build(b *models.Build) *flyingforgev1.Build {
+	parts := make([]*flyingforgev1.BuildPart, 0, len(b.Parts))
+	for _, part := range b.Parts {
+		p := &flyingforgev1.BuildPart{
+			Id:            part.ID,
+			GearType:      string(part.GearType),
+			CatalogItemId: part.CatalogItemID,
+			Position:      int32(part.Position),
+			Notes:         part.Notes,
+		}
+		if part.CatalogItem != nil {
+			p.Brand = part.CatalogItem.Brand
+			p.Model = part.CatalogItem.Model
+			p.Variant = part.CatalogItem.Variant
+		}
+		parts = append(parts, p)
+	}
+	return &flyingforgev1.Build{
+		Id:           b.ID,
+		OwnerUserId:  b.OwnerUserID,
+		Status:       string(b.Status),
+		Title:        b.Title,
+		Description:  b.Description,
+		Parts:        parts,
+		Verified:     b.Verified,
+		MainImageUrl: b.MainImageURL,
+		Images:       images(b.Images, ""),
+		VideoUrl:     b.VideoURL,
+		ViewCount:    b.ViewCount,
+		CreatedAt:    timestamp(b.CreatedAt),
+		UpdatedAt:    timestamp(b.UpdatedAt),
+		PublishedAt:  optionalTimestamp(b.PublishedAt),
+	}
+}
+
+func buildList(list []models.Build) []*flyingforgev1.Build {
+	out := make([]*flyingforgev1.Build, 0, len(list))
+	for i := range list {
+		out = append(out, build(&list[i]))
+	}
+	return out
+}
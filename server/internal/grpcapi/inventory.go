@@ -0,0 +1,166 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	flyingforgev1 "github.com/johnrirwin/flyingforge/proto/gen/go/flyingforge/v1"
+
+	"github.com/johnrirwin/flyingforge/internal/inventory"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+type inventoryServer struct {
+	flyingforgev1.UnimplementedInventoryServiceServer
+	inventory inventory.InventoryManager
+	logger    *logging.Logger
+}
+
+// requireUser returns the user a call acts for. The inventory services treat
+// an empty user as unscoped, so every inventory call must name one.
+func requireUser(userID string) (string, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return "", status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	return userID, nil
+}
+
+func (s *inventoryServer) ListInventory(ctx context.Context, req *flyingforgev1.ListInventoryRequest) (*flyingforgev1.ListInventoryResponse, error) {
+	userID, err := requireUser(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	params := models.InventoryFilterParams{
+		Category: models.EquipmentCategory(req.GetCategory()),
+		BuildID:  req.GetBuildId(),
+		Query:    req.GetQuery(),
+		Status:   models.InventoryStatus(req.GetStatus()),
+	}
+	params.Limit, params.Offset = page(req.GetPage())
+
+	result, err := s.inventory.GetInventory(ctx, userID, params)
+	if err != nil {
+		return nil, serviceError(s.logger, "ListInventory", err)
+	}
+
+	items := make([]*flyingforgev1.InventoryItem, 0, len(result.Items))
+	for i := range result.Items {
+		items = append(items, inventoryItem(&result.Items[i]))
+	}
+	return &flyingforgev1.ListInventoryResponse{Items: items, TotalCount: int32(result.TotalCount)}, nil
+}
+
+func (s *inventoryServer) GetInventoryItem(ctx context.Context, req *flyingforgev1.GetInventoryItemRequest) (*flyingforgev1.GetInventoryItemResponse, error) {
+	item, err := s.ownedItem(ctx, "GetInventoryItem", req.GetUserId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &flyingforgev1.GetInventoryItemResponse{Item: inventoryItem(item)}, nil
+}
+
+func (s *inventoryServer) AddInventoryItem(ctx context.Context, req *flyingforgev1.AddInventoryItemRequest) (*flyingforgev1.AddInventoryItemResponse, error) {
+	userID, err := requireUser(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	specs, err := specsJSON(req.GetSpecs())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid specs")
+	}
+
+	params := models.AddInventoryParams{
+		Name:           req.GetName(),
+		Category:       models.EquipmentCategory(req.GetCategory()),
+		Manufacturer:   req.GetManufacturer(),
+		Quantity:       int(req.GetQuantity()),
+		Notes:          req.GetNotes(),
+		CatalogID:      req.GetCatalogId(),
+		BuildID:        req.GetBuildId(),
+		PurchasePrice:  req.PurchasePrice,
+		PurchaseSeller: req.GetPurchaseSeller(),
+		ProductURL:     req.GetProductUrl(),
+		Specs:          specs,
+	}
+
+	item, err := s.inventory.AddItem(ctx, userID, params)
+	if err != nil {
+		return nil, serviceError(s.logger, "AddInventoryItem", err)
+	}
+	return &flyingforgev1.AddInventoryItemResponse{Item: inventoryItem(item)}, nil
+}
+
+func (s *inventoryServer) UpdateInventoryItem(ctx context.Context, req *flyingforgev1.UpdateInventoryItemRequest) (*flyingforgev1.UpdateInventoryItemResponse, error) {
+	item, err := s.ownedItem(ctx, "UpdateInventoryItem", req.GetUserId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	specs, err := specsJSON(req.GetSpecs())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid specs")
+	}
+
+	params := models.UpdateInventoryParams{
+		ID:             item.ID,
+		Name:           req.Name,
+		Manufacturer:   req.Manufacturer,
+		Notes:          req.Notes,
+		BuildID:        req.BuildId,
+		PurchasePrice:  req.PurchasePrice,
+		PurchaseSeller: req.PurchaseSeller,
+		ProductURL:     req.ProductUrl,
+		Specs:          specs,
+	}
+	if req.Category != nil {
+		category := models.EquipmentCategory(*req.Category)
+		params.Category = &category
+	}
+	if req.Quantity != nil {
+		quantity := int(*req.Quantity)
+		params.Quantity = &quantity
+	}
+
+	updated, err := s.inventory.UpdateItem(ctx, item.UserID, params)
+	if err != nil {
+		return nil, serviceError(s.logger, "UpdateInventoryItem", err)
+	}
+	return &flyingforgev1.UpdateInventoryItemResponse{Item: inventoryItem(updated)}, nil
+}
+
+func (s *inventoryServer) DeleteInventoryItem(ctx context.Context, req *flyingforgev1.DeleteInventoryItemRequest) (*flyingforgev1.DeleteInventoryItemResponse, error) {
+	item, err := s.ownedItem(ctx, "DeleteInventoryItem", req.GetUserId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.inventory.RemoveItem(ctx, item.ID, item.UserID); err != nil {
+		return nil, serviceError(s.logger, "DeleteInventoryItem", err)
+	}
+	return &flyingforgev1.DeleteInventoryItemResponse{}, nil
+}
+
+// ownedItem loads one of a user's items, or returns NotFound when the user
+// doesn't have it
+func (s *inventoryServer) ownedItem(ctx context.Context, method, userID, id string) (*models.InventoryItem, error) {
+	userID, err := requireUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	item, err := s.inventory.GetItem(ctx, id, userID)
+	if err != nil {
+		return nil, serviceError(s.logger, method, err)
+	}
+	if item == nil {
+		return nil, status.Error(codes.NotFound, "inventory item not found")
+	}
+	return item, nil
+}
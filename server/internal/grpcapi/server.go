@@ -0,0 +1,149 @@
+// Package grpcapi serves the internal gRPC API defined in proto/: catalog
+// reads, inventory CRUD and build reads for trusted services such as a
+// recommendation service or the MCP sidecar. It calls the same services as
+// the HTTP API and is only started when GRPC_ENABLED is set.
+package grpcapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	flyingforgev1 "github.com/johnrirwin/flyingforge/proto/gen/go/flyingforge/v1"
+
+	"github.com/johnrirwin/flyingforge/internal/builds"
+	"github.com/johnrirwin/flyingforge/internal/inventory"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/tenancy"
+)
+
+// tenantHostKey is the metadata key naming the host of the tenant a call is
+// made for, the same host the HTTP API resolves tenants from
+const tenantHostKey = "x-tenant-host"
+
+// ErrNoToken is returned by New when no shared token is configured. Every
+// caller can act for any user, so the API never runs unauthenticated.
+var ErrNoToken = errors.New("grpc api needs GRPC_TOKEN")
+
+// TenantResolver finds the tenant a call is made for
+type TenantResolver interface {
+	Enabled() bool
+	Resolve(host string) *models.Tenant
+	Lookup(host string) (*models.Tenant, bool)
+}
+
+// Server is the internal gRPC server
+type Server struct {
+	server *grpc.Server
+	logger *logging.Logger
+}
+
+// New creates a gRPC server for the given services. Callers authenticate
+// with token as a bearer token in the "authorization" metadata, and each
+// call is scoped to the tenant tenants resolves for its "x-tenant-host".
+func New(catalog CatalogReader, inventorySvc inventory.InventoryManager, buildSvc BuildReader, tenants TenantResolver, token string, logger *logging.Logger) (*Server, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, ErrNoToken
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(requireToken(token), scopeTenant(tenants)),
+	)
+	flyingforgev1.RegisterCatalogServiceServer(server, &catalogServer{catalog: catalog, logger: logger})
+	flyingforgev1.RegisterInventoryServiceServer(server, &inventoryServer{inventory: inventorySvc, logger: logger})
+	flyingforgev1.RegisterBuildServiceServer(server, &buildServer{builds: buildSvc, logger: logger})
+
+	return &Server{server: server, logger: logger}, nil
+}
+
+// Serve accepts connections on lis until Stop is called
+func (s *Server) Serve(lis net.Listener) error {
+	s.logger.Info("gRPC API server starting", logging.WithField("addr", lis.Addr().String()))
+	return s.server.Serve(lis)
+}
+
+// Stop waits for in-flight calls to finish, or cancels them when ctx ends
+// first
+func (s *Server) Stop(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.server.Stop()
+	}
+}
+
+// requireToken rejects calls without the shared bearer token
+func requireToken(token string) grpc.UnaryServerInterceptor {
+	want := []byte("Bearer " + token)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, got := range md.Get("authorization") {
+			if subtle.ConstantTimeCompare([]byte(got), want) == 1 {
+				return handler(ctx, req)
+			}
+		}
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
+}
+
+// scopeTenant scopes each call's queries to the tenant named by its
+// "x-tenant-host" metadata. With tenancy disabled every call runs in the
+// default tenant; with it enabled a call must name a known tenant host,
+// since falling back to the default tenant would let it act on another
+// tenant's users.
+func scopeTenant(tenants TenantResolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !tenants.Enabled() {
+			return handler(tenancy.WithTenant(ctx, tenants.Resolve(""), false), req)
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		hosts := md.Get(tenantHostKey)
+		if len(hosts) == 0 || strings.TrimSpace(hosts[0]) == "" {
+			return nil, status.Error(codes.InvalidArgument, tenantHostKey+" metadata is required")
+		}
+		tenant, ok := tenants.Lookup(hosts[0])
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "unknown tenant host")
+		}
+		return handler(tenancy.WithTenant(ctx, tenant, true), req)
+	}
+}
+
+// serviceError converts an error from the services into a gRPC status.
+// Validation errors carry their message; anything else is logged and
+// reported as internal.
+func serviceError(logger *logging.Logger, method string, err error) error {
+	var inventoryErr *inventory.ServiceError
+	if errors.As(err, &inventoryErr) {
+		return status.Error(codes.InvalidArgument, inventoryErr.Message)
+	}
+	var buildErr *builds.ServiceError
+	if errors.As(err, &buildErr) {
+		return status.Error(codes.InvalidArgument, buildErr.Message)
+	}
+	if errors.Is(err, context.Canceled) {
+		return status.Error(codes.Canceled, err.Error())
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	logger.Error("gRPC call failed", logging.WithFields(map[string]interface{}{
+		"method": method,
+		"error":  err.Error(),
+	}))
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,321 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	flyingforgev1 "github.com/johnrirwin/flyingforge/proto/gen/go/flyingforge/v1"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/inventory"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const testToken = "test-token"
+
+type fakeCatalog struct {
+	items      map[string]*models.GearCatalogItem
+	lastSearch models.GearCatalogSearchParams
+	lastTenant string
+}
+
+func (c *fakeCatalog) Get(_ context.Context, id string) (*models.GearCatalogItem, error) {
+	return c.items[id], nil
+}
+
+func (c *fakeCatalog) Search(ctx context.Context, params models.GearCatalogSearchParams) (*models.GearCatalogSearchResponse, error) {
+	c.lastSearch = params
+	c.lastTenant = database.TenantFromContext(ctx)
+	resp := &models.GearCatalogSearchResponse{}
+	for _, item := range c.items {
+		resp.Items = append(resp.Items, *item)
+	}
+	resp.TotalCount = len(resp.Items)
+	return resp, nil
+}
+
+type fakeBuilds struct {
+	public map[string]*models.Build
+	owned  map[string]*models.Build // keyed by owner and id
+	params models.BuildListParams
+}
+
+func (b *fakeBuilds) GetPublic(_ context.Context, id string) (*models.Build, error) {
+	return b.public[id], nil
+}
+
+func (b *fakeBuilds) GetOwnedOrPublic(_ context.Context, id string, userID string) (*models.Build, error) {
+	if build, ok := b.owned[userID+"/"+id]; ok {
+		return build, nil
+	}
+	return b.public[id], nil
+}
+
+func (b *fakeBuilds) ListPublic(_ context.Context, params models.BuildListParams) (*models.BuildListResponse, error) {
+	b.params = params
+	resp := &models.BuildListResponse{}
+	for _, build := range b.public {
+		resp.Builds = append(resp.Builds, *build)
+	}
+	resp.TotalCount = len(resp.Builds)
+	return resp, nil
+}
+
+func (b *fakeBuilds) ListByOwner(_ context.Context, ownerUserID string, params models.BuildListParams) (*models.BuildListResponse, error) {
+	b.params = params
+	resp := &models.BuildListResponse{}
+	for _, build := range b.owned {
+		if build.OwnerUserID == ownerUserID {
+			resp.Builds = append(resp.Builds, *build)
+		}
+	}
+	resp.TotalCount = len(resp.Builds)
+	return resp, nil
+}
+
+// fakeTenants maps hostnames to tenants
+type fakeTenants struct {
+	enabled bool
+	byHost  map[string]*models.Tenant
+}
+
+func (f *fakeTenants) Enabled() bool { return f.enabled }
+
+func (f *fakeTenants) Resolve(host string) *models.Tenant {
+	if tenant, ok := f.Lookup(host); ok && f.enabled {
+		return tenant
+	}
+	return &models.Tenant{ID: database.DefaultTenantID, Slug: "default"}
+}
+
+func (f *fakeTenants) Lookup(host string) (*models.Tenant, bool) {
+	tenant, ok := f.byHost[host]
+	return tenant, ok
+}
+
+// newTestClient serves the API over an in-memory listener and returns a
+// connection to it
+func newTestClient(t *testing.T, catalog CatalogReader, inventorySvc inventory.InventoryManager, buildSvc BuildReader) *grpc.ClientConn {
+	t.Helper()
+	return newTenantTestClient(t, catalog, inventorySvc, buildSvc, &fakeTenants{})
+}
+
+// newTenantTestClient is newTestClient with a tenant resolver
+func newTenantTestClient(t *testing.T, catalog CatalogReader, inventorySvc inventory.InventoryManager, buildSvc BuildReader, tenants TenantResolver) *grpc.ClientConn {
+	t.Helper()
+
+	server, err := New(catalog, inventorySvc, buildSvc, tenants, testToken, logging.New(logging.LevelError))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		server.Stop(ctx)
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func authed() context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+testToken)
+}
+
+func wantCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != want {
+		t.Fatalf("code = %v, want %v (err %v)", got, want, err)
+	}
+}
+
+func TestNew_RequiresToken(t *testing.T) {
+	if _, err := New(&fakeCatalog{}, inventory.NewInMemoryService(logging.New(logging.LevelError)), &fakeBuilds{}, &fakeTenants{}, " ", logging.New(logging.LevelError)); err != ErrNoToken {
+		t.Fatalf("New() error = %v, want ErrNoToken", err)
+	}
+}
+
+func TestServer_RejectsMissingOrWrongToken(t *testing.T) {
+	conn := newTestClient(t, &fakeCatalog{}, inventory.NewInMemoryService(logging.New(logging.LevelError)), &fakeBuilds{})
+	client := flyingforgev1.NewCatalogServiceClient(conn)
+
+	_, err := client.SearchCatalog(context.Background(), &flyingforgev1.SearchCatalogRequest{})
+	wantCode(t, err, codes.Unauthenticated)
+
+	wrong := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer nope")
+	_, err = client.SearchCatalog(wrong, &flyingforgev1.SearchCatalogRequest{})
+	wantCode(t, err, codes.Unauthenticated)
+
+	if _, err := client.SearchCatalog(authed(), &flyingforgev1.SearchCatalogRequest{}); err != nil {
+		t.Fatalf("SearchCatalog() with token error = %v", err)
+	}
+}
+
+func TestServer_ScopesCallsToTenant(t *testing.T) {
+	catalog := &fakeCatalog{}
+	tenants := &fakeTenants{enabled: true, byHost: map[string]*models.Tenant{
+		"club.example": {ID: "tenant-club", Slug: "club"},
+	}}
+	client := flyingforgev1.NewCatalogServiceClient(newTenantTestClient(t, catalog, inventory.NewInMemoryService(logging.New(logging.LevelError)), &fakeBuilds{}, tenants))
+
+	_, err := client.SearchCatalog(authed(), &flyingforgev1.SearchCatalogRequest{})
+	wantCode(t, err, codes.InvalidArgument)
+
+	unknown := metadata.AppendToOutgoingContext(authed(), "x-tenant-host", "other.example")
+	_, err = client.SearchCatalog(unknown, &flyingforgev1.SearchCatalogRequest{})
+	wantCode(t, err, codes.InvalidArgument)
+
+	club := metadata.AppendToOutgoingContext(authed(), "x-tenant-host", "club.example")
+	if _, err := client.SearchCatalog(club, &flyingforgev1.SearchCatalogRequest{}); err != nil {
+		t.Fatalf("SearchCatalog() error = %v", err)
+	}
+	if catalog.lastTenant != "tenant-club" {
+		t.Errorf("SearchCatalog() ran in tenant %q, want tenant-club", catalog.lastTenant)
+	}
+}
+
+func TestCatalogService_OnlyPublishedItems(t *testing.T) {
+	catalog := &fakeCatalog{items: map[string]*models.GearCatalogItem{
+		"pub":   {ID: "pub", GearType: models.GearTypeFrame, Brand: "Acme", Model: "X5", Status: models.CatalogStatusPublished, Specs: []byte(`{"wheelbase":225}`)},
+		"draft": {ID: "draft", GearType: models.GearTypeFrame, Brand: "Acme", Model: "X6", Status: models.CatalogStatusPending},
+	}}
+	client := flyingforgev1.NewCatalogServiceClient(newTestClient(t, catalog, inventory.NewInMemoryService(logging.New(logging.LevelError)), &fakeBuilds{}))
+
+	resp, err := client.GetCatalogItem(authed(), &flyingforgev1.GetCatalogItemRequest{Id: "pub"})
+	if err != nil {
+		t.Fatalf("GetCatalogItem() error = %v", err)
+	}
+	if resp.GetItem().GetModel() != "X5" || resp.GetItem().GetSpecs().GetFields()["wheelbase"].GetNumberValue() != 225 {
+		t.Errorf("GetCatalogItem() = %v", resp.GetItem())
+	}
+
+	_, err = client.GetCatalogItem(authed(), &flyingforgev1.GetCatalogItemRequest{Id: "draft"})
+	wantCode(t, err, codes.NotFound)
+
+	if _, err := client.SearchCatalog(authed(), &flyingforgev1.SearchCatalogRequest{Query: "acme", Page: &flyingforgev1.Page{Limit: 500}}); err != nil {
+		t.Fatalf("SearchCatalog() error = %v", err)
+	}
+	if catalog.lastSearch.Status != models.CatalogStatusPublished || catalog.lastSearch.Limit != maxPageLimit {
+		t.Errorf("SearchCatalog() params = %+v, want published items capped at %d", catalog.lastSearch, maxPageLimit)
+	}
+}
+
+func TestInventoryService_CRUDScopedToUser(t *testing.T) {
+	client := flyingforgev1.NewInventoryServiceClient(newTestClient(t, &fakeCatalog{}, inventory.NewInMemoryService(logging.New(logging.LevelError)), &fakeBuilds{}))
+	ctx := authed()
+
+	_, err := client.ListInventory(ctx, &flyingforgev1.ListInventoryRequest{})
+	wantCode(t, err, codes.InvalidArgument)
+
+	specs, _ := structpb.NewStruct(map[string]interface{}{"size": "5in"})
+	added, err := client.AddInventoryItem(ctx, &flyingforgev1.AddInventoryItemRequest{
+		UserId:        "user-1",
+		Name:          "Acme X5",
+		Category:      string(models.CategoryFrames),
+		Quantity:      2,
+		PurchasePrice: proto.Float64(49.99),
+		Specs:         specs,
+	})
+	if err != nil {
+		t.Fatalf("AddInventoryItem() error = %v", err)
+	}
+	id := added.GetItem().GetId()
+	if added.GetItem().GetQuantity() != 2 || added.GetItem().GetPurchasePrice() != 49.99 || added.GetItem().GetSpecs().GetFields()["size"].GetStringValue() != "5in" {
+		t.Errorf("AddInventoryItem() = %v", added.GetItem())
+	}
+
+	_, err = client.AddInventoryItem(ctx, &flyingforgev1.AddInventoryItemRequest{UserId: "user-1", Category: string(models.CategoryFrames)})
+	wantCode(t, err, codes.InvalidArgument)
+
+	// Another user can't see or change the item
+	_, err = client.GetInventoryItem(ctx, &flyingforgev1.GetInventoryItemRequest{UserId: "user-2", Id: id})
+	wantCode(t, err, codes.NotFound)
+	_, err = client.UpdateInventoryItem(ctx, &flyingforgev1.UpdateInventoryItemRequest{UserId: "user-2", Id: id, Notes: proto.String("mine")})
+	wantCode(t, err, codes.NotFound)
+	_, err = client.DeleteInventoryItem(ctx, &flyingforgev1.DeleteInventoryItemRequest{UserId: "user-2", Id: id})
+	wantCode(t, err, codes.NotFound)
+
+	updated, err := client.UpdateInventoryItem(ctx, &flyingforgev1.UpdateInventoryItemRequest{UserId: "user-1", Id: id, Quantity: proto.Int32(3)})
+	if err != nil {
+		t.Fatalf("UpdateInventoryItem() error = %v", err)
+	}
+	if updated.GetItem().GetQuantity() != 3 || updated.GetItem().GetName() != "Acme X5" {
+		t.Errorf("UpdateInventoryItem() = %v, want only quantity changed", updated.GetItem())
+	}
+
+	list, err := client.ListInventory(ctx, &flyingforgev1.ListInventoryRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("ListInventory() error = %v", err)
+	}
+	if list.GetTotalCount() != 1 || len(list.GetItems()) != 1 {
+		t.Errorf("ListInventory() = %v, want the one item", list)
+	}
+
+	if _, err := client.DeleteInventoryItem(ctx, &flyingforgev1.DeleteInventoryItemRequest{UserId: "user-1", Id: id}); err != nil {
+		t.Fatalf("DeleteInventoryItem() error = %v", err)
+	}
+	_, err = client.GetInventoryItem(ctx, &flyingforgev1.GetInventoryItemRequest{UserId: "user-1", Id: id})
+	wantCode(t, err, codes.NotFound)
+}
+
+func TestBuildService_Reads(t *testing.T) {
+	builds := &fakeBuilds{
+		public: map[string]*models.Build{
+			"b1": {ID: "b1", OwnerUserID: "user-1", Status: models.BuildStatusPublished, Title: "Race quad"},
+		},
+		owned: map[string]*models.Build{
+			"user-1/b2": {ID: "b2", OwnerUserID: "user-1", Status: models.BuildStatusDraft, Title: "Draft"},
+		},
+	}
+	client := flyingforgev1.NewBuildServiceClient(newTestClient(t, &fakeCatalog{}, inventory.NewInMemoryService(logging.New(logging.LevelError)), builds))
+	ctx := authed()
+
+	if resp, err := client.GetBuild(ctx, &flyingforgev1.GetBuildRequest{Id: "b1"}); err != nil || resp.GetBuild().GetTitle() != "Race quad" {
+		t.Fatalf("GetBuild(public) = %v, %v", resp, err)
+	}
+	_, err := client.GetBuild(ctx, &flyingforgev1.GetBuildRequest{Id: "b2"})
+	wantCode(t, err, codes.NotFound)
+	if resp, err := client.GetBuild(ctx, &flyingforgev1.GetBuildRequest{Id: "b2", UserId: "user-1"}); err != nil || resp.GetBuild().GetTitle() != "Draft" {
+		t.Fatalf("GetBuild(owned draft) = %v, %v", resp, err)
+	}
+
+	if _, err := client.ListPublishedBuilds(ctx, &flyingforgev1.ListPublishedBuildsRequest{}); err != nil {
+		t.Fatalf("ListPublishedBuilds() error = %v", err)
+	}
+	if builds.params.Sort != models.BuildSortNewest || builds.params.Limit != defaultPageLimit {
+		t.Errorf("ListPublishedBuilds() params = %+v, want newest first with the default page", builds.params)
+	}
+	_, err = client.ListPublishedBuilds(ctx, &flyingforgev1.ListPublishedBuildsRequest{Sort: "oldest"})
+	wantCode(t, err, codes.InvalidArgument)
+
+	_, err = client.ListUserBuilds(ctx, &flyingforgev1.ListUserBuildsRequest{})
+	wantCode(t, err, codes.InvalidArgument)
+	resp, err := client.ListUserBuilds(ctx, &flyingforgev1.ListUserBuildsRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("ListUserBuilds() error = %v", err)
+	}
+	if len(resp.GetBuilds()) != 1 || resp.GetBuilds()[0].GetId() != "b2" {
+		t.Errorf("ListUserBuilds() = %v", resp.GetBuilds())
+	}
+}
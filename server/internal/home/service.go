@@ -0,0 +1,324 @@
+// Package home composes the landing page from admin-configured modules, so
+// the layout can change without a frontend deploy.
+package home
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/aggregator"
+	"github.com/johnrirwin/flyingforge/internal/announcements"
+	"github.com/johnrirwin/flyingforge/internal/builds"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/featured"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	defaultModuleLimit = 6
+	maxModuleLimit     = 24
+	maxTitleLength     = 100
+	maxTagLength       = 50
+)
+
+// DefaultModules is the layout served until an admin configures one
+var DefaultModules = []models.HomeModule{
+	{Kind: models.HomeModuleAnnouncements, Title: "Announcements", Position: 0, Limit: 3, Enabled: true},
+	{Kind: models.HomeModuleFeatured, Title: "Featured", Position: 1, Limit: defaultModuleLimit, Enabled: true},
+	{Kind: models.HomeModulePopularBuilds, Title: "Popular Builds", Position: 2, Limit: defaultModuleLimit, Enabled: true},
+	{Kind: models.HomeModuleTrendingGear, Title: "Trending Gear", Position: 3, Limit: defaultModuleLimit, Enabled: true},
+	{Kind: models.HomeModuleLatestFeed, Title: "Latest News", Position: 4, Limit: 10, Enabled: true},
+}
+
+// Store defines the home module persistence operations
+type Store interface {
+	List(ctx context.Context) ([]models.HomeModule, error)
+	Get(ctx context.Context, id string) (*models.HomeModule, error)
+	Create(ctx context.Context, params models.SaveHomeModuleParams) (*models.HomeModule, error)
+	Update(ctx context.Context, id string, params models.SaveHomeModuleParams) (*models.HomeModule, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// FeaturedSource loads currently featured content
+type FeaturedSource interface {
+	Active(ctx context.Context, contentType models.FeaturedContentType, limit int) (*models.FeaturedResponse, error)
+}
+
+// BuildSource lists published builds
+type BuildSource interface {
+	ListPublic(ctx context.Context, params models.BuildListParams) (*models.BuildListResponse, error)
+}
+
+// GearSource lists the most used catalog items
+type GearSource interface {
+	GetPopular(ctx context.Context, gearType models.GearType, limit int) ([]models.GearCatalogItem, error)
+}
+
+// FeedSource lists aggregated news and community posts
+type FeedSource interface {
+	GetItems(ctx context.Context, params models.FilterParams) models.AggregatedResponse
+}
+
+// AnnouncementSource lists current announcements
+type AnnouncementSource interface {
+	Active(ctx context.Context, userID string, limit, offset int) (*models.AnnouncementListResponse, error)
+}
+
+// Service manages home modules and builds the home page from them
+type Service struct {
+	store         Store
+	featured      FeaturedSource
+	builds        BuildSource
+	gear          GearSource
+	feed          FeedSource
+	announcements AnnouncementSource
+	logger        *logging.Logger
+}
+
+// NewService creates a new home page service
+func NewService(store *database.HomeModuleStore, featuredSvc *featured.Service, buildSvc *builds.Service, catalogStore *database.GearCatalogStore, agg *aggregator.Aggregator, announcementSvc *announcements.Service, logger *logging.Logger) *Service {
+	svc := &Service{
+		store:  store,
+		logger: logger,
+	}
+	// Leave unavailable sources nil rather than wrapping a nil pointer, so
+	// their modules are skipped
+	if featuredSvc != nil {
+		svc.featured = featuredSvc
+	}
+	if buildSvc != nil {
+		svc.builds = buildSvc
+	}
+	if catalogStore != nil {
+		svc.gear = catalogStore
+	}
+	if agg != nil {
+		svc.feed = agg
+	}
+	if announcementSvc != nil {
+		svc.announcements = announcementSvc
+	}
+	return svc
+}
+
+// Home builds the home page for a visitor. userID is empty for anonymous
+// visitors. A module that fails to load is left out rather than failing the
+// page, as are modules with nothing to show.
+func (s *Service) Home(ctx context.Context, userID string) (*models.HomeResponse, error) {
+	modules, _, err := s.modules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := make([]models.HomeSection, 0, len(modules))
+	for _, module := range modules {
+		if !module.Enabled {
+			continue
+		}
+		section, err := s.load(ctx, module, userID)
+		if err != nil {
+			s.logger.Warn("Skipping home module that failed to load", logging.WithFields(map[string]interface{}{
+				"moduleId": module.ID,
+				"kind":     module.Kind,
+				"error":    err.Error(),
+			}))
+			continue
+		}
+		if section != nil {
+			sections = append(sections, *section)
+		}
+	}
+	return &models.HomeResponse{Sections: sections}, nil
+}
+
+// List returns the configured modules for admins, or the default layout
+// when none are configured
+func (s *Service) List(ctx context.Context) (*models.HomeModuleListResponse, error) {
+	modules, isDefault, err := s.modules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &models.HomeModuleListResponse{Modules: modules, Default: isDefault}, nil
+}
+
+// Get returns a module by ID, or nil if it doesn't exist
+func (s *Service) Get(ctx context.Context, id string) (*models.HomeModule, error) {
+	return s.store.Get(ctx, id)
+}
+
+// Create adds a module. Adding the first module replaces the default layout.
+func (s *Service) Create(ctx context.Context, params models.SaveHomeModuleParams) (*models.HomeModule, error) {
+	if err := normalize(&params); err != nil {
+		return nil, err
+	}
+	return s.store.Create(ctx, params)
+}
+
+// Update replaces a module, returning nil if it doesn't exist
+func (s *Service) Update(ctx context.Context, id string, params models.SaveHomeModuleParams) (*models.HomeModule, error) {
+	if err := normalize(&params); err != nil {
+		return nil, err
+	}
+	return s.store.Update(ctx, id, params)
+}
+
+// Delete removes a module. Removing the last one restores the default layout.
+func (s *Service) Delete(ctx context.Context, id string) error {
+	return s.store.Delete(ctx, id)
+}
+
+// modules returns the configured modules, or the default layout
+func (s *Service) modules(ctx context.Context) ([]models.HomeModule, bool, error) {
+	modules, err := s.store.List(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(modules) == 0 {
+		return append([]models.HomeModule(nil), DefaultModules...), true, nil
+	}
+	return modules, false, nil
+}
+
+// load fetches a module's content, returning nil when the module has nothing
+// to show or its source isn't available
+func (s *Service) load(ctx context.Context, module models.HomeModule, userID string) (*models.HomeSection, error) {
+	section := &models.HomeSection{ID: module.ID, Kind: module.Kind, Title: module.Title}
+	limit := module.Limit
+	if limit <= 0 {
+		limit = defaultModuleLimit
+	}
+
+	switch module.Kind {
+	case models.HomeModuleFeatured:
+		if s.featured == nil {
+			return nil, nil
+		}
+		response, err := s.featured.Active(ctx, module.Settings.ContentType, limit)
+		if err != nil {
+			return nil, err
+		}
+		section.Featured = response.Items
+		if len(section.Featured) == 0 {
+			return nil, nil
+		}
+	case models.HomeModulePopularBuilds, models.HomeModuleLatestBuilds:
+		if s.builds == nil {
+			return nil, nil
+		}
+		sort := models.BuildSortNewest
+		if module.Kind == models.HomeModulePopularBuilds {
+			sort = models.BuildSortPopular
+		}
+		response, err := s.builds.ListPublic(ctx, models.BuildListParams{Sort: sort, Limit: limit})
+		if err != nil {
+			return nil, err
+		}
+		section.Builds = response.Builds
+		if len(section.Builds) == 0 {
+			return nil, nil
+		}
+	case models.HomeModuleTrendingGear:
+		if s.gear == nil {
+			return nil, nil
+		}
+		items, err := s.gear.GetPopular(ctx, module.Settings.GearType, limit)
+		if err != nil {
+			return nil, err
+		}
+		section.Gear = items
+		if len(section.Gear) == 0 {
+			return nil, nil
+		}
+	case models.HomeModuleLatestFeed:
+		if s.feed == nil {
+			return nil, nil
+		}
+		response := s.feed.GetItems(ctx, models.FilterParams{
+			Limit:      limit,
+			Sort:       "newest",
+			SourceType: module.Settings.SourceType,
+			Tag:        module.Settings.Tag,
+		})
+		section.FeedItems = response.Items
+		if len(section.FeedItems) == 0 {
+			return nil, nil
+		}
+	case models.HomeModuleAnnouncements:
+		if s.announcements == nil {
+			return nil, nil
+		}
+		response, err := s.announcements.Active(ctx, userID, limit, 0)
+		if err != nil {
+			return nil, err
+		}
+		section.Announcements = response.Announcements
+		if len(section.Announcements) == 0 {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+	return section, nil
+}
+
+// normalize validates a module and applies defaults. Settings that don't
+// apply to the kind are cleared.
+func normalize(params *models.SaveHomeModuleParams) error {
+	params.Kind = models.HomeModuleKind(strings.ToLower(strings.TrimSpace(string(params.Kind))))
+	params.Title = strings.TrimSpace(params.Title)
+
+	if !models.IsValidHomeModuleKind(params.Kind) {
+		return &ServiceError{Message: "kind must be featured, popular_builds, latest_builds, trending_gear, latest_feed, or announcements"}
+	}
+	if params.Title == "" {
+		return &ServiceError{Message: "title is required"}
+	}
+	if len(params.Title) > maxTitleLength {
+		return &ServiceError{Message: fmt.Sprintf("title must be %d characters or fewer", maxTitleLength)}
+	}
+	if params.Limit == 0 {
+		params.Limit = defaultModuleLimit
+	}
+	if params.Limit < 1 || params.Limit > maxModuleLimit {
+		return &ServiceError{Message: fmt.Sprintf("limit must be between 1 and %d", maxModuleLimit)}
+	}
+	if params.Enabled == nil {
+		enabled := true
+		params.Enabled = &enabled
+	}
+
+	settings := params.Settings
+	params.Settings = models.HomeModuleSettings{}
+	switch params.Kind {
+	case models.HomeModuleFeatured:
+		contentType := models.FeaturedContentType(strings.ToLower(strings.TrimSpace(string(settings.ContentType))))
+		if contentType != "" && !models.IsValidFeaturedContentType(contentType) {
+			return &ServiceError{Message: "settings.contentType must be build or gear"}
+		}
+		params.Settings.ContentType = contentType
+	case models.HomeModuleTrendingGear:
+		gearType := models.GearType(strings.ToLower(strings.TrimSpace(string(settings.GearType))))
+		if gearType != "" && !gearType.IsValid() {
+			return &ServiceError{Message: "settings.gearType is not a known gear type"}
+		}
+		params.Settings.GearType = gearType
+	case models.HomeModuleLatestFeed:
+		params.Settings.SourceType = strings.ToLower(strings.TrimSpace(settings.SourceType))
+		params.Settings.Tag = strings.TrimSpace(settings.Tag)
+		if len(params.Settings.SourceType) > maxTagLength || len(params.Settings.Tag) > maxTagLength {
+			return &ServiceError{Message: fmt.Sprintf("settings.sourceType and settings.tag must be %d characters or fewer", maxTagLength)}
+		}
+	}
+	return nil
+}
+
+// ServiceError represents a home module request error that should be shown to the client
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,186 @@
+package home
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+type mockStore struct {
+	modules []models.HomeModule
+	created *models.SaveHomeModuleParams
+}
+
+func (m *mockStore) List(ctx context.Context) ([]models.HomeModule, error) {
+	return m.modules, nil
+}
+
+func (m *mockStore) Get(ctx context.Context, id string) (*models.HomeModule, error) {
+	return nil, nil
+}
+
+func (m *mockStore) Create(ctx context.Context, params models.SaveHomeModuleParams) (*models.HomeModule, error) {
+	m.created = &params
+	return &models.HomeModule{ID: "module-new", Kind: params.Kind, Title: params.Title}, nil
+}
+
+func (m *mockStore) Update(ctx context.Context, id string, params models.SaveHomeModuleParams) (*models.HomeModule, error) {
+	return &models.HomeModule{ID: id}, nil
+}
+
+func (m *mockStore) Delete(ctx context.Context, id string) error {
+	return nil
+}
+
+type mockBuilds struct {
+	params models.BuildListParams
+	err    error
+}
+
+func (m *mockBuilds) ListPublic(ctx context.Context, params models.BuildListParams) (*models.BuildListResponse, error) {
+	m.params = params
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &models.BuildListResponse{Builds: []models.Build{{ID: "build-1"}}}, nil
+}
+
+type mockGear struct {
+	items []models.GearCatalogItem
+}
+
+func (m *mockGear) GetPopular(ctx context.Context, gearType models.GearType, limit int) ([]models.GearCatalogItem, error) {
+	return m.items, nil
+}
+
+type mockAnnouncements struct {
+	userID string
+}
+
+func (m *mockAnnouncements) Active(ctx context.Context, userID string, limit, offset int) (*models.AnnouncementListResponse, error) {
+	m.userID = userID
+	return &models.AnnouncementListResponse{Announcements: []models.Announcement{{ID: "announcement-1"}}}, nil
+}
+
+func TestHomeComposesEnabledModulesInOrder(t *testing.T) {
+	builds := &mockBuilds{}
+	announcements := &mockAnnouncements{}
+	svc := &Service{
+		store: &mockStore{modules: []models.HomeModule{
+			{ID: "m1", Kind: models.HomeModuleAnnouncements, Title: "News", Limit: 2, Enabled: true},
+			{ID: "m2", Kind: models.HomeModulePopularBuilds, Title: "Hot", Limit: 4, Enabled: true},
+			{ID: "m3", Kind: models.HomeModuleLatestBuilds, Title: "Hidden", Limit: 4, Enabled: false},
+			{ID: "m4", Kind: models.HomeModuleTrendingGear, Title: "Gear", Limit: 4, Enabled: true},
+			{ID: "m5", Kind: models.HomeModuleLatestFeed, Title: "Feed", Limit: 4, Enabled: true},
+		}},
+		builds:        builds,
+		gear:          &mockGear{}, // nothing to show
+		announcements: announcements,
+		logger:        testutil.NullLogger(),
+	}
+
+	home, err := svc.Home(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("Home() error = %v", err)
+	}
+
+	if len(home.Sections) != 2 {
+		t.Fatalf("sections = %+v, want announcements and popular builds", home.Sections)
+	}
+	if home.Sections[0].ID != "m1" || len(home.Sections[0].Announcements) != 1 {
+		t.Errorf("first section = %+v, want announcements", home.Sections[0])
+	}
+	if home.Sections[1].ID != "m2" || len(home.Sections[1].Builds) != 1 {
+		t.Errorf("second section = %+v, want builds", home.Sections[1])
+	}
+	if builds.params.Sort != models.BuildSortPopular || builds.params.Limit != 4 {
+		t.Errorf("build params = %+v, want popular with limit 4", builds.params)
+	}
+	if announcements.userID != "user-1" {
+		t.Errorf("announcements user = %q, want user-1", announcements.userID)
+	}
+}
+
+func TestHomeSkipsFailingModules(t *testing.T) {
+	svc := &Service{
+		store: &mockStore{modules: []models.HomeModule{
+			{ID: "m1", Kind: models.HomeModuleLatestBuilds, Title: "Builds", Enabled: true},
+			{ID: "m2", Kind: models.HomeModuleAnnouncements, Title: "News", Enabled: true},
+		}},
+		builds:        &mockBuilds{err: errors.New("db down")},
+		announcements: &mockAnnouncements{},
+		logger:        testutil.NullLogger(),
+	}
+
+	home, err := svc.Home(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Home() error = %v", err)
+	}
+	if len(home.Sections) != 1 || home.Sections[0].ID != "m2" {
+		t.Errorf("sections = %+v, want only announcements", home.Sections)
+	}
+}
+
+func TestListFallsBackToDefaultLayout(t *testing.T) {
+	svc := &Service{store: &mockStore{}, logger: testutil.NullLogger()}
+
+	response, err := svc.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if !response.Default || len(response.Modules) != len(DefaultModules) {
+		t.Errorf("List() = %+v, want the default layout", response)
+	}
+}
+
+func TestCreateNormalizesParams(t *testing.T) {
+	store := &mockStore{}
+	svc := &Service{store: store, logger: testutil.NullLogger()}
+
+	_, err := svc.Create(context.Background(), models.SaveHomeModuleParams{
+		Kind:     " Trending_Gear ",
+		Title:    " Motors ",
+		Settings: models.HomeModuleSettings{GearType: "Motor", SourceType: "news"},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got := store.created
+	if got.Kind != models.HomeModuleTrendingGear || got.Title != "Motors" {
+		t.Errorf("kind/title = %q/%q, want trimmed", got.Kind, got.Title)
+	}
+	if got.Limit != defaultModuleLimit || got.Enabled == nil || !*got.Enabled {
+		t.Errorf("limit/enabled = %d/%v, want defaults", got.Limit, got.Enabled)
+	}
+	if got.Settings.GearType != models.GearTypeMotor || got.Settings.SourceType != "" {
+		t.Errorf("settings = %+v, want only gearType", got.Settings)
+	}
+}
+
+func TestCreateRejectsInvalidParams(t *testing.T) {
+	svc := &Service{store: &mockStore{}, logger: testutil.NullLogger()}
+
+	tests := []struct {
+		name   string
+		params models.SaveHomeModuleParams
+	}{
+		{"unknown kind", models.SaveHomeModuleParams{Kind: "carousel", Title: "x"}},
+		{"missing title", models.SaveHomeModuleParams{Kind: models.HomeModuleLatestFeed}},
+		{"limit too large", models.SaveHomeModuleParams{Kind: models.HomeModuleLatestFeed, Title: "x", Limit: maxModuleLimit + 1}},
+		{"bad content type", models.SaveHomeModuleParams{Kind: models.HomeModuleFeatured, Title: "x", Settings: models.HomeModuleSettings{ContentType: "video"}}},
+		{"bad gear type", models.SaveHomeModuleParams{Kind: models.HomeModuleTrendingGear, Title: "x", Settings: models.HomeModuleSettings{GearType: "toaster"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Create(context.Background(), tt.params)
+			var svcErr *ServiceError
+			if !errors.As(err, &svcErr) {
+				t.Errorf("Create() error = %v, want ServiceError", err)
+			}
+		})
+	}
+}
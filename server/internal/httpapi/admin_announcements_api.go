@@ -0,0 +1,135 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/announcements"
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// handleAdminAnnouncements handles GET/POST /api/admin/announcements
+func (api *AdminAPI) handleAdminAnnouncements(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		response, err := api.announcementSvc.List(ctx, models.AnnouncementListParams{
+			Status: models.AnnouncementStatus(strings.ToLower(query.Get("status"))),
+			Limit:  parseIntQuery(query.Get("limit"), 50),
+			Offset: parseIntQuery(query.Get("offset"), 0),
+		})
+		if err != nil {
+			api.writeAnnouncementError(w, err, "failed to list announcements")
+			return
+		}
+		api.writeJSON(w, http.StatusOK, response)
+	case http.MethodPost:
+		var params models.CreateAnnouncementParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+
+		adminID := auth.GetUserID(r.Context())
+		announcement, err := api.announcementSvc.Create(ctx, adminID, params)
+		if err != nil {
+			api.writeAnnouncementError(w, err, "failed to post announcement")
+			return
+		}
+
+		api.logger.Info("Admin posted announcement",
+			logging.WithField("announcementId", announcement.ID),
+			logging.WithField("kind", announcement.Kind),
+			logging.WithField("notify", params.Notify),
+			logging.WithField("adminId", adminID),
+		)
+		api.writeJSON(w, http.StatusCreated, announcement)
+	default:
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// handleAdminAnnouncementByID handles GET/PUT/DELETE /api/admin/announcements/{id}
+func (api *AdminAPI) handleAdminAnnouncementByID(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/admin/announcements/")
+	if _, err := uuid.Parse(id); err != nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "announcement not found"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	switch r.Method {
+	case http.MethodGet:
+		announcement, err := api.announcementSvc.Get(ctx, id)
+		if err != nil {
+			api.writeAnnouncementError(w, err, "failed to get announcement")
+			return
+		}
+		if announcement == nil {
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "announcement not found"})
+			return
+		}
+		api.writeJSON(w, http.StatusOK, announcement)
+	case http.MethodPut:
+		var params models.UpdateAnnouncementParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+		announcement, err := api.announcementSvc.Update(ctx, id, params)
+		if err != nil {
+			api.writeAnnouncementError(w, err, "failed to update announcement")
+			return
+		}
+		if announcement == nil {
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "announcement not found"})
+			return
+		}
+		api.logger.Info("Admin updated announcement",
+			logging.WithField("announcementId", id),
+			logging.WithField("adminId", auth.GetUserID(r.Context())),
+		)
+		api.writeJSON(w, http.StatusOK, announcement)
+	case http.MethodDelete:
+		if err := api.announcementSvc.Delete(ctx, id); err != nil {
+			if errors.Is(err, database.ErrAnnouncementNotFound) {
+				api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "announcement not found"})
+				return
+			}
+			api.writeAnnouncementError(w, err, "failed to delete announcement")
+			return
+		}
+		api.logger.Info("Admin removed announcement",
+			logging.WithField("announcementId", id),
+			logging.WithField("adminId", auth.GetUserID(r.Context())),
+		)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// writeAnnouncementError maps announcement service errors to HTTP responses
+func (api *AdminAPI) writeAnnouncementError(w http.ResponseWriter, err error, message string) {
+	var svcErr *announcements.ServiceError
+	if errors.As(err, &svcErr) {
+		api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+		return
+	}
+	api.logger.Error("Announcement admin operation failed", logging.WithField("error", err.Error()))
+	api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": message})
+}
@@ -10,111 +10,249 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 
+	"github.com/johnrirwin/flyingforge/internal/announcements"
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/auth"
 	"github.com/johnrirwin/flyingforge/internal/builds"
+	"github.com/johnrirwin/flyingforge/internal/cache"
+	"github.com/johnrirwin/flyingforge/internal/catalogrules"
 	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/equipment"
+	"github.com/johnrirwin/flyingforge/internal/featured"
+	"github.com/johnrirwin/flyingforge/internal/feedfilter"
+	"github.com/johnrirwin/flyingforge/internal/home"
 	"github.com/johnrirwin/flyingforge/internal/images"
+	"github.com/johnrirwin/flyingforge/internal/imagesourcing"
+	"github.com/johnrirwin/flyingforge/internal/inactivity"
+	"github.com/johnrirwin/flyingforge/internal/linkcheck"
 	"github.com/johnrirwin/flyingforge/internal/logging"
 	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/moderation"
+	"github.com/johnrirwin/flyingforge/internal/policies"
+	"github.com/johnrirwin/flyingforge/internal/reputation"
+	"github.com/johnrirwin/flyingforge/internal/shortlinks"
+	"github.com/johnrirwin/flyingforge/internal/tenancy"
 )
 
 // AdminAPI handles admin-only endpoints
 type AdminAPI struct {
-	catalogStore   *database.GearCatalogStore
-	userStore      *database.UserStore
-	buildSvc       *builds.Service
-	imageSvc       *images.Service
-	authMiddleware *auth.Middleware
-	logger         *logging.Logger
+	catalogStore    *database.GearCatalogStore
+	brandStore      *database.BrandStore
+	userStore       *database.UserStore
+	buildSvc        *builds.Service
+	featuredSvc     *featured.Service
+	announcementSvc *announcements.Service
+	homeSvc         *home.Service
+	policySvc       *policies.Service
+	tenancySvc      *tenancy.Service
+	shortLinkSvc    *shortlinks.Service
+	imageSvc        *images.Service
+	imageRescanner  *images.Rescanner
+	policies        *moderation.Policies
+	publishRules    *catalogrules.Engine
+	reputation      *reputation.Service
+	claims          *database.ModerationClaimStore
+	sla             *moderation.SLAMonitor
+	imageSourcing   *imagesourcing.Service
+	linkCheckSvc    *linkcheck.Service
+	feedFilterSvc   *feedfilter.Service
+	queryStats      *database.QueryStats
+	adminSearch     *database.AdminSearchStore
+	dbPool          *database.PoolMonitor
+	inactivitySvc   *inactivity.Service
+	equipmentSvc    *equipment.Service
+	authMiddleware  *auth.Middleware
+	brandStats      cache.Cache
+	logger          *logging.Logger
 }
 
+// brandStatsTTL is how long per-brand catalog counts are served from cache.
+// Counting scans the whole catalog, and curators refresh the page often.
+const brandStatsTTL = time.Minute
+
 // NewAdminAPI creates a new admin API handler
-func NewAdminAPI(catalogStore *database.GearCatalogStore, userStore *database.UserStore, buildSvc *builds.Service, imageSvc *images.Service, authMiddleware *auth.Middleware, logger *logging.Logger) *AdminAPI {
+func NewAdminAPI(catalogStore *database.GearCatalogStore, brandStore *database.BrandStore, userStore *database.UserStore, buildSvc *builds.Service, featuredSvc *featured.Service, announcementSvc *announcements.Service, homeSvc *home.Service, policySvc *policies.Service, tenancySvc *tenancy.Service, shortLinkSvc *shortlinks.Service, imageSvc *images.Service, imageRescanner *images.Rescanner, policies *moderation.Policies, publishRules *catalogrules.Engine, reputationSvc *reputation.Service, claims *database.ModerationClaimStore, sla *moderation.SLAMonitor, imageSourcing *imagesourcing.Service, linkCheckSvc *linkcheck.Service, feedFilterSvc *feedfilter.Service, queryStats *database.QueryStats, adminSearch *database.AdminSearchStore, dbPool *database.PoolMonitor, inactivitySvc *inactivity.Service, equipmentSvc *equipment.Service, authMiddleware *auth.Middleware, logger *logging.Logger) *AdminAPI {
 	return &AdminAPI{
-		catalogStore:   catalogStore,
-		userStore:      userStore,
-		buildSvc:       buildSvc,
-		imageSvc:       imageSvc,
-		authMiddleware: authMiddleware,
-		logger:         logger,
+		catalogStore:    catalogStore,
+		brandStore:      brandStore,
+		userStore:       userStore,
+		buildSvc:        buildSvc,
+		featuredSvc:     featuredSvc,
+		announcementSvc: announcementSvc,
+		homeSvc:         homeSvc,
+		policySvc:       policySvc,
+		tenancySvc:      tenancySvc,
+		shortLinkSvc:    shortLinkSvc,
+		imageSvc:        imageSvc,
+		imageRescanner:  imageRescanner,
+		policies:        policies,
+		publishRules:    publishRules,
+		reputation:      reputationSvc,
+		claims:          claims,
+		sla:             sla,
+		imageSourcing:   imageSourcing,
+		linkCheckSvc:    linkCheckSvc,
+		feedFilterSvc:   feedFilterSvc,
+		queryStats:      queryStats,
+		adminSearch:     adminSearch,
+		dbPool:          dbPool,
+		inactivitySvc:   inactivitySvc,
+		equipmentSvc:    equipmentSvc,
+		authMiddleware:  authMiddleware,
+		brandStats:      cache.NewMemory(brandStatsTTL),
+		logger:          logger,
 	}
 }
 
-// RegisterRoutes registers admin routes
-func (api *AdminAPI) RegisterRoutes(mux *http.ServeMux, corsMiddleware func(http.HandlerFunc) http.HandlerFunc) {
+// Routes returns the admin route table
+func (api *AdminAPI) Routes() []Route {
 	if api.authMiddleware == nil {
 		api.logger.Error("Admin API routes not registered: authMiddleware is nil")
-		return
+		return nil
 	}
 
 	// Content moderation routes: admin OR content-admin role.
-	mux.HandleFunc("/api/admin/gear", corsMiddleware(api.authMiddleware.RequireAuth(api.requireContentModerator(api.handleAdminGear))))
-	mux.HandleFunc("/api/admin/gear/bulk-delete", corsMiddleware(api.authMiddleware.RequireAuth(api.requireContentModerator(api.handleAdminGearBulkDelete))))
-	mux.HandleFunc("/api/admin/gear/near-matches", corsMiddleware(api.authMiddleware.RequireAuth(api.requireContentModerator(api.handleAdminGearNearMatches))))
-	mux.HandleFunc("/api/admin/gear/", corsMiddleware(api.authMiddleware.RequireAuth(api.requireContentModerator(api.handleAdminGearByID))))
+	routes := []Route{
+		{Pattern: "/api/admin/gear", Access: AccessModerator, Platform: true, Handler: api.handleAdminGear},
+		{Pattern: "/api/admin/gear/bulk-delete", Access: AccessModerator, Platform: true, Handler: api.handleAdminGearBulkDelete},
+		{Pattern: "/api/admin/gear/near-matches", Access: AccessModerator, Platform: true, Handler: api.handleAdminGearNearMatches},
+		{Pattern: "/api/admin/gear/key-collisions", Access: AccessModerator, Platform: true, Handler: api.handleAdminGearKeyCollisions},
+		{Pattern: "/api/admin/gear/key-collisions/", Access: AccessModerator, Platform: true, Handler: api.handleAdminGearKeyCollisionByID},
+		{Pattern: "/api/admin/gear/search-debug", Access: AccessModerator, Platform: true, Handler: api.handleAdminGearSearchDebug},
+		{Method: http.MethodGet, Pattern: "/api/admin/gear/inventory-sync", Access: AccessModerator, Platform: true, Handler: api.handleAdminGearInventorySync},
+		{Method: http.MethodPost, Pattern: "/api/admin/gear/inventory-sync", Access: AccessModerator, Platform: true, Handler: api.handleAdminGearInventorySync},
+		{Method: http.MethodGet, Pattern: "/api/admin/gear/brands", Access: AccessModerator, Platform: true, Handler: api.handleAdminGearBrands},
+		{Method: http.MethodPost, Pattern: "/api/admin/gear/images/batch", Access: AccessModerator, Platform: true, Handler: api.handleAdminGearImageBatch},
+		// Includes GET /api/admin/gear/{id}/image, which needs a moderator
+		// unlike the public GET /api/gear-catalog/{id}/image
+		{Pattern: "/api/admin/gear/", Access: AccessModerator, Platform: true, Handler: api.handleAdminGearByID},
+	}
+	if api.brandStore != nil {
+		routes = append(routes,
+			Route{Pattern: "/api/admin/brands", Access: AccessModerator, Platform: true, Handler: api.handleAdminBrands},
+			Route{Method: http.MethodPost, Pattern: "/api/admin/brands/merge", Access: AccessModerator, Platform: true, Handler: api.handleAdminBrandsMerge},
+			Route{Pattern: "/api/admin/brands/", Access: AccessModerator, Platform: true, Handler: api.handleAdminBrandByID},
+		)
+	}
 	if api.buildSvc != nil {
-		mux.HandleFunc("/api/admin/builds", corsMiddleware(api.authMiddleware.RequireAuth(api.requireContentModerator(api.handleAdminBuilds))))
-		mux.HandleFunc("/api/admin/builds/", corsMiddleware(api.authMiddleware.RequireAuth(api.requireContentModerator(api.handleAdminBuildByID))))
+		routes = append(routes,
+			Route{Pattern: "/api/admin/builds", Access: AccessModerator, Handler: api.handleAdminBuilds},
+			Route{Pattern: "/api/admin/builds/", Access: AccessModerator, Handler: api.handleAdminBuildByID},
+			Route{Method: http.MethodGet, Pattern: "/api/admin/builds/temp-admission", Access: AccessAdmin, Handler: api.handleAdminTempAdmission},
+		)
+	}
+	if api.featuredSvc != nil {
+		routes = append(routes,
+			Route{Pattern: "/api/admin/featured", Access: AccessModerator, Handler: api.handleAdminFeatured},
+			Route{Pattern: "/api/admin/featured/", Access: AccessModerator, Handler: api.handleAdminFeaturedByID},
+		)
+	}
+	if api.imageRescanner != nil {
+		routes = append(routes,
+			Route{Method: http.MethodGet, Pattern: "/api/admin/images/review", Access: AccessModerator, Platform: true, Handler: api.handleAdminImageReview},
+			Route{Pattern: "/api/admin/images/", Access: AccessModerator, Platform: true, Handler: api.handleAdminImageByID},
+		)
+	}
+
+	if api.sla != nil {
+		routes = append(routes, Route{Method: http.MethodGet, Pattern: "/api/admin/moderation/aging", Access: AccessModerator, Handler: api.handleAdminModerationAging})
+	}
+	if api.linkCheckSvc != nil {
+		routes = append(routes,
+			Route{Method: http.MethodGet, Pattern: "/api/admin/links/dead", Access: AccessModerator, Handler: api.handleAdminDeadLinks},
+			Route{Method: http.MethodPost, Pattern: "/api/admin/links/check", Access: AccessModerator, Handler: api.handleAdminCheckLink},
+		)
+	}
+	if api.feedFilterSvc != nil {
+		routes = append(routes,
+			Route{Method: http.MethodGet, Pattern: "/api/admin/feed/filters", Access: AccessModerator, Platform: true, Handler: api.handleAdminFeedFilters},
+			Route{Method: http.MethodPost, Pattern: "/api/admin/feed/filters", Access: AccessModerator, Platform: true, Handler: api.handleAdminCreateFeedFilter},
+			Route{Method: http.MethodPost, Pattern: "/api/admin/feed/filters/preview", Access: AccessModerator, Platform: true, Handler: api.handleAdminPreviewFeedFilter},
+			Route{Pattern: "/api/admin/feed/filters/{id}", Access: AccessModerator, Platform: true, Handler: api.handleAdminFeedFilterByID},
+		)
 	}
 
 	// User admin routes: admin role only
-	mux.HandleFunc("/api/admin/users", corsMiddleware(api.authMiddleware.RequireAuth(api.requireAdmin(api.handleAdminUsers))))
-	mux.HandleFunc("/api/admin/users/", corsMiddleware(api.authMiddleware.RequireAuth(api.requireAdmin(api.handleAdminUserByID))))
-}
-
-func canModerateContent(user *models.User) bool {
-	return user != nil && (user.IsAdmin || user.IsContentAdmin)
-}
-
-func canManageUsers(user *models.User) bool {
-	return user != nil && user.IsAdmin
-}
-
-// requireRole is middleware that checks role-based access for admin endpoints.
-func (api *AdminAPI) requireRole(next http.HandlerFunc, allowed func(*models.User) bool, deniedMessage string, deniedLogMessage string) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		userID := auth.GetUserID(r.Context())
-		if userID == "" {
-			http.Error(w, `{"error":"authentication required"}`, http.StatusUnauthorized)
-			return
-		}
-
-		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
-		defer cancel()
-
-		user, err := api.userStore.GetByID(ctx, userID)
-		if err != nil {
-			api.logger.Error("Failed to get user for admin check", logging.WithField("error", err.Error()))
-			http.Error(w, `{"error":"authentication required"}`, http.StatusUnauthorized)
-			return
-		}
-		if user == nil {
-			api.logger.Warn("Authenticated user missing during admin check", logging.WithField("userId", userID))
-			http.Error(w, `{"error":"authentication required"}`, http.StatusUnauthorized)
-			return
-		}
-
-		if !allowed(user) {
-			api.logger.Warn(deniedLogMessage, logging.WithField("userId", userID))
-			http.Error(w, fmt.Sprintf(`{"error":"%s"}`, deniedMessage), http.StatusForbidden)
-			return
-		}
-
-		next(w, r)
-	}
-}
-
-// requireAdmin is middleware that checks if the authenticated user is a full admin.
-func (api *AdminAPI) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
-	return api.requireRole(next, canManageUsers, "admin access required", "Non-admin user attempted user-admin access")
-}
-
-// requireContentModerator is middleware that allows full admins and content-admin users.
-func (api *AdminAPI) requireContentModerator(next http.HandlerFunc) http.HandlerFunc {
-	return api.requireRole(next, canModerateContent, "admin or content-admin access required", "User without content moderation role attempted admin content access")
+	if api.announcementSvc != nil {
+		routes = append(routes,
+			Route{Pattern: "/api/admin/announcements", Access: AccessAdmin, Handler: api.handleAdminAnnouncements},
+			Route{Pattern: "/api/admin/announcements/", Access: AccessAdmin, Handler: api.handleAdminAnnouncementByID},
+		)
+	}
+	if api.homeSvc != nil {
+		routes = append(routes,
+			Route{Method: http.MethodGet, Pattern: "/api/admin/home-modules", Access: AccessAdmin, Handler: api.handleAdminHomeModules},
+			Route{Method: http.MethodPost, Pattern: "/api/admin/home-modules", Access: AccessAdmin, Handler: api.handleAdminCreateHomeModule},
+			Route{Pattern: "/api/admin/home-modules/{id}", Access: AccessAdmin, Handler: api.handleAdminHomeModuleByID},
+		)
+	}
+	if api.policySvc != nil {
+		routes = append(routes,
+			Route{Method: http.MethodGet, Pattern: "/api/admin/policies", Access: AccessAdmin, Platform: true, Handler: api.handleAdminPolicies},
+			Route{Method: http.MethodPost, Pattern: "/api/admin/policies", Access: AccessAdmin, Platform: true, Handler: api.handleAdminPublishPolicy},
+		)
+	}
+	if api.tenancySvc != nil {
+		routes = append(routes,
+			Route{Method: http.MethodGet, Pattern: "/api/admin/tenants", Access: AccessAdmin, Platform: true, Handler: api.handleAdminTenants},
+			Route{Method: http.MethodPost, Pattern: "/api/admin/tenants", Access: AccessAdmin, Platform: true, Handler: api.handleAdminCreateTenant},
+			Route{Method: http.MethodPut, Pattern: "/api/admin/tenants/{id}", Access: AccessAdmin, Platform: true, Handler: api.handleAdminUpdateTenant},
+		)
+	}
+	if api.imageRescanner != nil {
+		routes = append(routes, Route{Pattern: "/api/admin/images/rescan", Access: AccessAdmin, Platform: true, Handler: api.handleAdminImageRescan})
+	}
+	if api.policies != nil {
+		routes = append(routes,
+			Route{Method: http.MethodGet, Pattern: "/api/admin/moderation/policies", Access: AccessAdmin, Platform: true, Handler: api.handleAdminModerationPolicies},
+			Route{Pattern: "/api/admin/moderation/policies/", Access: AccessAdmin, Platform: true, Handler: api.handleAdminModerationPolicyByType},
+		)
+	}
+	if api.publishRules != nil {
+		routes = append(routes,
+			Route{Method: http.MethodGet, Pattern: "/api/admin/gear/publish-rules", Access: AccessAdmin, Platform: true, Handler: api.handleAdminPublishRules},
+			Route{Method: http.MethodPost, Pattern: "/api/admin/gear/publish-rules", Access: AccessAdmin, Platform: true, Handler: api.handleAdminCreatePublishRule},
+			Route{Method: http.MethodPut, Pattern: "/api/admin/gear/publish-rules/{id}", Access: AccessAdmin, Platform: true, Handler: api.handleAdminUpdatePublishRule},
+			Route{Method: http.MethodDelete, Pattern: "/api/admin/gear/publish-rules/{id}", Access: AccessAdmin, Platform: true, Handler: api.handleAdminDeletePublishRule},
+			Route{Method: http.MethodGet, Pattern: "/api/admin/gear/publish-rules/decisions", Access: AccessAdmin, Platform: true, Handler: api.handleAdminPublishDecisions},
+		)
+	}
+	if api.equipmentSvc != nil {
+		routes = append(routes, Route{Method: http.MethodGet, Pattern: "/api/admin/sellers/health", Access: AccessAdmin, Handler: api.handleAdminSellerHealth})
+	}
+	if api.inactivitySvc != nil {
+		routes = append(routes,
+			Route{Method: http.MethodGet, Pattern: "/api/admin/inactivity/policy", Access: AccessAdmin, Handler: api.handleAdminInactivityPolicy},
+			Route{Method: http.MethodPut, Pattern: "/api/admin/inactivity/policy", Access: AccessAdmin, Handler: api.handleAdminUpdateInactivityPolicy},
+			Route{Method: http.MethodGet, Pattern: "/api/admin/inactivity/dry-run", Access: AccessAdmin, Handler: api.handleAdminInactivityDryRun},
+		)
+	}
+	if api.imageSvc != nil {
+		routes = append(routes, Route{Method: http.MethodGet, Pattern: "/api/admin/images/tiers", Access: AccessAdmin, Platform: true, Handler: api.handleAdminImageTiers})
+	}
+	if api.queryStats != nil {
+		routes = append(routes,
+			Route{Method: http.MethodGet, Pattern: "/api/admin/perf", Access: AccessAdmin, Handler: api.handleAdminPerf},
+			Route{Method: http.MethodDelete, Pattern: "/api/admin/perf", Access: AccessAdmin, Handler: api.handleAdminResetPerf},
+		)
+	}
+	if api.adminSearch != nil {
+		routes = append(routes, Route{Method: http.MethodGet, Pattern: "/api/admin/search", Access: AccessAdmin, Handler: api.handleAdminSearch})
+	}
+	if api.dbPool != nil {
+		routes = append(routes, Route{Method: http.MethodGet, Pattern: "/api/admin/db", Access: AccessAdmin, Handler: api.handleAdminDB})
+	}
+	return append(routes,
+		Route{Pattern: "/api/admin/users", Access: AccessAdmin, Handler: api.handleAdminUsers},
+		Route{Method: http.MethodGet, Pattern: "/api/admin/users/duplicates", Access: AccessAdmin, Handler: api.handleAdminUserDuplicates},
+		Route{Method: http.MethodGet, Pattern: "/api/admin/users/export", Access: AccessAdmin, Handler: api.handleAdminUsersExport},
+		Route{Method: http.MethodPost, Pattern: "/api/admin/users/merge", Access: AccessAdmin, Handler: api.handleAdminUsersMerge},
+		Route{Pattern: "/api/admin/users/", Access: AccessAdmin, Handler: api.handleAdminUserByID},
+	)
 }
 
 // handleAdminGear handles GET /api/admin/gear (list gear for moderation)
@@ -203,6 +341,160 @@ func (api *AdminAPI) handleAdminGearNearMatches(w http.ResponseWriter, r *http.R
 	})
 }
 
+// handleAdminGearBrands handles GET /api/admin/gear/brands. Counts are cached
+// for brandStatsTTL; ?refresh=true recounts.
+func (api *AdminAPI) handleAdminGearBrands(w http.ResponseWriter, r *http.Request) {
+	const cacheKey = "admin:gear:brands"
+	if r.URL.Query().Get("refresh") != "true" {
+		if cached, ok := api.brandStats.Get(cacheKey); ok {
+			if response, ok := cached.(*models.CatalogBrandStatsResponse); ok {
+				api.writeJSON(w, http.StatusOK, response)
+				return
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	stats, err := api.catalogStore.BrandStats(ctx)
+	if err != nil {
+		api.logger.Error("Failed to count catalog items by brand", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to load brand stats"})
+		return
+	}
+
+	response := &models.CatalogBrandStatsResponse{
+		Brands:      stats,
+		TotalCount:  len(stats),
+		GeneratedAt: time.Now().UTC(),
+	}
+	api.brandStats.Set(cacheKey, response)
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+// handleAdminGearSearchDebug handles GET /api/admin/gear/search-debug.
+// Runs the public catalog search and includes per-item relevance scores so admins
+// can see why results are ordered the way they are.
+func (api *AdminAPI) handleAdminGearSearchDebug(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	query := r.URL.Query()
+	params := models.GearCatalogSearchParams{
+		Query:    strings.TrimSpace(query.Get("q")),
+		GearType: models.GearType(query.Get("gearType")),
+		Brand:    query.Get("brand"),
+		Limit:    parseIntQuery(query.Get("limit"), 20),
+		Offset:   parseIntQuery(query.Get("offset"), 0),
+		Debug:    true,
+	}
+	if params.Query == "" {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "q is required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	response, err := api.catalogStore.Search(ctx, params)
+	if err != nil {
+		api.logger.Error("Failed to run catalog search debug", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to search catalog"})
+		return
+	}
+
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+// handleAdminGearKeyCollisions handles GET /api/admin/gear/key-collisions.
+// Lists items the canonical key recompute could not rekey because another item
+// already holds the new key.
+func (api *AdminAPI) handleAdminGearKeyCollisions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	includeResolved := r.URL.Query().Get("includeResolved") == "true"
+	collisions, err := api.catalogStore.ListKeyCollisions(ctx, includeResolved)
+	if err != nil {
+		api.logger.Error("Failed to list key collisions", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to list key collisions"})
+		return
+	}
+
+	api.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"collisions": collisions,
+		"totalCount": len(collisions),
+	})
+}
+
+// handleAdminGearInventorySync reports (GET) or fixes (POST) inventory items
+// whose copied name or manufacturer no longer match their catalog item.
+// ?catalogId= limits it to one catalog item.
+func (api *AdminAPI) handleAdminGearInventorySync(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
+
+	catalogID := r.URL.Query().Get("catalogId")
+	dryRun := r.Method == http.MethodGet
+	report, err := api.catalogStore.SyncInventoryDisplayFields(ctx, catalogID, dryRun)
+	if err != nil {
+		api.logger.Error("Failed to sync inventory display fields", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to sync inventory display fields"})
+		return
+	}
+
+	if !dryRun {
+		api.logger.Info("Admin synced inventory display fields",
+			logging.WithField("adminId", auth.GetUserID(r.Context())),
+			logging.WithField("catalogId", catalogID),
+			logging.WithField("count", report.Affected),
+		)
+	}
+	api.writeJSON(w, http.StatusOK, report)
+}
+
+// handleAdminGearKeyCollisionByID handles POST /api/admin/gear/key-collisions/{id}/resolve
+func (api *AdminAPI) handleAdminGearKeyCollisionByID(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/admin/gear/key-collisions/")
+	id := strings.TrimSuffix(path, "/resolve")
+	if id == path || id == "" || strings.Contains(id, "/") {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+		return
+	}
+	if r.Method != http.MethodPost {
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	userID := auth.GetUserID(r.Context())
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := api.catalogStore.ResolveKeyCollision(ctx, id, userID); err != nil {
+		if errors.Is(err, database.ErrKeyCollisionNotFound) {
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "key collision not found"})
+			return
+		}
+		api.logger.Error("Failed to resolve key collision", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to resolve key collision"})
+		return
+	}
+
+	api.logger.Info("Admin resolved key collision",
+		logging.WithField("collisionId", id),
+		logging.WithField("adminId", userID),
+	)
+	api.writeJSON(w, http.StatusOK, map[string]string{"status": "resolved"})
+}
+
 // handleAdminGearBulkDelete handles POST /api/admin/gear/bulk-delete.
 func (api *AdminAPI) handleAdminGearBulkDelete(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -281,11 +573,28 @@ func (api *AdminAPI) handleAdminGearBulkDelete(w http.ResponseWriter, r *http.Re
 	})
 }
 
-// handleAdminGearByID handles GET/PUT/DELETE /api/admin/gear/{id} and /api/admin/gear/{id}/image
+// handleAdminGearByID handles GET/PUT/DELETE /api/admin/gear/{id}, /api/admin/gear/{id}/image,
+// /api/admin/gear/{id}/image/primary, and /api/admin/gear/{id}/image-candidates
 func (api *AdminAPI) handleAdminGearByID(w http.ResponseWriter, r *http.Request) {
 	// Extract ID from path
 	path := strings.TrimPrefix(r.URL.Path, "/api/admin/gear/")
 
+	if strings.HasSuffix(path, "/claim") {
+		api.handleModerationClaim(w, r, models.ModerationQueueGear, strings.TrimSuffix(path, "/claim"))
+		return
+	}
+
+	// Writes are refused while another moderator has the item claimed
+	if r.Method != http.MethodGet && !api.checkClaim(w, r, models.ModerationQueueGear, strings.SplitN(path, "/", 2)[0]) {
+		return
+	}
+
+	// Check if this is an image candidate request
+	if idx := strings.Index(path, "/image-candidates"); idx > 0 {
+		api.handleGearImageCandidates(w, r, path[:idx], strings.Trim(path[idx+len("/image-candidates"):], "/"))
+		return
+	}
+
 	// Check if this is an image approval request
 	if strings.HasSuffix(path, "/image/approve") {
 		id := strings.TrimSuffix(path, "/image/approve")
@@ -297,6 +606,17 @@ func (api *AdminAPI) handleAdminGearByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Check if this is a primary image selection
+	if strings.HasSuffix(path, "/image/primary") {
+		id := strings.TrimSuffix(path, "/image/primary")
+		if r.Method != http.MethodPost {
+			api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+			return
+		}
+		api.setPrimaryGearImage(w, r, id)
+		return
+	}
+
 	// Check if this is an image request
 	if strings.HasSuffix(path, "/image") {
 		id := strings.TrimSuffix(path, "/image")
@@ -342,6 +662,12 @@ func (api *AdminAPI) handleGetGear(w http.ResponseWriter, r *http.Request, id st
 		})
 		return
 	}
+	if api.imageSvc != nil {
+		item.Images, err = api.imageSvc.Images(ctx, models.ImageEntityGear, item.ID, item.ImageAssetID, "/api/admin/gear/"+item.ID+"/image")
+		if err != nil {
+			api.logger.Warn("Failed to list gear images", logging.WithField("error", err.Error()))
+		}
+	}
 
 	api.writeJSON(w, http.StatusOK, item)
 }
@@ -372,14 +698,7 @@ func (api *AdminAPI) handleUpdateGear(w http.ResponseWriter, r *http.Request, id
 			return
 		}
 
-		valid := false
-		for _, gt := range models.AllGearTypes() {
-			if gt == normalizedGearType {
-				valid = true
-				break
-			}
-		}
-		if !valid {
+		if !normalizedGearType.IsValid() {
 			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid gearType"})
 			return
 		}
@@ -430,6 +749,13 @@ func (api *AdminAPI) handleUpdateGear(w http.ResponseWriter, r *http.Request, id
 
 	// Perform the update
 	item, err := api.catalogStore.AdminUpdate(ctx, id, userID, params)
+	if errors.Is(err, database.ErrCatalogDuplicate) {
+		api.writeJSON(w, http.StatusConflict, map[string]string{
+			"error": err.Error(),
+			"code":  string(apierror.CatalogDuplicate),
+		})
+		return
+	}
 	if err != nil {
 		api.logger.Error("Failed to update gear item", logging.WithField("error", err.Error()))
 		api.writeJSON(w, http.StatusInternalServerError, map[string]string{
@@ -443,6 +769,24 @@ func (api *AdminAPI) handleUpdateGear(w http.ResponseWriter, r *http.Request, id
 		logging.WithField("adminId", userID),
 	)
 
+	// Linked inventory items pick up a renamed item right away rather than
+	// on the next periodic sync
+	if item.Brand != existing.Brand || item.DisplayName() != existing.DisplayName() {
+		if report, err := api.catalogStore.SyncInventoryDisplayFields(ctx, id, false); err != nil {
+			api.logger.Warn("Inventory display sync failed", logging.WithField("gearId", id), logging.WithField("error", err.Error()))
+		} else if report.Affected > 0 {
+			api.logger.Info("Synced inventory display fields", logging.WithField("gearId", id), logging.WithField("count", report.Affected))
+		}
+	}
+
+	// Newly published items get their short share link right away
+	wasPublished := models.NormalizeCatalogStatus(existing.Status) == models.CatalogStatusPublished
+	if api.shortLinkSvc != nil && !wasPublished && models.NormalizeCatalogStatus(item.Status) == models.CatalogStatusPublished {
+		if _, err := api.shortLinkSvc.Issue(ctx, models.ShortLinkGear, item.ID); err != nil {
+			api.logger.Warn("Short link issue failed", logging.WithField("gearId", id), logging.WithField("error", err.Error()))
+		}
+	}
+
 	api.writeJSON(w, http.StatusOK, item)
 }
 
@@ -522,6 +866,24 @@ func (api *AdminAPI) handleAdminBuilds(w http.ResponseWriter, r *http.Request) {
 	api.writeJSON(w, http.StatusOK, response)
 }
 
+// handleAdminTempAdmission handles GET /api/admin/builds/temp-admission:
+// anonymous temp build attempts refused by each check since start, and how
+// many temp builds are open
+func (api *AdminAPI) handleAdminTempAdmission(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	stats, err := api.buildSvc.TempAdmissionStats(ctx)
+	if err != nil {
+		api.logger.Error("Failed to get temp build admission stats", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to get temp build admission stats"})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	api.writeJSON(w, http.StatusOK, stats)
+}
+
 // handleAdminBuildByID handles /api/admin/builds/{id} actions.
 func (api *AdminAPI) handleAdminBuildByID(w http.ResponseWriter, r *http.Request) {
 	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/admin/builds/"), "/")
@@ -537,6 +899,14 @@ func (api *AdminAPI) handleAdminBuildByID(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if len(parts) > 1 && parts[1] == "claim" {
+		api.handleModerationClaim(w, r, models.ModerationQueueBuild, buildID)
+		return
+	}
+	if r.Method != http.MethodGet && !api.checkClaim(w, r, models.ModerationQueueBuild, buildID) {
+		return
+	}
+
 	if len(parts) > 1 {
 		switch parts[1] {
 		case "image":
@@ -549,6 +919,20 @@ func (api *AdminAPI) handleAdminBuildByID(w http.ResponseWriter, r *http.Request
 			}
 			api.handlePublishAdminBuild(w, r, buildID)
 			return
+		case "reject":
+			if r.Method != http.MethodPost {
+				api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+				return
+			}
+			api.handleRejectAdminBuild(w, r, buildID)
+			return
+		case "history":
+			if r.Method != http.MethodGet {
+				api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+				return
+			}
+			api.handleAdminBuildHistory(w, r, buildID)
+			return
 		default:
 			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown build action"})
 			return
@@ -595,6 +979,11 @@ func (api *AdminAPI) handleUpdateAdminBuild(w http.ResponseWriter, r *http.Reque
 
 	updated, err := api.buildSvc.UpdateForModeration(ctx, buildID, params)
 	if err != nil {
+		var svcErr *builds.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+			return
+		}
 		api.logger.Error("Failed to update moderation build", logging.WithField("error", err.Error()))
 		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to update build"})
 		return
@@ -611,7 +1000,7 @@ func (api *AdminAPI) handlePublishAdminBuild(w http.ResponseWriter, r *http.Requ
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
 	defer cancel()
 
-	updated, validation, err := api.buildSvc.ApproveForModeration(ctx, buildID)
+	updated, validation, err := api.buildSvc.ApproveForModeration(ctx, buildID, auth.GetUserID(r.Context()))
 	if err != nil {
 		var validationErr *builds.ValidationError
 		if errors.As(err, &validationErr) {
@@ -619,8 +1008,8 @@ func (api *AdminAPI) handlePublishAdminBuild(w http.ResponseWriter, r *http.Requ
 			return
 		}
 		var svcErr *builds.ServiceError
-		if errors.As(err, &svcErr) && strings.EqualFold(strings.TrimSpace(svcErr.Message), "build is not pending moderation") {
-			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": svcErr.Message})
+		if errors.As(err, &svcErr) && svcErr.Code == apierror.BuildNotPending {
+			api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
 			return
 		}
 		api.logger.Error("Failed to publish moderation build", logging.WithField("error", err.Error()))
@@ -638,6 +1027,58 @@ func (api *AdminAPI) handlePublishAdminBuild(w http.ResponseWriter, r *http.Requ
 	})
 }
 
+// handleRejectAdminBuild handles POST /api/admin/builds/{id}/reject. The
+// build goes back to the owner as a draft with the reason attached.
+func (api *AdminAPI) handleRejectAdminBuild(w http.ResponseWriter, r *http.Request, buildID string) {
+	var params models.RejectBuildParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	moderatorID := auth.GetUserID(r.Context())
+	response, err := api.buildSvc.RejectForModeration(ctx, buildID, moderatorID, params)
+	if err != nil {
+		var svcErr *builds.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+			return
+		}
+		api.logger.Error("Failed to reject moderation build", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to reject build"})
+		return
+	}
+	if response == nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "build not found"})
+		return
+	}
+
+	api.logger.Info("Moderator rejected build",
+		logging.WithField("buildId", buildID),
+		logging.WithField("category", response.Rejection.Category),
+		logging.WithField("moderatorId", moderatorID),
+	)
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+// handleAdminBuildHistory handles GET /api/admin/builds/{id}/history
+func (api *AdminAPI) handleAdminBuildHistory(w http.ResponseWriter, r *http.Request, buildID string) {
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	history, err := api.buildSvc.ModerationHistoryFor(ctx, buildID)
+	if err != nil {
+		api.logger.Error("Failed to list build moderation history", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to list moderation history"})
+		return
+	}
+
+	api.writeJSON(w, http.StatusOK, map[string]interface{}{"history": history})
+}
+
 func (api *AdminAPI) handleAdminBuildImage(w http.ResponseWriter, r *http.Request, buildID string) {
 	switch r.Method {
 	case http.MethodGet:
@@ -693,9 +1134,12 @@ func (api *AdminAPI) uploadAdminBuildImage(w http.ResponseWriter, r *http.Reques
 		ImageData: imageData,
 	})
 	if err != nil {
+		if writeImageUploadError(w, err) {
+			return
+		}
 		var svcErr *builds.ServiceError
-		if errors.As(err, &svcErr) && strings.EqualFold(strings.TrimSpace(svcErr.Message), "build not found") {
-			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "build not found"})
+		if errors.As(err, &svcErr) && svcErr.Code == apierror.BuildNotFound {
+			api.writeJSON(w, http.StatusNotFound, errorBody(svcErr))
 			return
 		}
 		api.logger.Error("Failed to upload moderation build image", logging.WithField("error", err.Error()))
@@ -751,8 +1195,8 @@ func (api *AdminAPI) deleteAdminBuildImage(w http.ResponseWriter, r *http.Reques
 
 	if err := api.buildSvc.DeleteImageForModeration(ctx, buildID); err != nil {
 		var svcErr *builds.ServiceError
-		if errors.As(err, &svcErr) && strings.EqualFold(strings.TrimSpace(svcErr.Message), "build not found") {
-			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "build not found"})
+		if errors.As(err, &svcErr) && svcErr.Code == apierror.BuildNotFound {
+			api.writeJSON(w, http.StatusNotFound, errorBody(svcErr))
 			return
 		}
 		api.logger.Error("Failed to delete moderation build image", logging.WithField("error", err.Error()))
@@ -816,6 +1260,16 @@ func (api *AdminAPI) handleAdminUsers(w http.ResponseWriter, r *http.Request) {
 func (api *AdminAPI) handleAdminUserByID(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/api/admin/users/")
 
+	if strings.HasSuffix(path, "/image-quota") {
+		id := strings.TrimSuffix(path, "/image-quota")
+		if id == "" || strings.Contains(id, "/") {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "user ID required"})
+			return
+		}
+		api.handleAdminUserImageQuota(w, r, id)
+		return
+	}
+
 	if strings.HasSuffix(path, "/avatar") {
 		id := strings.TrimSuffix(path, "/avatar")
 		id = strings.TrimSuffix(id, "/")
@@ -871,7 +1325,22 @@ func (api *AdminAPI) handleGetAdminUser(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	api.writeJSON(w, http.StatusOK, user)
+	response := adminUserResponse{User: user}
+	if api.reputation != nil {
+		userReputation, err := api.reputation.Get(ctx, id)
+		if err != nil {
+			api.logger.Warn("Failed to get user reputation", logging.WithField("error", err.Error()))
+		}
+		response.Reputation = userReputation
+	}
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+// adminUserResponse is a user with the reputation moderators use to decide
+// whose submissions to fast-track
+type adminUserResponse struct {
+	*models.User
+	Reputation *models.UserReputation `json:"reputation,omitempty"`
 }
 
 // handleUpdateAdminUser handles PATCH /api/admin/users/{id}
@@ -923,6 +1392,16 @@ func (api *AdminAPI) handleUpdateAdminUser(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// Resending the current status, as the user admin form does on every
+	// save, keeps the reason and expiry already set
+	if params.Status != nil && *params.Status == existing.Status && params.StatusReason == nil && params.StatusExpiresAt == nil {
+		params.Status = nil
+	}
+	if msg := validateStatusChange(params, time.Now()); msg != "" {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": msg})
+		return
+	}
+
 	updated, err := api.userStore.AdminUpdate(ctx, id, params)
 	if err != nil {
 		api.logger.Error("Failed to update user from admin", logging.WithField("error", err.Error()))
@@ -940,6 +1419,41 @@ func (api *AdminAPI) handleUpdateAdminUser(w http.ResponseWriter, r *http.Reques
 	api.writeJSON(w, http.StatusOK, updated)
 }
 
+// maxStatusReasonLength bounds the reason shown to a disabled or suspended
+// user
+const maxStatusReasonLength = 500
+
+// validateStatusChange checks the reason and expiry sent with a status
+// change. Disabling or suspending needs a reason the user will see; only a
+// suspension can have an expiry, and it must be in the future.
+func validateStatusChange(params models.AdminUpdateUserParams, now time.Time) string {
+	if params.Status == nil {
+		if params.StatusReason != nil || params.StatusExpiresAt != nil {
+			return "statusReason and statusExpiresAt need a status"
+		}
+		return ""
+	}
+
+	status := *params.Status
+	if status == models.UserStatusDisabled || status == models.UserStatusSuspended {
+		if params.StatusReason == nil || strings.TrimSpace(*params.StatusReason) == "" {
+			return "a reason is required to disable or suspend an account"
+		}
+		if utf8.RuneCountInString(strings.TrimSpace(*params.StatusReason)) > maxStatusReasonLength {
+			return fmt.Sprintf("statusReason must be at most %d characters", maxStatusReasonLength)
+		}
+	}
+	if params.StatusExpiresAt != nil {
+		if status != models.UserStatusSuspended {
+			return "only a suspension can have an expiry"
+		}
+		if !params.StatusExpiresAt.After(now) {
+			return "statusExpiresAt must be in the future"
+		}
+	}
+	return ""
+}
+
 // handleDeleteAdminUser handles DELETE /api/admin/users/{id}
 func (api *AdminAPI) handleDeleteAdminUser(w http.ResponseWriter, r *http.Request, id string) {
 	adminUserID := auth.GetUserID(r.Context())
@@ -1028,13 +1542,81 @@ func (api *AdminAPI) handleDeleteAdminUserAvatar(w http.ResponseWriter, r *http.
 	api.writeJSON(w, http.StatusOK, updated)
 }
 
+// handleAdminUserImageQuota handles GET/PUT/DELETE /api/admin/users/{id}/image-quota.
+// GET reports usage against the effective quota; PUT overrides the default quota
+// for the user and DELETE restores it.
+func (api *AdminAPI) handleAdminUserImageQuota(w http.ResponseWriter, r *http.Request, id string) {
+	if api.imageSvc == nil {
+		api.writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "image storage unavailable"})
+		return
+	}
+
+	adminUserID := auth.GetUserID(r.Context())
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	existing, err := api.userStore.GetByID(ctx, id)
+	if err != nil {
+		api.logger.Error("Failed to get user for image quota", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to get user"})
+		return
+	}
+	if existing == nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "user not found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var params models.SetImageQuotaOverrideParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+		if (params.MaxCount != nil && *params.MaxCount < 0) || (params.MaxBytes != nil && *params.MaxBytes < 0) {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "maxCount and maxBytes must not be negative"})
+			return
+		}
+		if _, err := api.imageSvc.SetQuotaOverride(ctx, id, adminUserID, params); err != nil {
+			api.logger.Error("Failed to set image quota override", logging.WithField("error", err.Error()))
+			api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to set image quota"})
+			return
+		}
+		api.logger.Info("Admin set image quota override",
+			logging.WithField("targetUserId", id),
+			logging.WithField("adminId", adminUserID),
+		)
+	case http.MethodDelete:
+		if err := api.imageSvc.DeleteQuotaOverride(ctx, id); err != nil {
+			api.logger.Error("Failed to delete image quota override", logging.WithField("error", err.Error()))
+			api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to reset image quota"})
+			return
+		}
+		api.logger.Info("Admin reset image quota override",
+			logging.WithField("targetUserId", id),
+			logging.WithField("adminId", adminUserID),
+		)
+	default:
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	usage, err := api.imageSvc.Usage(ctx, id)
+	if err != nil {
+		api.logger.Error("Failed to get image usage", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to get image usage"})
+		return
+	}
+	api.writeJSON(w, http.StatusOK, usage)
+}
+
 // writeJSON writes a JSON response
 func (api *AdminAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
 	// Prevent browser caching of admin API responses to ensure fresh data after edits
 	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+	writeJSON(w, status, data)
 }
 
 // parseIntQuery parses an integer from query string with a default
@@ -1050,15 +1632,26 @@ func parseIntQuery(s string, defaultVal int) int {
 }
 
 // handleGearImage handles POST /api/admin/gear/{id}/image for image upload
-// and GET for serving the image
+// and GET for serving the image. An optional ?index= addresses one of the
+// item's images instead of the primary image.
 func (api *AdminAPI) handleGearImage(w http.ResponseWriter, r *http.Request, id string) {
+	index, ok := parseImageIndex(r)
+	if !ok {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "index must be a non-negative integer"})
+		return
+	}
+	if index != nil && api.imageSvc == nil {
+		api.writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "image moderation unavailable"})
+		return
+	}
+
 	switch r.Method {
 	case http.MethodPost:
-		api.uploadGearImage(w, r, id)
+		api.uploadGearImage(w, r, id, index)
 	case http.MethodGet:
-		api.getGearImage(w, r, id)
+		api.getGearImage(w, r, id, index)
 	case http.MethodDelete:
-		api.deleteGearImage(w, r, id)
+		api.deleteGearImage(w, r, id, index)
 	default:
 		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
 	}
@@ -1066,9 +1659,10 @@ func (api *AdminAPI) handleGearImage(w http.ResponseWriter, r *http.Request, id
 
 // uploadGearImage handles POST /api/admin/gear/{id}/image.
 // Supports either:
-//   - multipart image uploads (moderate + persist immediately), or
+//   - multipart image uploads (moderate + persist immediately), which take an
+//     optional ?background=white|transparent product shot cleanup, or
 //   - JSON {uploadId} for persisting a previously approved moderation token.
-func (api *AdminAPI) uploadGearImage(w http.ResponseWriter, r *http.Request, id string) {
+func (api *AdminAPI) uploadGearImage(w http.ResponseWriter, r *http.Request, id string, index *int) {
 	if api.imageSvc == nil {
 		api.writeJSON(w, http.StatusServiceUnavailable, map[string]string{
 			"error": "image moderation unavailable",
@@ -1097,7 +1691,17 @@ func (api *AdminAPI) uploadGearImage(w http.ResponseWriter, r *http.Request, id
 	}
 
 	if isJSONContentType(r.Header.Get("Content-Type")) {
-		api.persistApprovedGearUpload(w, r, ctx, id, userID)
+		api.persistApprovedGearUpload(w, r, ctx, existing, userID, index)
+		return
+	}
+
+	background, ok := parseImageBackground(r)
+	if !ok {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "background must be white or transparent"})
+		return
+	}
+	if background != "" && !api.imageSvc.BackgroundRemovalEnabled() {
+		api.writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "background removal unavailable"})
 		return
 	}
 
@@ -1139,8 +1743,7 @@ func (api *AdminAPI) uploadGearImage(w http.ResponseWriter, r *http.Request, id
 		})
 		return
 	}
-	contentType, ok := detectAllowedImageContentType(imageData)
-	if !ok {
+	if _, ok := detectAllowedImageContentType(imageData); !ok {
 		api.writeJSON(w, http.StatusBadRequest, map[string]string{
 			"error": "Image must be JPEG or PNG",
 		})
@@ -1153,8 +1756,17 @@ func (api *AdminAPI) uploadGearImage(w http.ResponseWriter, r *http.Request, id
 		EntityType:  models.ImageEntityGear,
 		EntityID:    id,
 		ImageBytes:  imageData,
+		Background:  background,
 	})
 	if err != nil {
+		if writeImageUploadError(w, err) {
+			return
+		}
+		if status, message, ok := imageBackgroundError(err); ok {
+			api.logger.Warn("Gear image background replacement failed", logging.WithField("error", err.Error()))
+			api.writeJSON(w, status, map[string]string{"error": message})
+			return
+		}
 		api.logger.Error("Failed to moderate gear image", logging.WithField("error", err.Error()))
 		api.writeJSON(w, http.StatusInternalServerError, map[string]string{
 			"error": "Failed to moderate image",
@@ -1173,7 +1785,13 @@ func (api *AdminAPI) uploadGearImage(w http.ResponseWriter, r *http.Request, id
 		return
 	}
 
-	if err := api.attachAdminGearImageAsset(ctx, id, userID, contentType, asset.ID); err != nil {
+	// Normalization may have re-encoded the upload, so type the stored bytes
+	contentType, _ := detectAllowedImageContentType(asset.ImageBytes)
+	if err := api.attachAdminGearImageAsset(ctx, existing, userID, contentType, asset.ID, index); err != nil {
+		if isGalleryRequestError(err) {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
 		api.logger.Error("Failed to store gear image", logging.WithFields(map[string]interface{}{
 			"gearId": id,
 			"error":  err.Error(),
@@ -1196,7 +1814,8 @@ func (api *AdminAPI) uploadGearImage(w http.ResponseWriter, r *http.Request, id
 	})
 }
 
-func (api *AdminAPI) persistApprovedGearUpload(w http.ResponseWriter, r *http.Request, ctx context.Context, id string, userID string) {
+func (api *AdminAPI) persistApprovedGearUpload(w http.ResponseWriter, r *http.Request, ctx context.Context, item *models.GearCatalogItem, userID string, index *int) {
+	id := item.ID
 	var req struct {
 		UploadID string `json:"uploadId"`
 	}
@@ -1246,7 +1865,11 @@ func (api *AdminAPI) persistApprovedGearUpload(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if err := api.attachAdminGearImageAsset(ctx, id, userID, contentType, asset.ID); err != nil {
+	if err := api.attachAdminGearImageAsset(ctx, item, userID, contentType, asset.ID, index); err != nil {
+		if isGalleryRequestError(err) {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
 		api.logger.Error("Failed to store approved gear upload", logging.WithFields(map[string]interface{}{
 			"gearId": id,
 			"error":  err.Error(),
@@ -1268,8 +1891,12 @@ func (api *AdminAPI) persistApprovedGearUpload(w http.ResponseWriter, r *http.Re
 	})
 }
 
-func (api *AdminAPI) attachAdminGearImageAsset(ctx context.Context, gearID string, adminUserID string, contentType string, assetID string) error {
-	previousAssetID, err := api.catalogStore.SetImage(ctx, gearID, adminUserID, contentType, assetID)
+func (api *AdminAPI) attachAdminGearImageAsset(ctx context.Context, item *models.GearCatalogItem, adminUserID string, contentType string, assetID string, index *int) error {
+	if index != nil {
+		return api.imageSvc.AttachAt(ctx, models.ImageEntityGear, item.ID, api.gearImageSlot(item, adminUserID), *index, assetID)
+	}
+
+	previousAssetID, err := api.catalogStore.SetImage(ctx, item.ID, adminUserID, contentType, assetID)
 	if err != nil {
 		_ = api.imageSvc.Delete(ctx, assetID)
 		return err
@@ -1280,17 +1907,51 @@ func (api *AdminAPI) attachAdminGearImageAsset(ctx context.Context, gearID strin
 	return nil
 }
 
+// gearImageSlot exposes a catalog item's primary image columns to the image
+// gallery. The stored image type is detected from the promoted asset.
+func (api *AdminAPI) gearImageSlot(item *models.GearCatalogItem, adminUserID string) images.PrimarySlot {
+	return images.PrimarySlot{
+		AssetID: item.ImageAssetID,
+		Set: func(ctx context.Context, assetID string) (string, error) {
+			asset, err := api.imageSvc.Load(ctx, assetID)
+			if err != nil {
+				return "", err
+			}
+			var contentType string
+			if asset != nil {
+				contentType, _ = detectAllowedImageContentType(asset.ImageBytes)
+			}
+			return api.catalogStore.SetImage(ctx, item.ID, adminUserID, contentType, assetID)
+		},
+		Clear: func(ctx context.Context) (string, error) {
+			return api.catalogStore.DeleteImage(ctx, item.ID)
+		},
+	}
+}
+
 func isJSONContentType(raw string) bool {
 	contentType := strings.ToLower(strings.TrimSpace(raw))
 	return strings.HasPrefix(contentType, "application/json")
 }
 
 // getGearImage handles GET /api/admin/gear/{id}/image
-func (api *AdminAPI) getGearImage(w http.ResponseWriter, r *http.Request, id string) {
+func (api *AdminAPI) getGearImage(w http.ResponseWriter, r *http.Request, id string, index *int) {
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
 	defer cancel()
 
-	imageData, imageType, err := api.catalogStore.GetImage(ctx, id)
+	var (
+		imageData []byte
+		imageType string
+		err       error
+	)
+	if index != nil {
+		imageData, err = api.imageSvc.LoadAt(ctx, models.ImageEntityGear, id, *index)
+		if isGalleryRequestError(err) {
+			imageData, err = nil, nil
+		}
+	} else {
+		imageData, imageType, err = api.catalogStore.GetImage(ctx, id)
+	}
 	if err != nil {
 		api.logger.Error("Failed to get gear image", logging.WithFields(map[string]interface{}{
 			"gearId": id,
@@ -1315,15 +1976,31 @@ func (api *AdminAPI) getGearImage(w http.ResponseWriter, r *http.Request, id str
 	w.Write(imageData)
 }
 
-// deleteGearImage handles DELETE /api/admin/gear/{id}/image
-func (api *AdminAPI) deleteGearImage(w http.ResponseWriter, r *http.Request, id string) {
+// deleteGearImage handles DELETE /api/admin/gear/{id}/image. Deleting the
+// primary image promotes the item's next image.
+func (api *AdminAPI) deleteGearImage(w http.ResponseWriter, r *http.Request, id string, index *int) {
 	userID := auth.GetUserID(r.Context())
 
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
 	defer cancel()
 
-	previousAssetID, err := api.catalogStore.DeleteImage(ctx, id)
+	item, err := api.catalogStore.Get(ctx, id)
+	if err == nil && item == nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "gear item not found"})
+		return
+	}
+	if err == nil {
+		if index != nil {
+			err = api.imageSvc.RemoveAt(ctx, models.ImageEntityGear, id, api.gearImageSlot(item, userID), *index)
+		} else {
+			err = api.deletePrimaryGearImage(ctx, item, userID)
+		}
+	}
 	if err != nil {
+		if isGalleryRequestError(err) {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
 		api.logger.Error("Failed to delete gear image", logging.WithFields(map[string]interface{}{
 			"gearId": id,
 			"error":  err.Error(),
@@ -1333,9 +2010,6 @@ func (api *AdminAPI) deleteGearImage(w http.ResponseWriter, r *http.Request, id
 		})
 		return
 	}
-	if previousAssetID != "" {
-		_ = api.imageSvc.Delete(ctx, previousAssetID)
-	}
 
 	api.logger.Info("Admin deleted gear image",
 		logging.WithField("gearId", id),
@@ -1347,6 +2021,63 @@ func (api *AdminAPI) deleteGearImage(w http.ResponseWriter, r *http.Request, id
 	})
 }
 
+func (api *AdminAPI) deletePrimaryGearImage(ctx context.Context, item *models.GearCatalogItem, adminUserID string) error {
+	previousAssetID, err := api.catalogStore.DeleteImage(ctx, item.ID)
+	if err != nil {
+		return err
+	}
+	if previousAssetID != "" {
+		_ = api.imageSvc.Delete(ctx, previousAssetID)
+	}
+	item.ImageAssetID = ""
+	return api.imageSvc.PromoteFirst(ctx, models.ImageEntityGear, item.ID, api.gearImageSlot(item, adminUserID))
+}
+
+// setPrimaryGearImage handles POST /api/admin/gear/{id}/image/primary?index=N
+func (api *AdminAPI) setPrimaryGearImage(w http.ResponseWriter, r *http.Request, id string) {
+	index, ok := parseImageIndex(r)
+	if !ok || index == nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "index must be a non-negative integer"})
+		return
+	}
+	if api.imageSvc == nil {
+		api.writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "image moderation unavailable"})
+		return
+	}
+
+	userID := auth.GetUserID(r.Context())
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	item, err := api.catalogStore.Get(ctx, id)
+	if err == nil && item == nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "gear item not found"})
+		return
+	}
+	if err == nil {
+		err = api.imageSvc.SetPrimaryAt(ctx, models.ImageEntityGear, id, api.gearImageSlot(item, userID), *index)
+	}
+	if err != nil {
+		if isGalleryRequestError(err) {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		api.logger.Error("Failed to set primary gear image", logging.WithFields(map[string]interface{}{
+			"gearId": id,
+			"error":  err.Error(),
+		}))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "Failed to set primary image"})
+		return
+	}
+
+	api.logger.Info("Admin set primary gear image",
+		logging.WithField("gearId", id),
+		logging.WithField("index", *index),
+		logging.WithField("adminId", userID),
+	)
+	api.writeJSON(w, http.StatusOK, map[string]string{"message": "Primary image updated"})
+}
+
 // approveGearImage handles POST /api/admin/gear/{id}/image/approve
 func (api *AdminAPI) approveGearImage(w http.ResponseWriter, r *http.Request, id string) {
 	userID := auth.GetUserID(r.Context())
@@ -2,6 +2,7 @@ package httpapi
 
 import (
 	"testing"
+	"time"
 
 	"github.com/johnrirwin/flyingforge/internal/models"
 )
@@ -124,3 +125,65 @@ func TestIsJSONContentType(t *testing.T) {
 		})
 	}
 }
+
+func TestUserExportRecord(t *testing.T) {
+	lastLogin := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	record := userExportRecord(models.UserExportRow{
+		ID:                 "u1",
+		Email:              "pilot@example.com",
+		DisplayName:        "=HYPERLINK(\"x\")",
+		CallSign:           "Ripper",
+		Role:               models.RoleFor(false, true),
+		Status:             models.UserStatusActive,
+		CreatedAt:          time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		LastLoginAt:        &lastLogin,
+		CatalogSubmissions: 4,
+		CatalogPublished:   3,
+		PublishedBuilds:    2,
+		ApprovedGearImages: 1,
+	})
+
+	want := []string{"u1", "pilot@example.com", "'=HYPERLINK(\"x\")", "Ripper", "moderator", "active",
+		"2025-01-02T03:04:05Z", "2026-03-04T05:06:07Z", "4", "3", "2", "1"}
+	if len(record) != len(userExportColumns) {
+		t.Fatalf("record has %d fields, header has %d", len(record), len(userExportColumns))
+	}
+	for i := range want {
+		if record[i] != want[i] {
+			t.Errorf("%s = %q, want %q", userExportColumns[i], record[i], want[i])
+		}
+	}
+}
+
+func TestValidateStatusChange(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	status := func(s models.UserStatus) *models.UserStatus { return &s }
+	reason := func(s string) *string { return &s }
+	at := func(d time.Duration) *time.Time { t := now.Add(d); return &t }
+
+	tests := []struct {
+		name   string
+		params models.AdminUpdateUserParams
+		valid  bool
+	}{
+		{"role change only", models.AdminUpdateUserParams{IsAdmin: func() *bool { b := true; return &b }()}, true},
+		{"reactivate", models.AdminUpdateUserParams{Status: status(models.UserStatusActive)}, true},
+		{"disable with reason", models.AdminUpdateUserParams{Status: status(models.UserStatusDisabled), StatusReason: reason("chargeback fraud")}, true},
+		{"disable without reason", models.AdminUpdateUserParams{Status: status(models.UserStatusDisabled)}, false},
+		{"suspend with blank reason", models.AdminUpdateUserParams{Status: status(models.UserStatusSuspended), StatusReason: reason("  ")}, false},
+		{"suspend until later", models.AdminUpdateUserParams{Status: status(models.UserStatusSuspended), StatusReason: reason("spam"), StatusExpiresAt: at(72 * time.Hour)}, true},
+		{"suspend with no end", models.AdminUpdateUserParams{Status: status(models.UserStatusSuspended), StatusReason: reason("spam")}, true},
+		{"suspend until the past", models.AdminUpdateUserParams{Status: status(models.UserStatusSuspended), StatusReason: reason("spam"), StatusExpiresAt: at(-time.Hour)}, false},
+		{"expiry on a disable", models.AdminUpdateUserParams{Status: status(models.UserStatusDisabled), StatusReason: reason("spam"), StatusExpiresAt: at(time.Hour)}, false},
+		{"reason without status", models.AdminUpdateUserParams{StatusReason: reason("spam")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := validateStatusChange(tt.params, now)
+			if (msg == "") != tt.valid {
+				t.Errorf("validateStatusChange() = %q, want valid %v", msg, tt.valid)
+			}
+		})
+	}
+}
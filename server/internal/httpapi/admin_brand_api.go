@@ -0,0 +1,199 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// handleAdminBrands handles GET/POST /api/admin/brands
+func (api *AdminAPI) handleAdminBrands(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+		defer cancel()
+
+		query := r.URL.Query()
+		response, err := api.brandStore.List(ctx, query.Get("q"), parseIntQuery(query.Get("limit"), 50), parseIntQuery(query.Get("offset"), 0))
+		if err != nil {
+			api.logger.Error("Failed to list brands", logging.WithField("error", err.Error()))
+			api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to list brands"})
+			return
+		}
+		api.writeJSON(w, http.StatusOK, response)
+	case http.MethodPost:
+		var params models.CreateBrandParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+		if strings.TrimSpace(params.Name) == "" {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "name is required"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+		defer cancel()
+
+		brand, err := api.brandStore.Create(ctx, params)
+		if err != nil {
+			api.writeBrandError(w, err, "failed to create brand")
+			return
+		}
+
+		api.logger.Info("Admin created brand",
+			logging.WithField("brandId", brand.ID),
+			logging.WithField("adminId", auth.GetUserID(r.Context())),
+		)
+		api.writeJSON(w, http.StatusCreated, brand)
+	default:
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// handleAdminBrandsMerge handles POST /api/admin/brands/merge.
+// Folds source brands into the target and rewrites matching catalog rows.
+func (api *AdminAPI) handleAdminBrandsMerge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	var params models.MergeBrandsParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+	params.TargetID = strings.TrimSpace(params.TargetID)
+	if params.TargetID == "" || len(params.SourceIDs) == 0 {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "targetId and sourceIds are required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	result, err := api.brandStore.Merge(ctx, params)
+	if err != nil {
+		api.writeBrandError(w, err, "failed to merge brands")
+		return
+	}
+
+	api.logger.Info("Admin merged brands",
+		logging.WithField("targetId", params.TargetID),
+		logging.WithField("sourceCount", len(params.SourceIDs)),
+		logging.WithField("rewrittenCount", result.RewrittenCount),
+		logging.WithField("conflictCount", len(result.Conflicts)),
+		logging.WithField("adminId", auth.GetUserID(r.Context())),
+	)
+	api.writeJSON(w, http.StatusOK, result)
+}
+
+// handleAdminBrandByID handles GET/PUT/DELETE /api/admin/brands/{id}
+// and POST /api/admin/brands/{id}/rewrite
+func (api *AdminAPI) handleAdminBrandByID(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/admin/brands/")
+
+	if strings.HasSuffix(path, "/rewrite") {
+		id := strings.TrimSuffix(path, "/rewrite")
+		if r.Method != http.MethodPost {
+			api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+			return
+		}
+		api.handleRewriteBrandCatalog(w, r, id)
+		return
+	}
+
+	id := path
+	if id == "" || strings.Contains(id, "/") {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	switch r.Method {
+	case http.MethodGet:
+		brand, err := api.brandStore.Get(ctx, id)
+		if err != nil {
+			api.writeBrandError(w, err, "failed to get brand")
+			return
+		}
+		if brand == nil {
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "brand not found"})
+			return
+		}
+		api.writeJSON(w, http.StatusOK, brand)
+	case http.MethodPut:
+		var params models.UpdateBrandParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+		brand, err := api.brandStore.Update(ctx, id, params)
+		if err != nil {
+			api.writeBrandError(w, err, "failed to update brand")
+			return
+		}
+		api.logger.Info("Admin updated brand",
+			logging.WithField("brandId", id),
+			logging.WithField("adminId", auth.GetUserID(r.Context())),
+		)
+		api.writeJSON(w, http.StatusOK, brand)
+	case http.MethodDelete:
+		if err := api.brandStore.Delete(ctx, id); err != nil {
+			api.writeBrandError(w, err, "failed to delete brand")
+			return
+		}
+		api.logger.Info("Admin deleted brand",
+			logging.WithField("brandId", id),
+			logging.WithField("adminId", auth.GetUserID(r.Context())),
+		)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// handleRewriteBrandCatalog handles POST /api/admin/brands/{id}/rewrite.
+// Applies the brand's aliases to existing catalog rows.
+func (api *AdminAPI) handleRewriteBrandCatalog(w http.ResponseWriter, r *http.Request, id string) {
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	result, err := api.brandStore.RewriteCatalog(ctx, id)
+	if err != nil {
+		api.writeBrandError(w, err, "failed to rewrite catalog brand")
+		return
+	}
+
+	api.logger.Info("Admin rewrote catalog brand",
+		logging.WithField("brandId", id),
+		logging.WithField("rewrittenCount", result.RewrittenCount),
+		logging.WithField("conflictCount", len(result.Conflicts)),
+		logging.WithField("adminId", auth.GetUserID(r.Context())),
+	)
+	api.writeJSON(w, http.StatusOK, result)
+}
+
+// writeBrandError maps brand store errors to HTTP responses
+func (api *AdminAPI) writeBrandError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, database.ErrBrandNotFound):
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "brand not found"})
+	case errors.Is(err, database.ErrBrandConflict):
+		api.writeJSON(w, http.StatusConflict, map[string]string{"error": err.Error()})
+	default:
+		api.logger.Error("Brand admin operation failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": message})
+	}
+}
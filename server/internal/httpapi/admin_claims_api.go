@@ -0,0 +1,90 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// handleModerationClaim handles the claim on a queue item:
+// POST   /api/admin/{gear|builds}/{id}/claim - claim the item or renew the claim
+// DELETE /api/admin/{gear|builds}/{id}/claim - release it; admins can add ?force=true
+func (api *AdminAPI) handleModerationClaim(w http.ResponseWriter, r *http.Request, queue models.ModerationQueue, id string) {
+	if api.claims == nil {
+		api.writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "moderation claims unavailable"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	moderatorID := auth.GetUserID(r.Context())
+
+	switch r.Method {
+	case http.MethodPost:
+		assignment, err := api.claims.Claim(ctx, queue, id, moderatorID)
+		if err != nil {
+			api.writeClaimError(w, err, assignment)
+			return
+		}
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"assignment": assignment})
+	case http.MethodDelete:
+		force := r.URL.Query().Get("force") == "true"
+		if force {
+			user, err := api.userStore.GetByID(ctx, moderatorID)
+			if err != nil || !canManageUsers(user) {
+				api.writeJSON(w, http.StatusForbidden, map[string]string{"error": "only admins can force-release a claim"})
+				return
+			}
+		}
+		if err := api.claims.Release(ctx, queue, id, moderatorID, force); err != nil {
+			api.writeClaimError(w, err, nil)
+			return
+		}
+		if force {
+			api.logger.Info("Admin force-released moderation claim",
+				logging.WithField("queue", queue),
+				logging.WithField("id", id),
+				logging.WithField("adminId", moderatorID),
+			)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// checkClaim refuses a change to an item another moderator has claimed. It
+// writes the response and returns false when the change must stop.
+func (api *AdminAPI) checkClaim(w http.ResponseWriter, r *http.Request, queue models.ModerationQueue, id string) bool {
+	if api.claims == nil {
+		return true
+	}
+	assignment, err := api.claims.CheckClaim(r.Context(), queue, id, auth.GetUserID(r.Context()))
+	if err != nil {
+		api.writeClaimError(w, err, assignment)
+		return false
+	}
+	return true
+}
+
+func (api *AdminAPI) writeClaimError(w http.ResponseWriter, err error, assignment *models.ModerationAssignment) {
+	switch {
+	case errors.Is(err, database.ErrClaimedByOther):
+		api.writeJSON(w, http.StatusConflict, map[string]interface{}{
+			"error":      "another moderator is working on this item",
+			"assignment": assignment,
+		})
+	case errors.Is(err, database.ErrModerationItemNotFound):
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "item not found"})
+	default:
+		api.logger.Error("Moderation claim failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to update claim"})
+	}
+}
@@ -0,0 +1,136 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/featured"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// handleAdminFeatured handles GET/POST /api/admin/featured
+func (api *AdminAPI) handleAdminFeatured(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		response, err := api.featuredSvc.List(ctx, models.FeaturedContentListParams{
+			Status:      models.FeaturedContentStatus(strings.ToLower(query.Get("status"))),
+			ContentType: models.FeaturedContentType(strings.ToLower(query.Get("type"))),
+			Limit:       parseIntQuery(query.Get("limit"), 50),
+			Offset:      parseIntQuery(query.Get("offset"), 0),
+		})
+		if err != nil {
+			api.writeFeaturedError(w, err, "failed to list featured content")
+			return
+		}
+		api.writeJSON(w, http.StatusOK, response)
+	case http.MethodPost:
+		var params models.CreateFeaturedContentParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+
+		adminID := auth.GetUserID(r.Context())
+		item, err := api.featuredSvc.Create(ctx, adminID, params)
+		if err != nil {
+			api.writeFeaturedError(w, err, "failed to schedule featured content")
+			return
+		}
+
+		api.logger.Info("Admin scheduled featured content",
+			logging.WithField("featuredId", item.ID),
+			logging.WithField("contentType", item.ContentType),
+			logging.WithField("contentId", item.ContentID),
+			logging.WithField("adminId", adminID),
+		)
+		api.writeJSON(w, http.StatusCreated, item)
+	default:
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// handleAdminFeaturedByID handles GET/PUT/DELETE /api/admin/featured/{id}
+func (api *AdminAPI) handleAdminFeaturedByID(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/admin/featured/")
+	if _, err := uuid.Parse(id); err != nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "featured content not found"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	switch r.Method {
+	case http.MethodGet:
+		item, err := api.featuredSvc.Get(ctx, id)
+		if err != nil {
+			api.writeFeaturedError(w, err, "failed to get featured content")
+			return
+		}
+		if item == nil {
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "featured content not found"})
+			return
+		}
+		api.writeJSON(w, http.StatusOK, item)
+	case http.MethodPut:
+		var params models.UpdateFeaturedContentParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+		item, err := api.featuredSvc.Update(ctx, id, params)
+		if err != nil {
+			api.writeFeaturedError(w, err, "failed to update featured content")
+			return
+		}
+		if item == nil {
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "featured content not found"})
+			return
+		}
+		api.logger.Info("Admin updated featured content",
+			logging.WithField("featuredId", id),
+			logging.WithField("adminId", auth.GetUserID(r.Context())),
+		)
+		api.writeJSON(w, http.StatusOK, item)
+	case http.MethodDelete:
+		if err := api.featuredSvc.Delete(ctx, id); err != nil {
+			if errors.Is(err, database.ErrFeaturedContentNotFound) {
+				api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "featured content not found"})
+				return
+			}
+			api.writeFeaturedError(w, err, "failed to delete featured content")
+			return
+		}
+		api.logger.Info("Admin removed featured content",
+			logging.WithField("featuredId", id),
+			logging.WithField("adminId", auth.GetUserID(r.Context())),
+		)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// writeFeaturedError maps featured service errors to HTTP responses
+func (api *AdminAPI) writeFeaturedError(w http.ResponseWriter, err error, message string) {
+	var svcErr *featured.ServiceError
+	if errors.As(err, &svcErr) {
+		api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+		return
+	}
+	api.logger.Error("Featured content admin operation failed", logging.WithField("error", err.Error()))
+	api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": message})
+}
@@ -0,0 +1,139 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/feedfilter"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// handleAdminFeedFilters handles GET /api/admin/feed/filters
+func (api *AdminAPI) handleAdminFeedFilters(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	filters, err := api.feedFilterSvc.List(ctx)
+	if err != nil {
+		api.writeFeedFilterError(w, err, "failed to list feed filters")
+		return
+	}
+	api.writeJSON(w, http.StatusOK, map[string]interface{}{"filters": filters})
+}
+
+// handleAdminCreateFeedFilter handles POST /api/admin/feed/filters
+func (api *AdminAPI) handleAdminCreateFeedFilter(w http.ResponseWriter, r *http.Request) {
+	var params models.SaveFeedFilterParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	adminID := auth.GetUserID(r.Context())
+	filter, err := api.feedFilterSvc.Create(ctx, adminID, params)
+	if err != nil {
+		api.writeFeedFilterError(w, err, "failed to create feed filter")
+		return
+	}
+
+	api.logger.Info("Admin added feed filter",
+		logging.WithField("filterId", filter.ID),
+		logging.WithField("kind", filter.Kind),
+		logging.WithField("adminId", adminID),
+	)
+	api.writeJSON(w, http.StatusCreated, filter)
+}
+
+// handleAdminPreviewFeedFilter handles POST /api/admin/feed/filters/preview,
+// showing what a filter would remove from the current feed without saving it
+func (api *AdminAPI) handleAdminPreviewFeedFilter(w http.ResponseWriter, r *http.Request) {
+	var params models.SaveFeedFilterParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	preview, err := api.feedFilterSvc.Preview(ctx, params)
+	if err != nil {
+		api.writeFeedFilterError(w, err, "failed to preview feed filter")
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	api.writeJSON(w, http.StatusOK, preview)
+}
+
+// handleAdminFeedFilterByID handles PUT/DELETE /api/admin/feed/filters/{id}
+func (api *AdminAPI) handleAdminFeedFilterByID(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, err := uuid.Parse(id); err != nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "feed filter not found"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	switch r.Method {
+	case http.MethodPut:
+		var params models.SaveFeedFilterParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+		filter, err := api.feedFilterSvc.Update(ctx, id, params)
+		if err != nil {
+			api.writeFeedFilterError(w, err, "failed to update feed filter")
+			return
+		}
+		if filter == nil {
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "feed filter not found"})
+			return
+		}
+		api.logger.Info("Admin updated feed filter",
+			logging.WithField("filterId", id),
+			logging.WithField("adminId", auth.GetUserID(r.Context())),
+		)
+		api.writeJSON(w, http.StatusOK, filter)
+	case http.MethodDelete:
+		if err := api.feedFilterSvc.Delete(ctx, id); err != nil {
+			api.writeFeedFilterError(w, err, "failed to delete feed filter")
+			return
+		}
+		api.logger.Info("Admin removed feed filter",
+			logging.WithField("filterId", id),
+			logging.WithField("adminId", auth.GetUserID(r.Context())),
+		)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// writeFeedFilterError maps feed filter errors to HTTP responses
+func (api *AdminAPI) writeFeedFilterError(w http.ResponseWriter, err error, message string) {
+	var svcErr *feedfilter.ServiceError
+	if errors.As(err, &svcErr) {
+		api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+		return
+	}
+	if errors.Is(err, database.ErrFeedFilterNotFound) {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "feed filter not found"})
+		return
+	}
+	api.logger.Error("Feed filter admin operation failed", logging.WithField("error", err.Error()))
+	api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": message})
+}
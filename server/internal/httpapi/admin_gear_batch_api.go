@@ -0,0 +1,277 @@
+package httpapi
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/images"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	// maxBatchImageFiles bounds the images in one batch upload, counting
+	// images inside zip archives.
+	maxBatchImageFiles = 100
+	// maxBatchImageBytes bounds the whole batch request body.
+	maxBatchImageBytes = 64 * 1024 * 1024
+	// maxGearImageBytes is the size limit for each image, as for single uploads.
+	maxGearImageBytes = 2 * 1024 * 1024
+)
+
+// batchImageFile is one image from a batch upload, named by its catalog ID
+type batchImageFile struct {
+	name string
+	data []byte
+	err  string // set when the file couldn't be read
+}
+
+// handleAdminGearImageBatch handles POST /api/admin/gear/images/batch. Every
+// multipart file (any field name) is an image named {catalogId}.jpg or .png,
+// or a zip of such images. Each image is moderated and set as its item's
+// primary image, after an optional ?background= cleanup. The response reports
+// each file; one bad file doesn't stop the rest.
+func (api *AdminAPI) handleAdminGearImageBatch(w http.ResponseWriter, r *http.Request) {
+	if api.imageSvc == nil {
+		api.writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "image moderation unavailable"})
+		return
+	}
+
+	background, ok := parseImageBackground(r)
+	if !ok {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "background must be white or transparent"})
+		return
+	}
+	if background != "" && !api.imageSvc.BackgroundRemovalEnabled() {
+		api.writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "background removal unavailable"})
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBatchImageBytes)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "multipart form required"})
+		return
+	}
+
+	files, err := readBatchImageFiles(reader)
+	if err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	if len(files) == 0 {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "no image files in request"})
+		return
+	}
+
+	userID := auth.GetUserID(r.Context())
+	resp := models.GearImageBatchResponse{Results: make([]models.GearImageBatchResult, 0, len(files))}
+	for _, file := range files {
+		result := api.attachBatchGearImage(r.Context(), userID, file, background)
+		if result.Status == models.GearImageBatchAttached {
+			resp.Attached++
+		} else {
+			resp.Failed++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	api.logger.Info("Admin batch uploaded gear images",
+		logging.WithField("adminId", userID),
+		logging.WithField("attached", resp.Attached),
+		logging.WithField("failed", resp.Failed),
+	)
+	api.writeJSON(w, http.StatusOK, resp)
+}
+
+// attachBatchGearImage moderates one batch image and sets it as the primary
+// image of the catalog item its file is named after
+func (api *AdminAPI) attachBatchGearImage(parent context.Context, userID string, file batchImageFile, background models.ImageBackground) models.GearImageBatchResult {
+	result := models.GearImageBatchResult{File: file.name, Status: models.GearImageBatchFailed}
+	if file.err != "" {
+		result.Error = file.err
+		return result
+	}
+
+	gearID := strings.TrimSuffix(path.Base(file.name), path.Ext(file.name))
+	if _, err := uuid.Parse(gearID); err != nil {
+		result.Error = "file name must be a catalog ID"
+		return result
+	}
+	result.GearID = gearID
+
+	ctx, cancel := context.WithTimeout(parent, 30*time.Second)
+	defer cancel()
+
+	item, err := api.catalogStore.Get(ctx, gearID)
+	if err != nil {
+		api.logger.Error("Failed to verify gear item", logging.WithField("error", err.Error()))
+		result.Error = "failed to verify gear item"
+		return result
+	}
+	if item == nil {
+		result.Error = "gear item not found"
+		return result
+	}
+	if api.claims != nil {
+		if _, err := api.claims.CheckClaim(ctx, models.ModerationQueueGear, gearID, userID); err != nil {
+			if errors.Is(err, database.ErrClaimedByOther) {
+				result.Error = "another moderator is working on this item"
+			} else {
+				result.Error = "failed to check moderation claim"
+			}
+			return result
+		}
+	}
+
+	if len(file.data) > maxGearImageBytes {
+		result.Error = "file too large. Maximum size is 2MB."
+		return result
+	}
+	if _, ok := detectAllowedImageContentType(file.data); !ok {
+		result.Error = "image must be JPEG or PNG"
+		return result
+	}
+
+	decision, asset, err := api.imageSvc.ModerateAndPersist(ctx, images.SaveRequest{
+		OwnerUserID: userID,
+		EntityType:  models.ImageEntityGear,
+		EntityID:    gearID,
+		ImageBytes:  file.data,
+		Background:  background,
+	})
+	if err != nil {
+		var quotaErr *images.QuotaExceededError
+		switch {
+		case errors.As(err, &quotaErr):
+			result.Error = quotaErr.Error()
+		case errors.Is(err, images.ErrInvalidImage):
+			result.Error = "image could not be read"
+		default:
+			if _, message, ok := imageBackgroundError(err); ok {
+				result.Error = message
+			} else {
+				api.logger.Error("Failed to moderate gear image", logging.WithField("error", err.Error()))
+				result.Error = "failed to moderate image"
+			}
+		}
+		return result
+	}
+	switch decision.Status {
+	case models.ImageModerationApproved:
+	case models.ImageModerationPendingReview:
+		result.Status = models.GearImageBatchPendingReview
+		result.Error = decision.Reason
+		return result
+	default:
+		result.Status = models.GearImageBatchRejected
+		result.Error = decision.Reason
+		return result
+	}
+
+	contentType, _ := detectAllowedImageContentType(asset.ImageBytes)
+	if err := api.attachAdminGearImageAsset(ctx, item, userID, contentType, asset.ID, nil); err != nil {
+		api.logger.Error("Failed to store gear image", logging.WithFields(map[string]interface{}{
+			"gearId": gearID,
+			"error":  err.Error(),
+		}))
+		result.Error = "failed to store image"
+		return result
+	}
+
+	result.Status = models.GearImageBatchAttached
+	return result
+}
+
+// readBatchImageFiles reads every file part of a batch upload, expanding zip
+// archives. Files over the size limit are kept with an error so they show up
+// in the report.
+func readBatchImageFiles(reader *multipart.Reader) ([]batchImageFile, error) {
+	files := make([]batchImageFile, 0)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.New("failed to read upload; the batch may exceed 64MB")
+		}
+		name := part.FileName()
+		if name == "" {
+			part.Close()
+			continue
+		}
+
+		if strings.EqualFold(path.Ext(name), ".zip") {
+			data, err := io.ReadAll(part)
+			part.Close()
+			if err != nil {
+				return nil, errors.New("failed to read upload; the batch may exceed 64MB")
+			}
+			entries, err := readBatchZip(name, data, maxBatchImageFiles-len(files))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, entries...)
+		} else {
+			files = append(files, readBatchImage(name, part))
+			part.Close()
+		}
+
+		if len(files) > maxBatchImageFiles {
+			return nil, fmt.Errorf("a batch can have at most %d images", maxBatchImageFiles)
+		}
+	}
+	return files, nil
+}
+
+// readBatchZip returns the images in a zip archive, failing when there are
+// more than limit. Directories, hidden files and macOS metadata are skipped.
+func readBatchZip(name string, data []byte, limit int) ([]batchImageFile, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid zip archive", name)
+	}
+
+	files := make([]batchImageFile, 0, len(archive.File))
+	for _, entry := range archive.File {
+		base := path.Base(entry.Name)
+		if entry.FileInfo().IsDir() || strings.HasPrefix(base, ".") || strings.HasPrefix(entry.Name, "__MACOSX/") {
+			continue
+		}
+		if len(files) >= limit {
+			return nil, fmt.Errorf("a batch can have at most %d images", maxBatchImageFiles)
+		}
+		rc, err := entry.Open()
+		if err != nil {
+			files = append(files, batchImageFile{name: entry.Name, err: "failed to read file from zip"})
+			continue
+		}
+		files = append(files, readBatchImage(entry.Name, rc))
+		rc.Close()
+	}
+	return files, nil
+}
+
+// readBatchImage reads one image, stopping just past the size limit so an
+// oversized file doesn't have to be read in full
+func readBatchImage(name string, r io.Reader) batchImageFile {
+	data, err := io.ReadAll(io.LimitReader(r, maxGearImageBytes+1))
+	if err != nil {
+		return batchImageFile{name: name, err: "failed to read file"}
+	}
+	return batchImageFile{name: name, data: data}
+}
@@ -0,0 +1,67 @@
+package httpapi
+
+import (
+	"archive/zip"
+	"bytes"
+	"mime/multipart"
+	"strings"
+	"testing"
+)
+
+func TestReadBatchImageFiles(t *testing.T) {
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	for _, name := range []string{"photos/aaa.jpg", "photos/.DS_Store", "__MACOSX/photos/._aaa.jpg", "bbb.png"} {
+		f, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("zip create: %v", err)
+		}
+		f.Write([]byte("image"))
+	}
+	zw.Close()
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, _ := mw.CreateFormFile("images", "ccc.jpg")
+	part.Write(bytes.Repeat([]byte{0xff}, maxGearImageBytes+10))
+	part, _ = mw.CreateFormFile("images", "photos.zip")
+	part.Write(archive.Bytes())
+	mw.WriteField("note", "ignored")
+	mw.Close()
+
+	files, err := readBatchImageFiles(multipart.NewReader(&body, mw.Boundary()))
+	if err != nil {
+		t.Fatalf("readBatchImageFiles() error = %v", err)
+	}
+
+	var names []string
+	for _, file := range files {
+		names = append(names, file.name)
+	}
+	if got := strings.Join(names, ","); got != "ccc.jpg,photos/aaa.jpg,bbb.png" {
+		t.Fatalf("files = %s, want the upload and the zip's images", got)
+	}
+	if len(files[0].data) != maxGearImageBytes+1 {
+		t.Errorf("oversized file read %d bytes, want reading to stop at %d", len(files[0].data), maxGearImageBytes+1)
+	}
+}
+
+func TestReadBatchImageFiles_TooMany(t *testing.T) {
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	for i := 0; i <= maxBatchImageFiles; i++ {
+		f, _ := zw.Create(strings.Repeat("a", i+1) + ".jpg")
+		f.Write([]byte("image"))
+	}
+	zw.Close()
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, _ := mw.CreateFormFile("images", "photos.zip")
+	part.Write(archive.Bytes())
+	mw.Close()
+
+	if _, err := readBatchImageFiles(multipart.NewReader(&body, mw.Boundary())); err == nil {
+		t.Fatal("expected an error for a batch over the file limit")
+	}
+}
@@ -0,0 +1,128 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/home"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// handleAdminHomeModules handles GET /api/admin/home-modules
+func (api *AdminAPI) handleAdminHomeModules(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := api.homeSvc.List(ctx)
+	if err != nil {
+		api.writeHomeModuleError(w, err, "failed to list home modules")
+		return
+	}
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+// handleAdminCreateHomeModule handles POST /api/admin/home-modules
+func (api *AdminAPI) handleAdminCreateHomeModule(w http.ResponseWriter, r *http.Request) {
+	var params models.SaveHomeModuleParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	module, err := api.homeSvc.Create(ctx, params)
+	if err != nil {
+		api.writeHomeModuleError(w, err, "failed to create home module")
+		return
+	}
+
+	api.logger.Info("Admin added home module",
+		logging.WithField("moduleId", module.ID),
+		logging.WithField("kind", module.Kind),
+		logging.WithField("adminId", auth.GetUserID(r.Context())),
+	)
+	api.writeJSON(w, http.StatusCreated, module)
+}
+
+// handleAdminHomeModuleByID handles GET/PUT/DELETE /api/admin/home-modules/{id}
+func (api *AdminAPI) handleAdminHomeModuleByID(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, err := uuid.Parse(id); err != nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "home module not found"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	switch r.Method {
+	case http.MethodGet:
+		module, err := api.homeSvc.Get(ctx, id)
+		if err != nil {
+			api.writeHomeModuleError(w, err, "failed to get home module")
+			return
+		}
+		if module == nil {
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "home module not found"})
+			return
+		}
+		api.writeJSON(w, http.StatusOK, module)
+	case http.MethodPut:
+		var params models.SaveHomeModuleParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+		module, err := api.homeSvc.Update(ctx, id, params)
+		if err != nil {
+			api.writeHomeModuleError(w, err, "failed to update home module")
+			return
+		}
+		if module == nil {
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "home module not found"})
+			return
+		}
+		api.logger.Info("Admin updated home module",
+			logging.WithField("moduleId", id),
+			logging.WithField("adminId", auth.GetUserID(r.Context())),
+		)
+		api.writeJSON(w, http.StatusOK, module)
+	case http.MethodDelete:
+		if err := api.homeSvc.Delete(ctx, id); err != nil {
+			api.writeHomeModuleError(w, err, "failed to delete home module")
+			return
+		}
+		api.logger.Info("Admin removed home module",
+			logging.WithField("moduleId", id),
+			logging.WithField("adminId", auth.GetUserID(r.Context())),
+		)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// writeHomeModuleError maps home module errors to HTTP responses
+func (api *AdminAPI) writeHomeModuleError(w http.ResponseWriter, err error, message string) {
+	var svcErr *home.ServiceError
+	if errors.As(err, &svcErr) {
+		api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+		return
+	}
+	if errors.Is(err, database.ErrHomeModuleNotFound) {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "home module not found"})
+		return
+	}
+	api.logger.Error("Home module admin operation failed", logging.WithField("error", err.Error()))
+	api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": message})
+}
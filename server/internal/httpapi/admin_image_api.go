@@ -0,0 +1,211 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/images"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// handleAdminImageRescan handles GET/POST/DELETE /api/admin/images/rescan
+func (api *AdminAPI) handleAdminImageRescan(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	adminID := auth.GetUserID(r.Context())
+
+	switch r.Method {
+	case http.MethodGet:
+		job, err := api.imageRescanner.Latest(ctx)
+		if err != nil {
+			api.logger.Error("Failed to get image rescan job", logging.WithField("error", err.Error()))
+			api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to get rescan job"})
+			return
+		}
+		if job == nil {
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "no rescan has run"})
+			return
+		}
+		api.writeJSON(w, http.StatusOK, job)
+	case http.MethodPost:
+		var params models.StartImageRescanParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil && !errors.Is(err, io.EOF) {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+
+		// A re-scan covers every tenant's images
+		job, err := api.imageRescanner.Start(database.AllTenants(ctx), adminID, params)
+		if err != nil {
+			switch {
+			case errors.Is(err, images.ErrInvalidEntityType):
+				api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			case errors.Is(err, images.ErrRescanRunning):
+				api.writeJSON(w, http.StatusConflict, map[string]string{"error": err.Error()})
+			default:
+				api.logger.Error("Failed to start image rescan", logging.WithField("error", err.Error()))
+				api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to start rescan"})
+			}
+			return
+		}
+
+		api.logger.Info("Admin started image rescan",
+			logging.WithField("jobId", job.ID),
+			logging.WithField("entityType", job.EntityType),
+			logging.WithField("adminId", adminID),
+		)
+		api.writeJSON(w, http.StatusAccepted, job)
+	case http.MethodDelete:
+		job, err := api.imageRescanner.Cancel(ctx)
+		if err != nil {
+			api.logger.Error("Failed to cancel image rescan", logging.WithField("error", err.Error()))
+			api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to cancel rescan"})
+			return
+		}
+		if job == nil {
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "no rescan is running"})
+			return
+		}
+
+		api.logger.Info("Admin cancelled image rescan",
+			logging.WithField("jobId", job.ID),
+			logging.WithField("adminId", adminID),
+		)
+		api.writeJSON(w, http.StatusOK, job)
+	default:
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// handleAdminImageReview handles GET /api/admin/images/review (flagged images)
+func (api *AdminAPI) handleAdminImageReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	query := r.URL.Query()
+	response, err := api.imageRescanner.ListReview(ctx, parseIntQuery(query.Get("limit"), 50), parseIntQuery(query.Get("offset"), 0))
+	if err != nil {
+		api.logger.Error("Failed to list image review queue", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to list images"})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+// handleAdminImageTiers handles GET /api/admin/images/tiers: image counts and
+// sizes per storage tier, and what the archiver has done since start
+func (api *AdminAPI) handleAdminImageTiers(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	status, err := api.imageSvc.ArchiveStatus(ctx)
+	if errors.Is(err, images.ErrArchiveUnavailable) {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		api.logger.Error("Failed to get image tier stats", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to get image tier stats"})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	api.writeJSON(w, http.StatusOK, status)
+}
+
+// handleAdminImageByID handles:
+// GET  /api/admin/images/{id}          - image bytes, whatever the moderation status
+// POST /api/admin/images/{id}/approve  - restore a flagged image
+// POST /api/admin/images/{id}/reject   - delete a flagged image
+func (api *AdminAPI) handleAdminImageByID(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/admin/images/")
+	parts := strings.Split(path, "/")
+	id := parts[0]
+	if _, err := uuid.Parse(id); err != nil || len(parts) > 2 {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "image not found"})
+		return
+	}
+
+	if len(parts) == 1 {
+		if r.Method != http.MethodGet {
+			api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+			return
+		}
+		api.getAdminImage(w, r, id)
+		return
+	}
+
+	action := parts[1]
+	if action != "approve" && action != "reject" {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+		return
+	}
+	if r.Method != http.MethodPost {
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	found, err := api.imageRescanner.ResolveReview(ctx, id, action == "approve")
+	if err != nil {
+		api.logger.Error("Failed to resolve image review", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to update image"})
+		return
+	}
+	if !found {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "image not in review queue"})
+		return
+	}
+
+	api.logger.Info("Admin resolved flagged image",
+		logging.WithField("imageId", id),
+		logging.WithField("action", action),
+		logging.WithField("adminId", auth.GetUserID(r.Context())),
+	)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *AdminAPI) getAdminImage(w http.ResponseWriter, r *http.Request, id string) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	asset, err := api.imageSvc.Load(ctx, id)
+	if err != nil {
+		api.logger.Error("Failed to load image for review", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to load image"})
+		return
+	}
+	if asset == nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "image not found"})
+		return
+	}
+
+	contentType, ok := detectAllowedImageContentType(asset.ImageBytes)
+	if !ok {
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		apierror.WriteStatus(w, http.StatusUnsupportedMediaType, "unsupported media type")
+		return
+	}
+	writeImage(w, r, nil, asset.ImageBytes, contentType, "private, no-store")
+}
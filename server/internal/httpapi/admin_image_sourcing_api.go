@@ -0,0 +1,146 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/imagesourcing"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// handleGearImageCandidates handles:
+// GET    /api/admin/gear/{id}/image-candidates                 - pending candidates
+// POST   /api/admin/gear/{id}/image-candidates                 - search sellers/manufacturer pages for images
+// POST   /api/admin/gear/{id}/image-candidates/{cid}/approve   - use a candidate as the item's image
+// DELETE /api/admin/gear/{id}/image-candidates/{cid}           - discard a candidate
+func (api *AdminAPI) handleGearImageCandidates(w http.ResponseWriter, r *http.Request, gearID string, rest string) {
+	if api.imageSourcing == nil {
+		api.writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "image sourcing unavailable"})
+		return
+	}
+
+	if rest == "" {
+		switch r.Method {
+		case http.MethodGet:
+			api.listGearImageCandidates(w, r, gearID)
+		case http.MethodPost:
+			api.findGearImageCandidates(w, r, gearID)
+		default:
+			api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		}
+		return
+	}
+
+	parts := strings.Split(rest, "/")
+	candidateID := parts[0]
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		api.resolveGearImageCandidate(w, r, gearID, candidateID, false)
+	case len(parts) == 2 && parts[1] == "approve" && r.Method == http.MethodPost:
+		api.resolveGearImageCandidate(w, r, gearID, candidateID, true)
+	case len(parts) > 2 || (len(parts) == 2 && parts[1] != "approve"):
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+	default:
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+func (api *AdminAPI) listGearImageCandidates(w http.ResponseWriter, r *http.Request, gearID string) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	candidates, err := api.imageSourcing.List(ctx, gearID)
+	if err != nil {
+		api.logger.Error("Failed to list gear image candidates", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to list image candidates"})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	api.writeJSON(w, http.StatusOK, map[string]interface{}{"candidates": candidates})
+}
+
+func (api *AdminAPI) findGearImageCandidates(w http.ResponseWriter, r *http.Request, gearID string) {
+	var params models.FindGearImagesParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil && !errors.Is(err, io.EOF) {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	// Searching sellers and downloading candidates is rate limited per host.
+	ctx, cancel := context.WithTimeout(r.Context(), 90*time.Second)
+	defer cancel()
+
+	adminID := auth.GetUserID(r.Context())
+	response, err := api.imageSourcing.Find(ctx, adminID, gearID, params)
+	if err != nil {
+		var svcErr *imagesourcing.ServiceError
+		switch {
+		case errors.Is(err, imagesourcing.ErrItemNotFound):
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "gear item not found"})
+		case errors.As(err, &svcErr):
+			api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+		default:
+			api.logger.Error("Failed to find gear image candidates", logging.WithField("error", err.Error()))
+			api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to find images"})
+		}
+		return
+	}
+
+	api.logger.Info("Admin searched for gear images",
+		logging.WithField("gearId", gearID),
+		logging.WithField("added", response.Added),
+		logging.WithField("rejected", response.Rejected),
+		logging.WithField("adminId", adminID),
+	)
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+func (api *AdminAPI) resolveGearImageCandidate(w http.ResponseWriter, r *http.Request, gearID, candidateID string, approve bool) {
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	adminID := auth.GetUserID(r.Context())
+
+	var err error
+	if approve {
+		err = api.imageSourcing.Approve(ctx, adminID, gearID, candidateID)
+	} else {
+		err = api.imageSourcing.Reject(ctx, gearID, candidateID)
+	}
+	if err != nil {
+		if errors.Is(err, imagesourcing.ErrCandidateNotFound) {
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "image candidate not found"})
+			return
+		}
+		api.logger.Error("Failed to resolve gear image candidate", logging.WithFields(map[string]interface{}{
+			"gearId":      gearID,
+			"candidateId": candidateID,
+			"error":       err.Error(),
+		}))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to update image candidate"})
+		return
+	}
+
+	api.logger.Info("Admin resolved gear image candidate",
+		logging.WithField("gearId", gearID),
+		logging.WithField("candidateId", candidateID),
+		logging.WithField("approved", approve),
+		logging.WithField("adminId", adminID),
+	)
+	if approve {
+		api.writeJSON(w, http.StatusOK, map[string]string{
+			"status":  string(models.ImageStatusApproved),
+			"message": "Image approved",
+		})
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,79 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/inactivity"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// handleAdminInactivityPolicy handles GET /api/admin/inactivity/policy
+func (api *AdminAPI) handleAdminInactivityPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	policy, err := api.inactivitySvc.Policy(ctx)
+	if err != nil {
+		api.writeInactivityError(w, err, "failed to get inactivity policy")
+		return
+	}
+	api.writeJSON(w, http.StatusOK, policy)
+}
+
+// handleAdminUpdateInactivityPolicy handles PUT /api/admin/inactivity/policy
+func (api *AdminAPI) handleAdminUpdateInactivityPolicy(w http.ResponseWriter, r *http.Request) {
+	var params models.InactivityPolicy
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	adminID := auth.GetUserID(r.Context())
+	policy, err := api.inactivitySvc.UpdatePolicy(ctx, adminID, params)
+	if err != nil {
+		api.writeInactivityError(w, err, "failed to save inactivity policy")
+		return
+	}
+
+	api.logger.Info("Admin updated inactivity policy",
+		logging.WithField("enabled", policy.Enabled),
+		logging.WithField("action", policy.Action),
+		logging.WithField("adminId", adminID),
+	)
+	api.writeJSON(w, http.StatusOK, policy)
+}
+
+// handleAdminInactivityDryRun handles GET /api/admin/inactivity/dry-run,
+// listing the accounts the next run would flag and act on
+func (api *AdminAPI) handleAdminInactivityDryRun(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	result, err := api.inactivitySvc.Run(ctx, time.Now(), true)
+	if err != nil {
+		api.writeInactivityError(w, err, "failed to run inactivity dry run")
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	api.writeJSON(w, http.StatusOK, result)
+}
+
+// writeInactivityError maps inactivity errors to HTTP responses
+func (api *AdminAPI) writeInactivityError(w http.ResponseWriter, err error, message string) {
+	var svcErr *inactivity.ServiceError
+	if errors.As(err, &svcErr) {
+		api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+		return
+	}
+	api.logger.Error("Inactivity admin operation failed", logging.WithField("error", err.Error()))
+	api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": message})
+}
@@ -0,0 +1,74 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/linkcheck"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+)
+
+// handleAdminDeadLinks handles GET /api/admin/links/dead?limit=&offset=
+func (api *AdminAPI) handleAdminDeadLinks(w http.ResponseWriter, r *http.Request) {
+	limit := parseIntQuery(r.URL.Query().Get("limit"), 50)
+	if limit < 1 || limit > 200 {
+		limit = 50
+	}
+	offset := parseIntQuery(r.URL.Query().Get("offset"), 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	// Suggestions search the seller adapters, which can be slow
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	report, err := api.linkCheckSvc.Report(ctx, limit, offset)
+	if err != nil {
+		api.logger.Error("Failed to build dead link report", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to list dead links"})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	api.writeJSON(w, http.StatusOK, report)
+}
+
+// handleAdminCheckLink handles POST /api/admin/links/check, checking one
+// link now, e.g. after it was fixed at the source
+func (api *AdminAPI) handleAdminCheckLink(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		URL string `json:"url"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	result, err := api.linkCheckSvc.CheckNow(ctx, body.URL)
+	if err != nil {
+		var svcErr *linkcheck.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+			return
+		}
+		api.logger.Error("Failed to record link check", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to check link"})
+		return
+	}
+
+	api.logger.Info("Admin checked link",
+		logging.WithField("url", body.URL),
+		logging.WithField("ok", result.OK),
+		logging.WithField("adminId", auth.GetUserID(r.Context())),
+	)
+
+	api.writeJSON(w, http.StatusOK, result)
+}
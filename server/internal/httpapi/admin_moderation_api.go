@@ -0,0 +1,139 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/moderation"
+)
+
+// handleAdminModerationPolicies handles GET /api/admin/moderation/policies
+func (api *AdminAPI) handleAdminModerationPolicies(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	policies, err := api.policies.List(ctx)
+	if err != nil {
+		api.logger.Error("Failed to list moderation policies", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to list moderation policies"})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	api.writeJSON(w, http.StatusOK, map[string]interface{}{"policies": policies})
+}
+
+// handleAdminModerationPolicyByType handles:
+// PUT    /api/admin/moderation/policies/{entityType} - set the policy
+// DELETE /api/admin/moderation/policies/{entityType} - revert to the default
+func (api *AdminAPI) handleAdminModerationPolicyByType(w http.ResponseWriter, r *http.Request) {
+	entityType := models.ImageEntityType(strings.TrimPrefix(r.URL.Path, "/api/admin/moderation/policies/"))
+	if !models.IsValidImageEntityType(entityType) {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown entity type"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	adminID := auth.GetUserID(r.Context())
+
+	switch r.Method {
+	case http.MethodPut:
+		var params models.SetModerationPolicyParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+			return
+		}
+
+		policy, err := api.policies.Set(ctx, adminID, entityType, params)
+		if err != nil {
+			var policyErr *moderation.PolicyError
+			if errors.As(err, &policyErr) {
+				api.writeJSON(w, http.StatusBadRequest, errorBody(policyErr))
+				return
+			}
+			api.logger.Error("Failed to save moderation policy", logging.WithField("error", err.Error()))
+			api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to save moderation policy"})
+			return
+		}
+
+		api.logger.Info("Admin updated moderation policy",
+			logging.WithField("entityType", entityType),
+			logging.WithField("rejectConfidence", policy.RejectConfidence),
+			logging.WithField("adminId", adminID),
+		)
+		api.writeJSON(w, http.StatusOK, policy)
+	case http.MethodDelete:
+		if err := api.policies.Reset(ctx, entityType); err != nil {
+			if errors.Is(err, database.ErrModerationPolicyNotFound) {
+				api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "no custom policy for entity type"})
+				return
+			}
+			api.logger.Error("Failed to reset moderation policy", logging.WithField("error", err.Error()))
+			api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to reset moderation policy"})
+			return
+		}
+
+		api.logger.Info("Admin reset moderation policy",
+			logging.WithField("entityType", entityType),
+			logging.WithField("adminId", adminID),
+		)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// handleAdminModerationAging handles GET /api/admin/moderation/aging. It
+// reports items waiting past their queue's SLA. ?queue= limits it to gear or
+// build, and ?gearSla= or ?buildSla= (e.g. 24h) override the configured SLA.
+func (api *AdminAPI) handleAdminModerationAging(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	queue := models.ModerationQueue(strings.ToLower(strings.TrimSpace(query.Get("queue"))))
+	if queue != "" && queue != models.ModerationQueueGear && queue != models.ModerationQueueBuild {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "queue must be gear or build"})
+		return
+	}
+
+	overrides := make(map[models.ModerationQueue]time.Duration)
+	for param, q := range map[string]models.ModerationQueue{"gearSla": models.ModerationQueueGear, "buildSla": models.ModerationQueueBuild} {
+		v := query.Get(param)
+		if v == "" {
+			continue
+		}
+		sla, err := time.ParseDuration(v)
+		if err != nil || sla <= 0 {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": param + " must be a positive duration such as 24h"})
+			return
+		}
+		overrides[q] = sla
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	report, err := api.sla.Report(ctx, queue, overrides, time.Now())
+	if err != nil {
+		api.logger.Error("Failed to build moderation aging report", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to build moderation aging report"})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	api.writeJSON(w, http.StatusOK, report)
+}
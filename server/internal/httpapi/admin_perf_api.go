@@ -0,0 +1,55 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// defaultPerfLimit and maxPerfLimit bound how many statements the slow
+// query report lists
+const (
+	defaultPerfLimit = 20
+	maxPerfLimit     = 100
+)
+
+// handleAdminPerf handles GET /api/admin/perf. ?sort= is total (default),
+// p95, max or calls; ?limit= caps the number of statements.
+func (api *AdminAPI) handleAdminPerf(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	sortBy := models.QueryStatsSortTotal
+	if raw := query.Get("sort"); raw != "" {
+		sortBy = models.QueryStatsSort(raw)
+		if !sortBy.IsValid() {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "sort must be total, p95, max or calls"})
+			return
+		}
+	}
+	limit := parseIntQuery(query.Get("limit"), defaultPerfLimit)
+	if limit <= 0 {
+		limit = defaultPerfLimit
+	}
+	if limit > maxPerfLimit {
+		limit = maxPerfLimit
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	api.writeJSON(w, http.StatusOK, api.queryStats.Top(limit, sortBy))
+}
+
+// handleAdminResetPerf handles DELETE /api/admin/perf, starting a fresh
+// measurement window
+func (api *AdminAPI) handleAdminResetPerf(w http.ResponseWriter, r *http.Request) {
+	api.queryStats.Reset()
+	api.logger.Info("Admin reset query stats", logging.WithField("adminId", auth.GetUserID(r.Context())))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleAdminDB handles GET /api/admin/db, the connection pool view: each
+// pool's sql.DBStats, the wait alert threshold and recent alerts
+func (api *AdminAPI) handleAdminDB(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	api.writeJSON(w, http.StatusOK, api.dbPool.Report())
+}
@@ -0,0 +1,67 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/policies"
+)
+
+// handleAdminPolicies handles GET /api/admin/policies, every published
+// policy version
+func (api *AdminAPI) handleAdminPolicies(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	versions, err := api.policySvc.List(ctx)
+	if err != nil {
+		api.writePolicyError(w, err, "failed to list policy versions")
+		return
+	}
+	api.writeJSON(w, http.StatusOK, models.PolicyVersionListResponse{Versions: versions})
+}
+
+// handleAdminPublishPolicy handles POST /api/admin/policies. The new version
+// must be accepted by every user before their next write.
+func (api *AdminAPI) handleAdminPublishPolicy(w http.ResponseWriter, r *http.Request) {
+	var params models.PublishPolicyVersionParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	adminID := auth.GetUserID(r.Context())
+	version, err := api.policySvc.Publish(ctx, adminID, params)
+	if err != nil {
+		api.writePolicyError(w, err, "failed to publish policy version")
+		return
+	}
+
+	api.logger.Info("Admin published policy version",
+		logging.WithField("policyVersionId", version.ID),
+		logging.WithField("kind", version.Kind),
+		logging.WithField("version", version.Version),
+		logging.WithField("adminId", adminID),
+	)
+	api.writeJSON(w, http.StatusCreated, version)
+}
+
+// writePolicyError maps policy service errors to HTTP responses
+func (api *AdminAPI) writePolicyError(w http.ResponseWriter, err error, message string) {
+	var svcErr *policies.ServiceError
+	if errors.As(err, &svcErr) {
+		api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+		return
+	}
+	api.logger.Error("Policy admin operation failed", logging.WithField("error", err.Error()))
+	api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": message})
+}
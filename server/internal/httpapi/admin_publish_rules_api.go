@@ -0,0 +1,141 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/catalogrules"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// handleAdminPublishRules handles GET /api/admin/gear/publish-rules
+func (api *AdminAPI) handleAdminPublishRules(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	rules, err := api.publishRules.List(ctx)
+	if err != nil {
+		api.logger.Error("Failed to list publish rules", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to list publish rules"})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	api.writeJSON(w, http.StatusOK, map[string]interface{}{"rules": rules})
+}
+
+// handleAdminCreatePublishRule handles POST /api/admin/gear/publish-rules
+func (api *AdminAPI) handleAdminCreatePublishRule(w http.ResponseWriter, r *http.Request) {
+	var params models.SetCatalogPublishRuleParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	adminID := auth.GetUserID(r.Context())
+	rule, err := api.publishRules.Create(ctx, adminID, params)
+	if err != nil {
+		api.writePublishRuleError(w, err, "failed to create publish rule")
+		return
+	}
+
+	api.logger.Info("Admin created publish rule",
+		logging.WithField("ruleId", rule.ID),
+		logging.WithField("name", rule.Name),
+		logging.WithField("adminId", adminID),
+	)
+	api.writeJSON(w, http.StatusCreated, rule)
+}
+
+// handleAdminUpdatePublishRule handles PUT /api/admin/gear/publish-rules/{id}
+func (api *AdminAPI) handleAdminUpdatePublishRule(w http.ResponseWriter, r *http.Request) {
+	var params models.SetCatalogPublishRuleParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	adminID := auth.GetUserID(r.Context())
+	rule, err := api.publishRules.Update(ctx, r.PathValue("id"), adminID, params)
+	if err != nil {
+		api.writePublishRuleError(w, err, "failed to update publish rule")
+		return
+	}
+
+	api.logger.Info("Admin updated publish rule",
+		logging.WithField("ruleId", rule.ID),
+		logging.WithField("enabled", rule.Enabled),
+		logging.WithField("adminId", adminID),
+	)
+	api.writeJSON(w, http.StatusOK, rule)
+}
+
+// handleAdminDeletePublishRule handles DELETE /api/admin/gear/publish-rules/{id}
+func (api *AdminAPI) handleAdminDeletePublishRule(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	id := r.PathValue("id")
+	if err := api.publishRules.Delete(ctx, id); err != nil {
+		api.writePublishRuleError(w, err, "failed to delete publish rule")
+		return
+	}
+
+	api.logger.Info("Admin deleted publish rule",
+		logging.WithField("ruleId", id),
+		logging.WithField("adminId", auth.GetUserID(r.Context())),
+	)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleAdminPublishDecisions handles GET /api/admin/gear/publish-rules/decisions,
+// the log of which rule published which submission
+func (api *AdminAPI) handleAdminPublishDecisions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit := parseIntQuery(query.Get("limit"), 50)
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	offset := parseIntQuery(query.Get("offset"), 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	decisions, err := api.publishRules.Decisions(ctx, limit, offset)
+	if err != nil {
+		api.logger.Error("Failed to list publish decisions", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to list publish decisions"})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	api.writeJSON(w, http.StatusOK, map[string]interface{}{"decisions": decisions})
+}
+
+func (api *AdminAPI) writePublishRuleError(w http.ResponseWriter, err error, message string) {
+	var ruleErr *catalogrules.RuleError
+	switch {
+	case errors.As(err, &ruleErr):
+		api.writeJSON(w, http.StatusBadRequest, errorBody(ruleErr))
+	case errors.Is(err, database.ErrPublishRuleNotFound):
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "publish rule not found"})
+	default:
+		api.logger.Error("Publish rule change failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": message})
+	}
+}
@@ -0,0 +1,65 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// defaultAdminSearchLimit and maxAdminSearchLimit bound how many results of
+// each type the admin search returns
+const (
+	defaultAdminSearchLimit = 5
+	maxAdminSearchLimit     = 25
+)
+
+// handleAdminSearch handles GET /api/admin/search?q=. It searches users,
+// catalog items, builds and orders in one call; ?types= narrows it to a
+// comma separated list of user, catalog_item, build and order, and ?limit=
+// caps the results per type.
+func (api *AdminAPI) handleAdminSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	q := strings.TrimSpace(query.Get("q"))
+	if utf8.RuneCountInString(q) < 2 {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "q must be at least 2 characters"})
+		return
+	}
+
+	var types []models.AdminSearchType
+	if raw := strings.TrimSpace(query.Get("types")); raw != "" {
+		for _, part := range strings.Split(raw, ",") {
+			t := models.AdminSearchType(strings.TrimSpace(part))
+			if !t.IsValid() {
+				api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "types must be user, catalog_item, build or order"})
+				return
+			}
+			types = append(types, t)
+		}
+	}
+
+	limit := parseIntQuery(query.Get("limit"), defaultAdminSearchLimit)
+	if limit <= 0 {
+		limit = defaultAdminSearchLimit
+	}
+	if limit > maxAdminSearchLimit {
+		limit = maxAdminSearchLimit
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	response, err := api.adminSearch.Search(ctx, q, types, limit)
+	if err != nil {
+		api.logger.Error("Admin search failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to search"})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	api.writeJSON(w, http.StatusOK, response)
+}
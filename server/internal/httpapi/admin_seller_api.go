@@ -0,0 +1,18 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// handleAdminSellerHealth handles GET /api/admin/sellers/health
+func (api *AdminAPI) handleAdminSellerHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	api.writeJSON(w, http.StatusOK, models.SellerHealthResponse{Sellers: api.equipmentSvc.SellerHealth()})
+}
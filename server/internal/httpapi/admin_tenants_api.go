@@ -0,0 +1,100 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/tenancy"
+)
+
+// handleAdminTenants handles GET /api/admin/tenants
+func (api *AdminAPI) handleAdminTenants(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	tenants, err := api.tenancySvc.List(ctx)
+	if err != nil {
+		api.writeTenantError(w, err, "failed to list tenants")
+		return
+	}
+	api.writeJSON(w, http.StatusOK, tenants)
+}
+
+// handleAdminCreateTenant handles POST /api/admin/tenants
+func (api *AdminAPI) handleAdminCreateTenant(w http.ResponseWriter, r *http.Request) {
+	var params models.SaveTenantParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	tenant, err := api.tenancySvc.Create(ctx, params)
+	if err != nil {
+		api.writeTenantError(w, err, "failed to create tenant")
+		return
+	}
+
+	api.logger.Info("Admin created tenant",
+		logging.WithField("tenantId", tenant.ID),
+		logging.WithField("slug", tenant.Slug),
+		logging.WithField("adminId", auth.GetUserID(r.Context())),
+	)
+	api.writeJSON(w, http.StatusCreated, tenant)
+}
+
+// handleAdminUpdateTenant handles PUT /api/admin/tenants/{id}
+func (api *AdminAPI) handleAdminUpdateTenant(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, err := uuid.Parse(id); err != nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "tenant not found"})
+		return
+	}
+
+	var params models.SaveTenantParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	tenant, err := api.tenancySvc.Update(ctx, id, params)
+	if err != nil {
+		api.writeTenantError(w, err, "failed to update tenant")
+		return
+	}
+	if tenant == nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "tenant not found"})
+		return
+	}
+
+	api.logger.Info("Admin updated tenant",
+		logging.WithField("tenantId", tenant.ID),
+		logging.WithField("slug", tenant.Slug),
+		logging.WithField("adminId", auth.GetUserID(r.Context())),
+	)
+	api.writeJSON(w, http.StatusOK, tenant)
+}
+
+// writeTenantError maps tenancy service errors to HTTP responses
+func (api *AdminAPI) writeTenantError(w http.ResponseWriter, err error, message string) {
+	var svcErr *tenancy.ServiceError
+	if errors.As(err, &svcErr) {
+		api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+		return
+	}
+	api.logger.Error("Tenant admin operation failed", logging.WithField("error", err.Error()))
+	api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": message})
+}
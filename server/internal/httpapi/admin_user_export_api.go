@@ -0,0 +1,118 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// userExportColumns is the header row of the user export
+var userExportColumns = []string{
+	"id", "email", "display_name", "call_sign", "role", "status", "created_at", "last_login_at",
+	"catalog_submissions", "catalog_published", "published_builds", "approved_gear_images",
+}
+
+// handleAdminUsersExport handles GET /api/admin/users/export?format=csv. It
+// takes the same query and status filters as GET /api/admin/users and
+// streams every matching user.
+func (api *AdminAPI) handleAdminUsersExport(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if format := strings.TrimSpace(query.Get("format")); format != "" && format != "csv" {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "format must be csv"})
+		return
+	}
+
+	status := models.UserStatus(strings.TrimSpace(query.Get("status")))
+	if status != "" && !models.IsValidUserStatus(status) {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid status filter"})
+		return
+	}
+	params := models.UserFilterParams{
+		Query:  strings.TrimSpace(query.Get("query")),
+		Status: status,
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Minute)
+	defer cancel()
+
+	// Headers go out with the first row, so a query that fails up front
+	// still gets a JSON error
+	cw := csv.NewWriter(w)
+	started := false
+	start := func() error {
+		if started {
+			return nil
+		}
+		started = true
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="users-%s.csv"`, time.Now().UTC().Format("20060102")))
+		w.Header().Set("Cache-Control", "no-store")
+		return cw.Write(userExportColumns)
+	}
+
+	count := 0
+	err := api.userStore.Export(ctx, params, func(row models.UserExportRow) error {
+		if err := start(); err != nil {
+			return err
+		}
+		count++
+		return cw.Write(userExportRecord(row))
+	})
+	if err != nil && !started {
+		api.logger.Error("Failed to export users", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to export users"})
+		return
+	}
+	if err == nil {
+		err = start()
+	}
+	cw.Flush()
+	if err != nil {
+		// Rows were already sent, so the client sees a truncated file
+		api.logger.Error("Failed to export users", logging.WithField("error", err.Error()))
+		return
+	}
+
+	api.logger.Info("Admin exported users",
+		logging.WithField("adminId", auth.GetUserID(r.Context())),
+		logging.WithField("count", count),
+	)
+}
+
+func userExportRecord(row models.UserExportRow) []string {
+	lastLogin := ""
+	if row.LastLoginAt != nil {
+		lastLogin = row.LastLoginAt.UTC().Format(time.RFC3339)
+	}
+	return []string{
+		row.ID,
+		csvCell(row.Email),
+		csvCell(row.DisplayName),
+		csvCell(row.CallSign),
+		string(row.Role),
+		string(row.Status),
+		row.CreatedAt.UTC().Format(time.RFC3339),
+		lastLogin,
+		strconv.Itoa(row.CatalogSubmissions),
+		strconv.Itoa(row.CatalogPublished),
+		strconv.Itoa(row.PublishedBuilds),
+		strconv.Itoa(row.ApprovedGearImages),
+	}
+}
+
+// csvCell stops user-entered text from being read as a formula when the
+// export is opened in a spreadsheet
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,85 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// handleAdminUserDuplicates handles GET /api/admin/users/duplicates
+func (api *AdminAPI) handleAdminUserDuplicates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	candidates, err := api.userStore.FindDuplicateUsers(ctx)
+	if err != nil {
+		api.logger.Error("Failed to find duplicate users", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to find duplicate users"})
+		return
+	}
+	api.writeJSON(w, http.StatusOK, map[string]interface{}{"candidates": candidates})
+}
+
+// handleAdminUsersMerge handles POST /api/admin/users/merge.
+// Moves the source account's content to the target and disables the source.
+func (api *AdminAPI) handleAdminUsersMerge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		api.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	var params models.MergeUsersParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+	params.Normalize()
+	if params.SourceUserID == "" || params.TargetUserID == "" || params.ConfirmSourceEmail == "" {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "sourceUserId, targetUserId and confirmSourceEmail are required"})
+		return
+	}
+
+	adminID := auth.GetUserID(r.Context())
+	if params.SourceUserID == adminID {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "cannot merge your own account away"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
+
+	result, err := api.userStore.MergeUsers(ctx, params, adminID)
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrMergeUserNotFound):
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+		case errors.Is(err, database.ErrMergeSameUser), errors.Is(err, database.ErrMergeNotConfirmed):
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case errors.Is(err, database.ErrMergeAdminSource), errors.Is(err, database.ErrMergeDisabledTarget):
+			api.writeJSON(w, http.StatusConflict, map[string]string{"error": err.Error()})
+		default:
+			api.logger.Error("Failed to merge users", logging.WithField("error", err.Error()))
+			api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to merge users"})
+		}
+		return
+	}
+
+	api.logger.Info("Admin merged users",
+		logging.WithField("sourceUserId", result.SourceUserID),
+		logging.WithField("targetUserId", result.TargetUserID),
+		logging.WithField("adminId", adminID),
+	)
+	api.writeJSON(w, http.StatusOK, result)
+}
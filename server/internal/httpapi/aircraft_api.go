@@ -3,6 +3,7 @@ package httpapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/johnrirwin/flyingforge/internal/aircraft"
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/auth"
 	"github.com/johnrirwin/flyingforge/internal/images"
 	"github.com/johnrirwin/flyingforge/internal/logging"
@@ -19,24 +21,30 @@ import (
 // AircraftAPI handles HTTP API requests for aircraft management
 type AircraftAPI struct {
 	aircraftSvc    *aircraft.Service
+	imageSvc       *images.Service
 	authMiddleware *auth.Middleware
 	logger         *logging.Logger
 }
 
 // NewAircraftAPI creates a new aircraft API handler
-func NewAircraftAPI(aircraftSvc *aircraft.Service, authMiddleware *auth.Middleware, logger *logging.Logger) *AircraftAPI {
+func NewAircraftAPI(aircraftSvc *aircraft.Service, imageSvc *images.Service, authMiddleware *auth.Middleware, logger *logging.Logger) *AircraftAPI {
 	return &AircraftAPI{
 		aircraftSvc:    aircraftSvc,
+		imageSvc:       imageSvc,
 		authMiddleware: authMiddleware,
 		logger:         logger,
 	}
 }
 
-// RegisterRoutes registers aircraft routes on the given mux
-func (api *AircraftAPI) RegisterRoutes(mux *http.ServeMux, corsMiddleware func(http.HandlerFunc) http.HandlerFunc) {
-	// Aircraft routes (require authentication)
-	mux.HandleFunc("/api/aircraft", corsMiddleware(api.authMiddleware.RequireAuth(api.handleAircraft)))
-	mux.HandleFunc("/api/aircraft/", corsMiddleware(api.authMiddleware.RequireAuth(api.handleAircraftItem)))
+// Routes returns the aircraft route table
+func (api *AircraftAPI) Routes() []Route {
+	return []Route{
+		// Aircraft routes (require authentication)
+		{Pattern: "/api/aircraft", Access: AccessUser, Handler: api.handleAircraft},
+		{Pattern: "/api/aircraft/expirations", Access: AccessUser, Handler: api.handleExpirations},
+		{Pattern: "/api/aircraft/from-build/", Access: AccessUser, Handler: api.handleFromBuild},
+		{Pattern: "/api/aircraft/", Access: AccessUser, Handler: api.handleAircraftItem},
+	}
 }
 
 // handleAircraft handles list and create operations
@@ -47,7 +55,7 @@ func (api *AircraftAPI) handleAircraft(w http.ResponseWriter, r *http.Request) {
 	case http.MethodPost:
 		api.createAircraft(w, r)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -95,7 +103,7 @@ func (api *AircraftAPI) createAircraft(w http.ResponseWriter, r *http.Request) {
 	var params models.CreateAircraftParams
 
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
@@ -114,6 +122,42 @@ func (api *AircraftAPI) createAircraft(w http.ResponseWriter, r *http.Request) {
 	api.writeJSON(w, http.StatusCreated, aircraft)
 }
 
+// handleFromBuild creates an aircraft from one of the user's builds or a published build
+func (api *AircraftAPI) handleFromBuild(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := auth.GetUserID(r.Context())
+	buildID := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/aircraft/from-build/"), "/")
+	if buildID == "" || strings.Contains(buildID, "/") {
+		apierror.WriteStatus(w, http.StatusBadRequest, "Build ID required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	response, err := api.aircraftSvc.CreateFromBuild(ctx, userID, buildID)
+	if err != nil {
+		api.logger.Error("Create aircraft from build failed", logging.WithFields(map[string]interface{}{
+			"build_id": buildID,
+			"error":    err.Error(),
+		}))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "failed to create aircraft from build",
+		})
+		return
+	}
+	if response == nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "build not found"})
+		return
+	}
+
+	api.writeJSON(w, http.StatusCreated, response)
+}
+
 // handleAircraftItem handles single aircraft operations (get, update, delete, components, receiver)
 func (api *AircraftAPI) handleAircraftItem(w http.ResponseWriter, r *http.Request) {
 	// Extract path after /api/aircraft/
@@ -121,7 +165,7 @@ func (api *AircraftAPI) handleAircraftItem(w http.ResponseWriter, r *http.Reques
 	parts := strings.Split(path, "/")
 
 	if len(parts) == 0 || parts[0] == "" {
-		http.Error(w, "Aircraft ID required", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Aircraft ID required")
 		return
 	}
 
@@ -136,6 +180,9 @@ func (api *AircraftAPI) handleAircraftItem(w http.ResponseWriter, r *http.Reques
 		case "receiver":
 			api.handleReceiver(w, r, aircraftID)
 			return
+		case "registration":
+			api.handleRegistration(w, r, aircraftID)
+			return
 		case "details":
 			api.getAircraftDetails(w, r, aircraftID)
 			return
@@ -143,7 +190,7 @@ func (api *AircraftAPI) handleAircraftItem(w http.ResponseWriter, r *http.Reques
 			api.handleImage(w, r, aircraftID)
 			return
 		default:
-			http.Error(w, "Unknown resource", http.StatusNotFound)
+			apierror.WriteStatus(w, http.StatusNotFound, "Unknown resource")
 			return
 		}
 	}
@@ -157,7 +204,7 @@ func (api *AircraftAPI) handleAircraftItem(w http.ResponseWriter, r *http.Reques
 	case http.MethodDelete:
 		api.deleteAircraft(w, r, aircraftID)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -181,7 +228,7 @@ func (api *AircraftAPI) getAircraft(w http.ResponseWriter, r *http.Request, id s
 	}
 
 	if aircraft == nil {
-		http.Error(w, "Aircraft not found", http.StatusNotFound)
+		apierror.WriteStatus(w, http.StatusNotFound, "Aircraft not found")
 		return
 	}
 
@@ -191,7 +238,7 @@ func (api *AircraftAPI) getAircraft(w http.ResponseWriter, r *http.Request, id s
 // getAircraftDetails retrieves full aircraft details including components and receiver settings
 func (api *AircraftAPI) getAircraftDetails(w http.ResponseWriter, r *http.Request, id string) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -213,7 +260,7 @@ func (api *AircraftAPI) getAircraftDetails(w http.ResponseWriter, r *http.Reques
 	}
 
 	if details == nil || details.Aircraft.ID == "" {
-		http.Error(w, "Aircraft not found", http.StatusNotFound)
+		apierror.WriteStatus(w, http.StatusNotFound, "Aircraft not found")
 		return
 	}
 
@@ -227,7 +274,7 @@ func (api *AircraftAPI) updateAircraft(w http.ResponseWriter, r *http.Request, i
 	var params models.UpdateAircraftParams
 
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 	params.ID = id
@@ -281,7 +328,7 @@ func (api *AircraftAPI) handleComponents(w http.ResponseWriter, r *http.Request,
 	case http.MethodDelete:
 		api.removeComponent(w, r, aircraftID)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -317,7 +364,7 @@ func (api *AircraftAPI) setComponent(w http.ResponseWriter, r *http.Request, air
 	var params models.SetComponentParams
 
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 	params.AircraftID = aircraftID
@@ -353,7 +400,7 @@ func (api *AircraftAPI) removeComponent(w http.ResponseWriter, r *http.Request,
 
 	category := r.URL.Query().Get("category")
 	if category == "" {
-		http.Error(w, "Category required", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Category required")
 		return
 	}
 
@@ -390,7 +437,7 @@ func (api *AircraftAPI) handleReceiver(w http.ResponseWriter, r *http.Request, a
 	case http.MethodPost, http.MethodPut:
 		api.setReceiverSettings(w, r, aircraftID)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -432,7 +479,7 @@ func (api *AircraftAPI) setReceiverSettings(w http.ResponseWriter, r *http.Reque
 	var params models.SetReceiverSettingsParams
 
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 	params.AircraftID = aircraftID
@@ -455,6 +502,91 @@ func (api *AircraftAPI) setReceiverSettings(w http.ResponseWriter, r *http.Reque
 	api.writeJSON(w, http.StatusOK, settings)
 }
 
+// handleRegistration handles registration, insurance, and remote ID details
+func (api *AircraftAPI) handleRegistration(w http.ResponseWriter, r *http.Request, aircraftID string) {
+	userID := auth.GetUserID(r.Context())
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	var (
+		registration *models.AircraftRegistration
+		err          error
+	)
+	switch r.Method {
+	case http.MethodGet:
+		registration, err = api.aircraftSvc.GetRegistration(ctx, aircraftID, userID)
+	case http.MethodPut:
+		var params models.SetAircraftRegistrationParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		params.AircraftID = aircraftID
+		registration, err = api.aircraftSvc.SetRegistration(ctx, userID, params)
+	default:
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	if err != nil {
+		var svcErr *aircraft.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+			return
+		}
+		api.logger.Error("Aircraft registration request failed", logging.WithFields(map[string]interface{}{
+			"aircraft_id": aircraftID,
+			"error":       err.Error(),
+		}))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "failed to load aircraft registration",
+		})
+		return
+	}
+
+	if registration == nil {
+		// No details recorded yet
+		api.writeJSON(w, http.StatusOK, models.AircraftRegistration{AircraftID: aircraftID})
+		return
+	}
+
+	api.writeJSON(w, http.StatusOK, registration)
+}
+
+// handleExpirations lists upcoming and past registration and insurance expirations
+func (api *AircraftAPI) handleExpirations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := auth.GetUserID(r.Context())
+	days := 0
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "days must be a positive integer"})
+			return
+		}
+		days = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	response, err := api.aircraftSvc.ListExpirations(ctx, userID, days, time.Now())
+	if err != nil {
+		api.logger.Error("List aircraft expirations failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "failed to list expirations",
+		})
+		return
+	}
+
+	api.writeJSON(w, http.StatusOK, response)
+}
+
 // handleImage handles image upload, retrieval, and deletion
 func (api *AircraftAPI) handleImage(w http.ResponseWriter, r *http.Request, aircraftID string) {
 	switch r.Method {
@@ -465,7 +597,7 @@ func (api *AircraftAPI) handleImage(w http.ResponseWriter, r *http.Request, airc
 	case http.MethodDelete:
 		api.deleteImage(w, r, aircraftID)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -500,6 +632,9 @@ func (api *AircraftAPI) uploadImage(w http.ResponseWriter, r *http.Request, airc
 			UploadID:   req.UploadID,
 		})
 		if err != nil {
+			if writeImageUploadError(w, err) {
+				return
+			}
 			switch err {
 			case images.ErrPendingUploadNotFound:
 				api.writeJSON(w, http.StatusUnprocessableEntity, map[string]string{
@@ -546,14 +681,14 @@ func (api *AircraftAPI) uploadImage(w http.ResponseWriter, r *http.Request, airc
 	// Parse multipart form
 	if err := r.ParseMultipartForm(3 * 1024 * 1024); err != nil {
 		api.logger.Error("Failed to parse multipart form", logging.WithField("error", err.Error()))
-		http.Error(w, "File too large or invalid form", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "File too large or invalid form")
 		return
 	}
 
 	file, _, err := r.FormFile("image")
 	if err != nil {
 		api.logger.Error("Failed to get image from form", logging.WithField("error", err.Error()))
-		http.Error(w, "Image file required", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Image file required")
 		return
 	}
 	defer file.Close()
@@ -562,7 +697,7 @@ func (api *AircraftAPI) uploadImage(w http.ResponseWriter, r *http.Request, airc
 	imageData, err := io.ReadAll(file)
 	if err != nil {
 		api.logger.Error("Failed to read image data", logging.WithField("error", err.Error()))
-		http.Error(w, "Failed to read image", http.StatusInternalServerError)
+		apierror.WriteStatus(w, http.StatusInternalServerError, "Failed to read image")
 		return
 	}
 	if len(imageData) > 2*1024*1024 {
@@ -573,7 +708,7 @@ func (api *AircraftAPI) uploadImage(w http.ResponseWriter, r *http.Request, airc
 	}
 	detectedContentType, ok := detectAllowedImageContentType(imageData)
 	if !ok {
-		http.Error(w, "Image must be JPEG or PNG", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Image must be JPEG or PNG")
 		return
 	}
 
@@ -588,6 +723,9 @@ func (api *AircraftAPI) uploadImage(w http.ResponseWriter, r *http.Request, airc
 
 	decision, err := api.aircraftSvc.SetImage(ctx, userID, params)
 	if err != nil {
+		if writeImageUploadError(w, err) {
+			return
+		}
 		api.logger.Error("Failed to set aircraft image", logging.WithFields(map[string]interface{}{
 			"aircraft_id": aircraftID,
 			"error":       err.Error(),
@@ -629,19 +767,16 @@ func (api *AircraftAPI) getImage(w http.ResponseWriter, r *http.Request, aircraf
 			"aircraft_id": aircraftID,
 			"error":       err.Error(),
 		}))
-		http.Error(w, "Image not found", http.StatusNotFound)
+		apierror.WriteStatus(w, http.StatusNotFound, "Image not found")
 		return
 	}
 
 	if imageData == nil {
-		http.Error(w, "No image for this aircraft", http.StatusNotFound)
+		apierror.WriteStatus(w, http.StatusNotFound, "No image for this aircraft")
 		return
 	}
 
-	w.Header().Set("Content-Type", imageType)
-	w.Header().Set("Cache-Control", "private, max-age=3600")
-	w.WriteHeader(http.StatusOK)
-	w.Write(imageData)
+	writeImage(w, r, api.imageSvc, imageData, imageType, "private, max-age=3600")
 }
 
 // deleteImage removes an aircraft's image
@@ -668,7 +803,5 @@ func (api *AircraftAPI) deleteImage(w http.ResponseWriter, r *http.Request, airc
 }
 
 func (api *AircraftAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+	writeJSON(w, status, data)
 }
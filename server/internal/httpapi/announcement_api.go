@@ -0,0 +1,103 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/announcements"
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+)
+
+// AnnouncementAPI serves release notes and outage notices, and tracks which
+// ones a signed-in user has read
+type AnnouncementAPI struct {
+	announcementSvc *announcements.Service
+	logger          *logging.Logger
+}
+
+// NewAnnouncementAPI creates a new announcement API handler
+func NewAnnouncementAPI(announcementSvc *announcements.Service, logger *logging.Logger) *AnnouncementAPI {
+	return &AnnouncementAPI{
+		announcementSvc: announcementSvc,
+		logger:          logger,
+	}
+}
+
+// Routes returns the announcement route table
+func (api *AnnouncementAPI) Routes() []Route {
+	return []Route{
+		// Public, with read state for signed-in users
+		{Method: http.MethodGet, Pattern: "/api/announcements", Access: AccessOptional, Handler: api.handleAnnouncements},
+		{Method: http.MethodPost, Pattern: "/api/announcements/read-all", Access: AccessUser, Handler: api.handleMarkAllRead},
+		{Method: http.MethodPost, Pattern: "/api/announcements/{id}/read", Access: AccessUser, Handler: api.handleMarkRead},
+	}
+}
+
+// handleAnnouncements handles GET /api/announcements?limit=N&offset=N
+func (api *AnnouncementAPI) handleAnnouncements(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	userID := auth.GetUserID(r.Context())
+	query := r.URL.Query()
+	response, err := api.announcementSvc.Active(ctx, userID, parseIntQuery(query.Get("limit"), 0), parseIntQuery(query.Get("offset"), 0))
+	if err != nil {
+		api.logger.Error("List announcements failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to load announcements"})
+		return
+	}
+
+	// Read state is per user, so only anonymous responses can be shared
+	if userID == "" {
+		w.Header().Set("Cache-Control", "public, max-age=60")
+	} else {
+		w.Header().Set("Cache-Control", "private, no-store")
+	}
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+// handleMarkRead handles POST /api/announcements/{id}/read
+func (api *AnnouncementAPI) handleMarkRead(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	id := r.PathValue("id")
+	if _, err := uuid.Parse(id); err != nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "announcement not found"})
+		return
+	}
+	if err := api.announcementSvc.MarkRead(ctx, id, auth.GetUserID(r.Context())); err != nil {
+		if errors.Is(err, database.ErrAnnouncementNotFound) {
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "announcement not found"})
+			return
+		}
+		api.logger.Error("Mark announcement read failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to mark announcement read"})
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleMarkAllRead handles POST /api/announcements/read-all
+func (api *AnnouncementAPI) handleMarkAllRead(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	marked, err := api.announcementSvc.MarkAllRead(ctx, auth.GetUserID(r.Context()))
+	if err != nil {
+		api.logger.Error("Mark announcements read failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to mark announcements read"})
+		return
+	}
+	api.writeJSON(w, http.StatusOK, map[string]int{"marked": marked})
+}
+
+func (api *AnnouncementAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
@@ -0,0 +1,150 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/appeals"
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// AppealAPI takes appeals from disabled and suspended users and serves the
+// admin appeal queue
+type AppealAPI struct {
+	appealSvc *appeals.Service
+	logger    *logging.Logger
+}
+
+// NewAppealAPI creates a new appeal API handler
+func NewAppealAPI(appealSvc *appeals.Service, logger *logging.Logger) *AppealAPI {
+	return &AppealAPI{
+		appealSvc: appealSvc,
+		logger:    logger,
+	}
+}
+
+// Routes returns the appeal route table. Submitting is public: the appeal
+// token from the refused sign-in identifies the account.
+func (api *AppealAPI) Routes() []Route {
+	return []Route{
+		{Method: http.MethodPost, Pattern: "/api/auth/appeals", Access: AccessPublic, Handler: api.handleSubmitAppeal},
+		{Method: http.MethodGet, Pattern: "/api/admin/appeals", Access: AccessAdmin, Handler: api.handleListAppeals},
+		{Method: http.MethodPost, Pattern: "/api/admin/appeals/{id}/resolve", Access: AccessAdmin, Handler: api.handleResolveAppeal},
+	}
+}
+
+// handleSubmitAppeal handles POST /api/auth/appeals
+func (api *AppealAPI) handleSubmitAppeal(w http.ResponseWriter, r *http.Request) {
+	var params models.SubmitAppealParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	appeal, err := api.appealSvc.Submit(ctx, params)
+	if err != nil {
+		api.writeAppealError(w, err, "failed to submit appeal")
+		return
+	}
+	api.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"id":        appeal.ID,
+		"status":    appeal.Status,
+		"createdAt": appeal.CreatedAt,
+	})
+}
+
+// handleListAppeals handles GET /api/admin/appeals
+func (api *AppealAPI) handleListAppeals(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	params := models.AppealListParams{Status: models.AppealStatus(query.Get("status"))}
+	if params.Status == "" {
+		params.Status = models.AppealStatusOpen
+	} else if params.Status == "all" {
+		params.Status = ""
+	}
+	if v := query.Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			params.Limit = n
+		}
+	}
+	if v := query.Get("offset"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			params.Offset = n
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := api.appealSvc.List(ctx, params)
+	if err != nil {
+		api.writeAppealError(w, err, "failed to list appeals")
+		return
+	}
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+// handleResolveAppeal handles POST /api/admin/appeals/{id}/resolve
+func (api *AppealAPI) handleResolveAppeal(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, err := uuid.Parse(id); err != nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "appeal not found"})
+		return
+	}
+
+	var params models.ResolveAppealParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	adminID := auth.GetUserID(r.Context())
+	appeal, err := api.appealSvc.Resolve(ctx, id, adminID, params)
+	if err != nil {
+		api.writeAppealError(w, err, "failed to resolve appeal")
+		return
+	}
+
+	api.logger.Info("Admin resolved account appeal",
+		logging.WithField("appealId", appeal.ID),
+		logging.WithField("targetUserId", appeal.UserID),
+		logging.WithField("decision", appeal.Status),
+		logging.WithField("adminId", adminID),
+	)
+	api.writeJSON(w, http.StatusOK, appeal)
+}
+
+// writeAppealError maps appeal errors to HTTP responses
+func (api *AppealAPI) writeAppealError(w http.ResponseWriter, err error, message string) {
+	var svcErr *appeals.ServiceError
+	if errors.As(err, &svcErr) {
+		api.writeJSON(w, apierror.StatusOf(svcErr, http.StatusBadRequest), errorBody(svcErr))
+		return
+	}
+	var authErr *auth.AuthError
+	if errors.As(err, &authErr) {
+		apierror.Write(w, apierror.Status(authErr.Code), authErr.Code, authErr.Message)
+		return
+	}
+	api.logger.Error("Appeal operation failed", logging.WithField("error", err.Error()))
+	api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": message})
+}
+
+func (api *AppealAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
@@ -2,16 +2,25 @@ package httpapi
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/auth"
 	"github.com/johnrirwin/flyingforge/internal/logging"
 	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/publicurl"
 )
 
+// handoffStatePrefix marks an OAuth state that carries a temp build handoff
+// token through the Google redirect
+const handoffStatePrefix = "handoff:"
+
 // AuthAPI handles authentication HTTP endpoints
 type AuthAPI struct {
 	authService    *auth.Service
@@ -20,9 +29,13 @@ type AuthAPI struct {
 	frontendURL    string
 }
 
-// NewAuthAPI creates a new auth API handler
-func NewAuthAPI(authService *auth.Service, authMiddleware *auth.Middleware, logger *logging.Logger) *AuthAPI {
+// NewAuthAPI creates a new auth API handler. Sign-in redirects go to
+// AUTH_FRONTEND_URL, falling back to the configured public base URL.
+func NewAuthAPI(authService *auth.Service, authMiddleware *auth.Middleware, publicURL *publicurl.Resolver, logger *logging.Logger) *AuthAPI {
 	frontendURL := os.Getenv("AUTH_FRONTEND_URL")
+	if frontendURL == "" {
+		frontendURL = publicURL.Configured()
+	}
 	if frontendURL == "" {
 		frontendURL = "http://localhost:3000"
 	}
@@ -34,39 +47,46 @@ func NewAuthAPI(authService *auth.Service, authMiddleware *auth.Middleware, logg
 	}
 }
 
-// RegisterRoutes registers auth routes on the given mux
-func (api *AuthAPI) RegisterRoutes(mux *http.ServeMux, corsMiddleware func(http.HandlerFunc) http.HandlerFunc) {
-	mux.HandleFunc("/api/auth/google", corsMiddleware(api.handleGoogleLogin))
-	mux.HandleFunc("/api/auth/google/callback", api.handleGoogleCallback)
-	mux.HandleFunc("/api/auth/refresh", corsMiddleware(api.handleRefresh))
-	mux.HandleFunc("/api/auth/logout", corsMiddleware(api.authMiddleware.RequireAuth(api.handleLogout)))
-	mux.HandleFunc("/api/auth/me", corsMiddleware(api.authMiddleware.RequireAuth(api.handleGetMe)))
+// Routes returns the auth route table
+func (api *AuthAPI) Routes() []Route {
+	return []Route{
+		{Pattern: "/api/auth/google", Access: AccessPublic, Handler: api.handleGoogleLogin},
+		{Pattern: "/api/auth/google/callback", Access: AccessPublic, NoCORS: true, Handler: api.handleGoogleCallback},
+		{Pattern: "/api/auth/refresh", Access: AccessPublic, Handler: api.handleRefresh},
+		{Pattern: "/api/auth/logout", Access: AccessUser, PolicyExempt: true, Handler: api.handleLogout},
+		{Pattern: "/api/auth/me", Access: AccessUser, Handler: api.handleGetMe},
+		{Method: http.MethodGet, Pattern: "/api/admin/auth/keys", Access: AccessAdmin, Platform: true, Handler: api.handleListSigningKeys},
+		{Method: http.MethodPost, Pattern: "/api/admin/auth/keys/rotate", Access: AccessAdmin, Platform: true, Handler: api.handleRotateSigningKey},
+	}
 }
 
 func (api *AuthAPI) handleGoogleLogin(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	var params models.GoogleLoginParams
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		api.writeError(w, http.StatusBadRequest, "invalid_request", "invalid request body")
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "invalid request body")
 		return
 	}
 
 	response, err := api.authService.LoginWithGoogle(r.Context(), params)
 	if err != nil {
 		if authErr, ok := err.(*auth.AuthError); ok {
-			status := http.StatusUnauthorized
-			if authErr.Code == "account_disabled" {
-				status = http.StatusForbidden
+			if authErr.Account != nil {
+				api.writeJSON(w, apierror.Status(authErr.Code), accountStatusErrorBody{
+					Body:    apierror.Body{Code: authErr.Code, Error: authErr.Message, Message: authErr.Message},
+					Account: authErr.Account,
+				})
+				return
 			}
-			api.writeError(w, status, authErr.Code, authErr.Message)
+			api.writeError(w, apierror.Status(authErr.Code), authErr.Code, authErr.Message)
 			return
 		}
 		api.logger.Error("Google login failed", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "google login failed")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "google login failed")
 		return
 	}
 
@@ -75,7 +95,7 @@ func (api *AuthAPI) handleGoogleLogin(w http.ResponseWriter, r *http.Request) {
 
 func (api *AuthAPI) handleRefresh(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -83,12 +103,12 @@ func (api *AuthAPI) handleRefresh(w http.ResponseWriter, r *http.Request) {
 		RefreshToken string `json:"refreshToken"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		api.writeError(w, http.StatusBadRequest, "invalid_request", "invalid request body")
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "invalid request body")
 		return
 	}
 
 	if params.RefreshToken == "" {
-		api.writeError(w, http.StatusBadRequest, "invalid_request", "refresh token is required")
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "refresh token is required")
 		return
 	}
 
@@ -99,7 +119,7 @@ func (api *AuthAPI) handleRefresh(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		api.logger.Error("Token refresh failed", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "refresh failed")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "refresh failed")
 		return
 	}
 
@@ -108,19 +128,19 @@ func (api *AuthAPI) handleRefresh(w http.ResponseWriter, r *http.Request) {
 
 func (api *AuthAPI) handleLogout(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	userID := auth.GetUserID(r.Context())
 	if userID == "" {
-		api.writeError(w, http.StatusUnauthorized, "unauthorized", "not authenticated")
+		api.writeError(w, http.StatusUnauthorized, apierror.Unauthorized, "not authenticated")
 		return
 	}
 
 	if err := api.authService.Logout(r.Context(), userID); err != nil {
 		api.logger.Error("Logout failed", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "logout failed")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "logout failed")
 		return
 	}
 
@@ -129,25 +149,25 @@ func (api *AuthAPI) handleLogout(w http.ResponseWriter, r *http.Request) {
 
 func (api *AuthAPI) handleGetMe(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	userID := auth.GetUserID(r.Context())
 	if userID == "" {
-		api.writeError(w, http.StatusUnauthorized, "unauthorized", "not authenticated")
+		api.writeError(w, http.StatusUnauthorized, apierror.Unauthorized, "not authenticated")
 		return
 	}
 
 	user, err := api.authService.GetUser(r.Context(), userID)
 	if err != nil {
 		api.logger.Error("Failed to get user", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to get user")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to get user")
 		return
 	}
 
 	if user == nil {
-		api.writeError(w, http.StatusNotFound, "not_found", "user not found")
+		api.writeError(w, http.StatusNotFound, apierror.NotFound, "user not found")
 		return
 	}
 
@@ -173,7 +193,7 @@ func (api *AuthAPI) handleGetMe(w http.ResponseWriter, r *http.Request) {
 
 func (api *AuthAPI) handleGoogleCallback(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -196,10 +216,23 @@ func (api *AuthAPI) handleGoogleCallback(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// A temp build handoff token survives the redirect through Google in
+	// the OAuth state
+	var handoffToken string
+	if state := r.URL.Query().Get("state"); strings.HasPrefix(state, handoffStatePrefix) {
+		handoffToken = strings.TrimPrefix(state, handoffStatePrefix)
+	}
+
 	// Exchange code for tokens and authenticate user
 	response, err := api.authService.LoginWithGoogle(r.Context(), models.GoogleLoginParams{
-		Code: code,
+		Code:         code,
+		HandoffToken: handoffToken,
 	})
+	var authErr *auth.AuthError
+	if errors.As(err, &authErr) && authErr.Account != nil {
+		http.Redirect(w, r, api.accountStatusRedirect(authErr.Account), http.StatusFound)
+		return
+	}
 	if err != nil {
 		api.logger.Error("Google callback failed", logging.WithField("error", err.Error()))
 		redirectURL := fmt.Sprintf("%s/login?error=auth_failed&error_description=%s",
@@ -217,17 +250,68 @@ func (api *AuthAPI) handleGoogleCallback(w http.ResponseWriter, r *http.Request)
 	http.Redirect(w, r, redirectURL, http.StatusFound)
 }
 
+// accountStatusErrorBody is the error response for a refused sign-in of a
+// disabled or suspended account
+type accountStatusErrorBody struct {
+	apierror.Body
+	Account *models.AccountStatusNotice `json:"account"`
+}
+
+// accountStatusRedirect sends a disabled or suspended user back to the login
+// page with the reason and expiry in the query. The appeal token goes in the
+// URL fragment so it stays out of server logs.
+func (api *AuthAPI) accountStatusRedirect(notice *models.AccountStatusNotice) string {
+	query := url.Values{}
+	query.Set("error", "account_"+string(notice.Status))
+	if notice.Reason != "" {
+		query.Set("error_description", notice.Reason)
+	}
+	if notice.ExpiresAt != nil {
+		query.Set("expires_at", notice.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	redirectURL := fmt.Sprintf("%s/login?%s", api.frontendURL, query.Encode())
+	if notice.AppealToken != "" {
+		redirectURL += "#appeal_token=" + url.QueryEscape(notice.AppealToken)
+	}
+	return redirectURL
+}
+
+func (api *AuthAPI) handleListSigningKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := api.authService.SigningKeys(r.Context())
+	if err != nil {
+		api.logger.Error("Failed to list JWT signing keys", logging.WithField("error", err.Error()))
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to list signing keys")
+		return
+	}
+	api.writeJSON(w, http.StatusOK, map[string]interface{}{"keys": keys})
+}
+
+// handleRotateSigningKey makes a new key current. Tokens signed with the
+// previous key stay valid until they expire.
+func (api *AuthAPI) handleRotateSigningKey(w http.ResponseWriter, r *http.Request) {
+	key, err := api.authService.RotateSigningKey(r.Context())
+	if errors.Is(err, auth.ErrNoKeyStore) {
+		api.writeError(w, http.StatusConflict, apierror.NotConfigured, "signing keys can only be rotated when BIND_PHRASE_ENCRYPTION_KEY is set")
+		return
+	}
+	if err != nil {
+		api.logger.Error("Failed to rotate JWT signing key", logging.WithField("error", err.Error()))
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to rotate signing key")
+		return
+	}
+
+	userID := auth.GetUserID(r.Context())
+	api.logger.Info("JWT signing key rotated by admin", logging.WithFields(map[string]interface{}{
+		"kid":     key.KID,
+		"adminId": userID,
+	}))
+	api.writeJSON(w, http.StatusOK, key)
+}
+
 func (api *AuthAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+	writeJSON(w, status, data)
 }
 
-func (api *AuthAPI) writeError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]string{
-		"error":   code,
-		"message": message,
-	})
+func (api *AuthAPI) writeError(w http.ResponseWriter, status int, code apierror.Code, message string) {
+	apierror.Write(w, status, code, message)
 }
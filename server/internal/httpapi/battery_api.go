@@ -2,12 +2,15 @@ package httpapi
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/auth"
 	"github.com/johnrirwin/flyingforge/internal/battery"
 	"github.com/johnrirwin/flyingforge/internal/logging"
@@ -30,11 +33,13 @@ func NewBatteryAPI(batterySvc *battery.Service, authMiddleware *auth.Middleware,
 	}
 }
 
-// RegisterRoutes registers battery routes on the given mux
-func (api *BatteryAPI) RegisterRoutes(mux *http.ServeMux, corsMiddleware func(http.HandlerFunc) http.HandlerFunc) {
-	// Battery routes (require authentication)
-	mux.HandleFunc("/api/batteries", corsMiddleware(api.authMiddleware.RequireAuth(api.handleBatteries)))
-	mux.HandleFunc("/api/batteries/", corsMiddleware(api.authMiddleware.RequireAuth(api.handleBatteryItem)))
+// Routes returns the battery route table
+func (api *BatteryAPI) Routes() []Route {
+	return []Route{
+		// Battery routes (require authentication)
+		{Pattern: "/api/batteries", Access: AccessUser, Handler: api.handleBatteries},
+		{Pattern: "/api/batteries/", Access: AccessUser, Handler: api.handleBatteryItem},
+	}
 }
 
 // handleBatteries handles list and create operations
@@ -45,7 +50,7 @@ func (api *BatteryAPI) handleBatteries(w http.ResponseWriter, r *http.Request) {
 	case http.MethodPost:
 		api.createBattery(w, r)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -110,7 +115,7 @@ func (api *BatteryAPI) createBattery(w http.ResponseWriter, r *http.Request) {
 
 	var params models.CreateBatteryParams
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
@@ -130,7 +135,7 @@ func (api *BatteryAPI) handleBatteryItem(w http.ResponseWriter, r *http.Request)
 	parts := strings.Split(path, "/")
 
 	if len(parts) == 0 || parts[0] == "" {
-		http.Error(w, "Battery ID required", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Battery ID required")
 		return
 	}
 
@@ -154,8 +159,11 @@ func (api *BatteryAPI) handleBatteryItem(w http.ResponseWriter, r *http.Request)
 		case "details":
 			api.getBatteryDetails(w, r, batteryID)
 			return
+		case "charged":
+			api.handleCharged(w, r, batteryID)
+			return
 		default:
-			http.Error(w, "Unknown resource", http.StatusNotFound)
+			apierror.WriteStatus(w, http.StatusNotFound, "Unknown resource")
 			return
 		}
 	}
@@ -169,7 +177,7 @@ func (api *BatteryAPI) handleBatteryItem(w http.ResponseWriter, r *http.Request)
 	case http.MethodDelete:
 		api.deleteBattery(w, r, batteryID)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -188,7 +196,7 @@ func (api *BatteryAPI) getBattery(w http.ResponseWriter, r *http.Request, id str
 	}
 
 	if battery == nil {
-		http.Error(w, "Battery not found", http.StatusNotFound)
+		apierror.WriteStatus(w, http.StatusNotFound, "Battery not found")
 		return
 	}
 
@@ -198,7 +206,7 @@ func (api *BatteryAPI) getBattery(w http.ResponseWriter, r *http.Request, id str
 // getBatteryDetails retrieves full battery details including logs
 func (api *BatteryAPI) getBatteryDetails(w http.ResponseWriter, r *http.Request, id string) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -212,7 +220,7 @@ func (api *BatteryAPI) getBatteryDetails(w http.ResponseWriter, r *http.Request,
 	}
 
 	if details == nil {
-		http.Error(w, "Battery not found", http.StatusNotFound)
+		apierror.WriteStatus(w, http.StatusNotFound, "Battery not found")
 		return
 	}
 
@@ -225,7 +233,7 @@ func (api *BatteryAPI) updateBattery(w http.ResponseWriter, r *http.Request, id
 
 	var params models.UpdateBatteryParams
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 	params.ID = id
@@ -261,7 +269,7 @@ func (api *BatteryAPI) handleLogs(w http.ResponseWriter, r *http.Request, batter
 	case http.MethodPost:
 		api.createLog(w, r, batteryID)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -292,7 +300,7 @@ func (api *BatteryAPI) createLog(w http.ResponseWriter, r *http.Request, battery
 
 	var params models.CreateBatteryLogParams
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 	params.BatteryID = batteryID
@@ -313,7 +321,7 @@ func (api *BatteryAPI) handleLogItem(w http.ResponseWriter, r *http.Request, bat
 	case http.MethodDelete:
 		api.deleteLog(w, r, logID)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -330,10 +338,53 @@ func (api *BatteryAPI) deleteLog(w http.ResponseWriter, r *http.Request, logID s
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// handleCharged marks a battery as charged for a session (POST) or clears
+// its charged state (DELETE)
+func (api *BatteryAPI) handleCharged(w http.ResponseWriter, r *http.Request, batteryID string) {
+	userID := auth.GetUserID(r.Context())
+
+	var result *models.Battery
+	var err error
+	switch r.Method {
+	case http.MethodPost:
+		var params models.MarkBatteryChargedParams
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+				apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
+				return
+			}
+		}
+		params.BatteryID = batteryID
+		result, err = api.batterySvc.MarkCharged(r.Context(), userID, params, time.Now())
+	case http.MethodDelete:
+		result, err = api.batterySvc.ClearCharged(r.Context(), batteryID, userID, time.Now())
+	default:
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	if err != nil {
+		var svcErr *battery.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeJSON(w, apierror.StatusOf(svcErr, http.StatusBadRequest), errorBody(svcErr))
+			return
+		}
+		api.logger.Error("Update battery charged state failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	if result == nil {
+		apierror.WriteStatus(w, http.StatusNotFound, "Battery not found")
+		return
+	}
+
+	api.writeJSON(w, http.StatusOK, result)
+}
+
 // handleLabel generates a printable label for a battery
 func (api *BatteryAPI) handleLabel(w http.ResponseWriter, r *http.Request, batteryID string) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -347,7 +398,7 @@ func (api *BatteryAPI) handleLabel(w http.ResponseWriter, r *http.Request, batte
 	}
 
 	if battery == nil {
-		http.Error(w, "Battery not found", http.StatusNotFound)
+		apierror.WriteStatus(w, http.StatusNotFound, "Battery not found")
 		return
 	}
 
@@ -646,7 +697,5 @@ func (api *BatteryAPI) generateLabelHTML(b *models.Battery, size string) string
 
 // writeJSON writes a JSON response
 func (api *BatteryAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+	writeJSON(w, status, data)
 }
@@ -0,0 +1,191 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/blackbox"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// BlackboxAPI handles blackbox log uploads, summaries and downloads
+type BlackboxAPI struct {
+	blackboxSvc *blackbox.Service
+	logger      *logging.Logger
+}
+
+// NewBlackboxAPI creates a new blackbox API handler
+func NewBlackboxAPI(blackboxSvc *blackbox.Service, logger *logging.Logger) *BlackboxAPI {
+	return &BlackboxAPI{
+		blackboxSvc: blackboxSvc,
+		logger:      logger,
+	}
+}
+
+// Routes returns the blackbox route table
+func (api *BlackboxAPI) Routes() []Route {
+	return []Route{
+		{Method: http.MethodGet, Pattern: "/api/blackbox-logs", Access: AccessUser, Handler: api.handleList},
+		{Method: http.MethodPost, Pattern: "/api/blackbox-logs", Access: AccessUser, Handler: api.handleCreate},
+		{Method: http.MethodGet, Pattern: "/api/blackbox-logs/{id}", Access: AccessUser, Handler: api.handleGet},
+		{Method: http.MethodDelete, Pattern: "/api/blackbox-logs/{id}", Access: AccessUser, Handler: api.handleDelete},
+		{Method: http.MethodGet, Pattern: "/api/blackbox-logs/{id}/download", Access: AccessUser, Handler: api.handleDownload},
+	}
+}
+
+// handleList handles GET /api/blackbox-logs, optionally filtered by
+// ?aircraftId=
+func (api *BlackboxAPI) handleList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	params := models.BlackboxLogListParams{AircraftID: query.Get("aircraftId")}
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 {
+		params.Limit = limit
+	}
+	if offset, err := strconv.Atoi(query.Get("offset")); err == nil && offset >= 0 {
+		params.Offset = offset
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := api.blackboxSvc.List(ctx, auth.GetUserID(r.Context()), params)
+	if err != nil {
+		api.writeError(w, "Failed to list blackbox logs", err)
+		return
+	}
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+// handleCreate handles POST /api/blackbox-logs. The multipart body is parsed
+// as it streams to storage, so the optional aircraftId, notes and checksum
+// (SHA-256 hex) fields must come before the file part.
+func (api *BlackboxAPI) handleCreate(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, blackbox.MaxFileSize+1024*1024)
+	extendDeadlines(w, backupTransferTimeout)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Failed to parse form: " + err.Error()})
+		return
+	}
+
+	params := models.CreateBlackboxLogParams{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "file is required"})
+			return
+		}
+		if err != nil {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Failed to parse form: " + err.Error()})
+			return
+		}
+
+		switch part.FormName() {
+		case "aircraftId", "notes", "checksum":
+			value, err := io.ReadAll(io.LimitReader(part, 4096))
+			if err != nil {
+				api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Failed to parse form: " + err.Error()})
+				return
+			}
+			switch part.FormName() {
+			case "aircraftId":
+				params.AircraftID = strings.TrimSpace(string(value))
+			case "notes":
+				params.Notes = string(value)
+			case "checksum":
+				params.Checksum = strings.TrimSpace(string(value))
+			}
+		case "file":
+			params.FileName = part.FileName()
+			ctx, cancel := context.WithTimeout(r.Context(), backupTransferTimeout)
+			defer cancel()
+
+			log, err := api.blackboxSvc.Create(ctx, auth.GetUserID(r.Context()), params, part)
+			if err != nil {
+				api.writeError(w, "Failed to create blackbox log", err)
+				return
+			}
+			api.writeJSON(w, http.StatusCreated, log)
+			return
+		}
+		part.Close()
+	}
+}
+
+// handleGet handles GET /api/blackbox-logs/{id}
+func (api *BlackboxAPI) handleGet(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	log, err := api.blackboxSvc.Get(ctx, r.PathValue("id"), auth.GetUserID(r.Context()))
+	if err != nil {
+		api.writeError(w, "Failed to get blackbox log", err)
+		return
+	}
+	if log == nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "blackbox log not found"})
+		return
+	}
+	api.writeJSON(w, http.StatusOK, log)
+}
+
+// handleDelete handles DELETE /api/blackbox-logs/{id}
+func (api *BlackboxAPI) handleDelete(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := api.blackboxSvc.Delete(ctx, r.PathValue("id"), auth.GetUserID(r.Context())); err != nil {
+		api.writeError(w, "Failed to delete blackbox log", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleDownload handles GET /api/blackbox-logs/{id}/download. Range
+// requests are honoured so interrupted downloads can resume; the ETag is
+// the file's SHA-256.
+func (api *BlackboxAPI) handleDownload(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), backupTransferTimeout)
+	defer cancel()
+	extendDeadlines(w, backupTransferTimeout)
+
+	file, log, err := api.blackboxSvc.GetFile(ctx, r.PathValue("id"), auth.GetUserID(r.Context()))
+	if err != nil {
+		api.writeError(w, "Failed to open blackbox file", err)
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": log.FileName}))
+	w.Header().Set("ETag", `"`+log.Checksum+`"`)
+
+	http.ServeContent(w, r, "", log.CreatedAt, file)
+}
+
+// writeError maps service errors to responses, logging unexpected ones
+func (api *BlackboxAPI) writeError(w http.ResponseWriter, logMessage string, err error) {
+	var svcErr *blackbox.ServiceError
+	switch {
+	case errors.As(err, &svcErr):
+		api.writeJSON(w, apierror.StatusOf(svcErr, http.StatusBadRequest), errorBody(svcErr))
+	default:
+		api.logger.Error(logMessage, logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal server error"})
+	}
+}
+
+// writeJSON writes a JSON response
+func (api *BlackboxAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
@@ -1,64 +1,86 @@
 package httpapi
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/auth"
 	"github.com/johnrirwin/flyingforge/internal/builds"
 	"github.com/johnrirwin/flyingforge/internal/images"
 	"github.com/johnrirwin/flyingforge/internal/logging"
 	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/publicurl"
 	"github.com/johnrirwin/flyingforge/internal/ratelimit"
+	"github.com/johnrirwin/flyingforge/internal/views"
 )
 
 // BuildAPI handles public, temporary, and authenticated build endpoints.
 type BuildAPI struct {
 	service         *builds.Service
+	imageSvc        *images.Service
 	authMiddleware  *auth.Middleware
 	tempRateLimiter ratelimit.RateLimiter
+	viewSvc         *views.Service
+	publicURL       *publicurl.Resolver
 	logger          *logging.Logger
 }
 
 // NewBuildAPI creates a build API handler.
-func NewBuildAPI(service *builds.Service, authMiddleware *auth.Middleware, tempRateLimiter ratelimit.RateLimiter, logger *logging.Logger) *BuildAPI {
+func NewBuildAPI(service *builds.Service, imageSvc *images.Service, authMiddleware *auth.Middleware, tempRateLimiter ratelimit.RateLimiter, viewSvc *views.Service, publicURL *publicurl.Resolver, logger *logging.Logger) *BuildAPI {
 	return &BuildAPI{
 		service:         service,
+		imageSvc:        imageSvc,
 		authMiddleware:  authMiddleware,
 		tempRateLimiter: tempRateLimiter,
+		viewSvc:         viewSvc,
+		publicURL:       publicURL,
 		logger:          logger,
 	}
 }
 
-// RegisterRoutes registers build routes.
-func (api *BuildAPI) RegisterRoutes(mux *http.ServeMux, corsMiddleware func(http.HandlerFunc) http.HandlerFunc) {
-	mux.HandleFunc("/api/public/builds", corsMiddleware(api.handlePublicBuilds))
-	mux.HandleFunc("/api/public/builds/", corsMiddleware(api.handlePublicBuildItem))
+// Routes returns the build route table
+func (api *BuildAPI) Routes() []Route {
+	return []Route{
+		{Pattern: "/api/public/builds", Access: AccessPublic, Handler: api.handlePublicBuilds},
+		{Pattern: "/api/public/builds/", Access: AccessPublic, Handler: api.handlePublicBuildItem},
 
-	mux.HandleFunc("/api/builds/temp", corsMiddleware(api.authMiddleware.OptionalAuth(api.handleTempCollection)))
-	mux.HandleFunc("/api/builds/temp/", corsMiddleware(api.handleTempItem))
+		{Pattern: "/api/builds/temp", Access: AccessOptional, Handler: api.handleTempCollection},
+		{Pattern: "/api/builds/temp/", Access: AccessPublic, Handler: api.handleTempItem},
+		{Method: http.MethodGet, Pattern: "/api/builds/temp/view/{token}", Access: AccessPublic, Handler: api.handleTempView},
 
-	mux.HandleFunc("/api/builds/from-aircraft/", corsMiddleware(api.authMiddleware.RequireAuth(api.handleBuildFromAircraft)))
-	mux.HandleFunc("/api/builds", corsMiddleware(api.authMiddleware.RequireAuth(api.handleBuildCollection)))
-	mux.HandleFunc("/api/builds/", corsMiddleware(api.authMiddleware.RequireAuth(api.handleBuildItem)))
+		{Pattern: "/api/builds/from-aircraft/", Access: AccessUser, Handler: api.handleBuildFromAircraft},
+		{Method: http.MethodGet, Pattern: "/api/builds/templates", Access: AccessPublic, Handler: api.handleListTemplates},
+		{Method: http.MethodPost, Pattern: "/api/builds/from-template/{id}", Access: AccessUser, Handler: api.handleBuildFromTemplate},
+		{Pattern: "/api/builds", Access: AccessUser, Handler: api.handleBuildCollection},
+		{Method: http.MethodGet, Pattern: "/api/builds/", Access: AccessPublic, Handler: api.handleBuildItemGet},
+		{Pattern: "/api/builds/", Access: AccessUser, Handler: api.handleBuildItem},
+	}
 }
 
 func (api *BuildAPI) handlePublicBuilds(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	params := api.parseListParams(r)
+	var ecosystemErr string
+	if params.RCProtocol, params.VideoSystem, ecosystemErr = parseEcosystemFilters(r.URL.Query()); ecosystemErr != "" {
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, ecosystemErr)
+		return
+	}
 	response, err := api.service.ListPublic(r.Context(), params)
 	if err != nil {
 		api.logger.Error("List public builds failed", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to load builds")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to load builds")
 		return
 	}
 
@@ -69,7 +91,7 @@ func (api *BuildAPI) handlePublicBuildItem(w http.ResponseWriter, r *http.Reques
 	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/public/builds/"), "/")
 	parts := strings.Split(path, "/")
 	if len(parts) == 0 || strings.TrimSpace(parts[0]) == "" {
-		api.writeError(w, http.StatusBadRequest, "invalid_id", "build id is required")
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "build id is required")
 		return
 	}
 	buildID := strings.TrimSpace(parts[0])
@@ -78,71 +100,122 @@ func (api *BuildAPI) handlePublicBuildItem(w http.ResponseWriter, r *http.Reques
 		switch parts[1] {
 		case "image":
 			if r.Method != http.MethodGet {
-				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+				return
+			}
+			index, ok := parseImageIndex(r)
+			if !ok {
+				api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "index must be a non-negative integer")
+				return
+			}
+			api.getPublicBuildImage(w, r, buildID, index)
+			return
+		case "bom":
+			if r.Method != http.MethodGet {
+				apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+				return
+			}
+			api.serveBOM(w, r, func() (*models.BuildBOM, error) {
+				return api.service.PublicBOM(r.Context(), buildID)
+			})
+			return
+		case "preset":
+			if r.Method != http.MethodGet {
+				apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 				return
 			}
-			api.getPublicBuildImage(w, r, buildID)
+			api.servePreset(w, r, func() (*models.BuildPreset, error) {
+				return api.service.PublicPreset(r.Context(), buildID)
+			})
 			return
 		default:
-			api.writeError(w, http.StatusNotFound, "not_found", "unknown build action")
+			api.writeError(w, http.StatusNotFound, apierror.NotFound, "unknown build action")
 			return
 		}
 	}
 
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	build, err := api.service.GetPublic(r.Context(), buildID)
 	if err != nil {
 		api.logger.Error("Get public build failed", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to load build")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to load build")
 		return
 	}
 	if build == nil {
-		api.writeError(w, http.StatusNotFound, "not_found", "build not found")
+		api.writeError(w, http.StatusNotFound, apierror.NotFound, "build not found")
 		return
 	}
+	api.viewSvc.Record(r.Context(), models.ViewTargetBuild, build.ID, viewerFromRequest(r, api.getClientIP(r)))
 
 	api.writeJSON(w, http.StatusOK, build)
 }
 
 func (api *BuildAPI) handleTempCollection(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	if api.tempRateLimiter != nil {
 		if !api.tempRateLimiter.Allow(api.getClientIP(r)) {
-			api.writeError(w, http.StatusTooManyRequests, "rate_limited", "too many temporary builds created from this IP")
+			api.writeError(w, http.StatusTooManyRequests, apierror.RateLimited, "too many temporary builds created from this IP")
 			return
 		}
 	}
 
 	var params models.CreateBuildParams
 	if err := decodeJSONAllowEmpty(r, &params); err != nil {
-		api.writeError(w, http.StatusBadRequest, "invalid_body", "invalid request body")
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidBody, "invalid request body")
 		return
 	}
 
 	ownerUserID := auth.GetUserID(r.Context())
+	if err := api.service.AdmitTemp(r.Context(), ownerUserID, api.getClientIP(r), r.Header.Get("X-Captcha-Token")); err != nil {
+		var svcErr *builds.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeServiceError(w, svcErr)
+			return
+		}
+		api.logger.Error("Temp build admission failed", logging.WithField("error", err.Error()))
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to create temporary build")
+		return
+	}
 	response, err := api.service.CreateTemp(r.Context(), ownerUserID, params)
 	if err != nil {
 		api.logger.Error("Create temp build failed", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to create temporary build")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to create temporary build")
 		return
 	}
 
+	response.ShareURL = api.publicURL.Absolute(r, response.URL)
 	api.writeJSON(w, http.StatusCreated, response)
 }
 
+// handleTempView handles GET /api/builds/temp/view/{token}: a temp build by
+// its read-only token
+func (api *BuildAPI) handleTempView(w http.ResponseWriter, r *http.Request) {
+	build, err := api.service.GetTempByViewToken(r.Context(), r.PathValue("token"))
+	if err != nil {
+		api.logger.Error("Get temp build by view token failed", logging.WithField("error", err.Error()))
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to load temporary build")
+		return
+	}
+	if build == nil {
+		api.writeError(w, http.StatusNotFound, apierror.NotFound, "temporary build not found or expired")
+		return
+	}
+	api.writeJSON(w, http.StatusOK, build)
+}
+
 func (api *BuildAPI) handleTempItem(w http.ResponseWriter, r *http.Request) {
 	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/builds/temp/"), "/")
 	parts := strings.Split(path, "/")
 	if len(parts) == 0 || strings.TrimSpace(parts[0]) == "" {
-		api.writeError(w, http.StatusBadRequest, "invalid_token", "temp build token is required")
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidToken, "temp build token is required")
 		return
 	}
 	token := strings.TrimSpace(parts[0])
@@ -151,25 +224,46 @@ func (api *BuildAPI) handleTempItem(w http.ResponseWriter, r *http.Request) {
 		switch parts[1] {
 		case "share":
 			if r.Method != http.MethodPost {
-				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 				return
 			}
 
 			shared, err := api.service.ShareTempByToken(r.Context(), token)
 			if err != nil {
 				api.logger.Error("Share temp build failed", logging.WithField("error", err.Error()))
-				api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to share temporary build")
+				api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to share temporary build")
 				return
 			}
 			if shared == nil {
-				api.writeError(w, http.StatusNotFound, "not_found", "temporary build not found or expired")
+				api.writeError(w, http.StatusNotFound, apierror.NotFound, "temporary build not found or expired")
 				return
 			}
 
+			shared.ShareURL = api.publicURL.Absolute(r, shared.URL)
 			api.writeJSON(w, http.StatusOK, shared)
 			return
+		case "view-link":
+			if r.Method != http.MethodPost {
+				apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+				return
+			}
+
+			link, err := api.service.TempViewLink(r.Context(), token)
+			if err != nil {
+				api.logger.Error("Create temp build view link failed", logging.WithField("error", err.Error()))
+				api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to create view link")
+				return
+			}
+			if link == nil {
+				api.writeError(w, http.StatusNotFound, apierror.NotFound, "temporary build not found, expired or replaced by a newer edit")
+				return
+			}
+
+			link.ShareURL = api.publicURL.Absolute(r, link.URL)
+			api.writeJSON(w, http.StatusOK, link)
+			return
 		default:
-			api.writeError(w, http.StatusNotFound, "not_found", "unknown temporary build action")
+			api.writeError(w, http.StatusNotFound, apierror.NotFound, "unknown temporary build action")
 			return
 		}
 	}
@@ -179,35 +273,36 @@ func (api *BuildAPI) handleTempItem(w http.ResponseWriter, r *http.Request) {
 		build, err := api.service.GetTempByToken(r.Context(), token)
 		if err != nil {
 			api.logger.Error("Get temp build failed", logging.WithField("error", err.Error()))
-			api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to load temporary build")
+			api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to load temporary build")
 			return
 		}
 		if build == nil {
-			api.writeError(w, http.StatusNotFound, "not_found", "temporary build not found or expired")
+			api.writeError(w, http.StatusNotFound, apierror.NotFound, "temporary build not found or expired")
 			return
 		}
 		api.writeJSON(w, http.StatusOK, build)
 	case http.MethodPut:
 		var params models.UpdateBuildParams
 		if err := decodeJSONAllowEmpty(r, &params); err != nil {
-			api.writeError(w, http.StatusBadRequest, "invalid_body", "invalid request body")
+			api.writeError(w, http.StatusBadRequest, apierror.InvalidBody, "invalid request body")
 			return
 		}
 
 		updated, err := api.service.UpdateTempByToken(r.Context(), token, params)
 		if err != nil {
 			api.logger.Error("Update temp build failed", logging.WithField("error", err.Error()))
-			api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to update temporary build")
+			api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to update temporary build")
 			return
 		}
 		if updated == nil {
-			api.writeError(w, http.StatusNotFound, "not_found", "temporary build not found or expired")
+			api.writeError(w, http.StatusNotFound, apierror.NotFound, "temporary build not found or expired")
 			return
 		}
 
+		updated.ShareURL = api.publicURL.Absolute(r, updated.URL)
 		api.writeJSON(w, http.StatusOK, updated)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -217,57 +312,143 @@ func (api *BuildAPI) handleBuildCollection(w http.ResponseWriter, r *http.Reques
 	switch r.Method {
 	case http.MethodGet:
 		params := api.parseListParams(r)
+		params.Archived, _ = strconv.ParseBool(r.URL.Query().Get("archived"))
 		response, err := api.service.ListByOwner(r.Context(), userID, params)
 		if err != nil {
 			api.logger.Error("List my builds failed", logging.WithField("error", err.Error()))
-			api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to list builds")
+			api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to list builds")
 			return
 		}
 		api.writeJSON(w, http.StatusOK, response)
 	case http.MethodPost:
 		var params models.CreateBuildParams
 		if err := decodeJSONAllowEmpty(r, &params); err != nil {
-			api.writeError(w, http.StatusBadRequest, "invalid_body", "invalid request body")
+			api.writeError(w, http.StatusBadRequest, apierror.InvalidBody, "invalid request body")
 			return
 		}
 
 		build, err := api.service.CreateDraft(r.Context(), userID, params)
 		if err != nil {
+			var svcErr *builds.ServiceError
+			if errors.As(err, &svcErr) {
+				api.writeServiceError(w, svcErr)
+				return
+			}
 			api.logger.Error("Create draft build failed", logging.WithField("error", err.Error()))
-			api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to create build")
+			api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to create build")
 			return
 		}
 		api.writeJSON(w, http.StatusCreated, build)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
 func (api *BuildAPI) handleBuildFromAircraft(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	userID := auth.GetUserID(r.Context())
 	aircraftID := strings.TrimSpace(strings.TrimPrefix(r.URL.Path, "/api/builds/from-aircraft/"))
 	if aircraftID == "" {
-		api.writeError(w, http.StatusBadRequest, "invalid_aircraft", "aircraft id is required")
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "aircraft id is required")
 		return
 	}
 
-	build, err := api.service.CreateDraftFromAircraft(r.Context(), userID, aircraftID)
+	response, err := api.service.CreateDraftFromAircraft(r.Context(), userID, aircraftID)
 	if err != nil {
+		var svcErr *builds.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeServiceError(w, svcErr)
+			return
+		}
 		api.logger.Error("Create build from aircraft failed", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to create build from aircraft")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to create build from aircraft")
 		return
 	}
-	if build == nil {
-		api.writeError(w, http.StatusNotFound, "not_found", "aircraft not found")
+	if response == nil {
+		api.writeError(w, http.StatusNotFound, apierror.NotFound, "aircraft not found")
 		return
 	}
 
-	api.writeJSON(w, http.StatusCreated, build)
+	api.writeJSON(w, http.StatusCreated, response)
+}
+
+// handleListTemplates handles GET /api/builds/templates
+func (api *BuildAPI) handleListTemplates(w http.ResponseWriter, r *http.Request) {
+	response, err := api.service.ListTemplates(r.Context())
+	if err != nil {
+		api.logger.Error("List build templates failed", logging.WithField("error", err.Error()))
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to load templates")
+		return
+	}
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+// handleBuildFromTemplate handles POST /api/builds/from-template/{id}
+func (api *BuildAPI) handleBuildFromTemplate(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserID(r.Context())
+	response, err := api.service.CreateDraftFromTemplate(r.Context(), userID, r.PathValue("id"))
+	if err != nil {
+		var svcErr *builds.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeServiceError(w, svcErr)
+			return
+		}
+		api.logger.Error("Create build from template failed", logging.WithField("error", err.Error()))
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to create build from template")
+		return
+	}
+	if response == nil {
+		api.writeError(w, http.StatusNotFound, apierror.NotFound, "template not found")
+		return
+	}
+
+	api.writeJSON(w, http.StatusCreated, response)
+}
+
+// handleBuildItemGet serves GET /api/builds/{id}/card.png to everyone and
+// leaves every other GET under /api/builds/ to signed-in owners. The card
+// can't have a route of its own because {id} would overlap /api/builds/temp/.
+func (api *BuildAPI) handleBuildItemGet(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/builds/"), "/"), "/")
+	if len(parts) == 2 && parts[1] == "card.png" && strings.TrimSpace(parts[0]) != "" {
+		api.handleBuildCard(w, r, strings.TrimSpace(parts[0]))
+		return
+	}
+	if api.authMiddleware == nil {
+		apierror.WriteStatus(w, http.StatusUnauthorized, "authorization required")
+		return
+	}
+	api.authMiddleware.RequireAuth(api.handleBuildItem)(w, r)
+}
+
+// handleBuildCard serves a PNG spec card for a published build, with a QR
+// code linking to its public page
+func (api *BuildAPI) handleBuildCard(w http.ResponseWriter, r *http.Request, buildID string) {
+	link := api.publicURL.Absolute(r, "/builds/"+buildID)
+	card, err := api.service.PublicCard(r.Context(), buildID, link)
+	if err != nil {
+		api.logger.Error("Render build card failed", logging.WithFields(map[string]interface{}{
+			"build_id": buildID,
+			"error":    err.Error(),
+		}))
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to render build card")
+		return
+	}
+	if card == nil {
+		api.writeError(w, http.StatusNotFound, apierror.BuildNotFound, "build not found")
+		return
+	}
+
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Header().Set("ETag", card.ETag)
+	// ServeContent answers If-None-Match and If-Modified-Since with a 304
+	http.ServeContent(w, r, "", card.UpdatedAt, bytes.NewReader(card.PNG))
 }
 
 func (api *BuildAPI) handleBuildItem(w http.ResponseWriter, r *http.Request) {
@@ -276,7 +457,7 @@ func (api *BuildAPI) handleBuildItem(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/api/builds/")
 	parts := strings.Split(strings.Trim(path, "/"), "/")
 	if len(parts) == 0 || strings.TrimSpace(parts[0]) == "" {
-		api.writeError(w, http.StatusBadRequest, "invalid_id", "build id is required")
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "build id is required")
 		return
 	}
 	buildID := strings.TrimSpace(parts[0])
@@ -284,20 +465,37 @@ func (api *BuildAPI) handleBuildItem(w http.ResponseWriter, r *http.Request) {
 	if len(parts) > 1 {
 		switch parts[1] {
 		case "image":
+			index, ok := parseImageIndex(r)
+			if !ok {
+				api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "index must be a non-negative integer")
+				return
+			}
+			if len(parts) > 2 {
+				if parts[2] != "primary" || len(parts) > 3 {
+					api.writeError(w, http.StatusNotFound, apierror.NotFound, "unknown build action")
+					return
+				}
+				if r.Method != http.MethodPost {
+					apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+					return
+				}
+				api.setPrimaryBuildImage(w, r, buildID, userID, index)
+				return
+			}
 			switch r.Method {
 			case http.MethodGet:
-				api.getBuildImage(w, r, buildID, userID)
+				api.getBuildImage(w, r, buildID, userID, index)
 			case http.MethodPost, http.MethodPut:
-				api.uploadBuildImage(w, r, buildID, userID)
+				api.uploadBuildImage(w, r, buildID, userID, index)
 			case http.MethodDelete:
-				api.deleteBuildImage(w, r, buildID, userID)
+				api.deleteBuildImage(w, r, buildID, userID, index)
 			default:
-				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 			}
 			return
 		case "publish":
 			if r.Method != http.MethodPost {
-				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 				return
 			}
 			build, validation, err := api.service.Publish(r.Context(), buildID, userID)
@@ -308,34 +506,110 @@ func (api *BuildAPI) handleBuildItem(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 				api.logger.Error("Publish build failed", logging.WithField("error", err.Error()))
-				api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to publish build")
+				api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to publish build")
 				return
 			}
 			if build == nil {
-				api.writeError(w, http.StatusNotFound, "not_found", "build not found")
+				api.writeError(w, http.StatusNotFound, apierror.NotFound, "build not found")
 				return
 			}
 			api.writeJSON(w, http.StatusOK, models.BuildPublishResponse{Build: build, Validation: validation})
 			return
 		case "unpublish":
 			if r.Method != http.MethodPost {
-				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 				return
 			}
 			build, err := api.service.Unpublish(r.Context(), buildID, userID)
 			if err != nil {
 				api.logger.Error("Unpublish build failed", logging.WithField("error", err.Error()))
-				api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to unpublish build")
+				api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to unpublish build")
+				return
+			}
+			if build == nil {
+				api.writeError(w, http.StatusNotFound, apierror.NotFound, "build not found")
+				return
+			}
+			api.writeJSON(w, http.StatusOK, build)
+			return
+		case "archive", "restore":
+			if r.Method != http.MethodPost {
+				apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+				return
+			}
+			change := api.service.ArchiveDraft
+			if parts[1] == "restore" {
+				change = api.service.RestoreDraft
+			}
+			build, err := change(r.Context(), buildID, userID)
+			if err != nil {
+				var svcErr *builds.ServiceError
+				if errors.As(err, &svcErr) {
+					api.writeServiceError(w, svcErr)
+					return
+				}
+				api.logger.Error("Change build draft archive failed", logging.WithField("error", err.Error()))
+				api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to "+parts[1]+" build")
 				return
 			}
 			if build == nil {
-				api.writeError(w, http.StatusNotFound, "not_found", "build not found")
+				api.writeError(w, http.StatusNotFound, apierror.NotFound, "draft not found")
 				return
 			}
 			api.writeJSON(w, http.StatusOK, build)
 			return
+		case "bom":
+			if r.Method != http.MethodGet {
+				apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+				return
+			}
+			api.serveBOM(w, r, func() (*models.BuildBOM, error) {
+				return api.service.BOMForOwner(r.Context(), buildID, userID)
+			})
+			return
+		case "preset":
+			switch r.Method {
+			case http.MethodGet:
+				api.servePreset(w, r, func() (*models.BuildPreset, error) {
+					return api.service.PresetForOwner(r.Context(), buildID, userID)
+				})
+			case http.MethodPut:
+				api.setPreset(w, r, buildID, userID)
+			case http.MethodDelete:
+				deleted, err := api.service.DeletePresetByOwner(r.Context(), buildID, userID)
+				if err != nil {
+					api.logger.Error("Delete build preset failed", logging.WithField("error", err.Error()))
+					api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to delete preset")
+					return
+				}
+				if !deleted {
+					api.writeError(w, http.StatusNotFound, apierror.NotFound, "preset not found")
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+			return
+		case "gap-analysis":
+			if r.Method != http.MethodGet {
+				apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+				return
+			}
+			analysis, err := api.service.GapAnalysis(r.Context(), buildID, userID)
+			if err != nil {
+				api.logger.Error("Build gap analysis failed", logging.WithField("error", err.Error()))
+				api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to compare build with inventory")
+				return
+			}
+			if analysis == nil {
+				api.writeError(w, http.StatusNotFound, apierror.NotFound, "build not found")
+				return
+			}
+			api.writeJSON(w, http.StatusOK, analysis)
+			return
 		default:
-			api.writeError(w, http.StatusNotFound, "not_found", "unknown build action")
+			api.writeError(w, http.StatusNotFound, apierror.NotFound, "unknown build action")
 			return
 		}
 	}
@@ -345,49 +619,100 @@ func (api *BuildAPI) handleBuildItem(w http.ResponseWriter, r *http.Request) {
 		build, err := api.service.GetByOwner(r.Context(), buildID, userID)
 		if err != nil {
 			api.logger.Error("Get build failed", logging.WithField("error", err.Error()))
-			api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to load build")
+			api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to load build")
 			return
 		}
 		if build == nil {
-			api.writeError(w, http.StatusNotFound, "not_found", "build not found")
+			api.writeError(w, http.StatusNotFound, apierror.NotFound, "build not found")
 			return
 		}
 		api.writeJSON(w, http.StatusOK, build)
 	case http.MethodPut:
 		var params models.UpdateBuildParams
 		if err := decodeJSONAllowEmpty(r, &params); err != nil {
-			api.writeError(w, http.StatusBadRequest, "invalid_body", "invalid request body")
+			api.writeError(w, http.StatusBadRequest, apierror.InvalidBody, "invalid request body")
 			return
 		}
 		build, err := api.service.UpdateByOwner(r.Context(), buildID, userID, params)
 		if err != nil {
+			var svcErr *builds.ServiceError
+			if errors.As(err, &svcErr) {
+				api.writeServiceError(w, svcErr)
+				return
+			}
 			api.logger.Error("Update build failed", logging.WithField("error", err.Error()))
-			api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to update build")
+			api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to update build")
 			return
 		}
 		if build == nil {
-			api.writeError(w, http.StatusNotFound, "not_found", "build not found")
+			api.writeError(w, http.StatusNotFound, apierror.NotFound, "build not found")
 			return
 		}
 		api.writeJSON(w, http.StatusOK, build)
+	case http.MethodPatch:
+		api.patchBuild(w, r, buildID, userID)
 	case http.MethodDelete:
 		deleted, err := api.service.DeleteByOwner(r.Context(), buildID, userID)
 		if err != nil {
 			api.logger.Error("Delete build failed", logging.WithField("error", err.Error()))
-			api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to delete build")
+			api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to delete build")
 			return
 		}
 		if !deleted {
-			api.writeError(w, http.StatusNotFound, "not_found", "build not found")
+			api.writeError(w, http.StatusNotFound, apierror.NotFound, "build not found")
 			return
 		}
 		w.WriteHeader(http.StatusNoContent)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// patchBuild applies a JSON merge patch for editor autosave. The response is
+// just the build's ID and new updatedAt so frequent saves stay cheap.
+func (api *BuildAPI) patchBuild(w http.ResponseWriter, r *http.Request, buildID string, userID string) {
+	contentType := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Type")))
+	if contentType != "" && !strings.HasPrefix(contentType, "application/merge-patch+json") && !strings.HasPrefix(contentType, "application/json") {
+		api.writeError(w, http.StatusUnsupportedMediaType, apierror.UnsupportedMediaType, "use application/merge-patch+json")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 1024*1024))
+	if err != nil {
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidBody, "invalid request body")
+		return
+	}
+	patch, err := builds.ParsePatch(body)
+	if err != nil {
+		api.writePatchError(w, err)
+		return
+	}
+	build, err := api.service.PatchByOwner(r.Context(), buildID, userID, patch)
+	if err != nil {
+		api.writePatchError(w, err)
+		return
+	}
+	if build == nil {
+		api.writeError(w, http.StatusNotFound, apierror.NotFound, "build not found")
+		return
+	}
+	api.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":        build.ID,
+		"updatedAt": build.UpdatedAt,
+	})
+}
+
+func (api *BuildAPI) writePatchError(w http.ResponseWriter, err error) {
+	var svcErr *builds.ServiceError
+	if errors.As(err, &svcErr) {
+		api.writeServiceError(w, svcErr)
+		return
 	}
+	api.logger.Error("Patch build failed", logging.WithField("error", err.Error()))
+	api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to save build")
 }
 
-func (api *BuildAPI) uploadBuildImage(w http.ResponseWriter, r *http.Request, buildID string, userID string) {
+func (api *BuildAPI) uploadBuildImage(w http.ResponseWriter, r *http.Request, buildID string, userID string, index *int) {
 	contentType := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Type")))
 
 	if strings.HasPrefix(contentType, "application/json") {
@@ -395,20 +720,24 @@ func (api *BuildAPI) uploadBuildImage(w http.ResponseWriter, r *http.Request, bu
 			UploadID string `json:"uploadId"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			api.writeError(w, http.StatusBadRequest, "invalid_body", "invalid request body")
+			api.writeError(w, http.StatusBadRequest, apierror.InvalidBody, "invalid request body")
 			return
 		}
 		req.UploadID = strings.TrimSpace(req.UploadID)
 		if req.UploadID == "" {
-			api.writeError(w, http.StatusBadRequest, "invalid_upload", "uploadId is required")
+			api.writeError(w, http.StatusBadRequest, apierror.UploadInvalid, "uploadId is required")
 			return
 		}
 
 		decision, err := api.service.SetImage(r.Context(), userID, models.SetBuildImageParams{
 			BuildID:  buildID,
 			UploadID: req.UploadID,
+			Index:    index,
 		})
 		if err != nil {
+			if writeImageUploadError(w, err) {
+				return
+			}
 			switch err {
 			case images.ErrPendingUploadNotFound:
 				api.writeJSON(w, http.StatusUnprocessableEntity, map[string]string{
@@ -427,24 +756,19 @@ func (api *BuildAPI) uploadBuildImage(w http.ResponseWriter, r *http.Request, bu
 			default:
 				var svcErr *builds.ServiceError
 				if errors.As(err, &svcErr) {
-					switch strings.ToLower(strings.TrimSpace(svcErr.Message)) {
-					case "build not found":
-						api.writeError(w, http.StatusNotFound, "not_found", "build not found")
-					default:
-						api.writeError(w, http.StatusBadRequest, "invalid_request", svcErr.Message)
-					}
+					api.writeServiceError(w, svcErr)
 					return
 				}
 				api.logger.Error("Set build image from approved upload failed", logging.WithFields(map[string]interface{}{
 					"build_id": buildID,
 					"error":    err.Error(),
 				}))
-				api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to set build image")
+				api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to set build image")
 				return
 			}
 		}
 		if decision == nil {
-			api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to set build image")
+			api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to set build image")
 			return
 		}
 
@@ -457,29 +781,29 @@ func (api *BuildAPI) uploadBuildImage(w http.ResponseWriter, r *http.Request, bu
 
 	r.Body = http.MaxBytesReader(w, r.Body, 3*1024*1024)
 	if err := r.ParseMultipartForm(3 * 1024 * 1024); err != nil {
-		api.writeError(w, http.StatusBadRequest, "invalid_upload", "file too large or invalid form")
+		api.writeError(w, http.StatusBadRequest, apierror.UploadInvalid, "file too large or invalid form")
 		return
 	}
 
 	file, _, err := r.FormFile("image")
 	if err != nil {
-		api.writeError(w, http.StatusBadRequest, "missing_image", "image file required")
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "image file required")
 		return
 	}
 	defer file.Close()
 
 	imageData, err := io.ReadAll(file)
 	if err != nil {
-		api.writeError(w, http.StatusInternalServerError, "read_error", "failed to read image")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to read image")
 		return
 	}
 	if len(imageData) > 2*1024*1024 {
-		api.writeError(w, http.StatusBadRequest, "invalid_upload", "image must be less than 2MB")
+		api.writeError(w, http.StatusBadRequest, apierror.UploadInvalid, "image must be less than 2MB")
 		return
 	}
 	detectedContentType, ok := detectAllowedImageContentType(imageData)
 	if !ok {
-		api.writeError(w, http.StatusBadRequest, "invalid_upload", "image must be JPEG or PNG")
+		api.writeError(w, http.StatusBadRequest, apierror.UploadInvalid, "image must be JPEG or PNG")
 		return
 	}
 
@@ -487,27 +811,26 @@ func (api *BuildAPI) uploadBuildImage(w http.ResponseWriter, r *http.Request, bu
 		BuildID:   buildID,
 		ImageType: detectedContentType,
 		ImageData: imageData,
+		Index:     index,
 	})
 	if err != nil {
+		if writeImageUploadError(w, err) {
+			return
+		}
 		var svcErr *builds.ServiceError
 		if errors.As(err, &svcErr) {
-			switch strings.ToLower(strings.TrimSpace(svcErr.Message)) {
-			case "build not found":
-				api.writeError(w, http.StatusNotFound, "not_found", "build not found")
-			default:
-				api.writeError(w, http.StatusBadRequest, "invalid_request", svcErr.Message)
-			}
+			api.writeServiceError(w, svcErr)
 			return
 		}
 		api.logger.Error("Set build image failed", logging.WithFields(map[string]interface{}{
 			"build_id": buildID,
 			"error":    err.Error(),
 		}))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to set build image")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to set build image")
 		return
 	}
 	if decision == nil {
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to set build image")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to set build image")
 		return
 	}
 	if decision.Status != models.ImageModerationApproved {
@@ -529,64 +852,78 @@ func (api *BuildAPI) uploadBuildImage(w http.ResponseWriter, r *http.Request, bu
 	})
 }
 
-func (api *BuildAPI) getBuildImage(w http.ResponseWriter, r *http.Request, buildID string, userID string) {
-	imageData, imageType, err := api.service.GetImage(r.Context(), buildID, userID)
+func (api *BuildAPI) getBuildImage(w http.ResponseWriter, r *http.Request, buildID string, userID string, index *int) {
+	var (
+		imageData []byte
+		imageType string
+		err       error
+	)
+	if index != nil {
+		imageData, imageType, err = api.service.GetImageAt(r.Context(), buildID, userID, *index)
+	} else {
+		imageData, imageType, err = api.service.GetImage(r.Context(), buildID, userID)
+	}
 	if err != nil {
 		api.logger.Error("Get build image failed", logging.WithFields(map[string]interface{}{
 			"build_id": buildID,
 			"error":    err.Error(),
 		}))
-		http.Error(w, "image not found", http.StatusNotFound)
+		apierror.WriteStatus(w, http.StatusNotFound, "image not found")
 		return
 	}
 	if len(imageData) == 0 {
-		http.Error(w, "no image for this build", http.StatusNotFound)
+		apierror.WriteStatus(w, http.StatusNotFound, "no image for this build")
 		return
 	}
 
-	w.Header().Set("X-Content-Type-Options", "nosniff")
-	w.Header().Set("Content-Type", imageType)
-	w.Header().Set("Content-Length", strconv.Itoa(len(imageData)))
-	w.Header().Set("Cache-Control", "private, max-age=300")
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write(imageData)
+	writeImage(w, r, api.imageSvc, imageData, imageType, "private, max-age=300")
 }
 
-func (api *BuildAPI) getPublicBuildImage(w http.ResponseWriter, r *http.Request, buildID string) {
-	imageData, imageType, err := api.service.GetPublicImage(r.Context(), buildID)
+func (api *BuildAPI) getPublicBuildImage(w http.ResponseWriter, r *http.Request, buildID string, index *int) {
+	var (
+		imageData []byte
+		imageType string
+		err       error
+	)
+	if index != nil {
+		imageData, imageType, err = api.service.GetPublicImageAt(r.Context(), buildID, *index)
+	} else {
+		imageData, imageType, err = api.service.GetPublicImage(r.Context(), buildID)
+	}
 	if err != nil {
 		api.logger.Error("Get public build image failed", logging.WithFields(map[string]interface{}{
 			"build_id": buildID,
 			"error":    err.Error(),
 		}))
-		http.Error(w, "image not found", http.StatusNotFound)
+		apierror.WriteStatus(w, http.StatusNotFound, "image not found")
 		return
 	}
 	if len(imageData) == 0 {
-		http.Error(w, "no image for this build", http.StatusNotFound)
+		apierror.WriteStatus(w, http.StatusNotFound, "no image for this build")
 		return
 	}
 
-	w.Header().Set("X-Content-Type-Options", "nosniff")
-	w.Header().Set("Content-Type", imageType)
-	w.Header().Set("Content-Length", strconv.Itoa(len(imageData)))
-	w.Header().Set("Cache-Control", "public, max-age=300")
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write(imageData)
+	writeImage(w, r, api.imageSvc, imageData, imageType, "public, max-age=300")
 }
 
-func (api *BuildAPI) deleteBuildImage(w http.ResponseWriter, r *http.Request, buildID string, userID string) {
-	if err := api.service.DeleteImage(r.Context(), buildID, userID); err != nil {
+func (api *BuildAPI) deleteBuildImage(w http.ResponseWriter, r *http.Request, buildID string, userID string, index *int) {
+	var err error
+	if index != nil {
+		err = api.service.DeleteImageAt(r.Context(), buildID, userID, *index)
+	} else {
+		err = api.service.DeleteImage(r.Context(), buildID, userID)
+	}
+	if err != nil {
 		var svcErr *builds.ServiceError
-		if errors.As(err, &svcErr) && strings.EqualFold(strings.TrimSpace(svcErr.Message), "build not found") {
-			api.writeError(w, http.StatusNotFound, "not_found", "build not found")
+		if errors.As(err, &svcErr) {
+			api.writeServiceError(w, svcErr)
 			return
 		}
 		api.logger.Error("Delete build image failed", logging.WithFields(map[string]interface{}{
 			"build_id": buildID,
 			"error":    err.Error(),
 		}))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to delete build image")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to delete build image")
 		return
 	}
 
@@ -595,14 +932,134 @@ func (api *BuildAPI) deleteBuildImage(w http.ResponseWriter, r *http.Request, bu
 	})
 }
 
+func (api *BuildAPI) setPrimaryBuildImage(w http.ResponseWriter, r *http.Request, buildID string, userID string, index *int) {
+	if index == nil {
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "index is required")
+		return
+	}
+	if err := api.service.SetPrimaryImage(r.Context(), buildID, userID, *index); err != nil {
+		var svcErr *builds.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeServiceError(w, svcErr)
+			return
+		}
+		api.logger.Error("Set primary build image failed", logging.WithFields(map[string]interface{}{
+			"build_id": buildID,
+			"error":    err.Error(),
+		}))
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to set primary build image")
+		return
+	}
+
+	api.writeJSON(w, http.StatusOK, map[string]string{
+		"message": "Primary image updated",
+	})
+}
+
+// serveBOM writes a bill of materials in the format given by the format
+// query parameter: json (default), csv, md, or pdf.
+func (api *BuildAPI) serveBOM(w http.ResponseWriter, r *http.Request, load func() (*models.BuildBOM, error)) {
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	switch format {
+	case "", builds.BOMFormatJSON, builds.BOMFormatCSV, builds.BOMFormatMarkdown, builds.BOMFormatPDF:
+	default:
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "format must be one of json, csv, md, pdf")
+		return
+	}
+
+	bom, err := load()
+	if err != nil {
+		api.logger.Error("Build BOM failed", logging.WithField("error", err.Error()))
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to build parts list")
+		return
+	}
+	if bom == nil {
+		api.writeError(w, http.StatusNotFound, apierror.NotFound, "build not found")
+		return
+	}
+
+	if format == "" || format == builds.BOMFormatJSON {
+		api.writeJSON(w, http.StatusOK, bom)
+		return
+	}
+
+	body, contentType, err := builds.RenderBOM(bom, format)
+	if err != nil {
+		api.logger.Error("Render build BOM failed", logging.WithField("error", err.Error()))
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to render parts list")
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="build-%s-bom.%s"`, bom.BuildID, format))
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// setPreset attaches a Betaflight diff to one of the caller's builds
+func (api *BuildAPI) setPreset(w http.ResponseWriter, r *http.Request, buildID string, userID string) {
+	var params models.SetBuildPresetParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidBody, "invalid request body")
+		return
+	}
+
+	preset, err := api.service.SetPresetByOwner(r.Context(), buildID, userID, params)
+	if err != nil {
+		var svcErr *builds.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeError(w, http.StatusBadRequest, apierror.BuildPresetInvalid, svcErr.Message)
+			return
+		}
+		api.logger.Error("Set build preset failed", logging.WithField("error", err.Error()))
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to save preset")
+		return
+	}
+	if preset == nil {
+		api.writeError(w, http.StatusNotFound, apierror.NotFound, "build not found")
+		return
+	}
+	api.writeJSON(w, http.StatusOK, preset)
+}
+
+// servePreset writes a build's preset as JSON, or with format=txt as a
+// download ready to paste into the Betaflight CLI
+func (api *BuildAPI) servePreset(w http.ResponseWriter, r *http.Request, load func() (*models.BuildPreset, error)) {
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	if format != "" && format != "json" && format != "txt" {
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "format must be one of json, txt")
+		return
+	}
+
+	preset, err := load()
+	if err != nil {
+		api.logger.Error("Get build preset failed", logging.WithField("error", err.Error()))
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to load preset")
+		return
+	}
+	if preset == nil {
+		api.writeError(w, http.StatusNotFound, apierror.NotFound, "preset not found")
+		return
+	}
+
+	if format != "txt" {
+		api.writeJSON(w, http.StatusOK, preset)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="build-%s-diff.txt"`, preset.BuildID))
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.WriteString(w, preset.Diff)
+}
+
 func (api *BuildAPI) parseListParams(r *http.Request) models.BuildListParams {
 	query := r.URL.Query()
 
 	params := models.BuildListParams{
 		Sort:        models.BuildSort(strings.TrimSpace(query.Get("sort"))),
 		FrameFilter: strings.TrimSpace(query.Get("frameFilter")),
+		Query:       strings.TrimSpace(query.Get("q")),
 	}
-	if params.Sort == "" {
+	if params.Sort != models.BuildSortPopular {
 		params.Sort = models.BuildSortNewest
 	}
 
@@ -658,14 +1115,19 @@ func decodeJSONAllowEmpty(r *http.Request, dst interface{}) error {
 }
 
 func (api *BuildAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(data)
+	writeJSON(w, status, data)
 }
 
-func (api *BuildAPI) writeError(w http.ResponseWriter, status int, code, message string) {
-	api.writeJSON(w, status, map[string]string{
-		"code":    code,
-		"message": message,
-	})
+// writeServiceError writes a build service error with its code's status, or
+// as a 400 when it has no code
+func (api *BuildAPI) writeServiceError(w http.ResponseWriter, svcErr *builds.ServiceError) {
+	if svcErr.Code != "" {
+		api.writeError(w, apierror.Status(svcErr.Code), svcErr.Code, svcErr.Message)
+		return
+	}
+	api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, svcErr.Message)
+}
+
+func (api *BuildAPI) writeError(w http.ResponseWriter, status int, code apierror.Code, message string) {
+	apierror.Write(w, status, code, message)
 }
@@ -0,0 +1,69 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/ecosystems"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// EcosystemAPI serves the RC protocol and video system registry
+type EcosystemAPI struct {
+	ecosystemSvc *ecosystems.Service
+	logger       *logging.Logger
+}
+
+// NewEcosystemAPI creates a new ecosystem API handler
+func NewEcosystemAPI(ecosystemSvc *ecosystems.Service, logger *logging.Logger) *EcosystemAPI {
+	return &EcosystemAPI{
+		ecosystemSvc: ecosystemSvc,
+		logger:       logger,
+	}
+}
+
+// Routes returns the ecosystem route table
+func (api *EcosystemAPI) Routes() []Route {
+	return []Route{
+		{Method: http.MethodGet, Pattern: "/api/ecosystems", Access: AccessPublic, Handler: api.handleListEcosystems},
+	}
+}
+
+// handleListEcosystems handles GET /api/ecosystems
+func (api *EcosystemAPI) handleListEcosystems(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := api.ecosystemSvc.Registry(ctx)
+	if err != nil {
+		api.logger.Error("Failed to list ecosystems", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to list ecosystems"})
+		return
+	}
+
+	// Item counts only change when the link refresh runs
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+// parseEcosystemFilters reads the rcProtocol and videoSystem filters shared
+// by the catalog and build lists. The message is empty when both are valid.
+func parseEcosystemFilters(query url.Values) (models.RCProtocol, models.VideoSystem, string) {
+	protocol := models.RCProtocol(strings.ToLower(strings.TrimSpace(query.Get("rcProtocol"))))
+	if protocol != "" && !protocol.IsValid() {
+		return "", "", "invalid rcProtocol"
+	}
+	system := models.VideoSystem(strings.ToLower(strings.TrimSpace(query.Get("videoSystem"))))
+	if system != "" && !system.IsValid() {
+		return "", "", "invalid videoSystem"
+	}
+	return protocol, system, ""
+}
+
+func (api *EcosystemAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
@@ -3,10 +3,15 @@ package httpapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/auth"
 	"github.com/johnrirwin/flyingforge/internal/equipment"
 	"github.com/johnrirwin/flyingforge/internal/inventory"
@@ -18,44 +23,48 @@ import (
 type EquipmentAPI struct {
 	equipmentSvc   *equipment.Service
 	inventorySvc   inventory.InventoryManager
+	attachmentSvc  *inventory.AttachmentService
 	authMiddleware *auth.Middleware
 	logger         *logging.Logger
 }
 
 // NewEquipmentAPI creates a new equipment API handler
-func NewEquipmentAPI(equipmentSvc *equipment.Service, inventorySvc inventory.InventoryManager, authMiddleware *auth.Middleware, logger *logging.Logger) *EquipmentAPI {
+func NewEquipmentAPI(equipmentSvc *equipment.Service, inventorySvc inventory.InventoryManager, attachmentSvc *inventory.AttachmentService, authMiddleware *auth.Middleware, logger *logging.Logger) *EquipmentAPI {
 	return &EquipmentAPI{
 		equipmentSvc:   equipmentSvc,
 		inventorySvc:   inventorySvc,
+		attachmentSvc:  attachmentSvc,
 		authMiddleware: authMiddleware,
 		logger:         logger,
 	}
 }
 
-// RegisterRoutes registers equipment and inventory routes on the given mux
-func (api *EquipmentAPI) RegisterRoutes(mux *http.ServeMux, corsMiddleware func(http.HandlerFunc) http.HandlerFunc) {
+// Routes returns the equipment and inventory route table
+func (api *EquipmentAPI) Routes() []Route {
 	if api.authMiddleware == nil {
 		api.logger.Error("Equipment API routes not registered: authMiddleware is nil")
-		return
+		return nil
 	}
 
-	// Equipment routes (require authentication)
-	mux.HandleFunc("/api/equipment/search", corsMiddleware(api.authMiddleware.RequireAuth(api.handleSearchEquipment)))
-	mux.HandleFunc("/api/equipment/category/", corsMiddleware(api.authMiddleware.RequireAuth(api.handleGetByCategory)))
-	mux.HandleFunc("/api/equipment/sellers", corsMiddleware(api.authMiddleware.RequireAuth(api.handleGetSellers)))
-	mux.HandleFunc("/api/equipment/sync", corsMiddleware(api.authMiddleware.RequireAuth(api.handleSyncProducts)))
+	return []Route{
+		// Equipment routes (require authentication)
+		{Pattern: "/api/equipment/search", Access: AccessUser, Handler: api.handleSearchEquipment},
+		{Pattern: "/api/equipment/category/", Access: AccessUser, Handler: api.handleGetByCategory},
+		{Pattern: "/api/equipment/sellers", Access: AccessUser, Handler: api.handleGetSellers},
+		{Pattern: "/api/equipment/sync", Access: AccessUser, Handler: api.handleSyncProducts},
 
-	// Inventory routes (require authentication)
-	mux.HandleFunc("/api/inventory", corsMiddleware(api.authMiddleware.RequireAuth(api.handleInventory)))
-	mux.HandleFunc("/api/inventory/summary", corsMiddleware(api.authMiddleware.RequireAuth(api.handleInventorySummary)))
-	mux.HandleFunc("/api/inventory/", corsMiddleware(api.authMiddleware.RequireAuth(api.handleInventoryItem)))
+		// Inventory routes (require authentication)
+		{Pattern: "/api/inventory", Access: AccessUser, Handler: api.handleInventory},
+		{Pattern: "/api/inventory/summary", Access: AccessUser, Handler: api.handleInventorySummary},
+		{Pattern: "/api/inventory/", Access: AccessUser, Handler: api.handleInventoryItem},
+	}
 }
 
 // Equipment handlers
 
 func (api *EquipmentAPI) handleSearchEquipment(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -116,7 +125,7 @@ func (api *EquipmentAPI) handleSearchEquipment(w http.ResponseWriter, r *http.Re
 
 func (api *EquipmentAPI) handleGetByCategory(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -124,7 +133,7 @@ func (api *EquipmentAPI) handleGetByCategory(w http.ResponseWriter, r *http.Requ
 	path := r.URL.Path
 	category := path[len("/api/equipment/category/"):]
 	if category == "" {
-		http.Error(w, "Category required", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Category required")
 		return
 	}
 
@@ -164,7 +173,7 @@ func (api *EquipmentAPI) handleGetByCategory(w http.ResponseWriter, r *http.Requ
 
 func (api *EquipmentAPI) handleGetSellers(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -174,7 +183,7 @@ func (api *EquipmentAPI) handleGetSellers(w http.ResponseWriter, r *http.Request
 
 func (api *EquipmentAPI) handleSyncProducts(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -205,7 +214,7 @@ func (api *EquipmentAPI) handleInventory(w http.ResponseWriter, r *http.Request)
 	case http.MethodPost:
 		api.addInventoryItem(w, r)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -218,6 +227,7 @@ func (api *EquipmentAPI) listInventory(w http.ResponseWriter, r *http.Request) {
 		Category: models.EquipmentCategory(query.Get("category")),
 		BuildID:  query.Get("buildId"),
 		Query:    query.Get("q"),
+		Status:   models.InventoryStatus(query.Get("status")),
 	}
 
 	if limit := query.Get("limit"); limit != "" {
@@ -253,7 +263,7 @@ func (api *EquipmentAPI) addInventoryItem(w http.ResponseWriter, r *http.Request
 	var params models.AddInventoryParams
 
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
@@ -269,15 +279,49 @@ func (api *EquipmentAPI) addInventoryItem(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	api.writeJSON(w, http.StatusCreated, item)
+	// Ecosystem warnings are opt-in so existing clients get the bare item
+	if r.URL.Query().Get("checkCompatibility") != "true" {
+		api.writeJSON(w, http.StatusCreated, item)
+		return
+	}
+	warnings, err := api.inventorySvc.CompatibilityWarnings(ctx, userID, item)
+	if err != nil {
+		// The item is already saved; a failed check shouldn't fail the add
+		api.logger.Warn("Inventory compatibility check failed", logging.WithField("error", err.Error()))
+		warnings = []models.CompatibilityIssue{}
+	}
+	api.writeJSON(w, http.StatusCreated, addInventoryResponse{InventoryItem: item, CompatibilityWarnings: warnings})
+}
+
+// addInventoryResponse is the added item plus ecosystem warnings, returned
+// when the request asks for a compatibility check
+type addInventoryResponse struct {
+	*models.InventoryItem
+	CompatibilityWarnings []models.CompatibilityIssue `json:"compatibilityWarnings"`
 }
 
 func (api *EquipmentAPI) handleInventoryItem(w http.ResponseWriter, r *http.Request) {
-	// Extract item ID from path: /api/inventory/{id}
+	// Extract item ID from path: /api/inventory/{id}[/attachments[/{attachmentId}] | /archive | /unarchive]
 	path := r.URL.Path
-	id := path[len("/api/inventory/"):]
+	id, rest, hasRest := strings.Cut(path[len("/api/inventory/"):], "/")
 	if id == "" || id == "summary" {
-		http.Error(w, "Item ID required", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Item ID required")
+		return
+	}
+	if hasRest {
+		action, attachmentID, _ := strings.Cut(rest, "/")
+		switch action {
+		case "attachments":
+			api.handleInventoryAttachments(w, r, id, attachmentID)
+		case "archive", "unarchive":
+			if r.Method != http.MethodPost {
+				apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+				return
+			}
+			api.archiveInventoryItem(w, r, id, action == "archive")
+		default:
+			apierror.WriteStatus(w, http.StatusNotFound, "Not found")
+		}
 		return
 	}
 
@@ -289,7 +333,7 @@ func (api *EquipmentAPI) handleInventoryItem(w http.ResponseWriter, r *http.Requ
 	case http.MethodDelete:
 		api.deleteInventoryItem(w, r, id)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -312,7 +356,7 @@ func (api *EquipmentAPI) getInventoryItem(w http.ResponseWriter, r *http.Request
 	}
 
 	if item == nil {
-		http.Error(w, "Item not found", http.StatusNotFound)
+		apierror.WriteStatus(w, http.StatusNotFound, "Item not found")
 		return
 	}
 
@@ -325,7 +369,7 @@ func (api *EquipmentAPI) updateInventoryItem(w http.ResponseWriter, r *http.Requ
 	var params models.UpdateInventoryParams
 
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 	params.ID = id
@@ -368,9 +412,37 @@ func (api *EquipmentAPI) deleteInventoryItem(w http.ResponseWriter, r *http.Requ
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// archiveInventoryItem handles POST /api/inventory/{id}/archive and
+// /api/inventory/{id}/unarchive
+func (api *EquipmentAPI) archiveInventoryItem(w http.ResponseWriter, r *http.Request, id string, archived bool) {
+	userID := auth.GetUserID(r.Context())
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	item, err := api.inventorySvc.ArchiveItem(ctx, id, userID, archived)
+	if err != nil {
+		var svcErr *inventory.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeJSON(w, http.StatusNotFound, errorBody(svcErr))
+			return
+		}
+		api.logger.Error("Archive inventory item failed", logging.WithFields(map[string]interface{}{
+			"id":    id,
+			"error": err.Error(),
+		}))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	api.writeJSON(w, http.StatusOK, item)
+}
+
 func (api *EquipmentAPI) handleInventorySummary(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -391,8 +463,111 @@ func (api *EquipmentAPI) handleInventorySummary(w http.ResponseWriter, r *http.R
 	api.writeJSON(w, http.StatusOK, summary)
 }
 
+func (api *EquipmentAPI) handleInventoryAttachments(w http.ResponseWriter, r *http.Request, itemID, attachmentID string) {
+	if api.attachmentSvc == nil {
+		apierror.WriteStatus(w, http.StatusServiceUnavailable, "Attachments unavailable")
+		return
+	}
+	userID := auth.GetUserID(r.Context())
+
+	if attachmentID == "" {
+		switch r.Method {
+		case http.MethodGet:
+			attachments, err := api.attachmentSvc.List(r.Context(), itemID, userID)
+			if err != nil {
+				api.logger.Error("List inventory attachments failed", logging.WithField("error", err.Error()))
+				api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to list attachments"})
+				return
+			}
+			api.writeJSON(w, http.StatusOK, map[string]interface{}{"attachments": attachments})
+		case http.MethodPost:
+			api.uploadInventoryAttachment(w, r, itemID, userID)
+		default:
+			apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		attachment, err := api.attachmentSvc.Get(r.Context(), attachmentID, itemID, userID)
+		if err != nil {
+			api.logger.Error("Get inventory attachment failed", logging.WithField("error", err.Error()))
+			api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to load attachment"})
+			return
+		}
+		if attachment == nil {
+			apierror.WriteStatus(w, http.StatusNotFound, "Attachment not found")
+			return
+		}
+		w.Header().Set("Content-Type", attachment.ContentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", attachment.FileName))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Cache-Control", "private, max-age=300")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(attachment.FileBytes)
+	case http.MethodDelete:
+		deleted, err := api.attachmentSvc.Delete(r.Context(), attachmentID, itemID, userID)
+		if err != nil {
+			api.logger.Error("Delete inventory attachment failed", logging.WithField("error", err.Error()))
+			api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to delete attachment"})
+			return
+		}
+		if !deleted {
+			apierror.WriteStatus(w, http.StatusNotFound, "Attachment not found")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (api *EquipmentAPI) uploadInventoryAttachment(w http.ResponseWriter, r *http.Request, itemID, userID string) {
+	// Leave room for multipart overhead above the largest allowed file
+	r.Body = http.MaxBytesReader(w, r.Body, inventory.MaxAttachmentBytes+1024*1024)
+	if err := r.ParseMultipartForm(inventory.MaxAttachmentBytes + 1024*1024); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "file too large or invalid form"})
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "file is required"})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "failed to read file"})
+		return
+	}
+
+	attachment, err := api.attachmentSvc.Add(r.Context(), userID, models.AddInventoryAttachmentParams{
+		InventoryItemID: itemID,
+		Kind:            models.InventoryAttachmentKind(strings.ToLower(strings.TrimSpace(r.FormValue("kind")))),
+		FileName:        header.Filename,
+		FileBytes:       data,
+	})
+	if err != nil {
+		var svcErr *inventory.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+			return
+		}
+		api.logger.Error("Add inventory attachment failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to save attachment"})
+		return
+	}
+	if attachment == nil {
+		apierror.WriteStatus(w, http.StatusNotFound, "Item not found")
+		return
+	}
+
+	api.writeJSON(w, http.StatusCreated, attachment)
+}
+
 func (api *EquipmentAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+	writeJSON(w, status, data)
 }
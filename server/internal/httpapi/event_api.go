@@ -0,0 +1,275 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/calc/vtx"
+	"github.com/johnrirwin/flyingforge/internal/events"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// EventAPI handles HTTP API requests for events, registration, and check-in
+type EventAPI struct {
+	eventSvc       *events.Service
+	authMiddleware *auth.Middleware
+	logger         *logging.Logger
+}
+
+// NewEventAPI creates a new event API handler
+func NewEventAPI(eventSvc *events.Service, authMiddleware *auth.Middleware, logger *logging.Logger) *EventAPI {
+	return &EventAPI{
+		eventSvc:       eventSvc,
+		authMiddleware: authMiddleware,
+		logger:         logger,
+	}
+}
+
+// Routes returns the event route table
+func (api *EventAPI) Routes() []Route {
+	return []Route{
+		{Pattern: "/api/events", Access: AccessUser, Handler: api.handleEvents},
+		{Pattern: "/api/events/", Access: AccessUser, Handler: api.handleEventItem},
+	}
+}
+
+// handleEvents handles GET/POST /api/events
+func (api *EventAPI) handleEvents(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserID(r.Context())
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		list, err := api.eventSvc.List(ctx, userID, limit, offset, time.Now())
+		if err != nil {
+			api.writeServiceError(w, "List events failed", err)
+			return
+		}
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"events": list})
+	case http.MethodPost:
+		var params models.CreateEventParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		event, err := api.eventSvc.Create(ctx, userID, params)
+		if err != nil {
+			api.writeServiceError(w, "Create event failed", err)
+			return
+		}
+		api.writeJSON(w, http.StatusCreated, event)
+	default:
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// handleEventItem routes /api/events/{id} and its sub-resources
+func (api *EventAPI) handleEventItem(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/events/"), "/"), "/")
+	if parts[0] == "" || len(parts) > 4 {
+		apierror.WriteStatus(w, http.StatusNotFound, "Not found")
+		return
+	}
+	eventID := parts[0]
+	userID := auth.GetUserID(r.Context())
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+	r = r.WithContext(ctx)
+
+	switch {
+	case len(parts) == 1:
+		api.handleEvent(w, r, eventID, userID)
+	case len(parts) == 2 && parts[1] == "registration":
+		api.handleRegistration(w, r, eventID, userID)
+	case len(parts) == 2 && parts[1] == "attendees":
+		api.handleAttendees(w, r, eventID, userID)
+	case len(parts) == 4 && parts[1] == "attendees" && parts[3] == "check-in":
+		api.handleCheckIn(w, r, eventID, userID, parts[2])
+	case len(parts) == 2 && parts[1] == "vtx-plan":
+		api.handleVTXPlan(w, r, eventID, userID)
+	case len(parts) == 2 && parts[1] == "check-in-sheet":
+		api.handleCheckInSheet(w, r, eventID, userID)
+	default:
+		apierror.WriteStatus(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// handleEvent handles GET/PATCH/DELETE /api/events/{id}
+func (api *EventAPI) handleEvent(w http.ResponseWriter, r *http.Request, eventID, userID string) {
+	switch r.Method {
+	case http.MethodGet:
+		event, err := api.eventSvc.Get(r.Context(), eventID, userID)
+		if err != nil {
+			api.writeServiceError(w, "Get event failed", err)
+			return
+		}
+		api.writeJSON(w, http.StatusOK, event)
+	case http.MethodPatch, http.MethodPut:
+		var params models.UpdateEventParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		event, err := api.eventSvc.Update(r.Context(), eventID, userID, params)
+		if err != nil {
+			api.writeServiceError(w, "Update event failed", err)
+			return
+		}
+		api.writeJSON(w, http.StatusOK, event)
+	case http.MethodDelete:
+		if err := api.eventSvc.Delete(r.Context(), eventID, userID); err != nil {
+			api.writeServiceError(w, "Delete event failed", err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// handleRegistration handles POST/DELETE /api/events/{id}/registration
+func (api *EventAPI) handleRegistration(w http.ResponseWriter, r *http.Request, eventID, userID string) {
+	switch r.Method {
+	case http.MethodPost:
+		var params models.RegisterForEventParams
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+				apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
+				return
+			}
+		}
+		event, err := api.eventSvc.Register(r.Context(), eventID, userID, params, time.Now())
+		if err != nil {
+			api.writeServiceError(w, "Register for event failed", err)
+			return
+		}
+		api.writeJSON(w, http.StatusOK, event)
+	case http.MethodDelete:
+		if err := api.eventSvc.Cancel(r.Context(), eventID, userID); err != nil {
+			api.writeServiceError(w, "Cancel event registration failed", err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// handleAttendees handles GET /api/events/{id}/attendees
+func (api *EventAPI) handleAttendees(w http.ResponseWriter, r *http.Request, eventID, userID string) {
+	if r.Method != http.MethodGet {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	attendees, err := api.eventSvc.Attendees(r.Context(), eventID, userID)
+	if err != nil {
+		api.writeServiceError(w, "List event attendees failed", err)
+		return
+	}
+	api.writeJSON(w, http.StatusOK, map[string]interface{}{"attendees": attendees})
+}
+
+// handleCheckIn handles POST/DELETE /api/events/{id}/attendees/{userId}/check-in
+func (api *EventAPI) handleCheckIn(w http.ResponseWriter, r *http.Request, eventID, userID, pilotID string) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if err := api.eventSvc.CheckIn(r.Context(), eventID, userID, pilotID, r.Method == http.MethodPost); err != nil {
+		api.writeServiceError(w, "Event check-in failed", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleVTXPlan handles GET /api/events/{id}/vtx-plan?checkedIn=&powerMw=&format=
+func (api *EventAPI) handleVTXPlan(w http.ResponseWriter, r *http.Request, eventID, userID string) {
+	if r.Method != http.MethodGet {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format != "" && format != vtx.FormatJSON && format != vtx.FormatMarkdown && format != vtx.FormatPDF {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "format must be one of json, md, pdf"})
+		return
+	}
+	checkedIn, _ := strconv.ParseBool(query.Get("checkedIn"))
+	powerMw, _ := strconv.Atoi(query.Get("powerMw"))
+
+	plan, err := api.eventSvc.VTXPlan(r.Context(), eventID, userID, checkedIn, vtx.Options{PowerMw: powerMw}, time.Now())
+	if err != nil {
+		api.writeServiceError(w, "Event VTX plan failed", err)
+		return
+	}
+
+	if format == "" || format == vtx.FormatJSON {
+		api.writeJSON(w, http.StatusOK, plan)
+		return
+	}
+	body, contentType, err := vtx.Render(plan, format)
+	if err != nil {
+		api.writeServiceError(w, "Render event VTX plan failed", err)
+		return
+	}
+	api.writeFile(w, body, contentType, "vtx-plan."+format)
+}
+
+// handleCheckInSheet handles GET /api/events/{id}/check-in-sheet?format=csv|pdf
+func (api *EventAPI) handleCheckInSheet(w http.ResponseWriter, r *http.Request, eventID, userID string) {
+	if r.Method != http.MethodGet {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = events.SheetFormatCSV
+	}
+	body, contentType, err := api.eventSvc.CheckInSheet(r.Context(), eventID, userID, format)
+	if err != nil {
+		api.writeServiceError(w, "Event check-in sheet failed", err)
+		return
+	}
+	api.writeFile(w, body, contentType, "check-in."+format)
+}
+
+func (api *EventAPI) writeFile(w http.ResponseWriter, body []byte, contentType, filename string) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// writeServiceError maps event service errors to HTTP statuses
+func (api *EventAPI) writeServiceError(w http.ResponseWriter, message string, err error) {
+	var svcErr *events.ServiceError
+	switch {
+	case errors.Is(err, events.ErrEventNotFound):
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, events.ErrForbidden):
+		api.writeJSON(w, http.StatusForbidden, map[string]string{"error": err.Error()})
+	case errors.As(err, &svcErr):
+		api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+	default:
+		api.logger.Error(message, logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal server error"})
+	}
+}
+
+func (api *EventAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
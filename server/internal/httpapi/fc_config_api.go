@@ -3,11 +3,13 @@ package httpapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/auth"
 	"github.com/johnrirwin/flyingforge/internal/betaflight"
 	"github.com/johnrirwin/flyingforge/internal/database"
@@ -35,12 +37,14 @@ func NewFCConfigAPI(fcConfigStore *database.FCConfigStore, inventoryStore *datab
 	}
 }
 
-// RegisterRoutes registers FC config routes on the given mux
-func (api *FCConfigAPI) RegisterRoutes(mux *http.ServeMux, corsMiddleware func(http.HandlerFunc) http.HandlerFunc) {
-	mux.HandleFunc("/api/fc-configs", corsMiddleware(api.authMiddleware.RequireAuth(api.handleFCConfigs)))
-	mux.HandleFunc("/api/fc-configs/", corsMiddleware(api.authMiddleware.RequireAuth(api.handleFCConfigItem)))
-	// Aircraft tuning routes use different paths to avoid conflicts
-	mux.HandleFunc("/api/tuning/aircraft/", corsMiddleware(api.authMiddleware.RequireAuth(api.handleAircraftTuningRoutes)))
+// Routes returns the FC config route table
+func (api *FCConfigAPI) Routes() []Route {
+	return []Route{
+		{Pattern: "/api/fc-configs", Access: AccessUser, Handler: api.handleFCConfigs},
+		{Pattern: "/api/fc-configs/", Access: AccessUser, Handler: api.handleFCConfigItem},
+		// Aircraft tuning routes use different paths to avoid conflicts
+		{Pattern: "/api/tuning/aircraft/", Access: AccessUser, Handler: api.handleAircraftTuningRoutes},
+	}
 }
 
 // handleAircraftTuningRoutes handles tuning routes under /api/tuning/aircraft/
@@ -50,7 +54,7 @@ func (api *FCConfigAPI) handleAircraftTuningRoutes(w http.ResponseWriter, r *htt
 	parts := strings.Split(path, "/")
 
 	if len(parts) == 0 || parts[0] == "" {
-		http.Error(w, "Aircraft ID required", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Aircraft ID required")
 		return
 	}
 
@@ -64,7 +68,7 @@ func (api *FCConfigAPI) handleAircraftTuningRoutes(w http.ResponseWriter, r *htt
 		case http.MethodPost:
 			api.createTuningSnapshot(w, r, aircraftID)
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		}
 		return
 	}
@@ -74,7 +78,7 @@ func (api *FCConfigAPI) handleAircraftTuningRoutes(w http.ResponseWriter, r *htt
 	case http.MethodGet:
 		api.getAircraftTuning(w, r, aircraftID)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -86,7 +90,7 @@ func (api *FCConfigAPI) handleFCConfigs(w http.ResponseWriter, r *http.Request)
 	case http.MethodPost:
 		api.createFCConfig(w, r)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -97,12 +101,35 @@ func (api *FCConfigAPI) handleFCConfigItem(w http.ResponseWriter, r *http.Reques
 	parts := strings.Split(path, "/")
 
 	if len(parts) == 0 || parts[0] == "" {
-		http.Error(w, "Config ID required", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Config ID required")
 		return
 	}
 
 	configID := parts[0]
 
+	if len(parts) >= 2 && parts[1] == "osd" {
+		if len(parts) >= 3 && parts[2] == "preview" {
+			// /api/fc-configs/{id}/osd/preview
+			if r.Method != http.MethodGet {
+				apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+				return
+			}
+			api.previewOSDLayout(w, r, configID)
+			return
+		}
+
+		// /api/fc-configs/{id}/osd
+		switch r.Method {
+		case http.MethodGet:
+			api.exportOSDLayout(w, r, configID)
+		case http.MethodPut:
+			api.importOSDLayout(w, r, configID)
+		default:
+			apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
 	switch r.Method {
 	case http.MethodGet:
 		api.getFCConfig(w, r, configID)
@@ -111,7 +138,7 @@ func (api *FCConfigAPI) handleFCConfigItem(w http.ResponseWriter, r *http.Reques
 	case http.MethodDelete:
 		api.deleteFCConfig(w, r, configID)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -173,6 +200,7 @@ func (api *FCConfigAPI) createFCConfig(w http.ResponseWriter, r *http.Request) {
 		ParseStatus:     result.ParseStatus,
 		ParseWarnings:   result.ParseWarnings,
 		ParsedTuning:    result.ParsedTuning,
+		OSDLayout:       result.OSDLayout,
 	}
 
 	if err := api.fcConfigStore.SaveConfig(ctx, userID, config); err != nil {
@@ -285,8 +313,8 @@ func (api *FCConfigAPI) deleteFCConfig(w http.ResponseWriter, r *http.Request, c
 	defer cancel()
 
 	if err := api.fcConfigStore.DeleteConfig(ctx, configID, userID); err != nil {
-		if err.Error() == "config not found" {
-			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "Config not found"})
+		if errors.Is(err, database.ErrFCConfigNotFound) {
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "Config not found", "code": string(apierror.FCConfigNotFound)})
 			return
 		}
 		api.logger.Error("Failed to delete FC config", logging.WithField("error", err.Error()))
@@ -297,6 +325,126 @@ func (api *FCConfigAPI) deleteFCConfig(w http.ResponseWriter, r *http.Request, c
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// exportOSDLayout returns a config's OSD layout with the CLI commands that restore it
+func (api *FCConfigAPI) exportOSDLayout(w http.ResponseWriter, r *http.Request, configID string) {
+	config, ok := api.getConfigWithOSDLayout(w, r, configID)
+	if !ok {
+		return
+	}
+
+	api.writeJSON(w, http.StatusOK, models.OSDLayoutExport{
+		ConfigID: config.ID,
+		Layout:   config.OSDLayout,
+		CLI:      betaflight.OSDLayoutCLI(config.OSDLayout),
+	})
+}
+
+// importOSDLayout replaces a config's OSD layout with another of the user's
+// configs' layout, or with a layout from the request body
+func (api *FCConfigAPI) importOSDLayout(w http.ResponseWriter, r *http.Request, configID string) {
+	userID := auth.GetUserID(r.Context())
+
+	var req models.ImportOSDLayoutParams
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+		return
+	}
+	if (req.SourceConfigID == "") == (req.Layout == nil) {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Provide either sourceConfigId or layout"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	layout := req.Layout
+	if req.SourceConfigID != "" {
+		source, err := api.fcConfigStore.GetConfig(ctx, req.SourceConfigID, userID)
+		if err != nil {
+			api.logger.Error("Failed to get source FC config", logging.WithField("error", err.Error()))
+			api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "Failed to get source config"})
+			return
+		}
+		if source == nil {
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "Source config not found"})
+			return
+		}
+		if source.OSDLayout == nil {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Source config has no OSD layout"})
+			return
+		}
+		layout = source.OSDLayout
+	}
+
+	layout, err := betaflight.NormalizeOSDLayout(layout)
+	if err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid OSD layout: " + err.Error()})
+		return
+	}
+
+	config, err := api.fcConfigStore.UpdateOSDLayout(ctx, configID, userID, layout)
+	if err != nil {
+		api.logger.Error("Failed to update OSD layout", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "Failed to update OSD layout"})
+		return
+	}
+
+	if config == nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "Config not found"})
+		return
+	}
+
+	api.writeJSON(w, http.StatusOK, config)
+}
+
+// previewOSDLayout renders a config's OSD layout as a text grid for one OSD profile
+func (api *FCConfigAPI) previewOSDLayout(w http.ResponseWriter, r *http.Request, configID string) {
+	profile := 1
+	if p := r.URL.Query().Get("profile"); p != "" {
+		parsed, err := strconv.Atoi(p)
+		if err != nil || !betaflight.ValidOSDProfile(parsed) {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "profile must be 1, 2 or 3"})
+			return
+		}
+		profile = parsed
+	}
+
+	config, ok := api.getConfigWithOSDLayout(w, r, configID)
+	if !ok {
+		return
+	}
+
+	api.writeJSON(w, http.StatusOK, betaflight.RenderOSDPreview(config.OSDLayout, profile))
+}
+
+// getConfigWithOSDLayout loads a config for the OSD endpoints, writing the
+// error response when it's missing or has no layout
+func (api *FCConfigAPI) getConfigWithOSDLayout(w http.ResponseWriter, r *http.Request, configID string) (*models.FlightControllerConfig, bool) {
+	userID := auth.GetUserID(r.Context())
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	config, err := api.fcConfigStore.GetConfig(ctx, configID, userID)
+	if err != nil {
+		api.logger.Error("Failed to get FC config", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "Failed to get config"})
+		return nil, false
+	}
+
+	if config == nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "Config not found"})
+		return nil, false
+	}
+
+	if config.OSDLayout == nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "Config has no OSD layout"})
+		return nil, false
+	}
+
+	return config, true
+}
+
 // getAircraftTuning returns the latest tuning data for an aircraft
 func (api *FCConfigAPI) getAircraftTuning(w http.ResponseWriter, r *http.Request, aircraftID string) {
 	userID := auth.GetUserID(r.Context())
@@ -480,7 +628,5 @@ func (api *FCConfigAPI) createTuningSnapshotFromConfig(ctx context.Context, user
 
 // writeJSON writes a JSON response
 func (api *FCConfigAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+	writeJSON(w, status, data)
 }
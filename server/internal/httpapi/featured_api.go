@@ -0,0 +1,72 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/featured"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// FeaturedAPI serves the public list of editorially featured content
+type FeaturedAPI struct {
+	featuredSvc *featured.Service
+	logger      *logging.Logger
+}
+
+// NewFeaturedAPI creates a new featured content API handler
+func NewFeaturedAPI(featuredSvc *featured.Service, logger *logging.Logger) *FeaturedAPI {
+	return &FeaturedAPI{
+		featuredSvc: featuredSvc,
+		logger:      logger,
+	}
+}
+
+// Routes returns the featured content route table
+func (api *FeaturedAPI) Routes() []Route {
+	return []Route{
+		// Public - consumed by the home page
+		{Pattern: "/api/featured", Access: AccessPublic, Handler: api.handleFeatured},
+	}
+}
+
+// handleFeatured handles GET /api/featured?type=build|gear&limit=N
+func (api *FeaturedAPI) handleFeatured(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if r.Method != http.MethodGet {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	query := r.URL.Query()
+	contentType := models.FeaturedContentType(strings.ToLower(strings.TrimSpace(query.Get("type"))))
+	response, err := api.featuredSvc.Active(ctx, contentType, parseIntQuery(query.Get("limit"), 0))
+	if err != nil {
+		var svcErr *featured.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+			return
+		}
+		api.logger.Error("List featured content failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to load featured content"})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+func (api *FeaturedAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
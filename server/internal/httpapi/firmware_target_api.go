@@ -0,0 +1,84 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/fwtargets"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+)
+
+// FirmwareTargetAPI serves the Betaflight board target registry
+type FirmwareTargetAPI struct {
+	fwTargetSvc *fwtargets.Service
+	logger      *logging.Logger
+}
+
+// NewFirmwareTargetAPI creates a new firmware target API handler
+func NewFirmwareTargetAPI(fwTargetSvc *fwtargets.Service, logger *logging.Logger) *FirmwareTargetAPI {
+	return &FirmwareTargetAPI{
+		fwTargetSvc: fwTargetSvc,
+		logger:      logger,
+	}
+}
+
+// Routes returns the firmware target route table
+func (api *FirmwareTargetAPI) Routes() []Route {
+	return []Route{
+		{Method: http.MethodGet, Pattern: "/api/firmware-targets", Access: AccessPublic, Handler: api.handleSearchTargets},
+		{Method: http.MethodGet, Pattern: "/api/firmware-targets/{target}", Access: AccessPublic, Handler: api.handleGetTarget},
+	}
+}
+
+// handleSearchTargets handles GET /api/firmware-targets?q=
+func (api *FirmwareTargetAPI) handleSearchTargets(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "limit must be a positive integer"})
+			return
+		}
+		limit = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := api.fwTargetSvc.Search(ctx, r.URL.Query().Get("q"), limit)
+	if err != nil {
+		api.logger.Error("Failed to search firmware targets", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to search firmware targets"})
+		return
+	}
+
+	// The registry only changes when the daily sync runs
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+// handleGetTarget handles GET /api/firmware-targets/{target}
+func (api *FirmwareTargetAPI) handleGetTarget(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	target, err := api.fwTargetSvc.Get(ctx, r.PathValue("target"))
+	if err != nil {
+		api.logger.Error("Failed to get firmware target", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to get firmware target"})
+		return
+	}
+	if target == nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown firmware target"})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	api.writeJSON(w, http.StatusOK, target)
+}
+
+func (api *FirmwareTargetAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
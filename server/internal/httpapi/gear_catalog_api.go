@@ -9,68 +9,106 @@ import (
 	"strings"
 	"time"
 
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/catalogrules"
 	"github.com/johnrirwin/flyingforge/internal/database"
 	"github.com/johnrirwin/flyingforge/internal/images"
 	"github.com/johnrirwin/flyingforge/internal/logging"
 	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/reputation"
+	"github.com/johnrirwin/flyingforge/internal/seo"
+	"github.com/johnrirwin/flyingforge/internal/units"
+	"github.com/johnrirwin/flyingforge/internal/views"
 )
 
 // GearCatalogAPI handles HTTP API requests for the gear catalog
 type GearCatalogAPI struct {
 	catalogStore   *database.GearCatalogStore
+	userStore      *database.UserStore
 	imageSvc       *images.Service
+	seoSvc         *seo.Service
+	publishRules   *catalogrules.Engine
+	reputation     *reputation.Service
+	viewSvc        *views.Service
+	clientIP       func(r *http.Request) string
 	authMiddleware *auth.Middleware
 	logger         *logging.Logger
 }
 
+// catalogItemResponse is a catalog item with its schema.org Product JSON-LD
+// attached for published items
+type catalogItemResponse struct {
+	*models.GearCatalogItem
+	StructuredData map[string]interface{} `json:"structuredData,omitempty"`
+}
+
 // NewGearCatalogAPI creates a new gear catalog API handler
-func NewGearCatalogAPI(catalogStore *database.GearCatalogStore, imageSvc *images.Service, authMiddleware *auth.Middleware, logger *logging.Logger) *GearCatalogAPI {
+func NewGearCatalogAPI(catalogStore *database.GearCatalogStore, userStore *database.UserStore, imageSvc *images.Service, seoSvc *seo.Service, publishRules *catalogrules.Engine, reputationSvc *reputation.Service, viewSvc *views.Service, clientIP func(r *http.Request) string, authMiddleware *auth.Middleware, logger *logging.Logger) *GearCatalogAPI {
 	return &GearCatalogAPI{
 		catalogStore:   catalogStore,
+		userStore:      userStore,
 		imageSvc:       imageSvc,
+		seoSvc:         seoSvc,
+		publishRules:   publishRules,
+		reputation:     reputationSvc,
+		viewSvc:        viewSvc,
+		clientIP:       clientIP,
 		authMiddleware: authMiddleware,
 		logger:         logger,
 	}
 }
 
-// RegisterRoutes registers gear catalog routes on the given mux
-func (api *GearCatalogAPI) RegisterRoutes(mux *http.ServeMux, corsMiddleware func(http.HandlerFunc) http.HandlerFunc) {
+// Routes returns the gear catalog route table
+func (api *GearCatalogAPI) Routes() []Route {
 	if api.authMiddleware == nil {
 		api.logger.Error("Gear Catalog API routes not registered: authMiddleware is nil")
-		return
+		return nil
+	}
+
+	return []Route{
+		// Public routes (read-only access to the shared gear catalog)
+		// These are intentionally unauthenticated to allow users to browse/search
+		// the crowd-sourced gear database without requiring login. A signed-in
+		// caller gets specs in their preferred units.
+		{Method: http.MethodGet, Pattern: "/api/gear-catalog/search", Access: AccessOptional, Handler: api.handleSearch},
+		{Method: http.MethodGet, Pattern: "/api/gear-catalog/popular", Access: AccessOptional, Handler: api.handleGetPopular},
+		{Method: http.MethodGet, Pattern: "/api/gear-catalog/lookup", Access: AccessOptional, Handler: api.handleLookupByKey},
+		{Method: http.MethodGet, Pattern: "/api/gear-catalog", Access: AccessOptional, Handler: api.handleSearch},
+		{Method: http.MethodGet, Pattern: "/api/gear-catalog/", Access: AccessOptional, Handler: api.handleCatalogItem},
+		{Method: http.MethodGet, Pattern: "/api/gear-catalog/{id}/image", Access: AccessPublic, Handler: api.handleGetGearImage},
+
+		// Authenticated routes
+		{Method: http.MethodPost, Pattern: "/api/gear-catalog", Access: AccessUser, Handler: api.createCatalogItem},
+		{Method: http.MethodPost, Pattern: "/api/gear-catalog/{id}/image", Access: AccessUser, Handler: api.handleUploadGearImage},
+		{Method: http.MethodPost, Pattern: "/api/gear-catalog/{id}/flag", Access: AccessUser, Handler: api.handleFlag},
+		{Method: http.MethodPost, Pattern: "/api/gear-catalog/near-matches", Access: AccessUser, Handler: api.handleNearMatches},
 	}
-
-	// Public routes (read-only access to the shared gear catalog)
-	// These are intentionally unauthenticated to allow users to browse/search
-	// the crowd-sourced gear database without requiring login
-	mux.HandleFunc("/api/gear-catalog/search", corsMiddleware(api.handleSearch))
-	mux.HandleFunc("/api/gear-catalog/popular", corsMiddleware(api.handleGetPopular))
-
-	// Mixed auth routes (GET is public, POST requires auth)
-	// GET: delegates to handleSearch (public read access)
-	// POST: requires authentication to create new catalog entries
-	mux.HandleFunc("/api/gear-catalog", corsMiddleware(api.handleCatalog))
-
-	// Authenticated routes
-	mux.HandleFunc("/api/gear-catalog/", corsMiddleware(api.handleCatalogItem))
-	mux.HandleFunc("/api/gear-catalog/near-matches", corsMiddleware(api.authMiddleware.RequireAuth(api.handleNearMatches)))
 }
 
 // handleSearch handles GET /api/gear-catalog/search
 func (api *GearCatalogAPI) handleSearch(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	query := r.URL.Query()
+	system, ok := api.unitSystem(w, r)
+	if !ok {
+		return
+	}
 
 	params := models.GearCatalogSearchParams{
 		Query:    query.Get("q"),
 		GearType: models.GearType(query.Get("gearType")),
 		Brand:    query.Get("brand"),
 	}
+	var ecosystemErr string
+	if params.RCProtocol, params.VideoSystem, ecosystemErr = parseEcosystemFilters(query); ecosystemErr != "" {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": ecosystemErr})
+		return
+	}
 
 	if limit := query.Get("limit"); limit != "" {
 		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
@@ -98,19 +136,65 @@ func (api *GearCatalogAPI) handleSearch(w http.ResponseWriter, r *http.Request)
 		})
 		return
 	}
+	localizeCatalogItems(response.Items, system)
 
 	api.writeJSON(w, http.StatusOK, response)
 }
 
+// handleLookupByKey handles GET /api/gear-catalog/lookup?canonicalKey=...
+// Resolves both current and legacy canonical keys so clients holding keys from
+// before a rekey still find their item.
+func (api *GearCatalogAPI) handleLookupByKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	key := strings.TrimSpace(r.URL.Query().Get("canonicalKey"))
+	if key == "" {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "canonicalKey is required"})
+		return
+	}
+	system, ok := api.unitSystem(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	item, err := api.catalogStore.GetByCanonicalKey(ctx, key)
+	if err != nil {
+		api.logger.Error("Gear catalog key lookup failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to look up catalog item"})
+		return
+	}
+	if item == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	response := catalogItemResponse{GearCatalogItem: item}
+	if api.seoSvc != nil {
+		response.StructuredData = api.seoSvc.Product(item)
+	}
+	item.Specs = units.LocalizeSpecs(item.Specs, system)
+	api.writeJSON(w, http.StatusOK, response)
+}
+
 // handleGetPopular handles GET /api/gear-catalog/popular
 func (api *GearCatalogAPI) handleGetPopular(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	query := r.URL.Query()
 	gearType := models.GearType(query.Get("gearType"))
+	system, ok := api.unitSystem(w, r)
+	if !ok {
+		return
+	}
 
 	limit := 10
 	if l := query.Get("limit"); l != "" {
@@ -135,44 +219,37 @@ func (api *GearCatalogAPI) handleGetPopular(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	localizeCatalogItems(items, system)
 	api.writeJSON(w, http.StatusOK, map[string]interface{}{
 		"items": items,
 	})
 }
 
-// handleCatalog handles GET/POST /api/gear-catalog
-func (api *GearCatalogAPI) handleCatalog(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodGet:
-		api.handleSearch(w, r)
-	case http.MethodPost:
-		api.authMiddleware.RequireAuth(api.createCatalogItem)(w, r)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-	}
-}
-
 // createCatalogItem handles POST /api/gear-catalog
 func (api *GearCatalogAPI) createCatalogItem(w http.ResponseWriter, r *http.Request) {
 	userID := auth.GetUserID(r.Context())
 
 	var params models.CreateGearCatalogParams
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
 	// Validate required fields
 	if params.GearType == "" {
-		http.Error(w, "gearType is required", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "gearType is required")
+		return
+	}
+	if !params.GearType.IsValid() {
+		apierror.WriteStatus(w, http.StatusBadRequest, "invalid gearType")
 		return
 	}
 	if params.Brand == "" {
-		http.Error(w, "brand is required", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "brand is required")
 		return
 	}
 	if params.Model == "" {
-		http.Error(w, "model is required", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "model is required")
 		return
 	}
 
@@ -181,6 +258,19 @@ func (api *GearCatalogAPI) createCatalogItem(w http.ResponseWriter, r *http.Requ
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
 	defer cancel()
 
+	// Daily submission limits rise with the submitter's reputation
+	if api.reputation != nil {
+		allowed, err := api.reputation.AllowSubmission(ctx, userID)
+		if err != nil {
+			api.logger.Warn("Failed to check submission limit", logging.WithField("error", err.Error()))
+		} else if !allowed {
+			api.writeJSON(w, http.StatusTooManyRequests, map[string]string{
+				"error": "daily catalog submission limit reached",
+			})
+			return
+		}
+	}
+
 	response, err := api.catalogStore.Create(ctx, userID, params)
 	if err != nil {
 		api.logger.Error("Failed to create catalog item", logging.WithField("error", err.Error()))
@@ -193,60 +283,50 @@ func (api *GearCatalogAPI) createCatalogItem(w http.ResponseWriter, r *http.Requ
 	status := http.StatusCreated
 	if response.Existing {
 		status = http.StatusOK
+	} else {
+		response.AutoPublished = api.evaluatePublishRules(ctx, response.Item, models.CatalogPublishOnSubmit)
 	}
 
 	api.writeJSON(w, status, response)
 }
 
-// handleCatalogItem handles GET/POST /api/gear-catalog/{id}
+// handleCatalogItem handles GET /api/gear-catalog/{id}
 func (api *GearCatalogAPI) handleCatalogItem(w http.ResponseWriter, r *http.Request) {
-	// Extract ID from path
-	path := r.URL.Path
-	id := path[len("/api/gear-catalog/"):]
-	if id == "" || id == "search" || id == "popular" || id == "near-matches" {
+	id := strings.TrimPrefix(r.URL.Path, "/api/gear-catalog/")
+	if id == "" || strings.Contains(id, "/") {
 		http.NotFound(w, r)
 		return
 	}
+	api.getCatalogItem(w, r, id)
+}
 
-	// Handle image endpoint (public, no auth required)
-	if strings.HasSuffix(id, "/image") {
-		id = strings.TrimSuffix(id, "/image")
-		switch r.Method {
-		case http.MethodGet:
-			api.getGearImage(w, r, id)
-		case http.MethodPost:
-			api.authMiddleware.RequireAuth(func(w http.ResponseWriter, r *http.Request) {
-				api.uploadGearImage(w, r, id)
-			})(w, r)
-		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
+// handleGetGearImage handles GET /api/gear-catalog/{id}/image
+func (api *GearCatalogAPI) handleGetGearImage(w http.ResponseWriter, r *http.Request) {
+	index, ok := parseImageIndex(r)
+	if !ok {
+		apierror.WriteStatus(w, http.StatusBadRequest, "index must be a non-negative integer")
 		return
 	}
+	api.getGearImage(w, r, r.PathValue("id"), index)
+}
 
-	// Handle flag endpoint
-	if len(id) > 5 && id[len(id)-5:] == "/flag" {
-		id = id[:len(id)-5]
-		if r.Method == http.MethodPost {
-			api.authMiddleware.RequireAuth(func(w http.ResponseWriter, r *http.Request) {
-				api.flagCatalogItem(w, r, id)
-			})(w, r)
-			return
-		}
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
+// handleUploadGearImage handles POST /api/gear-catalog/{id}/image
+func (api *GearCatalogAPI) handleUploadGearImage(w http.ResponseWriter, r *http.Request) {
+	api.uploadGearImage(w, r, r.PathValue("id"))
+}
 
-	switch r.Method {
-	case http.MethodGet:
-		api.getCatalogItem(w, r, id)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-	}
+// handleFlag handles POST /api/gear-catalog/{id}/flag
+func (api *GearCatalogAPI) handleFlag(w http.ResponseWriter, r *http.Request) {
+	api.flagCatalogItem(w, r, r.PathValue("id"))
 }
 
 // getCatalogItem handles GET /api/gear-catalog/{id}
 func (api *GearCatalogAPI) getCatalogItem(w http.ResponseWriter, r *http.Request, id string) {
+	system, ok := api.unitSystem(w, r)
+	if !ok {
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
 	defer cancel()
 
@@ -266,6 +346,16 @@ func (api *GearCatalogAPI) getCatalogItem(w http.ResponseWriter, r *http.Request
 		http.NotFound(w, r)
 		return
 	}
+	if api.imageSvc != nil {
+		item.Images, err = api.imageSvc.Images(ctx, models.ImageEntityGear, item.ID, item.ImageAssetID, "/api/gear-catalog/"+item.ID+"/image")
+		if err != nil {
+			api.logger.Warn("Failed to list gear images", logging.WithField("error", err.Error()))
+		}
+	}
+	if models.NormalizeCatalogStatus(item.Status) == models.CatalogStatusPublished {
+		api.viewSvc.Record(r.Context(), models.ViewTargetGear, item.ID, viewerFromRequest(r, api.clientIP(r)))
+	}
+	item.Specs = units.LocalizeSpecs(item.Specs, system)
 
 	api.writeJSON(w, http.StatusOK, item)
 }
@@ -276,7 +366,7 @@ func (api *GearCatalogAPI) flagCatalogItem(w http.ResponseWriter, r *http.Reques
 		Reason string `json:"reason"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
@@ -309,7 +399,7 @@ func (api *GearCatalogAPI) flagCatalogItem(w http.ResponseWriter, r *http.Reques
 // handleNearMatches handles POST /api/gear-catalog/near-matches
 func (api *GearCatalogAPI) handleNearMatches(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -320,12 +410,12 @@ func (api *GearCatalogAPI) handleNearMatches(w http.ResponseWriter, r *http.Requ
 		Threshold float64         `json:"threshold,omitempty"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
 	if body.GearType == "" || body.Brand == "" || body.Model == "" {
-		http.Error(w, "gearType, brand, and model are required", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "gearType, brand, and model are required")
 		return
 	}
 
@@ -346,41 +436,81 @@ func (api *GearCatalogAPI) handleNearMatches(w http.ResponseWriter, r *http.Requ
 	})
 }
 
+// unitSystem returns the units to show specs in: the units query parameter,
+// else the signed-in caller's preference, else metric. It writes a 400 and
+// returns false for an unknown system.
+func (api *GearCatalogAPI) unitSystem(w http.ResponseWriter, r *http.Request) (models.UnitSystem, bool) {
+	if value := r.URL.Query().Get("units"); value != "" {
+		system := models.UnitSystem(value)
+		if !system.Valid() {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "units must be 'metric' or 'imperial'"})
+			return "", false
+		}
+		return system, true
+	}
+
+	userID := auth.GetUserID(r.Context())
+	if userID == "" || api.userStore == nil {
+		return models.UnitSystemMetric, true
+	}
+	system, err := api.userStore.GetUnitSystem(r.Context(), userID)
+	if err != nil {
+		api.logger.Warn("Failed to get unit system", logging.WithField("error", err.Error()))
+		return models.UnitSystemMetric, true
+	}
+	return system, true
+}
+
+// localizeCatalogItems formats the items' specs in the given unit system
+func localizeCatalogItems(items []models.GearCatalogItem, system models.UnitSystem) {
+	for i := range items {
+		items[i].Specs = units.LocalizeSpecs(items[i].Specs, system)
+	}
+}
+
 func (api *GearCatalogAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+	writeJSON(w, status, data)
 }
 
-// getGearImage serves the uploaded image for a gear catalog item
+// getGearImage serves the uploaded image for a gear catalog item, or with
+// ?index= one of its other images
 // Public endpoint - no auth required
-func (api *GearCatalogAPI) getGearImage(w http.ResponseWriter, r *http.Request, id string) {
+func (api *GearCatalogAPI) getGearImage(w http.ResponseWriter, r *http.Request, id string, index *int) {
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
 	defer cancel()
 
-	imageData, imageType, err := api.catalogStore.GetImage(ctx, id)
+	var (
+		imageData []byte
+		imageType string
+		err       error
+	)
+	if index != nil && api.imageSvc != nil {
+		imageData, err = api.imageSvc.LoadAt(ctx, models.ImageEntityGear, id, *index)
+		if isGalleryRequestError(err) {
+			imageData, err = nil, nil
+		}
+	} else if index == nil || *index == 0 {
+		imageData, imageType, err = api.catalogStore.GetImage(ctx, id)
+	}
 	if err != nil {
 		api.logger.Error("Failed to get gear image", logging.WithFields(map[string]interface{}{
 			"gearId": id,
 			"error":  err.Error(),
 		}))
-		http.Error(w, "Failed to get image", http.StatusInternalServerError)
+		apierror.WriteStatus(w, http.StatusInternalServerError, "Failed to get image")
 		return
 	}
 
 	if imageData == nil {
-		http.Error(w, "No image for this gear item", http.StatusNotFound)
+		apierror.WriteStatus(w, http.StatusNotFound, "No image for this gear item")
 		return
 	}
 	if imageType == "" {
 		imageType = http.DetectContentType(imageData)
 	}
 
-	// Set caching headers (images cached for 60 seconds - allows quick refresh after admin updates)
-	w.Header().Set("Cache-Control", "public, max-age=60")
-	w.Header().Set("Content-Type", imageType)
-	w.Header().Set("Content-Length", strconv.Itoa(len(imageData)))
-	w.Write(imageData)
+	// Images cached for 60 seconds - allows quick refresh after admin updates
+	writeImage(w, r, api.imageSvc, imageData, imageType, "public, max-age=60")
 }
 
 // uploadGearImage persists a previously moderated user-submitted gear image.
@@ -477,8 +607,33 @@ func (api *GearCatalogAPI) uploadGearImage(w http.ResponseWriter, r *http.Reques
 		_ = api.imageSvc.Delete(ctx, previousAssetID)
 	}
 
+	// The image may complete a submission a publish rule was waiting on
+	if api.publishRules != nil {
+		if item, err := api.catalogStore.Get(ctx, id); err == nil {
+			api.evaluatePublishRules(ctx, item, models.CatalogPublishOnImage)
+		}
+	}
+
 	api.writeJSON(w, http.StatusOK, map[string]string{
 		"status":  string(models.ImageModerationApproved),
 		"message": "Image uploaded and queued for admin review",
 	})
 }
+
+// evaluatePublishRules lets the auto-publish rules publish a pending
+// submission. A failure leaves the item pending for an admin, so it is
+// logged rather than returned.
+func (api *GearCatalogAPI) evaluatePublishRules(ctx context.Context, item *models.GearCatalogItem, trigger models.CatalogPublishTrigger) *models.CatalogPublishDecision {
+	if api.publishRules == nil {
+		return nil
+	}
+	decision, err := api.publishRules.Evaluate(ctx, item, trigger)
+	if err != nil {
+		api.logger.Warn("Failed to evaluate publish rules", logging.WithFields(map[string]interface{}{
+			"item_id": item.ID,
+			"error":   err.Error(),
+		}))
+		return nil
+	}
+	return decision
+}
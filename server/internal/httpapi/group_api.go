@@ -0,0 +1,326 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/groups"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// GroupAPI handles HTTP API requests for groups and their shared fleets
+type GroupAPI struct {
+	groupSvc       *groups.Service
+	authMiddleware *auth.Middleware
+	logger         *logging.Logger
+}
+
+// NewGroupAPI creates a new group API handler
+func NewGroupAPI(groupSvc *groups.Service, authMiddleware *auth.Middleware, logger *logging.Logger) *GroupAPI {
+	return &GroupAPI{
+		groupSvc:       groupSvc,
+		authMiddleware: authMiddleware,
+		logger:         logger,
+	}
+}
+
+// Routes returns the group route table
+func (api *GroupAPI) Routes() []Route {
+	return []Route{
+		{Pattern: "/api/groups", Access: AccessUser, Handler: api.handleGroups},
+		{Pattern: "/api/groups/invitations", Access: AccessUser, Handler: api.handleMyInvitations},
+		{Pattern: "/api/groups/invitations/", Access: AccessUser, Handler: api.handleInvitationResponse},
+		{Pattern: "/api/groups/", Access: AccessUser, Handler: api.handleGroupItem},
+	}
+}
+
+// handleGroups handles GET/POST /api/groups
+func (api *GroupAPI) handleGroups(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserID(r.Context())
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	switch r.Method {
+	case http.MethodGet:
+		list, err := api.groupSvc.List(ctx, userID)
+		if err != nil {
+			api.writeServiceError(w, "List groups failed", err)
+			return
+		}
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"groups": list})
+	case http.MethodPost:
+		var params models.CreateGroupParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		group, err := api.groupSvc.Create(ctx, userID, params)
+		if err != nil {
+			api.writeServiceError(w, "Create group failed", err)
+			return
+		}
+		api.writeJSON(w, http.StatusCreated, group)
+	default:
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// handleMyInvitations handles GET /api/groups/invitations
+func (api *GroupAPI) handleMyInvitations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	invitations, err := api.groupSvc.ListInvitations(ctx, auth.GetUserID(r.Context()))
+	if err != nil {
+		api.writeServiceError(w, "List group invitations failed", err)
+		return
+	}
+	api.writeJSON(w, http.StatusOK, map[string]interface{}{"invitations": invitations})
+}
+
+// handleInvitationResponse handles POST /api/groups/invitations/{id}/accept
+// and /decline
+func (api *GroupAPI) handleInvitationResponse(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/groups/invitations/"), "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || (parts[1] != "accept" && parts[1] != "decline") {
+		apierror.WriteStatus(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := api.groupSvc.RespondToInvitation(ctx, parts[0], auth.GetUserID(r.Context()), parts[1] == "accept", time.Now()); err != nil {
+		api.writeServiceError(w, "Respond to group invitation failed", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleGroupItem routes /api/groups/{id} and its sub-resources
+func (api *GroupAPI) handleGroupItem(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/groups/"), "/"), "/")
+	if parts[0] == "" || len(parts) > 3 {
+		apierror.WriteStatus(w, http.StatusNotFound, "Not found")
+		return
+	}
+	groupID := parts[0]
+	userID := auth.GetUserID(r.Context())
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	r = r.WithContext(ctx)
+
+	if len(parts) == 1 {
+		api.handleGroup(w, r, groupID, userID)
+		return
+	}
+
+	itemID := ""
+	if len(parts) == 3 {
+		itemID = parts[2]
+	}
+	switch parts[1] {
+	case "members":
+		api.handleMember(w, r, groupID, userID, itemID)
+	case "invitations":
+		api.handleGroupInvitations(w, r, groupID, userID, itemID)
+	case "aircraft":
+		api.handleFleet(w, r, groupID, userID, itemID, "aircraft",
+			func(ctx context.Context) (interface{}, error) { return api.groupSvc.ListAircraft(ctx, groupID, userID) },
+			api.groupSvc.ShareAircraft, api.groupSvc.UnshareAircraft)
+	case "batteries":
+		api.handleFleet(w, r, groupID, userID, itemID, "batteries",
+			func(ctx context.Context) (interface{}, error) {
+				return api.groupSvc.ListBatteries(ctx, groupID, userID)
+			},
+			api.groupSvc.ShareBattery, api.groupSvc.UnshareBattery)
+	case "feed":
+		api.handleFeed(w, r, groupID, userID)
+	default:
+		apierror.WriteStatus(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// handleGroup handles GET/PATCH/DELETE /api/groups/{id}
+func (api *GroupAPI) handleGroup(w http.ResponseWriter, r *http.Request, groupID, userID string) {
+	switch r.Method {
+	case http.MethodGet:
+		details, err := api.groupSvc.Get(r.Context(), groupID, userID)
+		if err != nil {
+			api.writeServiceError(w, "Get group failed", err)
+			return
+		}
+		api.writeJSON(w, http.StatusOK, details)
+	case http.MethodPatch, http.MethodPut:
+		var params models.UpdateGroupParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		group, err := api.groupSvc.Update(r.Context(), groupID, userID, params)
+		if err != nil {
+			api.writeServiceError(w, "Update group failed", err)
+			return
+		}
+		api.writeJSON(w, http.StatusOK, group)
+	case http.MethodDelete:
+		if err := api.groupSvc.Delete(r.Context(), groupID, userID); err != nil {
+			api.writeServiceError(w, "Delete group failed", err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// handleMember handles PATCH/DELETE /api/groups/{id}/members/{userId}
+func (api *GroupAPI) handleMember(w http.ResponseWriter, r *http.Request, groupID, userID, memberID string) {
+	if memberID == "" {
+		apierror.WriteStatus(w, http.StatusNotFound, "Not found")
+		return
+	}
+	switch r.Method {
+	case http.MethodPatch:
+		var body struct {
+			Role models.GroupRole `json:"role"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if err := api.groupSvc.SetMemberRole(r.Context(), groupID, userID, memberID, body.Role); err != nil {
+			api.writeServiceError(w, "Set group member role failed", err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		if err := api.groupSvc.RemoveMember(r.Context(), groupID, userID, memberID); err != nil {
+			api.writeServiceError(w, "Remove group member failed", err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// handleGroupInvitations handles POST /api/groups/{id}/invitations and
+// DELETE /api/groups/{id}/invitations/{invitationId}
+func (api *GroupAPI) handleGroupInvitations(w http.ResponseWriter, r *http.Request, groupID, userID, invitationID string) {
+	switch {
+	case r.Method == http.MethodPost && invitationID == "":
+		var params models.InviteGroupMemberParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		invitation, err := api.groupSvc.Invite(r.Context(), groupID, userID, params, time.Now())
+		if err != nil {
+			api.writeServiceError(w, "Invite group member failed", err)
+			return
+		}
+		api.writeJSON(w, http.StatusCreated, invitation)
+	case r.Method == http.MethodDelete && invitationID != "":
+		if err := api.groupSvc.RevokeInvitation(r.Context(), groupID, invitationID, userID); err != nil {
+			api.writeServiceError(w, "Revoke group invitation failed", err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// handleFleet handles a shared fleet list: GET and POST on the collection,
+// DELETE on an item. key names the list in responses, e.g. "aircraft".
+func (api *GroupAPI) handleFleet(w http.ResponseWriter, r *http.Request, groupID, userID, itemID, key string,
+	list func(ctx context.Context) (interface{}, error),
+	share, unshare func(ctx context.Context, groupID, userID, itemID string) error,
+) {
+	switch {
+	case r.Method == http.MethodGet && itemID == "":
+		items, err := list(r.Context())
+		if err != nil {
+			api.writeServiceError(w, "List group "+key+" failed", err)
+			return
+		}
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{key: items})
+	case r.Method == http.MethodPost && itemID == "":
+		var body struct {
+			ID string `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || strings.TrimSpace(body.ID) == "" {
+			apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if err := share(r.Context(), groupID, userID, strings.TrimSpace(body.ID)); err != nil {
+			api.writeServiceError(w, "Share "+key+" failed", err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete && itemID != "":
+		if err := unshare(r.Context(), groupID, userID, itemID); err != nil {
+			api.writeServiceError(w, "Unshare "+key+" failed", err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// handleFeed handles GET /api/groups/{id}/feed
+func (api *GroupAPI) handleFeed(w http.ResponseWriter, r *http.Request, groupID, userID string) {
+	if r.Method != http.MethodGet {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	feed, err := api.groupSvc.Feed(r.Context(), groupID, userID, limit, offset)
+	if err != nil {
+		api.writeServiceError(w, "Group feed failed", err)
+		return
+	}
+	api.writeJSON(w, http.StatusOK, feed)
+}
+
+// writeServiceError maps group service errors to HTTP statuses
+func (api *GroupAPI) writeServiceError(w http.ResponseWriter, message string, err error) {
+	var svcErr *groups.ServiceError
+	switch {
+	case errors.Is(err, groups.ErrGroupNotFound), errors.Is(err, groups.ErrInvitationNotFound):
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, groups.ErrForbidden):
+		api.writeJSON(w, http.StatusForbidden, map[string]string{"error": err.Error()})
+	case errors.As(err, &svcErr):
+		api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+	default:
+		api.logger.Error(message, logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal server error"})
+	}
+}
+
+func (api *GroupAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
@@ -0,0 +1,59 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/home"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+)
+
+// HomeAPI serves the composed home page
+type HomeAPI struct {
+	homeSvc *home.Service
+	logger  *logging.Logger
+}
+
+// NewHomeAPI creates a new home page API handler
+func NewHomeAPI(homeSvc *home.Service, logger *logging.Logger) *HomeAPI {
+	return &HomeAPI{
+		homeSvc: homeSvc,
+		logger:  logger,
+	}
+}
+
+// Routes returns the home page route table
+func (api *HomeAPI) Routes() []Route {
+	return []Route{
+		// Public, with announcement read state for signed-in users
+		{Method: http.MethodGet, Pattern: "/api/home", Access: AccessOptional, Handler: api.handleHome},
+	}
+}
+
+// handleHome handles GET /api/home
+func (api *HomeAPI) handleHome(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	userID := auth.GetUserID(r.Context())
+	response, err := api.homeSvc.Home(ctx, userID)
+	if err != nil {
+		api.logger.Error("Load home page failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to load home page"})
+		return
+	}
+
+	// Announcement read state is per user, so only anonymous responses can be shared
+	if userID == "" {
+		w.Header().Set("Cache-Control", "public, max-age=60")
+	} else {
+		w.Header().Set("Cache-Control", "private, no-store")
+	}
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+func (api *HomeAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
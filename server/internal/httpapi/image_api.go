@@ -2,13 +2,14 @@ package httpapi
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/auth"
 	"github.com/johnrirwin/flyingforge/internal/images"
 	"github.com/johnrirwin/flyingforge/internal/logging"
@@ -31,10 +32,32 @@ func NewImageAPI(imageSvc *images.Service, authMiddleware *auth.Middleware, logg
 	}
 }
 
-// RegisterRoutes registers image routes.
-func (api *ImageAPI) RegisterRoutes(mux *http.ServeMux, corsMiddleware func(http.HandlerFunc) http.HandlerFunc) {
-	mux.HandleFunc("/api/images/upload", corsMiddleware(api.authMiddleware.RequireAuth(api.handleUpload)))
-	mux.HandleFunc("/api/images/", corsMiddleware(api.handleGetImage))
+// Routes returns the image route table
+func (api *ImageAPI) Routes() []Route {
+	return []Route{
+		{Pattern: "/api/images/upload", Access: AccessUser, Handler: api.handleUpload},
+		{Pattern: "/api/images/usage", Access: AccessUser, Handler: api.handleUsage},
+		{Pattern: "/api/images/", Access: AccessPublic, Handler: api.handleGetImage},
+	}
+}
+
+// handleUsage handles GET /api/images/usage, reporting stored images against the user's quota.
+func (api *ImageAPI) handleUsage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	usage, err := api.imageSvc.Usage(ctx, auth.GetUserID(r.Context()))
+	if err != nil {
+		api.logger.Error("Failed to get image usage", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to get image usage"})
+		return
+	}
+	api.writeJSON(w, http.StatusOK, usage)
 }
 
 func (api *ImageAPI) handleUpload(w http.ResponseWriter, r *http.Request) {
@@ -43,7 +66,7 @@ func (api *ImageAPI) handleUpload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 
@@ -109,6 +132,7 @@ func (api *ImageAPI) handleUpload(w http.ResponseWriter, r *http.Request) {
 	if _, ok := detectAllowedImageContentType(imageData); !ok {
 		api.writeJSON(w, http.StatusBadRequest, map[string]string{
 			"status": "PENDING_REVIEW",
+			"code":   string(apierror.ImageInvalid),
 			"reason": "Only JPEG and PNG images are allowed",
 		})
 		return
@@ -119,6 +143,9 @@ func (api *ImageAPI) handleUpload(w http.ResponseWriter, r *http.Request) {
 
 	decision, uploadID, err := api.imageSvc.ModerateUpload(ctx, userID, entityType, imageData)
 	if err != nil {
+		if writeImageUploadError(w, err) {
+			return
+		}
 		api.logger.Error("image moderation upload failed", logging.WithField("error", err.Error()))
 		api.writeJSON(w, http.StatusInternalServerError, map[string]string{
 			"status": string(models.ImageModerationPendingReview),
@@ -146,14 +173,14 @@ func (api *ImageAPI) handleGetImage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if r.Method != http.MethodGet {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	id := strings.TrimPrefix(r.URL.Path, "/api/images/")
 	id = strings.TrimSuffix(id, "/")
 	if id == "" {
-		http.Error(w, "image id required", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "image id required")
 		return
 	}
 
@@ -163,31 +190,67 @@ func (api *ImageAPI) handleGetImage(w http.ResponseWriter, r *http.Request) {
 	asset, err := api.imageSvc.Load(ctx, id)
 	if err != nil {
 		api.logger.Error("failed to load image asset", logging.WithField("error", err.Error()))
-		http.Error(w, "image not found", http.StatusNotFound)
+		apierror.WriteStatus(w, http.StatusNotFound, "image not found")
 		return
 	}
 	if asset == nil || asset.Status != models.ImageModerationApproved || asset.EntityType != models.ImageEntityAvatar {
-		http.Error(w, "image not found", http.StatusNotFound)
+		apierror.WriteStatus(w, http.StatusNotFound, "image not found")
 		return
 	}
 
 	contentType, ok := detectAllowedImageContentType(asset.ImageBytes)
 	if !ok {
 		w.Header().Set("X-Content-Type-Options", "nosniff")
-		http.Error(w, "unsupported media type", http.StatusUnsupportedMediaType)
+		apierror.WriteStatus(w, http.StatusUnsupportedMediaType, "unsupported media type")
 		return
 	}
 
+	writeImage(w, r, api.imageSvc, asset.ImageBytes, contentType, "public, max-age=300")
+}
+
+// writeImage serves stored image bytes, transcoding to WebP/AVIF when the
+// client's Accept header allows it and transcoders are configured.
+func writeImage(w http.ResponseWriter, r *http.Request, imageSvc *images.Service, data []byte, contentType, cacheControl string) {
+	if imageSvc != nil {
+		data, contentType = imageSvc.Negotiate(r.Context(), r.Header.Get("Accept"), data, contentType)
+	}
+
 	w.Header().Set("X-Content-Type-Options", "nosniff")
 	w.Header().Set("Content-Type", contentType)
-	w.Header().Set("Content-Length", strconv.Itoa(len(asset.ImageBytes)))
-	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Add("Vary", "Accept")
 	w.WriteHeader(http.StatusOK)
-	w.Write(asset.ImageBytes)
+	_, _ = w.Write(data)
 }
 
 func (api *ImageAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(data)
+	writeJSON(w, status, data)
+}
+
+// writeImageUploadError writes the response for image pipeline errors that are
+// the uploader's to fix (quota exceeded, undecodable image) and reports whether
+// err was one of them.
+func writeImageUploadError(w http.ResponseWriter, err error) bool {
+	var quotaErr *images.QuotaExceededError
+	switch {
+	case errors.As(err, &quotaErr):
+		writeJSON(w, http.StatusForbidden, map[string]interface{}{
+			"status": models.ImageModerationRejected,
+			"code":   apierror.QuotaExceeded,
+			"reason": quotaErr.Error(),
+			"error":  quotaErr.Error(),
+			"usage":  quotaErr.Usage,
+		})
+		return true
+	case errors.Is(err, images.ErrInvalidImage):
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"status": models.ImageModerationRejected,
+			"code":   apierror.ImageInvalid,
+			"reason": "Image could not be read",
+			"error":  "image could not be read",
+		})
+		return true
+	}
+	return false
 }
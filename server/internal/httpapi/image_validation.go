@@ -1,8 +1,13 @@
 package httpapi
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/images"
+	"github.com/johnrirwin/flyingforge/internal/models"
 )
 
 var allowedImageContentTypes = map[string]struct{}{
@@ -23,3 +28,47 @@ func detectAllowedImageContentType(imageData []byte) (string, bool) {
 	_, ok := allowedImageContentTypes[contentType]
 	return contentType, ok
 }
+
+// parseImageIndex reads the optional ?index= position of a build or catalog
+// item image. It returns nil without an index, and ok=false if it's malformed.
+func parseImageIndex(r *http.Request) (*int, bool) {
+	raw := strings.TrimSpace(r.URL.Query().Get("index"))
+	if raw == "" {
+		return nil, true
+	}
+	index, err := strconv.Atoi(raw)
+	if err != nil || index < 0 {
+		return nil, false
+	}
+	return &index, true
+}
+
+// isGalleryRequestError reports whether err is an indexed image operation the
+// client got wrong, e.g. an index past the end or too many images.
+func isGalleryRequestError(err error) bool {
+	return errors.Is(err, images.ErrImageIndexOutOfRange) ||
+		errors.Is(err, images.ErrTooManyImages) ||
+		errors.Is(err, images.ErrDuplicateImage) ||
+		errors.Is(err, images.ErrGalleryUnavailable)
+}
+
+// parseImageBackground reads the optional ?background= of an admin upload
+// (white or transparent). ok=false means the value isn't supported.
+func parseImageBackground(r *http.Request) (models.ImageBackground, bool) {
+	background := models.ImageBackground(strings.ToLower(strings.TrimSpace(r.URL.Query().Get("background"))))
+	return background, models.IsValidImageBackground(background)
+}
+
+// imageBackgroundError maps a background replacement failure to a status
+// and message. ok=false means err is something else.
+func imageBackgroundError(err error) (int, string, bool) {
+	switch {
+	case errors.Is(err, images.ErrBackgroundRemovalUnavailable):
+		return http.StatusServiceUnavailable, "background removal unavailable", true
+	case errors.Is(err, images.ErrBackgroundRemovalFailed):
+		return http.StatusBadGateway, "background removal failed", true
+	case errors.Is(err, images.ErrEmptyProductShot):
+		return http.StatusUnprocessableEntity, "no product found after removing the background", true
+	}
+	return 0, "", false
+}
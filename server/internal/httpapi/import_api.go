@@ -0,0 +1,82 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/builds"
+	"github.com/johnrirwin/flyingforge/internal/importers"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ImportAPI handles importing parts lists from other sites into draft builds
+type ImportAPI struct {
+	importSvc      *importers.Service
+	authMiddleware *auth.Middleware
+	logger         *logging.Logger
+}
+
+// NewImportAPI creates a new import API handler
+func NewImportAPI(importSvc *importers.Service, authMiddleware *auth.Middleware, logger *logging.Logger) *ImportAPI {
+	return &ImportAPI{
+		importSvc:      importSvc,
+		authMiddleware: authMiddleware,
+		logger:         logger,
+	}
+}
+
+// Routes returns the import route table
+func (api *ImportAPI) Routes() []Route {
+	return []Route{
+		{Pattern: "/api/builds/import", Access: AccessUser, Handler: api.handleImport},
+	}
+}
+
+// handleImport handles POST /api/builds/import
+func (api *ImportAPI) handleImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var params models.ImportBuildParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Fetching the page and matching every part takes longer than most requests
+	ctx, cancel := context.WithTimeout(r.Context(), 45*time.Second)
+	defer cancel()
+
+	response, err := api.importSvc.Import(ctx, auth.GetUserID(r.Context()), params.URL)
+	if err != nil {
+		api.writeServiceError(w, "Import build failed", err)
+		return
+	}
+	api.writeJSON(w, http.StatusCreated, response)
+}
+
+func (api *ImportAPI) writeServiceError(w http.ResponseWriter, message string, err error) {
+	var importErr *importers.ServiceError
+	var buildErr *builds.ServiceError
+	switch {
+	case errors.As(err, &importErr):
+		api.writeJSON(w, http.StatusBadRequest, errorBody(importErr))
+	case errors.As(err, &buildErr):
+		api.writeJSON(w, apierror.StatusOf(buildErr, http.StatusBadRequest), errorBody(buildErr))
+	default:
+		api.logger.Error(message, logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal server error"})
+	}
+}
+
+func (api *ImportAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
@@ -0,0 +1,59 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// metaLocale is the language of the labels in metadata responses. Clients
+// that localize use each entry's labelKey instead.
+const metaLocale = "en"
+
+// MetaAPI serves static taxonomy metadata so clients don't hardcode it
+type MetaAPI struct {
+	logger *logging.Logger
+}
+
+// NewMetaAPI creates a new metadata API handler
+func NewMetaAPI(logger *logging.Logger) *MetaAPI {
+	return &MetaAPI{logger: logger}
+}
+
+// Routes returns the metadata route table
+func (api *MetaAPI) Routes() []Route {
+	return []Route{
+		{Pattern: "/api/meta/gear-types", Access: AccessPublic, Handler: api.handleGearTypes},
+		{Method: http.MethodGet, Pattern: "/api/meta/error-codes", Access: AccessPublic, Handler: api.handleErrorCodes},
+	}
+}
+
+// handleGearTypes handles GET /api/meta/gear-types
+func (api *MetaAPI) handleGearTypes(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if r.Method != http.MethodGet {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", metaLocale)
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	json.NewEncoder(w).Encode(models.GearTypesResponse{
+		Locale:              metaLocale,
+		GearTypes:           models.GearTypeInfos(),
+		EquipmentCategories: models.EquipmentCategoryInfos(),
+	})
+}
+
+// handleErrorCodes handles GET /api/meta/error-codes
+func (api *MetaAPI) handleErrorCodes(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	writeJSON(w, http.StatusOK, map[string]interface{}{"codes": apierror.Catalog()})
+}
@@ -0,0 +1,51 @@
+package httpapi
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// poolMetrics are the connection pool gauges and counters on GET /metrics,
+// in the Prometheus text format
+var poolMetrics = []struct {
+	name, kind, help string
+	value            func(models.DBPoolStats) float64
+}{
+	{"flyingforge_db_max_open_connections", "gauge", "Maximum open connections, 0 for unlimited", func(s models.DBPoolStats) float64 { return float64(s.MaxOpenConnections) }},
+	{"flyingforge_db_open_connections", "gauge", "Open connections, in use and idle", func(s models.DBPoolStats) float64 { return float64(s.OpenConnections) }},
+	{"flyingforge_db_in_use_connections", "gauge", "Connections in use", func(s models.DBPoolStats) float64 { return float64(s.InUse) }},
+	{"flyingforge_db_idle_connections", "gauge", "Idle connections", func(s models.DBPoolStats) float64 { return float64(s.Idle) }},
+	{"flyingforge_db_wait_count_total", "counter", "Connections waited for", func(s models.DBPoolStats) float64 { return float64(s.WaitCount) }},
+	{"flyingforge_db_wait_duration_seconds_total", "counter", "Time spent waiting for a connection", func(s models.DBPoolStats) float64 { return s.WaitDurationMs / 1000 }},
+	{"flyingforge_db_max_idle_closed_total", "counter", "Connections closed by the idle connection limit", func(s models.DBPoolStats) float64 { return float64(s.MaxIdleClosed) }},
+	{"flyingforge_db_max_idle_time_closed_total", "counter", "Connections closed for sitting idle too long", func(s models.DBPoolStats) float64 { return float64(s.MaxIdleTimeClosed) }},
+	{"flyingforge_db_max_lifetime_closed_total", "counter", "Connections closed at their maximum lifetime", func(s models.DBPoolStats) float64 { return float64(s.MaxLifetimeClosed) }},
+}
+
+// handleMetrics handles GET /metrics for Prometheus scrapers, which send the
+// metrics token as a bearer token
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.metricsToken)) != 1 {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	pools := s.dbPool.Stats()
+	var b strings.Builder
+	for _, metric := range poolMetrics {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.kind)
+		for _, pool := range pools {
+			fmt.Fprintf(&b, "%s{pool=%q} %g\n", metric.name, pool.Pool, metric.value(pool))
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write([]byte(b.String()))
+}
@@ -6,8 +6,10 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/auth"
 	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/images"
 	"github.com/johnrirwin/flyingforge/internal/logging"
 	"github.com/johnrirwin/flyingforge/internal/models"
 )
@@ -17,31 +19,35 @@ type PilotAPI struct {
 	userStore      *database.UserStore
 	aircraftStore  *database.AircraftStore
 	fcConfigStore  *database.FCConfigStore
+	imageSvc       *images.Service
 	authMiddleware *auth.Middleware
 	logger         *logging.Logger
 }
 
 // NewPilotAPI creates a new pilot API handler
-func NewPilotAPI(userStore *database.UserStore, aircraftStore *database.AircraftStore, fcConfigStore *database.FCConfigStore, authMiddleware *auth.Middleware, logger *logging.Logger) *PilotAPI {
+func NewPilotAPI(userStore *database.UserStore, aircraftStore *database.AircraftStore, fcConfigStore *database.FCConfigStore, imageSvc *images.Service, authMiddleware *auth.Middleware, logger *logging.Logger) *PilotAPI {
 	return &PilotAPI{
 		userStore:      userStore,
 		aircraftStore:  aircraftStore,
 		fcConfigStore:  fcConfigStore,
+		imageSvc:       imageSvc,
 		authMiddleware: authMiddleware,
 		logger:         logger,
 	}
 }
 
-// RegisterRoutes registers pilot routes on the given mux
-func (api *PilotAPI) RegisterRoutes(mux *http.ServeMux, corsMiddleware func(http.HandlerFunc) http.HandlerFunc) {
-	// Search pilots - requires auth
-	mux.HandleFunc("/api/pilots/search", corsMiddleware(api.authMiddleware.RequireAuth(api.handleSearch)))
-	// Discover pilots - requires auth
-	mux.HandleFunc("/api/pilots/discover", corsMiddleware(api.authMiddleware.RequireAuth(api.handleDiscover)))
-	// Public aircraft image - requires auth but checks owner's visibility settings
-	mux.HandleFunc("/api/pilots/aircraft/", corsMiddleware(api.authMiddleware.RequireAuth(api.handleAircraftImage)))
-	// Get pilot profile - requires auth
-	mux.HandleFunc("/api/pilots/", corsMiddleware(api.authMiddleware.RequireAuth(api.handlePilotProfile)))
+// Routes returns the pilot route table
+func (api *PilotAPI) Routes() []Route {
+	return []Route{
+		// Search pilots - requires auth
+		{Pattern: "/api/pilots/search", Access: AccessUser, Handler: api.handleSearch},
+		// Discover pilots - requires auth
+		{Pattern: "/api/pilots/discover", Access: AccessUser, Handler: api.handleDiscover},
+		// Public aircraft image - requires auth but checks owner's visibility settings
+		{Pattern: "/api/pilots/aircraft/", Access: AccessUser, Handler: api.handleAircraftImage},
+		// Get pilot profile - requires auth
+		{Pattern: "/api/pilots/", Access: AccessUser, Handler: api.handlePilotProfile},
+	}
 }
 
 // handleSearch handles GET /api/pilots/search?q=searchterm
@@ -51,7 +57,7 @@ func (api *PilotAPI) handleSearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -66,7 +72,7 @@ func (api *PilotAPI) handleSearch(w http.ResponseWriter, r *http.Request) {
 
 	// Require at least 2 characters for search
 	if len(query) < 2 {
-		api.writeError(w, http.StatusBadRequest, "query_too_short", "search query must be at least 2 characters")
+		api.writeError(w, http.StatusBadRequest, apierror.QueryTooShort, "search query must be at least 2 characters")
 		return
 	}
 
@@ -77,7 +83,7 @@ func (api *PilotAPI) handleSearch(w http.ResponseWriter, r *http.Request) {
 	pilots, err := api.userStore.SearchPilots(r.Context(), searchParams)
 	if err != nil {
 		api.logger.Error("Failed to search pilots", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to search pilots")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to search pilots")
 		return
 	}
 
@@ -94,7 +100,7 @@ func (api *PilotAPI) handleDiscover(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -111,7 +117,7 @@ func (api *PilotAPI) handleDiscover(w http.ResponseWriter, r *http.Request) {
 	featured, err := api.userStore.GetFeaturedPilots(r.Context(), userID, limit)
 	if err != nil {
 		api.logger.Error("Failed to get featured pilots", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to get featured pilots")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to get featured pilots")
 		return
 	}
 
@@ -125,7 +131,7 @@ func (api *PilotAPI) handlePilotProfile(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -135,7 +141,7 @@ func (api *PilotAPI) handlePilotProfile(w http.ResponseWriter, r *http.Request)
 
 	if pilotID == "" || pilotID == "search" {
 		// This shouldn't happen as search is handled separately, but just in case
-		http.Error(w, "Pilot ID required", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Pilot ID required")
 		return
 	}
 
@@ -146,11 +152,11 @@ func (api *PilotAPI) handlePilotProfile(w http.ResponseWriter, r *http.Request)
 	user, err := api.userStore.GetByID(ctx, pilotID)
 	if err != nil {
 		api.logger.Error("Failed to get pilot", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to get pilot")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to get pilot")
 		return
 	}
 	if user == nil {
-		api.writeError(w, http.StatusNotFound, "not_found", "pilot not found")
+		api.writeError(w, http.StatusNotFound, apierror.NotFound, "pilot not found")
 		return
 	}
 
@@ -160,12 +166,12 @@ func (api *PilotAPI) handlePilotProfile(w http.ResponseWriter, r *http.Request)
 	// Require callsign to be set for social visibility (privacy protection)
 	// Users without callsigns are not discoverable in social features
 	if !isOwner && (user.CallSign == "") {
-		api.writeError(w, http.StatusNotFound, "not_found", "pilot not found")
+		api.writeError(w, http.StatusNotFound, apierror.NotFound, "pilot not found")
 		return
 	}
 
 	if !isOwner && user.SocialSettings.ProfileVisibility == models.ProfileVisibilityPrivate {
-		api.writeError(w, http.StatusNotFound, "private_profile", "this profile is private")
+		api.writeError(w, http.StatusNotFound, apierror.ProfilePrivate, "this profile is private")
 		return
 	}
 
@@ -283,7 +289,7 @@ func (api *PilotAPI) handleAircraftImage(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -293,7 +299,7 @@ func (api *PilotAPI) handleAircraftImage(w http.ResponseWriter, r *http.Request)
 	aircraftID = strings.TrimSuffix(aircraftID, "/")
 
 	if aircraftID == "" {
-		http.Error(w, "Aircraft ID required", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Aircraft ID required")
 		return
 	}
 
@@ -303,31 +309,22 @@ func (api *PilotAPI) handleAircraftImage(w http.ResponseWriter, r *http.Request)
 	imageData, imageType, err := api.aircraftStore.GetPublicImage(ctx, aircraftID)
 	if err != nil {
 		api.logger.Error("Failed to get aircraft image", logging.WithField("error", err.Error()))
-		http.Error(w, "Failed to get image", http.StatusInternalServerError)
+		apierror.WriteStatus(w, http.StatusInternalServerError, "Failed to get image")
 		return
 	}
 
 	if imageData == nil {
-		http.Error(w, "Image not found or not public", http.StatusNotFound)
+		apierror.WriteStatus(w, http.StatusNotFound, "Image not found or not public")
 		return
 	}
 
-	// Set cache headers
-	w.Header().Set("Content-Type", imageType)
-	w.Header().Set("Cache-Control", "public, max-age=3600")
-	w.WriteHeader(http.StatusOK)
-	w.Write(imageData)
+	writeImage(w, r, api.imageSvc, imageData, imageType, "public, max-age=3600")
 }
 
 func (api *PilotAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+	writeJSON(w, status, data)
 }
 
-func (api *PilotAPI) writeError(w http.ResponseWriter, status int, code, message string) {
-	api.writeJSON(w, status, map[string]string{
-		"code":    code,
-		"message": message,
-	})
+func (api *PilotAPI) writeError(w http.ResponseWriter, status int, code apierror.Code, message string) {
+	apierror.Write(w, status, code, message)
 }
@@ -0,0 +1,97 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/policies"
+)
+
+// PolicyAPI serves the current terms of service and privacy policy and
+// records users accepting them
+type PolicyAPI struct {
+	policySvc *policies.Service
+	logger    *logging.Logger
+}
+
+// NewPolicyAPI creates a new policy API handler
+func NewPolicyAPI(policySvc *policies.Service, logger *logging.Logger) *PolicyAPI {
+	return &PolicyAPI{
+		policySvc: policySvc,
+		logger:    logger,
+	}
+}
+
+// Routes returns the policy route table
+func (api *PolicyAPI) Routes() []Route {
+	return []Route{
+		{Method: http.MethodGet, Pattern: "/api/policies", Access: AccessPublic, Handler: api.handleCurrentPolicies},
+		{Method: http.MethodGet, Pattern: "/api/me/policies", Access: AccessUser, Handler: api.handleMyPolicies},
+		{Method: http.MethodPost, Pattern: "/api/me/policies/accept", Access: AccessUser, PolicyExempt: true, Handler: api.handleAcceptPolicies},
+	}
+}
+
+// handleCurrentPolicies handles GET /api/policies
+func (api *PolicyAPI) handleCurrentPolicies(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	versions, err := api.policySvc.Current(ctx)
+	if err != nil {
+		api.logger.Error("List current policies failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to load policies"})
+		return
+	}
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	api.writeJSON(w, http.StatusOK, models.PolicyVersionListResponse{Versions: versions})
+}
+
+// handleMyPolicies handles GET /api/me/policies, the versions the user has
+// accepted and the ones still pending
+func (api *PolicyAPI) handleMyPolicies(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	status, err := api.policySvc.Status(ctx, auth.GetUserID(r.Context()))
+	if err != nil {
+		api.logger.Error("Get policy status failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to load policy status"})
+		return
+	}
+	api.writeJSON(w, http.StatusOK, status)
+}
+
+// handleAcceptPolicies handles POST /api/me/policies/accept
+func (api *PolicyAPI) handleAcceptPolicies(w http.ResponseWriter, r *http.Request) {
+	var params models.AcceptPoliciesParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	status, err := api.policySvc.Accept(ctx, auth.GetUserID(r.Context()), params)
+	if err != nil {
+		var svcErr *policies.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+			return
+		}
+		api.logger.Error("Accept policies failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to accept policies"})
+		return
+	}
+	api.writeJSON(w, http.StatusOK, status)
+}
+
+func (api *PolicyAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
@@ -3,11 +3,13 @@ package httpapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/auth"
 	"github.com/johnrirwin/flyingforge/internal/database"
 	"github.com/johnrirwin/flyingforge/internal/images"
@@ -33,11 +35,17 @@ func NewProfileAPI(userStore *database.UserStore, imageSvc *images.Service, auth
 	}
 }
 
-// RegisterRoutes registers profile routes on the given mux
-func (api *ProfileAPI) RegisterRoutes(mux *http.ServeMux, corsMiddleware func(http.HandlerFunc) http.HandlerFunc) {
-	mux.HandleFunc("/api/me/profile", corsMiddleware(api.authMiddleware.RequireAuth(api.handleProfile)))
-	mux.HandleFunc("/api/me/avatar", corsMiddleware(api.authMiddleware.RequireAuth(api.handleAvatar)))
-	mux.HandleFunc("/api/users/avatar", corsMiddleware(api.authMiddleware.RequireAuth(api.handleAvatar)))
+// Routes returns the profile route table
+func (api *ProfileAPI) Routes() []Route {
+	return []Route{
+		{Pattern: "/api/me/profile", Access: AccessUser, Handler: api.handleProfile},
+		// Users can delete their account without accepting new policies
+		{Method: http.MethodDelete, Pattern: "/api/me/profile", Access: AccessUser, PolicyExempt: true, Handler: api.handleDeleteProfile},
+		{Pattern: "/api/me/avatar", Access: AccessUser, Handler: api.handleAvatar},
+		{Pattern: "/api/users/avatar", Access: AccessUser, Handler: api.handleAvatar},
+		{Method: http.MethodGet, Pattern: "/api/me/units", Access: AccessUser, Handler: api.handleGetUnits},
+		{Method: http.MethodPut, Pattern: "/api/me/units", Access: AccessUser, Handler: api.handleUpdateUnits},
+	}
 }
 
 // handleProfile handles GET, PUT, and DELETE /api/me/profile
@@ -52,7 +60,7 @@ func (api *ProfileAPI) handleProfile(w http.ResponseWriter, r *http.Request) {
 	case http.MethodOptions:
 		w.WriteHeader(http.StatusOK)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -63,11 +71,11 @@ func (api *ProfileAPI) handleGetProfile(w http.ResponseWriter, r *http.Request)
 	user, err := api.userStore.GetByID(r.Context(), userID)
 	if err != nil {
 		api.logger.Error("Failed to get user", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to get profile")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to get profile")
 		return
 	}
 	if user == nil {
-		api.writeError(w, http.StatusNotFound, "not_found", "user not found")
+		api.writeError(w, http.StatusNotFound, apierror.NotFound, "user not found")
 		return
 	}
 
@@ -96,7 +104,7 @@ func (api *ProfileAPI) handleUpdateProfile(w http.ResponseWriter, r *http.Reques
 
 	var params models.UpdateProfileParams
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		api.writeError(w, http.StatusBadRequest, "invalid_request", "invalid request body")
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "invalid request body")
 		return
 	}
 
@@ -106,10 +114,10 @@ func (api *ProfileAPI) handleUpdateProfile(w http.ResponseWriter, r *http.Reques
 		if trimmedCallSign != "" {
 			if err := models.ValidateCallSign(trimmedCallSign); err != nil {
 				if validErr, ok := err.(*models.ValidationError); ok {
-					api.writeError(w, http.StatusBadRequest, "validation_error", validErr.Message)
+					api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, validErr.Message)
 					return
 				}
-				api.writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+				api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, err.Error())
 				return
 			}
 
@@ -117,11 +125,11 @@ func (api *ProfileAPI) handleUpdateProfile(w http.ResponseWriter, r *http.Reques
 			existing, err := api.userStore.GetByCallSign(r.Context(), trimmedCallSign)
 			if err != nil {
 				api.logger.Error("Failed to check callsign", logging.WithField("error", err.Error()))
-				api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to check callsign")
+				api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to check callsign")
 				return
 			}
 			if existing != nil && existing.ID != userID {
-				api.writeError(w, http.StatusConflict, "callsign_taken", "this callsign is already in use")
+				api.writeError(w, http.StatusConflict, apierror.CallsignTaken, "this callsign is already in use")
 				return
 			}
 		} else {
@@ -129,7 +137,7 @@ func (api *ProfileAPI) handleUpdateProfile(w http.ResponseWriter, r *http.Reques
 			currentUser, err := api.userStore.GetByID(r.Context(), userID)
 			if err != nil {
 				api.logger.Error("Failed to get current user", logging.WithField("error", err.Error()))
-				api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to update profile")
+				api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to update profile")
 				return
 			}
 			if currentUser != nil && currentUser.CallSign != "" {
@@ -164,11 +172,11 @@ func (api *ProfileAPI) handleUpdateProfile(w http.ResponseWriter, r *http.Reques
 	user, err := api.userStore.Update(r.Context(), userID, updateParams)
 	if err != nil {
 		api.logger.Error("Failed to update profile", logging.WithField("error", err.Error()))
-		if strings.Contains(err.Error(), "duplicate key") {
-			api.writeError(w, http.StatusConflict, "callsign_taken", "this callsign is already in use")
+		if errors.Is(err, database.ErrCallSignTaken) {
+			api.writeError(w, http.StatusConflict, apierror.CallsignTaken, "this callsign is already in use")
 			return
 		}
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to update profile")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to update profile")
 		return
 	}
 
@@ -198,7 +206,7 @@ func (api *ProfileAPI) handleAvatar(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -210,11 +218,11 @@ func (api *ProfileAPI) handleAvatar(w http.ResponseWriter, r *http.Request) {
 	currentUser, err := api.userStore.GetByID(ctx, userID)
 	if err != nil {
 		api.logger.Error("Failed to load user before avatar save", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to save avatar")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to save avatar")
 		return
 	}
 	if currentUser == nil {
-		api.writeError(w, http.StatusNotFound, "not_found", "user not found")
+		api.writeError(w, http.StatusNotFound, apierror.NotFound, "user not found")
 		return
 	}
 
@@ -229,28 +237,31 @@ func (api *ProfileAPI) handleAvatar(w http.ResponseWriter, r *http.Request) {
 			UploadID string `json:"uploadId"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			api.writeError(w, http.StatusBadRequest, "invalid_request", "invalid request body")
+			api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "invalid request body")
 			return
 		}
 		req.UploadID = strings.TrimSpace(req.UploadID)
 		if req.UploadID == "" {
-			api.writeError(w, http.StatusBadRequest, "invalid_request", "uploadId is required")
+			api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "uploadId is required")
 			return
 		}
 
 		var err error
 		asset, err = api.imageSvc.PersistApprovedUpload(ctx, userID, req.UploadID, models.ImageEntityAvatar, userID)
 		if err != nil {
+			if writeImageUploadError(w, err) {
+				return
+			}
 			switch err {
 			case images.ErrPendingUploadNotFound:
-				api.writeError(w, http.StatusUnprocessableEntity, "not_approved", "image approval token expired or missing")
+				api.writeError(w, http.StatusUnprocessableEntity, apierror.ImageNotApproved, "image approval token expired or missing")
 				return
 			case images.ErrUploadNotApproved:
-				api.writeError(w, http.StatusUnprocessableEntity, "not_approved", "image is not approved")
+				api.writeError(w, http.StatusUnprocessableEntity, apierror.ImageNotApproved, "image is not approved")
 				return
 			default:
 				api.logger.Error("Failed to persist approved avatar image", logging.WithField("error", err.Error()))
-				api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to save avatar")
+				api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to save avatar")
 				return
 			}
 		}
@@ -258,7 +269,7 @@ func (api *ProfileAPI) handleAvatar(w http.ResponseWriter, r *http.Request) {
 		const maxSize = int64(3 * 1024 * 1024)
 		r.Body = http.MaxBytesReader(w, r.Body, maxSize)
 		if err := r.ParseMultipartForm(maxSize); err != nil {
-			api.writeError(w, http.StatusBadRequest, "invalid_request", "invalid upload payload")
+			api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "invalid upload payload")
 			return
 		}
 
@@ -267,22 +278,22 @@ func (api *ProfileAPI) handleAvatar(w http.ResponseWriter, r *http.Request) {
 			file, _, err = r.FormFile("avatar")
 		}
 		if err != nil {
-			api.writeError(w, http.StatusBadRequest, "invalid_request", "image file is required")
+			api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "image file is required")
 			return
 		}
 		defer file.Close()
 
 		imageData, err := io.ReadAll(file)
 		if err != nil {
-			api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to read image")
+			api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to read image")
 			return
 		}
 		if len(imageData) > 2*1024*1024 {
-			api.writeError(w, http.StatusBadRequest, "invalid_request", "image must be less than 2MB")
+			api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "image must be less than 2MB")
 			return
 		}
 		if _, ok := detectAllowedImageContentType(imageData); !ok {
-			api.writeError(w, http.StatusBadRequest, "invalid_request", "only JPEG and PNG images are allowed")
+			api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "only JPEG and PNG images are allowed")
 			return
 		}
 
@@ -293,27 +304,30 @@ func (api *ProfileAPI) handleAvatar(w http.ResponseWriter, r *http.Request) {
 			ImageBytes:  imageData,
 		})
 		if err != nil {
+			if writeImageUploadError(w, err) {
+				return
+			}
 			api.logger.Error("Failed to moderate avatar image", logging.WithField("error", err.Error()))
-			api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to save avatar")
+			api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to save avatar")
 			return
 		}
 		if decision == nil || decision.Status != models.ImageModerationApproved {
 			if decision != nil && decision.Status == models.ImageModerationPendingReview {
-				api.writeError(w, http.StatusServiceUnavailable, "not_approved", "unable to verify right now")
+				api.writeError(w, http.StatusServiceUnavailable, apierror.ImageNotApproved, "unable to verify right now")
 				return
 			}
 			reason := "image is not approved"
 			if decision != nil && strings.TrimSpace(decision.Reason) != "" {
 				reason = decision.Reason
 			}
-			api.writeError(w, http.StatusUnprocessableEntity, "not_approved", reason)
+			api.writeError(w, http.StatusUnprocessableEntity, apierror.ImageNotApproved, reason)
 			return
 		}
 		asset = savedAsset
 	}
 
 	if asset == nil {
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to save avatar")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to save avatar")
 		return
 	}
 
@@ -329,7 +343,7 @@ func (api *ProfileAPI) handleAvatar(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		api.logger.Error("Failed to update avatar", logging.WithField("error", err.Error()))
 		_ = api.imageSvc.Delete(ctx, asset.ID)
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to update avatar")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to update avatar")
 		return
 	}
 	if currentUser.AvatarImageID != "" && currentUser.AvatarImageID != asset.ID {
@@ -354,11 +368,11 @@ func (api *ProfileAPI) handleDeleteProfile(w http.ResponseWriter, r *http.Reques
 	user, err := api.userStore.GetByID(r.Context(), userID)
 	if err != nil {
 		api.logger.Error("Failed to get user for deletion", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to verify account")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to verify account")
 		return
 	}
 	if user == nil {
-		api.writeError(w, http.StatusNotFound, "not_found", "user not found")
+		api.writeError(w, http.StatusNotFound, apierror.NotFound, "user not found")
 		return
 	}
 
@@ -371,7 +385,7 @@ func (api *ProfileAPI) handleDeleteProfile(w http.ResponseWriter, r *http.Reques
 		api.logger.Error("Failed to delete user account",
 			logging.WithField("error", err.Error()),
 			logging.WithField("userID", userID))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to delete account")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to delete account")
 		return
 	}
 
@@ -382,15 +396,41 @@ func (api *ProfileAPI) handleDeleteProfile(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// handleGetUnits handles GET /api/me/units
+func (api *ProfileAPI) handleGetUnits(w http.ResponseWriter, r *http.Request) {
+	system, err := api.userStore.GetUnitSystem(r.Context(), auth.GetUserID(r.Context()))
+	if err != nil {
+		api.logger.Error("Failed to get unit system", logging.WithField("error", err.Error()))
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to get unit preference")
+		return
+	}
+	api.writeJSON(w, http.StatusOK, models.UnitPreference{UnitSystem: system})
+}
+
+// handleUpdateUnits handles PUT /api/me/units
+func (api *ProfileAPI) handleUpdateUnits(w http.ResponseWriter, r *http.Request) {
+	var params models.UnitPreference
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidBody, "invalid request body")
+		return
+	}
+	if !params.UnitSystem.Valid() {
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "unitSystem must be 'metric' or 'imperial'")
+		return
+	}
+
+	if err := api.userStore.SetUnitSystem(r.Context(), auth.GetUserID(r.Context()), params.UnitSystem); err != nil {
+		api.logger.Error("Failed to set unit system", logging.WithField("error", err.Error()))
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to update unit preference")
+		return
+	}
+	api.writeJSON(w, http.StatusOK, params)
+}
+
 func (api *ProfileAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+	writeJSON(w, status, data)
 }
 
-func (api *ProfileAPI) writeError(w http.ResponseWriter, status int, code, message string) {
-	api.writeJSON(w, status, map[string]string{
-		"code":    code,
-		"message": message,
-	})
+func (api *ProfileAPI) writeError(w http.ResponseWriter, status int, code apierror.Code, message string) {
+	apierror.Write(w, status, code, message)
 }
@@ -0,0 +1,295 @@
+package httpapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/ratelimit"
+)
+
+const (
+	// publicCatalogMaxLimit caps page size for third-party clients
+	publicCatalogMaxLimit = 50
+	// publicCatalogCacheControl lets browsers and CDNs cache responses for five
+	// minutes and serve stale copies for another ten while revalidating.
+	publicCatalogCacheControl = "public, max-age=300, stale-while-revalidate=600"
+	// publicCatalogMinInterval is the minimum time between requests per client IP
+	publicCatalogMinInterval = 200 * time.Millisecond
+)
+
+// publicCatalogFields are the field names accepted by ?fields=
+var publicCatalogFields = map[string]bool{
+	"id": true, "gearType": true, "brand": true, "model": true, "variant": true,
+	"canonicalKey": true, "specs": true, "bestFor": true, "msrp": true,
+	"description": true, "imageUrl": true, "updatedAt": true,
+}
+
+// publicCatalogReader is the read-only part of the gear catalog store
+type publicCatalogReader interface {
+	Search(ctx context.Context, params models.GearCatalogSearchParams) (*models.GearCatalogSearchResponse, error)
+	Get(ctx context.Context, id string) (*models.GearCatalogItem, error)
+	GetByCanonicalKey(ctx context.Context, canonicalKey string) (*models.GearCatalogItem, error)
+}
+
+// PublicCatalogAPI serves a read-only, unauthenticated view of the published
+// gear catalog for third-party tools
+type PublicCatalogAPI struct {
+	catalog  publicCatalogReader
+	limiter  ratelimit.RateLimiter
+	clientIP func(r *http.Request) string
+	logger   *logging.Logger
+}
+
+// NewPublicCatalogAPI creates a public catalog API handler
+func NewPublicCatalogAPI(catalog publicCatalogReader, limiter ratelimit.RateLimiter, clientIP func(r *http.Request) string, logger *logging.Logger) *PublicCatalogAPI {
+	return &PublicCatalogAPI{
+		catalog:  catalog,
+		limiter:  limiter,
+		clientIP: clientIP,
+		logger:   logger,
+	}
+}
+
+// Routes returns the public catalog route table
+func (api *PublicCatalogAPI) Routes() []Route {
+	return []Route{
+		{Pattern: "/api/public/gear-catalog", Access: AccessPublic, Handler: api.handleSearch},
+		{Pattern: "/api/public/gear-catalog/lookup", Access: AccessPublic, Handler: api.handleLookup},
+		{Pattern: "/api/public/gear-catalog/", Access: AccessPublic, Handler: api.handleItem},
+	}
+}
+
+// handleSearch handles GET /api/public/gear-catalog
+func (api *PublicCatalogAPI) handleSearch(w http.ResponseWriter, r *http.Request) {
+	fields, ok := api.begin(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	params := models.GearCatalogSearchParams{
+		Query:    query.Get("q"),
+		GearType: models.GearType(query.Get("gearType")),
+		Brand:    query.Get("brand"),
+		Limit:    20,
+	}
+	if params.GearType != "" && !params.GearType.IsValid() {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid gearType"})
+		return
+	}
+	var ecosystemErr string
+	if params.RCProtocol, params.VideoSystem, ecosystemErr = parseEcosystemFilters(query); ecosystemErr != "" {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": ecosystemErr})
+		return
+	}
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "limit must be a positive integer"})
+			return
+		}
+		params.Limit = min(limit, publicCatalogMaxLimit)
+	}
+	if raw := query.Get("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "offset must be a non-negative integer"})
+			return
+		}
+		params.Offset = offset
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := api.catalog.Search(ctx, params)
+	if err != nil {
+		api.logger.Error("Public gear catalog search failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to search catalog"})
+		return
+	}
+
+	items := make([]interface{}, len(response.Items))
+	for i := range response.Items {
+		items[i] = selectFields(response.Items[i].Public(), fields)
+	}
+	api.writeCached(w, r, map[string]interface{}{
+		"items":      items,
+		"totalCount": response.TotalCount,
+		"limit":      params.Limit,
+		"offset":     params.Offset,
+	})
+}
+
+// handleLookup handles GET /api/public/gear-catalog/lookup?canonicalKey=...
+func (api *PublicCatalogAPI) handleLookup(w http.ResponseWriter, r *http.Request) {
+	fields, ok := api.begin(w, r)
+	if !ok {
+		return
+	}
+
+	key := strings.TrimSpace(r.URL.Query().Get("canonicalKey"))
+	if key == "" {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "canonicalKey is required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	item, err := api.catalog.GetByCanonicalKey(ctx, key)
+	api.writeItem(w, r, item, err, fields)
+}
+
+// handleItem handles GET /api/public/gear-catalog/{id}
+func (api *PublicCatalogAPI) handleItem(w http.ResponseWriter, r *http.Request) {
+	fields, ok := api.begin(w, r)
+	if !ok {
+		return
+	}
+
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/public/gear-catalog/"), "/")
+	if id == "" || strings.Contains(id, "/") {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "catalog item not found"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	item, err := api.catalog.Get(ctx, id)
+	api.writeItem(w, r, item, err, fields)
+}
+
+// begin checks the method, rate limit, and fields parameter shared by every
+// public catalog request
+func (api *PublicCatalogAPI) begin(w http.ResponseWriter, r *http.Request) ([]string, bool) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return nil, false
+	}
+
+	if api.limiter != nil && !api.limiter.Allow(api.clientIP(r)) {
+		w.Header().Set("Retry-After", "1")
+		api.writeJSON(w, http.StatusTooManyRequests, map[string]string{"error": "rate limit exceeded"})
+		return nil, false
+	}
+
+	fields, err := parseFields(r.URL.Query().Get("fields"))
+	if err != "" {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": err})
+		return nil, false
+	}
+	return fields, true
+}
+
+func (api *PublicCatalogAPI) writeItem(w http.ResponseWriter, r *http.Request, item *models.GearCatalogItem, err error, fields []string) {
+	if err != nil {
+		api.logger.Error("Public gear catalog lookup failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to load catalog item"})
+		return
+	}
+	if item == nil || models.NormalizeCatalogStatus(item.Status) != models.CatalogStatusPublished {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "catalog item not found"})
+		return
+	}
+	api.writeCached(w, r, selectFields(item.Public(), fields))
+}
+
+// writeCached writes a cacheable JSON response with a strong ETag, answering
+// 304 when the client already has the same body
+func (api *PublicCatalogAPI) writeCached(w http.ResponseWriter, r *http.Request, data interface{}) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		api.logger.Error("Failed to encode public catalog response", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to encode response"})
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", publicCatalogCacheControl)
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		w.Write(body)
+	}
+}
+
+func (api *PublicCatalogAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, status, data)
+}
+
+// parseFields parses a comma-separated field list, returning an error message
+// for unknown names. An empty list means every field.
+func parseFields(raw string) ([]string, string) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, ""
+	}
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !publicCatalogFields[field] {
+			return nil, "unknown field: " + field
+		}
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields, ""
+}
+
+// selectFields returns the item unchanged when no fields were requested, or
+// a map holding only the requested ones
+func selectFields(item models.PublicGearCatalogItem, fields []string) interface{} {
+	if len(fields) == 0 {
+		return item
+	}
+
+	var all map[string]json.RawMessage
+	body, _ := json.Marshal(item)
+	_ = json.Unmarshal(body, &all)
+
+	selected := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if value, ok := all[field]; ok {
+			selected[field] = value
+		}
+	}
+	return selected
+}
+
+// etagMatches reports whether an If-None-Match header matches the ETag
+func etagMatches(header string, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+type fakeCatalogReader struct {
+	items      map[string]*models.GearCatalogItem
+	lastSearch models.GearCatalogSearchParams
+}
+
+func (f *fakeCatalogReader) Search(ctx context.Context, params models.GearCatalogSearchParams) (*models.GearCatalogSearchResponse, error) {
+	f.lastSearch = params
+	var items []models.GearCatalogItem
+	for _, item := range f.items {
+		if item.Status == models.CatalogStatusPublished {
+			items = append(items, *item)
+		}
+	}
+	return &models.GearCatalogSearchResponse{Items: items, TotalCount: len(items)}, nil
+}
+
+func (f *fakeCatalogReader) Get(ctx context.Context, id string) (*models.GearCatalogItem, error) {
+	return f.items[id], nil
+}
+
+func (f *fakeCatalogReader) GetByCanonicalKey(ctx context.Context, canonicalKey string) (*models.GearCatalogItem, error) {
+	for _, item := range f.items {
+		if item.CanonicalKey == canonicalKey {
+			return item, nil
+		}
+	}
+	return nil, nil
+}
+
+type denyAll struct{}
+
+func (denyAll) Allow(key string) bool { return false }
+
+func newTestPublicCatalogMux(reader publicCatalogReader, limiter interface{ Allow(string) bool }) *http.ServeMux {
+	api := NewPublicCatalogAPI(reader, limiter, func(r *http.Request) string { return r.RemoteAddr }, logging.New(logging.LevelError))
+	return (&router{logger: logging.New(logging.LevelError)}).mux(api.Routes())
+}
+
+func testCatalogReader() *fakeCatalogReader {
+	return &fakeCatalogReader{items: map[string]*models.GearCatalogItem{
+		"motor-1": {ID: "motor-1", GearType: models.GearTypeMotor, Brand: "T-Motor", Model: "F60", CanonicalKey: "motor:tmotor:f60", Status: models.CatalogStatusPublished, CreatedByUserID: "user-1"},
+		"motor-2": {ID: "motor-2", GearType: models.GearTypeMotor, Brand: "Emax", Model: "Eco", CanonicalKey: "motor:emax:eco", Status: models.CatalogStatusPending},
+	}}
+}
+
+func TestPublicCatalog_ItemHidesPrivateFieldsAndCaches(t *testing.T) {
+	mux := newTestPublicCatalogMux(testCatalogReader(), nil)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/gear-catalog/motor-1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Cache-Control") != publicCatalogCacheControl {
+		t.Errorf("Cache-Control = %q", w.Header().Get("Cache-Control"))
+	}
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("missing ETag")
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if body["brand"] != "T-Motor" {
+		t.Errorf("brand = %v", body["brand"])
+	}
+	for _, private := range []string{"createdByUserId", "status", "imageStatus", "usageCount"} {
+		if _, ok := body[private]; ok {
+			t.Errorf("public item exposes %s", private)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/public/gear-catalog/motor-1", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("conditional GET status = %d, body = %q", w.Code, w.Body.String())
+	}
+}
+
+func TestPublicCatalog_UnpublishedItemNotFound(t *testing.T) {
+	mux := newTestPublicCatalogMux(testCatalogReader(), nil)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/gear-catalog/motor-2", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/gear-catalog/lookup?canonicalKey=motor:emax:eco", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("lookup status = %d, want 404", w.Code)
+	}
+}
+
+func TestPublicCatalog_SearchFieldsAndLimit(t *testing.T) {
+	reader := testCatalogReader()
+	mux := newTestPublicCatalogMux(reader, nil)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/gear-catalog?q=f60&limit=500&fields=id,brand", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if reader.lastSearch.Limit != publicCatalogMaxLimit || reader.lastSearch.Query != "f60" {
+		t.Errorf("search params = %+v", reader.lastSearch)
+	}
+
+	var body struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(body.Items) != 1 || len(body.Items[0]) != 2 || body.Items[0]["id"] != "motor-1" {
+		t.Errorf("items = %+v, want only id and brand", body.Items)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/gear-catalog?fields=id,status", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unknown field status = %d, want 400", w.Code)
+	}
+}
+
+func TestPublicCatalog_RateLimited(t *testing.T) {
+	mux := newTestPublicCatalogMux(testCatalogReader(), denyAll{})
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/gear-catalog/motor-1", nil))
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") == "" {
+		t.Errorf("status = %d, Retry-After = %q", w.Code, w.Header().Get("Retry-After"))
+	}
+}
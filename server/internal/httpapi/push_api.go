@@ -0,0 +1,101 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/push"
+)
+
+// PushAPI handles HTTP API requests for push device registration
+type PushAPI struct {
+	pushSvc        *push.Service
+	authMiddleware *auth.Middleware
+	logger         *logging.Logger
+}
+
+// NewPushAPI creates a new push API handler
+func NewPushAPI(pushSvc *push.Service, authMiddleware *auth.Middleware, logger *logging.Logger) *PushAPI {
+	return &PushAPI{
+		pushSvc:        pushSvc,
+		authMiddleware: authMiddleware,
+		logger:         logger,
+	}
+}
+
+// Routes returns the push route table
+func (api *PushAPI) Routes() []Route {
+	return []Route{
+		// Push device routes (require authentication)
+		{Pattern: "/api/push/devices", Access: AccessUser, Handler: api.handleDevices},
+		{Pattern: "/api/push/devices/", Access: AccessUser, Handler: api.handleDeviceItem},
+	}
+}
+
+// handleDevices handles GET (list) and POST (register) on /api/push/devices
+func (api *PushAPI) handleDevices(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserID(r.Context())
+
+	switch r.Method {
+	case http.MethodGet:
+		response, err := api.pushSvc.List(r.Context(), userID)
+		if err != nil {
+			api.logger.Error("List push devices failed", logging.WithField("error", err.Error()))
+			api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to list devices"})
+			return
+		}
+		api.writeJSON(w, http.StatusOK, response)
+	case http.MethodPost:
+		var params models.RegisterPushDeviceParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		device, err := api.pushSvc.Register(r.Context(), userID, params)
+		if err != nil {
+			var svcErr *push.ServiceError
+			if errors.As(err, &svcErr) {
+				api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+				return
+			}
+			api.logger.Error("Register push device failed", logging.WithField("error", err.Error()))
+			api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to register device"})
+			return
+		}
+		api.writeJSON(w, http.StatusOK, device)
+	default:
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// handleDeviceItem handles DELETE /api/push/devices/{id}
+func (api *PushAPI) handleDeviceItem(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/push/devices/")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.WriteStatus(w, http.StatusBadRequest, "Device ID required")
+		return
+	}
+	if r.Method != http.MethodDelete {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := auth.GetUserID(r.Context())
+	if err := api.pushSvc.Unregister(r.Context(), id, userID); err != nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "device not found"})
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeJSON writes a JSON response
+func (api *PushAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
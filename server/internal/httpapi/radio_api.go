@@ -3,6 +3,7 @@ package httpapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"mime"
 	"net/http"
@@ -10,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/auth"
 	"github.com/johnrirwin/flyingforge/internal/logging"
 	"github.com/johnrirwin/flyingforge/internal/models"
@@ -32,20 +34,22 @@ func NewRadioAPI(radioSvc *radiosvc.Service, authMiddleware *auth.Middleware, lo
 	}
 }
 
-// RegisterRoutes registers radio routes on the given mux
-func (api *RadioAPI) RegisterRoutes(mux *http.ServeMux, corsMiddleware func(http.HandlerFunc) http.HandlerFunc) {
-	// Radio models (require authentication)
-	mux.HandleFunc("/api/radio/models", corsMiddleware(api.authMiddleware.RequireAuth(api.handleGetRadioModels)))
+// Routes returns the radio route table
+func (api *RadioAPI) Routes() []Route {
+	return []Route{
+		// Radio models (require authentication)
+		{Pattern: "/api/radio/models", Access: AccessUser, Handler: api.handleGetRadioModels},
 
-	// Radios (require authentication)
-	mux.HandleFunc("/api/radios", corsMiddleware(api.authMiddleware.RequireAuth(api.handleRadios)))
-	mux.HandleFunc("/api/radios/", corsMiddleware(api.authMiddleware.RequireAuth(api.handleRadioItem)))
+		// Radios (require authentication)
+		{Pattern: "/api/radios", Access: AccessUser, Handler: api.handleRadios},
+		{Pattern: "/api/radios/", Access: AccessUser, Handler: api.handleRadioItem},
+	}
 }
 
 // handleGetRadioModels returns the list of available radio models
 func (api *RadioAPI) handleGetRadioModels(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -66,7 +70,7 @@ func (api *RadioAPI) handleRadios(w http.ResponseWriter, r *http.Request) {
 	case http.MethodPost:
 		api.handleCreateRadio(w, r, userID)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -112,11 +116,7 @@ func (api *RadioAPI) handleCreateRadio(w http.ResponseWriter, r *http.Request, u
 
 	radio, err := api.radioSvc.CreateRadio(ctx, userID, params)
 	if err != nil {
-		status := http.StatusInternalServerError
-		if _, ok := err.(*radiosvc.ServiceError); ok {
-			status = http.StatusBadRequest
-		}
-		api.writeJSON(w, status, map[string]string{"error": err.Error()})
+		api.writeServiceError(w, err)
 		return
 	}
 
@@ -132,7 +132,7 @@ func (api *RadioAPI) handleRadioItem(w http.ResponseWriter, r *http.Request) {
 	parts := strings.Split(path, "/")
 
 	if len(parts) == 0 || parts[0] == "" {
-		http.Error(w, "Radio ID required", http.StatusBadRequest)
+		apierror.WriteStatus(w, http.StatusBadRequest, "Radio ID required")
 		return
 	}
 
@@ -148,7 +148,7 @@ func (api *RadioAPI) handleRadioItem(w http.ResponseWriter, r *http.Request) {
 			case http.MethodPost:
 				api.handleCreateBackup(w, r, radioID, userID)
 			default:
-				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 			}
 			return
 		}
@@ -162,7 +162,7 @@ func (api *RadioAPI) handleRadioItem(w http.ResponseWriter, r *http.Request) {
 				if r.Method == http.MethodGet {
 					api.handleDownloadBackup(w, r, radioID, backupID, userID)
 				} else {
-					http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 				}
 				return
 			}
@@ -174,7 +174,7 @@ func (api *RadioAPI) handleRadioItem(w http.ResponseWriter, r *http.Request) {
 			case http.MethodDelete:
 				api.handleDeleteBackup(w, r, radioID, backupID, userID)
 			default:
-				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 			}
 			return
 		}
@@ -189,7 +189,7 @@ func (api *RadioAPI) handleRadioItem(w http.ResponseWriter, r *http.Request) {
 	case http.MethodDelete:
 		api.handleDeleteRadio(w, r, radioID, userID)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -226,11 +226,7 @@ func (api *RadioAPI) handleUpdateRadio(w http.ResponseWriter, r *http.Request, r
 
 	radio, err := api.radioSvc.UpdateRadio(ctx, radioID, userID, params)
 	if err != nil {
-		status := http.StatusInternalServerError
-		if strings.Contains(err.Error(), "not found") {
-			status = http.StatusNotFound
-		}
-		api.writeJSON(w, status, map[string]string{"error": err.Error()})
+		api.writeServiceError(w, err)
 		return
 	}
 
@@ -244,11 +240,7 @@ func (api *RadioAPI) handleDeleteRadio(w http.ResponseWriter, r *http.Request, r
 
 	err := api.radioSvc.DeleteRadio(ctx, radioID, userID)
 	if err != nil {
-		status := http.StatusInternalServerError
-		if strings.Contains(err.Error(), "not found") {
-			status = http.StatusNotFound
-		}
-		api.writeJSON(w, status, map[string]string{"error": err.Error()})
+		api.writeServiceError(w, err)
 		return
 	}
 
@@ -276,65 +268,82 @@ func (api *RadioAPI) handleListBackups(w http.ResponseWriter, r *http.Request, r
 
 	response, err := api.radioSvc.ListBackups(ctx, radioID, userID, params)
 	if err != nil {
-		status := http.StatusInternalServerError
-		if strings.Contains(err.Error(), "not found") {
-			status = http.StatusNotFound
-		}
-		api.writeJSON(w, status, map[string]string{"error": err.Error()})
+		api.writeServiceError(w, err)
 		return
 	}
 
 	api.writeJSON(w, http.StatusOK, response)
 }
 
-// handleCreateBackup creates a new backup
+// backupTransferTimeout bounds a backup upload or download. It is well past
+// the server's read and write timeouts so a 100MB file can move over a slow
+// connection.
+const backupTransferTimeout = 15 * time.Minute
+
+// handleCreateBackup creates a new backup. The multipart body is streamed to
+// storage rather than buffered, so the backupName, backupType and optional
+// checksum (SHA-256 hex) fields must come before the file part.
 func (api *RadioAPI) handleCreateBackup(w http.ResponseWriter, r *http.Request, radioID string, userID string) {
 	// Limit request body to slightly more than MaxBackupFileSize to account for multipart overhead
 	// MaxBackupFileSize is 100MB, so we allow 105MB total
 	const maxRequestSize = 105 * 1024 * 1024
 	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+	extendDeadlines(w, backupTransferTimeout)
 
-	// Parse multipart form (max 100MB)
-	if err := r.ParseMultipartForm(radiosvc.MaxBackupFileSize); err != nil {
+	reader, err := r.MultipartReader()
+	if err != nil {
 		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Failed to parse form: " + err.Error()})
 		return
 	}
 
-	// Get form fields
-	backupName := r.FormValue("backupName")
-	backupType := r.FormValue("backupType")
-	if backupName == "" {
-		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "backupName is required"})
-		return
-	}
-
-	// Get the file
-	file, header, err := r.FormFile("file")
-	if err != nil {
-		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "file is required"})
-		return
-	}
-	defer file.Close()
+	params := models.CreateRadioBackupParams{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "file is required"})
+			return
+		}
+		if err != nil {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Failed to parse form: " + err.Error()})
+			return
+		}
 
-	params := models.CreateRadioBackupParams{
-		BackupName: backupName,
-		BackupType: models.BackupType(backupType),
-		FileName:   header.Filename,
-		FileSize:   header.Size,
+		switch part.FormName() {
+		case "backupName", "backupType", "checksum":
+			value, err := io.ReadAll(io.LimitReader(part, 1024))
+			if err != nil {
+				api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Failed to parse form: " + err.Error()})
+				return
+			}
+			switch part.FormName() {
+			case "backupName":
+				params.BackupName = string(value)
+			case "backupType":
+				params.BackupType = models.BackupType(value)
+			case "checksum":
+				params.Checksum = strings.TrimSpace(string(value))
+			}
+		case "file":
+			if params.BackupName == "" {
+				api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "backupName is required before the file"})
+				return
+			}
+			params.FileName = part.FileName()
+			api.createBackup(w, r, radioID, userID, params, part)
+			return
+		}
+		part.Close()
 	}
+}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+// createBackup stores an uploaded backup file and writes the response
+func (api *RadioAPI) createBackup(w http.ResponseWriter, r *http.Request, radioID string, userID string, params models.CreateRadioBackupParams, file io.Reader) {
+	ctx, cancel := context.WithTimeout(r.Context(), backupTransferTimeout)
 	defer cancel()
 
 	backup, err := api.radioSvc.CreateBackup(ctx, radioID, userID, params, file)
 	if err != nil {
-		status := http.StatusInternalServerError
-		if _, ok := err.(*radiosvc.ServiceError); ok {
-			status = http.StatusBadRequest
-		} else if strings.Contains(err.Error(), "not found") {
-			status = http.StatusNotFound
-		}
-		api.writeJSON(w, status, map[string]string{"error": err.Error()})
+		api.writeServiceError(w, err)
 		return
 	}
 
@@ -348,11 +357,7 @@ func (api *RadioAPI) handleGetBackup(w http.ResponseWriter, r *http.Request, rad
 
 	backup, err := api.radioSvc.GetBackup(ctx, backupID, radioID, userID)
 	if err != nil {
-		status := http.StatusInternalServerError
-		if strings.Contains(err.Error(), "not found") {
-			status = http.StatusNotFound
-		}
-		api.writeJSON(w, status, map[string]string{"error": err.Error()})
+		api.writeServiceError(w, err)
 		return
 	}
 
@@ -364,18 +369,16 @@ func (api *RadioAPI) handleGetBackup(w http.ResponseWriter, r *http.Request, rad
 	api.writeJSON(w, http.StatusOK, backup)
 }
 
-// handleDownloadBackup downloads a backup file
+// handleDownloadBackup downloads a backup file. Range requests are honoured
+// so interrupted downloads can resume; the ETag is the file's SHA-256.
 func (api *RadioAPI) handleDownloadBackup(w http.ResponseWriter, r *http.Request, radioID string, backupID string, userID string) {
-	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), backupTransferTimeout)
 	defer cancel()
+	extendDeadlines(w, backupTransferTimeout)
 
 	file, backup, err := api.radioSvc.GetBackupFile(ctx, backupID, radioID, userID)
 	if err != nil {
-		status := http.StatusInternalServerError
-		if strings.Contains(err.Error(), "not found") {
-			status = http.StatusNotFound
-		}
-		api.writeJSON(w, status, map[string]string{"error": err.Error()})
+		api.writeServiceError(w, err)
 		return
 	}
 	defer file.Close()
@@ -385,21 +388,21 @@ func (api *RadioAPI) handleDownloadBackup(w http.ResponseWriter, r *http.Request
 	// Use mime.FormatMediaType to safely format Content-Disposition and prevent header injection
 	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": backup.FileName})
 	w.Header().Set("Content-Disposition", disposition)
-	w.Header().Set("Content-Length", strconv.FormatInt(backup.FileSize, 10))
-
-	// Stream the file
-	bytesWritten, err := io.Copy(w, file)
-	if err != nil {
-		// We cannot send a JSON error here because headers/body may already be sent,
-		// but we can log the failure for observability.
-		api.logger.Error("failed to stream backup file", logging.WithFields(map[string]interface{}{
-			"radioID":      radioID,
-			"backupID":     backupID,
-			"userID":       userID,
-			"bytesWritten": bytesWritten,
-			"error":        err.Error(),
-		}))
+	if backup.Checksum != "" {
+		w.Header().Set("ETag", `"`+backup.Checksum+`"`)
 	}
+
+	// ServeContent handles Range and If-Range and sets Content-Length
+	http.ServeContent(w, r, "", backup.CreatedAt, file)
+}
+
+// extendDeadlines lifts the server's read and write timeouts for a long
+// transfer. Writers that don't support deadlines are left alone.
+func extendDeadlines(w http.ResponseWriter, d time.Duration) {
+	rc := http.NewResponseController(w)
+	deadline := time.Now().Add(d)
+	_ = rc.SetReadDeadline(deadline)
+	_ = rc.SetWriteDeadline(deadline)
 }
 
 // handleDeleteBackup deletes a backup
@@ -409,11 +412,7 @@ func (api *RadioAPI) handleDeleteBackup(w http.ResponseWriter, r *http.Request,
 
 	err := api.radioSvc.DeleteBackup(ctx, backupID, radioID, userID)
 	if err != nil {
-		status := http.StatusInternalServerError
-		if strings.Contains(err.Error(), "not found") {
-			status = http.StatusNotFound
-		}
-		api.writeJSON(w, status, map[string]string{"error": err.Error()})
+		api.writeServiceError(w, err)
 		return
 	}
 
@@ -421,8 +420,16 @@ func (api *RadioAPI) handleDeleteBackup(w http.ResponseWriter, r *http.Request,
 }
 
 // writeJSON writes a JSON response
+// writeServiceError maps radio service errors to responses
+func (api *RadioAPI) writeServiceError(w http.ResponseWriter, err error) {
+	var svcErr *radiosvc.ServiceError
+	if errors.As(err, &svcErr) {
+		api.writeJSON(w, apierror.StatusOf(svcErr, http.StatusBadRequest), errorBody(svcErr))
+		return
+	}
+	api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
+
 func (api *RadioAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+	writeJSON(w, status, data)
 }
@@ -0,0 +1,74 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/recommend"
+)
+
+// RecommendationAPI serves "pilots who used this also used" for catalog items
+type RecommendationAPI struct {
+	recommendSvc *recommend.Service
+	logger       *logging.Logger
+}
+
+// NewRecommendationAPI creates a new recommendation API handler
+func NewRecommendationAPI(recommendSvc *recommend.Service, logger *logging.Logger) *RecommendationAPI {
+	return &RecommendationAPI{
+		recommendSvc: recommendSvc,
+		logger:       logger,
+	}
+}
+
+// Routes returns the recommendation route table
+func (api *RecommendationAPI) Routes() []Route {
+	return []Route{
+		{Method: http.MethodGet, Pattern: "/api/gear-catalog/{id}/recommendations", Access: AccessPublic, Handler: api.handleGetRecommendations},
+	}
+}
+
+// handleGetRecommendations handles GET /api/gear-catalog/{id}/recommendations
+func (api *RecommendationAPI) handleGetRecommendations(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, err := uuid.Parse(id); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid catalog item id"})
+		return
+	}
+
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "limit must be a positive integer"})
+			return
+		}
+		limit = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := api.recommendSvc.Recommendations(ctx, id, limit)
+	if err != nil {
+		api.logger.Error("Failed to get recommendations", logging.WithFields(map[string]interface{}{
+			"itemId": id,
+			"error":  err.Error(),
+		}))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to get recommendations"})
+		return
+	}
+
+	// Recommendations only change when the nightly job runs
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+func (api *RecommendationAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
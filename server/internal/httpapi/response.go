@@ -0,0 +1,60 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// writeJSON writes data as the JSON response. Error bodies built as maps get
+// a code when the handler didn't set one, so every error response carries one.
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	if status >= http.StatusBadRequest {
+		addErrorCode(status, data)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+// addErrorCode sets "code" on a map error body that has none. Image
+// moderation decisions get the moderation code; everything else gets the
+// generic code for the status.
+func addErrorCode(status int, data interface{}) {
+	switch body := data.(type) {
+	case map[string]string:
+		if _, ok := body["code"]; !ok {
+			body["code"] = string(errorCodeFor(status, body["status"]))
+		}
+	case map[string]interface{}:
+		if _, ok := body["code"]; !ok {
+			moderationStatus, _ := body["status"].(string)
+			if s, ok := body["status"].(models.ImageModerationStatus); ok {
+				moderationStatus = string(s)
+			}
+			body["code"] = errorCodeFor(status, moderationStatus)
+		}
+	}
+}
+
+func errorCodeFor(status int, moderationStatus string) apierror.Code {
+	switch models.ImageModerationStatus(moderationStatus) {
+	case models.ImageModerationRejected:
+		return apierror.ImageRejected
+	case models.ImageModerationPendingReview:
+		return apierror.ImagePendingReview
+	}
+	return apierror.FromStatus(status)
+}
+
+// errorBody returns the JSON body for a service error, with its code when it
+// carries one
+func errorBody(err error) map[string]string {
+	body := map[string]string{"error": err.Error()}
+	if code := apierror.CodeOf(err); code != "" {
+		body["code"] = string(code)
+	}
+	return body
+}
@@ -0,0 +1,44 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/builds"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+func TestWriteJSON_ErrorCodes(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		data   interface{}
+		want   string
+	}{
+		{name: "generic code from status", status: http.StatusNotFound, data: map[string]string{"error": "gear item not found"}, want: "NOT_FOUND"},
+		{name: "handler code kept", status: http.StatusConflict, data: map[string]string{"error": "taken", "code": "CALLSIGN_TAKEN"}, want: "CALLSIGN_TAKEN"},
+		{name: "rejected image", status: http.StatusUnprocessableEntity, data: map[string]string{"status": string(models.ImageModerationRejected), "error": "nope"}, want: "IMAGE_REJECTED"},
+		{name: "image pending review", status: http.StatusServiceUnavailable, data: map[string]interface{}{"status": models.ImageModerationPendingReview}, want: "IMAGE_PENDING_REVIEW"},
+		{name: "service error code", status: http.StatusNotFound, data: errorBody(&builds.ServiceError{Code: apierror.BuildNotFound, Message: "build not found"}), want: "BUILD_NOT_FOUND"},
+		{name: "success untouched", status: http.StatusOK, data: map[string]string{"status": "ok"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			writeJSON(rec, tt.status, tt.data)
+
+			var body map[string]interface{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body %q: %v", rec.Body.String(), err)
+			}
+			got, _ := body["code"].(string)
+			if got != tt.want {
+				t.Errorf("code = %q, want %q (body %s)", got, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
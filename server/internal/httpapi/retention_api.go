@@ -0,0 +1,82 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/retention"
+)
+
+// RetentionAPI lets users choose how long their log data is kept
+type RetentionAPI struct {
+	retentionSvc *retention.Service
+	logger       *logging.Logger
+}
+
+// NewRetentionAPI creates a new retention API handler
+func NewRetentionAPI(retentionSvc *retention.Service, logger *logging.Logger) *RetentionAPI {
+	return &RetentionAPI{
+		retentionSvc: retentionSvc,
+		logger:       logger,
+	}
+}
+
+// Routes returns the retention route table
+func (api *RetentionAPI) Routes() []Route {
+	return []Route{
+		{Method: http.MethodGet, Pattern: "/api/me/retention", Access: AccessUser, Handler: api.handleGetRetention},
+		{Method: http.MethodPut, Pattern: "/api/me/retention", Access: AccessUser, Handler: api.handleUpdateRetention},
+	}
+}
+
+// handleGetRetention handles GET /api/me/retention
+func (api *RetentionAPI) handleGetRetention(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	settings, err := api.retentionSvc.Settings(ctx, auth.GetUserID(r.Context()))
+	if err != nil {
+		api.logger.Error("Get retention settings failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to load retention settings"})
+		return
+	}
+	api.writeJSON(w, http.StatusOK, settings)
+}
+
+// handleUpdateRetention handles PUT /api/me/retention
+func (api *RetentionAPI) handleUpdateRetention(w http.ResponseWriter, r *http.Request) {
+	var params models.UpdateRetentionParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	userID := auth.GetUserID(r.Context())
+	settings, err := api.retentionSvc.Update(ctx, userID, params)
+	if err != nil {
+		var svcErr *retention.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+			return
+		}
+		api.logger.Error("Update retention settings failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to update retention settings"})
+		return
+	}
+
+	api.logger.Info("User updated data retention", logging.WithField("userId", userID))
+	api.writeJSON(w, http.StatusOK, settings)
+}
+
+func (api *RetentionAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
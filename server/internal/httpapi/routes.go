@@ -0,0 +1,297 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/tenancy"
+)
+
+// Access is the authorization a route requires. Every route in the table
+// declares one; a route with anything else is still registered but refuses
+// every request.
+type Access string
+
+const (
+	// AccessPublic needs no credentials
+	AccessPublic Access = "public"
+	// AccessOptional identifies the caller when a token is sent
+	AccessOptional Access = "optional"
+	// AccessUser needs any signed-in user
+	AccessUser Access = "user"
+	// AccessModerator needs an admin or content admin
+	AccessModerator Access = "moderator"
+	// AccessAdmin needs a full admin
+	AccessAdmin Access = "admin"
+)
+
+// Route is one entry in the route table
+type Route struct {
+	// Method limits the route to one HTTP method. Empty accepts every
+	// method and leaves the check to the handler. A pattern can be listed
+	// once per method when methods need different access.
+	Method  string
+	Pattern string
+	Access  Access
+	// NoCORS serves the route without CORS headers, for redirects and
+	// browser callbacks
+	NoCORS bool
+	// PolicyExempt lets signed-in users write before accepting the current
+	// terms and privacy policy, for accepting them, signing out, and
+	// deleting their account
+	PolicyExempt bool
+	// Feature hides the route, with a 404, on sites whose tenant switched
+	// the feature off
+	Feature models.TenantFeature
+	// Platform hides the route, with a 404, on every site but the default
+	// tenant's, for admin routes over data all tenants share, so a club's
+	// admins can't change it for other clubs
+	Platform bool
+	Handler  http.HandlerFunc
+}
+
+// roleLookup loads the user whose role a moderator or admin route checks
+type roleLookup interface {
+	GetByID(ctx context.Context, id string) (*models.User, error)
+}
+
+// policyGate lists the current policy versions a user hasn't accepted
+type policyGate interface {
+	Pending(ctx context.Context, userID string) ([]models.PolicyVersion, error)
+}
+
+// tenantResolver picks the tenant serving a request from its hostname
+type tenantResolver interface {
+	Enabled() bool
+	Resolve(host string) *models.Tenant
+}
+
+// router wires a route table into a ServeMux, wrapping each route in the
+// middleware its access requires
+type router struct {
+	cors           func(http.HandlerFunc) http.HandlerFunc
+	authMiddleware *auth.Middleware
+	users          roleLookup
+	policies       policyGate
+	tenants        tenantResolver
+	logger         *logging.Logger
+}
+
+// mux registers every route. Routes sharing a pattern are served by one
+// handler that picks the route by method, so a method nobody declared gets
+// 405 before any handler runs.
+func (rt *router) mux(routes []Route) *http.ServeMux {
+	mux := http.NewServeMux()
+	var patterns []string
+	byPattern := make(map[string][]Route)
+	for _, route := range routes {
+		if _, ok := byPattern[route.Pattern]; !ok {
+			patterns = append(patterns, route.Pattern)
+		}
+		byPattern[route.Pattern] = append(byPattern[route.Pattern], route)
+	}
+	for _, pattern := range patterns {
+		mux.HandleFunc(pattern, rt.dispatch(byPattern[pattern]))
+	}
+	return mux
+}
+
+func (rt *router) dispatch(routes []Route) http.HandlerFunc {
+	handlers := make(map[string]http.HandlerFunc, len(routes))
+	var methods []string
+	noCORS := false
+	for _, route := range routes {
+		handlers[route.Method] = rt.requirePlatform(route.Platform, rt.requireFeature(route.Feature, rt.protect(route)))
+		if route.Method != "" {
+			methods = append(methods, route.Method)
+		}
+		noCORS = noCORS || route.NoCORS
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		next, ok := handlers[r.Method]
+		if !ok && r.Method == http.MethodHead {
+			next, ok = handlers[http.MethodGet]
+		}
+		if !ok {
+			next, ok = handlers[""]
+		}
+		if !ok {
+			w.Header().Set("Allow", strings.Join(methods, ", "))
+			apierror.WriteStatus(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		next(w, r)
+	}
+	if noCORS || rt.cors == nil {
+		return handler
+	}
+	return rt.cors(handler)
+}
+
+// protect wraps a route's handler in the checks its access requires
+func (rt *router) protect(route Route) http.HandlerFunc {
+	if route.Access == AccessPublic {
+		return route.Handler
+	}
+	if rt.authMiddleware == nil {
+		return rt.deny(route, "auth middleware is not configured")
+	}
+
+	handler := route.Handler
+	if rt.policies != nil && !route.PolicyExempt {
+		handler = rt.requirePolicies(handler)
+	}
+
+	switch route.Access {
+	case AccessOptional:
+		return rt.authMiddleware.OptionalAuth(handler)
+	case AccessUser:
+		return rt.authMiddleware.RequireAuth(handler)
+	case AccessModerator:
+		if rt.users == nil {
+			return rt.deny(route, "no user store for role checks")
+		}
+		return rt.authMiddleware.RequireAuth(rt.requireRole(handler, canModerateContent, "admin or content-admin access required", "User without content moderation role attempted admin content access"))
+	case AccessAdmin:
+		if rt.users == nil {
+			return rt.deny(route, "no user store for role checks")
+		}
+		return rt.authMiddleware.RequireAuth(rt.requireRole(handler, canManageUsers, "admin access required", "Non-admin user attempted user-admin access"))
+	default:
+		return rt.deny(route, fmt.Sprintf("unknown access %q", route.Access))
+	}
+}
+
+// scopeTenant resolves the tenant serving each request and, when tenancy is
+// enabled, scopes the request's queries to it
+func (rt *router) scopeTenant(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant := rt.tenants.Resolve(r.Host)
+		next.ServeHTTP(w, r.WithContext(tenancy.WithTenant(r.Context(), tenant, rt.tenants.Enabled())))
+	})
+}
+
+// requireFeature answers 404 when the tenant serving the request has the
+// feature switched off
+func (rt *router) requireFeature(feature models.TenantFeature, next http.HandlerFunc) http.HandlerFunc {
+	if feature == "" || rt.tenants == nil {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !tenancy.FromContext(r.Context()).FeatureEnabled(feature) {
+			apierror.WriteStatus(w, http.StatusNotFound, "not found")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// requirePlatform answers 404 when a platform route is requested on a site
+// other than the default tenant's
+func (rt *router) requirePlatform(platform bool, next http.HandlerFunc) http.HandlerFunc {
+	if !platform || rt.tenants == nil {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if tenant := tenancy.FromContext(r.Context()); tenant != nil && tenant.ID != database.DefaultTenantID {
+			apierror.WriteStatus(w, http.StatusNotFound, "not found")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// deny refuses every request to a route whose access can't be enforced
+func (rt *router) deny(route Route, reason string) http.HandlerFunc {
+	rt.logger.Error("Route refuses all requests", logging.WithFields(map[string]interface{}{
+		"method":  route.Method,
+		"pattern": route.Pattern,
+		"reason":  reason,
+	}))
+	return func(w http.ResponseWriter, r *http.Request) {
+		apierror.WriteStatus(w, http.StatusForbidden, "forbidden")
+	}
+}
+
+// requireRole checks the signed-in user's role before calling next
+func (rt *router) requireRole(next http.HandlerFunc, allowed func(*models.User) bool, deniedMessage string, deniedLogMessage string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := auth.GetUserID(r.Context())
+		if userID == "" {
+			apierror.WriteStatus(w, http.StatusUnauthorized, "authentication required")
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+
+		user, err := rt.users.GetByID(ctx, userID)
+		if err != nil {
+			rt.logger.Error("Failed to get user for admin check", logging.WithField("error", err.Error()))
+			apierror.WriteStatus(w, http.StatusUnauthorized, "authentication required")
+			return
+		}
+		if user == nil {
+			rt.logger.Warn("Authenticated user missing during admin check", logging.WithField("userId", userID))
+			apierror.WriteStatus(w, http.StatusUnauthorized, "authentication required")
+			return
+		}
+
+		if !allowed(user) {
+			rt.logger.Warn(deniedLogMessage, logging.WithField("userId", userID))
+			apierror.WriteStatus(w, http.StatusForbidden, deniedMessage)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// requirePolicies refuses writes from signed-in users who haven't accepted
+// the current policy versions. Reads, and requests without a user, pass.
+func (rt *router) requirePolicies(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := auth.GetUserID(r.Context())
+		if userID == "" || r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+			next(w, r)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+
+		pending, err := rt.policies.Pending(ctx, userID)
+		if err != nil {
+			rt.logger.Error("Failed to check policy acceptance", logging.WithField("error", err.Error()))
+			apierror.WriteStatus(w, http.StatusServiceUnavailable, "failed to check policy acceptance")
+			return
+		}
+		if len(pending) > 0 {
+			writeJSON(w, http.StatusForbidden, map[string]interface{}{
+				"error":   "accept the current terms and privacy policy to continue",
+				"code":    apierror.PolicyAcceptanceRequired,
+				"pending": pending,
+			})
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func canModerateContent(user *models.User) bool {
+	return user != nil && (user.IsAdmin || user.IsContentAdmin)
+}
+
+func canManageUsers(user *models.User) bool {
+	return user != nil && user.IsAdmin
+}
@@ -0,0 +1,405 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/johnrirwin/flyingforge/internal/aggregator"
+	"github.com/johnrirwin/flyingforge/internal/aircraft"
+	"github.com/johnrirwin/flyingforge/internal/announcements"
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/appeals"
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/battery"
+	"github.com/johnrirwin/flyingforge/internal/blackbox"
+	"github.com/johnrirwin/flyingforge/internal/builds"
+	"github.com/johnrirwin/flyingforge/internal/catalogrules"
+	"github.com/johnrirwin/flyingforge/internal/config"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/ecosystems"
+	"github.com/johnrirwin/flyingforge/internal/equipment"
+	"github.com/johnrirwin/flyingforge/internal/events"
+	"github.com/johnrirwin/flyingforge/internal/featured"
+	"github.com/johnrirwin/flyingforge/internal/feedfilter"
+	"github.com/johnrirwin/flyingforge/internal/fwtargets"
+	"github.com/johnrirwin/flyingforge/internal/groups"
+	"github.com/johnrirwin/flyingforge/internal/home"
+	"github.com/johnrirwin/flyingforge/internal/images"
+	"github.com/johnrirwin/flyingforge/internal/imagesourcing"
+	"github.com/johnrirwin/flyingforge/internal/importers"
+	"github.com/johnrirwin/flyingforge/internal/inactivity"
+	"github.com/johnrirwin/flyingforge/internal/inventory"
+	"github.com/johnrirwin/flyingforge/internal/linkcheck"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/moderation"
+	"github.com/johnrirwin/flyingforge/internal/offlinesync"
+	"github.com/johnrirwin/flyingforge/internal/policies"
+	"github.com/johnrirwin/flyingforge/internal/publicurl"
+	"github.com/johnrirwin/flyingforge/internal/push"
+	"github.com/johnrirwin/flyingforge/internal/radio"
+	"github.com/johnrirwin/flyingforge/internal/recommend"
+	"github.com/johnrirwin/flyingforge/internal/reputation"
+	"github.com/johnrirwin/flyingforge/internal/retention"
+	"github.com/johnrirwin/flyingforge/internal/seo"
+	"github.com/johnrirwin/flyingforge/internal/shortlinks"
+	"github.com/johnrirwin/flyingforge/internal/tenancy"
+	"github.com/johnrirwin/flyingforge/internal/uploads"
+	"github.com/johnrirwin/flyingforge/internal/views"
+)
+
+var testAuthConfig = config.AuthConfig{
+	JWTSecret:   "route-test-secret",
+	JWTIssuer:   "flyingforge",
+	JWTAudience: "flyingforge",
+}
+
+type roleUsers map[string]*models.User
+
+func (u roleUsers) GetByID(ctx context.Context, id string) (*models.User, error) {
+	return u[id], nil
+}
+
+// fullRouteServer has every dependency set, so its table holds every route.
+// Nothing behind the services is usable; only denied requests may be sent.
+func fullRouteServer() *Server {
+	logger := logging.New(logging.LevelError)
+	authSvc := auth.NewService(nil, testAuthConfig, logger)
+	return &Server{
+		agg:                 &aggregator.Aggregator{},
+		equipmentSvc:        &equipment.Service{},
+		inventorySvc:        &inventory.Service{},
+		attachmentSvc:       &inventory.AttachmentService{},
+		aircraftSvc:         &aircraft.Service{},
+		buildSvc:            &builds.Service{},
+		radioSvc:            &radio.Service{},
+		uploadSvc:           &uploads.Service{},
+		blackboxSvc:         &blackbox.Service{},
+		viewSvc:             &views.Service{},
+		batterySvc:          &battery.Service{},
+		syncSvc:             &offlinesync.Service{},
+		pushSvc:             &push.Service{},
+		featuredSvc:         &featured.Service{},
+		homeSvc:             &home.Service{},
+		announcementSvc:     &announcements.Service{},
+		policySvc:           &policies.Service{},
+		retentionSvc:        &retention.Service{},
+		tenancySvc:          tenancy.NewService(nil, false, logger),
+		seoSvc:              &seo.Service{},
+		shortLinkSvc:        &shortlinks.Service{},
+		groupSvc:            &groups.Service{},
+		eventSvc:            &events.Service{},
+		importSvc:           &importers.Service{},
+		authSvc:             authSvc,
+		authMiddleware:      auth.NewMiddleware(authSvc),
+		userStore:           &database.UserStore{},
+		aircraftStore:       &database.AircraftStore{},
+		fcConfigStore:       &database.FCConfigStore{},
+		inventoryStore:      &database.InventoryStore{},
+		gearCatalogStore:    &database.GearCatalogStore{},
+		brandStore:          &database.BrandStore{},
+		imageSvc:            &images.Service{},
+		imageRescanner:      &images.Rescanner{},
+		moderationPolicies:  &moderation.Policies{},
+		publishRules:        &catalogrules.Engine{},
+		reputation:          &reputation.Service{},
+		moderationClaims:    &database.ModerationClaimStore{},
+		moderationSLA:       &moderation.SLAMonitor{},
+		imageSourcing:       &imagesourcing.Service{},
+		linkCheckSvc:        &linkcheck.Service{},
+		feedFilterSvc:       &feedfilter.Service{},
+		queryStats:          database.NewQueryStats(),
+		adminSearch:         &database.AdminSearchStore{},
+		dbPool:              database.NewPoolMonitor(nil, 0),
+		metricsToken:        "metrics-token",
+		inactivitySvc:       &inactivity.Service{},
+		appealSvc:           &appeals.Service{},
+		recommendSvc:        &recommend.Service{},
+		ecosystemSvc:        &ecosystems.Service{},
+		fwTargetSvc:         &fwtargets.Service{},
+		webUI:               http.NotFoundHandler(),
+		publicURL:           publicurl.New("", false),
+		logger:              logger,
+		enableManualRefresh: true,
+	}
+}
+
+func testToken(t *testing.T, userID string) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": userID,
+		"iss": testAuthConfig.JWTIssuer,
+		"aud": testAuthConfig.JWTAudience,
+		"exp": time.Now().Add(time.Minute).Unix(),
+	}).SignedString([]byte(testAuthConfig.JWTSecret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
+
+// samplePath turns a route pattern into a path it serves
+func samplePath(pattern string) string {
+	path := strings.ReplaceAll(pattern, "{id}", "sample-id")
+	if strings.HasSuffix(path, "/") {
+		path += "sample-id"
+	}
+	return path
+}
+
+func sampleRequest(route Route, token string) *http.Request {
+	method := route.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req := httptest.NewRequest(method, samplePath(route.Pattern), nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func TestRouteTable_EveryRouteHasExplicitAccess(t *testing.T) {
+	s := fullRouteServer()
+	routes := s.routes()
+	if len(routes) < 100 {
+		t.Fatalf("route table has %d routes; is a dependency missing from fullRouteServer?", len(routes))
+	}
+
+	known := map[Access]bool{AccessPublic: true, AccessOptional: true, AccessUser: true, AccessModerator: true, AccessAdmin: true}
+	seen := make(map[string]bool)
+	for _, route := range routes {
+		key := route.Method + " " + route.Pattern
+		if !known[route.Access] {
+			t.Errorf("%s has no known access policy (%q)", key, route.Access)
+		}
+		if route.Handler == nil {
+			t.Errorf("%s has no handler", key)
+		}
+		if seen[key] {
+			t.Errorf("%s is listed twice", key)
+		}
+		seen[key] = true
+
+		if strings.HasPrefix(route.Pattern, "/api/admin/") && route.Access != AccessModerator && route.Access != AccessAdmin {
+			t.Errorf("%s is an admin route with %s access", key, route.Access)
+		}
+	}
+
+	// Every route is reachable: no broader pattern swallows its paths
+	mux := (&router{authMiddleware: s.authMiddleware, users: roleUsers{}, logger: s.logger}).mux(routes)
+	for _, route := range routes {
+		if _, pattern := mux.Handler(sampleRequest(route, "")); pattern != route.Pattern {
+			t.Errorf("%s %s is served by %q", route.Method, samplePath(route.Pattern), pattern)
+		}
+	}
+}
+
+func TestRouteTable_AuthMatrix(t *testing.T) {
+	s := fullRouteServer()
+	users := roleUsers{
+		"pilot":     {ID: "pilot"},
+		"moderator": {ID: "moderator", IsContentAdmin: true},
+	}
+	mux := (&router{cors: s.corsMiddleware, authMiddleware: s.authMiddleware, users: users, logger: s.logger}).mux(s.routes())
+
+	callers := []struct {
+		name   string
+		token  string
+		denied map[Access]int
+	}{
+		{"anonymous", "", map[Access]int{AccessUser: http.StatusUnauthorized, AccessModerator: http.StatusUnauthorized, AccessAdmin: http.StatusUnauthorized}},
+		{"invalid token", "not-a-token", map[Access]int{AccessUser: http.StatusUnauthorized, AccessModerator: http.StatusUnauthorized, AccessAdmin: http.StatusUnauthorized}},
+		{"pilot", testToken(t, "pilot"), map[Access]int{AccessModerator: http.StatusForbidden, AccessAdmin: http.StatusForbidden}},
+		{"moderator", testToken(t, "moderator"), map[Access]int{AccessAdmin: http.StatusForbidden}},
+	}
+
+	for _, caller := range callers {
+		for _, route := range s.routes() {
+			want, denied := caller.denied[route.Access]
+			if !denied {
+				continue
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, sampleRequest(route, caller.token))
+			if rec.Code != want {
+				t.Errorf("%s: %s %s = %d, want %d", caller.name, route.Method, route.Pattern, rec.Code, want)
+			}
+		}
+	}
+}
+
+func TestRouter_DeniesByDefault(t *testing.T) {
+	logger := logging.New(logging.LevelError)
+	authSvc := auth.NewService(nil, testAuthConfig, logger)
+	called := false
+	handler := func(w http.ResponseWriter, r *http.Request) { called = true }
+
+	rt := &router{authMiddleware: auth.NewMiddleware(authSvc), logger: logger}
+	mux := rt.mux([]Route{
+		{Pattern: "/undeclared", Handler: handler},
+		{Pattern: "/no-role-store", Access: AccessAdmin, Handler: handler},
+		{Method: http.MethodGet, Pattern: "/get-only", Access: AccessPublic, Handler: handler},
+	})
+
+	for _, path := range []string{"/undeclared", "/no-role-store"} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("%s = %d, want 403", path, rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/get-only", nil))
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != http.MethodGet {
+		t.Errorf("POST /get-only = %d, Allow %q", rec.Code, rec.Header().Get("Allow"))
+	}
+	if called {
+		t.Error("handler ran for a refused request")
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/get-only", nil))
+	if !called {
+		t.Errorf("HEAD /get-only = %d, want the GET handler", rec.Code)
+	}
+}
+
+type pendingPolicies map[string][]models.PolicyVersion
+
+func (p pendingPolicies) Pending(ctx context.Context, userID string) ([]models.PolicyVersion, error) {
+	return p[userID], nil
+}
+
+func TestRouter_RequiresPolicyAcceptanceForWrites(t *testing.T) {
+	logger := logging.New(logging.LevelError)
+	authSvc := auth.NewService(nil, testAuthConfig, logger)
+	handler := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }
+
+	rt := &router{
+		authMiddleware: auth.NewMiddleware(authSvc),
+		policies:       pendingPolicies{"pilot": {{ID: "terms-2", Kind: models.PolicyTerms, Version: "2"}}},
+		logger:         logger,
+	}
+	mux := rt.mux([]Route{
+		{Pattern: "/write", Access: AccessUser, Handler: handler},
+		{Pattern: "/optional", Access: AccessOptional, Handler: handler},
+		{Method: http.MethodPost, Pattern: "/accept", Access: AccessUser, PolicyExempt: true, Handler: handler},
+	})
+
+	tests := []struct {
+		method, path, user string
+		want               int
+	}{
+		{http.MethodPost, "/write", "pilot", http.StatusForbidden},
+		{http.MethodGet, "/write", "pilot", http.StatusNoContent},
+		{http.MethodPost, "/write", "accepted", http.StatusNoContent},
+		{http.MethodPost, "/optional", "pilot", http.StatusForbidden},
+		{http.MethodPost, "/optional", "", http.StatusNoContent},
+		{http.MethodPost, "/accept", "pilot", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if tt.user != "" {
+			req.Header.Set("Authorization", "Bearer "+testToken(t, tt.user))
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s %s as %q = %d, want %d", tt.method, tt.path, tt.user, rec.Code, tt.want)
+		}
+		if rec.Code == http.StatusForbidden && !strings.Contains(rec.Body.String(), string(apierror.PolicyAcceptanceRequired)) {
+			t.Errorf("%s %s body = %s, want the POLICY_ACCEPTANCE_REQUIRED code", tt.method, tt.path, rec.Body.String())
+		}
+	}
+}
+
+// hostTenants resolves tenants by exact hostname
+type hostTenants map[string]*models.Tenant
+
+func (h hostTenants) Enabled() bool { return true }
+
+func (h hostTenants) Resolve(host string) *models.Tenant {
+	if tenant, ok := h[host]; ok {
+		return tenant
+	}
+	return &models.Tenant{ID: database.DefaultTenantID, Slug: "default"}
+}
+
+func TestRouter_ScopesRequestsToTenant(t *testing.T) {
+	club := &models.Tenant{
+		ID:       "5d9b6d1e-3c61-4c39-9c0e-4a3f1d1b2a10",
+		Slug:     "club",
+		Features: map[models.TenantFeature]bool{models.TenantFeatureEvents: false},
+	}
+	var scopedTo string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		scopedTo = database.TenantFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	rt := &router{tenants: hostTenants{"fpv.club.example": club}, logger: logging.New(logging.LevelError)}
+	handlerWithTenant := rt.scopeTenant(rt.mux([]Route{
+		{Pattern: "/api/events", Access: AccessPublic, Feature: models.TenantFeatureEvents, Handler: handler},
+		{Pattern: "/api/groups", Access: AccessPublic, Feature: models.TenantFeatureGroups, Handler: handler},
+	}))
+
+	tests := []struct {
+		host, path string
+		want       int
+		wantTenant string
+	}{
+		{"fpv.club.example", "/api/events", http.StatusNotFound, ""},
+		{"fpv.club.example", "/api/groups", http.StatusNoContent, club.ID},
+		{"flyingforge.app", "/api/events", http.StatusNoContent, database.DefaultTenantID},
+	}
+	for _, tt := range tests {
+		scopedTo = ""
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		req.Host = tt.host
+		rec := httptest.NewRecorder()
+		handlerWithTenant.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s%s = %d, want %d", tt.host, tt.path, rec.Code, tt.want)
+		}
+		if scopedTo != tt.wantTenant {
+			t.Errorf("%s%s scoped to %q, want %q", tt.host, tt.path, scopedTo, tt.wantTenant)
+		}
+	}
+}
+
+func TestRouter_PlatformRoutesOnlyOnDefaultTenant(t *testing.T) {
+	club := &models.Tenant{ID: "5d9b6d1e-3c61-4c39-9c0e-4a3f1d1b2a10", Slug: "club"}
+	handler := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }
+
+	rt := &router{tenants: hostTenants{"fpv.club.example": club}, logger: logging.New(logging.LevelError)}
+	handlerWithTenant := rt.scopeTenant(rt.mux([]Route{
+		{Pattern: "/api/admin/images/rescan", Access: AccessPublic, Platform: true, Handler: handler},
+		{Pattern: "/api/admin/announcements", Access: AccessPublic, Handler: handler},
+	}))
+
+	tests := []struct {
+		host, path string
+		want       int
+	}{
+		{"fpv.club.example", "/api/admin/images/rescan", http.StatusNotFound},
+		{"fpv.club.example", "/api/admin/announcements", http.StatusNoContent},
+		{"flyingforge.app", "/api/admin/images/rescan", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+		req.Host = tt.host
+		rec := httptest.NewRecorder()
+		handlerWithTenant.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s%s = %d, want %d", tt.host, tt.path, rec.Code, tt.want)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package httpapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/publicurl"
+	"github.com/johnrirwin/flyingforge/internal/seo"
+	"github.com/johnrirwin/flyingforge/internal/tenancy"
+)
+
+// SEOAPI serves the generated sitemap to crawlers
+type SEOAPI struct {
+	seoSvc    *seo.Service
+	publicURL *publicurl.Resolver
+	logger    *logging.Logger
+}
+
+// NewSEOAPI creates a new sitemap API handler
+func NewSEOAPI(seoSvc *seo.Service, publicURL *publicurl.Resolver, logger *logging.Logger) *SEOAPI {
+	return &SEOAPI{
+		seoSvc:    seoSvc,
+		publicURL: publicURL,
+		logger:    logger,
+	}
+}
+
+// Routes returns the sitemap route table
+func (api *SEOAPI) Routes() []Route {
+	return []Route{
+		// Public - served at the site root for crawlers
+		{Pattern: "/sitemap.xml", Access: AccessPublic, Handler: api.handleSitemap},
+		{Pattern: "/sitemaps/", Access: AccessPublic, Handler: api.handleSitemapPage},
+	}
+}
+
+// handleSitemap handles GET /sitemap.xml
+func (api *SEOAPI) handleSitemap(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	tenantID, siteURL := api.sitemapScope(r)
+	data, generatedAt, ok := api.seoSvc.Sitemap(r.Context(), tenantID, siteURL)
+	if !ok {
+		w.Header().Set("Retry-After", "60")
+		apierror.WriteStatus(w, http.StatusServiceUnavailable, "Sitemap not available")
+		return
+	}
+	api.writeXML(w, r, data, generatedAt)
+}
+
+// handleSitemapPage handles GET /sitemaps/{n}.xml
+func (api *SEOAPI) handleSitemapPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/sitemaps/")
+	n, err := strconv.Atoi(strings.TrimSuffix(name, ".xml"))
+	if err != nil || !strings.HasSuffix(name, ".xml") {
+		http.NotFound(w, r)
+		return
+	}
+
+	tenantID, siteURL := api.sitemapScope(r)
+	data, generatedAt, ok := api.seoSvc.SitemapPage(r.Context(), tenantID, siteURL, n)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	api.writeXML(w, r, data, generatedAt)
+}
+
+// sitemapScope returns the tenant serving a request and the origin its
+// sitemap links to. The default tenant links to the configured site URL;
+// other tenants link to the hostname they were reached on.
+func (api *SEOAPI) sitemapScope(r *http.Request) (tenantID, siteURL string) {
+	tenant := tenancy.FromContext(r.Context())
+	if tenant == nil || tenant.ID == database.DefaultTenantID {
+		return database.DefaultTenantID, ""
+	}
+	return tenant.ID, api.publicURL.RequestBase(r)
+}
+
+func (api *SEOAPI) writeXML(w http.ResponseWriter, r *http.Request, data []byte, generatedAt time.Time) {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Header().Set("Last-Modified", generatedAt.UTC().Format(http.TimeFormat))
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, _ = w.Write(data)
+}
@@ -2,7 +2,8 @@ package httpapi
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,27 +11,73 @@ import (
 
 	"github.com/johnrirwin/flyingforge/internal/aggregator"
 	"github.com/johnrirwin/flyingforge/internal/aircraft"
+	"github.com/johnrirwin/flyingforge/internal/announcements"
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/appeals"
 	"github.com/johnrirwin/flyingforge/internal/auth"
 	"github.com/johnrirwin/flyingforge/internal/battery"
+	"github.com/johnrirwin/flyingforge/internal/blackbox"
 	"github.com/johnrirwin/flyingforge/internal/builds"
+	"github.com/johnrirwin/flyingforge/internal/catalogrules"
 	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/ecosystems"
 	"github.com/johnrirwin/flyingforge/internal/equipment"
+	"github.com/johnrirwin/flyingforge/internal/events"
+	"github.com/johnrirwin/flyingforge/internal/featured"
+	"github.com/johnrirwin/flyingforge/internal/feedfilter"
+	"github.com/johnrirwin/flyingforge/internal/fwtargets"
+	"github.com/johnrirwin/flyingforge/internal/groups"
+	"github.com/johnrirwin/flyingforge/internal/home"
 	"github.com/johnrirwin/flyingforge/internal/images"
+	"github.com/johnrirwin/flyingforge/internal/imagesourcing"
+	"github.com/johnrirwin/flyingforge/internal/importers"
+	"github.com/johnrirwin/flyingforge/internal/inactivity"
 	"github.com/johnrirwin/flyingforge/internal/inventory"
+	"github.com/johnrirwin/flyingforge/internal/linkcheck"
 	"github.com/johnrirwin/flyingforge/internal/logging"
 	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/moderation"
+	"github.com/johnrirwin/flyingforge/internal/offlinesync"
+	"github.com/johnrirwin/flyingforge/internal/policies"
+	"github.com/johnrirwin/flyingforge/internal/publicurl"
+	"github.com/johnrirwin/flyingforge/internal/push"
 	"github.com/johnrirwin/flyingforge/internal/radio"
 	"github.com/johnrirwin/flyingforge/internal/ratelimit"
+	"github.com/johnrirwin/flyingforge/internal/recommend"
+	"github.com/johnrirwin/flyingforge/internal/reputation"
+	"github.com/johnrirwin/flyingforge/internal/retention"
+	"github.com/johnrirwin/flyingforge/internal/seo"
+	"github.com/johnrirwin/flyingforge/internal/shortlinks"
+	"github.com/johnrirwin/flyingforge/internal/tenancy"
+	"github.com/johnrirwin/flyingforge/internal/uploads"
+	"github.com/johnrirwin/flyingforge/internal/views"
 )
 
 type Server struct {
 	agg                 *aggregator.Aggregator
 	equipmentSvc        *equipment.Service
 	inventorySvc        inventory.InventoryManager
+	attachmentSvc       *inventory.AttachmentService
 	aircraftSvc         *aircraft.Service
 	buildSvc            *builds.Service
 	radioSvc            *radio.Service
+	uploadSvc           *uploads.Service
+	blackboxSvc         *blackbox.Service
+	viewSvc             *views.Service
 	batterySvc          *battery.Service
+	syncSvc             *offlinesync.Service
+	pushSvc             *push.Service
+	featuredSvc         *featured.Service
+	homeSvc             *home.Service
+	announcementSvc     *announcements.Service
+	policySvc           *policies.Service
+	retentionSvc        *retention.Service
+	tenancySvc          *tenancy.Service
+	seoSvc              *seo.Service
+	shortLinkSvc        *shortlinks.Service
+	groupSvc            *groups.Service
+	eventSvc            *events.Service
+	importSvc           *importers.Service
 	authSvc             *auth.Service
 	authMiddleware      *auth.Middleware
 	userStore           *database.UserStore
@@ -38,130 +85,405 @@ type Server struct {
 	fcConfigStore       *database.FCConfigStore
 	inventoryStore      *database.InventoryStore
 	gearCatalogStore    *database.GearCatalogStore
+	brandStore          *database.BrandStore
 	imageSvc            *images.Service
+	imageRescanner      *images.Rescanner
+	moderationPolicies  *moderation.Policies
+	publishRules        *catalogrules.Engine
+	reputation          *reputation.Service
+	moderationClaims    *database.ModerationClaimStore
+	moderationSLA       *moderation.SLAMonitor
+	imageSourcing       *imagesourcing.Service
+	linkCheckSvc        *linkcheck.Service
+	feedFilterSvc       *feedfilter.Service
+	queryStats          *database.QueryStats
+	adminSearch         *database.AdminSearchStore
+	dbPool              *database.PoolMonitor
+	inactivitySvc       *inactivity.Service
+	appealSvc           *appeals.Service
+	recommendSvc        *recommend.Service
+	ecosystemSvc        *ecosystems.Service
+	fwTargetSvc         *fwtargets.Service
+	webUI               http.Handler // embedded web frontend, nil when not served
+	publicURL           *publicurl.Resolver
 	logger              *logging.Logger
 	server              *http.Server
 	refreshLimiter      ratelimit.RateLimiter
 	tempBuildLimiter    ratelimit.RateLimiter
+	catalogLimiter      ratelimit.RateLimiter // per-IP limit for the public catalog API
 	enableManualRefresh bool
+	metricsToken        string // bearer token for GET /metrics; empty leaves it off
 }
 
-func New(agg *aggregator.Aggregator, equipmentSvc *equipment.Service, inventorySvc inventory.InventoryManager, aircraftSvc *aircraft.Service, buildSvc *builds.Service, radioSvc *radio.Service, batterySvc *battery.Service, authSvc *auth.Service, authMiddleware *auth.Middleware, userStore *database.UserStore, aircraftStore *database.AircraftStore, fcConfigStore *database.FCConfigStore, inventoryStore *database.InventoryStore, gearCatalogStore *database.GearCatalogStore, imageSvc *images.Service, refreshLimiter ratelimit.RateLimiter, enableManualRefresh bool, logger *logging.Logger) *Server {
+// Deps holds what the HTTP server serves. Services left nil turn their
+// routes off.
+type Deps struct {
+	Aggregator          *aggregator.Aggregator
+	EquipmentSvc        *equipment.Service
+	InventorySvc        inventory.InventoryManager
+	AttachmentSvc       *inventory.AttachmentService
+	AircraftSvc         *aircraft.Service
+	BuildSvc            *builds.Service
+	RadioSvc            *radio.Service
+	UploadSvc           *uploads.Service
+	BlackboxSvc         *blackbox.Service
+	ViewSvc             *views.Service
+	BatterySvc          *battery.Service
+	SyncSvc             *offlinesync.Service
+	PushSvc             *push.Service
+	FeaturedSvc         *featured.Service
+	HomeSvc             *home.Service
+	AnnouncementSvc     *announcements.Service
+	PolicySvc           *policies.Service
+	RetentionSvc        *retention.Service
+	TenancySvc          *tenancy.Service
+	SEOSvc              *seo.Service
+	ShortLinkSvc        *shortlinks.Service
+	GroupSvc            *groups.Service
+	EventSvc            *events.Service
+	ImportSvc           *importers.Service
+	AuthSvc             *auth.Service
+	AuthMiddleware      *auth.Middleware
+	UserStore           *database.UserStore
+	AircraftStore       *database.AircraftStore
+	FCConfigStore       *database.FCConfigStore
+	InventoryStore      *database.InventoryStore
+	GearCatalogStore    *database.GearCatalogStore
+	BrandStore          *database.BrandStore
+	ImageSvc            *images.Service
+	ImageRescanner      *images.Rescanner
+	ModerationPolicies  *moderation.Policies
+	PublishRules        *catalogrules.Engine
+	ReputationSvc       *reputation.Service
+	ModerationClaims    *database.ModerationClaimStore
+	ModerationSLA       *moderation.SLAMonitor
+	ImageSourcing       *imagesourcing.Service
+	LinkCheckSvc        *linkcheck.Service
+	FeedFilterSvc       *feedfilter.Service
+	QueryStats          *database.QueryStats
+	AdminSearch         *database.AdminSearchStore
+	DBPool              *database.PoolMonitor
+	InactivitySvc       *inactivity.Service
+	AppealSvc           *appeals.Service
+	RecommendSvc        *recommend.Service
+	EcosystemSvc        *ecosystems.Service
+	FirmwareTargetSvc   *fwtargets.Service
+	WebUI               http.Handler // embedded web frontend, nil when not served
+	PublicURL           *publicurl.Resolver
+	RefreshLimiter      ratelimit.RateLimiter
+	EnableManualRefresh bool   // serve /api/refresh
+	MetricsToken        string // bearer token for GET /metrics; empty leaves it off
+	Logger              *logging.Logger
+}
+
+// New creates the HTTP server for deps
+func New(deps Deps) *Server {
 	return &Server{
-		agg:                 agg,
-		equipmentSvc:        equipmentSvc,
-		inventorySvc:        inventorySvc,
-		aircraftSvc:         aircraftSvc,
-		buildSvc:            buildSvc,
-		radioSvc:            radioSvc,
-		batterySvc:          batterySvc,
-		authSvc:             authSvc,
-		authMiddleware:      authMiddleware,
-		userStore:           userStore,
-		aircraftStore:       aircraftStore,
-		fcConfigStore:       fcConfigStore,
-		inventoryStore:      inventoryStore,
-		gearCatalogStore:    gearCatalogStore,
-		imageSvc:            imageSvc,
-		logger:              logger,
-		refreshLimiter:      refreshLimiter,
+		agg:                 deps.Aggregator,
+		equipmentSvc:        deps.EquipmentSvc,
+		inventorySvc:        deps.InventorySvc,
+		attachmentSvc:       deps.AttachmentSvc,
+		aircraftSvc:         deps.AircraftSvc,
+		buildSvc:            deps.BuildSvc,
+		radioSvc:            deps.RadioSvc,
+		uploadSvc:           deps.UploadSvc,
+		blackboxSvc:         deps.BlackboxSvc,
+		viewSvc:             deps.ViewSvc,
+		batterySvc:          deps.BatterySvc,
+		syncSvc:             deps.SyncSvc,
+		pushSvc:             deps.PushSvc,
+		featuredSvc:         deps.FeaturedSvc,
+		homeSvc:             deps.HomeSvc,
+		announcementSvc:     deps.AnnouncementSvc,
+		policySvc:           deps.PolicySvc,
+		retentionSvc:        deps.RetentionSvc,
+		tenancySvc:          deps.TenancySvc,
+		seoSvc:              deps.SEOSvc,
+		shortLinkSvc:        deps.ShortLinkSvc,
+		groupSvc:            deps.GroupSvc,
+		eventSvc:            deps.EventSvc,
+		importSvc:           deps.ImportSvc,
+		authSvc:             deps.AuthSvc,
+		authMiddleware:      deps.AuthMiddleware,
+		userStore:           deps.UserStore,
+		aircraftStore:       deps.AircraftStore,
+		fcConfigStore:       deps.FCConfigStore,
+		inventoryStore:      deps.InventoryStore,
+		gearCatalogStore:    deps.GearCatalogStore,
+		brandStore:          deps.BrandStore,
+		imageSvc:            deps.ImageSvc,
+		imageRescanner:      deps.ImageRescanner,
+		moderationPolicies:  deps.ModerationPolicies,
+		publishRules:        deps.PublishRules,
+		reputation:          deps.ReputationSvc,
+		moderationClaims:    deps.ModerationClaims,
+		moderationSLA:       deps.ModerationSLA,
+		imageSourcing:       deps.ImageSourcing,
+		linkCheckSvc:        deps.LinkCheckSvc,
+		feedFilterSvc:       deps.FeedFilterSvc,
+		queryStats:          deps.QueryStats,
+		adminSearch:         deps.AdminSearch,
+		dbPool:              deps.DBPool,
+		inactivitySvc:       deps.InactivitySvc,
+		appealSvc:           deps.AppealSvc,
+		recommendSvc:        deps.RecommendSvc,
+		ecosystemSvc:        deps.EcosystemSvc,
+		fwTargetSvc:         deps.FirmwareTargetSvc,
+		webUI:               deps.WebUI,
+		publicURL:           deps.PublicURL,
+		logger:              deps.Logger,
+		refreshLimiter:      deps.RefreshLimiter,
 		tempBuildLimiter:    ratelimit.New(10 * time.Second),
-		enableManualRefresh: enableManualRefresh,
+		catalogLimiter:      ratelimit.New(publicCatalogMinInterval),
+		enableManualRefresh: deps.EnableManualRefresh,
+		metricsToken:        deps.MetricsToken,
 	}
 }
 
-func (s *Server) Start(addr string) error {
-	mux := http.NewServeMux()
-
+// routes is the route table for every API the server was given
+func (s *Server) routes() []Route {
 	// News feed routes (public read, rate-limited refresh)
-	mux.HandleFunc("/api/items", s.corsMiddleware(s.handleGetItems))
-	mux.HandleFunc("/api/sources", s.corsMiddleware(s.handleGetSources))
+	routes := []Route{
+		{Pattern: "/api/items", Access: AccessPublic, Feature: models.TenantFeatureNews, Handler: s.handleGetItems},
+		{Pattern: "/api/sources", Access: AccessPublic, Feature: models.TenantFeatureNews, Handler: s.handleGetSources},
+		{Method: http.MethodGet, Pattern: "/api/feed/search", Access: AccessPublic, Feature: models.TenantFeatureNews, Handler: s.handleFeedSearch},
+	}
 	if s.enableManualRefresh {
-		mux.HandleFunc("/api/refresh", s.corsMiddleware(s.handleRefresh))
+		routes = append(routes, Route{Pattern: "/api/refresh", Access: AccessPublic, Handler: s.handleRefresh})
 	}
+	routes = append(routes, Route{Method: http.MethodGet, Pattern: "/api/admin/feed/sources", Access: AccessAdmin, Handler: s.handleFeedSchedules})
 
 	// Auth routes
 	if s.authSvc != nil && s.authMiddleware != nil {
-		authAPI := NewAuthAPI(s.authSvc, s.authMiddleware, s.logger)
-		authAPI.RegisterRoutes(mux, s.corsMiddleware)
+		authAPI := NewAuthAPI(s.authSvc, s.authMiddleware, s.publicURL, s.logger)
+		routes = append(routes, authAPI.Routes()...)
+	}
+	if s.appealSvc != nil {
+		appealAPI := NewAppealAPI(s.appealSvc, s.logger)
+		routes = append(routes, appealAPI.Routes()...)
 	}
 
+	// Calculator routes
+	if s.authMiddleware != nil {
+		toolsAPI := NewToolsAPI(s.aircraftSvc, s.buildSvc, s.batterySvc, s.authMiddleware, s.logger)
+		routes = append(routes, toolsAPI.Routes()...)
+	}
+
+	// Taxonomy metadata routes
+	metaAPI := NewMetaAPI(s.logger)
+	routes = append(routes, metaAPI.Routes()...)
+
 	// Equipment and inventory routes
-	equipmentAPI := NewEquipmentAPI(s.equipmentSvc, s.inventorySvc, s.authMiddleware, s.logger)
-	equipmentAPI.RegisterRoutes(mux, s.corsMiddleware)
+	equipmentAPI := NewEquipmentAPI(s.equipmentSvc, s.inventorySvc, s.attachmentSvc, s.authMiddleware, s.logger)
+	routes = append(routes, equipmentAPI.Routes()...)
 
 	// Aircraft routes
 	if s.aircraftSvc != nil && s.authMiddleware != nil {
-		aircraftAPI := NewAircraftAPI(s.aircraftSvc, s.authMiddleware, s.logger)
-		aircraftAPI.RegisterRoutes(mux, s.corsMiddleware)
+		aircraftAPI := NewAircraftAPI(s.aircraftSvc, s.imageSvc, s.authMiddleware, s.logger)
+		routes = append(routes, aircraftAPI.Routes()...)
 	}
 
 	// Build routes (public browsing + temp + authenticated drafts/publication)
 	if s.buildSvc != nil && s.authMiddleware != nil {
-		buildAPI := NewBuildAPI(s.buildSvc, s.authMiddleware, s.tempBuildLimiter, s.logger)
-		buildAPI.RegisterRoutes(mux, s.corsMiddleware)
+		buildAPI := NewBuildAPI(s.buildSvc, s.imageSvc, s.authMiddleware, s.tempBuildLimiter, s.viewSvc, s.publicURL, s.logger)
+		routes = append(routes, buildAPI.Routes()...)
+	}
+
+	// Build import routes (RotorBuilds and Google Sheets parts lists)
+	if s.importSvc != nil && s.authMiddleware != nil {
+		importAPI := NewImportAPI(s.importSvc, s.authMiddleware, s.logger)
+		routes = append(routes, importAPI.Routes()...)
 	}
 
 	// Radio routes
 	if s.radioSvc != nil && s.authMiddleware != nil {
 		radioAPI := NewRadioAPI(s.radioSvc, s.authMiddleware, s.logger)
-		radioAPI.RegisterRoutes(mux, s.corsMiddleware)
+		routes = append(routes, radioAPI.Routes()...)
+	}
+
+	// Resumable upload routes (large radio backups)
+	if s.uploadSvc != nil {
+		uploadAPI := NewUploadAPI(s.uploadSvc, s.logger)
+		routes = append(routes, uploadAPI.Routes()...)
+	}
+
+	// Blackbox log routes
+	if s.blackboxSvc != nil {
+		blackboxAPI := NewBlackboxAPI(s.blackboxSvc, s.logger)
+		routes = append(routes, blackboxAPI.Routes()...)
 	}
 
 	// Battery routes
 	if s.batterySvc != nil && s.authMiddleware != nil {
 		batteryAPI := NewBatteryAPI(s.batterySvc, s.authMiddleware, s.logger)
-		batteryAPI.RegisterRoutes(mux, s.corsMiddleware)
+		routes = append(routes, batteryAPI.Routes()...)
+	}
+
+	// Offline sync routes (change feeds + batched client changes)
+	if s.syncSvc != nil && s.authMiddleware != nil {
+		syncAPI := NewSyncAPI(s.syncSvc, s.authMiddleware, s.logger)
+		routes = append(routes, syncAPI.Routes()...)
+	}
+
+	// Push device registration routes
+	if s.pushSvc != nil && s.authMiddleware != nil {
+		pushAPI := NewPushAPI(s.pushSvc, s.authMiddleware, s.logger)
+		routes = append(routes, pushAPI.Routes()...)
+	}
+
+	// Featured content routes (public home page list)
+	if s.featuredSvc != nil {
+		featuredAPI := NewFeaturedAPI(s.featuredSvc, s.logger)
+		routes = append(routes, featuredAPI.Routes()...)
+	}
+	if s.homeSvc != nil {
+		homeAPI := NewHomeAPI(s.homeSvc, s.logger)
+		routes = append(routes, homeAPI.Routes()...)
+	}
+	if s.announcementSvc != nil {
+		announcementAPI := NewAnnouncementAPI(s.announcementSvc, s.logger)
+		routes = append(routes, withFeature(models.TenantFeatureAnnouncements, announcementAPI.Routes())...)
+	}
+	if s.policySvc != nil {
+		policyAPI := NewPolicyAPI(s.policySvc, s.logger)
+		routes = append(routes, policyAPI.Routes()...)
+	}
+	if s.retentionSvc != nil {
+		retentionAPI := NewRetentionAPI(s.retentionSvc, s.logger)
+		routes = append(routes, retentionAPI.Routes()...)
+	}
+
+	// Tenant routes (branding and features for the requested site)
+	if s.tenancySvc != nil {
+		tenantAPI := NewTenantAPI(s.tenancySvc, s.logger)
+		routes = append(routes, tenantAPI.Routes()...)
+	}
+
+	// Sitemap routes (crawler-facing, served from the site root)
+	if s.seoSvc != nil {
+		seoAPI := NewSEOAPI(s.seoSvc, s.publicURL, s.logger)
+		routes = append(routes, seoAPI.Routes()...)
+	}
+
+	// Short link routes (public redirects + lookup)
+	if s.shortLinkSvc != nil {
+		shortLinkAPI := NewShortLinkAPI(s.shortLinkSvc, s.publicURL, s.logger)
+		routes = append(routes, shortLinkAPI.Routes()...)
+	}
+
+	// Group routes (clubs, invitations, shared fleets)
+	if s.groupSvc != nil && s.authMiddleware != nil {
+		groupAPI := NewGroupAPI(s.groupSvc, s.authMiddleware, s.logger)
+		routes = append(routes, withFeature(models.TenantFeatureGroups, groupAPI.Routes())...)
+	}
+
+	// Event routes (race days, registration, check-in)
+	if s.eventSvc != nil && s.authMiddleware != nil {
+		eventAPI := NewEventAPI(s.eventSvc, s.authMiddleware, s.logger)
+		routes = append(routes, withFeature(models.TenantFeatureEvents, eventAPI.Routes())...)
 	}
 
 	// Profile routes (user profile management)
 	if s.userStore != nil && s.authMiddleware != nil && s.imageSvc != nil {
 		profileAPI := NewProfileAPI(s.userStore, s.imageSvc, s.authMiddleware, s.logger)
-		profileAPI.RegisterRoutes(mux, s.corsMiddleware)
+		routes = append(routes, profileAPI.Routes()...)
 	}
 
 	// Generic image moderation + serving endpoints
 	if s.authMiddleware != nil && s.imageSvc != nil {
 		imageAPI := NewImageAPI(s.imageSvc, s.authMiddleware, s.logger)
-		imageAPI.RegisterRoutes(mux, s.corsMiddleware)
+		routes = append(routes, imageAPI.Routes()...)
 	}
 
 	// Pilot routes (social/pilot directory)
 	if s.userStore != nil && s.aircraftStore != nil && s.authMiddleware != nil {
-		pilotAPI := NewPilotAPI(s.userStore, s.aircraftStore, s.fcConfigStore, s.authMiddleware, s.logger)
-		pilotAPI.RegisterRoutes(mux, s.corsMiddleware)
+		pilotAPI := NewPilotAPI(s.userStore, s.aircraftStore, s.fcConfigStore, s.imageSvc, s.authMiddleware, s.logger)
+		routes = append(routes, withFeature(models.TenantFeatureSocial, pilotAPI.Routes())...)
 	}
 
 	// Social routes (follow/unfollow, social settings)
 	if s.userStore != nil && s.authMiddleware != nil {
 		socialAPI := NewSocialAPI(s.userStore, s.authMiddleware, s.logger)
-		socialAPI.RegisterRoutes(mux, s.corsMiddleware)
+		routes = append(routes, withFeature(models.TenantFeatureSocial, socialAPI.Routes())...)
 	}
 
 	// FC Config routes (flight controller tuning)
 	if s.fcConfigStore != nil && s.authMiddleware != nil {
 		fcConfigAPI := NewFCConfigAPI(s.fcConfigStore, s.inventoryStore, s.authMiddleware, s.logger)
-		fcConfigAPI.RegisterRoutes(mux, s.corsMiddleware)
+		routes = append(routes, fcConfigAPI.Routes()...)
 	}
 
 	// Gear Catalog routes (crowd-sourced gear definitions)
 	if s.gearCatalogStore != nil && s.authMiddleware != nil {
-		gearCatalogAPI := NewGearCatalogAPI(s.gearCatalogStore, s.imageSvc, s.authMiddleware, s.logger)
-		gearCatalogAPI.RegisterRoutes(mux, s.corsMiddleware)
+		gearCatalogAPI := NewGearCatalogAPI(s.gearCatalogStore, s.userStore, s.imageSvc, s.seoSvc, s.publishRules, s.reputation, s.viewSvc, s.getClientIP, s.authMiddleware, s.logger)
+		routes = append(routes, gearCatalogAPI.Routes()...)
+	}
+	if s.gearCatalogStore != nil {
+		publicCatalogAPI := NewPublicCatalogAPI(s.gearCatalogStore, s.catalogLimiter, s.getClientIP, s.logger)
+		routes = append(routes, publicCatalogAPI.Routes()...)
+	}
+	if s.recommendSvc != nil {
+		recommendationAPI := NewRecommendationAPI(s.recommendSvc, s.logger)
+		routes = append(routes, recommendationAPI.Routes()...)
+	}
+	if s.ecosystemSvc != nil {
+		ecosystemAPI := NewEcosystemAPI(s.ecosystemSvc, s.logger)
+		routes = append(routes, ecosystemAPI.Routes()...)
+	}
+	if s.fwTargetSvc != nil {
+		firmwareTargetAPI := NewFirmwareTargetAPI(s.fwTargetSvc, s.logger)
+		routes = append(routes, firmwareTargetAPI.Routes()...)
 	}
 
 	// Admin routes (content moderation + user admin).
 	if s.gearCatalogStore != nil && s.userStore != nil && s.authMiddleware != nil && s.imageSvc != nil {
-		adminAPI := NewAdminAPI(s.gearCatalogStore, s.userStore, s.buildSvc, s.imageSvc, s.authMiddleware, s.logger)
-		adminAPI.RegisterRoutes(mux, s.corsMiddleware)
+		adminAPI := NewAdminAPI(s.gearCatalogStore, s.brandStore, s.userStore, s.buildSvc, s.featuredSvc, s.announcementSvc, s.homeSvc, s.policySvc, s.tenancySvc, s.shortLinkSvc, s.imageSvc, s.imageRescanner, s.moderationPolicies, s.publishRules, s.reputation, s.moderationClaims, s.moderationSLA, s.imageSourcing, s.linkCheckSvc, s.feedFilterSvc, s.queryStats, s.adminSearch, s.dbPool, s.inactivitySvc, s.equipmentSvc, s.authMiddleware, s.logger)
+		routes = append(routes, adminAPI.Routes()...)
+	}
+
+	// Web frontend, for everything no other route matches
+	if s.webUI != nil {
+		routes = append(routes, Route{Pattern: "/", Access: AccessPublic, NoCORS: true, Handler: s.webUI.ServeHTTP})
+	}
+
+	// Prometheus metrics, for scrapers holding the metrics token
+	if s.dbPool != nil && s.metricsToken != "" {
+		routes = append(routes, Route{Method: http.MethodGet, Pattern: "/metrics", Access: AccessPublic, NoCORS: true, Handler: s.handleMetrics})
 	}
 
 	// Health check
-	mux.HandleFunc("/health", s.handleHealth)
+	return append(routes, Route{Pattern: "/health", Access: AccessPublic, NoCORS: true, Handler: s.handleHealth})
+}
 
+// handler serves the route table. Forwarded proxy headers are applied first
+// so tenant lookups and generated URLs see the host the visitor used.
+func (s *Server) handler() http.Handler {
+	rt := &router{cors: s.corsMiddleware, authMiddleware: s.authMiddleware, logger: s.logger}
+	if s.userStore != nil {
+		rt.users = s.userStore
+	}
+	if s.policySvc != nil {
+		rt.policies = s.policySvc
+	}
+	if s.tenancySvc != nil {
+		rt.tenants = s.tenancySvc
+		return s.publicURL.ProxyHeaders(rt.scopeTenant(rt.mux(s.routes())))
+	}
+	return s.publicURL.ProxyHeaders(rt.mux(s.routes()))
+}
+
+// withFeature marks routes as part of a feature tenants can switch off
+func withFeature(feature models.TenantFeature, routes []Route) []Route {
+	for i := range routes {
+		routes[i].Feature = feature
+	}
+	return routes
+}
+
+func (s *Server) Start(addr string) error {
 	s.server = &http.Server{
 		Addr:         addr,
-		Handler:      mux,
+		Handler:      s.handler(),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 	}
@@ -180,8 +502,9 @@ func (s *Server) Shutdown(ctx context.Context) error {
 func (s *Server) corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Upload-Offset, Upload-Checksum, X-Captcha-Token")
+		w.Header().Set("Access-Control-Expose-Headers", "Location, Upload-Offset, Upload-Length, Upload-Expires")
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -194,7 +517,7 @@ func (s *Server) corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
 
 func (s *Server) handleGetItems(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -237,9 +560,63 @@ func (s *Server) handleGetItems(w http.ResponseWriter, r *http.Request) {
 	s.writeJSON(w, http.StatusOK, response)
 }
 
+// maxFeedSearchQuery bounds the search text accepted by /api/feed/search
+const maxFeedSearchQuery = 200
+
+// handleFeedSearch handles GET /api/feed/search?q=...
+func (s *Server) handleFeedSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	q := strings.TrimSpace(query.Get("q"))
+	if len(q) < 2 || len(q) > maxFeedSearchQuery {
+		s.writeJSON(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("q must be between 2 and %d characters", maxFeedSearchQuery)})
+		return
+	}
+
+	limit := parseIntQuery(query.Get("limit"), 20)
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	offset := parseIntQuery(query.Get("offset"), 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	var sources []string
+	if s := query.Get("sources"); s != "" {
+		sources = strings.Split(s, ",")
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := s.agg.Search(ctx, models.FilterParams{
+		Limit:      limit,
+		Offset:     offset,
+		Sources:    sources,
+		SourceType: query.Get("sourceType"),
+		Query:      q,
+		Sort:       query.Get("sort"),
+		FromDate:   query.Get("fromDate"),
+		ToDate:     query.Get("toDate"),
+		Tag:        query.Get("tag"),
+	})
+	if err != nil {
+		if errors.Is(err, aggregator.ErrArchiveUnavailable) {
+			s.writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "search is not available"})
+			return
+		}
+		s.logger.Error("Feed search failed", logging.WithField("error", err.Error()))
+		s.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to search feed"})
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, response)
+}
+
 func (s *Server) handleGetSources(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -250,9 +627,20 @@ func (s *Server) handleGetSources(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleFeedSchedules handles GET /api/admin/feed/sources, each source's
+// fetch schedule and metrics
+func (s *Server) handleFeedSchedules(w http.ResponseWriter, r *http.Request) {
+	schedules := s.agg.Schedules()
+	w.Header().Set("Cache-Control", "no-store")
+	s.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"sources": schedules,
+		"count":   len(schedules),
+	})
+}
+
 func (s *Server) handleRefresh(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -308,6 +696,15 @@ func (s *Server) getClientIP(r *http.Request) string {
 	return addr
 }
 
+// viewerFromRequest identifies the visitor behind a page view
+func viewerFromRequest(r *http.Request, clientIP string) views.Viewer {
+	return views.Viewer{
+		UserID:    auth.GetUserID(r.Context()),
+		IP:        clientIP,
+		UserAgent: r.UserAgent(),
+	}
+}
+
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	s.writeJSON(w, http.StatusOK, map[string]string{
 		"status": "healthy",
@@ -315,7 +712,5 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+	writeJSON(w, status, data)
 }
@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/johnrirwin/flyingforge/internal/database"
 	"github.com/johnrirwin/flyingforge/internal/logging"
 	"github.com/johnrirwin/flyingforge/internal/models"
 )
@@ -287,3 +289,30 @@ func testParsePagination(r *http.Request, defaultLimit, maxLimit int) (limit, of
 
 	return limit, offset
 }
+
+func TestHandleMetrics_RequiresToken(t *testing.T) {
+	s := &Server{dbPool: database.NewPoolMonitor(nil, 0), metricsToken: "scrape-me"}
+
+	for _, header := range []string{"", "Bearer wrong", "scrape-me"} {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+		s.handleMetrics(w, req)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Authorization %q: status = %d, want 401", header, w.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer scrape-me")
+	w := httptest.NewRecorder()
+	s.handleMetrics(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "# TYPE flyingforge_db_wait_count_total counter") {
+		t.Errorf("body missing pool metrics:\n%s", w.Body.String())
+	}
+}
@@ -0,0 +1,112 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/publicurl"
+	"github.com/johnrirwin/flyingforge/internal/shortlinks"
+)
+
+// ShortLinkAPI serves short link redirects and lookups
+type ShortLinkAPI struct {
+	shortLinkSvc *shortlinks.Service
+	publicURL    *publicurl.Resolver
+	logger       *logging.Logger
+}
+
+// NewShortLinkAPI creates a new short link API handler
+func NewShortLinkAPI(shortLinkSvc *shortlinks.Service, publicURL *publicurl.Resolver, logger *logging.Logger) *ShortLinkAPI {
+	return &ShortLinkAPI{
+		shortLinkSvc: shortLinkSvc,
+		publicURL:    publicURL,
+		logger:       logger,
+	}
+}
+
+// Routes returns the short link route table
+func (api *ShortLinkAPI) Routes() []Route {
+	return []Route{
+		// Public redirects - served at the site root so links stay short
+		{Pattern: shortlinks.PathPrefix(models.ShortLinkBuild), Access: AccessPublic, NoCORS: true, Handler: api.redirectHandler(models.ShortLinkBuild)},
+		{Pattern: shortlinks.PathPrefix(models.ShortLinkGear), Access: AccessPublic, NoCORS: true, Handler: api.redirectHandler(models.ShortLinkGear)},
+
+		// Public lookup for share buttons
+		{Pattern: "/api/shortlinks/", Access: AccessPublic, Handler: api.handleGetShortLink},
+	}
+}
+
+// redirectHandler handles GET /b/{slug} and /g/{slug}
+func (api *ShortLinkAPI) redirectHandler(targetType models.ShortLinkTargetType) http.HandlerFunc {
+	prefix := shortlinks.PathPrefix(targetType)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+
+		target, err := api.shortLinkSvc.Resolve(ctx, targetType, strings.TrimPrefix(r.URL.Path, prefix))
+		if err != nil {
+			api.logger.Error("Short link resolve failed", logging.WithField("error", err.Error()))
+			apierror.WriteStatus(w, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		if target == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		// Temporary redirect so every visit reaches us and is counted
+		w.Header().Set("Cache-Control", "no-store")
+		http.Redirect(w, r, target, http.StatusFound)
+	}
+}
+
+// handleGetShortLink handles GET /api/shortlinks/{build|gear}/{id}
+func (api *ShortLinkAPI) handleGetShortLink(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/shortlinks/"), "/")
+	if len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	link, err := api.shortLinkSvc.Ensure(ctx, models.ShortLinkTargetType(parts[0]), parts[1])
+	if err != nil {
+		var svcErr *shortlinks.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+			return
+		}
+		api.logger.Error("Short link lookup failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to get short link"})
+		return
+	}
+	if link == nil {
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found or not published"})
+		return
+	}
+
+	link.URL = api.publicURL.Absolute(r, link.Path)
+	api.writeJSON(w, http.StatusOK, link)
+}
+
+func (api *ShortLinkAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
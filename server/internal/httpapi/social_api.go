@@ -2,10 +2,12 @@ package httpapi
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/johnrirwin/flyingforge/internal/apierror"
 	"github.com/johnrirwin/flyingforge/internal/auth"
 	"github.com/johnrirwin/flyingforge/internal/database"
 	"github.com/johnrirwin/flyingforge/internal/logging"
@@ -28,16 +30,18 @@ func NewSocialAPI(userStore *database.UserStore, authMiddleware *auth.Middleware
 	}
 }
 
-// RegisterRoutes registers social routes on the given mux
-func (api *SocialAPI) RegisterRoutes(mux *http.ServeMux, corsMiddleware func(http.HandlerFunc) http.HandlerFunc) {
-	// Follow endpoints
-	mux.HandleFunc("/api/social/follow/", corsMiddleware(api.authMiddleware.RequireAuth(api.handleFollow)))
+// Routes returns the social route table
+func (api *SocialAPI) Routes() []Route {
+	return []Route{
+		// Follow endpoints
+		{Pattern: "/api/social/follow/", Access: AccessUser, Handler: api.handleFollow},
 
-	// Followers/following lists
-	mux.HandleFunc("/api/social/", corsMiddleware(api.authMiddleware.RequireAuth(api.handleSocialLists)))
+		// Followers/following lists
+		{Pattern: "/api/social/", Access: AccessUser, Handler: api.handleSocialLists},
 
-	// Social settings
-	mux.HandleFunc("/api/me/social-settings", corsMiddleware(api.authMiddleware.RequireAuth(api.handleSocialSettings)))
+		// Social settings
+		{Pattern: "/api/me/social-settings", Access: AccessUser, Handler: api.handleSocialSettings},
+	}
 }
 
 // handleFollow handles POST/DELETE /api/social/follow/:userId
@@ -52,7 +56,7 @@ func (api *SocialAPI) handleFollow(w http.ResponseWriter, r *http.Request) {
 	targetUserID := strings.TrimSuffix(path, "/")
 
 	if targetUserID == "" {
-		api.writeError(w, http.StatusBadRequest, "invalid_request", "user ID required")
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "user ID required")
 		return
 	}
 
@@ -64,7 +68,7 @@ func (api *SocialAPI) handleFollow(w http.ResponseWriter, r *http.Request) {
 	case http.MethodDelete:
 		api.unfollowUser(w, r, userID, targetUserID)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -76,11 +80,11 @@ func (api *SocialAPI) followUser(w http.ResponseWriter, r *http.Request, followe
 	follower, err := api.userStore.GetByID(ctx, followerID)
 	if err != nil {
 		api.logger.Error("Failed to get follower user", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to follow user")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to follow user")
 		return
 	}
 	if follower == nil || follower.CallSign == "" {
-		api.writeError(w, http.StatusBadRequest, "callsign_required", "you must set a call sign before following other pilots")
+		api.writeError(w, http.StatusBadRequest, apierror.CallsignRequired, "you must set a call sign before following other pilots")
 		return
 	}
 
@@ -88,29 +92,29 @@ func (api *SocialAPI) followUser(w http.ResponseWriter, r *http.Request, followe
 	targetUser, err := api.userStore.GetByID(ctx, followedID)
 	if err != nil {
 		api.logger.Error("Failed to get target user", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to follow user")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to follow user")
 		return
 	}
 	if targetUser == nil {
-		api.writeError(w, http.StatusNotFound, "not_found", "user not found")
+		api.writeError(w, http.StatusNotFound, apierror.NotFound, "user not found")
 		return
 	}
 
 	// Check if target user has private profile
 	if targetUser.SocialSettings.ProfileVisibility == models.ProfileVisibilityPrivate {
-		api.writeError(w, http.StatusForbidden, "private_profile", "cannot follow a private profile")
+		api.writeError(w, http.StatusForbidden, apierror.ProfilePrivate, "cannot follow a private profile")
 		return
 	}
 
 	// Create follow relationship
 	follow, err := api.userStore.CreateFollow(ctx, followerID, followedID)
 	if err != nil {
-		if strings.Contains(err.Error(), "cannot follow yourself") {
-			api.writeError(w, http.StatusBadRequest, "invalid_request", "cannot follow yourself")
+		if errors.Is(err, database.ErrCannotFollowSelf) {
+			api.writeError(w, http.StatusBadRequest, apierror.CannotFollowSelf, "cannot follow yourself")
 			return
 		}
 		api.logger.Error("Failed to create follow", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to follow user")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to follow user")
 		return
 	}
 
@@ -128,7 +132,7 @@ func (api *SocialAPI) unfollowUser(w http.ResponseWriter, r *http.Request, follo
 	err := api.userStore.DeleteFollow(ctx, followerID, followedID)
 	if err != nil {
 		api.logger.Error("Failed to delete follow", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to unfollow user")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to unfollow user")
 		return
 	}
 
@@ -145,7 +149,7 @@ func (api *SocialAPI) handleSocialLists(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -154,7 +158,7 @@ func (api *SocialAPI) handleSocialLists(w http.ResponseWriter, r *http.Request)
 	parts := strings.Split(strings.TrimSuffix(path, "/"), "/")
 
 	if len(parts) < 2 {
-		api.writeError(w, http.StatusBadRequest, "invalid_request", "invalid path")
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "invalid path")
 		return
 	}
 
@@ -182,7 +186,7 @@ func (api *SocialAPI) handleSocialLists(w http.ResponseWriter, r *http.Request)
 		response, err := api.userStore.GetFollowers(ctx, userID, limit, offset)
 		if err != nil {
 			api.logger.Error("Failed to get followers", logging.WithField("error", err.Error()))
-			api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to get followers")
+			api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to get followers")
 			return
 		}
 		api.writeJSON(w, http.StatusOK, response)
@@ -191,13 +195,13 @@ func (api *SocialAPI) handleSocialLists(w http.ResponseWriter, r *http.Request)
 		response, err := api.userStore.GetFollowing(ctx, userID, limit, offset)
 		if err != nil {
 			api.logger.Error("Failed to get following", logging.WithField("error", err.Error()))
-			api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to get following")
+			api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to get following")
 			return
 		}
 		api.writeJSON(w, http.StatusOK, response)
 
 	default:
-		api.writeError(w, http.StatusBadRequest, "invalid_request", "invalid list type")
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "invalid list type")
 	}
 }
 
@@ -216,7 +220,7 @@ func (api *SocialAPI) handleSocialSettings(w http.ResponseWriter, r *http.Reques
 	case http.MethodPut:
 		api.updateSocialSettings(w, r, userID)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
@@ -225,11 +229,11 @@ func (api *SocialAPI) getSocialSettings(w http.ResponseWriter, r *http.Request,
 	user, err := api.userStore.GetByID(r.Context(), userID)
 	if err != nil {
 		api.logger.Error("Failed to get user", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to get settings")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to get settings")
 		return
 	}
 	if user == nil {
-		api.writeError(w, http.StatusNotFound, "not_found", "user not found")
+		api.writeError(w, http.StatusNotFound, apierror.NotFound, "user not found")
 		return
 	}
 
@@ -240,21 +244,21 @@ func (api *SocialAPI) getSocialSettings(w http.ResponseWriter, r *http.Request,
 func (api *SocialAPI) updateSocialSettings(w http.ResponseWriter, r *http.Request, userID string) {
 	var params models.UpdateSocialSettingsParams
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		api.writeError(w, http.StatusBadRequest, "invalid_json", "invalid request body")
+		api.writeError(w, http.StatusBadRequest, apierror.InvalidBody, "invalid request body")
 		return
 	}
 
 	// Validate profile visibility if provided
 	if params.ProfileVisibility != nil {
 		if *params.ProfileVisibility != models.ProfileVisibilityPublic && *params.ProfileVisibility != models.ProfileVisibilityPrivate {
-			api.writeError(w, http.StatusBadRequest, "invalid_value", "profileVisibility must be 'public' or 'private'")
+			api.writeError(w, http.StatusBadRequest, apierror.InvalidRequest, "profileVisibility must be 'public' or 'private'")
 			return
 		}
 	}
 
 	if err := api.userStore.UpdateSocialSettings(r.Context(), userID, params); err != nil {
 		api.logger.Error("Failed to update social settings", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to update settings")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to update settings")
 		return
 	}
 
@@ -262,7 +266,7 @@ func (api *SocialAPI) updateSocialSettings(w http.ResponseWriter, r *http.Reques
 	user, err := api.userStore.GetByID(r.Context(), userID)
 	if err != nil {
 		api.logger.Error("Failed to get updated user", logging.WithField("error", err.Error()))
-		api.writeError(w, http.StatusInternalServerError, "internal_error", "failed to get updated settings")
+		api.writeError(w, http.StatusInternalServerError, apierror.Internal, "failed to get updated settings")
 		return
 	}
 
@@ -271,15 +275,10 @@ func (api *SocialAPI) updateSocialSettings(w http.ResponseWriter, r *http.Reques
 
 // writeJSON writes a JSON response
 func (api *SocialAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+	writeJSON(w, status, data)
 }
 
 // writeError writes an error response
-func (api *SocialAPI) writeError(w http.ResponseWriter, status int, code, message string) {
-	api.writeJSON(w, status, map[string]string{
-		"error":   code,
-		"message": message,
-	})
+func (api *SocialAPI) writeError(w http.ResponseWriter, status int, code apierror.Code, message string) {
+	apierror.Write(w, status, code, message)
 }
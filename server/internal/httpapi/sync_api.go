@@ -0,0 +1,130 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/offlinesync"
+)
+
+// maxSyncBatchBodyBytes bounds the size of a batched sync request body
+const maxSyncBatchBodyBytes = 2 << 20
+
+// SyncAPI handles HTTP API requests for offline sync
+type SyncAPI struct {
+	syncSvc        *offlinesync.Service
+	authMiddleware *auth.Middleware
+	logger         *logging.Logger
+}
+
+// NewSyncAPI creates a new sync API handler
+func NewSyncAPI(syncSvc *offlinesync.Service, authMiddleware *auth.Middleware, logger *logging.Logger) *SyncAPI {
+	return &SyncAPI{
+		syncSvc:        syncSvc,
+		authMiddleware: authMiddleware,
+		logger:         logger,
+	}
+}
+
+// Routes returns the sync route table
+func (api *SyncAPI) Routes() []Route {
+	return []Route{
+		// Sync routes (require authentication)
+		{Pattern: "/api/sync/changes", Access: AccessUser, Handler: api.handleChanges},
+		{Pattern: "/api/sync/batch", Access: AccessUser, Handler: api.handleBatch},
+	}
+}
+
+// handleChanges handles GET /api/sync/changes?since=<cursor>&types=inventory,aircraft,battery&limit=N
+func (api *SyncAPI) handleChanges(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := auth.GetUserID(r.Context())
+	query := r.URL.Query()
+
+	since, ok := models.ParseSyncCursor(query.Get("since"))
+	if !ok {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid since cursor"})
+		return
+	}
+
+	params := models.SyncChangesParams{Since: since}
+	if types := query.Get("types"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				params.Types = append(params.Types, models.SyncEntityType(t))
+			}
+		}
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
+			params.Limit = l
+		}
+	}
+
+	response, err := api.syncSvc.Changes(r.Context(), userID, params)
+	if err != nil {
+		var svcErr *offlinesync.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+			return
+		}
+		api.logger.Error("Sync changes failed", logging.WithFields(map[string]interface{}{
+			"userId": userID,
+			"error":  err.Error(),
+		}))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to load changes"})
+		return
+	}
+
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+// handleBatch handles POST /api/sync/batch. Operations are applied independently;
+// the response always reports a result per operation.
+func (api *SyncAPI) handleBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID := auth.GetUserID(r.Context())
+
+	var req models.SyncBatchRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSyncBatchBodyBytes)).Decode(&req); err != nil {
+		apierror.WriteStatus(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := api.syncSvc.ApplyBatch(r.Context(), userID, req)
+	if err != nil {
+		var svcErr *offlinesync.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+			return
+		}
+		api.logger.Error("Sync batch failed", logging.WithFields(map[string]interface{}{
+			"userId": userID,
+			"error":  err.Error(),
+		}))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to apply batch"})
+		return
+	}
+
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+// writeJSON writes a JSON response
+func (api *SyncAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
@@ -0,0 +1,45 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/tenancy"
+)
+
+// TenantAPI serves the branding and features of the site a request is for
+type TenantAPI struct {
+	tenancySvc *tenancy.Service
+	logger     *logging.Logger
+}
+
+// NewTenantAPI creates a new tenant API handler
+func NewTenantAPI(tenancySvc *tenancy.Service, logger *logging.Logger) *TenantAPI {
+	return &TenantAPI{
+		tenancySvc: tenancySvc,
+		logger:     logger,
+	}
+}
+
+// Routes returns the tenant route table
+func (api *TenantAPI) Routes() []Route {
+	return []Route{
+		{Method: http.MethodGet, Pattern: "/api/tenant", Access: AccessPublic, Handler: api.handleCurrentTenant},
+	}
+}
+
+// handleCurrentTenant handles GET /api/tenant, the branding and enabled
+// features for the requested hostname
+func (api *TenantAPI) handleCurrentTenant(w http.ResponseWriter, r *http.Request) {
+	tenant := tenancy.FromContext(r.Context())
+	if tenant == nil {
+		tenant = api.tenancySvc.Resolve(r.Host)
+	}
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Header().Set("Vary", "Host")
+	api.writeJSON(w, http.StatusOK, api.tenancySvc.Public(tenant))
+}
+
+func (api *TenantAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
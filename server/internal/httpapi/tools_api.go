@@ -0,0 +1,347 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/aircraft"
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/battery"
+	"github.com/johnrirwin/flyingforge/internal/builds"
+	"github.com/johnrirwin/flyingforge/internal/calc"
+	"github.com/johnrirwin/flyingforge/internal/calc/vtx"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// Weight sources reported with a flight time estimate
+const (
+	weightSourceRequest = "request"
+	weightSourceBuild   = "build"
+)
+
+// ToolsAPI serves FPV calculators that can use the caller's own gear
+type ToolsAPI struct {
+	aircraftSvc    *aircraft.Service
+	buildSvc       *builds.Service
+	batterySvc     *battery.Service
+	authMiddleware *auth.Middleware
+	logger         *logging.Logger
+}
+
+// NewToolsAPI creates a new tools API handler. The aircraft, build and battery
+// services are optional; without them callers give every input explicitly.
+func NewToolsAPI(aircraftSvc *aircraft.Service, buildSvc *builds.Service, batterySvc *battery.Service, authMiddleware *auth.Middleware, logger *logging.Logger) *ToolsAPI {
+	return &ToolsAPI{
+		aircraftSvc:    aircraftSvc,
+		buildSvc:       buildSvc,
+		batterySvc:     batterySvc,
+		authMiddleware: authMiddleware,
+		logger:         logger,
+	}
+}
+
+// Routes returns the tool route table
+func (api *ToolsAPI) Routes() []Route {
+	return []Route{
+		// Public, but a signed-in user can use their own builds and batteries
+		{Pattern: "/api/tools/flight-time", Access: AccessOptional, Handler: api.handleFlightTime},
+		{Pattern: "/api/tools/vtx-plan", Access: AccessOptional, Handler: api.handleVTXPlan},
+		{Method: http.MethodPost, Pattern: "/api/tools/build-wizard", Access: AccessPublic, Handler: api.handleBuildWizard},
+	}
+}
+
+type flightTimeRequest struct {
+	WeightGrams        float64          `json:"weightGrams,omitempty"` // aircraft without battery
+	BuildID            string           `json:"buildId,omitempty"`
+	BatteryID          string           `json:"batteryId,omitempty"`
+	Cells              int              `json:"cells,omitempty"`
+	CapacityMah        int              `json:"capacityMah,omitempty"`
+	Chemistry          string           `json:"chemistry,omitempty"`
+	BatteryWeightGrams float64          `json:"batteryWeightGrams,omitempty"`
+	Style              calc.FlyingStyle `json:"style,omitempty"`
+}
+
+type flightTimeResponse struct {
+	*calc.FlightTimeEstimate
+	WeightSource string `json:"weightSource"`
+	// PartsWithoutWeight lists build parts whose catalog specs have no weight
+	PartsWithoutWeight []string `json:"partsWithoutWeight,omitempty"`
+}
+
+// handleFlightTime handles POST /api/tools/flight-time
+func (api *ToolsAPI) handleFlightTime(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req flightTimeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	userID := auth.GetUserID(r.Context())
+
+	input := calc.FlightTimeInput{
+		WeightGrams:        req.WeightGrams,
+		BatteryWeightGrams: req.BatteryWeightGrams,
+		Cells:              req.Cells,
+		CapacityMah:        req.CapacityMah,
+		Chemistry:          req.Chemistry,
+		Style:              calc.FlyingStyle(strings.ToLower(strings.TrimSpace(string(req.Style)))),
+	}
+	response := flightTimeResponse{WeightSource: weightSourceRequest}
+
+	// An explicit weight wins over the build's catalog weights
+	if buildID := strings.TrimSpace(req.BuildID); buildID != "" && req.WeightGrams <= 0 {
+		if api.buildSvc == nil {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "builds are not available"})
+			return
+		}
+		build, err := api.buildSvc.GetOwnedOrPublic(ctx, buildID, userID)
+		if err != nil {
+			api.logger.Error("Flight time build lookup failed", logging.WithField("error", err.Error()))
+			api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to load build"})
+			return
+		}
+		if build == nil {
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "build not found"})
+			return
+		}
+		input.WeightGrams, response.PartsWithoutWeight = buildWeightGrams(build.Parts)
+		response.WeightSource = weightSourceBuild
+		if input.WeightGrams <= 0 {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "build parts have no catalog weights; provide weightGrams"})
+			return
+		}
+	}
+
+	if batteryID := strings.TrimSpace(req.BatteryID); batteryID != "" {
+		if api.batterySvc == nil {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "batteries are not available"})
+			return
+		}
+		if userID == "" {
+			api.writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "sign in to use a saved battery"})
+			return
+		}
+		pack, err := api.batterySvc.Get(ctx, batteryID, userID)
+		if err != nil {
+			api.logger.Error("Flight time battery lookup failed", logging.WithField("error", err.Error()))
+			api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to load battery"})
+			return
+		}
+		if pack == nil {
+			api.writeJSON(w, http.StatusNotFound, map[string]string{"error": "battery not found"})
+			return
+		}
+		applyBattery(&input, pack)
+	}
+
+	estimate, err := calc.EstimateFlightTime(input)
+	if err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	response.FlightTimeEstimate = estimate
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+type vtxPlanPilot struct {
+	Name       string `json:"name"`
+	AircraftID string `json:"aircraftId,omitempty"` // caller's aircraft to read the VTX from
+	vtx.Capability
+}
+
+type vtxPlanRequest struct {
+	Pilots  []vtxPlanPilot `json:"pilots"`
+	PowerMw int            `json:"powerMw,omitempty"`
+}
+
+// handleVTXPlan handles POST /api/tools/vtx-plan?format=json|md|pdf
+func (api *ToolsAPI) handleVTXPlan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	switch format {
+	case "", vtx.FormatJSON, vtx.FormatMarkdown, vtx.FormatPDF:
+	default:
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "format must be one of json, md, pdf"})
+		return
+	}
+
+	var req vtxPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	userID := auth.GetUserID(r.Context())
+
+	pilots := make([]vtx.Pilot, len(req.Pilots))
+	for i, entry := range req.Pilots {
+		pilot := vtx.Pilot{Name: entry.Name, Capability: entry.Capability}
+		if aircraftID := strings.TrimSpace(entry.AircraftID); aircraftID != "" {
+			status, message := api.applyAircraftVTX(ctx, &pilot, aircraftID, userID)
+			if status != http.StatusOK {
+				api.writeJSON(w, status, map[string]string{"error": message})
+				return
+			}
+		}
+		pilots[i] = pilot
+	}
+
+	plan, err := vtx.NewPlan(pilots, vtx.Options{PowerMw: req.PowerMw}, time.Now())
+	if err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	if format == "" || format == vtx.FormatJSON {
+		api.writeJSON(w, http.StatusOK, plan)
+		return
+	}
+	body, contentType, err := vtx.Render(plan, format)
+	if err != nil {
+		api.logger.Error("Render VTX plan failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to render plan"})
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="vtx-plan.%s"`, format))
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// applyAircraftVTX fills a pilot's VTX capability from the specs of one of
+// the caller's aircraft. Fields given in the request are kept. Returns the
+// HTTP status and error message when the aircraft can't be used.
+func (api *ToolsAPI) applyAircraftVTX(ctx context.Context, pilot *vtx.Pilot, aircraftID string, userID string) (int, string) {
+	if api.aircraftSvc == nil {
+		return http.StatusBadRequest, "aircraft are not available"
+	}
+	if userID == "" {
+		return http.StatusUnauthorized, "sign in to plan with your aircraft"
+	}
+	details, err := api.aircraftSvc.GetDetails(ctx, aircraftID, userID)
+	if err != nil {
+		api.logger.Error("VTX plan aircraft lookup failed", logging.WithField("error", err.Error()))
+		return http.StatusInternalServerError, "failed to load aircraft"
+	}
+	if details == nil {
+		return http.StatusNotFound, "aircraft not found"
+	}
+
+	item := details.ComponentItem(models.ComponentCategoryVTX)
+	if item == nil {
+		return http.StatusBadRequest, fmt.Sprintf("%s has no VTX component", details.Aircraft.Name)
+	}
+
+	fromSpecs := vtx.CapabilityFromSpecs(item.Specs)
+	if pilot.System == "" {
+		pilot.System = fromSpecs.System
+	}
+	if len(pilot.Bands) == 0 {
+		pilot.Bands = fromSpecs.Bands
+	}
+	if len(pilot.FrequenciesMHz) == 0 {
+		pilot.FrequenciesMHz = fromSpecs.FrequenciesMHz
+	}
+	if len(pilot.PowerLevelsMw) == 0 {
+		pilot.PowerLevelsMw = fromSpecs.PowerLevelsMw
+	}
+	if strings.TrimSpace(pilot.Name) == "" {
+		pilot.Name = details.Aircraft.Name
+	}
+	return http.StatusOK, ""
+}
+
+// buildWeightGrams sums catalog weights for a build's parts, one unit per
+// part, and names the parts that have none
+func buildWeightGrams(parts []models.BuildPart) (float64, []string) {
+	var total float64
+	var missing []string
+	for _, part := range parts {
+		if part.CatalogItem == nil {
+			continue
+		}
+		// Batteries are weighed separately
+		if part.GearType == models.GearTypeBattery {
+			continue
+		}
+		weight, ok := calc.SpecWeightGrams(part.CatalogItem.Specs)
+		if !ok {
+			missing = append(missing, part.CatalogItem.DisplayName())
+			continue
+		}
+		total += weight
+	}
+	return total, missing
+}
+
+// applyBattery fills pack details from a saved battery, keeping any values
+// given explicitly in the request
+func applyBattery(input *calc.FlightTimeInput, pack *models.Battery) {
+	if input.Cells == 0 {
+		input.Cells = pack.Cells
+	}
+	if input.CapacityMah == 0 {
+		input.CapacityMah = pack.CapacityMah
+	}
+	if input.Chemistry == "" {
+		input.Chemistry = string(pack.Chemistry)
+	}
+	if input.BatteryWeightGrams == 0 && pack.WeightGrams != nil {
+		input.BatteryWeightGrams = float64(*pack.WeightGrams)
+	}
+}
+
+// handleBuildWizard handles POST /api/tools/build-wizard
+func (api *ToolsAPI) handleBuildWizard(w http.ResponseWriter, r *http.Request) {
+	if api.buildSvc == nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "builds are not available"})
+		return
+	}
+
+	var params models.BuildWizardParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		return
+	}
+
+	// Longer than the other tools: each candidate looks up live prices
+	ctx, cancel := context.WithTimeout(r.Context(), 20*time.Second)
+	defer cancel()
+
+	response, err := api.buildSvc.BuildWizard(ctx, params)
+	if err != nil {
+		var svcErr *builds.ServiceError
+		if errors.As(err, &svcErr) {
+			api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": svcErr.Message})
+			return
+		}
+		api.logger.Error("Build wizard failed", logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to compose builds"})
+		return
+	}
+	api.writeJSON(w, http.StatusOK, response)
+}
+
+func (api *ToolsAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
@@ -0,0 +1,218 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/auth"
+	"github.com/johnrirwin/flyingforge/internal/blackbox"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	radiosvc "github.com/johnrirwin/flyingforge/internal/radio"
+	"github.com/johnrirwin/flyingforge/internal/uploads"
+)
+
+// chunkContentType is the content type chunks are sent as, following tus
+const chunkContentType = "application/offset+octet-stream"
+
+// statusChecksumMismatch is tus's status for a chunk that fails its checksum
+const statusChecksumMismatch = 460
+
+// UploadAPI handles resumable uploads. The protocol follows tus: the client
+// creates an upload, sends the file in PATCH requests that each carry the
+// offset they start at, and after a dropped connection asks with HEAD where
+// to resume.
+type UploadAPI struct {
+	uploadSvc *uploads.Service
+	logger    *logging.Logger
+}
+
+// NewUploadAPI creates a new upload API handler
+func NewUploadAPI(uploadSvc *uploads.Service, logger *logging.Logger) *UploadAPI {
+	return &UploadAPI{
+		uploadSvc: uploadSvc,
+		logger:    logger,
+	}
+}
+
+// Routes returns the upload route table
+func (api *UploadAPI) Routes() []Route {
+	return []Route{
+		{Method: http.MethodPost, Pattern: "/api/uploads", Access: AccessUser, Handler: api.handleCreate},
+		{Method: http.MethodHead, Pattern: "/api/uploads/{id}", Access: AccessUser, Handler: api.handleHead},
+		{Method: http.MethodGet, Pattern: "/api/uploads/{id}", Access: AccessUser, Handler: api.handleGet},
+		{Method: http.MethodPatch, Pattern: "/api/uploads/{id}", Access: AccessUser, Handler: api.handlePatch},
+		{Method: http.MethodDelete, Pattern: "/api/uploads/{id}", Access: AccessUser, Handler: api.handleDelete},
+	}
+}
+
+// handleCreate handles POST /api/uploads
+func (api *UploadAPI) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var params models.CreateUploadParams
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64*1024)).Decode(&params); err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	upload, err := api.uploadSvc.Create(ctx, auth.GetUserID(r.Context()), params)
+	if err != nil {
+		api.writeError(w, "Create upload failed", err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/uploads/"+upload.ID)
+	api.setUploadHeaders(w, upload)
+	api.writeJSON(w, http.StatusCreated, upload)
+}
+
+// handleHead handles HEAD /api/uploads/{id}, reporting the offset to
+// resume from
+func (api *UploadAPI) handleHead(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	upload, err := api.uploadSvc.Get(ctx, auth.GetUserID(r.Context()), r.PathValue("id"))
+	if err != nil {
+		if errors.Is(err, uploads.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		api.logger.Error("Get upload failed", logging.WithField("error", err.Error()))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	api.setUploadHeaders(w, upload)
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleGet handles GET /api/uploads/{id}
+func (api *UploadAPI) handleGet(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	upload, err := api.uploadSvc.Get(ctx, auth.GetUserID(r.Context()), r.PathValue("id"))
+	if err != nil {
+		api.writeError(w, "Get upload failed", err)
+		return
+	}
+
+	api.setUploadHeaders(w, upload)
+	api.writeJSON(w, http.StatusOK, upload)
+}
+
+// handlePatch handles PATCH /api/uploads/{id}, storing one chunk. It answers
+// 204 with the new Upload-Offset, or 200 with the upload once the last chunk
+// is in and the upload has been completed or has failed.
+func (api *UploadAPI) handlePatch(w http.ResponseWriter, r *http.Request) {
+	if mediaType := strings.TrimSpace(strings.Split(r.Header.Get("Content-Type"), ";")[0]); mediaType != chunkContentType {
+		api.writeJSON(w, http.StatusUnsupportedMediaType, map[string]string{"error": "Content-Type must be " + chunkContentType})
+		return
+	}
+	offset, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Upload-Offset header is required"})
+		return
+	}
+	checksum, err := parseUploadChecksum(r.Header.Get("Upload-Checksum"))
+	if err != nil {
+		api.writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, uploads.MaxChunkSize)
+	extendDeadlines(w, backupTransferTimeout)
+	ctx, cancel := context.WithTimeout(r.Context(), backupTransferTimeout)
+	defer cancel()
+
+	upload, err := api.uploadSvc.WriteChunk(ctx, auth.GetUserID(r.Context()), r.PathValue("id"), offset, r.Body, checksum)
+	if err != nil {
+		api.writeError(w, "Write upload chunk failed", err)
+		return
+	}
+
+	api.setUploadHeaders(w, upload)
+	if upload.Status == models.UploadStatusPending {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	api.writeJSON(w, http.StatusOK, upload)
+}
+
+// handleDelete handles DELETE /api/uploads/{id}, cancelling the upload
+func (api *UploadAPI) handleDelete(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	if err := api.uploadSvc.Cancel(ctx, auth.GetUserID(r.Context()), r.PathValue("id")); err != nil {
+		api.writeError(w, "Cancel upload failed", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setUploadHeaders sets the tus headers describing an upload's progress
+func (api *UploadAPI) setUploadHeaders(w http.ResponseWriter, upload *models.Upload) {
+	w.Header().Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	w.Header().Set("Upload-Length", strconv.FormatInt(upload.Size, 10))
+	if upload.Status == models.UploadStatusPending {
+		w.Header().Set("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	}
+	w.Header().Set("Cache-Control", "no-store")
+}
+
+// writeError maps an upload service error to its status. Errors from the
+// purpose's handler, such as an unknown radio, pass through unchanged.
+func (api *UploadAPI) writeError(w http.ResponseWriter, logMessage string, err error) {
+	var svcErr *uploads.ServiceError
+	var radioErr *radiosvc.ServiceError
+	var blackboxErr *blackbox.ServiceError
+	switch {
+	case errors.Is(err, uploads.ErrNotFound):
+		api.writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, uploads.ErrOffsetConflict), errors.Is(err, uploads.ErrNotPending):
+		api.writeJSON(w, http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, uploads.ErrChunkChecksum):
+		api.writeJSON(w, statusChecksumMismatch, map[string]string{"error": err.Error()})
+	case errors.As(err, &svcErr):
+		api.writeJSON(w, http.StatusBadRequest, errorBody(svcErr))
+	case errors.As(err, &radioErr):
+		api.writeJSON(w, http.StatusBadRequest, errorBody(radioErr))
+	case errors.As(err, &blackboxErr):
+		api.writeJSON(w, http.StatusBadRequest, errorBody(blackboxErr))
+	default:
+		api.logger.Error(logMessage, logging.WithField("error", err.Error()))
+		api.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "upload failed"})
+	}
+}
+
+// parseUploadChecksum reads a tus Upload-Checksum header, "sha256 <base64>",
+// into a hex digest. An empty header skips the check.
+func parseUploadChecksum(header string) (string, error) {
+	if header == "" {
+		return "", nil
+	}
+	algorithm, value, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(algorithm, "sha256") {
+		return "", errors.New("Upload-Checksum must be \"sha256 <base64 digest>\"")
+	}
+	digest, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil || len(digest) != 32 {
+		return "", errors.New("Upload-Checksum must be \"sha256 <base64 digest>\"")
+	}
+	return hex.EncodeToString(digest), nil
+}
+
+func (api *UploadAPI) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, data)
+}
@@ -0,0 +1,277 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/blobstore"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ErrArchiveUnavailable is returned for archive status without an archiver.
+var ErrArchiveUnavailable = errors.New("image archive unavailable")
+
+const (
+	archiveBatchSize = 50
+	// maxArchivePerRun bounds how much one pass moves, so a backlog is
+	// worked off over several runs instead of in one long burst
+	maxArchivePerRun = 1000
+)
+
+// ArchivedImage is an image asset's storage tier. Hot images carry their
+// bytes; cold images name the object holding them.
+type ArchivedImage struct {
+	Tier        models.ImageStorageTier
+	ImageBytes  []byte
+	Key         string
+	Backend     string
+	ContentHash string
+}
+
+// ArchiveObject is an object in cold storage that nothing references any more
+type ArchiveObject struct {
+	Key     string
+	Backend string
+}
+
+// ArchiveStore tracks which image assets are archived and where.
+type ArchiveStore interface {
+	// ListArchiveCandidates returns approved hot images that haven't been
+	// created, restored or shown on a viewed build or catalog item since idleSince
+	ListArchiveCandidates(ctx context.Context, idleSince time.Time, limit int) ([]models.ImageAsset, error)
+	// MarkArchived drops an image's bytes in favor of the object at key.
+	// Returns false if the image changed or was deleted since it was listed.
+	MarkArchived(ctx context.Context, imageID, key, backend string, size int64) (bool, error)
+	// GetArchivedImage returns an image's tier, or nil if it doesn't exist
+	GetArchivedImage(ctx context.Context, imageID string) (*ArchivedImage, error)
+	// MarkRestored puts an archived image's bytes back and queues its object
+	// for removal. Returns false if the image is no longer archived.
+	MarkRestored(ctx context.Context, imageID string, imageBytes []byte) (bool, error)
+	// ListArchiveOrphans returns objects in backend left behind by restored
+	// or deleted images
+	ListArchiveOrphans(ctx context.Context, backend string, limit int) ([]ArchiveObject, error)
+	DeleteArchiveOrphan(ctx context.Context, key string) error
+	TierStats(ctx context.Context) ([]models.ImageTierStats, error)
+}
+
+// Archiver moves the bytes of images nobody has looked at for a while out of
+// the database into object storage, and restores them the first time they
+// are read again.
+type Archiver struct {
+	store     ArchiveStore
+	blobs     blobstore.Store
+	idleAfter time.Duration // zero disables archiving; restores still work
+	logger    *logging.Logger
+
+	archived atomic.Int64
+	restored atomic.Int64
+
+	mu      sync.Mutex
+	lastRun *models.ImageArchiveRun
+}
+
+// NewArchiver creates an archiver that archives images idle for idleAfter
+func NewArchiver(store ArchiveStore, blobs blobstore.Store, idleAfter time.Duration, logger *logging.Logger) *Archiver {
+	return &Archiver{
+		store:     store,
+		blobs:     blobs,
+		idleAfter: idleAfter,
+		logger:    logger,
+	}
+}
+
+// Enabled reports whether idle images are archived
+func (a *Archiver) Enabled() bool {
+	return a.idleAfter > 0
+}
+
+// Run archives idle images and removes objects that are no longer needed.
+func (a *Archiver) Run(ctx context.Context) (*models.ImageArchiveRun, error) {
+	run := &models.ImageArchiveRun{StartedAt: time.Now()}
+	err := a.run(ctx, run)
+	run.FinishedAt = time.Now()
+	if err != nil {
+		run.Error = err.Error()
+	}
+
+	a.mu.Lock()
+	a.lastRun = run
+	a.mu.Unlock()
+	return run, err
+}
+
+func (a *Archiver) run(ctx context.Context, run *models.ImageArchiveRun) error {
+	removed, err := a.removeOrphans(ctx)
+	run.OrphansRemoved = removed
+	if err != nil {
+		return err
+	}
+	if !a.Enabled() {
+		return nil
+	}
+
+	idleSince := time.Now().Add(-a.idleAfter)
+	for run.Archived < maxArchivePerRun {
+		batch, err := a.store.ListArchiveCandidates(ctx, idleSince, archiveBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		moved := 0
+		for _, asset := range batch {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			ok, err := a.archive(ctx, asset)
+			if err != nil {
+				return err
+			}
+			if ok {
+				moved++
+				run.Archived++
+				run.ArchivedBytes += int64(len(asset.ImageBytes))
+			}
+		}
+		// Every candidate changed under us; stop rather than list them again
+		if moved == 0 {
+			return nil
+		}
+	}
+	return nil
+}
+
+// archive copies one image to object storage and drops its bytes from the
+// database. Returns false if the image changed since it was listed.
+func (a *Archiver) archive(ctx context.Context, asset models.ImageAsset) (bool, error) {
+	// A new key each time, so removing the object of an earlier archive of
+	// the same image can't remove this one
+	key := fmt.Sprintf("images/%s/%d", asset.ID, time.Now().UnixNano())
+	obj, err := a.blobs.Put(ctx, key, bytes.NewReader(asset.ImageBytes))
+	if err != nil {
+		return false, fmt.Errorf("archive image %s: %w", asset.ID, err)
+	}
+
+	ok, err := a.store.MarkArchived(ctx, asset.ID, key, a.blobs.Name(), obj.Size)
+	if err != nil || !ok {
+		_ = a.blobs.Delete(ctx, key)
+		return false, err
+	}
+	a.archived.Add(1)
+	return true, nil
+}
+
+// RestoreImage returns an archived image's bytes, moving them back into the
+// database so later reads are served from there.
+func (a *Archiver) RestoreImage(ctx context.Context, imageID string) ([]byte, error) {
+	image, err := a.store.GetArchivedImage(ctx, imageID)
+	if err != nil {
+		return nil, err
+	}
+	if image == nil {
+		return nil, nil
+	}
+	if image.Tier != models.ImageTierCold {
+		return image.ImageBytes, nil
+	}
+	if image.Backend != a.blobs.Name() {
+		return nil, fmt.Errorf("image %s is archived in %s storage, not %s", imageID, image.Backend, a.blobs.Name())
+	}
+
+	imageBytes, err := a.read(ctx, image.Key)
+	if errors.Is(err, blobstore.ErrNotFound) {
+		// Restored by another request, which queued the object for removal
+		// and the removal already ran
+		image, err = a.store.GetArchivedImage(ctx, imageID)
+		if err != nil {
+			return nil, err
+		}
+		if image == nil || image.Tier != models.ImageTierCold {
+			return imageBytesOf(image), nil
+		}
+		return nil, fmt.Errorf("archived image %s is missing from storage", imageID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("restore image %s: %w", imageID, err)
+	}
+	if image.ContentHash != "" && ContentHash(imageBytes) != image.ContentHash {
+		return nil, fmt.Errorf("restore image %s: archived bytes don't match", imageID)
+	}
+
+	restored, err := a.store.MarkRestored(ctx, imageID, imageBytes)
+	if err != nil {
+		return nil, err
+	}
+	if restored {
+		a.restored.Add(1)
+		a.logger.Debug("Restored archived image", logging.WithField("imageId", imageID))
+	}
+	return imageBytes, nil
+}
+
+func (a *Archiver) read(ctx context.Context, key string) ([]byte, error) {
+	r, err := a.blobs.Open(ctx, key, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func imageBytesOf(image *ArchivedImage) []byte {
+	if image == nil {
+		return nil
+	}
+	return image.ImageBytes
+}
+
+// removeOrphans deletes objects left behind by restored and deleted images.
+// Objects in another backend than the configured one are left for when it's
+// configured again.
+func (a *Archiver) removeOrphans(ctx context.Context) (int, error) {
+	orphans, err := a.store.ListArchiveOrphans(ctx, a.blobs.Name(), maxArchivePerRun)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, orphan := range orphans {
+		if err := a.blobs.Delete(ctx, orphan.Key); err != nil {
+			return removed, fmt.Errorf("remove archived image %s: %w", orphan.Key, err)
+		}
+		if err := a.store.DeleteArchiveOrphan(ctx, orphan.Key); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// Status reports tier sizes and what the archiver has done since start.
+func (a *Archiver) Status(ctx context.Context) (*models.ImageArchiveStatus, error) {
+	tiers, err := a.store.TierStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	lastRun := a.lastRun
+	a.mu.Unlock()
+
+	return &models.ImageArchiveStatus{
+		Enabled:  a.Enabled(),
+		Tiers:    tiers,
+		Archived: a.archived.Load(),
+		Restored: a.restored.Load(),
+		LastRun:  lastRun,
+	}, nil
+}
@@ -0,0 +1,193 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/blobstore"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+// archiveStore keeps image tiers in memory. Every hot image is a candidate.
+type archiveStore struct {
+	images  map[string]*ArchivedImage
+	orphans []ArchiveObject
+}
+
+func newArchiveStore(contents map[string]string) *archiveStore {
+	s := &archiveStore{images: make(map[string]*ArchivedImage)}
+	for id, content := range contents {
+		s.images[id] = &ArchivedImage{
+			Tier:        models.ImageTierHot,
+			ImageBytes:  []byte(content),
+			ContentHash: ContentHash([]byte(content)),
+		}
+	}
+	return s
+}
+
+func (s *archiveStore) ListArchiveCandidates(ctx context.Context, idleSince time.Time, limit int) ([]models.ImageAsset, error) {
+	var assets []models.ImageAsset
+	for id, image := range s.images {
+		if image.Tier == models.ImageTierHot && len(assets) < limit {
+			assets = append(assets, models.ImageAsset{ID: id, ImageBytes: image.ImageBytes})
+		}
+	}
+	return assets, nil
+}
+
+func (s *archiveStore) MarkArchived(ctx context.Context, imageID, key, backend string, size int64) (bool, error) {
+	image := s.images[imageID]
+	if image == nil || image.Tier != models.ImageTierHot {
+		return false, nil
+	}
+	image.Tier, image.ImageBytes, image.Key, image.Backend = models.ImageTierCold, nil, key, backend
+	return true, nil
+}
+
+func (s *archiveStore) GetArchivedImage(ctx context.Context, imageID string) (*ArchivedImage, error) {
+	image, ok := s.images[imageID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *image
+	return &copied, nil
+}
+
+func (s *archiveStore) MarkRestored(ctx context.Context, imageID string, imageBytes []byte) (bool, error) {
+	image := s.images[imageID]
+	if image == nil || image.Tier != models.ImageTierCold {
+		return false, nil
+	}
+	s.orphans = append(s.orphans, ArchiveObject{Key: image.Key, Backend: image.Backend})
+	image.Tier, image.ImageBytes, image.Key, image.Backend = models.ImageTierHot, imageBytes, "", ""
+	return true, nil
+}
+
+func (s *archiveStore) ListArchiveOrphans(ctx context.Context, backend string, limit int) ([]ArchiveObject, error) {
+	var orphans []ArchiveObject
+	for _, orphan := range s.orphans {
+		if orphan.Backend == backend {
+			orphans = append(orphans, orphan)
+		}
+	}
+	return orphans, nil
+}
+
+func (s *archiveStore) DeleteArchiveOrphan(ctx context.Context, key string) error {
+	for i, orphan := range s.orphans {
+		if orphan.Key == key {
+			s.orphans = append(s.orphans[:i], s.orphans[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (s *archiveStore) TierStats(ctx context.Context) ([]models.ImageTierStats, error) {
+	stats := []models.ImageTierStats{{Tier: models.ImageTierHot}, {Tier: models.ImageTierCold}}
+	for _, image := range s.images {
+		i := 0
+		if image.Tier == models.ImageTierCold {
+			i = 1
+		}
+		stats[i].Count++
+	}
+	return stats, nil
+}
+
+func TestArchiver_ArchiveAndRestore(t *testing.T) {
+	ctx := context.Background()
+	store := newArchiveStore(map[string]string{"a": "frame photo", "b": "motor photo"})
+	blobs := blobstore.NewLocal(t.TempDir())
+	archiver := NewArchiver(store, blobs, 24*time.Hour, testutil.NullLogger())
+
+	run, err := archiver.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if run.Archived != 2 || run.ArchivedBytes != int64(len("frame photo")+len("motor photo")) {
+		t.Errorf("Expected both images archived, got %+v", run)
+	}
+	if image := store.images["a"]; image.Tier != models.ImageTierCold || image.ImageBytes != nil {
+		t.Fatalf("Expected image a to be cold without bytes, got %+v", image)
+	}
+	key := store.images["a"].Key
+
+	restored, err := archiver.RestoreImage(ctx, "a")
+	if err != nil {
+		t.Fatalf("RestoreImage() error = %v", err)
+	}
+	if string(restored) != "frame photo" {
+		t.Errorf("Expected restored bytes, got %q", restored)
+	}
+	if image := store.images["a"]; image.Tier != models.ImageTierHot || string(image.ImageBytes) != "frame photo" {
+		t.Errorf("Expected image a back in the hot tier, got %+v", image)
+	}
+
+	// Hot images are returned as they are
+	if again, err := archiver.RestoreImage(ctx, "a"); err != nil || string(again) != "frame photo" {
+		t.Errorf("RestoreImage() of a hot image = %q, %v", again, err)
+	}
+
+	status, err := archiver.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if !status.Enabled || status.Archived != 2 || status.Restored != 1 || status.LastRun == nil {
+		t.Errorf("Unexpected status %+v", status)
+	}
+	if status.Tiers[0].Count != 1 || status.Tiers[1].Count != 1 {
+		t.Errorf("Expected one hot and one cold image, got %+v", status.Tiers)
+	}
+
+	// The next run removes the restored image's archived copy, even with
+	// archiving turned off
+	run, err = NewArchiver(store, blobs, 0, testutil.NullLogger()).Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if run.OrphansRemoved != 1 || run.Archived != 0 {
+		t.Errorf("Expected one orphan removed and nothing archived, got %+v", run)
+	}
+	if _, err := blobs.Open(ctx, key, 0); err == nil {
+		t.Error("Expected the restored image's object to be removed")
+	}
+}
+
+func TestArchiver_RestoreRejectsChangedBytes(t *testing.T) {
+	ctx := context.Background()
+	store := newArchiveStore(map[string]string{"a": "frame photo"})
+	blobs := blobstore.NewLocal(t.TempDir())
+	archiver := NewArchiver(store, blobs, 24*time.Hour, testutil.NullLogger())
+
+	if _, err := archiver.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if _, err := blobs.Put(ctx, store.images["a"].Key, bytes.NewReader([]byte("tampered"))); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	if _, err := archiver.RestoreImage(ctx, "a"); err == nil {
+		t.Error("Expected an error for archived bytes that don't match the image")
+	}
+	if store.images["a"].Tier != models.ImageTierCold {
+		t.Error("Expected the image to stay archived")
+	}
+}
+
+func TestArchiver_Disabled(t *testing.T) {
+	store := newArchiveStore(map[string]string{"a": "frame photo"})
+	archiver := NewArchiver(store, blobstore.NewLocal(t.TempDir()), 0, testutil.NullLogger())
+
+	run, err := archiver.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if run.Archived != 0 || store.images["a"].Tier != models.ImageTierHot {
+		t.Error("Expected nothing archived with archiving disabled")
+	}
+}
@@ -0,0 +1,230 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+var (
+	// ErrBackgroundRemovalUnavailable is returned when a background is
+	// requested but no remover is configured.
+	ErrBackgroundRemovalUnavailable = errors.New("background removal is not configured")
+	// ErrBackgroundRemovalFailed is returned when the remover errors or returns
+	// something that isn't an image.
+	ErrBackgroundRemovalFailed = errors.New("background removal failed")
+	// ErrEmptyProductShot is returned when nothing is left after the
+	// background is removed.
+	ErrEmptyProductShot = errors.New("no product found after removing the background")
+)
+
+const (
+	// productShotPadding is the margin around the cropped product, as a
+	// fraction of its longest side.
+	productShotPadding = 0.08
+	// productAlphaThreshold is the alpha above which a pixel counts as part
+	// of the product when cropping, so faint halos are trimmed.
+	productAlphaThreshold = 16
+)
+
+// BackgroundRemover cuts the product out of a photo and returns it as a PNG
+// with a transparent background.
+type BackgroundRemover interface {
+	RemoveBackground(ctx context.Context, data []byte) ([]byte, error)
+}
+
+// SetBackgroundRemover enables replacing the background of uploads that ask
+// for it.
+func (s *Service) SetBackgroundRemover(remover BackgroundRemover) {
+	s.backgroundRemover = remover
+}
+
+// BackgroundRemovalEnabled reports whether uploads can ask for a background.
+func (s *Service) BackgroundRemovalEnabled() bool {
+	return s != nil && s.backgroundRemover != nil
+}
+
+// replaceBackground removes the background, crops to the product and places
+// it on a padded square canvas with the requested background.
+func (s *Service) replaceBackground(ctx context.Context, data []byte, background models.ImageBackground) ([]byte, error) {
+	if !models.IsValidImageBackground(background) {
+		return nil, fmt.Errorf("unknown background %q", background)
+	}
+	if s.backgroundRemover == nil {
+		return nil, ErrBackgroundRemovalUnavailable
+	}
+
+	cutout, err := s.backgroundRemover.RemoveBackground(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBackgroundRemovalFailed, err)
+	}
+	shot, err := FinishProductShot(cutout, background)
+	if err != nil {
+		return nil, err
+	}
+	return s.normalizeBytes(shot)
+}
+
+// FinishProductShot crops a cutout to its visible pixels and centers it on a
+// square canvas with even padding. A white background is filled in; a
+// transparent one is kept. The result is a PNG.
+func FinishProductShot(cutout []byte, background models.ImageBackground) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(cutout))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > defaultMaxPixels {
+		return nil, ErrBackgroundRemovalFailed
+	}
+	src, _, err := image.Decode(bytes.NewReader(cutout))
+	if err != nil {
+		return nil, ErrBackgroundRemovalFailed
+	}
+	img := toNRGBA(src)
+
+	bounds, ok := visibleBounds(img)
+	if !ok {
+		return nil, ErrEmptyProductShot
+	}
+
+	side := bounds.Dx()
+	if bounds.Dy() > side {
+		side = bounds.Dy()
+	}
+	pad := int(float64(side) * productShotPadding)
+	size := side + 2*pad
+
+	canvas := image.NewNRGBA(image.Rect(0, 0, size, size))
+	if background == models.ImageBackgroundWhite {
+		draw.Draw(canvas, canvas.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	}
+	offset := image.Pt((size-bounds.Dx())/2, (size-bounds.Dy())/2)
+	draw.Draw(canvas, bounds.Sub(bounds.Min).Add(offset), img, bounds.Min, draw.Over)
+
+	var out bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := encoder.Encode(&out, canvas); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// visibleBounds returns the smallest rectangle holding every pixel above
+// productAlphaThreshold
+func visibleBounds(img *image.NRGBA) (image.Rectangle, bool) {
+	minX, minY := img.Rect.Dx(), img.Rect.Dy()
+	maxX, maxY := -1, -1
+	for y := 0; y < img.Rect.Dy(); y++ {
+		row := img.Pix[y*img.Stride:]
+		for x := 0; x < img.Rect.Dx(); x++ {
+			if row[x*4+3] <= productAlphaThreshold {
+				continue
+			}
+			if x < minX {
+				minX = x
+			}
+			if x > maxX {
+				maxX = x
+			}
+			if y < minY {
+				minY = y
+			}
+			if y > maxY {
+				maxY = y
+			}
+		}
+	}
+	if maxX < 0 {
+		return image.Rectangle{}, false
+	}
+	return image.Rect(minX, minY, maxX+1, maxY+1), true
+}
+
+// CommandBackgroundRemover runs a local tool such as rembg. The command's
+// arguments reference {in} and {out} like an image transcoder's.
+type CommandBackgroundRemover struct {
+	command *CommandTranscoder
+}
+
+// NewCommandBackgroundRemover parses a command line like "rembg i {in} {out}".
+// Removal is slow and memory hungry, so one runs at a time.
+func NewCommandBackgroundRemover(commandLine string, timeout time.Duration) (*CommandBackgroundRemover, error) {
+	command, err := NewCommandTranscoder("image/png", commandLine, timeout, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &CommandBackgroundRemover{command: command}, nil
+}
+
+// RemoveBackground runs the command on data and returns its output file.
+func (r *CommandBackgroundRemover) RemoveBackground(ctx context.Context, data []byte) ([]byte, error) {
+	return r.command.Transcode(ctx, data)
+}
+
+// HTTPBackgroundRemover calls an external API that takes the photo as the
+// multipart field image_file and answers with the PNG cutout, as remove.bg
+// does. The key is sent in the X-Api-Key header.
+type HTTPBackgroundRemover struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+// NewHTTPBackgroundRemover creates a remover for the API at url.
+func NewHTTPBackgroundRemover(url, apiKey string, timeout time.Duration) *HTTPBackgroundRemover {
+	if timeout <= 0 {
+		timeout = 20 * time.Second
+	}
+	return &HTTPBackgroundRemover{url: url, apiKey: apiKey, client: &http.Client{Timeout: timeout}}
+}
+
+// RemoveBackground uploads data and returns the API's response body.
+func (r *HTTPBackgroundRemover) RemoveBackground(ctx context.Context, data []byte) ([]byte, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("image_file", "image")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(data); err != nil {
+		return nil, err
+	}
+	_ = form.WriteField("size", "auto")
+	_ = form.WriteField("format", "png")
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	if r.apiKey != "" {
+		req.Header.Set("X-Api-Key", r.apiKey)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("call background removal API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Cutouts are PNGs of at most defaultMaxPixels, so bound what is read
+	out, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024*1024))
+	if err != nil {
+		return nil, fmt.Errorf("read background removal response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("background removal API returned %d", resp.StatusCode)
+	}
+	return out, nil
+}
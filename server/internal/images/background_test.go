@@ -0,0 +1,117 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// fakeRemover returns a fixed cutout
+type fakeRemover struct {
+	cutout []byte
+	err    error
+}
+
+func (f *fakeRemover) RemoveBackground(ctx context.Context, data []byte) ([]byte, error) {
+	return f.cutout, f.err
+}
+
+// encodeTestCutout draws an opaque red square on a transparent canvas
+func encodeTestCutout(t *testing.T, w, h int, square image.Rectangle) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := square.Min.Y; y < square.Max.Y; y++ {
+		for x := square.Min.X; x < square.Max.X; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: 0xff, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func decodeTestPNG(t *testing.T, data []byte) image.Image {
+	t.Helper()
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode png: %v", err)
+	}
+	return img
+}
+
+func TestFinishProductShot(t *testing.T) {
+	cutout := encodeTestCutout(t, 200, 100, image.Rect(40, 20, 90, 70))
+
+	out, err := FinishProductShot(cutout, models.ImageBackgroundWhite)
+	if err != nil {
+		t.Fatalf("FinishProductShot() error = %v", err)
+	}
+	img := decodeTestPNG(t, out)
+	// 50px product plus 4px of padding on each side
+	if b := img.Bounds(); b.Dx() != 58 || b.Dy() != 58 {
+		t.Fatalf("size = %dx%d, want 58x58", b.Dx(), b.Dy())
+	}
+	if r, g, b, a := img.At(0, 0).RGBA(); r != 0xffff || g != 0xffff || b != 0xffff || a != 0xffff {
+		t.Errorf("corner = %v, want white", img.At(0, 0))
+	}
+	if r, g, _, _ := img.At(29, 29).RGBA(); r != 0xffff || g != 0 {
+		t.Errorf("center = %v, want the red product", img.At(29, 29))
+	}
+
+	out, err = FinishProductShot(cutout, models.ImageBackgroundTransparent)
+	if err != nil {
+		t.Fatalf("FinishProductShot() error = %v", err)
+	}
+	if _, _, _, a := decodeTestPNG(t, out).At(0, 0).RGBA(); a != 0 {
+		t.Errorf("corner alpha = %d, want transparent", a)
+	}
+
+	empty := encodeTestCutout(t, 20, 20, image.Rectangle{})
+	if _, err := FinishProductShot(empty, models.ImageBackgroundWhite); !errors.Is(err, ErrEmptyProductShot) {
+		t.Errorf("FinishProductShot(empty) error = %v, want ErrEmptyProductShot", err)
+	}
+}
+
+func TestServiceModerateAndPersistBackground(t *testing.T) {
+	storage := &fakeStorage{}
+	svc := NewService(
+		&fakeModerator{decision: &models.ModerationDecision{Status: models.ImageModerationApproved}},
+		storage,
+		nil,
+		5*time.Second,
+	)
+	req := SaveRequest{
+		OwnerUserID: "admin-1",
+		EntityType:  models.ImageEntityGear,
+		EntityID:    "gear-1",
+		ImageBytes:  encodeTestJPEG(t, 40, 40),
+		Background:  models.ImageBackgroundWhite,
+	}
+
+	if _, _, err := svc.ModerateAndPersist(context.Background(), req); !errors.Is(err, ErrBackgroundRemovalUnavailable) {
+		t.Fatalf("ModerateAndPersist() without remover error = %v, want ErrBackgroundRemovalUnavailable", err)
+	}
+
+	svc.SetBackgroundRemover(&fakeRemover{err: errors.New("api down")})
+	if _, _, err := svc.ModerateAndPersist(context.Background(), req); !errors.Is(err, ErrBackgroundRemovalFailed) {
+		t.Fatalf("ModerateAndPersist() with failing remover error = %v, want ErrBackgroundRemovalFailed", err)
+	}
+
+	svc.SetBackgroundRemover(&fakeRemover{cutout: encodeTestCutout(t, 40, 40, image.Rect(10, 10, 30, 30))})
+	_, asset, err := svc.ModerateAndPersist(context.Background(), req)
+	if err != nil {
+		t.Fatalf("ModerateAndPersist() error = %v", err)
+	}
+	if img := decodeTestPNG(t, asset.ImageBytes); img.Bounds().Dx() != img.Bounds().Dy() {
+		t.Errorf("stored image is %v, want a square product shot", img.Bounds())
+	}
+}
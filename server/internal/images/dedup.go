@@ -0,0 +1,24 @@
+package images
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ContentHash identifies stored image bytes. Identical uploads hash the same,
+// so storage keeps one copy and counts the references to it.
+func ContentHash(imageBytes []byte) string {
+	sum := sha256.Sum256(imageBytes)
+	return hex.EncodeToString(sum[:])
+}
+
+// SharedAcrossOwners reports whether an identical image uploaded by another
+// user may be reused. Catalog images are shared content, so one copy of a
+// manufacturer photo serves every item it is uploaded to. Other images are
+// only reused within one owner's uploads, which keeps quotas, account
+// deletion and account merges per user.
+func SharedAcrossOwners(entityType models.ImageEntityType) bool {
+	return entityType == models.ImageEntityGear
+}
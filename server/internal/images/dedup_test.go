@@ -0,0 +1,31 @@
+package images
+
+import (
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+func TestContentHash(t *testing.T) {
+	a := ContentHash([]byte("frame photo"))
+	if a != ContentHash([]byte("frame photo")) {
+		t.Error("Expected identical bytes to hash the same")
+	}
+	if a == ContentHash([]byte("frame photo 2")) {
+		t.Error("Expected different bytes to hash differently")
+	}
+	if len(a) != 64 {
+		t.Errorf("Expected a hex SHA-256, got %q", a)
+	}
+}
+
+func TestSharedAcrossOwners(t *testing.T) {
+	if !SharedAcrossOwners(models.ImageEntityGear) {
+		t.Error("Expected catalog images to be shared across owners")
+	}
+	for _, entityType := range []models.ImageEntityType{models.ImageEntityAvatar, models.ImageEntityBuild, models.ImageEntityAircraft} {
+		if SharedAcrossOwners(entityType) {
+			t.Errorf("Expected %s images to stay per owner", entityType)
+		}
+	}
+}
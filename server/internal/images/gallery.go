@@ -0,0 +1,227 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+var (
+	// ErrImageIndexOutOfRange is returned for an image index the entity doesn't have.
+	ErrImageIndexOutOfRange = errors.New("image index out of range")
+	// ErrTooManyImages is returned when adding an image past models.MaxEntityImages.
+	ErrTooManyImages = fmt.Errorf("an item can have at most %d images", models.MaxEntityImages)
+	// ErrGalleryUnavailable is returned for indexed image operations without a gallery store.
+	ErrGalleryUnavailable = errors.New("multiple images unavailable")
+	// ErrDuplicateImage is returned when adding an image the entity already has
+	// at another index; identical uploads are stored as the same asset.
+	ErrDuplicateImage = errors.New("the item already has this image")
+)
+
+// GalleryStore keeps the ordered images attached to builds and catalog items.
+// The entity's own image_asset_id column remains the primary image and is
+// mirrored into the gallery by the database, so primary changes go through
+// PrimarySlot rather than the store.
+type GalleryStore interface {
+	List(ctx context.Context, entityType models.ImageEntityType, entityID string) ([]string, error)
+	Append(ctx context.Context, entityType models.ImageEntityType, entityID, assetID string) error
+	Replace(ctx context.Context, entityType models.ImageEntityType, entityID, oldAssetID, newAssetID string) error
+	Remove(ctx context.Context, entityType models.ImageEntityType, entityID, assetID string) error
+}
+
+// PrimarySlot reads and writes an entity's primary image. Set and Clear
+// return the previous primary asset ID, like the entity stores' SetImage and
+// DeleteImage.
+type PrimarySlot struct {
+	AssetID string
+	Set     func(ctx context.Context, assetID string) (string, error)
+	Clear   func(ctx context.Context) (string, error)
+}
+
+// SetGallery enables multiple images per build and catalog item.
+func (s *Service) SetGallery(store GalleryStore) {
+	s.gallery = store
+}
+
+// Images lists an entity's images in order. Each URL is baseURL with the
+// image's index; the primary image is also still served by the plain baseURL.
+// Without a gallery store only the primary image is listed.
+func (s *Service) Images(ctx context.Context, entityType models.ImageEntityType, entityID, primaryAssetID, baseURL string) ([]models.EntityImage, error) {
+	if s.gallery == nil {
+		if primaryAssetID == "" {
+			return nil, nil
+		}
+		return []models.EntityImage{{
+			URL:     fmt.Sprintf("%s?v=%s", baseURL, assetVersion(primaryAssetID)),
+			Primary: true,
+		}}, nil
+	}
+
+	assetIDs, err := s.gallery.List(ctx, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.EntityImage, 0, len(assetIDs))
+	for i, assetID := range assetIDs {
+		result = append(result, models.EntityImage{
+			Index:   i,
+			URL:     fmt.Sprintf("%s?index=%d&v=%s", baseURL, i, assetVersion(assetID)),
+			Primary: assetID == primaryAssetID,
+		})
+	}
+	return result, nil
+}
+
+// LoadAt returns the approved image bytes at index, or nil if the image is
+// not approved. Callers must check access to the entity first.
+func (s *Service) LoadAt(ctx context.Context, entityType models.ImageEntityType, entityID string, index int) ([]byte, error) {
+	if s.gallery == nil {
+		return nil, ErrGalleryUnavailable
+	}
+	assetIDs, err := s.gallery.List(ctx, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(assetIDs) {
+		return nil, ErrImageIndexOutOfRange
+	}
+
+	asset, err := s.storage.Load(ctx, assetIDs[index])
+	if err != nil {
+		return nil, err
+	}
+	if asset == nil || asset.Status != models.ImageModerationApproved {
+		return nil, nil
+	}
+	return asset.ImageBytes, nil
+}
+
+// AttachAt stores an already persisted asset at index. An index equal to the
+// image count appends; any other index replaces that image and deletes it.
+// The new asset is deleted if it can't be attached.
+func (s *Service) AttachAt(ctx context.Context, entityType models.ImageEntityType, entityID string, slot PrimarySlot, index int, assetID string) error {
+	if err := s.attachAt(ctx, entityType, entityID, slot, index, assetID); err != nil {
+		_ = s.storage.Delete(ctx, assetID)
+		return err
+	}
+	return nil
+}
+
+func (s *Service) attachAt(ctx context.Context, entityType models.ImageEntityType, entityID string, slot PrimarySlot, index int, assetID string) error {
+	if s.gallery == nil {
+		return ErrGalleryUnavailable
+	}
+	assetIDs, err := s.gallery.List(ctx, entityType, entityID)
+	if err != nil {
+		return err
+	}
+
+	for i, id := range assetIDs {
+		if id == assetID && i != index {
+			return ErrDuplicateImage
+		}
+	}
+
+	switch {
+	case index < 0 || index > len(assetIDs):
+		return ErrImageIndexOutOfRange
+	case index == len(assetIDs):
+		if len(assetIDs) >= models.MaxEntityImages {
+			return ErrTooManyImages
+		}
+		if slot.AssetID == "" {
+			_, err := slot.Set(ctx, assetID)
+			return err
+		}
+		return s.gallery.Append(ctx, entityType, entityID, assetID)
+	}
+
+	previousAssetID := assetIDs[index]
+	if previousAssetID == slot.AssetID {
+		if _, err := slot.Set(ctx, assetID); err != nil {
+			return err
+		}
+	} else if err := s.gallery.Replace(ctx, entityType, entityID, previousAssetID, assetID); err != nil {
+		return err
+	}
+	_ = s.storage.Delete(ctx, previousAssetID)
+	return nil
+}
+
+// RemoveAt removes and deletes the image at index. Removing the primary image
+// promotes the first remaining image.
+func (s *Service) RemoveAt(ctx context.Context, entityType models.ImageEntityType, entityID string, slot PrimarySlot, index int) error {
+	if s.gallery == nil {
+		return ErrGalleryUnavailable
+	}
+	assetIDs, err := s.gallery.List(ctx, entityType, entityID)
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(assetIDs) {
+		return ErrImageIndexOutOfRange
+	}
+
+	assetID := assetIDs[index]
+	if assetID == slot.AssetID {
+		if _, err := slot.Clear(ctx); err != nil {
+			return err
+		}
+		if err := s.promoteFirst(ctx, entityType, entityID, slot); err != nil {
+			return err
+		}
+	} else if err := s.gallery.Remove(ctx, entityType, entityID, assetID); err != nil {
+		return err
+	}
+	_ = s.storage.Delete(ctx, assetID)
+	return nil
+}
+
+// PromoteFirst makes the first remaining image primary after the primary
+// image was removed outside the gallery, e.g. by a legacy delete endpoint.
+func (s *Service) PromoteFirst(ctx context.Context, entityType models.ImageEntityType, entityID string, slot PrimarySlot) error {
+	if s.gallery == nil {
+		return nil
+	}
+	return s.promoteFirst(ctx, entityType, entityID, slot)
+}
+
+func (s *Service) promoteFirst(ctx context.Context, entityType models.ImageEntityType, entityID string, slot PrimarySlot) error {
+	remaining, err := s.gallery.List(ctx, entityType, entityID)
+	if err != nil || len(remaining) == 0 {
+		return err
+	}
+	_, err = slot.Set(ctx, remaining[0])
+	return err
+}
+
+// SetPrimaryAt makes the image at index the entity's primary image. The
+// previous primary image stays in the gallery.
+func (s *Service) SetPrimaryAt(ctx context.Context, entityType models.ImageEntityType, entityID string, slot PrimarySlot, index int) error {
+	if s.gallery == nil {
+		return ErrGalleryUnavailable
+	}
+	assetIDs, err := s.gallery.List(ctx, entityType, entityID)
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(assetIDs) {
+		return ErrImageIndexOutOfRange
+	}
+	if assetIDs[index] == slot.AssetID {
+		return nil
+	}
+	_, err = slot.Set(ctx, assetIDs[index])
+	return err
+}
+
+// assetVersion shortens an asset ID into a cache-busting URL parameter.
+func assetVersion(assetID string) string {
+	if len(assetID) > 8 {
+		return assetID[:8]
+	}
+	return assetID
+}
@@ -0,0 +1,248 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// fakeGallery keeps one entity's images and a primary column, mirroring the
+// primary into the gallery like the database trigger does
+type fakeGallery struct {
+	ids     []string
+	primary string
+}
+
+func (f *fakeGallery) List(ctx context.Context, entityType models.ImageEntityType, entityID string) ([]string, error) {
+	return append([]string(nil), f.ids...), nil
+}
+
+func (f *fakeGallery) Append(ctx context.Context, entityType models.ImageEntityType, entityID, assetID string) error {
+	f.ids = append(f.ids, assetID)
+	return nil
+}
+
+func (f *fakeGallery) Replace(ctx context.Context, entityType models.ImageEntityType, entityID, oldAssetID, newAssetID string) error {
+	for i, id := range f.ids {
+		if id == oldAssetID {
+			f.ids[i] = newAssetID
+			return nil
+		}
+	}
+	return errors.New("entity image not found")
+}
+
+func (f *fakeGallery) Remove(ctx context.Context, entityType models.ImageEntityType, entityID, assetID string) error {
+	for i, id := range f.ids {
+		if id == assetID {
+			f.ids = append(f.ids[:i], f.ids[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (f *fakeGallery) index(assetID string) int {
+	for i, id := range f.ids {
+		if id == assetID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (f *fakeGallery) slot() PrimarySlot {
+	return PrimarySlot{
+		AssetID: f.primary,
+		Set: func(ctx context.Context, assetID string) (string, error) {
+			previous := f.primary
+			f.primary = assetID
+			switch {
+			case f.index(assetID) >= 0:
+			case previous != "":
+				f.ids[f.index(previous)] = assetID
+			default:
+				f.ids = append([]string{assetID}, f.ids...)
+			}
+			return previous, nil
+		},
+		Clear: func(ctx context.Context) (string, error) {
+			previous := f.primary
+			f.primary = ""
+			_ = f.Remove(ctx, "", "", previous)
+			return previous, nil
+		},
+	}
+}
+
+type deleteRecordingStorage struct {
+	fakeStorage
+	deleted []string
+}
+
+func (d *deleteRecordingStorage) Delete(ctx context.Context, imageID string) error {
+	d.deleted = append(d.deleted, imageID)
+	return nil
+}
+
+func newGalleryService(gallery *fakeGallery) (*Service, *deleteRecordingStorage) {
+	storage := &deleteRecordingStorage{}
+	svc := NewService(&fakeModerator{}, storage, nil, 0)
+	svc.SetGallery(gallery)
+	return svc, storage
+}
+
+func TestAttachAt(t *testing.T) {
+	tests := []struct {
+		name        string
+		ids         []string
+		primary     string
+		index       int
+		wantIDs     []string
+		wantPrimary string
+		wantDeleted []string
+		wantErr     error
+	}{
+		{
+			name:        "first image becomes primary",
+			index:       0,
+			wantIDs:     []string{"new"},
+			wantPrimary: "new",
+		},
+		{
+			name:        "appends after existing images",
+			ids:         []string{"a", "b"},
+			primary:     "a",
+			index:       2,
+			wantIDs:     []string{"a", "b", "new"},
+			wantPrimary: "a",
+		},
+		{
+			name:        "replacing the primary keeps its position",
+			ids:         []string{"a", "b"},
+			primary:     "b",
+			index:       1,
+			wantIDs:     []string{"a", "new"},
+			wantPrimary: "new",
+			wantDeleted: []string{"b"},
+		},
+		{
+			name:        "replaces a secondary image",
+			ids:         []string{"a", "b"},
+			primary:     "a",
+			index:       1,
+			wantIDs:     []string{"a", "new"},
+			wantPrimary: "a",
+			wantDeleted: []string{"b"},
+		},
+		{
+			name:        "index past the end",
+			ids:         []string{"a"},
+			primary:     "a",
+			index:       2,
+			wantIDs:     []string{"a"},
+			wantPrimary: "a",
+			wantDeleted: []string{"new"},
+			wantErr:     ErrImageIndexOutOfRange,
+		},
+		{
+			name:        "too many images",
+			ids:         []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"},
+			primary:     "0",
+			index:       10,
+			wantIDs:     []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"},
+			wantPrimary: "0",
+			wantDeleted: []string{"new"},
+			wantErr:     ErrTooManyImages,
+		},
+		{
+			name:        "adding an image the item already has",
+			ids:         []string{"a", "new"},
+			primary:     "a",
+			index:       2,
+			wantIDs:     []string{"a", "new"},
+			wantPrimary: "a",
+			wantDeleted: []string{"new"},
+			wantErr:     ErrDuplicateImage,
+		},
+		{
+			name:        "replacing an image with the same image",
+			ids:         []string{"a", "new"},
+			primary:     "a",
+			index:       1,
+			wantIDs:     []string{"a", "new"},
+			wantPrimary: "a",
+			wantDeleted: []string{"new"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gallery := &fakeGallery{ids: tt.ids, primary: tt.primary}
+			svc, storage := newGalleryService(gallery)
+
+			err := svc.AttachAt(context.Background(), models.ImageEntityBuild, "build-1", gallery.slot(), tt.index, "new")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AttachAt() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(gallery.ids, tt.wantIDs) {
+				t.Errorf("images = %v, want %v", gallery.ids, tt.wantIDs)
+			}
+			if gallery.primary != tt.wantPrimary {
+				t.Errorf("primary = %q, want %q", gallery.primary, tt.wantPrimary)
+			}
+			if !reflect.DeepEqual(storage.deleted, tt.wantDeleted) {
+				t.Errorf("deleted = %v, want %v", storage.deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestRemoveAt_PromotesNextImage(t *testing.T) {
+	gallery := &fakeGallery{ids: []string{"a", "b", "c"}, primary: "a"}
+	svc, storage := newGalleryService(gallery)
+	ctx := context.Background()
+
+	if err := svc.RemoveAt(ctx, models.ImageEntityGear, "gear-1", gallery.slot(), 0); err != nil {
+		t.Fatalf("RemoveAt() error = %v", err)
+	}
+	if gallery.primary != "b" || !reflect.DeepEqual(gallery.ids, []string{"b", "c"}) {
+		t.Errorf("primary = %q, images = %v, want b of [b c]", gallery.primary, gallery.ids)
+	}
+
+	if err := svc.RemoveAt(ctx, models.ImageEntityGear, "gear-1", gallery.slot(), 1); err != nil {
+		t.Fatalf("RemoveAt() error = %v", err)
+	}
+	if gallery.primary != "b" || !reflect.DeepEqual(gallery.ids, []string{"b"}) {
+		t.Errorf("primary = %q, images = %v, want b of [b]", gallery.primary, gallery.ids)
+	}
+	if !reflect.DeepEqual(storage.deleted, []string{"a", "c"}) {
+		t.Errorf("deleted = %v, want [a c]", storage.deleted)
+	}
+}
+
+func TestSetPrimaryAt(t *testing.T) {
+	gallery := &fakeGallery{ids: []string{"a", "b"}, primary: "a"}
+	svc, storage := newGalleryService(gallery)
+
+	if err := svc.SetPrimaryAt(context.Background(), models.ImageEntityBuild, "build-1", gallery.slot(), 1); err != nil {
+		t.Fatalf("SetPrimaryAt() error = %v", err)
+	}
+	if gallery.primary != "b" || !reflect.DeepEqual(gallery.ids, []string{"a", "b"}) {
+		t.Errorf("primary = %q, images = %v, want b with order unchanged", gallery.primary, gallery.ids)
+	}
+	if len(storage.deleted) != 0 {
+		t.Errorf("deleted = %v, want none", storage.deleted)
+	}
+
+	images, err := svc.Images(context.Background(), models.ImageEntityBuild, "build-1", gallery.primary, "/api/builds/build-1/image")
+	if err != nil {
+		t.Fatalf("Images() error = %v", err)
+	}
+	if len(images) != 2 || images[0].Primary || !images[1].Primary || images[1].URL != "/api/builds/build-1/image?index=1&v=b" {
+		t.Errorf("images = %+v, want second image primary", images)
+	}
+}
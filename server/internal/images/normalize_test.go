@@ -0,0 +1,211 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+func encodeTestJPEG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 0x80, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("encode jpeg: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// withExifOrientation inserts an APP1 EXIF segment carrying an orientation tag
+// and a fake GPS IFD pointer right after the JPEG SOI marker.
+func withExifOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte{
+		'M', 'M', 0x00, 0x2a, 0x00, 0x00, 0x00, 0x08, // header, IFD0 at offset 8
+		0x00, 0x02, // two entries
+		0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, byte(orientation >> 8), byte(orientation), 0x00, 0x00, // Orientation
+		0x88, 0x25, 0x00, 0x04, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, // GPSInfo pointer
+		0x00, 0x00, 0x00, 0x00, // no next IFD
+	}
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	length := len(payload) + 2
+
+	out := []byte{0xff, 0xd8, 0xff, 0xe1, byte(length >> 8), byte(length)}
+	out = append(out, payload...)
+	return append(out, data[2:]...)
+}
+
+func TestNormalizeStripsExifAndAppliesOrientation(t *testing.T) {
+	src := withExifOrientation(encodeTestJPEG(t, 40, 20), 6)
+	if got := jpegOrientation(src); got != 6 {
+		t.Fatalf("jpegOrientation() = %d, want 6", got)
+	}
+
+	out, err := Normalize(src, NormalizeOptions{})
+	if err != nil {
+		t.Fatalf("Normalize() error = %v", err)
+	}
+	if bytes.Contains(out, []byte("Exif")) {
+		t.Error("normalized image still contains EXIF data")
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("decode normalized image: %v", err)
+	}
+	if format != "jpeg" {
+		t.Errorf("format = %s, want jpeg", format)
+	}
+	if cfg.Width != 20 || cfg.Height != 40 {
+		t.Errorf("dimensions = %dx%d, want 20x40 after rotation", cfg.Width, cfg.Height)
+	}
+}
+
+func TestNormalizeBoundsDimensions(t *testing.T) {
+	out, err := Normalize(encodeTestJPEG(t, 300, 150), NormalizeOptions{MaxDimension: 100})
+	if err != nil {
+		t.Fatalf("Normalize() error = %v", err)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("decode normalized image: %v", err)
+	}
+	if cfg.Width != 100 || cfg.Height != 50 {
+		t.Errorf("dimensions = %dx%d, want 100x50", cfg.Width, cfg.Height)
+	}
+}
+
+func TestNormalizeOutputFormat(t *testing.T) {
+	encodePNG := func(alpha uint8) []byte {
+		img := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+		for i := range img.Pix {
+			img.Pix[i] = 0x40
+		}
+		for i := 3; i < len(img.Pix); i += 4 {
+			img.Pix[i] = alpha
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			t.Fatalf("encode png: %v", err)
+		}
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name       string
+		input      []byte
+		wantFormat string
+	}{
+		{name: "opaque png becomes jpeg", input: encodePNG(0xff), wantFormat: "jpeg"},
+		{name: "transparent png stays png", input: encodePNG(0x80), wantFormat: "png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Normalize(tt.input, NormalizeOptions{})
+			if err != nil {
+				t.Fatalf("Normalize() error = %v", err)
+			}
+			_, format, err := image.DecodeConfig(bytes.NewReader(out))
+			if err != nil {
+				t.Fatalf("decode normalized image: %v", err)
+			}
+			if format != tt.wantFormat {
+				t.Errorf("format = %s, want %s", format, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestNormalizeRejectsInvalidImages(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		opts  NormalizeOptions
+	}{
+		{name: "garbage", input: []byte("not an image")},
+		{name: "too many pixels", input: encodeTestJPEG(t, 100, 100), opts: NormalizeOptions{MaxPixels: 5000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Normalize(tt.input, tt.opts); !errors.Is(err, ErrInvalidImage) {
+				t.Errorf("Normalize() error = %v, want ErrInvalidImage", err)
+			}
+		})
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// 2x1 image: red then blue
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	copy(src.Pix, []uint8{0xff, 0, 0, 0xff, 0, 0, 0xff, 0xff})
+
+	tests := []struct {
+		orientation int
+		w, h        int
+		topLeftRed  bool
+	}{
+		{orientation: 1, w: 2, h: 1, topLeftRed: true},
+		{orientation: 2, w: 2, h: 1, topLeftRed: false},
+		{orientation: 6, w: 1, h: 2, topLeftRed: true},
+		{orientation: 8, w: 1, h: 2, topLeftRed: false},
+	}
+
+	for _, tt := range tests {
+		got := applyOrientation(src, tt.orientation)
+		if got.Bounds().Dx() != tt.w || got.Bounds().Dy() != tt.h {
+			t.Errorf("orientation %d: size = %dx%d, want %dx%d", tt.orientation, got.Bounds().Dx(), got.Bounds().Dy(), tt.w, tt.h)
+			continue
+		}
+		if isRed := got.Pix[0] == 0xff; isRed != tt.topLeftRed {
+			t.Errorf("orientation %d: top-left red = %v, want %v", tt.orientation, isRed, tt.topLeftRed)
+		}
+	}
+}
+
+func TestServiceModerateAndPersistNormalizes(t *testing.T) {
+	storage := &fakeStorage{}
+	svc := NewService(
+		&fakeModerator{decision: &models.ModerationDecision{Status: models.ImageModerationApproved}},
+		storage,
+		NewInMemoryPendingStore(5*time.Minute),
+		5*time.Second,
+	)
+	svc.SetNormalization(NormalizeOptions{MaxDimension: 64})
+
+	src := withExifOrientation(encodeTestJPEG(t, 128, 128), 1)
+	if _, _, err := svc.ModerateAndPersist(context.Background(), SaveRequest{
+		OwnerUserID: "user-1",
+		EntityType:  models.ImageEntityAircraft,
+		ImageBytes:  src,
+	}); err != nil {
+		t.Fatalf("ModerateAndPersist() error = %v", err)
+	}
+	if len(storage.saved) != 1 {
+		t.Fatalf("expected one save, got %d", len(storage.saved))
+	}
+	if bytes.Contains(storage.saved[0].ImageBytes, []byte("Exif")) {
+		t.Error("persisted image still contains EXIF data")
+	}
+
+	if _, _, err := svc.ModerateAndPersist(context.Background(), SaveRequest{
+		OwnerUserID: "user-1",
+		EntityType:  models.ImageEntityAircraft,
+		ImageBytes:  []byte("abc"),
+	}); !errors.Is(err, ErrInvalidImage) {
+		t.Errorf("ModerateAndPersist() error = %v, want ErrInvalidImage", err)
+	}
+}
@@ -0,0 +1,117 @@
+package images
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// QuotaStore reports stored image usage and per-user quota overrides.
+type QuotaStore interface {
+	Usage(ctx context.Context, ownerUserID string, excludeType models.ImageEntityType, excludeEntityID string) (int, int64, error)
+	GetQuotaOverride(ctx context.Context, userID string) (*models.ImageQuotaOverride, error)
+	SetQuotaOverride(ctx context.Context, userID, adminUserID string, params models.SetImageQuotaOverrideParams) (*models.ImageQuotaOverride, error)
+	DeleteQuotaOverride(ctx context.Context, userID string) error
+}
+
+// QuotaExceededError is returned when saving an image would exceed the owner's quota.
+type QuotaExceededError struct {
+	Usage       models.ImageUsage
+	UploadBytes int64
+	countLimit  bool
+}
+
+func (e *QuotaExceededError) Error() string {
+	if e.countLimit {
+		return fmt.Sprintf("image quota exceeded: you have %d of %d images; delete some images to upload more",
+			e.Usage.Count, e.Usage.Quota.MaxCount)
+	}
+	return fmt.Sprintf("image storage quota exceeded: this %s upload would use %s of your %s; delete some images to upload more",
+		formatBytes(e.UploadBytes), formatBytes(e.Usage.Bytes+e.UploadBytes), formatBytes(e.Usage.Quota.MaxBytes))
+}
+
+// SetQuota enables per-user quotas. Without a quota store, uploads are unlimited.
+func (s *Service) SetQuota(defaults models.ImageQuota, store QuotaStore) {
+	s.quota = defaults
+	s.quotaStore = store
+}
+
+// Usage returns the user's stored image usage and effective quota.
+func (s *Service) Usage(ctx context.Context, userID string) (*models.ImageUsage, error) {
+	if s.quotaStore == nil {
+		return &models.ImageUsage{}, nil
+	}
+	return s.usage(ctx, userID, "", "")
+}
+
+// SetQuotaOverride sets an admin override of the user's quota.
+func (s *Service) SetQuotaOverride(ctx context.Context, userID, adminUserID string, params models.SetImageQuotaOverrideParams) (*models.ImageQuotaOverride, error) {
+	if s.quotaStore == nil {
+		return nil, fmt.Errorf("image quotas are not enabled")
+	}
+	if (params.MaxCount != nil && *params.MaxCount < 0) || (params.MaxBytes != nil && *params.MaxBytes < 0) {
+		return nil, fmt.Errorf("quota limits must not be negative")
+	}
+	return s.quotaStore.SetQuotaOverride(ctx, userID, adminUserID, params)
+}
+
+// DeleteQuotaOverride restores the default quota for the user.
+func (s *Service) DeleteQuotaOverride(ctx context.Context, userID string) error {
+	if s.quotaStore == nil {
+		return nil
+	}
+	return s.quotaStore.DeleteQuotaOverride(ctx, userID)
+}
+
+func (s *Service) usage(ctx context.Context, userID string, excludeType models.ImageEntityType, excludeEntityID string) (*models.ImageUsage, error) {
+	count, bytes, err := s.quotaStore.Usage(ctx, userID, excludeType, excludeEntityID)
+	if err != nil {
+		return nil, err
+	}
+	override, err := s.quotaStore.GetQuotaOverride(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ImageUsage{
+		Count:      count,
+		Bytes:      bytes,
+		Quota:      override.Apply(s.quota),
+		Overridden: override != nil,
+	}, nil
+}
+
+// checkQuota verifies the owner has room for the image being saved. Gear
+// catalog images are shared content and never count against a user.
+func (s *Service) checkQuota(ctx context.Context, req SaveRequest) error {
+	if s.quotaStore == nil || req.EntityType == models.ImageEntityGear {
+		return nil
+	}
+
+	usage, err := s.usage(ctx, req.OwnerUserID, req.EntityType, req.EntityID)
+	if err != nil {
+		return err
+	}
+
+	uploadBytes := int64(len(req.ImageBytes))
+	if usage.Quota.MaxCount > 0 && usage.Count+1 > usage.Quota.MaxCount {
+		return &QuotaExceededError{Usage: *usage, UploadBytes: uploadBytes, countLimit: true}
+	}
+	if usage.Quota.MaxBytes > 0 && usage.Bytes+uploadBytes > usage.Quota.MaxBytes {
+		return &QuotaExceededError{Usage: *usage, UploadBytes: uploadBytes}
+	}
+	return nil
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,175 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+type fakeQuotaStore struct {
+	count    int
+	bytes    int64
+	override *models.ImageQuotaOverride
+	excluded string
+}
+
+func (f *fakeQuotaStore) Usage(ctx context.Context, ownerUserID string, excludeType models.ImageEntityType, excludeEntityID string) (int, int64, error) {
+	_ = ctx
+	_ = ownerUserID
+	f.excluded = string(excludeType) + "/" + excludeEntityID
+	return f.count, f.bytes, nil
+}
+
+func (f *fakeQuotaStore) GetQuotaOverride(ctx context.Context, userID string) (*models.ImageQuotaOverride, error) {
+	_ = ctx
+	_ = userID
+	return f.override, nil
+}
+
+func (f *fakeQuotaStore) SetQuotaOverride(ctx context.Context, userID, adminUserID string, params models.SetImageQuotaOverrideParams) (*models.ImageQuotaOverride, error) {
+	_ = ctx
+	_ = adminUserID
+	f.override = &models.ImageQuotaOverride{UserID: userID, MaxCount: params.MaxCount, MaxBytes: params.MaxBytes}
+	return f.override, nil
+}
+
+func (f *fakeQuotaStore) DeleteQuotaOverride(ctx context.Context, userID string) error {
+	_ = ctx
+	_ = userID
+	f.override = nil
+	return nil
+}
+
+func TestServiceModerateAndPersistQuota(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name       string
+		quota      models.ImageQuota
+		store      *fakeQuotaStore
+		entityType models.ImageEntityType
+		wantErr    string
+	}{
+		{
+			name:       "under quota",
+			quota:      models.ImageQuota{MaxCount: 10, MaxBytes: 1000},
+			store:      &fakeQuotaStore{count: 9, bytes: 500},
+			entityType: models.ImageEntityAircraft,
+		},
+		{
+			name:       "count exceeded",
+			quota:      models.ImageQuota{MaxCount: 10, MaxBytes: 1000},
+			store:      &fakeQuotaStore{count: 10, bytes: 500},
+			entityType: models.ImageEntityAircraft,
+			wantErr:    "10 of 10 images",
+		},
+		{
+			name:       "bytes exceeded",
+			quota:      models.ImageQuota{MaxCount: 10, MaxBytes: 1000},
+			store:      &fakeQuotaStore{count: 1, bytes: 999},
+			entityType: models.ImageEntityAircraft,
+			wantErr:    "storage quota exceeded",
+		},
+		{
+			name:       "unlimited",
+			quota:      models.ImageQuota{},
+			store:      &fakeQuotaStore{count: 5000, bytes: 1 << 40},
+			entityType: models.ImageEntityAircraft,
+		},
+		{
+			name:       "override raises limit",
+			quota:      models.ImageQuota{MaxCount: 10},
+			store:      &fakeQuotaStore{count: 10, override: &models.ImageQuotaOverride{MaxCount: intPtr(20)}},
+			entityType: models.ImageEntityAircraft,
+		},
+		{
+			name:       "gear catalog images are exempt",
+			quota:      models.ImageQuota{MaxCount: 10},
+			store:      &fakeQuotaStore{count: 10},
+			entityType: models.ImageEntityGear,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &fakeStorage{}
+			svc := NewService(
+				&fakeModerator{decision: &models.ModerationDecision{Status: models.ImageModerationApproved}},
+				storage,
+				NewInMemoryPendingStore(5*time.Minute),
+				5*time.Second,
+			)
+			svc.SetQuota(tt.quota, tt.store)
+
+			_, _, err := svc.ModerateAndPersist(context.Background(), SaveRequest{
+				OwnerUserID: "user-1",
+				EntityType:  tt.entityType,
+				EntityID:    "entity-1",
+				ImageBytes:  []byte("abc"),
+			})
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(storage.saved) != 1 {
+					t.Fatalf("expected one save, got %d", len(storage.saved))
+				}
+				return
+			}
+
+			var quotaErr *QuotaExceededError
+			if !errors.As(err, &quotaErr) {
+				t.Fatalf("expected QuotaExceededError, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error %q does not contain %q", err.Error(), tt.wantErr)
+			}
+			if len(storage.saved) != 0 {
+				t.Errorf("expected no save, got %d", len(storage.saved))
+			}
+		})
+	}
+}
+
+func TestServicePersistApprovedUploadExcludesReplacedImage(t *testing.T) {
+	quotaStore := &fakeQuotaStore{}
+	svc := NewService(
+		&fakeModerator{decision: &models.ModerationDecision{Status: models.ImageModerationApproved}},
+		&fakeStorage{},
+		NewInMemoryPendingStore(5*time.Minute),
+		5*time.Second,
+	)
+	svc.SetQuota(models.ImageQuota{MaxCount: 10}, quotaStore)
+
+	_, uploadID, err := svc.ModerateUpload(context.Background(), "user-1", models.ImageEntityAvatar, []byte("abc"))
+	if err != nil {
+		t.Fatalf("moderate upload error: %v", err)
+	}
+	if _, err := svc.PersistApprovedUpload(context.Background(), "user-1", uploadID, models.ImageEntityAvatar, "user-1"); err != nil {
+		t.Fatalf("persist approved upload error: %v", err)
+	}
+	if quotaStore.excluded != "avatar/user-1" {
+		t.Errorf("excluded = %q, want avatar/user-1", quotaStore.excluded)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want string
+	}{
+		{in: 512, want: "512 B"},
+		{in: 2048, want: "2.0 KB"},
+		{in: 200 * 1024 * 1024, want: "200.0 MB"},
+	}
+	for _, tt := range tests {
+		if got := formatBytes(tt.in); got != tt.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
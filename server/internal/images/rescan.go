@@ -0,0 +1,234 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+var (
+	// ErrRescanRunning is returned when starting a re-scan while another is running.
+	ErrRescanRunning = errors.New("an image re-scan is already running")
+	// ErrInvalidEntityType is returned for a re-scan filtered to an unknown entity type.
+	ErrInvalidEntityType = errors.New("invalid entityType")
+)
+
+const (
+	rescanBatchSize = 100
+	// maxConsecutiveRescanErrors fails a job whose moderation provider keeps
+	// erroring instead of walking every image.
+	maxConsecutiveRescanErrors = 10
+)
+
+// RescanStore lists approved images for re-scanning and manages the review queue.
+type RescanStore interface {
+	ListApprovedForRescan(ctx context.Context, afterID string, entityType models.ImageEntityType, limit int) ([]models.ImageAsset, error)
+	RecordRescan(ctx context.Context, imageID string, decision models.ModerationDecision) error
+	ListPendingReview(ctx context.Context, limit, offset int) (*models.ImageReviewListResponse, error)
+	ApproveReview(ctx context.Context, imageID string) (bool, error)
+	RejectReview(ctx context.Context, imageID string) (bool, error)
+}
+
+// RescanJobStore persists re-scan job state so progress and cancellation work
+// across server instances.
+type RescanJobStore interface {
+	CreateJob(ctx context.Context, startedBy string, entityType models.ImageEntityType) (*models.ImageRescanJob, error)
+	SaveProgress(ctx context.Context, job *models.ImageRescanJob) (bool, error)
+	FinishJob(ctx context.Context, id string, status models.ImageRescanStatus, lastError string) error
+	CancelRunning(ctx context.Context) (*models.ImageRescanJob, error)
+	LatestJob(ctx context.Context) (*models.ImageRescanJob, error)
+}
+
+// Rescanner re-runs moderation over approved images in rate-limited batches,
+// e.g. after the reject confidence is tightened. Images that no longer pass
+// are moved to the review queue instead of being deleted.
+type Rescanner struct {
+	moderator Moderator
+	store     RescanStore
+	jobs      RescanJobStore
+	interval  time.Duration // minimum gap between moderation calls
+	timeout   time.Duration
+	batchSize int
+	logger    *logging.Logger
+}
+
+// NewRescanner creates a re-scanner that makes at most ratePerSecond
+// moderation calls per second.
+func NewRescanner(moderator Moderator, store RescanStore, jobs RescanJobStore, ratePerSecond float64, timeout time.Duration, logger *logging.Logger) *Rescanner {
+	if ratePerSecond <= 0 {
+		ratePerSecond = 5
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &Rescanner{
+		moderator: moderator,
+		store:     store,
+		jobs:      jobs,
+		interval:  time.Duration(float64(time.Second) / ratePerSecond),
+		timeout:   timeout,
+		batchSize: rescanBatchSize,
+		logger:    logger,
+	}
+}
+
+// Start begins a re-scan in the background and returns the new job. The
+// job keeps ctx's values, such as its tenant scope, but not its deadline.
+func (r *Rescanner) Start(ctx context.Context, adminUserID string, params models.StartImageRescanParams) (*models.ImageRescanJob, error) {
+	if params.EntityType != "" && !models.IsValidImageEntityType(params.EntityType) {
+		return nil, ErrInvalidEntityType
+	}
+
+	job, err := r.jobs.CreateJob(ctx, adminUserID, params.EntityType)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrRescanRunning
+	}
+
+	// The job outlives the admin request that started it.
+	go r.run(context.WithoutCancel(ctx), *job)
+	return job, nil
+}
+
+// Latest returns the most recent re-scan job, or nil if none has run.
+func (r *Rescanner) Latest(ctx context.Context) (*models.ImageRescanJob, error) {
+	return r.jobs.LatestJob(ctx)
+}
+
+// Cancel stops the running re-scan after its current batch. Returns nil if
+// nothing is running.
+func (r *Rescanner) Cancel(ctx context.Context) (*models.ImageRescanJob, error) {
+	return r.jobs.CancelRunning(ctx)
+}
+
+// ListReview returns images waiting in the review queue.
+func (r *Rescanner) ListReview(ctx context.Context, limit, offset int) (*models.ImageReviewListResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return r.store.ListPendingReview(ctx, limit, offset)
+}
+
+// ResolveReview approves (restoring the image) or rejects (deleting it) a
+// flagged image. Returns false if the image isn't in the review queue.
+func (r *Rescanner) ResolveReview(ctx context.Context, imageID string, approve bool) (bool, error) {
+	if approve {
+		return r.store.ApproveReview(ctx, imageID)
+	}
+	return r.store.RejectReview(ctx, imageID)
+}
+
+func (r *Rescanner) run(ctx context.Context, job models.ImageRescanJob) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	consecutiveErrors := 0
+	for {
+		batch, err := r.store.ListApprovedForRescan(ctx, job.CursorID, job.EntityType, r.batchSize)
+		if err != nil {
+			r.finish(ctx, job, models.ImageRescanFailed, err.Error())
+			return
+		}
+		if len(batch) == 0 {
+			r.finish(ctx, job, models.ImageRescanCompleted, "")
+			return
+		}
+
+		for _, asset := range batch {
+			select {
+			case <-ctx.Done():
+				r.finish(context.WithoutCancel(ctx), job, models.ImageRescanInterrupted, ctx.Err().Error())
+				return
+			case <-ticker.C:
+			}
+
+			flagged, err := r.rescan(ctx, asset)
+			job.CursorID = asset.ID
+			if err != nil {
+				job.Errors++
+				job.LastError = err.Error()
+				consecutiveErrors++
+				if consecutiveErrors >= maxConsecutiveRescanErrors {
+					r.saveProgress(ctx, &job)
+					r.finish(ctx, job, models.ImageRescanFailed, "moderation unavailable: "+err.Error())
+					return
+				}
+				continue
+			}
+
+			consecutiveErrors = 0
+			job.Scanned++
+			if flagged {
+				job.Flagged++
+			}
+		}
+
+		if !r.saveProgress(ctx, &job) {
+			r.logger.Info("Image rescan stopped", logging.WithField("jobId", job.ID))
+			return
+		}
+	}
+}
+
+// rescan moderates one image and records the result. Returns true if the
+// image was moved to the review queue.
+func (r *Rescanner) rescan(ctx context.Context, asset models.ImageAsset) (bool, error) {
+	moderationCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	decision, err := r.moderator.ModerateImageBytes(moderationCtx, asset.EntityType, asset.ImageBytes)
+	if err != nil {
+		return false, err
+	}
+	if decision == nil {
+		return false, errors.New("moderation returned no decision")
+	}
+
+	if err := r.store.RecordRescan(ctx, asset.ID, *decision); err != nil {
+		return false, err
+	}
+	return decision.Status != models.ImageModerationApproved, nil
+}
+
+// saveProgress persists the job's counters. Returns false if the job should
+// stop because it was cancelled or its state can't be saved.
+func (r *Rescanner) saveProgress(ctx context.Context, job *models.ImageRescanJob) bool {
+	running, err := r.jobs.SaveProgress(ctx, job)
+	if err != nil {
+		r.logger.Error("Failed to save image rescan progress", logging.WithFields(map[string]interface{}{
+			"jobId": job.ID,
+			"error": err.Error(),
+		}))
+		return false
+	}
+	return running
+}
+
+func (r *Rescanner) finish(ctx context.Context, job models.ImageRescanJob, status models.ImageRescanStatus, lastError string) {
+	if status == models.ImageRescanCompleted && !r.saveProgress(ctx, &job) {
+		return
+	}
+	if err := r.jobs.FinishJob(ctx, job.ID, status, lastError); err != nil {
+		r.logger.Error("Failed to finish image rescan", logging.WithFields(map[string]interface{}{
+			"jobId": job.ID,
+			"error": err.Error(),
+		}))
+		return
+	}
+
+	r.logger.Info("Image rescan finished", logging.WithFields(map[string]interface{}{
+		"jobId":   job.ID,
+		"status":  status,
+		"scanned": job.Scanned,
+		"flagged": job.Flagged,
+		"errors":  job.Errors,
+	}))
+}
@@ -0,0 +1,180 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+// rescanStore holds approved assets in ID order and records decisions
+type rescanStore struct {
+	assets    []models.ImageAsset
+	decisions map[string]models.ModerationDecision
+}
+
+func newRescanStore(count int) *rescanStore {
+	store := &rescanStore{decisions: make(map[string]models.ModerationDecision)}
+	for i := 0; i < count; i++ {
+		store.assets = append(store.assets, models.ImageAsset{ID: fmt.Sprintf("img-%03d", i), ImageBytes: []byte{byte(i)}})
+	}
+	return store
+}
+
+func (s *rescanStore) ListApprovedForRescan(ctx context.Context, afterID string, entityType models.ImageEntityType, limit int) ([]models.ImageAsset, error) {
+	var batch []models.ImageAsset
+	for _, asset := range s.assets {
+		if asset.ID > afterID && len(batch) < limit {
+			batch = append(batch, asset)
+		}
+	}
+	return batch, nil
+}
+
+func (s *rescanStore) RecordRescan(ctx context.Context, imageID string, decision models.ModerationDecision) error {
+	s.decisions[imageID] = decision
+	return nil
+}
+
+func (s *rescanStore) ListPendingReview(ctx context.Context, limit, offset int) (*models.ImageReviewListResponse, error) {
+	return &models.ImageReviewListResponse{}, nil
+}
+
+func (s *rescanStore) ApproveReview(ctx context.Context, imageID string) (bool, error) {
+	return true, nil
+}
+
+func (s *rescanStore) RejectReview(ctx context.Context, imageID string) (bool, error) {
+	return true, nil
+}
+
+// jobStore records progress saves and the final status
+type jobStore struct {
+	running     bool
+	cancelAfter int // progress saves before the job reports cancelled
+	saves       int
+	finalStatus models.ImageRescanStatus
+	last        models.ImageRescanJob
+}
+
+func (s *jobStore) CreateJob(ctx context.Context, startedBy string, entityType models.ImageEntityType) (*models.ImageRescanJob, error) {
+	if s.running {
+		return nil, nil
+	}
+	s.running = true
+	return &models.ImageRescanJob{ID: "job-1", Status: models.ImageRescanRunning, EntityType: entityType}, nil
+}
+
+func (s *jobStore) SaveProgress(ctx context.Context, job *models.ImageRescanJob) (bool, error) {
+	s.saves++
+	s.last = *job
+	if s.cancelAfter > 0 && s.saves >= s.cancelAfter {
+		return false, nil
+	}
+	return true, nil
+}
+
+func (s *jobStore) FinishJob(ctx context.Context, id string, status models.ImageRescanStatus, lastError string) error {
+	s.finalStatus = status
+	return nil
+}
+
+func (s *jobStore) CancelRunning(ctx context.Context) (*models.ImageRescanJob, error) {
+	return nil, nil
+}
+
+func (s *jobStore) LatestJob(ctx context.Context) (*models.ImageRescanJob, error) {
+	return nil, nil
+}
+
+// byteModerator rejects images whose first byte is in reject and errors when err is set
+type byteModerator struct {
+	reject map[byte]bool
+	err    error
+}
+
+func (m *byteModerator) ModerateImageBytes(ctx context.Context, entityType models.ImageEntityType, imageBytes []byte) (*models.ModerationDecision, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.reject[imageBytes[0]] {
+		return &models.ModerationDecision{Status: models.ImageModerationRejected, MaxConfidence: 80}, nil
+	}
+	return &models.ModerationDecision{Status: models.ImageModerationApproved}, nil
+}
+
+func newTestRescanner(moderator Moderator, store *rescanStore, jobs *jobStore) *Rescanner {
+	r := NewRescanner(moderator, store, jobs, 0, time.Second, testutil.NullLogger())
+	r.interval = time.Microsecond
+	r.batchSize = 10
+	return r
+}
+
+func TestRescanner_Run(t *testing.T) {
+	store := newRescanStore(25)
+	jobs := &jobStore{}
+	moderator := &byteModerator{reject: map[byte]bool{3: true, 17: true}}
+	r := newTestRescanner(moderator, store, jobs)
+
+	r.run(context.Background(), models.ImageRescanJob{ID: "job-1"})
+
+	if jobs.finalStatus != models.ImageRescanCompleted {
+		t.Fatalf("final status = %q, want completed", jobs.finalStatus)
+	}
+	if jobs.last.Scanned != 25 || jobs.last.Flagged != 2 || jobs.last.Errors != 0 {
+		t.Errorf("progress = %+v, want 25 scanned, 2 flagged", jobs.last)
+	}
+	if len(store.decisions) != 25 {
+		t.Errorf("recorded %d decisions, want 25", len(store.decisions))
+	}
+	if store.decisions["img-003"].Status != models.ImageModerationRejected {
+		t.Errorf("img-003 decision = %+v, want rejected", store.decisions["img-003"])
+	}
+}
+
+func TestRescanner_Run_StopsWhenCancelled(t *testing.T) {
+	store := newRescanStore(25)
+	jobs := &jobStore{cancelAfter: 1}
+	r := newTestRescanner(&byteModerator{}, store, jobs)
+
+	r.run(context.Background(), models.ImageRescanJob{ID: "job-1"})
+
+	if len(store.decisions) != 10 {
+		t.Errorf("recorded %d decisions, want only the first batch of 10", len(store.decisions))
+	}
+	if jobs.finalStatus != "" {
+		t.Errorf("final status = %q, cancelled jobs should not be finished again", jobs.finalStatus)
+	}
+}
+
+func TestRescanner_Run_FailsWhenModerationUnavailable(t *testing.T) {
+	store := newRescanStore(25)
+	jobs := &jobStore{}
+	r := newTestRescanner(&byteModerator{err: errors.New("throttled")}, store, jobs)
+
+	r.run(context.Background(), models.ImageRescanJob{ID: "job-1"})
+
+	if jobs.finalStatus != models.ImageRescanFailed {
+		t.Fatalf("final status = %q, want failed", jobs.finalStatus)
+	}
+	if jobs.last.Errors != maxConsecutiveRescanErrors || len(store.decisions) != 0 {
+		t.Errorf("errors = %d with %d decisions, want %d errors and no decisions",
+			jobs.last.Errors, len(store.decisions), maxConsecutiveRescanErrors)
+	}
+}
+
+func TestRescanner_Start(t *testing.T) {
+	jobs := &jobStore{running: true}
+	r := newTestRescanner(&byteModerator{}, newRescanStore(0), jobs)
+
+	if _, err := r.Start(context.Background(), "admin-1", models.StartImageRescanParams{EntityType: "poster"}); !errors.Is(err, ErrInvalidEntityType) {
+		t.Errorf("Start() with unknown entity type error = %v, want ErrInvalidEntityType", err)
+	}
+	if _, err := r.Start(context.Background(), "admin-1", models.StartImageRescanParams{}); !errors.Is(err, ErrRescanRunning) {
+		t.Errorf("Start() while running error = %v, want ErrRescanRunning", err)
+	}
+}
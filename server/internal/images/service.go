@@ -18,7 +18,7 @@ var (
 
 // Moderator defines the moderation abstraction used by image flows.
 type Moderator interface {
-	ModerateImageBytes(ctx context.Context, imageBytes []byte) (*models.ModerationDecision, error)
+	ModerateImageBytes(ctx context.Context, entityType models.ImageEntityType, imageBytes []byte) (*models.ModerationDecision, error)
 }
 
 // SaveRequest defines a single image save operation.
@@ -29,9 +29,14 @@ type SaveRequest struct {
 	ImageBytes              []byte
 	ModerationLabels        []models.ModerationLabel
 	ModerationMaxConfidence float64
+	// Background, when set, replaces the background before moderation
+	Background models.ImageBackground
 }
 
 // Storage abstracts image persistence so DB storage can later be swapped for S3.
+// Save may return an existing asset with identical bytes rather than a new
+// one; each Save takes a reference and each Delete releases one, so callers
+// delete the assets they saved exactly as before.
 type Storage interface {
 	Save(ctx context.Context, req SaveRequest) (*models.ImageAsset, error)
 	Load(ctx context.Context, imageID string) (*models.ImageAsset, error)
@@ -48,6 +53,12 @@ type PendingUpload struct {
 	ExpiresAt   time.Time
 }
 
+// EventRecorder records domain events, such as image.rejected, for the
+// outbox relay to publish
+type EventRecorder interface {
+	Enqueue(ctx context.Context, eventType models.DomainEventType, aggregateID string, payload interface{}) error
+}
+
 // PendingStore tracks approved uploads that still require explicit Save.
 type PendingStore interface {
 	Put(upload PendingUpload) string
@@ -61,6 +72,21 @@ type Service struct {
 	storage   Storage
 	pending   PendingStore
 	timeout   time.Duration
+
+	quota      models.ImageQuota
+	quotaStore QuotaStore
+	normalize  *NormalizeOptions
+
+	transcoders  []Transcoder
+	variantCache VariantCache
+
+	gallery GalleryStore
+
+	backgroundRemover BackgroundRemover
+
+	events EventRecorder
+
+	archiver *Archiver
 }
 
 // NewService creates a new image pipeline service.
@@ -73,10 +99,40 @@ func NewService(moderator Moderator, storage Storage, pending PendingStore, time
 	}
 }
 
+// SetNormalization enables re-encoding of uploads before moderation and storage,
+// which strips EXIF/GPS metadata and bounds image dimensions.
+func (s *Service) SetNormalization(opts NormalizeOptions) {
+	s.normalize = &opts
+}
+
+// SetEvents enables recording an image.rejected event for each rejected upload
+func (s *Service) SetEvents(events EventRecorder) {
+	s.events = events
+}
+
+// SetArchiver enables reporting on the cold storage tier
+func (s *Service) SetArchiver(archiver *Archiver) {
+	s.archiver = archiver
+}
+
+// ArchiveStatus reports storage tier sizes and archiver activity
+func (s *Service) ArchiveStatus(ctx context.Context) (*models.ImageArchiveStatus, error) {
+	if s.archiver == nil {
+		return nil, ErrArchiveUnavailable
+	}
+	return s.archiver.Status(ctx)
+}
+
 // ModerateUpload runs synchronous moderation and, if approved, stores a pending token.
 func (s *Service) ModerateUpload(ctx context.Context, ownerUserID string, entityType models.ImageEntityType, imageBytes []byte) (*models.ModerationDecision, string, error) {
-	decision := s.moderate(ctx, imageBytes)
+	imageBytes, err := s.normalizeBytes(imageBytes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	decision := s.moderate(ctx, entityType, imageBytes)
 	if decision.Status != models.ImageModerationApproved {
+		s.recordRejection(ctx, ownerUserID, entityType, decision)
 		return decision, "", nil
 	}
 	if s.pending == nil {
@@ -104,8 +160,25 @@ func (s *Service) ModerateUpload(ctx context.Context, ownerUserID string, entity
 
 // ModerateAndPersist runs moderation and immediately persists approved images.
 func (s *Service) ModerateAndPersist(ctx context.Context, req SaveRequest) (*models.ModerationDecision, *models.ImageAsset, error) {
-	decision := s.moderate(ctx, req.ImageBytes)
+	imageBytes, err := s.normalizeBytes(req.ImageBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	if req.Background != "" {
+		imageBytes, err = s.replaceBackground(ctx, imageBytes, req.Background)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	req.ImageBytes = imageBytes
+
+	if err := s.checkQuota(ctx, req); err != nil {
+		return nil, nil, err
+	}
+
+	decision := s.moderate(ctx, req.EntityType, req.ImageBytes)
 	if decision.Status != models.ImageModerationApproved {
+		s.recordRejection(ctx, req.OwnerUserID, req.EntityType, decision)
 		return decision, nil, nil
 	}
 
@@ -119,6 +192,33 @@ func (s *Service) ModerateAndPersist(ctx context.Context, req SaveRequest) (*mod
 	return decision, asset, nil
 }
 
+// recordRejection records image.rejected for a rejected decision. Uploads
+// that are only pending review (e.g. moderation timed out) aren't rejections.
+// Recording is best effort; the upload response doesn't depend on it.
+func (s *Service) recordRejection(ctx context.Context, ownerUserID string, entityType models.ImageEntityType, decision *models.ModerationDecision) {
+	if s.events == nil || decision.Status != models.ImageModerationRejected {
+		return
+	}
+	payload := models.ImageRejectedPayload{
+		OwnerUserID: ownerUserID,
+		EntityType:  entityType,
+		Reason:      decision.Reason,
+		Labels:      decision.Labels,
+	}
+	_ = s.events.Enqueue(ctx, models.DomainEventImageRejected, ownerUserID, payload)
+}
+
+// Screen normalizes and moderates image bytes without storing them, for
+// files kept outside image_assets such as inventory attachments. It returns
+// the normalized bytes to store.
+func (s *Service) Screen(ctx context.Context, entityType models.ImageEntityType, imageBytes []byte) (*models.ModerationDecision, []byte, error) {
+	imageBytes, err := s.normalizeBytes(imageBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.moderate(ctx, entityType, imageBytes), imageBytes, nil
+}
+
 // PersistApprovedUpload stores a previously approved pending upload.
 func (s *Service) PersistApprovedUpload(ctx context.Context, ownerUserID, uploadID string, entityType models.ImageEntityType, entityID string) (*models.ImageAsset, error) {
 	if s.pending == nil {
@@ -136,14 +236,19 @@ func (s *Service) PersistApprovedUpload(ctx context.Context, ownerUserID, upload
 		return nil, ErrUploadNotApproved
 	}
 
-	asset, err := s.storage.Save(ctx, SaveRequest{
+	req := SaveRequest{
 		OwnerUserID:             ownerUserID,
 		EntityType:              entityType,
 		EntityID:                entityID,
 		ImageBytes:              pendingUpload.ImageBytes,
 		ModerationLabels:        pendingUpload.Decision.Labels,
 		ModerationMaxConfidence: pendingUpload.Decision.MaxConfidence,
-	})
+	}
+	if err := s.checkQuota(ctx, req); err != nil {
+		return nil, err
+	}
+
+	asset, err := s.storage.Save(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -162,7 +267,15 @@ func (s *Service) Delete(ctx context.Context, imageID string) error {
 	return s.storage.Delete(ctx, imageID)
 }
 
-func (s *Service) moderate(ctx context.Context, imageBytes []byte) *models.ModerationDecision {
+// normalizeBytes re-encodes image bytes when normalization is enabled.
+func (s *Service) normalizeBytes(imageBytes []byte) ([]byte, error) {
+	if s.normalize == nil {
+		return imageBytes, nil
+	}
+	return Normalize(imageBytes, *s.normalize)
+}
+
+func (s *Service) moderate(ctx context.Context, entityType models.ImageEntityType, imageBytes []byte) *models.ModerationDecision {
 	timeout := s.timeout
 	if timeout <= 0 {
 		timeout = 5 * time.Second
@@ -171,7 +284,7 @@ func (s *Service) moderate(ctx context.Context, imageBytes []byte) *models.Moder
 	moderationCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	decision, err := s.moderator.ModerateImageBytes(moderationCtx, imageBytes)
+	decision, err := s.moderator.ModerateImageBytes(moderationCtx, entityType, imageBytes)
 	if err != nil || decision == nil {
 		return &models.ModerationDecision{
 			Status: models.ImageModerationPendingReview,
@@ -14,8 +14,9 @@ type fakeModerator struct {
 	err      error
 }
 
-func (f *fakeModerator) ModerateImageBytes(ctx context.Context, imageBytes []byte) (*models.ModerationDecision, error) {
+func (f *fakeModerator) ModerateImageBytes(ctx context.Context, entityType models.ImageEntityType, imageBytes []byte) (*models.ModerationDecision, error) {
 	_ = ctx
+	_ = entityType
 	_ = imageBytes
 	if f.err != nil {
 		return nil, f.err
@@ -176,3 +177,69 @@ func TestServiceModerateUploadPendingStoreFailure(t *testing.T) {
 		t.Fatalf("expected empty upload id")
 	}
 }
+
+type recordedEvent struct {
+	eventType   models.DomainEventType
+	aggregateID string
+	payload     interface{}
+}
+
+type fakeEventRecorder struct {
+	events []recordedEvent
+}
+
+func (f *fakeEventRecorder) Enqueue(ctx context.Context, eventType models.DomainEventType, aggregateID string, payload interface{}) error {
+	_ = ctx
+	f.events = append(f.events, recordedEvent{eventType: eventType, aggregateID: aggregateID, payload: payload})
+	return nil
+}
+
+func TestServiceModerateUploadRecordsRejection(t *testing.T) {
+	svc := NewService(
+		&fakeModerator{
+			decision: &models.ModerationDecision{
+				Status: models.ImageModerationRejected,
+				Reason: "Explicit content",
+				Labels: []models.ModerationLabel{{Name: "Explicit Nudity", Confidence: 98}},
+			},
+		},
+		&fakeStorage{},
+		NewInMemoryPendingStore(5*time.Minute),
+		5*time.Second,
+	)
+	events := &fakeEventRecorder{}
+	svc.SetEvents(events)
+
+	if _, _, err := svc.ModerateUpload(context.Background(), "user-1", models.ImageEntityAvatar, []byte("abc")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events.events) != 1 {
+		t.Fatalf("expected one event, got %d", len(events.events))
+	}
+	event := events.events[0]
+	if event.eventType != models.DomainEventImageRejected || event.aggregateID != "user-1" {
+		t.Fatalf("event=%+v", event)
+	}
+	payload, ok := event.payload.(models.ImageRejectedPayload)
+	if !ok || payload.EntityType != models.ImageEntityAvatar || payload.Reason != "Explicit content" || len(payload.Labels) != 1 {
+		t.Fatalf("payload=%+v", event.payload)
+	}
+}
+
+func TestServiceModerateUploadPendingReviewRecordsNoEvent(t *testing.T) {
+	svc := NewService(
+		&fakeModerator{err: errors.New("boom")},
+		&fakeStorage{},
+		NewInMemoryPendingStore(5*time.Minute),
+		5*time.Second,
+	)
+	events := &fakeEventRecorder{}
+	svc.SetEvents(events)
+
+	if _, _, err := svc.ModerateAndPersist(context.Background(), SaveRequest{OwnerUserID: "user-1", EntityType: models.ImageEntityAvatar, ImageBytes: []byte("abc")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events.events) != 0 {
+		t.Fatalf("expected no events, got %d", len(events.events))
+	}
+}
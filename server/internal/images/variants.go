@@ -0,0 +1,202 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Transcoder converts a stored JPEG or PNG into another output format.
+type Transcoder interface {
+	ContentType() string
+	Transcode(ctx context.Context, data []byte) ([]byte, error)
+}
+
+// VariantCache stores transcoded variants keyed by source hash and format.
+type VariantCache interface {
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{})
+}
+
+// SetTranscoders enables serving modern formats to clients that accept them.
+// Transcoders are listed in server preference order (e.g. AVIF before WebP).
+func (s *Service) SetTranscoders(cache VariantCache, transcoders ...Transcoder) {
+	s.variantCache = cache
+	s.transcoders = transcoders
+}
+
+// Negotiate returns the best representation of a stored image for the given
+// Accept header. The original bytes are returned when no transcoder matches,
+// transcoding fails, or the result would be larger than the original.
+func (s *Service) Negotiate(ctx context.Context, accept string, data []byte, contentType string) ([]byte, string) {
+	if s == nil || len(s.transcoders) == 0 || len(data) == 0 {
+		return data, contentType
+	}
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return data, contentType
+	}
+
+	accepted := parseAccept(accept)
+	for _, t := range s.transcoders {
+		if !accepted.allows(t.ContentType()) {
+			continue
+		}
+		if out, ok := s.variant(ctx, t, data); ok {
+			return out, t.ContentType()
+		}
+	}
+	return data, contentType
+}
+
+// variant returns a cached or freshly transcoded variant. A cached empty value
+// records that the variant is not worth serving so it isn't retried.
+func (s *Service) variant(ctx context.Context, t Transcoder, data []byte) ([]byte, bool) {
+	sum := sha256.Sum256(data)
+	key := "image-variant:" + t.ContentType() + ":" + hex.EncodeToString(sum[:])
+
+	if s.variantCache != nil {
+		if cached, ok := s.variantCache.Get(key); ok {
+			out, _ := cached.([]byte)
+			return out, len(out) > 0
+		}
+	}
+
+	out, err := t.Transcode(ctx, data)
+	if err != nil || len(out) >= len(data) {
+		out = nil
+	}
+	// Don't remember failures caused by the request going away
+	if s.variantCache != nil && ctx.Err() == nil {
+		s.variantCache.Set(key, out)
+	}
+	return out, len(out) > 0
+}
+
+// acceptList holds media ranges from an Accept header with their q-values.
+type acceptList map[string]float64
+
+func parseAccept(header string) acceptList {
+	accepted := make(acceptList)
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(fields[0]))
+		if mediaType == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.EqualFold(strings.TrimSpace(name), "q") {
+				if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		accepted[mediaType] = q
+	}
+	return accepted
+}
+
+// allows reports whether the media type is explicitly accepted. Wildcards are
+// ignored on purpose: browsers send */* for images they may not decode, so
+// only an explicit image/webp or image/avif opts a client in.
+func (a acceptList) allows(mediaType string) bool {
+	q, ok := a[mediaType]
+	return ok && q > 0
+}
+
+// CommandTranscoder runs an external encoder such as cwebp or avifenc. The
+// command's arguments may reference {in} and {out}, which are replaced with
+// temporary file paths for the source image and the encoded result.
+type CommandTranscoder struct {
+	contentType string
+	command     []string
+	timeout     time.Duration
+	slots       chan struct{}
+}
+
+// NewCommandTranscoder parses a command line like "cwebp -q 75 {in} -o {out}".
+// At most maxConcurrent encodes run at once so bursts of cache misses can't
+// exhaust CPU.
+func NewCommandTranscoder(contentType, commandLine string, timeout time.Duration, maxConcurrent int) (*CommandTranscoder, error) {
+	command := strings.Fields(commandLine)
+	if len(command) == 0 {
+		return nil, fmt.Errorf("empty transcoder command")
+	}
+	if !strings.Contains(commandLine, "{in}") || !strings.Contains(commandLine, "{out}") {
+		return nil, fmt.Errorf("transcoder command must reference {in} and {out}")
+	}
+	if _, err := exec.LookPath(command[0]); err != nil {
+		return nil, fmt.Errorf("find transcoder %s: %w", command[0], err)
+	}
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	if maxConcurrent <= 0 {
+		maxConcurrent = 2
+	}
+	return &CommandTranscoder{
+		contentType: contentType,
+		command:     command,
+		timeout:     timeout,
+		slots:       make(chan struct{}, maxConcurrent),
+	}, nil
+}
+
+// ContentType returns the media type the command produces.
+func (t *CommandTranscoder) ContentType() string {
+	return t.contentType
+}
+
+// Transcode writes data to a temp file, runs the command, and returns its output file.
+func (t *CommandTranscoder) Transcode(ctx context.Context, data []byte) ([]byte, error) {
+	select {
+	case t.slots <- struct{}{}:
+		defer func() { <-t.slots }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	dir, err := os.MkdirTemp("", "image-variant-")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	in := filepath.Join(dir, "in")
+	out := filepath.Join(dir, "out")
+	if err := os.WriteFile(in, data, 0o600); err != nil {
+		return nil, fmt.Errorf("write source image: %w", err)
+	}
+
+	args := make([]string, 0, len(t.command)-1)
+	for _, arg := range t.command[1:] {
+		arg = strings.ReplaceAll(arg, "{in}", in)
+		arg = strings.ReplaceAll(arg, "{out}", out)
+		args = append(args, arg)
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(runCtx, t.command[0], args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("run %s: %w: %s", t.command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	encoded, err := os.ReadFile(out)
+	if err != nil {
+		return nil, fmt.Errorf("read encoded image: %w", err)
+	}
+	return encoded, nil
+}
@@ -0,0 +1,179 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/cache"
+)
+
+type fakeTranscoder struct {
+	contentType string
+	out         []byte
+	err         error
+	calls       int
+}
+
+func (f *fakeTranscoder) ContentType() string {
+	return f.contentType
+}
+
+func (f *fakeTranscoder) Transcode(ctx context.Context, data []byte) ([]byte, error) {
+	f.calls++
+	return f.out, f.err
+}
+
+func TestServiceNegotiate(t *testing.T) {
+	original := bytes.Repeat([]byte{0x01}, 100)
+
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+		avif        *fakeTranscoder
+		webp        *fakeTranscoder
+		wantType    string
+		wantLen     int
+	}{
+		{
+			name:        "prefers avif when both accepted",
+			accept:      "image/avif,image/webp,image/*,*/*;q=0.8",
+			contentType: "image/jpeg",
+			avif:        &fakeTranscoder{contentType: "image/avif", out: make([]byte, 30)},
+			webp:        &fakeTranscoder{contentType: "image/webp", out: make([]byte, 50)},
+			wantType:    "image/avif",
+			wantLen:     30,
+		},
+		{
+			name:        "webp only",
+			accept:      "image/webp,*/*",
+			contentType: "image/png",
+			avif:        &fakeTranscoder{contentType: "image/avif", out: make([]byte, 30)},
+			webp:        &fakeTranscoder{contentType: "image/webp", out: make([]byte, 50)},
+			wantType:    "image/webp",
+			wantLen:     50,
+		},
+		{
+			name:        "wildcards do not opt in",
+			accept:      "image/*,*/*",
+			contentType: "image/jpeg",
+			avif:        &fakeTranscoder{contentType: "image/avif", out: make([]byte, 30)},
+			webp:        &fakeTranscoder{contentType: "image/webp", out: make([]byte, 50)},
+			wantType:    "image/jpeg",
+			wantLen:     100,
+		},
+		{
+			name:        "q=0 refuses format",
+			accept:      "image/avif;q=0, image/webp",
+			contentType: "image/jpeg",
+			avif:        &fakeTranscoder{contentType: "image/avif", out: make([]byte, 30)},
+			webp:        &fakeTranscoder{contentType: "image/webp", out: make([]byte, 50)},
+			wantType:    "image/webp",
+			wantLen:     50,
+		},
+		{
+			name:        "falls back when transcode fails",
+			accept:      "image/avif,image/webp",
+			contentType: "image/jpeg",
+			avif:        &fakeTranscoder{contentType: "image/avif", err: errors.New("boom")},
+			webp:        &fakeTranscoder{contentType: "image/webp", out: make([]byte, 50)},
+			wantType:    "image/webp",
+			wantLen:     50,
+		},
+		{
+			name:        "keeps original when variant is larger",
+			accept:      "image/avif",
+			contentType: "image/jpeg",
+			avif:        &fakeTranscoder{contentType: "image/avif", out: make([]byte, 150)},
+			webp:        &fakeTranscoder{contentType: "image/webp", out: make([]byte, 50)},
+			wantType:    "image/jpeg",
+			wantLen:     100,
+		},
+		{
+			name:        "unsupported source passes through",
+			accept:      "image/avif,image/webp",
+			contentType: "image/gif",
+			avif:        &fakeTranscoder{contentType: "image/avif", out: make([]byte, 30)},
+			webp:        &fakeTranscoder{contentType: "image/webp", out: make([]byte, 50)},
+			wantType:    "image/gif",
+			wantLen:     100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewService(nil, nil, nil, time.Second)
+			svc.SetTranscoders(nil, tt.avif, tt.webp)
+
+			out, contentType := svc.Negotiate(context.Background(), tt.accept, original, tt.contentType)
+			if contentType != tt.wantType {
+				t.Errorf("content type = %s, want %s", contentType, tt.wantType)
+			}
+			if len(out) != tt.wantLen {
+				t.Errorf("len = %d, want %d", len(out), tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestServiceNegotiateCachesVariants(t *testing.T) {
+	original := bytes.Repeat([]byte{0x01}, 100)
+	good := &fakeTranscoder{contentType: "image/webp", out: make([]byte, 40)}
+	bad := &fakeTranscoder{contentType: "image/avif", err: errors.New("boom")}
+
+	variants := cache.NewMemory(time.Minute)
+	defer variants.Stop()
+
+	svc := NewService(nil, nil, nil, time.Second)
+	svc.SetTranscoders(variants, bad, good)
+
+	for i := 0; i < 3; i++ {
+		_, contentType := svc.Negotiate(context.Background(), "image/avif,image/webp", original, "image/jpeg")
+		if contentType != "image/webp" {
+			t.Fatalf("content type = %s, want image/webp", contentType)
+		}
+	}
+	if good.calls != 1 {
+		t.Errorf("webp transcodes = %d, want 1", good.calls)
+	}
+	if bad.calls != 1 {
+		t.Errorf("failed avif transcodes = %d, want 1 (failure should be cached)", bad.calls)
+	}
+}
+
+func TestNilServiceNegotiate(t *testing.T) {
+	var svc *Service
+	out, contentType := svc.Negotiate(context.Background(), "image/webp", []byte("abc"), "image/jpeg")
+	if string(out) != "abc" || contentType != "image/jpeg" {
+		t.Errorf("Negotiate() = %q, %s; want passthrough", out, contentType)
+	}
+}
+
+func TestCommandTranscoder(t *testing.T) {
+	if _, err := exec.LookPath("cp"); err != nil {
+		t.Skip("cp not available")
+	}
+
+	if _, err := NewCommandTranscoder("image/webp", "cp {in}", time.Second, 1); err == nil {
+		t.Error("expected error for command without {out}")
+	}
+	if _, err := NewCommandTranscoder("image/webp", "definitely-not-an-encoder {in} {out}", time.Second, 1); err == nil {
+		t.Error("expected error for missing binary")
+	}
+
+	transcoder, err := NewCommandTranscoder("image/webp", "cp {in} {out}", time.Second, 1)
+	if err != nil {
+		t.Fatalf("NewCommandTranscoder() error = %v", err)
+	}
+	out, err := transcoder.Transcode(context.Background(), []byte("image-bytes"))
+	if err != nil {
+		t.Fatalf("Transcode() error = %v", err)
+	}
+	if string(out) != "image-bytes" {
+		t.Errorf("Transcode() = %q, want image-bytes", out)
+	}
+}
@@ -0,0 +1,415 @@
+package imagesourcing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/equipment"
+	"github.com/johnrirwin/flyingforge/internal/images"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/ratelimit"
+	"github.com/johnrirwin/flyingforge/internal/safehttp"
+)
+
+const (
+	maxCandidatesPerSearch = 5
+	maxPageURLs            = 5
+	maxSellerResults       = 20
+	maxImageBytes          = 5 * 1024 * 1024
+	maxPageBytes           = 1024 * 1024
+	userAgent              = "FlyingForge-ImageSourcing/1.0"
+)
+
+var (
+	// ErrItemNotFound is returned when the catalog item doesn't exist.
+	ErrItemNotFound = errors.New("gear catalog item not found")
+	// ErrCandidateNotFound is returned when the candidate doesn't exist for the item.
+	ErrCandidateNotFound = errors.New("image candidate not found")
+
+	metaTagPattern  = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	metaAttrPattern = regexp.MustCompile(`(?is)(property|name|content)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+)
+
+// ProductSearcher searches seller listings
+type ProductSearcher interface {
+	Search(ctx context.Context, params models.EquipmentSearchParams) (*models.EquipmentSearchResponse, error)
+}
+
+// CatalogStore loads catalog items and sets their curated image
+type CatalogStore interface {
+	Get(ctx context.Context, id string) (*models.GearCatalogItem, error)
+	SetImage(ctx context.Context, id string, adminUserID string, imageType string, imageAssetID string) (string, error)
+}
+
+// CandidateStore persists image candidates
+type CandidateStore interface {
+	Create(ctx context.Context, createdBy string, candidate models.GearImageCandidate) (*models.GearImageCandidate, error)
+	Get(ctx context.Context, id string) (*models.GearImageCandidate, error)
+	ListByGear(ctx context.Context, gearID string) ([]models.GearImageCandidate, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// ImageStore moderates and stores downloaded images
+type ImageStore interface {
+	ModerateAndPersist(ctx context.Context, req images.SaveRequest) (*models.ModerationDecision, *models.ImageAsset, error)
+	Delete(ctx context.Context, imageID string) error
+}
+
+// Service finds product images for catalog items that are missing one. It
+// searches seller listings and admin-supplied manufacturer pages, downloads
+// the images, and keeps those that pass moderation as candidates for an
+// admin to approve.
+type Service struct {
+	searcher   ProductSearcher
+	catalog    CatalogStore
+	candidates CandidateStore
+	images     ImageStore
+	limiter    *ratelimit.Limiter
+	client     *http.Client
+	logger     *logging.Logger
+}
+
+// NewService creates a new image sourcing service
+func NewService(equipmentSvc *equipment.Service, catalogStore *database.GearCatalogStore, candidateStore *database.GearImageCandidateStore, imageSvc *images.Service, limiter *ratelimit.Limiter, logger *logging.Logger) *Service {
+	return &Service{
+		searcher:   equipmentSvc,
+		catalog:    catalogStore,
+		candidates: candidateStore,
+		images:     imageSvc,
+		limiter:    limiter,
+		client:     safehttp.NewClient(20 * time.Second),
+		logger:     logger,
+	}
+}
+
+// source is an image URL found for a catalog item
+type source struct {
+	name     string
+	imageURL string
+	pageURL  string
+}
+
+// Find searches for images of a catalog item and stores the ones that pass
+// moderation as candidates.
+func (s *Service) Find(ctx context.Context, adminUserID, gearID string, params models.FindGearImagesParams) (*models.FindGearImagesResponse, error) {
+	item, err := s.catalog.Get(ctx, gearID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrItemNotFound
+	}
+	if item.ImageStatus == models.ImageStatusApproved {
+		return nil, &ServiceError{Message: "catalog item already has an approved image"}
+	}
+
+	pageURLs, err := validatePageURLs(params.PageURLs)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.candidates.ListByGear(ctx, gearID)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(existing))
+	for _, candidate := range existing {
+		seen[candidate.SourceURL] = true
+	}
+
+	sources := append(s.manufacturerSources(ctx, pageURLs), s.sellerSources(ctx, item)...)
+
+	response := &models.FindGearImagesResponse{}
+	for _, src := range sources {
+		if response.Added >= maxCandidatesPerSearch {
+			break
+		}
+		if seen[src.imageURL] {
+			continue
+		}
+		seen[src.imageURL] = true
+
+		added, err := s.addCandidate(ctx, adminUserID, gearID, src)
+		if err != nil {
+			var quotaErr *images.QuotaExceededError
+			if errors.As(err, &quotaErr) {
+				return nil, &ServiceError{Message: quotaErr.Error()}
+			}
+			response.Failed++
+			s.logger.Debug("Image candidate skipped", logging.WithFields(map[string]interface{}{
+				"gearId": gearID,
+				"url":    src.imageURL,
+				"error":  err.Error(),
+			}))
+			continue
+		}
+		if added {
+			response.Added++
+		} else {
+			response.Rejected++
+		}
+	}
+
+	candidates, err := s.candidates.ListByGear(ctx, gearID)
+	if err != nil {
+		return nil, err
+	}
+	response.Candidates = candidates
+	return response, nil
+}
+
+// List returns the pending candidates for a catalog item
+func (s *Service) List(ctx context.Context, gearID string) ([]models.GearImageCandidate, error) {
+	return s.candidates.ListByGear(ctx, gearID)
+}
+
+// Approve makes a candidate the catalog item's approved image and discards
+// the item's other candidates.
+func (s *Service) Approve(ctx context.Context, adminUserID, gearID, candidateID string) error {
+	candidate, err := s.candidate(ctx, gearID, candidateID)
+	if err != nil {
+		return err
+	}
+
+	previousAssetID, err := s.catalog.SetImage(ctx, gearID, adminUserID, candidate.ImageType, candidate.ImageAssetID)
+	if err != nil {
+		return err
+	}
+	if err := s.candidates.Delete(ctx, candidate.ID); err != nil {
+		return err
+	}
+	if previousAssetID != "" && previousAssetID != candidate.ImageAssetID {
+		_ = s.images.Delete(ctx, previousAssetID)
+	}
+
+	others, err := s.candidates.ListByGear(ctx, gearID)
+	if err != nil {
+		return err
+	}
+	for _, other := range others {
+		// Deleting the asset removes the candidate row with it.
+		if err := s.images.Delete(ctx, other.ImageAssetID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reject discards a candidate and its stored image
+func (s *Service) Reject(ctx context.Context, gearID, candidateID string) error {
+	candidate, err := s.candidate(ctx, gearID, candidateID)
+	if err != nil {
+		return err
+	}
+	return s.images.Delete(ctx, candidate.ImageAssetID)
+}
+
+func (s *Service) candidate(ctx context.Context, gearID, candidateID string) (*models.GearImageCandidate, error) {
+	if _, err := uuid.Parse(candidateID); err != nil {
+		return nil, ErrCandidateNotFound
+	}
+	candidate, err := s.candidates.Get(ctx, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	if candidate == nil || candidate.GearID != gearID {
+		return nil, ErrCandidateNotFound
+	}
+	return candidate, nil
+}
+
+// sellerSources searches sellers for the item and returns the images of
+// listings whose name contains the item's model.
+func (s *Service) sellerSources(ctx context.Context, item *models.GearCatalogItem) []source {
+	result, err := s.searcher.Search(ctx, models.EquipmentSearchParams{
+		Query: item.DisplayName(),
+		Limit: maxSellerResults,
+	})
+	if err != nil {
+		s.logger.Warn("Seller search for catalog image failed", logging.WithFields(map[string]interface{}{
+			"gearId": item.ID,
+			"error":  err.Error(),
+		}))
+		return nil
+	}
+
+	model := strings.ToLower(strings.TrimSpace(item.Model))
+	sources := make([]source, 0)
+	for _, listing := range result.Items {
+		if listing.ImageURL == "" || !strings.Contains(strings.ToLower(listing.Name), model) {
+			continue
+		}
+		sources = append(sources, source{name: listing.Seller, imageURL: listing.ImageURL, pageURL: listing.ProductURL})
+	}
+	return sources
+}
+
+// manufacturerSources reads the og:image of each product page
+func (s *Service) manufacturerSources(ctx context.Context, pageURLs []*url.URL) []source {
+	sources := make([]source, 0, len(pageURLs))
+	for _, pageURL := range pageURLs {
+		page, err := s.fetch(ctx, pageURL, maxPageBytes)
+		if err != nil {
+			s.logger.Warn("Failed to fetch manufacturer page", logging.WithFields(map[string]interface{}{
+				"url":   pageURL.String(),
+				"error": err.Error(),
+			}))
+			continue
+		}
+		imageURL := extractOGImage(page, pageURL)
+		if imageURL == "" {
+			continue
+		}
+		sources = append(sources, source{name: pageURL.Hostname(), imageURL: imageURL, pageURL: pageURL.String()})
+	}
+	return sources
+}
+
+// addCandidate downloads, moderates, and stores one image. Returns false if
+// the image failed moderation.
+func (s *Service) addCandidate(ctx context.Context, adminUserID, gearID string, src source) (bool, error) {
+	imageURL, err := url.Parse(src.imageURL)
+	if err != nil || (imageURL.Scheme != "http" && imageURL.Scheme != "https") {
+		return false, fmt.Errorf("invalid image URL")
+	}
+
+	imageBytes, err := s.fetch(ctx, imageURL, maxImageBytes)
+	if err != nil {
+		return false, err
+	}
+	if !isSupportedImage(imageBytes) {
+		return false, fmt.Errorf("image must be JPEG or PNG")
+	}
+
+	decision, asset, err := s.images.ModerateAndPersist(ctx, images.SaveRequest{
+		OwnerUserID: adminUserID,
+		EntityType:  models.ImageEntityGear,
+		EntityID:    gearID,
+		ImageBytes:  imageBytes,
+	})
+	if err != nil {
+		return false, err
+	}
+	if decision.Status != models.ImageModerationApproved {
+		if decision.Status == models.ImageModerationPendingReview {
+			return false, fmt.Errorf("moderation unavailable")
+		}
+		return false, nil
+	}
+
+	candidate, err := s.candidates.Create(ctx, adminUserID, models.GearImageCandidate{
+		GearID:       gearID,
+		ImageAssetID: asset.ID,
+		ImageType:    http.DetectContentType(asset.ImageBytes),
+		SourceName:   src.name,
+		SourceURL:    src.imageURL,
+		PageURL:      src.pageURL,
+	})
+	if err != nil || candidate == nil {
+		_ = s.images.Delete(ctx, asset.ID)
+		if err == nil {
+			err = fmt.Errorf("candidate already exists")
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// fetch downloads up to maxBytes from u, waiting on the per-host rate limit
+func (s *Service) fetch(ctx context.Context, u *url.URL, maxBytes int64) ([]byte, error) {
+	if s.limiter != nil {
+		s.limiter.Wait(u.Hostname())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", u.Hostname(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if int64(len(body)) > maxBytes {
+		return nil, fmt.Errorf("response larger than %d bytes", maxBytes)
+	}
+	return body, nil
+}
+
+func validatePageURLs(raw []string) ([]*url.URL, error) {
+	if len(raw) > maxPageURLs {
+		return nil, &ServiceError{Message: fmt.Sprintf("at most %d pageUrls are allowed", maxPageURLs)}
+	}
+
+	pageURLs := make([]*url.URL, 0, len(raw))
+	for _, value := range raw {
+		u, err := url.Parse(strings.TrimSpace(value))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+			return nil, &ServiceError{Message: "pageUrls must be absolute http(s) URLs"}
+		}
+		pageURLs = append(pageURLs, u)
+	}
+	return pageURLs, nil
+}
+
+// extractOGImage returns the absolute og:image URL of an HTML page, if any
+func extractOGImage(page []byte, pageURL *url.URL) string {
+	for _, tag := range metaTagPattern.FindAll(page, -1) {
+		var property, content string
+		for _, attr := range metaAttrPattern.FindAllSubmatch(tag, -1) {
+			value := string(attr[2]) + string(attr[3])
+			switch strings.ToLower(string(attr[1])) {
+			case "property", "name":
+				property = strings.ToLower(strings.TrimSpace(value))
+			case "content":
+				content = strings.TrimSpace(value)
+			}
+		}
+		if (property != "og:image" && property != "og:image:url") || content == "" {
+			continue
+		}
+
+		imageURL, err := pageURL.Parse(content)
+		if err != nil {
+			return ""
+		}
+		return imageURL.String()
+	}
+	return ""
+}
+
+func isSupportedImage(data []byte) bool {
+	contentType := http.DetectContentType(data)
+	return contentType == "image/jpeg" || contentType == "image/png"
+}
+
+// ServiceError represents an image sourcing request error that should be shown to the client
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,276 @@
+package imagesourcing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/images"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+type mockSearcher struct {
+	items []models.EquipmentItem
+}
+
+func (m *mockSearcher) Search(ctx context.Context, params models.EquipmentSearchParams) (*models.EquipmentSearchResponse, error) {
+	return &models.EquipmentSearchResponse{Items: m.items}, nil
+}
+
+type mockCatalog struct {
+	item     *models.GearCatalogItem
+	imageSet string
+}
+
+func (m *mockCatalog) Get(ctx context.Context, id string) (*models.GearCatalogItem, error) {
+	if m.item == nil || m.item.ID != id {
+		return nil, nil
+	}
+	return m.item, nil
+}
+
+func (m *mockCatalog) SetImage(ctx context.Context, id string, adminUserID string, imageType string, imageAssetID string) (string, error) {
+	m.imageSet = imageAssetID
+	return "", nil
+}
+
+type mockCandidates struct {
+	byID map[string]models.GearImageCandidate
+	next int
+}
+
+func (m *mockCandidates) Create(ctx context.Context, createdBy string, candidate models.GearImageCandidate) (*models.GearImageCandidate, error) {
+	m.next++
+	candidate.ID = fmt.Sprintf("00000000-0000-0000-0000-%012d", m.next)
+	m.byID[candidate.ID] = candidate
+	return &candidate, nil
+}
+
+func (m *mockCandidates) Get(ctx context.Context, id string) (*models.GearImageCandidate, error) {
+	candidate, ok := m.byID[id]
+	if !ok {
+		return nil, nil
+	}
+	return &candidate, nil
+}
+
+func (m *mockCandidates) ListByGear(ctx context.Context, gearID string) ([]models.GearImageCandidate, error) {
+	candidates := make([]models.GearImageCandidate, 0)
+	for i := 1; i <= m.next; i++ {
+		if candidate, ok := m.byID[fmt.Sprintf("00000000-0000-0000-0000-%012d", i)]; ok && candidate.GearID == gearID {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates, nil
+}
+
+func (m *mockCandidates) Delete(ctx context.Context, id string) error {
+	delete(m.byID, id)
+	return nil
+}
+
+// mockImages approves every image except those in reject and cascades asset
+// deletes to candidates like the database does
+type mockImages struct {
+	candidates *mockCandidates
+	reject     map[string]bool // image bytes to reject
+	next       int
+	deleted    []string
+}
+
+func (m *mockImages) ModerateAndPersist(ctx context.Context, req images.SaveRequest) (*models.ModerationDecision, *models.ImageAsset, error) {
+	if m.reject[string(req.ImageBytes)] {
+		return &models.ModerationDecision{Status: models.ImageModerationRejected}, nil, nil
+	}
+	m.next++
+	return &models.ModerationDecision{Status: models.ImageModerationApproved},
+		&models.ImageAsset{ID: fmt.Sprintf("asset-%d", m.next), ImageBytes: req.ImageBytes}, nil
+}
+
+func (m *mockImages) Delete(ctx context.Context, imageID string) error {
+	m.deleted = append(m.deleted, imageID)
+	for id, candidate := range m.candidates.byID {
+		if candidate.ImageAssetID == imageID {
+			delete(m.candidates.byID, id)
+		}
+	}
+	return nil
+}
+
+func testPNG(t *testing.T, size int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, size, size))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func newTestService(t *testing.T, item *models.GearCatalogItem, listings []models.EquipmentItem) (*Service, *mockCatalog, *mockCandidates, *mockImages) {
+	t.Helper()
+	catalog := &mockCatalog{item: item}
+	candidates := &mockCandidates{byID: make(map[string]models.GearImageCandidate)}
+	imageStore := &mockImages{candidates: candidates, reject: make(map[string]bool)}
+	svc := &Service{
+		searcher:   &mockSearcher{items: listings},
+		catalog:    catalog,
+		candidates: candidates,
+		images:     imageStore,
+		client:     http.DefaultClient,
+		logger:     testutil.NullLogger(),
+	}
+	return svc, catalog, candidates, imageStore
+}
+
+func TestFind(t *testing.T) {
+	good, flagged := testPNG(t, 2), testPNG(t, 3)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/good.png", func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write(good) })
+	mux.HandleFunc("/flagged.png", func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write(flagged) })
+	mux.HandleFunc("/page.html", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><head><meta content="/good.png" property="og:image"></head></html>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	item := &models.GearCatalogItem{ID: "gear-1", Brand: "T-Motor", Model: "F60 Pro", ImageStatus: models.ImageStatusMissing}
+	listings := []models.EquipmentItem{
+		{Name: "T-Motor F60 Pro V 2550KV", Seller: "GetFPV", ImageURL: server.URL + "/good.png"}, // same image as the page
+		{Name: "T-Motor F60 PRO 1950KV", Seller: "RaceDayQuads", ImageURL: server.URL + "/flagged.png"},
+		{Name: "T-Motor F40 Pro", Seller: "GetFPV", ImageURL: server.URL + "/other.png"}, // different model
+		{Name: "T-Motor F60 Pro Bundle", Seller: "GetFPV", ImageURL: server.URL + "/missing.png"},
+	}
+	svc, _, _, imageStore := newTestService(t, item, listings)
+	imageStore.reject[string(flagged)] = true
+
+	response, err := svc.Find(context.Background(), "admin-1", "gear-1", models.FindGearImagesParams{
+		PageURLs: []string{server.URL + "/page.html"},
+	})
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if response.Added != 1 || response.Rejected != 1 || response.Failed != 1 {
+		t.Errorf("added=%d rejected=%d failed=%d, want 1, 1, 1", response.Added, response.Rejected, response.Failed)
+	}
+	if len(response.Candidates) != 1 {
+		t.Fatalf("candidates = %d, want 1", len(response.Candidates))
+	}
+	candidate := response.Candidates[0]
+	serverURL, _ := url.Parse(server.URL)
+	if candidate.SourceName != serverURL.Hostname() || candidate.PageURL != server.URL+"/page.html" || candidate.ImageType != "image/png" {
+		t.Errorf("candidate = %+v, want manufacturer page source", candidate)
+	}
+}
+
+func TestFind_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		item    *models.GearCatalogItem
+		params  models.FindGearImagesParams
+		wantErr error
+	}{
+		{
+			name:    "unknown item",
+			wantErr: ErrItemNotFound,
+		},
+		{
+			name: "already approved",
+			item: &models.GearCatalogItem{ID: "gear-1", ImageStatus: models.ImageStatusApproved},
+		},
+		{
+			name:   "relative page URL",
+			item:   &models.GearCatalogItem{ID: "gear-1", ImageStatus: models.ImageStatusMissing},
+			params: models.FindGearImagesParams{PageURLs: []string{"/products/f60"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _, _ := newTestService(t, tt.item, nil)
+
+			_, err := svc.Find(context.Background(), "admin-1", "gear-1", tt.params)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Find() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			var svcErr *ServiceError
+			if !errors.As(err, &svcErr) {
+				t.Fatalf("Find() error = %v, want ServiceError", err)
+			}
+		})
+	}
+}
+
+func TestApprove(t *testing.T) {
+	svc, catalog, candidates, imageStore := newTestService(t, &models.GearCatalogItem{ID: "gear-1"}, nil)
+	ctx := context.Background()
+	chosen, _ := candidates.Create(ctx, "admin-1", models.GearImageCandidate{GearID: "gear-1", ImageAssetID: "asset-1"})
+	_, _ = candidates.Create(ctx, "admin-1", models.GearImageCandidate{GearID: "gear-1", ImageAssetID: "asset-2"})
+	_, _ = candidates.Create(ctx, "admin-1", models.GearImageCandidate{GearID: "gear-2", ImageAssetID: "asset-3"})
+
+	if err := svc.Approve(ctx, "admin-1", "gear-2", chosen.ID); !errors.Is(err, ErrCandidateNotFound) {
+		t.Fatalf("Approve() for another item error = %v, want ErrCandidateNotFound", err)
+	}
+	if err := svc.Approve(ctx, "admin-1", "gear-1", chosen.ID); err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+
+	if catalog.imageSet != "asset-1" {
+		t.Errorf("catalog image = %q, want asset-1", catalog.imageSet)
+	}
+	if len(imageStore.deleted) != 1 || imageStore.deleted[0] != "asset-2" {
+		t.Errorf("deleted assets = %v, want only the other candidate's asset-2", imageStore.deleted)
+	}
+	if remaining, _ := candidates.ListByGear(ctx, "gear-1"); len(remaining) != 0 {
+		t.Errorf("gear-1 candidates = %d, want none after approval", len(remaining))
+	}
+}
+
+func TestExtractOGImage(t *testing.T) {
+	pageURL, _ := url.Parse("https://example.com/products/f60")
+
+	tests := []struct {
+		name string
+		page string
+		want string
+	}{
+		{
+			name: "absolute",
+			page: `<meta property="og:image" content="https://cdn.example.com/f60.jpg" />`,
+			want: "https://cdn.example.com/f60.jpg",
+		},
+		{
+			name: "relative with single quotes",
+			page: `<META name='og:image' content='/img/f60.png'>`,
+			want: "https://example.com/img/f60.png",
+		},
+		{
+			name: "skips other meta tags",
+			page: `<meta property="og:title" content="F60"><meta property="og:image:url" content="f60.jpg">`,
+			want: "https://example.com/products/f60.jpg",
+		},
+		{
+			name: "missing",
+			page: `<html><img src="/f60.jpg"></html>`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractOGImage([]byte(tt.page), pageURL); got != tt.want {
+				t.Errorf("extractOGImage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
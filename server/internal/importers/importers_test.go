@@ -0,0 +1,276 @@
+package importers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+func TestResolveURL(t *testing.T) {
+	tests := []struct {
+		raw        string
+		wantSource string
+		wantURL    string
+	}{
+		{"https://rotorbuilds.com/build/12345", SourceRotorBuilds, "https://rotorbuilds.com/build/12345"},
+		{"http://www.rotorbuilds.com/build/42/some-slug?x=1", SourceRotorBuilds, "https://rotorbuilds.com/build/42"},
+		{"https://docs.google.com/spreadsheets/d/abc_DEF-1/edit#gid=778", SourceSheets, "https://docs.google.com/spreadsheets/d/abc_DEF-1/export?format=csv&gid=778"},
+		{"https://docs.google.com/spreadsheets/d/abc/edit?usp=sharing", SourceSheets, "https://docs.google.com/spreadsheets/d/abc/export?format=csv"},
+	}
+	for _, tt := range tests {
+		source, fetchURL, err := resolveURL(tt.raw)
+		if err != nil || source != tt.wantSource || fetchURL != tt.wantURL {
+			t.Errorf("resolveURL(%q) = %q, %q, %v; want %q, %q", tt.raw, source, fetchURL, err, tt.wantSource, tt.wantURL)
+		}
+	}
+
+	for _, raw := range []string{
+		"ftp://rotorbuilds.com/build/1",
+		"https://rotorbuilds.com/user/1",
+		"https://docs.google.com/document/d/abc",
+		"https://example.com/build/1",
+		"not a url",
+	} {
+		var svcErr *ServiceError
+		if _, _, err := resolveURL(raw); !errors.As(err, &svcErr) {
+			t.Errorf("resolveURL(%q) error = %v, want a ServiceError", raw, err)
+		}
+	}
+}
+
+func TestGearTypeForLabel(t *testing.T) {
+	tests := map[string]models.GearType{
+		"Motors":             models.GearTypeMotor,
+		"Flight Controller:": models.GearTypeFC,
+		"ESC":                models.GearTypeESC,
+		"Props":              models.GearTypeProp,
+		"Air Unit":           models.GearTypeHDUnit,
+		"FPV Camera":         models.GearTypeCamera,
+		"rx":                 models.GearTypeReceiver,
+	}
+	for label, want := range tests {
+		if got, ok := gearTypeForLabel(label); !ok || got != want {
+			t.Errorf("gearTypeForLabel(%q) = %q, %v; want %q", label, got, ok, want)
+		}
+	}
+	if _, ok := gearTypeForLabel("T-Motor F60 Pro IV"); ok {
+		t.Error("a part name should not read as a category")
+	}
+}
+
+func TestParseSheetCSV(t *testing.T) {
+	csv := "My 5in build,,,\n" +
+		"Category,Brand,Part,Qty\n" +
+		"Motors,T-Motor,F60 Pro IV 1950KV,4\n" +
+		"Flight Controller,SpeedyBee,SpeedyBee F405 V4,1\n" +
+		"Frame,,\"Five33 Switchback\",\n" +
+		",,,\n" +
+		"Total,,,\n"
+
+	lines, err := parseSheetCSV([]byte(csv))
+	if err != nil {
+		t.Fatalf("parseSheetCSV() error = %v", err)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("lines = %+v", lines)
+	}
+	if lines[0].gearType != models.GearTypeMotor || lines[0].name != "T-Motor F60 Pro IV 1950KV" || lines[0].quantity != 4 {
+		t.Errorf("motor line = %+v", lines[0])
+	}
+	// The brand is already in the name, so it isn't added twice
+	if lines[1].name != "SpeedyBee F405 V4" || lines[1].gearType != models.GearTypeFC {
+		t.Errorf("fc line = %+v", lines[1])
+	}
+	if lines[2].gearType != models.GearTypeFrame || lines[2].quantity != 1 {
+		t.Errorf("frame line = %+v", lines[2])
+	}
+
+	// Without a header the first column is the category
+	lines, err = parseSheetCSV([]byte("VTX,Rush Tank Solo\nBattery,CNHL 1300 6S\n"))
+	if err != nil || len(lines) != 2 || lines[0].gearType != models.GearTypeVTX || lines[1].name != "CNHL 1300 6S" {
+		t.Errorf("headerless lines = %+v, err = %v", lines, err)
+	}
+}
+
+const rotorBuildsPage = `<html><head>
+<meta property="og:title" content="Freestyle Ripper &amp; Co - RotorBuilds">
+<title>ignored</title>
+<script>var motors = "x";</script>
+</head><body>
+<div class="part"><div class="type">Motors</div><a href="/p/1">T-Motor F60 Pro IV 1950KV</a><span>x4</span><span>$27.99</span></div>
+<div class="part"><div class="type">Frame</div><a href="/p/2">Five33 Switchback</a><span>Buy</span></div>
+<div class="part"><div class="type">Antenna</div><a href="/p/3">Lumenier AXII 2</a><span>2x</span></div>
+</body></html>`
+
+func TestParseRotorBuildsHTML(t *testing.T) {
+	title, lines := parseRotorBuildsHTML([]byte(rotorBuildsPage))
+	if title != "Freestyle Ripper & Co" {
+		t.Errorf("title = %q", title)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("lines = %+v", lines)
+	}
+	if lines[0].gearType != models.GearTypeMotor || lines[0].name != "T-Motor F60 Pro IV 1950KV" || lines[0].quantity != 4 {
+		t.Errorf("motor line = %+v", lines[0])
+	}
+	if lines[1].gearType != models.GearTypeFrame || lines[1].quantity != 1 {
+		t.Errorf("frame line = %+v", lines[1])
+	}
+	if lines[2].gearType != models.GearTypeAntenna || lines[2].quantity != 2 {
+		t.Errorf("antenna line = %+v", lines[2])
+	}
+}
+
+func TestMatchScore(t *testing.T) {
+	exact := &models.GearCatalogItem{Brand: "T-Motor", Model: "F60 Pro IV", Variant: "1950KV"}
+	other := &models.GearCatalogItem{Brand: "T-Motor", Model: "F60 Pro IV", Variant: "2550KV"}
+	specs := &models.GearCatalogItem{Brand: "T-Motor", Model: "F60 Pro IV", Specs: json.RawMessage(`{"kv": "1950kv"}`)}
+
+	name := "T-Motor F60 Pro IV 1950KV"
+	if got := matchScore(name, exact); got != 1 {
+		t.Errorf("exact score = %v, want 1", got)
+	}
+	if matchScore(name, other) >= matchScore(name, specs) {
+		t.Errorf("wrong KV should score below a spec match: %v >= %v", matchScore(name, other), matchScore(name, specs))
+	}
+	if got := matchScore("", exact); got != 0 {
+		t.Errorf("empty name score = %v", got)
+	}
+
+	ranked := rankCandidates(name, []models.GearCatalogItem{*other, *exact})
+	if len(ranked) != 2 || ranked[0].Item.Variant != "1950KV" || !confident(ranked) {
+		t.Errorf("ranked = %+v", ranked)
+	}
+	if confident([]models.NearMatch{{Similarity: 0.8}, {Similarity: 0.75}}) {
+		t.Error("two close matches should not be confident")
+	}
+}
+
+type mockCatalog struct {
+	items  []models.GearCatalogItem
+	params []models.GearCatalogSearchParams
+}
+
+func (m *mockCatalog) Search(ctx context.Context, params models.GearCatalogSearchParams) (*models.GearCatalogSearchResponse, error) {
+	m.params = append(m.params, params)
+	var items []models.GearCatalogItem
+	for _, item := range m.items {
+		if params.GearType == "" || item.GearType == params.GearType {
+			items = append(items, item)
+		}
+	}
+	return &models.GearCatalogSearchResponse{Items: items, TotalCount: len(items)}, nil
+}
+
+type mockDrafts struct {
+	params models.CreateBuildParams
+}
+
+func (m *mockDrafts) CreateDraft(ctx context.Context, ownerUserID string, params models.CreateBuildParams) (*models.Build, error) {
+	m.params = params
+	return &models.Build{ID: "build-1", OwnerUserID: ownerUserID, Title: params.Title, Status: models.BuildStatusDraft}, nil
+}
+
+// rewriteTransport sends every request to a test server
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newTestService(t *testing.T, handler http.HandlerFunc, catalog *mockCatalog, drafts *mockDrafts) *Service {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	target, _ := url.Parse(server.URL)
+
+	client := newClient()
+	client.Transport = rewriteTransport{target: target}
+	return &Service{catalog: catalog, builds: drafts, client: client, logger: testutil.NullLogger()}
+}
+
+func TestImport_RotorBuilds(t *testing.T) {
+	catalog := &mockCatalog{items: []models.GearCatalogItem{
+		{ID: "motor-1950", GearType: models.GearTypeMotor, Brand: "T-Motor", Model: "F60 Pro IV", Variant: "1950KV"},
+		{ID: "motor-2550", GearType: models.GearTypeMotor, Brand: "T-Motor", Model: "F60 Pro IV", Variant: "2550KV"},
+		{ID: "frame-1", GearType: models.GearTypeFrame, Brand: "Five33", Model: "Switchback"},
+	}}
+	drafts := &mockDrafts{}
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/build/77" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(rotorBuildsPage))
+	}, catalog, drafts)
+
+	resp, err := svc.Import(context.Background(), "user-1", "https://rotorbuilds.com/build/77")
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if resp.Source != SourceRotorBuilds || resp.Build.ID != "build-1" || resp.Build.Title != "Freestyle Ripper & Co" {
+		t.Errorf("response = %+v", resp)
+	}
+	if len(resp.Parts) != 3 {
+		t.Fatalf("parts = %+v", resp.Parts)
+	}
+	if !resp.Parts[0].Resolved || resp.Parts[0].CatalogItemID != "motor-1950" {
+		t.Errorf("motor part = %+v", resp.Parts[0])
+	}
+	if !resp.Parts[1].Resolved || resp.Parts[1].CatalogItemID != "frame-1" {
+		t.Errorf("frame part = %+v", resp.Parts[1])
+	}
+	if resp.Parts[2].Resolved {
+		t.Errorf("antenna should be unresolved: %+v", resp.Parts[2])
+	}
+	if catalog.params[0].GearType != models.GearTypeMotor || catalog.params[0].Status != models.CatalogStatusPublished {
+		t.Errorf("search params = %+v", catalog.params[0])
+	}
+
+	// Four motors plus the frame
+	if len(drafts.params.Parts) != 5 || drafts.params.Parts[3].Position != 3 || drafts.params.Parts[4].Position != 0 {
+		t.Errorf("draft parts = %+v", drafts.params.Parts)
+	}
+	if !strings.Contains(drafts.params.Description, "rotorbuilds.com/build/77") || !strings.Contains(drafts.params.Description, "- Lumenier AXII 2") {
+		t.Errorf("description = %q", drafts.params.Description)
+	}
+}
+
+func TestImport_Errors(t *testing.T) {
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/spreadsheets/d/private/export":
+			http.Redirect(w, r, "https://accounts.google.com/ServiceLogin", http.StatusFound)
+		case "/spreadsheets/d/empty/export":
+			_, _ = w.Write([]byte("Category,Part\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}, &mockCatalog{}, &mockDrafts{})
+
+	tests := map[string]string{
+		"https://docs.google.com/spreadsheets/d/private/edit": "isn't public",
+		"https://docs.google.com/spreadsheets/d/empty/edit":   "no parts",
+		"https://rotorbuilds.com/build/1":                     "wasn't found",
+	}
+	for link, want := range tests {
+		var svcErr *ServiceError
+		_, err := svc.Import(context.Background(), "user-1", link)
+		if !errors.As(err, &svcErr) || !strings.Contains(svcErr.Message, want) {
+			t.Errorf("Import(%q) error = %v, want %q", link, err, want)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package importers
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	// resolveScore is the lowest score a match needs to be taken without
+	// asking the pilot
+	resolveScore = 0.6
+	// resolveLead is how far the best match must lead the next one
+	resolveLead = 0.1
+	// specBonus is added for each spec in the line, such as 1950kv or 6s,
+	// that the catalog item also has
+	specBonus = 0.1
+	// specPenalty is taken for each spec in the item's name the line lacks,
+	// so a 2207 motor doesn't resolve to the 2306 version
+	specPenalty = 0.15
+)
+
+var wordPattern = regexp.MustCompile(`[a-z0-9]+(?:\.[0-9]+)?`)
+
+// tokens splits a part name into lower case words
+func tokens(text string) []string {
+	return wordPattern.FindAllString(strings.ToLower(text), -1)
+}
+
+// isSpecToken reports whether a word carries a number, like 1950kv, 6s or 2207
+func isSpecToken(word string) bool {
+	return strings.ContainsAny(word, "0123456789")
+}
+
+// matchScore rates how well a catalog item fits a line of a parts list, from
+// 0 to 1. Word overlap with the item's name is the base, adjusted for specs
+// that agree or are missing.
+func matchScore(name string, item *models.GearCatalogItem) float64 {
+	lineWords := tokens(name)
+	itemWords := tokens(item.DisplayName())
+	if len(lineWords) == 0 || len(itemWords) == 0 {
+		return 0
+	}
+
+	lineSet := make(map[string]bool, len(lineWords))
+	for _, word := range lineWords {
+		lineSet[word] = true
+	}
+	itemSet := make(map[string]bool, len(itemWords))
+	for _, word := range itemWords {
+		itemSet[word] = true
+	}
+
+	shared := 0
+	for word := range lineSet {
+		if itemSet[word] {
+			shared++
+		}
+	}
+	score := 2 * float64(shared) / float64(len(lineSet)+len(itemSet))
+
+	specText := strings.ToLower(string(item.Specs))
+	for word := range lineSet {
+		if isSpecToken(word) && !itemSet[word] && strings.Contains(specText, word) {
+			score += specBonus
+		}
+	}
+	for word := range itemSet {
+		if isSpecToken(word) && !lineSet[word] {
+			score -= specPenalty
+		}
+	}
+
+	if score < 0 {
+		return 0
+	}
+	if score > 1 {
+		return 1
+	}
+	return score
+}
+
+// rankCandidates scores catalog items against a line, best first
+func rankCandidates(name string, items []models.GearCatalogItem) []models.NearMatch {
+	matches := make([]models.NearMatch, 0, len(items))
+	for i := range items {
+		score := matchScore(name, &items[i])
+		if score == 0 {
+			continue
+		}
+		matches = append(matches, models.NearMatch{Item: items[i], Similarity: score})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Similarity > matches[j].Similarity
+	})
+	return matches
+}
+
+// confident reports whether the best of the ranked matches should be used
+func confident(matches []models.NearMatch) bool {
+	if len(matches) == 0 || matches[0].Similarity < resolveScore {
+		return false
+	}
+	return len(matches) == 1 || matches[0].Similarity-matches[1].Similarity > resolveLead
+}
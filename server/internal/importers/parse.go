@@ -0,0 +1,235 @@
+package importers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// maxLines bounds how many parts one import can match
+const maxLines = 60
+
+// line is one part read from an imported list
+type line struct {
+	gearType models.GearType // empty when the list doesn't say
+	name     string
+	quantity int
+}
+
+// gearTypeAliases are labels parts lists use for each gear type, on top of
+// the gear type labels themselves
+var gearTypeAliases = map[string]models.GearType{
+	"motor":             models.GearTypeMotor,
+	"4in1":              models.GearTypeESC,
+	"4in1 esc":          models.GearTypeESC,
+	"fc":                models.GearTypeFC,
+	"stack":             models.GearTypeFC,
+	"aio board":         models.GearTypeAIO,
+	"video transmitter": models.GearTypeVTX,
+	"rx":                models.GearTypeReceiver,
+	"vtx antenna":       models.GearTypeAntenna,
+	"rx antenna":        models.GearTypeAntenna,
+	"lipo":              models.GearTypeBattery,
+	"props":             models.GearTypeProp,
+	"prop":              models.GearTypeProp,
+	"propeller":         models.GearTypeProp,
+	"transmitter":       models.GearTypeRadio,
+	"fpv camera":        models.GearTypeCamera,
+	"cam":               models.GearTypeCamera,
+	"air unit":          models.GearTypeHDUnit,
+	"digital vtx":       models.GearTypeHDUnit,
+	"gps module":        models.GearTypeGPS,
+	"misc":              models.GearTypeOther,
+	"accessories":       models.GearTypeOther,
+}
+
+var (
+	nonLabelChars = regexp.MustCompile(`[^a-z0-9 ]+`)
+	quantityText  = regexp.MustCompile(`^(?:x\s*(\d+)|(\d+)\s*x|qty:?\s*(\d+))$`)
+	priceText     = regexp.MustCompile(`^[$€£]\s*\d|^\d+([.,]\d{2})?\s*(usd|eur|gbp)?$`)
+
+	scriptBlocks = regexp.MustCompile(`(?is)<(script|style|noscript)[^>]*>.*?</(script|style|noscript)>`)
+	blockTags    = regexp.MustCompile(`(?i)<(br|/?(p|div|li|tr|td|th|h[1-6]|span|a|ul|ol|table|section|article))[^>]*>`)
+	anyTag       = regexp.MustCompile(`<[^>]*>`)
+	titleTag     = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	ogTitle      = regexp.MustCompile(`(?is)<meta[^>]+property=["']og:title["'][^>]+content=["']([^"']*)["']`)
+)
+
+// gearTypeForLabel maps a category label such as "Motors" or "Flight
+// Controller:" to a gear type
+func gearTypeForLabel(label string) (models.GearType, bool) {
+	label = strings.TrimSpace(nonLabelChars.ReplaceAllString(strings.ToLower(label), ""))
+	if label == "" {
+		return "", false
+	}
+	for _, info := range models.GearTypeInfos() {
+		if label == string(info.GearType) || label == strings.ToLower(info.Label) || label == strings.ToLower(info.PluralLabel) {
+			return info.GearType, true
+		}
+	}
+	if gearType, ok := gearTypeAliases[label]; ok {
+		return gearType, true
+	}
+	if gearType, ok := gearTypeAliases[strings.TrimSuffix(label, "s")]; ok {
+		return gearType, true
+	}
+	return "", false
+}
+
+// Sheet header names, lower case
+var (
+	typeHeaders     = []string{"type", "category", "part type", "gear type", "component"}
+	nameHeaders     = []string{"name", "part", "item", "product", "part name", "model", "description"}
+	brandHeaders    = []string{"brand", "manufacturer", "make"}
+	quantityHeaders = []string{"qty", "quantity", "count", "#"}
+)
+
+// parseSheetCSV reads a parts list exported from a spreadsheet. It looks for
+// a header row in the first few rows; without one, the first column is taken
+// as the category and the second as the part.
+func parseSheetCSV(data []byte) ([]line, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, &ServiceError{Message: "the spreadsheet couldn't be read as CSV"}
+	}
+
+	typeCol, nameCol, brandCol, qtyCol := 0, 1, -1, -1
+	start := 0
+	for i := 0; i < len(rows) && i < 10; i++ {
+		cells := lowerCells(rows[i])
+		if name := findColumn(cells, nameHeaders); name >= 0 {
+			nameCol = name
+			typeCol = findColumn(cells, typeHeaders)
+			if typeCol == nameCol {
+				typeCol = -1
+			}
+			brandCol = findColumn(cells, brandHeaders)
+			qtyCol = findColumn(cells, quantityHeaders)
+			start = i + 1
+			break
+		}
+	}
+
+	var lines []line
+	for _, row := range rows[start:] {
+		name := cell(row, nameCol)
+		if brand := cell(row, brandCol); brand != "" && !strings.HasPrefix(strings.ToLower(name), strings.ToLower(brand)) {
+			name = brand + " " + name
+		}
+		name = strings.TrimSpace(name)
+		if name == "" || strings.EqualFold(cell(row, typeCol), "total") || strings.EqualFold(name, "total") {
+			continue
+		}
+
+		gearType, _ := gearTypeForLabel(cell(row, typeCol))
+		quantity := 1
+		if qty, err := strconv.Atoi(cell(row, qtyCol)); err == nil && qty > 0 {
+			quantity = qty
+		}
+		lines = append(lines, line{gearType: gearType, name: name, quantity: quantity})
+		if len(lines) == maxLines {
+			break
+		}
+	}
+	return lines, nil
+}
+
+// parseRotorBuildsHTML reads a RotorBuilds build page. The page lists parts
+// under category headings, so the text is walked line by line: a heading
+// sets the gear type and the next line of text is the part.
+func parseRotorBuildsHTML(page []byte) (string, []line) {
+	text := string(page)
+
+	title := ""
+	if match := ogTitle.FindStringSubmatch(text); match != nil {
+		title = match[1]
+	} else if match := titleTag.FindStringSubmatch(text); match != nil {
+		title = match[1]
+	}
+	title = strings.TrimSpace(html.UnescapeString(title))
+	for _, suffix := range []string{" - RotorBuilds", " | RotorBuilds"} {
+		title = strings.TrimSuffix(title, suffix)
+	}
+
+	text = scriptBlocks.ReplaceAllString(text, "\n")
+	text = blockTags.ReplaceAllString(text, "\n")
+	text = anyTag.ReplaceAllString(text, "")
+
+	var lines []line
+	var current models.GearType
+	for _, raw := range strings.Split(text, "\n") {
+		value := strings.TrimSpace(html.UnescapeString(raw))
+		if value == "" {
+			continue
+		}
+		if gearType, ok := gearTypeForLabel(value); ok {
+			current = gearType
+			continue
+		}
+
+		// A quantity after a part belongs to it
+		lower := strings.ToLower(value)
+		if match := quantityText.FindStringSubmatch(lower); match != nil {
+			if len(lines) > 0 {
+				lines[len(lines)-1].quantity = atoiFirst(match[1:])
+			}
+			continue
+		}
+		if current == "" {
+			continue
+		}
+		if priceText.MatchString(lower) || lower == "buy" || len(value) > 120 {
+			continue
+		}
+
+		lines = append(lines, line{gearType: current, name: value, quantity: 1})
+		current = ""
+		if len(lines) == maxLines {
+			break
+		}
+	}
+	return title, lines
+}
+
+func lowerCells(row []string) []string {
+	cells := make([]string, len(row))
+	for i, value := range row {
+		cells[i] = strings.ToLower(strings.TrimSpace(value))
+	}
+	return cells
+}
+
+func findColumn(cells []string, names []string) int {
+	for _, name := range names {
+		for i, value := range cells {
+			if value == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func cell(row []string, index int) string {
+	if index < 0 || index >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[index])
+}
+
+func atoiFirst(values []string) int {
+	for _, value := range values {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 1
+}
@@ -0,0 +1,180 @@
+package importers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/builds"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	defaultImportTitle = "Imported Build"
+	// candidateLimit is how many catalog items are considered per line
+	candidateLimit = 5
+	// maxQuantity bounds how many copies of one part are added to a build
+	maxQuantity = 8
+)
+
+// CatalogSearcher finds catalog items for a line of a parts list
+type CatalogSearcher interface {
+	Search(ctx context.Context, params models.GearCatalogSearchParams) (*models.GearCatalogSearchResponse, error)
+}
+
+// DraftCreator creates the draft build an import ends up in
+type DraftCreator interface {
+	CreateDraft(ctx context.Context, ownerUserID string, params models.CreateBuildParams) (*models.Build, error)
+}
+
+// Service imports parts lists into draft builds
+type Service struct {
+	catalog CatalogSearcher
+	builds  DraftCreator
+	client  *http.Client
+	logger  *logging.Logger
+}
+
+// NewService creates a new import service
+func NewService(catalogStore *database.GearCatalogStore, buildSvc *builds.Service, logger *logging.Logger) *Service {
+	return &Service{
+		catalog: catalogStore,
+		builds:  buildSvc,
+		client:  newClient(),
+		logger:  logger,
+	}
+}
+
+// Import reads the parts list at rawURL and creates a draft build for the
+// user from it. Parts that match the catalog with confidence are added to the
+// build; the rest are returned unresolved with their best candidates and
+// listed in the build description so the pilot can pick them by hand.
+func (s *Service) Import(ctx context.Context, userID string, rawURL string) (*models.BuildImportResponse, error) {
+	source, fetchURL, err := resolveURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.fetch(ctx, fetchURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var title string
+	var lines []line
+	switch source {
+	case SourceRotorBuilds:
+		title, lines = parseRotorBuildsHTML(page)
+	default:
+		lines, err = parseSheetCSV(page)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(lines) == 0 {
+		return nil, &ServiceError{Message: "no parts were found at that link"}
+	}
+
+	parts := make([]models.ImportedBuildPart, 0, len(lines))
+	var inputs []models.BuildPartInput
+	var unresolved []string
+	positions := make(map[models.GearType]int)
+	for _, l := range lines {
+		part, err := s.matchLine(ctx, l)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+
+		if !part.Resolved {
+			unresolved = append(unresolved, l.name)
+			continue
+		}
+		// Each copy gets its own position so the bill of materials counts it
+		for i := 0; i < min(part.Quantity, maxQuantity); i++ {
+			inputs = append(inputs, models.BuildPartInput{
+				GearType:      part.GearType,
+				CatalogItemID: part.CatalogItemID,
+				Position:      positions[part.GearType],
+			})
+			positions[part.GearType]++
+		}
+	}
+
+	if strings.TrimSpace(title) == "" {
+		title = defaultImportTitle
+	}
+	build, err := s.builds.CreateDraft(ctx, userID, models.CreateBuildParams{
+		Title:       title,
+		Description: importDescription(strings.TrimSpace(rawURL), unresolved),
+		Parts:       inputs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Imported parts list", logging.WithFields(map[string]interface{}{
+		"build_id":   build.ID,
+		"source":     source,
+		"parts":      len(parts),
+		"unresolved": len(unresolved),
+	}))
+
+	return &models.BuildImportResponse{Build: build, Source: source, Parts: parts}, nil
+}
+
+// matchLine searches the catalog for one line, within its gear type when the
+// list gave one
+func (s *Service) matchLine(ctx context.Context, l line) (models.ImportedBuildPart, error) {
+	part := models.ImportedBuildPart{GearType: l.gearType, Name: l.name, Quantity: l.quantity}
+
+	result, err := s.catalog.Search(ctx, models.GearCatalogSearchParams{
+		Query:    l.name,
+		GearType: l.gearType,
+		Status:   models.CatalogStatusPublished,
+		Limit:    candidateLimit,
+	})
+	if err != nil {
+		return part, fmt.Errorf("failed to search catalog: %w", err)
+	}
+	if result == nil {
+		return part, nil
+	}
+
+	matches := rankCandidates(l.name, result.Items)
+	if len(matches) > 0 {
+		part.Confidence = matches[0].Similarity
+	}
+	if confident(matches) {
+		part.Resolved = true
+		part.CatalogItemID = matches[0].Item.ID
+		part.GearType = matches[0].Item.GearType
+		return part, nil
+	}
+	part.Candidates = matches
+	return part, nil
+}
+
+func importDescription(sourceURL string, unresolved []string) string {
+	var b strings.Builder
+	b.WriteString("Imported from " + sourceURL + ".")
+	if len(unresolved) > 0 {
+		b.WriteString("\n\nParts that couldn't be matched to the catalog:")
+		for _, name := range unresolved {
+			b.WriteString("\n- " + name)
+		}
+	}
+	return b.String()
+}
+
+// ServiceError represents a user-facing import error
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
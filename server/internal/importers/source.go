@@ -0,0 +1,132 @@
+// Package importers turns parts lists kept on other sites into draft builds.
+// It reads RotorBuilds build pages and Google Sheets, matches each line to
+// the gear catalog, and flags the lines it couldn't match.
+package importers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Import sources
+const (
+	SourceRotorBuilds = "rotorbuilds"
+	SourceSheets      = "sheets"
+)
+
+const (
+	maxPageBytes = 2 * 1024 * 1024
+	userAgent    = "FlyingForge-Importer/1.0"
+)
+
+var (
+	// errRedirectRefused is returned for redirects away from the import hosts,
+	// which for Sheets means a sign-in page
+	errRedirectRefused = errors.New("redirect away from the import site")
+
+	rotorBuildsPath = regexp.MustCompile(`^/build/(\d+)`)
+	sheetsPath      = regexp.MustCompile(`^/spreadsheets/d/([A-Za-z0-9_-]+)`)
+	sheetGID        = regexp.MustCompile(`(?:^|[&#?])gid=(\d+)`)
+)
+
+// resolveURL works out which site a link points at and the URL to download.
+// Sheets links are turned into their CSV export.
+func resolveURL(raw string) (string, string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", "", &ServiceError{Message: "url must be an http(s) link"}
+	}
+
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "rotorbuilds.com" || host == "www.rotorbuilds.com":
+		match := rotorBuildsPath.FindStringSubmatch(u.Path)
+		if match == nil {
+			return "", "", &ServiceError{Message: "RotorBuilds links must point at a build page"}
+		}
+		return SourceRotorBuilds, "https://rotorbuilds.com/build/" + match[1], nil
+	case host == "docs.google.com":
+		match := sheetsPath.FindStringSubmatch(u.Path)
+		if match == nil {
+			return "", "", &ServiceError{Message: "Google links must point at a spreadsheet"}
+		}
+		export := "https://docs.google.com/spreadsheets/d/" + match[1] + "/export?format=csv"
+		for _, part := range []string{u.Fragment, u.RawQuery} {
+			if gid := sheetGID.FindStringSubmatch(part); gid != nil {
+				export += "&gid=" + gid[1]
+				break
+			}
+		}
+		return SourceSheets, export, nil
+	default:
+		return "", "", &ServiceError{Message: "only RotorBuilds and Google Sheets links can be imported"}
+	}
+}
+
+// allowedHost reports whether the importer may fetch from host. Sheets
+// exports redirect to googleusercontent.com.
+func allowedHost(host string) bool {
+	host = strings.ToLower(host)
+	return host == "rotorbuilds.com" || host == "www.rotorbuilds.com" || host == "docs.google.com" ||
+		strings.HasSuffix(host, ".googleusercontent.com")
+}
+
+// newClient returns an HTTP client that only follows redirects to the hosts
+// imports come from
+func newClient() *http.Client {
+	return &http.Client{
+		Timeout: 20 * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return fmt.Errorf("too many redirects")
+			}
+			if req.URL.Scheme != "https" || !allowedHost(req.URL.Hostname()) {
+				return errRedirectRefused
+			}
+			return nil
+		},
+	}
+}
+
+// fetch downloads a page or export, up to maxPageBytes
+func (s *Service) fetch(ctx context.Context, pageURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := s.client.Do(req)
+	if errors.Is(err, errRedirectRefused) {
+		return nil, &ServiceError{Message: "the page isn't public; share it with anyone who has the link"}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", req.URL.Hostname(), err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, &ServiceError{Message: "the page wasn't found"}
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, &ServiceError{Message: "the page isn't public; share it with anyone who has the link"}
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(body) > maxPageBytes {
+		return nil, &ServiceError{Message: "the page is too large to import"}
+	}
+	return body, nil
+}
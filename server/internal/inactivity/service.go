@@ -0,0 +1,207 @@
+// Package inactivity runs the inactive account cleanup policy: accounts with
+// no activity for a configured number of months are flagged and notified,
+// then optionally disabled or deleted once a grace period passes.
+package inactivity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/push"
+)
+
+// batchSize bounds how many accounts one run flags or acts on; the rest
+// wait for the next run
+const batchSize = 500
+
+// Store defines the inactivity persistence operations
+type Store interface {
+	GetPolicy(ctx context.Context) (*models.InactivityPolicy, error)
+	SavePolicy(ctx context.Context, adminUserID string, policy models.InactivityPolicy) error
+	ListUnflagged(ctx context.Context, cutoff time.Time, exemptPublished bool, limit int) ([]models.InactiveAccount, error)
+	ListGraceExpired(ctx context.Context, cutoff, flaggedBefore time.Time, exemptPublished bool, limit int) ([]models.InactiveAccount, error)
+	Flag(ctx context.Context, userID string, at time.Time) error
+	ClearReturned(ctx context.Context) (int64, error)
+	Disable(ctx context.Context, userID string) error
+	Delete(ctx context.Context, userID string) error
+}
+
+// Service applies the inactive account policy
+type Service struct {
+	store    Store
+	notifier push.Notifier
+	logger   *logging.Logger
+}
+
+// NewService creates a new inactivity service
+func NewService(store *database.InactivityStore, logger *logging.Logger) *Service {
+	return &Service{store: store, logger: logger}
+}
+
+// SetNotifier enables notifying accounts when they're flagged
+func (s *Service) SetNotifier(notifier push.Notifier) {
+	s.notifier = notifier
+}
+
+// Policy returns the saved policy, or the disabled default
+func (s *Service) Policy(ctx context.Context) (*models.InactivityPolicy, error) {
+	policy, err := s.store.GetPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		defaults := models.DefaultInactivityPolicy()
+		return &defaults, nil
+	}
+	return policy, nil
+}
+
+// UpdatePolicy validates and saves the policy
+func (s *Service) UpdatePolicy(ctx context.Context, adminUserID string, policy models.InactivityPolicy) (*models.InactivityPolicy, error) {
+	if policy.InactiveMonths < models.MinInactiveMonths || policy.InactiveMonths > models.MaxInactiveMonths {
+		return nil, &ServiceError{Message: fmt.Sprintf("inactiveMonths must be between %d and %d", models.MinInactiveMonths, models.MaxInactiveMonths)}
+	}
+	if policy.GraceDays < models.MinGraceDays || policy.GraceDays > models.MaxGraceDays {
+		return nil, &ServiceError{Message: fmt.Sprintf("graceDays must be between %d and %d", models.MinGraceDays, models.MaxGraceDays)}
+	}
+	if policy.Action == "" {
+		policy.Action = models.InactivityActionNone
+	}
+	if !models.IsValidInactivityAction(policy.Action) {
+		return nil, &ServiceError{Message: "action must be none, disable, or delete"}
+	}
+
+	if err := s.store.SavePolicy(ctx, adminUserID, policy); err != nil {
+		return nil, err
+	}
+	return s.Policy(ctx)
+}
+
+// Run applies the policy as of now: accounts idle past the policy's limit
+// are flagged and notified, and accounts whose grace period has ended get
+// the policy's action. A dry run reports the same accounts without changing
+// anything, and works while the policy is disabled. A real run with the
+// policy disabled does nothing.
+func (s *Service) Run(ctx context.Context, now time.Time, dryRun bool) (*models.InactivityRunResult, error) {
+	policy, err := s.Policy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.InactivityRunResult{
+		DryRun:   dryRun,
+		RanAt:    now,
+		Action:   policy.Action,
+		Flagged:  []models.InactiveAccount{},
+		Actioned: []models.InactiveAccount{},
+	}
+	if !policy.Enabled && !dryRun {
+		return result, nil
+	}
+
+	cutoff := now.AddDate(0, -policy.InactiveMonths, 0)
+	if !dryRun {
+		if cleared, err := s.store.ClearReturned(ctx); err != nil {
+			return nil, err
+		} else if cleared > 0 {
+			s.logger.Info("Cleared inactivity flags for returning accounts", logging.WithField("count", cleared))
+		}
+	}
+
+	toFlag, err := s.store.ListUnflagged(ctx, cutoff, policy.ExemptPublished, batchSize)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range toFlag {
+		if dryRun {
+			result.Flagged = append(result.Flagged, account)
+			continue
+		}
+		if s.notify(ctx, account, policy) {
+			result.Notified++
+		}
+		if err := s.store.Flag(ctx, account.UserID, now); err != nil {
+			return result, err
+		}
+		flaggedAt := now
+		account.FlaggedAt = &flaggedAt
+		result.Flagged = append(result.Flagged, account)
+	}
+
+	if policy.Action == models.InactivityActionNone {
+		return result, nil
+	}
+	flaggedBefore := now.AddDate(0, 0, -policy.GraceDays)
+	expired, err := s.store.ListGraceExpired(ctx, cutoff, flaggedBefore, policy.ExemptPublished, batchSize)
+	if err != nil {
+		return result, err
+	}
+	for _, account := range expired {
+		if !dryRun {
+			if err := s.apply(ctx, policy.Action, account.UserID); err != nil {
+				s.logger.Warn("Failed to apply inactivity action", logging.WithFields(map[string]interface{}{
+					"user_id": account.UserID,
+					"action":  policy.Action,
+					"error":   err.Error(),
+				}))
+				continue
+			}
+		}
+		result.Actioned = append(result.Actioned, account)
+	}
+	return result, nil
+}
+
+func (s *Service) apply(ctx context.Context, action models.InactivityAction, userID string) error {
+	switch action {
+	case models.InactivityActionDisable:
+		return s.store.Disable(ctx, userID)
+	case models.InactivityActionDelete:
+		return s.store.Delete(ctx, userID)
+	}
+	return nil
+}
+
+// notify tells a flagged account what will happen, reporting whether the
+// notification was delivered
+func (s *Service) notify(ctx context.Context, account models.InactiveAccount, policy *models.InactivityPolicy) bool {
+	if s.notifier == nil {
+		return false
+	}
+	if err := s.notifier.Notify(ctx, account.UserID, inactivityNotification(policy)); err != nil {
+		s.logger.Warn("Failed to send inactivity notice", logging.WithFields(map[string]interface{}{
+			"user_id": account.UserID,
+			"error":   err.Error(),
+		}))
+		return false
+	}
+	return true
+}
+
+func inactivityNotification(policy *models.InactivityPolicy) models.Notification {
+	body := "You haven't signed in for a while. Sign in to keep your account active."
+	switch policy.Action {
+	case models.InactivityActionDisable:
+		body = fmt.Sprintf("You haven't signed in for a while. Sign in within %d days or your account will be disabled.", policy.GraceDays)
+	case models.InactivityActionDelete:
+		body = fmt.Sprintf("You haven't signed in for a while. Sign in within %d days or your account and its data will be deleted.", policy.GraceDays)
+	}
+	return models.Notification{
+		Kind:  models.NotificationAccountInactive,
+		Title: "Your account is inactive",
+		Body:  body,
+	}
+}
+
+// ServiceError represents an inactivity request error that should be shown to the client
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
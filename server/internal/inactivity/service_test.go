@@ -0,0 +1,195 @@
+package inactivity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+// mockStore returns fixed candidate lists and records what the service did
+type mockStore struct {
+	policy     *models.InactivityPolicy
+	unflagged  []models.InactiveAccount
+	expired    []models.InactiveAccount
+	flagged    []string
+	disabled   []string
+	deleted    []string
+	cleared    int
+	cutoff     time.Time
+	graceStart time.Time
+	deleteErr  error
+}
+
+func (m *mockStore) GetPolicy(ctx context.Context) (*models.InactivityPolicy, error) {
+	return m.policy, nil
+}
+
+func (m *mockStore) SavePolicy(ctx context.Context, adminUserID string, policy models.InactivityPolicy) error {
+	policy.UpdatedBy = adminUserID
+	m.policy = &policy
+	return nil
+}
+
+func (m *mockStore) ListUnflagged(ctx context.Context, cutoff time.Time, exemptPublished bool, limit int) ([]models.InactiveAccount, error) {
+	m.cutoff = cutoff
+	return m.unflagged, nil
+}
+
+func (m *mockStore) ListGraceExpired(ctx context.Context, cutoff, flaggedBefore time.Time, exemptPublished bool, limit int) ([]models.InactiveAccount, error) {
+	m.graceStart = flaggedBefore
+	return m.expired, nil
+}
+
+func (m *mockStore) Flag(ctx context.Context, userID string, at time.Time) error {
+	m.flagged = append(m.flagged, userID)
+	return nil
+}
+
+func (m *mockStore) ClearReturned(ctx context.Context) (int64, error) {
+	m.cleared++
+	return 0, nil
+}
+
+func (m *mockStore) Disable(ctx context.Context, userID string) error {
+	m.disabled = append(m.disabled, userID)
+	return nil
+}
+
+func (m *mockStore) Delete(ctx context.Context, userID string) error {
+	if m.deleteErr != nil {
+		return m.deleteErr
+	}
+	m.deleted = append(m.deleted, userID)
+	return nil
+}
+
+type mockNotifier struct {
+	sent []models.Notification
+}
+
+func (m *mockNotifier) Notify(ctx context.Context, userID string, n models.Notification) error {
+	m.sent = append(m.sent, n)
+	return nil
+}
+
+var now = time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func enabledPolicy(action models.InactivityAction) *models.InactivityPolicy {
+	return &models.InactivityPolicy{Enabled: true, InactiveMonths: 12, GraceDays: 30, Action: action, ExemptPublished: true}
+}
+
+func TestService_UpdatePolicy_Validation(t *testing.T) {
+	svc := &Service{store: &mockStore{}, logger: testutil.NullLogger()}
+	tests := []struct {
+		name   string
+		policy models.InactivityPolicy
+	}{
+		{"months too short", models.InactivityPolicy{InactiveMonths: 1, GraceDays: 30}},
+		{"grace too long", models.InactivityPolicy{InactiveMonths: 12, GraceDays: 1000}},
+		{"unknown action", models.InactivityPolicy{InactiveMonths: 12, GraceDays: 30, Action: "archive"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.UpdatePolicy(context.Background(), "admin-1", tt.policy)
+			var svcErr *ServiceError
+			if !errors.As(err, &svcErr) {
+				t.Errorf("UpdatePolicy() error = %v, want a ServiceError", err)
+			}
+		})
+	}
+
+	policy, err := svc.UpdatePolicy(context.Background(), "admin-1", models.InactivityPolicy{InactiveMonths: 12, GraceDays: 30})
+	if err != nil {
+		t.Fatalf("UpdatePolicy() error = %v", err)
+	}
+	if policy.Action != models.InactivityActionNone || policy.UpdatedBy != "admin-1" {
+		t.Errorf("policy = %+v, want action none saved by admin-1", policy)
+	}
+}
+
+func TestService_Run_DisabledPolicyDoesNothing(t *testing.T) {
+	store := &mockStore{unflagged: []models.InactiveAccount{{UserID: "u1"}}}
+	svc := &Service{store: store, logger: testutil.NullLogger()}
+
+	result, err := svc.Run(context.Background(), now, false)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(result.Flagged) != 0 || len(store.flagged) != 0 || store.cleared != 0 {
+		t.Errorf("disabled policy should not flag anything, got %+v", result)
+	}
+}
+
+func TestService_Run_FlagsNotifiesAndDisables(t *testing.T) {
+	store := &mockStore{
+		policy:    enabledPolicy(models.InactivityActionDisable),
+		unflagged: []models.InactiveAccount{{UserID: "u1"}, {UserID: "u2"}},
+		expired:   []models.InactiveAccount{{UserID: "u3"}},
+	}
+	notifier := &mockNotifier{}
+	svc := &Service{store: store, notifier: notifier, logger: testutil.NullLogger()}
+
+	result, err := svc.Run(context.Background(), now, false)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !store.cutoff.Equal(now.AddDate(-1, 0, 0)) || !store.graceStart.Equal(now.AddDate(0, 0, -30)) {
+		t.Errorf("cutoff = %v grace start = %v", store.cutoff, store.graceStart)
+	}
+	if len(store.flagged) != 2 || result.Notified != 2 || len(notifier.sent) != 2 {
+		t.Errorf("flagged = %v notified = %d", store.flagged, result.Notified)
+	}
+	if notifier.sent[0].Kind != models.NotificationAccountInactive {
+		t.Errorf("notification kind = %q", notifier.sent[0].Kind)
+	}
+	if len(store.disabled) != 1 || store.disabled[0] != "u3" || len(result.Actioned) != 1 {
+		t.Errorf("disabled = %v actioned = %+v", store.disabled, result.Actioned)
+	}
+	if store.cleared != 1 {
+		t.Error("returning accounts should be unflagged first")
+	}
+}
+
+func TestService_Run_DryRunChangesNothing(t *testing.T) {
+	policy := enabledPolicy(models.InactivityActionDelete)
+	policy.Enabled = false
+	store := &mockStore{
+		policy:    policy,
+		unflagged: []models.InactiveAccount{{UserID: "u1"}},
+		expired:   []models.InactiveAccount{{UserID: "u2"}},
+	}
+	notifier := &mockNotifier{}
+	svc := &Service{store: store, notifier: notifier, logger: testutil.NullLogger()}
+
+	result, err := svc.Run(context.Background(), now, true)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !result.DryRun || len(result.Flagged) != 1 || len(result.Actioned) != 1 {
+		t.Fatalf("result = %+v, want one flagged and one deleted", result)
+	}
+	if len(store.flagged)+len(store.deleted) != 0 || len(notifier.sent) != 0 || store.cleared != 0 {
+		t.Error("dry run must not change anything")
+	}
+}
+
+func TestService_Run_FailedActionIsSkipped(t *testing.T) {
+	store := &mockStore{
+		policy:    enabledPolicy(models.InactivityActionDelete),
+		expired:   []models.InactiveAccount{{UserID: "u1"}},
+		deleteErr: errors.New("boom"),
+	}
+	svc := &Service{store: store, logger: testutil.NullLogger()}
+
+	result, err := svc.Run(context.Background(), now, false)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(result.Actioned) != 0 {
+		t.Errorf("actioned = %+v, want none", result.Actioned)
+	}
+}
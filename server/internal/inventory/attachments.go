@@ -0,0 +1,152 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/images"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// attachmentSizeLimits whitelists attachment content types, detected from
+// the file bytes, with the largest file allowed for each.
+var attachmentSizeLimits = map[string]int{
+	"application/pdf": 10 * 1024 * 1024,
+	"image/jpeg":      5 * 1024 * 1024,
+	"image/png":       5 * 1024 * 1024,
+	"text/plain":      1024 * 1024,
+}
+
+// MaxAttachmentBytes is the largest attachment of any type.
+const MaxAttachmentBytes = 10 * 1024 * 1024
+
+// AttachmentStore persists inventory attachments.
+type AttachmentStore interface {
+	List(ctx context.Context, itemID, userID string) ([]models.InventoryAttachment, error)
+	Add(ctx context.Context, userID, contentType string, params models.AddInventoryAttachmentParams) (*models.InventoryAttachment, error)
+	Get(ctx context.Context, id, itemID, userID string) (*models.InventoryAttachment, error)
+	Delete(ctx context.Context, id, itemID, userID string) (bool, error)
+}
+
+// ImageScreener moderates photo attachments and strips their metadata.
+type ImageScreener interface {
+	Screen(ctx context.Context, entityType models.ImageEntityType, imageBytes []byte) (*models.ModerationDecision, []byte, error)
+}
+
+// AttachmentService handles receipts, manuals, and other files attached to
+// inventory items. Photos go through the image pipeline before they are
+// stored; PDFs and text files are only type and size checked.
+type AttachmentService struct {
+	store    AttachmentStore
+	screener ImageScreener
+	logger   *logging.Logger
+}
+
+// NewAttachmentService creates a new inventory attachment service
+func NewAttachmentService(store *database.InventoryAttachmentStore, imageSvc *images.Service, logger *logging.Logger) *AttachmentService {
+	return &AttachmentService{
+		store:    store,
+		screener: imageSvc,
+		logger:   logger,
+	}
+}
+
+// List returns an item's attachments
+func (s *AttachmentService) List(ctx context.Context, itemID, userID string) ([]models.InventoryAttachment, error) {
+	return s.store.List(ctx, itemID, userID)
+}
+
+// Add validates and stores a new attachment. Returns nil if the item is not found.
+func (s *AttachmentService) Add(ctx context.Context, userID string, params models.AddInventoryAttachmentParams) (*models.InventoryAttachment, error) {
+	if params.Kind == "" {
+		params.Kind = models.InventoryAttachmentOther
+	}
+	if !params.Kind.IsValid() {
+		return nil, &ServiceError{Message: "kind must be receipt, manual, calibration, or other"}
+	}
+	if len(params.FileBytes) == 0 {
+		return nil, &ServiceError{Message: "file is required"}
+	}
+
+	contentType := detectAttachmentType(params.FileBytes)
+	limit, ok := attachmentSizeLimits[contentType]
+	if !ok {
+		return nil, &ServiceError{Message: "file must be a PDF, JPEG, PNG, or text file"}
+	}
+	if len(params.FileBytes) > limit {
+		return nil, &ServiceError{Message: fmt.Sprintf("%s files must be at most %d MB", contentType, limit/(1024*1024))}
+	}
+
+	existing, err := s.store.List(ctx, params.InventoryItemID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= models.MaxInventoryAttachments {
+		return nil, &ServiceError{Message: fmt.Sprintf("an item can have at most %d attachments", models.MaxInventoryAttachments)}
+	}
+
+	if strings.HasPrefix(contentType, "image/") {
+		if s.screener == nil {
+			return nil, &ServiceError{Message: "image attachments are unavailable"}
+		}
+		decision, screened, err := s.screener.Screen(ctx, models.ImageEntityInventory, params.FileBytes)
+		if err != nil {
+			return nil, &ServiceError{Message: "image could not be processed"}
+		}
+		if decision.Status != models.ImageModerationApproved {
+			return nil, &ServiceError{Message: "image was not approved: " + decision.Reason}
+		}
+		params.FileBytes = screened
+		contentType = http.DetectContentType(screened)
+	}
+
+	params.FileName = sanitizeFileName(params.FileName)
+	attachment, err := s.store.Add(ctx, userID, contentType, params)
+	if err != nil {
+		s.logger.Error("Failed to add inventory attachment", logging.WithField("error", err.Error()))
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// Get returns an attachment with its file bytes. Returns nil if not found.
+func (s *AttachmentService) Get(ctx context.Context, id, itemID, userID string) (*models.InventoryAttachment, error) {
+	return s.store.Get(ctx, id, itemID, userID)
+}
+
+// Delete removes an attachment. Returns false if it was not found.
+func (s *AttachmentService) Delete(ctx context.Context, id, itemID, userID string) (bool, error) {
+	return s.store.Delete(ctx, id, itemID, userID)
+}
+
+// detectAttachmentType sniffs a file's content type without parameters.
+func detectAttachmentType(data []byte) string {
+	contentType, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	return strings.TrimSpace(contentType)
+}
+
+// sanitizeFileName keeps the base name of an uploaded file without control
+// characters, so it is safe to echo in a Content-Disposition header.
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	if len(name) > 255 {
+		name = strings.ToValidUTF8(name[len(name)-255:], "")
+	}
+	return name
+}
@@ -0,0 +1,185 @@
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+type fakeAttachmentStore struct {
+	attachments []models.InventoryAttachment
+	itemOwners  map[string]string
+}
+
+func (f *fakeAttachmentStore) List(ctx context.Context, itemID, userID string) ([]models.InventoryAttachment, error) {
+	result := make([]models.InventoryAttachment, 0)
+	for _, attachment := range f.attachments {
+		if attachment.InventoryItemID == itemID && f.itemOwners[itemID] == userID {
+			result = append(result, attachment)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeAttachmentStore) Add(ctx context.Context, userID, contentType string, params models.AddInventoryAttachmentParams) (*models.InventoryAttachment, error) {
+	if f.itemOwners[params.InventoryItemID] != userID {
+		return nil, nil
+	}
+	attachment := models.InventoryAttachment{
+		ID:              "att-" + strconv.Itoa(len(f.attachments)+1),
+		InventoryItemID: params.InventoryItemID,
+		Kind:            params.Kind,
+		FileName:        params.FileName,
+		ContentType:     contentType,
+		ByteSize:        int64(len(params.FileBytes)),
+		FileBytes:       params.FileBytes,
+	}
+	f.attachments = append(f.attachments, attachment)
+	return &attachment, nil
+}
+
+func (f *fakeAttachmentStore) Get(ctx context.Context, id, itemID, userID string) (*models.InventoryAttachment, error) {
+	return nil, nil
+}
+
+func (f *fakeAttachmentStore) Delete(ctx context.Context, id, itemID, userID string) (bool, error) {
+	return false, nil
+}
+
+// fakeScreener approves images and replaces them with a re-encoded copy, or
+// rejects them all
+type fakeScreener struct {
+	reject bool
+}
+
+func (f fakeScreener) Screen(ctx context.Context, entityType models.ImageEntityType, imageBytes []byte) (*models.ModerationDecision, []byte, error) {
+	if f.reject {
+		return &models.ModerationDecision{Status: models.ImageModerationRejected, Reason: "Not allowed"}, nil, nil
+	}
+	return &models.ModerationDecision{Status: models.ImageModerationApproved}, append(pngHeader, "normalized"...), nil
+}
+
+func newAttachmentService(screener ImageScreener) (*AttachmentService, *fakeAttachmentStore) {
+	store := &fakeAttachmentStore{itemOwners: map[string]string{"item-1": "user-1"}}
+	return &AttachmentService{store: store, screener: screener, logger: testutil.NullLogger()}, store
+}
+
+func TestAttachmentService_Add(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newAttachmentService(fakeScreener{})
+
+	pdf, err := svc.Add(ctx, "user-1", models.AddInventoryAttachmentParams{
+		InventoryItemID: "item-1",
+		Kind:            models.InventoryAttachmentManual,
+		FileName:        `C:\Users\pilot\Downloads\"manual".pdf`,
+		FileBytes:       []byte("%PDF-1.7\n..."),
+	})
+	if err != nil {
+		t.Fatalf("Add(pdf) error = %v", err)
+	}
+	if pdf.ContentType != "application/pdf" || pdf.FileName != "manual.pdf" || pdf.Kind != models.InventoryAttachmentManual {
+		t.Errorf("pdf attachment = %+v, want sanitized manual.pdf", pdf)
+	}
+
+	cli, err := svc.Add(ctx, "user-1", models.AddInventoryAttachmentParams{
+		InventoryItemID: "item-1",
+		FileName:        "diff.txt",
+		FileBytes:       []byte("set motor_pwm_protocol = DSHOT600\n"),
+	})
+	if err != nil {
+		t.Fatalf("Add(text) error = %v", err)
+	}
+	if cli.ContentType != "text/plain" || cli.Kind != models.InventoryAttachmentOther {
+		t.Errorf("text attachment = %+v, want text/plain of kind other", cli)
+	}
+
+	receipt, err := svc.Add(ctx, "user-1", models.AddInventoryAttachmentParams{
+		InventoryItemID: "item-1",
+		Kind:            models.InventoryAttachmentReceipt,
+		FileName:        "receipt.png",
+		FileBytes:       pngHeader,
+	})
+	if err != nil {
+		t.Fatalf("Add(image) error = %v", err)
+	}
+	if !bytes.HasSuffix(receipt.FileBytes, []byte("normalized")) || receipt.ContentType != "image/png" {
+		t.Errorf("image attachment = %+v, want screened bytes", receipt)
+	}
+
+	missing, err := svc.Add(ctx, "user-2", models.AddInventoryAttachmentParams{
+		InventoryItemID: "item-1",
+		FileBytes:       []byte("%PDF-1.7\n..."),
+	})
+	if err != nil || missing != nil {
+		t.Errorf("Add(other user's item) = %+v, %v, want nil", missing, err)
+	}
+}
+
+func TestAttachmentService_AddRejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		screener ImageScreener
+		params   models.AddInventoryAttachmentParams
+	}{
+		{
+			name:   "unknown kind",
+			params: models.AddInventoryAttachmentParams{Kind: "warranty", FileBytes: []byte("%PDF-1.7\n")},
+		},
+		{
+			name:   "html",
+			params: models.AddInventoryAttachmentParams{FileBytes: []byte("<html><script>alert(1)</script></html>")},
+		},
+		{
+			name:   "oversized text",
+			params: models.AddInventoryAttachmentParams{FileBytes: bytes.Repeat([]byte("a"), 1024*1024+1)},
+		},
+		{
+			name:     "rejected image",
+			screener: fakeScreener{reject: true},
+			params:   models.AddInventoryAttachmentParams{FileBytes: pngHeader},
+		},
+		{
+			name:   "image without pipeline",
+			params: models.AddInventoryAttachmentParams{FileBytes: pngHeader},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store := newAttachmentService(tt.screener)
+			tt.params.InventoryItemID = "item-1"
+
+			_, err := svc.Add(context.Background(), "user-1", tt.params)
+			var svcErr *ServiceError
+			if !errors.As(err, &svcErr) {
+				t.Fatalf("Add() error = %v, want ServiceError", err)
+			}
+			if len(store.attachments) != 0 {
+				t.Errorf("stored %d attachments, want none", len(store.attachments))
+			}
+		})
+	}
+}
+
+func TestAttachmentService_AddLimit(t *testing.T) {
+	svc, store := newAttachmentService(nil)
+	for i := 0; i < models.MaxInventoryAttachments; i++ {
+		store.attachments = append(store.attachments, models.InventoryAttachment{InventoryItemID: "item-1"})
+	}
+
+	_, err := svc.Add(context.Background(), "user-1", models.AddInventoryAttachmentParams{
+		InventoryItemID: "item-1",
+		FileBytes:       []byte("%PDF-1.7\n"),
+	})
+	var svcErr *ServiceError
+	if !errors.As(err, &svcErr) {
+		t.Fatalf("Add() past the limit error = %v, want ServiceError", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/johnrirwin/flyingforge/internal/compat"
 	"github.com/johnrirwin/flyingforge/internal/database"
 	"github.com/johnrirwin/flyingforge/internal/logging"
 	"github.com/johnrirwin/flyingforge/internal/models"
@@ -17,10 +18,13 @@ type InventoryManager interface {
 	AddItem(ctx context.Context, userID string, params models.AddInventoryParams) (*models.InventoryItem, error)
 	AddFromEquipment(ctx context.Context, userID string, equipment models.EquipmentItem, quantity int, notes string) (*models.InventoryItem, error)
 	GetItem(ctx context.Context, id string, userID string) (*models.InventoryItem, error)
+	GetItemByCatalogID(ctx context.Context, userID string, catalogID string) (*models.InventoryItem, error)
 	GetInventory(ctx context.Context, userID string, params models.InventoryFilterParams) (*models.InventoryResponse, error)
 	UpdateItem(ctx context.Context, userID string, params models.UpdateInventoryParams) (*models.InventoryItem, error)
 	RemoveItem(ctx context.Context, id string, userID string) error
+	ArchiveItem(ctx context.Context, id string, userID string, archived bool) (*models.InventoryItem, error)
 	GetSummary(ctx context.Context, userID string) (*models.InventorySummary, error)
+	CompatibilityWarnings(ctx context.Context, userID string, item *models.InventoryItem) ([]models.CompatibilityIssue, error)
 }
 
 // Service handles inventory operations backed by PostgreSQL
@@ -120,6 +124,11 @@ func (s *Service) GetItem(ctx context.Context, id string, userID string) (*model
 	return s.store.Get(ctx, id, userID)
 }
 
+// GetItemByCatalogID retrieves the user's item linked to a catalog item
+func (s *Service) GetItemByCatalogID(ctx context.Context, userID string, catalogID string) (*models.InventoryItem, error) {
+	return s.store.GetByCatalogID(ctx, userID, catalogID)
+}
+
 // GetInventory retrieves inventory items with optional filtering
 func (s *Service) GetInventory(ctx context.Context, userID string, params models.InventoryFilterParams) (*models.InventoryResponse, error) {
 	return s.store.List(ctx, userID, params)
@@ -166,11 +175,67 @@ func (s *Service) RemoveItem(ctx context.Context, id string, userID string) erro
 	return nil
 }
 
+// ArchiveItem archives or restores an item. Archiving hides retired gear
+// without deleting it, so purchase history and aircraft components that
+// use it are kept.
+func (s *Service) ArchiveItem(ctx context.Context, id string, userID string, archived bool) (*models.InventoryItem, error) {
+	if id == "" {
+		return nil, &ServiceError{Message: "item ID is required"}
+	}
+
+	item, err := s.store.SetArchived(ctx, id, userID, archived)
+	if err != nil {
+		s.logger.Error("Failed to archive inventory item", logging.WithFields(map[string]interface{}{
+			"id":    id,
+			"error": err.Error(),
+		}))
+		return nil, err
+	}
+	if item == nil {
+		return nil, &ServiceError{Message: "inventory item not found"}
+	}
+
+	s.logger.Info("Set inventory item archived", logging.WithFields(map[string]interface{}{
+		"id":       id,
+		"archived": archived,
+	}))
+	return item, nil
+}
+
 // GetSummary returns a summary of the inventory
 func (s *Service) GetSummary(ctx context.Context, userID string) (*models.InventorySummary, error) {
 	return s.store.GetSummary(ctx, userID)
 }
 
+// CompatibilityWarnings checks a catalog-linked item against the rest of the
+// user's gear, e.g. a Crossfire receiver when all their radios are ELRS.
+// Items without a catalog link have no specs to compare and get none.
+func (s *Service) CompatibilityWarnings(ctx context.Context, userID string, item *models.InventoryItem) ([]models.CompatibilityIssue, error) {
+	if item == nil || item.CatalogID == "" {
+		return []models.CompatibilityIssue{}, nil
+	}
+
+	owned, err := s.store.CatalogItemsForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var added *compat.Part
+	existing := make([]compat.Part, 0, len(owned))
+	for _, catalogItem := range owned {
+		part := compat.PartFromCatalog(catalogItem)
+		if catalogItem.ID == item.CatalogID {
+			added = &part
+			continue
+		}
+		existing = append(existing, part)
+	}
+	if added == nil {
+		return []models.CompatibilityIssue{}, nil
+	}
+	return compat.CheckEcosystem(*added, existing), nil
+}
+
 // InMemoryService is an in-memory implementation for development/testing
 type InMemoryService struct {
 	items  map[string]models.InventoryItem
@@ -216,6 +281,7 @@ func (s *InMemoryService) AddItem(ctx context.Context, userID string, params mod
 		ProductURL:        params.ProductURL,
 		Specs:             params.Specs,
 		SourceEquipmentID: params.SourceEquipmentID,
+		CatalogID:         params.CatalogID,
 		CreatedAt:         now,
 		UpdatedAt:         now,
 	}
@@ -259,6 +325,19 @@ func (s *InMemoryService) GetItem(ctx context.Context, id string, userID string)
 	return &item, nil
 }
 
+// GetItemByCatalogID retrieves the user's item linked to a catalog item
+func (s *InMemoryService) GetItemByCatalogID(ctx context.Context, userID string, catalogID string) (*models.InventoryItem, error) {
+	if catalogID == "" {
+		return nil, nil
+	}
+	for _, item := range s.items {
+		if item.UserID == userID && item.CatalogID == catalogID {
+			return &item, nil
+		}
+	}
+	return nil, nil
+}
+
 // GetInventory retrieves inventory items with optional filtering
 func (s *InMemoryService) GetInventory(ctx context.Context, userID string, params models.InventoryFilterParams) (*models.InventoryResponse, error) {
 	items := make([]models.InventoryItem, 0, len(s.items))
@@ -275,6 +354,17 @@ func (s *InMemoryService) GetInventory(ctx context.Context, userID string, param
 		if params.BuildID != "" && item.BuildID != params.BuildID {
 			continue
 		}
+		switch params.Status {
+		case models.InventoryStatusArchived:
+			if !item.Archived {
+				continue
+			}
+		case models.InventoryStatusAll:
+		default:
+			if item.Archived {
+				continue
+			}
+		}
 		if params.Query != "" {
 			query := strings.ToLower(params.Query)
 			name := strings.ToLower(item.Name)
@@ -374,6 +464,26 @@ func (s *InMemoryService) RemoveItem(ctx context.Context, id string, userID stri
 	return nil
 }
 
+// ArchiveItem archives or restores an item
+func (s *InMemoryService) ArchiveItem(ctx context.Context, id string, userID string, archived bool) (*models.InventoryItem, error) {
+	item, ok := s.items[id]
+	if !ok || (userID != "" && item.UserID != userID) {
+		return nil, &ServiceError{Message: "inventory item not found"}
+	}
+
+	now := time.Now()
+	if !archived {
+		item.ArchivedAt = nil
+	} else if item.ArchivedAt == nil {
+		item.ArchivedAt = &now
+	}
+	item.Archived = archived
+	item.UpdatedAt = now
+	s.items[id] = item
+
+	return &item, nil
+}
+
 // GetSummary returns a summary of the inventory
 func (s *InMemoryService) GetSummary(ctx context.Context, userID string) (*models.InventorySummary, error) {
 	summary := &models.InventorySummary{
@@ -385,6 +495,9 @@ func (s *InMemoryService) GetSummary(ctx context.Context, userID string) (*model
 		if userID != "" && item.UserID != userID {
 			continue
 		}
+		if item.Archived {
+			continue
+		}
 		summary.TotalItems += item.Quantity
 		if item.PurchasePrice != nil {
 			summary.TotalValue += *item.PurchasePrice * float64(item.Quantity)
@@ -395,6 +508,12 @@ func (s *InMemoryService) GetSummary(ctx context.Context, userID string) (*model
 	return summary, nil
 }
 
+// CompatibilityWarnings returns no warnings; the in-memory store has no
+// catalog specs to compare
+func (s *InMemoryService) CompatibilityWarnings(ctx context.Context, userID string, item *models.InventoryItem) ([]models.CompatibilityIssue, error) {
+	return []models.CompatibilityIssue{}, nil
+}
+
 // ServiceError represents an error from the inventory service
 type ServiceError struct {
 	Message string
@@ -0,0 +1,59 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+func TestInMemoryService_ArchiveItem(t *testing.T) {
+	ctx := context.Background()
+	svc := NewInMemoryService(testutil.NullLogger())
+
+	price := 20.0
+	motor, err := svc.AddItem(ctx, "user-1", models.AddInventoryParams{Name: "2207 Motor", Category: models.CategoryMotors, Quantity: 4, PurchasePrice: &price})
+	if err != nil {
+		t.Fatalf("AddItem() error = %v", err)
+	}
+	if _, err := svc.AddItem(ctx, "user-1", models.AddInventoryParams{Name: "F7 FC", Category: models.CategoryFC, Quantity: 1}); err != nil {
+		t.Fatalf("AddItem() error = %v", err)
+	}
+
+	archived, err := svc.ArchiveItem(ctx, motor.ID, "user-1", true)
+	if err != nil {
+		t.Fatalf("ArchiveItem() error = %v", err)
+	}
+	if !archived.Archived || archived.ArchivedAt == nil || archived.PurchasePrice == nil {
+		t.Fatalf("archived item = %+v, want archived with its purchase price", archived)
+	}
+
+	counts := map[models.InventoryStatus]int{"": 1, models.InventoryStatusActive: 1, models.InventoryStatusArchived: 1, models.InventoryStatusAll: 2}
+	for status, want := range counts {
+		resp, err := svc.GetInventory(ctx, "user-1", models.InventoryFilterParams{Status: status})
+		if err != nil {
+			t.Fatalf("GetInventory() error = %v", err)
+		}
+		if resp.TotalCount != want {
+			t.Errorf("GetInventory(status %q) = %d items, want %d", status, resp.TotalCount, want)
+		}
+	}
+
+	summary, _ := svc.GetSummary(ctx, "user-1")
+	if summary.TotalItems != 1 || summary.TotalValue != 0 {
+		t.Errorf("summary = %+v, want only the active item", summary)
+	}
+
+	if _, err := svc.ArchiveItem(ctx, motor.ID, "user-2", true); err == nil {
+		t.Error("ArchiveItem() should not find another user's item")
+	}
+
+	restored, err := svc.ArchiveItem(ctx, motor.ID, "user-1", false)
+	if err != nil {
+		t.Fatalf("ArchiveItem() error = %v", err)
+	}
+	if restored.Archived || restored.ArchivedAt != nil {
+		t.Errorf("restored item = %+v, want active", restored)
+	}
+}
@@ -0,0 +1,273 @@
+package linkcheck
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/equipment"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/ratelimit"
+	"github.com/johnrirwin/flyingforge/internal/safehttp"
+)
+
+const (
+	// RecheckInterval is how often a working link is checked again
+	RecheckInterval = 7 * 24 * time.Hour
+	// RetryInterval is how soon a failing link is checked again
+	RetryInterval = 24 * time.Hour
+	// DeadAfterFailures is how many failed checks in a row make a link dead,
+	// so a site that's briefly down isn't reported
+	DeadAfterFailures = 3
+
+	batchSize         = 200
+	checkConcurrency  = 4
+	suggestionTimeout = 15 * time.Second
+	userAgent         = "FlyingForge-LinkChecker/1.0"
+)
+
+// Store tracks link health
+type Store interface {
+	SyncURLs(ctx context.Context) error
+	DueURLs(ctx context.Context, recheckBefore, retryBefore time.Time, limit int) ([]string, error)
+	RecordResult(ctx context.Context, result models.LinkCheckResult, deadAfter int) error
+	ListDead(ctx context.Context, limit, offset int) (*models.DeadLinkReport, error)
+}
+
+// OfferFinder finds the best current seller listing for a product name
+type OfferFinder interface {
+	FindOffer(ctx context.Context, name string) (*models.EquipmentItem, error)
+}
+
+// Service periodically checks the product and manufacturer links stored on
+// inventory, equipment, and catalog records, and reports the dead ones with
+// a replacement listing from the seller adapters.
+type Service struct {
+	store   Store
+	offers  OfferFinder
+	limiter *ratelimit.Limiter
+	client  *http.Client
+	logger  *logging.Logger
+	now     func() time.Time
+}
+
+// NewService creates a new link check service
+func NewService(store *database.LinkCheckStore, equipmentSvc *equipment.Service, limiter *ratelimit.Limiter, logger *logging.Logger) *Service {
+	s := &Service{
+		store:   store,
+		limiter: limiter,
+		client:  safehttp.NewClient(20 * time.Second),
+		logger:  logger,
+		now:     time.Now,
+	}
+	if equipmentSvc != nil {
+		s.offers = equipmentSvc
+	}
+	return s
+}
+
+// RunOnce checks a batch of links that are due and returns how many were
+// checked
+func (s *Service) RunOnce(ctx context.Context) (int, error) {
+	if err := s.store.SyncURLs(ctx); err != nil {
+		return 0, err
+	}
+
+	now := s.now()
+	urls, err := s.store.DueURLs(ctx, now.Add(-RecheckInterval), now.Add(-RetryInterval), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		checked int
+		sem     = make(chan struct{}, checkConcurrency)
+	)
+	for _, u := range urls {
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(u string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result := s.Check(ctx, u)
+			if ctx.Err() != nil {
+				// Shutting down, not a verdict on the link
+				return
+			}
+			if err := s.store.RecordResult(ctx, result, DeadAfterFailures); err != nil {
+				s.logger.Warn("Failed to record link check", logging.WithFields(map[string]interface{}{
+					"url":   u,
+					"error": err.Error(),
+				}))
+				return
+			}
+			mu.Lock()
+			checked++
+			mu.Unlock()
+		}(u)
+	}
+	wg.Wait()
+
+	return checked, nil
+}
+
+// CheckNow checks one link immediately and records the result
+func (s *Service) CheckNow(ctx context.Context, rawURL string) (*models.LinkCheckResult, error) {
+	if _, err := parseLink(rawURL); err != nil {
+		return nil, err
+	}
+	result := s.Check(ctx, rawURL)
+	if err := s.store.RecordResult(ctx, result, DeadAfterFailures); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Check requests a link. HEAD is tried first; sites that don't support it
+// get a GET.
+func (s *Service) Check(ctx context.Context, rawURL string) models.LinkCheckResult {
+	result := models.LinkCheckResult{URL: rawURL}
+
+	u, err := parseLink(rawURL)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if s.limiter != nil {
+		s.limiter.Wait(u.Hostname())
+	}
+
+	status, err := s.request(ctx, http.MethodHead, u)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented ||
+		status == http.StatusForbidden || status == http.StatusNotFound) {
+		// Some sites reject or mishandle HEAD but serve the page
+		status, err = s.request(ctx, http.MethodGet, u)
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.HTTPStatus = status
+	switch {
+	case status < 400:
+		result.OK = true
+	case status == http.StatusNotFound || status == http.StatusGone || status >= 500:
+		result.Error = http.StatusText(status)
+	default:
+		// 401, 403, 429 and the like: the site refused us, the page may
+		// well exist
+		result.Inconclusive = true
+	}
+	return result
+}
+
+func (s *Service) request(ctx context.Context, method string, u *url.URL) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,*/*;q=0.8")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Read a little so the connection can be reused for small pages
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	return resp.StatusCode, nil
+}
+
+// Report returns dead links with where they're used and, when a seller has
+// a matching listing, a suggested replacement
+func (s *Service) Report(ctx context.Context, limit, offset int) (*models.DeadLinkReport, error) {
+	report, err := s.store.ListDead(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if s.offers == nil {
+		return report, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, suggestionTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, checkConcurrency)
+	for i := range report.Links {
+		name := suggestionName(report.Links[i])
+		if name == "" {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(link *models.DeadLink, name string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			offer, err := s.offers.FindOffer(ctx, name)
+			if err != nil {
+				s.logger.Debug("No replacement found for dead link", logging.WithFields(map[string]interface{}{
+					"url":   link.URL,
+					"error": err.Error(),
+				}))
+				return
+			}
+			if offer != nil && offer.ProductURL != link.URL {
+				link.Suggestion = offer
+			}
+		}(&report.Links[i], name)
+	}
+	wg.Wait()
+
+	return report, nil
+}
+
+// suggestionName picks the product name to search sellers for, preferring
+// catalog names over free-text inventory names
+func suggestionName(link models.DeadLink) string {
+	name := ""
+	for _, usage := range link.Usages {
+		if usage.Name == "" {
+			continue
+		}
+		if usage.Source == models.LinkUsageCatalog {
+			return usage.Name
+		}
+		if name == "" {
+			name = usage.Name
+		}
+	}
+	return name
+}
+
+func parseLink(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, &ServiceError{Message: "url must be an absolute http(s) URL"}
+	}
+	return u, nil
+}
+
+// ServiceError represents a link check request error that should be shown to the client
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,161 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+type mockStore struct {
+	mu      sync.Mutex
+	due     []string
+	results []models.LinkCheckResult
+	dead    *models.DeadLinkReport
+}
+
+func (m *mockStore) SyncURLs(ctx context.Context) error { return nil }
+
+func (m *mockStore) DueURLs(ctx context.Context, recheckBefore, retryBefore time.Time, limit int) ([]string, error) {
+	return m.due, nil
+}
+
+func (m *mockStore) RecordResult(ctx context.Context, result models.LinkCheckResult, deadAfter int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.results = append(m.results, result)
+	return nil
+}
+
+func (m *mockStore) ListDead(ctx context.Context, limit, offset int) (*models.DeadLinkReport, error) {
+	return m.dead, nil
+}
+
+type mockOffers struct {
+	offers map[string]*models.EquipmentItem
+}
+
+func (m *mockOffers) FindOffer(ctx context.Context, name string) (*models.EquipmentItem, error) {
+	offer, ok := m.offers[name]
+	if !ok {
+		return nil, errors.New("no match")
+	}
+	return offer, nil
+}
+
+// newTestServer serves status codes by path. /head-405 rejects HEAD but
+// serves GET.
+func newTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+		case "/moved":
+			http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+		case "/head-405":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case "/gone":
+			w.WriteHeader(http.StatusGone)
+		case "/error":
+			w.WriteHeader(http.StatusBadGateway)
+		case "/blocked":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestCheck(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	svc := &Service{client: server.Client(), logger: testutil.NullLogger(), now: time.Now}
+
+	tests := []struct {
+		path             string
+		wantOK           bool
+		wantInconclusive bool
+		wantStatus       int
+	}{
+		{path: "/ok", wantOK: true, wantStatus: http.StatusOK},
+		{path: "/moved", wantOK: true, wantStatus: http.StatusOK},
+		{path: "/head-405", wantOK: true, wantStatus: http.StatusOK},
+		{path: "/missing", wantStatus: http.StatusNotFound},
+		{path: "/gone", wantStatus: http.StatusGone},
+		{path: "/error", wantStatus: http.StatusBadGateway},
+		{path: "/blocked", wantInconclusive: true, wantStatus: http.StatusTooManyRequests},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := svc.Check(context.Background(), server.URL+tt.path)
+			if got.OK != tt.wantOK || got.Inconclusive != tt.wantInconclusive || got.HTTPStatus != tt.wantStatus {
+				t.Errorf("Check(%s) = %+v, want ok=%v inconclusive=%v status=%d", tt.path, got, tt.wantOK, tt.wantInconclusive, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestCheck_InvalidURL(t *testing.T) {
+	svc := &Service{client: http.DefaultClient, logger: testutil.NullLogger(), now: time.Now}
+
+	for _, rawURL := range []string{"", "ftp://example.com/file", "/relative/path"} {
+		got := svc.Check(context.Background(), rawURL)
+		if got.OK || got.Inconclusive || got.Error == "" {
+			t.Errorf("Check(%q) = %+v, want a failure", rawURL, got)
+		}
+	}
+}
+
+func TestRunOnce(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	store := &mockStore{due: []string{server.URL + "/ok", server.URL + "/gone"}}
+	svc := &Service{store: store, client: server.Client(), logger: testutil.NullLogger(), now: time.Now}
+
+	checked, err := svc.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce() error = %v", err)
+	}
+	if checked != 2 || len(store.results) != 2 {
+		t.Fatalf("RunOnce() checked %d, recorded %d, want 2", checked, len(store.results))
+	}
+	for _, result := range store.results {
+		wantOK := result.URL == server.URL+"/ok"
+		if result.OK != wantOK {
+			t.Errorf("recorded %s ok = %v, want %v", result.URL, result.OK, wantOK)
+		}
+	}
+}
+
+func TestReport_Suggestions(t *testing.T) {
+	store := &mockStore{dead: &models.DeadLinkReport{Links: []models.DeadLink{
+		{URL: "https://shop.example/a", Usages: []models.LinkUsage{
+			{Source: models.LinkUsageInventory, Name: "my motor"},
+			{Source: models.LinkUsageCatalog, Name: "T-Motor F60"},
+		}},
+		{URL: "https://shop.example/b", Usages: []models.LinkUsage{{Source: models.LinkUsageInventory, Name: "Unknown Part"}}},
+	}}}
+	offers := &mockOffers{offers: map[string]*models.EquipmentItem{
+		"T-Motor F60": {ID: "rdq-1", ProductURL: "https://rdq.example/f60"},
+	}}
+	svc := &Service{store: store, offers: offers, logger: testutil.NullLogger(), now: time.Now}
+
+	report, err := svc.Report(context.Background(), 50, 0)
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if s := report.Links[0].Suggestion; s == nil || s.ID != "rdq-1" {
+		t.Errorf("Links[0].Suggestion = %+v, want the listing for the catalog name", s)
+	}
+	if s := report.Links[1].Suggestion; s != nil {
+		t.Errorf("Links[1].Suggestion = %+v, want none", s)
+	}
+}
@@ -0,0 +1,201 @@
+package linkpreview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/cache"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/ratelimit"
+	"github.com/johnrirwin/flyingforge/internal/safehttp"
+)
+
+const (
+	cachePrefix = "link_preview:"
+	// previewTTL is how long a found preview is reused
+	previewTTL = 7 * 24 * time.Hour
+	// missTTL is how long a page without a preview, or that failed to load,
+	// is left alone
+	missTTL = 24 * time.Hour
+
+	maxPageBytes      = 512 * 1024
+	maxDescriptionLen = 300
+	userAgent         = "FlyingForge-LinkPreview/1.0"
+)
+
+var (
+	metaTagPattern  = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	metaAttrPattern = regexp.MustCompile(`(?is)(property|name|content)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	headEndPattern  = regexp.MustCompile(`(?i)</head\s*>`)
+)
+
+// Service reads OpenGraph metadata from web pages. Previews are cached,
+// including pages that have none, and fetches share the per-host rate limit.
+type Service struct {
+	cache   cache.Cache
+	limiter *ratelimit.Limiter
+	client  *http.Client
+}
+
+// NewService creates a new link preview service
+func NewService(c cache.Cache, limiter *ratelimit.Limiter) *Service {
+	return &Service{
+		cache:   c,
+		limiter: limiter,
+		client:  safehttp.NewClient(15 * time.Second),
+	}
+}
+
+// Preview returns the OpenGraph metadata of a page. A page without any
+// returns an empty preview rather than an error.
+func (s *Service) Preview(ctx context.Context, pageURL string) (*models.LinkPreview, error) {
+	if cached, ok := s.cached(pageURL); ok {
+		return cached, nil
+	}
+
+	preview, err := s.fetch(ctx, pageURL)
+	if err != nil {
+		if ctx.Err() == nil && s.cache != nil {
+			// Don't retry a broken page on every refresh
+			s.cache.SetWithTTL(cachePrefix+pageURL, models.LinkPreview{URL: pageURL}, missTTL)
+		}
+		return nil, err
+	}
+
+	if s.cache != nil {
+		ttl := previewTTL
+		if preview.ImageURL == "" && preview.Description == "" {
+			ttl = missTTL
+		}
+		s.cache.SetWithTTL(cachePrefix+pageURL, *preview, ttl)
+	}
+	return preview, nil
+}
+
+func (s *Service) cached(pageURL string) (*models.LinkPreview, bool) {
+	if s.cache == nil {
+		return nil, false
+	}
+	cached, ok := s.cache.Get(cachePrefix + pageURL)
+	if !ok || cached == nil {
+		return nil, false
+	}
+	if preview, ok := cached.(models.LinkPreview); ok {
+		return &preview, true
+	}
+
+	// Redis hands back the decoded JSON
+	raw, err := json.Marshal(cached)
+	if err != nil {
+		return nil, false
+	}
+	var preview models.LinkPreview
+	if err := json.Unmarshal(raw, &preview); err != nil {
+		return nil, false
+	}
+	return &preview, true
+}
+
+func (s *Service) fetch(ctx context.Context, pageURL string) (*models.LinkPreview, error) {
+	u, err := url.Parse(pageURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return nil, fmt.Errorf("not an absolute http(s) URL: %q", pageURL)
+	}
+	if s.limiter != nil {
+		s.limiter.Wait(u.Hostname())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", u.Hostname(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return &models.LinkPreview{URL: pageURL}, nil
+	}
+
+	// OpenGraph tags are in <head>, which is usually well within the limit
+	page, err := io.ReadAll(io.LimitReader(resp.Body, maxPageBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Relative image paths resolve against the page redirects ended on
+	return parsePreview(page, resp.Request.URL, pageURL), nil
+}
+
+// parsePreview reads the OpenGraph tags of a page, falling back to the
+// Twitter card and description meta tags
+func parsePreview(page []byte, base *url.URL, pageURL string) *models.LinkPreview {
+	if loc := headEndPattern.FindIndex(page); loc != nil {
+		page = page[:loc[0]]
+	}
+
+	meta := make(map[string]string)
+	for _, tag := range metaTagPattern.FindAll(page, -1) {
+		var property, content string
+		for _, attr := range metaAttrPattern.FindAllSubmatch(tag, -1) {
+			value := string(attr[2]) + string(attr[3])
+			switch strings.ToLower(string(attr[1])) {
+			case "property", "name":
+				property = strings.ToLower(strings.TrimSpace(value))
+			case "content":
+				content = strings.TrimSpace(html.UnescapeString(value))
+			}
+		}
+		// The first of each wins, as in most OpenGraph consumers
+		if property != "" && content != "" && meta[property] == "" {
+			meta[property] = content
+		}
+	}
+
+	preview := &models.LinkPreview{
+		URL:         pageURL,
+		Title:       firstOf(meta, "og:title", "twitter:title"),
+		Description: truncate(firstOf(meta, "og:description", "twitter:description", "description"), maxDescriptionLen),
+		SiteName:    firstOf(meta, "og:site_name"),
+	}
+	if image := firstOf(meta, "og:image:secure_url", "og:image", "og:image:url", "twitter:image", "twitter:image:src"); image != "" {
+		if imageURL, err := base.Parse(image); err == nil && (imageURL.Scheme == "http" || imageURL.Scheme == "https") {
+			preview.ImageURL = imageURL.String()
+		}
+	}
+	return preview
+}
+
+func firstOf(meta map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value := meta[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func truncate(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:maxLen])) + "..."
+}
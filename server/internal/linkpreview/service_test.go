@@ -0,0 +1,99 @@
+package linkpreview
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/cache"
+)
+
+func TestParsePreview(t *testing.T) {
+	base, _ := url.Parse("https://news.example.com/2026/03/new-quad")
+
+	tests := []struct {
+		name string
+		page string
+		want string // ImageURL
+		desc string
+	}{
+		{
+			name: "open graph",
+			page: `<head><meta property="og:image" content="https://cdn.example.com/quad.jpg"><meta property="og:description" content="A new quad &amp; more"></head>`,
+			want: "https://cdn.example.com/quad.jpg",
+			desc: "A new quad & more",
+		},
+		{
+			name: "relative image and twitter fallback",
+			page: `<head><meta name="twitter:image" content="/img/quad.png"><meta name="description" content='Plain description'></head>`,
+			want: "https://news.example.com/img/quad.png",
+			desc: "Plain description",
+		},
+		{
+			name: "secure url preferred",
+			page: `<meta property="og:image" content="http://cdn.example.com/a.jpg"><meta property="og:image:secure_url" content="https://cdn.example.com/a.jpg">`,
+			want: "https://cdn.example.com/a.jpg",
+		},
+		{
+			name: "tags after head ignored",
+			page: `<head><title>x</title></head><body><meta property="og:image" content="https://cdn.example.com/body.jpg"></body>`,
+		},
+		{
+			name: "non-http image ignored",
+			page: `<meta property="og:image" content="data:image/png;base64,AAAA">`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parsePreview([]byte(tt.page), base, base.String())
+			if got.ImageURL != tt.want || got.Description != tt.desc {
+				t.Errorf("parsePreview() = image %q, description %q, want %q, %q", got.ImageURL, got.Description, tt.want, tt.desc)
+			}
+		})
+	}
+}
+
+func TestPreview_Cached(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><head><meta property="og:image" content="/thumb.jpg"></head></html>`))
+	}))
+	defer server.Close()
+
+	svc := &Service{cache: cache.NewMemory(time.Hour), client: server.Client()}
+	for i := 0; i < 2; i++ {
+		preview, err := svc.Preview(context.Background(), server.URL+"/article")
+		if err != nil {
+			t.Fatalf("Preview() error = %v", err)
+		}
+		if preview.ImageURL != server.URL+"/thumb.jpg" {
+			t.Errorf("Preview().ImageURL = %q, want %q", preview.ImageURL, server.URL+"/thumb.jpg")
+		}
+	}
+	if requests != 1 {
+		t.Errorf("server got %d requests, want 1", requests)
+	}
+}
+
+func TestPreview_NonHTML(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write([]byte(`<meta property="og:image" content="/thumb.jpg">`))
+	}))
+	defer server.Close()
+
+	svc := &Service{client: server.Client()}
+	preview, err := svc.Preview(context.Background(), server.URL+"/manual.pdf")
+	if err != nil {
+		t.Fatalf("Preview() error = %v", err)
+	}
+	if preview.ImageURL != "" {
+		t.Errorf("Preview().ImageURL = %q, want none for a PDF", preview.ImageURL)
+	}
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// AdminSearchType is the kind of record an admin search result points to
+type AdminSearchType string
+
+const (
+	AdminSearchUser        AdminSearchType = "user"
+	AdminSearchCatalogItem AdminSearchType = "catalog_item"
+	AdminSearchBuild       AdminSearchType = "build"
+	AdminSearchOrder       AdminSearchType = "order"
+)
+
+// AdminSearchTypes lists every searchable type in the order results are
+// returned
+var AdminSearchTypes = []AdminSearchType{AdminSearchUser, AdminSearchCatalogItem, AdminSearchBuild, AdminSearchOrder}
+
+// IsValid reports whether t is a searchable type
+func (t AdminSearchType) IsValid() bool {
+	for _, known := range AdminSearchTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// AdminSearchResult is one match from GET /api/admin/search. Title and
+// Subtitle depend on the type: call sign and email for users, name and gear
+// type for catalog items, title and owner for builds, label and tracking
+// number for orders.
+type AdminSearchResult struct {
+	Type      AdminSearchType `json:"type"`
+	ID        string          `json:"id"`
+	Title     string          `json:"title"`
+	Subtitle  string          `json:"subtitle,omitempty"`
+	Status    string          `json:"status,omitempty"`
+	OwnerID   string          `json:"ownerId,omitempty"` // the user a build or order belongs to
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// AdminSearchResponse groups matches by type. Each type has at most Limit
+// results; an ID pasted as the query comes first within its type.
+type AdminSearchResponse struct {
+	Query   string              `json:"query"`
+	Limit   int                 `json:"limit"`
+	Results []AdminSearchResult `json:"results"`
+}
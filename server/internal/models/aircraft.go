@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -199,4 +200,121 @@ type AircraftDetailsResponse struct {
 	Aircraft         Aircraft                  `json:"aircraft"`
 	Components       []AircraftComponent       `json:"components"`
 	ReceiverSettings *AircraftReceiverSettings `json:"receiverSettings,omitempty"`
+	Registration     *AircraftRegistration     `json:"registration,omitempty"`
+}
+
+// ComponentItem returns the inventory item installed as the first component of
+// the given category, or nil if there is none
+func (d *AircraftDetailsResponse) ComponentItem(category ComponentCategory) *InventoryItem {
+	for _, component := range d.Components {
+		if component.Category == category && component.InventoryItem != nil {
+			return component.InventoryItem
+		}
+	}
+	return nil
+}
+
+// AircraftFromBuildResponse is returned after creating an aircraft from a build
+type AircraftFromBuildResponse struct {
+	Aircraft              Aircraft            `json:"aircraft"`
+	Components            []AircraftComponent `json:"components"`
+	CreatedInventoryItems int                 `json:"createdInventoryItems"` // New inventory items added for the build's parts
+	LinkedInventoryItems  int                 `json:"linkedInventoryItems"`  // Existing inventory items reused
+	Skipped               []SkippedBuildPart  `json:"skipped,omitempty"`
+}
+
+// SkippedBuildPart is a build part that could not become an aircraft component
+type SkippedBuildPart struct {
+	GearType      GearType `json:"gearType"`
+	CatalogItemID string   `json:"catalogItemId,omitempty"`
+	Name          string   `json:"name,omitempty"`
+	Reason        string   `json:"reason"`
+}
+
+// RegistrationAuthority identifies the aviation authority an aircraft is registered with
+type RegistrationAuthority string
+
+const (
+	RegistrationAuthorityFAA   RegistrationAuthority = "faa"  // United States
+	RegistrationAuthorityCAA   RegistrationAuthority = "caa"  // United Kingdom
+	RegistrationAuthorityEASA  RegistrationAuthority = "easa" // EU member state authorities
+	RegistrationAuthorityTC    RegistrationAuthority = "tc"   // Transport Canada
+	RegistrationAuthorityCASA  RegistrationAuthority = "casa" // Australia
+	RegistrationAuthorityOther RegistrationAuthority = "other"
+)
+
+// IsValidRegistrationAuthority checks if an authority is known
+func IsValidRegistrationAuthority(a RegistrationAuthority) bool {
+	switch a {
+	case RegistrationAuthorityFAA, RegistrationAuthorityCAA, RegistrationAuthorityEASA,
+		RegistrationAuthorityTC, RegistrationAuthorityCASA, RegistrationAuthorityOther:
+		return true
+	}
+	return false
+}
+
+// ComplianceDateLayout is the format of registration and insurance expiry dates
+const ComplianceDateLayout = "2006-01-02"
+
+// AircraftRegistration holds an aircraft's registration, insurance, and
+// remote ID details. Expiry dates are YYYY-MM-DD.
+type AircraftRegistration struct {
+	AircraftID            string                `json:"aircraftId"`
+	RegistrationNumber    string                `json:"registrationNumber,omitempty"`
+	Authority             RegistrationAuthority `json:"authority,omitempty"`
+	RegistrationExpiresOn string                `json:"registrationExpiresOn,omitempty"`
+	InsuranceProvider     string                `json:"insuranceProvider,omitempty"`
+	InsurancePolicyNumber string                `json:"insurancePolicyNumber,omitempty"`
+	InsuranceExpiresOn    string                `json:"insuranceExpiresOn,omitempty"`
+	RemoteIDItemID        string                `json:"remoteIdItemId,omitempty"` // Inventory item of the remote ID module
+	RemindersEnabled      bool                  `json:"remindersEnabled"`
+	UpdatedAt             time.Time             `json:"updatedAt"`
+}
+
+// SetAircraftRegistrationParams replaces an aircraft's registration details
+type SetAircraftRegistrationParams struct {
+	AircraftID            string                `json:"-"`
+	RegistrationNumber    string                `json:"registrationNumber"`
+	Authority             RegistrationAuthority `json:"authority"`
+	RegistrationExpiresOn string                `json:"registrationExpiresOn"`
+	InsuranceProvider     string                `json:"insuranceProvider"`
+	InsurancePolicyNumber string                `json:"insurancePolicyNumber"`
+	InsuranceExpiresOn    string                `json:"insuranceExpiresOn"`
+	RemoteIDItemID        string                `json:"remoteIdItemId"`
+	RemindersEnabled      bool                  `json:"remindersEnabled"`
+}
+
+// Normalize trims fields, uppercases the registration number, and lowercases the authority
+func (p *SetAircraftRegistrationParams) Normalize() {
+	p.RegistrationNumber = strings.ToUpper(strings.TrimSpace(p.RegistrationNumber))
+	p.Authority = RegistrationAuthority(strings.ToLower(strings.TrimSpace(string(p.Authority))))
+	p.RegistrationExpiresOn = strings.TrimSpace(p.RegistrationExpiresOn)
+	p.InsuranceProvider = strings.TrimSpace(p.InsuranceProvider)
+	p.InsurancePolicyNumber = strings.TrimSpace(p.InsurancePolicyNumber)
+	p.InsuranceExpiresOn = strings.TrimSpace(p.InsuranceExpiresOn)
+	p.RemoteIDItemID = strings.TrimSpace(p.RemoteIDItemID)
+}
+
+// ComplianceKind identifies what is expiring
+type ComplianceKind string
+
+const (
+	ComplianceRegistration ComplianceKind = "registration"
+	ComplianceInsurance    ComplianceKind = "insurance"
+)
+
+// AircraftExpiration is an upcoming or past registration or insurance expiry
+type AircraftExpiration struct {
+	AircraftID   string         `json:"aircraftId"`
+	AircraftName string         `json:"aircraftName"`
+	UserID       string         `json:"-"`
+	Kind         ComplianceKind `json:"kind"`
+	Reference    string         `json:"reference,omitempty"` // Registration number or insurance provider
+	ExpiresOn    string         `json:"expiresOn"`
+	DaysLeft     int            `json:"daysLeft"` // Negative once expired
+}
+
+// AircraftExpirationListResponse is the response for listing expirations
+type AircraftExpirationListResponse struct {
+	Expirations []AircraftExpiration `json:"expirations"`
 }
@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+// AnnouncementKind identifies what an announcement is about
+type AnnouncementKind string
+
+const (
+	AnnouncementRelease AnnouncementKind = "release" // release notes
+	AnnouncementOutage  AnnouncementKind = "outage"  // outage or maintenance notice
+	AnnouncementNotice  AnnouncementKind = "notice"  // anything else
+)
+
+// IsValidAnnouncementKind reports whether k is a supported announcement kind
+func IsValidAnnouncementKind(k AnnouncementKind) bool {
+	return k == AnnouncementRelease || k == AnnouncementOutage || k == AnnouncementNotice
+}
+
+// AnnouncementStatus describes where an announcement is in its schedule
+type AnnouncementStatus string
+
+const (
+	AnnouncementStatusScheduled AnnouncementStatus = "scheduled"
+	AnnouncementStatusActive    AnnouncementStatus = "active"
+	AnnouncementStatusExpired   AnnouncementStatus = "expired"
+)
+
+// Announcement is a message from the team to every user, shown from
+// PublishedAt until ExpiresAt. A nil ExpiresAt shows it until removed.
+type Announcement struct {
+	ID              string             `json:"id"`
+	Kind            AnnouncementKind   `json:"kind"`
+	Title           string             `json:"title"`
+	Body            string             `json:"body"`
+	PublishedAt     time.Time          `json:"publishedAt"`
+	ExpiresAt       *time.Time         `json:"expiresAt,omitempty"`
+	Status          AnnouncementStatus `json:"status"`
+	NotifiedAt      *time.Time         `json:"notifiedAt,omitempty"` // when it was pushed to devices
+	CreatedByUserID string             `json:"createdByUserId,omitempty"`
+	CreatedAt       time.Time          `json:"createdAt"`
+	UpdatedAt       time.Time          `json:"updatedAt"`
+	// Read is set for signed-in users
+	Read *bool `json:"read,omitempty"`
+}
+
+// CreateAnnouncementParams represents an admin request to post an announcement
+type CreateAnnouncementParams struct {
+	Kind        AnnouncementKind `json:"kind"`
+	Title       string           `json:"title"`
+	Body        string           `json:"body"`
+	PublishedAt *time.Time       `json:"publishedAt,omitempty"` // defaults to now
+	ExpiresAt   *time.Time       `json:"expiresAt,omitempty"`
+	// Notify pushes the announcement to every registered device once it's published
+	Notify bool `json:"notify,omitempty"`
+}
+
+// UpdateAnnouncementParams replaces the editable fields of an announcement
+type UpdateAnnouncementParams struct {
+	Kind        AnnouncementKind `json:"kind"`
+	Title       string           `json:"title"`
+	Body        string           `json:"body"`
+	PublishedAt time.Time        `json:"publishedAt"`
+	ExpiresAt   *time.Time       `json:"expiresAt"`
+}
+
+// AnnouncementListParams filters and pages announcements
+type AnnouncementListParams struct {
+	Status AnnouncementStatus `json:"status,omitempty"` // admin list only
+	Limit  int                `json:"limit,omitempty"`
+	Offset int                `json:"offset,omitempty"`
+}
+
+// AnnouncementListResponse is a page of announcements. UnreadCount is set
+// for signed-in users on the public list.
+type AnnouncementListResponse struct {
+	Announcements []Announcement `json:"announcements"`
+	TotalCount    int            `json:"totalCount"`
+	UnreadCount   *int           `json:"unreadCount,omitempty"`
+}
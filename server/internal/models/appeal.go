@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// Appeal message bounds
+const (
+	MinAppealMessageLength = 10
+	MaxAppealMessageLength = 2000
+)
+
+// AppealStatus is where an account appeal is in review
+type AppealStatus string
+
+const (
+	AppealStatusOpen       AppealStatus = "open"
+	AppealStatusReinstated AppealStatus = "reinstated" // the account was made active again
+	AppealStatusUpheld     AppealStatus = "upheld"     // the account stays disabled or suspended
+)
+
+// IsValidAppealStatus reports whether s is a supported appeal status
+func IsValidAppealStatus(s AppealStatus) bool {
+	return s == AppealStatusOpen || s == AppealStatusReinstated || s == AppealStatusUpheld
+}
+
+// AccountStatusNotice tells a disabled or suspended user why they can't sign
+// in. AppealToken lets them submit an appeal without a session.
+type AccountStatusNotice struct {
+	Status      UserStatus `json:"status"`
+	Reason      string     `json:"reason,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	AppealToken string     `json:"appealToken,omitempty"`
+}
+
+// AccountAppeal is a user's request to have their account reinstated
+type AccountAppeal struct {
+	ID           string `json:"id"`
+	UserID       string `json:"userId"`
+	UserEmail    string `json:"userEmail,omitempty"`
+	UserCallSign string `json:"userCallSign,omitempty"`
+	// The account's status and reason when the appeal was submitted
+	UserStatus     UserStatus   `json:"userStatus"`
+	StatusReason   string       `json:"statusReason,omitempty"`
+	Message        string       `json:"message"`
+	Status         AppealStatus `json:"status"`
+	ResolvedBy     string       `json:"resolvedBy,omitempty"`
+	ResolutionNote string       `json:"resolutionNote,omitempty"`
+	ResolvedAt     *time.Time   `json:"resolvedAt,omitempty"`
+	CreatedAt      time.Time    `json:"createdAt"`
+}
+
+// SubmitAppealParams is an appeal sent from the sign-in page
+type SubmitAppealParams struct {
+	AppealToken string `json:"appealToken"`
+	Message     string `json:"message"`
+}
+
+// ResolveAppealParams is an admin's decision on an open appeal
+type ResolveAppealParams struct {
+	Decision AppealStatus `json:"decision"` // reinstated or upheld
+	Note     string       `json:"note,omitempty"`
+}
+
+// AppealListParams filters the admin appeal queue
+type AppealListParams struct {
+	Status AppealStatus `json:"status,omitempty"`
+	Limit  int          `json:"limit,omitempty"`
+	Offset int          `json:"offset,omitempty"`
+}
+
+// AppealsResponse is a page of the admin appeal queue
+type AppealsResponse struct {
+	Appeals    []AccountAppeal `json:"appeals"`
+	TotalCount int             `json:"totalCount"`
+}
@@ -48,6 +48,11 @@ type Battery struct {
 	CreatedAt    time.Time        `json:"created_at"`
 	UpdatedAt    time.Time        `json:"updated_at"`
 
+	// Storage state: set when the battery is charged for a session and cleared
+	// once a discharge or storage log is recorded
+	ChargedAt            *time.Time `json:"charged_at,omitempty"`
+	StorageReminderDueAt *time.Time `json:"storage_reminder_due_at,omitempty"`
+
 	// Computed fields (populated on detail fetch)
 	TotalCycles    int        `json:"total_cycles,omitempty"`
 	LastLoggedDate *time.Time `json:"last_logged_date,omitempty"`
@@ -136,6 +141,22 @@ type CreateBatteryLogParams struct {
 	Notes         string          `json:"notes,omitempty"`
 }
 
+// MarkBatteryChargedParams defines parameters for marking a battery as charged
+// for a session
+type MarkBatteryChargedParams struct {
+	BatteryID       string `json:"battery_id"`
+	RemindAfterDays int    `json:"remind_after_days,omitempty"` // Defaults to the server setting
+}
+
+// ChargedBattery is a battery left charged past its storage reminder time
+type ChargedBattery struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"-"`
+	BatteryCode string    `json:"battery_code"`
+	Name        string    `json:"name,omitempty"`
+	ChargedAt   time.Time `json:"charged_at"`
+}
+
 // BatteryLogListResponse represents the response for listing logs
 type BatteryLogListResponse struct {
 	Logs       []BatteryLog `json:"logs"`
@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+// BlackboxLog is an uploaded blackbox file (.bbl or .bfl). A file holds
+// one log per arm, each summarized as a flight.
+type BlackboxLog struct {
+	ID              string           `json:"id"`
+	UserID          string           `json:"-"`
+	AircraftID      string           `json:"aircraftId,omitempty"`
+	FileName        string           `json:"fileName"`
+	FileSize        int64            `json:"fileSize"`
+	Checksum        string           `json:"checksum"`
+	Notes           string           `json:"notes,omitempty"`
+	Firmware        string           `json:"firmware,omitempty"`  // From the first flight
+	CraftName       string           `json:"craftName,omitempty"` // From the first flight
+	DurationSeconds float64          `json:"durationSeconds"`     // Total over all flights
+	ParseStatus     ParseStatus      `json:"parseStatus"`
+	ParseWarnings   []string         `json:"parseWarnings,omitempty"`
+	Flights         []BlackboxFlight `json:"flights"`
+	StoragePath     string           `json:"-"`
+	StorageBackend  string           `json:"-"`
+	CreatedAt       time.Time        `json:"createdAt"`
+}
+
+// BlackboxFlight summarizes one log in a blackbox file. Battery figures are
+// nil when the log has no voltage or current, or its firmware's units
+// aren't known.
+type BlackboxFlight struct {
+	Index           int                 `json:"index"`
+	Firmware        string              `json:"firmware,omitempty"`
+	CraftName       string              `json:"craftName,omitempty"`
+	Board           string              `json:"board,omitempty"`
+	StartedAt       *time.Time          `json:"startedAt,omitempty"`
+	DurationSeconds float64             `json:"durationSeconds"`
+	FrameCount      int                 `json:"frameCount"`
+	SampleRateHz    float64             `json:"sampleRateHz"`
+	MaxCurrentAmps  *float64            `json:"maxCurrentAmps,omitempty"`
+	AvgCurrentAmps  *float64            `json:"avgCurrentAmps,omitempty"`
+	StartVoltage    *float64            `json:"startVoltage,omitempty"`
+	MinVoltage      *float64            `json:"minVoltage,omitempty"`
+	MaxSagVolts     *float64            `json:"maxSagVolts,omitempty"` // Largest drop below the highest voltage in the second before
+	GyroNoise       []BlackboxGyroNoise `json:"gyroNoise,omitempty"`
+}
+
+// BlackboxGyroNoise is the noise on one gyro axis
+type BlackboxGyroNoise struct {
+	Axis   string              `json:"axis"`
+	PeakHz float64             `json:"peakHz"` // Strongest frequency at or above 100 Hz
+	Bands  []BlackboxNoiseBand `json:"bands"`
+}
+
+// BlackboxNoiseBand is the RMS gyro signal within a frequency band
+type BlackboxNoiseBand struct {
+	FromHz          float64 `json:"fromHz"`
+	ToHz            float64 `json:"toHz"`
+	RMSDegPerSecond float64 `json:"rmsDegPerSec"`
+}
+
+// CreateBlackboxLogParams describes an uploaded blackbox file
+type CreateBlackboxLogParams struct {
+	AircraftID string `json:"aircraftId,omitempty"`
+	FileName   string `json:"fileName"`
+	Notes      string `json:"notes,omitempty"`
+	Checksum   string `json:"checksum,omitempty"` // Expected SHA-256, hex
+}
+
+// BlackboxLogListParams filters a user's blackbox logs
+type BlackboxLogListParams struct {
+	AircraftID string `json:"aircraftId,omitempty"`
+	Limit      int    `json:"limit,omitempty"`
+	Offset     int    `json:"offset,omitempty"`
+}
+
+// BlackboxLogListResponse lists blackbox logs, newest first
+type BlackboxLogListResponse struct {
+	Logs       []BlackboxLog `json:"logs"`
+	TotalCount int           `json:"totalCount"`
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Brand is a canonical manufacturer name with the aliases that resolve to it
+type Brand struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Aliases   []string  `json:"aliases"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// CreateBrandParams represents the request to create a canonical brand
+type CreateBrandParams struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// UpdateBrandParams represents an admin update to a brand.
+// Name renames the canonical brand; Aliases replaces the alias list when provided.
+type UpdateBrandParams struct {
+	Name    *string   `json:"name,omitempty"`
+	Aliases *[]string `json:"aliases,omitempty"`
+}
+
+// MergeBrandsParams merges one or more source brands into a target brand
+type MergeBrandsParams struct {
+	SourceIDs []string `json:"sourceIds"`
+	TargetID  string   `json:"targetId"`
+}
+
+// BrandRewriteConflict describes a catalog row that could not be renamed
+// because the rewritten canonical key already belongs to another item
+type BrandRewriteConflict struct {
+	ItemID          string `json:"itemId"`
+	Brand           string `json:"brand"`
+	Model           string `json:"model"`
+	CanonicalKey    string `json:"canonicalKey"`
+	ConflictsWithID string `json:"conflictsWithId"`
+}
+
+// BrandRewriteResult summarizes catalog rows rewritten to a canonical brand
+type BrandRewriteResult struct {
+	Brand          *Brand                 `json:"brand"`
+	RewrittenCount int                    `json:"rewrittenCount"`
+	Conflicts      []BrandRewriteConflict `json:"conflicts"`
+}
+
+// BrandListResponse represents the admin brand list
+type BrandListResponse struct {
+	Brands     []Brand `json:"brands"`
+	TotalCount int     `json:"totalCount"`
+}
+
+// CatalogBrandStats counts one brand's catalog items by curation state, so
+// curators can work through the catalog brand by brand
+type CatalogBrandStats struct {
+	Brand               string                    `json:"brand"`
+	Total               int                       `json:"total"`
+	ByStatus            map[CatalogItemStatus]int `json:"byStatus"`
+	ByImageStatus       map[ImageStatus]int       `json:"byImageStatus"`
+	ByDescriptionStatus map[ImageStatus]int       `json:"byDescriptionStatus"`
+	LastUpdatedAt       *time.Time                `json:"lastUpdatedAt,omitempty"`
+	LastCuratedAt       *time.Time                `json:"lastCuratedAt,omitempty"`
+}
+
+// CatalogBrandStatsResponse is the admin per-brand catalog statistics list
+type CatalogBrandStatsResponse struct {
+	Brands      []CatalogBrandStats `json:"brands"`
+	TotalCount  int                 `json:"totalCount"`
+	GeneratedAt time.Time           `json:"generatedAt"`
+}
+
+// NormalizeBrandKey reduces a brand name to the key used for alias lookup, so
+// "T-Motor", "T Motor" and "tmotor" all resolve to the same alias entry
+func NormalizeBrandKey(brand string) string {
+	return CompactSearchTerm(brand)
+}
+
+// NormalizeBrandAliases trims aliases, drops blanks, and removes entries that
+// normalize to the same key (including the canonical name itself)
+func NormalizeBrandAliases(name string, aliases []string) []string {
+	seen := map[string]struct{}{NormalizeBrandKey(name): {}}
+	result := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		key := NormalizeBrandKey(alias)
+		if key == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, alias)
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeBrandKey(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"T-Motor", "tmotor"},
+		{"TMotor", "tmotor"},
+		{"t motor", "tmotor"},
+		{"Tiger Motor", "tigermotor"},
+		{"  ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeBrandKey(tt.input); got != tt.expected {
+				t.Errorf("NormalizeBrandKey(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeBrandAliases(t *testing.T) {
+	tests := []struct {
+		name     string
+		brand    string
+		aliases  []string
+		expected []string
+	}{
+		{
+			name:     "drops aliases matching canonical name",
+			brand:    "T-Motor",
+			aliases:  []string{"TMotor", "T Motor", "Tiger Motor"},
+			expected: []string{"Tiger Motor"},
+		},
+		{
+			name:     "trims and dedupes",
+			brand:    "TBS",
+			aliases:  []string{" Team BlackSheep ", "team-blacksheep", "", "!!"},
+			expected: []string{"Team BlackSheep"},
+		},
+		{
+			name:     "nil aliases",
+			brand:    "iFlight",
+			aliases:  nil,
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeBrandAliases(tt.brand, tt.aliases)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("NormalizeBrandAliases(%q, %v) = %v, want %v", tt.brand, tt.aliases, got, tt.expected)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"strings"
 	"time"
 )
@@ -15,6 +16,12 @@ const (
 	BuildStatusPendingReview BuildStatus = "PENDING_REVIEW"
 	BuildStatusPublished     BuildStatus = "PUBLISHED"
 	BuildStatusUnpublished   BuildStatus = "UNPUBLISHED"
+	// BuildStatusTemplate marks a curated starting point. Templates have no
+	// owner and are never listed with other builds.
+	BuildStatusTemplate BuildStatus = "TEMPLATE"
+	// BuildStatusArchived sets a draft aside. Archived drafts don't count
+	// toward the active draft limit and can't be edited until restored.
+	BuildStatusArchived BuildStatus = "ARCHIVED"
 )
 
 // NormalizeBuildStatus canonicalizes user-provided status values.
@@ -32,6 +39,10 @@ func NormalizeBuildStatus(status BuildStatus) BuildStatus {
 		return BuildStatusPublished
 	case string(BuildStatusUnpublished):
 		return BuildStatusUnpublished
+	case string(BuildStatusTemplate):
+		return BuildStatusTemplate
+	case string(BuildStatusArchived):
+		return BuildStatusArchived
 	default:
 		return status
 	}
@@ -41,7 +52,8 @@ func NormalizeBuildStatus(status BuildStatus) BuildStatus {
 type BuildSort string
 
 const (
-	BuildSortNewest BuildSort = "newest"
+	BuildSortNewest  BuildSort = "newest"
+	BuildSortPopular BuildSort = "popular" // most viewed first
 )
 
 // BuildPartInput is a request payload for setting a build part.
@@ -104,6 +116,8 @@ type BuildCatalogItem struct {
 	Variant  string            `json:"variant,omitempty"`
 	Status   CatalogItemStatus `json:"status"`
 	ImageURL string            `json:"imageUrl,omitempty"`
+	MSRP     *float64          `json:"msrp,omitempty"`
+	Specs    json.RawMessage   `json:"specs,omitempty"`
 }
 
 // DisplayName returns a formatted catalog item name.
@@ -143,22 +157,47 @@ func (p *BuildPilot) DisplayNameOrDefault() string {
 
 // Build is a curated or temporary parts list.
 type Build struct {
-	ID               string      `json:"id"`
-	OwnerUserID      string      `json:"ownerUserId,omitempty"`
-	ImageAssetID     string      `json:"-"`
-	Status           BuildStatus `json:"status"`
-	Token            string      `json:"-"`
-	ExpiresAt        *time.Time  `json:"expiresAt,omitempty"`
-	Title            string      `json:"title"`
-	Description      string      `json:"description,omitempty"`
-	SourceAircraftID string      `json:"sourceAircraftId,omitempty"`
-	CreatedAt        time.Time   `json:"createdAt"`
-	UpdatedAt        time.Time   `json:"updatedAt"`
-	PublishedAt      *time.Time  `json:"publishedAt,omitempty"`
-	Parts            []BuildPart `json:"parts,omitempty"`
-	Verified         bool        `json:"verified"`
-	MainImageURL     string      `json:"mainImageUrl,omitempty"`
-	Pilot            *BuildPilot `json:"pilot,omitempty"`
+	ID               string        `json:"id"`
+	TenantID         string        `json:"-"`
+	OwnerUserID      string        `json:"ownerUserId,omitempty"`
+	ImageAssetID     string        `json:"-"`
+	Status           BuildStatus   `json:"status"`
+	Token            string        `json:"-"`
+	ExpiresAt        *time.Time    `json:"expiresAt,omitempty"`
+	Title            string        `json:"title"`
+	Description      string        `json:"description,omitempty"`
+	SourceAircraftID string        `json:"sourceAircraftId,omitempty"`
+	CreatedAt        time.Time     `json:"createdAt"`
+	UpdatedAt        time.Time     `json:"updatedAt"`
+	PublishedAt      *time.Time    `json:"publishedAt,omitempty"`
+	ViewCount        int64         `json:"viewCount"`
+	Parts            []BuildPart   `json:"parts,omitempty"`
+	Verified         bool          `json:"verified"`
+	MainImageURL     string        `json:"mainImageUrl,omitempty"`
+	Images           []EntityImage `json:"images,omitempty"` // every image in order, on single-build responses
+	VideoURL         string        `json:"videoUrl,omitempty"`
+	Video            *BuildVideo   `json:"video,omitempty"` // embed details for VideoURL, on single-build responses
+	Pilot            *BuildPilot   `json:"pilot,omitempty"`
+	// Rejection is the moderator feedback on a draft that was sent back,
+	// on the owner's single-build response
+	Rejection *BuildModerationEvent `json:"rejection,omitempty"`
+	// Assignment is the moderator working on the build, on the moderation list
+	Assignment *ModerationAssignment `json:"assignment,omitempty"`
+	// QueuedAt and QueueAgeSeconds say how long the build has waited for
+	// moderation, on the same admin lists
+	QueuedAt        *time.Time `json:"queuedAt,omitempty"`
+	QueueAgeSeconds int64      `json:"queueAgeSeconds,omitempty"`
+}
+
+// BuildVideo describes the YouTube or Vimeo video linked from a build.
+// Title, author, and thumbnail come from the provider's oEmbed endpoint and
+// are left empty if it could not be reached.
+type BuildVideo struct {
+	Provider     string `json:"provider"` // "youtube" or "vimeo"
+	EmbedURL     string `json:"embedUrl"`
+	Title        string `json:"title,omitempty"`
+	AuthorName   string `json:"authorName,omitempty"`
+	ThumbnailURL string `json:"thumbnailUrl,omitempty"`
 }
 
 // CreateBuildParams defines payload for new authenticated builds.
@@ -173,6 +212,7 @@ type CreateBuildParams struct {
 type UpdateBuildParams struct {
 	Title       *string          `json:"title,omitempty"`
 	Description *string          `json:"description,omitempty"`
+	VideoURL    *string          `json:"videoUrl,omitempty"` // YouTube or Vimeo link; empty removes it. Not kept on temp builds
 	Parts       []BuildPartInput `json:"parts,omitempty"`
 }
 
@@ -182,14 +222,31 @@ type SetBuildImageParams struct {
 	ImageType string // "image/jpeg" or "image/png"
 	ImageData []byte
 	UploadID  string // approved token from /api/images/upload
+	Index     *int   // position to store the image at; nil replaces the primary image
 }
 
 // BuildListParams describes list query options.
 type BuildListParams struct {
 	Sort        BuildSort `json:"sort,omitempty"`
 	FrameFilter string    `json:"frameFilter,omitempty"`
+	Query       string    `json:"query,omitempty"` // full-text search on title and description
 	Limit       int       `json:"limit,omitempty"`
 	Offset      int       `json:"offset,omitempty"`
+
+	// RCProtocol and VideoSystem limit results to builds with a part linked
+	// to the ecosystem
+	RCProtocol  RCProtocol  `json:"rcProtocol,omitempty"`
+	VideoSystem VideoSystem `json:"videoSystem,omitempty"`
+
+	// OwnerUserIDs limits results to builds by these users, e.g. a group's members
+	OwnerUserIDs []string `json:"-"`
+
+	// Archived lists the owner's archived drafts instead of their other builds
+	Archived bool `json:"archived,omitempty"`
+
+	// IDs restricts results to these builds, in this order; set when the
+	// external search index has already matched Query
+	IDs []string `json:"-"`
 }
 
 // BuildModerationListParams describes admin moderation list query options.
@@ -202,10 +259,13 @@ type BuildModerationListParams struct {
 
 // BuildListResponse is returned by build list endpoints.
 type BuildListResponse struct {
-	Builds      []Build   `json:"builds"`
-	TotalCount  int       `json:"totalCount"`
-	Sort        BuildSort `json:"sort,omitempty"`
-	FrameFilter string    `json:"frameFilter,omitempty"`
+	Builds      []Build     `json:"builds"`
+	TotalCount  int         `json:"totalCount"`
+	Sort        BuildSort   `json:"sort,omitempty"`
+	FrameFilter string      `json:"frameFilter,omitempty"`
+	Query       string      `json:"query,omitempty"`
+	RCProtocol  RCProtocol  `json:"rcProtocol,omitempty"`
+	VideoSystem VideoSystem `json:"videoSystem,omitempty"`
 }
 
 // BuildValidationError is a single publish validation issue.
@@ -227,9 +287,170 @@ type BuildPublishResponse struct {
 	Validation BuildValidationResult `json:"validation"`
 }
 
+// TempBuildViewLink is a read-only link to a temp build. Its token can't be
+// used to edit or share the build, and it follows the build's later
+// revisions until the build expires.
+type TempBuildViewLink struct {
+	ViewToken string `json:"viewToken"`
+	URL       string `json:"url"`
+	// ShareURL is URL made absolute against the site's public base URL
+	ShareURL string `json:"shareUrl,omitempty"`
+}
+
+// TempBuildAdmissionStats counts anonymous temp build creation attempts by
+// outcome since the server started, and how many temp builds are open now.
+type TempBuildAdmissionStats struct {
+	Admitted      int64 `json:"admitted"`
+	RateLimited   int64 `json:"rateLimited"`
+	CaptchaFailed int64 `json:"captchaFailed"`
+	// CaptchaErrors are attempts let through because the captcha provider
+	// couldn't be reached
+	CaptchaErrors  int64 `json:"captchaErrors"`
+	AtCapacity     int64 `json:"atCapacity"`
+	Outstanding    int   `json:"outstanding"`
+	MaxOutstanding int   `json:"maxOutstanding"`
+	CaptchaEnabled bool  `json:"captchaEnabled"`
+}
+
 // TempBuildCreateResponse is returned after creating a temporary build.
 type TempBuildCreateResponse struct {
 	Build *Build `json:"build"`
 	Token string `json:"token"`
 	URL   string `json:"url"`
+	// ShareURL is URL made absolute against the site's public base URL
+	ShareURL string `json:"shareUrl,omitempty"`
+
+	// HandoffToken is sent with sign-in to give the builds of this
+	// anonymous session to the account signing in
+	HandoffToken     string     `json:"handoffToken,omitempty"`
+	HandoffExpiresAt *time.Time `json:"handoffExpiresAt,omitempty"`
+}
+
+// BuildHandoffResult lists the builds an account took over from the
+// anonymous session it signed in from. The latest temporary build becomes a
+// draft; shared snapshots stay shared under their new owner.
+type BuildHandoffResult struct {
+	DraftBuildID    string   `json:"draftBuildId,omitempty"`
+	ClaimedBuildIDs []string `json:"claimedBuildIds"`
+	// ArchivedBuildIDs are older drafts archived to keep the account within
+	// its active draft limit
+	ArchivedBuildIDs []string `json:"archivedBuildIds,omitempty"`
+}
+
+// BuildBOM is a bill of materials for a build. Parts that reference the same
+// catalog item are combined into one line with a quantity.
+type BuildBOM struct {
+	BuildID        string    `json:"buildId"`
+	Title          string    `json:"title"`
+	Lines          []BOMLine `json:"lines"`
+	MSRPTotal      float64   `json:"msrpTotal"`      // Sum of lines with a known MSRP
+	LivePriceTotal float64   `json:"livePriceTotal"` // Sum of lines with a live price
+	Currency       string    `json:"currency,omitempty"`
+	GeneratedAt    time.Time `json:"generatedAt"`
+}
+
+// BOMLine is one line of a bill of materials. Prices are per unit.
+type BOMLine struct {
+	GearType      GearType `json:"gearType"`
+	CatalogItemID string   `json:"catalogItemId,omitempty"`
+	Name          string   `json:"name"`
+	Quantity      int      `json:"quantity"`
+	Notes         string   `json:"notes,omitempty"`
+	MSRP          *float64 `json:"msrp,omitempty"`
+	LivePrice     *float64 `json:"livePrice,omitempty"`
+	Currency      string   `json:"currency,omitempty"`
+	Seller        string   `json:"seller,omitempty"`
+	InStock       *bool    `json:"inStock,omitempty"`
+	PurchaseURL   string   `json:"purchaseUrl,omitempty"`
+}
+
+// BuildGapAnalysis compares a build's parts with the caller's inventory
+type BuildGapAnalysis struct {
+	BuildID string         `json:"buildId"`
+	Title   string         `json:"title"`
+	Owned   []BuildGapLine `json:"owned"`
+	Missing []BuildGapLine `json:"missing"`
+	// EstimatedCost prices each missing unit at its live price, or its MSRP
+	// when there is none. Lines with neither are counted in UnpricedLines.
+	EstimatedCost float64 `json:"estimatedCost"`
+	UnpricedLines int     `json:"unpricedLines"`
+	Currency      string  `json:"currency,omitempty"`
+}
+
+// BuildGapLine is one bill of materials line with how many the caller
+// already owns and how many are still needed
+type BuildGapLine struct {
+	BOMLine
+	OwnedQuantity  int `json:"ownedQuantity"`
+	NeededQuantity int `json:"neededQuantity"`
+}
+
+// BuildFromAircraftResponse is a draft build created from an aircraft, along
+// with what the owner should fix before it can be published. Build fields are
+// inlined so clients that expect a plain build keep working.
+type BuildFromAircraftResponse struct {
+	*Build
+	CatalogMatches    []BuildPartCatalogMatch    `json:"catalogMatches,omitempty"`
+	SkippedComponents []SkippedAircraftComponent `json:"skippedComponents,omitempty"`
+}
+
+// ImportBuildParams is the request to import a parts list from another site
+type ImportBuildParams struct {
+	URL string `json:"url"` // a RotorBuilds build page or a Google Sheets share link
+}
+
+// ImportedBuildPart is one line of an imported parts list and how it matched
+// the catalog. Unresolved parts are left out of the build and listed with any
+// candidates found.
+type ImportedBuildPart struct {
+	GearType      GearType    `json:"gearType,omitempty"`
+	Name          string      `json:"name"`
+	Quantity      int         `json:"quantity"`
+	Resolved      bool        `json:"resolved"`
+	CatalogItemID string      `json:"catalogItemId,omitempty"`
+	Confidence    float64     `json:"confidence"`
+	Candidates    []NearMatch `json:"candidates,omitempty"`
+}
+
+// BuildImportResponse is returned after importing a parts list into a draft build
+type BuildImportResponse struct {
+	Build  *Build              `json:"build"`
+	Source string              `json:"source"` // rotorbuilds or sheets
+	Parts  []ImportedBuildPart `json:"parts"`
+}
+
+// BuildPreset is a Betaflight `diff all` shared with a build, with settings
+// that identify the pilot or their hardware removed.
+type BuildPreset struct {
+	BuildID         string        `json:"buildId"`
+	Diff            string        `json:"diff"`
+	FirmwareVersion string        `json:"firmwareVersion,omitempty"` // e.g. "4.4.2"
+	BoardTarget     string        `json:"boardTarget,omitempty"`     // e.g. "STM32F405"
+	BoardName       string        `json:"boardName,omitempty"`       // e.g. "MATEKF405"
+	RemovedSettings []string      `json:"removedSettings,omitempty"` // what was stripped before saving
+	ParsedTuning    *ParsedTuning `json:"parsedTuning,omitempty"`
+	UpdatedAt       time.Time     `json:"updatedAt"`
+}
+
+// SetBuildPresetParams is the request to attach a Betaflight diff to a build
+type SetBuildPresetParams struct {
+	Diff string `json:"diff"` // output of the Betaflight CLI `diff all`
+}
+
+// BuildPartCatalogMatch suggests published catalog items for a build part
+// whose catalog item is still awaiting review. Matches may be empty.
+type BuildPartCatalogMatch struct {
+	GearType      GearType    `json:"gearType"`
+	Position      int         `json:"position"`
+	CatalogItemID string      `json:"catalogItemId"`
+	Name          string      `json:"name"`
+	Matches       []NearMatch `json:"matches"`
+}
+
+// SkippedAircraftComponent is an aircraft component that was left out of a
+// build created from the aircraft
+type SkippedAircraftComponent struct {
+	Category ComponentCategory `json:"category"`
+	Name     string            `json:"name,omitempty"`
+	Reason   string            `json:"reason"`
 }
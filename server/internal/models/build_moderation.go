@@ -0,0 +1,93 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// BuildModerationAction is a moderator decision on a build
+type BuildModerationAction string
+
+const (
+	BuildModerationApproved BuildModerationAction = "approved"
+	BuildModerationRejected BuildModerationAction = "rejected"
+)
+
+// BuildRejectionCategory is the kind of problem a build was rejected for
+type BuildRejectionCategory string
+
+const (
+	BuildRejectionIncompleteParts   BuildRejectionCategory = "incomplete_parts"
+	BuildRejectionPoorImages        BuildRejectionCategory = "poor_images"
+	BuildRejectionInaccurateDetails BuildRejectionCategory = "inaccurate_details"
+	BuildRejectionInappropriate     BuildRejectionCategory = "inappropriate"
+	BuildRejectionDuplicate         BuildRejectionCategory = "duplicate"
+	BuildRejectionOther             BuildRejectionCategory = "other"
+)
+
+// buildRejectionLabels are shown to owners in rejection notifications
+var buildRejectionLabels = map[BuildRejectionCategory]string{
+	BuildRejectionIncompleteParts:   "Incomplete parts list",
+	BuildRejectionPoorImages:        "Images need work",
+	BuildRejectionInaccurateDetails: "Inaccurate details",
+	BuildRejectionInappropriate:     "Inappropriate content",
+	BuildRejectionDuplicate:         "Duplicate build",
+	BuildRejectionOther:             "Other",
+}
+
+// IsValid reports whether c is a known rejection category
+func (c BuildRejectionCategory) IsValid() bool {
+	_, ok := buildRejectionLabels[c]
+	return ok
+}
+
+// Label returns the category's display name
+func (c BuildRejectionCategory) Label() string {
+	return buildRejectionLabels[c]
+}
+
+// maxRejectionNoteLength bounds the free-text note on a rejection
+const maxRejectionNoteLength = 2000
+
+// RejectBuildParams is the structured reason for sending a build back
+type RejectBuildParams struct {
+	Category BuildRejectionCategory `json:"category"`
+	Note     string                 `json:"note,omitempty"`
+}
+
+// Normalize trims the note and lowercases the category
+func (p *RejectBuildParams) Normalize() {
+	p.Category = BuildRejectionCategory(strings.ToLower(strings.TrimSpace(string(p.Category))))
+	p.Note = strings.TrimSpace(p.Note)
+}
+
+// Validate checks the category and note. "other" needs a note saying what
+// to fix.
+func (p RejectBuildParams) Validate() string {
+	switch {
+	case !p.Category.IsValid():
+		return "invalid rejection category"
+	case p.Category == BuildRejectionOther && p.Note == "":
+		return "a note is required for category other"
+	case len(p.Note) > maxRejectionNoteLength:
+		return "note is too long"
+	}
+	return ""
+}
+
+// BuildModerationEvent is one entry in a build's moderation history
+type BuildModerationEvent struct {
+	ID              string                 `json:"id"`
+	BuildID         string                 `json:"buildId"`
+	Action          BuildModerationAction  `json:"action"`
+	Category        BuildRejectionCategory `json:"category,omitempty"`
+	Note            string                 `json:"note,omitempty"`
+	ModeratorUserID string                 `json:"moderatorUserId,omitempty"`
+	CreatedAt       time.Time              `json:"createdAt"`
+}
+
+// BuildRejectResponse is returned after rejecting a build
+type BuildRejectResponse struct {
+	Build     *Build                `json:"build"`
+	Rejection *BuildModerationEvent `json:"rejection"`
+}
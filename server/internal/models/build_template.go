@@ -0,0 +1,155 @@
+package models
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// BuildSpecFilter is one condition a catalog item's specs must meet to fill
+// a template slot. A numeric filter reads the first number in the spec value,
+// so "1950kv" and 1950 both match a KV range. A spec that is itself a range,
+// like an ESC's "3-6S", matches when the two ranges overlap. A value filter
+// matches the spec's text, ignoring case.
+type BuildSpecFilter struct {
+	Key    string   `json:"key"`
+	Min    *float64 `json:"min,omitempty"`
+	Max    *float64 `json:"max,omitempty"`
+	Values []string `json:"values,omitempty"`
+}
+
+// Matches reports whether specs meet the filter. An item without the spec
+// doesn't match.
+func (f BuildSpecFilter) Matches(specs map[string]interface{}) bool {
+	raw, ok := specs[f.Key]
+	if !ok || raw == nil {
+		return false
+	}
+
+	if len(f.Values) > 0 {
+		text := strings.TrimSpace(strings.ToLower(specText(raw)))
+		matched := false
+		for _, value := range f.Values {
+			if text == strings.TrimSpace(strings.ToLower(value)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if f.Min != nil || f.Max != nil {
+		low, high, ok := specRange(raw)
+		if !ok {
+			return false
+		}
+		if f.Min != nil && high < *f.Min {
+			return false
+		}
+		if f.Max != nil && low > *f.Max {
+			return false
+		}
+	}
+	return true
+}
+
+// specRange reads a spec value as a range; a single number is a range of one
+func specRange(value interface{}) (float64, float64, bool) {
+	if text, ok := value.(string); ok {
+		if lowText, highText, found := strings.Cut(text, "-"); found {
+			low, lowOK := SpecNumber(lowText)
+			high, highOK := SpecNumber(highText)
+			if lowOK && highOK && low <= high {
+				return low, high, true
+			}
+		}
+	}
+	number, ok := SpecNumber(value)
+	return number, number, ok
+}
+
+// MatchesSpecs reports whether a catalog item's specs meet every filter
+func MatchesSpecs(filters []BuildSpecFilter, specs json.RawMessage) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(specs, &fields); err != nil {
+		return false
+	}
+	for _, filter := range filters {
+		if !filter.Matches(fields) {
+			return false
+		}
+	}
+	return true
+}
+
+func specText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return ""
+	}
+}
+
+// SpecNumber reads a number from a spec value: a JSON number, or the leading
+// number of a string such as `5"`, "1950kv" or "6S"
+func SpecNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		text := strings.TrimSpace(v)
+		end := 0
+		for end < len(text) && (unicode.IsDigit(rune(text[end])) || text[end] == '.') {
+			end++
+		}
+		if end == 0 {
+			return 0, false
+		}
+		number, err := strconv.ParseFloat(strings.TrimSuffix(text[:end], "."), 64)
+		return number, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// BuildTemplateSlot is a placeholder part in a template
+type BuildTemplateSlot struct {
+	GearType    GearType          `json:"gearType"`
+	Position    int               `json:"position"`
+	Label       string            `json:"label,omitempty"`
+	SpecFilters []BuildSpecFilter `json:"specFilters"`
+}
+
+// BuildTemplate is a curated starting point for a flying style. It is stored
+// as a build with status TEMPLATE whose parts are placeholder slots.
+type BuildTemplate struct {
+	ID          string              `json:"id"`
+	Title       string              `json:"title"`
+	Description string              `json:"description,omitempty"`
+	Slots       []BuildTemplateSlot `json:"slots"`
+}
+
+// BuildTemplateListResponse lists the available templates
+type BuildTemplateListResponse struct {
+	Templates []BuildTemplate `json:"templates"`
+}
+
+// BuildFromTemplateResponse is the draft started from a template
+type BuildFromTemplateResponse struct {
+	Build      *Build `json:"build"`
+	TemplateID string `json:"templateId"`
+	// UnfilledSlots are slots no published part matched; the pilot picks
+	// these themselves
+	UnfilledSlots []BuildTemplateSlot `json:"unfilledSlots"`
+}
@@ -0,0 +1,54 @@
+package models
+
+// BuildWizardStyle is the kind of flying a wizard build is for
+type BuildWizardStyle string
+
+const (
+	BuildWizardFreestyle BuildWizardStyle = "freestyle"  // 5" on 6S
+	BuildWizardSub250    BuildWizardStyle = "sub250"     // 3.5" under 250 g
+	BuildWizardLongRange BuildWizardStyle = "long_range" // 7" with GPS
+)
+
+// BuildWizardVideo is the video answer: analog, or any digital system
+type BuildWizardVideo string
+
+const (
+	BuildWizardAnalog  BuildWizardVideo = "analog"
+	BuildWizardDigital BuildWizardVideo = "digital"
+)
+
+// BuildWizardParams are the beginner questionnaire's answers
+type BuildWizardParams struct {
+	// Budget is for the parts, in USD; 0 means no limit
+	Budget         float64          `json:"budget"`
+	Style          BuildWizardStyle `json:"style"`
+	Video          BuildWizardVideo `json:"video"`
+	RadioEcosystem RCProtocol       `json:"radioEcosystem"`
+}
+
+// BuildWizardCandidate is one suggested build
+type BuildWizardCandidate struct {
+	Rank int `json:"rank"`
+	// Strategy is how parts were picked: "popular" takes the most used part
+	// for each slot, "value" the cheapest, and "balanced" upgrades the value
+	// build toward the popular one while it stays within budget
+	Strategy string      `json:"strategy"`
+	Title    string      `json:"title"`
+	Parts    []BuildPart `json:"parts"`
+	Lines    []BOMLine   `json:"lines"`
+	// EstimatedCost prices each unit at its live price, or its MSRP when
+	// there is none. Lines with neither are counted in UnpricedLines.
+	EstimatedCost float64              `json:"estimatedCost"`
+	UnpricedLines int                  `json:"unpricedLines"`
+	Currency      string               `json:"currency,omitempty"`
+	WithinBudget  bool                 `json:"withinBudget"`
+	Compatibility []CompatibilityIssue `json:"compatibility"`
+	// MissingGearTypes had no published part matching the answers
+	MissingGearTypes []GearType `json:"missingGearTypes"`
+}
+
+// BuildWizardResponse holds the ranked candidates, best first
+type BuildWizardResponse struct {
+	Params     BuildWizardParams      `json:"params"`
+	Candidates []BuildWizardCandidate `json:"candidates"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CatalogPublishTrigger is the submission step a publish rule was checked at
+type CatalogPublishTrigger string
+
+const (
+	CatalogPublishOnSubmit CatalogPublishTrigger = "submit" // the item was created
+	CatalogPublishOnImage  CatalogPublishTrigger = "image"  // the submitter added an image
+)
+
+// CatalogPublishRule publishes pending catalog submissions that meet all of
+// its conditions without waiting for an admin. Rules are checked in priority
+// order, lowest first, and the first match wins.
+type CatalogPublishRule struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Enabled  bool   `json:"enabled"`
+	Priority int    `json:"priority"`
+	// GearTypes limits the rule to these types; empty means any type
+	GearTypes []GearType `json:"gearTypes"`
+	// MinPublishedSubmissions is how many of the submitter's earlier
+	// submissions must already be published
+	MinPublishedSubmissions int `json:"minPublishedSubmissions"`
+	// MinReputation is the reputation score the submitter needs
+	MinReputation int `json:"minReputation"`
+	// RequiredSpecs are spec keys that must have a value
+	RequiredSpecs      []string  `json:"requiredSpecs"`
+	RequireImage       bool      `json:"requireImage"` // an image that passed moderation
+	RequireDescription bool      `json:"requireDescription"`
+	UpdatedByUserID    string    `json:"updatedByUserId,omitempty"`
+	CreatedAt          time.Time `json:"createdAt"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+}
+
+// SetCatalogPublishRuleParams creates a publish rule or replaces one
+type SetCatalogPublishRuleParams struct {
+	Name                    string     `json:"name"`
+	Enabled                 bool       `json:"enabled"`
+	Priority                int        `json:"priority"`
+	GearTypes               []GearType `json:"gearTypes"`
+	MinPublishedSubmissions int        `json:"minPublishedSubmissions"`
+	MinReputation           int        `json:"minReputation"`
+	RequiredSpecs           []string   `json:"requiredSpecs"`
+	RequireImage            bool       `json:"requireImage"`
+	RequireDescription      bool       `json:"requireDescription"`
+}
+
+// CatalogPublishDecision records a catalog item published by a rule
+type CatalogPublishDecision struct {
+	ID              string                `json:"id"`
+	CatalogItemID   string                `json:"catalogItemId"`
+	RuleID          string                `json:"ruleId,omitempty"` // empty once the rule is deleted
+	RuleName        string                `json:"ruleName"`
+	Rule            json.RawMessage       `json:"rule"` // the rule as it was when it fired
+	SubmitterUserID string                `json:"submitterUserId,omitempty"`
+	Trigger         CatalogPublishTrigger `json:"trigger"`
+	DecidedAt       time.Time             `json:"decidedAt"`
+}
@@ -0,0 +1,68 @@
+package models
+
+// RCProtocol is an RC link ecosystem. A receiver only binds to a radio or
+// module speaking the same protocol.
+type RCProtocol string
+
+const (
+	RCProtocolELRS      RCProtocol = "elrs"
+	RCProtocolCrossfire RCProtocol = "crossfire"
+	RCProtocolGhost     RCProtocol = "ghost"
+	RCProtocolFrSky     RCProtocol = "frsky"
+)
+
+// IsValid reports whether p is a known RC protocol
+func (p RCProtocol) IsValid() bool {
+	switch p {
+	case RCProtocolELRS, RCProtocolCrossfire, RCProtocolGhost, RCProtocolFrSky:
+		return true
+	}
+	return false
+}
+
+// VideoSystem is an FPV video ecosystem. The VTX, camera and goggles all
+// have to be on the same one.
+type VideoSystem string
+
+const (
+	VideoSystemAnalog    VideoSystem = "analog"
+	VideoSystemDJI       VideoSystem = "dji"
+	VideoSystemHDZero    VideoSystem = "hdzero"
+	VideoSystemWalksnail VideoSystem = "walksnail"
+)
+
+// IsValid reports whether v is a known video system
+func (v VideoSystem) IsValid() bool {
+	switch v {
+	case VideoSystemAnalog, VideoSystemDJI, VideoSystemHDZero, VideoSystemWalksnail:
+		return true
+	}
+	return false
+}
+
+// CompatibilitySeverity says whether a combination can't work at all or
+// only needs a closer look
+type CompatibilitySeverity string
+
+const (
+	CompatibilityError   CompatibilitySeverity = "error"
+	CompatibilityWarning CompatibilitySeverity = "warning"
+)
+
+// Compatibility warning codes
+const (
+	CompatRCProtocolMismatch  = "rc_protocol_mismatch"
+	CompatVideoSystemMismatch = "video_system_mismatch"
+	CompatCellsOverRating     = "cells_over_rating"
+	CompatCellsUnderRating    = "cells_under_rating"
+	CompatPropTooLarge        = "prop_too_large"
+)
+
+// CompatibilityIssue is a problem found between two or more parts
+type CompatibilityIssue struct {
+	Code     string                `json:"code"`
+	Severity CompatibilitySeverity `json:"severity"`
+	Message  string                `json:"message"`
+	// ItemIDs are the catalog items involved
+	ItemIDs []string `json:"itemIds"`
+}
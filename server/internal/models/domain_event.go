@@ -0,0 +1,55 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DomainEventType names something that happened that external consumers,
+// such as analytics or a search indexer, may want to react to. It's also
+// the broker subject or topic, after the configured prefix.
+type DomainEventType string
+
+const (
+	DomainEventCatalogPublished DomainEventType = "catalog.published"
+	DomainEventBuildPublished   DomainEventType = "build.published"
+	DomainEventUserRegistered   DomainEventType = "user.registered"
+	DomainEventImageRejected    DomainEventType = "image.rejected"
+)
+
+// DomainEvent is one event in the outbox. Payloads carry IDs rather than
+// full records, and never email addresses; consumers look up what they need.
+type DomainEvent struct {
+	ID          string          `json:"id"`
+	Type        DomainEventType `json:"type"`
+	AggregateID string          `json:"aggregateId,omitempty"` // the catalog item, build, or user the event is about
+	TenantID    string          `json:"tenantId,omitempty"`
+	OccurredAt  time.Time       `json:"occurredAt"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// CatalogPublishedPayload is the payload of catalog.published. Via is
+// "admin" or "rule".
+type CatalogPublishedPayload struct {
+	CatalogItemID string `json:"catalogItemId"`
+	Via           string `json:"via"`
+}
+
+// BuildPublishedPayload is the payload of build.published
+type BuildPublishedPayload struct {
+	BuildID     string `json:"buildId"`
+	OwnerUserID string `json:"ownerUserId,omitempty"`
+}
+
+// UserRegisteredPayload is the payload of user.registered
+type UserRegisteredPayload struct {
+	UserID string `json:"userId"`
+}
+
+// ImageRejectedPayload is the payload of image.rejected
+type ImageRejectedPayload struct {
+	OwnerUserID string            `json:"ownerUserId,omitempty"`
+	EntityType  ImageEntityType   `json:"entityType"`
+	Reason      string            `json:"reason,omitempty"`
+	Labels      []ModerationLabel `json:"labels,omitempty"`
+}
@@ -0,0 +1,23 @@
+package models
+
+// Ecosystem is a registry entry for an RC protocol or video system
+type Ecosystem struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// ItemCount is how many published catalog items are linked to it
+	ItemCount int `json:"itemCount"`
+}
+
+// EcosystemRegistryResponse lists every RC protocol and video system
+type EcosystemRegistryResponse struct {
+	RCProtocols  []Ecosystem `json:"rcProtocols"`
+	VideoSystems []Ecosystem `json:"videoSystems"`
+}
+
+// CatalogEcosystemLinks are the ecosystems read from one catalog item
+type CatalogEcosystemLinks struct {
+	CatalogID   string
+	RCProtocols []RCProtocol
+	VideoSystem VideoSystem
+}
@@ -75,6 +75,36 @@ type SellerInfo struct {
 	Region      string   `json:"region,omitempty"`
 }
 
+// SellerCircuitState is the state of a seller's circuit breaker
+type SellerCircuitState string
+
+const (
+	SellerCircuitClosed   SellerCircuitState = "closed"    // Requests pass through
+	SellerCircuitOpen     SellerCircuitState = "open"      // Requests are skipped until the cooldown ends
+	SellerCircuitHalfOpen SellerCircuitState = "half_open" // One trial request is allowed
+)
+
+// SellerHealth reports how a seller adapter's requests have been going
+type SellerHealth struct {
+	ID                  string             `json:"id"`
+	Name                string             `json:"name"`
+	State               SellerCircuitState `json:"state"`
+	ConsecutiveFailures int                `json:"consecutiveFailures"`
+	TotalRequests       int64              `json:"totalRequests"`
+	TotalFailures       int64              `json:"totalFailures"`
+	SkippedRequests     int64              `json:"skippedRequests"`
+	AvgLatencyMs        float64            `json:"avgLatencyMs"`
+	LastSuccessAt       *time.Time         `json:"lastSuccessAt,omitempty"`
+	LastFailureAt       *time.Time         `json:"lastFailureAt,omitempty"`
+	LastError           string             `json:"lastError,omitempty"`
+	OpenUntil           *time.Time         `json:"openUntil,omitempty"`
+}
+
+// SellerHealthResponse lists the health of every registered seller
+type SellerHealthResponse struct {
+	Sellers []SellerHealth `json:"sellers"`
+}
+
 // EquipmentSearchParams defines parameters for searching equipment
 type EquipmentSearchParams struct {
 	Query       string            `json:"query,omitempty"`
@@ -85,7 +115,7 @@ type EquipmentSearchParams struct {
 	InStockOnly bool              `json:"inStockOnly,omitempty"`
 	Limit       int               `json:"limit,omitempty"`
 	Offset      int               `json:"offset,omitempty"`
-	Sort        string            `json:"sort,omitempty"` // "price_asc", "price_desc", "name", "newest"
+	Sort        string            `json:"sort,omitempty"` // "relevance" (default), "price_asc", "price_desc", "name"
 }
 
 // EquipmentSearchResponse represents the response from equipment search
@@ -101,6 +131,52 @@ type EquipmentSearchResponse struct {
 		PriceRange  []float64 `json:"priceRange,omitempty"`
 		InStockOnly bool      `json:"inStockOnly"`
 	} `json:"filters"`
+	Facets  *EquipmentFacets     `json:"facets,omitempty"`
+	Sellers []SellerSearchStatus `json:"sellers,omitempty"`
+	Partial bool                 `json:"partial,omitempty"` // Some sellers timed out, failed, or were skipped
+}
+
+// SellerSearchOutcome is how one seller's part of a search went
+type SellerSearchOutcome string
+
+const (
+	SellerSearchOK      SellerSearchOutcome = "ok"
+	SellerSearchTimeout SellerSearchOutcome = "timeout"
+	SellerSearchError   SellerSearchOutcome = "error"
+	SellerSearchSkipped SellerSearchOutcome = "skipped" // Circuit breaker open
+)
+
+// SellerSearchStatus reports one seller's part of a search
+type SellerSearchStatus struct {
+	ID        string              `json:"id"`
+	Status    SellerSearchOutcome `json:"status"`
+	ItemCount int                 `json:"itemCount"`
+	LatencyMs int64               `json:"latencyMs"`
+}
+
+// EquipmentFacets holds filter counts for a search. Each facet is counted
+// with every other active filter applied but not its own, so the UI can show
+// how many results each choice would give.
+type EquipmentFacets struct {
+	Categories   []FacetCount  `json:"categories"`
+	Sellers      []FacetCount  `json:"sellers"`
+	InStock      int           `json:"inStock"`
+	OutOfStock   int           `json:"outOfStock"`
+	PriceBuckets []PriceBucket `json:"priceBuckets"`
+}
+
+// FacetCount is the number of results for one facet value
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// PriceBucket is the number of results in a price range. Min is inclusive,
+// Max is exclusive, and a nil Max means no upper bound.
+type PriceBucket struct {
+	Min   float64  `json:"min"`
+	Max   *float64 `json:"max,omitempty"`
+	Count int      `json:"count"`
 }
 
 // SellersResponse represents the list of available sellers
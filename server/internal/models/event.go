@@ -0,0 +1,96 @@
+package models
+
+import "time"
+
+// EventVisibility controls who can see and register for an event
+type EventVisibility string
+
+const (
+	EventVisibilityPublic    EventVisibility = "public"
+	EventVisibilityFollowers EventVisibility = "followers" // people following the organizer
+	EventVisibilityGroup     EventVisibility = "group"     // members of the event's group
+)
+
+// IsValid reports whether v is a known event visibility
+func (v EventVisibility) IsValid() bool {
+	return v == EventVisibilityPublic || v == EventVisibilityFollowers || v == EventVisibilityGroup
+}
+
+// EventRegistrationStatus is the state of a pilot's registration
+type EventRegistrationStatus string
+
+const (
+	EventRegistrationRegistered EventRegistrationStatus = "registered"
+	EventRegistrationCancelled  EventRegistrationStatus = "cancelled"
+)
+
+// Event is a race day, meetup, or other session pilots can register for
+type Event struct {
+	ID              string          `json:"id"`
+	OrganizerUserID string          `json:"organizerUserId"`
+	OrganizerName   string          `json:"organizerName"`
+	GroupID         string          `json:"groupId,omitempty"`
+	Name            string          `json:"name"`
+	Description     string          `json:"description,omitempty"`
+	Location        string          `json:"location,omitempty"`
+	StartsAt        time.Time       `json:"startsAt"`
+	EndsAt          *time.Time      `json:"endsAt,omitempty"`
+	Visibility      EventVisibility `json:"visibility"`
+	MaxPilots       *int            `json:"maxPilots,omitempty"`
+	RegisteredCount int             `json:"registeredCount"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	UpdatedAt       time.Time       `json:"updatedAt"`
+
+	// The caller's registration status, if they have one
+	MyStatus EventRegistrationStatus `json:"myStatus,omitempty"`
+	// Whether the caller can see the event; not serialized
+	Visible bool `json:"-"`
+}
+
+// EventRegistration is a pilot signed up for an event
+type EventRegistration struct {
+	EventID      string                  `json:"eventId"`
+	UserID       string                  `json:"userId"`
+	CallSign     string                  `json:"callSign,omitempty"`
+	DisplayName  string                  `json:"displayName"`
+	AircraftID   string                  `json:"aircraftId,omitempty"`
+	AircraftName string                  `json:"aircraftName,omitempty"`
+	Status       EventRegistrationStatus `json:"status"`
+	RegisteredAt time.Time               `json:"registeredAt"`
+	CheckedInAt  *time.Time              `json:"checkedInAt,omitempty"`
+}
+
+// EventListParams describes event list query options
+type EventListParams struct {
+	From   time.Time // events ending (or starting, without an end) after this time
+	Limit  int
+	Offset int
+}
+
+// CreateEventParams represents the request to create an event
+type CreateEventParams struct {
+	GroupID     string          `json:"groupId,omitempty"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Location    string          `json:"location,omitempty"`
+	StartsAt    time.Time       `json:"startsAt"`
+	EndsAt      *time.Time      `json:"endsAt,omitempty"`
+	Visibility  EventVisibility `json:"visibility,omitempty"` // defaults to group for group events, else public
+	MaxPilots   *int            `json:"maxPilots,omitempty"`
+}
+
+// UpdateEventParams represents the request to update an event
+type UpdateEventParams struct {
+	Name        *string          `json:"name,omitempty"`
+	Description *string          `json:"description,omitempty"`
+	Location    *string          `json:"location,omitempty"`
+	StartsAt    *time.Time       `json:"startsAt,omitempty"`
+	EndsAt      *time.Time       `json:"endsAt,omitempty"`
+	Visibility  *EventVisibility `json:"visibility,omitempty"`
+	MaxPilots   *int             `json:"maxPilots,omitempty"`
+}
+
+// RegisterForEventParams represents a pilot's registration request
+type RegisterForEventParams struct {
+	AircraftID string `json:"aircraftId,omitempty"` // the aircraft they're bringing
+}
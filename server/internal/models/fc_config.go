@@ -31,15 +31,17 @@ type FlightControllerConfig struct {
 	InventoryItemID string           `json:"inventoryItemId"` // Links to the FC in inventory
 	Name            string           `json:"name"`            // User-given name for this config backup
 	Notes           string           `json:"notes,omitempty"`
-	RawCLIDump      string           `json:"rawCliDump"`      // Full CLI dump text, always preserved
-	FirmwareName    FCConfigFirmware `json:"firmwareName"`    // betaflight, inav, etc.
-	FirmwareVersion string           `json:"firmwareVersion"` // e.g., "4.4.2"
-	BoardTarget     string           `json:"boardTarget"`     // e.g., "STM32F405"
-	BoardName       string           `json:"boardName"`       // e.g., "MATEKF405"
-	MCUType         string           `json:"mcuType"`         // e.g., "STM32F405"
+	RawCLIDump      string           `json:"rawCliDump"`               // Full CLI dump text, always preserved
+	FirmwareName    FCConfigFirmware `json:"firmwareName"`             // betaflight, inav, etc.
+	FirmwareVersion string           `json:"firmwareVersion"`          // e.g., "4.4.2"
+	BoardTarget     string           `json:"boardTarget"`              // e.g., "STM32F405"
+	BoardName       string           `json:"boardName"`                // e.g., "MATEKF405"
+	MCUType         string           `json:"mcuType"`                  // e.g., "STM32F405"
+	FirmwareTarget  string           `json:"firmwareTarget,omitempty"` // Known Betaflight target matching the board
 	ParseStatus     ParseStatus      `json:"parseStatus"`
 	ParseWarnings   []string         `json:"parseWarnings,omitempty"`
 	ParsedTuning    *ParsedTuning    `json:"parsedTuning,omitempty"` // Extracted tuning data
+	OSDLayout       *OSDLayout       `json:"osdLayout,omitempty"`    // OSD element positions, if the dump has any
 	CreatedAt       time.Time        `json:"createdAt"`
 	UpdatedAt       time.Time        `json:"updatedAt"`
 }
@@ -324,3 +326,52 @@ type AircraftTuningResponse struct {
 	HasDiffBackup   bool             `json:"hasDiffBackup"`
 	DiffBackup      string           `json:"diffBackup,omitempty"`
 }
+
+// OSD video systems, from "set vcd_video_system"
+const (
+	OSDVideoAuto = "AUTO"
+	OSDVideoPAL  = "PAL"
+	OSDVideoNTSC = "NTSC"
+	OSDVideoHD   = "HD"
+)
+
+// OSDLayout is the set of OSD element positions in a Betaflight config
+type OSDLayout struct {
+	VideoSystem string       `json:"videoSystem,omitempty"` // AUTO, PAL, NTSC or HD
+	Elements    []OSDElement `json:"elements"`
+}
+
+// OSDElement is one "set osd_<name>_pos" setting. Value is the packed
+// position and profile visibility exactly as the firmware stores it; the
+// other fields are decoded from it.
+type OSDElement struct {
+	Name     string `json:"name"` // e.g. "vbat" for osd_vbat_pos
+	Value    int    `json:"value"`
+	X        int    `json:"x"`
+	Y        int    `json:"y"`
+	Profiles []int  `json:"profiles,omitempty"` // OSD profiles (1-3) the element is shown in
+}
+
+// OSDLayoutExport is an FC config's OSD layout with the CLI commands that restore it
+type OSDLayoutExport struct {
+	ConfigID string     `json:"configId"`
+	Layout   *OSDLayout `json:"layout"`
+	CLI      string     `json:"cli"` // "set osd_..._pos" lines to paste into the CLI
+}
+
+// ImportOSDLayoutParams replaces a config's OSD layout, either with another
+// config's layout or with one supplied directly
+type ImportOSDLayoutParams struct {
+	SourceConfigID string     `json:"sourceConfigId,omitempty"`
+	Layout         *OSDLayout `json:"layout,omitempty"`
+}
+
+// OSDPreview is a text rendering of an OSD layout on its video system's grid
+type OSDPreview struct {
+	VideoSystem string   `json:"videoSystem"`
+	Profile     int      `json:"profile"`
+	Columns     int      `json:"columns"`
+	Rows        int      `json:"rows"`
+	Lines       []string `json:"lines"`
+	Offscreen   []string `json:"offscreen,omitempty"` // Visible elements positioned outside the grid
+}
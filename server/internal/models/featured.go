@@ -0,0 +1,94 @@
+package models
+
+import "time"
+
+// FeaturedContentType identifies what kind of content a featured slot points at
+type FeaturedContentType string
+
+const (
+	FeaturedContentBuild FeaturedContentType = "build"
+	FeaturedContentGear  FeaturedContentType = "gear"
+)
+
+// IsValidFeaturedContentType reports whether t can be featured
+func IsValidFeaturedContentType(t FeaturedContentType) bool {
+	return t == FeaturedContentBuild || t == FeaturedContentGear
+}
+
+// FeaturedContentStatus describes where a featured slot is in its schedule
+type FeaturedContentStatus string
+
+const (
+	FeaturedStatusScheduled FeaturedContentStatus = "scheduled"
+	FeaturedStatusActive    FeaturedContentStatus = "active"
+	FeaturedStatusExpired   FeaturedContentStatus = "expired"
+)
+
+// FeaturedContent is an editorially scheduled build or catalog item shown on
+// the home page between StartsAt and EndsAt. A nil EndsAt features it until removed.
+type FeaturedContent struct {
+	ID              string                `json:"id"`
+	ContentType     FeaturedContentType   `json:"contentType"`
+	ContentID       string                `json:"contentId"`
+	Headline        string                `json:"headline,omitempty"`
+	Blurb           string                `json:"blurb,omitempty"`
+	Position        int                   `json:"position"`
+	StartsAt        time.Time             `json:"startsAt"`
+	EndsAt          *time.Time            `json:"endsAt,omitempty"`
+	Status          FeaturedContentStatus `json:"status"`
+	CreatedByUserID string                `json:"createdByUserId,omitempty"`
+	CreatedAt       time.Time             `json:"createdAt"`
+	UpdatedAt       time.Time             `json:"updatedAt"`
+}
+
+// CreateFeaturedContentParams represents an admin request to schedule featured content
+type CreateFeaturedContentParams struct {
+	ContentType FeaturedContentType `json:"contentType"`
+	ContentID   string              `json:"contentId"`
+	Headline    string              `json:"headline,omitempty"`
+	Blurb       string              `json:"blurb,omitempty"`
+	Position    int                 `json:"position"`
+	StartsAt    *time.Time          `json:"startsAt,omitempty"` // defaults to now
+	EndsAt      *time.Time          `json:"endsAt,omitempty"`
+}
+
+// UpdateFeaturedContentParams replaces the editable fields of a featured slot.
+// The featured content itself cannot be changed; schedule a new slot instead.
+type UpdateFeaturedContentParams struct {
+	Headline string     `json:"headline"`
+	Blurb    string     `json:"blurb"`
+	Position int        `json:"position"`
+	StartsAt time.Time  `json:"startsAt"`
+	EndsAt   *time.Time `json:"endsAt"`
+}
+
+// FeaturedContentListParams filters the admin list of featured slots
+type FeaturedContentListParams struct {
+	Status      FeaturedContentStatus `json:"status,omitempty"`
+	ContentType FeaturedContentType   `json:"contentType,omitempty"`
+	Limit       int                   `json:"limit,omitempty"`
+	Offset      int                   `json:"offset,omitempty"`
+}
+
+// FeaturedContentListResponse represents the admin list of featured slots
+type FeaturedContentListResponse struct {
+	Items      []FeaturedContent `json:"items"`
+	TotalCount int               `json:"totalCount"`
+}
+
+// FeaturedItem is an active featured slot with its content attached.
+// Exactly one of Build or Gear is set, matching ContentType.
+type FeaturedItem struct {
+	ID          string              `json:"id"`
+	ContentType FeaturedContentType `json:"contentType"`
+	Headline    string              `json:"headline,omitempty"`
+	Blurb       string              `json:"blurb,omitempty"`
+	EndsAt      *time.Time          `json:"endsAt,omitempty"`
+	Build       *Build              `json:"build,omitempty"`
+	Gear        *GearCatalogItem    `json:"gear,omitempty"`
+}
+
+// FeaturedResponse is the public list of currently featured content
+type FeaturedResponse struct {
+	Items []FeaturedItem `json:"items"`
+}
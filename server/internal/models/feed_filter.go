@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// FeedFilterKind is what a feed filter matches on
+type FeedFilterKind string
+
+const (
+	FeedFilterKeyword   FeedFilterKind = "keyword"    // a word or phrase in the title or summary
+	FeedFilterDomain    FeedFilterKind = "domain"     // the item's link is on the domain or a subdomain
+	FeedFilterMinLength FeedFilterKind = "min_length" // title and summary together are shorter than MinLength
+)
+
+// FeedFilter removes matching items from the aggregated feed
+type FeedFilter struct {
+	ID        string         `json:"id"`
+	Kind      FeedFilterKind `json:"kind"`
+	Pattern   string         `json:"pattern,omitempty"`   // keyword or domain
+	MinLength int            `json:"minLength,omitempty"` // for min_length filters, in characters
+	Sources   []string       `json:"sources"`             // source names it applies to; empty for all
+	Enabled   bool           `json:"enabled"`
+	Note      string         `json:"note,omitempty"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+// SaveFeedFilterParams creates or replaces a feed filter
+type SaveFeedFilterParams struct {
+	Kind      FeedFilterKind `json:"kind"`
+	Pattern   string         `json:"pattern"`
+	MinLength int            `json:"minLength"`
+	Sources   []string       `json:"sources"`
+	Enabled   *bool          `json:"enabled"` // defaults to true
+	Note      string         `json:"note"`
+}
+
+// FeedFilterPreview is what a filter would remove from the current feed
+type FeedFilterPreview struct {
+	Checked int        `json:"checked"`
+	Removed int        `json:"removed"`
+	Items   []FeedItem `json:"items"` // the first removed items
+}
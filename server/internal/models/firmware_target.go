@@ -0,0 +1,44 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// FirmwareTarget is a Betaflight board target, e.g. SPEEDYBEEF405V4
+type FirmwareTarget struct {
+	Target       string `json:"target"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	MCU          string `json:"mcu,omitempty"`
+	// RemovedAt is set once the target drops out of Betaflight's list
+	RemovedAt   *time.Time `json:"removedAt,omitempty"`
+	FirstSeenAt time.Time  `json:"firstSeenAt"`
+	LastSeenAt  time.Time  `json:"lastSeenAt"`
+}
+
+// FirmwareTargetListResponse is a page of targets matching a prefix
+type FirmwareTargetListResponse struct {
+	Targets []FirmwareTarget `json:"targets"`
+	Query   string           `json:"query,omitempty"`
+}
+
+// FirmwareTargetDetail is a target with the catalog boards linked to it
+type FirmwareTargetDetail struct {
+	FirmwareTarget
+	CatalogItems []GearCatalogItem `json:"catalogItems"`
+}
+
+// FirmwareTargetSyncResult summarizes one sync of the target list
+type FirmwareTargetSyncResult struct {
+	Targets            int   `json:"targets"`
+	Added              int   `json:"added"`
+	Removed            int64 `json:"removed"`
+	LinkedConfigs      int64 `json:"linkedConfigs"`
+	LinkedCatalogItems int64 `json:"linkedCatalogItems"`
+}
+
+// NormalizeFirmwareTarget puts a board target in the registry's form:
+// Betaflight target names are upper case with no spaces
+func NormalizeFirmwareTarget(target string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(target), ""))
+}
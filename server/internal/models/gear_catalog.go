@@ -3,9 +3,11 @@ package models
 import (
 	"encoding/json"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
 	"golang.org/x/text/unicode/norm"
 )
@@ -26,88 +28,19 @@ const (
 	GearTypeProp     GearType = "prop"
 	GearTypeRadio    GearType = "radio"
 	GearTypeCamera   GearType = "camera"
+	GearTypeHDUnit   GearType = "hd_unit" // digital camera and VTX in one unit
+	GearTypeGPS      GearType = "gps"
+	GearTypeBuzzer   GearType = "buzzer" // lost-model buzzer or finder
 	GearTypeOther    GearType = "other"
 )
 
-// AllGearTypes returns all valid gear types
+// AllGearTypes returns all valid gear types in display order
 func AllGearTypes() []GearType {
-	return []GearType{
-		GearTypeMotor,
-		GearTypeESC,
-		GearTypeFC,
-		GearTypeAIO,
-		GearTypeFrame,
-		GearTypeVTX,
-		GearTypeReceiver,
-		GearTypeAntenna,
-		GearTypeBattery,
-		GearTypeProp,
-		GearTypeRadio,
-		GearTypeCamera,
-		GearTypeOther,
-	}
-}
-
-// GearTypeFromEquipmentCategory converts an EquipmentCategory to a GearType
-func GearTypeFromEquipmentCategory(cat EquipmentCategory) GearType {
-	switch cat {
-	case CategoryMotors:
-		return GearTypeMotor
-	case CategoryESC:
-		return GearTypeESC
-	case CategoryFC:
-		return GearTypeFC
-	case CategoryAIO:
-		return GearTypeAIO
-	case CategoryFrames:
-		return GearTypeFrame
-	case CategoryVTX:
-		return GearTypeVTX
-	case CategoryReceivers:
-		return GearTypeReceiver
-	case CategoryAntennas:
-		return GearTypeAntenna
-	case CategoryBatteries:
-		return GearTypeBattery
-	case CategoryPropellers:
-		return GearTypeProp
-	case CategoryCameras:
-		return GearTypeCamera
-	default:
-		return GearTypeOther
-	}
-}
-
-// ToEquipmentCategory converts a GearType back to EquipmentCategory
-func (gt GearType) ToEquipmentCategory() EquipmentCategory {
-	switch gt {
-	case GearTypeMotor:
-		return CategoryMotors
-	case GearTypeESC:
-		return CategoryESC
-	case GearTypeFC:
-		return CategoryFC
-	case GearTypeAIO:
-		return CategoryAIO
-	case GearTypeFrame:
-		return CategoryFrames
-	case GearTypeVTX:
-		return CategoryVTX
-	case GearTypeReceiver:
-		return CategoryReceivers
-	case GearTypeAntenna:
-		return CategoryAntennas
-	case GearTypeBattery:
-		return CategoryBatteries
-	case GearTypeProp:
-		return CategoryPropellers
-	case GearTypeRadio:
-		return CategoryAccessories
-	case GearTypeCamera:
-		return CategoryCameras
-	default:
-		return CategoryAccessories
+	gearTypes := make([]GearType, len(gearTypeInfos))
+	for i, info := range gearTypeInfos {
+		gearTypes[i] = info.GearType
 	}
+	return gearTypes
 }
 
 // CatalogItemStatus represents the moderation status of a catalog item
@@ -181,15 +114,19 @@ type GearCatalogItem struct {
 	Model           string            `json:"model"`
 	Variant         string            `json:"variant,omitempty"`
 	Specs           json.RawMessage   `json:"specs,omitempty"`
-	BestFor         []string          `json:"bestFor,omitempty"` // Drone types: freestyle, long-range, cinematic, etc.
-	MSRP            *float64          `json:"msrp,omitempty"`    // Manufacturer suggested retail price
+	SpecOriginals   map[string]string `json:"specOriginals,omitempty"` // spec values as entered, before unit conversion; only loaded by Get
+	BestFor         []string          `json:"bestFor,omitempty"`       // Drone types: freestyle, long-range, cinematic, etc.
+	MSRP            *float64          `json:"msrp,omitempty"`          // Manufacturer suggested retail price
 	Source          CatalogItemSource `json:"source"`
 	CreatedByUserID string            `json:"createdByUserId,omitempty"`
 	Status          CatalogItemStatus `json:"status"`
 	CanonicalKey    string            `json:"canonicalKey"`
 	ImageURL        string            `json:"imageUrl,omitempty"`
+	ImageAssetID    string            `json:"-"`                // primary image asset; only loaded by Get
+	Images          []EntityImage     `json:"images,omitempty"` // every image in order, on single-item responses
 	Description     string            `json:"description,omitempty"`
-	UsageCount      int               `json:"usageCount"` // How many users have this in inventory
+	UsageCount      int               `json:"usageCount"`          // How many users have this in inventory
+	ViewCount       int64             `json:"viewCount,omitempty"` // Page views while published
 	CreatedAt       time.Time         `json:"createdAt"`
 	UpdatedAt       time.Time         `json:"updatedAt"`
 
@@ -202,6 +139,48 @@ type GearCatalogItem struct {
 	DescriptionStatus          ImageStatus `json:"descriptionStatus"`
 	DescriptionCuratedByUserID string      `json:"descriptionCuratedByUserId,omitempty"`
 	DescriptionCuratedAt       *time.Time  `json:"descriptionCuratedAt,omitempty"`
+
+	// Assignment is the moderator working on the item, on admin search results
+	Assignment *ModerationAssignment `json:"assignment,omitempty"`
+	// QueuedAt and QueueAgeSeconds say how long the item has waited for
+	// moderation, on the same admin lists
+	QueuedAt        *time.Time `json:"queuedAt,omitempty"`
+	QueueAgeSeconds int64      `json:"queueAgeSeconds,omitempty"`
+}
+
+// PublicGearCatalogItem is the subset of a catalog item exposed to third-party
+// tools. It leaves out moderation and curation details.
+type PublicGearCatalogItem struct {
+	ID           string          `json:"id"`
+	GearType     GearType        `json:"gearType"`
+	Brand        string          `json:"brand"`
+	Model        string          `json:"model"`
+	Variant      string          `json:"variant,omitempty"`
+	CanonicalKey string          `json:"canonicalKey"`
+	Specs        json.RawMessage `json:"specs,omitempty"`
+	BestFor      []string        `json:"bestFor,omitempty"`
+	MSRP         *float64        `json:"msrp,omitempty"`
+	Description  string          `json:"description,omitempty"`
+	ImageURL     string          `json:"imageUrl,omitempty"`
+	UpdatedAt    time.Time       `json:"updatedAt"`
+}
+
+// Public returns the fields of the item that third-party tools can see
+func (g *GearCatalogItem) Public() PublicGearCatalogItem {
+	return PublicGearCatalogItem{
+		ID:           g.ID,
+		GearType:     g.GearType,
+		Brand:        g.Brand,
+		Model:        g.Model,
+		Variant:      g.Variant,
+		CanonicalKey: g.CanonicalKey,
+		Specs:        g.Specs,
+		BestFor:      g.BestFor,
+		MSRP:         g.MSRP,
+		Description:  g.Description,
+		ImageURL:     g.ImageURL,
+		UpdatedAt:    g.UpdatedAt,
+	}
 }
 
 // DisplayName returns a formatted display name for the catalog item
@@ -260,19 +239,48 @@ type GearCatalogSearchParams struct {
 	Status   CatalogItemStatus `json:"status,omitempty"`
 	Limit    int               `json:"limit,omitempty"`
 	Offset   int               `json:"offset,omitempty"`
+	Debug    bool              `json:"-"` // Admin-only: include per-item relevance breakdown
+
+	// RCProtocol and VideoSystem limit results to items linked to the ecosystem
+	RCProtocol  RCProtocol  `json:"rcProtocol,omitempty"`
+	VideoSystem VideoSystem `json:"videoSystem,omitempty"`
+
+	// IDs restricts results to these items, in this order; set when the
+	// external search index has already matched the query
+	IDs []string `json:"-"`
 }
 
 // GearCatalogSearchResponse represents the response from a catalog search
 type GearCatalogSearchResponse struct {
-	Items      []GearCatalogItem `json:"items"`
-	TotalCount int               `json:"totalCount"`
-	Query      string            `json:"query,omitempty"`
+	Items      []GearCatalogItem       `json:"items"`
+	TotalCount int                     `json:"totalCount"`
+	Query      string                  `json:"query,omitempty"`
+	Debug      *GearCatalogSearchDebug `json:"debug,omitempty"`
+}
+
+// GearCatalogSearchDebug explains how a search query was interpreted and ranked.
+// Only populated for admin relevance debugging.
+type GearCatalogSearchDebug struct {
+	TSQuery      string                     `json:"tsQuery"`
+	CompactQuery string                     `json:"compactQuery"`
+	Trigram      bool                       `json:"trigram"` // False when pg_trgm is unavailable
+	Scores       []GearCatalogRelevanceInfo `json:"scores"`
+}
+
+// GearCatalogRelevanceInfo is the blended relevance breakdown for a single result.
+type GearCatalogRelevanceInfo struct {
+	ID           string  `json:"id"`
+	TextRank     float64 `json:"textRank"`
+	TrigramScore float64 `json:"trigramScore"`
+	Score        float64 `json:"score"`
 }
 
 // GearCatalogCreateResponse represents the response when creating/finding a catalog item
 type GearCatalogCreateResponse struct {
 	Item     *GearCatalogItem `json:"item"`
 	Existing bool             `json:"existing"` // True if we found an existing match instead of creating new
+	// AutoPublished is set when a publish rule skipped the pending queue
+	AutoPublished *CatalogPublishDecision `json:"autoPublished,omitempty"`
 }
 
 // NearMatch represents a potential duplicate found during catalog creation
@@ -286,7 +294,61 @@ type NearMatchResponse struct {
 	Matches []NearMatch `json:"matches"`
 }
 
-// BuildCanonicalKey creates a normalized key for deduplication
+// CanonicalKeyCollision records a catalog item whose recomputed canonical key is
+// already held by another item. Admins resolve these by merging or editing the pair.
+type CanonicalKeyCollision struct {
+	ID              string     `json:"id"`
+	CatalogID       string     `json:"catalogId"`
+	ConflictsWithID string     `json:"conflictsWithId"`
+	ProposedKey     string     `json:"proposedKey"`
+	Brand           string     `json:"brand"`
+	Model           string     `json:"model"`
+	Variant         string     `json:"variant,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	ResolvedAt      *time.Time `json:"resolvedAt,omitempty"`
+}
+
+// CanonicalKeyMigrationReport summarizes a canonical key recompute run
+type CanonicalKeyMigrationReport struct {
+	DryRun     bool                    `json:"dryRun"`
+	Total      int                     `json:"total"`
+	Updated    int                     `json:"updated"`
+	Unchanged  int                     `json:"unchanged"`
+	Collisions []CanonicalKeyCollision `json:"collisions"`
+}
+
+// SpecUnitNormalizationReport summarizes a pass converting catalog spec
+// values to canonical units
+type SpecUnitNormalizationReport struct {
+	DryRun  bool `json:"dryRun"`
+	Total   int  `json:"total"`
+	Updated int  `json:"updated"`
+}
+
+// InventoryDisplaySyncReport lists inventory items whose copied name or
+// manufacturer no longer match their catalog item
+type InventoryDisplaySyncReport struct {
+	DryRun   bool                         `json:"dryRun"`
+	Affected int                          `json:"affected"`
+	Changes  []InventoryDisplaySyncChange `json:"changes"` // The first MaxInventorySyncChanges
+}
+
+// MaxInventorySyncChanges caps the changes listed in a sync report
+const MaxInventorySyncChanges = 100
+
+// InventoryDisplaySyncChange is one inventory item's display fields before
+// and after a catalog sync
+type InventoryDisplaySyncChange struct {
+	InventoryItemID string `json:"inventoryItemId"`
+	CatalogID       string `json:"catalogId"`
+	OldName         string `json:"oldName"`
+	NewName         string `json:"newName"`
+	OldManufacturer string `json:"oldManufacturer"`
+	NewManufacturer string `json:"newManufacturer"`
+}
+
+// BuildCanonicalKey creates the legacy (v1) normalized key for deduplication.
+// New rows use BuildCanonicalKeyV2; v1 keys are kept for lookups of older rows.
 // Format: gear_type|brand|model|variant (all lowercase, normalized)
 func BuildCanonicalKey(gearType GearType, brand, model, variant string) string {
 	parts := []string{
@@ -302,6 +364,67 @@ func BuildCanonicalKey(gearType GearType, brand, model, variant string) string {
 	return strings.Join(parts, "|")
 }
 
+// CanonicalKeyVersion is the canonical key format new catalog rows are written with
+const CanonicalKeyVersion = 2
+
+// canonicalKeyV2Prefix keeps v2 keys disjoint from legacy keys so both can live
+// in the same unique column while rows are migrated.
+const canonicalKeyV2Prefix = "v2"
+
+// BuildCanonicalKeyV2 creates the v2 deduplication key.
+// Format: v2|gear_type|brand_key|model_tokens[|variant_key]
+//   - brand is expected to already be resolved through the alias table and is
+//     compacted ("T-Motor" and "TMotor" both become "tmotor")
+//   - model tokens are deduplicated and sorted so word order does not matter, and a
+//     leading repeat of the brand ("T-Motor F60") is dropped
+//   - variant is compacted ("1950 KV" and "1950kv" match)
+func BuildCanonicalKeyV2(gearType GearType, brand, model, variant string) string {
+	brandKey := NormalizeBrandKey(brand)
+	parts := []string{
+		canonicalKeyV2Prefix,
+		string(gearType),
+		brandKey,
+		canonicalModelTokens(brandKey, model),
+	}
+	if variantKey := CompactSearchTerm(variant); variantKey != "" {
+		parts = append(parts, variantKey)
+	}
+	return strings.Join(parts, "|")
+}
+
+// canonicalModelTokens returns the sorted, deduplicated model tokens with any
+// leading brand prefix removed
+func canonicalModelTokens(brandKey, model string) string {
+	tokens := strings.Fields(normalizeString(model))
+	if brandKey != "" {
+		prefix := ""
+		for i, token := range tokens {
+			prefix += token
+			if prefix == brandKey {
+				if i+1 < len(tokens) {
+					tokens = tokens[i+1:]
+				}
+				break
+			}
+			if !strings.HasPrefix(brandKey, prefix) {
+				break
+			}
+		}
+	}
+
+	seen := make(map[string]struct{}, len(tokens))
+	unique := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if _, ok := seen[token]; ok {
+			continue
+		}
+		seen[token] = struct{}{}
+		unique = append(unique, token)
+	}
+	sort.Strings(unique)
+	return strings.Join(unique, " ")
+}
+
 // normalizeString normalizes a string for canonical key generation
 func normalizeString(s string) string {
 	// 1. Normalize unicode (NFC form)
@@ -327,6 +450,85 @@ func normalizeString(s string) string {
 	return s
 }
 
+// catalogSearchSynonyms maps common FPV shorthand to the spelled-out term used in
+// catalog entries. Lookups are symmetric: either form expands to both.
+var catalogSearchSynonyms = map[string][]string{
+	"crsf": {"crossfire"},
+	"elrs": {"expresslrs"},
+	"hdz":  {"hdzero"},
+	"rx":   {"receiver"},
+	"vtx":  {"video", "transmitter"},
+	"fc":   {"flight", "controller"},
+}
+
+// BuildCatalogSearchTSQuery converts free text into a prefix-matching to_tsquery
+// expression. Hyphenated words become a phrase with only the last fragment
+// prefix-matched ("t-mot" -> "(t <-> mot:*)"), and one-character fragments are
+// never prefix-matched, since "t:*" would match every word starting with "t".
+// Known abbreviations are OR-ed with their expansion so "crsf" also matches
+// "Crossfire". Returns "" when nothing searchable remains.
+func BuildCatalogSearchTSQuery(query string) string {
+	clauses := make([]string, 0)
+	for _, word := range strings.Fields(query) {
+		fragments := strings.Fields(normalizeString(word))
+		if len(fragments) > 1 && strings.Contains(word, "-") {
+			terms := make([]string, len(fragments))
+			for i, fragment := range fragments {
+				terms[i] = fragment
+				if i == len(fragments)-1 {
+					terms[i] = catalogSearchPrefix(fragment)
+				}
+			}
+			clauses = append(clauses, "("+strings.Join(terms, " <-> ")+")")
+			continue
+		}
+		for _, token := range fragments {
+			clauses = append(clauses, catalogSearchClause(token))
+		}
+	}
+	return strings.Join(clauses, " & ")
+}
+
+// catalogSearchClause prefix-matches a single token, OR-ed with its synonyms
+func catalogSearchClause(token string) string {
+	alternatives := []string{catalogSearchPrefix(token)}
+	if expansion, ok := catalogSearchSynonyms[token]; ok {
+		parts := make([]string, 0, len(expansion))
+		for _, e := range expansion {
+			parts = append(parts, e+":*")
+		}
+		if len(parts) == 1 {
+			alternatives = append(alternatives, parts[0])
+		} else {
+			alternatives = append(alternatives, "("+strings.Join(parts, " & ")+")")
+		}
+	}
+	for abbrev, expansion := range catalogSearchSynonyms {
+		if len(expansion) == 1 && expansion[0] == token {
+			alternatives = append(alternatives, abbrev+":*")
+		}
+	}
+	if len(alternatives) == 1 {
+		return alternatives[0]
+	}
+	return "(" + strings.Join(alternatives, " | ") + ")"
+}
+
+// catalogSearchPrefix returns the prefix-matching term for a token, or the
+// token itself when it is a single character
+func catalogSearchPrefix(token string) string {
+	if utf8.RuneCountInString(token) < 2 {
+		return token
+	}
+	return token + ":*"
+}
+
+// CompactSearchTerm strips case, punctuation, and whitespace so "T-Motor F60" and
+// "tmotor f60" compare equal for trigram similarity.
+func CompactSearchTerm(s string) string {
+	return strings.ReplaceAll(normalizeString(s), " ", "")
+}
+
 // removeDiacritics removes diacritical marks from a string
 func removeDiacritics(s string) string {
 	t := norm.NFD.String(s)
@@ -408,3 +610,55 @@ func extractVariant(s string) (model, variant string) {
 	// No variant found, entire string is the model
 	return s, ""
 }
+
+// GearImageCandidate is a product image found for a catalog item. It has
+// passed moderation and waits for an admin to pick it as the item's image.
+type GearImageCandidate struct {
+	ID           string    `json:"id"`
+	GearID       string    `json:"gearId"`
+	ImageAssetID string    `json:"imageAssetId"`
+	ImageType    string    `json:"imageType"`
+	SourceName   string    `json:"sourceName"`        // seller name or manufacturer host
+	SourceURL    string    `json:"sourceUrl"`         // where the image was downloaded from
+	PageURL      string    `json:"pageUrl,omitempty"` // product page the image was found on
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// FindGearImagesParams requests an image search for a catalog item
+type FindGearImagesParams struct {
+	// PageURLs are manufacturer product pages to check in addition to seller searches
+	PageURLs []string `json:"pageUrls,omitempty"`
+}
+
+// FindGearImagesResponse reports the result of an image search
+type FindGearImagesResponse struct {
+	Candidates []GearImageCandidate `json:"candidates"` // every pending candidate for the item
+	Added      int                  `json:"added"`
+	Rejected   int                  `json:"rejected"` // images that failed moderation
+	Failed     int                  `json:"failed"`   // images that couldn't be downloaded or decoded
+}
+
+// GearImageBatchStatus is the outcome of one file in a batch image upload
+type GearImageBatchStatus string
+
+const (
+	GearImageBatchAttached      GearImageBatchStatus = "attached"
+	GearImageBatchRejected      GearImageBatchStatus = "rejected"       // failed moderation
+	GearImageBatchPendingReview GearImageBatchStatus = "pending_review" // moderation unavailable; not attached
+	GearImageBatchFailed        GearImageBatchStatus = "failed"         // unreadable, unmatched or not saved
+)
+
+// GearImageBatchResult reports what happened to one uploaded file
+type GearImageBatchResult struct {
+	File   string               `json:"file"`
+	GearID string               `json:"gearId,omitempty"`
+	Status GearImageBatchStatus `json:"status"`
+	Error  string               `json:"error,omitempty"`
+}
+
+// GearImageBatchResponse reports a batch image upload, one result per file
+type GearImageBatchResponse struct {
+	Results  []GearImageBatchResult `json:"results"`
+	Attached int                    `json:"attached"`
+	Failed   int                    `json:"failed"` // every file that wasn't attached
+}
@@ -303,7 +303,7 @@ func TestAllGearTypes(t *testing.T) {
 	gearTypes := AllGearTypes()
 
 	// Should include all defined gear types
-	expectedCount := 13 // motor, esc, fc, aio, frame, vtx, receiver, antenna, battery, prop, radio, camera, other
+	expectedCount := 16 // motor, esc, fc, aio, frame, vtx, receiver, antenna, battery, prop, radio, camera, hd_unit, gps, buzzer, other
 	if len(gearTypes) != expectedCount {
 		t.Errorf("AllGearTypes() returned %d types, want %d", len(gearTypes), expectedCount)
 	}
@@ -312,7 +312,8 @@ func TestAllGearTypes(t *testing.T) {
 	expectedTypes := []GearType{
 		GearTypeMotor, GearTypeESC, GearTypeFC, GearTypeAIO,
 		GearTypeFrame, GearTypeVTX, GearTypeReceiver, GearTypeAntenna,
-		GearTypeBattery, GearTypeProp, GearTypeRadio, GearTypeCamera, GearTypeOther,
+		GearTypeBattery, GearTypeProp, GearTypeRadio, GearTypeCamera,
+		GearTypeHDUnit, GearTypeGPS, GearTypeBuzzer, GearTypeOther,
 	}
 
 	for _, expected := range expectedTypes {
@@ -463,3 +464,125 @@ func TestNormalizeString_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildCatalogSearchTSQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "empty", input: "", expected: ""},
+		{name: "punctuation only", input: " -- ", expected: ""},
+		{name: "single token prefix", input: "Tmotor", expected: "tmotor:*"},
+		{name: "hyphenated word is a phrase", input: "T-Mot", expected: "(t <-> mot:*)"},
+		{name: "hyphenated word with more fragments", input: "go-pro-hero", expected: "(go <-> pro <-> hero:*)"},
+		{name: "single character is not a prefix", input: "x 2207", expected: "x & 2207:*"},
+		{name: "other punctuation splits tokens", input: "F7/Pro", expected: "f7:* & pro:*"},
+		{name: "abbreviation expands", input: "crsf", expected: "(crsf:* | crossfire:*)"},
+		{name: "expansion maps back to abbreviation", input: "ExpressLRS nano", expected: "(expresslrs:* | elrs:*) & nano:*"},
+		{name: "multi word expansion", input: "vtx", expected: "(vtx:* | (video:* & transmitter:*))"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := BuildCatalogSearchTSQuery(tt.input)
+			if result != tt.expected {
+				t.Errorf("BuildCatalogSearchTSQuery(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompactSearchTerm(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"T-Motor F60 Pro", "tmotorf60pro"},
+		{"tmotor", "tmotor"},
+		{"  ", ""},
+		{"Café Ü", "cafeu"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := CompactSearchTerm(tt.input)
+			if result != tt.expected {
+				t.Errorf("CompactSearchTerm(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildCanonicalKeyV2(t *testing.T) {
+	tests := []struct {
+		name     string
+		gearType GearType
+		brand    string
+		model    string
+		variant  string
+		expected string
+	}{
+		{
+			name:     "brand punctuation collapses",
+			gearType: GearTypeMotor,
+			brand:    "T-Motor",
+			model:    "F60 Pro",
+			expected: "v2|motor|tmotor|f60 pro",
+		},
+		{
+			name:     "model token order does not matter",
+			gearType: GearTypeMotor,
+			brand:    "TMotor",
+			model:    "Pro F60",
+			expected: "v2|motor|tmotor|f60 pro",
+		},
+		{
+			name:     "brand repeated in model is dropped",
+			gearType: GearTypeMotor,
+			brand:    "T-Motor",
+			model:    "T Motor F60 Pro",
+			expected: "v2|motor|tmotor|f60 pro",
+		},
+		{
+			name:     "model equal to brand is kept",
+			gearType: GearTypeFrame,
+			brand:    "Nazgul",
+			model:    "Nazgul",
+			expected: "v2|frame|nazgul|nazgul",
+		},
+		{
+			name:     "duplicate tokens removed",
+			gearType: GearTypeESC,
+			brand:    "Hobbywing",
+			model:    "XRotor XRotor 60A",
+			expected: "v2|esc|hobbywing|60a xrotor",
+		},
+		{
+			name:     "variant compacted",
+			gearType: GearTypeMotor,
+			brand:    "T-Motor",
+			model:    "F60 Pro",
+			variant:  "1950 KV",
+			expected: "v2|motor|tmotor|f60 pro|1950kv",
+		},
+		{
+			name:     "blank variant omitted",
+			gearType: GearTypeMotor,
+			brand:    "T-Motor",
+			model:    "F60 Pro",
+			variant:  "  ",
+			expected: "v2|motor|tmotor|f60 pro",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := BuildCanonicalKeyV2(tt.gearType, tt.brand, tt.model, tt.variant)
+			if result != tt.expected {
+				t.Errorf("BuildCanonicalKeyV2(%q, %q, %q, %q) = %q, want %q",
+					tt.gearType, tt.brand, tt.model, tt.variant, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package models
+
+// GearTypeInfo describes a gear type for clients: how to label and order it
+// and whether a build needs one before it can be published
+type GearTypeInfo struct {
+	GearType          GearType          `json:"gearType"`
+	Label             string            `json:"label"`
+	PluralLabel       string            `json:"pluralLabel"`
+	LabelKey          string            `json:"labelKey"` // translation key for localized clients
+	Icon              string            `json:"icon"`
+	EquipmentCategory EquipmentCategory `json:"equipmentCategory"`
+	Order             int               `json:"order"`
+	// RequiredForPublish means a build needs this part to be published
+	RequiredForPublish bool `json:"requiredForPublish"`
+	// PowerStack marks the AIO, FC and ESC types. A published build needs
+	// either an AIO or both an FC and an ESC.
+	PowerStack bool `json:"powerStack"`
+	// Satisfies is a required type this one can stand in for when publishing
+	Satisfies GearType `json:"satisfies,omitempty"`
+}
+
+// GearTypesResponse is the response for the gear type metadata endpoint
+type GearTypesResponse struct {
+	Locale              string                  `json:"locale"`
+	GearTypes           []GearTypeInfo          `json:"gearTypes"`
+	EquipmentCategories []EquipmentCategoryInfo `json:"equipmentCategories"`
+}
+
+// gearTypeInfos lists every gear type in display order. EquipmentCategory
+// is the inventory category an item of the type is filed under.
+var gearTypeInfos = []GearTypeInfo{
+	{GearType: GearTypeMotor, Label: "Motors", PluralLabel: "Motors", Icon: "🔄", EquipmentCategory: CategoryMotors, RequiredForPublish: true},
+	{GearType: GearTypeESC, Label: "ESC", PluralLabel: "ESCs", Icon: "⚡", EquipmentCategory: CategoryESC, PowerStack: true},
+	{GearType: GearTypeFC, Label: "Flight Controller", PluralLabel: "Flight Controllers", Icon: "🧠", EquipmentCategory: CategoryFC, PowerStack: true},
+	{GearType: GearTypeAIO, Label: "AIO", PluralLabel: "AIO (FC/ESC)", Icon: "🔌", EquipmentCategory: CategoryAIO, PowerStack: true},
+	{GearType: GearTypeFrame, Label: "Frame", PluralLabel: "Frames", Icon: "🏗️", EquipmentCategory: CategoryFrames, RequiredForPublish: true},
+	{GearType: GearTypeVTX, Label: "VTX", PluralLabel: "Video Transmitters", Icon: "📺", EquipmentCategory: CategoryVTX, RequiredForPublish: true},
+	{GearType: GearTypeReceiver, Label: "Receiver", PluralLabel: "Receivers", Icon: "📡", EquipmentCategory: CategoryReceivers, RequiredForPublish: true},
+	{GearType: GearTypeAntenna, Label: "Antenna", PluralLabel: "Antennas", Icon: "📶", EquipmentCategory: CategoryAntennas},
+	{GearType: GearTypeBattery, Label: "Battery", PluralLabel: "Batteries", Icon: "🔋", EquipmentCategory: CategoryBatteries},
+	{GearType: GearTypeProp, Label: "Propellers", PluralLabel: "Propellers", Icon: "🍃", EquipmentCategory: CategoryPropellers},
+	{GearType: GearTypeRadio, Label: "Radio", PluralLabel: "Radios", Icon: "🎮", EquipmentCategory: CategoryAccessories},
+	{GearType: GearTypeCamera, Label: "Camera", PluralLabel: "Cameras", Icon: "📷", EquipmentCategory: CategoryCameras},
+	{GearType: GearTypeHDUnit, Label: "HD Unit", PluralLabel: "HD Units (Camera + VTX)", Icon: "🎥", EquipmentCategory: CategoryVTX, Satisfies: GearTypeVTX},
+	{GearType: GearTypeGPS, Label: "GPS", PluralLabel: "GPS Modules", Icon: "🛰️", EquipmentCategory: CategoryAccessories},
+	{GearType: GearTypeBuzzer, Label: "Buzzer", PluralLabel: "Buzzers", Icon: "🔔", EquipmentCategory: CategoryAccessories},
+	{GearType: GearTypeOther, Label: "Other", PluralLabel: "Other", Icon: "📦", EquipmentCategory: CategoryAccessories},
+}
+
+// GearTypeInfos returns metadata for every gear type in display order
+func GearTypeInfos() []GearTypeInfo {
+	infos := make([]GearTypeInfo, len(gearTypeInfos))
+	for i, info := range gearTypeInfos {
+		info.LabelKey = "gearTypes." + string(info.GearType)
+		info.Order = i
+		infos[i] = info
+	}
+	return infos
+}
+
+// LookupGearType returns the metadata for a gear type
+func LookupGearType(gearType GearType) (GearTypeInfo, bool) {
+	for _, info := range GearTypeInfos() {
+		if info.GearType == gearType {
+			return info, true
+		}
+	}
+	return GearTypeInfo{}, false
+}
+
+// IsValid reports whether the gear type is known
+func (gt GearType) IsValid() bool {
+	_, ok := LookupGearType(gt)
+	return ok
+}
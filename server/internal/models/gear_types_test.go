@@ -0,0 +1,43 @@
+package models
+
+import "testing"
+
+func TestGearTypeInfos_CoverAllGearTypes(t *testing.T) {
+	infos := GearTypeInfos()
+	gearTypes := AllGearTypes()
+	if len(infos) != len(gearTypes) {
+		t.Fatalf("got %d infos for %d gear types", len(infos), len(gearTypes))
+	}
+	for i, info := range infos {
+		if info.GearType != gearTypes[i] || info.Order != i {
+			t.Errorf("infos[%d] = %s (order %d), want %s", i, info.GearType, info.Order, gearTypes[i])
+		}
+		if info.Label == "" || info.PluralLabel == "" || info.Icon == "" {
+			t.Errorf("%s is missing display metadata: %+v", info.GearType, info)
+		}
+		if info.LabelKey != "gearTypes."+string(info.GearType) {
+			t.Errorf("%s labelKey = %q", info.GearType, info.LabelKey)
+		}
+		if info.EquipmentCategory != info.GearType.ToEquipmentCategory() {
+			t.Errorf("%s equipmentCategory = %s", info.GearType, info.EquipmentCategory)
+		}
+	}
+}
+
+func TestGearType_IsValid(t *testing.T) {
+	if !GearTypeVTX.IsValid() {
+		t.Error("vtx should be valid")
+	}
+	if GearType("gimbal").IsValid() {
+		t.Error("unknown gear type should not be valid")
+	}
+
+	info, ok := LookupGearType(GearTypeFrame)
+	if !ok || !info.RequiredForPublish || info.PowerStack {
+		t.Errorf("frame info = %+v", info)
+	}
+	info, _ = LookupGearType(GearTypeAIO)
+	if info.RequiredForPublish || !info.PowerStack {
+		t.Errorf("aio info = %+v", info)
+	}
+}
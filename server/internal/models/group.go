@@ -0,0 +1,123 @@
+package models
+
+import "time"
+
+// GroupRole is a member's role within a group
+type GroupRole string
+
+const (
+	GroupRoleOwner  GroupRole = "owner"
+	GroupRoleAdmin  GroupRole = "admin"
+	GroupRoleMember GroupRole = "member"
+)
+
+// IsValid reports whether r is a known group role
+func (r GroupRole) IsValid() bool {
+	return r == GroupRoleOwner || r == GroupRoleAdmin || r == GroupRoleMember
+}
+
+// CanManage reports whether the role can edit the group and invite members
+func (r GroupRole) CanManage() bool {
+	return r == GroupRoleOwner || r == GroupRoleAdmin
+}
+
+// GroupInvitationStatus is the state of a group invitation
+type GroupInvitationStatus string
+
+const (
+	GroupInvitationPending  GroupInvitationStatus = "pending"
+	GroupInvitationAccepted GroupInvitationStatus = "accepted"
+	GroupInvitationDeclined GroupInvitationStatus = "declined"
+	GroupInvitationRevoked  GroupInvitationStatus = "revoked"
+)
+
+// Group is a club or crew of pilots sharing a fleet
+type Group struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description,omitempty"`
+	CreatedByUserID string    `json:"createdByUserId"`
+	MemberCount     int       `json:"memberCount"`
+	Role            GroupRole `json:"role,omitempty"` // the caller's role, when they are a member
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// GroupMember is a user's membership in a group
+type GroupMember struct {
+	UserID      string    `json:"userId"`
+	CallSign    string    `json:"callSign,omitempty"`
+	DisplayName string    `json:"displayName"`
+	Role        GroupRole `json:"role"`
+	JoinedAt    time.Time `json:"joinedAt"`
+}
+
+// GroupInvitation invites a user to join a group
+type GroupInvitation struct {
+	ID              string                `json:"id"`
+	GroupID         string                `json:"groupId"`
+	GroupName       string                `json:"groupName"`
+	InvitedUserID   string                `json:"invitedUserId"`
+	InvitedUserName string                `json:"invitedUserName"`
+	InvitedByUserID string                `json:"invitedByUserId"`
+	InvitedByName   string                `json:"invitedByName"`
+	Role            GroupRole             `json:"role"`
+	Status          GroupInvitationStatus `json:"status"`
+	CreatedAt       time.Time             `json:"createdAt"`
+	ExpiresAt       time.Time             `json:"expiresAt"`
+	RespondedAt     *time.Time            `json:"respondedAt,omitempty"`
+}
+
+// GroupDetails is a group with its members. Pending invitations are only
+// included for owners and admins.
+type GroupDetails struct {
+	Group
+	Members     []GroupMember     `json:"members"`
+	Invitations []GroupInvitation `json:"invitations,omitempty"`
+}
+
+// GroupSharedAircraft is an aircraft a member has shared with a group
+type GroupSharedAircraft struct {
+	AircraftID     string       `json:"aircraftId"`
+	Name           string       `json:"name"`
+	Nickname       string       `json:"nickname,omitempty"`
+	Type           AircraftType `json:"type,omitempty"`
+	OwnerUserID    string       `json:"ownerUserId"`
+	OwnerName      string       `json:"ownerName"`
+	SharedByUserID string       `json:"sharedByUserId"`
+	SharedAt       time.Time    `json:"sharedAt"`
+}
+
+// GroupSharedBattery is a battery a member has shared with a group
+type GroupSharedBattery struct {
+	BatteryID      string           `json:"batteryId"`
+	BatteryCode    string           `json:"batteryCode"`
+	Name           string           `json:"name,omitempty"`
+	Chemistry      BatteryChemistry `json:"chemistry"`
+	Cells          int              `json:"cells"`
+	CapacityMah    int              `json:"capacityMah"`
+	OwnerUserID    string           `json:"ownerUserId"`
+	OwnerName      string           `json:"ownerName"`
+	SharedByUserID string           `json:"sharedByUserId"`
+	SharedAt       time.Time        `json:"sharedAt"`
+}
+
+// CreateGroupParams represents the request to create a group
+type CreateGroupParams struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// UpdateGroupParams represents the request to update a group
+type UpdateGroupParams struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// InviteGroupMemberParams represents the request to invite a user to a group.
+// The invitee is found by user ID or call sign.
+type InviteGroupMemberParams struct {
+	UserID   string    `json:"userId,omitempty"`
+	CallSign string    `json:"callSign,omitempty"`
+	Role     GroupRole `json:"role,omitempty"` // defaults to member
+}
@@ -0,0 +1,84 @@
+package models
+
+import "time"
+
+// HomeModuleKind identifies what a home page module shows
+type HomeModuleKind string
+
+const (
+	HomeModuleFeatured      HomeModuleKind = "featured"       // editorially featured builds and gear
+	HomeModulePopularBuilds HomeModuleKind = "popular_builds" // most viewed published builds
+	HomeModuleLatestBuilds  HomeModuleKind = "latest_builds"  // newest published builds
+	HomeModuleTrendingGear  HomeModuleKind = "trending_gear"  // catalog items in the most inventories
+	HomeModuleLatestFeed    HomeModuleKind = "latest_feed"    // newest news and community posts
+	HomeModuleAnnouncements HomeModuleKind = "announcements"  // current announcements
+)
+
+// IsValidHomeModuleKind reports whether k is a supported home module kind
+func IsValidHomeModuleKind(k HomeModuleKind) bool {
+	switch k {
+	case HomeModuleFeatured, HomeModulePopularBuilds, HomeModuleLatestBuilds,
+		HomeModuleTrendingGear, HomeModuleLatestFeed, HomeModuleAnnouncements:
+		return true
+	}
+	return false
+}
+
+// HomeModuleSettings narrows what a module shows. Each setting applies to
+// one kind and is ignored by the others.
+type HomeModuleSettings struct {
+	ContentType FeaturedContentType `json:"contentType,omitempty"` // featured: build or gear only
+	GearType    GearType            `json:"gearType,omitempty"`    // trending_gear
+	SourceType  string              `json:"sourceType,omitempty"`  // latest_feed: news, community, or a source type
+	Tag         string              `json:"tag,omitempty"`         // latest_feed
+}
+
+// HomeModule is an admin-configured section of the home page. Modules are
+// shown in Position order.
+type HomeModule struct {
+	ID        string             `json:"id"`
+	Kind      HomeModuleKind     `json:"kind"`
+	Title     string             `json:"title"`
+	Position  int                `json:"position"`
+	Limit     int                `json:"limit"`
+	Enabled   bool               `json:"enabled"`
+	Settings  HomeModuleSettings `json:"settings"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+// SaveHomeModuleParams represents an admin request to add or replace a module
+type SaveHomeModuleParams struct {
+	Kind     HomeModuleKind     `json:"kind"`
+	Title    string             `json:"title"`
+	Position int                `json:"position"`
+	Limit    int                `json:"limit,omitempty"`   // defaults to 6
+	Enabled  *bool              `json:"enabled,omitempty"` // defaults to true
+	Settings HomeModuleSettings `json:"settings"`
+}
+
+// HomeModuleListResponse represents the admin list of home modules
+type HomeModuleListResponse struct {
+	Modules []HomeModule `json:"modules"`
+	// Default is set when no modules are configured and the home page uses
+	// the built-in layout, which is listed instead
+	Default bool `json:"default,omitempty"`
+}
+
+// HomeSection is a module with its content loaded. Only the field matching
+// Kind is set.
+type HomeSection struct {
+	ID            string            `json:"id,omitempty"`
+	Kind          HomeModuleKind    `json:"kind"`
+	Title         string            `json:"title"`
+	Featured      []FeaturedItem    `json:"featured,omitempty"`
+	Builds        []Build           `json:"builds,omitempty"`
+	Gear          []GearCatalogItem `json:"gear,omitempty"`
+	FeedItems     []FeedItem        `json:"feedItems,omitempty"`
+	Announcements []Announcement    `json:"announcements,omitempty"`
+}
+
+// HomeResponse is the composed home page
+type HomeResponse struct {
+	Sections []HomeSection `json:"sections"`
+}
@@ -9,11 +9,12 @@ import (
 type ImageEntityType string
 
 const (
-	ImageEntityAvatar   ImageEntityType = "avatar"
-	ImageEntityAircraft ImageEntityType = "aircraft"
-	ImageEntityBuild    ImageEntityType = "build"
-	ImageEntityGear     ImageEntityType = "gear"
-	ImageEntityOther    ImageEntityType = "other"
+	ImageEntityAvatar    ImageEntityType = "avatar"
+	ImageEntityAircraft  ImageEntityType = "aircraft"
+	ImageEntityBuild     ImageEntityType = "build"
+	ImageEntityGear      ImageEntityType = "gear"
+	ImageEntityInventory ImageEntityType = "inventory"
+	ImageEntityOther     ImageEntityType = "other"
 )
 
 // ImageModerationStatus is the moderation outcome returned to clients.
@@ -25,6 +26,20 @@ const (
 	ImageModerationPendingReview ImageModerationStatus = "PENDING_REVIEW"
 )
 
+// ImageBackground selects how an admin upload's background is replaced to
+// make a consistent product shot. Empty keeps the image as uploaded.
+type ImageBackground string
+
+const (
+	ImageBackgroundWhite       ImageBackground = "white"
+	ImageBackgroundTransparent ImageBackground = "transparent"
+)
+
+// IsValidImageBackground reports whether b is empty or a supported background
+func IsValidImageBackground(b ImageBackground) bool {
+	return b == "" || b == ImageBackgroundWhite || b == ImageBackgroundTransparent
+}
+
 // ModerationLabel captures a single Rekognition moderation label.
 type ModerationLabel struct {
 	Name       string  `json:"name"`
@@ -50,6 +65,183 @@ type ImageAsset struct {
 	Status                  ImageModerationStatus
 	ModerationLabels        json.RawMessage
 	ModerationMaxConfidence float64
+	StorageTier             ImageStorageTier
 	CreatedAt               time.Time
 	UpdatedAt               time.Time
 }
+
+// ImageQuota limits how many image assets a user may store. Zero means unlimited.
+type ImageQuota struct {
+	MaxCount int   `json:"maxCount"`
+	MaxBytes int64 `json:"maxBytes"`
+}
+
+// ImageUsage reports a user's stored images against their effective quota.
+type ImageUsage struct {
+	Count      int        `json:"count"`
+	Bytes      int64      `json:"bytes"`
+	Quota      ImageQuota `json:"quota"`
+	Overridden bool       `json:"overridden"`
+}
+
+// ImageQuotaOverride replaces the default quota for one user. Nil fields fall
+// back to the default; zero means unlimited.
+type ImageQuotaOverride struct {
+	UserID    string    `json:"userId"`
+	MaxCount  *int      `json:"maxCount"`
+	MaxBytes  *int64    `json:"maxBytes"`
+	UpdatedBy string    `json:"updatedBy,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SetImageQuotaOverrideParams defines parameters for an admin quota override.
+type SetImageQuotaOverrideParams struct {
+	MaxCount *int   `json:"maxCount"`
+	MaxBytes *int64 `json:"maxBytes"`
+}
+
+// Apply returns the quota with any override fields substituted.
+func (o *ImageQuotaOverride) Apply(quota ImageQuota) ImageQuota {
+	if o == nil {
+		return quota
+	}
+	if o.MaxCount != nil {
+		quota.MaxCount = *o.MaxCount
+	}
+	if o.MaxBytes != nil {
+		quota.MaxBytes = *o.MaxBytes
+	}
+	return quota
+}
+
+// ImageRescanStatus tracks a moderation re-scan job.
+type ImageRescanStatus string
+
+const (
+	ImageRescanRunning     ImageRescanStatus = "running"
+	ImageRescanCompleted   ImageRescanStatus = "completed"
+	ImageRescanFailed      ImageRescanStatus = "failed"
+	ImageRescanCancelled   ImageRescanStatus = "cancelled"
+	ImageRescanInterrupted ImageRescanStatus = "interrupted"
+)
+
+// ImageRescanJob re-runs moderation over approved image assets in batches.
+// Images that no longer pass are moved to the review queue.
+type ImageRescanJob struct {
+	ID              string            `json:"id"`
+	Status          ImageRescanStatus `json:"status"`
+	EntityType      ImageEntityType   `json:"entityType,omitempty"` // empty scans every entity type
+	StartedByUserID string            `json:"startedByUserId,omitempty"`
+	CursorID        string            `json:"-"` // last scanned asset ID
+	Scanned         int               `json:"scanned"`
+	Flagged         int               `json:"flagged"`
+	Errors          int               `json:"errors"`
+	LastError       string            `json:"lastError,omitempty"`
+	StartedAt       time.Time         `json:"startedAt"`
+	UpdatedAt       time.Time         `json:"updatedAt"`
+	FinishedAt      *time.Time        `json:"finishedAt,omitempty"`
+}
+
+// StartImageRescanParams defines an admin request to start a re-scan.
+type StartImageRescanParams struct {
+	EntityType ImageEntityType `json:"entityType,omitempty"`
+}
+
+// ImageReviewItem is a flagged image awaiting moderator review. Image bytes
+// are served separately.
+type ImageReviewItem struct {
+	ID                      string            `json:"id"`
+	OwnerUserID             string            `json:"ownerUserId"`
+	EntityType              ImageEntityType   `json:"entityType"`
+	EntityID                string            `json:"entityId,omitempty"`
+	ModerationLabels        []ModerationLabel `json:"moderationLabels"`
+	ModerationMaxConfidence float64           `json:"moderationMaxConfidence"`
+	ByteSize                int64             `json:"byteSize"`
+	FlaggedAt               *time.Time        `json:"flaggedAt,omitempty"`
+	CreatedAt               time.Time         `json:"createdAt"`
+}
+
+// ImageReviewListResponse is a page of the image review queue.
+type ImageReviewListResponse struct {
+	Items      []ImageReviewItem `json:"items"`
+	TotalCount int               `json:"totalCount"`
+}
+
+// ModerationPolicy tunes image moderation for one entity type. Labels in
+// AllowedLabels (matched by name or parent name) never cause a rejection.
+type ModerationPolicy struct {
+	EntityType       ImageEntityType `json:"entityType"`
+	RejectConfidence float64         `json:"rejectConfidence"`
+	AllowedLabels    []string        `json:"allowedLabels"`
+	Custom           bool            `json:"custom"` // false when the global default applies
+	UpdatedBy        string          `json:"updatedBy,omitempty"`
+	UpdatedAt        *time.Time      `json:"updatedAt,omitempty"`
+}
+
+// SetModerationPolicyParams defines an admin request to set an entity type's policy.
+type SetModerationPolicyParams struct {
+	RejectConfidence float64  `json:"rejectConfidence"`
+	AllowedLabels    []string `json:"allowedLabels"`
+}
+
+// AllImageEntityTypes returns every image entity type.
+func AllImageEntityTypes() []ImageEntityType {
+	return []ImageEntityType{ImageEntityAvatar, ImageEntityAircraft, ImageEntityBuild, ImageEntityGear, ImageEntityInventory, ImageEntityOther}
+}
+
+// IsValidImageEntityType reports whether t is a known image entity type.
+func IsValidImageEntityType(t ImageEntityType) bool {
+	for _, known := range AllImageEntityTypes() {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxEntityImages bounds how many images a build or catalog item can have.
+const MaxEntityImages = 10
+
+// EntityImage is one image in a build's or catalog item's ordered image list.
+type EntityImage struct {
+	Index   int    `json:"index"`
+	URL     string `json:"url"`
+	Primary bool   `json:"primary"`
+}
+
+// ImageStorageTier is where an image asset's bytes are kept.
+type ImageStorageTier string
+
+const (
+	// ImageTierHot keeps the bytes in image_assets.
+	ImageTierHot ImageStorageTier = "hot"
+	// ImageTierCold keeps the bytes in object storage until first accessed.
+	ImageTierCold ImageStorageTier = "cold"
+)
+
+// ImageTierStats is how many image assets a storage tier holds and their size.
+type ImageTierStats struct {
+	Tier  ImageStorageTier `json:"tier"`
+	Count int64            `json:"count"`
+	Bytes int64            `json:"bytes"`
+}
+
+// ImageArchiveRun summarizes one pass of the image archiver.
+type ImageArchiveRun struct {
+	StartedAt      time.Time `json:"startedAt"`
+	FinishedAt     time.Time `json:"finishedAt"`
+	Archived       int       `json:"archived"`
+	ArchivedBytes  int64     `json:"archivedBytes"`
+	OrphansRemoved int       `json:"orphansRemoved"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// ImageArchiveStatus reports storage tier sizes and archiver activity since
+// the server started.
+type ImageArchiveStatus struct {
+	Enabled  bool             `json:"enabled"`
+	Tiers    []ImageTierStats `json:"tiers"`
+	Archived int64            `json:"archivedSinceStart"`
+	Restored int64            `json:"restoredSinceStart"`
+	LastRun  *ImageArchiveRun `json:"lastRun,omitempty"`
+}
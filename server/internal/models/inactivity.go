@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// InactivityAction is what happens to an inactive account once its grace
+// period ends
+type InactivityAction string
+
+const (
+	InactivityActionNone    InactivityAction = "none" // flag and notify only
+	InactivityActionDisable InactivityAction = "disable"
+	InactivityActionDelete  InactivityAction = "delete"
+)
+
+// IsValidInactivityAction reports whether a is a supported action
+func IsValidInactivityAction(a InactivityAction) bool {
+	return a == InactivityActionNone || a == InactivityActionDisable || a == InactivityActionDelete
+}
+
+// Inactivity policy bounds
+const (
+	MinInactiveMonths = 3
+	MaxInactiveMonths = 120
+	MinGraceDays      = 7
+	MaxGraceDays      = 365
+)
+
+// InactivityPolicy controls the inactive account cleanup job. Accounts with
+// no login for InactiveMonths are flagged and notified; GraceDays later
+// Action is applied unless they have signed in. Admins and moderators are
+// never flagged.
+type InactivityPolicy struct {
+	Enabled        bool             `json:"enabled"`
+	InactiveMonths int              `json:"inactiveMonths"`
+	GraceDays      int              `json:"graceDays"`
+	Action         InactivityAction `json:"action"`
+	// ExemptPublished skips accounts with a published build or catalog item
+	ExemptPublished bool       `json:"exemptPublished"`
+	UpdatedBy       string     `json:"updatedBy,omitempty"`
+	UpdatedAt       *time.Time `json:"updatedAt,omitempty"`
+}
+
+// DefaultInactivityPolicy is used until an admin saves one. It's disabled.
+func DefaultInactivityPolicy() InactivityPolicy {
+	return InactivityPolicy{
+		InactiveMonths:  24,
+		GraceDays:       30,
+		Action:          InactivityActionNone,
+		ExemptPublished: true,
+	}
+}
+
+// InactiveAccount is an account the cleanup job flagged or would act on
+type InactiveAccount struct {
+	UserID      string     `json:"userId"`
+	Email       string     `json:"email"`
+	DisplayName string     `json:"displayName"`
+	LastLoginAt *time.Time `json:"lastLoginAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	FlaggedAt   *time.Time `json:"flaggedAt,omitempty"`
+}
+
+// InactivityRunResult reports one run of the cleanup job. In a dry run
+// Flagged and Actioned list what a real run would do and nothing changes.
+type InactivityRunResult struct {
+	DryRun   bool              `json:"dryRun"`
+	RanAt    time.Time         `json:"ranAt"`
+	Action   InactivityAction  `json:"action"`
+	Flagged  []InactiveAccount `json:"flagged"`
+	Actioned []InactiveAccount `json:"actioned"` // disabled or deleted after their grace period
+	Notified int               `json:"notified"`
+}
@@ -34,6 +34,10 @@ type InventoryItem struct {
 	// Source tracking - if added from equipment search
 	SourceEquipmentID string `json:"sourceEquipmentId,omitempty"`
 
+	// Archived items are retired gear (crashed, sold) kept for their history
+	Archived   bool       `json:"archived"`
+	ArchivedAt *time.Time `json:"archivedAt,omitempty"`
+
 	// Timestamps
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
@@ -70,11 +74,21 @@ type UpdateInventoryParams struct {
 	Specs          json.RawMessage    `json:"specs,omitempty"`
 }
 
+// InventoryStatus filters inventory by archived state
+type InventoryStatus string
+
+const (
+	InventoryStatusActive   InventoryStatus = "active"
+	InventoryStatusArchived InventoryStatus = "archived"
+	InventoryStatusAll      InventoryStatus = "all"
+)
+
 // InventoryFilterParams defines parameters for filtering inventory
 type InventoryFilterParams struct {
 	Category EquipmentCategory `json:"category,omitempty"`
 	BuildID  string            `json:"buildId,omitempty"`
 	Query    string            `json:"query,omitempty"`
+	Status   InventoryStatus   `json:"status,omitempty"` // Defaults to active items
 	Limit    int               `json:"limit,omitempty"`
 	Offset   int               `json:"offset,omitempty"`
 }
@@ -92,3 +106,47 @@ type InventorySummary struct {
 	TotalValue float64                   `json:"totalValue"`
 	ByCategory map[EquipmentCategory]int `json:"byCategory"`
 }
+
+// InventoryAttachmentKind describes what an inventory attachment is for
+type InventoryAttachmentKind string
+
+const (
+	InventoryAttachmentReceipt     InventoryAttachmentKind = "receipt"
+	InventoryAttachmentManual      InventoryAttachmentKind = "manual"
+	InventoryAttachmentCalibration InventoryAttachmentKind = "calibration"
+	InventoryAttachmentOther       InventoryAttachmentKind = "other"
+)
+
+// IsValid reports whether k is a known attachment kind
+func (k InventoryAttachmentKind) IsValid() bool {
+	switch k {
+	case InventoryAttachmentReceipt, InventoryAttachmentManual, InventoryAttachmentCalibration, InventoryAttachmentOther:
+		return true
+	}
+	return false
+}
+
+// MaxInventoryAttachments is the most files one inventory item can have
+const MaxInventoryAttachments = 20
+
+// InventoryAttachment is a file attached to an inventory item, such as a
+// receipt, manual, or calibration data. File bytes are only served by the
+// download endpoint.
+type InventoryAttachment struct {
+	ID              string                  `json:"id"`
+	InventoryItemID string                  `json:"inventoryItemId"`
+	Kind            InventoryAttachmentKind `json:"kind"`
+	FileName        string                  `json:"fileName"`
+	ContentType     string                  `json:"contentType"`
+	ByteSize        int64                   `json:"byteSize"`
+	CreatedAt       time.Time               `json:"createdAt"`
+	FileBytes       []byte                  `json:"-"`
+}
+
+// AddInventoryAttachmentParams defines a new inventory attachment
+type AddInventoryAttachmentParams struct {
+	InventoryItemID string
+	Kind            InventoryAttachmentKind
+	FileName        string
+	FileBytes       []byte
+}
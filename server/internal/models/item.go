@@ -61,3 +61,48 @@ type AggregatedResponse struct {
 	FetchedAt   time.Time  `json:"fetchedAt"`
 	SourceCount int        `json:"sourceCount"`
 }
+
+// FeedSearchResult is an archived feed item matching a search
+type FeedSearchResult struct {
+	FeedItem
+	Rank float64 `json:"rank"`
+	// Snippet is HTML-escaped text around the matches, each wrapped in <mark>
+	Snippet string `json:"snippet"`
+}
+
+// FeedSearchResponse is a page of feed archive search results
+type FeedSearchResponse struct {
+	Query      string             `json:"query"`
+	Items      []FeedSearchResult `json:"items"`
+	TotalCount int                `json:"totalCount"`
+}
+
+// SourceSchedule is when a feed source is fetched and how its fetches have
+// gone since the server started
+type SourceSchedule struct {
+	ID                  string     `json:"id"`
+	Name                string     `json:"name"`
+	IntervalSeconds     int64      `json:"intervalSeconds"`
+	NextFetchAt         *time.Time `json:"nextFetchAt,omitempty"` // unset until the first fetch, when it's due now
+	LastFetchAt         *time.Time `json:"lastFetchAt,omitempty"`
+	LastSuccessAt       *time.Time `json:"lastSuccessAt,omitempty"`
+	LatestPostAt        *time.Time `json:"latestPostAt,omitempty"`
+	LastError           string     `json:"lastError,omitempty"`
+	LastDurationMs      int64      `json:"lastDurationMs"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	Fetches             int        `json:"fetches"`
+	Failures            int        `json:"failures"`
+	ItemsFetched        int        `json:"itemsFetched"`
+	NewItems            int        `json:"newItems"`    // items published after the newest one seen before
+	NotModified         int        `json:"notModified"` // fetches answered 304 Not Modified
+	NotModifiedRate     float64    `json:"notModifiedRate"`
+}
+
+// LinkPreview is the OpenGraph metadata of a page
+type LinkPreview struct {
+	URL         string `json:"url"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	ImageURL    string `json:"imageUrl,omitempty"`
+	SiteName    string `json:"siteName,omitempty"`
+}
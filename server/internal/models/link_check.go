@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// LinkStatus is the outcome of checking a stored product link
+type LinkStatus string
+
+const (
+	LinkStatusPending LinkStatus = "pending" // not checked yet
+	LinkStatusOK      LinkStatus = "ok"
+	LinkStatusFailing LinkStatus = "failing" // failed recently, not yet enough times to be dead
+	LinkStatusDead    LinkStatus = "dead"
+)
+
+// LinkUsageSource is where a checked link is stored
+type LinkUsageSource string
+
+const (
+	LinkUsageInventory LinkUsageSource = "inventory" // inventory item product links
+	LinkUsageEquipment LinkUsageSource = "equipment" // equipment listings
+	LinkUsageCatalog   LinkUsageSource = "catalog"   // manufacturer pages recorded for catalog items
+)
+
+// LinkUsage is where a dead link is used. RefID is an example record: the
+// linked catalog item for inventory when there is one, the catalog item, or
+// the equipment listing.
+type LinkUsage struct {
+	Source LinkUsageSource `json:"source"`
+	RefID  string          `json:"refId,omitempty"`
+	Name   string          `json:"name"`
+	Count  int             `json:"count"`
+}
+
+// LinkCheckResult is the outcome of requesting one link
+type LinkCheckResult struct {
+	URL string `json:"url"`
+	OK  bool   `json:"ok"`
+	// Inconclusive is set when the site refused the check (401, 403, 429),
+	// which says nothing about whether the page exists
+	Inconclusive bool   `json:"inconclusive,omitempty"`
+	HTTPStatus   int    `json:"httpStatus,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// DeadLink is a link that failed enough checks in a row to be considered
+// gone, with where it's used and a suggested replacement listing
+type DeadLink struct {
+	URL        string         `json:"url"`
+	HTTPStatus int            `json:"httpStatus,omitempty"`
+	Error      string         `json:"error,omitempty"`
+	Failures   int            `json:"failures"`
+	CheckedAt  *time.Time     `json:"checkedAt,omitempty"`
+	DeadSince  *time.Time     `json:"deadSince,omitempty"`
+	Usages     []LinkUsage    `json:"usages"`
+	Suggestion *EquipmentItem `json:"suggestion,omitempty"`
+}
+
+// DeadLinkReport is the admin report of dead links
+type DeadLinkReport struct {
+	Links      []DeadLink         `json:"links"`
+	TotalCount int                `json:"totalCount"`
+	Counts     map[LinkStatus]int `json:"counts"` // tracked links by status
+}
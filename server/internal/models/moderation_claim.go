@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// ModerationClaimTimeout is how long a moderator's claim on a queue item
+// lasts without being renewed. Claiming again renews it.
+const ModerationClaimTimeout = 30 * time.Minute
+
+// ModerationQueue names a moderation queue whose items can be claimed
+type ModerationQueue string
+
+const (
+	ModerationQueueGear  ModerationQueue = "gear"
+	ModerationQueueBuild ModerationQueue = "build"
+)
+
+// ModerationAssignment is a moderator's claim on a queue item, so two
+// moderators don't curate the same item at once
+type ModerationAssignment struct {
+	UserID     string    `json:"userId"`
+	AssignedAt time.Time `json:"assignedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+// NewModerationAssignment returns the assignment for a claim, or nil when
+// there is none or it has expired
+func NewModerationAssignment(userID string, assignedAt *time.Time) *ModerationAssignment {
+	if userID == "" || assignedAt == nil {
+		return nil
+	}
+	expiresAt := assignedAt.Add(ModerationClaimTimeout)
+	if !time.Now().Before(expiresAt) {
+		return nil
+	}
+	return &ModerationAssignment{UserID: userID, AssignedAt: *assignedAt, ExpiresAt: expiresAt}
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewModerationAssignment(t *testing.T) {
+	recent := time.Now().Add(-5 * time.Minute)
+	expired := time.Now().Add(-ModerationClaimTimeout - time.Minute)
+
+	assignment := NewModerationAssignment("user-1", &recent)
+	if assignment == nil {
+		t.Fatal("expected assignment for a recent claim")
+	}
+	if !assignment.ExpiresAt.Equal(recent.Add(ModerationClaimTimeout)) {
+		t.Errorf("ExpiresAt = %v, want %v", assignment.ExpiresAt, recent.Add(ModerationClaimTimeout))
+	}
+
+	if got := NewModerationAssignment("user-1", &expired); got != nil {
+		t.Errorf("expected nil for an expired claim, got %+v", got)
+	}
+	if got := NewModerationAssignment("", &recent); got != nil {
+		t.Errorf("expected nil without a user, got %+v", got)
+	}
+	if got := NewModerationAssignment("user-1", nil); got != nil {
+		t.Errorf("expected nil without a claim time, got %+v", got)
+	}
+}
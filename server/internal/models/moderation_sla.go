@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Default moderation SLAs: how long an item can wait in its queue before
+// it's reported as overdue
+const (
+	DefaultGearModerationSLA  = 48 * time.Hour
+	DefaultBuildModerationSLA = 72 * time.Hour
+)
+
+// ModerationAgingItem is a pending item that has waited past its queue's SLA
+type ModerationAgingItem struct {
+	Queue      ModerationQueue       `json:"queue"`
+	ID         string                `json:"id"`
+	Title      string                `json:"title"`
+	QueuedAt   time.Time             `json:"queuedAt"`
+	AgeSeconds int64                 `json:"ageSeconds"`
+	Assignment *ModerationAssignment `json:"assignment,omitempty"`
+}
+
+// ModerationQueueAging summarizes how long one queue's items have waited.
+// Items lists the overdue items, oldest first.
+type ModerationQueueAging struct {
+	Queue            ModerationQueue       `json:"queue"`
+	SLASeconds       int64                 `json:"slaSeconds"`
+	Pending          int                   `json:"pending"`
+	Overdue          int                   `json:"overdue"`
+	OldestAgeSeconds int64                 `json:"oldestAgeSeconds"`
+	Items            []ModerationAgingItem `json:"items"`
+}
+
+// ModerationAgingReport is the SLA report across moderation queues
+type ModerationAgingReport struct {
+	GeneratedAt time.Time              `json:"generatedAt"`
+	Queues      []ModerationQueueAging `json:"queues"`
+}
+
+// QueueAge returns how long an item queued at queuedAt has waited, in seconds
+func QueueAge(queuedAt *time.Time, now time.Time) int64 {
+	if queuedAt == nil {
+		return 0
+	}
+	age := int64(now.Sub(*queuedAt).Seconds())
+	if age < 0 {
+		return 0
+	}
+	return age
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// NotificationKind identifies the event a notification is about
+type NotificationKind string
+
+const (
+	NotificationBuildApproved    NotificationKind = "build_approved"
+	NotificationBuildRejected    NotificationKind = "build_rejected"
+	NotificationAircraftExpiring NotificationKind = "aircraft_expiring"
+	NotificationBatteryStorage   NotificationKind = "battery_storage"
+	NotificationModerationSLA    NotificationKind = "moderation_sla"
+	NotificationAnnouncement     NotificationKind = "announcement"
+	NotificationAccountInactive  NotificationKind = "account_inactive"
+)
+
+// Notification is a short user-facing message about an event
+type Notification struct {
+	Kind  NotificationKind  `json:"kind"`
+	Title string            `json:"title"`
+	Body  string            `json:"body"`
+	Data  map[string]string `json:"data,omitempty"`
+}
+
+// PushPlatform identifies the push service a device token belongs to
+type PushPlatform string
+
+const (
+	PushPlatformIOS     PushPlatform = "ios"     // Apple Push Notification service
+	PushPlatformAndroid PushPlatform = "android" // Firebase Cloud Messaging
+)
+
+// IsValidPushPlatform checks if a platform is supported for push delivery
+func IsValidPushPlatform(p PushPlatform) bool {
+	return p == PushPlatformIOS || p == PushPlatformAndroid
+}
+
+// PushDevice is a device registered to receive push notifications
+type PushDevice struct {
+	ID         string       `json:"id"`
+	UserID     string       `json:"-"`
+	Platform   PushPlatform `json:"platform"`
+	Token      string       `json:"token"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	LastSeenAt time.Time    `json:"lastSeenAt"`
+}
+
+// RegisterPushDeviceParams defines parameters for registering a device token
+type RegisterPushDeviceParams struct {
+	Platform PushPlatform `json:"platform"`
+	Token    string       `json:"token"`
+}
+
+// Normalize trims the token and lowercases the platform
+func (p *RegisterPushDeviceParams) Normalize() {
+	p.Platform = PushPlatform(strings.ToLower(strings.TrimSpace(string(p.Platform))))
+	p.Token = strings.TrimSpace(p.Token)
+}
+
+// PushDeviceListResponse is the response for listing a user's devices
+type PushDeviceListResponse struct {
+	Devices []PushDevice `json:"devices"`
+}
@@ -0,0 +1,84 @@
+package models
+
+import "time"
+
+// QueryStatsSort orders the slow query report
+type QueryStatsSort string
+
+const (
+	QueryStatsSortTotal QueryStatsSort = "total" // total time spent, the default
+	QueryStatsSortP95   QueryStatsSort = "p95"
+	QueryStatsSortMax   QueryStatsSort = "max"
+	QueryStatsSortCalls QueryStatsSort = "calls"
+)
+
+// IsValid reports whether s is a known sort order
+func (s QueryStatsSort) IsValid() bool {
+	switch s {
+	case QueryStatsSortTotal, QueryStatsSortP95, QueryStatsSortMax, QueryStatsSortCalls:
+		return true
+	}
+	return false
+}
+
+// QueryStat summarizes every run of one SQL statement since the stats were
+// last reset. P95Ms is taken from the most recent runs only.
+type QueryStat struct {
+	Query   string  `json:"query"`
+	Calls   int64   `json:"calls"`
+	Errors  int64   `json:"errors"`
+	TotalMs float64 `json:"totalMs"`
+	MeanMs  float64 `json:"meanMs"`
+	P95Ms   float64 `json:"p95Ms"`
+	MaxMs   float64 `json:"maxMs"`
+}
+
+// QueryStatsResponse is the slow query report for GET /api/admin/perf
+type QueryStatsResponse struct {
+	Since   time.Time      `json:"since"`
+	Sort    QueryStatsSort `json:"sort"`
+	Queries []QueryStat    `json:"queries"`
+	// TrackedQueries is how many distinct statements have been seen;
+	// DroppedCalls counts runs of statements that arrived after the tracking
+	// limit was reached
+	TrackedQueries int   `json:"trackedQueries"`
+	DroppedCalls   int64 `json:"droppedCalls"`
+}
+
+// DBPoolStats is a snapshot of one connection pool's sql.DBStats. Pool is
+// "default" for the shared pool or the tenant's id for a tenant pool. Wait
+// counts and durations are totals since the pool opened.
+type DBPoolStats struct {
+	Pool               string  `json:"pool"`
+	MaxOpenConnections int     `json:"maxOpenConnections"` // 0 is unlimited
+	OpenConnections    int     `json:"openConnections"`
+	InUse              int     `json:"inUse"`
+	Idle               int     `json:"idle"`
+	WaitCount          int64   `json:"waitCount"`
+	WaitDurationMs     float64 `json:"waitDurationMs"`
+	MaxIdleClosed      int64   `json:"maxIdleClosed"`
+	MaxIdleTimeClosed  int64   `json:"maxIdleTimeClosed"`
+	MaxLifetimeClosed  int64   `json:"maxLifetimeClosed"`
+}
+
+// DBPoolAlert records a check where connections waited longer than the
+// alert threshold on average
+type DBPoolAlert struct {
+	Pool               string    `json:"pool"`
+	At                 time.Time `json:"at"`
+	Waits              int64     `json:"waits"` // new waits since the previous check
+	MeanWaitMs         float64   `json:"meanWaitMs"`
+	TotalWaitMs        float64   `json:"totalWaitMs"`
+	ThresholdMs        float64   `json:"thresholdMs"`
+	OpenConnections    int       `json:"openConnections"`
+	MaxOpenConnections int       `json:"maxOpenConnections"`
+}
+
+// DBPoolResponse is the connection pool report for GET /api/admin/db
+type DBPoolResponse struct {
+	Pools []DBPoolStats `json:"pools"`
+	// WaitAlertMs is the mean wait that raises an alert; 0 when alerts are off
+	WaitAlertMs  float64       `json:"waitAlertMs"`
+	LastCheck    *time.Time    `json:"lastCheck,omitempty"`
+	RecentAlerts []DBPoolAlert `json:"recentAlerts"`
+}
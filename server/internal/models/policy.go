@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// PolicyKind identifies a legal policy users must accept
+type PolicyKind string
+
+const (
+	PolicyTerms   PolicyKind = "terms"   // terms of service
+	PolicyPrivacy PolicyKind = "privacy" // privacy policy
+)
+
+// IsValidPolicyKind reports whether k is a supported policy kind
+func IsValidPolicyKind(k PolicyKind) bool {
+	return k == PolicyTerms || k == PolicyPrivacy
+}
+
+// PolicyVersion is a published version of a policy. The latest version of
+// each kind is the one users must accept. Versions are never edited, so an
+// acceptance always refers to the text the user saw.
+type PolicyVersion struct {
+	ID              string     `json:"id"`
+	Kind            PolicyKind `json:"kind"`
+	Version         string     `json:"version"`
+	URL             string     `json:"url"`
+	Summary         string     `json:"summary,omitempty"` // what changed
+	PublishedAt     time.Time  `json:"publishedAt"`
+	CreatedByUserID string     `json:"createdByUserId,omitempty"`
+}
+
+// PublishPolicyVersionParams represents an admin request to publish a policy version
+type PublishPolicyVersionParams struct {
+	Kind    PolicyKind `json:"kind"`
+	Version string     `json:"version"`
+	URL     string     `json:"url"`
+	Summary string     `json:"summary,omitempty"`
+}
+
+// PolicyAcceptance records when a user accepted a policy version
+type PolicyAcceptance struct {
+	PolicyVersion
+	AcceptedAt time.Time `json:"acceptedAt"`
+}
+
+// AcceptPoliciesParams lists the policy versions a user accepts
+type AcceptPoliciesParams struct {
+	VersionIDs []string `json:"versionIds"`
+}
+
+// UserPolicyStatus is a user's standing against the current policies.
+// Pending lists the latest versions they still have to accept.
+type UserPolicyStatus struct {
+	Current  []PolicyVersion    `json:"current"`
+	Pending  []PolicyVersion    `json:"pending"`
+	Accepted []PolicyAcceptance `json:"accepted"`
+}
+
+// PolicyVersionListResponse is the response for listing policy versions
+type PolicyVersionListResponse struct {
+	Versions []PolicyVersion `json:"versions"`
+}
@@ -69,15 +69,16 @@ type Radio struct {
 
 // RadioBackup represents a configuration backup for a radio
 type RadioBackup struct {
-	ID          string     `json:"id"`
-	RadioID     string     `json:"radioId"`
-	BackupName  string     `json:"backupName"`
-	BackupType  BackupType `json:"backupType"`
-	FileName    string     `json:"fileName"`
-	FileSize    int64      `json:"fileSize"`
-	Checksum    string     `json:"checksum,omitempty"`
-	StoragePath string     `json:"-"` // Internal storage path, not exposed in JSON
-	CreatedAt   time.Time  `json:"createdAt"`
+	ID             string     `json:"id"`
+	RadioID        string     `json:"radioId"`
+	BackupName     string     `json:"backupName"`
+	BackupType     BackupType `json:"backupType"`
+	FileName       string     `json:"fileName"`
+	FileSize       int64      `json:"fileSize"`
+	Checksum       string     `json:"checksum,omitempty"`
+	StoragePath    string     `json:"-"` // Internal storage path, not exposed in JSON
+	StorageBackend string     `json:"-"` // Blob store holding the file; empty when StoragePath is a file path
+	CreatedAt      time.Time  `json:"createdAt"`
 }
 
 // CreateRadioParams defines parameters for creating a radio
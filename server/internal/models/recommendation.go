@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// CatalogRecommendation is a catalog item often used alongside another one
+type CatalogRecommendation struct {
+	Item BuildCatalogItem `json:"item"`
+	// SharedCount is how many inventories and builds hold both items
+	SharedCount int `json:"sharedCount"`
+	// Score ranks recommendations; 1 means the items always appear together
+	Score float64 `json:"score"`
+}
+
+// CatalogRecommendationsResponse lists recommendations for one catalog item
+type CatalogRecommendationsResponse struct {
+	ItemID          string                  `json:"itemId"`
+	Recommendations []CatalogRecommendation `json:"recommendations"`
+	// ComputedAt is when the co-occurrence data was last rebuilt; omitted
+	// when there is nothing to recommend yet
+	ComputedAt *time.Time `json:"computedAt,omitempty"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// ReputationTier groups contributors by reputation score
+type ReputationTier string
+
+const (
+	ReputationTierNew         ReputationTier = "new"
+	ReputationTierContributor ReputationTier = "contributor"
+	ReputationTierTrusted     ReputationTier = "trusted"
+)
+
+// ContributionStats counts a user's catalog contributions by outcome
+type ContributionStats struct {
+	SubmissionsPublished int `json:"submissionsPublished"`
+	SubmissionsPending   int `json:"submissionsPending"`
+	SubmissionsRemoved   int `json:"submissionsRemoved"`
+	ImagesApproved       int `json:"imagesApproved"` // gear images a curator approved
+	ImagesRejected       int `json:"imagesRejected"` // gear images moderation rejected
+}
+
+// UserReputation is a user's contribution record and the score derived from it
+type UserReputation struct {
+	Score      int               `json:"score"`
+	Tier       ReputationTier    `json:"tier"`
+	Stats      ContributionStats `json:"stats"`
+	ComputedAt time.Time         `json:"computedAt"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// RetentionCategory identifies a kind of log data users can have purged
+type RetentionCategory string
+
+const (
+	RetentionBatteryLogs RetentionCategory = "battery_logs" // battery charge and health logs
+)
+
+// RetentionCategories lists the categories users can set retention for
+var RetentionCategories = []RetentionCategory{RetentionBatteryLogs}
+
+// IsValidRetentionCategory reports whether c is a supported retention category
+func IsValidRetentionCategory(c RetentionCategory) bool {
+	for _, category := range RetentionCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// MinRetentionDays is the shortest retention a user can choose
+	MinRetentionDays = 30
+	// MaxRetentionDays is the longest retention a user can choose, short of
+	// keeping data forever
+	MaxRetentionDays = 3650
+)
+
+// RetentionSetting is how long one category of a user's data is kept. A nil
+// RetainDays keeps it forever.
+type RetentionSetting struct {
+	Category        RetentionCategory `json:"category"`
+	RetainDays      *int              `json:"retainDays"`
+	LastPurgedAt    *time.Time        `json:"lastPurgedAt,omitempty"`
+	LastPurgedCount int               `json:"lastPurgedCount,omitempty"` // rows deleted by the last purge
+}
+
+// UpdateRetentionParams sets retention for the listed categories. Categories
+// left out are unchanged.
+type UpdateRetentionParams struct {
+	Settings []RetentionSetting `json:"settings"`
+}
+
+// RetentionSettingsResponse lists a user's retention for every category
+type RetentionSettingsResponse struct {
+	Settings []RetentionSetting `json:"settings"`
+}
+
+// RetentionPurgeResult reports one category's purge run
+type RetentionPurgeResult struct {
+	Category RetentionCategory `json:"category"`
+	Users    int               `json:"users"`   // users who had rows deleted
+	Deleted  int               `json:"deleted"` // rows deleted
+}
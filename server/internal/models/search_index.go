@@ -0,0 +1,26 @@
+package models
+
+// SearchCollection names a collection in the external search index
+type SearchCollection string
+
+const (
+	SearchCollectionCatalog SearchCollection = "catalog"
+	SearchCollectionBuilds  SearchCollection = "builds"
+)
+
+// SearchIndexQuery is a text query against one collection of the external
+// search index. Filters are exact matches on filterable fields.
+type SearchIndexQuery struct {
+	Collection SearchCollection
+	Query      string
+	Filters    map[string]string
+	Limit      int
+	Offset     int
+}
+
+// SearchIndexResult is the matching document IDs in relevance order, and
+// how many documents matched in total
+type SearchIndexResult struct {
+	IDs   []string
+	Total int
+}
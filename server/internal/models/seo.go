@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+// SitemapEntry is one public page listed in the sitemap
+type SitemapEntry struct {
+	ID        string
+	UpdatedAt time.Time
+}
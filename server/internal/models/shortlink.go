@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// ShortLinkTargetType identifies what a short link redirects to
+type ShortLinkTargetType string
+
+const (
+	ShortLinkBuild ShortLinkTargetType = "build"
+	ShortLinkGear  ShortLinkTargetType = "gear"
+)
+
+// IsValidShortLinkTargetType reports whether t can have a short link
+func IsValidShortLinkTargetType(t ShortLinkTargetType) bool {
+	return t == ShortLinkBuild || t == ShortLinkGear
+}
+
+// ShortLink maps a short slug (e.g. /b/7fG3k) to a published build or catalog item
+type ShortLink struct {
+	Slug          string              `json:"slug"`
+	TargetType    ShortLinkTargetType `json:"targetType"`
+	TargetID      string              `json:"targetId"`
+	Path          string              `json:"path"`          // short path, e.g. /b/7fG3k
+	URL           string              `json:"url,omitempty"` // absolute short link for sharing
+	ClickCount    int64               `json:"clickCount"`
+	LastClickedAt *time.Time          `json:"lastClickedAt,omitempty"`
+	CreatedAt     time.Time           `json:"createdAt"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// JWTSigningKey is a key access tokens are signed with, identified by the
+// kid header of the tokens it signs. Retired keys keep verifying tokens
+// until ExpiresAt, when the last token they signed has expired.
+type JWTSigningKey struct {
+	KID       string     `json:"kid"`
+	Secret    string     `json:"-"`
+	Current   bool       `json:"current"`
+	CreatedAt time.Time  `json:"createdAt"`
+	RetiredAt *time.Time `json:"retiredAt,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
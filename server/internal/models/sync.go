@@ -0,0 +1,136 @@
+package models
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// SyncEntityType identifies a user-owned entity exposed through the sync API
+type SyncEntityType string
+
+const (
+	SyncEntityInventory SyncEntityType = "inventory"
+	SyncEntityAircraft  SyncEntityType = "aircraft"
+	SyncEntityBattery   SyncEntityType = "battery"
+)
+
+// AllSyncEntityTypes returns every entity type the sync API serves
+func AllSyncEntityTypes() []SyncEntityType {
+	return []SyncEntityType{SyncEntityInventory, SyncEntityAircraft, SyncEntityBattery}
+}
+
+// IsValidSyncEntityType checks if an entity type is served by the sync API
+func IsValidSyncEntityType(t SyncEntityType) bool {
+	for _, valid := range AllSyncEntityTypes() {
+		if t == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// SyncOp is the kind of change a client pushes
+type SyncOp string
+
+const (
+	SyncOpUpsert SyncOp = "upsert"
+	SyncOpDelete SyncOp = "delete"
+)
+
+// SyncResultStatus reports what happened to a pushed operation
+type SyncResultStatus string
+
+const (
+	SyncStatusApplied  SyncResultStatus = "applied"
+	SyncStatusConflict SyncResultStatus = "conflict"
+	SyncStatusNotFound SyncResultStatus = "not_found"
+	SyncStatusRejected SyncResultStatus = "rejected"
+	SyncStatusError    SyncResultStatus = "error"
+)
+
+// SyncChange is one entry in a change feed. Deleted entries are tombstones and
+// carry no data.
+type SyncChange struct {
+	EntityType SyncEntityType `json:"entityType"`
+	ID         string         `json:"id"`
+	Cursor     string         `json:"cursor"`
+	Deleted    bool           `json:"deleted"`
+	ChangedAt  time.Time      `json:"changedAt"`
+	Data       interface{}    `json:"data,omitempty"`
+	Seq        int64          `json:"-"`
+}
+
+// SyncChangesParams defines parameters for reading a change feed
+type SyncChangesParams struct {
+	Since int64
+	Types []SyncEntityType
+	Limit int
+}
+
+// SyncChangesResponse is a page of changes. Clients pass Cursor as `since` on the
+// next request and keep paging while HasMore is true.
+type SyncChangesResponse struct {
+	Changes []SyncChange `json:"changes"`
+	Cursor  string       `json:"cursor"`
+	HasMore bool         `json:"hasMore"`
+}
+
+// SyncOperation is a single client change in a batch push.
+//
+// Creates omit ID and set ClientRef to a client-generated identifier; replaying the
+// same ClientRef returns the originally created entity instead of a duplicate.
+// BaseCursor is the cursor of the version the client edited; if the server copy has
+// changed since, the operation is reported as a conflict unless Force is set.
+type SyncOperation struct {
+	EntityType SyncEntityType  `json:"entityType"`
+	Op         SyncOp          `json:"op"`
+	ID         string          `json:"id,omitempty"`
+	ClientRef  string          `json:"clientRef,omitempty"`
+	BaseCursor string          `json:"baseCursor,omitempty"`
+	Force      bool            `json:"force,omitempty"`
+	Data       json.RawMessage `json:"data,omitempty"`
+}
+
+// SyncBatchRequest is a batch of client changes
+type SyncBatchRequest struct {
+	Operations []SyncOperation `json:"operations"`
+}
+
+// SyncOperationResult reports the outcome of one pushed operation. On conflict,
+// Current holds the server copy so the client can reconcile.
+type SyncOperationResult struct {
+	Index      int              `json:"index"`
+	EntityType SyncEntityType   `json:"entityType"`
+	ID         string           `json:"id,omitempty"`
+	ClientRef  string           `json:"clientRef,omitempty"`
+	Status     SyncResultStatus `json:"status"`
+	Error      string           `json:"error,omitempty"`
+	Cursor     string           `json:"cursor,omitempty"`
+	Current    interface{}      `json:"current,omitempty"`
+}
+
+// SyncBatchResponse holds per-operation results in request order
+type SyncBatchResponse struct {
+	Results []SyncOperationResult `json:"results"`
+}
+
+// FormatSyncCursor encodes a change sequence number as an opaque cursor
+func FormatSyncCursor(seq int64) string {
+	if seq <= 0 {
+		return ""
+	}
+	return strconv.FormatInt(seq, 10)
+}
+
+// ParseSyncCursor decodes a cursor; an empty cursor means "from the beginning"
+func ParseSyncCursor(cursor string) (int64, bool) {
+	if cursor == "" {
+		return 0, true
+	}
+	seq, err := strconv.ParseInt(cursor, 10, 64)
+	if err != nil || seq < 0 {
+		return 0, false
+	}
+	return seq, true
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+)
+
+// EquipmentCategoryInfo describes an inventory category and the gear types
+// filed under it. Categories are coarser than gear types: accessories holds
+// radios, GPS modules and buzzers, and vtx holds HD units.
+type EquipmentCategoryInfo struct {
+	Category        EquipmentCategory `json:"category"`
+	Label           string            `json:"label"`
+	DefaultGearType GearType          `json:"defaultGearType"`
+	GearTypes       []GearType        `json:"gearTypes"`
+}
+
+// gearTypeHint picks a more specific gear type than a category's default
+// when an item's name contains one of its words
+type gearTypeHint struct {
+	gearType GearType
+	words    []string
+}
+
+// equipmentCategoryInfos lists every equipment category in AllCategories
+// order, with the gear type an item of the category becomes in the catalog
+var equipmentCategoryInfos = []struct {
+	category        EquipmentCategory
+	label           string
+	defaultGearType GearType
+	hints           []gearTypeHint
+}{
+	{CategoryFrames, "Frames", GearTypeFrame, nil},
+	{CategoryVTX, "Video Transmitters", GearTypeVTX, []gearTypeHint{
+		{GearTypeHDUnit, []string{"air unit", "o3", "o4", "vista", "walksnail", "avatar", "hdzero", "hd unit"}},
+	}},
+	{CategoryFC, "Flight Controllers", GearTypeFC, nil},
+	{CategoryESC, "ESCs", GearTypeESC, nil},
+	{CategoryAIO, "AIO (FC/ESC)", GearTypeAIO, nil},
+	{CategoryMotors, "Motors", GearTypeMotor, nil},
+	{CategoryPropellers, "Propellers", GearTypeProp, nil},
+	{CategoryReceivers, "Receivers", GearTypeReceiver, nil},
+	{CategoryBatteries, "Batteries", GearTypeBattery, nil},
+	{CategoryCameras, "Cameras", GearTypeCamera, nil},
+	{CategoryAntennas, "Antennas", GearTypeAntenna, nil},
+	{CategoryAccessories, "Accessories", GearTypeOther, []gearTypeHint{
+		{GearTypeGPS, []string{"gps", "gnss"}},
+		{GearTypeBuzzer, []string{"buzzer", "finder", "beeper"}},
+		{GearTypeRadio, []string{"radio", "transmitter", "tx16s", "tx12", "boxer", "zorro", "tango", "taranis", "commando"}},
+	}},
+}
+
+// EquipmentCategoryInfos returns every equipment category with the gear
+// types filed under it
+func EquipmentCategoryInfos() []EquipmentCategoryInfo {
+	infos := make([]EquipmentCategoryInfo, len(equipmentCategoryInfos))
+	for i, entry := range equipmentCategoryInfos {
+		info := EquipmentCategoryInfo{
+			Category:        entry.category,
+			Label:           entry.label,
+			DefaultGearType: entry.defaultGearType,
+			GearTypes:       []GearType{},
+		}
+		for _, gearType := range gearTypeInfos {
+			if gearType.EquipmentCategory == entry.category {
+				info.GearTypes = append(info.GearTypes, gearType.GearType)
+			}
+		}
+		infos[i] = info
+	}
+	return infos
+}
+
+// GearTypeFromEquipmentCategory returns the gear type items of a category
+// become when nothing more is known about them
+func GearTypeFromEquipmentCategory(cat EquipmentCategory) GearType {
+	return GearTypeForEquipment(cat, "")
+}
+
+// GearTypeForEquipment returns the gear type of an inventory item. Where a
+// category holds several gear types, the item's name picks one, so a GPS
+// module filed under accessories becomes gps rather than other.
+func GearTypeForEquipment(cat EquipmentCategory, name string) GearType {
+	words := " " + strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ") + " "
+
+	for _, entry := range equipmentCategoryInfos {
+		if entry.category != cat {
+			continue
+		}
+		for _, hint := range entry.hints {
+			for _, word := range hint.words {
+				if strings.Contains(words, " "+word+" ") {
+					return hint.gearType
+				}
+			}
+		}
+		return entry.defaultGearType
+	}
+	return GearTypeOther
+}
+
+// ToEquipmentCategory returns the inventory category items of the gear type
+// are filed under
+func (gt GearType) ToEquipmentCategory() EquipmentCategory {
+	for _, info := range gearTypeInfos {
+		if info.GearType == gt {
+			return info.EquipmentCategory
+		}
+	}
+	return CategoryAccessories
+}
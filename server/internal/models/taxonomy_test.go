@@ -0,0 +1,61 @@
+package models
+
+import "testing"
+
+func TestEquipmentCategoryInfos_CoverBothEnums(t *testing.T) {
+	infos := EquipmentCategoryInfos()
+	categories := AllCategories()
+	if len(infos) != len(categories) {
+		t.Fatalf("got %d category infos for %d categories", len(infos), len(categories))
+	}
+
+	filed := map[GearType]EquipmentCategory{}
+	for i, info := range infos {
+		if info.Category != categories[i] || info.Label == "" {
+			t.Errorf("infos[%d] = %+v, want category %s", i, info, categories[i])
+		}
+		// A category's gear types convert back to the category, so moving
+		// an item between inventory and catalog never changes its category
+		if info.DefaultGearType.ToEquipmentCategory() != info.Category {
+			t.Errorf("%s default gear type %s is filed under %s", info.Category, info.DefaultGearType, info.DefaultGearType.ToEquipmentCategory())
+		}
+		for _, gearType := range info.GearTypes {
+			filed[gearType] = info.Category
+		}
+		for _, entry := range equipmentCategoryInfos[i].hints {
+			if entry.gearType.ToEquipmentCategory() != info.Category {
+				t.Errorf("%s hint %s is filed under %s", info.Category, entry.gearType, entry.gearType.ToEquipmentCategory())
+			}
+		}
+	}
+	for _, gearType := range AllGearTypes() {
+		if _, ok := filed[gearType]; !ok {
+			t.Errorf("gear type %s is not filed under any category", gearType)
+		}
+	}
+}
+
+func TestGearTypeForEquipment(t *testing.T) {
+	tests := []struct {
+		category EquipmentCategory
+		name     string
+		expected GearType
+	}{
+		{CategoryAccessories, "BN-880 GPS Module", GearTypeGPS},
+		{CategoryAccessories, "Vifly Finder 2 Buzzer", GearTypeBuzzer},
+		{CategoryAccessories, "RadioMaster TX16S MKII", GearTypeRadio},
+		{CategoryAccessories, "Velcro battery straps", GearTypeOther},
+		{CategoryVTX, "DJI O3 Air Unit", GearTypeHDUnit},
+		{CategoryVTX, "Rush Tank Ultimate", GearTypeVTX},
+		{CategoryMotors, "T-Motor F60 GPS edition", GearTypeMotor},
+		{EquipmentCategory("gimbals"), "Gimbal", GearTypeOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GearTypeForEquipment(tt.category, tt.name); got != tt.expected {
+				t.Errorf("GearTypeForEquipment(%q, %q) = %q, want %q", tt.category, tt.name, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package models
+
+import "time"
+
+// TenantFeature names a feature a tenant can switch off
+type TenantFeature string
+
+const (
+	TenantFeatureGroups        TenantFeature = "groups"        // pilot groups
+	TenantFeatureEvents        TenantFeature = "events"        // events and registrations
+	TenantFeatureSocial        TenantFeature = "social"        // pilot profiles and follows
+	TenantFeatureNews          TenantFeature = "news"          // aggregated news feed
+	TenantFeatureAnnouncements TenantFeature = "announcements" // admin announcements
+)
+
+// TenantFeatures lists every feature a tenant can switch off
+var TenantFeatures = []TenantFeature{
+	TenantFeatureGroups,
+	TenantFeatureEvents,
+	TenantFeatureSocial,
+	TenantFeatureNews,
+	TenantFeatureAnnouncements,
+}
+
+// IsValidTenantFeature reports whether f is a known tenant feature
+func IsValidTenantFeature(f TenantFeature) bool {
+	for _, feature := range TenantFeatures {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// TenantBranding is how a tenant's site presents itself
+type TenantBranding struct {
+	DisplayName  string `json:"displayName,omitempty"`
+	LogoURL      string `json:"logoUrl,omitempty"`
+	FaviconURL   string `json:"faviconUrl,omitempty"`
+	PrimaryColor string `json:"primaryColor,omitempty"` // #rrggbb
+	AccentColor  string `json:"accentColor,omitempty"`  // #rrggbb
+	SupportEmail string `json:"supportEmail,omitempty"`
+}
+
+// Tenant is a white-label instance served from the shared deployment. Users,
+// their gear, builds and social data belong to exactly one tenant; the gear
+// catalog and news sources are shared.
+type Tenant struct {
+	ID        string                 `json:"id"`
+	Slug      string                 `json:"slug"`
+	Name      string                 `json:"name"`
+	Hostnames []string               `json:"hostnames"`
+	Branding  TenantBranding         `json:"branding"`
+	Features  map[TenantFeature]bool `json:"features"` // missing features are enabled
+	CreatedAt time.Time              `json:"createdAt"`
+	UpdatedAt time.Time              `json:"updatedAt"`
+}
+
+// FeatureEnabled reports whether the tenant has a feature switched on
+func (t *Tenant) FeatureEnabled(f TenantFeature) bool {
+	if t == nil {
+		return true
+	}
+	enabled, ok := t.Features[f]
+	return !ok || enabled
+}
+
+// SaveTenantParams represents an admin request to create or update a tenant
+type SaveTenantParams struct {
+	Slug      string                 `json:"slug"`
+	Name      string                 `json:"name"`
+	Hostnames []string               `json:"hostnames"`
+	Branding  TenantBranding         `json:"branding"`
+	Features  map[TenantFeature]bool `json:"features"`
+}
+
+// PublicTenant is the site configuration the frontend loads for a hostname
+type PublicTenant struct {
+	Slug     string                 `json:"slug"`
+	Name     string                 `json:"name"`
+	Branding TenantBranding         `json:"branding"`
+	Features map[TenantFeature]bool `json:"features"` // every feature, resolved
+}
+
+// TenantListResponse is the response for listing tenants
+type TenantListResponse struct {
+	Tenants []Tenant `json:"tenants"`
+}
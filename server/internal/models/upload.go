@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// UploadPurpose says what a resumable upload becomes once it's complete
+type UploadPurpose string
+
+const (
+	// UploadPurposeRadioBackup becomes a radio backup. Metadata carries
+	// radioId, backupName and optionally backupType.
+	UploadPurposeRadioBackup UploadPurpose = "radio_backup"
+	// UploadPurposeBlackboxLog becomes a blackbox log. Metadata optionally
+	// carries aircraftId and notes.
+	UploadPurposeBlackboxLog UploadPurpose = "blackbox_log"
+)
+
+// UploadStatus is where a resumable upload is in its lifecycle
+type UploadStatus string
+
+const (
+	UploadStatusPending   UploadStatus = "pending"
+	UploadStatusCompleted UploadStatus = "completed"
+	UploadStatusFailed    UploadStatus = "failed"
+)
+
+// Upload is a file sent in chunks that can resume after a dropped
+// connection. Offset is how many bytes have been received.
+type Upload struct {
+	ID        string            `json:"id"`
+	UserID    string            `json:"-"`
+	Purpose   UploadPurpose     `json:"purpose"`
+	FileName  string            `json:"fileName"`
+	Size      int64             `json:"size"`
+	Offset    int64             `json:"offset"`
+	Checksum  string            `json:"checksum,omitempty"` // Expected SHA-256 of the whole file, hex
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	ChunkKeys []string          `json:"-"`
+	Status    UploadStatus      `json:"status"`
+	ResultID  string            `json:"resultId,omitempty"` // ID of what the upload became, such as a backup
+	Error     string            `json:"error,omitempty"`
+	ExpiresAt time.Time         `json:"expiresAt"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+// CreateUploadParams starts a resumable upload
+type CreateUploadParams struct {
+	Purpose  UploadPurpose     `json:"purpose"`
+	FileName string            `json:"fileName"`
+	Size     int64             `json:"size"`
+	Checksum string            `json:"checksum,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
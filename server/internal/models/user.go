@@ -10,9 +10,10 @@ import (
 type UserStatus string
 
 const (
-	UserStatusActive   UserStatus = "active"
-	UserStatusDisabled UserStatus = "disabled"
-	UserStatusPending  UserStatus = "pending"
+	UserStatusActive    UserStatus = "active"
+	UserStatusDisabled  UserStatus = "disabled"
+	UserStatusPending   UserStatus = "pending"
+	UserStatusSuspended UserStatus = "suspended" // disabled until StatusExpiresAt
 )
 
 // AuthProvider represents an identity provider
@@ -38,6 +39,24 @@ const (
 	ProfileVisibilityPrivate ProfileVisibility = "private"
 )
 
+// UnitSystem is the units a user reads catalog specs in
+type UnitSystem string
+
+const (
+	UnitSystemMetric   UnitSystem = "metric"
+	UnitSystemImperial UnitSystem = "imperial"
+)
+
+// Valid reports whether the unit system is one of the known systems
+func (u UnitSystem) Valid() bool {
+	return u == UnitSystemMetric || u == UnitSystemImperial
+}
+
+// UnitPreference is the unit system a user reads catalog specs in
+type UnitPreference struct {
+	UnitSystem UnitSystem `json:"unitSystem"`
+}
+
 // SocialSettings contains user's social/privacy preferences
 type SocialSettings struct {
 	ProfileVisibility ProfileVisibility `json:"profileVisibility"` // public or private
@@ -68,6 +87,11 @@ type User struct {
 	UpdatedAt      time.Time  `json:"updatedAt"`
 	LastLoginAt    *time.Time `json:"lastLoginAt,omitempty"`
 
+	// Why an admin disabled or suspended the account, and when a suspension
+	// ends. Both are cleared when the account is made active again.
+	StatusReason    string     `json:"statusReason,omitempty"`
+	StatusExpiresAt *time.Time `json:"statusExpiresAt,omitempty"`
+
 	// Profile fields
 	CallSign        string     `json:"callSign,omitempty"`
 	GoogleName      string     `json:"googleName,omitempty"`
@@ -129,6 +153,9 @@ type AuthResponse struct {
 	Tokens    *AuthTokens `json:"tokens"`
 	IsNewUser bool        `json:"isNewUser,omitempty"`
 	IsLinked  bool        `json:"isLinked,omitempty"`
+
+	// Handoff lists the anonymous builds claimed with the handoff token
+	Handoff *BuildHandoffResult `json:"handoff,omitempty"`
 }
 
 // GoogleLoginParams represents Google OAuth login parameters
@@ -137,6 +164,8 @@ type GoogleLoginParams struct {
 	IDToken     string `json:"idToken,omitempty"`
 	Code        string `json:"code,omitempty"`
 	RedirectURI string `json:"redirectUri,omitempty"`
+	// HandoffToken from a temporary build claims that session's builds
+	HandoffToken string `json:"handoffToken,omitempty"`
 }
 
 // GoogleClaims represents the claims from a Google ID token
@@ -176,10 +205,12 @@ type UpdateUserParams struct {
 
 // AdminUpdateUserParams represents admin-only user updates
 type AdminUpdateUserParams struct {
-	Status         *UserStatus `json:"status,omitempty"`
-	IsAdmin        *bool       `json:"isAdmin,omitempty"`
-	IsContentAdmin *bool       `json:"isContentAdmin,omitempty"`
-	IsGearAdmin    *bool       `json:"isGearAdmin,omitempty"` // Deprecated alias accepted for compatibility
+	Status          *UserStatus `json:"status,omitempty"`
+	StatusReason    *string     `json:"statusReason,omitempty"`    // required when disabling or suspending
+	StatusExpiresAt *time.Time  `json:"statusExpiresAt,omitempty"` // suspensions only
+	IsAdmin         *bool       `json:"isAdmin,omitempty"`
+	IsContentAdmin  *bool       `json:"isContentAdmin,omitempty"`
+	IsGearAdmin     *bool       `json:"isGearAdmin,omitempty"` // Deprecated alias accepted for compatibility
 }
 
 // UpdateProfileParams represents parameters for updating user profile
@@ -355,7 +386,7 @@ func (e *ValidationError) Error() string {
 // IsValidUserStatus checks if a user status is one of the supported values.
 func IsValidUserStatus(status UserStatus) bool {
 	switch status {
-	case UserStatusActive, UserStatusDisabled, UserStatusPending:
+	case UserStatusActive, UserStatusDisabled, UserStatusPending, UserStatusSuspended:
 		return true
 	default:
 		return false
@@ -376,6 +407,43 @@ type UsersResponse struct {
 	TotalCount int    `json:"totalCount"`
 }
 
+// UserRole is the highest access level a user has, for access reviews
+type UserRole string
+
+const (
+	UserRoleAdmin     UserRole = "admin"
+	UserRoleModerator UserRole = "moderator" // content admin
+	UserRoleUser      UserRole = "user"
+)
+
+// UserExportRow is one user in the admin access review export
+type UserExportRow struct {
+	ID                 string
+	Email              string
+	DisplayName        string
+	CallSign           string
+	Role               UserRole
+	Status             UserStatus
+	CreatedAt          time.Time
+	LastLoginAt        *time.Time
+	CatalogSubmissions int // catalog items the user created
+	CatalogPublished   int // of those, how many are published
+	PublishedBuilds    int
+	ApprovedGearImages int // gear images a curator approved
+}
+
+// RoleFor returns the highest role implied by a user's admin flags
+func RoleFor(isAdmin, isContentAdmin bool) UserRole {
+	switch {
+	case isAdmin:
+		return UserRoleAdmin
+	case isContentAdmin:
+		return UserRoleModerator
+	default:
+		return UserRoleUser
+	}
+}
+
 // RefreshToken represents a stored refresh token
 type RefreshToken struct {
 	ID        string     `json:"id"`
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// DuplicateUserReason explains why two accounts look like the same pilot
+type DuplicateUserReason string
+
+const (
+	// DuplicateReasonEmailCase is two accounts whose emails differ only in case
+	DuplicateReasonEmailCase DuplicateUserReason = "email_case"
+	// DuplicateReasonGoogleAccount is a Google sign-in linked to one account
+	// whose email is another account's, as when a pilot signs up again after
+	// deleting their account or changing their Google address
+	DuplicateReasonGoogleAccount DuplicateUserReason = "google_account"
+)
+
+// DuplicateUserSummary is one account of a likely duplicate pair, with enough
+// of its content for an admin to pick which one survives
+type DuplicateUserSummary struct {
+	ID             string     `json:"id"`
+	Email          string     `json:"email"`
+	DisplayName    string     `json:"displayName,omitempty"`
+	CallSign       string     `json:"callSign,omitempty"`
+	Status         UserStatus `json:"status"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	LastLoginAt    *time.Time `json:"lastLoginAt,omitempty"`
+	InventoryCount int        `json:"inventoryCount"`
+	AircraftCount  int        `json:"aircraftCount"`
+	BuildCount     int        `json:"buildCount"`
+}
+
+// DuplicateUserCandidate is a pair of accounts that likely belong to the same
+// pilot. Users are listed oldest first.
+type DuplicateUserCandidate struct {
+	Reason DuplicateUserReason    `json:"reason"`
+	Users  []DuplicateUserSummary `json:"users"`
+}
+
+// MergeUsersParams asks to fold one account into another. The source account
+// is disabled once its content has moved.
+type MergeUsersParams struct {
+	SourceUserID       string `json:"sourceUserId"`
+	TargetUserID       string `json:"targetUserId"`
+	ConfirmSourceEmail string `json:"confirmSourceEmail"` // must match the source account's email
+}
+
+// Normalize trims the IDs and confirmation email
+func (p *MergeUsersParams) Normalize() {
+	p.SourceUserID = strings.TrimSpace(p.SourceUserID)
+	p.TargetUserID = strings.TrimSpace(p.TargetUserID)
+	p.ConfirmSourceEmail = strings.TrimSpace(p.ConfirmSourceEmail)
+}
+
+// UserMergeResult counts what moved from the source account to the target
+type UserMergeResult struct {
+	SourceUserID string `json:"sourceUserId"`
+	TargetUserID string `json:"targetUserId"`
+	// InventoryItems moved as they were; InventoryItemsCombined were added to
+	// the target's quantity of the same catalog item
+	InventoryItems         int `json:"inventoryItems"`
+	InventoryItemsCombined int `json:"inventoryItemsCombined"`
+	Aircraft               int `json:"aircraft"`
+	Radios                 int `json:"radios"`
+	Batteries              int `json:"batteries"`
+	FCConfigs              int `json:"fcConfigs"`
+	Builds                 int `json:"builds"`
+	Follows                int `json:"follows"`
+	Identities             int `json:"identities"`
+}
@@ -30,7 +30,8 @@ func TestIsValidUserStatus(t *testing.T) {
 		{name: "active", status: UserStatusActive, want: true},
 		{name: "disabled", status: UserStatusDisabled, want: true},
 		{name: "pending", status: UserStatusPending, want: true},
-		{name: "invalid", status: UserStatus("suspended"), want: false},
+		{name: "suspended", status: UserStatusSuspended, want: true},
+		{name: "invalid", status: UserStatus("banned"), want: false},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,9 @@
+package models
+
+// ViewTarget is a kind of page whose views are counted
+type ViewTarget string
+
+const (
+	ViewTargetBuild ViewTarget = "build"
+	ViewTargetGear  ViewTarget = "gear"
+)
@@ -13,8 +13,9 @@ type MockModerator struct {
 }
 
 // ModerateImageBytes returns the configured decision/error.
-func (m *MockModerator) ModerateImageBytes(ctx context.Context, imageBytes []byte) (*models.ModerationDecision, error) {
+func (m *MockModerator) ModerateImageBytes(ctx context.Context, entityType models.ImageEntityType, imageBytes []byte) (*models.ModerationDecision, error) {
 	_ = ctx
+	_ = entityType
 	_ = imageBytes
 	if m.Err != nil {
 		return nil, m.Err
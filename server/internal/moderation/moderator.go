@@ -15,6 +15,7 @@ type Detector interface {
 type Service struct {
 	detector         Detector
 	rejectConfidence float64
+	policies         *Policies
 }
 
 // NewService creates a moderation service using the configured detector.
@@ -28,13 +29,26 @@ func NewService(detector Detector, rejectConfidence float64) *Service {
 	}
 }
 
-// ModerateImageBytes moderates image bytes and returns an APPROVED/REJECTED decision.
-func (s *Service) ModerateImageBytes(ctx context.Context, imageBytes []byte) (*models.ModerationDecision, error) {
+// SetPolicies applies per-entity-type thresholds and label allowlists in place
+// of the single reject confidence.
+func (s *Service) SetPolicies(policies *Policies) {
+	s.policies = policies
+}
+
+// ModerateImageBytes moderates image bytes and returns an APPROVED/REJECTED
+// decision under the policy for entityType. Allowlisted labels are reported
+// but never reject, and don't count toward MaxConfidence.
+func (s *Service) ModerateImageBytes(ctx context.Context, entityType models.ImageEntityType, imageBytes []byte) (*models.ModerationDecision, error) {
 	labels, err := s.detector.DetectModerationLabels(ctx, imageBytes)
 	if err != nil {
 		return nil, err
 	}
 
+	policy := models.ModerationPolicy{EntityType: entityType, RejectConfidence: s.rejectConfidence}
+	if s.policies != nil {
+		policy = s.policies.For(ctx, entityType)
+	}
+
 	decision := &models.ModerationDecision{
 		Status: models.ImageModerationApproved,
 		Reason: "Approved",
@@ -44,10 +58,13 @@ func (s *Service) ModerateImageBytes(ctx context.Context, imageBytes []byte) (*m
 	maxConfidence := 0.0
 	shouldReject := false
 	for _, label := range labels {
+		if allows(policy, label) {
+			continue
+		}
 		if label.Confidence > maxConfidence {
 			maxConfidence = label.Confidence
 		}
-		if label.Confidence >= s.rejectConfidence {
+		if label.Confidence >= policy.RejectConfidence {
 			shouldReject = true
 		}
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/johnrirwin/flyingforge/internal/models"
 )
@@ -71,7 +72,7 @@ func TestServiceModerateImageBytes(t *testing.T) {
 				err:    tt.err,
 			}, tt.threshold)
 
-			decision, err := svc.ModerateImageBytes(context.Background(), []byte("abc"))
+			decision, err := svc.ModerateImageBytes(context.Background(), models.ImageEntityOther, []byte("abc"))
 			if tt.err != nil {
 				if err == nil {
 					t.Fatalf("expected error")
@@ -91,3 +92,71 @@ func TestServiceModerateImageBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceModerateImageBytes_Policies(t *testing.T) {
+	store := &fakePolicyStore{policies: []models.ModerationPolicy{
+		{EntityType: models.ImageEntityAvatar, RejectConfidence: 40},
+		{EntityType: models.ImageEntityBuild, RejectConfidence: 70, AllowedLabels: []string{"weapons"}},
+	}}
+
+	tests := []struct {
+		name       string
+		entityType models.ImageEntityType
+		labels     []models.ModerationLabel
+		wantStatus models.ImageModerationStatus
+		wantMax    float64
+	}{
+		{
+			name:       "stricter threshold rejects avatars",
+			entityType: models.ImageEntityAvatar,
+			labels:     []models.ModerationLabel{{Name: "Suggestive", Confidence: 45}},
+			wantStatus: models.ImageModerationRejected,
+			wantMax:    45,
+		},
+		{
+			name:       "default threshold approves other images",
+			entityType: models.ImageEntityOther,
+			labels:     []models.ModerationLabel{{Name: "Suggestive", Confidence: 45}},
+			wantStatus: models.ImageModerationApproved,
+			wantMax:    45,
+		},
+		{
+			name:       "allowed parent label is ignored",
+			entityType: models.ImageEntityBuild,
+			labels:     []models.ModerationLabel{{Name: "Weapon Violence", ParentName: "Weapons", Confidence: 92}},
+			wantStatus: models.ImageModerationApproved,
+			wantMax:    0,
+		},
+		{
+			name:       "allowlist does not cover other labels",
+			entityType: models.ImageEntityBuild,
+			labels: []models.ModerationLabel{
+				{Name: "Weapons", Confidence: 92},
+				{Name: "Explicit Nudity", Confidence: 75},
+			},
+			wantStatus: models.ImageModerationRejected,
+			wantMax:    75,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewService(&fakeDetector{labels: tt.labels}, 70)
+			svc.SetPolicies(NewPolicies(store, 70, time.Minute))
+
+			decision, err := svc.ModerateImageBytes(context.Background(), tt.entityType, []byte("abc"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if decision.Status != tt.wantStatus {
+				t.Fatalf("status=%s want=%s", decision.Status, tt.wantStatus)
+			}
+			if decision.MaxConfidence != tt.wantMax {
+				t.Fatalf("max=%v want=%v", decision.MaxConfidence, tt.wantMax)
+			}
+			if len(decision.Labels) != len(tt.labels) {
+				t.Fatalf("labels=%d want=%d, allowed labels should still be reported", len(decision.Labels), len(tt.labels))
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package moderation
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// maxAllowedLabels bounds the allowlist an admin can set for one entity type.
+const maxAllowedLabels = 50
+
+// PolicyStore persists per-entity-type moderation policies.
+type PolicyStore interface {
+	List(ctx context.Context) ([]models.ModerationPolicy, error)
+	Upsert(ctx context.Context, entityType models.ImageEntityType, adminUserID string, params models.SetModerationPolicyParams) error
+	Delete(ctx context.Context, entityType models.ImageEntityType) error
+}
+
+// Policies resolves the moderation policy for each image entity type. Stored
+// policies are cached for ttl so every instance picks up admin changes
+// without a database read per upload.
+type Policies struct {
+	store             PolicyStore
+	defaultConfidence float64
+	ttl               time.Duration
+
+	mu       sync.RWMutex
+	cached   map[models.ImageEntityType]models.ModerationPolicy
+	loadedAt time.Time
+}
+
+// NewPolicies creates a policy resolver. Entity types without a stored policy
+// use defaultConfidence and no allowed labels.
+func NewPolicies(store PolicyStore, defaultConfidence float64, ttl time.Duration) *Policies {
+	if defaultConfidence <= 0 {
+		defaultConfidence = 70
+	}
+	return &Policies{
+		store:             store,
+		defaultConfidence: defaultConfidence,
+		ttl:               ttl,
+	}
+}
+
+// For returns the effective policy for an entity type. If stored policies
+// can't be loaded, the last loaded policies (or the default) are used.
+func (p *Policies) For(ctx context.Context, entityType models.ImageEntityType) models.ModerationPolicy {
+	p.mu.RLock()
+	cached, fresh := p.cached, p.cached != nil && time.Since(p.loadedAt) < p.ttl
+	p.mu.RUnlock()
+
+	if !fresh {
+		if loaded, err := p.load(ctx); err == nil {
+			cached = loaded
+		}
+	}
+
+	if policy, ok := cached[entityType]; ok {
+		return policy
+	}
+	return p.defaultPolicy(entityType)
+}
+
+// List returns the effective policy for every entity type.
+func (p *Policies) List(ctx context.Context) ([]models.ModerationPolicy, error) {
+	stored, err := p.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]models.ModerationPolicy, 0, len(models.AllImageEntityTypes()))
+	for _, entityType := range models.AllImageEntityTypes() {
+		if policy, ok := stored[entityType]; ok {
+			policies = append(policies, policy)
+			continue
+		}
+		policies = append(policies, p.defaultPolicy(entityType))
+	}
+	return policies, nil
+}
+
+// Set validates and stores the policy for an entity type.
+func (p *Policies) Set(ctx context.Context, adminUserID string, entityType models.ImageEntityType, params models.SetModerationPolicyParams) (*models.ModerationPolicy, error) {
+	if !models.IsValidImageEntityType(entityType) {
+		return nil, &PolicyError{Message: "unknown entity type"}
+	}
+	if params.RejectConfidence <= 0 || params.RejectConfidence > 100 {
+		return nil, &PolicyError{Message: "rejectConfidence must be between 0 and 100"}
+	}
+
+	labels := make([]string, 0, len(params.AllowedLabels))
+	seen := make(map[string]bool)
+	for _, label := range params.AllowedLabels {
+		label = strings.TrimSpace(label)
+		if label == "" || seen[strings.ToLower(label)] {
+			continue
+		}
+		seen[strings.ToLower(label)] = true
+		labels = append(labels, label)
+	}
+	if len(labels) > maxAllowedLabels {
+		return nil, &PolicyError{Message: "too many allowed labels"}
+	}
+	params.AllowedLabels = labels
+
+	if err := p.store.Upsert(ctx, entityType, adminUserID, params); err != nil {
+		return nil, err
+	}
+	stored, err := p.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policy := stored[entityType]
+	return &policy, nil
+}
+
+// Reset removes an entity type's stored policy so the default applies again.
+func (p *Policies) Reset(ctx context.Context, entityType models.ImageEntityType) error {
+	if err := p.store.Delete(ctx, entityType); err != nil {
+		return err
+	}
+	_, err := p.load(ctx)
+	return err
+}
+
+func (p *Policies) load(ctx context.Context) (map[models.ImageEntityType]models.ModerationPolicy, error) {
+	policies, err := p.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := make(map[models.ImageEntityType]models.ModerationPolicy, len(policies))
+	for _, policy := range policies {
+		loaded[policy.EntityType] = policy
+	}
+
+	p.mu.Lock()
+	p.cached = loaded
+	p.loadedAt = time.Now()
+	p.mu.Unlock()
+	return loaded, nil
+}
+
+func (p *Policies) defaultPolicy(entityType models.ImageEntityType) models.ModerationPolicy {
+	return models.ModerationPolicy{
+		EntityType:       entityType,
+		RejectConfidence: p.defaultConfidence,
+		AllowedLabels:    []string{},
+	}
+}
+
+// allows reports whether a label is on the policy's allowlist, matching the
+// label or its parent category case-insensitively.
+func allows(policy models.ModerationPolicy, label models.ModerationLabel) bool {
+	for _, allowed := range policy.AllowedLabels {
+		if strings.EqualFold(allowed, label.Name) || (label.ParentName != "" && strings.EqualFold(allowed, label.ParentName)) {
+			return true
+		}
+	}
+	return false
+}
+
+// PolicyError represents an invalid policy change that should be shown to the client
+type PolicyError struct {
+	Message string
+}
+
+func (e *PolicyError) Error() string {
+	return e.Message
+}
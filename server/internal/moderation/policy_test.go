@@ -0,0 +1,128 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// fakePolicyStore keeps policies in memory and counts List calls
+type fakePolicyStore struct {
+	policies []models.ModerationPolicy
+	lists    int
+	listErr  error
+}
+
+func (f *fakePolicyStore) List(ctx context.Context) ([]models.ModerationPolicy, error) {
+	f.lists++
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	policies := make([]models.ModerationPolicy, len(f.policies))
+	for i, policy := range f.policies {
+		policy.Custom = true
+		policies[i] = policy
+	}
+	return policies, nil
+}
+
+func (f *fakePolicyStore) Upsert(ctx context.Context, entityType models.ImageEntityType, adminUserID string, params models.SetModerationPolicyParams) error {
+	policy := models.ModerationPolicy{EntityType: entityType, RejectConfidence: params.RejectConfidence, AllowedLabels: params.AllowedLabels, UpdatedBy: adminUserID}
+	for i := range f.policies {
+		if f.policies[i].EntityType == entityType {
+			f.policies[i] = policy
+			return nil
+		}
+	}
+	f.policies = append(f.policies, policy)
+	return nil
+}
+
+func (f *fakePolicyStore) Delete(ctx context.Context, entityType models.ImageEntityType) error {
+	for i := range f.policies {
+		if f.policies[i].EntityType == entityType {
+			f.policies = append(f.policies[:i], f.policies[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("moderation policy not found")
+}
+
+func TestPolicies_For(t *testing.T) {
+	store := &fakePolicyStore{policies: []models.ModerationPolicy{{EntityType: models.ImageEntityAvatar, RejectConfidence: 40}}}
+	policies := NewPolicies(store, 70, time.Minute)
+	ctx := context.Background()
+
+	if got := policies.For(ctx, models.ImageEntityAvatar); got.RejectConfidence != 40 || !got.Custom {
+		t.Errorf("avatar policy = %+v, want stored confidence 40", got)
+	}
+	if got := policies.For(ctx, models.ImageEntityGear); got.RejectConfidence != 70 || got.Custom {
+		t.Errorf("gear policy = %+v, want default confidence 70", got)
+	}
+	if store.lists != 1 {
+		t.Errorf("store listed %d times, want 1 while cached", store.lists)
+	}
+
+	// A failed reload keeps serving the last loaded policies.
+	policies.loadedAt = time.Time{}
+	store.listErr = errors.New("db down")
+	if got := policies.For(ctx, models.ImageEntityAvatar); got.RejectConfidence != 40 {
+		t.Errorf("avatar policy after failed reload = %+v, want stale confidence 40", got)
+	}
+}
+
+func TestPolicies_Set(t *testing.T) {
+	tests := []struct {
+		name       string
+		entityType models.ImageEntityType
+		params     models.SetModerationPolicyParams
+		wantErr    bool
+		wantLabels []string
+	}{
+		{
+			name:       "stores trimmed, deduplicated labels",
+			entityType: models.ImageEntityBuild,
+			params:     models.SetModerationPolicyParams{RejectConfidence: 80, AllowedLabels: []string{" Weapons ", "weapons", ""}},
+			wantLabels: []string{"Weapons"},
+		},
+		{
+			name:       "rejects unknown entity type",
+			entityType: "poster",
+			params:     models.SetModerationPolicyParams{RejectConfidence: 80},
+			wantErr:    true,
+		},
+		{
+			name:       "rejects confidence out of range",
+			entityType: models.ImageEntityAvatar,
+			params:     models.SetModerationPolicyParams{RejectConfidence: 101},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policies := NewPolicies(&fakePolicyStore{}, 70, time.Minute)
+
+			policy, err := policies.Set(context.Background(), "admin-1", tt.entityType, tt.params)
+			if tt.wantErr {
+				var policyErr *PolicyError
+				if !errors.As(err, &policyErr) {
+					t.Fatalf("Set() error = %v, want PolicyError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Set() unexpected error: %v", err)
+			}
+			if len(policy.AllowedLabels) != len(tt.wantLabels) || policy.AllowedLabels[0] != tt.wantLabels[0] {
+				t.Errorf("allowed labels = %v, want %v", policy.AllowedLabels, tt.wantLabels)
+			}
+			if got := policies.For(context.Background(), tt.entityType); got.RejectConfidence != tt.params.RejectConfidence {
+				t.Errorf("For() confidence = %v, want %v", got.RejectConfidence, tt.params.RejectConfidence)
+			}
+		})
+	}
+}
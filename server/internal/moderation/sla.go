@@ -0,0 +1,162 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/push"
+)
+
+// maxAgingItems bounds the overdue items listed per queue in a report.
+const maxAgingItems = 200
+
+// slaQueues are the moderation queues with SLAs, in report order.
+var slaQueues = []models.ModerationQueue{models.ModerationQueueGear, models.ModerationQueueBuild}
+
+// AgingStore reads how long items have waited in the moderation queues.
+type AgingStore interface {
+	QueueAging(ctx context.Context, queue models.ModerationQueue, overdueBefore time.Time, limit int) (*models.ModerationQueueAging, error)
+	ListUnescalated(ctx context.Context, queue models.ModerationQueue, overdueBefore time.Time) ([]string, error)
+	MarkEscalated(ctx context.Context, queue models.ModerationQueue, ids []string) error
+	ListModeratorIDs(ctx context.Context) ([]string, error)
+}
+
+// SLAMonitor reports moderation queue items that have waited longer than
+// their queue's SLA and can alert moderators about them.
+type SLAMonitor struct {
+	store    AgingStore
+	slas     map[models.ModerationQueue]time.Duration
+	notifier push.Notifier
+	logger   *logging.Logger
+}
+
+// NewSLAMonitor creates an SLA monitor. Queues missing from slas use the
+// defaults.
+func NewSLAMonitor(store AgingStore, slas map[models.ModerationQueue]time.Duration, logger *logging.Logger) *SLAMonitor {
+	resolved := map[models.ModerationQueue]time.Duration{
+		models.ModerationQueueGear:  models.DefaultGearModerationSLA,
+		models.ModerationQueueBuild: models.DefaultBuildModerationSLA,
+	}
+	for queue, sla := range slas {
+		if sla > 0 {
+			resolved[queue] = sla
+		}
+	}
+	return &SLAMonitor{store: store, slas: resolved, logger: logger}
+}
+
+// SetNotifier enables alerting moderators about overdue items.
+func (m *SLAMonitor) SetNotifier(notifier push.Notifier) {
+	m.notifier = notifier
+}
+
+// SLA returns how long an item can wait in queue before it's overdue.
+func (m *SLAMonitor) SLA(queue models.ModerationQueue) time.Duration {
+	return m.slas[queue]
+}
+
+// Report summarizes each queue and lists its overdue items, oldest first.
+// overrides replaces the SLA of a queue for this report only. When queue is
+// set, only that queue is reported.
+func (m *SLAMonitor) Report(ctx context.Context, queue models.ModerationQueue, overrides map[models.ModerationQueue]time.Duration, now time.Time) (*models.ModerationAgingReport, error) {
+	report := &models.ModerationAgingReport{GeneratedAt: now, Queues: []models.ModerationQueueAging{}}
+	for _, q := range slaQueues {
+		if queue != "" && q != queue {
+			continue
+		}
+		sla := m.slas[q]
+		if override := overrides[q]; override > 0 {
+			sla = override
+		}
+
+		aging, err := m.store.QueueAging(ctx, q, now.Add(-sla), maxAgingItems)
+		if err != nil {
+			return nil, err
+		}
+		aging.SLASeconds = int64(sla.Seconds())
+		report.Queues = append(report.Queues, *aging)
+	}
+	return report, nil
+}
+
+// Escalate alerts every moderator once about items that went overdue since
+// the last run. Items are only marked alerted when at least one moderator
+// was notified. Returns the number of items escalated.
+func (m *SLAMonitor) Escalate(ctx context.Context, now time.Time) (int, error) {
+	if m.notifier == nil {
+		return 0, nil
+	}
+
+	overdue := make(map[models.ModerationQueue][]string)
+	total := 0
+	for _, queue := range slaQueues {
+		ids, err := m.store.ListUnescalated(ctx, queue, now.Add(-m.slas[queue]))
+		if err != nil {
+			return 0, err
+		}
+		overdue[queue] = ids
+		total += len(ids)
+	}
+	if total == 0 {
+		return 0, nil
+	}
+
+	moderators, err := m.store.ListModeratorIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	notification := escalationNotification(overdue)
+	delivered := false
+	for _, userID := range moderators {
+		if err := m.notifier.Notify(ctx, userID, notification); err != nil {
+			m.logger.Warn("Failed to send moderation SLA alert", logging.WithFields(map[string]interface{}{
+				"userId": userID,
+				"error":  err.Error(),
+			}))
+			continue
+		}
+		delivered = true
+	}
+	if !delivered {
+		return 0, nil
+	}
+
+	for _, queue := range slaQueues {
+		if err := m.store.MarkEscalated(ctx, queue, overdue[queue]); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+func escalationNotification(overdue map[models.ModerationQueue][]string) models.Notification {
+	parts := make([]string, 0, len(slaQueues))
+	data := map[string]string{}
+	for _, queue := range slaQueues {
+		count := len(overdue[queue])
+		data[string(queue)] = fmt.Sprintf("%d", count)
+		if count == 0 {
+			continue
+		}
+		noun := "gear items"
+		if queue == models.ModerationQueueBuild {
+			noun = "builds"
+		}
+		if count == 1 {
+			noun = strings.TrimSuffix(noun, "s")
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", count, noun))
+	}
+
+	return models.Notification{
+		Kind:  models.NotificationModerationSLA,
+		Title: "Moderation queue is overdue",
+		Body:  fmt.Sprintf("%s waited past the review SLA.", strings.Join(parts, " and ")),
+		Data:  data,
+	}
+}
@@ -0,0 +1,124 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// fakeAgingStore records the cutoffs it was asked about and which items were
+// marked escalated
+type fakeAgingStore struct {
+	cutoffs     map[models.ModerationQueue]time.Time
+	unescalated map[models.ModerationQueue][]string
+	escalated   map[models.ModerationQueue][]string
+	moderators  []string
+}
+
+func (f *fakeAgingStore) QueueAging(ctx context.Context, queue models.ModerationQueue, overdueBefore time.Time, limit int) (*models.ModerationQueueAging, error) {
+	f.cutoffs[queue] = overdueBefore
+	return &models.ModerationQueueAging{Queue: queue, Items: []models.ModerationAgingItem{}}, nil
+}
+
+func (f *fakeAgingStore) ListUnescalated(ctx context.Context, queue models.ModerationQueue, overdueBefore time.Time) ([]string, error) {
+	return f.unescalated[queue], nil
+}
+
+func (f *fakeAgingStore) MarkEscalated(ctx context.Context, queue models.ModerationQueue, ids []string) error {
+	f.escalated[queue] = append(f.escalated[queue], ids...)
+	return nil
+}
+
+func (f *fakeAgingStore) ListModeratorIDs(ctx context.Context) ([]string, error) {
+	return f.moderators, nil
+}
+
+type fakeNotifier struct {
+	sent []models.Notification
+	err  error
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, userID string, n models.Notification) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, n)
+	return nil
+}
+
+func newFakeAgingStore() *fakeAgingStore {
+	return &fakeAgingStore{
+		cutoffs:     map[models.ModerationQueue]time.Time{},
+		unescalated: map[models.ModerationQueue][]string{},
+		escalated:   map[models.ModerationQueue][]string{},
+	}
+}
+
+func TestSLAMonitor_Report(t *testing.T) {
+	store := newFakeAgingStore()
+	monitor := NewSLAMonitor(store, map[models.ModerationQueue]time.Duration{models.ModerationQueueGear: 24 * time.Hour}, logging.New(logging.LevelError))
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	report, err := monitor.Report(context.Background(), "", map[models.ModerationQueue]time.Duration{models.ModerationQueueBuild: time.Hour}, now)
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if len(report.Queues) != 2 {
+		t.Fatalf("Report() queues = %d, want 2", len(report.Queues))
+	}
+	if got := report.Queues[0].SLASeconds; got != int64((24 * time.Hour).Seconds()) {
+		t.Errorf("gear SLA = %d, want the configured 24h", got)
+	}
+	if got := store.cutoffs[models.ModerationQueueBuild]; !got.Equal(now.Add(-time.Hour)) {
+		t.Errorf("build cutoff = %v, want the 1h override", got)
+	}
+
+	report, err = monitor.Report(context.Background(), models.ModerationQueueBuild, nil, now)
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if len(report.Queues) != 1 || report.Queues[0].SLASeconds != int64(models.DefaultBuildModerationSLA.Seconds()) {
+		t.Errorf("Report(build) = %+v, want only the build queue with the default SLA", report.Queues)
+	}
+}
+
+func TestSLAMonitor_Escalate(t *testing.T) {
+	store := newFakeAgingStore()
+	store.unescalated[models.ModerationQueueGear] = []string{"gear-1", "gear-2"}
+	store.unescalated[models.ModerationQueueBuild] = []string{"build-1"}
+	store.moderators = []string{"mod-1", "mod-2"}
+	monitor := NewSLAMonitor(store, nil, logging.New(logging.LevelError))
+	now := time.Now()
+
+	if escalated, err := monitor.Escalate(context.Background(), now); escalated != 0 || err != nil {
+		t.Fatalf("Escalate() without notifier = %d, %v, want 0, nil", escalated, err)
+	}
+
+	notifier := &fakeNotifier{err: errors.New("push down")}
+	monitor.SetNotifier(notifier)
+	if escalated, err := monitor.Escalate(context.Background(), now); escalated != 0 || err != nil || len(store.escalated) != 0 {
+		t.Fatalf("Escalate() with failing push = %d, %v, marked %v; want nothing marked", escalated, err, store.escalated)
+	}
+
+	notifier.err = nil
+	escalated, err := monitor.Escalate(context.Background(), now)
+	if err != nil {
+		t.Fatalf("Escalate() error = %v", err)
+	}
+	if escalated != 3 {
+		t.Errorf("Escalate() = %d, want 3", escalated)
+	}
+	if len(notifier.sent) != 2 {
+		t.Fatalf("sent %d notifications, want one per moderator", len(notifier.sent))
+	}
+	if want := "2 gear items and 1 build waited past the review SLA."; notifier.sent[0].Body != want {
+		t.Errorf("notification body = %q, want %q", notifier.sent[0].Body, want)
+	}
+	if len(store.escalated[models.ModerationQueueGear]) != 2 || len(store.escalated[models.ModerationQueueBuild]) != 1 {
+		t.Errorf("escalated = %v, want every overdue item marked", store.escalated)
+	}
+}
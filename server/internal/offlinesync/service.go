@@ -0,0 +1,418 @@
+package offlinesync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/johnrirwin/flyingforge/internal/aircraft"
+	"github.com/johnrirwin/flyingforge/internal/battery"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/inventory"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	defaultChangesLimit = 100
+	maxChangesLimit     = 500
+	maxBatchOperations  = 100
+)
+
+// Store defines the change-tracking operations the sync service needs
+type Store interface {
+	ChangesSince(ctx context.Context, userID string, since int64, types []models.SyncEntityType, limit int) ([]models.SyncChange, error)
+	CurrentSeq(ctx context.Context, entityType models.SyncEntityType, id, userID string) (int64, bool, error)
+	GetClientRef(ctx context.Context, userID, clientRef string) (models.SyncEntityType, string, error)
+	ReserveClientRef(ctx context.Context, userID, clientRef string, entityType models.SyncEntityType) (bool, error)
+	SaveClientRef(ctx context.Context, userID, clientRef string, entityType models.SyncEntityType, entityID string) error
+	RunInTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// AircraftManager defines the aircraft operations used by sync
+type AircraftManager interface {
+	Create(ctx context.Context, userID string, params models.CreateAircraftParams) (*models.Aircraft, error)
+	Get(ctx context.Context, id string, userID string) (*models.Aircraft, error)
+	Update(ctx context.Context, userID string, params models.UpdateAircraftParams) (*models.Aircraft, error)
+	Delete(ctx context.Context, id string, userID string) error
+}
+
+// BatteryManager defines the battery operations used by sync
+type BatteryManager interface {
+	Create(ctx context.Context, userID string, params models.CreateBatteryParams) (*models.Battery, error)
+	Get(ctx context.Context, id string, userID string) (*models.Battery, error)
+	Update(ctx context.Context, userID string, params models.UpdateBatteryParams) (*models.Battery, error)
+	Delete(ctx context.Context, id string, userID string) error
+}
+
+// Service serves change feeds and applies batched client changes for offline clients
+type Service struct {
+	store        Store
+	inventorySvc inventory.InventoryManager
+	aircraftSvc  AircraftManager
+	batterySvc   BatteryManager
+	logger       *logging.Logger
+}
+
+// NewService creates a new sync service
+func NewService(store *database.SyncStore, inventorySvc inventory.InventoryManager, aircraftSvc *aircraft.Service, batterySvc *battery.Service, logger *logging.Logger) *Service {
+	return &Service{
+		store:        store,
+		inventorySvc: inventorySvc,
+		aircraftSvc:  aircraftSvc,
+		batterySvc:   batterySvc,
+		logger:       logger,
+	}
+}
+
+// Changes returns the next page of changes after params.Since, with the current
+// copy of every changed entity attached
+func (s *Service) Changes(ctx context.Context, userID string, params models.SyncChangesParams) (*models.SyncChangesResponse, error) {
+	for _, entityType := range params.Types {
+		if !models.IsValidSyncEntityType(entityType) {
+			return nil, &ServiceError{Message: fmt.Sprintf("unsupported entity type %q", entityType)}
+		}
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultChangesLimit
+	}
+	if limit > maxChangesLimit {
+		limit = maxChangesLimit
+	}
+
+	// Fetch one extra row to know whether another page follows
+	changes, err := s.store.ChangesSince(ctx, userID, params.Since, params.Types, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	hasMore := len(changes) > limit
+	if hasMore {
+		changes = changes[:limit]
+	}
+
+	for i := range changes {
+		change := &changes[i]
+		if change.Deleted {
+			continue
+		}
+		data, err := s.load(ctx, change.EntityType, change.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			// Deleted after the feed was read; its tombstone follows on a later page
+			change.Deleted = true
+			continue
+		}
+		change.Data = data
+	}
+
+	cursor := models.FormatSyncCursor(params.Since)
+	if len(changes) > 0 {
+		cursor = changes[len(changes)-1].Cursor
+	}
+
+	return &models.SyncChangesResponse{
+		Changes: changes,
+		Cursor:  cursor,
+		HasMore: hasMore,
+	}, nil
+}
+
+// ApplyBatch applies client operations in order. Each operation succeeds or fails
+// on its own; a failed operation never aborts the rest of the batch.
+func (s *Service) ApplyBatch(ctx context.Context, userID string, req models.SyncBatchRequest) (*models.SyncBatchResponse, error) {
+	if len(req.Operations) == 0 {
+		return nil, &ServiceError{Message: "operations are required"}
+	}
+	if len(req.Operations) > maxBatchOperations {
+		return nil, &ServiceError{Message: fmt.Sprintf("at most %d operations per batch", maxBatchOperations)}
+	}
+
+	results := make([]models.SyncOperationResult, len(req.Operations))
+	for i, op := range req.Operations {
+		result := s.apply(ctx, userID, op)
+		result.Index = i
+		result.EntityType = op.EntityType
+		result.ClientRef = op.ClientRef
+		if result.Status == models.SyncStatusError {
+			s.logger.Error("Sync operation failed", logging.WithFields(map[string]interface{}{
+				"userId":     userID,
+				"entityType": op.EntityType,
+				"id":         op.ID,
+				"error":      result.Error,
+			}))
+			result.Error = "internal error"
+		}
+		results[i] = result
+	}
+
+	return &models.SyncBatchResponse{Results: results}, nil
+}
+
+func (s *Service) apply(ctx context.Context, userID string, op models.SyncOperation) models.SyncOperationResult {
+	if !models.IsValidSyncEntityType(op.EntityType) {
+		return rejected(fmt.Sprintf("unsupported entity type %q", op.EntityType))
+	}
+	baseSeq, ok := models.ParseSyncCursor(op.BaseCursor)
+	if !ok {
+		return rejected("invalid baseCursor")
+	}
+
+	switch op.Op {
+	case models.SyncOpUpsert:
+		if op.ID == "" {
+			return s.applyCreate(ctx, userID, op)
+		}
+	case models.SyncOpDelete:
+		if op.ID == "" {
+			return rejected("id is required for delete")
+		}
+	default:
+		return rejected(fmt.Sprintf("unsupported op %q", op.Op))
+	}
+
+	seq, found, err := s.store.CurrentSeq(ctx, op.EntityType, op.ID, userID)
+	if err != nil {
+		return failed(err)
+	}
+	if !found {
+		if op.Op == models.SyncOpDelete {
+			// Already gone: deletes are idempotent
+			return models.SyncOperationResult{ID: op.ID, Status: models.SyncStatusApplied}
+		}
+		return models.SyncOperationResult{ID: op.ID, Status: models.SyncStatusNotFound}
+	}
+
+	if !op.Force && baseSeq > 0 && seq > baseSeq {
+		current, err := s.load(ctx, op.EntityType, op.ID, userID)
+		if err != nil {
+			return failed(err)
+		}
+		return models.SyncOperationResult{
+			ID:      op.ID,
+			Status:  models.SyncStatusConflict,
+			Cursor:  models.FormatSyncCursor(seq),
+			Current: current,
+		}
+	}
+
+	if op.Op == models.SyncOpDelete {
+		if err := s.delete(ctx, op.EntityType, op.ID, userID); err != nil {
+			return failedOrRejected(err)
+		}
+		return models.SyncOperationResult{ID: op.ID, Status: models.SyncStatusApplied}
+	}
+
+	if err := s.update(ctx, op.EntityType, op.ID, userID, op.Data); err != nil {
+		return failedOrRejected(err)
+	}
+	return s.applied(ctx, userID, op.EntityType, op.ID)
+}
+
+func (s *Service) applyCreate(ctx context.Context, userID string, op models.SyncOperation) models.SyncOperationResult {
+	if op.ClientRef == "" {
+		id, err := s.create(ctx, op.EntityType, userID, op.Data)
+		if err != nil {
+			return failedOrRejected(err)
+		}
+		return s.applied(ctx, userID, op.EntityType, id)
+	}
+
+	// The client ref is reserved before the entity is created, in the same
+	// transaction, so a concurrent replay waits for this one and then finds
+	// the entity it made
+	var entityType models.SyncEntityType
+	var id string
+	err := s.store.RunInTx(ctx, func(ctx context.Context) error {
+		reserved, err := s.store.ReserveClientRef(ctx, userID, op.ClientRef, op.EntityType)
+		if err != nil {
+			return err
+		}
+		if !reserved {
+			existingType, existingID, err := s.store.GetClientRef(ctx, userID, op.ClientRef)
+			if err != nil {
+				return err
+			}
+			if existingID == "" {
+				return fmt.Errorf("client ref %q has no entity", op.ClientRef)
+			}
+			if existingType != op.EntityType {
+				return &ServiceError{Message: "clientRef already used for a different entity type"}
+			}
+			// Replayed create: report the entity made the first time
+			entityType, id = existingType, existingID
+			return nil
+		}
+
+		created, err := s.create(ctx, op.EntityType, userID, op.Data)
+		if err != nil {
+			return err
+		}
+		entityType, id = op.EntityType, created
+		return s.store.SaveClientRef(ctx, userID, op.ClientRef, op.EntityType, created)
+	})
+	if err != nil {
+		return failedOrRejected(err)
+	}
+	return s.applied(ctx, userID, entityType, id)
+}
+
+// applied builds a success result carrying the entity's new cursor
+func (s *Service) applied(ctx context.Context, userID string, entityType models.SyncEntityType, id string) models.SyncOperationResult {
+	result := models.SyncOperationResult{ID: id, Status: models.SyncStatusApplied}
+	seq, found, err := s.store.CurrentSeq(ctx, entityType, id, userID)
+	if err != nil {
+		return failed(err)
+	}
+	if found {
+		result.Cursor = models.FormatSyncCursor(seq)
+	}
+	return result
+}
+
+func (s *Service) load(ctx context.Context, entityType models.SyncEntityType, id, userID string) (interface{}, error) {
+	switch entityType {
+	case models.SyncEntityInventory:
+		item, err := s.inventorySvc.GetItem(ctx, id, userID)
+		if err != nil || item == nil {
+			return nil, err
+		}
+		return item, nil
+	case models.SyncEntityAircraft:
+		a, err := s.aircraftSvc.Get(ctx, id, userID)
+		if err != nil || a == nil {
+			return nil, err
+		}
+		return a, nil
+	case models.SyncEntityBattery:
+		b, err := s.batterySvc.Get(ctx, id, userID)
+		if err != nil || b == nil {
+			return nil, err
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("unsupported sync entity type %q", entityType)
+}
+
+func (s *Service) create(ctx context.Context, entityType models.SyncEntityType, userID string, data json.RawMessage) (string, error) {
+	switch entityType {
+	case models.SyncEntityInventory:
+		var params models.AddInventoryParams
+		if err := decodeData(data, &params); err != nil {
+			return "", err
+		}
+		item, err := s.inventorySvc.AddItem(ctx, userID, params)
+		if err != nil {
+			return "", err
+		}
+		return item.ID, nil
+	case models.SyncEntityAircraft:
+		var params models.CreateAircraftParams
+		if err := decodeData(data, &params); err != nil {
+			return "", err
+		}
+		a, err := s.aircraftSvc.Create(ctx, userID, params)
+		if err != nil {
+			return "", err
+		}
+		return a.ID, nil
+	case models.SyncEntityBattery:
+		var params models.CreateBatteryParams
+		if err := decodeData(data, &params); err != nil {
+			return "", err
+		}
+		b, err := s.batterySvc.Create(ctx, userID, params)
+		if err != nil {
+			return "", err
+		}
+		return b.ID, nil
+	}
+	return "", fmt.Errorf("unsupported sync entity type %q", entityType)
+}
+
+func (s *Service) update(ctx context.Context, entityType models.SyncEntityType, id, userID string, data json.RawMessage) error {
+	switch entityType {
+	case models.SyncEntityInventory:
+		var params models.UpdateInventoryParams
+		if err := decodeData(data, &params); err != nil {
+			return err
+		}
+		params.ID = id
+		_, err := s.inventorySvc.UpdateItem(ctx, userID, params)
+		return err
+	case models.SyncEntityAircraft:
+		var params models.UpdateAircraftParams
+		if err := decodeData(data, &params); err != nil {
+			return err
+		}
+		params.ID = id
+		_, err := s.aircraftSvc.Update(ctx, userID, params)
+		return err
+	case models.SyncEntityBattery:
+		var params models.UpdateBatteryParams
+		if err := decodeData(data, &params); err != nil {
+			return err
+		}
+		params.ID = id
+		_, err := s.batterySvc.Update(ctx, userID, params)
+		return err
+	}
+	return fmt.Errorf("unsupported sync entity type %q", entityType)
+}
+
+func (s *Service) delete(ctx context.Context, entityType models.SyncEntityType, id, userID string) error {
+	switch entityType {
+	case models.SyncEntityInventory:
+		return s.inventorySvc.RemoveItem(ctx, id, userID)
+	case models.SyncEntityAircraft:
+		return s.aircraftSvc.Delete(ctx, id, userID)
+	case models.SyncEntityBattery:
+		return s.batterySvc.Delete(ctx, id, userID)
+	}
+	return fmt.Errorf("unsupported sync entity type %q", entityType)
+}
+
+func decodeData(data json.RawMessage, v interface{}) error {
+	if len(data) == 0 {
+		return &ServiceError{Message: "data is required"}
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return &ServiceError{Message: "invalid data"}
+	}
+	return nil
+}
+
+func rejected(message string) models.SyncOperationResult {
+	return models.SyncOperationResult{Status: models.SyncStatusRejected, Error: message}
+}
+
+func failed(err error) models.SyncOperationResult {
+	return models.SyncOperationResult{Status: models.SyncStatusError, Error: err.Error()}
+}
+
+// failedOrRejected reports validation errors from the domain services back to the
+// client and treats everything else as an internal failure
+func failedOrRejected(err error) models.SyncOperationResult {
+	var syncErr *ServiceError
+	var inventoryErr *inventory.ServiceError
+	var aircraftErr *aircraft.ServiceError
+	var batteryErr *battery.ServiceError
+	switch {
+	case errors.As(err, &syncErr), errors.As(err, &inventoryErr), errors.As(err, &aircraftErr), errors.As(err, &batteryErr):
+		return rejected(err.Error())
+	}
+	return failed(err)
+}
+
+// ServiceError represents a sync request error that should be shown to the client
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,352 @@
+package offlinesync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/aircraft"
+	"github.com/johnrirwin/flyingforge/internal/inventory"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+// mockStore implements the Store interface for testing
+type mockStore struct {
+	changes    []models.SyncChange
+	seqs       map[string]int64
+	clientRefs map[string]string
+	lastLimit  int
+}
+
+func (m *mockStore) ChangesSince(ctx context.Context, userID string, since int64, types []models.SyncEntityType, limit int) ([]models.SyncChange, error) {
+	m.lastLimit = limit
+	var out []models.SyncChange
+	for _, c := range m.changes {
+		if c.Seq > since && len(out) < limit {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockStore) CurrentSeq(ctx context.Context, entityType models.SyncEntityType, id, userID string) (int64, bool, error) {
+	seq, ok := m.seqs[id]
+	return seq, ok, nil
+}
+
+func (m *mockStore) GetClientRef(ctx context.Context, userID, clientRef string) (models.SyncEntityType, string, error) {
+	if id, ok := m.clientRefs[clientRef]; ok {
+		return models.SyncEntityAircraft, id, nil
+	}
+	return "", "", nil
+}
+
+func (m *mockStore) ReserveClientRef(ctx context.Context, userID, clientRef string, entityType models.SyncEntityType) (bool, error) {
+	if _, ok := m.clientRefs[clientRef]; ok {
+		return false, nil
+	}
+	m.clientRefs[clientRef] = ""
+	return true, nil
+}
+
+func (m *mockStore) SaveClientRef(ctx context.Context, userID, clientRef string, entityType models.SyncEntityType, entityID string) error {
+	m.clientRefs[clientRef] = entityID
+	return nil
+}
+
+// RunInTx rolls back client refs when fn fails
+func (m *mockStore) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	saved := make(map[string]string, len(m.clientRefs))
+	for k, v := range m.clientRefs {
+		saved[k] = v
+	}
+	if err := fn(ctx); err != nil {
+		m.clientRefs = saved
+		return err
+	}
+	return nil
+}
+
+// mockAircraft implements AircraftManager for testing
+type mockAircraft struct {
+	store   *mockStore
+	items   map[string]*models.Aircraft
+	creates int
+	updates int
+	deletes int
+}
+
+func (m *mockAircraft) Create(ctx context.Context, userID string, params models.CreateAircraftParams) (*models.Aircraft, error) {
+	if params.Name == "" {
+		return nil, &aircraft.ServiceError{Message: "name is required"}
+	}
+	m.creates++
+	a := &models.Aircraft{ID: "ac-new", Name: params.Name}
+	m.items[a.ID] = a
+	m.store.seqs[a.ID] = 50
+	return a, nil
+}
+
+func (m *mockAircraft) Get(ctx context.Context, id string, userID string) (*models.Aircraft, error) {
+	return m.items[id], nil
+}
+
+func (m *mockAircraft) Update(ctx context.Context, userID string, params models.UpdateAircraftParams) (*models.Aircraft, error) {
+	m.updates++
+	m.store.seqs[params.ID]++
+	return m.items[params.ID], nil
+}
+
+func (m *mockAircraft) Delete(ctx context.Context, id string, userID string) error {
+	m.deletes++
+	delete(m.items, id)
+	delete(m.store.seqs, id)
+	return nil
+}
+
+// mockInventory implements inventory.InventoryManager for testing
+type mockInventory struct {
+	inventory.InventoryManager
+	items map[string]*models.InventoryItem
+}
+
+func (m *mockInventory) GetItem(ctx context.Context, id string, userID string) (*models.InventoryItem, error) {
+	return m.items[id], nil
+}
+
+func newTestService() (*Service, *mockStore, *mockAircraft) {
+	store := &mockStore{seqs: map[string]int64{}, clientRefs: map[string]string{}}
+	ac := &mockAircraft{store: store, items: map[string]*models.Aircraft{}}
+	return &Service{
+		store:        store,
+		inventorySvc: &mockInventory{items: map[string]*models.InventoryItem{}},
+		aircraftSvc:  ac,
+		logger:       testutil.NullLogger(),
+	}, store, ac
+}
+
+func TestService_Changes(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      models.SyncChangesParams
+		wantIDs     []string
+		wantDeleted []string // IDs reported as deleted
+		wantHasMore bool
+		wantCursor  string
+		wantErr     bool
+	}{
+		{
+			// ac-gone no longer loads, so it's reported as deleted
+			name:        "first page",
+			params:      models.SyncChangesParams{Limit: 3},
+			wantIDs:     []string{"ac-1", "inv-1", "ac-gone"},
+			wantDeleted: []string{"ac-gone"},
+			wantHasMore: true,
+			wantCursor:  "7",
+		},
+		{
+			name:       "nothing newer",
+			params:     models.SyncChangesParams{Since: 9},
+			wantCursor: "9",
+		},
+		{
+			name:    "unknown type",
+			params:  models.SyncChangesParams{Types: []models.SyncEntityType{"drone"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store, ac := newTestService()
+			ac.items["ac-1"] = &models.Aircraft{ID: "ac-1", Name: "Five"}
+			svc.inventorySvc.(*mockInventory).items["inv-1"] = &models.InventoryItem{ID: "inv-1"}
+			store.changes = []models.SyncChange{
+				{EntityType: models.SyncEntityAircraft, ID: "ac-1", Seq: 3, Cursor: "3"},
+				{EntityType: models.SyncEntityInventory, ID: "inv-1", Seq: 5, Cursor: "5"},
+				{EntityType: models.SyncEntityAircraft, ID: "ac-gone", Seq: 7, Cursor: "7"},
+				{EntityType: models.SyncEntityAircraft, ID: "ac-2", Seq: 9, Cursor: "9", Deleted: true},
+			}
+
+			page, err := svc.Changes(context.Background(), "user-1", tt.params)
+			if tt.wantErr {
+				var svcErr *ServiceError
+				if !errors.As(err, &svcErr) {
+					t.Errorf("Changes() error = %v, want ServiceError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Changes() error = %v", err)
+			}
+			if tt.params.Limit > 0 && store.lastLimit != tt.params.Limit+1 {
+				t.Errorf("store limit = %d, want %d", store.lastLimit, tt.params.Limit+1)
+			}
+			if page.HasMore != tt.wantHasMore || page.Cursor != tt.wantCursor {
+				t.Errorf("hasMore=%v, cursor=%q; want %v, %q", page.HasMore, page.Cursor, tt.wantHasMore, tt.wantCursor)
+			}
+
+			var ids, deleted []string
+			for _, change := range page.Changes {
+				ids = append(ids, change.ID)
+				if change.Deleted {
+					deleted = append(deleted, change.ID)
+					if change.Data != nil {
+						t.Errorf("deleted %s carries data", change.ID)
+					}
+				} else if change.Data == nil {
+					t.Errorf("existing %s carries no data", change.ID)
+				}
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) || !reflect.DeepEqual(deleted, tt.wantDeleted) {
+				t.Errorf("changes = %v (deleted %v), want %v (deleted %v)", ids, deleted, tt.wantIDs, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestService_ApplyBatch(t *testing.T) {
+	data := func(v interface{}) json.RawMessage {
+		b, _ := json.Marshal(v)
+		return b
+	}
+
+	tests := []struct {
+		name        string
+		op          models.SyncOperation
+		wantStatus  models.SyncResultStatus
+		wantUpdates int
+		wantDeletes int
+		wantCreates int
+	}{
+		{
+			name:        "update at base cursor applies",
+			op:          models.SyncOperation{EntityType: models.SyncEntityAircraft, Op: models.SyncOpUpsert, ID: "ac-1", BaseCursor: "10", Data: data(map[string]string{"name": "Seven"})},
+			wantStatus:  models.SyncStatusApplied,
+			wantUpdates: 1,
+		},
+		{
+			name:       "update behind server conflicts",
+			op:         models.SyncOperation{EntityType: models.SyncEntityAircraft, Op: models.SyncOpUpsert, ID: "ac-1", BaseCursor: "4", Data: data(map[string]string{"name": "Seven"})},
+			wantStatus: models.SyncStatusConflict,
+		},
+		{
+			name:        "forced update behind server applies",
+			op:          models.SyncOperation{EntityType: models.SyncEntityAircraft, Op: models.SyncOpUpsert, ID: "ac-1", BaseCursor: "4", Force: true, Data: data(map[string]string{"name": "Seven"})},
+			wantStatus:  models.SyncStatusApplied,
+			wantUpdates: 1,
+		},
+		{
+			name:       "update of missing entity",
+			op:         models.SyncOperation{EntityType: models.SyncEntityAircraft, Op: models.SyncOpUpsert, ID: "ac-missing", Data: data(map[string]string{"name": "Seven"})},
+			wantStatus: models.SyncStatusNotFound,
+		},
+		{
+			name:        "delete applies",
+			op:          models.SyncOperation{EntityType: models.SyncEntityAircraft, Op: models.SyncOpDelete, ID: "ac-1", BaseCursor: "10"},
+			wantStatus:  models.SyncStatusApplied,
+			wantDeletes: 1,
+		},
+		{
+			name:       "delete of missing entity is idempotent",
+			op:         models.SyncOperation{EntityType: models.SyncEntityAircraft, Op: models.SyncOpDelete, ID: "ac-missing"},
+			wantStatus: models.SyncStatusApplied,
+		},
+		{
+			name:        "create applies",
+			op:          models.SyncOperation{EntityType: models.SyncEntityAircraft, Op: models.SyncOpUpsert, ClientRef: "local-2", Data: data(map[string]string{"name": "Whoop"})},
+			wantStatus:  models.SyncStatusApplied,
+			wantCreates: 1,
+		},
+		{
+			name:       "replayed create returns original entity",
+			op:         models.SyncOperation{EntityType: models.SyncEntityAircraft, Op: models.SyncOpUpsert, ClientRef: "local-1", Data: data(map[string]string{"name": "Whoop"})},
+			wantStatus: models.SyncStatusApplied,
+		},
+		{
+			name:       "create with client ref failing validation is rejected",
+			op:         models.SyncOperation{EntityType: models.SyncEntityAircraft, Op: models.SyncOpUpsert, ClientRef: "local-3", Data: data(map[string]string{})},
+			wantStatus: models.SyncStatusRejected,
+		},
+		{
+			name:       "create failing validation is rejected",
+			op:         models.SyncOperation{EntityType: models.SyncEntityAircraft, Op: models.SyncOpUpsert, Data: data(map[string]string{})},
+			wantStatus: models.SyncStatusRejected,
+		},
+		{
+			name:       "malformed data is rejected",
+			op:         models.SyncOperation{EntityType: models.SyncEntityAircraft, Op: models.SyncOpUpsert, ID: "ac-1", Data: json.RawMessage(`"nope"`)},
+			wantStatus: models.SyncStatusRejected,
+		},
+		{
+			name:       "unknown entity type is rejected",
+			op:         models.SyncOperation{EntityType: "drone", Op: models.SyncOpUpsert, ID: "x"},
+			wantStatus: models.SyncStatusRejected,
+		},
+		{
+			name:       "invalid base cursor is rejected",
+			op:         models.SyncOperation{EntityType: models.SyncEntityAircraft, Op: models.SyncOpUpsert, ID: "ac-1", BaseCursor: "abc"},
+			wantStatus: models.SyncStatusRejected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store, ac := newTestService()
+			ac.items["ac-1"] = &models.Aircraft{ID: "ac-1", Name: "Five"}
+			ac.items["ac-old"] = &models.Aircraft{ID: "ac-old", Name: "Whoop"}
+			store.seqs["ac-1"] = 10
+			store.seqs["ac-old"] = 20
+			store.clientRefs["local-1"] = "ac-old"
+
+			resp, err := svc.ApplyBatch(context.Background(), "user-1", models.SyncBatchRequest{Operations: []models.SyncOperation{tt.op}})
+			if err != nil {
+				t.Fatalf("ApplyBatch() error = %v", err)
+			}
+			if len(resp.Results) != 1 {
+				t.Fatalf("got %d results, want 1", len(resp.Results))
+			}
+			result := resp.Results[0]
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %q (%s), want %q", result.Status, result.Error, tt.wantStatus)
+			}
+			if ac.updates != tt.wantUpdates || ac.deletes != tt.wantDeletes || ac.creates != tt.wantCreates {
+				t.Errorf("calls = %d updates, %d deletes, %d creates; want %d, %d, %d",
+					ac.updates, ac.deletes, ac.creates, tt.wantUpdates, tt.wantDeletes, tt.wantCreates)
+			}
+			if result.Status == models.SyncStatusConflict && (result.Current == nil || result.Cursor != "10") {
+				t.Errorf("conflict should carry the server copy and cursor, got cursor %q", result.Cursor)
+			}
+			if tt.op.ClientRef == "local-1" && result.ID != "ac-old" {
+				t.Errorf("replayed create ID = %q, want ac-old", result.ID)
+			}
+			if id, ok := store.clientRefs[tt.op.ClientRef]; tt.op.ClientRef != "" && result.Status == models.SyncStatusApplied && (!ok || id != result.ID) {
+				t.Errorf("client ref %q = %q, want %q", tt.op.ClientRef, id, result.ID)
+			}
+			if _, ok := store.clientRefs[tt.op.ClientRef]; tt.op.ClientRef != "" && result.Status == models.SyncStatusRejected && ok {
+				t.Errorf("client ref %q kept after a rejected create", tt.op.ClientRef)
+			}
+		})
+	}
+}
+
+func TestService_ApplyBatch_Limits(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  []models.SyncOperation
+	}{
+		{name: "empty batch"},
+		{name: "oversized batch", ops: make([]models.SyncOperation, maxBatchOperations+1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _ := newTestService()
+			if _, err := svc.ApplyBatch(context.Background(), "user-1", models.SyncBatchRequest{Operations: tt.ops}); err == nil {
+				t.Error("ApplyBatch() should fail")
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package policies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	maxVersionLength = 50
+	maxSummaryLength = 2000
+)
+
+// Store defines the policy version persistence operations
+type Store interface {
+	Publish(ctx context.Context, createdBy string, params models.PublishPolicyVersionParams) (*models.PolicyVersion, error)
+	List(ctx context.Context) ([]models.PolicyVersion, error)
+	Latest(ctx context.Context) ([]models.PolicyVersion, error)
+	Pending(ctx context.Context, userID string) ([]models.PolicyVersion, error)
+	Accept(ctx context.Context, userID string, versionIDs []string) (int, error)
+	ListAcceptances(ctx context.Context, userID string) ([]models.PolicyAcceptance, error)
+}
+
+// Service publishes terms of service and privacy policy versions and
+// records which ones each user accepted
+type Service struct {
+	store  Store
+	logger *logging.Logger
+}
+
+// NewService creates a new policy service
+func NewService(store *database.PolicyStore, logger *logging.Logger) *Service {
+	return &Service{store: store, logger: logger}
+}
+
+// Publish adds a policy version. Every user has to accept it before their
+// next write.
+func (s *Service) Publish(ctx context.Context, adminUserID string, params models.PublishPolicyVersionParams) (*models.PolicyVersion, error) {
+	params.Kind = models.PolicyKind(strings.ToLower(strings.TrimSpace(string(params.Kind))))
+	params.Version = strings.TrimSpace(params.Version)
+	params.URL = strings.TrimSpace(params.URL)
+	params.Summary = strings.TrimSpace(params.Summary)
+
+	if !models.IsValidPolicyKind(params.Kind) {
+		return nil, &ServiceError{Message: "kind must be terms or privacy"}
+	}
+	if params.Version == "" {
+		return nil, &ServiceError{Message: "version is required"}
+	}
+	if len(params.Version) > maxVersionLength {
+		return nil, &ServiceError{Message: fmt.Sprintf("version must be %d characters or fewer", maxVersionLength)}
+	}
+	if u, err := url.Parse(params.URL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, &ServiceError{Message: "url must be an http or https link to the policy text"}
+	}
+	if len(params.Summary) > maxSummaryLength {
+		return nil, &ServiceError{Message: fmt.Sprintf("summary must be %d characters or fewer", maxSummaryLength)}
+	}
+
+	version, err := s.store.Publish(ctx, adminUserID, params)
+	if errors.Is(err, database.ErrPolicyVersionExists) {
+		return nil, &ServiceError{Message: fmt.Sprintf("%s version %s already exists", params.Kind, params.Version)}
+	}
+	return version, err
+}
+
+// List returns every published policy version
+func (s *Service) List(ctx context.Context) ([]models.PolicyVersion, error) {
+	return s.store.List(ctx)
+}
+
+// Current returns the latest version of each policy
+func (s *Service) Current(ctx context.Context) ([]models.PolicyVersion, error) {
+	return s.store.Latest(ctx)
+}
+
+// Pending returns the current policy versions a user still has to accept
+func (s *Service) Pending(ctx context.Context, userID string) ([]models.PolicyVersion, error) {
+	return s.store.Pending(ctx, userID)
+}
+
+// Status returns a user's standing against the current policies
+func (s *Service) Status(ctx context.Context, userID string) (*models.UserPolicyStatus, error) {
+	current, err := s.store.Latest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.store.Pending(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	accepted, err := s.store.ListAcceptances(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.UserPolicyStatus{Current: current, Pending: pending, Accepted: accepted}, nil
+}
+
+// Accept records that a user accepted policy versions. Only current
+// versions can be accepted.
+func (s *Service) Accept(ctx context.Context, userID string, params models.AcceptPoliciesParams) (*models.UserPolicyStatus, error) {
+	if len(params.VersionIDs) == 0 {
+		return nil, &ServiceError{Message: "versionIds is required"}
+	}
+
+	current, err := s.store.Latest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	isCurrent := make(map[string]bool, len(current))
+	for _, version := range current {
+		isCurrent[version.ID] = true
+	}
+	ids := make([]string, 0, len(params.VersionIDs))
+	for _, id := range params.VersionIDs {
+		id = strings.TrimSpace(id)
+		if !isCurrent[id] {
+			return nil, &ServiceError{Message: "only the current policy versions can be accepted"}
+		}
+		ids = append(ids, id)
+	}
+
+	if _, err := s.store.Accept(ctx, userID, ids); err != nil {
+		return nil, err
+	}
+	s.logger.Info("User accepted policy versions",
+		logging.WithField("userId", userID),
+		logging.WithField("versionIds", ids),
+	)
+	return s.Status(ctx, userID)
+}
+
+// ServiceError represents a policy request error that should be shown to the client
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
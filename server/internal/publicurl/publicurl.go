@@ -0,0 +1,128 @@
+// Package publicurl builds the absolute URLs the server hands out, such as
+// share links, sitemap entries and sign-in redirects, and applies the
+// X-Forwarded-* headers set by a trusted reverse proxy.
+package publicurl
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Resolver turns paths into absolute URLs. With a configured base URL every
+// URL uses it; without one the URL is taken from the request, which is only
+// right behind a proxy when its forwarded headers are trusted.
+type Resolver struct {
+	base       string
+	trustProxy bool
+}
+
+// New creates a resolver. base is the site's public origin, optionally with
+// a path prefix, e.g. "https://flyingforge.app"; empty derives it from each
+// request. trustProxy applies X-Forwarded-Proto and X-Forwarded-Host.
+func New(base string, trustProxy bool) *Resolver {
+	return &Resolver{
+		base:       strings.TrimRight(strings.TrimSpace(base), "/"),
+		trustProxy: trustProxy,
+	}
+}
+
+// Configured returns the configured base URL, or "" when URLs come from requests
+func (r *Resolver) Configured() string {
+	if r == nil {
+		return ""
+	}
+	return r.base
+}
+
+// Base returns the public origin for a request
+func (r *Resolver) Base(req *http.Request) string {
+	if base := r.Configured(); base != "" {
+		return base
+	}
+	if req == nil || req.Host == "" {
+		return ""
+	}
+	return scheme(req) + "://" + req.Host
+}
+
+// RequestBase returns the origin a request was made to, ignoring the
+// configured base URL. Tenants served on their own hostnames link to it.
+func (r *Resolver) RequestBase(req *http.Request) string {
+	if req == nil || req.Host == "" {
+		return ""
+	}
+	return scheme(req) + "://" + strings.ToLower(req.Host)
+}
+
+// Absolute returns path as an absolute URL. Paths that already are absolute
+// URLs are returned unchanged.
+func (r *Resolver) Absolute(req *http.Request, path string) string {
+	if path == "" || strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return r.Base(req) + path
+}
+
+func scheme(req *http.Request) string {
+	if req.URL != nil && req.URL.Scheme != "" {
+		return req.URL.Scheme
+	}
+	if req.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// ProxyHeaders applies the scheme and host a trusted reverse proxy forwards,
+// so URLs built from the request, and tenant lookups by host, see what the
+// visitor used. Without trustProxy it passes requests through untouched,
+// since anyone could send the headers. Only the last value of each header
+// is used: a proxy that appends adds its value after whatever the client
+// sent, and one that overwrites leaves a single value.
+func (r *Resolver) ProxyHeaders(next http.Handler) http.Handler {
+	if r == nil || !r.trustProxy {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if proto := strings.ToLower(lastValue(req.Header.Values("X-Forwarded-Proto"))); proto == "http" || proto == "https" {
+			req.URL.Scheme = proto
+		}
+		if host := lastValue(req.Header.Values("X-Forwarded-Host")); validHost(host) {
+			req.Host = host
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// lastValue returns the last comma-separated value of a header that may be
+// sent on several lines
+func lastValue(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	header := lines[len(lines)-1]
+	if i := strings.LastIndexByte(header, ','); i >= 0 {
+		header = header[i+1:]
+	}
+	return strings.TrimSpace(header)
+}
+
+// validHost accepts a hostname or IP with an optional port, and nothing that
+// could change the URL's path or authority
+func validHost(host string) bool {
+	if host == "" || len(host) > 255 {
+		return false
+	}
+	for _, c := range host {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '.' || c == '-' || c == ':' || c == '[' || c == ']':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+package publicurl
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAbsolute_ConfiguredBase(t *testing.T) {
+	r := New(" https://flyingforge.app/ ", false)
+	req := httptest.NewRequest(http.MethodGet, "http://internal:8080/api/x", nil)
+
+	tests := map[string]string{
+		"/b/7fG3k":              "https://flyingforge.app/b/7fG3k",
+		"builds/temp/abc":       "https://flyingforge.app/builds/temp/abc",
+		"https://cdn.example/x": "https://cdn.example/x",
+		"":                      "",
+	}
+	for path, want := range tests {
+		if got := r.Absolute(req, path); got != want {
+			t.Errorf("Absolute(%q) = %q, want %q", path, got, want)
+		}
+	}
+	if got := r.Absolute(nil, "/sitemap.xml"); got != "https://flyingforge.app/sitemap.xml" {
+		t.Errorf("Expected the configured base without a request, got %q", got)
+	}
+}
+
+func TestAbsolute_FromRequest(t *testing.T) {
+	r := New("", false)
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/x", nil)
+	if got := r.Absolute(req, "/b/1"); got != "http://localhost:8080/b/1" {
+		t.Errorf("Expected the request origin, got %q", got)
+	}
+
+	// Server requests carry no scheme on the URL, only the TLS state
+	req.URL.Scheme = ""
+	req.TLS = &tls.ConnectionState{}
+	if got := r.Absolute(req, "/b/1"); got != "https://localhost:8080/b/1" {
+		t.Errorf("Expected https for TLS requests, got %q", got)
+	}
+
+	if got := r.Absolute(nil, "/b/1"); got != "/b/1" {
+		t.Errorf("Expected the bare path without a request or base, got %q", got)
+	}
+}
+
+func TestRequestBase_IgnoresConfiguredBase(t *testing.T) {
+	r := New("https://flyingforge.app", false)
+	req := httptest.NewRequest(http.MethodGet, "https://FPV.Club.Example/sitemap.xml", nil)
+	if got := r.RequestBase(req); got != "https://fpv.club.example" {
+		t.Errorf("RequestBase() = %q, want the tenant host", got)
+	}
+}
+
+func TestProxyHeaders(t *testing.T) {
+	var seen string
+	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		seen = New("", false).Absolute(req, "/b/1")
+	})
+
+	request := func(headers map[string]string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "http://server:8080/api/x", nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		return req
+	}
+	forwarded := map[string]string{
+		"X-Forwarded-Proto": "https",
+		"X-Forwarded-Host":  "flyingforge.app",
+	}
+
+	New("", true).ProxyHeaders(next).ServeHTTP(httptest.NewRecorder(), request(forwarded))
+	if seen != "https://flyingforge.app/b/1" {
+		t.Errorf("Expected the forwarded origin, got %q", seen)
+	}
+
+	// Untrusted headers are ignored
+	New("", false).ProxyHeaders(next).ServeHTTP(httptest.NewRecorder(), request(forwarded))
+	if seen != "http://server:8080/b/1" {
+		t.Errorf("Expected the request origin, got %q", seen)
+	}
+
+	// Hosts that would change the URL's path or authority are ignored
+	New("", true).ProxyHeaders(next).ServeHTTP(httptest.NewRecorder(), request(map[string]string{
+		"X-Forwarded-Proto": "javascript",
+		"X-Forwarded-Host":  "evil.example/phish?",
+	}))
+	if seen != "http://server:8080/b/1" {
+		t.Errorf("Expected invalid forwarded values to be ignored, got %q", seen)
+	}
+}
+
+func TestProxyHeaders_IgnoresClientValues(t *testing.T) {
+	var seen string
+	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		seen = New("", false).Absolute(req, "/b/1")
+	})
+
+	// The client's spoofed values come first and the appending proxy's last
+	req := httptest.NewRequest(http.MethodGet, "http://server:8080/api/x", nil)
+	req.Header.Set("X-Forwarded-Proto", "http, https")
+	req.Header.Set("X-Forwarded-Host", "evil.example, other.example, flyingforge.app")
+	New("", true).ProxyHeaders(next).ServeHTTP(httptest.NewRecorder(), req)
+	if seen != "https://flyingforge.app/b/1" {
+		t.Errorf("Expected the proxy's origin, got %q", seen)
+	}
+
+	// The same when the proxy adds its own header line
+	req = httptest.NewRequest(http.MethodGet, "http://server:8080/api/x", nil)
+	req.Header.Add("X-Forwarded-Host", "evil.example")
+	req.Header.Add("X-Forwarded-Host", "flyingforge.app")
+	New("", true).ProxyHeaders(next).ServeHTTP(httptest.NewRecorder(), req)
+	if seen != "http://flyingforge.app/b/1" {
+		t.Errorf("Expected the proxy's host, got %q", seen)
+	}
+}
@@ -0,0 +1,172 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	apnsProductionURL = "https://api.push.apple.com"
+	apnsSandboxURL    = "https://api.sandbox.push.apple.com"
+
+	// apnsTokenTTL keeps provider tokens inside Apple's one hour limit while
+	// staying above the 20 minute minimum refresh interval
+	apnsTokenTTL = 45 * time.Minute
+)
+
+// APNsConfig holds token-based APNs credentials
+type APNsConfig struct {
+	KeyFile string // .p8 signing key from the Apple developer portal
+	KeyID   string
+	TeamID  string
+	Topic   string // app bundle ID
+	Sandbox bool
+}
+
+// APNsSender delivers notifications through the Apple Push Notification service
+type APNsSender struct {
+	key     *ecdsa.PrivateKey
+	keyID   string
+	teamID  string
+	topic   string
+	baseURL string
+	client  *http.Client
+
+	mu          sync.Mutex
+	signedToken string
+	issuedAt    time.Time
+}
+
+// NewAPNsSender creates an APNs sender using token-based authentication
+func NewAPNsSender(cfg APNsConfig) (*APNsSender, error) {
+	if cfg.KeyID == "" || cfg.TeamID == "" || cfg.Topic == "" {
+		return nil, fmt.Errorf("APNs key ID, team ID, and topic are required")
+	}
+
+	raw, err := os.ReadFile(cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read APNs key: %w", err)
+	}
+	key, err := jwt.ParseECPrivateKeyFromPEM(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse APNs key: %w", err)
+	}
+
+	baseURL := apnsProductionURL
+	if cfg.Sandbox {
+		baseURL = apnsSandboxURL
+	}
+
+	return &APNsSender{
+		key:     key,
+		keyID:   cfg.KeyID,
+		teamID:  cfg.TeamID,
+		topic:   cfg.Topic,
+		baseURL: baseURL,
+		// APNs requires HTTP/2, which net/http negotiates automatically over TLS
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Send delivers a notification to an APNs device token
+func (a *APNsSender) Send(ctx context.Context, token string, n models.Notification) error {
+	providerToken, err := a.providerToken()
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{
+		"aps": map[string]interface{}{
+			"alert": map[string]string{
+				"title": n.Title,
+				"body":  n.Body,
+			},
+			"sound": "default",
+		},
+		"kind": n.Kind,
+	}
+	for k, v := range n.Data {
+		if k != "aps" && k != "kind" {
+			payload[k] = v
+		}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode APNs payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+"/3/device/"+url.PathEscape(token), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create APNs request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "bearer "+providerToken)
+	req.Header.Set("apns-topic", a.topic)
+	req.Header.Set("apns-push-type", "alert")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("APNs request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var apnsErr struct {
+		Reason string `json:"reason"`
+	}
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	_ = json.Unmarshal(respBody, &apnsErr)
+
+	if resp.StatusCode == http.StatusGone || apnsErr.Reason == "BadDeviceToken" || apnsErr.Reason == "Unregistered" {
+		return ErrInvalidToken
+	}
+	if apnsErr.Reason == "ExpiredProviderToken" {
+		a.mu.Lock()
+		a.signedToken = ""
+		a.mu.Unlock()
+	}
+	return fmt.Errorf("APNs returned status %d: %s", resp.StatusCode, strings.TrimSpace(apnsErr.Reason))
+}
+
+// providerToken returns the cached ES256 provider token, re-signing it when it
+// is older than apnsTokenTTL
+func (a *APNsSender) providerToken() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.signedToken != "" && time.Since(a.issuedAt) < apnsTokenTTL {
+		return a.signedToken, nil
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": a.teamID,
+		"iat": now.Unix(),
+	})
+	token.Header["kid"] = a.keyID
+
+	signed, err := token.SignedString(a.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign APNs provider token: %w", err)
+	}
+	a.signedToken = signed
+	a.issuedAt = now
+	return signed, nil
+}
@@ -0,0 +1,133 @@
+package push
+
+import (
+	"context"
+	"errors"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// maxTokenLength bounds device tokens; FCM and APNs tokens are far shorter
+const maxTokenLength = 4096
+
+// ErrInvalidToken is returned by senders when the push service reports that a
+// device token is no longer valid (app uninstalled, token rotated, etc.)
+var ErrInvalidToken = errors.New("push token is no longer valid")
+
+// Sender delivers a notification to a single device token
+type Sender interface {
+	Send(ctx context.Context, token string, n models.Notification) error
+}
+
+// Notifier delivers user-facing notifications. Service implements it with
+// mobile push; the services that notify users depend on this instead, so
+// their tests can record what was sent.
+type Notifier interface {
+	Notify(ctx context.Context, userID string, n models.Notification) error
+}
+
+// Store defines the device token operations the push service needs
+type Store interface {
+	Register(ctx context.Context, userID string, params models.RegisterPushDeviceParams) (*models.PushDevice, error)
+	ListForUser(ctx context.Context, userID string) ([]models.PushDevice, error)
+	Delete(ctx context.Context, id, userID string) error
+	DeleteToken(ctx context.Context, token string) error
+}
+
+// Service manages device registrations and fans notifications out to them
+type Service struct {
+	store   Store
+	senders map[models.PushPlatform]Sender
+	logger  *logging.Logger
+}
+
+// NewService creates a new push service with no senders configured
+func NewService(store *database.PushDeviceStore, logger *logging.Logger) *Service {
+	return &Service{
+		store:   store,
+		senders: make(map[models.PushPlatform]Sender),
+		logger:  logger,
+	}
+}
+
+// SetSender configures delivery for a platform. Devices on platforms without a
+// sender stay registered but receive nothing.
+func (s *Service) SetSender(platform models.PushPlatform, sender Sender) {
+	s.senders[platform] = sender
+}
+
+// Register records a device token for the user
+func (s *Service) Register(ctx context.Context, userID string, params models.RegisterPushDeviceParams) (*models.PushDevice, error) {
+	params.Normalize()
+	if !models.IsValidPushPlatform(params.Platform) {
+		return nil, &ServiceError{Message: "platform must be ios or android"}
+	}
+	if params.Token == "" {
+		return nil, &ServiceError{Message: "token is required"}
+	}
+	if len(params.Token) > maxTokenLength {
+		return nil, &ServiceError{Message: "token is too long"}
+	}
+	return s.store.Register(ctx, userID, params)
+}
+
+// List returns the user's registered devices
+func (s *Service) List(ctx context.Context, userID string) (*models.PushDeviceListResponse, error) {
+	devices, err := s.store.ListForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.PushDeviceListResponse{Devices: devices}, nil
+}
+
+// Unregister removes one of the user's devices
+func (s *Service) Unregister(ctx context.Context, id, userID string) error {
+	return s.store.Delete(ctx, id, userID)
+}
+
+// Notify sends a notification to every device the user has registered.
+// Delivery is best effort: failures for one device are logged and do not stop
+// delivery to the others, and tokens the push service rejects are removed.
+func (s *Service) Notify(ctx context.Context, userID string, n models.Notification) error {
+	devices, err := s.store.ListForUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, device := range devices {
+		sender, ok := s.senders[device.Platform]
+		if !ok {
+			continue
+		}
+
+		err := sender.Send(ctx, device.Token, n)
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, ErrInvalidToken) {
+			if err := s.store.DeleteToken(ctx, device.Token); err != nil {
+				s.logger.Warn("Failed to remove invalid push token", logging.WithField("error", err.Error()))
+			}
+			continue
+		}
+		s.logger.Warn("Push delivery failed", logging.WithFields(map[string]interface{}{
+			"userId":   userID,
+			"deviceId": device.ID,
+			"platform": device.Platform,
+			"kind":     n.Kind,
+			"error":    err.Error(),
+		}))
+	}
+	return nil
+}
+
+// ServiceError represents a push registration error that should be shown to the client
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
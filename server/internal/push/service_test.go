@@ -0,0 +1,140 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+// mockStore implements the Store interface for testing
+type mockStore struct {
+	devices []models.PushDevice
+	deleted []string
+}
+
+func (m *mockStore) Register(ctx context.Context, userID string, params models.RegisterPushDeviceParams) (*models.PushDevice, error) {
+	device := models.PushDevice{ID: "dev-new", UserID: userID, Platform: params.Platform, Token: params.Token}
+	m.devices = append(m.devices, device)
+	return &device, nil
+}
+
+func (m *mockStore) ListForUser(ctx context.Context, userID string) ([]models.PushDevice, error) {
+	var out []models.PushDevice
+	for _, d := range m.devices {
+		if d.UserID == userID {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockStore) Delete(ctx context.Context, id, userID string) error {
+	return nil
+}
+
+func (m *mockStore) DeleteToken(ctx context.Context, token string) error {
+	m.deleted = append(m.deleted, token)
+	return nil
+}
+
+// mockSender records deliveries and fails for configured tokens
+type mockSender struct {
+	sent   []string
+	errFor map[string]error
+}
+
+func (m *mockSender) Send(ctx context.Context, token string, n models.Notification) error {
+	if err := m.errFor[token]; err != nil {
+		return err
+	}
+	m.sent = append(m.sent, token)
+	return nil
+}
+
+func newTestService(store *mockStore) *Service {
+	return &Service{
+		store:   store,
+		senders: make(map[models.PushPlatform]Sender),
+		logger:  testutil.NullLogger(),
+	}
+}
+
+func TestService_Register_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  models.RegisterPushDeviceParams
+		wantErr bool
+	}{
+		{name: "android", params: models.RegisterPushDeviceParams{Platform: "android", Token: "abc"}},
+		{name: "platform normalized", params: models.RegisterPushDeviceParams{Platform: " IOS ", Token: "abc"}},
+		{name: "unknown platform", params: models.RegisterPushDeviceParams{Platform: "web", Token: "abc"}, wantErr: true},
+		{name: "blank token", params: models.RegisterPushDeviceParams{Platform: "ios", Token: "   "}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(&mockStore{})
+			_, err := svc.Register(context.Background(), "user-1", tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Register() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var svcErr *ServiceError
+			if tt.wantErr && !errors.As(err, &svcErr) {
+				t.Errorf("Register() error = %T, want *ServiceError", err)
+			}
+		})
+	}
+}
+
+func TestService_Notify(t *testing.T) {
+	store := &mockStore{devices: []models.PushDevice{
+		{ID: "1", UserID: "user-1", Platform: models.PushPlatformAndroid, Token: "android-ok"},
+		{ID: "2", UserID: "user-1", Platform: models.PushPlatformAndroid, Token: "android-dead"},
+		{ID: "3", UserID: "user-1", Platform: models.PushPlatformAndroid, Token: "android-flaky"},
+		{ID: "4", UserID: "user-1", Platform: models.PushPlatformIOS, Token: "ios-no-sender"},
+		{ID: "5", UserID: "user-2", Platform: models.PushPlatformAndroid, Token: "other-user"},
+	}}
+	sender := &mockSender{errFor: map[string]error{
+		"android-dead":  ErrInvalidToken,
+		"android-flaky": errors.New("503"),
+	}}
+
+	svc := newTestService(store)
+	svc.SetSender(models.PushPlatformAndroid, sender)
+
+	if err := svc.Notify(context.Background(), "user-1", models.Notification{Kind: models.NotificationBuildApproved}); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if len(sender.sent) != 1 || sender.sent[0] != "android-ok" {
+		t.Errorf("sent = %v, want [android-ok]", sender.sent)
+	}
+	if len(store.deleted) != 1 || store.deleted[0] != "android-dead" {
+		t.Errorf("deleted tokens = %v, want [android-dead]", store.deleted)
+	}
+}
+
+func TestIsFCMUnregistered(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   bool
+	}{
+		{name: "not found", status: 404, body: ``, want: true},
+		{name: "unregistered detail", status: 400, body: `{"error":{"details":[{"errorCode":"UNREGISTERED"}]}}`, want: true},
+		{name: "invalid argument", status: 400, body: `{"error":{"details":[{"errorCode":"INVALID_ARGUMENT"}]}}`, want: false},
+		{name: "server error", status: 500, body: `oops`, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isFCMUnregistered(tt.status, []byte(tt.body)); got != tt.want {
+				t.Errorf("isFCMUnregistered() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,15 +2,18 @@ package radio
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/blobstore"
 	"github.com/johnrirwin/flyingforge/internal/database"
 	"github.com/johnrirwin/flyingforge/internal/logging"
 	"github.com/johnrirwin/flyingforge/internal/models"
@@ -19,12 +22,12 @@ import (
 const (
 	// MaxBackupFileSize is the maximum allowed backup file size (100MB)
 	MaxBackupFileSize = 100 * 1024 * 1024
-	// DefaultStorageDir is the default directory for backup storage
-	DefaultStorageDir = "./data/radio_backups"
 )
 
-// ServiceError represents a service-level error
+// ServiceError represents a service-level error. Code is set for errors
+// handlers map to something other than a 400.
 type ServiceError struct {
+	Code    apierror.Code
 	Message string
 }
 
@@ -32,22 +35,28 @@ func (e *ServiceError) Error() string {
 	return e.Message
 }
 
-// Service handles radio operations
+// ErrorCode returns the error's API error code
+func (e *ServiceError) ErrorCode() apierror.Code {
+	return e.Code
+}
+
+// Service handles radio operations. Backup files go to the configured blob
+// store; backups saved before pluggable storage are read from their file
+// paths.
 type Service struct {
-	store      *database.RadioStore
-	storageDir string
-	logger     *logging.Logger
+	store   *database.RadioStore
+	storage blobstore.Store
+	legacy  blobstore.Store
+	logger  *logging.Logger
 }
 
 // NewService creates a new radio service
-func NewService(store *database.RadioStore, storageDir string, logger *logging.Logger) *Service {
-	if storageDir == "" {
-		storageDir = DefaultStorageDir
-	}
+func NewService(store *database.RadioStore, storage blobstore.Store, logger *logging.Logger) *Service {
 	return &Service{
-		store:      store,
-		storageDir: storageDir,
-		logger:     logger,
+		store:   store,
+		storage: storage,
+		legacy:  blobstore.NewLocal(""),
+		logger:  logger,
 	}
 }
 
@@ -103,7 +112,7 @@ func (s *Service) UpdateRadio(ctx context.Context, id string, userID string, par
 			"id":    id,
 			"error": err.Error(),
 		}))
-		return nil, err
+		return nil, storeError(err)
 	}
 
 	s.logger.Info("Updated radio", logging.WithField("id", id))
@@ -119,31 +128,18 @@ func (s *Service) DeleteRadio(ctx context.Context, id string, userID string) err
 	if err != nil {
 		s.logger.Warn("Failed to list backups for deletion", logging.WithField("error", err.Error()))
 	} else {
-		for _, backup := range backups.Backups {
-			if backup.StoragePath != "" {
-				if err := os.Remove(backup.StoragePath); err != nil && !os.IsNotExist(err) {
-					s.logger.Warn("Failed to delete backup file", logging.WithFields(map[string]interface{}{
-						"path":  backup.StoragePath,
-						"error": err.Error(),
-					}))
-				}
-			}
+		for i := range backups.Backups {
+			s.deleteBackupFile(ctx, &backups.Backups[i])
 		}
 	}
 
-	// Delete the radio directory if it exists
-	radioDir := filepath.Join(s.storageDir, id)
-	if err := os.RemoveAll(radioDir); err != nil && !os.IsNotExist(err) {
-		s.logger.Warn("Failed to delete radio directory", logging.WithField("error", err.Error()))
-	}
-
 	// Delete the radio record (cascades to backups in DB)
 	if err := s.store.DeleteRadio(ctx, id, userID); err != nil {
 		s.logger.Error("Failed to delete radio", logging.WithFields(map[string]interface{}{
 			"id":    id,
 			"error": err.Error(),
 		}))
-		return err
+		return storeError(err)
 	}
 
 	s.logger.Info("Deleted radio", logging.WithField("id", id))
@@ -172,54 +168,30 @@ func (s *Service) CreateBackup(ctx context.Context, radioID string, userID strin
 		return nil, err
 	}
 	if radio == nil {
-		return nil, &ServiceError{Message: "radio not found"}
-	}
-
-	// Create storage directory
-	radioDir := filepath.Join(s.storageDir, radioID)
-	if err := os.MkdirAll(radioDir, 0755); err != nil {
-		s.logger.Error("Failed to create storage directory", logging.WithField("error", err.Error()))
-		return nil, &ServiceError{Message: "failed to create storage directory"}
+		return nil, &ServiceError{Code: apierror.RadioNotFound, Message: "radio not found"}
 	}
 
-	// Sanitize filename and create storage path
-	safeFileName := sanitizeFileName(params.FileName)
-	storagePath := filepath.Join(radioDir, safeFileName)
-
-	// Ensure unique filename
-	storagePath = ensureUniquePath(storagePath)
-
-	// Create the file
-	file, err := os.Create(storagePath)
+	// Stream the file to storage, checksumming as it goes. Reading one byte
+	// past the limit detects oversized uploads.
+	key := fmt.Sprintf("radio_backups/%s/%s-%s", radioID, uuid.NewString(), sanitizeFileName(params.FileName))
+	object, err := s.storage.Put(ctx, key, io.LimitReader(fileReader, MaxBackupFileSize+1))
 	if err != nil {
-		s.logger.Error("Failed to create backup file", logging.WithField("error", err.Error()))
-		return nil, &ServiceError{Message: "failed to create backup file"}
+		s.logger.Error("Failed to store backup file", logging.WithField("error", err.Error()))
+		return nil, &ServiceError{Message: "failed to store backup file"}
 	}
-	defer file.Close()
-
-	// Copy file content and calculate checksum
-	// Enforce max size while copying by using LimitReader
-	// We add 1 to the limit to detect if the stream exceeds the max size
-	hasher := sha256.New()
-	limitedReader := io.LimitReader(fileReader, MaxBackupFileSize+1)
-	teeReader := io.TeeReader(limitedReader, hasher)
 
-	written, err := io.Copy(file, teeReader)
-	if err != nil {
-		os.Remove(storagePath)
-		s.logger.Error("Failed to write backup file", logging.WithField("error", err.Error()))
-		return nil, &ServiceError{Message: "failed to write backup file"}
+	if object.Size > MaxBackupFileSize {
+		s.discardObject(key)
+		return nil, &ServiceError{Message: fmt.Sprintf("file size exceeds maximum allowed (%d bytes)", MaxBackupFileSize)}
 	}
-
-	// Verify actual written size doesn't exceed limit
-	if written > MaxBackupFileSize {
-		os.Remove(storagePath)
-		return nil, &ServiceError{Message: fmt.Sprintf("actual file size (%d bytes) exceeds maximum allowed (%d bytes)", written, MaxBackupFileSize)}
+	if params.Checksum != "" && !strings.EqualFold(params.Checksum, object.Checksum) {
+		s.discardObject(key)
+		return nil, &ServiceError{Message: fmt.Sprintf("checksum mismatch: received file has SHA-256 %s", object.Checksum)}
 	}
 
 	// Update params with actual values
-	params.FileSize = written
-	params.Checksum = hex.EncodeToString(hasher.Sum(nil))
+	params.FileSize = object.Size
+	params.Checksum = object.Checksum
 
 	s.logger.Debug("Creating backup record", logging.WithFields(map[string]interface{}{
 		"radio_id":    radioID,
@@ -229,9 +201,9 @@ func (s *Service) CreateBackup(ctx context.Context, radioID string, userID strin
 	}))
 
 	// Create the database record
-	backup, err := s.store.CreateBackup(ctx, radioID, params, storagePath)
+	backup, err := s.store.CreateBackup(ctx, radioID, params, s.storage.Name(), key)
 	if err != nil {
-		os.Remove(storagePath)
+		s.discardObject(key)
 		s.logger.Error("Failed to create backup record", logging.WithField("error", err.Error()))
 		return nil, err
 	}
@@ -248,7 +220,7 @@ func (s *Service) ListBackups(ctx context.Context, radioID string, userID string
 		return nil, err
 	}
 	if radio == nil {
-		return nil, &ServiceError{Message: "radio not found"}
+		return nil, &ServiceError{Code: apierror.RadioNotFound, Message: "radio not found"}
 	}
 
 	return s.store.ListBackups(ctx, radioID, params)
@@ -262,31 +234,39 @@ func (s *Service) GetBackup(ctx context.Context, backupID string, radioID string
 		return nil, err
 	}
 	if radio == nil {
-		return nil, &ServiceError{Message: "radio not found"}
+		return nil, &ServiceError{Code: apierror.RadioNotFound, Message: "radio not found"}
 	}
 
 	return s.store.GetBackup(ctx, backupID, radioID)
 }
 
-// GetBackupFile returns a reader for a backup file
-func (s *Service) GetBackupFile(ctx context.Context, backupID string, radioID string, userID string) (io.ReadCloser, *models.RadioBackup, error) {
+// GetBackupFile returns a seekable reader over a backup file, for serving
+// range requests
+func (s *Service) GetBackupFile(ctx context.Context, backupID string, radioID string, userID string) (*blobstore.ReadSeeker, *models.RadioBackup, error) {
 	backup, err := s.GetBackup(ctx, backupID, radioID, userID)
 	if err != nil {
 		return nil, nil, err
 	}
 	if backup == nil {
-		return nil, nil, &ServiceError{Message: "backup not found"}
+		return nil, nil, &ServiceError{Code: apierror.RadioBackupNotFound, Message: "backup not found"}
 	}
 
-	file, err := os.Open(backup.StoragePath)
+	storage, err := s.storageFor(backup)
 	if err != nil {
+		s.logger.Error("Backup is in an unavailable store", logging.WithFields(map[string]interface{}{
+			"id":    backup.ID,
+			"error": err.Error(),
+		}))
+		return nil, nil, &ServiceError{Code: apierror.RadioBackupNotFound, Message: "backup file not found"}
+	}
+	file := blobstore.NewReadSeeker(ctx, storage, backup.StoragePath, backup.FileSize)
+	if err := file.Open(); err != nil {
 		s.logger.Error("Failed to open backup file", logging.WithFields(map[string]interface{}{
 			"path":  backup.StoragePath,
 			"error": err.Error(),
 		}))
-		return nil, nil, &ServiceError{Message: "backup file not found"}
+		return nil, nil, &ServiceError{Code: apierror.RadioBackupNotFound, Message: "backup file not found"}
 	}
-
 	return file, backup, nil
 }
 
@@ -298,7 +278,7 @@ func (s *Service) DeleteBackup(ctx context.Context, backupID string, radioID str
 		return err
 	}
 	if radio == nil {
-		return &ServiceError{Message: "radio not found"}
+		return &ServiceError{Code: apierror.RadioNotFound, Message: "radio not found"}
 	}
 
 	s.logger.Debug("Deleting backup", logging.WithField("id", backupID))
@@ -310,23 +290,69 @@ func (s *Service) DeleteBackup(ctx context.Context, backupID string, radioID str
 			"id":    backupID,
 			"error": err.Error(),
 		}))
-		return err
+		return storeError(err)
 	}
 
-	// Delete the file
-	if backup.StoragePath != "" {
-		if err := os.Remove(backup.StoragePath); err != nil && !os.IsNotExist(err) {
-			s.logger.Warn("Failed to delete backup file", logging.WithFields(map[string]interface{}{
-				"path":  backup.StoragePath,
-				"error": err.Error(),
-			}))
-		}
-	}
+	s.deleteBackupFile(ctx, backup)
 
 	s.logger.Info("Deleted backup", logging.WithField("id", backupID))
 	return nil
 }
 
+// storeError turns the store's not-found errors into service errors
+func storeError(err error) error {
+	switch {
+	case errors.Is(err, database.ErrRadioNotFound):
+		return &ServiceError{Code: apierror.RadioNotFound, Message: "radio not found"}
+	case errors.Is(err, database.ErrRadioBackupNotFound):
+		return &ServiceError{Code: apierror.RadioBackupNotFound, Message: "backup not found"}
+	}
+	return err
+}
+
+// storageFor returns the blob store holding a backup's file
+func (s *Service) storageFor(backup *models.RadioBackup) (blobstore.Store, error) {
+	switch backup.StorageBackend {
+	case "":
+		return s.legacy, nil
+	case s.storage.Name():
+		return s.storage, nil
+	default:
+		return nil, fmt.Errorf("backup is stored in %q but %q is configured", backup.StorageBackend, s.storage.Name())
+	}
+}
+
+// deleteBackupFile removes a backup's file, logging rather than failing
+// since the record is already gone
+func (s *Service) deleteBackupFile(ctx context.Context, backup *models.RadioBackup) {
+	if backup.StoragePath == "" {
+		return
+	}
+	storage, err := s.storageFor(backup)
+	if err == nil {
+		err = storage.Delete(ctx, backup.StoragePath)
+	}
+	if err != nil {
+		s.logger.Warn("Failed to delete backup file", logging.WithFields(map[string]interface{}{
+			"path":  backup.StoragePath,
+			"error": err.Error(),
+		}))
+	}
+}
+
+// discardObject removes an upload that was rejected after it was stored.
+// It runs on its own context since the request's may have been cancelled.
+func (s *Service) discardObject(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := s.storage.Delete(ctx, key); err != nil {
+		s.logger.Warn("Failed to discard rejected backup file", logging.WithFields(map[string]interface{}{
+			"path":  key,
+			"error": err.Error(),
+		}))
+	}
+}
+
 // Helper functions
 
 // sanitizeFileName removes unsafe characters from a filename
@@ -347,23 +373,3 @@ func sanitizeFileName(filename string) string {
 
 	return safe
 }
-
-// ensureUniquePath adds a suffix if the path already exists
-func ensureUniquePath(path string) string {
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return path
-	}
-
-	ext := filepath.Ext(path)
-	base := strings.TrimSuffix(path, ext)
-
-	for i := 1; i < 1000; i++ {
-		newPath := fmt.Sprintf("%s_%d%s", base, i, ext)
-		if _, err := os.Stat(newPath); os.IsNotExist(err) {
-			return newPath
-		}
-	}
-
-	// Fallback: use timestamp
-	return fmt.Sprintf("%s_%d%s", base, os.Getpid(), ext)
-}
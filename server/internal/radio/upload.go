@@ -0,0 +1,49 @@
+package radio
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// PrepareUpload checks a resumable backup upload before any data is sent.
+// Metadata must name the radioId and backupName; backupType is optional.
+func (s *Service) PrepareUpload(ctx context.Context, userID string, params models.CreateUploadParams) error {
+	if params.Size > MaxBackupFileSize {
+		return &ServiceError{Message: fmt.Sprintf("file size exceeds maximum allowed (%d bytes)", MaxBackupFileSize)}
+	}
+	if params.Metadata["radioId"] == "" {
+		return &ServiceError{Message: "radioId is required"}
+	}
+	if params.Metadata["backupName"] == "" {
+		return &ServiceError{Message: "backup name is required"}
+	}
+
+	radio, err := s.store.GetRadio(ctx, params.Metadata["radioId"], userID)
+	if err != nil {
+		return err
+	}
+	if radio == nil {
+		return &ServiceError{Code: apierror.RadioNotFound, Message: "radio not found"}
+	}
+	return nil
+}
+
+// CompleteUpload saves a finished resumable upload as a backup and returns
+// the backup's ID
+func (s *Service) CompleteUpload(ctx context.Context, upload *models.Upload, file io.Reader) (string, error) {
+	backup, err := s.CreateBackup(ctx, upload.Metadata["radioId"], upload.UserID, models.CreateRadioBackupParams{
+		BackupName: upload.Metadata["backupName"],
+		BackupType: models.BackupType(upload.Metadata["backupType"]),
+		FileName:   upload.FileName,
+		FileSize:   upload.Size,
+		Checksum:   upload.Checksum,
+	}, file)
+	if err != nil {
+		return "", err
+	}
+	return backup.ID, nil
+}
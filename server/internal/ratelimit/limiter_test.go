@@ -208,3 +208,25 @@ func TestLimiter_ZeroInterval(t *testing.T) {
 		}
 	}
 }
+
+func TestWindowLimiter(t *testing.T) {
+	limiter := NewWindow(2, 50*time.Millisecond)
+
+	if !limiter.Allow("1.2.3.4") || !limiter.Allow("1.2.3.4") {
+		t.Fatal("Allow() should allow requests up to the limit")
+	}
+	if limiter.Allow("1.2.3.4") {
+		t.Error("Allow() should refuse requests over the limit")
+	}
+	if !limiter.Allow("5.6.7.8") {
+		t.Error("Allow() should count each key separately")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if !limiter.Allow("1.2.3.4") {
+		t.Error("Allow() should allow requests once the window has passed")
+	}
+	if _, exists := limiter.windows["5.6.7.8"]; exists {
+		t.Error("finished windows should be swept")
+	}
+}
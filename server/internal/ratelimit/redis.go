@@ -73,3 +73,44 @@ func (l *RedisLimiter) Reset(host string) {
 
 // Ensure RedisLimiter implements RateLimiter interface
 var _ RateLimiter = (*RedisLimiter)(nil)
+
+// RedisWindowLimiter is a distributed WindowLimiter backed by Redis
+type RedisWindowLimiter struct {
+	client *redis.Client
+	prefix string
+	limit  int
+	length time.Duration
+}
+
+// NewRedisWindow creates a Redis-backed limiter allowing limit requests per
+// key every length
+func NewRedisWindow(client *redis.Client, prefix string, limit int, length time.Duration) *RedisWindowLimiter {
+	if prefix == "" {
+		prefix = "ratelimit:window:"
+	}
+	return &RedisWindowLimiter{
+		client: client,
+		prefix: prefix,
+		limit:  limit,
+		length: length,
+	}
+}
+
+// Allow counts a request from key and reports whether it is within the limit
+func (l *RedisWindowLimiter) Allow(key string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// The first request of a window starts its expiry
+	pipe := l.client.TxPipeline()
+	count := pipe.Incr(ctx, l.prefix+key)
+	pipe.ExpireNX(ctx, l.prefix+key, l.length)
+	if _, err := pipe.Exec(ctx); err != nil {
+		// On Redis error, fail open (allow the request)
+		return true
+	}
+	return count.Val() <= int64(l.limit)
+}
+
+// Ensure RedisWindowLimiter implements RateLimiter interface
+var _ RateLimiter = (*RedisWindowLimiter)(nil)
@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// WindowLimiter allows up to limit requests per key in each fixed window,
+// e.g. 20 per hour. Unlike Limiter, short bursts are fine as long as the
+// total stays under the limit.
+type WindowLimiter struct {
+	mu        sync.Mutex
+	windows   map[string]*window
+	limit     int
+	length    time.Duration
+	lastSweep time.Time
+}
+
+type window struct {
+	start time.Time
+	count int
+}
+
+// NewWindow creates an in-memory limiter allowing limit requests per key
+// every length
+func NewWindow(limit int, length time.Duration) *WindowLimiter {
+	return &WindowLimiter{
+		windows:   make(map[string]*window),
+		limit:     limit,
+		length:    length,
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow counts a request from key and reports whether it is within the limit
+func (l *WindowLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastSweep) >= l.length {
+		// Drop finished windows so keys seen once don't pile up
+		for k, w := range l.windows {
+			if now.Sub(w.start) >= l.length {
+				delete(l.windows, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	w, exists := l.windows[key]
+	if !exists || now.Sub(w.start) >= l.length {
+		w = &window{start: now}
+		l.windows[key] = w
+	}
+	if w.count >= l.limit {
+		return false
+	}
+	w.count++
+	return true
+}
+
+// Ensure WindowLimiter implements RateLimiter interface
+var _ RateLimiter = (*WindowLimiter)(nil)
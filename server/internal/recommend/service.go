@@ -0,0 +1,106 @@
+// Package recommend answers "pilots who used this also used": catalog items
+// that often share an inventory or a build with a given item. Co-occurrence
+// is precomputed nightly; requests only read and diversify it.
+package recommend
+
+import (
+	"context"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	// MinSharedCount is how many inventories or builds must hold both items
+	// before the pair is recommended, so one pilot's gear never shows up
+	MinSharedCount = 3
+	// storedPerItem bounds how many related items are kept per item
+	storedPerItem = 50
+	// MaxPerGearType keeps one gear type from filling the list: a popular
+	// motor shouldn't only recommend other motors
+	MaxPerGearType = 2
+
+	DefaultLimit = 8
+	MaxLimit     = 20
+)
+
+// Store defines the co-occurrence persistence operations
+type Store interface {
+	Recompute(ctx context.Context, minShared, perItem int) (int64, error)
+	ListRelated(ctx context.Context, itemID string, limit int) ([]models.CatalogRecommendation, *time.Time, error)
+}
+
+// Service serves catalog recommendations
+type Service struct {
+	store  Store
+	logger *logging.Logger
+}
+
+// NewService creates a new recommendation service
+func NewService(store *database.RecommendationStore, logger *logging.Logger) *Service {
+	return &Service{store: store, logger: logger}
+}
+
+// Recompute rebuilds co-occurrence from every inventory and build
+func (s *Service) Recompute(ctx context.Context) error {
+	start := time.Now()
+	pairs, err := s.store.Recompute(ctx, MinSharedCount, storedPerItem)
+	if err != nil {
+		return err
+	}
+	s.logger.Info("Catalog recommendations recomputed", logging.WithFields(map[string]interface{}{
+		"pairs":    pairs,
+		"duration": time.Since(start).String(),
+	}))
+	return nil
+}
+
+// Recommendations returns up to limit items often used with itemID, best
+// first, with at most MaxPerGearType items of any one gear type
+func (s *Service) Recommendations(ctx context.Context, itemID string, limit int) (*models.CatalogRecommendationsResponse, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	candidates, computedAt, err := s.store.ListRelated(ctx, itemID, storedPerItem)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CatalogRecommendationsResponse{
+		ItemID:          itemID,
+		Recommendations: diversify(candidates, limit, MaxPerGearType),
+		ComputedAt:      computedAt,
+	}, nil
+}
+
+// diversify walks candidates in rank order, skipping any whose gear type
+// already has perType picks. If that leaves the list short, the skipped
+// candidates fill it in rank order, since a full list beats a varied one.
+func diversify(candidates []models.CatalogRecommendation, limit, perType int) []models.CatalogRecommendation {
+	picked := make([]models.CatalogRecommendation, 0, limit)
+	var skipped []models.CatalogRecommendation
+	counts := make(map[models.GearType]int)
+	for _, c := range candidates {
+		if len(picked) == limit {
+			break
+		}
+		if counts[c.Item.GearType] >= perType {
+			skipped = append(skipped, c)
+			continue
+		}
+		counts[c.Item.GearType]++
+		picked = append(picked, c)
+	}
+	for _, c := range skipped {
+		if len(picked) == limit {
+			break
+		}
+		picked = append(picked, c)
+	}
+	return picked
+}
@@ -0,0 +1,134 @@
+package recommend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+type mockStore struct {
+	related    []models.CatalogRecommendation
+	computedAt *time.Time
+	minShared  int
+	perItem    int
+	listLimit  int
+}
+
+func (m *mockStore) Recompute(ctx context.Context, minShared, perItem int) (int64, error) {
+	m.minShared = minShared
+	m.perItem = perItem
+	return int64(len(m.related)), nil
+}
+
+func (m *mockStore) ListRelated(ctx context.Context, itemID string, limit int) ([]models.CatalogRecommendation, *time.Time, error) {
+	m.listLimit = limit
+	return m.related, m.computedAt, nil
+}
+
+func rec(id string, gearType models.GearType, score float64) models.CatalogRecommendation {
+	return models.CatalogRecommendation{
+		Item:  models.BuildCatalogItem{ID: id, GearType: gearType},
+		Score: score,
+	}
+}
+
+func ids(recs []models.CatalogRecommendation) []string {
+	out := make([]string, len(recs))
+	for i, r := range recs {
+		out[i] = r.Item.ID
+	}
+	return out
+}
+
+func assertIDs(t *testing.T, got []models.CatalogRecommendation, want ...string) {
+	t.Helper()
+	gotIDs := ids(got)
+	if len(gotIDs) != len(want) {
+		t.Fatalf("got %v, want %v", gotIDs, want)
+	}
+	for i := range want {
+		if gotIDs[i] != want[i] {
+			t.Fatalf("got %v, want %v", gotIDs, want)
+		}
+	}
+}
+
+func TestDiversify_CapsEachGearType(t *testing.T) {
+	candidates := []models.CatalogRecommendation{
+		rec("m1", models.GearTypeMotor, 0.9),
+		rec("m2", models.GearTypeMotor, 0.8),
+		rec("m3", models.GearTypeMotor, 0.7),
+		rec("p1", models.GearTypeProp, 0.6),
+		rec("f1", models.GearTypeFC, 0.5),
+	}
+
+	assertIDs(t, diversify(candidates, 4, 2), "m1", "m2", "p1", "f1")
+}
+
+func TestDiversify_FillsFromSkippedWhenShort(t *testing.T) {
+	candidates := []models.CatalogRecommendation{
+		rec("m1", models.GearTypeMotor, 0.9),
+		rec("m2", models.GearTypeMotor, 0.8),
+		rec("m3", models.GearTypeMotor, 0.7),
+		rec("m4", models.GearTypeMotor, 0.6),
+		rec("p1", models.GearTypeProp, 0.5),
+	}
+
+	assertIDs(t, diversify(candidates, 4, 2), "m1", "m2", "p1", "m3")
+}
+
+func TestDiversify_Empty(t *testing.T) {
+	got := diversify(nil, 8, 2)
+	if got == nil || len(got) != 0 {
+		t.Fatalf("expected empty non-nil slice, got %v", got)
+	}
+}
+
+func TestRecommendations_ClampsLimit(t *testing.T) {
+	var related []models.CatalogRecommendation
+	gearTypes := []models.GearType{models.GearTypeMotor, models.GearTypeProp, models.GearTypeFC, models.GearTypeESC,
+		models.GearTypeFrame, models.GearTypeVTX, models.GearTypeReceiver, models.GearTypeAntenna,
+		models.GearTypeBattery, models.GearTypeCamera, models.GearTypeRadio}
+	for i := 0; i < 30; i++ {
+		related = append(related, rec(string(rune('a'+i)), gearTypes[i%len(gearTypes)], 1))
+	}
+	store := &mockStore{related: related}
+	svc := &Service{store: store, logger: testutil.NullLogger()}
+
+	resp, err := svc.Recommendations(context.Background(), "item-1", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Recommendations) != DefaultLimit {
+		t.Fatalf("expected default limit %d, got %d", DefaultLimit, len(resp.Recommendations))
+	}
+	if resp.ItemID != "item-1" {
+		t.Fatalf("expected itemId item-1, got %q", resp.ItemID)
+	}
+
+	resp, err = svc.Recommendations(context.Background(), "item-1", 500)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Recommendations) != MaxLimit {
+		t.Fatalf("expected max limit %d, got %d", MaxLimit, len(resp.Recommendations))
+	}
+	if store.listLimit != storedPerItem {
+		t.Fatalf("expected to read %d candidates, read %d", storedPerItem, store.listLimit)
+	}
+}
+
+func TestRecompute_UsesPrivacyThreshold(t *testing.T) {
+	store := &mockStore{}
+	svc := &Service{store: store, logger: testutil.NullLogger()}
+
+	if err := svc.Recompute(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if store.minShared != MinSharedCount || store.perItem != storedPerItem {
+		t.Fatalf("expected Recompute(%d, %d), got (%d, %d)", MinSharedCount, storedPerItem, store.minShared, store.perItem)
+	}
+}
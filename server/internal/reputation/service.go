@@ -0,0 +1,122 @@
+// Package reputation scores users by the outcome of their catalog
+// contributions. Moderators see the score on the admin user view, the
+// auto-publish rules can require one, and it sets how many catalog items a
+// user may submit per day.
+package reputation
+
+import (
+	"context"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/cache"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// Score weights. Removals weigh more than publications so a run of rejected
+// submissions quickly costs a user their fast track.
+const (
+	pointsPerPublished     = 10
+	pointsPerImageApproved = 5
+	pointsPerRemoved       = -20
+	pointsPerImageRejected = -10
+
+	contributorScore = 50
+	trustedScore     = 200
+)
+
+// submissionWindow is the period daily submission limits apply to
+const submissionWindow = 24 * time.Hour
+
+// Daily catalog submission limits by tier. Trusted users aren't limited.
+var submissionLimits = map[models.ReputationTier]int{
+	models.ReputationTierNew:         10,
+	models.ReputationTierContributor: 50,
+}
+
+// Store counts contributions
+type Store interface {
+	ContributionStats(ctx context.Context, userID string) (*models.ContributionStats, error)
+	CountSubmissionsSince(ctx context.Context, userID string, since time.Time) (int, error)
+}
+
+// Service computes user reputation. Results are cached for ttl, since the
+// counts behind them only move when a moderator acts.
+type Service struct {
+	store Store
+	cache cache.Cache
+}
+
+// NewService creates a reputation service
+func NewService(store Store, ttl time.Duration) *Service {
+	return &Service{store: store, cache: cache.NewMemory(ttl)}
+}
+
+// Get returns a user's reputation
+func (s *Service) Get(ctx context.Context, userID string) (*models.UserReputation, error) {
+	if cached, ok := s.cache.Get(userID); ok {
+		if reputation, ok := cached.(*models.UserReputation); ok {
+			return reputation, nil
+		}
+	}
+
+	stats, err := s.store.ContributionStats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	score, tier := Compute(*stats)
+	reputation := &models.UserReputation{
+		Score:      score,
+		Tier:       tier,
+		Stats:      *stats,
+		ComputedAt: time.Now().UTC(),
+	}
+	s.cache.Set(userID, reputation)
+	return reputation, nil
+}
+
+// Score returns a user's reputation score
+func (s *Service) Score(ctx context.Context, userID string) (int, error) {
+	reputation, err := s.Get(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return reputation.Score, nil
+}
+
+// AllowSubmission reports whether a user may submit another catalog item
+// under their tier's daily limit
+func (s *Service) AllowSubmission(ctx context.Context, userID string) (bool, error) {
+	reputation, err := s.Get(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	limit, limited := submissionLimits[reputation.Tier]
+	if !limited {
+		return true, nil
+	}
+	count, err := s.store.CountSubmissionsSince(ctx, userID, time.Now().Add(-submissionWindow))
+	if err != nil {
+		return false, err
+	}
+	return count < limit, nil
+}
+
+// Compute scores contribution stats. The score never drops below zero.
+func Compute(stats models.ContributionStats) (int, models.ReputationTier) {
+	score := stats.SubmissionsPublished*pointsPerPublished +
+		stats.ImagesApproved*pointsPerImageApproved +
+		stats.SubmissionsRemoved*pointsPerRemoved +
+		stats.ImagesRejected*pointsPerImageRejected
+	if score < 0 {
+		score = 0
+	}
+
+	switch {
+	case score >= trustedScore:
+		return score, models.ReputationTierTrusted
+	case score >= contributorScore:
+		return score, models.ReputationTierContributor
+	default:
+		return score, models.ReputationTierNew
+	}
+}
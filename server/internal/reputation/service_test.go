@@ -0,0 +1,70 @@
+package reputation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+type fakeStore struct {
+	stats  map[string]models.ContributionStats
+	recent map[string]int
+	reads  int
+}
+
+func (f *fakeStore) ContributionStats(ctx context.Context, userID string) (*models.ContributionStats, error) {
+	f.reads++
+	stats := f.stats[userID]
+	return &stats, nil
+}
+
+func (f *fakeStore) CountSubmissionsSince(ctx context.Context, userID string, since time.Time) (int, error) {
+	return f.recent[userID], nil
+}
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		stats models.ContributionStats
+		score int
+		tier  models.ReputationTier
+	}{
+		{models.ContributionStats{}, 0, models.ReputationTierNew},
+		{models.ContributionStats{SubmissionsPublished: 4, ImagesApproved: 2}, 50, models.ReputationTierContributor},
+		{models.ContributionStats{SubmissionsPublished: 20}, 200, models.ReputationTierTrusted},
+		{models.ContributionStats{SubmissionsPublished: 20, SubmissionsRemoved: 1}, 180, models.ReputationTierContributor},
+		{models.ContributionStats{SubmissionsRemoved: 3, ImagesRejected: 1}, 0, models.ReputationTierNew},
+		{models.ContributionStats{SubmissionsPending: 40}, 0, models.ReputationTierNew},
+	}
+	for _, tt := range tests {
+		if score, tier := Compute(tt.stats); score != tt.score || tier != tt.tier {
+			t.Errorf("Compute(%+v) = %d, %s; want %d, %s", tt.stats, score, tier, tt.score, tt.tier)
+		}
+	}
+}
+
+func TestAllowSubmission(t *testing.T) {
+	store := &fakeStore{
+		stats: map[string]models.ContributionStats{
+			"trusted": {SubmissionsPublished: 30},
+		},
+		recent: map[string]int{"new": 10, "fresh": 9, "trusted": 500},
+	}
+	svc := NewService(store, time.Minute)
+	ctx := context.Background()
+
+	for user, want := range map[string]bool{"new": false, "fresh": true, "trusted": true} {
+		if got, err := svc.AllowSubmission(ctx, user); err != nil || got != want {
+			t.Errorf("AllowSubmission(%s) = %v, %v; want %v", user, got, err, want)
+		}
+	}
+
+	reads := store.reads
+	if score, _ := svc.Score(ctx, "trusted"); score != 300 {
+		t.Errorf("Score(trusted) = %d, want 300", score)
+	}
+	if store.reads != reads {
+		t.Error("reputation was recomputed within the cache ttl")
+	}
+}
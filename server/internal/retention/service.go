@@ -0,0 +1,107 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// Store defines the retention persistence operations
+type Store interface {
+	List(ctx context.Context, userID string) ([]models.RetentionSetting, error)
+	Set(ctx context.Context, userID string, category models.RetentionCategory, retainDays *int) error
+	Purge(ctx context.Context, category models.RetentionCategory) (*models.RetentionPurgeResult, error)
+}
+
+// Service lets users choose how long their log data is kept and purges
+// data past that age
+type Service struct {
+	store  Store
+	logger *logging.Logger
+}
+
+// NewService creates a new retention service
+func NewService(store *database.RetentionStore, logger *logging.Logger) *Service {
+	return &Service{store: store, logger: logger}
+}
+
+// Settings returns a user's retention for every category, including ones
+// kept forever
+func (s *Service) Settings(ctx context.Context, userID string) (*models.RetentionSettingsResponse, error) {
+	stored, err := s.store.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	byCategory := make(map[models.RetentionCategory]models.RetentionSetting, len(stored))
+	for _, setting := range stored {
+		byCategory[setting.Category] = setting
+	}
+
+	settings := make([]models.RetentionSetting, 0, len(models.RetentionCategories))
+	for _, category := range models.RetentionCategories {
+		setting, ok := byCategory[category]
+		if !ok {
+			setting = models.RetentionSetting{Category: category}
+		}
+		settings = append(settings, setting)
+	}
+	return &models.RetentionSettingsResponse{Settings: settings}, nil
+}
+
+// Update changes a user's retention. Shortening it doesn't delete anything
+// until the next purge.
+func (s *Service) Update(ctx context.Context, userID string, params models.UpdateRetentionParams) (*models.RetentionSettingsResponse, error) {
+	if len(params.Settings) == 0 {
+		return nil, &ServiceError{Message: "settings is required"}
+	}
+	for _, setting := range params.Settings {
+		if !models.IsValidRetentionCategory(setting.Category) {
+			return nil, &ServiceError{Message: fmt.Sprintf("unknown retention category %q", setting.Category)}
+		}
+		if setting.RetainDays != nil && (*setting.RetainDays < models.MinRetentionDays || *setting.RetainDays > models.MaxRetentionDays) {
+			return nil, &ServiceError{Message: fmt.Sprintf("retainDays must be between %d and %d, or null to keep forever", models.MinRetentionDays, models.MaxRetentionDays)}
+		}
+	}
+
+	for _, setting := range params.Settings {
+		if err := s.store.Set(ctx, userID, setting.Category, setting.RetainDays); err != nil {
+			return nil, err
+		}
+	}
+	return s.Settings(ctx, userID)
+}
+
+// Purge deletes data older than each user's retention in every category.
+// A failing category doesn't stop the rest; the first error is returned
+// with the results that succeeded.
+func (s *Service) Purge(ctx context.Context) ([]models.RetentionPurgeResult, error) {
+	results := make([]models.RetentionPurgeResult, 0, len(models.RetentionCategories))
+	var firstErr error
+	for _, category := range models.RetentionCategories {
+		result, err := s.store.Purge(ctx, category)
+		if err != nil {
+			s.logger.Warn("Retention purge failed", logging.WithFields(map[string]interface{}{
+				"category": category,
+				"error":    err.Error(),
+			}))
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		results = append(results, *result)
+	}
+	return results, firstErr
+}
+
+// ServiceError represents a retention request error that should be shown to the client
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
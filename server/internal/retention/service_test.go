@@ -0,0 +1,100 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+// mockStore keeps settings in memory
+type mockStore struct {
+	days     map[models.RetentionCategory]int
+	purgeErr error
+}
+
+func (m *mockStore) List(ctx context.Context, userID string) ([]models.RetentionSetting, error) {
+	settings := make([]models.RetentionSetting, 0, len(m.days))
+	for category, days := range m.days {
+		days := days
+		settings = append(settings, models.RetentionSetting{Category: category, RetainDays: &days})
+	}
+	return settings, nil
+}
+
+func (m *mockStore) Set(ctx context.Context, userID string, category models.RetentionCategory, retainDays *int) error {
+	if retainDays == nil {
+		delete(m.days, category)
+	} else {
+		m.days[category] = *retainDays
+	}
+	return nil
+}
+
+func (m *mockStore) Purge(ctx context.Context, category models.RetentionCategory) (*models.RetentionPurgeResult, error) {
+	if m.purgeErr != nil {
+		return nil, m.purgeErr
+	}
+	return &models.RetentionPurgeResult{Category: category, Users: 1, Deleted: 12}, nil
+}
+
+func intPtr(n int) *int { return &n }
+
+func TestService_Update(t *testing.T) {
+	store := &mockStore{days: map[models.RetentionCategory]int{}}
+	svc := &Service{store: store, logger: testutil.NullLogger()}
+
+	resp, err := svc.Update(context.Background(), "user-1", models.UpdateRetentionParams{Settings: []models.RetentionSetting{
+		{Category: models.RetentionBatteryLogs, RetainDays: intPtr(365)},
+	}})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if len(resp.Settings) != len(models.RetentionCategories) {
+		t.Fatalf("settings = %+v, want one per category", resp.Settings)
+	}
+	if got := resp.Settings[0].RetainDays; got == nil || *got != 365 {
+		t.Errorf("battery log retention = %v, want 365", got)
+	}
+
+	resp, err = svc.Update(context.Background(), "user-1", models.UpdateRetentionParams{Settings: []models.RetentionSetting{
+		{Category: models.RetentionBatteryLogs},
+	}})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if resp.Settings[0].RetainDays != nil {
+		t.Errorf("battery log retention = %d, want kept forever", *resp.Settings[0].RetainDays)
+	}
+
+	invalid := []models.RetentionSetting{
+		{Category: "browser_history", RetainDays: intPtr(365)},
+		{Category: models.RetentionBatteryLogs, RetainDays: intPtr(1)},
+		{Category: models.RetentionBatteryLogs, RetainDays: intPtr(models.MaxRetentionDays + 1)},
+	}
+	for _, setting := range invalid {
+		_, err := svc.Update(context.Background(), "user-1", models.UpdateRetentionParams{Settings: []models.RetentionSetting{setting}})
+		var svcErr *ServiceError
+		if !errors.As(err, &svcErr) {
+			t.Errorf("Update(%+v) error = %v, want ServiceError", setting, err)
+		}
+	}
+}
+
+func TestService_Purge(t *testing.T) {
+	svc := &Service{store: &mockStore{}, logger: testutil.NullLogger()}
+	results, err := svc.Purge(context.Background())
+	if err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+	if len(results) != len(models.RetentionCategories) || results[0].Deleted != 12 {
+		t.Errorf("Purge() = %+v, want a result per category", results)
+	}
+
+	svc = &Service{store: &mockStore{purgeErr: errors.New("db down")}, logger: testutil.NullLogger()}
+	if _, err := svc.Purge(context.Background()); err == nil {
+		t.Error("Purge() should report a failing category")
+	}
+}
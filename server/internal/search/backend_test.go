@@ -0,0 +1,126 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+func TestMeilisearchSearch(t *testing.T) {
+	var gotPath, gotAuth string
+	var gotBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		_, _ = w.Write([]byte(`{"hits":[{"id":"item-2"},{"id":"item-1"}],"estimatedTotalHits":7}`))
+	}))
+	defer server.Close()
+
+	backend := NewMeilisearch(server.URL, "master-key", "ff_")
+	result, err := backend.Search(context.Background(), models.SearchIndexQuery{
+		Collection: models.SearchCollectionCatalog,
+		Query:      "tmotor f60",
+		Filters:    map[string]string{"gearType": "motor", "brandKey": "t-motor"},
+		Limit:      20,
+	})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if gotPath != "/indexes/ff_catalog/search" || gotAuth != "Bearer master-key" {
+		t.Fatalf("path=%q auth=%q", gotPath, gotAuth)
+	}
+	filters, _ := gotBody["filter"].([]interface{})
+	if len(filters) != 2 || filters[0] != `brandKey = "t-motor"` || filters[1] != `gearType = "motor"` {
+		t.Fatalf("filter=%v", gotBody["filter"])
+	}
+	if len(result.IDs) != 2 || result.IDs[0] != "item-2" || result.Total != 7 {
+		t.Fatalf("result=%+v", result)
+	}
+}
+
+func TestTypesenseSearch(t *testing.T) {
+	var gotQuery map[string][]string
+	var gotKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/collections/ff_builds/documents/search" {
+			t.Errorf("path=%q", r.URL.Path)
+		}
+		gotQuery = r.URL.Query()
+		gotKey = r.Header.Get("X-TYPESENSE-API-KEY")
+		_, _ = w.Write([]byte(`{"found":3,"hits":[{"document":{"id":"build-1"}}]}`))
+	}))
+	defer server.Close()
+
+	backend := NewTypesense(server.URL, "ts-key", "ff_")
+	result, err := backend.Search(context.Background(), models.SearchIndexQuery{
+		Collection: models.SearchCollectionBuilds,
+		Query:      "freestyle",
+		Limit:      24,
+		Offset:     24,
+	})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if gotKey != "ts-key" || gotQuery["query_by"][0] != "title,parts,pilot,description" || gotQuery["offset"][0] != "24" {
+		t.Fatalf("key=%q query=%v", gotKey, gotQuery)
+	}
+	if len(result.IDs) != 1 || result.IDs[0] != "build-1" || result.Total != 3 {
+		t.Fatalf("result=%+v", result)
+	}
+}
+
+func TestTypesenseEnsureCollectionAddsMissingFields(t *testing.T) {
+	var patched map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/collections":
+			w.WriteHeader(http.StatusConflict)
+		case r.Method == http.MethodGet && r.URL.Path == "/collections/ff_builds":
+			_, _ = w.Write([]byte(`{"name":"ff_builds","fields":[{"name":"title"},{"name":"parts"},{"name":"pilot"},{"name":"description"},{"name":"viewCount"},{"name":"indexedAt"}]}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/collections/ff_builds":
+			_ = json.NewDecoder(r.Body).Decode(&patched)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	backend := NewTypesense(server.URL, "ts-key", "ff_")
+	if err := backend.EnsureCollection(context.Background(), buildsCollection); err != nil {
+		t.Fatalf("EnsureCollection: %v", err)
+	}
+	fields, _ := patched["fields"].([]interface{})
+	if len(fields) != 1 {
+		t.Fatalf("patched fields=%v", patched["fields"])
+	}
+	if f, _ := fields[0].(map[string]interface{}); f["name"] != "tenantId" || f["facet"] != true {
+		t.Fatalf("patched field=%v", fields[0])
+	}
+}
+
+func TestTypesenseUpsertReportsRejectedDocuments(t *testing.T) {
+	var gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		_, _ = w.Write([]byte("{\"success\":true}\n{\"success\":false,\"error\":\"Bad JSON.\"}"))
+	}))
+	defer server.Close()
+
+	backend := NewTypesense(server.URL, "ts-key", "ff_")
+	err := backend.Upsert(context.Background(), models.SearchCollectionCatalog, []document{{"id": "a"}, {"id": "b"}})
+	if err == nil || !strings.Contains(err.Error(), "Bad JSON") {
+		t.Fatalf("expected rejected document error, got %v", err)
+	}
+	if strings.Count(gotBody, "\n") != 2 {
+		t.Fatalf("expected JSONL body, got %q", gotBody)
+	}
+}
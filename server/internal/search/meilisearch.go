@@ -0,0 +1,138 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// Meilisearch is a Backend for a Meilisearch server. Writes are queued as
+// Meilisearch tasks and applied shortly after they return.
+type Meilisearch struct {
+	baseURL string
+	apiKey  string
+	prefix  string
+	client  *http.Client
+}
+
+// NewMeilisearch creates a backend for the server at baseURL. Index names
+// are prefix followed by the collection name.
+func NewMeilisearch(baseURL, apiKey, prefix string) *Meilisearch {
+	return &Meilisearch{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		prefix:  prefix,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (m *Meilisearch) index(c models.SearchCollection) string {
+	return url.PathEscape(m.prefix + string(c))
+}
+
+// EnsureCollection creates the index if needed and applies its settings
+func (m *Meilisearch) EnsureCollection(ctx context.Context, c collection) error {
+	// Creating an index that exists fails its task, not the request
+	if err := m.do(ctx, http.MethodPost, "/indexes", map[string]string{"uid": m.prefix + string(c.Name), "primaryKey": "id"}, nil); err != nil {
+		return err
+	}
+	settings := map[string]interface{}{
+		"searchableAttributes": c.searchable(),
+		"filterableAttributes": c.filterable(),
+	}
+	return m.do(ctx, http.MethodPatch, "/indexes/"+m.index(c.Name)+"/settings", settings, nil)
+}
+
+// Upsert adds or replaces documents
+func (m *Meilisearch) Upsert(ctx context.Context, c models.SearchCollection, docs []document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	return m.do(ctx, http.MethodPost, "/indexes/"+m.index(c)+"/documents", docs, nil)
+}
+
+// DeleteIndexedBefore deletes documents last written before indexedAt
+func (m *Meilisearch) DeleteIndexedBefore(ctx context.Context, c models.SearchCollection, indexedAt time.Time) error {
+	body := map[string]string{"filter": fmt.Sprintf("%s < %d", indexedAtField, indexedAt.UnixMilli())}
+	return m.do(ctx, http.MethodPost, "/indexes/"+m.index(c)+"/documents/delete", body, nil)
+}
+
+// Search runs a query and returns matching IDs in relevance order
+func (m *Meilisearch) Search(ctx context.Context, query models.SearchIndexQuery) (*models.SearchIndexResult, error) {
+	body := map[string]interface{}{
+		"q":                    query.Query,
+		"limit":                query.Limit,
+		"offset":               query.Offset,
+		"attributesToRetrieve": []string{"id"},
+	}
+	if len(query.Filters) > 0 {
+		keys := make([]string, 0, len(query.Filters))
+		for key := range query.Filters {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		filters := make([]string, 0, len(keys))
+		for _, key := range keys {
+			value := strings.ReplaceAll(query.Filters[key], `"`, `\"`)
+			filters = append(filters, fmt.Sprintf(`%s = "%s"`, key, value))
+		}
+		body["filter"] = filters
+	}
+
+	var resp struct {
+		Hits []struct {
+			ID string `json:"id"`
+		} `json:"hits"`
+		EstimatedTotalHits int `json:"estimatedTotalHits"`
+	}
+	if err := m.do(ctx, http.MethodPost, "/indexes/"+m.index(query.Collection)+"/search", body, &resp); err != nil {
+		return nil, err
+	}
+
+	result := &models.SearchIndexResult{IDs: make([]string, 0, len(resp.Hits)), Total: resp.EstimatedTotalHits}
+	for _, hit := range resp.Hits {
+		result.IDs = append(result.IDs, hit.ID)
+	}
+	return result, nil
+}
+
+func (m *Meilisearch) do(ctx context.Context, method, path string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode Meilisearch request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, m.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create Meilisearch request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if m.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("meilisearch request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("meilisearch returned %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("failed to decode Meilisearch response: %w", err)
+		}
+	}
+	return nil
+}
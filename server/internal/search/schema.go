@@ -0,0 +1,133 @@
+package search
+
+import (
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// fieldType is a document field's type, in Typesense's names
+type fieldType string
+
+const (
+	fieldString      fieldType = "string"
+	fieldStringArray fieldType = "string[]"
+	fieldInt64       fieldType = "int64"
+)
+
+// field describes one document field. Searchable fields are listed in
+// ranking order, most important first.
+type field struct {
+	Name       string
+	Type       fieldType
+	Searchable bool
+	Filterable bool
+}
+
+// collection describes one index collection
+type collection struct {
+	Name   models.SearchCollection
+	Fields []field
+}
+
+func (c collection) searchable() []string {
+	var names []string
+	for _, f := range c.Fields {
+		if f.Searchable {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+func (c collection) filterable() []string {
+	var names []string
+	for _, f := range c.Fields {
+		if f.Filterable {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+// indexedAtField records which reindex or event wrote a document, so a
+// reindex can drop whatever it didn't rewrite
+const indexedAtField = "indexedAt"
+
+var catalogCollection = collection{
+	Name: models.SearchCollectionCatalog,
+	Fields: []field{
+		{Name: "brand", Type: fieldString, Searchable: true},
+		{Name: "model", Type: fieldString, Searchable: true},
+		{Name: "variant", Type: fieldString, Searchable: true},
+		{Name: "bestFor", Type: fieldStringArray, Searchable: true},
+		{Name: "description", Type: fieldString, Searchable: true},
+		{Name: "gearType", Type: fieldString, Filterable: true},
+		{Name: "brandKey", Type: fieldString, Filterable: true},
+		{Name: "usageCount", Type: fieldInt64},
+		{Name: indexedAtField, Type: fieldInt64, Filterable: true},
+	},
+}
+
+var buildsCollection = collection{
+	Name: models.SearchCollectionBuilds,
+	Fields: []field{
+		{Name: "title", Type: fieldString, Searchable: true},
+		{Name: "parts", Type: fieldStringArray, Searchable: true},
+		{Name: "pilot", Type: fieldString, Searchable: true},
+		{Name: "description", Type: fieldString, Searchable: true},
+		{Name: "tenantId", Type: fieldString, Filterable: true},
+		{Name: "viewCount", Type: fieldInt64},
+		{Name: indexedAtField, Type: fieldInt64, Filterable: true},
+	},
+}
+
+var collections = []collection{catalogCollection, buildsCollection}
+
+// document is one indexed record; "id" is the catalog item or build ID
+type document map[string]interface{}
+
+func catalogDocument(item models.GearCatalogItem, indexedAt time.Time) document {
+	bestFor := item.BestFor
+	if bestFor == nil {
+		bestFor = []string{}
+	}
+	return document{
+		"id":           item.ID,
+		"brand":        item.Brand,
+		"model":        item.Model,
+		"variant":      item.Variant,
+		"bestFor":      bestFor,
+		"description":  item.Description,
+		"gearType":     string(item.GearType),
+		"brandKey":     strings.ToLower(item.Brand),
+		"usageCount":   item.UsageCount,
+		indexedAtField: indexedAt.UnixMilli(),
+	}
+}
+
+func buildDocument(build models.Build, indexedAt time.Time) document {
+	parts := make([]string, 0, len(build.Parts))
+	for _, part := range build.Parts {
+		if part.CatalogItem == nil {
+			continue
+		}
+		name := strings.TrimSpace(part.CatalogItem.Brand + " " + part.CatalogItem.Model + " " + part.CatalogItem.Variant)
+		parts = append(parts, name)
+	}
+	pilot := ""
+	if build.Pilot != nil {
+		pilot = build.Pilot.CallSign
+	}
+	return document{
+		"id":           build.ID,
+		"title":        build.Title,
+		"parts":        parts,
+		"pilot":        pilot,
+		"description":  build.Description,
+		"tenantId":     build.TenantID,
+		"viewCount":    build.ViewCount,
+		indexedAtField: indexedAt.UnixMilli(),
+	}
+}
@@ -0,0 +1,180 @@
+// Package search mirrors published catalog items and builds into an
+// external search engine (Meilisearch or Typesense) for better relevance
+// and typo tolerance than Postgres full-text search. Documents are written
+// as catalog.published and build.published events come off the domain
+// event outbox, and a periodic reindex picks up edits and drops anything
+// that's been unpublished since. The catalog and build stores query the
+// index through Service.Search and fall back to Postgres when it fails.
+package search
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// reindexPageSize is how many items a reindex loads and writes at a time
+const reindexPageSize = 100
+
+// Backend is a search engine holding the index collections
+type Backend interface {
+	EnsureCollection(ctx context.Context, c collection) error
+	Upsert(ctx context.Context, c models.SearchCollection, docs []document) error
+	DeleteIndexedBefore(ctx context.Context, c models.SearchCollection, indexedAt time.Time) error
+	Search(ctx context.Context, query models.SearchIndexQuery) (*models.SearchIndexResult, error)
+}
+
+// CatalogSource loads published catalog items to index
+type CatalogSource interface {
+	Get(ctx context.Context, id string) (*models.GearCatalogItem, error)
+	Search(ctx context.Context, params models.GearCatalogSearchParams) (*models.GearCatalogSearchResponse, error)
+}
+
+// BuildSource loads published builds to index
+type BuildSource interface {
+	GetPublic(ctx context.Context, id string) (*models.Build, error)
+	ListPublic(ctx context.Context, params models.BuildListParams) (*models.BuildListResponse, error)
+}
+
+// ReindexResult counts the documents a reindex wrote
+type ReindexResult struct {
+	CatalogItems int `json:"catalogItems"`
+	Builds       int `json:"builds"`
+}
+
+// Service keeps the index in step with published content and answers
+// queries from it
+type Service struct {
+	backend Backend
+	catalog CatalogSource
+	builds  BuildSource
+	logger  *logging.Logger
+}
+
+// NewService creates a new search index service
+func NewService(backend Backend, catalog *database.GearCatalogStore, builds *database.BuildStore, logger *logging.Logger) *Service {
+	return &Service{backend: backend, catalog: catalog, builds: builds, logger: logger}
+}
+
+// NewBackend creates the backend named by kind, "meilisearch" or "typesense"
+func NewBackend(kind, baseURL, apiKey, prefix string) (Backend, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("SEARCH_URL is required for the %s backend", kind)
+	}
+	switch kind {
+	case "meilisearch":
+		return NewMeilisearch(baseURL, apiKey, prefix), nil
+	case "typesense":
+		return NewTypesense(baseURL, apiKey, prefix), nil
+	default:
+		return nil, fmt.Errorf("unknown search backend %q", kind)
+	}
+}
+
+// Setup creates the index collections if they don't exist
+func (s *Service) Setup(ctx context.Context) error {
+	for _, c := range collections {
+		if err := s.backend.EnsureCollection(ctx, c); err != nil {
+			return fmt.Errorf("failed to set up %s search collection: %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
+// Search answers a query from the index. Failures are logged here, since
+// the stores calling it fall back to Postgres without reporting them.
+func (s *Service) Search(ctx context.Context, query models.SearchIndexQuery) (*models.SearchIndexResult, error) {
+	result, err := s.backend.Search(ctx, query)
+	if err != nil {
+		s.logger.Warn("Search index query failed, using Postgres", logging.WithFields(map[string]interface{}{
+			"collection": string(query.Collection),
+			"error":      err.Error(),
+		}))
+		return nil, err
+	}
+	return result, nil
+}
+
+// HandleEvent indexes the item or build a published event is about. It's
+// registered as a domain event relay handler; an error leaves the event in
+// the outbox to be retried.
+func (s *Service) HandleEvent(ctx context.Context, event models.DomainEvent) error {
+	now := time.Now()
+	switch event.Type {
+	case models.DomainEventCatalogPublished:
+		item, err := s.catalog.Get(ctx, event.AggregateID)
+		if err != nil {
+			return err
+		}
+		if item == nil || item.Status != models.CatalogStatusPublished {
+			return nil
+		}
+		return s.backend.Upsert(ctx, models.SearchCollectionCatalog, []document{catalogDocument(*item, now)})
+	case models.DomainEventBuildPublished:
+		build, err := s.builds.GetPublic(ctx, event.AggregateID)
+		if err != nil {
+			return err
+		}
+		if build == nil {
+			return nil
+		}
+		return s.backend.Upsert(ctx, models.SearchCollectionBuilds, []document{buildDocument(*build, now)})
+	}
+	return nil
+}
+
+// Reindex rewrites every published catalog item and build, then deletes
+// the documents it didn't rewrite: items and builds unpublished or removed
+// since they were indexed
+func (s *Service) Reindex(ctx context.Context) (*ReindexResult, error) {
+	start := time.Now()
+	result := &ReindexResult{}
+
+	for offset := 0; ; offset += reindexPageSize {
+		page, err := s.catalog.Search(ctx, models.GearCatalogSearchParams{Limit: reindexPageSize, Offset: offset})
+		if err != nil {
+			return result, fmt.Errorf("failed to load catalog items: %w", err)
+		}
+		docs := make([]document, 0, len(page.Items))
+		for _, item := range page.Items {
+			docs = append(docs, catalogDocument(item, start))
+		}
+		if err := s.backend.Upsert(ctx, models.SearchCollectionCatalog, docs); err != nil {
+			return result, fmt.Errorf("failed to index catalog items: %w", err)
+		}
+		result.CatalogItems += len(docs)
+		if len(page.Items) < reindexPageSize {
+			break
+		}
+	}
+	if err := s.backend.DeleteIndexedBefore(ctx, models.SearchCollectionCatalog, start); err != nil {
+		return result, fmt.Errorf("failed to drop stale catalog items: %w", err)
+	}
+
+	for offset := 0; ; offset += reindexPageSize {
+		page, err := s.builds.ListPublic(ctx, models.BuildListParams{Sort: models.BuildSortNewest, Limit: reindexPageSize, Offset: offset})
+		if err != nil {
+			return result, fmt.Errorf("failed to load builds: %w", err)
+		}
+		docs := make([]document, 0, len(page.Builds))
+		for _, build := range page.Builds {
+			docs = append(docs, buildDocument(build, start))
+		}
+		if err := s.backend.Upsert(ctx, models.SearchCollectionBuilds, docs); err != nil {
+			return result, fmt.Errorf("failed to index builds: %w", err)
+		}
+		result.Builds += len(docs)
+		if len(page.Builds) < reindexPageSize {
+			break
+		}
+	}
+	if err := s.backend.DeleteIndexedBefore(ctx, models.SearchCollectionBuilds, start); err != nil {
+		return result, fmt.Errorf("failed to drop stale builds: %w", err)
+	}
+
+	return result, nil
+}
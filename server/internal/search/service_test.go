@@ -0,0 +1,175 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+type upsert struct {
+	collection models.SearchCollection
+	docs       []document
+}
+
+type fakeBackend struct {
+	upserts   []upsert
+	deletes   []models.SearchCollection
+	searchErr error
+}
+
+func (f *fakeBackend) EnsureCollection(ctx context.Context, c collection) error { return nil }
+
+func (f *fakeBackend) Upsert(ctx context.Context, c models.SearchCollection, docs []document) error {
+	f.upserts = append(f.upserts, upsert{collection: c, docs: docs})
+	return nil
+}
+
+func (f *fakeBackend) DeleteIndexedBefore(ctx context.Context, c models.SearchCollection, indexedAt time.Time) error {
+	f.deletes = append(f.deletes, c)
+	return nil
+}
+
+func (f *fakeBackend) Search(ctx context.Context, query models.SearchIndexQuery) (*models.SearchIndexResult, error) {
+	if f.searchErr != nil {
+		return nil, f.searchErr
+	}
+	return &models.SearchIndexResult{IDs: []string{"item-1"}, Total: 1}, nil
+}
+
+type fakeCatalog struct {
+	items map[string]*models.GearCatalogItem
+	all   []models.GearCatalogItem
+}
+
+func (f *fakeCatalog) Get(ctx context.Context, id string) (*models.GearCatalogItem, error) {
+	return f.items[id], nil
+}
+
+func (f *fakeCatalog) Search(ctx context.Context, params models.GearCatalogSearchParams) (*models.GearCatalogSearchResponse, error) {
+	end := min(params.Offset+params.Limit, len(f.all))
+	start := min(params.Offset, end)
+	return &models.GearCatalogSearchResponse{Items: f.all[start:end], TotalCount: len(f.all)}, nil
+}
+
+type fakeBuilds struct {
+	builds map[string]*models.Build
+	all    []models.Build
+}
+
+func (f *fakeBuilds) GetPublic(ctx context.Context, id string) (*models.Build, error) {
+	return f.builds[id], nil
+}
+
+func (f *fakeBuilds) ListPublic(ctx context.Context, params models.BuildListParams) (*models.BuildListResponse, error) {
+	end := min(params.Offset+params.Limit, len(f.all))
+	start := min(params.Offset, end)
+	return &models.BuildListResponse{Builds: f.all[start:end], TotalCount: len(f.all)}, nil
+}
+
+func newTestService(backend Backend, catalog CatalogSource, builds BuildSource) *Service {
+	return &Service{backend: backend, catalog: catalog, builds: builds, logger: testutil.NullLogger()}
+}
+
+func TestHandleEvent(t *testing.T) {
+	catalog := &fakeCatalog{items: map[string]*models.GearCatalogItem{
+		"item-1": {ID: "item-1", GearType: models.GearTypeMotor, Brand: "T-Motor", Model: "F60 Pro", Status: models.CatalogStatusPublished},
+		"item-2": {ID: "item-2", Brand: "Pending", Status: models.CatalogStatusPending},
+	}}
+	builds := &fakeBuilds{builds: map[string]*models.Build{
+		"build-1": {
+			ID:       "build-1",
+			TenantID: "tenant-1",
+			Title:    "5in freestyle",
+			Pilot:    &models.BuildPilot{CallSign: "Ripper"},
+			Parts:    []models.BuildPart{{CatalogItem: &models.BuildCatalogItem{Brand: "T-Motor", Model: "F60 Pro"}}},
+		},
+	}}
+
+	tests := []struct {
+		name           string
+		event          models.DomainEvent
+		wantCollection models.SearchCollection // empty when nothing is indexed
+		want           document                // fields the document must have
+	}{
+		{
+			name:           "published catalog item",
+			event:          models.DomainEvent{Type: models.DomainEventCatalogPublished, AggregateID: "item-1"},
+			wantCollection: models.SearchCollectionCatalog,
+			want:           document{"id": "item-1", "brandKey": "t-motor"},
+		},
+		{
+			name:  "catalog item no longer published",
+			event: models.DomainEvent{Type: models.DomainEventCatalogPublished, AggregateID: "item-2"},
+		},
+		{
+			name:           "build with parts",
+			event:          models.DomainEvent{Type: models.DomainEventBuildPublished, AggregateID: "build-1"},
+			wantCollection: models.SearchCollectionBuilds,
+			want:           document{"id": "build-1", "pilot": "Ripper", "tenantId": "tenant-1", "parts": []string{"T-Motor F60 Pro"}},
+		},
+		{
+			name:  "unrelated event",
+			event: models.DomainEvent{Type: models.DomainEventUserRegistered, AggregateID: "user-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &fakeBackend{}
+			svc := newTestService(backend, catalog, builds)
+
+			if err := svc.HandleEvent(context.Background(), tt.event); err != nil {
+				t.Fatalf("HandleEvent: %v", err)
+			}
+			if tt.wantCollection == "" {
+				if len(backend.upserts) != 0 {
+					t.Fatalf("expected no upsert, got %+v", backend.upserts)
+				}
+				return
+			}
+			if len(backend.upserts) != 1 || backend.upserts[0].collection != tt.wantCollection {
+				t.Fatalf("upserts=%+v", backend.upserts)
+			}
+			doc := backend.upserts[0].docs[0]
+			for key, want := range tt.want {
+				if !reflect.DeepEqual(doc[key], want) {
+					t.Errorf("doc[%q]=%#v, want %#v", key, doc[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestReindexPagesAndDropsStaleDocuments(t *testing.T) {
+	backend := &fakeBackend{}
+	catalog := &fakeCatalog{}
+	for i := 0; i < reindexPageSize+1; i++ {
+		catalog.all = append(catalog.all, models.GearCatalogItem{ID: "item", Status: models.CatalogStatusPublished})
+	}
+	builds := &fakeBuilds{all: []models.Build{{ID: "build-1"}}}
+	svc := newTestService(backend, catalog, builds)
+
+	result, err := svc.Reindex(context.Background())
+	if err != nil {
+		t.Fatalf("Reindex: %v", err)
+	}
+	if result.CatalogItems != reindexPageSize+1 || result.Builds != 1 {
+		t.Fatalf("result=%+v", result)
+	}
+	if len(backend.deletes) != 2 || backend.deletes[0] != models.SearchCollectionCatalog || backend.deletes[1] != models.SearchCollectionBuilds {
+		t.Fatalf("deletes=%v", backend.deletes)
+	}
+}
+
+func TestSearchReturnsBackendError(t *testing.T) {
+	svc := newTestService(&fakeBackend{searchErr: errors.New("connection refused")}, &fakeCatalog{}, &fakeBuilds{})
+
+	if _, err := svc.Search(context.Background(), models.SearchIndexQuery{Collection: models.SearchCollectionCatalog, Query: "motor"}); err == nil {
+		t.Fatal("expected error so the store falls back to Postgres")
+	}
+}
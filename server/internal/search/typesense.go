@@ -0,0 +1,241 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// Typesense is a Backend for a Typesense server
+type Typesense struct {
+	baseURL string
+	apiKey  string
+	prefix  string
+	client  *http.Client
+}
+
+// NewTypesense creates a backend for the server at baseURL. Collection
+// names are prefix followed by the collection name.
+func NewTypesense(baseURL, apiKey, prefix string) *Typesense {
+	return &Typesense{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		prefix:  prefix,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (t *Typesense) collectionPath(c models.SearchCollection) string {
+	return "/collections/" + url.PathEscape(t.prefix+string(c))
+}
+
+// EnsureCollection creates the collection if it doesn't exist. An existing
+// collection gets any fields it's missing; fields it already has are left
+// as is.
+func (t *Typesense) EnsureCollection(ctx context.Context, c collection) error {
+	schema := map[string]interface{}{"name": t.prefix + string(c.Name), "fields": typesenseFields(c.Fields)}
+
+	status, body, err := t.do(ctx, http.MethodPost, "/collections", "application/json", jsonBody(schema))
+	if err != nil {
+		return err
+	}
+	if status == http.StatusConflict {
+		return t.addMissingFields(ctx, c)
+	}
+	return checkStatus(status, body)
+}
+
+// addMissingFields patches fields added to c since its collection was
+// created into the existing schema. Documents indexed before then lack
+// the fields until the next reindex rewrites them.
+func (t *Typesense) addMissingFields(ctx context.Context, c collection) error {
+	status, body, err := t.do(ctx, http.MethodGet, t.collectionPath(c.Name), "", nil)
+	if err != nil {
+		return err
+	}
+	if err := checkStatus(status, body); err != nil {
+		return err
+	}
+	var existing struct {
+		Fields []struct {
+			Name string `json:"name"`
+		} `json:"fields"`
+	}
+	if err := json.Unmarshal(body, &existing); err != nil {
+		return fmt.Errorf("failed to decode Typesense collection: %w", err)
+	}
+	have := make(map[string]bool, len(existing.Fields))
+	for _, f := range existing.Fields {
+		have[f.Name] = true
+	}
+	var missing []field
+	for _, f := range c.Fields {
+		if !have[f.Name] {
+			missing = append(missing, f)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	patch := map[string]interface{}{"fields": typesenseFields(missing)}
+	status, body, err = t.do(ctx, http.MethodPatch, t.collectionPath(c.Name), "application/json", jsonBody(patch))
+	if err != nil {
+		return err
+	}
+	return checkStatus(status, body)
+}
+
+func typesenseFields(fields []field) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(fields))
+	for _, f := range fields {
+		out = append(out, map[string]interface{}{
+			"name":     f.Name,
+			"type":     string(f.Type),
+			"facet":    f.Filterable,
+			"optional": true,
+		})
+	}
+	return out
+}
+
+// Upsert adds or replaces documents through the JSONL import endpoint
+func (t *Typesense) Upsert(ctx context.Context, c models.SearchCollection, docs []document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	for _, doc := range docs {
+		line, err := json.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("failed to encode Typesense document: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	status, body, err := t.do(ctx, http.MethodPost, t.collectionPath(c)+"/documents/import?action=upsert", "text/plain", buf.Bytes())
+	if err != nil {
+		return err
+	}
+	if err := checkStatus(status, body); err != nil {
+		return err
+	}
+	// The import answers 200 with one result line per document
+	for _, line := range bytes.Split(bytes.TrimSpace(body), []byte("\n")) {
+		var result struct {
+			Success bool   `json:"success"`
+			Error   string `json:"error"`
+		}
+		if err := json.Unmarshal(line, &result); err == nil && !result.Success {
+			return fmt.Errorf("typesense rejected document: %s", result.Error)
+		}
+	}
+	return nil
+}
+
+// DeleteIndexedBefore deletes documents last written before indexedAt
+func (t *Typesense) DeleteIndexedBefore(ctx context.Context, c models.SearchCollection, indexedAt time.Time) error {
+	filter := url.QueryEscape(fmt.Sprintf("%s:<%d", indexedAtField, indexedAt.UnixMilli()))
+	status, body, err := t.do(ctx, http.MethodDelete, t.collectionPath(c)+"/documents?filter_by="+filter, "", nil)
+	if err != nil {
+		return err
+	}
+	return checkStatus(status, body)
+}
+
+// Search runs a query and returns matching IDs in relevance order
+func (t *Typesense) Search(ctx context.Context, query models.SearchIndexQuery) (*models.SearchIndexResult, error) {
+	c := catalogCollection
+	if query.Collection == models.SearchCollectionBuilds {
+		c = buildsCollection
+	}
+
+	params := url.Values{}
+	params.Set("q", query.Query)
+	params.Set("query_by", strings.Join(c.searchable(), ","))
+	params.Set("limit", strconv.Itoa(query.Limit))
+	params.Set("offset", strconv.Itoa(query.Offset))
+	params.Set("include_fields", "id")
+	if len(query.Filters) > 0 {
+		keys := make([]string, 0, len(query.Filters))
+		for key := range query.Filters {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		filters := make([]string, 0, len(keys))
+		for _, key := range keys {
+			value := strings.ReplaceAll(query.Filters[key], "`", "")
+			filters = append(filters, fmt.Sprintf("%s:=`%s`", key, value))
+		}
+		params.Set("filter_by", strings.Join(filters, " && "))
+	}
+
+	status, body, err := t.do(ctx, http.MethodGet, t.collectionPath(query.Collection)+"/documents/search?"+params.Encode(), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStatus(status, body); err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Found int `json:"found"`
+		Hits  []struct {
+			Document struct {
+				ID string `json:"id"`
+			} `json:"document"`
+		} `json:"hits"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode Typesense response: %w", err)
+	}
+
+	result := &models.SearchIndexResult{IDs: make([]string, 0, len(resp.Hits)), Total: resp.Found}
+	for _, hit := range resp.Hits {
+		result.IDs = append(result.IDs, hit.Document.ID)
+	}
+	return result, nil
+}
+
+func (t *Typesense) do(ctx context.Context, method, path, contentType string, body []byte) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, t.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create Typesense request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("X-TYPESENSE-API-KEY", t.apiKey)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("typesense request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	return resp.StatusCode, respBody, nil
+}
+
+func jsonBody(v interface{}) []byte {
+	data, _ := json.Marshal(v)
+	return data
+}
+
+func checkStatus(status int, body []byte) error {
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("typesense returned %d: %s", status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
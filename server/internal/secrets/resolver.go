@@ -0,0 +1,167 @@
+// Package secrets resolves secret references in configuration, so database
+// credentials and signing keys can live in files, Vault or AWS Secrets
+// Manager instead of plaintext environment variables.
+//
+// A reference is one of:
+//
+//	file:/run/secrets/db_password
+//	vault:secret/data/flyingforge#db_password
+//	awssm:flyingforge/prod#db_password
+//
+// The part after # picks a field of a JSON secret. Any other value is used as
+// it is.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/johnrirwin/flyingforge/internal/config"
+)
+
+const (
+	schemeFile  = "file:"
+	schemeVault = "vault:"
+	schemeAWS   = "awssm:"
+)
+
+// Resolver reads secret references
+type Resolver struct {
+	cfg    config.SecretsConfig
+	client *http.Client
+
+	// AWS credentials are loaded on first use so deployments without
+	// Secrets Manager references never touch the AWS credential chain
+	awsOnce     sync.Once
+	awsCreds    aws.CredentialsProvider
+	awsErr      error
+	awsEndpoint string // overrides the regional endpoint in tests
+}
+
+// NewResolver creates a resolver for the configured secret stores
+func NewResolver(cfg config.SecretsConfig) *Resolver {
+	return &Resolver{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// IsReference reports whether value points at a secret store rather than
+// being the secret itself
+func IsReference(value string) bool {
+	for _, scheme := range []string{schemeFile, schemeVault, schemeAWS} {
+		if strings.HasPrefix(value, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve returns the secret a reference points at, or value itself when it
+// isn't a reference
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, schemeFile):
+		return readFile(strings.TrimPrefix(strings.TrimPrefix(value, schemeFile), "//"))
+	case strings.HasPrefix(value, schemeVault):
+		path, field := splitField(strings.TrimPrefix(value, schemeVault))
+		return r.readVault(ctx, path, field)
+	case strings.HasPrefix(value, schemeAWS):
+		id, field := splitField(strings.TrimPrefix(value, schemeAWS))
+		return r.readAWS(ctx, id, field)
+	default:
+		return value, nil
+	}
+}
+
+// ResolveConfig replaces references in the secret settings of cfg with the
+// secrets they point at. AWS keys set as references in the environment are
+// resolved into the environment, where the AWS SDK reads them; they can't
+// come from Secrets Manager, which needs them first.
+func (r *Resolver) ResolveConfig(ctx context.Context, cfg *config.Config) error {
+	fields := []struct {
+		name  string
+		value *string
+	}{
+		{"DB_USER", &cfg.Database.User},
+		{"DB_PASSWORD", &cfg.Database.Password},
+		{"AUTH_JWT_SECRET", &cfg.Auth.JWTSecret},
+		{"GOOGLE_CLIENT_SECRET", &cfg.Auth.GoogleClientSecret},
+		{"TEMP_BUILD_CAPTCHA_SECRET", &cfg.Builds.CaptchaSecret},
+	}
+	for _, field := range fields {
+		if !IsReference(*field.value) {
+			continue
+		}
+		secret, err := r.Resolve(ctx, *field.value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", field.name, err)
+		}
+		*field.value = secret
+	}
+
+	for _, name := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN"} {
+		value := os.Getenv(name)
+		if !IsReference(value) {
+			continue
+		}
+		if strings.HasPrefix(value, schemeAWS) {
+			return fmt.Errorf("%s: AWS credentials can't be read from Secrets Manager", name)
+		}
+		secret, err := r.Resolve(ctx, value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if err := os.Setenv(name, secret); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func readFile(path string) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("secret file path is empty")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// splitField splits "path#field" into its parts
+func splitField(ref string) (string, string) {
+	if i := strings.LastIndex(ref, "#"); i >= 0 {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, ""
+}
+
+// pickField returns one field of a secret made of several
+func pickField(fields map[string]interface{}, field string) (string, error) {
+	if field == "" {
+		if len(fields) != 1 {
+			return "", fmt.Errorf("secret has %d fields; name one with #field", len(fields))
+		}
+		for name := range fields {
+			field = name
+		}
+	}
+	value, ok := fields[field]
+	if !ok {
+		return "", fmt.Errorf("secret has no field %q", field)
+	}
+	text, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("secret field %q is not a string", field)
+	}
+	return text, nil
+}
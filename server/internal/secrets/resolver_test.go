@@ -0,0 +1,176 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/johnrirwin/flyingforge/internal/config"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+func TestResolve_PlainAndFile(t *testing.T) {
+	r := NewResolver(config.SecretsConfig{})
+	ctx := context.Background()
+
+	if got, err := r.Resolve(ctx, "plain-password"); err != nil || got != "plain-password" {
+		t.Errorf("plain value = %q, %v", got, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "db_password")
+	if err := os.WriteFile(path, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, ref := range []string{"file:" + path, "file://" + path} {
+		if got, err := r.Resolve(ctx, ref); err != nil || got != "s3cret" {
+			t.Errorf("Resolve(%s) = %q, %v", ref, got, err)
+		}
+	}
+	if _, err := r.Resolve(ctx, "file:"+path+".missing"); err == nil {
+		t.Error("missing file should fail")
+	}
+}
+
+func TestResolve_Vault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/flyingforge":
+			_, _ = w.Write([]byte(`{"data": {"data": {"db_password": "kv2", "jwt": "j"}, "metadata": {"version": 3}}}`))
+		case "/v1/kv/flyingforge":
+			_, _ = w.Write([]byte(`{"data": {"db_password": "kv1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	r := NewResolver(config.SecretsConfig{VaultAddr: server.URL, VaultToken: "root"})
+	ctx := context.Background()
+
+	if got, err := r.Resolve(ctx, "vault:secret/data/flyingforge#db_password"); err != nil || got != "kv2" {
+		t.Errorf("kv2 = %q, %v", got, err)
+	}
+	// A secret with one field needs no field name
+	if got, err := r.Resolve(ctx, "vault:kv/flyingforge"); err != nil || got != "kv1" {
+		t.Errorf("kv1 = %q, %v", got, err)
+	}
+	if _, err := r.Resolve(ctx, "vault:secret/data/flyingforge"); err == nil || !strings.Contains(err.Error(), "#field") {
+		t.Errorf("ambiguous field error = %v", err)
+	}
+	if _, err := r.Resolve(ctx, "vault:secret/data/missing#x"); err == nil {
+		t.Error("missing secret should fail")
+	}
+
+	r = NewResolver(config.SecretsConfig{VaultAddr: server.URL, VaultToken: "wrong"})
+	if _, err := r.Resolve(ctx, "vault:kv/flyingforge"); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("bad token error = %v", err)
+	}
+}
+
+func TestResolve_AWSSecretsManager(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" || !strings.Contains(r.Header.Get("Authorization"), "Credential=AKIDTEST/") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var body struct{ SecretId string }
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch body.SecretId {
+		case "flyingforge/prod":
+			_, _ = w.Write([]byte(`{"SecretString": "{\"db_password\": \"from-aws\"}"}`))
+		case "flyingforge/jwt":
+			_, _ = w.Write([]byte(`{"SecretString": "raw-jwt"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	r := NewResolver(config.SecretsConfig{AWSRegion: "us-east-1"})
+	r.awsEndpoint = server.URL
+	r.awsCreds = aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+		return aws.Credentials{AccessKeyID: "AKIDTEST", SecretAccessKey: "secret"}, nil
+	})
+	ctx := context.Background()
+
+	if got, err := r.Resolve(ctx, "awssm:flyingforge/prod#db_password"); err != nil || got != "from-aws" {
+		t.Errorf("field = %q, %v", got, err)
+	}
+	if got, err := r.Resolve(ctx, "awssm:flyingforge/jwt"); err != nil || got != "raw-jwt" {
+		t.Errorf("whole secret = %q, %v", got, err)
+	}
+	if _, err := r.Resolve(ctx, "awssm:flyingforge/jwt#field"); err == nil {
+		t.Error("field of a non-JSON secret should fail")
+	}
+}
+
+func TestResolveConfig(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, value string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(value), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return "file:" + path
+	}
+
+	cfg := &config.Config{}
+	cfg.Database.User = "postgres"
+	cfg.Database.Password = write("db", "db-pass")
+	cfg.Auth.JWTSecret = write("jwt", "jwt-secret")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", write("aws", "aws-secret"))
+
+	r := NewResolver(config.SecretsConfig{})
+	if err := r.ResolveConfig(context.Background(), cfg); err != nil {
+		t.Fatalf("ResolveConfig() error = %v", err)
+	}
+	if cfg.Database.User != "postgres" || cfg.Database.Password != "db-pass" || cfg.Auth.JWTSecret != "jwt-secret" {
+		t.Errorf("config = %+v / %+v", cfg.Database, cfg.Auth)
+	}
+	if got := os.Getenv("AWS_SECRET_ACCESS_KEY"); got != "aws-secret" {
+		t.Errorf("AWS_SECRET_ACCESS_KEY = %q", got)
+	}
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "awssm:keys#id")
+	if err := r.ResolveConfig(context.Background(), cfg); err == nil {
+		t.Error("AWS keys from Secrets Manager should fail")
+	}
+}
+
+func TestWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwt")
+	if err := os.WriteFile(path, []byte("one"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan string, 1)
+	go NewResolver(config.SecretsConfig{}).Watch(ctx, "file:"+path, "one", 10*time.Millisecond, func(secret string) {
+		changes <- secret
+	}, testutil.NullLogger())
+
+	if err := os.WriteFile(path, []byte("two"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-changes:
+		if got != "two" {
+			t.Errorf("changed secret = %q", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("watch did not report the new secret")
+	}
+}
@@ -0,0 +1,65 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// vaultResponse covers both KV engines: version 2 nests the secret in
+// data.data, version 1 returns it in data
+type vaultResponse struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+// readVault reads a field of a Vault KV secret. Paths of version 2 engines
+// include "data/", such as secret/data/flyingforge.
+func (r *Resolver) readVault(ctx context.Context, path, field string) (string, error) {
+	if r.cfg.VaultAddr == "" {
+		return "", fmt.Errorf("VAULT_ADDR is not set")
+	}
+	token := r.cfg.VaultToken
+	if strings.HasPrefix(token, schemeFile) {
+		var err error
+		if token, err = readFile(strings.TrimPrefix(strings.TrimPrefix(token, schemeFile), "//")); err != nil {
+			return "", fmt.Errorf("failed to read vault token: %w", err)
+		}
+	}
+	if token == "" {
+		return "", fmt.Errorf("VAULT_TOKEN is not set")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.cfg.VaultAddr+"/v1/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if r.cfg.VaultNamespace != "" {
+		req.Header.Set("X-Vault-Namespace", r.cfg.VaultNamespace)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read vault secret: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return "", fmt.Errorf("vault returned %d for %s", resp.StatusCode, path)
+	}
+
+	var body vaultResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode vault secret: %w", err)
+	}
+	fields := body.Data
+	if nested, ok := fields["data"].(map[string]interface{}); ok {
+		if _, versioned := fields["metadata"]; versioned {
+			fields = nested
+		}
+	}
+	return pickField(fields, field)
+}
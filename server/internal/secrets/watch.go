@@ -0,0 +1,40 @@
+package secrets
+
+import (
+	"context"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/logging"
+)
+
+// Watch re-reads ref every interval and calls onChange when the secret
+// differs from the last one seen, starting from current. A failed read keeps
+// the last secret. Watch returns when ctx is done.
+func (r *Resolver) Watch(ctx context.Context, ref, current string, interval time.Duration, onChange func(string), logger *logging.Logger) {
+	if !IsReference(ref) || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		readCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		secret, err := r.Resolve(readCtx, ref)
+		cancel()
+		if err != nil {
+			logger.Warn("Failed to re-read secret", logging.WithField("error", err.Error()))
+			continue
+		}
+		if secret == "" || secret == current {
+			continue
+		}
+		current = secret
+		onChange(secret)
+	}
+}
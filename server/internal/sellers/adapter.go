@@ -2,6 +2,7 @@ package sellers
 
 import (
 	"context"
+	"time"
 
 	"github.com/johnrirwin/flyingforge/internal/models"
 )
@@ -33,18 +34,24 @@ type Adapter interface {
 // Registry manages seller adapters
 type Registry struct {
 	adapters map[string]Adapter
+	now      func() time.Time
 }
 
 // NewRegistry creates a new seller registry
 func NewRegistry() *Registry {
 	return &Registry{
 		adapters: make(map[string]Adapter),
+		now:      time.Now,
 	}
 }
 
-// Register adds a seller adapter to the registry
+// Register adds a seller adapter to the registry. Calls through the registry
+// are tracked for health and skipped while the seller's circuit is open.
 func (r *Registry) Register(adapter Adapter) {
-	r.adapters[adapter.ID()] = adapter
+	r.adapters[adapter.ID()] = &trackedAdapter{
+		Adapter: adapter,
+		health:  &adapterHealth{now: r.now},
+	}
 }
 
 // Get returns a seller adapter by ID
@@ -0,0 +1,190 @@
+package sellers
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ErrCircuitOpen is returned instead of calling a seller whose circuit
+// breaker is open
+var ErrCircuitOpen = errors.New("seller temporarily unavailable")
+
+const (
+	// failureThreshold is how many consecutive failures open the circuit.
+	failureThreshold = 5
+	// openCooldown is how long a seller is skipped before a trial request.
+	openCooldown = 30 * time.Second
+	// latencySmoothing weights the newest sample in the moving average.
+	latencySmoothing = 0.2
+)
+
+// adapterHealth tracks one seller's request outcomes and circuit breaker
+type adapterHealth struct {
+	mu  sync.Mutex
+	now func() time.Time
+
+	consecutiveFailures int
+	totalRequests       int64
+	totalFailures       int64
+	skippedRequests     int64
+	avgLatencyMs        float64
+	lastSuccess         time.Time
+	lastFailure         time.Time
+	lastError           string
+	openUntil           time.Time
+	probing             bool
+}
+
+// allow reports whether a request may be sent. Once the cooldown of an open
+// circuit has passed, a single trial request is let through; its result
+// closes the circuit or opens it again.
+func (h *adapterHealth) allow() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.consecutiveFailures < failureThreshold {
+		return true
+	}
+	if h.probing || h.now().Before(h.openUntil) {
+		h.skippedRequests++
+		return false
+	}
+	h.probing = true
+	return true
+}
+
+// record stores the outcome of a request that started at the given time.
+// Requests cancelled by the caller say nothing about the seller and are
+// not counted.
+func (h *adapterHealth) record(started time.Time, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.probing = false
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	now := h.now()
+	latencyMs := float64(now.Sub(started)) / float64(time.Millisecond)
+	h.totalRequests++
+	if h.totalRequests == 1 {
+		h.avgLatencyMs = latencyMs
+	} else {
+		h.avgLatencyMs += latencySmoothing * (latencyMs - h.avgLatencyMs)
+	}
+
+	if err == nil {
+		h.consecutiveFailures = 0
+		h.lastSuccess = now
+		return
+	}
+
+	h.totalFailures++
+	h.consecutiveFailures++
+	h.lastFailure = now
+	h.lastError = err.Error()
+	if h.consecutiveFailures >= failureThreshold {
+		h.openUntil = now.Add(openCooldown)
+	}
+}
+
+func (h *adapterHealth) snapshot(id, name string) models.SellerHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	health := models.SellerHealth{
+		ID:                  id,
+		Name:                name,
+		State:               models.SellerCircuitClosed,
+		ConsecutiveFailures: h.consecutiveFailures,
+		TotalRequests:       h.totalRequests,
+		TotalFailures:       h.totalFailures,
+		SkippedRequests:     h.skippedRequests,
+		AvgLatencyMs:        h.avgLatencyMs,
+		LastError:           h.lastError,
+	}
+	if h.consecutiveFailures >= failureThreshold {
+		health.State = models.SellerCircuitHalfOpen
+		if h.now().Before(h.openUntil) {
+			health.State = models.SellerCircuitOpen
+			openUntil := h.openUntil
+			health.OpenUntil = &openUntil
+		}
+	}
+	if !h.lastSuccess.IsZero() {
+		lastSuccess := h.lastSuccess
+		health.LastSuccessAt = &lastSuccess
+	}
+	if !h.lastFailure.IsZero() {
+		lastFailure := h.lastFailure
+		health.LastFailureAt = &lastFailure
+	}
+	return health
+}
+
+// trackedAdapter records the health of every call to the wrapped adapter and
+// skips calls while its circuit is open
+type trackedAdapter struct {
+	Adapter
+	health *adapterHealth
+}
+
+func (t *trackedAdapter) Search(ctx context.Context, query string, category models.EquipmentCategory, limit int) ([]models.EquipmentItem, error) {
+	if !t.health.allow() {
+		return nil, ErrCircuitOpen
+	}
+	started := t.health.now()
+	items, err := t.Adapter.Search(ctx, query, category, limit)
+	t.health.record(started, err)
+	return items, err
+}
+
+func (t *trackedAdapter) GetByCategory(ctx context.Context, category models.EquipmentCategory, limit, offset int) ([]models.EquipmentItem, error) {
+	if !t.health.allow() {
+		return nil, ErrCircuitOpen
+	}
+	started := t.health.now()
+	items, err := t.Adapter.GetByCategory(ctx, category, limit, offset)
+	t.health.record(started, err)
+	return items, err
+}
+
+func (t *trackedAdapter) GetProduct(ctx context.Context, productID string) (*models.EquipmentItem, error) {
+	if !t.health.allow() {
+		return nil, ErrCircuitOpen
+	}
+	started := t.health.now()
+	item, err := t.Adapter.GetProduct(ctx, productID)
+	t.health.record(started, err)
+	return item, err
+}
+
+func (t *trackedAdapter) SyncProducts(ctx context.Context) error {
+	if !t.health.allow() {
+		return ErrCircuitOpen
+	}
+	started := t.health.now()
+	err := t.Adapter.SyncProducts(ctx)
+	t.health.record(started, err)
+	return err
+}
+
+// Health returns the health of every registered seller, ordered by ID
+func (r *Registry) Health() []models.SellerHealth {
+	result := make([]models.SellerHealth, 0, len(r.adapters))
+	for id, adapter := range r.adapters {
+		if tracked, ok := adapter.(*trackedAdapter); ok {
+			result = append(result, tracked.health.snapshot(id, adapter.Name()))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
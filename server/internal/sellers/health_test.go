@@ -0,0 +1,114 @@
+package sellers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// flakyAdapter fails every search while fail is set
+type flakyAdapter struct {
+	mockAdapter
+	fail  bool
+	calls int
+}
+
+func (f *flakyAdapter) Search(ctx context.Context, query string, category models.EquipmentCategory, limit int) ([]models.EquipmentItem, error) {
+	f.calls++
+	if f.fail {
+		return nil, errors.New("upstream 503")
+	}
+	return []models.EquipmentItem{{ID: "item-1"}}, nil
+}
+
+func newTestRegistry(now *time.Time) *Registry {
+	registry := NewRegistry()
+	registry.now = func() time.Time { return *now }
+	return registry
+}
+
+func TestRegistry_CircuitBreaker(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	registry := newTestRegistry(&now)
+	flaky := &flakyAdapter{mockAdapter: mockAdapter{id: "rdq", name: "RaceDayQuads"}, fail: true}
+	registry.Register(flaky)
+	adapter := registry.Get("rdq")
+
+	for i := 0; i < failureThreshold; i++ {
+		if _, err := adapter.Search(context.Background(), "motor", "", 10); err == nil {
+			t.Fatal("Search() error = nil, want upstream failure")
+		}
+	}
+
+	// The circuit is open: calls are skipped without reaching the seller
+	if _, err := adapter.Search(context.Background(), "motor", "", 10); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Search() with open circuit error = %v, want ErrCircuitOpen", err)
+	}
+	if flaky.calls != failureThreshold {
+		t.Errorf("seller called %d times, want %d", flaky.calls, failureThreshold)
+	}
+
+	health := registry.Health()[0]
+	if health.State != models.SellerCircuitOpen || health.SkippedRequests != 1 || health.OpenUntil == nil {
+		t.Errorf("health = %+v, want open with 1 skipped request", health)
+	}
+
+	// After the cooldown a trial request goes through and closes the circuit
+	now = now.Add(openCooldown)
+	if state := registry.Health()[0].State; state != models.SellerCircuitHalfOpen {
+		t.Errorf("state after cooldown = %q, want half_open", state)
+	}
+	flaky.fail = false
+	if _, err := adapter.Search(context.Background(), "motor", "", 10); err != nil {
+		t.Fatalf("trial Search() error = %v", err)
+	}
+
+	health = registry.Health()[0]
+	if health.State != models.SellerCircuitClosed || health.ConsecutiveFailures != 0 || health.LastSuccessAt == nil {
+		t.Errorf("health after recovery = %+v, want closed", health)
+	}
+	if health.TotalRequests != failureThreshold+1 || health.TotalFailures != failureThreshold {
+		t.Errorf("totals = %d requests / %d failures, want %d / %d", health.TotalRequests, health.TotalFailures, failureThreshold+1, failureThreshold)
+	}
+}
+
+func TestRegistry_FailedTrialReopensCircuit(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	registry := newTestRegistry(&now)
+	flaky := &flakyAdapter{mockAdapter: mockAdapter{id: "rdq"}, fail: true}
+	registry.Register(flaky)
+	adapter := registry.Get("rdq")
+
+	for i := 0; i < failureThreshold; i++ {
+		_, _ = adapter.Search(context.Background(), "", "", 10)
+	}
+	now = now.Add(openCooldown)
+	if _, err := adapter.Search(context.Background(), "", "", 10); errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("trial request was skipped")
+	}
+	if _, err := adapter.Search(context.Background(), "", "", 10); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Search() after failed trial error = %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestAdapterHealth_IgnoresCallerCancellation(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	health := &adapterHealth{now: func() time.Time { return now }}
+
+	for i := 0; i < failureThreshold; i++ {
+		health.record(now, context.Canceled)
+	}
+	health.record(now.Add(-100*time.Millisecond), nil)
+	health.record(now.Add(-200*time.Millisecond), nil)
+
+	snapshot := health.snapshot("rdq", "RaceDayQuads")
+	if snapshot.TotalRequests != 2 || snapshot.State != models.SellerCircuitClosed {
+		t.Errorf("snapshot = %+v, want 2 counted requests and closed circuit", snapshot)
+	}
+	if want := 100 + latencySmoothing*100; snapshot.AvgLatencyMs != want {
+		t.Errorf("AvgLatencyMs = %v, want %v", snapshot.AvgLatencyMs, want)
+	}
+}
@@ -0,0 +1,296 @@
+package seo
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// maxURLsPerSitemap is the sitemaps.org limit for a single sitemap file
+const maxURLsPerSitemap = 50000
+
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// Public page paths on the web frontend
+const (
+	buildPathFormat = "/builds/%s"
+	gearPathFormat  = "/gear-catalog/%s"
+	pilotPathFormat = "/social/pilots/%s"
+)
+
+// staticPaths are public landing pages always listed in the sitemap
+var staticPaths = []string{"/", "/builds", "/gear-catalog"}
+
+// Store lists the public content that belongs in the sitemap
+type Store interface {
+	PublishedBuilds(ctx context.Context) ([]models.SitemapEntry, error)
+	PublishedCatalogItems(ctx context.Context) ([]models.SitemapEntry, error)
+	PublicPilots(ctx context.Context) ([]models.SitemapEntry, error)
+}
+
+// Service generates the sitemap and schema.org structured data for public pages
+type Service struct {
+	store    Store
+	siteURL  string
+	pageSize int
+	logger   *logging.Logger
+
+	mu       sync.RWMutex
+	sitemaps map[sitemapKey]*sitemap
+}
+
+// sitemapKey identifies a tenant's sitemap for one public origin. Tenants
+// list only their own content, under the origin they are served on.
+type sitemapKey struct {
+	tenantID string
+	siteURL  string
+}
+
+// sitemap is a generated sitemap for one key
+type sitemap struct {
+	index       []byte   // sitemap index when the sitemap spans several files
+	pages       [][]byte // urlset documents
+	generatedAt time.Time
+}
+
+// NewService creates a new SEO service. siteURL is the public web origin that
+// sitemap and structured data links point at.
+func NewService(store *database.SitemapStore, siteURL string, logger *logging.Logger) *Service {
+	return &Service{
+		store:    store,
+		siteURL:  strings.TrimRight(strings.TrimSpace(siteURL), "/"),
+		pageSize: maxURLsPerSitemap,
+		logger:   logger,
+		sitemaps: make(map[sitemapKey]*sitemap),
+	}
+}
+
+type urlSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	Xmlns    string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapURL `xml:"sitemap"`
+}
+
+// Regenerate rebuilds the default tenant's sitemap and every tenant sitemap
+// served since startup from the current published content. A sitemap keeps
+// being served as it was if its generation fails.
+func (s *Service) Regenerate(ctx context.Context) error {
+	s.mu.RLock()
+	keys := []sitemapKey{{tenantID: database.DefaultTenantID, siteURL: s.siteURL}}
+	for key := range s.sitemaps {
+		if key != keys[0] {
+			keys = append(keys, key)
+		}
+	}
+	s.mu.RUnlock()
+
+	var firstErr error
+	for _, key := range keys {
+		if _, err := s.generate(ctx, key); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// generate builds and caches the sitemap for key, listing only the content
+// of key's tenant
+func (s *Service) generate(ctx context.Context, key sitemapKey) (*sitemap, error) {
+	ctx = database.WithTenant(ctx, key.tenantID)
+	builds, err := s.store.PublishedBuilds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gear, err := s.store.PublishedCatalogItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pilots, err := s.store.PublicPilots(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]sitemapURL, 0, len(staticPaths)+len(builds)+len(gear)+len(pilots))
+	for _, path := range staticPaths {
+		urls = append(urls, sitemapURL{Loc: key.siteURL + path})
+	}
+	urls = appendEntries(urls, key.siteURL, buildPathFormat, builds)
+	urls = appendEntries(urls, key.siteURL, gearPathFormat, gear)
+	urls = appendEntries(urls, key.siteURL, pilotPathFormat, pilots)
+
+	generated := &sitemap{generatedAt: time.Now()}
+	for start := 0; start < len(urls); start += s.pageSize {
+		end := start + s.pageSize
+		if end > len(urls) {
+			end = len(urls)
+		}
+		page, err := encodeXML(urlSet{Xmlns: sitemapNamespace, URLs: urls[start:end]})
+		if err != nil {
+			return nil, fmt.Errorf("encode sitemap: %w", err)
+		}
+		generated.pages = append(generated.pages, page)
+	}
+
+	if len(generated.pages) > 1 {
+		lastMod := generated.generatedAt.UTC().Format(time.RFC3339)
+		refs := make([]sitemapURL, len(generated.pages))
+		for i := range generated.pages {
+			refs[i] = sitemapURL{Loc: fmt.Sprintf("%s/sitemaps/%d.xml", key.siteURL, i+1), LastMod: lastMod}
+		}
+		generated.index, err = encodeXML(sitemapIndex{Xmlns: sitemapNamespace, Sitemaps: refs})
+		if err != nil {
+			return nil, fmt.Errorf("encode sitemap index: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	s.sitemaps[key] = generated
+	s.mu.Unlock()
+
+	s.logger.Info("Sitemap regenerated", logging.WithFields(map[string]interface{}{
+		"tenantId": key.tenantID,
+		"siteUrl":  key.siteURL,
+		"urls":     len(urls),
+		"files":    len(generated.pages),
+		"builds":   len(builds),
+		"gear":     len(gear),
+		"pilots":   len(pilots),
+	}))
+	return generated, nil
+}
+
+// cached returns the sitemap of a tenant served at siteURL, generating it
+// on first request. An empty siteURL is the configured site URL.
+func (s *Service) cached(ctx context.Context, tenantID, siteURL string) (*sitemap, bool) {
+	if siteURL == "" {
+		siteURL = s.siteURL
+	}
+	key := sitemapKey{tenantID: tenantID, siteURL: strings.TrimRight(siteURL, "/")}
+
+	s.mu.RLock()
+	cached, ok := s.sitemaps[key]
+	s.mu.RUnlock()
+	if ok {
+		return cached, true
+	}
+
+	generated, err := s.generate(ctx, key)
+	if err != nil {
+		s.logger.Warn("Sitemap generation failed", logging.WithFields(map[string]interface{}{
+			"tenantId": tenantID,
+			"error":    err.Error(),
+		}))
+		return nil, false
+	}
+	return generated, true
+}
+
+// Sitemap returns the document served at /sitemap.xml for a tenant and the
+// origin it is served on: the only urlset, or an index of the numbered
+// sitemap files when there are too many URLs for one. Returns false if the
+// sitemap can't be generated.
+func (s *Service) Sitemap(ctx context.Context, tenantID, siteURL string) ([]byte, time.Time, bool) {
+	cached, ok := s.cached(ctx, tenantID, siteURL)
+	if !ok {
+		return nil, time.Time{}, false
+	}
+	if cached.index != nil {
+		return cached.index, cached.generatedAt, true
+	}
+	if len(cached.pages) == 0 {
+		return nil, time.Time{}, false
+	}
+	return cached.pages[0], cached.generatedAt, true
+}
+
+// SitemapPage returns the 1-based numbered sitemap file listed in a
+// tenant's index.
+func (s *Service) SitemapPage(ctx context.Context, tenantID, siteURL string, n int) ([]byte, time.Time, bool) {
+	cached, ok := s.cached(ctx, tenantID, siteURL)
+	if !ok || n < 1 || n > len(cached.pages) {
+		return nil, time.Time{}, false
+	}
+	return cached.pages[n-1], cached.generatedAt, true
+}
+
+// Product returns schema.org Product JSON-LD for a published catalog item.
+// Returns nil for items that aren't public.
+func (s *Service) Product(item *models.GearCatalogItem) map[string]interface{} {
+	if item == nil || models.NormalizeCatalogStatus(item.Status) != models.CatalogStatusPublished {
+		return nil
+	}
+
+	product := map[string]interface{}{
+		"@context": "https://schema.org",
+		"@type":    "Product",
+		"name":     item.DisplayName(),
+		"brand": map[string]interface{}{
+			"@type": "Brand",
+			"name":  item.Brand,
+		},
+		"model":    strings.TrimSpace(item.Model + " " + item.Variant),
+		"category": string(item.GearType),
+		"url":      s.siteURL + fmt.Sprintf(gearPathFormat, item.ID),
+		"sku":      item.ID,
+	}
+	if item.Description != "" {
+		product["description"] = item.Description
+	}
+	if item.ImageURL != "" {
+		product["image"] = s.absoluteURL(item.ImageURL)
+	}
+	if item.MSRP != nil && *item.MSRP > 0 {
+		product["offers"] = map[string]interface{}{
+			"@type":         "Offer",
+			"price":         fmt.Sprintf("%.2f", *item.MSRP),
+			"priceCurrency": "USD",
+		}
+	}
+	return product
+}
+
+func appendEntries(urls []sitemapURL, siteURL, pathFormat string, entries []models.SitemapEntry) []sitemapURL {
+	for _, entry := range entries {
+		u := sitemapURL{Loc: siteURL + fmt.Sprintf(pathFormat, entry.ID)}
+		if !entry.UpdatedAt.IsZero() {
+			u.LastMod = entry.UpdatedAt.UTC().Format(time.RFC3339)
+		}
+		urls = append(urls, u)
+	}
+	return urls
+}
+
+func (s *Service) absoluteURL(path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	return s.siteURL + path
+}
+
+func encodeXML(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	if err := xml.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,232 @@
+package seo
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+// mockStore implements the Store interface for testing
+type mockStore struct {
+	builds       []models.SitemapEntry
+	tenantBuilds map[string][]models.SitemapEntry // builds of tenants other than the default
+	gear         []models.SitemapEntry
+	pilots       []models.SitemapEntry
+}
+
+func (m *mockStore) PublishedBuilds(ctx context.Context) ([]models.SitemapEntry, error) {
+	if tenantID := database.TenantFromContext(ctx); tenantID != database.DefaultTenantID {
+		return m.tenantBuilds[tenantID], nil
+	}
+	return m.builds, nil
+}
+
+func (m *mockStore) PublishedCatalogItems(ctx context.Context) ([]models.SitemapEntry, error) {
+	return m.gear, nil
+}
+
+func (m *mockStore) PublicPilots(ctx context.Context) ([]models.SitemapEntry, error) {
+	return m.pilots, nil
+}
+
+func newTestService(store *mockStore, pageSize int) *Service {
+	return &Service{
+		store:    store,
+		siteURL:  "https://flyingforge.example",
+		pageSize: pageSize,
+		logger:   testutil.NullLogger(),
+		sitemaps: make(map[sitemapKey]*sitemap),
+	}
+}
+
+func TestService_Regenerate(t *testing.T) {
+	updated := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		store     *mockStore
+		pageSize  int
+		wantPages int               // sitemaps in the index, or 0 for a single sitemap
+		wantURLs  map[string]string // loc to lastmod on the single or last sitemap
+	}{
+		{
+			name: "single sitemap",
+			store: &mockStore{
+				builds: []models.SitemapEntry{{ID: "b1", UpdatedAt: updated}},
+				gear:   []models.SitemapEntry{{ID: "g1"}},
+				pilots: []models.SitemapEntry{{ID: "p1", UpdatedAt: updated}},
+			},
+			pageSize: maxURLsPerSitemap,
+			wantURLs: map[string]string{
+				"https://flyingforge.example/":                 "",
+				"https://flyingforge.example/builds":           "",
+				"https://flyingforge.example/gear-catalog":     "",
+				"https://flyingforge.example/builds/b1":        "2026-03-01T12:00:00Z",
+				"https://flyingforge.example/gear-catalog/g1":  "",
+				"https://flyingforge.example/social/pilots/p1": "2026-03-01T12:00:00Z",
+			},
+		},
+		{
+			// 3 static pages + 4 builds across pages of 3 URLs
+			name: "splits into an index",
+			store: &mockStore{builds: []models.SitemapEntry{
+				{ID: "b1"}, {ID: "b2"}, {ID: "b3"}, {ID: "b4"},
+			}},
+			pageSize:  3,
+			wantPages: 3,
+			wantURLs:  map[string]string{"https://flyingforge.example/builds/b4": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := newTestService(tt.store, tt.pageSize)
+			if err := svc.Regenerate(ctx); err != nil {
+				t.Fatalf("Regenerate() error = %v", err)
+			}
+
+			data, _, ok := svc.Sitemap(ctx, database.DefaultTenantID, "")
+			if !ok {
+				t.Fatal("Sitemap() after generation should be available")
+			}
+			if tt.wantPages > 0 {
+				var index sitemapIndex
+				if err := xml.Unmarshal(data, &index); err != nil {
+					t.Fatalf("sitemap index is not valid XML: %v", err)
+				}
+				if index.XMLName.Local != "sitemapindex" || len(index.Sitemaps) != tt.wantPages {
+					t.Fatalf("index = %+v, want sitemapindex with %d sitemaps", index, tt.wantPages)
+				}
+				wantLoc := fmt.Sprintf("https://flyingforge.example/sitemaps/%d.xml", tt.wantPages)
+				if loc := index.Sitemaps[tt.wantPages-1].Loc; loc != wantLoc {
+					t.Errorf("last sitemap loc = %q, want %q", loc, wantLoc)
+				}
+				if data, _, ok = svc.SitemapPage(ctx, database.DefaultTenantID, "", tt.wantPages); !ok {
+					t.Fatalf("SitemapPage(%d) should exist", tt.wantPages)
+				}
+			}
+			if _, _, ok := svc.SitemapPage(ctx, database.DefaultTenantID, "", max(tt.wantPages, 1)+1); ok {
+				t.Errorf("SitemapPage(%d) should not exist", max(tt.wantPages, 1)+1)
+			}
+
+			var set urlSet
+			if err := xml.Unmarshal(data, &set); err != nil {
+				t.Fatalf("sitemap is not valid XML: %v", err)
+			}
+			if len(set.URLs) != len(tt.wantURLs) {
+				t.Fatalf("sitemap has %d urls, want %d", len(set.URLs), len(tt.wantURLs))
+			}
+			for _, u := range set.URLs {
+				lastMod, found := tt.wantURLs[u.Loc]
+				if !found {
+					t.Errorf("unexpected url %q", u.Loc)
+					continue
+				}
+				if u.LastMod != lastMod {
+					t.Errorf("lastmod for %q = %q, want %q", u.Loc, u.LastMod, lastMod)
+				}
+			}
+		})
+	}
+}
+
+func TestService_Sitemap_PerTenant(t *testing.T) {
+	store := &mockStore{
+		builds:       []models.SitemapEntry{{ID: "default-build"}},
+		tenantBuilds: map[string][]models.SitemapEntry{"club": {{ID: "club-build"}}},
+	}
+	svc := newTestService(store, maxURLsPerSitemap)
+
+	tests := []struct {
+		tenantID string
+		siteURL  string
+		want     string
+		notWant  string
+	}{
+		{database.DefaultTenantID, "", "https://flyingforge.example/builds/default-build", "club-build"},
+		{"club", "https://fpv.club.example", "https://fpv.club.example/builds/club-build", "default-build"},
+	}
+	for _, tt := range tests {
+		data, _, ok := svc.Sitemap(context.Background(), tt.tenantID, tt.siteURL)
+		if !ok {
+			t.Fatalf("Sitemap(%s) not available", tt.tenantID)
+		}
+		if !strings.Contains(string(data), "<loc>"+tt.want+"</loc>") {
+			t.Errorf("Sitemap(%s) is missing %s:\n%s", tt.tenantID, tt.want, data)
+		}
+		if strings.Contains(string(data), tt.notWant) {
+			t.Errorf("Sitemap(%s) lists another tenant's %s", tt.tenantID, tt.notWant)
+		}
+	}
+
+	// Regenerate refreshes every tenant sitemap that has been served
+	store.tenantBuilds["club"] = append(store.tenantBuilds["club"], models.SitemapEntry{ID: "club-build-2"})
+	if err := svc.Regenerate(context.Background()); err != nil {
+		t.Fatalf("Regenerate() error = %v", err)
+	}
+	data, _, _ := svc.Sitemap(context.Background(), "club", "https://fpv.club.example")
+	if !strings.Contains(string(data), "club-build-2") {
+		t.Error("Regenerate() didn't refresh the club sitemap")
+	}
+}
+
+func TestService_Product(t *testing.T) {
+	msrp := 24.99
+	svc := newTestService(&mockStore{}, maxURLsPerSitemap)
+
+	published := &models.GearCatalogItem{
+		ID:          "g1",
+		GearType:    models.GearTypeMotor,
+		Brand:       "T-Motor",
+		Model:       "F60 Pro V",
+		Variant:     "1950KV",
+		Description: "5 inch freestyle motor",
+		ImageURL:    "/api/gear-catalog/g1/image?v=1",
+		MSRP:        &msrp,
+		Status:      models.CatalogStatusPublished,
+	}
+
+	product := svc.Product(published)
+	if product == nil {
+		t.Fatal("Product() = nil for published item")
+	}
+	checks := map[string]interface{}{
+		"@type":    "Product",
+		"model":    "F60 Pro V 1950KV",
+		"category": "motor",
+		"url":      "https://flyingforge.example/gear-catalog/g1",
+		"image":    "https://flyingforge.example/api/gear-catalog/g1/image?v=1",
+	}
+	for key, want := range checks {
+		if product[key] != want {
+			t.Errorf("product[%q] = %v, want %v", key, product[key], want)
+		}
+	}
+	offers, _ := product["offers"].(map[string]interface{})
+	if offers["price"] != "24.99" || offers["priceCurrency"] != "USD" {
+		t.Errorf("offers = %v, want USD 24.99", offers)
+	}
+	if name, _ := product["name"].(string); !strings.Contains(name, "F60 Pro V") {
+		t.Errorf("name = %q, want display name", name)
+	}
+
+	pending := *published
+	pending.Status = models.CatalogStatusPending
+	if svc.Product(&pending) != nil {
+		t.Error("Product() should be nil for unpublished items")
+	}
+
+	noPrice := *published
+	noPrice.MSRP = nil
+	if _, found := svc.Product(&noPrice)["offers"]; found {
+		t.Error("Product() should omit offers without an MSRP")
+	}
+}
@@ -0,0 +1,226 @@
+package shortlinks
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/builds"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	// slugAlphabet avoids punctuation so slugs survive being read aloud or typed
+	slugAlphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+	defaultSlugLength = 5
+	maxSlugLength     = 16
+
+	// maxAttempts bounds slug generation; the slug grows by one character
+	// after every attemptsPerLength collisions.
+	maxAttempts       = 9
+	attemptsPerLength = 3
+)
+
+// pathPrefixes are the short path prefixes per target type
+var pathPrefixes = map[models.ShortLinkTargetType]string{
+	models.ShortLinkBuild: "/b/",
+	models.ShortLinkGear:  "/g/",
+}
+
+// targetPaths are the web frontend pages short links redirect to
+var targetPaths = map[models.ShortLinkTargetType]string{
+	models.ShortLinkBuild: "/builds/",
+	models.ShortLinkGear:  "/gear-catalog/",
+}
+
+// Store defines the short link persistence operations
+type Store interface {
+	Create(ctx context.Context, slug string, targetType models.ShortLinkTargetType, targetID string) (*models.ShortLink, error)
+	GetByTarget(ctx context.Context, targetType models.ShortLinkTargetType, targetID string) (*models.ShortLink, error)
+	RecordClick(ctx context.Context, targetType models.ShortLinkTargetType, slug string) (*models.ShortLink, error)
+}
+
+// BuildReader loads published builds
+type BuildReader interface {
+	GetPublic(ctx context.Context, id string) (*models.Build, error)
+}
+
+// CatalogReader loads gear catalog items
+type CatalogReader interface {
+	Get(ctx context.Context, id string) (*models.GearCatalogItem, error)
+}
+
+// Service issues and resolves short links for published builds and catalog items
+type Service struct {
+	store   Store
+	builds  BuildReader
+	catalog CatalogReader
+	newSlug func(length int) (string, error)
+	logger  *logging.Logger
+}
+
+// NewService creates a new short link service
+func NewService(store *database.ShortLinkStore, buildSvc *builds.Service, catalogStore *database.GearCatalogStore, logger *logging.Logger) *Service {
+	return &Service{
+		store:   store,
+		builds:  buildSvc,
+		catalog: catalogStore,
+		newSlug: randomSlug,
+		logger:  logger,
+	}
+}
+
+// Issue returns the short link for a target, creating one if it has none.
+// Callers are expected to have checked that the target is published.
+func (s *Service) Issue(ctx context.Context, targetType models.ShortLinkTargetType, targetID string) (*models.ShortLink, error) {
+	if !models.IsValidShortLinkTargetType(targetType) {
+		return nil, &ServiceError{Message: "type must be build or gear"}
+	}
+
+	existing, err := s.store.GetByTarget(ctx, targetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return withPath(existing), nil
+	}
+
+	length := defaultSlugLength
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		slug, err := s.newSlug(length)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate short link slug: %w", err)
+		}
+
+		link, err := s.store.Create(ctx, slug, targetType, targetID)
+		if err != nil {
+			return nil, err
+		}
+		if link != nil {
+			return withPath(link), nil
+		}
+
+		// Either the slug is taken or a concurrent request linked this target first.
+		existing, err := s.store.GetByTarget(ctx, targetType, targetID)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return withPath(existing), nil
+		}
+
+		s.logger.Debug("Short link slug collision", logging.WithFields(map[string]interface{}{
+			"slug":    slug,
+			"attempt": attempt,
+		}))
+		if attempt%attemptsPerLength == 0 && length < maxSlugLength {
+			length++
+		}
+	}
+
+	return nil, fmt.Errorf("failed to generate a unique short link slug after %d attempts", maxAttempts)
+}
+
+// Ensure returns the short link for a published build or catalog item, issuing
+// one for content published before short links existed. Returns nil if the
+// target isn't published.
+func (s *Service) Ensure(ctx context.Context, targetType models.ShortLinkTargetType, targetID string) (*models.ShortLink, error) {
+	targetType = models.ShortLinkTargetType(strings.ToLower(strings.TrimSpace(string(targetType))))
+	targetID = strings.TrimSpace(targetID)
+
+	if !models.IsValidShortLinkTargetType(targetType) {
+		return nil, &ServiceError{Message: "type must be build or gear"}
+	}
+	if _, err := uuid.Parse(targetID); err != nil {
+		return nil, nil
+	}
+
+	published, err := s.isPublished(ctx, targetType, targetID)
+	if err != nil || !published {
+		return nil, err
+	}
+	return s.Issue(ctx, targetType, targetID)
+}
+
+// Resolve counts a click on a slug and returns the page it redirects to.
+// Returns an empty path for unknown slugs.
+func (s *Service) Resolve(ctx context.Context, targetType models.ShortLinkTargetType, slug string) (string, error) {
+	if !validSlug(slug) {
+		return "", nil
+	}
+
+	link, err := s.store.RecordClick(ctx, targetType, slug)
+	if err != nil || link == nil {
+		return "", err
+	}
+	return targetPaths[link.TargetType] + link.TargetID, nil
+}
+
+// PathPrefix returns the short path prefix for a target type, e.g. "/b/"
+func PathPrefix(targetType models.ShortLinkTargetType) string {
+	return pathPrefixes[targetType]
+}
+
+func (s *Service) isPublished(ctx context.Context, targetType models.ShortLinkTargetType, targetID string) (bool, error) {
+	switch targetType {
+	case models.ShortLinkBuild:
+		build, err := s.builds.GetPublic(ctx, targetID)
+		if err != nil {
+			return false, err
+		}
+		return build != nil, nil
+	case models.ShortLinkGear:
+		item, err := s.catalog.Get(ctx, targetID)
+		if err != nil {
+			return false, err
+		}
+		return item != nil && models.NormalizeCatalogStatus(item.Status) == models.CatalogStatusPublished, nil
+	}
+	return false, nil
+}
+
+func withPath(link *models.ShortLink) *models.ShortLink {
+	link.Path = pathPrefixes[link.TargetType] + link.Slug
+	return link
+}
+
+func validSlug(slug string) bool {
+	if slug == "" || len(slug) > maxSlugLength {
+		return false
+	}
+	for _, c := range slug {
+		if !strings.ContainsRune(slugAlphabet, c) {
+			return false
+		}
+	}
+	return true
+}
+
+func randomSlug(length int) (string, error) {
+	max := big.NewInt(int64(len(slugAlphabet)))
+	slug := make([]byte, length)
+	for i := range slug {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		slug[i] = slugAlphabet[n.Int64()]
+	}
+	return string(slug), nil
+}
+
+// ServiceError represents a short link request error that should be shown to the client
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
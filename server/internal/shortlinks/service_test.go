@@ -0,0 +1,242 @@
+package shortlinks
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+const (
+	buildID      = "11111111-1111-1111-1111-111111111111"
+	draftBuildID = "22222222-2222-2222-2222-222222222222"
+	gearID       = "33333333-3333-3333-3333-333333333333"
+	pendingGear  = "44444444-4444-4444-4444-444444444444"
+)
+
+// mockStore implements the Store interface in memory
+type mockStore struct {
+	links   map[string]*models.ShortLink
+	creates int
+}
+
+func newMockStore() *mockStore {
+	return &mockStore{links: make(map[string]*models.ShortLink)}
+}
+
+func (m *mockStore) Create(ctx context.Context, slug string, targetType models.ShortLinkTargetType, targetID string) (*models.ShortLink, error) {
+	m.creates++
+	if _, taken := m.links[slug]; taken {
+		return nil, nil
+	}
+	for _, link := range m.links {
+		if link.TargetType == targetType && link.TargetID == targetID {
+			return nil, nil
+		}
+	}
+	link := &models.ShortLink{Slug: slug, TargetType: targetType, TargetID: targetID}
+	m.links[slug] = link
+	return link, nil
+}
+
+func (m *mockStore) GetByTarget(ctx context.Context, targetType models.ShortLinkTargetType, targetID string) (*models.ShortLink, error) {
+	for _, link := range m.links {
+		if link.TargetType == targetType && link.TargetID == targetID {
+			return link, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockStore) RecordClick(ctx context.Context, targetType models.ShortLinkTargetType, slug string) (*models.ShortLink, error) {
+	link, ok := m.links[slug]
+	if !ok || link.TargetType != targetType {
+		return nil, nil
+	}
+	link.ClickCount++
+	return link, nil
+}
+
+type mockBuilds struct{}
+
+func (m *mockBuilds) GetPublic(ctx context.Context, id string) (*models.Build, error) {
+	if id == buildID {
+		return &models.Build{ID: id}, nil
+	}
+	return nil, nil
+}
+
+type mockCatalog struct{}
+
+func (m *mockCatalog) Get(ctx context.Context, id string) (*models.GearCatalogItem, error) {
+	switch id {
+	case gearID:
+		return &models.GearCatalogItem{ID: id, Status: models.CatalogStatusPublished}, nil
+	case pendingGear:
+		return &models.GearCatalogItem{ID: id, Status: models.CatalogStatusPending}, nil
+	}
+	return nil, nil
+}
+
+// sequenceSlugs returns the given slugs in order and records requested lengths
+func sequenceSlugs(slugs ...string) (func(int) (string, error), *[]int) {
+	var lengths []int
+	i := 0
+	return func(length int) (string, error) {
+		lengths = append(lengths, length)
+		if i >= len(slugs) {
+			return "", errors.New("out of slugs")
+		}
+		slug := slugs[i]
+		i++
+		return slug, nil
+	}, &lengths
+}
+
+func newTestService(store *mockStore, newSlug func(int) (string, error)) *Service {
+	return &Service{
+		store:   store,
+		builds:  &mockBuilds{},
+		catalog: &mockCatalog{},
+		newSlug: newSlug,
+		logger:  testutil.NullLogger(),
+	}
+}
+
+func TestService_Issue(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    []*models.ShortLink
+		slugs       []string
+		wantSlug    string
+		wantLengths []int
+	}{
+		{
+			name:        "first slug",
+			slugs:       []string{"7fG3k", "unused"},
+			wantSlug:    "7fG3k",
+			wantLengths: []int{5},
+		},
+		{
+			name: "retries collisions with a longer slug",
+			existing: []*models.ShortLink{
+				{Slug: "aaaaa", TargetType: models.ShortLinkGear, TargetID: gearID},
+				{Slug: "bbbbb", TargetType: models.ShortLinkGear, TargetID: pendingGear},
+				{Slug: "ccccc", TargetType: models.ShortLinkGear, TargetID: "other"},
+			},
+			slugs:       []string{"aaaaa", "bbbbb", "ccccc", "dddddd"},
+			wantSlug:    "dddddd",
+			wantLengths: []int{5, 5, 5, 6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMockStore()
+			for _, link := range tt.existing {
+				store.links[link.Slug] = link
+			}
+			newSlug, lengths := sequenceSlugs(tt.slugs...)
+			svc := newTestService(store, newSlug)
+
+			link, err := svc.Issue(context.Background(), models.ShortLinkBuild, buildID)
+			if err != nil {
+				t.Fatalf("Issue() error = %v", err)
+			}
+			if link.Slug != tt.wantSlug || link.Path != "/b/"+tt.wantSlug {
+				t.Errorf("link = %+v, want slug %s at /b/%s", link, tt.wantSlug, tt.wantSlug)
+			}
+			if !reflect.DeepEqual(*lengths, tt.wantLengths) {
+				t.Errorf("requested slug lengths = %v, want %v", *lengths, tt.wantLengths)
+			}
+
+			creates := store.creates
+			again, err := svc.Issue(context.Background(), models.ShortLinkBuild, buildID)
+			if err != nil {
+				t.Fatalf("second Issue() error = %v", err)
+			}
+			if again.Slug != link.Slug || store.creates != creates {
+				t.Errorf("second Issue() = %q after %d more creates, want existing link reused", again.Slug, store.creates-creates)
+			}
+		})
+	}
+}
+
+func TestService_Ensure(t *testing.T) {
+	tests := []struct {
+		name       string
+		targetType models.ShortLinkTargetType
+		targetID   string
+		wantLink   bool
+		wantErr    bool
+	}{
+		{name: "published build", targetType: "build", targetID: buildID, wantLink: true},
+		{name: "type normalized", targetType: " Gear ", targetID: gearID, wantLink: true},
+		{name: "draft build", targetType: "build", targetID: draftBuildID},
+		{name: "pending catalog item", targetType: "gear", targetID: pendingGear},
+		{name: "invalid id", targetType: "build", targetID: "nope"},
+		{name: "unknown type", targetType: "pilot", targetID: buildID, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(newMockStore(), randomSlug)
+
+			link, err := svc.Ensure(context.Background(), tt.targetType, tt.targetID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Ensure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (link != nil) != tt.wantLink {
+				t.Errorf("Ensure() link = %+v, want link %v", link, tt.wantLink)
+			}
+		})
+	}
+}
+
+func TestService_Resolve(t *testing.T) {
+	store := newMockStore()
+	store.links["7fG3k"] = &models.ShortLink{Slug: "7fG3k", TargetType: models.ShortLinkBuild, TargetID: buildID}
+	svc := newTestService(store, randomSlug)
+
+	tests := []struct {
+		name       string
+		targetType models.ShortLinkTargetType
+		slug       string
+		want       string // "" for not found
+	}{
+		{name: "build link", targetType: models.ShortLinkBuild, slug: "7fG3k", want: "/builds/" + buildID},
+		{name: "empty", targetType: models.ShortLinkBuild, slug: ""},
+		{name: "path suffix", targetType: models.ShortLinkBuild, slug: "7fG3k/x"},
+		{name: "path traversal", targetType: models.ShortLinkBuild, slug: "../etc"},
+		{name: "unknown slug", targetType: models.ShortLinkBuild, slug: "zzzzz"},
+		{name: "other prefix", targetType: models.ShortLinkGear, slug: "7fG3k"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := svc.Resolve(context.Background(), tt.targetType, tt.slug)
+			if tt.want != "" && err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if target != tt.want {
+				t.Errorf("Resolve(%q) = %q, want %q", tt.slug, target, tt.want)
+			}
+		})
+	}
+
+	if store.links["7fG3k"].ClickCount != 1 {
+		t.Errorf("click count = %d, want 1", store.links["7fG3k"].ClickCount)
+	}
+}
+
+func TestRandomSlug(t *testing.T) {
+	slug, err := randomSlug(defaultSlugLength)
+	if err != nil {
+		t.Fatalf("randomSlug() error = %v", err)
+	}
+	if len(slug) != defaultSlugLength || !validSlug(slug) {
+		t.Errorf("randomSlug() = %q, want %d alphanumeric characters", slug, defaultSlugLength)
+	}
+}
@@ -0,0 +1,111 @@
+package sources
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/cache"
+)
+
+const (
+	validatorCachePrefix = "feed_validators:"
+	// validatorTTL is shorter than the aggregator's item cache, so a source
+	// isn't told "not modified" after the items it had are gone
+	validatorTTL = 24 * time.Hour
+)
+
+// Validators are the ETag and Last-Modified a feed last responded with
+type Validators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// ValidatorStore keeps each feed's validators for conditional requests. With
+// Redis they survive restarts and one-shot refresh runs.
+type ValidatorStore struct {
+	cache cache.Cache
+	mu    sync.Mutex
+	local map[string]Validators
+}
+
+// NewValidatorStore creates a validator store backed by c, or by memory
+// when c is nil
+func NewValidatorStore(c cache.Cache) *ValidatorStore {
+	return &ValidatorStore{
+		cache: c,
+		local: make(map[string]Validators),
+	}
+}
+
+// Get returns the validators saved for a feed URL
+func (s *ValidatorStore) Get(feedURL string) Validators {
+	if s == nil {
+		return Validators{}
+	}
+	if s.cache == nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.local[feedURL]
+	}
+
+	cached, ok := s.cache.Get(validatorCachePrefix + feedURL)
+	if !ok || cached == nil {
+		return Validators{}
+	}
+	if v, ok := cached.(Validators); ok {
+		return v
+	}
+
+	// Redis hands back the decoded JSON
+	raw, err := json.Marshal(cached)
+	if err != nil {
+		return Validators{}
+	}
+	var v Validators
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return Validators{}
+	}
+	return v
+}
+
+// Save records the validators of a successful response. Responses without
+// any clear what was saved.
+func (s *ValidatorStore) Save(feedURL string, resp *http.Response) {
+	if s == nil {
+		return
+	}
+	v := Validators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+
+	if s.cache == nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if v == (Validators{}) {
+			delete(s.local, feedURL)
+			return
+		}
+		s.local[feedURL] = v
+		return
+	}
+
+	if v == (Validators{}) {
+		s.cache.Delete(validatorCachePrefix + feedURL)
+		return
+	}
+	s.cache.SetWithTTL(validatorCachePrefix+feedURL, v, validatorTTL)
+}
+
+// setConditionalHeaders makes req conditional on the feed having changed
+// since v was saved
+func setConditionalHeaders(req *http.Request, v Validators) {
+	if v.ETag != "" {
+		req.Header.Set("If-None-Match", v.ETag)
+	}
+	if v.LastModified != "" {
+		req.Header.Set("If-Modified-Since", v.LastModified)
+	}
+}
@@ -0,0 +1,104 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/cache"
+	"github.com/johnrirwin/flyingforge/internal/ratelimit"
+)
+
+const testRSS = `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Test</title>
+<item><title>First post</title><link>https://example.com/first</link><pubDate>Mon, 02 Mar 2026 10:00:00 GMT</pubDate></item>
+</channel></rss>`
+
+const testReddit = `{"data":{"children":[{"data":{"id":"abc","title":"Hello","permalink":"/r/fpv/comments/abc/hello/","created_utc":1772445600}}]}}`
+
+// newConditionalServer serves body with an ETag and Last-Modified, and 304
+// when the request carries either back
+func newConditionalServer(body string) (*httptest.Server, *int) {
+	full := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` || r.Header.Get("If-Modified-Since") == "Mon, 02 Mar 2026 10:00:00 GMT" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full++
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Mar 2026 10:00:00 GMT")
+		_, _ = w.Write([]byte(body))
+	}))
+	return server, &full
+}
+
+func testFetcherConfig(validators *ValidatorStore) FetcherConfig {
+	return FetcherConfig{Timeout: 5 * time.Second, MaxItems: 10, UserAgent: "TestAgent/1.0", Validators: validators}
+}
+
+func TestRSSFetcher_ConditionalGet(t *testing.T) {
+	server, full := newConditionalServer(testRSS)
+	defer server.Close()
+
+	fetcher := NewRSSFetcher("Test", server.URL, ratelimit.New(0), testFetcherConfig(NewValidatorStore(cache.NewMemory(time.Minute))))
+
+	items, err := fetcher.Fetch(context.Background())
+	if err != nil || len(items) != 1 {
+		t.Fatalf("first Fetch() = %d items, %v, want 1 item", len(items), err)
+	}
+	if _, err := fetcher.Fetch(context.Background()); !errors.Is(err, ErrNotModified) {
+		t.Fatalf("second Fetch() error = %v, want ErrNotModified", err)
+	}
+	if *full != 1 {
+		t.Errorf("server sent the full feed %d times, want 1", *full)
+	}
+}
+
+func TestRSSFetcher_NoValidators(t *testing.T) {
+	server, full := newConditionalServer(testRSS)
+	defer server.Close()
+
+	fetcher := NewRSSFetcher("Test", server.URL, ratelimit.New(0), testFetcherConfig(nil))
+
+	for i := 0; i < 2; i++ {
+		if _, err := fetcher.Fetch(context.Background()); err != nil {
+			t.Fatalf("Fetch() error = %v", err)
+		}
+	}
+	if *full != 2 {
+		t.Errorf("server sent the full feed %d times, want 2", *full)
+	}
+}
+
+func TestRedditFetcher_ConditionalGet(t *testing.T) {
+	server, full := newConditionalServer(testReddit)
+	defer server.Close()
+
+	fetcher := NewRedditFetcher("fpv", ratelimit.New(0), testFetcherConfig(NewValidatorStore(nil)))
+	fetcher.baseURL = server.URL
+
+	items, err := fetcher.Fetch(context.Background())
+	if err != nil || len(items) != 1 {
+		t.Fatalf("first Fetch() = %d items, %v, want 1 item", len(items), err)
+	}
+	if _, err := fetcher.Fetch(context.Background()); !errors.Is(err, ErrNotModified) {
+		t.Fatalf("second Fetch() error = %v, want ErrNotModified", err)
+	}
+	if *full != 1 {
+		t.Errorf("server sent the full listing %d times, want 1", *full)
+	}
+}
+
+func TestValidatorStore_DecodesCachedJSON(t *testing.T) {
+	c := cache.NewMemory(time.Minute)
+	c.Set(validatorCachePrefix+"https://example.com/feed", map[string]interface{}{"etag": `"v2"`, "lastModified": "yesterday"})
+
+	got := NewValidatorStore(c).Get("https://example.com/feed")
+	if got.ETag != `"v2"` || got.LastModified != "yesterday" {
+		t.Errorf("Get() = %+v, want the decoded validators", got)
+	}
+}
@@ -2,11 +2,16 @@ package sources
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/johnrirwin/flyingforge/internal/models"
 )
 
+// ErrNotModified is returned by Fetch when the feed hasn't changed since the
+// last fetch, so the items from that fetch are still current
+var ErrNotModified = errors.New("feed not modified")
+
 type Fetcher interface {
 	Name() string
 	Fetch(ctx context.Context) ([]models.FeedItem, error)
@@ -23,6 +28,8 @@ type FetcherConfig struct {
 	Timeout   time.Duration
 	MaxItems  int
 	UserAgent string
+	// Validators makes RSS and Reddit fetches conditional; nil fetches in full
+	Validators *ValidatorStore
 }
 
 func DefaultConfig() FetcherConfig {
@@ -16,6 +16,7 @@ type RedditFetcher struct {
 	limiter   *ratelimit.Limiter
 	config    FetcherConfig
 	client    *http.Client
+	baseURL   string
 }
 
 type redditResponse struct {
@@ -48,6 +49,7 @@ func NewRedditFetcher(subreddit string, limiter *ratelimit.Limiter, config Fetch
 		client: &http.Client{
 			Timeout: config.Timeout,
 		},
+		baseURL: "https://www.reddit.com",
 	}
 }
 
@@ -70,13 +72,14 @@ func (f *RedditFetcher) SourceInfo() models.SourceInfo {
 func (f *RedditFetcher) Fetch(ctx context.Context) ([]models.FeedItem, error) {
 	f.limiter.Wait("reddit.com")
 
-	url := fmt.Sprintf("https://www.reddit.com/r/%s/hot.json?limit=%d", f.subreddit, f.config.MaxItems)
+	url := fmt.Sprintf("%s/r/%s/hot.json?limit=%d", f.baseURL, f.subreddit, f.config.MaxItems)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("User-Agent", f.config.UserAgent)
+	setConditionalHeaders(req, f.config.Validators.Get(url))
 
 	resp, err := f.client.Do(req)
 	if err != nil {
@@ -84,6 +87,9 @@ func (f *RedditFetcher) Fetch(ctx context.Context) ([]models.FeedItem, error) {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, ErrNotModified
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("reddit returned status %d", resp.StatusCode)
 	}
@@ -92,6 +98,7 @@ func (f *RedditFetcher) Fetch(ctx context.Context) ([]models.FeedItem, error) {
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
 		return nil, fmt.Errorf("failed to decode reddit response: %w", err)
 	}
+	f.config.Validators.Save(url, resp)
 
 	items := make([]models.FeedItem, 0, len(data.Data.Children))
 	for _, child := range data.Data.Children {
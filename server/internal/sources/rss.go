@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -19,6 +20,7 @@ type RSSFetcher struct {
 	parser  *gofeed.Parser
 	limiter *ratelimit.Limiter
 	config  FetcherConfig
+	client  *http.Client
 }
 
 func NewRSSFetcher(name, url string, limiter *ratelimit.Limiter, config FetcherConfig) *RSSFetcher {
@@ -28,6 +30,9 @@ func NewRSSFetcher(name, url string, limiter *ratelimit.Limiter, config FetcherC
 		parser:  gofeed.NewParser(),
 		limiter: limiter,
 		config:  config,
+		client: &http.Client{
+			Timeout: config.Timeout,
+		},
 	}
 }
 
@@ -53,10 +58,31 @@ func (f *RSSFetcher) Fetch(ctx context.Context) ([]models.FeedItem, error) {
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, f.config.Timeout)
 	defer cancel()
 
-	feed, err := f.parser.ParseURLWithContext(f.url, ctxWithTimeout)
+	req, err := http.NewRequestWithContext(ctxWithTimeout, http.MethodGet, f.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", f.config.UserAgent)
+	setConditionalHeaders(req, f.config.Validators.Get(f.url))
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch RSS feed %s: %w", f.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, ErrNotModified
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("RSS feed %s returned status %d", f.url, resp.StatusCode)
+	}
+
+	feed, err := f.parser.Parse(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse RSS feed %s: %w", f.url, err)
 	}
+	f.config.Validators.Save(f.url, resp)
 
 	items := make([]models.FeedItem, 0, len(feed.Items))
 	for i, item := range feed.Items {
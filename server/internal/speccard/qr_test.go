@@ -0,0 +1,99 @@
+package speccard
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomon(t *testing.T) {
+	// "HELLO WORLD" as version 1-M, the worked example most QR references use
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := reedSolomon(data, len(want)); !bytes.Equal(got, want) {
+		t.Errorf("reedSolomon() = %v, want %v", got, want)
+	}
+}
+
+func TestFormatBits(t *testing.T) {
+	// From the format information table in the QR specification
+	for mask, want := range []int{0x77C4, 0x72F3, 0x7DAA, 0x789D, 0x662F, 0x6318, 0x6C41, 0x6976} {
+		if got := formatBits(mask); got != want {
+			t.Errorf("formatBits(%d) = %015b, want %015b", mask, got, want)
+		}
+	}
+}
+
+func TestEncodeQR_RoundTrip(t *testing.T) {
+	for _, text := range []string{"a", "https://flyingforge.example/builds/4f9c2a51-1d3e-4b7a-9c6f-0e8d7b5a3c21", strings.Repeat("x", 106)} {
+		q, err := encodeQR(text)
+		if err != nil {
+			t.Fatalf("encodeQR(%d bytes) error = %v", len(text), err)
+		}
+
+		// Read the mask back from the format bits beside the top-left finder
+		bits := 0
+		for i := 0; i <= 5; i++ {
+			bits |= boolBit(q.modules[i][8]) << i
+		}
+		bits |= boolBit(q.modules[7][8])<<6 | boolBit(q.modules[8][8])<<7 | boolBit(q.modules[8][7])<<8
+		for i := 9; i < 15; i++ {
+			bits |= boolBit(q.modules[8][14-i]) << i
+		}
+		mask := -1
+		for m := 0; m < 8; m++ {
+			if formatBits(m) == bits {
+				mask = m
+			}
+		}
+		if mask < 0 {
+			t.Fatalf("format bits %015b don't match any mask", bits)
+		}
+
+		q.applyMask(mask)
+		var stream []byte
+		var b byte
+		n := 0
+		for right := q.size - 1; right >= 1; right -= 2 {
+			if right == 6 {
+				right = 5
+			}
+			upward := (right+1)&2 == 0
+			for vert := 0; vert < q.size; vert++ {
+				y := vert
+				if upward {
+					y = q.size - 1 - vert
+				}
+				for j := 0; j < 2; j++ {
+					if q.function[y][right-j] {
+						continue
+					}
+					b = b<<1 | byte(boolBit(q.modules[y][right-j]))
+					if n++; n%8 == 0 {
+						stream = append(stream, b)
+					}
+				}
+			}
+		}
+
+		v := qrVersions[(q.size-17)/4-1]
+		if len(stream) != v.data+v.ec {
+			t.Fatalf("read %d codewords, want %d", len(stream), v.data+v.ec)
+		}
+		if want := qrDataCodewords([]byte(text), v.data); !bytes.Equal(stream[:v.data], want) {
+			t.Errorf("data codewords for %q don't round trip", text)
+		}
+	}
+
+	if _, err := encodeQR(strings.Repeat("x", 107)); !errors.Is(err, ErrLinkTooLong) {
+		t.Errorf("encodeQR(107 bytes) error = %v, want ErrLinkTooLong", err)
+	}
+}
+
+func boolBit(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,224 @@
+// Package speccard renders shareable PNG "spec cards" for builds: a title,
+// the build photo, a short list of key parts and a QR code linking back to
+// the build, sized for Instagram and Discord posts. It uses only the
+// standard library image packages, with a built-in bitmap font and QR
+// encoder.
+package speccard
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+)
+
+// Card dimensions follow Instagram's 4:5 portrait format
+const (
+	Width  = 1080
+	Height = 1350
+
+	margin      = 60
+	photoHeight = 480
+
+	brandScale = 3
+	titleScale = 8
+	titleLines = 2
+	bylineGap  = 16
+	textScale  = 4
+	rowHeight  = 44
+	labelChars = 8 // width of the part label column
+	linkScale  = 2
+	qrScale    = 5
+	qrQuiet    = 4 // light modules around the code
+)
+
+var (
+	background = color.RGBA{R: 0x0F, G: 0x17, B: 0x2A, A: 0xFF}
+	panel      = color.RGBA{R: 0x1E, G: 0x29, B: 0x3B, A: 0xFF}
+	accent     = color.RGBA{R: 0x22, G: 0xD3, B: 0xEE, A: 0xFF}
+	foreground = color.RGBA{R: 0xF8, G: 0xFA, B: 0xFC, A: 0xFF}
+	muted      = color.RGBA{R: 0x94, G: 0xA3, B: 0xB8, A: 0xFF}
+)
+
+// Spec is one labelled line on the card, such as a part
+type Spec struct {
+	Label string
+	Value string
+}
+
+// Card is the content of a spec card. Everything but Title is optional.
+type Card struct {
+	Brand  string // printed above the title
+	Title  string
+	Byline string      // e.g. the pilot's call sign
+	Photo  image.Image // cropped to fill the photo area
+	Specs  []Spec      // drawn in order until the space runs out
+	Link   string      // encoded in the QR code and printed beside it
+}
+
+// Render draws the card and encodes it as a PNG. It returns ErrLinkTooLong
+// if the link doesn't fit in a QR code.
+func Render(card Card) ([]byte, error) {
+	var qr *qrCode
+	if card.Link != "" {
+		var err error
+		if qr, err = encodeQR(card.Link); err != nil {
+			return nil, err
+		}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	fill(img, img.Bounds(), background)
+	fill(img, image.Rect(0, 0, Width, 12), accent)
+
+	y := margin
+	if card.Brand != "" {
+		drawText(img, margin, y, card.Brand, brandScale, accent)
+		y += (glyphHeight + 6) * brandScale
+	}
+
+	contentWidth := Width - 2*margin
+	for _, line := range wrapText(card.Title, charsIn(contentWidth, titleScale), titleLines) {
+		drawText(img, margin, y, line, titleScale, foreground)
+		y += (glyphHeight + 2) * titleScale
+	}
+	if card.Byline != "" {
+		y += bylineGap
+		drawText(img, margin, y, fitText("by "+card.Byline, charsIn(contentWidth, textScale)), textScale, muted)
+		y += glyphHeight * textScale
+	}
+
+	y += 30
+	photo := image.Rect(margin, y, Width-margin, y+photoHeight)
+	fill(img, photo, panel)
+	if card.Photo != nil {
+		drawCover(img, photo, card.Photo)
+	} else {
+		text := "No photo"
+		drawText(img, photo.Min.X+(photo.Dx()-textWidth(text, textScale))/2, photo.Min.Y+(photo.Dy()-glyphHeight*textScale)/2, text, textScale, muted)
+	}
+	y = photo.Max.Y + 40
+
+	// The QR code sits in the bottom right corner; specs fill the space above
+	footerTop := Height - margin
+	if qr != nil {
+		side := (qr.size + 2*qrQuiet) * qrScale
+		footerTop = Height - margin - side
+		drawQR(img, Width-margin-side, footerTop, qr)
+
+		textX := margin
+		textRight := Width - margin - side - 30
+		drawText(img, textX, footerTop+side-90, "Scan for the full build", brandScale, foreground)
+		link := strings.TrimPrefix(strings.TrimPrefix(card.Link, "https://"), "http://")
+		drawText(img, textX, footerTop+side-50, fitText(link, charsIn(textRight-textX, linkScale)), linkScale, muted)
+	}
+
+	labelWidth := labelChars * glyphAdvance * textScale
+	valueChars := charsIn(contentWidth-labelWidth, textScale)
+	for _, spec := range card.Specs {
+		if y+rowHeight > footerTop-20 {
+			break
+		}
+		drawText(img, margin, y, fitText(spec.Label, labelChars-1), textScale, accent)
+		drawText(img, margin+labelWidth, y, fitText(spec.Value, valueChars), textScale, foreground)
+		y += rowHeight
+	}
+
+	var out bytes.Buffer
+	if err := png.Encode(&out, img); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// charsIn returns how many characters fit in width at scale
+func charsIn(width, scale int) int {
+	return (width + scale) / (glyphAdvance * scale)
+}
+
+func fill(dst draw.Image, r image.Rectangle, c color.Color) {
+	draw.Draw(dst, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// drawCover scales src to cover r, cropping the overflow evenly from both
+// sides. Each destination pixel averages the source pixels under it, so
+// large photos shrink without aliasing.
+func drawCover(dst *image.RGBA, r image.Rectangle, src image.Image) {
+	sb := src.Bounds()
+	if sb.Empty() {
+		return
+	}
+
+	// Crop the source to the destination's aspect ratio
+	crop := sb
+	if sb.Dx()*r.Dy() > sb.Dy()*r.Dx() {
+		w := sb.Dy() * r.Dx() / r.Dy()
+		crop.Min.X += (sb.Dx() - w) / 2
+		crop.Max.X = crop.Min.X + w
+	} else {
+		h := sb.Dx() * r.Dy() / r.Dx()
+		crop.Min.Y += (sb.Dy() - h) / 2
+		crop.Max.Y = crop.Min.Y + h
+	}
+	if crop.Empty() {
+		return
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, crop.Dx(), crop.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, crop.Min, draw.Src)
+
+	sw, sh := crop.Dx(), crop.Dy()
+	dw, dh := r.Dx(), r.Dy()
+	for dy := 0; dy < dh; dy++ {
+		y0, y1 := dy*sh/dh, (dy+1)*sh/dh
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for dx := 0; dx < dw; dx++ {
+			x0, x1 := dx*sw/dw, (dx+1)*sw/dw
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var red, green, blue, alpha uint64
+			for y := y0; y < y1; y++ {
+				i := rgba.PixOffset(x0, y)
+				for x := x0; x < x1; x++ {
+					red += uint64(rgba.Pix[i])
+					green += uint64(rgba.Pix[i+1])
+					blue += uint64(rgba.Pix[i+2])
+					alpha += uint64(rgba.Pix[i+3])
+					i += 4
+				}
+			}
+
+			// RGBA is premultiplied, so transparent photos composite over
+			// the panel by adding what shows through
+			n := uint64((x1 - x0) * (y1 - y0))
+			through := 255 - alpha/n
+			di := dst.PixOffset(r.Min.X+dx, r.Min.Y+dy)
+			dst.Pix[di] = uint8(red/n + uint64(dst.Pix[di])*through/255)
+			dst.Pix[di+1] = uint8(green/n + uint64(dst.Pix[di+1])*through/255)
+			dst.Pix[di+2] = uint8(blue/n + uint64(dst.Pix[di+2])*through/255)
+			dst.Pix[di+3] = 0xFF
+		}
+	}
+}
+
+// drawQR draws the code with its quiet zone, top left at (x, y)
+func drawQR(dst draw.Image, x, y int, qr *qrCode) {
+	side := (qr.size + 2*qrQuiet) * qrScale
+	fill(dst, image.Rect(x, y, x+side, y+side), color.White)
+	for row := 0; row < qr.size; row++ {
+		for col := 0; col < qr.size; col++ {
+			if !qr.modules[row][col] {
+				continue
+			}
+			px := x + (col+qrQuiet)*qrScale
+			py := y + (row+qrQuiet)*qrScale
+			fill(dst, image.Rect(px, py, px+qrScale, py+qrScale), color.Black)
+		}
+	}
+}
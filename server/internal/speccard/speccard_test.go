@@ -0,0 +1,72 @@
+package speccard
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	photo := image.NewRGBA(image.Rect(0, 0, 400, 100))
+	red := color.RGBA{R: 0xFF, A: 0xFF}
+	for i := 0; i < len(photo.Pix); i += 4 {
+		copy(photo.Pix[i:], []byte{red.R, red.G, red.B, red.A})
+	}
+
+	data, err := Render(Card{
+		Brand:  "FlyingForge",
+		Title:  "Freestyle 5\" Analog Ripper",
+		Byline: "skyhawk",
+		Photo:  photo,
+		Specs: []Spec{
+			{Label: "Frame", Value: "ImpulseRC Apex 5"},
+			{Label: "Motors", Value: "T-Motor F60 Pro V 2207.5 1950KV"},
+		},
+		Link: "https://flyingforge.example/builds/b-1",
+	})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decoding the card: %v", err)
+	}
+	if got := img.Bounds().Size(); got != image.Pt(Width, Height) {
+		t.Errorf("card size = %v, want %dx%d", got, Width, Height)
+	}
+
+	// The wide photo is cropped to fill its area edge to edge
+	for _, pt := range []image.Point{{margin + 1, 400}, {Width - margin - 2, 400}} {
+		if got := color.RGBAModel.Convert(img.At(pt.X, pt.Y)); got != red {
+			t.Errorf("pixel at %v = %v, want the photo's %v", pt, got, red)
+		}
+	}
+
+	if _, err := Render(Card{Title: "Too long", Link: "https://example.com/" + strings.Repeat("x", 200)}); err != ErrLinkTooLong {
+		t.Errorf("Render() with a long link error = %v, want ErrLinkTooLong", err)
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		text     string
+		maxChars int
+		maxLines int
+		want     []string
+	}{
+		{"Apex 5", 10, 2, []string{"Apex 5"}},
+		{"Budget freestyle quad build", 12, 2, []string{"Budget", "freestyle..."}},
+		{"Budget freestyle quad", 12, 3, []string{"Budget", "freestyle", "quad"}},
+		{"Supercalifragilistic", 10, 2, []string{"Superca..."}},
+	}
+	for _, tt := range tests {
+		if got := wrapText(tt.text, tt.maxChars, tt.maxLines); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("wrapText(%q, %d, %d) = %q, want %q", tt.text, tt.maxChars, tt.maxLines, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+var (
+	slugPattern     = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,49}$`)
+	hostnamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`)
+	colorPattern    = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+// Store defines the tenant persistence operations
+type Store interface {
+	List(ctx context.Context) ([]models.Tenant, error)
+	Create(ctx context.Context, params models.SaveTenantParams) (*models.Tenant, error)
+	Update(ctx context.Context, id string, params models.SaveTenantParams) (*models.Tenant, error)
+	Conflicts(ctx context.Context, excludeID, slug string, hostnames []string) (bool, error)
+}
+
+// Service resolves which tenant serves a request and manages tenants. The
+// hostname mapping is cached in memory and reloaded by Refresh. Requests for
+// hostnames no tenant claims are served by the default tenant.
+type Service struct {
+	store   Store
+	enabled bool
+	logger  *logging.Logger
+
+	mu            sync.RWMutex
+	byHost        map[string]*models.Tenant
+	defaultTenant *models.Tenant
+}
+
+// NewService creates a new tenancy service. When enabled is false every
+// request resolves to the default tenant and queries stay unscoped.
+func NewService(store *database.TenantStore, enabled bool, logger *logging.Logger) *Service {
+	return &Service{
+		store:         store,
+		enabled:       enabled,
+		logger:        logger,
+		byHost:        map[string]*models.Tenant{},
+		defaultTenant: &models.Tenant{ID: database.DefaultTenantID, Slug: "default", Name: "FlyingForge"},
+	}
+}
+
+// Enabled reports whether requests are scoped to the tenant serving them
+func (s *Service) Enabled() bool {
+	return s.enabled
+}
+
+// Refresh reloads the hostname mapping from the store
+func (s *Service) Refresh(ctx context.Context) error {
+	tenants, err := s.store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	byHost := make(map[string]*models.Tenant)
+	var defaultTenant *models.Tenant
+	for i := range tenants {
+		tenant := &tenants[i]
+		if tenant.ID == database.DefaultTenantID {
+			defaultTenant = tenant
+		}
+		for _, host := range tenant.Hostnames {
+			byHost[host] = tenant
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.byHost = byHost
+	if defaultTenant != nil {
+		s.defaultTenant = defaultTenant
+	}
+	return nil
+}
+
+// Resolve returns the tenant serving a request's Host header
+func (s *Service) Resolve(host string) *models.Tenant {
+	if s.enabled {
+		if tenant, ok := s.Lookup(host); ok {
+			return tenant
+		}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.defaultTenant
+}
+
+// Lookup returns the tenant a hostname is mapped to, without falling back
+// to the default tenant
+func (s *Service) Lookup(host string) (*models.Tenant, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tenant, ok := s.byHost[normalizeHost(host)]
+	return tenant, ok
+}
+
+// Public returns the site configuration the frontend needs for a tenant,
+// with every feature listed
+func (s *Service) Public(tenant *models.Tenant) *models.PublicTenant {
+	features := make(map[models.TenantFeature]bool, len(models.TenantFeatures))
+	for _, feature := range models.TenantFeatures {
+		features[feature] = tenant.FeatureEnabled(feature)
+	}
+	return &models.PublicTenant{
+		Slug:     tenant.Slug,
+		Name:     tenant.Name,
+		Branding: tenant.Branding,
+		Features: features,
+	}
+}
+
+// List returns every tenant
+func (s *Service) List(ctx context.Context) (*models.TenantListResponse, error) {
+	tenants, err := s.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &models.TenantListResponse{Tenants: tenants}, nil
+}
+
+// Create validates and adds a tenant
+func (s *Service) Create(ctx context.Context, params models.SaveTenantParams) (*models.Tenant, error) {
+	params, err := s.validate(ctx, "", params)
+	if err != nil {
+		return nil, err
+	}
+	tenant, err := s.store.Create(ctx, params)
+	if errors.Is(err, database.ErrTenantConflict) {
+		return nil, &ServiceError{Message: "slug or hostname is already used by another tenant"}
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.refreshAfterWrite(ctx)
+	return tenant, nil
+}
+
+// Update validates and replaces a tenant's settings, returning nil if it
+// doesn't exist
+func (s *Service) Update(ctx context.Context, id string, params models.SaveTenantParams) (*models.Tenant, error) {
+	params, err := s.validate(ctx, id, params)
+	if err != nil {
+		return nil, err
+	}
+	tenant, err := s.store.Update(ctx, id, params)
+	if err != nil || tenant == nil {
+		return tenant, err
+	}
+	s.refreshAfterWrite(ctx)
+	return tenant, nil
+}
+
+func (s *Service) refreshAfterWrite(ctx context.Context) {
+	if err := s.Refresh(ctx); err != nil {
+		s.logger.Warn("Failed to reload tenants after a change", logging.WithField("error", err.Error()))
+	}
+}
+
+func (s *Service) validate(ctx context.Context, id string, params models.SaveTenantParams) (models.SaveTenantParams, error) {
+	params.Slug = strings.ToLower(strings.TrimSpace(params.Slug))
+	params.Name = strings.TrimSpace(params.Name)
+	if !slugPattern.MatchString(params.Slug) {
+		return params, &ServiceError{Message: "slug must be 2-50 lowercase letters, digits or dashes"}
+	}
+	if params.Name == "" || len(params.Name) > 100 {
+		return params, &ServiceError{Message: "name is required and must be at most 100 characters"}
+	}
+
+	hostnames := make([]string, 0, len(params.Hostnames))
+	seen := make(map[string]bool, len(params.Hostnames))
+	for _, host := range params.Hostnames {
+		host = normalizeHost(host)
+		if !hostnamePattern.MatchString(host) || len(host) > 253 {
+			return params, &ServiceError{Message: fmt.Sprintf("invalid hostname %q", host)}
+		}
+		if !seen[host] {
+			seen[host] = true
+			hostnames = append(hostnames, host)
+		}
+	}
+	params.Hostnames = hostnames
+
+	for feature := range params.Features {
+		if !models.IsValidTenantFeature(feature) {
+			return params, &ServiceError{Message: fmt.Sprintf("unknown feature %q", feature)}
+		}
+	}
+
+	branding := params.Branding
+	for _, color := range []string{branding.PrimaryColor, branding.AccentColor} {
+		if color != "" && !colorPattern.MatchString(color) {
+			return params, &ServiceError{Message: "branding colors must be #rrggbb"}
+		}
+	}
+	for _, link := range []string{branding.LogoURL, branding.FaviconURL} {
+		if link != "" && !isHTTPURL(link) {
+			return params, &ServiceError{Message: "branding image URLs must be http or https"}
+		}
+	}
+	if len(branding.DisplayName) > 100 || len(branding.SupportEmail) > 255 {
+		return params, &ServiceError{Message: "branding display name or support email is too long"}
+	}
+
+	conflict, err := s.store.Conflicts(ctx, id, params.Slug, params.Hostnames)
+	if err != nil {
+		return params, err
+	}
+	if conflict {
+		return params, &ServiceError{Message: "slug or hostname is already used by another tenant"}
+	}
+	return params, nil
+}
+
+// normalizeHost lowercases a Host header and strips its port and any
+// trailing dot
+func normalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, port, err := net.SplitHostPort(host); err == nil {
+		if _, err := strconv.Atoi(port); err == nil {
+			host = h
+		}
+	}
+	return strings.TrimSuffix(host, ".")
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+type contextKey struct{}
+
+// WithTenant returns a context for a request served by tenant. Queries made
+// with it are scoped to the tenant when scoped is true.
+func WithTenant(ctx context.Context, tenant *models.Tenant, scoped bool) context.Context {
+	ctx = context.WithValue(ctx, contextKey{}, tenant)
+	if scoped {
+		ctx = database.WithTenant(ctx, tenant.ID)
+	}
+	return ctx
+}
+
+// FromContext returns the tenant serving a request, or nil outside one
+func FromContext(ctx context.Context) *models.Tenant {
+	tenant, _ := ctx.Value(contextKey{}).(*models.Tenant)
+	return tenant
+}
+
+// ServiceError represents a tenant request error that should be shown to the client
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,154 @@
+package tenancy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+// mockStore keeps tenants in memory
+type mockStore struct {
+	tenants  []models.Tenant
+	conflict bool
+	created  *models.SaveTenantParams
+}
+
+func (m *mockStore) List(ctx context.Context) ([]models.Tenant, error) {
+	return m.tenants, nil
+}
+
+func (m *mockStore) Create(ctx context.Context, params models.SaveTenantParams) (*models.Tenant, error) {
+	m.created = &params
+	tenant := models.Tenant{ID: "tenant-new", Slug: params.Slug, Name: params.Name, Hostnames: params.Hostnames}
+	m.tenants = append(m.tenants, tenant)
+	return &tenant, nil
+}
+
+func (m *mockStore) Update(ctx context.Context, id string, params models.SaveTenantParams) (*models.Tenant, error) {
+	return nil, nil
+}
+
+func (m *mockStore) Conflicts(ctx context.Context, excludeID, slug string, hostnames []string) (bool, error) {
+	return m.conflict, nil
+}
+
+func newTestService(store *mockStore, enabled bool) *Service {
+	return &Service{
+		store:         store,
+		enabled:       enabled,
+		logger:        testutil.NullLogger(),
+		byHost:        map[string]*models.Tenant{},
+		defaultTenant: &models.Tenant{ID: database.DefaultTenantID, Slug: "default"},
+	}
+}
+
+func TestResolve(t *testing.T) {
+	store := &mockStore{tenants: []models.Tenant{
+		{ID: database.DefaultTenantID, Slug: "default", Name: "FlyingForge", Hostnames: []string{"flyingforge.app"}},
+		{ID: "tenant-club", Slug: "club", Name: "Club", Hostnames: []string{"fpv.club.example"}},
+	}}
+	svc := newTestService(store, true)
+	if err := svc.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"fpv.club.example", "club"},
+		{"FPV.Club.Example:443", "club"},
+		{"fpv.club.example.", "club"},
+		{"flyingforge.app", "default"},
+		{"localhost:8080", "default"},
+	}
+	for _, tt := range tests {
+		if got := svc.Resolve(tt.host).Slug; got != tt.want {
+			t.Errorf("Resolve(%q) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+
+	if _, ok := svc.Lookup("localhost:8080"); ok {
+		t.Error("Lookup() of an unmapped host found a tenant, want no fallback")
+	}
+	if tenant, ok := svc.Lookup("FPV.Club.Example"); !ok || tenant.Slug != "club" {
+		t.Errorf("Lookup() = %v, %v; want club", tenant, ok)
+	}
+
+	disabled := newTestService(store, false)
+	_ = disabled.Refresh(context.Background())
+	if got := disabled.Resolve("fpv.club.example").ID; got != database.DefaultTenantID {
+		t.Errorf("disabled Resolve() = %q, want default tenant", got)
+	}
+}
+
+func TestPublic_ListsEveryFeature(t *testing.T) {
+	svc := newTestService(&mockStore{}, true)
+	public := svc.Public(&models.Tenant{Slug: "club", Features: map[models.TenantFeature]bool{models.TenantFeatureEvents: false}})
+
+	if len(public.Features) != len(models.TenantFeatures) {
+		t.Fatalf("features = %v", public.Features)
+	}
+	if public.Features[models.TenantFeatureEvents] {
+		t.Error("events should be disabled")
+	}
+	if !public.Features[models.TenantFeatureGroups] {
+		t.Error("groups should default to enabled")
+	}
+}
+
+func TestCreate_Validation(t *testing.T) {
+	valid := func() models.SaveTenantParams {
+		return models.SaveTenantParams{Slug: "club", Name: "Club", Hostnames: []string{"fpv.club.example"}}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*models.SaveTenantParams)
+	}{
+		{"bad slug", func(p *models.SaveTenantParams) { p.Slug = "Club!" }},
+		{"missing name", func(p *models.SaveTenantParams) { p.Name = " " }},
+		{"bad hostname", func(p *models.SaveTenantParams) { p.Hostnames = []string{"https://club.example"} }},
+		{"unknown feature", func(p *models.SaveTenantParams) {
+			p.Features = map[models.TenantFeature]bool{"marketplace": true}
+		}},
+		{"bad color", func(p *models.SaveTenantParams) { p.Branding.PrimaryColor = "red" }},
+		{"bad logo url", func(p *models.SaveTenantParams) { p.Branding.LogoURL = "javascript:alert(1)" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := valid()
+			tt.mutate(&params)
+			_, err := newTestService(&mockStore{}, true).Create(context.Background(), params)
+			if _, ok := err.(*ServiceError); !ok {
+				t.Fatalf("Create() error = %v, want ServiceError", err)
+			}
+		})
+	}
+
+	t.Run("conflict", func(t *testing.T) {
+		_, err := newTestService(&mockStore{conflict: true}, true).Create(context.Background(), valid())
+		if _, ok := err.(*ServiceError); !ok {
+			t.Fatalf("Create() error = %v, want ServiceError", err)
+		}
+	})
+
+	t.Run("normalizes and serves new hostnames", func(t *testing.T) {
+		store := &mockStore{}
+		svc := newTestService(store, true)
+		params := valid()
+		params.Hostnames = []string{"FPV.Club.Example", "fpv.club.example:443"}
+		if _, err := svc.Create(context.Background(), params); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if len(store.created.Hostnames) != 1 || store.created.Hostnames[0] != "fpv.club.example" {
+			t.Errorf("hostnames = %v", store.created.Hostnames)
+		}
+		if got := svc.Resolve("fpv.club.example").Slug; got != "club" {
+			t.Errorf("Resolve() after create = %q", got)
+		}
+	})
+}
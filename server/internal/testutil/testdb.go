@@ -15,7 +15,7 @@ import (
 // TestDB wraps a test database connection
 type TestDB struct {
 	*sql.DB
-	t *testing.T
+	t testing.TB
 }
 
 // getTestDSN builds the DSN from environment variables or defaults
@@ -27,8 +27,10 @@ func getTestDSN() string {
 	dbname := getEnvOrDefault("DB_NAME", "flyingforge_test")
 	sslmode := getEnvOrDefault("DB_SSLMODE", "disable")
 
+	// Test connections bypass the store layer's tenant scoping, so they see
+	// every tenant's rows like a migration does
 	return fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s options='-c app.tenant_id=*'",
 		host, port, user, password, dbname, sslmode,
 	)
 }
@@ -42,7 +44,7 @@ func getEnvOrDefault(key, defaultValue string) string {
 
 // NewTestDB creates a new test database connection
 // It skips the test if the database is not available or schema is not set up
-func NewTestDB(t *testing.T) *TestDB {
+func NewTestDB(t testing.TB) *TestDB {
 	t.Helper()
 
 	dsn := getTestDSN()
@@ -0,0 +1,204 @@
+// Package textpdf writes simple text-only PDF documents: headings, lines of
+// text, and fixed-width table rows on US Letter pages in Helvetica. It has
+// no layout engine and is meant for server-rendered exports such as parts
+// lists.
+package textpdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+const (
+	pageWidth    = 612.0 // US Letter, in points
+	pageHeight   = 792.0
+	margin       = 54.0
+	textSize     = 10.0
+	headingSize  = 16.0
+	lineSpacing  = 1.4
+	avgCharWidth = 0.5 // Rough Helvetica advance width as a fraction of the font size
+)
+
+// ContentWidth is the usable width of a page in points.
+const ContentWidth = pageWidth - 2*margin
+
+type textRun struct {
+	x, y float64
+	size float64
+	bold bool
+	text string
+}
+
+// Document accumulates pages of text. The zero value is not usable; create
+// documents with New.
+type Document struct {
+	pages [][]textRun
+	y     float64
+}
+
+// New creates an empty document with one blank page.
+func New() *Document {
+	d := &Document{}
+	d.newPage()
+	return d
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, nil)
+	d.y = pageHeight - margin
+}
+
+// advance moves down by one line of the given size, starting a new page when
+// the line would not fit, and returns the baseline to draw at.
+func (d *Document) advance(size float64) float64 {
+	height := size * lineSpacing
+	if d.y-height < margin {
+		d.newPage()
+	}
+	d.y -= height
+	return d.y
+}
+
+func (d *Document) add(run textRun) {
+	last := len(d.pages) - 1
+	d.pages[last] = append(d.pages[last], run)
+}
+
+// Heading writes a line of bold heading text.
+func (d *Document) Heading(text string) {
+	y := d.advance(headingSize)
+	d.add(textRun{x: margin, y: y, size: headingSize, bold: true, text: fit(text, ContentWidth, headingSize)})
+}
+
+// Text writes a line of body text, truncated to the page width.
+func (d *Document) Text(text string) {
+	y := d.advance(textSize)
+	d.add(textRun{x: margin, y: y, size: textSize, text: fit(text, ContentWidth, textSize)})
+}
+
+// Gap leaves an empty line.
+func (d *Document) Gap() {
+	d.advance(textSize)
+}
+
+// Row writes one table row. Each cell starts at the sum of the preceding
+// widths and is truncated to its own width.
+func (d *Document) Row(cells []string, widths []float64, bold bool) {
+	y := d.advance(textSize)
+	x := margin
+	for i, cell := range cells {
+		if i >= len(widths) {
+			break
+		}
+		if cell != "" {
+			d.add(textRun{x: x, y: y, size: textSize, bold: bold, text: fit(cell, widths[i], textSize)})
+		}
+		x += widths[i]
+	}
+}
+
+// PageCount returns the number of pages written so far.
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// Bytes renders the document as a PDF file.
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	var offsets []int
+
+	// Objects 1-4 are fixed; each page then takes a page object followed by
+	// its content stream.
+	startObject := func() int {
+		offsets = append(offsets, buf.Len())
+		n := len(offsets)
+		fmt.Fprintf(&buf, "%d 0 obj\n", n)
+		return n
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	startObject()
+	buf.WriteString("<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	startObject()
+	fmt.Fprintf(&buf, "<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", strings.Join(kids, " "), len(d.pages))
+
+	startObject()
+	buf.WriteString("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>\nendobj\n")
+	startObject()
+	buf.WriteString("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>\nendobj\n")
+
+	for _, runs := range d.pages {
+		page := startObject()
+		fmt.Fprintf(&buf, "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %g %g] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>\nendobj\n",
+			pageWidth, pageHeight, page+1)
+
+		var content bytes.Buffer
+		for _, run := range runs {
+			font := "F1"
+			if run.bold {
+				font = "F2"
+			}
+			fmt.Fprintf(&content, "BT /%s %g Tf %.2f %.2f Td (%s) Tj ET\n", font, run.size, run.x, run.y, escape(run.text))
+		}
+		startObject()
+		fmt.Fprintf(&buf, "<< /Length %d >>\nstream\n", content.Len())
+		buf.Write(content.Bytes())
+		buf.WriteString("endstream\nendobj\n")
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+// fit truncates text that would overflow width at the given font size
+func fit(text string, width, size float64) string {
+	text = strings.Join(strings.Fields(text), " ")
+	maxChars := int(width / (size * avgCharWidth))
+	runes := []rune(text)
+	if len(runes) <= maxChars {
+		return text
+	}
+	if maxChars <= 3 {
+		return string(runes[:maxChars])
+	}
+	return string(runes[:maxChars-3]) + "..."
+}
+
+// escape encodes text as WinAnsi and escapes it for a PDF string literal.
+// Characters outside WinAnsi are replaced with '?'.
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		c, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok || c < 0x20 {
+			c = '?'
+		}
+		switch c {
+		case '\\', '(', ')':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			if c < 0x80 {
+				b.WriteByte(c)
+			} else {
+				fmt.Fprintf(&b, "\\%03o", c)
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,83 @@
+package textpdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBytesWritesValidStructure(t *testing.T) {
+	doc := New()
+	doc.Heading("Parts list")
+	doc.Text("Generated for testing")
+	doc.Row([]string{"Motor", "4"}, []float64{200, 50}, false)
+
+	out := doc.Bytes()
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4\n")) {
+		t.Fatalf("missing PDF header")
+	}
+	if !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatalf("missing EOF marker")
+	}
+	if !bytes.Contains(out, []byte("(Parts list) Tj")) {
+		t.Fatalf("heading text not found in output")
+	}
+
+	// Every xref entry must point at the start of its object
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	if m == nil {
+		t.Fatalf("missing startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	lines := strings.Split(string(out[xref:]), "\n")
+	if lines[0] != "xref" {
+		t.Fatalf("startxref does not point at xref table")
+	}
+	count, _ := strconv.Atoi(strings.Fields(lines[1])[1])
+	for i := 1; i < count; i++ {
+		offset, _ := strconv.Atoi(strings.Fields(lines[2+i])[0])
+		want := fmt.Sprintf("%d 0 obj", i)
+		if !bytes.HasPrefix(out[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q", i, out[offset:offset+10])
+		}
+	}
+}
+
+func TestDocumentPaginates(t *testing.T) {
+	doc := New()
+	for i := 0; i < 200; i++ {
+		doc.Text(fmt.Sprintf("line %d", i))
+	}
+	if doc.PageCount() < 2 {
+		t.Fatalf("expected multiple pages, got %d", doc.PageCount())
+	}
+	if got := bytes.Count(doc.Bytes(), []byte("/Type /Page ")); got != doc.PageCount() {
+		t.Errorf("page objects = %d, want %d", got, doc.PageCount())
+	}
+}
+
+func TestEscape(t *testing.T) {
+	cases := map[string]string{
+		`a (b) \c`:  `a \(b\) \\c`,
+		"café":      `caf\351`,
+		"5.8 GHz ✓": "5.8 GHz ?",
+	}
+	for in, want := range cases {
+		if got := escape(in); got != want {
+			t.Errorf("escape(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFitTruncates(t *testing.T) {
+	got := fit(strings.Repeat("x", 100), 50, textSize)
+	if len(got) != 10 || !strings.HasSuffix(got, "...") {
+		t.Errorf("fit() = %q", got)
+	}
+	if got := fit("short", 50, textSize); got != "short" {
+		t.Errorf("fit() = %q, want unchanged", got)
+	}
+}
@@ -0,0 +1,208 @@
+// Package units keeps catalog spec values that carry a unit comparable.
+// Weights, lengths and battery capacities are stored as plain numbers in
+// grams, millimetres and mAh, whatever unit they were entered in, so numeric
+// filters read them directly. Readers get them back formatted in their
+// preferred unit system.
+package units
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// quantity is a kind of measurement with one canonical unit
+type quantity struct {
+	unit string
+	// factors converts each accepted unit, lower case, to the canonical unit
+	factors map[string]float64
+	// imperialUnit is the unit shown to imperial readers; empty shows the
+	// canonical unit to everyone
+	imperialUnit   string
+	imperialFactor float64
+}
+
+const (
+	gramsPerOunce = 28.349523125
+	mmPerInch     = 25.4
+)
+
+var (
+	mass = &quantity{
+		unit: "g",
+		factors: map[string]float64{
+			"g": 1, "gr": 1, "gram": 1, "grams": 1,
+			"kg": 1000,
+			"oz": gramsPerOunce, "ounce": gramsPerOunce, "ounces": gramsPerOunce,
+			"lb": 453.59237, "lbs": 453.59237,
+		},
+		imperialUnit:   "oz",
+		imperialFactor: gramsPerOunce,
+	}
+	length = &quantity{
+		unit: "mm",
+		factors: map[string]float64{
+			"mm": 1,
+			"cm": 10,
+			"m":  1000,
+			"in": mmPerInch, "inch": mmPerInch, "inches": mmPerInch, `"`: mmPerInch,
+		},
+		imperialUnit:   "in",
+		imperialFactor: mmPerInch,
+	}
+	capacity = &quantity{
+		unit: "mAh",
+		factors: map[string]float64{
+			"mah": 1,
+			"ah":  1000,
+		},
+	}
+)
+
+// specQuantities maps spec keys to what they measure. Size isn't listed:
+// frame and prop sizes name a class in inches rather than a measurement.
+var specQuantities = map[string]*quantity{
+	"weight":       mass,
+	"weightGrams":  mass,
+	"weight_g":     mass,
+	"wheelbase":    length,
+	"length":       length,
+	"width":        length,
+	"height":       length,
+	"capacity":     capacity,
+	"capacityMah":  capacity,
+	"capacity_mah": capacity,
+}
+
+// NormalizeSpecs converts the unit-bearing values in catalog specs to plain
+// numbers in canonical units. It returns the new specs and the original text
+// of every value it converted. Values in an unknown unit are left as they
+// are, and specs that aren't a JSON object are returned unchanged.
+func NormalizeSpecs(specs json.RawMessage) (json.RawMessage, map[string]string) {
+	fields, ok := decode(specs)
+	if !ok {
+		return specs, nil
+	}
+
+	originals := make(map[string]string)
+	for key, raw := range fields {
+		q := specQuantities[key]
+		if q == nil {
+			continue
+		}
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			continue // numbers are already canonical
+		}
+		value, ok := q.parse(text)
+		if !ok {
+			continue
+		}
+		fields[key] = number(value)
+		originals[key] = text
+	}
+	if len(originals) == 0 {
+		return specs, nil
+	}
+
+	normalized, err := json.Marshal(fields)
+	if err != nil {
+		return specs, nil
+	}
+	return normalized, originals
+}
+
+// LocalizeSpecs formats the canonical numbers in catalog specs with their
+// unit in the given system, e.g. 32 becomes "32 g" or "1.13 oz". Values that
+// were never normalized are left as they are.
+func LocalizeSpecs(specs json.RawMessage, system models.UnitSystem) json.RawMessage {
+	fields, ok := decode(specs)
+	if !ok {
+		return specs
+	}
+
+	changed := false
+	for key, raw := range fields {
+		q := specQuantities[key]
+		if q == nil {
+			continue
+		}
+		var value float64
+		if err := json.Unmarshal(raw, &value); err != nil {
+			continue
+		}
+		text, _ := json.Marshal(q.format(value, system))
+		fields[key] = text
+		changed = true
+	}
+	if !changed {
+		return specs
+	}
+
+	localized, err := json.Marshal(fields)
+	if err != nil {
+		return specs
+	}
+	return localized
+}
+
+// parse reads a value such as "1.2 oz", "1300mAh" or "32" in this quantity.
+// A bare number is taken to be in the canonical unit.
+func (q *quantity) parse(text string) (float64, bool) {
+	text = strings.TrimSpace(text)
+	end := 0
+	for end < len(text) && (unicode.IsDigit(rune(text[end])) || text[end] == '.') {
+		end++
+	}
+	if end == 0 {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(strings.TrimSuffix(text[:end], "."), 64)
+	if err != nil {
+		return 0, false
+	}
+
+	unit := strings.ToLower(strings.TrimSpace(text[end:]))
+	if unit == "" {
+		return value, true
+	}
+	factor, ok := q.factors[unit]
+	if !ok {
+		return 0, false
+	}
+	return round(value * factor), true
+}
+
+// format writes a canonical value in the given unit system
+func (q *quantity) format(value float64, system models.UnitSystem) string {
+	unit := q.unit
+	if system == models.UnitSystemImperial && q.imperialUnit != "" {
+		value /= q.imperialFactor
+		unit = q.imperialUnit
+	}
+	return strconv.FormatFloat(round(value), 'f', -1, 64) + " " + unit
+}
+
+func decode(specs json.RawMessage) (map[string]json.RawMessage, bool) {
+	if len(specs) == 0 {
+		return nil, false
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(specs, &fields); err != nil || fields == nil {
+		return nil, false
+	}
+	return fields, true
+}
+
+func number(value float64) json.RawMessage {
+	return json.RawMessage(strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// round keeps two decimals, enough for grams and hundredths of an inch
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
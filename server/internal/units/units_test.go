@@ -0,0 +1,89 @@
+package units
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+func TestNormalizeSpecs(t *testing.T) {
+	specs := json.RawMessage(`{"weight": "1.5oz", "wheelbase": "8.9 in", "capacity": "1.3Ah", "size": "5\"", "kv": "1950kv", "height": 30}`)
+
+	normalized, originals := NormalizeSpecs(specs)
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(normalized, &fields); err != nil {
+		t.Fatalf("normalized specs are not JSON: %v", err)
+	}
+	want := map[string]interface{}{
+		"weight":    42.52,
+		"wheelbase": 226.06,
+		"capacity":  float64(1300),
+		"size":      `5"`,
+		"kv":        "1950kv",
+		"height":    float64(30),
+	}
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("%s = %v, want %v", key, fields[key], value)
+		}
+	}
+
+	if len(originals) != 3 || originals["weight"] != "1.5oz" || originals["wheelbase"] != "8.9 in" || originals["capacity"] != "1.3Ah" {
+		t.Errorf("Unexpected originals %v", originals)
+	}
+}
+
+func TestNormalizeSpecs_LeavesUnknownUnits(t *testing.T) {
+	tests := []string{
+		`{"weight": "light"}`,
+		`{"weight": "32 stone"}`,
+		`{"weight": 32}`,
+		`["not", "an", "object"]`,
+		``,
+	}
+	for _, specs := range tests {
+		normalized, originals := NormalizeSpecs(json.RawMessage(specs))
+		if string(normalized) != specs || originals != nil {
+			t.Errorf("NormalizeSpecs(%s) = %s, %v; want it unchanged", specs, normalized, originals)
+		}
+	}
+}
+
+func TestLocalizeSpecs(t *testing.T) {
+	specs := json.RawMessage(`{"weight": 32, "wheelbase": 225, "capacity": 1300, "kv": "1950kv", "length": "long"}`)
+
+	tests := []struct {
+		system models.UnitSystem
+		want   map[string]string
+	}{
+		{models.UnitSystemMetric, map[string]string{"weight": "32 g", "wheelbase": "225 mm", "capacity": "1300 mAh", "kv": "1950kv", "length": "long"}},
+		{models.UnitSystemImperial, map[string]string{"weight": "1.13 oz", "wheelbase": "8.86 in", "capacity": "1300 mAh", "kv": "1950kv", "length": "long"}},
+	}
+	for _, tt := range tests {
+		var fields map[string]string
+		if err := json.Unmarshal(LocalizeSpecs(specs, tt.system), &fields); err != nil {
+			t.Fatalf("%s: localized specs are not JSON: %v", tt.system, err)
+		}
+		for key, value := range tt.want {
+			if fields[key] != value {
+				t.Errorf("%s: %s = %q, want %q", tt.system, key, fields[key], value)
+			}
+		}
+	}
+}
+
+func TestNormalizeSpecs_RoundTrip(t *testing.T) {
+	// An imperial reader saving what they were shown keeps the same value
+	localized := LocalizeSpecs(json.RawMessage(`{"weight": 425}`), models.UnitSystemImperial)
+	normalized, _ := NormalizeSpecs(localized)
+
+	var fields map[string]float64
+	if err := json.Unmarshal(normalized, &fields); err != nil {
+		t.Fatalf("normalized specs are not JSON: %v", err)
+	}
+	if fields["weight"] < 424.9 || fields["weight"] > 425.1 {
+		t.Errorf("weight = %v, want about 425", fields["weight"])
+	}
+}
@@ -0,0 +1,372 @@
+// Package uploads implements resumable uploads: a file is sent as a series
+// of chunks, each stored as it arrives, so a dropped connection only loses
+// the chunk in flight. Once every byte is in, the chunks are handed in order
+// to the handler registered for the upload's purpose.
+package uploads
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/johnrirwin/flyingforge/internal/blobstore"
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const (
+	// MaxChunkSize is the largest chunk accepted in one request
+	MaxChunkSize = 32 * 1024 * 1024
+	// MaxPendingUploads is how many unfinished uploads a user can have
+	MaxPendingUploads = 5
+	// DefaultExpiry is how long an upload can sit idle before it's removed
+	DefaultExpiry = 24 * time.Hour
+
+	completeTimeout = 15 * time.Minute
+)
+
+var (
+	// ErrNotFound is returned for an upload that doesn't exist or has expired
+	ErrNotFound = errors.New("upload not found")
+	// ErrOffsetConflict is returned when a chunk doesn't start at the
+	// upload's current offset
+	ErrOffsetConflict = errors.New("chunk offset does not match the upload offset")
+	// ErrChunkChecksum is returned when a chunk doesn't match its checksum
+	ErrChunkChecksum = errors.New("chunk checksum mismatch")
+	// ErrNotPending is returned for a chunk sent to a finished upload
+	ErrNotPending = errors.New("upload is already finished")
+)
+
+// ServiceError represents a service-level error
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
+
+// Handler turns finished uploads of one purpose into what they're for
+type Handler interface {
+	// PrepareUpload checks a new upload before any data is sent
+	PrepareUpload(ctx context.Context, userID string, params models.CreateUploadParams) error
+	// CompleteUpload consumes the assembled file and returns the ID of what
+	// it became
+	CompleteUpload(ctx context.Context, upload *models.Upload, file io.Reader) (string, error)
+}
+
+// Store defines the upload persistence operations
+type Store interface {
+	Create(ctx context.Context, userID string, params models.CreateUploadParams, expiresAt time.Time) (*models.Upload, error)
+	Get(ctx context.Context, id, userID string) (*models.Upload, error)
+	CountPending(ctx context.Context, userID string) (int, error)
+	AppendChunk(ctx context.Context, id, userID string, from, to int64, key string, expiresAt time.Time) (*models.Upload, error)
+	Finish(ctx context.Context, id string, status models.UploadStatus, resultID, errMessage string) error
+	Delete(ctx context.Context, id, userID string) (*models.Upload, error)
+	DeleteExpired(ctx context.Context, now time.Time, limit int) ([]models.Upload, error)
+}
+
+// Service manages resumable uploads
+type Service struct {
+	store    Store
+	storage  blobstore.Store
+	handlers map[models.UploadPurpose]Handler
+	expiry   time.Duration
+	now      func() time.Time
+	logger   *logging.Logger
+}
+
+// NewService creates a new upload service. Uploads expire after sitting
+// idle for expiry.
+func NewService(store *database.UploadStore, storage blobstore.Store, expiry time.Duration, logger *logging.Logger) *Service {
+	if expiry <= 0 {
+		expiry = DefaultExpiry
+	}
+	return &Service{
+		store:    store,
+		storage:  storage,
+		handlers: make(map[models.UploadPurpose]Handler),
+		expiry:   expiry,
+		now:      time.Now,
+		logger:   logger,
+	}
+}
+
+// RegisterHandler sets the handler for uploads of a purpose
+func (s *Service) RegisterHandler(purpose models.UploadPurpose, handler Handler) {
+	s.handlers[purpose] = handler
+}
+
+// Create starts an upload
+func (s *Service) Create(ctx context.Context, userID string, params models.CreateUploadParams) (*models.Upload, error) {
+	handler, ok := s.handlers[params.Purpose]
+	if !ok {
+		return nil, &ServiceError{Message: fmt.Sprintf("unknown upload purpose %q", params.Purpose)}
+	}
+	params.FileName = strings.TrimSpace(params.FileName)
+	if params.FileName == "" {
+		return nil, &ServiceError{Message: "fileName is required"}
+	}
+	if len(params.FileName) > 255 {
+		return nil, &ServiceError{Message: "fileName must be at most 255 characters"}
+	}
+	if params.Size <= 0 {
+		return nil, &ServiceError{Message: "size must be greater than zero"}
+	}
+	params.Checksum = strings.ToLower(strings.TrimSpace(params.Checksum))
+	if params.Checksum != "" && !isSHA256Hex(params.Checksum) {
+		return nil, &ServiceError{Message: "checksum must be a hex SHA-256"}
+	}
+	if err := handler.PrepareUpload(ctx, userID, params); err != nil {
+		return nil, err
+	}
+
+	pending, err := s.store.CountPending(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if pending >= MaxPendingUploads {
+		return nil, &ServiceError{Message: fmt.Sprintf("at most %d uploads can be in progress; finish or cancel one first", MaxPendingUploads)}
+	}
+
+	upload, err := s.store.Create(ctx, userID, params, s.now().Add(s.expiry))
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("Started upload", logging.WithFields(map[string]interface{}{
+		"id":      upload.ID,
+		"purpose": upload.Purpose,
+		"size":    upload.Size,
+	}))
+	return upload, nil
+}
+
+// Get returns one of a user's uploads
+func (s *Service) Get(ctx context.Context, userID, id string) (*models.Upload, error) {
+	upload, err := s.store.Get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if upload == nil || (upload.Status == models.UploadStatusPending && s.now().After(upload.ExpiresAt)) {
+		return nil, ErrNotFound
+	}
+	return upload, nil
+}
+
+// WriteChunk stores a chunk starting at offset. checksum is the chunk's
+// SHA-256 in hex, or empty to skip the check. When the chunk completes the
+// upload, it's handed to its handler and the returned upload says whether
+// that worked.
+func (s *Service) WriteChunk(ctx context.Context, userID, id string, offset int64, chunk io.Reader, checksum string) (*models.Upload, error) {
+	upload, err := s.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Status != models.UploadStatusPending {
+		return nil, ErrNotPending
+	}
+	if offset != upload.Offset {
+		return nil, ErrOffsetConflict
+	}
+
+	// Reading one byte past what's left detects chunks that overrun the
+	// upload's size
+	remaining := upload.Size - upload.Offset
+	key := fmt.Sprintf("uploads/%s/%020d-%s", upload.ID, offset, uuid.NewString())
+	object, err := s.storage.Put(ctx, key, io.LimitReader(chunk, remaining+1))
+	if err != nil {
+		s.logger.Warn("Failed to store upload chunk", logging.WithFields(map[string]interface{}{
+			"id":     upload.ID,
+			"offset": offset,
+			"error":  err.Error(),
+		}))
+		return nil, &ServiceError{Message: "failed to store chunk; resume from the current offset"}
+	}
+	switch {
+	case object.Size == 0:
+		s.discardChunk(key)
+		return nil, &ServiceError{Message: "chunk is empty"}
+	case object.Size > remaining:
+		s.discardChunk(key)
+		return nil, &ServiceError{Message: fmt.Sprintf("chunk runs past the upload's size; %d bytes remain", remaining)}
+	case checksum != "" && !strings.EqualFold(checksum, object.Checksum):
+		s.discardChunk(key)
+		return nil, ErrChunkChecksum
+	}
+
+	updated, err := s.store.AppendChunk(ctx, upload.ID, userID, offset, offset+object.Size, key, s.now().Add(s.expiry))
+	if err != nil {
+		s.discardChunk(key)
+		return nil, err
+	}
+	if updated == nil {
+		// Another request stored a chunk at this offset first
+		s.discardChunk(key)
+		return nil, ErrOffsetConflict
+	}
+
+	if updated.Offset == updated.Size {
+		return s.complete(ctx, updated), nil
+	}
+	return updated, nil
+}
+
+// Cancel removes an upload and its chunks
+func (s *Service) Cancel(ctx context.Context, userID, id string) error {
+	upload, err := s.store.Delete(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if upload == nil {
+		return ErrNotFound
+	}
+	s.deleteChunks(ctx, upload)
+	return nil
+}
+
+// CleanupExpired removes uploads past their expiry along with any chunks
+// left behind, and returns how many were removed
+func (s *Service) CleanupExpired(ctx context.Context) (int, error) {
+	removed := 0
+	for {
+		expired, err := s.store.DeleteExpired(ctx, s.now(), 100)
+		if err != nil {
+			return removed, err
+		}
+		for i := range expired {
+			s.deleteChunks(ctx, &expired[i])
+		}
+		removed += len(expired)
+		if len(expired) < 100 {
+			return removed, nil
+		}
+	}
+}
+
+// complete hands a fully received upload to its handler and records the
+// outcome. The chunks are removed either way: a failed upload has to start
+// over. It carries on if the client disconnects, since every byte is in.
+func (s *Service) complete(ctx context.Context, upload *models.Upload) *models.Upload {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), completeTimeout)
+	defer cancel()
+
+	file := &chunkReader{ctx: ctx, storage: s.storage, upload: upload}
+	defer file.Close()
+
+	handler, ok := s.handlers[upload.Purpose]
+	var err error
+	if !ok {
+		err = fmt.Errorf("no handler for upload purpose %q", upload.Purpose)
+	} else {
+		upload.ResultID, err = handler.CompleteUpload(ctx, upload, file)
+	}
+
+	upload.Status = models.UploadStatusCompleted
+	if err != nil {
+		upload.Status = models.UploadStatusFailed
+		upload.ResultID = ""
+		upload.Error = err.Error()
+		s.logger.Warn("Upload failed to complete", logging.WithFields(map[string]interface{}{
+			"id":    upload.ID,
+			"error": err.Error(),
+		}))
+	} else {
+		s.logger.Info("Completed upload", logging.WithFields(map[string]interface{}{
+			"id":       upload.ID,
+			"purpose":  upload.Purpose,
+			"resultId": upload.ResultID,
+		}))
+	}
+
+	if err := s.store.Finish(ctx, upload.ID, upload.Status, upload.ResultID, upload.Error); err != nil {
+		s.logger.Error("Failed to record upload outcome", logging.WithFields(map[string]interface{}{
+			"id":    upload.ID,
+			"error": err.Error(),
+		}))
+	}
+	s.deleteChunks(ctx, upload)
+	return upload
+}
+
+// deleteChunks removes an upload's chunks, logging rather than failing
+func (s *Service) deleteChunks(ctx context.Context, upload *models.Upload) {
+	for _, key := range upload.ChunkKeys {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			s.logger.Warn("Failed to delete upload chunk", logging.WithFields(map[string]interface{}{
+				"id":    upload.ID,
+				"path":  key,
+				"error": err.Error(),
+			}))
+		}
+	}
+}
+
+// discardChunk removes a chunk that was rejected after it was stored. It
+// runs on its own context since the request's may have been cancelled.
+func (s *Service) discardChunk(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := s.storage.Delete(ctx, key); err != nil {
+		s.logger.Warn("Failed to discard rejected upload chunk", logging.WithFields(map[string]interface{}{
+			"path":  key,
+			"error": err.Error(),
+		}))
+	}
+}
+
+func isSHA256Hex(s string) bool {
+	b, err := hex.DecodeString(s)
+	return err == nil && len(b) == 32
+}
+
+// chunkReader reads an upload's chunks one after another, opening each only
+// when the previous one is used up
+type chunkReader struct {
+	ctx     context.Context
+	storage blobstore.Store
+	upload  *models.Upload
+	next    int
+	current io.ReadCloser
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if r.next >= len(r.upload.ChunkKeys) {
+				return 0, io.EOF
+			}
+			body, err := r.storage.Open(r.ctx, r.upload.ChunkKeys[r.next], 0)
+			if err != nil {
+				return 0, fmt.Errorf("failed to open upload chunk: %w", err)
+			}
+			r.current = body
+			r.next++
+		}
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current.Close()
+			r.current = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (r *chunkReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
@@ -0,0 +1,307 @@
+package uploads
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/blobstore"
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+// mockStore keeps uploads in memory
+type mockStore struct {
+	uploads map[string]*models.Upload
+	nextID  int
+}
+
+func (m *mockStore) Create(ctx context.Context, userID string, params models.CreateUploadParams, expiresAt time.Time) (*models.Upload, error) {
+	m.nextID++
+	upload := &models.Upload{
+		ID: fmt.Sprintf("upload-%d", m.nextID), UserID: userID, Purpose: params.Purpose, FileName: params.FileName,
+		Size: params.Size, Checksum: params.Checksum, Metadata: params.Metadata,
+		Status: models.UploadStatusPending, ExpiresAt: expiresAt,
+	}
+	m.uploads[upload.ID] = upload
+	copied := *upload
+	return &copied, nil
+}
+
+func (m *mockStore) Get(ctx context.Context, id, userID string) (*models.Upload, error) {
+	upload, ok := m.uploads[id]
+	if !ok || upload.UserID != userID {
+		return nil, nil
+	}
+	copied := *upload
+	return &copied, nil
+}
+
+func (m *mockStore) CountPending(ctx context.Context, userID string) (int, error) {
+	count := 0
+	for _, upload := range m.uploads {
+		if upload.UserID == userID && upload.Status == models.UploadStatusPending {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockStore) AppendChunk(ctx context.Context, id, userID string, from, to int64, key string, expiresAt time.Time) (*models.Upload, error) {
+	upload, ok := m.uploads[id]
+	if !ok || upload.Offset != from || upload.Status != models.UploadStatusPending {
+		return nil, nil
+	}
+	upload.Offset = to
+	upload.ChunkKeys = append(append([]string(nil), upload.ChunkKeys...), key)
+	upload.ExpiresAt = expiresAt
+	copied := *upload
+	return &copied, nil
+}
+
+func (m *mockStore) Finish(ctx context.Context, id string, status models.UploadStatus, resultID, errMessage string) error {
+	m.uploads[id].Status = status
+	m.uploads[id].ResultID = resultID
+	m.uploads[id].Error = errMessage
+	return nil
+}
+
+func (m *mockStore) Delete(ctx context.Context, id, userID string) (*models.Upload, error) {
+	upload, err := m.Get(ctx, id, userID)
+	if upload != nil {
+		delete(m.uploads, id)
+	}
+	return upload, err
+}
+
+func (m *mockStore) DeleteExpired(ctx context.Context, now time.Time, limit int) ([]models.Upload, error) {
+	var expired []models.Upload
+	for id, upload := range m.uploads {
+		if upload.ExpiresAt.Before(now) && len(expired) < limit {
+			expired = append(expired, *upload)
+			delete(m.uploads, id)
+		}
+	}
+	return expired, nil
+}
+
+// recordingHandler keeps the last completed file
+type recordingHandler struct {
+	file        []byte
+	completeErr error
+}
+
+func (h *recordingHandler) PrepareUpload(ctx context.Context, userID string, params models.CreateUploadParams) error {
+	if params.Metadata["radioId"] == "" {
+		return &ServiceError{Message: "radioId is required"}
+	}
+	return nil
+}
+
+func (h *recordingHandler) CompleteUpload(ctx context.Context, upload *models.Upload, file io.Reader) (string, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+	h.file = data
+	if h.completeErr != nil {
+		return "", h.completeErr
+	}
+	return "backup-1", nil
+}
+
+func newTestService(t *testing.T) (*Service, *mockStore, *recordingHandler, string) {
+	t.Helper()
+	dir := t.TempDir()
+	store := &mockStore{uploads: map[string]*models.Upload{}}
+	handler := &recordingHandler{}
+	svc := &Service{
+		store:    store,
+		storage:  blobstore.NewLocal(dir),
+		handlers: map[models.UploadPurpose]Handler{models.UploadPurposeRadioBackup: handler},
+		expiry:   time.Hour,
+		now:      time.Now,
+		logger:   testutil.NullLogger(),
+	}
+	return svc, store, handler, dir
+}
+
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// chunkFiles counts the chunks left in storage
+func chunkFiles(t *testing.T, dir string) int {
+	t.Helper()
+	count := 0
+	_ = filepath.Walk(filepath.Join(dir, "uploads"), func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			count++
+		}
+		return nil
+	})
+	return count
+}
+
+func createUpload(t *testing.T, svc *Service, data string) *models.Upload {
+	t.Helper()
+	upload, err := svc.Create(context.Background(), "user-1", models.CreateUploadParams{
+		Purpose:  models.UploadPurposeRadioBackup,
+		FileName: "sdcard.img",
+		Size:     int64(len(data)),
+		Checksum: sha256Hex(data),
+		Metadata: map[string]string{"radioId": "radio-1", "backupName": "Before update"},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return upload
+}
+
+func TestService_ResumesAndCompletes(t *testing.T) {
+	svc, _, handler, dir := newTestService(t)
+	ctx := context.Background()
+	upload := createUpload(t, svc, "hello, resumable world")
+
+	got, err := svc.WriteChunk(ctx, "user-1", upload.ID, 0, strings.NewReader("hello, "), sha256Hex("hello, "))
+	if err != nil {
+		t.Fatalf("WriteChunk() error = %v", err)
+	}
+	if got.Offset != 7 || got.Status != models.UploadStatusPending {
+		t.Fatalf("after first chunk = %+v, want offset 7 and pending", got)
+	}
+
+	// Rejected chunks leave the offset where it was
+	rejected := []struct {
+		name     string
+		offset   int64
+		data     string
+		checksum string
+		wantErr  error // nil for a ServiceError
+	}{
+		{name: "retry after the offset moved", offset: 0, data: "hello, ", wantErr: ErrOffsetConflict},
+		{name: "corrupted chunk", offset: 7, data: "resumable", checksum: sha256Hex("garbled"), wantErr: ErrChunkChecksum},
+		{name: "overruns the declared size", offset: 7, data: "resumable world and then some"},
+	}
+	for _, tt := range rejected {
+		_, err := svc.WriteChunk(ctx, "user-1", upload.ID, tt.offset, strings.NewReader(tt.data), tt.checksum)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: error = %v, want %v", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		var svcErr *ServiceError
+		if !errors.As(err, &svcErr) {
+			t.Errorf("%s: error = %v, want ServiceError", tt.name, err)
+		}
+	}
+	if n := chunkFiles(t, dir); n != 1 {
+		t.Errorf("%d chunks stored after rejections, want 1", n)
+	}
+
+	got, err = svc.WriteChunk(ctx, "user-1", upload.ID, 7, strings.NewReader("resumable world"), "")
+	if err != nil {
+		t.Fatalf("WriteChunk() error = %v", err)
+	}
+	if got.Status != models.UploadStatusCompleted || got.ResultID != "backup-1" {
+		t.Errorf("after last chunk = %+v, want completed with result", got)
+	}
+	if string(handler.file) != "hello, resumable world" {
+		t.Errorf("handler got %q, want the assembled file", handler.file)
+	}
+	if n := chunkFiles(t, dir); n != 0 {
+		t.Errorf("%d chunks left after completion, want 0", n)
+	}
+	if _, err := svc.WriteChunk(ctx, "user-1", upload.ID, got.Offset, bytes.NewReader([]byte("x")), ""); !errors.Is(err, ErrNotPending) {
+		t.Errorf("chunk after completion error = %v, want ErrNotPending", err)
+	}
+}
+
+func TestService_FailedCompletion(t *testing.T) {
+	svc, store, handler, _ := newTestService(t)
+	handler.completeErr = errors.New("checksum mismatch")
+	upload := createUpload(t, svc, "data")
+
+	got, err := svc.WriteChunk(context.Background(), "user-1", upload.ID, 0, strings.NewReader("data"), "")
+	if err != nil {
+		t.Fatalf("WriteChunk() error = %v", err)
+	}
+	if got.Status != models.UploadStatusFailed || got.Error != "checksum mismatch" {
+		t.Errorf("upload = %+v, want failed with the handler's error", got)
+	}
+	if store.uploads[upload.ID].Status != models.UploadStatusFailed {
+		t.Error("failure was not recorded")
+	}
+}
+
+func TestService_Create(t *testing.T) {
+	svc, _, _, _ := newTestService(t)
+	valid := models.CreateUploadParams{
+		Purpose: models.UploadPurposeRadioBackup, FileName: "models.zip", Size: 10,
+		Metadata: map[string]string{"radioId": "radio-1"},
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(p *models.CreateUploadParams)
+	}{
+		{name: "unknown purpose", mutate: func(p *models.CreateUploadParams) { p.Purpose = "avatar" }},
+		{name: "no file name", mutate: func(p *models.CreateUploadParams) { p.FileName = " " }},
+		{name: "empty", mutate: func(p *models.CreateUploadParams) { p.Size = 0 }},
+		{name: "bad checksum", mutate: func(p *models.CreateUploadParams) { p.Checksum = "abc" }},
+		{name: "handler refuses", mutate: func(p *models.CreateUploadParams) { p.Metadata = nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := valid
+			tt.mutate(&params)
+			var svcErr *ServiceError
+			if _, err := svc.Create(context.Background(), "user-1", params); !errors.As(err, &svcErr) {
+				t.Errorf("Create() error = %v, want ServiceError", err)
+			}
+		})
+	}
+
+	for i := 0; i < MaxPendingUploads; i++ {
+		if _, err := svc.Create(context.Background(), "user-1", valid); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if _, err := svc.Create(context.Background(), "user-1", valid); err == nil {
+		t.Error("Create() past the pending limit should fail")
+	}
+}
+
+func TestService_CleanupExpired(t *testing.T) {
+	svc, store, _, dir := newTestService(t)
+	upload := createUpload(t, svc, "partial upload")
+	if _, err := svc.WriteChunk(context.Background(), "user-1", upload.ID, 0, strings.NewReader("partial"), ""); err != nil {
+		t.Fatalf("WriteChunk() error = %v", err)
+	}
+
+	svc.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, err := svc.Get(context.Background(), "user-1", upload.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() of expired upload error = %v, want ErrNotFound", err)
+	}
+	removed, err := svc.CleanupExpired(context.Background())
+	if err != nil {
+		t.Fatalf("CleanupExpired() error = %v", err)
+	}
+	if removed != 1 || len(store.uploads) != 0 {
+		t.Errorf("removed %d, %d left; want 1 removed", removed, len(store.uploads))
+	}
+	if n := chunkFiles(t, dir); n != 0 {
+		t.Errorf("%d chunks left after cleanup, want 0", n)
+	}
+}
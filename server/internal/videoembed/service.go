@@ -0,0 +1,198 @@
+// Package videoembed validates YouTube and Vimeo links and looks up their
+// oEmbed metadata for build showcases.
+package videoembed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/cache"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// ErrUnsupportedURL is returned for links that aren't a YouTube or Vimeo video.
+var ErrUnsupportedURL = errors.New("video URL must be a YouTube or Vimeo video link")
+
+const (
+	ProviderYouTube = "youtube"
+	ProviderVimeo   = "vimeo"
+
+	// failureTTL bounds how often an unreachable oEmbed lookup is retried.
+	failureTTL = 15 * time.Minute
+	// maxOEmbedBytes bounds an oEmbed response body.
+	maxOEmbedBytes = 64 * 1024
+)
+
+var (
+	youTubeIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	vimeoIDPattern   = regexp.MustCompile(`^[0-9]{1,12}$`)
+)
+
+// Service looks up oEmbed metadata for build videos and caches it in memory.
+type Service struct {
+	client    *http.Client
+	cache     cache.Cache
+	ttl       time.Duration
+	endpoints map[string]string // provider -> oEmbed endpoint
+	logger    *logging.Logger
+}
+
+// NewService creates an oEmbed lookup service. Successful lookups are cached
+// for ttl; failures are retried after 15 minutes.
+func NewService(c cache.Cache, ttl time.Duration, logger *logging.Logger) *Service {
+	return &Service{
+		client: &http.Client{Timeout: 5 * time.Second},
+		cache:  c,
+		ttl:    ttl,
+		endpoints: map[string]string{
+			ProviderYouTube: "https://www.youtube.com/oembed",
+			ProviderVimeo:   "https://vimeo.com/api/oembed.json",
+		},
+		logger: logger,
+	}
+}
+
+// Normalize validates a YouTube or Vimeo link and returns its canonical URL.
+// An empty link is returned unchanged.
+func Normalize(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+	provider, id, err := parse(raw)
+	if err != nil {
+		return "", err
+	}
+	if provider == ProviderYouTube {
+		return "https://www.youtube.com/watch?v=" + id, nil
+	}
+	return "https://vimeo.com/" + id, nil
+}
+
+// Lookup returns embed details for a video link. The provider and player URL
+// are always set; title, author, and thumbnail are filled from oEmbed when
+// the provider answers. Returns nil for an unsupported link.
+func (s *Service) Lookup(ctx context.Context, videoURL string) *models.BuildVideo {
+	provider, id, err := parse(videoURL)
+	if err != nil {
+		return nil
+	}
+
+	key := "oembed:" + provider + ":" + id
+	if cached, ok := s.cache.Get(key); ok {
+		if video, ok := cached.(models.BuildVideo); ok {
+			return &video
+		}
+	}
+
+	video := models.BuildVideo{Provider: provider, EmbedURL: embedURL(provider, id)}
+	canonical, _ := Normalize(videoURL)
+	if err := s.fetch(ctx, provider, canonical, &video); err != nil {
+		s.logger.Warn("oEmbed lookup failed", logging.WithFields(map[string]interface{}{
+			"url":   canonical,
+			"error": err.Error(),
+		}))
+		s.cache.SetWithTTL(key, video, failureTTL)
+		return &video
+	}
+	s.cache.SetWithTTL(key, video, s.ttl)
+	return &video
+}
+
+func (s *Service) fetch(ctx context.Context, provider, videoURL string, video *models.BuildVideo) error {
+	endpoint := s.endpoints[provider] + "?format=json&url=" + url.QueryEscape(videoURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("oEmbed returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Title        string `json:"title"`
+		AuthorName   string `json:"author_name"`
+		ThumbnailURL string `json:"thumbnail_url"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxOEmbedBytes)).Decode(&body); err != nil {
+		return fmt.Errorf("failed to decode oEmbed response: %w", err)
+	}
+
+	video.Title = strings.TrimSpace(body.Title)
+	video.AuthorName = strings.TrimSpace(body.AuthorName)
+	if strings.HasPrefix(body.ThumbnailURL, "https://") {
+		video.ThumbnailURL = body.ThumbnailURL
+	}
+	return nil
+}
+
+// parse extracts the provider and video ID from a YouTube or Vimeo link.
+func parse(raw string) (string, string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.User != nil || u.Port() != "" {
+		return "", "", ErrUnsupportedURL
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	var provider, id string
+	switch host {
+	case "youtube.com", "m.youtube.com", "youtube-nocookie.com":
+		provider = ProviderYouTube
+		switch {
+		case len(segments) == 1 && segments[0] == "watch":
+			id = u.Query().Get("v")
+		case len(segments) == 2 && (segments[0] == "shorts" || segments[0] == "embed" || segments[0] == "live"):
+			id = segments[1]
+		}
+	case "youtu.be":
+		provider = ProviderYouTube
+		if len(segments) == 1 {
+			id = segments[0]
+		}
+	case "vimeo.com":
+		provider = ProviderVimeo
+		if len(segments) == 1 {
+			id = segments[0]
+		}
+	case "player.vimeo.com":
+		provider = ProviderVimeo
+		if len(segments) == 2 && segments[0] == "video" {
+			id = segments[1]
+		}
+	}
+
+	switch {
+	case provider == ProviderYouTube && youTubeIDPattern.MatchString(id):
+		return provider, id, nil
+	case provider == ProviderVimeo && vimeoIDPattern.MatchString(id):
+		return provider, id, nil
+	}
+	return "", "", ErrUnsupportedURL
+}
+
+// embedURL returns the provider's player URL. YouTube videos use the
+// privacy-enhanced domain so embeds don't set tracking cookies until played.
+func embedURL(provider, id string) string {
+	if provider == ProviderYouTube {
+		return "https://www.youtube-nocookie.com/embed/" + id
+	}
+	return "https://player.vimeo.com/video/" + id
+}
@@ -0,0 +1,101 @@
+package videoembed
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/cache"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty", raw: "  ", want: ""},
+		{name: "youtube watch", raw: "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=42s", want: "https://www.youtube.com/watch?v=dQw4w9WgXcQ"},
+		{name: "youtube short link", raw: "http://youtu.be/dQw4w9WgXcQ", want: "https://www.youtube.com/watch?v=dQw4w9WgXcQ"},
+		{name: "youtube shorts", raw: "https://m.youtube.com/shorts/dQw4w9WgXcQ", want: "https://www.youtube.com/watch?v=dQw4w9WgXcQ"},
+		{name: "vimeo", raw: "https://vimeo.com/76979871", want: "https://vimeo.com/76979871"},
+		{name: "vimeo player", raw: "https://player.vimeo.com/video/76979871", want: "https://vimeo.com/76979871"},
+		{name: "youtube bad id", raw: "https://www.youtube.com/watch?v=short", wantErr: true},
+		{name: "youtube channel", raw: "https://www.youtube.com/@fpvpilot", wantErr: true},
+		{name: "lookalike host", raw: "https://youtube.com.example.com/watch?v=dQw4w9WgXcQ", wantErr: true},
+		{name: "other provider", raw: "https://www.dailymotion.com/video/x7tgad0", wantErr: true},
+		{name: "javascript scheme", raw: "javascript:alert(1)", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.raw)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnsupportedURL) {
+					t.Fatalf("Normalize(%q) error = %v, want ErrUnsupportedURL", tt.raw, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Normalize(%q) unexpected error: %v", tt.raw, err)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if got := r.URL.Query().Get("url"); got != "https://www.youtube.com/watch?v=dQw4w9WgXcQ" {
+			t.Errorf("oEmbed url = %q, want canonical link", got)
+		}
+		_, _ = w.Write([]byte(`{"title":"5\" freestyle build","author_name":"FPV Pilot","thumbnail_url":"https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg","html":"<iframe></iframe>"}`))
+	}))
+	defer server.Close()
+
+	svc := NewService(cache.NewMemory(time.Hour), time.Hour, testutil.NullLogger())
+	svc.endpoints[ProviderYouTube] = server.URL
+
+	for i := 0; i < 2; i++ {
+		video := svc.Lookup(context.Background(), "https://youtu.be/dQw4w9WgXcQ")
+		if video == nil {
+			t.Fatal("Lookup() = nil, want video")
+		}
+		if video.Provider != ProviderYouTube || video.EmbedURL != "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ" {
+			t.Errorf("video = %+v, want youtube embed", video)
+		}
+		if video.Title != `5" freestyle build` || video.AuthorName != "FPV Pilot" || video.ThumbnailURL == "" {
+			t.Errorf("video = %+v, want oEmbed metadata", video)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("oEmbed requests = %d, want 1 with caching", requests)
+	}
+}
+
+func TestLookup_ProviderDown(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	svc := NewService(cache.NewMemory(time.Hour), time.Hour, testutil.NullLogger())
+	svc.endpoints[ProviderVimeo] = server.URL
+
+	video := svc.Lookup(context.Background(), "https://vimeo.com/76979871")
+	if video == nil || video.EmbedURL != "https://player.vimeo.com/video/76979871" || video.Title != "" {
+		t.Errorf("video = %+v, want embed URL without metadata", video)
+	}
+	if svc.Lookup(context.Background(), "https://example.com/video.mp4") != nil {
+		t.Error("Lookup(unsupported) returned a video, want nil")
+	}
+}
@@ -0,0 +1,89 @@
+package views
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// Counts are buffered view counts by target and ID
+type Counts map[models.ViewTarget]map[string]int64
+
+// add adds n views of one item
+func (c Counts) add(target models.ViewTarget, id string, n int64) {
+	if c[target] == nil {
+		c[target] = make(map[string]int64)
+	}
+	c[target][id] += n
+}
+
+// Buffer holds view counts between flushes to Postgres, and remembers who
+// viewed what for the dedup window
+type Buffer interface {
+	// MarkSeen records a visitor's view under key and reports whether it's
+	// the first within window
+	MarkSeen(ctx context.Context, key string, window time.Duration) (bool, error)
+	// Add adds views of an item
+	Add(ctx context.Context, target models.ViewTarget, id string, n int64) error
+	// Drain removes and returns the buffered counts
+	Drain(ctx context.Context) (Counts, error)
+}
+
+// MemoryBuffer is a Buffer for a single instance
+type MemoryBuffer struct {
+	mu     sync.Mutex
+	seen   map[string]time.Time
+	counts Counts
+	now    func() time.Time
+}
+
+// NewMemoryBuffer creates an in-memory view buffer
+func NewMemoryBuffer() *MemoryBuffer {
+	return &MemoryBuffer{
+		seen:   make(map[string]time.Time),
+		counts: make(Counts),
+		now:    time.Now,
+	}
+}
+
+// MarkSeen records a view under key
+func (b *MemoryBuffer) MarkSeen(ctx context.Context, key string, window time.Duration) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if expires, ok := b.seen[key]; ok && now.Before(expires) {
+		return false, nil
+	}
+	b.seen[key] = now.Add(window)
+	return true, nil
+}
+
+// Add adds views of an item
+func (b *MemoryBuffer) Add(ctx context.Context, target models.ViewTarget, id string, n int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.counts.add(target, id, n)
+	return nil
+}
+
+// Drain returns the buffered counts and forgets expired visitors
+func (b *MemoryBuffer) Drain(ctx context.Context) (Counts, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	for key, expires := range b.seen {
+		if !now.Before(expires) {
+			delete(b.seen, key)
+		}
+	}
+	counts := b.counts
+	b.counts = make(Counts)
+	return counts, nil
+}
+
+var _ Buffer = (*MemoryBuffer)(nil)
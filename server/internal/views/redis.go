@@ -0,0 +1,76 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+const defaultRedisPrefix = "views:"
+
+// RedisBuffer is a Buffer shared by every instance. Counts accumulate in a
+// hash that Drain renames away, so views recorded during a flush go into a
+// fresh hash rather than being lost.
+type RedisBuffer struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisBuffer creates a Redis-backed view buffer
+func NewRedisBuffer(client *redis.Client) *RedisBuffer {
+	return &RedisBuffer{client: client, prefix: defaultRedisPrefix}
+}
+
+// MarkSeen records a view under key, expiring after window
+func (b *RedisBuffer) MarkSeen(ctx context.Context, key string, window time.Duration) (bool, error) {
+	first, err := b.client.SetNX(ctx, b.prefix+"seen:"+key, 1, window).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to record viewer: %w", err)
+	}
+	return first, nil
+}
+
+// Add adds views of an item
+func (b *RedisBuffer) Add(ctx context.Context, target models.ViewTarget, id string, n int64) error {
+	if err := b.client.HIncrBy(ctx, b.prefix+"pending", string(target)+":"+id, n).Err(); err != nil {
+		return fmt.Errorf("failed to buffer view: %w", err)
+	}
+	return nil
+}
+
+// Drain takes the pending hash and returns its counts
+func (b *RedisBuffer) Drain(ctx context.Context) (Counts, error) {
+	flushing := b.prefix + "flushing:" + uuid.NewString()
+	if err := b.client.Rename(ctx, b.prefix+"pending", flushing).Err(); err != nil {
+		if strings.Contains(err.Error(), "no such key") {
+			return Counts{}, nil
+		}
+		return nil, fmt.Errorf("failed to take buffered views: %w", err)
+	}
+
+	fields, err := b.client.HGetAll(ctx, flushing).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read buffered views: %w", err)
+	}
+	b.client.Del(ctx, flushing)
+
+	counts := make(Counts)
+	for field, value := range fields {
+		target, id, ok := strings.Cut(field, ":")
+		n, err := strconv.ParseInt(value, 10, 64)
+		if !ok || err != nil {
+			continue
+		}
+		counts.add(models.ViewTarget(target), id, n)
+	}
+	return counts, nil
+}
+
+var _ Buffer = (*RedisBuffer)(nil)
@@ -0,0 +1,132 @@
+// Package views counts page views of published builds and catalog items.
+// Views are buffered (in Redis when available) and flushed to Postgres in
+// batches, so a popular page doesn't write a row on every request.
+package views
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/database"
+	"github.com/johnrirwin/flyingforge/internal/logging"
+	"github.com/johnrirwin/flyingforge/internal/models"
+)
+
+// DedupWindow is how long repeat views from the same visitor are ignored
+const DedupWindow = 30 * time.Minute
+
+// recordTimeout bounds the buffer calls made while serving a page
+const recordTimeout = 500 * time.Millisecond
+
+// botMarkers are user agent substrings of crawlers, link previewers,
+// monitors and scripts
+var botMarkers = []string{
+	"bot", "crawl", "spider", "slurp", "archiver", "preview", "facebookexternalhit", "embedly",
+	"headless", "lighthouse", "pingdom", "uptime", "monitor",
+	"curl", "wget", "python", "go-http-client", "java/", "okhttp", "axios", "node-fetch", "libwww", "httpclient", "scrapy",
+}
+
+// IsBot reports whether a user agent looks automated. Requests without one
+// are treated as bots.
+func IsBot(userAgent string) bool {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	if ua == "" {
+		return true
+	}
+	for _, marker := range botMarkers {
+		if strings.Contains(ua, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// Store adds flushed view counts to their items
+type Store interface {
+	AddViews(ctx context.Context, target models.ViewTarget, counts map[string]int64) error
+}
+
+// Service records and flushes views
+type Service struct {
+	store  Store
+	buffer Buffer
+	logger *logging.Logger
+}
+
+// NewService creates a new view counting service
+func NewService(store *database.ViewStore, buffer Buffer, logger *logging.Logger) *Service {
+	return &Service{
+		store:  store,
+		buffer: buffer,
+		logger: logger,
+	}
+}
+
+// Viewer identifies who made a request
+type Viewer struct {
+	UserID    string
+	IP        string
+	UserAgent string
+}
+
+// Record counts a view unless it's from a bot or the viewer already saw the
+// item within DedupWindow. Signed-in viewers are recognized by user rather
+// than IP. Failures are logged, never returned, since a lost view shouldn't
+// fail the page.
+func (s *Service) Record(ctx context.Context, target models.ViewTarget, id string, viewer Viewer) {
+	if s == nil || id == "" || IsBot(viewer.UserAgent) {
+		return
+	}
+
+	visitor := "ip:" + viewer.IP
+	if viewer.UserID != "" {
+		visitor = "user:" + viewer.UserID
+	}
+	// Hashing keeps raw IPs out of the buffer
+	sum := sha256.Sum256([]byte(visitor))
+	key := string(target) + ":" + id + ":" + hex.EncodeToString(sum[:16])
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
+	defer cancel()
+
+	first, err := s.buffer.MarkSeen(ctx, key, DedupWindow)
+	if err == nil && first {
+		err = s.buffer.Add(ctx, target, id, 1)
+	}
+	if err != nil {
+		s.logger.Debug("Failed to record view", logging.WithField("error", err.Error()))
+	}
+}
+
+// Flush writes buffered views to their items and returns how many were
+// written. Counts that fail to write go back into the buffer.
+func (s *Service) Flush(ctx context.Context) (int64, error) {
+	counts, err := s.buffer.Drain(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var written int64
+	var firstErr error
+	for target, byID := range counts {
+		if err := s.store.AddViews(ctx, target, byID); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			for id, n := range byID {
+				if err := s.buffer.Add(ctx, target, id, n); err != nil {
+					s.logger.Warn("Dropped views that failed to flush", logging.WithField("error", err.Error()))
+					break
+				}
+			}
+			continue
+		}
+		for _, n := range byID {
+			written += n
+		}
+	}
+	return written, firstErr
+}
@@ -0,0 +1,163 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/models"
+	"github.com/johnrirwin/flyingforge/internal/testutil"
+)
+
+type mockStore struct {
+	added Counts
+	err   error
+}
+
+func (m *mockStore) AddViews(ctx context.Context, target models.ViewTarget, counts map[string]int64) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.added == nil {
+		m.added = make(Counts)
+	}
+	for id, n := range counts {
+		m.added.add(target, id, n)
+	}
+	return nil
+}
+
+const browserUA = "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_4) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Safari/605.1.15"
+
+func newTestService(store *mockStore) (*Service, *MemoryBuffer) {
+	buffer := NewMemoryBuffer()
+	return &Service{store: store, buffer: buffer, logger: testutil.NullLogger()}, buffer
+}
+
+func TestIsBot(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		want      bool
+	}{
+		{browserUA, false},
+		{"Mozilla/5.0 (Linux; Android 14) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0 Mobile Safari/537.36", false},
+		{"", true},
+		{"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", true},
+		{"facebookexternalhit/1.1", true},
+		{"curl/8.4.0", true},
+		{"python-requests/2.31", true},
+		{"Go-http-client/1.1", true},
+		{"Mozilla/5.0 HeadlessChrome/124.0", true},
+	}
+	for _, tt := range tests {
+		if got := IsBot(tt.userAgent); got != tt.want {
+			t.Errorf("IsBot(%q) = %v, want %v", tt.userAgent, got, tt.want)
+		}
+	}
+}
+
+func TestRecord(t *testing.T) {
+	visitor := Viewer{IP: "203.0.113.7", UserAgent: browserUA}
+	type view struct {
+		target models.ViewTarget
+		id     string
+		viewer Viewer
+		after  time.Duration // clock advance before the view
+	}
+	tests := []struct {
+		name  string
+		views []view
+		want  Counts
+	}{
+		{
+			name: "dedups and skips bots",
+			views: []view{
+				{target: models.ViewTargetBuild, id: "b1", viewer: visitor},
+				{target: models.ViewTargetBuild, id: "b1", viewer: visitor},
+				{target: models.ViewTargetBuild, id: "b1", viewer: Viewer{IP: "203.0.113.8", UserAgent: browserUA}},
+				{target: models.ViewTargetBuild, id: "b1", viewer: Viewer{IP: "203.0.113.9", UserAgent: "curl/8.4.0"}},
+				{target: models.ViewTargetBuild, id: "b2", viewer: visitor},
+				{target: models.ViewTargetGear, id: "b1", viewer: visitor},
+			},
+			want: Counts{
+				models.ViewTargetBuild: {"b1": 2, "b2": 1},
+				models.ViewTargetGear:  {"b1": 1},
+			},
+		},
+		{
+			name: "signed-in user across IPs",
+			views: []view{
+				{target: models.ViewTargetBuild, id: "b1", viewer: Viewer{UserID: "u1", IP: "203.0.113.7", UserAgent: browserUA}},
+				{target: models.ViewTargetBuild, id: "b1", viewer: Viewer{UserID: "u1", IP: "198.51.100.2", UserAgent: browserUA}},
+			},
+			want: Counts{models.ViewTargetBuild: {"b1": 1}},
+		},
+		{
+			name: "counts again after the window",
+			views: []view{
+				{target: models.ViewTargetBuild, id: "b1", viewer: visitor},
+				{target: models.ViewTargetBuild, id: "b1", viewer: visitor, after: DedupWindow + time.Second},
+			},
+			want: Counts{models.ViewTargetBuild: {"b1": 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockStore{}
+			svc, buffer := newTestService(store)
+			ctx := context.Background()
+			now := time.Now()
+			buffer.now = func() time.Time { return now }
+
+			var wantWritten int64
+			for _, byID := range tt.want {
+				for _, n := range byID {
+					wantWritten += n
+				}
+			}
+			for _, v := range tt.views {
+				now = now.Add(v.after)
+				svc.Record(ctx, v.target, v.id, v.viewer)
+			}
+
+			written, err := svc.Flush(ctx)
+			if err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if written != wantWritten {
+				t.Errorf("written = %d, want %d", written, wantWritten)
+			}
+			if !reflect.DeepEqual(store.added, tt.want) {
+				t.Errorf("added = %v, want %v", store.added, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlushKeepsCountsOnStoreError(t *testing.T) {
+	store := &mockStore{err: errors.New("db down")}
+	svc, _ := newTestService(store)
+	ctx := context.Background()
+
+	svc.Record(ctx, models.ViewTargetBuild, "b1", Viewer{IP: "203.0.113.7", UserAgent: browserUA})
+	if _, err := svc.Flush(ctx); err == nil {
+		t.Fatal("Flush() error = nil, want store error")
+	}
+
+	store.err = nil
+	written, err := svc.Flush(ctx)
+	if err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if written != 1 || store.added[models.ViewTargetBuild]["b1"] != 1 {
+		t.Errorf("written = %d, added = %v, want the retried view", written, store.added)
+	}
+}
+
+func TestRecordNilServiceIsNoop(t *testing.T) {
+	var svc *Service
+	svc.Record(context.Background(), models.ViewTargetBuild, "b1", Viewer{IP: "203.0.113.7", UserAgent: browserUA})
+}
@@ -0,0 +1,197 @@
+// Package webui serves the built web frontend from the server binary, so a
+// self-hosted site can run as a single artifact without a separate static
+// host. The Vite build output is copied into dist before compiling; in the
+// repository dist only holds a .gitkeep.
+package webui
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/johnrirwin/flyingforge/internal/apierror"
+)
+
+//go:embed all:dist
+var embedded embed.FS
+
+// ErrNotBuilt is returned when the binary was compiled without the frontend
+var ErrNotBuilt = errors.New("web frontend is not embedded; run make build-single")
+
+const (
+	// Vite puts content-hashed bundles under assets/, so they never change
+	immutableCacheControl = "public, max-age=31536000, immutable"
+	// index.html names the current bundles and must be revalidated
+	indexCacheControl = "no-cache"
+	// Other files, like the favicon and robots.txt, keep their names across releases
+	defaultCacheControl = "public, max-age=3600"
+)
+
+// minGzipSize is the smallest file worth compressing
+const minGzipSize = 1024
+
+// compressible lists the extensions that are gzipped ahead of time
+var compressible = map[string]bool{
+	".html": true, ".js": true, ".mjs": true, ".css": true, ".json": true,
+	".svg": true, ".txt": true, ".xml": true, ".map": true, ".webmanifest": true,
+}
+
+// file is one frontend file held in memory with its gzip encoding
+type file struct {
+	name   string
+	data   []byte
+	gzip   []byte // nil when not worth compressing
+	etag   string
+	hashed bool // under assets/, so cached for a year
+}
+
+// Handler serves the frontend with SPA fallback routing
+type Handler struct {
+	files map[string]*file
+	index *file
+}
+
+// New loads the frontend embedded in the binary
+func New() (*Handler, error) {
+	dist, err := fs.Sub(embedded, "dist")
+	if err != nil {
+		return nil, err
+	}
+	return NewFromFS(dist)
+}
+
+// NewFromFS loads a frontend build from fsys, gzipping each compressible
+// file once up front. A file.gz next to a file is used as its encoding
+// instead, for builds that pre-compress at a higher level.
+func NewFromFS(fsys fs.FS) (*Handler, error) {
+	h := &Handler{files: make(map[string]*file)}
+
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || name == ".gitkeep" || strings.HasSuffix(name, ".gz") {
+			return err
+		}
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		sum := sha256.Sum256(data)
+		f := &file{
+			name:   name,
+			data:   data,
+			etag:   `"` + hex.EncodeToString(sum[:8]) + `"`,
+			hashed: strings.HasPrefix(name, "assets/"),
+		}
+		if compressible[path.Ext(name)] && len(data) >= minGzipSize {
+			if gz, err := fs.ReadFile(fsys, name+".gz"); err == nil {
+				f.gzip = gz
+			} else if f.gzip, err = compress(data); err != nil {
+				return err
+			}
+		}
+		h.files[name] = f
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	h.index = h.files["index.html"]
+	if h.index == nil {
+		return nil, ErrNotBuilt
+	}
+	return h, nil
+}
+
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	// Keep the original when compression doesn't pay off
+	if buf.Len() >= len(data) {
+		return nil, nil
+	}
+	return buf.Bytes(), nil
+}
+
+// ServeHTTP serves a frontend file, or index.html for client-side routes.
+// Unknown /api/ paths get a JSON 404 instead, and missing files with an
+// extension get a plain 404 so a stale bundle reference doesn't load HTML.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/api/") {
+		apierror.WriteStatus(w, http.StatusNotFound, "not found")
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		apierror.WriteStatus(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	f, ok := h.files[name]
+	if !ok {
+		if path.Ext(name) != "" {
+			http.NotFound(w, r)
+			return
+		}
+		f = h.index
+	}
+
+	header := w.Header()
+	switch {
+	case f == h.index:
+		header.Set("Cache-Control", indexCacheControl)
+	case f.hashed:
+		header.Set("Cache-Control", immutableCacheControl)
+	default:
+		header.Set("Cache-Control", defaultCacheControl)
+	}
+
+	data, etag := f.data, f.etag
+	if f.gzip != nil {
+		header.Add("Vary", "Accept-Encoding")
+		if acceptsGzip(r) {
+			data = f.gzip
+			etag = strings.TrimSuffix(f.etag, `"`) + `-gz"`
+			header.Set("Content-Encoding", "gzip")
+		}
+	}
+	header.Set("ETag", etag)
+
+	// ServeContent sets the type from the file name and answers
+	// If-None-Match, HEAD and Range requests
+	http.ServeContent(w, r, f.name, time.Time{}, bytes.NewReader(data))
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding := strings.TrimSpace(part)
+		if i := strings.IndexByte(coding, ';'); i >= 0 {
+			if strings.TrimSpace(coding[i+1:]) == "q=0" {
+				continue
+			}
+			coding = strings.TrimSpace(coding[:i])
+		}
+		if coding == "gzip" || coding == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package webui
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+var bundle = strings.Repeat("console.log('flyingforge');\n", 100)
+
+func testHandler(t *testing.T) *Handler {
+	t.Helper()
+	h, err := NewFromFS(fstest.MapFS{
+		".gitkeep":             {},
+		"index.html":           {Data: []byte("<!doctype html><div id=root></div>")},
+		"assets/index-ab12.js": {Data: []byte(bundle)},
+		"favicon.svg":          {Data: []byte("<svg/>")},
+	})
+	if err != nil {
+		t.Fatalf("NewFromFS failed: %v", err)
+	}
+	return h
+}
+
+func serve(h http.Handler, method, target string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewFromFS_RequiresIndex(t *testing.T) {
+	_, err := NewFromFS(fstest.MapFS{".gitkeep": {}})
+	if !errors.Is(err, ErrNotBuilt) {
+		t.Errorf("Expected ErrNotBuilt, got %v", err)
+	}
+}
+
+func TestHandler_SPAFallback(t *testing.T) {
+	h := testHandler(t)
+
+	for _, target := range []string{"/", "/builds/123", "/gear?type=motor"} {
+		rec := serve(h, http.MethodGet, target, nil)
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "id=root") {
+			t.Errorf("%s: expected index.html, got %d %q", target, rec.Code, rec.Body.String())
+		}
+		if got := rec.Header().Get("Cache-Control"); got != indexCacheControl {
+			t.Errorf("%s: expected %q, got %q", target, indexCacheControl, got)
+		}
+	}
+
+	// Missing files and unknown API paths are not the SPA
+	if rec := serve(h, http.MethodGet, "/assets/index-old.js", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing bundle, got %d", rec.Code)
+	}
+	rec := serve(h, http.MethodGet, "/api/nope", nil)
+	if rec.Code != http.StatusNotFound || !strings.Contains(rec.Header().Get("Content-Type"), "json") {
+		t.Errorf("Expected a JSON 404 for an API path, got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if rec := serve(h, http.MethodPost, "/builds", nil); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for POST, got %d", rec.Code)
+	}
+	if rec := serve(h, http.MethodGet, "/.gitkeep", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected .gitkeep to be left out, got %d", rec.Code)
+	}
+}
+
+func TestHandler_CacheHeaders(t *testing.T) {
+	h := testHandler(t)
+
+	rec := serve(h, http.MethodGet, "/assets/index-ab12.js", nil)
+	if got := rec.Header().Get("Cache-Control"); got != immutableCacheControl {
+		t.Errorf("Expected immutable caching for hashed assets, got %q", got)
+	}
+	if got := rec.Header().Get("Content-Type"); !strings.Contains(got, "javascript") {
+		t.Errorf("Expected a JavaScript content type, got %q", got)
+	}
+
+	rec = serve(h, http.MethodGet, "/favicon.svg", nil)
+	if got := rec.Header().Get("Cache-Control"); got != defaultCacheControl {
+		t.Errorf("Expected %q, got %q", defaultCacheControl, got)
+	}
+
+	etag := rec.Header().Get("ETag")
+	rec = serve(h, http.MethodGet, "/favicon.svg", map[string]string{"If-None-Match": etag})
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 for a matching ETag, got %d", rec.Code)
+	}
+}
+
+func TestHandler_Gzip(t *testing.T) {
+	h := testHandler(t)
+
+	rec := serve(h, http.MethodGet, "/assets/index-ab12.js", map[string]string{"Accept-Encoding": "br, gzip"})
+	if rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Expected a gzip response, got headers %v", rec.Header())
+	}
+	if rec.Header().Get("Vary") != "Accept-Encoding" {
+		t.Errorf("Expected Vary: Accept-Encoding, got %q", rec.Header().Get("Vary"))
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(rec.Body.Bytes()))
+	if err != nil {
+		t.Fatalf("Invalid gzip body: %v", err)
+	}
+	body, _ := io.ReadAll(zr)
+	if string(body) != bundle {
+		t.Error("Decompressed body doesn't match the bundle")
+	}
+
+	// Clients that don't ask for gzip, or refuse it, get the original
+	for _, accept := range []string{"", "gzip;q=0"} {
+		rec = serve(h, http.MethodGet, "/assets/index-ab12.js", map[string]string{"Accept-Encoding": accept})
+		if rec.Header().Get("Content-Encoding") != "" || rec.Body.String() != bundle {
+			t.Errorf("Accept-Encoding %q: expected the uncompressed bundle", accept)
+		}
+	}
+
+	// Small files aren't compressed
+	rec = serve(h, http.MethodGet, "/favicon.svg", map[string]string{"Accept-Encoding": "gzip"})
+	if rec.Header().Get("Content-Encoding") != "" {
+		t.Error("Expected the small favicon to be sent uncompressed")
+	}
+}
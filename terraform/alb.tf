@@ -103,7 +103,7 @@ resource "aws_lb_listener_rule" "api_http" {
 
   condition {
     path_pattern {
-      values = ["/api/*", "/health"]
+      values = ["/api/*", "/health", "/sitemap.xml", "/sitemaps/*", "/b/*", "/g/*"]
     }
   }
 }
@@ -136,7 +136,7 @@ resource "aws_lb_listener_rule" "api_https" {
 
   condition {
     path_pattern {
-      values = ["/api/*", "/health"]
+      values = ["/api/*", "/health", "/sitemap.xml", "/sitemaps/*", "/b/*", "/g/*"]
     }
   }
 }
@@ -358,9 +358,12 @@ function App() {
     } else {
       // Add new manual item
       console.log('[App] Adding new manual/catalog item');
-      const newItem = await addInventoryItem(params);
+      const { compatibilityWarnings, ...newItem } = await addInventoryItem(params, Boolean(params.catalogId));
       console.log('[App] New item created:', newItem);
       setInventoryItems(prev => upsertInventoryItem(prev, newItem));
+      if (compatibilityWarnings?.length) {
+        alert(`Added, but heads up:\n\n${compatibilityWarnings.map(w => `• ${w.message}`).join('\n')}`);
+      }
       // Track gear addition for GA4 conversions
       trackEvent('gear_added', { category: params.category, method: 'manual' });
     }
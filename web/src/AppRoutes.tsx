@@ -9,6 +9,7 @@ import {
   PublicBuildsPage,
   PublicBuildDetailsPage,
   TempBuildPage,
+  TempBuildViewPage,
   MyBuildsPage,
   InventoryPage,
   AircraftPage,
@@ -287,6 +288,7 @@ export function AppRoutes({
       <Route path="/builds" element={<PublicBuildsPage />} />
       <Route path="/builds/:id" element={<PublicBuildDetailsPage />} />
       <Route path="/builds/temp/:token" element={<TempBuildPage />} />
+      <Route path="/builds/view/:token" element={<TempBuildViewPage />} />
       <Route
         path="/gear-catalog"
         element={
@@ -1,4 +1,4 @@
-export type AdminUserStatus = 'active' | 'disabled' | 'pending';
+export type AdminUserStatus = 'active' | 'disabled' | 'pending' | 'suspended';
 
 export interface AdminUser {
   id: string;
@@ -16,6 +16,8 @@ export interface AdminUser {
   createdAt: string;
   updatedAt: string;
   lastLoginAt?: string;
+  statusReason?: string;
+  statusExpiresAt?: string;
 }
 
 export interface AdminUserSearchParams {
@@ -32,6 +34,8 @@ export interface AdminUsersResponse {
 
 export interface AdminUpdateUserParams {
   status?: AdminUserStatus;
+  statusReason?: string; // required when disabling or suspending
+  statusExpiresAt?: string; // suspensions only
   isAdmin?: boolean;
   isContentAdmin?: boolean;
   isGearAdmin?: boolean;
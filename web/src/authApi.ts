@@ -1,8 +1,10 @@
 import type {
+  AppealSubmission,
   AuthResponse,
   AuthTokens,
   GoogleLoginParams,
   RefreshParams,
+  SubmitAppealParams,
   User,
 } from './authTypes';
 
@@ -98,7 +100,7 @@ async function handleResponse<T>(response: Response): Promise<T> {
   
   if (!response.ok) {
     throw {
-      code: data.code || 'unknown_error',
+      code: data.code || 'UNKNOWN_ERROR',
       message: data.message || data.error || 'An error occurred',
     };
   }
@@ -167,6 +169,16 @@ export async function logout(): Promise<void> {
   }
 }
 
+// Appeal a disabled or suspended account with the token from the refused sign-in
+export async function submitAppeal(params: SubmitAppealParams): Promise<AppealSubmission> {
+  const response = await fetch(`${API_BASE}/api/auth/appeals`, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(params),
+  });
+  return handleResponse<AppealSubmission>(response);
+}
+
 export async function getCurrentUser(): Promise<User> {
   const response = await authFetch('/api/auth/me');
   return handleResponse<User>(response);
@@ -7,7 +7,9 @@ export interface User {
   email: string;
   displayName: string;
   avatarUrl?: string;
-  status: 'active' | 'disabled' | 'pending';
+  status: 'active' | 'disabled' | 'pending' | 'suspended';
+  statusReason?: string;
+  statusExpiresAt?: string;
   emailVerified: boolean;
   isAdmin: boolean; // Full admin access (content moderation + user admin)
   isContentAdmin: boolean; // Content moderation access
@@ -77,6 +79,25 @@ export interface AuthError {
   message: string;
 }
 
+// Sent back with a refused sign-in of a disabled or suspended account
+export interface AccountStatusNotice {
+  status: 'disabled' | 'suspended';
+  reason?: string;
+  expiresAt?: string;
+  appealToken?: string;
+}
+
+export interface SubmitAppealParams {
+  appealToken: string;
+  message: string;
+}
+
+export interface AppealSubmission {
+  id: string;
+  status: 'open' | 'reinstated' | 'upheld';
+  createdAt: string;
+}
+
 export interface AuthState {
   user: User | null;
   tokens: AuthTokens | null;
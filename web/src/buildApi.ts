@@ -2,9 +2,14 @@ import type {
   Build,
   BuildListParams,
   BuildListResponse,
+  BuildPreset,
+  BuildFromTemplateResponse,
   BuildPublishResponse,
+  BuildTemplate,
   CreateBuildParams,
+  EcosystemRegistry,
   TempBuildCreateResponse,
+  TempBuildViewLink,
   UpdateBuildParams,
 } from './buildTypes';
 import type { ImageModerationResponse } from './imageTypes';
@@ -51,8 +56,12 @@ function buildQuery(params?: BuildListParams): string {
   const query = new URLSearchParams();
   if (params.sort) query.set('sort', params.sort);
   if (params.frameFilter) query.set('frameFilter', params.frameFilter);
+  if (params.rcProtocol) query.set('rcProtocol', params.rcProtocol);
+  if (params.videoSystem) query.set('videoSystem', params.videoSystem);
+  if (params.q) query.set('q', params.q);
   if (params.limit !== undefined) query.set('limit', String(params.limit));
   if (params.offset !== undefined) query.set('offset', String(params.offset));
+  if (params.archived) query.set('archived', 'true');
   const q = query.toString();
   return q ? `?${q}` : '';
 }
@@ -62,12 +71,39 @@ export async function listPublicBuilds(params?: BuildListParams): Promise<BuildL
   return fetchJSON<BuildListResponse>(`/api/public/builds${buildQuery(params)}`, undefined, false);
 }
 
+export async function getEcosystems(): Promise<EcosystemRegistry> {
+  return fetchJSON<EcosystemRegistry>('/api/ecosystems', undefined, false);
+}
+
 export async function getPublicBuild(id: string): Promise<Build> {
   return fetchJSON<Build>(`/api/public/builds/${id}`, undefined, false);
 }
 
+// getPublicBuildPreset returns null when the build has no Betaflight preset
+export async function getPublicBuildPreset(id: string): Promise<BuildPreset | null> {
+  const response = await fetch(`${API_BASE}/api/public/builds/${id}/preset`);
+  if (response.status === 404) {
+    return null;
+  }
+  if (!response.ok) {
+    throw new Error(`HTTP ${response.status}`);
+  }
+  return response.json() as Promise<BuildPreset>;
+}
+
+export function getPublicBuildPresetDownloadUrl(id: string): string {
+  return `${API_BASE}/api/public/builds/${id}/preset?format=txt`;
+}
+
+// getBuildCardUrl is the shareable PNG spec card for a published build
+export function getBuildCardUrl(id: string): string {
+  return `${API_BASE}/api/builds/${id}/card.png`;
+}
+
 // Temporary build endpoints
-export async function createTempBuild(params?: CreateBuildParams): Promise<TempBuildCreateResponse> {
+// captchaToken is the widget's response, needed when the server requires a
+// captcha for anonymous temp builds
+export async function createTempBuild(params?: CreateBuildParams, captchaToken?: string): Promise<TempBuildCreateResponse> {
   const token = getAccessToken();
   const headers: HeadersInit = {
     'Content-Type': 'application/json',
@@ -75,6 +111,9 @@ export async function createTempBuild(params?: CreateBuildParams): Promise<TempB
   if (token) {
     (headers as Record<string, string>).Authorization = `Bearer ${token}`;
   }
+  if (captchaToken) {
+    (headers as Record<string, string>)['X-Captcha-Token'] = captchaToken;
+  }
 
   const response = await fetch(`${API_BASE}/api/builds/temp`, {
     method: 'POST',
@@ -107,6 +146,16 @@ export async function shareTempBuild(token: string): Promise<TempBuildCreateResp
   }, false);
 }
 
+export async function createTempBuildViewLink(token: string): Promise<TempBuildViewLink> {
+  return fetchJSON<TempBuildViewLink>(`/api/builds/temp/${token}/view-link`, {
+    method: 'POST',
+  }, false);
+}
+
+export async function getTempBuildByViewToken(viewToken: string): Promise<Build> {
+  return fetchJSON<Build>(`/api/builds/temp/view/${viewToken}`, undefined, false);
+}
+
 // Authenticated build management
 export async function listMyBuilds(params?: BuildListParams): Promise<BuildListResponse> {
   return fetchJSON<BuildListResponse>(`/api/builds${buildQuery(params)}`);
@@ -125,6 +174,17 @@ export async function createBuildFromAircraft(aircraftId: string): Promise<Build
   });
 }
 
+export async function listBuildTemplates(): Promise<BuildTemplate[]> {
+  const response = await fetchJSON<{ templates: BuildTemplate[] }>('/api/builds/templates', undefined, false);
+  return response.templates ?? [];
+}
+
+export async function createBuildFromTemplate(templateId: string): Promise<BuildFromTemplateResponse> {
+  return fetchJSON<BuildFromTemplateResponse>(`/api/builds/from-template/${templateId}`, {
+    method: 'POST',
+  });
+}
+
 export async function getMyBuild(id: string): Promise<Build> {
   return fetchJSON<Build>(`/api/builds/${id}`);
 }
@@ -136,6 +196,19 @@ export async function updateMyBuild(id: string, params: UpdateBuildParams): Prom
   });
 }
 
+export async function setMyBuildPreset(id: string, diff: string): Promise<BuildPreset> {
+  return fetchJSON<BuildPreset>(`/api/builds/${id}/preset`, {
+    method: 'PUT',
+    body: JSON.stringify({ diff }),
+  });
+}
+
+export async function deleteMyBuildPreset(id: string): Promise<void> {
+  await fetchJSON<void>(`/api/builds/${id}/preset`, {
+    method: 'DELETE',
+  });
+}
+
 export async function deleteMyBuild(id: string): Promise<void> {
   await fetchJSON<void>(`/api/builds/${id}`, {
     method: 'DELETE',
@@ -226,3 +299,15 @@ export async function unpublishMyBuild(id: string): Promise<Build> {
     method: 'POST',
   });
 }
+
+export async function archiveMyBuild(id: string): Promise<Build> {
+  return fetchJSON<Build>(`/api/builds/${id}/archive`, {
+    method: 'POST',
+  });
+}
+
+export async function restoreMyBuild(id: string): Promise<Build> {
+  return fetchJSON<Build>(`/api/builds/${id}/restore`, {
+    method: 'POST',
+  });
+}
@@ -1,6 +1,6 @@
 import type { GearType, CatalogItemStatus } from './gearCatalogTypes';
 
-export type BuildStatus = 'TEMP' | 'SHARED' | 'DRAFT' | 'PENDING_REVIEW' | 'PUBLISHED' | 'UNPUBLISHED';
+export type BuildStatus = 'TEMP' | 'SHARED' | 'DRAFT' | 'PENDING_REVIEW' | 'PUBLISHED' | 'UNPUBLISHED' | 'TEMPLATE' | 'ARCHIVED';
 export type BuildSort = 'newest';
 
 export interface BuildCatalogItem {
@@ -70,11 +70,30 @@ export interface UpdateBuildParams {
   parts?: BuildPartInput[];
 }
 
+export type RCProtocol = 'elrs' | 'crossfire' | 'ghost' | 'frsky';
+export type VideoSystem = 'analog' | 'dji' | 'hdzero' | 'walksnail';
+
+export interface Ecosystem {
+  id: string;
+  name: string;
+  description: string;
+  itemCount: number;
+}
+
+export interface EcosystemRegistry {
+  rcProtocols: Ecosystem[];
+  videoSystems: Ecosystem[];
+}
+
 export interface BuildListParams {
   sort?: BuildSort;
   frameFilter?: string;
+  rcProtocol?: RCProtocol;
+  videoSystem?: VideoSystem;
+  q?: string;
   limit?: number;
   offset?: number;
+  archived?: boolean;
 }
 
 export interface BuildListResponse {
@@ -82,6 +101,9 @@ export interface BuildListResponse {
   totalCount: number;
   sort?: BuildSort;
   frameFilter?: string;
+  query?: string;
+  rcProtocol?: RCProtocol;
+  videoSystem?: VideoSystem;
 }
 
 export interface BuildValidationError {
@@ -104,6 +126,51 @@ export interface TempBuildCreateResponse {
   build: Build;
   token: string;
   url: string;
+  shareUrl?: string; // absolute share URL from the server's public base URL
+}
+
+// A read-only link to a temp build that follows its later edits
+export interface TempBuildViewLink {
+  viewToken: string;
+  url: string;
+  shareUrl?: string;
+}
+
+export interface BuildSpecFilter {
+  key: string;
+  min?: number;
+  max?: number;
+  values?: string[];
+}
+
+export interface BuildTemplateSlot {
+  gearType: GearType;
+  position: number;
+  label?: string;
+  specFilters: BuildSpecFilter[];
+}
+
+export interface BuildTemplate {
+  id: string;
+  title: string;
+  description?: string;
+  slots: BuildTemplateSlot[];
+}
+
+export interface BuildFromTemplateResponse {
+  build: Build;
+  templateId: string;
+  unfilledSlots: BuildTemplateSlot[];
+}
+
+export interface BuildPreset {
+  buildId: string;
+  diff: string;
+  firmwareVersion?: string;
+  boardTarget?: string;
+  boardName?: string;
+  removedSettings?: string[];
+  updatedAt: string;
 }
 
 export function getBuildPartDisplayName(part?: BuildPart): string {
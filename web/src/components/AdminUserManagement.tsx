@@ -42,6 +42,8 @@ function getStatusLabel(status: AdminUserStatus): string {
       return 'Disabled';
     case 'pending':
       return 'Pending';
+    case 'suspended':
+      return 'Suspended';
     default:
       return status;
   }
@@ -55,6 +57,8 @@ function getStatusBadgeClass(status: AdminUserStatus): string {
       return 'bg-red-500/20 text-red-300 border-red-500/40';
     case 'pending':
       return 'bg-amber-500/20 text-amber-300 border-amber-500/40';
+    case 'suspended':
+      return 'bg-orange-500/20 text-orange-300 border-orange-500/40';
     default:
       return 'bg-slate-700 text-slate-200 border-slate-600';
   }
@@ -83,6 +87,8 @@ export function AdminUserManagement({ isAdmin, currentUserId, authLoading }: Adm
   const [selectedUserID, setSelectedUserID] = useState<string | null>(null);
   const [profileUser, setProfileUser] = useState<AdminUser | null>(null);
   const [profileStatus, setProfileStatus] = useState<AdminUserStatus>('active');
+  const [profileStatusReason, setProfileStatusReason] = useState('');
+  const [profileStatusExpiresAt, setProfileStatusExpiresAt] = useState('');
   const [profileIsAdmin, setProfileIsAdmin] = useState(false);
   const [profileIsContentAdmin, setProfileIsContentAdmin] = useState(false);
   const [isProfileLoading, setIsProfileLoading] = useState(false);
@@ -209,6 +215,8 @@ export function AdminUserManagement({ isAdmin, currentUserId, authLoading }: Adm
         if (cancelled) return;
         setProfileUser(user);
         setProfileStatus(user.status);
+        setProfileStatusReason('');
+        setProfileStatusExpiresAt('');
         setProfileIsAdmin(user.isAdmin);
         setProfileIsContentAdmin(Boolean(user.isContentAdmin ?? user.isGearAdmin));
       })
@@ -249,6 +257,8 @@ export function AdminUserManagement({ isAdmin, currentUserId, authLoading }: Adm
     setSelectedUserID(user.id);
     setProfileUser(user);
     setProfileStatus(user.status);
+    setProfileStatusReason('');
+    setProfileStatusExpiresAt('');
     setProfileIsAdmin(user.isAdmin);
     setProfileIsContentAdmin(Boolean(user.isContentAdmin ?? user.isGearAdmin));
     setProfileError(null);
@@ -273,13 +283,21 @@ export function AdminUserManagement({ isAdmin, currentUserId, authLoading }: Adm
     setIsSavingProfile(true);
     setProfileError(null);
     try {
+      const statusChanged = profileStatus !== profileUser.status;
+      const needsReason = profileStatus === 'disabled' || profileStatus === 'suspended';
       const updated = await adminUpdateUser(profileUser.id, {
         status: profileStatus,
+        statusReason: statusChanged && needsReason ? profileStatusReason.trim() : undefined,
+        statusExpiresAt: statusChanged && profileStatus === 'suspended' && profileStatusExpiresAt
+          ? new Date(profileStatusExpiresAt).toISOString()
+          : undefined,
         isAdmin: profileIsAdmin,
         isContentAdmin: profileIsContentAdmin,
       });
       setProfileUser(updated);
       setProfileStatus(updated.status);
+      setProfileStatusReason('');
+      setProfileStatusExpiresAt('');
       setProfileIsAdmin(updated.isAdmin);
       setProfileIsContentAdmin(Boolean(updated.isContentAdmin ?? updated.isGearAdmin));
       applyUserUpdateToList(updated);
@@ -289,7 +307,7 @@ export function AdminUserManagement({ isAdmin, currentUserId, authLoading }: Adm
     } finally {
       setIsSavingProfile(false);
     }
-  }, [applyUserUpdateToList, profileIsAdmin, profileIsContentAdmin, profileStatus, profileUser]);
+  }, [applyUserUpdateToList, profileIsAdmin, profileIsContentAdmin, profileStatus, profileStatusExpiresAt, profileStatusReason, profileUser]);
 
   const handleOpenRemoveAvatarModal = useCallback(() => {
     if (!profileUser || !profileAvatarURL || isRemovingAvatar) return;
@@ -408,6 +426,7 @@ export function AdminUserManagement({ isAdmin, currentUserId, authLoading }: Adm
           <option value="active">Active</option>
           <option value="disabled">Disabled</option>
           <option value="pending">Pending</option>
+          <option value="suspended">Suspended</option>
         </select>
         <button
           onClick={handleSearch}
@@ -660,8 +679,48 @@ export function AdminUserManagement({ isAdmin, currentUserId, authLoading }: Adm
                       >
                         <option value="active">Active</option>
                         <option value="disabled">Disabled</option>
+                        <option value="suspended">Suspended</option>
                         <option value="pending">Pending</option>
                       </select>
+                      {profileStatus !== profileUser.status && (profileStatus === 'disabled' || profileStatus === 'suspended') && (
+                        <div className="mt-3 space-y-3">
+                          <div>
+                            <label htmlFor="profile-status-reason" className="block text-sm font-medium text-slate-300 mb-2">
+                              Reason (shown to the user)
+                            </label>
+                            <textarea
+                              id="profile-status-reason"
+                              value={profileStatusReason}
+                              onChange={(e) => setProfileStatusReason(e.target.value)}
+                              disabled={isSavingProfile}
+                              maxLength={500}
+                              rows={3}
+                              className="w-full px-3 py-2 bg-slate-900 border border-slate-700 rounded-lg text-white focus:outline-none focus:ring-2 focus:ring-primary-500 disabled:opacity-60"
+                            />
+                          </div>
+                          {profileStatus === 'suspended' && (
+                            <div>
+                              <label htmlFor="profile-status-expires" className="block text-sm font-medium text-slate-300 mb-2">
+                                Suspended until (optional)
+                              </label>
+                              <input
+                                id="profile-status-expires"
+                                type="datetime-local"
+                                value={profileStatusExpiresAt}
+                                onChange={(e) => setProfileStatusExpiresAt(e.target.value)}
+                                disabled={isSavingProfile}
+                                className="w-full h-11 px-3 bg-slate-900 border border-slate-700 rounded-lg text-white focus:outline-none focus:ring-2 focus:ring-primary-500 disabled:opacity-60"
+                              />
+                            </div>
+                          )}
+                        </div>
+                      )}
+                      {profileStatus === profileUser.status && profileUser.statusReason && (
+                        <p className="mt-2 text-xs text-slate-400">
+                          Reason: {profileUser.statusReason}
+                          {profileUser.statusExpiresAt && <> (until {formatDate(profileUser.statusExpiresAt)})</>}
+                        </p>
+                      )}
                     </div>
 
                     <div>
@@ -0,0 +1,80 @@
+import type { ReactNode } from 'react';
+import type { BuildPreset } from '../buildTypes';
+
+interface BetaflightPresetProps {
+  preset: BuildPreset;
+  downloadUrl: string;
+}
+
+// Highlights one line of Betaflight CLI output: comments, commands, setting
+// names and values each get their own color.
+function highlightLine(line: string): ReactNode {
+  const trimmed = line.trim();
+  if (trimmed.startsWith('#')) {
+    return <span className="text-slate-500">{line}</span>;
+  }
+
+  const setMatch = /^(\s*)(set)(\s+)([^\s=]+)(\s*=\s*)(.*)$/.exec(line);
+  if (setMatch) {
+    const [, indent, command, gap, name, equals, value] = setMatch;
+    return (
+      <>
+        {indent}
+        <span className="text-purple-400">{command}</span>
+        {gap}
+        <span className="text-sky-300">{name}</span>
+        <span className="text-slate-500">{equals}</span>
+        <span className="text-amber-300">{value}</span>
+      </>
+    );
+  }
+
+  const commandMatch = /^(\s*)(\S+)(.*)$/.exec(line);
+  if (commandMatch) {
+    const [, indent, command, rest] = commandMatch;
+    return (
+      <>
+        {indent}
+        <span className="text-purple-400">{command}</span>
+        <span className="text-slate-300">{rest}</span>
+      </>
+    );
+  }
+  return line;
+}
+
+export function BetaflightPreset({ preset, downloadUrl }: BetaflightPresetProps) {
+  const lines = preset.diff.replace(/\n$/, '').split('\n');
+  const details = [
+    preset.firmwareVersion && `Betaflight ${preset.firmwareVersion}`,
+    preset.boardName || preset.boardTarget,
+  ].filter(Boolean);
+
+  return (
+    <section className="rounded-xl border border-slate-700 bg-slate-800/60 p-5">
+      <div className="flex flex-wrap items-start justify-between gap-3">
+        <div>
+          <h2 className="text-lg font-semibold text-white">Betaflight Tune</h2>
+          {details.length > 0 && <p className="mt-1 text-sm text-slate-400">{details.join(' • ')}</p>}
+        </div>
+        <a
+          href={downloadUrl}
+          download
+          className="rounded-lg border border-slate-600 px-3 py-1.5 text-sm text-slate-200 transition hover:border-primary-500 hover:text-white"
+        >
+          Download diff
+        </a>
+      </div>
+      <pre className="mt-4 max-h-[480px] overflow-auto rounded-lg bg-slate-950/80 p-4 font-mono text-xs leading-relaxed">
+        {lines.map((line, index) => (
+          <div key={index}>{line ? highlightLine(line) : ' '}</div>
+        ))}
+      </pre>
+      {preset.removedSettings && preset.removedSettings.length > 0 && (
+        <p className="mt-3 text-xs text-slate-500">
+          Removed before sharing: {preset.removedSettings.join(', ')}. Set these on your own quad after pasting.
+        </p>
+      )}
+    </section>
+  );
+}
@@ -41,9 +41,12 @@ const POWER_ROWS: BuildRow[] = [
 
 const OPTIONAL_ROWS: BuildRow[] = [
   { label: 'Camera', gearType: 'camera', categoryKey: 'camera' },
+  { label: 'HD Unit', gearType: 'hd_unit', categoryKey: 'hd_unit' },
   { label: 'Propellers', gearType: 'prop', categoryKey: 'prop' },
   { label: 'Antenna', gearType: 'antenna', categoryKey: 'antenna' },
-  { label: 'GPS / Other', gearType: 'other', categoryKey: 'other' },
+  { label: 'GPS', gearType: 'gps', categoryKey: 'gps' },
+  { label: 'Buzzer', gearType: 'buzzer', categoryKey: 'buzzer' },
+  { label: 'Other', gearType: 'other', categoryKey: 'other' },
 ];
 
 export function BuildBuilder({
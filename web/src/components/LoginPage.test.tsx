@@ -71,4 +71,25 @@ describe('LoginPage', () => {
 
     expect(screen.getByText('Your session expired. Please sign in again to continue.')).toBeInTheDocument();
   });
+
+  it('shows the reason and an appeal form to a suspended account', () => {
+    mockUseAuth.mockReturnValue({
+      isLoading: false,
+      isAuthenticated: false,
+      error: null,
+    });
+
+    render(
+      <MemoryRouter initialEntries={['/login?error=account_suspended&error_description=Spam%20in%20build%20descriptions#appeal_token=abc']}>
+        <Routes>
+          <Route path="/login" element={<LoginPage />} />
+        </Routes>
+      </MemoryRouter>,
+    );
+
+    expect(screen.getByText('Your account is suspended.')).toBeInTheDocument();
+    expect(screen.getByText('Reason: Spam in build descriptions')).toBeInTheDocument();
+    expect(screen.getByRole('button', { name: 'Submit appeal' })).toBeDisabled();
+    expect(screen.queryByText('Sign-in failed. Please try again.')).not.toBeInTheDocument();
+  });
 });
@@ -1,8 +1,95 @@
 import { useEffect, useMemo, useState } from 'react';
+import type { FormEvent } from 'react';
 import { useLocation, useNavigate } from 'react-router-dom';
+import { submitAppeal } from '../authApi';
 import { sanitizeNextPath, storePendingLoginNext } from '../authRouting';
 import { useAuth } from '../hooks/useAuth';
 
+const MIN_APPEAL_LENGTH = 10;
+const MAX_APPEAL_LENGTH = 2000;
+
+// AccountStatusPanel explains why a disabled or suspended account can't sign
+// in, and takes an appeal when the sign-in handed out an appeal token
+function AccountStatusPanel({
+  status,
+  reason,
+  expiresAt,
+  appealToken,
+}: {
+  status: 'disabled' | 'suspended';
+  reason: string | null;
+  expiresAt: string | null;
+  appealToken: string | null;
+}) {
+  const [message, setMessage] = useState('');
+  const [isSubmitting, setIsSubmitting] = useState(false);
+  const [submitted, setSubmitted] = useState(false);
+  const [appealError, setAppealError] = useState<string | null>(null);
+
+  const expiresLabel = useMemo(() => {
+    if (!expiresAt) return null;
+    const date = new Date(expiresAt);
+    return Number.isNaN(date.getTime()) ? null : date.toLocaleString();
+  }, [expiresAt]);
+
+  const handleSubmit = async (e: FormEvent) => {
+    e.preventDefault();
+    if (!appealToken) return;
+    setIsSubmitting(true);
+    setAppealError(null);
+    try {
+      await submitAppeal({ appealToken, message: message.trim() });
+      setSubmitted(true);
+    } catch (err) {
+      const messageText = err && typeof err === 'object' && 'message' in err ? String((err as { message: string }).message) : null;
+      setAppealError(messageText || 'Failed to submit appeal');
+    } finally {
+      setIsSubmitting(false);
+    }
+  };
+
+  const trimmedLength = message.trim().length;
+
+  return (
+    <div className="mb-4 rounded-lg border border-red-500/40 bg-red-500/10 px-4 py-3 text-sm text-red-100">
+      <p className="font-medium">
+        {status === 'suspended'
+          ? expiresLabel ? `Your account is suspended until ${expiresLabel}.` : 'Your account is suspended.'
+          : 'Your account has been disabled.'}
+      </p>
+      {reason && <p className="mt-1">Reason: {reason}</p>}
+
+      {appealToken && !submitted && (
+        <form onSubmit={handleSubmit} className="mt-3 space-y-2">
+          <label htmlFor="appeal-message" className="block text-red-100">
+            Think this is a mistake? Tell us why and an admin will review it.
+          </label>
+          <textarea
+            id="appeal-message"
+            value={message}
+            onChange={(e) => setMessage(e.target.value)}
+            maxLength={MAX_APPEAL_LENGTH}
+            rows={4}
+            disabled={isSubmitting}
+            className="w-full px-3 py-2 bg-slate-900 border border-slate-700 rounded-lg text-white focus:outline-none focus:ring-2 focus:ring-primary-500 disabled:opacity-60"
+          />
+          {appealError && <p className="text-red-300">{appealError}</p>}
+          <button
+            type="submit"
+            disabled={isSubmitting || trimmedLength < MIN_APPEAL_LENGTH}
+            className="px-4 py-2 bg-primary-600 hover:bg-primary-700 text-white rounded-lg font-medium transition-colors disabled:opacity-50 disabled:cursor-not-allowed"
+          >
+            {isSubmitting ? 'Submitting...' : 'Submit appeal'}
+          </button>
+        </form>
+      )}
+      {submitted && (
+        <p className="mt-3 text-emerald-200">Your appeal was submitted. An admin will review it.</p>
+      )}
+    </div>
+  );
+}
+
 export function LoginPage() {
   const location = useLocation();
   const navigate = useNavigate();
@@ -15,6 +102,13 @@ export function LoginPage() {
   const reason = searchParams.get('reason');
   const callbackError = searchParams.get('error');
   const callbackErrorDescription = searchParams.get('error_description');
+  const accountStatus = callbackError === 'account_disabled' || callbackError === 'account_suspended'
+    ? callbackError.slice('account_'.length) as 'disabled' | 'suspended'
+    : null;
+  const appealToken = useMemo(
+    () => new URLSearchParams(location.hash.replace(/^#/, '')).get('appeal_token'),
+    [location.hash],
+  );
 
   useEffect(() => {
     if (isLoading) {
@@ -46,8 +140,8 @@ export function LoginPage() {
   };
 
   const bannerMessage = configError
-    ?? callbackErrorDescription
-    ?? (callbackError ? 'Sign-in failed. Please try again.' : null)
+    ?? (accountStatus ? null : callbackErrorDescription)
+    ?? (callbackError && !accountStatus ? 'Sign-in failed. Please try again.' : null)
     ?? error?.message
     ?? null;
 
@@ -67,6 +161,15 @@ export function LoginPage() {
           </div>
         )}
 
+        {accountStatus && (
+          <AccountStatusPanel
+            status={accountStatus}
+            reason={callbackErrorDescription}
+            expiresAt={searchParams.get('expires_at')}
+            appealToken={appealToken}
+          />
+        )}
+
         {bannerMessage && (
           <div className="mb-4 rounded-lg border border-red-500/40 bg-red-500/10 px-4 py-3 text-sm text-red-100">
             {bannerMessage}
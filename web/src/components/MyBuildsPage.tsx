@@ -2,10 +2,12 @@ import { useCallback, useEffect, useMemo, useRef, useState } from 'react';
 import { useLocation, useNavigate } from 'react-router-dom';
 import {
   createBuildFromAircraft,
+  createBuildFromTemplate,
   createDraftBuild,
   deleteMyBuild,
   getMyBuildImageUrl,
   getMyBuild,
+  listBuildTemplates,
   listMyBuilds,
   moderateBuildImageUpload,
   publishMyBuild,
@@ -14,7 +16,7 @@ import {
   unpublishMyBuild,
   updateMyBuild,
 } from '../buildApi';
-import type { Build, BuildValidationError } from '../buildTypes';
+import type { Build, BuildTemplate, BuildValidationError } from '../buildTypes';
 import type { Aircraft } from '../aircraftTypes';
 import { listAircraft } from '../aircraftApi';
 import { BuildBuilder } from './BuildBuilder';
@@ -43,6 +45,9 @@ export function MyBuildsPage() {
   const [editorBuild, setEditorBuild] = useState<Build | null>(null);
   const [aircraft, setAircraft] = useState<Aircraft[]>([]);
   const [selectedAircraftId, setSelectedAircraftId] = useState<string>('');
+  const [templates, setTemplates] = useState<BuildTemplate[]>([]);
+  const [selectedTemplateId, setSelectedTemplateId] = useState<string>('');
+  const [templateNotice, setTemplateNotice] = useState<string | null>(null);
 
   const [isLoadingList, setIsLoadingList] = useState(true);
   const [isLoadingBuild, setIsLoadingBuild] = useState(false);
@@ -80,6 +85,9 @@ export function MyBuildsPage() {
     listAircraft({ limit: 100 })
       .then((response) => setAircraft(response.aircraft ?? []))
       .catch(() => setAircraft([]));
+    listBuildTemplates()
+      .then(setTemplates)
+      .catch(() => setTemplates([]));
   }, [loadBuildList]);
 
   useEffect(() => {
@@ -165,6 +173,27 @@ export function MyBuildsPage() {
     }
   };
 
+  const handleCreateFromTemplate = async () => {
+    if (!selectedTemplateId) return;
+
+    setError(null);
+    setTemplateNotice(null);
+    try {
+      const response = await createBuildFromTemplate(selectedTemplateId);
+      const created = response.build;
+      setBuilds((prev) => [created, ...prev.filter((item) => item.id !== created.id)]);
+      setSelectedBuildId(created.id);
+      setEditorBuild(created);
+      setValidationErrors([]);
+      if (response.unfilledSlots.length > 0) {
+        const labels = response.unfilledSlots.map((slot) => slot.label || slot.gearType);
+        setTemplateNotice(`No published part matched these slots yet, so pick them yourself: ${Array.from(new Set(labels)).join(', ')}.`);
+      }
+    } catch (err) {
+      setError(err instanceof Error ? err.message : 'Failed to create build from template');
+    }
+  };
+
   const handleSave = async () => {
     if (!editorBuild) return;
 
@@ -468,9 +497,42 @@ export function MyBuildsPage() {
                 Create
               </button>
             </div>
+
+            {templates.length > 0 && (
+              <div className="flex w-full min-w-0 items-center gap-2 sm:w-auto">
+                <select
+                  value={selectedTemplateId}
+                  onChange={(event) => setSelectedTemplateId(event.target.value)}
+                  className="h-10 min-w-0 flex-1 rounded-md border border-slate-600 bg-slate-700 px-3 text-sm text-white focus:border-primary-500 focus:outline-none sm:w-56"
+                >
+                  <option value="">Start from a template...</option>
+                  {templates.map((template) => (
+                    <option key={template.id} value={template.id}>{template.title}</option>
+                  ))}
+                </select>
+                <button
+                  type="button"
+                  disabled={!selectedTemplateId}
+                  onClick={handleCreateFromTemplate}
+                  className={`h-10 shrink-0 rounded-md px-3 text-sm font-medium transition disabled:cursor-not-allowed ${
+                    selectedTemplateId
+                      ? 'bg-primary-600 text-white hover:bg-primary-500'
+                      : 'bg-slate-700 text-slate-400 disabled:opacity-70'
+                  }`}
+                >
+                  Start
+                </button>
+              </div>
+            )}
           </div>
         </header>
 
+        {templateNotice && (
+          <div className="rounded-lg border border-sky-500/30 bg-sky-500/10 p-3 text-sm text-sky-200">
+            {templateNotice}
+          </div>
+        )}
+
         {error && (
           <div className="rounded-lg border border-red-500/30 bg-red-500/10 p-3 text-sm text-red-300">
             {error}
@@ -76,8 +76,8 @@ export function PilotProfile({ pilotId, onBack, onSelectPilot, isModal = false }
       // Check if this is a callsign required error - show the modal instead
       // Use both instanceof and property check for robustness with bundlers
       const isCallSignRequired = 
-        (err instanceof ApiError && err.code === 'callsign_required') ||
-        (err && typeof err === 'object' && 'code' in err && (err as { code: string }).code === 'callsign_required');
+        (err instanceof ApiError && err.code === 'CALLSIGN_REQUIRED') ||
+        (err && typeof err === 'object' && 'code' in err && (err as { code: string }).code === 'CALLSIGN_REQUIRED');
       
       if (isCallSignRequired) {
         setShowCallSignPrompt(true);
@@ -1,9 +1,10 @@
 import { useCallback, useEffect, useMemo, useState } from 'react';
 import { Link, useNavigate, useParams } from 'react-router-dom';
-import { createTempBuild, getPublicBuild } from '../buildApi';
-import type { Build, BuildPart } from '../buildTypes';
+import { createTempBuild, getBuildCardUrl, getPublicBuild, getPublicBuildPreset, getPublicBuildPresetDownloadUrl } from '../buildApi';
+import type { Build, BuildPart, BuildPreset } from '../buildTypes';
 import { getBuildPartDisplayName } from '../buildTypes';
 import { useAuth } from '../hooks/useAuth';
+import { BetaflightPreset } from './BetaflightPreset';
 
 interface SectionPart {
   label: string;
@@ -16,6 +17,7 @@ export function PublicBuildDetailsPage() {
   const { isAuthenticated } = useAuth();
 
   const [build, setBuild] = useState<Build | null>(null);
+  const [preset, setPreset] = useState<BuildPreset | null>(null);
   const [isLoading, setIsLoading] = useState(true);
   const [isCreatingTemp, setIsCreatingTemp] = useState(false);
   const [error, setError] = useState<string | null>(null);
@@ -29,6 +31,12 @@ export function PublicBuildDetailsPage() {
       .then((response) => setBuild(response))
       .catch((err) => setError(err instanceof Error ? err.message : 'Failed to load build'))
       .finally(() => setIsLoading(false));
+
+    // The tune is optional, so a failure here leaves the rest of the page alone
+    setPreset(null);
+    getPublicBuildPreset(id)
+      .then((response) => setPreset(response))
+      .catch(() => setPreset(null));
   }, [id]);
 
   const partsByType = useMemo(() => {
@@ -140,14 +148,23 @@ export function PublicBuildDetailsPage() {
                 <span>{build.verified ? 'Verified' : 'Unverified'}</span>
               </div>
             </div>
-            <button
-              type="button"
-              disabled={isCreatingTemp}
-              onClick={handleBuildYourOwn}
-              className="rounded-lg bg-primary-600 px-4 py-2 text-sm font-medium text-white transition hover:bg-primary-500 disabled:cursor-not-allowed disabled:opacity-60"
-            >
-              {isCreatingTemp ? 'Creating...' : 'Build Your Own'}
-            </button>
+            <div className="flex flex-wrap gap-2">
+              <a
+                href={getBuildCardUrl(build.id)}
+                download={`build-${build.id}-card.png`}
+                className="rounded-lg border border-slate-600 px-4 py-2 text-sm font-medium text-slate-200 transition hover:border-slate-500 hover:text-white"
+              >
+                Spec Card
+              </a>
+              <button
+                type="button"
+                disabled={isCreatingTemp}
+                onClick={handleBuildYourOwn}
+                className="rounded-lg bg-primary-600 px-4 py-2 text-sm font-medium text-white transition hover:bg-primary-500 disabled:cursor-not-allowed disabled:opacity-60"
+              >
+                {isCreatingTemp ? 'Creating...' : 'Build Your Own'}
+              </button>
+            </div>
           </div>
           {build.description && <p className="mt-4 text-sm text-slate-300">{build.description}</p>}
         </header>
@@ -175,6 +192,8 @@ export function PublicBuildDetailsPage() {
             ))}
           </div>
         </section>
+
+        {preset && <BetaflightPreset preset={preset} downloadUrl={getPublicBuildPresetDownloadUrl(build.id)} />}
       </div>
     </div>
   );
@@ -1,7 +1,7 @@
 import { useCallback, useEffect, useMemo, useState } from 'react';
 import { Link, useNavigate } from 'react-router-dom';
-import { createTempBuild, listPublicBuilds } from '../buildApi';
-import type { Build } from '../buildTypes';
+import { createTempBuild, getEcosystems, listPublicBuilds } from '../buildApi';
+import type { Build, EcosystemRegistry, RCProtocol, VideoSystem } from '../buildTypes';
 import { findPart, getBuildPartDisplayName } from '../buildTypes';
 import { useAuth } from '../hooks/useAuth';
 import { MobileFloatingControls } from './MobileFloatingControls';
@@ -16,6 +16,16 @@ export function PublicBuildsPage() {
   const [isMobileControlsOpen, setIsMobileControlsOpen] = useState(false);
   const [error, setError] = useState<string | null>(null);
   const [frameFilter, setFrameFilter] = useState('');
+  const [searchQuery, setSearchQuery] = useState('');
+  const [rcProtocol, setRCProtocol] = useState<RCProtocol | ''>('');
+  const [videoSystem, setVideoSystem] = useState<VideoSystem | ''>('');
+  const [ecosystems, setEcosystems] = useState<EcosystemRegistry | null>(null);
+
+  useEffect(() => {
+    getEcosystems()
+      .then(setEcosystems)
+      .catch(() => setEcosystems(null));
+  }, []);
 
   const loadBuilds = useCallback(async () => {
     setIsLoading(true);
@@ -24,6 +34,9 @@ export function PublicBuildsPage() {
       const response = await listPublicBuilds({
         sort: 'newest',
         frameFilter: frameFilter.trim() || undefined,
+        rcProtocol: rcProtocol || undefined,
+        videoSystem: videoSystem || undefined,
+        q: searchQuery.trim() || undefined,
         limit: 60,
       });
       setBuilds(response.builds ?? []);
@@ -32,7 +45,7 @@ export function PublicBuildsPage() {
     } finally {
       setIsLoading(false);
     }
-  }, [frameFilter]);
+  }, [frameFilter, rcProtocol, searchQuery, videoSystem]);
 
   useEffect(() => {
     loadBuilds();
@@ -59,11 +72,15 @@ export function PublicBuildsPage() {
   }, [isAuthenticated, navigate]);
 
   const emptyMessage = useMemo(() => {
-    if (frameFilter.trim()) {
-      return `No published builds match "${frameFilter.trim()}" yet.`;
+    const filter = searchQuery.trim() || frameFilter.trim();
+    if (filter) {
+      return `No published builds match "${filter}" yet.`;
+    }
+    if (rcProtocol || videoSystem) {
+      return 'No published builds use that radio link or video system yet.';
     }
     return 'No public builds are published yet. Be the first to share one.';
-  }, [frameFilter]);
+  }, [frameFilter, rcProtocol, searchQuery, videoSystem]);
 
   const controls = (
     <div className="border-b border-slate-800 bg-slate-900">
@@ -85,7 +102,17 @@ export function PublicBuildsPage() {
           </button>
         </div>
 
-        <div className="mt-4 grid gap-3 sm:grid-cols-2">
+        <div className="mt-4 grid gap-3 sm:grid-cols-3 lg:grid-cols-5">
+          <label className="text-sm text-slate-300">
+            <span className="mb-1 block text-xs uppercase tracking-wide text-slate-400">Search</span>
+            <input
+              type="search"
+              value={searchQuery}
+              onChange={(event) => setSearchQuery(event.target.value)}
+              placeholder="Title, parts, or pilot"
+              className="h-11 w-full rounded-lg border border-slate-600 bg-slate-700 px-3 text-white placeholder:text-slate-500 focus:border-primary-500 focus:outline-none"
+            />
+          </label>
           <label className="text-sm text-slate-300">
             <span className="mb-1 block text-xs uppercase tracking-wide text-slate-400">Sort</span>
             <select
@@ -105,6 +132,36 @@ export function PublicBuildsPage() {
               className="h-11 w-full rounded-lg border border-slate-600 bg-slate-700 px-3 text-white placeholder:text-slate-500 focus:border-primary-500 focus:outline-none"
             />
           </label>
+          <label className="text-sm text-slate-300">
+            <span className="mb-1 block text-xs uppercase tracking-wide text-slate-400">Radio link</span>
+            <select
+              value={rcProtocol}
+              onChange={(event) => setRCProtocol(event.target.value as RCProtocol | '')}
+              className="h-11 w-full rounded-lg border border-slate-600 bg-slate-700 px-3 text-white"
+            >
+              <option value="">Any</option>
+              {ecosystems?.rcProtocols.map((protocol) => (
+                <option key={protocol.id} value={protocol.id} title={protocol.description}>
+                  {protocol.name}
+                </option>
+              ))}
+            </select>
+          </label>
+          <label className="text-sm text-slate-300">
+            <span className="mb-1 block text-xs uppercase tracking-wide text-slate-400">Video system</span>
+            <select
+              value={videoSystem}
+              onChange={(event) => setVideoSystem(event.target.value as VideoSystem | '')}
+              className="h-11 w-full rounded-lg border border-slate-600 bg-slate-700 px-3 text-white"
+            >
+              <option value="">Any</option>
+              {ecosystems?.videoSystems.map((system) => (
+                <option key={system.id} value={system.id} title={system.description}>
+                  {system.name}
+                </option>
+              ))}
+            </select>
+          </label>
         </div>
       </div>
     </div>
@@ -8,6 +8,7 @@ vi.mock('../buildApi', () => ({
   getTempBuild: vi.fn(),
   updateTempBuild: vi.fn(),
   shareTempBuild: vi.fn(),
+  createTempBuildViewLink: vi.fn(),
 }));
 
 import { getTempBuild, shareTempBuild } from '../buildApi';
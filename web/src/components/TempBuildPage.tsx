@@ -1,6 +1,6 @@
 import { useEffect, useMemo, useRef, useState } from 'react';
 import { Link, useNavigate, useParams } from 'react-router-dom';
-import { getTempBuild, shareTempBuild, updateTempBuild } from '../buildApi';
+import { createTempBuildViewLink, getTempBuild, shareTempBuild, updateTempBuild } from '../buildApi';
 import type { Build, BuildPart } from '../buildTypes';
 import { BuildBuilder } from './BuildBuilder';
 
@@ -14,6 +14,7 @@ export function TempBuildPage() {
   const [isLoading, setIsLoading] = useState(true);
   const [isAutoSaving, setIsAutoSaving] = useState(false);
   const [isCopying, setIsCopying] = useState(false);
+  const [isCopyingViewLink, setIsCopyingViewLink] = useState(false);
   const [error, setError] = useState<string | null>(null);
   const lastSavedPayloadRef = useRef<string>('');
   const lastSharedPayloadRef = useRef<string>('');
@@ -143,7 +144,7 @@ export function TempBuildPage() {
       }
 
       const shared = await shareTempBuild(tokenToShare);
-      const copiedUrl = toAbsoluteTempBuildUrl(shared.url || `/builds/temp/${shared.token}`);
+      const copiedUrl = shared.shareUrl || toAbsoluteTempBuildUrl(shared.url || `/builds/temp/${shared.token}`);
       lastSharedPayloadRef.current = sharedPayloadKey;
 
       try {
@@ -158,6 +159,24 @@ export function TempBuildPage() {
     }
   };
 
+  const handleCopyViewLink = async () => {
+    if (!activeToken || !build || build.status === 'SHARED') return;
+    setIsCopyingViewLink(true);
+    setError(null);
+    try {
+      const link = await createTempBuildViewLink(activeToken);
+      try {
+        await navigator.clipboard.writeText(link.shareUrl || toAbsoluteTempBuildUrl(link.url));
+      } catch {
+        // best effort only; no toast needed
+      }
+    } catch (err) {
+      setError(err instanceof Error ? err.message : 'Failed to copy view-only link');
+    } finally {
+      setIsCopyingViewLink(false);
+    }
+  };
+
   if (isLoading) {
     return (
       <div className="flex-1 overflow-y-auto p-6">
@@ -205,13 +224,25 @@ export function TempBuildPage() {
               >
                 {isCopying ? 'Copying...' : 'Copy Share URL'}
               </button>
+              {build.status !== 'SHARED' && (
+                <button
+                  type="button"
+                  onClick={handleCopyViewLink}
+                  disabled={isCopyingViewLink || isLoading}
+                  className="rounded-lg border border-slate-600 px-4 py-2 text-sm font-medium text-slate-200 transition hover:bg-slate-700 disabled:cursor-not-allowed disabled:opacity-60"
+                >
+                  {isCopyingViewLink ? 'Copying...' : 'Copy View-Only Link'}
+                </button>
+              )}
             </div>
           </div>
 
           <div className="mt-4 rounded-lg border border-slate-700 bg-slate-900/60 p-3 text-xs text-slate-300">
             <p className="break-all">{shareUrl}</p>
           </div>
-          <p className="mt-2 text-xs text-slate-400">This URL rotates when the build changes. Copy Share URL saves a permanent snapshot link.</p>
+          <p className="mt-2 text-xs text-slate-400">
+            This URL rotates when the build changes and lets anyone with it edit. Copy Share URL saves a permanent snapshot link; Copy View-Only Link gives a link that shows your latest changes but can't edit.
+          </p>
           {hasUnsharedChanges && <p className="mt-2 text-xs text-amber-300">Build changed since last copy. Copy again to generate a new share URL.</p>}
 
           {error && <p className="mt-2 text-xs text-red-300">{error}</p>}
@@ -0,0 +1,73 @@
+import { useEffect, useState } from 'react';
+import { Link, useParams } from 'react-router-dom';
+import { getTempBuildByViewToken } from '../buildApi';
+import type { Build } from '../buildTypes';
+import { BuildBuilder } from './BuildBuilder';
+
+// TempBuildViewPage shows a temp build through its view-only link. The build
+// can't be edited from here; reloading shows the owner's latest changes.
+export function TempBuildViewPage() {
+  const { token } = useParams<{ token: string }>();
+  const viewToken = token ?? '';
+  const [build, setBuild] = useState<Build | null>(null);
+  const [isLoading, setIsLoading] = useState(true);
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    if (!viewToken) return;
+    setIsLoading(true);
+    setError(null);
+    getTempBuildByViewToken(viewToken)
+      .then((response) => setBuild({ ...response, parts: response.parts ?? [] }))
+      .catch((err) => setError(err instanceof Error ? err.message : 'Failed to load temporary build'))
+      .finally(() => setIsLoading(false));
+  }, [viewToken]);
+
+  if (isLoading) {
+    return (
+      <div className="flex-1 overflow-y-auto p-6">
+        <div className="mx-auto w-full max-w-4xl rounded-xl border border-slate-700 bg-slate-800/60 p-8 text-center text-slate-400">
+          Loading temporary build...
+        </div>
+      </div>
+    );
+  }
+
+  if (!build) {
+    return (
+      <div className="flex-1 overflow-y-auto p-6">
+        <div className="mx-auto w-full max-w-4xl rounded-xl border border-red-500/30 bg-red-500/10 p-6 text-sm text-red-300">
+          {error || 'Temporary build not found or expired.'}
+        </div>
+      </div>
+    );
+  }
+
+  return (
+    <div className="flex-1 overflow-y-auto p-6">
+      <div className="mx-auto w-full max-w-4xl space-y-6">
+        <header className="rounded-2xl border border-slate-700 bg-slate-800/70 p-5">
+          <div className="space-y-2">
+            <Link to="/builds" className="text-xs uppercase tracking-wide text-primary-400 hover:text-primary-300">
+              ← Back to Public Builds
+            </Link>
+            <h1 className="text-2xl font-semibold text-white">Temporary Build</h1>
+            <p className="text-sm text-slate-400">
+              View only. This link expires on {build.expiresAt ? new Date(build.expiresAt).toLocaleString() : 'unknown date'}.
+            </p>
+          </div>
+        </header>
+
+        <BuildBuilder
+          title={build.title}
+          description={build.description || ''}
+          parts={build.parts || []}
+          readOnly
+          onTitleChange={() => undefined}
+          onDescriptionChange={() => undefined}
+          onPartsChange={() => undefined}
+        />
+      </div>
+    </div>
+  );
+}
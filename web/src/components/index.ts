@@ -15,6 +15,7 @@ export { GearCatalogPage } from './GearCatalogPage';
 export { PublicBuildsPage } from './PublicBuildsPage';
 export { PublicBuildDetailsPage } from './PublicBuildDetailsPage';
 export { TempBuildPage } from './TempBuildPage';
+export { TempBuildViewPage } from './TempBuildViewPage';
 export { MyBuildsPage } from './MyBuildsPage';
 export { BuildBuilder } from './BuildBuilder';
 export { AdminGearModeration } from './AdminGearModeration';
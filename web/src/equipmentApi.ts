@@ -8,6 +8,7 @@ import type {
   AddInventoryParams,
   UpdateInventoryParams,
   InventoryItem,
+  AddedInventoryItem,
   InventorySummary,
 } from './equipmentTypes';
 
@@ -110,8 +111,9 @@ export async function getInventoryItem(id: string): Promise<InventoryItem> {
   return fetchAPI<InventoryItem>(`/api/inventory/${id}`);
 }
 
-export async function addInventoryItem(params: AddInventoryParams): Promise<InventoryItem> {
-  return fetchAPI<InventoryItem>('/api/inventory', {
+export async function addInventoryItem(params: AddInventoryParams, checkCompatibility = false): Promise<AddedInventoryItem> {
+  const path = checkCompatibility ? '/api/inventory?checkCompatibility=true' : '/api/inventory';
+  return fetchAPI<AddedInventoryItem>(path, {
     method: 'POST',
     body: JSON.stringify(params),
   });
@@ -163,6 +163,19 @@ export interface InventoryResponse {
   categories?: Record<EquipmentCategory, number>;
 }
 
+// A clash between a newly added part and the user's existing gear ecosystem
+export interface CompatibilityIssue {
+  code: string;
+  severity: 'error' | 'warning';
+  message: string;
+  itemIds: string[];
+}
+
+// Inventory item returned from an add with ?checkCompatibility=true
+export interface AddedInventoryItem extends InventoryItem {
+  compatibilityWarnings?: CompatibilityIssue[];
+}
+
 // Inventory summary
 export interface InventorySummary {
   totalItems: number;
@@ -7,6 +7,9 @@ import type {
   CreateTuningSnapshotParams,
   AircraftTuningSnapshot,
   TuningSnapshotsListResponse,
+  OSDLayoutExport,
+  ImportOSDLayoutParams,
+  OSDPreview,
 } from './fcConfigTypes';
 import { getStoredTokens } from './authApi';
 
@@ -97,6 +100,32 @@ export async function deleteFCConfig(id: string): Promise<void> {
   });
 }
 
+// OSD layout operations
+
+/**
+ * Export an FC config's OSD layout with the CLI commands that restore it
+ */
+export async function exportOSDLayout(id: string): Promise<OSDLayoutExport> {
+  return fetchAPI<OSDLayoutExport>(`/api/fc-configs/${id}/osd`);
+}
+
+/**
+ * Replace an FC config's OSD layout from another config or a supplied layout
+ */
+export async function importOSDLayout(id: string, params: ImportOSDLayoutParams): Promise<FlightControllerConfig> {
+  return fetchAPI<FlightControllerConfig>(`/api/fc-configs/${id}/osd`, {
+    method: 'PUT',
+    body: JSON.stringify(params),
+  });
+}
+
+/**
+ * Render an FC config's OSD layout as a text grid for one OSD profile
+ */
+export async function getOSDPreview(id: string, profile = 1): Promise<OSDPreview> {
+  return fetchAPI<OSDPreview>(`/api/fc-configs/${id}/osd/preview?profile=${profile}`);
+}
+
 // Aircraft Tuning operations
 
 /**
@@ -199,13 +199,51 @@ export interface FlightControllerConfig {
   boardTarget?: string;
   boardName?: string;
   mcuType?: string;
+  firmwareTarget?: string; // Known Betaflight target matching the board
   parseStatus: ParseStatus;
   parseWarnings?: string[];
   parsedTuning?: ParsedTuning;
+  osdLayout?: OSDLayout;
   createdAt: string;
   updatedAt: string;
 }
 
+// OSD layout parsed from "set osd_<name>_pos" settings
+export type OSDVideoSystem = 'AUTO' | 'PAL' | 'NTSC' | 'HD';
+
+export interface OSDElement {
+  name: string;
+  value: number; // Packed position and profile flags as stored by the firmware
+  x: number;
+  y: number;
+  profiles?: number[];
+}
+
+export interface OSDLayout {
+  videoSystem?: OSDVideoSystem;
+  elements: OSDElement[];
+}
+
+export interface OSDLayoutExport {
+  configId: string;
+  layout: OSDLayout;
+  cli: string;
+}
+
+export interface ImportOSDLayoutParams {
+  sourceConfigId?: string;
+  layout?: OSDLayout;
+}
+
+export interface OSDPreview {
+  videoSystem: OSDVideoSystem;
+  profile: number;
+  columns: number;
+  rows: number;
+  lines: string[];
+  offscreen?: string[];
+}
+
 // Aircraft Tuning Snapshot
 export interface AircraftTuningSnapshot {
   id: string;
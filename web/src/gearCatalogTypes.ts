@@ -14,6 +14,9 @@ export type GearType =
   | 'prop'
   | 'radio'
   | 'camera'
+  | 'hd_unit'
+  | 'gps'
+  | 'buzzer'
   | 'other';
 
 export const GEAR_TYPES: { value: GearType; label: string }[] = [
@@ -29,6 +32,9 @@ export const GEAR_TYPES: { value: GearType; label: string }[] = [
   { value: 'prop', label: 'Propellers' },
   { value: 'radio', label: 'Radios' },
   { value: 'camera', label: 'Cameras' },
+  { value: 'hd_unit', label: 'HD Units (Camera + VTX)' },
+  { value: 'gps', label: 'GPS Modules' },
+  { value: 'buzzer', label: 'Buzzers' },
   { value: 'other', label: 'Other' },
 ];
 
@@ -79,6 +85,7 @@ export interface GearCatalogItem {
   model: string;
   variant?: string;
   specs?: Record<string, unknown>;
+  specOriginals?: Record<string, string>; // Spec values as entered, before unit conversion
   bestFor?: DroneType[]; // What drone types this gear is best suited for
   msrp?: number; // Manufacturer suggested retail price
   source: CatalogItemSource;
@@ -198,6 +205,9 @@ export function gearTypeToEquipmentCategory(gearType: GearType): EquipmentCatego
     prop: 'propellers',
     radio: 'accessories',
     camera: 'cameras',
+    hd_unit: 'vtx',
+    gps: 'accessories',
+    buzzer: 'accessories',
     other: 'accessories',
   };
   return mapping[gearType] || 'accessories';
@@ -63,7 +63,7 @@ export async function followPilot(userId: string): Promise<FollowResponse> {
 
   if (!response.ok) {
     const error = await response.json().catch(() => ({}));
-    throw new ApiError(error.message || 'Failed to follow pilot', error.error || 'unknown_error');
+    throw new ApiError(error.error || error.message || 'Failed to follow pilot', error.code || 'UNKNOWN_ERROR');
   }
 
   return response.json();